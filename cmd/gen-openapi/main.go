@@ -0,0 +1,57 @@
+// Command gen-openapi writes the OpenAPI 3 document of the Scry API.
+//
+// The request and response schemas are generated from the API structs, so
+// the document is regenerated rather than edited by hand:
+//
+//	go run ./cmd/gen-openapi -o openapi.json
+//
+// Without -o the document is written to standard output.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/api/openapi"
+)
+
+func main() {
+	output := flag.String("o", "", "File to write the document to (default: standard output)")
+	server := flag.String("server", "", "Base URL of the API to list in the document (optional)")
+	flag.Parse()
+
+	if err := run(*output, *server, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "gen-openapi:", err)
+		os.Exit(1)
+	}
+}
+
+// run writes the document to the file named output, or to stdout if output is empty
+func run(output, server string, stdout io.Writer) error {
+	doc, err := api.OpenAPIDocument()
+	if err != nil {
+		return fmt.Errorf("failed to build OpenAPI document: %w", err)
+	}
+	if server != "" {
+		doc.Servers = []openapi.Server{{URL: server}}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	data = append(data, '\n')
+
+	if output == "" {
+		_, err = stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(output, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	return nil
+}
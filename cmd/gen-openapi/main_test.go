@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRun_WritesValidDocument generates the document and checks that it is a
+// well-formed OpenAPI 3.0 document.
+func TestRun_WritesValidDocument(t *testing.T) {
+	var stdout bytes.Buffer
+	require.NoError(t, run("", "https://api.example.com", &stdout))
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &doc), "document must be valid JSON")
+	for _, problem := range validateDocument(doc) {
+		t.Error(problem)
+	}
+
+	// The document describes the structs the handlers use
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	for _, name := range []string{"RegisterRequest", "CardResponse", "SubmitAnswerRequest", "ErrorResponse"} {
+		assert.Contains(t, schemas, name)
+	}
+	outcome := schemas["SubmitAnswerRequest"].(map[string]any)["properties"].(map[string]any)["outcome"]
+	assert.Equal(t, []any{"again", "hard", "good", "easy"}, outcome.(map[string]any)["enum"])
+
+	servers := doc["servers"].([]any)
+	assert.Equal(t, "https://api.example.com", servers[0].(map[string]any)["url"])
+}
+
+func TestRun_WritesFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "openapi.json")
+	var stdout bytes.Buffer
+	require.NoError(t, run(output, "", &stdout))
+
+	assert.Empty(t, stdout.String(), "nothing is written to stdout with -o")
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.NotContains(t, doc, "servers")
+	assert.Empty(t, validateDocument(doc))
+}
+
+var (
+	httpMethods     = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+	statusKeyRegexp = regexp.MustCompile(`^([1-5][0-9][0-9]|[1-5]XX|default)$`)
+	pathParamRegexp = regexp.MustCompile(`\{([^}]+)\}`)
+	schemaTypes     = map[string]bool{
+		"string": true, "number": true, "integer": true, "boolean": true, "array": true, "object": true,
+	}
+)
+
+// validateDocument checks the rules of the OpenAPI 3.0 specification that a
+// generated document could break, and returns a description of each problem.
+func validateDocument(doc map[string]any) []string {
+	var problems []string
+	fail := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if version, _ := doc["openapi"].(string); !strings.HasPrefix(version, "3.0.") {
+		fail("openapi must be a 3.0.x version, got %v", doc["openapi"])
+	}
+	info, _ := doc["info"].(map[string]any)
+	if info["title"] == "" || info["title"] == nil || info["version"] == "" || info["version"] == nil {
+		fail("info must have a title and version")
+	}
+
+	components, _ := doc["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	securitySchemes, _ := components["securitySchemes"].(map[string]any)
+	for name, schema := range schemas {
+		validateSchema(schema, "components.schemas."+name, schemas, fail)
+	}
+
+	paths, ok := doc["paths"].(map[string]any)
+	if !ok || len(paths) == 0 {
+		fail("paths must not be empty")
+	}
+	operationIDs := make(map[string]string)
+	for path, item := range paths {
+		if !strings.HasPrefix(path, "/") {
+			fail("path %q must start with /", path)
+		}
+		templateParams := make(map[string]bool)
+		for _, match := range pathParamRegexp.FindAllStringSubmatch(path, -1) {
+			templateParams[match[1]] = true
+		}
+
+		for method, rawOp := range item.(map[string]any) {
+			where := strings.ToUpper(method) + " " + path
+			if !slices.Contains(httpMethods, method) {
+				fail("%s: unknown method", where)
+				continue
+			}
+			op := rawOp.(map[string]any)
+
+			id, _ := op["operationId"].(string)
+			if id == "" {
+				fail("%s: missing operationId", where)
+			} else if other, ok := operationIDs[id]; ok {
+				fail("%s: operationId %q is also used by %s", where, id, other)
+			}
+			operationIDs[id] = where
+
+			declared := make(map[string]bool)
+			params, _ := op["parameters"].([]any)
+			for _, rawParam := range params {
+				param := rawParam.(map[string]any)
+				name, _ := param["name"].(string)
+				in, _ := param["in"].(string)
+				if !slices.Contains([]string{"query", "header", "path", "cookie"}, in) {
+					fail("%s: parameter %q has invalid location %q", where, name, in)
+				}
+				if in == "path" {
+					if param["required"] != true {
+						fail("%s: path parameter %q must be required", where, name)
+					}
+					declared[name] = true
+				}
+				validateSchema(param["schema"], where+" parameter "+name, schemas, fail)
+			}
+			for name := range templateParams {
+				if !declared[name] {
+					fail("%s: path parameter %q is not declared", where, name)
+				}
+			}
+			for name := range declared {
+				if !templateParams[name] {
+					fail("%s: parameter %q is not in the path", where, name)
+				}
+			}
+
+			if body, ok := op["requestBody"].(map[string]any); ok {
+				validateContent(body["content"], where+" request", schemas, fail)
+			}
+
+			responses, _ := op["responses"].(map[string]any)
+			if len(responses) == 0 {
+				fail("%s: must have at least one response", where)
+			}
+			for status, rawResponse := range responses {
+				if !statusKeyRegexp.MatchString(status) {
+					fail("%s: invalid response status %q", where, status)
+				}
+				response := rawResponse.(map[string]any)
+				if desc, _ := response["description"].(string); desc == "" {
+					fail("%s: response %s must have a description", where, status)
+				}
+				if content, ok := response["content"]; ok {
+					validateContent(content, where+" response "+status, schemas, fail)
+				}
+			}
+
+			security, _ := op["security"].([]any)
+			for _, requirement := range security {
+				for scheme := range requirement.(map[string]any) {
+					if _, ok := securitySchemes[scheme]; !ok {
+						fail("%s: unknown security scheme %q", where, scheme)
+					}
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func validateContent(
+	raw any,
+	where string,
+	schemas map[string]any,
+	fail func(format string, args ...any),
+) {
+	content, ok := raw.(map[string]any)
+	if !ok || len(content) == 0 {
+		fail("%s: content must not be empty", where)
+		return
+	}
+	for mediaType, rawMedia := range content {
+		media := rawMedia.(map[string]any)
+		validateSchema(media["schema"], where+" "+mediaType, schemas, fail)
+	}
+}
+
+// validateSchema checks that a schema resolves its references and uses known types
+func validateSchema(
+	raw any,
+	where string,
+	schemas map[string]any,
+	fail func(format string, args ...any),
+) {
+	schema, ok := raw.(map[string]any)
+	if !ok {
+		fail("%s: schema must be an object", where)
+		return
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		if len(schema) != 1 {
+			fail("%s: $ref must not have sibling keys", where)
+		}
+		name, found := strings.CutPrefix(ref, "#/components/schemas/")
+		if _, exists := schemas[name]; !found || !exists {
+			fail("%s: unresolved $ref %q", where, ref)
+		}
+		return
+	}
+
+	typ, hasType := schema["type"].(string)
+	if hasType && !schemaTypes[typ] {
+		fail("%s: unknown type %q", where, typ)
+	}
+	if typ == "array" {
+		validateSchema(schema["items"], where+"[]", schemas, fail)
+	} else if _, ok := schema["items"]; ok {
+		fail("%s: items is only allowed on arrays", where)
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	for name, prop := range properties {
+		validateSchema(prop, where+"."+name, schemas, fail)
+	}
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		if _, ok := properties[name.(string)]; !ok {
+			fail("%s: required property %q is not defined", where, name)
+		}
+	}
+	if additional, ok := schema["additionalProperties"]; ok {
+		validateSchema(additional, where+".*", schemas, fail)
+	}
+}
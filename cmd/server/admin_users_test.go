@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminUsers(t *testing.T) {
+	const password = "a-long-enough-password"
+	deps := newMemoryTestDeps(t)
+	router := setupRouter(deps)
+
+	// register creates a user with the given role
+	register := func(email string, role domain.UserRole) *domain.User {
+		user, err := domain.NewUser(email, password)
+		require.NoError(t, err)
+		user.Role = role
+		require.NoError(t, deps.UserStore.Create(context.Background(), user))
+		return user
+	}
+	admin := register("admin@example.com", domain.UserRoleAdmin)
+	alice := register("alice@example.com", domain.UserRoleUser)
+	bob := register("bob@example.com", domain.UserRoleUser)
+
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	login := func(email string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/api/v1/auth/login", "",
+			`{"email":"`+email+`","password":"`+password+`"}`)
+	}
+	tokens := func(email string) api.AuthResponse {
+		rec := login(email)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp api.AuthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+	errorCode := func(rec *httptest.ResponseRecorder) domain.ErrorCode {
+		var resp shared.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp.Code
+	}
+	adminToken := tokens(admin.Email).AccessToken
+	aliceTokens := tokens(alice.Email)
+	bobToken := tokens(bob.Email).AccessToken
+
+	t.Run("regular users are forbidden", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/api/v1/admin/users", bobToken, "").Code)
+		assert.Equal(t, http.StatusForbidden,
+			serve(http.MethodPatch, "/api/v1/admin/users/"+alice.ID.String(), bobToken, `{"disabled":true}`).Code)
+	})
+
+	t.Run("lists and searches users", func(t *testing.T) {
+		list := func(query string) []string {
+			rec := serve(http.MethodGet, "/api/v1/admin/users"+query, adminToken, "")
+			require.Equal(t, http.StatusOK, rec.Code)
+			var resp []api.AdminUserResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			emails := make([]string, 0, len(resp))
+			for _, user := range resp {
+				emails = append(emails, user.Email)
+			}
+			return emails
+		}
+
+		assert.Equal(t, []string{"admin@example.com", "alice@example.com", "bob@example.com"}, list(""))
+		assert.Equal(t, []string{"alice@example.com"}, list("?email=ALICE"))
+		assert.Equal(t, []string{"bob@example.com"}, list("?limit=1&offset=2"))
+		assert.Empty(t, list("?email=nobody"))
+	})
+
+	t.Run("disabled users fail authentication", func(t *testing.T) {
+		rec := serve(http.MethodPatch, "/api/v1/admin/users/"+alice.ID.String(), adminToken, `{"disabled":true}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		// Tokens issued before the account was disabled stop working at once.
+		// Services aren't set up here, so a route that fails on the role check
+		// shows whether authentication passed; its error code tells the two apart.
+		rec = serve(http.MethodGet, "/api/v1/admin/users", aliceTokens.AccessToken, "")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, domain.CodeAccountDisabled, errorCode(rec))
+
+		rec = serve(http.MethodPost, "/api/v1/auth/refresh", "",
+			`{"refresh_token":"`+aliceTokens.RefreshToken+`"}`)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, domain.CodeAccountDisabled, errorCode(rec))
+
+		rec = login(alice.Email)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, domain.CodeAccountDisabled, errorCode(rec))
+
+		// Re-enabling the account restores access
+		rec = serve(http.MethodPatch, "/api/v1/admin/users/"+alice.ID.String(), adminToken, `{"disabled":false}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		rec = serve(http.MethodGet, "/api/v1/admin/users", aliceTokens.AccessToken, "")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, domain.CodeForbidden, errorCode(rec))
+		assert.Equal(t, http.StatusOK, login(alice.Email).Code)
+	})
+
+	t.Run("role changes take effect immediately", func(t *testing.T) {
+		rec := serve(http.MethodPatch, "/api/v1/admin/users/"+bob.ID.String(), adminToken, `{"role":"admin"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp api.AdminUserResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "admin", resp.Role)
+
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/admin/users", bobToken, "").Code)
+
+		rec = serve(http.MethodPatch, "/api/v1/admin/users/"+bob.ID.String(), adminToken, `{"role":"user"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/api/v1/admin/users", bobToken, "").Code)
+	})
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/api"
+	apiMiddleware "github.com/phrazzld/scry-api/internal/api/middleware"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/service/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVersionTestRouter returns the application router backed by in-memory
+// stores, with one registered user
+func newVersionTestRouter(t *testing.T, email, password string) http.Handler {
+	t.Helper()
+
+	deps := newMemoryTestDeps(t)
+	user, err := domain.NewUser(email, password)
+	require.NoError(t, err)
+	require.NoError(t, deps.UserStore.Create(context.Background(), user))
+
+	return setupRouter(deps)
+}
+
+// newMemoryTestDeps returns application dependencies backed by in-memory stores
+func newMemoryTestDeps(t *testing.T) *appDependencies {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			MaxRequestBodyBytes: 1 << 20,
+			LegacyAPISunset:     "2027-04-30",
+		},
+		Auth: config.AuthConfig{
+			JWTSecret:                   "thisisasecretkeythatis32charslong!!",
+			BCryptCost:                  4,
+			TokenLifetimeMinutes:        60,
+			RefreshTokenLifetimeMinutes: 120,
+		},
+		Import: config.ImportConfig{MaxBodyBytes: 1 << 20},
+	}
+	jwtService, err := auth.NewJWTService(cfg.Auth)
+	require.NoError(t, err)
+	passwordScheme, err := auth.NewPasswordScheme(&cfg.Auth)
+	require.NoError(t, err)
+
+	deps := &appDependencies{
+		Config:           cfg,
+		Logger:           logger,
+		JWTService:       jwtService,
+		PasswordVerifier: passwordScheme,
+		ResponseCache:    apiMiddleware.NewResponseCache(nil, 0, logger),
+	}
+	backend := setupMemoryStores(deps, passwordScheme)
+	t.Cleanup(func() { _ = backend.Close() })
+	deps.UserService = service.NewUserService(deps.UserStore, deps.ReviewLogStore, deps.DB, logger)
+
+	return deps
+}
+
+func TestAPIVersions_LegacyAliasServesV1(t *testing.T) {
+	const (
+		email    = "versions@example.com"
+		password = "a-long-enough-password"
+	)
+	router := newVersionTestRouter(t, email, password)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "login",
+			method:     http.MethodPost,
+			path:       "/auth/login",
+			body:       `{"email":"` + email + `","password":"` + password + `"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "login with wrong password",
+			method:     http.MethodPost,
+			path:       "/auth/login",
+			body:       `{"email":"` + email + `","password":"not-the-password"}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "protected route without a token",
+			method:     http.MethodGet,
+			path:       "/cards/next",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1 := serve(tt.method, "/api/v1"+tt.path, tt.body)
+			legacy := serve(tt.method, "/api"+tt.path, tt.body)
+
+			assert.Equal(t, tt.wantStatus, v1.Code)
+			assert.Equal(t, v1.Code, legacy.Code, "the alias must respond like v1")
+			assert.Equal(t, v1.Header().Get("Content-Type"), legacy.Header().Get("Content-Type"))
+
+			// Tokens and trace IDs differ between requests, so compare the fields
+			var v1Body, legacyBody map[string]any
+			require.NoError(t, json.Unmarshal(v1.Body.Bytes(), &v1Body))
+			require.NoError(t, json.Unmarshal(legacy.Body.Bytes(), &legacyBody))
+			assert.Equal(t, slices.Sorted(maps.Keys(v1Body)), slices.Sorted(maps.Keys(legacyBody)))
+			for _, field := range []string{"user_id", "code", "error"} {
+				assert.Equal(t, v1Body[field], legacyBody[field], field)
+			}
+
+			// Both report the version that served them
+			assert.Equal(t, "v1", v1.Header().Get(apiMiddleware.APIVersionHeader))
+			assert.Equal(t, "v1", legacy.Header().Get(apiMiddleware.APIVersionHeader))
+
+			// Only the alias is deprecated
+			assert.Empty(t, v1.Header().Get("Deprecation"))
+			assert.Empty(t, v1.Header().Get("Sunset"))
+			assert.Equal(t, "@1792108800", legacy.Header().Get("Deprecation"))
+			assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", legacy.Header().Get("Sunset"))
+			assert.Equal(t, `</api/v1`+tt.path+`>; rel="successor-version"`, legacy.Header().Get("Link"))
+		})
+	}
+}
+
+func TestAPIVersions_UnversionedRoutesAreNotAliased(t *testing.T) {
+	router := newVersionTestRouter(t, "health@example.com", "a-long-enough-password")
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var body api.HealthResponse
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, api.HealthStatusOK, body.Status)
+	assert.Empty(t, rec.Header().Get("Deprecation"), "health checks are not versioned")
+	assert.Empty(t, rec.Header().Get(apiMiddleware.APIVersionHeader))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/task"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRunEnqueueGeneration_InvalidMemoID checks that a missing or malformed
+// memo ID is rejected before any configuration is loaded
+func TestRunEnqueueGeneration_InvalidMemoID(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	err := runEnqueueGeneration("", &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "-memo-id is required")
+
+	err = runEnqueueGeneration("not-a-uuid", &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid memo ID")
+
+	assert.Zero(t, out.Len(), "nothing is printed when no task is created")
+}
+
+// TestEnqueueGeneration verifies that enqueueing generation for a memo stores
+// a pending memo generation task for it, and that unknown memos are rejected
+func TestEnqueueGeneration(t *testing.T) {
+	if testDB == nil {
+		t.Skip("Skipping integration test - database connection not available")
+	}
+
+	dbURL := testutils.GetTestDatabaseURL(t)
+	chdirProjectRoot(t)
+	cfg := &config.Config{Database: config.DatabaseConfig{URL: dbURL}}
+	if err := runMigrations(cfg, "up"); err != nil &&
+		!strings.Contains(err.Error(), "no migrations to run") {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	testutils.WithTx(t, testDB, func(tx store.DBTX) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		userID := testutils.MustInsertUser(ctx, t, tx, "enqueue-"+uuid.NewString()+"@example.com", 0)
+		memo := testutils.MustInsertMemo(ctx, t, tx, userID)
+
+		taskID, err := enqueueGeneration(ctx, tx, memo.ID)
+		require.NoError(t, err)
+
+		var (
+			taskType string
+			payload  []byte
+			status   string
+			priority int
+		)
+		err = tx.QueryRowContext(ctx,
+			`SELECT type, payload, status, priority FROM tasks WHERE id = $1`, taskID).
+			Scan(&taskType, &payload, &status, &priority)
+		require.NoError(t, err, "a task row should exist for the printed task ID")
+		assert.Equal(t, task.TaskTypeMemoGeneration, taskType)
+		assert.Equal(t, string(task.TaskStatusPending), status)
+		assert.Equal(t, task.PriorityBackground, priority)
+
+		var p struct {
+			MemoID uuid.UUID `json:"memo_id"`
+		}
+		require.NoError(t, json.Unmarshal(payload, &p))
+		assert.Equal(t, memo.ID, p.MemoID)
+
+		// A second request while the first is pending is refused
+		_, err = enqueueGeneration(ctx, tx, memo.ID)
+		assert.ErrorIs(t, err, task.ErrTaskAlreadyActive)
+	})
+
+	testutils.WithTx(t, testDB, func(tx store.DBTX) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		memoID := uuid.New()
+		_, err := enqueueGeneration(ctx, tx, memoID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not exist")
+
+		var count int
+		require.NoError(t, tx.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM tasks WHERE payload->>'memo_id' = $1`, memoID.String()).Scan(&count))
+		assert.Zero(t, count, "no task is created for an unknown memo")
+	})
+}
@@ -3,14 +3,17 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -20,18 +23,23 @@ import (
 	"github.com/phrazzld/scry-api/internal/api"
 	apiMiddleware "github.com/phrazzld/scry-api/internal/api/middleware"
 	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/domain/srs"
 	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/generation"
+	"github.com/phrazzld/scry-api/internal/platform/email"
 	"github.com/phrazzld/scry-api/internal/platform/gemini"
 	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
 	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/platform/tracing"
+	"github.com/phrazzld/scry-api/internal/platform/webhook"
 	"github.com/phrazzld/scry-api/internal/service"
 	"github.com/phrazzld/scry-api/internal/service/auth"
 	"github.com/phrazzld/scry-api/internal/service/card_review"
 	"github.com/phrazzld/scry-api/internal/store"
 	"github.com/phrazzld/scry-api/internal/task"
 	"github.com/pressly/goose/v3"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // Constant for the migrations directory path
@@ -39,108 +47,6 @@ import (
 // This is a relative path from the project root
 const migrationsDir = "internal/platform/postgres/migrations"
 
-// TaskFactoryEventHandler is an event handler that creates tasks when events are emitted
-type TaskFactoryEventHandler struct {
-	taskFactory *task.MemoGenerationTaskFactory
-	taskRunner  *task.TaskRunner
-	logger      *slog.Logger
-}
-
-// HandleEvent processes events by creating and submitting tasks
-func (h *TaskFactoryEventHandler) HandleEvent(
-	ctx context.Context,
-	event *events.TaskRequestEvent,
-) error {
-	// Only handle memo generation events for now
-	if event.Type != task.TaskTypeMemoGeneration {
-		h.logger.Debug(
-			"ignoring event with unsupported type",
-			"event_type",
-			event.Type,
-			"event_id",
-			event.ID,
-		)
-		return nil
-	}
-
-	// Extract the memo ID from the event payload
-	var payload struct {
-		MemoID string `json:"memo_id"`
-	}
-
-	if err := event.UnmarshalPayload(&payload); err != nil {
-		h.logger.Error("failed to unmarshal payload", "error", err, "event_id", event.ID)
-		return fmt.Errorf("failed to unmarshal payload: %w", err)
-	}
-
-	// Parse the memo ID
-	memoID, err := uuid.Parse(payload.MemoID)
-	if err != nil {
-		h.logger.Error(
-			"invalid memo ID",
-			"error",
-			err,
-			"memo_id",
-			payload.MemoID,
-			"event_id",
-			event.ID,
-		)
-		return fmt.Errorf("invalid memo ID: %w", err)
-	}
-
-	// Create the task
-	h.logger.Debug("creating task for memo", "memo_id", memoID, "event_id", event.ID)
-	task, err := h.taskFactory.CreateTask(memoID)
-	if err != nil {
-		h.logger.Error(
-			"failed to create task",
-			"error",
-			err,
-			"memo_id",
-			memoID,
-			"event_id",
-			event.ID,
-		)
-		return fmt.Errorf("failed to create task: %w", err)
-	}
-
-	// Submit the task to the runner
-	h.logger.Debug(
-		"submitting task to runner",
-		"task_id",
-		task.ID(),
-		"memo_id",
-		memoID,
-		"event_id",
-		event.ID,
-	)
-	if err := h.taskRunner.Submit(ctx, task); err != nil {
-		h.logger.Error(
-			"failed to submit task",
-			"error",
-			err,
-			"task_id",
-			task.ID(),
-			"memo_id",
-			memoID,
-			"event_id",
-			event.ID,
-		)
-		return fmt.Errorf("failed to submit task: %w", err)
-	}
-
-	h.logger.Info(
-		"task created and submitted successfully",
-		"task_id",
-		task.ID(),
-		"memo_id",
-		memoID,
-		"event_id",
-		event.ID,
-	)
-	return nil
-}
-
 // appDependencies holds all the shared application dependencies
 // to simplify passing them around between functions.
 type appDependencies struct {
@@ -151,27 +57,61 @@ type appDependencies struct {
 	Logger *slog.Logger
 	DB     *sql.DB
 
+	// ExpectedMigrationVersion is the newest migration shipped with the server.
+	// Readiness fails until the database has reached it; 0 skips the check.
+	ExpectedMigrationVersion int64
+
+	// MigrationVersion reports the migration version DB has reached
+	MigrationVersion api.MigrationVersionFunc
+
 	// Stores (using interfaces for proper abstraction)
-	UserStore          store.UserStore
-	TaskStore          task.TaskStore // Using the interface defined in task.TaskStore
-	MemoStore          store.MemoStore
-	CardStore          store.CardStore
-	UserCardStatsStore store.UserCardStatsStore
+	UserStore           store.UserStore
+	TaskStore           task.TaskStore // Using the interface defined in task.TaskStore
+	MemoStore           store.MemoStore
+	CardStore           store.CardStore
+	UserCardStatsStore  store.UserCardStatsStore
+	ReviewLogStore      store.ReviewLogStore
+	ReviewEventStore    store.ReviewEventStore
+	DeckStore           store.DeckStore
+	IdempotencyKeyStore store.IdempotencyKeyStore
+	GenerationUsage     store.GenerationUsageStore
+
+	// Read stores route queries to read replicas when configured.
+	// Writes made through them and their transactions use the primary.
+	ReadCardStore          store.CardStore
+	ReadUserCardStatsStore store.UserCardStatsStore
 
 	// Repository interfaces for card operations
 	CardRepository store.CardStore // Interface for card operations
 
 	// Services
-	JWTService        auth.JWTService
-	PasswordVerifier  auth.PasswordVerifier
-	Generator         task.Generator                // Interface for card generation
-	CardService       task.CardService              // Interface for card service operations
-	MemoService       service.MemoService           // Interface for memo service operations
-	CardReviewService card_review.CardReviewService // Interface for card review operations
+	JWTService           auth.JWTService
+	PasswordVerifier     auth.PasswordVerifier
+	PasswordHasher       auth.PasswordHasher           // Hashes passwords with the configured scheme
+	DummyPasswordHash    string                        // Checked for unknown emails on login
+	Generator            task.Generator                // Interface for card generation
+	GenerationProvider   generation.Generator          // The provider behind Generator, for health checks
+	CardService          service.CardService           // Interface for card service operations
+	MemoService          service.MemoService           // Interface for memo service operations
+	CardReviewService    card_review.CardReviewService // Interface for card review operations
+	UserProfileService   service.UserProfileService    // Interface for user profile operations
+	UserService          service.UserService           // Interface for account management
+	AnalyticsService     service.AnalyticsService      // Interface for review statistics
+	CardDuplicateService service.CardDuplicateService  // Interface for duplicate card operations
+	DeckService          service.DeckService           // Interface for deck operations
+	ExportService        service.ExportService         // Interface for collection export
+	ImportService        service.ImportService         // Interface for bulk card import
 
 	// Event system
 	EventEmitter events.EventEmitter
 
+	// EventBus publishes notifications, such as completed generation and
+	// account emails, to the handlers subscribed to them
+	EventBus *events.EventBus
+
+	// ResponseCache caches per-user responses of frequently-read endpoints
+	ResponseCache *apiMiddleware.ResponseCache
+
 	// Task handling
 	TaskRunner *task.TaskRunner
 }
@@ -186,9 +126,16 @@ func main() {
 	migrateCmd := flag.String(
 		"migrate",
 		"",
-		"Run database migrations (up|down|create|status|version)",
+		"Run database migrations (up|down|create|status|version); status prints JSON to stdout",
 	)
 	migrationName := flag.String("name", "", "Name for the new migration (only used with 'create')")
+	enqueueGenerationCmd := flag.Bool(
+		"enqueue-generation",
+		false,
+		"Enqueue card generation for the memo given by -memo-id, print the task ID and exit; "+
+			"the task runs when a server next starts and recovers pending tasks",
+	)
+	memoIDFlag := flag.String("memo-id", "", "ID of the memo to generate cards for (only used with -enqueue-generation)")
 	flag.Parse()
 
 	// If a migration command was specified, execute it and exit
@@ -205,12 +152,16 @@ func main() {
 			os.Exit(1)
 		}
 
-		// Set up logging with the shared logger setup function
-		_, err = setupLogger(cfg)
-		if err != nil {
-			slog.Error("Failed to set up logger for migration",
-				"error", err)
-			os.Exit(1)
+		// Set up logging with the shared logger setup function.
+		// The JSON logger writes to stdout, so status keeps the default stderr
+		// logger and stdout carries only its JSON report.
+		if *migrateCmd != "status" {
+			_, err = setupLogger(cfg)
+			if err != nil {
+				slog.Error("Failed to set up logger for migration",
+					"error", err)
+				os.Exit(1)
+			}
 		}
 
 		// Execute the migration command
@@ -227,6 +178,19 @@ func main() {
 		os.Exit(0)
 	}
 
+	// If card generation was requested for a memo, enqueue it and exit without
+	// starting the server. Logs keep the default stderr logger so that stdout
+	// carries only the task ID.
+	if *enqueueGenerationCmd {
+		if err := runEnqueueGeneration(*memoIDFlag, os.Stdout); err != nil {
+			slog.Error("Failed to enqueue card generation",
+				"memo_id", *memoIDFlag,
+				"error", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// IMPORTANT: Log messages here use Go's default slog handler (plain text)
 	// rather than our custom JSON handler. This is intentional - we can't set up
 	// the custom JSON logger until we've loaded configuration, but we still want
@@ -287,6 +251,18 @@ func main() {
 	startServer(cfg)
 }
 
+// apiVersion is a version of the API, served under /api/<Name>
+type apiVersion struct {
+	Name     string
+	Register func(r chi.Router)
+}
+
+// The unversioned /api routes are a deprecated alias of this version
+const legacyAPIVersion = "v1"
+
+// legacyAPIDeprecatedAt is when the unversioned /api routes were deprecated
+var legacyAPIDeprecatedAt = time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+
 // setupRouter creates and configures the application router with all routes and middleware.
 // It accepts the application dependencies to create handlers and register routes.
 // Returns the configured router.
@@ -294,17 +270,32 @@ func setupRouter(deps *appDependencies) *chi.Mux {
 	// Create a router
 	r := chi.NewRouter()
 
+	// Trace each request, continuing the caller's trace if there is one
+	if deps.Config.Tracing.Enabled {
+		r.Use(apiMiddleware.NewTracingMiddleware())
+	}
+
 	// Apply standard middleware
 	r.Use(middleware.RequestID)
+	if deps.Config.Server.ExposeRequestIDs {
+		r.Use(apiMiddleware.ExposeRequestID)
+	}
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(
 		apiMiddleware.NewTraceMiddleware(deps.Logger),
 	) // Add trace IDs for improved error handling
+	r.Use(apiMiddleware.CORS(apiMiddleware.CORSOptions{
+		AllowedOrigins:   deps.Config.CORS.AllowedOrigins,
+		AllowedMethods:   deps.Config.CORS.AllowedMethods,
+		AllowedHeaders:   deps.Config.CORS.AllowedHeaders,
+		AllowCredentials: deps.Config.CORS.AllowCredentials,
+		MaxAge:           time.Duration(deps.Config.CORS.MaxAgeSeconds) * time.Second,
+	}))
 
-	// Create the password verifier
-	passwordVerifier := auth.NewBcryptVerifier()
+	// The password verifier checks hashes made by any supported scheme
+	passwordVerifier := deps.PasswordVerifier
 
 	// Create API handlers (user service will be created later when needed)
 	authHandler := api.NewAuthHandler(
@@ -314,33 +305,271 @@ func setupRouter(deps *appDependencies) *chi.Mux {
 		&deps.Config.Auth,
 		deps.Logger,
 	)
-	authMiddleware := apiMiddleware.NewAuthMiddleware(deps.JWTService)
+	if deps.Config.Server.CreateDefaultDecks {
+		authHandler = authHandler.WithDefaultDecks(deps.DeckService)
+	}
+	authHandler = authHandler.WithEventEmitter(deps.EventBus)
+	authHandler = authHandler.WithPasswordPolicy(passwordPolicy(deps.Config.Auth))
+	authHandler = authHandler.WithPasswordHasher(deps.PasswordHasher)
+	authHandler = authHandler.WithDummyPasswordHash(deps.DummyPasswordHash)
+	authHandler = authHandler.WithLoginThrottle(auth.NewLoginThrottle(
+		deps.Config.Auth.LoginMaxFailures,
+		time.Duration(deps.Config.Auth.LoginFailureWindowMinutes)*time.Minute,
+		time.Duration(deps.Config.Auth.LoginLockoutMinutes)*time.Minute,
+	))
+	// Tokens are checked against the user store so disabling an account takes
+	// effect immediately
+	authMiddleware := apiMiddleware.NewAuthMiddleware(deps.JWTService).WithUserStore(deps.UserStore)
+	roleMiddleware := apiMiddleware.NewRoleMiddleware(deps.UserStore, deps.Logger)
+
+	// Per-client rate limits: by IP on the public auth routes, by user elsewhere
+	rateLimits := deps.Config.RateLimit
+	authLimiter := apiMiddleware.NewRateLimiter(
+		rateLimits.Auth.RequestsPerMinute, rateLimits.Auth.Burst, deps.Logger)
+	generationLimiter := apiMiddleware.NewRateLimiter(
+		rateLimits.Generation.RequestsPerMinute, rateLimits.Generation.Burst, deps.Logger)
+	apiLimiter := apiMiddleware.NewRateLimiter(
+		rateLimits.API.RequestsPerMinute, rateLimits.API.Burst, deps.Logger)
+
+	// Short-TTL per-user cache for frequently-read endpoints, created in startServer
+	// so that background generation can invalidate it
+	responseCache := deps.ResponseCache
+
+	// Memo creation and card previews optionally require a verified email
+	// address. Previews save nothing, so they leave the response cache alone.
+	createMemoMiddlewares := []func(http.Handler) http.Handler{generationLimiter.Limit, responseCache.Invalidate}
+	previewMiddlewares := []func(http.Handler) http.Handler{generationLimiter.Limit}
+	if deps.Config.Auth.RequireEmailVerification {
+		requireVerified := apiMiddleware.RequireVerifiedEmail(deps.UserStore, deps.Logger)
+		createMemoMiddlewares = append(createMemoMiddlewares, requireVerified)
+		previewMiddlewares = append(previewMiddlewares, requireVerified)
+	}
 
 	// Use memo service from dependencies, which has been properly initialized in startServer
-	memoHandler := api.NewMemoHandler(deps.MemoService, deps.Logger)
+	memoHandlerOpts := []api.MemoHandlerOption{
+		api.WithGenerationTiming(deps.Config.Server.ExposeGenerationTiming),
+	}
+	if deps.EventBus != nil {
+		memoHandlerOpts = append(memoHandlerOpts, api.WithMemoEvents(deps.EventBus))
+	}
+	memoHandler := api.NewMemoHandler(deps.MemoService, deps.Logger, memoHandlerOpts...)
 
 	// Use the card review service from dependencies
-	cardHandler := api.NewCardHandler(deps.CardReviewService, deps.Logger)
+	cardHandler := api.NewCardHandler(deps.CardReviewService, deps.CardService, deps.Logger)
+
+	// Use the user profile service from dependencies
+	userHandler := api.NewUserHandler(deps.UserProfileService, deps.Logger)
+
+	// Use the analytics service from dependencies
+	analyticsHandler := api.NewAnalyticsHandler(deps.AnalyticsService, deps.Logger)
+
+	// Use the card duplicate service from dependencies
+	duplicateHandler := api.NewCardDuplicateHandler(deps.CardDuplicateService, deps.Logger)
+
+	// Use the deck service from dependencies
+	deckHandler := api.NewDeckHandler(deps.DeckService, deps.Logger)
+
+	// Use the export service from dependencies
+	exportHandler := api.NewExportHandler(deps.ExportService, deps.Logger)
+
+	// Use the import service from dependencies
+	importHandler := api.NewImportHandler(deps.ImportService, deps.Logger)
+
+	// Migration status is read from the same directory used to check readiness
+	adminHandler := api.NewAdminHandler(
+		func(ctx context.Context) (*api.MigrationStatusResponse, error) {
+			// Nothing is migrated when data is kept in memory
+			if deps.Config.Store.Backend == config.StoreBackendMemory {
+				return &api.MigrationStatusResponse{}, nil
+			}
+			report, err := postgres.GetMigrationReport(ctx, deps.DB, migrationsDir)
+			if err != nil {
+				return nil, err
+			}
+			return &api.MigrationStatusResponse{
+				CurrentVersion: report.CurrentVersion,
+				LatestVersion:  report.LatestVersion,
+				PendingCount:   report.PendingCount,
+			}, nil
+		},
+		deps.UserStore,
+		deps.UserService,
+		deps.Logger,
+	)
+	if deps.GenerationProvider != nil {
+		adminHandler = adminHandler.WithGenerationDiagnostics(deps.GenerationProvider, deps.Config.LLM.ModelName)
+	}
 
 	// Register routes
-	r.Route("/api", func(r chi.Router) {
+	// Reject oversized and non-JSON request bodies before they reach handlers
+	limitBody := apiMiddleware.LimitRequestBody(deps.Config.Server.MaxRequestBodyBytes)
+
+	// Cancel requests, and the queries they make, that run past their deadline
+	requestTimeout := apiMiddleware.Timeout(
+		time.Duration(deps.Config.Server.RequestTimeoutSeconds) * time.Second)
+	generationTimeout := apiMiddleware.Timeout(
+		time.Duration(deps.Config.Server.GenerationRequestTimeoutSeconds) * time.Second)
+
+	// Version 1 of the API; later versions are registered alongside it in
+	// apiVersions, so clients can move between them at their own pace
+	registerV1Routes := func(r chi.Router) {
 		// Authentication endpoints (public)
-		r.Post("/auth/register", authHandler.Register)
-		r.Post("/auth/login", authHandler.Login)
-		r.Post("/auth/refresh", authHandler.RefreshToken)
+		r.Group(func(r chi.Router) {
+			r.Use(requestTimeout)
+			r.Use(limitBody)
+			r.Use(authLimiter.Limit)
+			r.Post("/auth/register", authHandler.Register)
+			r.Post("/auth/login", authHandler.Login)
+			r.Post("/auth/refresh", authHandler.RefreshToken)
+			r.Post("/auth/verify-email", authHandler.VerifyEmail)
+			r.Post("/auth/forgot-password", authHandler.ForgotPassword)
+			r.Post("/auth/reset-password", authHandler.ResetPassword)
+		})
+
+		// Memo creation and card generation, which get longer to finish than
+		// other protected routes
+		r.Group(func(r chi.Router) {
+			r.Use(generationTimeout)
+			r.Use(limitBody)
+			r.Use(authMiddleware.Authenticate)
+			r.Use(apiLimiter.Limit)
+			r.With(createMemoMiddlewares...).Post("/memos", memoHandler.CreateMemo)
+			r.With(previewMiddlewares...).Post("/memos/preview", memoHandler.PreviewCards)
+			r.With(generationLimiter.Limit, responseCache.Invalidate).
+				Post("/memos/{id}/generate", memoHandler.GenerateMemo)
+			r.With(generationLimiter.Limit, responseCache.Invalidate).
+				Post("/memos/{id}/regenerate", memoHandler.RegenerateMemo)
+		})
+
+		// Memo event streams stay open while cards generate, and status requests
+		// wait for a change, so neither has the request timeout
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Use(apiLimiter.Limit)
+			r.Get("/memos/{id}/events", memoHandler.StreamMemoEvents)
+			r.Get("/memos/{id}/status", memoHandler.WaitForMemoStatus)
+		})
+
+		// Exports stream every card the user has, which can take longer than
+		// the request timeout allows, so they have none either
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Use(apiLimiter.Limit)
+			r.Get("/export", exportHandler.Export)
+		})
 
 		// Protected routes
 		r.Group(func(r chi.Router) {
+			r.Use(requestTimeout)
+			r.Use(limitBody)
 			r.Use(authMiddleware.Authenticate)
+			r.Use(apiLimiter.Limit)
 			// Memo endpoints
-			r.Post("/memos", memoHandler.CreateMemo)
+			r.Get("/memos/{id}", memoHandler.GetMemo)
+			r.Put("/memos/{id}/deck", deckHandler.AssignMemoDeck)
 
 			// Card review endpoints
+			r.Get("/cards", cardHandler.ListCards)
 			r.Get("/cards/next", cardHandler.GetNextReviewCard)
-			r.Post("/cards/{id}/answer", cardHandler.SubmitAnswer)
+			r.With(responseCache.Invalidate).Post("/cards/{id}/answer", cardHandler.SubmitAnswer)
+			r.With(responseCache.Invalidate).Post("/cards/answers", cardHandler.SubmitAnswers)
+			r.Get("/cards/{id}", cardHandler.GetCard)
+			r.With(responseCache.Invalidate).Put("/cards/{id}", cardHandler.UpdateCardContent)
+			r.With(responseCache.Invalidate).Post("/cards/{id}/move", cardHandler.MoveCard)
+			r.With(responseCache.Invalidate).Post("/cards/{id}/suspend", cardHandler.SuspendCard)
+			r.With(responseCache.Invalidate).Post("/cards/{id}/unsuspend", cardHandler.UnsuspendCard)
+			r.With(responseCache.Invalidate).Post("/cards/{id}/bury", cardHandler.BuryCard)
+			r.With(responseCache.Invalidate).Post("/cards/{id}/reset", cardHandler.ResetCardProgress)
+			r.Get("/cards/{id}/history", cardHandler.GetCardHistory)
+			r.With(responseCache.Cache("days", "deck_id")).Get("/cards/forecast", userHandler.GetReviewForecast)
+			r.Get("/cards/duplicates", duplicateHandler.ListDuplicates)
+			r.With(responseCache.Invalidate).
+				Post("/cards/duplicates/merge", duplicateHandler.MergeDuplicates)
+			r.With(responseCache.Invalidate).Put("/cards/{id}/deck", deckHandler.AssignCardDeck)
+
+			// Review statistics endpoints
+			r.With(responseCache.Cache("days")).Get("/stats/retention", analyticsHandler.GetRetention)
+
+			// Deck endpoints
+			r.Post("/decks", deckHandler.CreateDeck)
+			r.Get("/decks", deckHandler.ListDecks)
+			r.Get("/decks/{id}", deckHandler.GetDeck)
+			r.Put("/decks/{id}", deckHandler.RenameDeck)
+			r.With(responseCache.Invalidate).Put("/decks/{id}/settings", deckHandler.UpdateDeckSettings)
+			r.With(responseCache.Invalidate).Delete("/decks/{id}", deckHandler.DeleteDeck)
+			r.Get("/decks/{id}/cards", deckHandler.ListDeckCards)
+			r.With(responseCache.Invalidate).Post("/decks/{id}/cards", deckHandler.MoveCards)
+			r.Get("/decks/{id}/cards/next", deckHandler.GetNextDeckCard)
+
+			// User endpoints
+			r.With(responseCache.Cache()).Get("/users/me", userHandler.GetProfile)
+
+			// Admin endpoints
+			r.Group(func(r chi.Router) {
+				r.Use(roleMiddleware.RequireRole(domain.UserRoleAdmin))
+				r.Get("/admin/migrations", adminHandler.GetMigrationStatus)
+				r.Get("/admin/diagnostics/generation", adminHandler.GetGenerationDiagnostics)
+				r.Get("/admin/users", adminHandler.ListUsers)
+				r.Patch("/admin/users/{id}", adminHandler.UpdateUser)
+			})
+		})
+
+		// Bulk import accepts CSV as well as JSON, and larger bodies than other routes
+		r.Group(func(r chi.Router) {
+			r.Use(requestTimeout)
+			r.Use(apiMiddleware.LimitRequestBody(deps.Config.Import.MaxBodyBytes, api.ImportMediaTypes...))
+			r.Use(authMiddleware.Authenticate)
+			r.Use(apiLimiter.Limit)
+			r.With(responseCache.Invalidate).Post("/import", importHandler.Import)
+		})
+	}
+	apiVersions := []apiVersion{
+		{Name: "v1", Register: registerV1Routes},
+	}
+
+	// Legacy unversioned routes are deprecated in favor of /api/v1
+	var legacySunset time.Time
+	if deps.Config.Server.LegacyAPISunset != "" {
+		// Validated as a date when the configuration was loaded
+		legacySunset, _ = time.Parse(time.DateOnly, deps.Config.Server.LegacyAPISunset)
+	}
+
+	r.Route("/api", func(r chi.Router) {
+		for _, version := range apiVersions {
+			r.Route("/"+version.Name, func(r chi.Router) {
+				r.Use(apiMiddleware.APIVersion(version.Name))
+				version.Register(r)
+			})
+		}
+
+		// Routes under /api itself are an alias of v1, kept until clients have moved
+		r.Group(func(r chi.Router) {
+			r.Use(apiMiddleware.APIVersion(legacyAPIVersion))
+			r.Use(apiMiddleware.Deprecated(apiMiddleware.DeprecationOptions{
+				DeprecatedAt: legacyAPIDeprecatedAt,
+				Sunset:       legacySunset,
+				Successor: func(path string) string {
+					return "/api/" + legacyAPIVersion + strings.TrimPrefix(path, "/api")
+				},
+			}))
+			registerV1Routes(r)
 		})
 	})
 
+	// Kubernetes-style probes: liveness never touches the database,
+	// readiness requires a reachable database with current migrations
+	healthHandler := api.NewHealthHandler(
+		deps.DB,
+		deps.MigrationVersion,
+		deps.ExpectedMigrationVersion,
+		deps.Logger,
+	)
+	if deps.Config.LLM.ReadinessCheck && deps.GenerationProvider != nil {
+		healthHandler = healthHandler.WithGenerationCheck(deps.GenerationProvider)
+	}
+	r.Get("/healthz", healthHandler.Liveness)
+	r.Get("/readyz", healthHandler.Readiness)
+
 	// Health check endpoint
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -356,25 +585,29 @@ func setupRouter(deps *appDependencies) *chi.Mux {
 // startServer starts the HTTP server with graceful shutdown.
 // It's split from main() to improve readability and testability.
 func startServer(cfg *config.Config) {
-	// Step 1: Set up database connection and logger
+	// Step 1: Set up logger
 	logger, err := setupLogger(cfg)
 	if err != nil {
 		slog.Error("Failed to set up logger", "error", err)
 		os.Exit(1)
 	}
 
-	db, err := setupDatabase(cfg, logger)
+	// Export traces when tracing is enabled, flushing pending spans on shutdown
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		logger.Error("Failed to setup database", "error", err)
+		logger.Error("Failed to set up tracing", "error", err)
 		os.Exit(1)
 	}
-
-	// Ensure database connection is closed when the server shuts down
 	defer func() {
-		if err := db.Close(); err != nil {
-			logger.Error("Error closing database connection", "error", err)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Warn("Failed to flush traces on shutdown", "error", err)
 		}
 	}()
+	if cfg.Tracing.Enabled {
+		logger.Info("Tracing enabled", "endpoint", cfg.Tracing.Endpoint, "sample_ratio", cfg.Tracing.SampleRatio)
+	}
 
 	// Step 2: Initialize JWT service
 	jwtService, err := setupJWTService(cfg)
@@ -383,15 +616,35 @@ func startServer(cfg *config.Config) {
 		os.Exit(1)
 	}
 
-	// Step 3: Initialize stores and other dependencies
-	userStore := postgres.NewPostgresUserStore(db, bcrypt.DefaultCost)
-	taskStore := postgres.NewPostgresTaskStore(
-		db,
-	) // Concrete implementation that satisfies task.TaskStore
-	memoStore := postgres.NewPostgresMemoStore(db, logger)
-	cardStore := postgres.NewPostgresCardStore(db, logger)
-	userCardStatsStore := postgres.NewPostgresUserCardStatsStore(db, logger)
-	passwordVerifier := auth.NewBcryptVerifier()
+	// Card content is validated against the configured schema, if any
+	if err := setupCardContentValidator(cfg, logger); err != nil {
+		logger.Error("Failed to load card content schema", "error", err)
+		os.Exit(1)
+	}
+
+	// Memo text length is bounded as configured
+	domain.SetMemoTextLimits(domain.MemoTextLimits{
+		MinLength: cfg.Server.MemoMinTextLength,
+		MaxLength: cfg.Server.MemoMaxTextLength,
+	})
+
+	// Card content size is bounded as configured
+	domain.SetMaxCardContentBytes(cfg.Server.CardContentMaxBytes)
+
+	// New passwords are hashed with the configured scheme
+	passwordScheme, err := auth.NewPasswordScheme(&cfg.Auth)
+	if err != nil {
+		logger.Error("Failed to initialize password scheme", "error", err)
+		os.Exit(1)
+	}
+
+	// Logins for unknown emails check a dummy hash made with the same scheme
+	// and cost, so they take as long as wrong passwords
+	dummyPasswordHash, err := auth.NewDummyPasswordHash(passwordScheme)
+	if err != nil {
+		logger.Error("Failed to generate dummy password hash", "error", err)
+		os.Exit(1)
+	}
 
 	// Create the appropriate generator service for card generation based on build tags
 	generator, err := gemini.NewGenerator(
@@ -403,49 +656,106 @@ func startServer(cfg *config.Config) {
 		logger.Error("Failed to initialize LLM generator", "error", err)
 		os.Exit(1)
 	}
-	logger.Info("LLM generator initialized successfully")
+	logger.Info("LLM generator initialized successfully",
+		"model", cfg.LLM.ModelName,
+		"max_output_tokens", cfg.LLM.MaxOutputTokens)
+
+	// Workers share one circuit breaker, so an unavailable model is not called by
+	// every task in turn
+	var cardGenerator generation.Generator = generator
+	if cfg.LLM.BreakerFailureThreshold > 0 {
+		cardGenerator, err = generation.NewCircuitBreakerGenerator(
+			generator,
+			cfg.LLM.BreakerFailureThreshold,
+			time.Duration(cfg.LLM.BreakerCooldownSeconds)*time.Second,
+			logger,
+		)
+		if err != nil {
+			logger.Error("Failed to initialize generation circuit breaker", "error", err)
+			os.Exit(1)
+		}
+	}
 
-	// Step 4: Populate the application dependencies struct
+	// The card count bounds wrap the breaker, so a retry for too few cards is
+	// refused while the model is unavailable
+	cardGenerator, err = generation.NewCardBoundsGenerator(
+		cardGenerator,
+		cfg.LLM.GenerationMinCards,
+		cfg.LLM.GenerationMaxCards,
+		logger,
+	)
+	if err != nil {
+		logger.Error("Failed to initialize generation card count bounds", "error", err)
+		os.Exit(1)
+	}
+
+	// Step 3: Populate the application dependencies struct
 	deps := &appDependencies{
 		Config:             cfg,
 		Logger:             logger,
-		DB:                 db,
-		UserStore:          userStore,
-		TaskStore:          taskStore,
-		MemoStore:          memoStore,
-		CardStore:          cardStore,
-		UserCardStatsStore: userCardStatsStore,
-		// MemoRepository removed - using MemoStore with adapter instead
-		CardRepository:   cardStore, // Now using the real CardStore implementation
-		Generator:        generator,
-		JWTService:       jwtService,
-		PasswordVerifier: passwordVerifier,
+		Generator:          cardGenerator,
+		GenerationProvider: generator,
+		JWTService:         jwtService,
+		PasswordVerifier:   passwordScheme,
+		PasswordHasher:     passwordScheme,
+		DummyPasswordHash:  dummyPasswordHash,
 	}
 
-	// Step 5: Set up task runner using the new setup function
-	taskRunner, err := setupTaskRunner(deps)
-	if err != nil {
-		logger.Error("Failed to setup task runner", "error", err)
-		os.Exit(1)
+	// Step 4: Initialize stores in the configured backend
+	if cfg.Store.Backend == config.StoreBackendMemory {
+		backend := setupMemoryStores(deps, passwordScheme)
+		defer func() {
+			if err := backend.Close(); err != nil {
+				logger.Error("Error closing in-memory store backend", "error", err)
+			}
+		}()
+		logger.Warn("Using in-memory stores; all data will be lost when the server stops")
+	} else {
+		closeDatabases, err := setupPostgresStores(deps, passwordScheme)
+		if err != nil {
+			logger.Error("Failed to setup database", "error", err)
+			os.Exit(1)
+		}
+		// Ensure database connections are closed when the server shuts down
+		defer closeDatabases()
 	}
 
+	// Step 5: Set up task runner using the new setup function
+	taskRunner := setupTaskRunner(deps)
+
 	// Update dependencies with the task runner
 	deps.TaskRunner = taskRunner
 
-	// Step 6: Set up event emitter
-	eventEmitter := events.NewInMemoryEventEmitter(logger)
+	// Step 6: Set up event emitter that turns task request events into queued tasks.
+	// Task types are registered below once their factories have been created.
+	eventEmitter := task.NewTaskQueueEmitter(taskRunner, logger)
 	// Add event emitter to dependencies immediately so it can be used by services
 	deps.EventEmitter = eventEmitter
 
-	// Create a memo repository adapter
+	// Create memo and card repository adapters
 	memoRepoAdapter := service.NewMemoRepositoryAdapter(deps.MemoStore, deps.DB)
+	cardRepoAdapter := service.NewCardRepositoryAdapter(deps.CardStore, deps.DB)
 
 	// Create memo service
 	memoService, err := service.NewMemoService(
 		memoRepoAdapter,
-		deps.TaskRunner,
 		deps.EventEmitter,
 		logger,
+		service.WithMemoStatusTransitionEnforcement(deps.Config.Task.EnforceMemoStatusTransitions),
+		service.WithIdempotencyKeys(
+			deps.IdempotencyKeyStore,
+			time.Duration(deps.Config.Server.IdempotencyKeyTTLMinutes)*time.Minute,
+		),
+		service.WithMemoCardRepository(cardRepoAdapter),
+		service.WithGenerationQuota(
+			deps.GenerationUsage,
+			deps.UserStore,
+			deps.Config.LLM.MonthlyGenerationQuota,
+		),
+		service.WithCardPreview(
+			deps.Generator,
+			time.Duration(deps.Config.LLM.PreviewTimeoutSeconds)*time.Second,
+		),
 	)
 	if err != nil {
 		logger.Error("Failed to create memo service", "error", err)
@@ -454,37 +764,54 @@ func startServer(cfg *config.Config) {
 	deps.MemoService = memoService
 
 	// Create a memo service adapter for tasks
-	memoServiceAdapter, err := task.NewMemoServiceAdapter(memoRepoAdapter)
+	memoServiceAdapter, err := task.NewMemoServiceAdapter(
+		memoRepoAdapter,
+		task.WithStatusTransitionEnforcement(deps.Config.Task.EnforceMemoStatusTransitions),
+	)
 	if err != nil {
 		logger.Error("Failed to create memo service adapter", "error", err)
 		os.Exit(1)
 	}
 
-	// Create a card repository adapter for the card service
-	cardRepoAdapter := service.NewCardRepositoryAdapter(deps.CardStore, deps.DB)
+	// Create a stats repository adapter for the card service
 	statsRepoAdapter := service.NewStatsRepositoryAdapter(deps.UserCardStatsStore)
 
 	// Create the card service
-	cardService, err := service.NewCardService(cardRepoAdapter, statsRepoAdapter, logger)
+	cardService, err := service.NewCardService(
+		cardRepoAdapter,
+		statsRepoAdapter,
+		memoRepoAdapter,
+		logger,
+		service.WithCardUserStore(deps.UserStore),
+		service.WithNewCardSettings(deps.UserStore, deps.DeckStore),
+	)
 	if err != nil {
 		logger.Error("Failed to create card service", "error", err)
 		os.Exit(1)
 	}
 	deps.CardService = cardService
 
-	// Create SRS service with default parameters
-	srsService, err := srs.NewDefaultService()
+	// Create SRS service with default parameters and the configured learning steps
+	srsService, err := srs.NewServiceWithParams(srs.NewParams(srs.ParamsConfig{
+		LearningSteps: deps.Config.SRS.LearningSteps,
+	}))
 	if err != nil {
 		logger.Error("Failed to create SRS service", "error", err)
 		os.Exit(1)
 	}
 
-	// Create card review service with direct store dependencies
+	// Create card review service with direct store dependencies. It reads from the
+	// primary: a lagging replica could serve the card that was just answered again.
 	cardReviewService, err := card_review.NewCardReviewService(
 		deps.CardStore,
 		deps.UserCardStatsStore,
+		deps.ReviewLogStore,
+		deps.UserStore,
 		srsService,
 		logger,
+		card_review.WithDeckSettings(deps.DeckStore),
+		card_review.WithReviewEvents(deps.ReviewEventStore),
+		card_review.WithLeechDetection(deps.Config.SRS.LeechThreshold, deps.Config.SRS.LeechSuspend),
 	)
 	if err != nil {
 		logger.Error("Failed to create card review service", "error", err)
@@ -492,23 +819,172 @@ func startServer(cfg *config.Config) {
 	}
 	deps.CardReviewService = cardReviewService
 
+	// Create user profile service for the /users/me endpoint
+	userProfileService, err := service.NewUserProfileService(
+		deps.UserStore,
+		deps.ReadCardStore,
+		deps.ReadUserCardStatsStore,
+		deps.ReviewLogStore,
+		logger,
+	)
+	if err != nil {
+		logger.Error("Failed to create user profile service", "error", err)
+		os.Exit(1)
+	}
+	deps.UserProfileService = userProfileService
+
+	// Create user service for account management by admins
+	deps.UserService = service.NewUserService(deps.UserStore, deps.ReviewLogStore, deps.DB, logger,
+		service.WithUserPasswordPolicy(passwordPolicy(cfg.Auth)))
+
+	// Create analytics service for the /stats endpoints
+	analyticsService, err := service.NewAnalyticsService(
+		deps.ReviewEventStore,
+		logger,
+		service.WithHardIsCorrect(cfg.Analytics.HardIsCorrect),
+	)
+	if err != nil {
+		logger.Error("Failed to create analytics service", "error", err)
+		os.Exit(1)
+	}
+	deps.AnalyticsService = analyticsService
+
+	// Create card duplicate service for the /cards/duplicates endpoints
+	cardDuplicateService, err := service.NewCardDuplicateService(deps.CardStore, logger)
+	if err != nil {
+		logger.Error("Failed to create card duplicate service", "error", err)
+		os.Exit(1)
+	}
+	deps.CardDuplicateService = cardDuplicateService
+
+	// Create deck service for the /decks endpoints
+	deckService, err := service.NewDeckService(
+		deps.DeckStore,
+		deps.CardStore,
+		deps.MemoStore,
+		deps.CardReviewService,
+		logger,
+	)
+	if err != nil {
+		logger.Error("Failed to create deck service", "error", err)
+		os.Exit(1)
+	}
+	deps.DeckService = deckService
+
+	// Create export service for the /export endpoint; exports only read, so use the read card store
+	exportService, err := service.NewExportService(deps.ReadCardStore, logger)
+	if err != nil {
+		logger.Error("Failed to create export service", "error", err)
+		os.Exit(1)
+	}
+	deps.ExportService = exportService
+
+	// Create import service for the /import endpoint
+	importService, err := service.NewImportService(
+		deps.MemoStore,
+		deps.CardStore,
+		deps.UserCardStatsStore,
+		logger,
+		service.WithImportMaxCards(cfg.Import.MaxCards),
+		service.WithImportMaxFailureFraction(cfg.Import.MaxFailureFraction),
+		service.WithImportNewCardSettings(deps.UserStore),
+	)
+	if err != nil {
+		logger.Error("Failed to create import service", "error", err)
+		os.Exit(1)
+	}
+	deps.ImportService = importService
+
+	// Short-TTL per-user cache for frequently-read endpoints; disabled when the TTL is 0
+	deps.ResponseCache = apiMiddleware.NewResponseCache(
+		apiMiddleware.NewMemoryResponseCacheStore(cfg.Server.ResponseCacheMaxEntries),
+		time.Duration(cfg.Server.ResponseCacheTTLSeconds)*time.Second,
+		logger,
+	)
+
+	// Cards added by background generation invalidate the owner's cached responses,
+	// and notify the configured webhook, if any
+	eventBus := events.NewEventBus(logger)
+	deps.EventBus = eventBus
+	eventBus.Subscribe(events.EventTypeCardsGenerated, deps.ResponseCache)
+	if cfg.Webhooks.URL != "" {
+		webhookNotifier, err := webhook.NewWebhookNotifier(cfg.Webhooks, logger)
+		if err != nil {
+			logger.Error("Failed to create webhook notifier", "error", err)
+			os.Exit(1)
+		}
+		// Deliver in the background so retries against a slow or unreachable
+		// receiver never hold up generation workers
+		webhookQueue := events.NewAsyncHandler(webhookNotifier, cfg.Webhooks.QueueSize, logger)
+		defer func() {
+			closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := webhookQueue.Close(closeCtx); err != nil {
+				logger.Warn("Pending webhook deliveries abandoned on shutdown", "error", err)
+			}
+		}()
+		eventBus.Subscribe(events.EventTypeCardsGenerated, webhookQueue)
+	}
+
+	// Email verification links, password reset links and generation notices,
+	// sent in the background so an unreachable mail server never holds up requests
+	if cfg.Email.SMTPHost != "" {
+		emailNotifier, err := email.NewEmailNotifier(
+			cfg.Email,
+			email.NewSMTPSender(cfg.Email),
+			deps.UserStore,
+			logger,
+		)
+		if err != nil {
+			logger.Error("Failed to create email notifier", "error", err)
+			os.Exit(1)
+		}
+		emailQueue := events.NewAsyncHandler(emailNotifier, cfg.Email.QueueSize, logger)
+		defer func() {
+			closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := emailQueue.Close(closeCtx); err != nil {
+				logger.Warn("Pending emails abandoned on shutdown", "error", err)
+			}
+		}()
+		eventBus.Subscribe(events.EventTypeEmailVerificationRequested, emailQueue)
+		eventBus.Subscribe(events.EventTypePasswordResetRequested, emailQueue)
+		eventBus.Subscribe(events.EventTypeCardsGenerated, emailQueue)
+	}
+	memoTaskOpts := []task.MemoGenerationTaskOption{
+		task.WithCompletionEmitter(eventBus),
+		task.WithStatusEmitter(eventBus),
+	}
+
+	// Put generated cards without a deck into the user's default deck
+	if cfg.Server.CreateDefaultDecks {
+		memoTaskOpts = append(memoTaskOpts, task.WithDefaultDeck(deps.DeckService))
+	}
+
+	// Skip generated cards the user already has
+	if cfg.LLM.DeduplicateCards {
+		memoTaskOpts = append(memoTaskOpts, task.WithDuplicateFilter(deps.CardStore))
+	}
+
 	// Create the task factory
 	memoTaskFactory := task.NewMemoGenerationTaskFactory(
 		memoServiceAdapter,
 		deps.Generator,
 		deps.CardService, // Use CardService instead of CardRepository
 		logger,
+		memoTaskOpts...,
 	)
 
-	// Create and register task factory event handler
-	taskFactoryHandler := &TaskFactoryEventHandler{
-		taskFactory: memoTaskFactory,
-		taskRunner:  taskRunner,
-		logger:      logger.With("component", "task_factory_event_handler"),
-	}
+	// Register memo generation so emitted events are enqueued as tasks, and
+	// tasks recovered from the database can run again
+	eventEmitter.RegisterTaskType(task.TaskTypeMemoGeneration, task.MemoGenerationTaskBuilder(memoTaskFactory))
+	taskRunner.RegisterTaskType(task.TaskTypeMemoGeneration, memoTaskFactory.RestoreTask)
 
-	// Register the event handler with the event emitter
-	eventEmitter.RegisterHandler(taskFactoryHandler)
+	// Start the task runner only now, so the tasks it recovers can be restored
+	if err := taskRunner.Start(); err != nil {
+		logger.Error("Failed to start task runner", "error", err)
+		os.Exit(1)
+	}
 
 	// Ensure task runner is stopped when the server shuts down
 	defer taskRunner.Stop()
@@ -578,28 +1054,182 @@ func setupLogger(cfg *config.Config) (*slog.Logger, error) {
 // setupDatabase establishes a connection to the database and configures connection pools.
 // Returns the database connection if successful, or an error if the connection fails.
 func setupDatabase(cfg *config.Config, logger *slog.Logger) (*sql.DB, error) {
+	return openDatabase(cfg.Database.URL, cfg.Database)
+}
+
+// setupPostgresStores connects to the configured database and read replicas and
+// adds the PostgreSQL stores to deps. The returned function closes the connections.
+func setupPostgresStores(deps *appDependencies, passwordScheme auth.PasswordScheme) (func(), error) {
+	cfg, logger := deps.Config, deps.Logger
+
+	db, err := setupDatabase(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Connect to read replicas, if any. Profile and forecast reads are spread across
+	// them; with none configured those reads use the primary.
+	replicas, err := setupReplicaDatabases(cfg, logger)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to setup read replicas: %w", err)
+	}
+	if len(replicas) > 0 {
+		logger.Info("Read replicas configured", "count", len(replicas))
+	}
+	readDB := store.NewReplicatedDB(db, replicas...)
+	closeAll := func() {
+		closeDatabases(replicas, logger)
+		if err := db.Close(); err != nil {
+			logger.Error("Error closing database connection", "error", err)
+		}
+	}
+
+	// Reads are optionally retried after transient connection errors; writes never are
+	readRetryPolicy := postgres.ReadRetryPolicy{
+		MaxRetries: cfg.Database.ReadMaxRetries,
+		Delay:      time.Duration(cfg.Database.ReadRetryDelayMs) * time.Millisecond,
+	}
+	// Store queries are timed and slow ones logged when a threshold is configured,
+	// and traced when tracing is enabled
+	var storeDB, readStoreDB store.DBTX = db, readDB
+	if cfg.Database.SlowQueryMs > 0 || cfg.Tracing.Enabled {
+		threshold := time.Duration(-1) // No slow query logging
+		if cfg.Database.SlowQueryMs > 0 {
+			threshold = time.Duration(cfg.Database.SlowQueryMs) * time.Millisecond
+			logger.Info("Slow query logging enabled", "threshold_ms", cfg.Database.SlowQueryMs)
+		}
+		var instrumentOpts []postgres.InstrumentOption
+		if cfg.Tracing.Enabled {
+			instrumentOpts = append(instrumentOpts, postgres.WithTracing())
+		}
+		storeDB = postgres.NewInstrumentedDB(db, threshold, logger, instrumentOpts...)
+		readStoreDB = postgres.NewInstrumentedDB(readDB, threshold, logger, instrumentOpts...)
+	}
+
+	deps.DB = db
+	deps.UserStore = postgres.NewRetryingUserStore(
+		postgres.NewPostgresUserStore(storeDB, cfg.Auth.BCryptCost).WithPasswordHasher(passwordScheme),
+		readRetryPolicy, logger)
+	deps.TaskStore = postgres.NewPostgresTaskStore(storeDB)
+	deps.MemoStore = postgres.NewRetryingMemoStore(
+		postgres.NewPostgresMemoStore(storeDB, logger), readRetryPolicy, logger)
+	cardStore := postgres.NewRetryingCardStore(
+		postgres.NewPostgresCardStore(storeDB, logger), readRetryPolicy, logger)
+	deps.CardStore = cardStore
+	deps.CardRepository = cardStore
+	deps.UserCardStatsStore = postgres.NewRetryingUserCardStatsStore(
+		postgres.NewPostgresUserCardStatsStore(storeDB, logger), readRetryPolicy, logger)
+	deps.ReviewLogStore = postgres.NewPostgresReviewLogStore(storeDB, logger)
+	deps.ReviewEventStore = postgres.NewPostgresReviewEventStore(storeDB, logger)
+	deps.DeckStore = postgres.NewPostgresDeckStore(storeDB, logger)
+	deps.IdempotencyKeyStore = postgres.NewPostgresIdempotencyKeyStore(db, logger)
+	deps.GenerationUsage = postgres.NewPostgresGenerationUsageStore(db, logger)
+	// Read stores may send queries to a replica. Only services that read these
+	// stores outside of transactions use them; transactions still run on the primary.
+	deps.ReadCardStore = postgres.NewRetryingCardStore(
+		postgres.NewPostgresCardStore(readStoreDB, logger), readRetryPolicy, logger)
+	deps.ReadUserCardStatsStore = postgres.NewRetryingUserCardStatsStore(
+		postgres.NewPostgresUserCardStatsStore(readStoreDB, logger), readRetryPolicy, logger)
+
+	// Find the newest migration so readiness can report when the schema is behind
+	deps.MigrationVersion = postgres.AppliedMigrationVersion
+	deps.ExpectedMigrationVersion, err = postgres.LatestMigrationVersion(migrationsDir)
+	if err != nil {
+		logger.Warn("Could not determine latest migration version; readiness will not check migrations",
+			"error", err,
+			"dir", migrationsDir)
+	}
+
+	return closeAll, nil
+}
+
+// setupMemoryStores adds in-memory stores to deps, all sharing one backend,
+// and returns the backend. There are no migrations, so readiness only checks
+// that the backend's handle can be pinged.
+func setupMemoryStores(deps *appDependencies, passwordScheme auth.PasswordScheme) *memory.Backend {
+	backend := memory.NewBackend()
+
+	deps.DB = backend.DB()
+	deps.UserStore = memory.NewUserStore(backend, deps.Config.Auth.BCryptCost).WithPasswordHasher(passwordScheme)
+	deps.TaskStore = memory.NewTaskStore(backend)
+	deps.MemoStore = memory.NewMemoStore(backend)
+	deps.CardStore = memory.NewCardStore(backend)
+	deps.CardRepository = deps.CardStore
+	deps.UserCardStatsStore = memory.NewUserCardStatsStore(backend)
+	deps.ReviewLogStore = memory.NewReviewLogStore(backend)
+	deps.ReviewEventStore = memory.NewReviewEventStore(backend)
+	deps.DeckStore = memory.NewDeckStore(backend)
+	deps.IdempotencyKeyStore = memory.NewIdempotencyKeyStore(backend)
+	deps.GenerationUsage = memory.NewGenerationUsageStore(backend)
+	deps.ReadCardStore = deps.CardStore
+	deps.ReadUserCardStatsStore = deps.UserCardStatsStore
+
+	deps.MigrationVersion = func(context.Context, store.DBTX) (int64, error) {
+		return 0, nil
+	}
+	deps.ExpectedMigrationVersion = 0
+
+	return backend
+}
+
+// setupReplicaDatabases connects to each configured read replica, using the same pool
+// limits as the primary. If any replica cannot be reached, the ones already opened
+// are closed and an error is returned.
+func setupReplicaDatabases(cfg *config.Config, logger *slog.Logger) ([]*sql.DB, error) {
+	replicas := make([]*sql.DB, 0, len(cfg.Database.ReplicaURLs))
+	for i, url := range cfg.Database.ReplicaURLs {
+		replica, err := openDatabase(url, cfg.Database)
+		if err != nil {
+			closeDatabases(replicas, logger)
+			return nil, fmt.Errorf("failed to set up read replica %d: %w", i, err)
+		}
+		replicas = append(replicas, replica)
+	}
+
+	return replicas, nil
+}
+
+// openDatabase opens a connection pool for the given URL and verifies it with a ping.
+func openDatabase(url string, cfg config.DatabaseConfig) (*sql.DB, error) {
 	// Open database connection
-	db, err := sql.Open("pgx", cfg.Database.URL)
+	db, err := sql.Open("pgx", url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
-	// Configure connection pool with reasonable defaults
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	configureConnectionPool(db, cfg)
 
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	return db, nil
 }
 
+// closeDatabases closes each connection pool, logging rather than returning failures.
+func closeDatabases(dbs []*sql.DB, logger *slog.Logger) {
+	for _, db := range dbs {
+		if err := db.Close(); err != nil {
+			logger.Error("Error closing database connection", "error", err)
+		}
+	}
+}
+
+// configureConnectionPool applies the pool limits from the database configuration.
+// A zero ConnMaxLifetimeMinutes or ConnMaxIdleTimeMinutes leaves connections open indefinitely.
+func configureConnectionPool(db *sql.DB, cfg config.DatabaseConfig) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetimeMinutes) * time.Minute)
+	db.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTimeMinutes) * time.Minute)
+}
+
 // setupJWTService initializes and configures the JWT authentication service.
 // Returns the configured service or an error if setup fails.
 func setupJWTService(cfg *config.Config) (auth.JWTService, error) {
@@ -607,6 +1237,17 @@ func setupJWTService(cfg *config.Config) (auth.JWTService, error) {
 		return nil, fmt.Errorf("JWT secret cannot be empty")
 	}
 
+	// In strict mode NewJWTService rejects bad lifetimes itself; otherwise
+	// report them so they can be fixed before strict mode is turned on
+	if !cfg.Auth.StrictTokenLifetimes {
+		if err := auth.ValidateTokenLifetimes(cfg.Auth); err != nil {
+			slog.Warn("Token lifetimes would be rejected with auth.strict_token_lifetimes",
+				"error", err,
+				"token_lifetime_minutes", cfg.Auth.TokenLifetimeMinutes,
+				"refresh_token_lifetime_minutes", cfg.Auth.RefreshTokenLifetimeMinutes)
+		}
+	}
+
 	// Initialize the JWT service with configuration
 	jwtService, err := auth.NewJWTService(cfg.Auth)
 	if err != nil {
@@ -616,22 +1257,51 @@ func setupJWTService(cfg *config.Config) (auth.JWTService, error) {
 	return jwtService, nil
 }
 
-// setupTaskRunner initializes and starts the background task processor.
-// Takes a fully populated appDependencies struct and returns a started TaskRunner.
-func setupTaskRunner(deps *appDependencies) (*task.TaskRunner, error) {
+// passwordPolicy returns the password policy configured in cfg.
+func passwordPolicy(cfg config.AuthConfig) domain.PasswordPolicy {
+	return domain.PasswordPolicy{
+		MinLength:           cfg.PasswordMinLength,
+		RequireMixedClasses: cfg.PasswordRequireMixedClasses,
+		MinStrength:         cfg.PasswordMinStrength,
+	}
+}
+
+// setupCardContentValidator installs a JSON Schema validator for card content
+// when llm.card_schema_path is configured. Otherwise the default validator,
+// which requires front and back fields, stays in place.
+func setupCardContentValidator(cfg *config.Config, logger *slog.Logger) error {
+	if cfg.LLM.CardSchemaPath == "" {
+		return nil
+	}
+
+	schema, err := os.ReadFile(cfg.LLM.CardSchemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to read card content schema: %w", err)
+	}
+	validator, err := domain.NewJSONSchemaValidator(schema)
+	if err != nil {
+		return err
+	}
+
+	domain.SetCardContentValidator(validator)
+	logger.Info("Card content schema loaded", "path", cfg.LLM.CardSchemaPath)
+	return nil
+}
+
+// setupTaskRunner initializes the background task processor.
+// Takes a fully populated appDependencies struct and returns a TaskRunner that
+// is started once its task types have been registered.
+func setupTaskRunner(deps *appDependencies) *task.TaskRunner {
 	// Create the task runner with the configured dependencies
-	taskRunner := task.NewTaskRunner(deps.TaskStore, task.TaskRunnerConfig{
+	return task.NewTaskRunner(deps.TaskStore, task.TaskRunnerConfig{
 		QueueSize:    deps.Config.Task.QueueSize,
 		WorkerCount:  deps.Config.Task.WorkerCount,
 		StuckTaskAge: time.Duration(deps.Config.Task.StuckTaskAgeMinutes) * time.Minute,
+		StuckTaskCheckInterval: time.Duration(
+			deps.Config.Task.StuckTaskCheckIntervalSeconds,
+		) * time.Second,
+		HeartbeatInterval: time.Duration(deps.Config.Task.HeartbeatIntervalSeconds) * time.Second,
 	}, deps.Logger)
-
-	// Start the task runner
-	if err := taskRunner.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start task runner: %w", err)
-	}
-
-	return taskRunner, nil
 }
 
 // slogGooseLogger adapts slog for goose's logger interface
@@ -752,7 +1422,7 @@ func runMigrations(cfg *config.Config, command string, args ...string) error {
 	case "reset":
 		err = goose.Reset(db, migrationsDir)
 	case "status":
-		err = goose.Status(db, migrationsDir)
+		err = printMigrationStatus(context.Background(), os.Stdout, db)
 	case "version":
 		err = goose.Version(db, migrationsDir)
 	case "create":
@@ -777,3 +1447,117 @@ func runMigrations(cfg *config.Config, command string, args ...string) error {
 
 	return nil
 }
+
+// printMigrationStatus writes a JSON report of applied and pending migrations to w.
+// The version table is created first if needed, as goose.Status does, so a
+// pristine database reports every migration as pending.
+func printMigrationStatus(ctx context.Context, w io.Writer, db *sql.DB) error {
+	if _, err := goose.EnsureDBVersionContext(ctx, db); err != nil {
+		return fmt.Errorf("failed to ensure DB version: %w", err)
+	}
+
+	report, err := postgres.GetMigrationReport(ctx, db, migrationsDir)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write migration status: %w", err)
+	}
+	return nil
+}
+
+// runEnqueueGeneration loads the configuration, connects to the database and
+// enqueues card generation for the memo with the given ID, writing the ID of
+// the new task to w.
+func runEnqueueGeneration(memoIDArg string, w io.Writer) error {
+	if memoIDArg == "" {
+		return errors.New("-memo-id is required with -enqueue-generation")
+	}
+	memoID, err := uuid.Parse(memoIDArg)
+	if err != nil {
+		return fmt.Errorf("invalid memo ID %q: %w", memoIDArg, err)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Database.URL == "" {
+		return fmt.Errorf("database URL is empty: check your configuration")
+	}
+
+	db, err := openDatabase(cfg.Database.URL, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			slog.Error("Error closing database connection", "error", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	taskID, err := enqueueGeneration(ctx, db, memoID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, taskID); err != nil {
+		return fmt.Errorf("failed to write task ID: %w", err)
+	}
+	return nil
+}
+
+// enqueueGeneration saves a pending card generation task for the memo and
+// returns its ID. It fails if the memo does not exist, or with
+// task.ErrTaskAlreadyActive if generation is already pending or running for it.
+//
+// The task is only stored, not run. A server picks it up the next time it
+// starts and recovers pending tasks.
+func enqueueGeneration(ctx context.Context, db store.DBTX, memoID uuid.UUID) (uuid.UUID, error) {
+	if _, err := postgres.NewPostgresMemoStore(db, nil).GetByID(ctx, memoID); err != nil {
+		if errors.Is(err, store.ErrMemoNotFound) {
+			return uuid.Nil, fmt.Errorf("memo %s does not exist", memoID)
+		}
+		return uuid.Nil, fmt.Errorf("failed to look up memo: %w", err)
+	}
+
+	payload, err := json.Marshal(struct {
+		MemoID uuid.UUID `json:"memo_id"`
+	}{MemoID: memoID})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	t := &enqueuedTask{id: uuid.New(), payload: payload}
+	if err := postgres.NewPostgresTaskStore(db).SaveTask(ctx, t); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to save task: %w", err)
+	}
+	return t.id, nil
+}
+
+// enqueuedTask is a memo generation task saved from the command line. It only
+// carries what the task store records; the server that recovers it runs it.
+type enqueuedTask struct {
+	id      uuid.UUID
+	payload []byte
+}
+
+func (t *enqueuedTask) ID() uuid.UUID           { return t.id }
+func (t *enqueuedTask) Type() string            { return task.TaskTypeMemoGeneration }
+func (t *enqueuedTask) Payload() []byte         { return t.payload }
+func (t *enqueuedTask) Status() task.TaskStatus { return task.TaskStatusPending }
+
+// Priority implements task.Prioritized. Backfills must not hold up memos that
+// users are waiting on.
+func (t *enqueuedTask) Priority() int { return task.PriorityBackground }
+
+// Execute always fails: enqueued tasks are run by a server, not by the command
+func (t *enqueuedTask) Execute(ctx context.Context) error {
+	return errors.New("enqueued tasks are run by the server")
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chdirProjectRoot changes to the project root, so that migrationsDir resolves,
+// until the test ends
+func chdirProjectRoot(t *testing.T) {
+	t.Helper()
+
+	_, thisFile, _, ok := runtime.Caller(0)
+	require.True(t, ok, "Failed to get current file path from runtime.Caller")
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(thisFile)))
+
+	origWD, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(projectRoot))
+	t.Cleanup(func() {
+		if err := os.Chdir(origWD); err != nil {
+			t.Logf("Warning: Failed to restore working directory: %v", err)
+		}
+	})
+}
+
+// TestMigrationStatus verifies that the status report shows every migration
+// applied after migrating up, in a stable JSON shape
+func TestMigrationStatus(t *testing.T) {
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	dbURL := testutils.GetTestDatabaseURL(t)
+	chdirProjectRoot(t)
+
+	cfg := &config.Config{Database: config.DatabaseConfig{URL: dbURL}}
+	require.NoError(t, runMigrations(cfg, "up"), "Failed to run migrations up")
+
+	db, err := sql.Open("pgx", dbURL)
+	require.NoError(t, err)
+	defer testutils.AssertCloseNoError(t, db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var out bytes.Buffer
+	require.NoError(t, printMigrationStatus(ctx, &out, db))
+
+	latest, err := postgres.LatestMigrationVersion(migrationsDir)
+	require.NoError(t, err)
+
+	var report postgres.MigrationReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, latest, report.CurrentVersion)
+	assert.Equal(t, latest, report.LatestVersion)
+	assert.Zero(t, report.PendingCount)
+	require.NotEmpty(t, report.Migrations)
+	for _, migration := range report.Migrations {
+		assert.True(t, migration.Applied, "Migration %s should be applied", migration.Name)
+	}
+
+	// Tooling depends on these field names
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(out.Bytes(), &raw))
+	assert.ElementsMatch(t,
+		[]string{"current_version", "latest_version", "pending_count", "migrations"},
+		keys(raw))
+
+	var migrations []map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(raw["migrations"], &migrations))
+	assert.ElementsMatch(t, []string{"version", "name", "applied", "applied_at"}, keys(migrations[0]))
+}
+
+// keys returns the keys of a decoded JSON object
+func keys(m map[string]json.RawMessage) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/phrazzld/scry-api/internal/api"
+	apiMiddleware "github.com/phrazzld/scry-api/internal/api/middleware"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenAPIRoutesMatchRouter checks that the OpenAPI document lists exactly
+// the endpoints the router serves, so new routes are not left undocumented.
+func TestOpenAPIRoutesMatchRouter(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	deps := &appDependencies{
+		Config: &config.Config{},
+		Logger: logger,
+		MigrationVersion: func(ctx context.Context, db store.DBTX) (int64, error) {
+			return 0, nil
+		},
+		ResponseCache: apiMiddleware.NewResponseCache(nil, 0, logger),
+		UserStore:     mocks.NewMockUserStore(),
+		UserService:   service.NewUserService(nil, nil, nil, logger),
+	}
+	router := setupRouter(deps)
+
+	// The unversioned /api alias is not documented, but must serve the same
+	// routes as /api/v1
+	var served, legacy []string
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.HasPrefix(route, "/api/") && !strings.HasPrefix(route, "/api/v1/") {
+			legacy = append(legacy, method+" "+route)
+			return nil
+		}
+		served = append(served, method+" "+route)
+		return nil
+	})
+	require.NoError(t, err)
+
+	var documented, aliases []string
+	for _, route := range api.OpenAPIRoutes() {
+		documented = append(documented, route.Method+" "+route.Path)
+		if path, ok := strings.CutPrefix(route.Path, "/api/v1/"); ok {
+			aliases = append(aliases, route.Method+" /api/"+path)
+		}
+	}
+
+	sort.Strings(served)
+	sort.Strings(documented)
+	assert.Equal(t, documented, served,
+		"api.OpenAPIRoutes must list the routes registered in setupRouter")
+
+	sort.Strings(legacy)
+	sort.Strings(aliases)
+	assert.Equal(t, aliases, legacy, "/api must serve the same routes as /api/v1")
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/redact"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+const (
+	// DefaultAdminUserListLimit is the number of users returned per page when no limit is given
+	DefaultAdminUserListLimit = 50
+
+	// MaxAdminUserListLimit is the largest page size accepted for user listings
+	MaxAdminUserListLimit = 200
+)
+
+// MigrationStatusResponse is the body returned by GET /admin/migrations
+type MigrationStatusResponse struct {
+	// CurrentVersion is the newest migration applied to the database
+	CurrentVersion int64 `json:"current_version"`
+
+	// LatestVersion is the newest migration shipped with this server
+	LatestVersion int64 `json:"latest_version"`
+
+	// PendingCount is the number of shipped migrations not yet applied
+	PendingCount int `json:"pending_count"`
+}
+
+// AdminUserResponse represents a user in admin API responses
+type AdminUserResponse struct {
+	ID            string     `json:"id"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	EmailVerified bool       `json:"email_verified"`
+	Disabled      bool       `json:"disabled"`
+	DisabledAt    *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// GenerationDiagnosticsResponse is the body returned by GET /admin/diagnostics/generation
+type GenerationDiagnosticsResponse struct {
+	// Model is the configured generation model
+	Model string `json:"model"`
+
+	// Reachable reports whether the provider answered a ping with the
+	// configured credentials and model
+	Reachable bool `json:"reachable"`
+
+	// Error describes why the provider is unreachable
+	Error string `json:"error,omitempty"`
+
+	// LatencyMS is how long the ping took
+	LatencyMS int64 `json:"latency_ms"`
+}
+
+// UpdateUserRequest is the body of PATCH /admin/users/{id}.
+// Omitted fields are left unchanged, but at least one must be given.
+type UpdateUserRequest struct {
+	Role     *string `json:"role,omitempty" validate:"omitempty,oneof=user admin"`
+	Disabled *bool   `json:"disabled,omitempty"`
+}
+
+// MigrationStatusFunc reports the migration state of the database
+type MigrationStatusFunc func(ctx context.Context) (*MigrationStatusResponse, error)
+
+// AdminHandler handles operational endpoints for running the service
+type AdminHandler struct {
+	migrationStatus MigrationStatusFunc
+	userStore       store.UserStore
+	userService     service.UserService
+	generation      GenerationPinger
+	generationModel string
+	logger          *slog.Logger
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(
+	migrationStatus MigrationStatusFunc,
+	userStore store.UserStore,
+	userService service.UserService,
+	logger *slog.Logger,
+) *AdminHandler {
+	if migrationStatus == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("migrationStatus cannot be nil for AdminHandler")
+	}
+	if userStore == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("userStore cannot be nil for AdminHandler")
+	}
+	if userService == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("userService cannot be nil for AdminHandler")
+	}
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for AdminHandler")
+	}
+
+	return &AdminHandler{
+		migrationStatus: migrationStatus,
+		userStore:       userStore,
+		userService:     userService,
+		logger:          logger.With(slog.String("component", "admin_handler")),
+	}
+}
+
+// WithGenerationDiagnostics returns a new AdminHandler whose generation
+// diagnostics ping pinger and report model as the configured model.
+// The original handler remains unchanged.
+func (h *AdminHandler) WithGenerationDiagnostics(pinger GenerationPinger, model string) *AdminHandler {
+	newHandler := *h
+	newHandler.generation = pinger
+	newHandler.generationModel = model
+	return &newHandler
+}
+
+// GetMigrationStatus handles GET /admin/migrations requests
+// It returns the database's migration version and how many migrations are pending.
+func (h *AdminHandler) GetMigrationStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.migrationStatus(r.Context())
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get migration status")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, status)
+}
+
+// ListUsers handles GET /admin/users requests
+// It returns users ordered by email. The optional email query parameter limits
+// them to emails containing it, ignoring case, and limit (default
+// DefaultAdminUserListLimit, capped at MaxAdminUserListLimit) and offset page
+// through them.
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	limit, offset, err := parsePaginationQuery(r, DefaultAdminUserListLimit, MaxAdminUserListLimit)
+	if err != nil {
+		log.Warn("invalid pagination parameters", slog.String("error", err.Error()))
+		HandleAPIError(w, r, err, "Invalid pagination parameters")
+		return
+	}
+
+	users, err := h.userStore.List(r.Context(), r.URL.Query().Get("email"), limit, offset)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to list users")
+		return
+	}
+
+	response := make([]AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		response = append(response, adminUserToResponse(user))
+	}
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// UpdateUser handles PATCH /admin/users/{id} requests
+// It changes a user's role or disables or re-enables their account. Disabled
+// users fail authentication, including with tokens issued before they were
+// disabled.
+func (h *AdminHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid user ID format", slog.String("user_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid user ID format")
+		return
+	}
+
+	var req UpdateUserRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+	if err := shared.Validate.Struct(req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+	if req.Role == nil && req.Disabled == nil {
+		HandleAPIError(w, r,
+			domain.NewValidationError("body", "role or disabled is required", domain.ErrValidation),
+			"Invalid request")
+		return
+	}
+
+	var user *domain.User
+	if req.Role != nil {
+		user, err = h.userStore.GetByID(r.Context(), userID)
+		if err != nil {
+			HandleAPIError(w, r, err, "Failed to get user")
+			return
+		}
+		user.Role = domain.UserRole(*req.Role)
+		if err := h.userStore.Update(r.Context(), user); err != nil {
+			HandleAPIError(w, r, err, "Failed to update user")
+			return
+		}
+	}
+	if req.Disabled != nil {
+		user, err = h.userService.SetDisabled(r.Context(), userID, *req.Disabled)
+		if err != nil {
+			HandleAPIError(w, r, err, "Failed to update user")
+			return
+		}
+	}
+
+	adminID, _ := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	log.Info("admin updated user",
+		slog.String("admin_id", adminID.String()),
+		slog.String("user_id", userID.String()),
+		slog.String("role", string(user.Role)),
+		slog.Bool("disabled", user.IsDisabled()))
+
+	shared.RespondWithJSON(w, r, http.StatusOK, adminUserToResponse(user))
+}
+
+// adminUserToResponse converts a domain.User to an AdminUserResponse
+func adminUserToResponse(user *domain.User) AdminUserResponse {
+	return AdminUserResponse{
+		ID:            user.ID.String(),
+		Email:         user.Email,
+		Role:          string(user.Role),
+		EmailVerified: user.EmailVerified,
+		Disabled:      user.IsDisabled(),
+		DisabledAt:    user.DisabledAt,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
+
+// GetGenerationDiagnostics handles GET /admin/diagnostics/generation requests
+// It pings the generation provider, without generating anything or counting
+// against any user's quota, and reports whether it is reachable with the
+// configured credentials and model. An unreachable provider is reported in
+// the body of a 200 response.
+func (h *AdminHandler) GetGenerationDiagnostics(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	response := GenerationDiagnosticsResponse{Model: h.generationModel}
+	if h.generation == nil {
+		response.Error = "generation provider is not configured"
+		shared.RespondWithJSON(w, r, http.StatusOK, response)
+		return
+	}
+
+	start := time.Now()
+	err := h.generation.Ping(r.Context())
+	response.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		log.Warn("generation provider unreachable", slog.String("error", redact.Error(err)))
+		response.Error = redact.Error(err)
+	} else {
+		response.Reachable = true
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
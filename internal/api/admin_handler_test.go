@@ -0,0 +1,306 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/generation"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminHandler_GetMigrationStatus(t *testing.T) {
+	t.Run("reports_status", func(t *testing.T) {
+		handler := NewAdminHandler(func(ctx context.Context) (*MigrationStatusResponse, error) {
+			return &MigrationStatusResponse{
+				CurrentVersion: 20250501000009,
+				LatestVersion:  20250501000010,
+				PendingCount:   1,
+			}, nil
+		}, mocks.NewMockUserStore(), &MockUserService{}, slog.Default())
+
+		w := httptest.NewRecorder()
+		handler.GetMigrationStatus(w, httptest.NewRequest(http.MethodGet, "/api/admin/migrations", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, map[string]interface{}{
+			"current_version": float64(20250501000009),
+			"latest_version":  float64(20250501000010),
+			"pending_count":   float64(1),
+		}, resp)
+	})
+
+	t.Run("hides_database_errors", func(t *testing.T) {
+		handler := NewAdminHandler(func(ctx context.Context) (*MigrationStatusResponse, error) {
+			return nil, errors.New("relation \"goose_db_version\" does not exist")
+		}, mocks.NewMockUserStore(), &MockUserService{}, slog.Default())
+
+		w := httptest.NewRecorder()
+		handler.GetMigrationStatus(w, httptest.NewRequest(http.MethodGet, "/api/admin/migrations", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotContains(t, w.Body.String(), "goose_db_version")
+	})
+}
+
+func TestAdminHandler_GetGenerationDiagnostics(t *testing.T) {
+	diagnose := func(t *testing.T, handler *AdminHandler) GenerationDiagnosticsResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.GetGenerationDiagnostics(w,
+			httptest.NewRequest(http.MethodGet, "/api/admin/diagnostics/generation", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp GenerationDiagnosticsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+	newHandler := func() *AdminHandler {
+		return NewAdminHandler(noMigrations, mocks.NewMockUserStore(), &MockUserService{}, slog.Default())
+	}
+
+	t.Run("reachable", func(t *testing.T) {
+		provider := &mocks.MockGenerator{}
+		resp := diagnose(t, newHandler().WithGenerationDiagnostics(provider, "gemini-2.0-flash"))
+
+		assert.True(t, resp.Reachable)
+		assert.Equal(t, "gemini-2.0-flash", resp.Model)
+		assert.Empty(t, resp.Error)
+		assert.Zero(t, provider.GenerateCardsCalls.Count, "diagnostics must not generate cards")
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		provider := &mocks.MockGenerator{
+			PingErr: fmt.Errorf("%w: API key not valid", generation.ErrProviderUnreachable),
+		}
+		resp := diagnose(t, newHandler().WithGenerationDiagnostics(provider, "gemini-2.0-flash"))
+
+		assert.False(t, resp.Reachable)
+		assert.Equal(t, "gemini-2.0-flash", resp.Model)
+		assert.Contains(t, resp.Error, "API key not valid")
+		assert.Zero(t, provider.GenerateCardsCalls.Count, "diagnostics must not generate cards")
+	})
+
+	t.Run("not_configured", func(t *testing.T) {
+		resp := diagnose(t, newHandler())
+
+		assert.False(t, resp.Reachable)
+		assert.NotEmpty(t, resp.Error)
+	})
+}
+
+func TestNewAdminHandler_NilDependencies(t *testing.T) {
+	statusFn := func(ctx context.Context) (*MigrationStatusResponse, error) { return nil, nil }
+
+	userStore := mocks.NewMockUserStore()
+	userService := &MockUserService{}
+
+	assert.Panics(t, func() { NewAdminHandler(nil, userStore, userService, slog.Default()) })
+	assert.Panics(t, func() { NewAdminHandler(statusFn, nil, userService, slog.Default()) })
+	assert.Panics(t, func() { NewAdminHandler(statusFn, userStore, nil, slog.Default()) })
+	assert.Panics(t, func() { NewAdminHandler(statusFn, userStore, userService, nil) })
+}
+
+// MockUserService implements service.UserService for admin handler tests.
+// Only SetDisabled is implemented; the other methods panic if called.
+type MockUserService struct {
+	service.UserService
+
+	SetDisabledFn func(ctx context.Context, targetID uuid.UUID, disabled bool) (*domain.User, error)
+}
+
+// SetDisabled implements service.UserService
+func (m *MockUserService) SetDisabled(ctx context.Context, targetID uuid.UUID, disabled bool) (*domain.User, error) {
+	if m.SetDisabledFn != nil {
+		return m.SetDisabledFn(ctx, targetID, disabled)
+	}
+	return nil, nil
+}
+
+// noMigrations is a MigrationStatusFunc for tests that don't exercise it
+func noMigrations(ctx context.Context) (*MigrationStatusResponse, error) {
+	return &MigrationStatusResponse{}, nil
+}
+
+func TestAdminHandler_ListUsers(t *testing.T) {
+	createdAt := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	users := []*domain.User{
+		{ID: uuid.New(), Email: "ada@example.com", Role: domain.UserRoleAdmin, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: uuid.New(), Email: "bob@example.com", Role: domain.UserRoleUser, DisabledAt: &createdAt, CreatedAt: createdAt},
+	}
+
+	var gotQuery string
+	var gotLimit, gotOffset int
+	userStore := mocks.NewMockUserStore()
+	userStore.ListFn = func(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error) {
+		gotQuery, gotLimit, gotOffset = emailQuery, limit, offset
+		return users, nil
+	}
+	handler := NewAdminHandler(noMigrations, userStore, &MockUserService{}, slog.Default())
+
+	t.Run("lists_users", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ListUsers(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?email=EXAMPLE&offset=10", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp []AdminUserResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp, 2)
+		assert.Equal(t, AdminUserResponse{
+			ID:        users[0].ID.String(),
+			Email:     "ada@example.com",
+			Role:      "admin",
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}, resp[0])
+		assert.True(t, resp[1].Disabled)
+		assert.Equal(t, &createdAt, resp[1].DisabledAt)
+
+		assert.Equal(t, "EXAMPLE", gotQuery)
+		assert.Equal(t, DefaultAdminUserListLimit, gotLimit)
+		assert.Equal(t, 10, gotOffset)
+	})
+
+	t.Run("caps_the_limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ListUsers(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?limit=1000", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "", gotQuery)
+		assert.Equal(t, MaxAdminUserListLimit, gotLimit)
+	})
+
+	t.Run("rejects_invalid_pagination", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ListUsers(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?limit=0", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAdminHandler_UpdateUser(t *testing.T) {
+	adminID := uuid.New()
+
+	// newUpdateRequest builds an admin's PATCH request for the user with the given ID
+	newUpdateRequest := func(id, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/users/"+id, bytes.NewBufferString(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		ctx := context.WithValue(req.Context(), shared.UserIDContextKey, adminID)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	// newHandler returns a handler whose store holds one regular user
+	newHandler := func(t *testing.T) (*AdminHandler, *mocks.MockUserStore, *domain.User) {
+		t.Helper()
+		user := &domain.User{
+			ID:             uuid.New(),
+			Email:          "user@example.com",
+			HashedPassword: "$2a$10$hash",
+			Role:           domain.UserRoleUser,
+			Timezone:       domain.DefaultUserTimezone,
+			CreatedAt:      time.Now().UTC(),
+			UpdatedAt:      time.Now().UTC(),
+		}
+		userStore := mocks.NewMockUserStore()
+		userStore.Users[user.Email] = user
+
+		// Disabling goes through the service, which updates the same store
+		userService := &MockUserService{
+			SetDisabledFn: func(ctx context.Context, targetID uuid.UUID, disabled bool) (*domain.User, error) {
+				user, err := userStore.GetByID(ctx, targetID)
+				if err != nil {
+					return nil, err
+				}
+				user.DisabledAt = nil
+				if disabled {
+					now := time.Now().UTC()
+					user.DisabledAt = &now
+				}
+				return user, userStore.Update(ctx, user)
+			},
+		}
+		return NewAdminHandler(noMigrations, userStore, userService, slog.Default()), userStore, user
+	}
+
+	t.Run("changes_role", func(t *testing.T) {
+		handler, userStore, user := newHandler(t)
+
+		w := httptest.NewRecorder()
+		handler.UpdateUser(w, newUpdateRequest(user.ID.String(), `{"role":"admin"}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp AdminUserResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "admin", resp.Role)
+		assert.False(t, resp.Disabled)
+		assert.Equal(t, domain.UserRoleAdmin, userStore.Users[user.Email].Role)
+	})
+
+	t.Run("disables_and_enables_account", func(t *testing.T) {
+		handler, userStore, user := newHandler(t)
+
+		w := httptest.NewRecorder()
+		handler.UpdateUser(w, newUpdateRequest(user.ID.String(), `{"disabled":true}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp AdminUserResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.True(t, resp.Disabled)
+		assert.NotNil(t, resp.DisabledAt)
+		assert.Equal(t, "user", resp.Role, "omitted fields are unchanged")
+		assert.True(t, userStore.Users[user.Email].IsDisabled())
+
+		w = httptest.NewRecorder()
+		handler.UpdateUser(w, newUpdateRequest(user.ID.String(), `{"disabled":false}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, userStore.Users[user.Email].IsDisabled())
+	})
+
+	t.Run("rejects_invalid_requests", func(t *testing.T) {
+		handler, userStore, user := newHandler(t)
+
+		tests := []struct {
+			name           string
+			id             string
+			body           string
+			expectedStatus int
+		}{
+			{"unknown role", user.ID.String(), `{"role":"superuser"}`, http.StatusBadRequest},
+			{"no changes", user.ID.String(), `{}`, http.StatusBadRequest},
+			{"malformed body", user.ID.String(), `{"disabled":"yes"}`, http.StatusBadRequest},
+			{"invalid user ID", "not-a-uuid", `{"disabled":true}`, http.StatusBadRequest},
+			{"unknown user", uuid.New().String(), `{"disabled":true}`, http.StatusNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				handler.UpdateUser(w, newUpdateRequest(tt.id, tt.body))
+
+				assert.Equal(t, tt.expectedStatus, w.Code)
+			})
+		}
+
+		stored := userStore.Users[user.Email]
+		assert.Equal(t, domain.UserRoleUser, stored.Role)
+		assert.False(t, stored.IsDisabled())
+	})
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/service"
+)
+
+// DefaultRetentionDays is the retention period used when the days parameter is omitted
+const DefaultRetentionDays = 30
+
+// AccuracyResponse represents the share of reviews answered correctly
+type AccuracyResponse struct {
+	Correct  int     `json:"correct"`
+	Total    int     `json:"total"`
+	Accuracy float64 `json:"accuracy"` // Correct / Total, or 0 without reviews
+}
+
+// MaturityAccuracyResponse represents the accuracy for cards of one maturity
+type MaturityAccuracyResponse struct {
+	Maturity string `json:"maturity"`
+	AccuracyResponse
+}
+
+// ReviewDayResponse represents the number of reviews made on one day
+type ReviewDayResponse struct {
+	Date  string `json:"date"` // YYYY-MM-DD in the user's timezone
+	Count int    `json:"count"`
+}
+
+// RetentionResponse represents the authenticated user's retention statistics
+type RetentionResponse struct {
+	Days          int                        `json:"days"`
+	Overall       AccuracyResponse           `json:"overall"`
+	ByMaturity    []MaturityAccuracyResponse `json:"by_maturity"`
+	ReviewsPerDay []ReviewDayResponse        `json:"reviews_per_day"`
+}
+
+// AnalyticsHandler handles review statistics requests
+type AnalyticsHandler struct {
+	analyticsService service.AnalyticsService
+	logger           *slog.Logger
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler
+func NewAnalyticsHandler(analyticsService service.AnalyticsService, logger *slog.Logger) *AnalyticsHandler {
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for AnalyticsHandler")
+	}
+
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+		logger:           logger.With(slog.String("component", "analytics_handler")),
+	}
+}
+
+// GetRetention handles GET /api/stats/retention requests
+// It returns the user's overall accuracy, accuracy by card maturity and reviews
+// per day over the last days days (default 30, capped at domain.MaxRetentionDays).
+func (h *AnalyticsHandler) GetRetention(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	days, err := parseDaysQuery(r, DefaultRetentionDays, domain.MaxRetentionDays)
+	if err != nil {
+		log.Warn("invalid retention days parameter", slog.String("days", r.URL.Query().Get("days")))
+		HandleAPIError(w, r, err, "Invalid days parameter")
+		return
+	}
+
+	report, err := h.analyticsService.Retention(r.Context(), userID, days)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get retention statistics")
+		return
+	}
+
+	response := RetentionResponse{
+		Days:          report.Days,
+		Overall:       accuracyToResponse(report.Overall),
+		ByMaturity:    make([]MaturityAccuracyResponse, 0, len(report.ByMaturity)),
+		ReviewsPerDay: make([]ReviewDayResponse, 0, len(report.ReviewsPerDay)),
+	}
+	for _, bucket := range report.ByMaturity {
+		response.ByMaturity = append(response.ByMaturity, MaturityAccuracyResponse{
+			Maturity:         string(bucket.Maturity),
+			AccuracyResponse: accuracyToResponse(bucket.Accuracy),
+		})
+	}
+	for _, day := range report.ReviewsPerDay {
+		response.ReviewsPerDay = append(response.ReviewsPerDay, ReviewDayResponse{
+			Date:  day.Date.Format(time.DateOnly),
+			Count: day.Count,
+		})
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// accuracyToResponse converts a service.Accuracy to its response representation
+func accuracyToResponse(accuracy service.Accuracy) AccuracyResponse {
+	return AccuracyResponse{
+		Correct:  accuracy.Correct,
+		Total:    accuracy.Total,
+		Accuracy: accuracy.Rate(),
+	}
+}
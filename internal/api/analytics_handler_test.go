@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAnalyticsService is a mock implementation of service.AnalyticsService for testing
+type MockAnalyticsService struct {
+	RetentionFn func(ctx context.Context, userID uuid.UUID, days int) (*service.RetentionReport, error)
+}
+
+// Retention implements service.AnalyticsService
+func (m *MockAnalyticsService) Retention(
+	ctx context.Context,
+	userID uuid.UUID,
+	days int,
+) (*service.RetentionReport, error) {
+	if m.RetentionFn != nil {
+		return m.RetentionFn(ctx, userID, days)
+	}
+	return &service.RetentionReport{Days: days}, nil
+}
+
+// TestAnalyticsHandler_GetRetention tests the days parameter handling and response shape.
+func TestAnalyticsHandler_GetRetention(t *testing.T) {
+	userID := uuid.New()
+	day := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedDays   int
+	}{
+		{name: "default_days", query: "", expectedStatus: http.StatusOK, expectedDays: 30},
+		{name: "explicit_days", query: "?days=7", expectedStatus: http.StatusOK, expectedDays: 7},
+		{name: "capped_days", query: "?days=1000", expectedStatus: http.StatusOK, expectedDays: 365},
+		{name: "zero_days", query: "?days=0", expectedStatus: http.StatusBadRequest},
+		{name: "non_numeric_days", query: "?days=abc", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requestedDays int
+			handler := NewAnalyticsHandler(&MockAnalyticsService{
+				RetentionFn: func(ctx context.Context, id uuid.UUID, days int) (*service.RetentionReport, error) {
+					requestedDays = days
+					assert.Equal(t, userID, id)
+					return &service.RetentionReport{
+						Days:    days,
+						Overall: service.Accuracy{Correct: 3, Total: 4},
+						ByMaturity: []service.MaturityAccuracy{
+							{Maturity: domain.CardMaturityLearning, Accuracy: service.Accuracy{Correct: 1, Total: 2}},
+							{Maturity: domain.CardMaturityYoung, Accuracy: service.Accuracy{Correct: 2, Total: 2}},
+							{Maturity: domain.CardMaturityMature},
+						},
+						ReviewsPerDay: []domain.ReviewDayCount{
+							{Date: day, Count: 0},
+							{Date: day.AddDate(0, 0, 1), Count: 4},
+						},
+					}, nil
+				},
+			}, slog.Default())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/stats/retention"+tc.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			w := httptest.NewRecorder()
+
+			handler.GetRetention(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus != http.StatusOK {
+				assert.Zero(t, requestedDays, "Service should not be called for invalid input")
+				return
+			}
+
+			assert.Equal(t, tc.expectedDays, requestedDays)
+			var resp RetentionResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tc.expectedDays, resp.Days)
+			assert.Equal(t, AccuracyResponse{Correct: 3, Total: 4, Accuracy: 0.75}, resp.Overall)
+			assert.Equal(t, []MaturityAccuracyResponse{
+				{Maturity: "learning", AccuracyResponse: AccuracyResponse{Correct: 1, Total: 2, Accuracy: 0.5}},
+				{Maturity: "young", AccuracyResponse: AccuracyResponse{Correct: 2, Total: 2, Accuracy: 1}},
+				{Maturity: "mature", AccuracyResponse: AccuracyResponse{}},
+			}, resp.ByMaturity)
+			assert.Equal(t, []ReviewDayResponse{
+				{Date: "2025-04-01", Count: 0},
+				{Date: "2025-04-02", Count: 4},
+			}, resp.ReviewsPerDay)
+		})
+	}
+}
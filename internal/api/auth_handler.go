@@ -5,15 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/phrazzld/scry-api/internal/api/shared"
 	"github.com/phrazzld/scry-api/internal/config"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
 	"github.com/phrazzld/scry-api/internal/redact"
+	"github.com/phrazzld/scry-api/internal/service"
 	"github.com/phrazzld/scry-api/internal/service/auth"
 	"github.com/phrazzld/scry-api/internal/store"
 )
@@ -26,6 +31,27 @@ type AuthHandler struct {
 	authConfig       *config.AuthConfig // For accessing token lifetime and other auth settings
 	timeFunc         func() time.Time   // Injectable time source for testing
 	logger           *slog.Logger       // Added logger field
+
+	// deckService, if set, is used to give each newly registered user a default deck
+	deckService service.DeckService
+
+	// loginThrottle, if set, locks emails out after repeated failed logins
+	loginThrottle *auth.LoginThrottle
+
+	// eventEmitter, if set, receives account events such as email verification
+	// requests, for notifiers to deliver
+	eventEmitter events.EventEmitter
+
+	// passwordPolicy is enforced on the passwords of new users and password resets
+	passwordPolicy domain.PasswordPolicy
+
+	// passwordHasher, if set, rehashes passwords on login when the stored hash
+	// was made with another scheme or a lower cost than the configured ones
+	passwordHasher auth.PasswordHasher
+
+	// dummyPasswordHash is checked in place of a real hash for unknown emails,
+	// so their logins take as long as wrong passwords
+	dummyPasswordHash string
 }
 
 // generateTokenResponse generates access and refresh tokens for a user, along with expiration time.
@@ -92,6 +118,7 @@ func NewAuthHandler(
 		authConfig:       authConfig,
 		timeFunc:         time.Now, // Default to system time
 		logger:           logger.With(slog.String("component", "auth_handler")),
+		passwordPolicy:   domain.DefaultPasswordPolicy(),
 	}
 }
 
@@ -111,16 +138,79 @@ func NewAuthHandler(
 func (h *AuthHandler) WithTimeFunc(timeFunc func() time.Time) *AuthHandler {
 	// Create a new handler that's a copy of the current one
 	newHandler := &AuthHandler{
-		userStore:        h.userStore,
-		jwtService:       h.jwtService,
-		passwordVerifier: h.passwordVerifier,
-		authConfig:       h.authConfig,
-		timeFunc:         timeFunc, // Set the new time function
-		logger:           h.logger,
+		userStore:         h.userStore,
+		jwtService:        h.jwtService,
+		passwordVerifier:  h.passwordVerifier,
+		authConfig:        h.authConfig,
+		timeFunc:          timeFunc, // Set the new time function
+		logger:            h.logger,
+		deckService:       h.deckService,
+		loginThrottle:     h.loginThrottle,
+		eventEmitter:      h.eventEmitter,
+		passwordPolicy:    h.passwordPolicy,
+		passwordHasher:    h.passwordHasher,
+		dummyPasswordHash: h.dummyPasswordHash,
 	}
 	return newHandler
 }
 
+// WithDefaultDecks returns a new AuthHandler that creates a deck named
+// domain.DefaultDeckName for every user it registers.
+// Like WithTimeFunc, the original handler remains unchanged.
+func (h *AuthHandler) WithDefaultDecks(deckService service.DeckService) *AuthHandler {
+	newHandler := *h
+	newHandler.deckService = deckService
+	return &newHandler
+}
+
+// WithLoginThrottle returns a new AuthHandler that refuses logins for emails
+// the throttle has locked out after repeated failures.
+// Like WithTimeFunc, the original handler remains unchanged.
+func (h *AuthHandler) WithLoginThrottle(throttle *auth.LoginThrottle) *AuthHandler {
+	newHandler := *h
+	newHandler.loginThrottle = throttle
+	return &newHandler
+}
+
+// WithEventEmitter returns a new AuthHandler that publishes account events,
+// such as a new user's email verification request, to emitter.
+// Like WithTimeFunc, the original handler remains unchanged.
+func (h *AuthHandler) WithEventEmitter(emitter events.EventEmitter) *AuthHandler {
+	newHandler := *h
+	newHandler.eventEmitter = emitter
+	return &newHandler
+}
+
+// WithPasswordPolicy returns a new AuthHandler that enforces policy on the
+// passwords of new users and password resets, instead of
+// domain.DefaultPasswordPolicy.
+// Like WithTimeFunc, the original handler remains unchanged.
+func (h *AuthHandler) WithPasswordPolicy(policy domain.PasswordPolicy) *AuthHandler {
+	newHandler := *h
+	newHandler.passwordPolicy = policy
+	return &newHandler
+}
+
+// WithPasswordHasher returns a new AuthHandler that rehashes the password of a
+// login with hasher when the stored hash was made with another scheme or a
+// lower cost than configured. Without a hasher, hashes are never upgraded.
+// Like WithTimeFunc, the original handler remains unchanged.
+func (h *AuthHandler) WithPasswordHasher(hasher auth.PasswordHasher) *AuthHandler {
+	newHandler := *h
+	newHandler.passwordHasher = hasher
+	return &newHandler
+}
+
+// WithDummyPasswordHash returns a new AuthHandler that checks the password of
+// logins for unknown emails against hash, made by auth.NewDummyPasswordHash
+// with the configured scheme, so they take as long as wrong passwords.
+// Like WithTimeFunc, the original handler remains unchanged.
+func (h *AuthHandler) WithDummyPasswordHash(hash string) *AuthHandler {
+	newHandler := *h
+	newHandler.dummyPasswordHash = hash
+	return &newHandler
+}
+
 // Register handles the /auth/register endpoint.
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
@@ -132,11 +222,17 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate request
-	if err := shared.Validate.Struct(req); err != nil {
+	if err := shared.ValidateStruct(req); err != nil {
 		HandleValidationError(w, r, err)
 		return
 	}
 
+	// Reject passwords that break the policy, listing every rule they break
+	if err := h.passwordPolicy.Check(req.Password, req.Email); err != nil {
+		HandleAPIError(w, r, err, "Invalid password")
+		return
+	}
+
 	// Create user
 	user, err := domain.NewUser(req.Email, req.Password)
 	if err != nil {
@@ -144,12 +240,33 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Store user
+	// Reject emails that are already registered before attempting the insert
+	if err := service.EnsureEmailAvailable(r.Context(), h.userStore, user.Email, uuid.Nil); err != nil {
+		HandleAPIError(w, r, err, "Failed to create user")
+		return
+	}
+
+	// Store user; a concurrent registration for the same email is still caught
+	// by the unique constraint and surfaces as the same store.ErrEmailExists
 	if err := h.userStore.Create(r.Context(), user); err != nil {
 		HandleAPIError(w, r, err, "Failed to create user")
 		return
 	}
 
+	// The account already exists at this point, so a missing default deck is
+	// logged rather than failing registration; it is created again on demand
+	// when the user's first cards are generated.
+	if h.deckService != nil {
+		if _, err := h.deckService.EnsureDefaultDeck(r.Context(), user.ID); err != nil {
+			h.logger.Error("failed to create default deck",
+				slog.String("error", redact.Error(err)),
+				slog.String("user_id", user.ID.String()))
+		}
+	}
+
+	// Like the default deck, a lost verification email doesn't fail registration
+	h.requestEmailVerification(r.Context(), user)
+
 	// Generate tokens
 	accessToken, refreshToken, expiresAt, err := h.generateTokenResponse(r.Context(), user.ID)
 	if err != nil {
@@ -166,6 +283,251 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// requestEmailVerification issues an email verification token for a newly
+// registered user and publishes it for a notifier to deliver. Nothing is issued
+// if no event emitter is configured, since the token could never reach the user.
+// Failures are logged rather than returned.
+func (h *AuthHandler) requestEmailVerification(ctx context.Context, user *domain.User) {
+	if h.eventEmitter == nil {
+		return
+	}
+
+	log := h.logger.With(slog.String("user_id", user.ID.String()))
+
+	token, err := h.jwtService.GenerateEmailVerificationToken(ctx, user.ID)
+	if err != nil {
+		log.Error("failed to generate email verification token",
+			slog.String("error", redact.Error(err)))
+		return
+	}
+
+	lifetime := time.Duration(h.authConfig.EmailVerificationTokenLifetimeMinutes) * time.Minute
+	if lifetime <= 0 {
+		lifetime = auth.DefaultEmailVerificationTokenLifetime
+	}
+
+	event, err := events.NewEmailVerificationRequestedEvent(events.EmailVerificationRequestedEvent{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: h.timeFunc().Add(lifetime),
+	})
+	if err != nil {
+		log.Error("failed to create email verification event",
+			slog.String("error", redact.Error(err)))
+		return
+	}
+
+	if err := h.eventEmitter.EmitEvent(ctx, event); err != nil {
+		log.Error("failed to publish email verification event",
+			slog.String("error", redact.Error(err)))
+	}
+}
+
+// VerifyEmail handles the /auth/verify-email endpoint.
+// It marks the user named by a valid email verification token as verified.
+// Tokens are single-use: presenting one for an already verified user fails
+// with auth.ErrTokenAlreadyUsed.
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req VerifyEmailRequest
+
+	// Parse request
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	// Validate request
+	if err := shared.Validate.Struct(req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	claims, err := h.jwtService.ValidateEmailVerificationToken(r.Context(), req.Token)
+	if err != nil {
+		HandleAPIError(w, r, err, "Invalid verification token")
+		return
+	}
+
+	user, err := h.userStore.GetByID(r.Context(), claims.UserID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to verify email")
+		return
+	}
+
+	if user.EmailVerified {
+		HandleAPIError(w, r, auth.ErrTokenAlreadyUsed, "Verification token has already been used")
+		return
+	}
+
+	user.EmailVerified = true
+	if err := h.userStore.Update(r.Context(), user); err != nil {
+		HandleAPIError(w, r, err, "Failed to verify email")
+		return
+	}
+
+	h.logger.Info("email verified",
+		slog.String("user_id", user.ID.String()),
+		slog.String("token_id", claims.ID))
+
+	shared.RespondWithJSON(w, r, http.StatusOK, VerifyEmailResponse{
+		UserID:        user.ID,
+		EmailVerified: true,
+	})
+}
+
+// forgotPasswordMessage is the response to every forgot password request, so
+// that responses don't reveal which emails belong to accounts.
+const forgotPasswordMessage = "If an account exists for that email, a password reset link has been sent"
+
+// ForgotPassword handles the /auth/forgot-password endpoint.
+// If the email belongs to an account, it issues a password reset token and
+// publishes it for a notifier to deliver. The response is the same whether or
+// not the account exists, to avoid revealing which emails are registered.
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req ForgotPasswordRequest
+
+	// Parse request
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	// Validate request
+	if err := shared.Validate.Struct(req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	h.requestPasswordReset(r.Context(), req.Email)
+
+	shared.RespondWithJSON(w, r, http.StatusOK, MessageResponse{Message: forgotPasswordMessage})
+}
+
+// requestPasswordReset issues a password reset token for the user with the
+// given email, if any, and publishes it for a notifier to deliver. Failures
+// are logged rather than returned, since ForgotPassword never reports them.
+func (h *AuthHandler) requestPasswordReset(ctx context.Context, email string) {
+	if h.eventEmitter == nil {
+		h.logger.Warn("password reset requested but no event emitter is configured to deliver it")
+		return
+	}
+
+	user, err := h.userStore.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			h.logger.Debug("password reset requested for unknown email")
+		} else {
+			h.logger.Error("failed to look up user for password reset",
+				slog.String("error", redact.Error(err)))
+		}
+		return
+	}
+
+	log := h.logger.With(slog.String("user_id", user.ID.String()))
+
+	fingerprint := auth.PasswordFingerprint(user.HashedPassword)
+	token, err := h.jwtService.GeneratePasswordResetToken(ctx, user.ID, fingerprint)
+	if err != nil {
+		log.Error("failed to generate password reset token",
+			slog.String("error", redact.Error(err)))
+		return
+	}
+
+	lifetime := time.Duration(h.authConfig.PasswordResetTokenLifetimeMinutes) * time.Minute
+	if lifetime <= 0 {
+		lifetime = auth.DefaultPasswordResetTokenLifetime
+	}
+
+	event, err := events.NewPasswordResetRequestedEvent(events.PasswordResetRequestedEvent{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: h.timeFunc().Add(lifetime),
+	})
+	if err != nil {
+		log.Error("failed to create password reset event",
+			slog.String("error", redact.Error(err)))
+		return
+	}
+
+	if err := h.eventEmitter.EmitEvent(ctx, event); err != nil {
+		log.Error("failed to publish password reset event",
+			slog.String("error", redact.Error(err)))
+		return
+	}
+
+	log.Info("password reset requested")
+}
+
+// ResetPassword handles the /auth/reset-password endpoint.
+// It sets a new password for the user named by a valid password reset token
+// and revokes the user's existing sessions. Tokens are single-use: once the
+// password has changed, the token no longer matches it and fails with
+// auth.ErrTokenAlreadyUsed.
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+
+	// Parse request
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	// Validate request
+	if err := shared.Validate.Struct(req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	claims, err := h.jwtService.ValidatePasswordResetToken(r.Context(), req.Token)
+	if err != nil {
+		HandleAPIError(w, r, err, "Invalid reset token")
+		return
+	}
+
+	user, err := h.userStore.GetByID(r.Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			err = auth.ErrInvalidToken
+		}
+		HandleAPIError(w, r, err, "Invalid reset token")
+		return
+	}
+
+	// The token was issued for the password hash it fingerprints; any change
+	// since, including a reset with this token, means it has been used up
+	if claims.Fingerprint != auth.PasswordFingerprint(user.HashedPassword) {
+		HandleAPIError(w, r, auth.ErrTokenAlreadyUsed, "Reset token has already been used")
+		return
+	}
+
+	if err := h.passwordPolicy.Check(req.Password, user.Email); err != nil {
+		HandleAPIError(w, r, err, "Invalid password")
+		return
+	}
+
+	// The store hashes the new password; sessions issued before now are revoked
+	changedAt := h.timeFunc().UTC()
+	user.Password = req.Password
+	user.PasswordChangedAt = &changedAt
+	if err := h.userStore.Update(r.Context(), user); err != nil {
+		HandleAPIError(w, r, err, "Failed to reset password")
+		return
+	}
+
+	// Whoever reset the password may log in straight away
+	if h.loginThrottle != nil {
+		h.loginThrottle.Reset(user.Email)
+	}
+
+	h.logger.Info("password reset",
+		slog.String("user_id", user.ID.String()),
+		slog.String("token_id", claims.ID))
+
+	shared.RespondWithJSON(w, r, http.StatusOK, MessageResponse{Message: "Password has been reset"})
+}
+
 // RefreshToken handles the /auth/refresh endpoint.
 // It validates a refresh token and issues a new access + refresh token pair.
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
@@ -193,6 +555,28 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	// Extract user ID from claims
 	userID := claims.UserID
 
+	// Refresh tokens of deleted users, and those issued before the user's
+	// password was last changed or reset, are revoked
+	user, err := h.userStore.GetByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			err = auth.ErrInvalidRefreshToken
+		}
+		HandleAPIError(w, r, err, "Invalid refresh token")
+		return
+	}
+	if user.SessionRevoked(claims.IssuedAt) {
+		h.logger.Debug("refresh token revoked by password change",
+			slog.String("user_id", userID.String()),
+			slog.String("token_id", claims.ID))
+		HandleAPIError(w, r, auth.ErrInvalidRefreshToken, "Invalid refresh token")
+		return
+	}
+	if user.IsDisabled() {
+		HandleAPIError(w, r, auth.ErrAccountDisabled, "Account is disabled")
+		return
+	}
+
 	// Log successful refresh token validation
 	h.logger.Debug("refresh token validated successfully",
 		slog.String("user_id", userID.String()),
@@ -224,18 +608,29 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate request
-	if err := shared.Validate.Struct(req); err != nil {
+	if err := shared.ValidateStruct(req); err != nil {
 		HandleValidationError(w, r, err)
 		return
 	}
 
+	// Refuse emails locked out after repeated failures, before doing any work
+	// that could reveal whether the account exists
+	if h.loginThrottle != nil {
+		if retryAfter, err := h.loginThrottle.Check(req.Email); err != nil {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			HandleAPIError(w, r, err, "", shared.WithElevatedLogLevel())
+			return
+		}
+	}
+
 	// Get user by email
 	user, err := h.userStore.GetByEmail(r.Context(), req.Email)
 	if err != nil {
 		if errors.Is(err, store.ErrUserNotFound) {
-			// Use generic error message for security (don't reveal if email exists)
-			// Elevate to WARN level as repeated auth failures are operationally important
-			HandleAPIError(w, r, err, "Invalid credentials", shared.WithElevatedLogLevel())
+			// Check the password anyway so that unknown emails take as long as
+			// wrong passwords, and don't reveal which emails have accounts
+			_ = h.passwordVerifier.Compare(h.dummyPasswordHash, req.Password)
+			h.loginFailed(w, r, req.Email, err)
 			return
 		}
 		HandleAPIError(w, r, err, "Failed to authenticate user")
@@ -244,12 +639,23 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	// Verify password using the injected verifier
 	if err := h.passwordVerifier.Compare(user.HashedPassword, req.Password); err != nil {
-		// Use same generic error message as above for security
-		// Elevate to WARN level as repeated auth failures are operationally important
-		HandleAPIError(w, r, err, "Invalid credentials", shared.WithElevatedLogLevel())
+		h.loginFailed(w, r, req.Email, err)
+		return
+	}
+
+	if h.loginThrottle != nil {
+		h.loginThrottle.Reset(req.Email)
+	}
+
+	// Disabled accounts are only reported once the password is verified, so
+	// the response doesn't reveal account state to anyone without it
+	if user.IsDisabled() {
+		HandleAPIError(w, r, auth.ErrAccountDisabled, "Account is disabled")
 		return
 	}
 
+	h.upgradePasswordHash(r.Context(), user, req.Password)
+
 	// Generate tokens
 	accessToken, refreshToken, expiresAt, err := h.generateTokenResponse(r.Context(), user.ID)
 	if err != nil {
@@ -265,3 +671,53 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		ExpiresAt:    expiresAt,
 	})
 }
+
+// upgradePasswordHash rehashes the user's password if it was stored with a
+// different scheme than the configured one, or with a lower bcrypt cost.
+// password must already have been verified. Only the hash is written, and only
+// if it has not changed since user was read, so a password changed meanwhile
+// is kept. Failing to upgrade is logged but does not fail the login; the
+// upgrade is simply retried on the next one.
+func (h *AuthHandler) upgradePasswordHash(ctx context.Context, user *domain.User, password string) {
+	if h.passwordHasher == nil {
+		return
+	}
+	cost := h.authConfig.BCryptCost
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	if !auth.NeedsRehash(user.HashedPassword, h.authConfig.PasswordScheme, cost) {
+		return
+	}
+
+	upgraded, err := h.passwordHasher.Hash(password)
+	if err == nil {
+		err = h.userStore.UpdatePasswordHash(ctx, user.ID, user.HashedPassword, upgraded)
+	}
+	if err != nil {
+		h.logger.Warn("failed to upgrade password hash",
+			slog.String("user_id", user.ID.String()),
+			slog.String("error", redact.Error(err)))
+		return
+	}
+
+	h.logger.Info("upgraded password hash",
+		slog.String("user_id", user.ID.String()),
+		slog.String("password_scheme", h.authConfig.PasswordScheme),
+		slog.Int("bcrypt_cost", cost))
+}
+
+// loginFailed records a failed login and responds with the same generic error
+// whether the email or the password was wrong, for security.
+func (h *AuthHandler) loginFailed(w http.ResponseWriter, r *http.Request, email string, err error) {
+	if h.loginThrottle != nil {
+		h.loginThrottle.RecordFailure(email)
+	}
+
+	// Elevate to WARN level as repeated auth failures are operationally important
+	HandleAPIError(w, r, domain.NewDomainError(
+		domain.CodeInvalidCredentials,
+		"Invalid credentials",
+		fmt.Errorf("%w: %w", domain.ErrUnauthorized, err),
+	), "", shared.WithElevatedLogLevel())
+}
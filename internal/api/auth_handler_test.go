@@ -8,18 +8,22 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
 	"github.com/phrazzld/scry-api/internal/config"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
 	"github.com/phrazzld/scry-api/internal/mocks"
 	"github.com/phrazzld/scry-api/internal/service/auth"
 	"github.com/phrazzld/scry-api/internal/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // TestAuthHandler_Register tests the Register handler functionality.
@@ -109,7 +113,28 @@ func TestAuthHandler_Register(t *testing.T) {
 				Password: "securePassword123",
 			},
 			setupMocks: func(us *mocks.MockUserStore, js *mocks.MockJWTService, pv *mocks.MockPasswordVerifier) {
-				// Simulate existing email error
+				// Email is found by the pre-check, so Create is never reached
+				us.Users["existing@example.com"] = &domain.User{
+					ID:    uuid.New(),
+					Email: "existing@example.com",
+				}
+				us.CreateFn = func(ctx context.Context, user *domain.User) error {
+					t.Error("Create should not be called when the email is already registered")
+					return nil
+				}
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "Email already exists",
+			wantTokens:     false,
+		},
+		{
+			name: "email_claimed_concurrently",
+			requestBody: RegisterRequest{
+				Email:    "existing@example.com",
+				Password: "securePassword123",
+			},
+			setupMocks: func(us *mocks.MockUserStore, js *mocks.MockJWTService, pv *mocks.MockPasswordVerifier) {
+				// Pre-check passes but the unique constraint rejects the insert
 				us.CreateFn = func(ctx context.Context, user *domain.User) error {
 					return store.ErrEmailExists
 				}
@@ -134,6 +159,20 @@ func TestAuthHandler_Register(t *testing.T) {
 			expectedBody:   "Failed to create user",
 			wantTokens:     false,
 		},
+		{
+			name: "email_check_error",
+			requestBody: RegisterRequest{
+				Email:    "valid@example.com",
+				Password: "securePassword123",
+			},
+			setupMocks: func(us *mocks.MockUserStore, js *mocks.MockJWTService, pv *mocks.MockPasswordVerifier) {
+				// Simulate database error during the email pre-check
+				us.GetByEmailError = errors.New("database connection error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to create user",
+			wantTokens:     false,
+		},
 		{
 			name: "token_generation_error",
 			requestBody: RegisterRequest{
@@ -340,8 +379,9 @@ func TestAuthHandler_Login(t *testing.T) {
 					return nil, store.ErrUserNotFound
 				}
 			},
-			expectedStatus: http.StatusNotFound,
-			expectedBody:   "User not found",
+			// Unknown emails get the same response as wrong passwords
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Invalid credentials",
 			wantTokens:     false,
 		},
 		{
@@ -368,7 +408,7 @@ func TestAuthHandler_Login(t *testing.T) {
 				// Password comparison will fail
 				pv.ShouldSucceed = false
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   "Invalid credentials",
 			wantTokens:     false,
 		},
@@ -591,6 +631,7 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 			// Create mocks - only need JWT service for this endpoint
 			mockJWTService := &mocks.MockJWTService{}
 			mockUserStore := mocks.NewMockUserStore()
+			mockUserStore.Users["user@example.com"] = &domain.User{ID: fixedUserID, Email: "user@example.com"}
 			mockPasswordVerifier := &mocks.MockPasswordVerifier{}
 
 			// Configure mocks based on test case
@@ -1012,3 +1053,738 @@ func TestAuthHandler_NewAuthHandler(t *testing.T) {
 
 	})
 }
+
+// TestAuthHandler_Register_DefaultDeck tests that registration creates a default
+// deck when enabled, and that failing to create it does not fail registration.
+func TestAuthHandler_Register_DefaultDeck(t *testing.T) {
+	authConfig := &config.AuthConfig{
+		JWTSecret:                   "test-secret",
+		TokenLifetimeMinutes:        60,
+		RefreshTokenLifetimeMinutes: 1440,
+	}
+
+	register := func(t *testing.T, handler *AuthHandler) *httptest.ResponseRecorder {
+		t.Helper()
+		jsonBody, err := json.Marshal(RegisterRequest{
+			Email:    "newuser@example.com",
+			Password: "securePassword123",
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewReader(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.Register(w, req)
+		return w
+	}
+
+	newHandler := func() *AuthHandler {
+		return NewAuthHandler(
+			mocks.NewMockUserStore(),
+			&mocks.MockJWTService{Token: "access", RefreshToken: "refresh"},
+			&mocks.MockPasswordVerifier{ShouldSucceed: true},
+			authConfig,
+			slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+		)
+	}
+
+	t.Run("creates_default_deck_for_new_user", func(t *testing.T) {
+		var deckUserIDs []uuid.UUID
+		handler := newHandler().WithDefaultDecks(&MockDeckService{
+			EnsureDefaultDeckFn: func(ctx context.Context, userID uuid.UUID) (*domain.Deck, error) {
+				deckUserIDs = append(deckUserIDs, userID)
+				return domain.NewDeck(userID, domain.DefaultDeckName)
+			},
+		})
+
+		w := register(t, handler)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var resp AuthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, []uuid.UUID{resp.UserID}, deckUserIDs)
+	})
+
+	t.Run("deck_failure_does_not_fail_registration", func(t *testing.T) {
+		handler := newHandler().WithDefaultDecks(&MockDeckService{
+			EnsureDefaultDeckFn: func(ctx context.Context, userID uuid.UUID) (*domain.Deck, error) {
+				return nil, errors.New("database unavailable")
+			},
+		})
+
+		w := register(t, handler)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("disabled_by_default", func(t *testing.T) {
+		handler := newHandler()
+		assert.Nil(t, handler.deckService)
+
+		w := register(t, handler)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+}
+
+// TestAuthHandler_Register_ReportsAllInvalidFields tests that a request with
+// several invalid fields lists all of them, not just the first.
+func TestAuthHandler_Register_ReportsAllInvalidFields(t *testing.T) {
+	handler := NewAuthHandler(
+		mocks.NewMockUserStore(),
+		&mocks.MockJWTService{Token: "access", RefreshToken: "refresh"},
+		&mocks.MockPasswordVerifier{ShouldSucceed: true},
+		&config.AuthConfig{JWTSecret: "test-secret", TokenLifetimeMinutes: 60, RefreshTokenLifetimeMinutes: 1440},
+		slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+	)
+
+	body := `{"email":"not-an-email","password":"short"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.Register(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp shared.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, domain.CodeValidationFailed, resp.Code)
+	assert.Equal(t, "Invalid Email: invalid email format", resp.Error, "the summary names the first field")
+	assert.Equal(t, []shared.FieldError{
+		{Field: "email", Message: "invalid email format"},
+		{Field: "password", Message: "too short"},
+	}, resp.Errors)
+}
+
+// TestAuthHandler_Register_PasswordPolicy tests that registration enforces the
+// configured password policy and reports every rule a password breaks.
+func TestAuthHandler_Register_PasswordPolicy(t *testing.T) {
+	newHandler := func(userStore *mocks.MockUserStore) *AuthHandler {
+		return NewAuthHandler(
+			userStore,
+			&mocks.MockJWTService{Token: "access", RefreshToken: "refresh"},
+			&mocks.MockPasswordVerifier{ShouldSucceed: true},
+			&config.AuthConfig{JWTSecret: "test-secret", TokenLifetimeMinutes: 60, RefreshTokenLifetimeMinutes: 1440},
+			slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+		).WithPasswordPolicy(domain.PasswordPolicy{MinLength: 16, MinStrength: 3})
+	}
+	register := func(handler *AuthHandler, password string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(RegisterRequest{Email: "policy@example.com", Password: password})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.Register(w, req)
+		return w
+	}
+
+	t.Run("weak password is rejected", func(t *testing.T) {
+		userStore := mocks.NewMockUserStore()
+		w := register(newHandler(userStore), "password1234")
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var resp shared.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, domain.CodeValidationFailed, resp.Code)
+		assert.Contains(t, resp.Error, "at least 16 characters")
+		assert.Contains(t, resp.Error, "too easy to guess")
+		assert.Empty(t, userStore.Users, "No user should be created")
+	})
+
+	t.Run("strong password is accepted", func(t *testing.T) {
+		w := register(newHandler(mocks.NewMockUserStore()), "vT8#qLm2!zRw9pXy")
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+}
+
+// TestAuthHandler_Login_Throttle tests that repeated failed logins lock an email
+// out, whether or not it has an account, and that a successful login resets it.
+func TestAuthHandler_Login_Throttle(t *testing.T) {
+	fixedTime := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+	now := fixedTime
+	testEmail := "user@example.com"
+	testPassword := "securePassword123"
+
+	mockUserStore := mocks.NewLoginMockUserStore(uuid.New(), testEmail, "hashed-password")
+	mockJWTService := &mocks.MockJWTService{Token: "access-token", RefreshToken: "refresh-token"}
+	mockPasswordVerifier := &mocks.MockPasswordVerifier{
+		CompareFn: func(hashedPassword, password string) error {
+			if hashedPassword == "hashed-password" && password == testPassword {
+				return nil
+			}
+			return errors.New("password mismatch")
+		},
+	}
+	authConfig := &config.AuthConfig{
+		JWTSecret:                   "test-secret",
+		TokenLifetimeMinutes:        60,
+		RefreshTokenLifetimeMinutes: 1440,
+	}
+
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil))
+	throttle := auth.NewLoginThrottle(3, 15*time.Minute, 5*time.Minute).
+		WithTimeFunc(func() time.Time { return now })
+	handler := NewAuthHandler(mockUserStore, mockJWTService, mockPasswordVerifier, authConfig, logger).
+		WithTimeFunc(func() time.Time { return fixedTime }).
+		WithLoginThrottle(throttle)
+
+	login := func(email, password string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(LoginRequest{Email: email, Password: password})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.Login(w, req)
+		return w
+	}
+
+	errorCode := func(w *httptest.ResponseRecorder) domain.ErrorCode {
+		var resp shared.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Code
+	}
+
+	t.Run("bad passwords lock the email out", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			w := login(testEmail, "wrong-password")
+			require.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, domain.CodeInvalidCredentials, errorCode(w))
+		}
+
+		// Even the right password is refused while locked out
+		compares := mockPasswordVerifier.CompareCallCount
+		w := login(testEmail, testPassword)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, domain.CodeLoginLocked, errorCode(w))
+		assert.Equal(t, "300", w.Header().Get("Retry-After"))
+		assert.Equal(t, compares, mockPasswordVerifier.CompareCallCount, "Locked out logins should not check passwords")
+	})
+
+	t.Run("successful login resets the failures", func(t *testing.T) {
+		now = now.Add(5 * time.Minute)
+
+		login(testEmail, "wrong-password")
+		login(testEmail, "wrong-password")
+		require.Equal(t, http.StatusOK, login(testEmail, testPassword).Code)
+
+		// Two more failures do not lock the email out again
+		login(testEmail, "wrong-password")
+		login(testEmail, "wrong-password")
+		assert.Equal(t, http.StatusOK, login(testEmail, testPassword).Code)
+	})
+
+	t.Run("unknown emails are throttled and indistinguishable", func(t *testing.T) {
+		unknownEmail := "nobody@example.com"
+
+		for i := 0; i < 3; i++ {
+			compares := mockPasswordVerifier.CompareCallCount
+			w := login(unknownEmail, "any-password")
+			require.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, domain.CodeInvalidCredentials, errorCode(w))
+			assert.Equal(t, compares+1, mockPasswordVerifier.CompareCallCount,
+				"A password should be checked for unknown emails too")
+		}
+
+		assert.Equal(t, http.StatusTooManyRequests, login(unknownEmail, "any-password").Code)
+	})
+}
+
+// TestAuthHandler_Login_DisabledUser tests that disabled users cannot log in
+// with the right password, and that the wrong one doesn't reveal the account state
+func TestAuthHandler_Login_DisabledUser(t *testing.T) {
+	testEmail := "disabled@example.com"
+	testPassword := "securePassword123"
+	disabledAt := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+	userStore := mocks.NewMockUserStore()
+	userStore.Users[testEmail] = &domain.User{
+		ID:             uuid.New(),
+		Email:          testEmail,
+		HashedPassword: "hashed-password",
+		DisabledAt:     &disabledAt,
+	}
+	handler := NewAuthHandler(
+		userStore,
+		&mocks.MockJWTService{Token: "access-token", RefreshToken: "refresh-token"},
+		&mocks.MockPasswordVerifier{
+			CompareFn: func(hashedPassword, password string) error {
+				if password == testPassword {
+					return nil
+				}
+				return errors.New("password mismatch")
+			},
+		},
+		&config.AuthConfig{
+			JWTSecret:                   "test-secret",
+			TokenLifetimeMinutes:        60,
+			RefreshTokenLifetimeMinutes: 1440,
+		},
+		slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+	)
+
+	tests := []struct {
+		name           string
+		password       string
+		expectedStatus int
+		expectedCode   domain.ErrorCode
+	}{
+		{"right password", testPassword, http.StatusForbidden, domain.CodeAccountDisabled},
+		{"wrong password", "wrongPassword123", http.StatusUnauthorized, domain.CodeInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(LoginRequest{Email: testEmail, Password: tt.password})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			handler.Login(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			var resp shared.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.NotContains(t, w.Body.String(), "access-token")
+		})
+	}
+}
+
+// passwordHashUpdate records a call to UserStore.UpdatePasswordHash
+type passwordHashUpdate struct {
+	id               uuid.UUID
+	oldHash, newHash string
+}
+
+// TestAuthHandler_Login_RehashesPassword tests that passwords stored with a
+// bcrypt cost below the configured one are rehashed on successful login, and
+// that only the hash is written, guarded by the hash that was verified.
+func TestAuthHandler_Login_RehashesPassword(t *testing.T) {
+	testEmail := "user@example.com"
+	testPassword := "securePassword123"
+	const configuredCost = 10
+
+	login := func(t *testing.T, storedCost int) (*httptest.ResponseRecorder, string, []passwordHashUpdate) {
+		t.Helper()
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), storedCost)
+		require.NoError(t, err)
+
+		var updates []passwordHashUpdate
+		userStore := mocks.NewMockUserStore()
+		userStore.Users[testEmail] = &domain.User{
+			ID:             uuid.New(),
+			Email:          testEmail,
+			HashedPassword: string(hash),
+		}
+		userStore.UpdateFn = func(ctx context.Context, user *domain.User) error {
+			t.Error("Login should not rewrite the whole user")
+			return nil
+		}
+		userStore.UpdatePasswordHashFn = func(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+			updates = append(updates, passwordHashUpdate{id: id, oldHash: oldHash, newHash: newHash})
+			return nil
+		}
+
+		authConfig := &config.AuthConfig{
+			JWTSecret:                   "test-secret",
+			BCryptCost:                  configuredCost,
+			TokenLifetimeMinutes:        60,
+			RefreshTokenLifetimeMinutes: 1440,
+		}
+		handler := NewAuthHandler(
+			userStore,
+			&mocks.MockJWTService{Token: "access-token", RefreshToken: "refresh-token"},
+			auth.NewBcryptVerifier(),
+			authConfig,
+			slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+		).WithPasswordHasher(auth.NewBcryptVerifierWithCost(configuredCost))
+
+		body, err := json.Marshal(LoginRequest{Email: testEmail, Password: testPassword})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.Login(w, req)
+		return w, string(hash), updates
+	}
+
+	t.Run("low-cost hash is upgraded", func(t *testing.T) {
+		w, oldHash, updates := login(t, bcrypt.MinCost)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, updates, 1)
+		assert.Equal(t, oldHash, updates[0].oldHash, "The update should be guarded by the verified hash")
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(updates[0].newHash), []byte(testPassword)),
+			"The new hash should be of the verified password")
+		cost, err := bcrypt.Cost([]byte(updates[0].newHash))
+		require.NoError(t, err)
+		assert.Equal(t, configuredCost, cost)
+	})
+
+	t.Run("current-cost hash is unchanged", func(t *testing.T) {
+		w, _, updates := login(t, configuredCost)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, updates)
+	})
+
+	t.Run("failed upgrade does not fail the login", func(t *testing.T) {
+		hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		userStore := mocks.NewLoginMockUserStore(uuid.New(), testEmail, string(hash))
+		handler := NewAuthHandler(
+			&failingUpdateUserStore{UserStore: userStore},
+			&mocks.MockJWTService{Token: "access-token", RefreshToken: "refresh-token"},
+			auth.NewBcryptVerifier(),
+			&config.AuthConfig{BCryptCost: configuredCost, TokenLifetimeMinutes: 60, RefreshTokenLifetimeMinutes: 1440},
+			slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+		).WithPasswordHasher(auth.NewBcryptVerifierWithCost(configuredCost))
+
+		body, err := json.Marshal(LoginRequest{Email: testEmail, Password: testPassword})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.Login(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+// TestAuthHandler_Login_UnknownEmailChecksDummyHash tests that the password of
+// a login for an unknown email is checked against the configured dummy hash
+func TestAuthHandler_Login_UnknownEmailChecksDummyHash(t *testing.T) {
+	authConfig := &config.AuthConfig{
+		JWTSecret:                   "test-secret",
+		PasswordScheme:              auth.SchemeArgon2id,
+		TokenLifetimeMinutes:        60,
+		RefreshTokenLifetimeMinutes: 1440,
+	}
+	scheme, err := auth.NewPasswordScheme(authConfig)
+	require.NoError(t, err)
+	dummy, err := auth.NewDummyPasswordHash(scheme)
+	require.NoError(t, err)
+
+	verifier := &mocks.MockPasswordVerifier{}
+	handler := NewAuthHandler(
+		mocks.NewMockUserStore(),
+		&mocks.MockJWTService{Token: "access-token", RefreshToken: "refresh-token"},
+		verifier,
+		authConfig,
+		slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+	).WithDummyPasswordHash(dummy)
+
+	body, err := json.Marshal(LoginRequest{Email: "nobody@example.com", Password: "securePassword123"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.Login(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, 1, verifier.CompareCallCount, "A password should be checked for unknown emails too")
+	assert.Equal(t, dummy, verifier.CompareCalledWith.HashedPassword)
+	assert.False(t,
+		auth.NeedsRehash(verifier.CompareCalledWith.HashedPassword, authConfig.PasswordScheme, bcrypt.DefaultCost),
+		"The dummy hash should use the configured scheme")
+}
+
+// failingUpdateUserStore is a UserStore whose updates always fail.
+type failingUpdateUserStore struct {
+	store.UserStore
+}
+
+func (s *failingUpdateUserStore) Update(ctx context.Context, user *domain.User) error {
+	return store.ErrUpdateFailed
+}
+
+func (s *failingUpdateUserStore) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	return store.ErrVersionConflict
+}
+
+// recordingEventEmitter records the events emitted to it
+type recordingEventEmitter struct {
+	events []*events.TaskRequestEvent
+}
+
+func (e *recordingEventEmitter) EmitEvent(ctx context.Context, event *events.TaskRequestEvent) error {
+	e.events = append(e.events, event)
+	return nil
+}
+
+func TestAuthHandler_VerifyEmail(t *testing.T) {
+	testEmail := "user@example.com"
+	testPassword := "securePassword123"
+
+	// setup registers a user and returns a handler sharing its clock with the
+	// JWT service, the store, and the verification token sent on registration
+	setup := func(t *testing.T) (*AuthHandler, *mocks.MockUserStore, *time.Time, string) {
+		t.Helper()
+
+		now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+
+		userStore := mocks.NewMockUserStore()
+		emitter := &recordingEventEmitter{}
+		handler := NewAuthHandler(
+			userStore,
+			auth.NewTestJWTService("test-secret-that-is-32-chars-long!", time.Hour, clock),
+			auth.NewBcryptVerifier(),
+			&config.AuthConfig{
+				TokenLifetimeMinutes:                  60,
+				RefreshTokenLifetimeMinutes:           1440,
+				EmailVerificationTokenLifetimeMinutes: 1440,
+			},
+			slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+		).WithTimeFunc(clock).WithEventEmitter(emitter)
+
+		body, err := json.Marshal(RegisterRequest{Email: testEmail, Password: testPassword})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.Register(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		require.Len(t, emitter.events, 1, "Registration should request email verification")
+		event := emitter.events[0]
+		assert.Equal(t, events.EventTypeEmailVerificationRequested, event.Type)
+
+		var payload events.EmailVerificationRequestedEvent
+		require.NoError(t, event.UnmarshalPayload(&payload))
+		assert.Equal(t, testEmail, payload.Email)
+		assert.Equal(t, userStore.LastUserID, payload.UserID)
+		assert.Equal(t, now.Add(24*time.Hour), payload.ExpiresAt)
+		require.NotEmpty(t, payload.Token)
+
+		user, err := userStore.GetByEmail(context.Background(), testEmail)
+		require.NoError(t, err)
+		assert.False(t, user.EmailVerified, "New users should start unverified")
+
+		return handler, userStore, &now, payload.Token
+	}
+
+	verify := func(handler *AuthHandler, token string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(VerifyEmailRequest{Token: token})
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/verify-email", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.VerifyEmail(w, req)
+		return w
+	}
+
+	decodeError := func(t *testing.T, w *httptest.ResponseRecorder) shared.ErrorResponse {
+		t.Helper()
+		var resp shared.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	t.Run("valid token verifies the user", func(t *testing.T) {
+		handler, userStore, _, token := setup(t)
+
+		w := verify(handler, token)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp VerifyEmailResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, userStore.LastUserID, resp.UserID)
+		assert.True(t, resp.EmailVerified)
+
+		user, err := userStore.GetByEmail(context.Background(), testEmail)
+		require.NoError(t, err)
+		assert.True(t, user.EmailVerified)
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		handler, userStore, now, token := setup(t)
+		*now = now.Add(25 * time.Hour)
+
+		w := verify(handler, token)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, domain.CodeTokenExpired, decodeError(t, w).Code)
+
+		user, err := userStore.GetByEmail(context.Background(), testEmail)
+		require.NoError(t, err)
+		assert.False(t, user.EmailVerified)
+	})
+
+	t.Run("reused token is rejected", func(t *testing.T) {
+		handler, _, _, token := setup(t)
+		require.Equal(t, http.StatusOK, verify(handler, token).Code)
+
+		w := verify(handler, token)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, domain.CodeTokenUsed, decodeError(t, w).Code)
+	})
+
+	t.Run("access token is rejected", func(t *testing.T) {
+		handler, _, _, _ := setup(t)
+		accessToken, err := handler.jwtService.GenerateToken(context.Background(), uuid.New())
+		require.NoError(t, err)
+
+		w := verify(handler, accessToken)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuthHandler_PasswordReset(t *testing.T) {
+	testEmail := "user@example.com"
+	oldPassword := "oldSecurePassword123"
+	newPassword := "newSecurePassword456"
+
+	type fixture struct {
+		handler   *AuthHandler
+		userStore *mocks.MockUserStore
+		emitter   *recordingEventEmitter
+		now       *time.Time
+	}
+
+	setup := func(t *testing.T) fixture {
+		t.Helper()
+
+		now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(oldPassword), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		// The mock store keeps users as given, so hash new passwords like the real one
+		userStore := mocks.NewMockUserStore()
+		userStore.Users[testEmail] = &domain.User{ID: uuid.New(), Email: testEmail, HashedPassword: string(hash)}
+		userStore.UpdateFn = func(ctx context.Context, user *domain.User) error {
+			if user.Password != "" {
+				hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.MinCost)
+				if err != nil {
+					return err
+				}
+				user.HashedPassword = string(hash)
+				user.Password = ""
+			}
+			userStore.Users[user.Email] = user
+			return nil
+		}
+
+		emitter := &recordingEventEmitter{}
+		handler := NewAuthHandler(
+			userStore,
+			auth.NewTestJWTService("test-secret-that-is-32-chars-long!", time.Hour, clock),
+			auth.NewBcryptVerifier(),
+			&config.AuthConfig{
+				TokenLifetimeMinutes:              60,
+				RefreshTokenLifetimeMinutes:       1440,
+				PasswordResetTokenLifetimeMinutes: 60,
+			},
+			slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil)),
+		).WithTimeFunc(clock).WithEventEmitter(emitter)
+
+		return fixture{handler: handler, userStore: userStore, emitter: emitter, now: &now}
+	}
+
+	post := func(handlerFunc http.HandlerFunc, path string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	// requestReset asks for a reset of testEmail and returns the token sent for it
+	requestReset := func(t *testing.T, f fixture) string {
+		t.Helper()
+
+		w := post(f.handler.ForgotPassword, "/api/auth/forgot-password", ForgotPasswordRequest{Email: testEmail})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, f.emitter.events, 1)
+
+		event := f.emitter.events[0]
+		assert.Equal(t, events.EventTypePasswordResetRequested, event.Type)
+		var payload events.PasswordResetRequestedEvent
+		require.NoError(t, event.UnmarshalPayload(&payload))
+		assert.Equal(t, testEmail, payload.Email)
+		assert.Equal(t, f.now.Add(time.Hour), payload.ExpiresAt)
+		require.NotEmpty(t, payload.Token)
+		return payload.Token
+	}
+
+	t.Run("request, confirm and log in", func(t *testing.T) {
+		f := setup(t)
+
+		// A session from before the reset
+		w := post(f.handler.Login, "/api/auth/login", LoginRequest{Email: testEmail, Password: oldPassword})
+		require.Equal(t, http.StatusOK, w.Code)
+		var oldSession AuthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&oldSession))
+
+		token := requestReset(t, f)
+		*f.now = f.now.Add(time.Minute)
+
+		w = post(f.handler.ResetPassword, "/api/auth/reset-password",
+			ResetPasswordRequest{Token: token, Password: newPassword})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = post(f.handler.Login, "/api/auth/login", LoginRequest{Email: testEmail, Password: oldPassword})
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "The old password should no longer work")
+
+		w = post(f.handler.Login, "/api/auth/login", LoginRequest{Email: testEmail, Password: newPassword})
+		require.Equal(t, http.StatusOK, w.Code, "The new password should work")
+		var newSession AuthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&newSession))
+
+		w = post(f.handler.RefreshToken, "/api/auth/refresh",
+			RefreshTokenRequest{RefreshToken: oldSession.RefreshToken})
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Sessions from before the reset should be revoked")
+
+		w = post(f.handler.RefreshToken, "/api/auth/refresh",
+			RefreshTokenRequest{RefreshToken: newSession.RefreshToken})
+		assert.Equal(t, http.StatusOK, w.Code, "Sessions from after the reset should be kept")
+	})
+
+	t.Run("reused token is rejected", func(t *testing.T) {
+		f := setup(t)
+		token := requestReset(t, f)
+
+		w := post(f.handler.ResetPassword, "/api/auth/reset-password",
+			ResetPasswordRequest{Token: token, Password: newPassword})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = post(f.handler.ResetPassword, "/api/auth/reset-password",
+			ResetPasswordRequest{Token: token, Password: "attackerPassword789"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		var resp shared.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, domain.CodeTokenUsed, resp.Code)
+
+		w = post(f.handler.Login, "/api/auth/login", LoginRequest{Email: testEmail, Password: newPassword})
+		assert.Equal(t, http.StatusOK, w.Code, "The first reset's password should still be in effect")
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		f := setup(t)
+		token := requestReset(t, f)
+		*f.now = f.now.Add(2 * time.Hour)
+
+		w := post(f.handler.ResetPassword, "/api/auth/reset-password",
+			ResetPasswordRequest{Token: token, Password: newPassword})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = post(f.handler.Login, "/api/auth/login", LoginRequest{Email: testEmail, Password: oldPassword})
+		assert.Equal(t, http.StatusOK, w.Code, "The password should be unchanged")
+	})
+
+	t.Run("unknown email gets the same response", func(t *testing.T) {
+		f := setup(t)
+
+		known := post(f.handler.ForgotPassword, "/api/auth/forgot-password",
+			ForgotPasswordRequest{Email: testEmail})
+		unknown := post(f.handler.ForgotPassword, "/api/auth/forgot-password",
+			ForgotPasswordRequest{Email: "nobody@example.com"})
+
+		assert.Equal(t, http.StatusOK, unknown.Code)
+		assert.Equal(t, known.Body.String(), unknown.Body.String())
+		assert.Len(t, f.emitter.events, 1, "Only the known email should be sent a token")
+	})
+}
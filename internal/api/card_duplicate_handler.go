@@ -0,0 +1,121 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/redact"
+	"github.com/phrazzld/scry-api/internal/service"
+)
+
+// DuplicateCardGroupResponse represents a group of cards with identical content
+type DuplicateCardGroupResponse struct {
+	ContentHash string         `json:"content_hash"`
+	Cards       []CardResponse `json:"cards"`
+}
+
+// MergeDuplicatesRequest represents the request body for merging duplicate cards
+type MergeDuplicatesRequest struct {
+	KeepCardID uuid.UUID   `json:"keep_card_id" validate:"required"`
+	CardIDs    []uuid.UUID `json:"card_ids"     validate:"required,min=1"`
+}
+
+// CardDuplicateHandler handles requests for reviewing and merging duplicate cards
+type CardDuplicateHandler struct {
+	duplicateService service.CardDuplicateService
+	logger           *slog.Logger
+}
+
+// NewCardDuplicateHandler creates a new CardDuplicateHandler
+func NewCardDuplicateHandler(
+	duplicateService service.CardDuplicateService,
+	logger *slog.Logger,
+) *CardDuplicateHandler {
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for CardDuplicateHandler")
+	}
+
+	return &CardDuplicateHandler{
+		duplicateService: duplicateService,
+		logger:           logger.With(slog.String("component", "card_duplicate_handler")),
+	}
+}
+
+// ListDuplicates handles GET /api/cards/duplicates requests
+// It returns the authenticated user's groups of cards with identical content.
+func (h *CardDuplicateHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	groups, err := h.duplicateService.FindDuplicates(r.Context(), userID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to find duplicate cards")
+		return
+	}
+
+	response := make([]DuplicateCardGroupResponse, 0, len(groups))
+	for _, group := range groups {
+		cards := make([]CardResponse, 0, len(group.Cards))
+		for _, card := range group.Cards {
+			cards = append(cards, cardToResponse(card))
+		}
+		response = append(response, DuplicateCardGroupResponse{
+			ContentHash: group.ContentHash,
+			Cards:       cards,
+		})
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// MergeDuplicates handles POST /api/cards/duplicates/merge requests
+// It keeps one card and deletes the listed duplicates of it.
+func (h *CardDuplicateHandler) MergeDuplicates(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	// Parse and validate request body
+	var req MergeDuplicatesRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+	if err := shared.Validate.Struct(req); err != nil {
+		log.Warn("validation error", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	err := h.duplicateService.MergeDuplicates(r.Context(), userID, req.KeepCardID, req.CardIDs)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to merge duplicate cards")
+		return
+	}
+
+	log.Debug("merged duplicate cards",
+		slog.String("user_id", userID.String()),
+		slog.String("kept_card_id", req.KeepCardID.String()))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCardDuplicateService is a mock implementation of service.CardDuplicateService for testing
+type MockCardDuplicateService struct {
+	FindDuplicatesFn  func(ctx context.Context, userID uuid.UUID) ([]*domain.DuplicateCardGroup, error)
+	MergeDuplicatesFn func(ctx context.Context, userID, keepCardID uuid.UUID, duplicateIDs []uuid.UUID) error
+}
+
+// FindDuplicates implements service.CardDuplicateService
+func (m *MockCardDuplicateService) FindDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*domain.DuplicateCardGroup, error) {
+	if m.FindDuplicatesFn != nil {
+		return m.FindDuplicatesFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+// MergeDuplicates implements service.CardDuplicateService
+func (m *MockCardDuplicateService) MergeDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+	keepCardID uuid.UUID,
+	duplicateIDs []uuid.UUID,
+) error {
+	if m.MergeDuplicatesFn != nil {
+		return m.MergeDuplicatesFn(ctx, userID, keepCardID, duplicateIDs)
+	}
+	return nil
+}
+
+var _ service.CardDuplicateService = (*MockCardDuplicateService)(nil)
+
+// TestCardDuplicateHandler_ListDuplicates tests the ListDuplicates handler.
+func TestCardDuplicateHandler_ListDuplicates(t *testing.T) {
+	userID := uuid.New()
+	memoID := uuid.New()
+	content := json.RawMessage(`{"front":"Q","back":"A"}`)
+	cardA := &domain.Card{ID: uuid.New(), UserID: userID, MemoID: memoID, Content: content}
+	cardB := &domain.Card{ID: uuid.New(), UserID: userID, MemoID: uuid.New(), Content: content}
+
+	t.Run("returns_groups", func(t *testing.T) {
+		handler := NewCardDuplicateHandler(&MockCardDuplicateService{
+			FindDuplicatesFn: func(ctx context.Context, id uuid.UUID) ([]*domain.DuplicateCardGroup, error) {
+				assert.Equal(t, userID, id)
+				return []*domain.DuplicateCardGroup{
+					{ContentHash: "abc123", Cards: []*domain.Card{cardA, cardB}},
+				}, nil
+			},
+		}, slog.Default())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/cards/duplicates", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		w := httptest.NewRecorder()
+
+		handler.ListDuplicates(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp []DuplicateCardGroupResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp, 1)
+		assert.Equal(t, "abc123", resp[0].ContentHash)
+		require.Len(t, resp[0].Cards, 2)
+		assert.Equal(t, cardA.ID.String(), resp[0].Cards[0].ID)
+		assert.Equal(t, cardB.ID.String(), resp[0].Cards[1].ID)
+	})
+
+	t.Run("empty_list", func(t *testing.T) {
+		handler := NewCardDuplicateHandler(&MockCardDuplicateService{}, slog.Default())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/cards/duplicates", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		w := httptest.NewRecorder()
+
+		handler.ListDuplicates(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("missing_user_id", func(t *testing.T) {
+		handler := NewCardDuplicateHandler(&MockCardDuplicateService{}, slog.Default())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/cards/duplicates", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListDuplicates(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+// TestCardDuplicateHandler_MergeDuplicates tests the MergeDuplicates handler.
+func TestCardDuplicateHandler_MergeDuplicates(t *testing.T) {
+	userID := uuid.New()
+	keepID := uuid.New()
+	dupID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		mergeErr       error
+		expectedStatus int
+		expectedErrMsg string
+	}{
+		{
+			name:           "success",
+			body:           `{"keep_card_id":"` + keepID.String() + `","card_ids":["` + dupID.String() + `"]}`,
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "missing_card_ids",
+			body:           `{"keep_card_id":"` + keepID.String() + `","card_ids":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid_uuid",
+			body:           `{"keep_card_id":"not-a-uuid","card_ids":["` + dupID.String() + `"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not_duplicates",
+			body:           `{"keep_card_id":"` + keepID.String() + `","card_ids":["` + dupID.String() + `"]}`,
+			mergeErr:       service.ErrCardsNotDuplicates,
+			expectedStatus: http.StatusConflict,
+			expectedErrMsg: "Cards do not have identical content",
+		},
+		{
+			name:           "card_not_owned",
+			body:           `{"keep_card_id":"` + keepID.String() + `","card_ids":["` + dupID.String() + `"]}`,
+			mergeErr:       card_review.ErrCardNotOwned,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var called bool
+			handler := NewCardDuplicateHandler(&MockCardDuplicateService{
+				MergeDuplicatesFn: func(ctx context.Context, uid, keep uuid.UUID, ids []uuid.UUID) error {
+					called = true
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, keepID, keep)
+					assert.Equal(t, []uuid.UUID{dupID}, ids)
+					return tc.mergeErr
+				},
+			}, slog.Default())
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/cards/duplicates/merge",
+				bytes.NewBufferString(tc.body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			w := httptest.NewRecorder()
+
+			handler.MergeDuplicates(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus == http.StatusBadRequest {
+				assert.False(t, called, "Service should not be called for invalid requests")
+			}
+			if tc.expectedErrMsg != "" {
+				var errResp shared.ErrorResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&errResp))
+				assert.Equal(t, tc.expectedErrMsg, errResp.Error)
+			}
+		})
+	}
+}
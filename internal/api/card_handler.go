@@ -6,6 +6,8 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -14,28 +16,44 @@ import (
 	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/platform/logger"
 	"github.com/phrazzld/scry-api/internal/redact"
+	"github.com/phrazzld/scry-api/internal/service"
 	"github.com/phrazzld/scry-api/internal/service/card_review"
 )
 
+const (
+	// DefaultCardListLimit is the number of cards returned per page when no limit is given
+	DefaultCardListLimit = 50
+
+	// MaxCardListLimit is the largest page size accepted for card listings
+	MaxCardListLimit = 200
+)
+
 // CardResponse represents the response data for a card
 type CardResponse struct {
-	ID        string      `json:"id"`
-	UserID    string      `json:"user_id"`
-	MemoID    string      `json:"memo_id"`
-	Content   interface{} `json:"content"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	ID          string      `json:"id"`
+	UserID      string      `json:"user_id"`
+	MemoID      string      `json:"memo_id"`
+	DeckID      *string     `json:"deck_id,omitempty"`
+	Type        string      `json:"type"`
+	Content     interface{} `json:"content"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	Version     int         `json:"version"`
+	SuspendedAt *time.Time  `json:"suspended_at,omitempty"`
+	BuriedUntil *time.Time  `json:"buried_until,omitempty"`
 }
 
 // CardHandler handles card-related HTTP requests
 type CardHandler struct {
 	cardReviewService card_review.CardReviewService
+	cardService       service.CardService
 	logger            *slog.Logger
 }
 
 // NewCardHandler creates a new CardHandler
 func NewCardHandler(
 	cardReviewService card_review.CardReviewService,
+	cardService service.CardService,
 	logger *slog.Logger,
 ) *CardHandler {
 	if logger == nil {
@@ -45,12 +63,17 @@ func NewCardHandler(
 
 	return &CardHandler{
 		cardReviewService: cardReviewService,
+		cardService:       cardService,
 		logger:            logger.With(slog.String("component", "card_handler")),
 	}
 }
 
 // GetNextReviewCard handles GET /cards/next requests
 // It retrieves the next card due for review for the authenticated user.
+// An optional deck_id query parameter restricts the queue to one deck, and an
+// optional order query parameter chooses which due card is served first.
+// An optional exclude query parameter lists the IDs of cards already served in
+// the session, comma-separated or repeated, so they are not served again.
 func (h *CardHandler) GetNextReviewCard(w http.ResponseWriter, r *http.Request) {
 	// Get logger from context or use default
 	log := logger.FromContextOrDefault(r.Context(), h.logger)
@@ -63,10 +86,33 @@ func (h *CardHandler) GetNextReviewCard(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	log.Debug("getting next review card", slog.String("user_id", userID.String()))
+	deckID, err := parseDeckIDQuery(r)
+	if err != nil {
+		log.Warn("invalid deck_id parameter", slog.String("deck_id", r.URL.Query().Get("deck_id")))
+		HandleAPIError(w, r, err, "Invalid deck_id parameter")
+		return
+	}
+
+	order, err := parseReviewOrderQuery(r)
+	if err != nil {
+		log.Warn("invalid order parameter", slog.String("order", r.URL.Query().Get("order")))
+		HandleAPIError(w, r, err, "Invalid order parameter")
+		return
+	}
+
+	exclude, err := parseExcludeQuery(r)
+	if err != nil {
+		log.Warn("invalid exclude parameter", slog.String("exclude", r.URL.Query().Get("exclude")))
+		HandleAPIError(w, r, err, "Invalid exclude parameter")
+		return
+	}
+
+	log.Debug("getting next review card",
+		slog.String("user_id", userID.String()),
+		slog.Int("excluded", len(exclude)))
 
 	// Get next card from service
-	card, err := h.cardReviewService.GetNextCard(r.Context(), userID)
+	card, err := h.cardReviewService.GetNextCardExcluding(r.Context(), userID, deckID, order, exclude)
 
 	// Special case: no cards due for review
 	if errors.Is(err, card_review.ErrNoCardsDue) {
@@ -106,6 +152,9 @@ type UserCardStatsResponse struct {
 	LastReviewedAt     time.Time `json:"last_reviewed_at"`
 	NextReviewAt       time.Time `json:"next_review_at"`
 	ReviewCount        int       `json:"review_count"`
+	Lapses             int       `json:"lapses"`
+	Phase              string    `json:"phase"`
+	LearningStep       int       `json:"learning_step"`
 }
 
 // SubmitAnswer handles POST /cards/{id}/answer requests
@@ -150,7 +199,7 @@ func (h *CardHandler) SubmitAnswer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate request
-	if err := shared.Validate.Struct(req); err != nil {
+	if err := shared.ValidateStruct(req); err != nil {
 		log.Warn("validation error",
 			slog.String("error", redact.Error(err)),
 			slog.String("user_id", userID.String()),
@@ -187,6 +236,525 @@ func (h *CardHandler) SubmitAnswer(w http.ResponseWriter, r *http.Request) {
 	shared.RespondWithJSON(w, r, http.StatusOK, response)
 }
 
+// BatchAnswerRequest is one answer in a SubmitAnswersRequest
+type BatchAnswerRequest struct {
+	CardID  uuid.UUID `json:"card_id" validate:"required"`
+	Outcome string    `json:"outcome" validate:"required,oneof=again hard good easy"`
+}
+
+// SubmitAnswersRequest represents the request body for submitting several answers at once.
+// The maximum matches card_review.MaxBatchAnswers.
+type SubmitAnswersRequest struct {
+	Answers []BatchAnswerRequest `json:"answers" validate:"required,min=1,max=100,dive"`
+}
+
+// SubmitAnswersResponse holds the updated statistics, in the order of the submitted answers
+type SubmitAnswersResponse struct {
+	Stats []UserCardStatsResponse `json:"stats"`
+}
+
+// SubmitAnswers handles POST /cards/answers requests
+// It applies a batch of review answers, each to a different card, all-or-nothing.
+func (h *CardHandler) SubmitAnswers(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	// Parse and validate request body
+	var req SubmitAnswersRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format",
+			slog.String("error", redact.Error(err)),
+			slog.String("user_id", userID.String()))
+		HandleValidationError(w, r, err)
+		return
+	}
+	if err := shared.ValidateStruct(req); err != nil {
+		log.Warn("validation error",
+			slog.String("error", redact.Error(err)),
+			slog.String("user_id", userID.String()))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	answers := make([]card_review.CardAnswer, len(req.Answers))
+	for i, answer := range req.Answers {
+		answers[i] = card_review.CardAnswer{
+			CardID:  answer.CardID,
+			Outcome: domain.ReviewOutcome(answer.Outcome),
+		}
+	}
+
+	stats, err := h.cardReviewService.SubmitAnswers(r.Context(), userID, answers)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to submit answers")
+		return
+	}
+
+	response := SubmitAnswersResponse{Stats: make([]UserCardStatsResponse, len(stats))}
+	for i, s := range stats {
+		response.Stats[i] = statsToResponse(s)
+	}
+
+	log.Debug("successfully submitted answers",
+		slog.String("user_id", userID.String()),
+		slog.Int("answer_count", len(answers)))
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// GetCard handles GET /cards/{id} requests
+// It returns one of the user's cards with a weak ETag, answering 304 Not
+// Modified when the client's If-None-Match shows its copy is current.
+func (h *CardHandler) GetCard(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract card ID from URL path using chi router
+	cardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid card ID format", slog.String("card_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid card ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	card, err := h.cardService.GetUserCard(r.Context(), userID, cardID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get card")
+		return
+	}
+
+	shared.RespondWithJSONETag(w, r, cardToResponse(card))
+}
+
+// UpdateCardContentRequest represents the request body for editing a card's content.
+// Version must be the card version the client last read.
+type UpdateCardContentRequest struct {
+	Content json.RawMessage `json:"content" validate:"required"`
+	Version int             `json:"version" validate:"required,gte=1"`
+}
+
+// UpdateCardContent handles PUT /cards/{id} requests
+// It replaces the card's content using optimistic concurrency control: if the card
+// has been edited since the supplied version, the request fails with 409 Conflict.
+func (h *CardHandler) UpdateCardContent(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract card ID from URL path using chi router
+	cardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid card ID format", slog.String("card_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid card ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	// Parse and validate request body
+	var req UpdateCardContentRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format",
+			slog.String("error", redact.Error(err)),
+			slog.String("card_id", cardID.String()))
+		HandleValidationError(w, r, err)
+		return
+	}
+	if err := shared.ValidateStruct(req); err != nil {
+		log.Warn("validation error",
+			slog.String("error", redact.Error(err)),
+			slog.String("card_id", cardID.String()))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	card, err := h.cardService.UpdateCardContent(r.Context(), userID, cardID, req.Content, req.Version)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to update card")
+		return
+	}
+
+	log.Debug("successfully updated card content",
+		slog.String("user_id", userID.String()),
+		slog.String("card_id", cardID.String()),
+		slog.Int("version", card.Version))
+	shared.RespondWithJSON(w, r, http.StatusOK, cardToResponse(card))
+}
+
+// MoveCardRequest represents the request body for moving a card to another memo.
+type MoveCardRequest struct {
+	MemoID uuid.UUID `json:"memo_id" validate:"required"`
+}
+
+// MoveCard handles POST /cards/{id}/move requests
+// It moves the card to another memo owned by the same user and returns the moved card.
+func (h *CardHandler) MoveCard(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract card ID from URL path using chi router
+	cardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid card ID format", slog.String("card_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid card ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	// Parse and validate request body
+	var req MoveCardRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format",
+			slog.String("error", redact.Error(err)),
+			slog.String("card_id", cardID.String()))
+		HandleValidationError(w, r, err)
+		return
+	}
+	if err := shared.ValidateStruct(req); err != nil {
+		log.Warn("validation error",
+			slog.String("error", redact.Error(err)),
+			slog.String("card_id", cardID.String()))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	card, err := h.cardService.MoveCardToMemo(r.Context(), userID, cardID, req.MemoID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to move card")
+		return
+	}
+
+	log.Debug("successfully moved card",
+		slog.String("user_id", userID.String()),
+		slog.String("card_id", cardID.String()),
+		slog.String("memo_id", req.MemoID.String()))
+	shared.RespondWithJSON(w, r, http.StatusOK, cardToResponse(card))
+}
+
+// SuspendCard handles POST /cards/{id}/suspend requests
+// It removes the card from review until it is unsuspended and returns the card.
+func (h *CardHandler) SuspendCard(w http.ResponseWriter, r *http.Request) {
+	h.setCardSuspended(w, r, true)
+}
+
+// UnsuspendCard handles POST /cards/{id}/unsuspend requests
+// It returns a suspended card to review and returns the card.
+func (h *CardHandler) UnsuspendCard(w http.ResponseWriter, r *http.Request) {
+	h.setCardSuspended(w, r, false)
+}
+
+// setCardSuspended handles both suspension endpoints
+func (h *CardHandler) setCardSuspended(w http.ResponseWriter, r *http.Request, suspend bool) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract card ID from URL path using chi router
+	cardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid card ID format", slog.String("card_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid card ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	var card *domain.Card
+	if suspend {
+		card, err = h.cardService.SuspendCard(r.Context(), userID, cardID)
+	} else {
+		card, err = h.cardService.UnsuspendCard(r.Context(), userID, cardID)
+	}
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to update card")
+		return
+	}
+
+	log.Debug("successfully set card suspension",
+		slog.String("user_id", userID.String()),
+		slog.String("card_id", cardID.String()),
+		slog.Bool("suspended", suspend))
+	shared.RespondWithJSON(w, r, http.StatusOK, cardToResponse(card))
+}
+
+// BuryCard handles POST /cards/{id}/bury requests
+// It hides the card from review until the end of the user's day and returns the card.
+func (h *CardHandler) BuryCard(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract card ID from URL path using chi router
+	cardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid card ID format", slog.String("card_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid card ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	card, err := h.cardService.BuryCard(r.Context(), userID, cardID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to bury card")
+		return
+	}
+
+	log.Debug("successfully buried card",
+		slog.String("user_id", userID.String()),
+		slog.String("card_id", cardID.String()))
+	shared.RespondWithJSON(w, r, http.StatusOK, cardToResponse(card))
+}
+
+// ResetCardProgress handles POST /cards/{id}/reset requests
+// It returns the card to the state of a new card, due now, and returns its statistics.
+// The card's review history is kept.
+func (h *CardHandler) ResetCardProgress(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract card ID from URL path using chi router
+	cardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid card ID format", slog.String("card_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid card ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	stats, err := h.cardService.ResetProgress(r.Context(), userID, cardID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to reset card progress")
+		return
+	}
+
+	log.Debug("successfully reset card progress",
+		slog.String("user_id", userID.String()),
+		slog.String("card_id", cardID.String()))
+	shared.RespondWithJSON(w, r, http.StatusOK, statsToResponse(stats))
+}
+
+// ReviewEventResponse represents one answer in a card's review history.
+// The intervals are omitted for answers recorded before they were tracked.
+type ReviewEventResponse struct {
+	ID             string    `json:"id"`
+	Outcome        string    `json:"outcome"`
+	ReviewedAt     time.Time `json:"reviewed_at"`
+	IntervalBefore *int      `json:"interval_before,omitempty"`
+	IntervalAfter  *int      `json:"interval_after,omitempty"`
+}
+
+// GetCardHistory handles GET /cards/{id}/history requests
+// It returns every answer the user has given for the card, oldest first.
+func (h *CardHandler) GetCardHistory(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract card ID from URL path using chi router
+	cardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid card ID format", slog.String("card_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid card ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	events, err := h.cardReviewService.GetCardHistory(r.Context(), userID, cardID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get card history")
+		return
+	}
+
+	response := make([]ReviewEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, ReviewEventResponse{
+			ID:             event.ID.String(),
+			Outcome:        string(event.Outcome),
+			ReviewedAt:     event.ReviewedAt,
+			IntervalBefore: event.IntervalBefore,
+			IntervalAfter:  event.IntervalAfter,
+		})
+	}
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// ListCards handles GET /cards requests
+// It lists a page of the user's cards with the tag given by the required tag
+// query parameter, such as the cards tagged "leech", oldest first.
+func (h *CardHandler) ListCards(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "User ID not found or invalid")
+		return
+	}
+
+	tag := r.URL.Query().Get("tag")
+	if tag == "" {
+		HandleAPIError(w, r,
+			domain.NewValidationError("tag", "is required", domain.ErrValidation),
+			"Missing tag parameter")
+		return
+	}
+
+	limit, offset, err := parsePaginationQuery(r, DefaultCardListLimit, MaxCardListLimit)
+	if err != nil {
+		log.Warn("invalid pagination parameters", slog.String("error", err.Error()))
+		HandleAPIError(w, r, err, "Invalid pagination parameters")
+		return
+	}
+
+	cards, err := h.cardService.ListCardsByTag(r.Context(), userID, tag, limit, offset)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to list cards")
+		return
+	}
+
+	response := make([]CardResponse, 0, len(cards))
+	for _, card := range cards {
+		response = append(response, cardToResponse(card))
+	}
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// parsePaginationQuery parses the optional limit and offset query parameters.
+// A missing limit is defaultLimit and a larger one is capped at maxLimit; a
+// missing offset is 0.
+func parsePaginationQuery(r *http.Request, defaultLimit, maxLimit int) (limit, offset int, err error) {
+	limit = defaultLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return 0, 0, domain.NewValidationError("limit", "must be a positive integer", domain.ErrValidation)
+		}
+		limit = min(parsed, maxLimit)
+	}
+
+	if raw := r.URL.Query().Get("offset"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return 0, 0, domain.NewValidationError("offset", "must be a non-negative integer", domain.ErrValidation)
+		}
+		offset = parsed
+	}
+	return limit, offset, nil
+}
+
+// parseDeckIDQuery parses the optional deck_id query parameter.
+// It returns nil if the parameter is absent.
+func parseDeckIDQuery(r *http.Request) (*uuid.UUID, error) {
+	raw := r.URL.Query().Get("deck_id")
+	if raw == "" {
+		return nil, nil
+	}
+
+	deckID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, domain.NewValidationError("deck_id", "must be a valid UUID", domain.ErrInvalidID)
+	}
+	return &deckID, nil
+}
+
+// parseExcludeQuery parses the optional exclude query parameter, a list of card
+// IDs given comma-separated, as repeated parameters, or both.
+// It returns nil if the parameter is absent.
+func parseExcludeQuery(r *http.Request) ([]uuid.UUID, error) {
+	var exclude []uuid.UUID
+	for _, raw := range r.URL.Query()["exclude"] {
+		for _, part := range strings.Split(raw, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			id, err := uuid.Parse(part)
+			if err != nil {
+				return nil, domain.NewValidationError("exclude", "must be a list of valid UUIDs", domain.ErrInvalidID)
+			}
+			exclude = append(exclude, id)
+		}
+	}
+	return exclude, nil
+}
+
+// parseReviewOrderQuery parses the optional order query parameter.
+// It returns an empty order if the parameter is absent, leaving the choice to
+// the deck being reviewed or the default.
+func parseReviewOrderQuery(r *http.Request) (domain.ReviewOrder, error) {
+	raw := r.URL.Query().Get("order")
+	if raw == "" {
+		return "", nil
+	}
+
+	order := domain.ReviewOrder(raw)
+	if !order.IsValid() {
+		allowed := make([]string, 0, len(domain.ReviewOrders()))
+		for _, o := range domain.ReviewOrders() {
+			allowed = append(allowed, string(o))
+		}
+		return "", domain.NewValidationError(
+			"order",
+			"must be one of: "+strings.Join(allowed, ", "),
+			domain.ErrInvalidReviewOrder,
+		)
+	}
+	return order, nil
+}
+
 // statsToResponse converts a domain.UserCardStats to a UserCardStatsResponse
 func statsToResponse(stats *domain.UserCardStats) UserCardStatsResponse {
 	return UserCardStatsResponse{
@@ -198,6 +766,9 @@ func statsToResponse(stats *domain.UserCardStats) UserCardStatsResponse {
 		LastReviewedAt:     stats.LastReviewedAt,
 		NextReviewAt:       stats.NextReviewAt,
 		ReviewCount:        stats.ReviewCount,
+		Lapses:             stats.Lapses,
+		Phase:              string(stats.CurrentPhase()),
+		LearningStep:       stats.LearningStep,
 	}
 }
 
@@ -209,12 +780,23 @@ func cardToResponse(card *domain.Card) CardResponse {
 		content = string(card.Content)
 	}
 
+	var deckID *string
+	if card.DeckID != nil {
+		id := card.DeckID.String()
+		deckID = &id
+	}
+
 	return CardResponse{
-		ID:        card.ID.String(),
-		UserID:    card.UserID.String(),
-		MemoID:    card.MemoID.String(),
-		Content:   content,
-		CreatedAt: card.CreatedAt,
-		UpdatedAt: card.UpdatedAt,
+		ID:          card.ID.String(),
+		UserID:      card.UserID.String(),
+		MemoID:      card.MemoID.String(),
+		DeckID:      deckID,
+		Type:        string(card.Type.OrDefault()),
+		Content:     content,
+		CreatedAt:   card.CreatedAt,
+		UpdatedAt:   card.UpdatedAt,
+		Version:     card.Version,
+		SuspendedAt: card.SuspendedAt,
+		BuriedUntil: card.BuriedUntil,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -17,21 +18,42 @@ import (
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/api/shared"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
 	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/store"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockCardReviewService is a mock implementation of the CardReviewService interface
 type mockCardReviewService struct {
-	nextCardFn     func(ctx context.Context, userID uuid.UUID) (*domain.Card, error)
-	submitAnswerFn func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, answer card_review.ReviewAnswer) (*domain.UserCardStats, error)
+	nextCardFn      func(ctx context.Context, userID uuid.UUID, deckID *uuid.UUID, order domain.ReviewOrder) (*domain.Card, error)
+	submitAnswerFn  func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, answer card_review.ReviewAnswer) (*domain.UserCardStats, error)
+	submitAnswersFn func(ctx context.Context, userID uuid.UUID, answers []card_review.CardAnswer) ([]*domain.UserCardStats, error)
+	historyFn       func(ctx context.Context, userID, cardID uuid.UUID) ([]*domain.ReviewEvent, error)
+
+	// excluded records the cards excluded by the last GetNextCardExcluding call
+	excluded []uuid.UUID
 }
 
 func (m *mockCardReviewService) GetNextCard(
 	ctx context.Context,
 	userID uuid.UUID,
+	deckID *uuid.UUID,
+	order domain.ReviewOrder,
 ) (*domain.Card, error) {
-	return m.nextCardFn(ctx, userID)
+	return m.nextCardFn(ctx, userID, deckID, order)
+}
+
+func (m *mockCardReviewService) GetNextCardExcluding(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
+) (*domain.Card, error) {
+	m.excluded = exclude
+	return m.nextCardFn(ctx, userID, deckID, order)
 }
 
 func (m *mockCardReviewService) SubmitAnswer(
@@ -43,6 +65,89 @@ func (m *mockCardReviewService) SubmitAnswer(
 	return m.submitAnswerFn(ctx, userID, cardID, answer)
 }
 
+func (m *mockCardReviewService) SubmitAnswers(
+	ctx context.Context,
+	userID uuid.UUID,
+	answers []card_review.CardAnswer,
+) ([]*domain.UserCardStats, error) {
+	return m.submitAnswersFn(ctx, userID, answers)
+}
+
+func (m *mockCardReviewService) GetCardHistory(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) ([]*domain.ReviewEvent, error) {
+	return m.historyFn(ctx, userID, cardID)
+}
+
+// mockCardService is a mock implementation of the service.CardService interface
+type mockCardService struct {
+	getUserCardFn       func(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
+	updateCardContentFn func(ctx context.Context, userID, cardID uuid.UUID, content json.RawMessage, version int) (*domain.Card, error)
+	moveCardToMemoFn    func(ctx context.Context, userID, cardID, memoID uuid.UUID) (*domain.Card, error)
+	suspendCardFn       func(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
+	unsuspendCardFn     func(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
+	buryCardFn          func(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
+	resetProgressFn     func(ctx context.Context, userID, cardID uuid.UUID) (*domain.UserCardStats, error)
+	listCardsByTagFn    func(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.Card, error)
+}
+
+func (m *mockCardService) CreateCards(ctx context.Context, cards []*domain.Card) error {
+	return nil
+}
+
+func (m *mockCardService) GetCard(ctx context.Context, cardID uuid.UUID) (*domain.Card, error) {
+	return nil, nil
+}
+
+func (m *mockCardService) GetUserCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error) {
+	return m.getUserCardFn(ctx, userID, cardID)
+}
+
+func (m *mockCardService) UpdateCardContent(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+	content json.RawMessage,
+	expectedVersion int,
+) (*domain.Card, error) {
+	return m.updateCardContentFn(ctx, userID, cardID, content, expectedVersion)
+}
+
+func (m *mockCardService) MoveCardToMemo(
+	ctx context.Context,
+	userID, cardID, memoID uuid.UUID,
+) (*domain.Card, error) {
+	return m.moveCardToMemoFn(ctx, userID, cardID, memoID)
+}
+
+func (m *mockCardService) SuspendCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error) {
+	return m.suspendCardFn(ctx, userID, cardID)
+}
+
+func (m *mockCardService) UnsuspendCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error) {
+	return m.unsuspendCardFn(ctx, userID, cardID)
+}
+
+func (m *mockCardService) BuryCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error) {
+	return m.buryCardFn(ctx, userID, cardID)
+}
+
+func (m *mockCardService) ResetProgress(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) (*domain.UserCardStats, error) {
+	return m.resetProgressFn(ctx, userID, cardID)
+}
+
+func (m *mockCardService) ListCardsByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	return m.listCardsByTagFn(ctx, userID, tag, limit, offset)
+}
+
 func TestGetNextReviewCard(t *testing.T) {
 	userID := uuid.New()
 	cardID := uuid.New()
@@ -123,14 +228,20 @@ func TestGetNextReviewCard(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create a mock service that returns the test case's result
 			mockService := &mockCardReviewService{
-				nextCardFn: func(ctx context.Context, userID uuid.UUID) (*domain.Card, error) {
+				nextCardFn: func(
+					ctx context.Context,
+					userID uuid.UUID,
+					deckID *uuid.UUID,
+					order domain.ReviewOrder,
+				) (*domain.Card, error) {
+					assert.Nil(t, deckID, "No deck filter should be applied without deck_id")
 					return tc.serviceResult, tc.serviceError
 				},
 			}
 
 			// Create the handler
 			testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
-			handler := NewCardHandler(mockService, testLogger)
+			handler := NewCardHandler(mockService, nil, testLogger)
 
 			// Create a request
 			req, err := http.NewRequest("GET", "/cards/next", nil)
@@ -409,7 +520,7 @@ func TestSubmitAnswer(t *testing.T) {
 
 			// Create the handler
 			testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
-			handler := NewCardHandler(mockService, testLogger)
+			handler := NewCardHandler(mockService, nil, testLogger)
 
 			// Create request body
 			var jsonBody []byte
@@ -510,12 +621,121 @@ func TestSubmitAnswer(t *testing.T) {
 	}
 }
 
+func TestSubmitAnswers(t *testing.T) {
+	userID := uuid.New()
+	firstID := uuid.New()
+	secondID := uuid.New()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	validBody := fmt.Sprintf(`{"answers":[{"card_id":%q,"outcome":"good"},{"card_id":%q,"outcome":"again"}]}`,
+		firstID, secondID)
+	tooMany := make([]string, card_review.MaxBatchAnswers+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf(`{"card_id":%q,"outcome":"good"}`, uuid.New())
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectCall     bool
+	}{
+		{
+			name:           "success",
+			body:           validBody,
+			expectedStatus: http.StatusOK,
+			expectCall:     true,
+		},
+		{
+			name:           "card not owned",
+			body:           validBody,
+			serviceErr:     card_review.ErrCardNotOwned,
+			expectedStatus: http.StatusForbidden,
+			expectCall:     true,
+		},
+		{
+			name:           "card not found",
+			body:           validBody,
+			serviceErr:     card_review.ErrCardNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectCall:     true,
+		},
+		{
+			name:           "empty batch",
+			body:           `{"answers":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "too many answers",
+			body:           `{"answers":[` + strings.Join(tooMany, ",") + `]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid outcome",
+			body:           fmt.Sprintf(`{"answers":[{"card_id":%q,"outcome":"maybe"}]}`, firstID),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing card id",
+			body:           `{"answers":[{"outcome":"good"}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			reviewService := &mockCardReviewService{
+				submitAnswersFn: func(
+					ctx context.Context,
+					uid uuid.UUID,
+					answers []card_review.CardAnswer,
+				) ([]*domain.UserCardStats, error) {
+					called = true
+					assert.Equal(t, userID, uid)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					stats := make([]*domain.UserCardStats, len(answers))
+					for i, answer := range answers {
+						stats[i] = &domain.UserCardStats{UserID: uid, CardID: answer.CardID, ReviewCount: 1}
+					}
+					return stats, nil
+				},
+			}
+			handler := NewCardHandler(reviewService, nil, testLogger)
+
+			router := chi.NewRouter()
+			router.Post("/cards/answers", handler.SubmitAnswers)
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/answers", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, tc.expectCall, called)
+
+			if tc.expectedStatus == http.StatusOK {
+				var response SubmitAnswersResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+				require.Len(t, response.Stats, 2)
+				assert.Equal(t, firstID.String(), response.Stats[0].CardID)
+				assert.Equal(t, secondID.String(), response.Stats[1].CardID)
+			}
+		})
+	}
+}
+
 func TestNewCardHandler(t *testing.T) {
 	mockService := &mockCardReviewService{}
 
 	// Test with valid logger
 	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	handler := NewCardHandler(mockService, testLogger)
+	handler := NewCardHandler(mockService, nil, testLogger)
 
 	if handler == nil {
 		t.Fatal("expected handler to be created")
@@ -523,7 +743,7 @@ func TestNewCardHandler(t *testing.T) {
 
 	// Test with nil logger should panic
 	assert.Panics(t, func() {
-		NewCardHandler(mockService, nil)
+		NewCardHandler(mockService, nil, nil)
 	})
 
 	if handler.cardReviewService == nil {
@@ -534,3 +754,910 @@ func TestNewCardHandler(t *testing.T) {
 		t.Error("expected default logger to be set")
 	}
 }
+
+func TestUpdateCardContent(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectCall     bool
+	}{
+		{
+			name:           "success",
+			body:           `{"content":{"front":"New","back":"Content"},"version":2}`,
+			expectedStatus: http.StatusOK,
+			expectCall:     true,
+		},
+		{
+			name:           "stale version",
+			body:           `{"content":{"front":"New","back":"Content"},"version":1}`,
+			serviceErr:     fmt.Errorf("update failed: %w", store.ErrVersionConflict),
+			expectedStatus: http.StatusConflict,
+			expectCall:     true,
+		},
+		{
+			name:           "missing version",
+			body:           `{"content":{"front":"New","back":"Content"}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing content",
+			body:           `{"version":2}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			cardService := &mockCardService{
+				updateCardContentFn: func(
+					ctx context.Context,
+					uid, cid uuid.UUID,
+					content json.RawMessage,
+					version int,
+				) (*domain.Card, error) {
+					called = true
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, cardID, cid)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.Card{
+						ID:      cid,
+						UserID:  uid,
+						MemoID:  uuid.New(),
+						Content: content,
+						Version: version + 1,
+					}, nil
+				},
+			}
+			handler := NewCardHandler(&mockCardReviewService{}, cardService, testLogger)
+
+			router := chi.NewRouter()
+			router.Put("/cards/{id}", handler.UpdateCardContent)
+
+			req := httptest.NewRequest(http.MethodPut, "/cards/"+cardID.String(), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, tc.expectCall, called)
+
+			if tc.expectedStatus == http.StatusOK {
+				var response CardResponse
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				assert.Equal(t, 3, response.Version)
+				assert.Equal(t, cardID.String(), response.ID)
+			}
+		})
+	}
+}
+
+func TestMoveCard(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	memoID := uuid.New()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectCall     bool
+	}{
+		{
+			name:           "success",
+			body:           `{"memo_id":"` + memoID.String() + `"}`,
+			expectedStatus: http.StatusOK,
+			expectCall:     true,
+		},
+		{
+			name:           "card not owned",
+			body:           `{"memo_id":"` + memoID.String() + `"}`,
+			serviceErr:     fmt.Errorf("move failed: %w", card_review.ErrCardNotOwned),
+			expectedStatus: http.StatusForbidden,
+			expectCall:     true,
+		},
+		{
+			name:           "memo not owned",
+			body:           `{"memo_id":"` + memoID.String() + `"}`,
+			serviceErr:     fmt.Errorf("move failed: %w", service.ErrMemoNotOwned),
+			expectedStatus: http.StatusForbidden,
+			expectCall:     true,
+		},
+		{
+			name:           "memo not found",
+			body:           `{"memo_id":"` + memoID.String() + `"}`,
+			serviceErr:     fmt.Errorf("move failed: %w", store.ErrMemoNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectCall:     true,
+		},
+		{
+			name:           "missing memo_id",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid memo_id",
+			body:           `{"memo_id":"not-a-uuid"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			cardService := &mockCardService{
+				moveCardToMemoFn: func(ctx context.Context, uid, cid, mid uuid.UUID) (*domain.Card, error) {
+					called = true
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, cardID, cid)
+					assert.Equal(t, memoID, mid)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.Card{
+						ID:      cid,
+						UserID:  uid,
+						MemoID:  mid,
+						Content: json.RawMessage(`{"front":"Front","back":"Back"}`),
+						Version: 1,
+					}, nil
+				},
+			}
+			handler := NewCardHandler(&mockCardReviewService{}, cardService, testLogger)
+
+			router := chi.NewRouter()
+			router.Post("/cards/{id}/move", handler.MoveCard)
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/"+cardID.String()+"/move", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, tc.expectCall, called)
+
+			if tc.expectedStatus == http.StatusOK {
+				var response CardResponse
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				assert.Equal(t, memoID.String(), response.MemoID)
+			}
+		})
+	}
+}
+
+// TestSuspendCard tests the suspend and unsuspend endpoints
+func TestSuspendCard(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	suspendedAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	newCard := func(suspendedAt *time.Time) *domain.Card {
+		return &domain.Card{
+			ID:          cardID,
+			UserID:      userID,
+			MemoID:      uuid.New(),
+			Content:     json.RawMessage(`{"front":"Front","back":"Back"}`),
+			Version:     1,
+			SuspendedAt: suspendedAt,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		serviceErr     error
+		expectedStatus int
+		expectSuspend  bool
+	}{
+		{name: "suspend", path: "/suspend", expectedStatus: http.StatusOK, expectSuspend: true},
+		{name: "unsuspend", path: "/unsuspend", expectedStatus: http.StatusOK},
+		{
+			name:           "card not owned",
+			path:           "/suspend",
+			serviceErr:     fmt.Errorf("suspend failed: %w", card_review.ErrCardNotOwned),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "card not found",
+			path:           "/unsuspend",
+			serviceErr:     fmt.Errorf("unsuspend failed: %w", store.ErrCardNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			cardService := &mockCardService{
+				suspendCardFn: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+					calls = append(calls, "suspend")
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, cardID, cid)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return newCard(&suspendedAt), nil
+				},
+				unsuspendCardFn: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+					calls = append(calls, "unsuspend")
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, cardID, cid)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return newCard(nil), nil
+				},
+			}
+			handler := NewCardHandler(&mockCardReviewService{}, cardService, testLogger)
+
+			router := chi.NewRouter()
+			router.Post("/cards/{id}/suspend", handler.SuspendCard)
+			router.Post("/cards/{id}/unsuspend", handler.UnsuspendCard)
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/"+cardID.String()+tc.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, []string{strings.TrimPrefix(tc.path, "/")}, calls)
+
+			if tc.expectedStatus == http.StatusOK {
+				var response CardResponse
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if tc.expectSuspend {
+					require.NotNil(t, response.SuspendedAt)
+					assert.True(t, suspendedAt.Equal(*response.SuspendedAt))
+				} else {
+					assert.Nil(t, response.SuspendedAt)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid card ID", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{}, &mockCardService{}, testLogger)
+
+		router := chi.NewRouter()
+		router.Post("/cards/{id}/suspend", handler.SuspendCard)
+
+		req := httptest.NewRequest(http.MethodPost, "/cards/not-a-uuid/suspend", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+// TestBuryCard tests the bury endpoint
+func TestBuryCard(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	buriedUntil := time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "bury", expectedStatus: http.StatusOK},
+		{
+			name:           "card not owned",
+			serviceErr:     fmt.Errorf("bury failed: %w", card_review.ErrCardNotOwned),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "card not found",
+			serviceErr:     fmt.Errorf("bury failed: %w", store.ErrCardNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cardService := &mockCardService{
+				buryCardFn: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, cardID, cid)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.Card{
+						ID:          cardID,
+						UserID:      userID,
+						MemoID:      uuid.New(),
+						Content:     json.RawMessage(`{"front":"Front","back":"Back"}`),
+						Version:     1,
+						BuriedUntil: &buriedUntil,
+					}, nil
+				},
+			}
+			handler := NewCardHandler(&mockCardReviewService{}, cardService, testLogger)
+
+			router := chi.NewRouter()
+			router.Post("/cards/{id}/bury", handler.BuryCard)
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/"+cardID.String()+"/bury", nil)
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var response CardResponse
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				require.NotNil(t, response.BuriedUntil)
+				assert.True(t, buriedUntil.Equal(*response.BuriedUntil))
+			}
+		})
+	}
+
+	t.Run("invalid card ID", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{}, &mockCardService{}, testLogger)
+
+		router := chi.NewRouter()
+		router.Post("/cards/{id}/bury", handler.BuryCard)
+
+		req := httptest.NewRequest(http.MethodPost, "/cards/not-a-uuid/bury", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestResetCardProgress(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	now := time.Now().UTC()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "reset", expectedStatus: http.StatusOK},
+		{
+			name:           "card not owned",
+			serviceErr:     fmt.Errorf("reset failed: %w", card_review.ErrCardNotOwned),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "card not found",
+			serviceErr:     fmt.Errorf("reset failed: %w", store.ErrCardNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cardService := &mockCardService{
+				resetProgressFn: func(ctx context.Context, uid, cid uuid.UUID) (*domain.UserCardStats, error) {
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, cardID, cid)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.UserCardStats{
+						UserID:       userID,
+						CardID:       cardID,
+						Interval:     0,
+						EaseFactor:   2.5,
+						NextReviewAt: now,
+					}, nil
+				},
+			}
+			handler := NewCardHandler(&mockCardReviewService{}, cardService, testLogger)
+
+			router := chi.NewRouter()
+			router.Post("/cards/{id}/reset", handler.ResetCardProgress)
+
+			req := httptest.NewRequest(http.MethodPost, "/cards/"+cardID.String()+"/reset", nil)
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var response UserCardStatsResponse
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				assert.Equal(t, cardID.String(), response.CardID)
+				assert.Zero(t, response.ReviewCount)
+				assert.True(t, now.Equal(response.NextReviewAt))
+			}
+		})
+	}
+
+	t.Run("invalid card ID", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{}, &mockCardService{}, testLogger)
+
+		router := chi.NewRouter()
+		router.Post("/cards/{id}/reset", handler.ResetCardProgress)
+
+		req := httptest.NewRequest(http.MethodPost, "/cards/not-a-uuid/reset", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+// TestGetNextReviewCard_DeckFilter tests the optional deck_id query parameter.
+func TestGetNextReviewCard_DeckFilter(t *testing.T) {
+	userID := uuid.New()
+	deckID := uuid.New()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("deck_id is passed to the service", func(t *testing.T) {
+		var gotDeckID *uuid.UUID
+		handler := NewCardHandler(&mockCardReviewService{
+			nextCardFn: func(
+				ctx context.Context,
+				id uuid.UUID,
+				deck *uuid.UUID,
+				order domain.ReviewOrder,
+			) (*domain.Card, error) {
+				gotDeckID = deck
+				return &domain.Card{
+					ID:      uuid.New(),
+					UserID:  id,
+					MemoID:  uuid.New(),
+					DeckID:  deck,
+					Content: json.RawMessage(`{}`),
+				}, nil
+			},
+		}, nil, testLogger)
+
+		req := httptest.NewRequest(http.MethodGet, "/cards/next?deck_id="+deckID.String(), nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		rr := httptest.NewRecorder()
+
+		handler.GetNextReviewCard(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.NotNil(t, gotDeckID)
+		assert.Equal(t, deckID, *gotDeckID)
+	})
+
+	t.Run("invalid deck_id is rejected", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{
+			nextCardFn: func(
+				ctx context.Context,
+				id uuid.UUID,
+				deck *uuid.UUID,
+				order domain.ReviewOrder,
+			) (*domain.Card, error) {
+				t.Fatal("service should not be called for an invalid deck_id")
+				return nil, nil
+			},
+		}, nil, testLogger)
+
+		req := httptest.NewRequest(http.MethodGet, "/cards/next?deck_id=not-a-uuid", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		rr := httptest.NewRecorder()
+
+		handler.GetNextReviewCard(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+// TestGetNextReviewCard_Order tests the optional order query parameter.
+func TestGetNextReviewCard_Order(t *testing.T) {
+	userID := uuid.New()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedOrder domain.ReviewOrder
+	}{
+		// Without an order the service applies the deck's order or the default
+		{name: "default", query: "", expectedOrder: ""},
+		{name: "due date", query: "?order=due-date-asc", expectedOrder: domain.ReviewOrderDueDate},
+		{name: "random", query: "?order=random-due", expectedOrder: domain.ReviewOrderRandomDue},
+		{name: "lowest ease", query: "?order=lowest-ease-first", expectedOrder: domain.ReviewOrderLowestEase},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotOrder domain.ReviewOrder
+			handler := NewCardHandler(&mockCardReviewService{
+				nextCardFn: func(
+					ctx context.Context,
+					id uuid.UUID,
+					deck *uuid.UUID,
+					order domain.ReviewOrder,
+				) (*domain.Card, error) {
+					gotOrder = order
+					return &domain.Card{
+						ID:      uuid.New(),
+						UserID:  id,
+						MemoID:  uuid.New(),
+						Content: json.RawMessage(`{}`),
+					}, nil
+				},
+			}, nil, testLogger)
+
+			req := httptest.NewRequest(http.MethodGet, "/cards/next"+tc.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			handler.GetNextReviewCard(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tc.expectedOrder, gotOrder)
+		})
+	}
+
+	t.Run("unknown order is rejected", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{
+			nextCardFn: func(
+				ctx context.Context,
+				id uuid.UUID,
+				deck *uuid.UUID,
+				order domain.ReviewOrder,
+			) (*domain.Card, error) {
+				t.Fatal("service should not be called for an unknown order")
+				return nil, nil
+			},
+		}, nil, testLogger)
+
+		req := httptest.NewRequest(http.MethodGet, "/cards/next?order=hardest-first", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		rr := httptest.NewRecorder()
+
+		handler.GetNextReviewCard(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "lowest-ease-first")
+	})
+}
+
+// TestGetNextReviewCard_Exclude tests the optional exclude query parameter.
+func TestGetNextReviewCard_Exclude(t *testing.T) {
+	userID := uuid.New()
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	nextCard := func(ctx context.Context, id uuid.UUID, deck *uuid.UUID, order domain.ReviewOrder) (*domain.Card, error) {
+		return &domain.Card{ID: uuid.New(), UserID: id, MemoID: uuid.New(), Content: json.RawMessage(`{}`)}, nil
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []uuid.UUID
+	}{
+		{name: "absent", query: "", expected: nil},
+		{name: "comma separated", query: "?exclude=" + first.String() + "," + second.String(),
+			expected: []uuid.UUID{first, second}},
+		{name: "repeated", query: "?exclude=" + first.String() + "&exclude=" + second.String() + "," + third.String(),
+			expected: []uuid.UUID{first, second, third}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service := &mockCardReviewService{nextCardFn: nextCard}
+			handler := NewCardHandler(service, nil, testLogger)
+
+			req := httptest.NewRequest(http.MethodGet, "/cards/next"+tc.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			handler.GetNextReviewCard(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tc.expected, service.excluded)
+		})
+	}
+
+	t.Run("invalid card ID is rejected", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{
+			nextCardFn: func(
+				ctx context.Context,
+				id uuid.UUID,
+				deck *uuid.UUID,
+				order domain.ReviewOrder,
+			) (*domain.Card, error) {
+				t.Fatal("service should not be called for an invalid exclude list")
+				return nil, nil
+			},
+		}, nil, testLogger)
+
+		req := httptest.NewRequest(http.MethodGet, "/cards/next?exclude="+first.String()+",nope", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		rr := httptest.NewRecorder()
+
+		handler.GetNextReviewCard(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestListCards(t *testing.T) {
+	userID := uuid.New()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	leech := &domain.Card{
+		ID:      uuid.New(),
+		UserID:  userID,
+		MemoID:  uuid.New(),
+		Content: json.RawMessage(`{"front":"Front","back":"Back","tags":["leech"]}`),
+		Version: 2,
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{name: "default page", query: "?tag=leech", expectedStatus: http.StatusOK, expectedLimit: DefaultCardListLimit},
+		{name: "explicit page", query: "?tag=leech&limit=10&offset=20", expectedStatus: http.StatusOK,
+			expectedLimit: 10, expectedOffset: 20},
+		{name: "limit is capped", query: "?tag=leech&limit=100000", expectedStatus: http.StatusOK,
+			expectedLimit: MaxCardListLimit},
+		{name: "missing tag", query: "", expectedStatus: http.StatusBadRequest},
+		{name: "invalid limit", query: "?tag=leech&limit=0", expectedStatus: http.StatusBadRequest},
+		{
+			name:           "invalid tag",
+			query:          "?tag=leech!",
+			serviceErr:     fmt.Errorf("list failed: %w", domain.ErrInvalidTag),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			cardService := &mockCardService{
+				listCardsByTagFn: func(
+					ctx context.Context,
+					uid uuid.UUID,
+					tag string,
+					limit, offset int,
+				) ([]*domain.Card, error) {
+					called = true
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, "leech", tag)
+					assert.Equal(t, tc.expectedLimit, limit)
+					assert.Equal(t, tc.expectedOffset, offset)
+					return []*domain.Card{leech}, nil
+				},
+			}
+			handler := NewCardHandler(&mockCardReviewService{}, cardService, testLogger)
+
+			req := httptest.NewRequest(http.MethodGet, "/cards"+tc.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			rr := httptest.NewRecorder()
+
+			handler.ListCards(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus != http.StatusOK {
+				assert.Equal(t, tc.serviceErr != nil, called, "Invalid parameters are rejected before listing")
+				return
+			}
+			var response []CardResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			require.Len(t, response, 1)
+			assert.Equal(t, leech.ID.String(), response[0].ID)
+		})
+	}
+}
+
+// TestGetCardHistory tests the card review history endpoint.
+func TestGetCardHistory(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	serve := func(handler *CardHandler, path string) *httptest.ResponseRecorder {
+		router := chi.NewRouter()
+		router.Get("/cards/{id}/history", handler.GetCardHistory)
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("returns the history", func(t *testing.T) {
+		reviewedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+		legacy, err := domain.NewReviewEvent(userID, cardID, domain.ReviewOutcomeAgain, reviewedAt)
+		require.NoError(t, err)
+		latest, err := domain.NewReviewEvent(userID, cardID, domain.ReviewOutcomeGood, reviewedAt.Add(time.Hour))
+		require.NoError(t, err)
+		latest.SetIntervals(1, 6)
+
+		handler := NewCardHandler(&mockCardReviewService{
+			historyFn: func(ctx context.Context, id, card uuid.UUID) ([]*domain.ReviewEvent, error) {
+				assert.Equal(t, userID, id)
+				assert.Equal(t, cardID, card)
+				return []*domain.ReviewEvent{legacy, latest}, nil
+			},
+		}, nil, testLogger)
+
+		rr := serve(handler, "/cards/"+cardID.String()+"/history")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response []ReviewEventResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response, 2)
+		assert.Equal(t, legacy.ID.String(), response[0].ID)
+		assert.Equal(t, "again", response[0].Outcome)
+		assert.True(t, reviewedAt.Equal(response[0].ReviewedAt))
+		assert.Nil(t, response[0].IntervalBefore, "Intervals are omitted when they were not recorded")
+		assert.Nil(t, response[0].IntervalAfter)
+		require.NotNil(t, response[1].IntervalBefore)
+		require.NotNil(t, response[1].IntervalAfter)
+		assert.Equal(t, 1, *response[1].IntervalBefore)
+		assert.Equal(t, 6, *response[1].IntervalAfter)
+	})
+
+	t.Run("empty history is an empty array", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{
+			historyFn: func(ctx context.Context, id, card uuid.UUID) ([]*domain.ReviewEvent, error) {
+				return []*domain.ReviewEvent{}, nil
+			},
+		}, nil, testLogger)
+
+		rr := serve(handler, "/cards/"+cardID.String()+"/history")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `[]`, rr.Body.String())
+	})
+
+	t.Run("service errors are mapped", func(t *testing.T) {
+		tests := map[error]int{
+			card_review.ErrCardNotFound: http.StatusNotFound,
+			card_review.ErrCardNotOwned: http.StatusForbidden,
+		}
+		for serviceErr, expected := range tests {
+			handler := NewCardHandler(&mockCardReviewService{
+				historyFn: func(ctx context.Context, id, card uuid.UUID) ([]*domain.ReviewEvent, error) {
+					return nil, serviceErr
+				},
+			}, nil, testLogger)
+
+			rr := serve(handler, "/cards/"+cardID.String()+"/history")
+
+			assert.Equal(t, expected, rr.Code, serviceErr.Error())
+		}
+	})
+
+	t.Run("invalid card ID is rejected", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{}, nil, testLogger)
+
+		rr := serve(handler, "/cards/not-a-uuid/history")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetCard(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	updatedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	card := &domain.Card{
+		ID:        cardID,
+		UserID:    userID,
+		MemoID:    uuid.New(),
+		Content:   json.RawMessage(`{"front":"Front","back":"Back"}`),
+		Version:   1,
+		UpdatedAt: updatedAt,
+	}
+
+	serve := func(handler *CardHandler, path, ifNoneMatch string) *httptest.ResponseRecorder {
+		router := chi.NewRouter()
+		router.Get("/cards/{id}", handler.GetCard)
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("conditional request returns 304 until the card changes", func(t *testing.T) {
+		current := *card
+		handler := NewCardHandler(&mockCardReviewService{}, &mockCardService{
+			getUserCardFn: func(ctx context.Context, id, card uuid.UUID) (*domain.Card, error) {
+				assert.Equal(t, userID, id)
+				assert.Equal(t, cardID, card)
+				c := current
+				return &c, nil
+			},
+		}, testLogger)
+		path := "/cards/" + cardID.String()
+
+		first := serve(handler, path, "")
+		require.Equal(t, http.StatusOK, first.Code)
+		etag := first.Header().Get("ETag")
+		assert.True(t, strings.HasPrefix(etag, `W/"`), "ETag %q should be weak", etag)
+		var response CardResponse
+		require.NoError(t, json.Unmarshal(first.Body.Bytes(), &response))
+		assert.Equal(t, cardID.String(), response.ID)
+
+		notModified := serve(handler, path, etag)
+		assert.Equal(t, http.StatusNotModified, notModified.Code)
+		assert.Equal(t, etag, notModified.Header().Get("ETag"))
+		assert.Empty(t, notModified.Body.String())
+
+		// An edit changes the ETag, so the stale one no longer matches
+		current.UpdatedAt = updatedAt.Add(time.Minute)
+		changed := serve(handler, path, etag)
+		assert.Equal(t, http.StatusOK, changed.Code)
+		assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+	})
+
+	t.Run("service errors are mapped", func(t *testing.T) {
+		tests := map[error]int{
+			service.NewCardServiceError("get_user_card", "card not found", store.ErrCardNotFound): http.StatusNotFound,
+			card_review.ErrCardNotOwned: http.StatusForbidden,
+		}
+		for serviceErr, expected := range tests {
+			handler := NewCardHandler(&mockCardReviewService{}, &mockCardService{
+				getUserCardFn: func(ctx context.Context, id, card uuid.UUID) (*domain.Card, error) {
+					return nil, serviceErr
+				},
+			}, testLogger)
+
+			rr := serve(handler, "/cards/"+cardID.String(), "")
+
+			assert.Equal(t, expected, rr.Code, serviceErr.Error())
+		}
+	})
+
+	t.Run("invalid card ID is rejected", func(t *testing.T) {
+		handler := NewCardHandler(&mockCardReviewService{}, &mockCardService{}, testLogger)
+
+		rr := serve(handler, "/cards/not-a-uuid", "")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,474 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/redact"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+)
+
+const (
+	// DefaultDeckCardsLimit is the number of cards returned per page when no limit is given
+	DefaultDeckCardsLimit = 50
+
+	// MaxDeckCardsLimit is the largest page size accepted for deck card listings
+	MaxDeckCardsLimit = 200
+)
+
+// DeckRequest represents the request body for creating or renaming a deck
+type DeckRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// AssignCardDeckRequest represents the request body for moving a card into a deck.
+// A null deck_id removes the card from its deck.
+type AssignCardDeckRequest struct {
+	DeckID *uuid.UUID `json:"deck_id"`
+}
+
+// AssignMemoDeckRequest represents the request body for moving a memo into a deck.
+// A null deck_id removes the memo from its deck.
+type AssignMemoDeckRequest struct {
+	DeckID *uuid.UUID `json:"deck_id"`
+}
+
+// MoveCardsRequest represents the request body for moving several cards into a deck
+type MoveCardsRequest struct {
+	CardIDs []uuid.UUID `json:"card_ids" validate:"required,min=1,max=500"`
+}
+
+// CardMoveResultResponse reports the outcome of moving one card.
+// Status is "moved", "not_found" or "not_owned".
+type CardMoveResultResponse struct {
+	CardID string `json:"card_id"`
+	Status string `json:"status"`
+}
+
+// MoveCardsResponse represents the response body for a bulk card move
+type MoveCardsResponse struct {
+	Results []CardMoveResultResponse `json:"results"`
+}
+
+// DeckSRSSettings represents a deck's SRS overrides in requests and responses.
+// Omitted or null fields use the user's and the algorithm's defaults.
+type DeckSRSSettings struct {
+	NewCardsPerDay         *int     `json:"new_cards_per_day,omitempty"`
+	AgainReviewMinutes     *int     `json:"again_review_minutes,omitempty"`
+	InitialEaseFactor      *float64 `json:"initial_ease_factor,omitempty"`
+	LearningSteps          []int    `json:"learning_steps,omitempty"`
+	NewCardIntervalDays    *int     `json:"new_card_interval_days,omitempty"`
+	NewCardsDueImmediately *bool    `json:"new_cards_due_immediately,omitempty"`
+
+	// ReviewOrder is one of due-date-asc, random-due or lowest-ease-first
+	ReviewOrder *domain.ReviewOrder `json:"review_order,omitempty"`
+}
+
+// DeckResponse represents a deck in API responses
+type DeckResponse struct {
+	ID          string          `json:"id"`
+	UserID      string          `json:"user_id"`
+	Name        string          `json:"name"`
+	SRSSettings DeckSRSSettings `json:"srs_settings"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// DeckHandler handles deck-related HTTP requests
+type DeckHandler struct {
+	deckService service.DeckService
+	logger      *slog.Logger
+}
+
+// NewDeckHandler creates a new DeckHandler
+func NewDeckHandler(deckService service.DeckService, logger *slog.Logger) *DeckHandler {
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for DeckHandler")
+	}
+
+	return &DeckHandler{
+		deckService: deckService,
+		logger:      logger.With(slog.String("component", "deck_handler")),
+	}
+}
+
+// CreateDeck handles POST /decks requests
+func (h *DeckHandler) CreateDeck(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req DeckRequest
+	if !h.decodeDeckRequest(w, r, &req) {
+		return
+	}
+
+	deck, err := h.deckService.CreateDeck(r.Context(), userID, req.Name)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to create deck")
+		return
+	}
+
+	log.Debug("deck created",
+		slog.String("user_id", userID.String()),
+		slog.String("deck_id", deck.ID.String()))
+	shared.RespondWithJSON(w, r, http.StatusCreated, deckToResponse(deck))
+}
+
+// ListDecks handles GET /decks requests
+func (h *DeckHandler) ListDecks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	decks, err := h.deckService.ListDecks(r.Context(), userID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to list decks")
+		return
+	}
+
+	response := make([]DeckResponse, 0, len(decks))
+	for _, deck := range decks {
+		response = append(response, deckToResponse(deck))
+	}
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// GetDeck handles GET /decks/{id} requests
+func (h *DeckHandler) GetDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	deckID, ok := h.deckID(w, r)
+	if !ok {
+		return
+	}
+
+	deck, err := h.deckService.GetDeck(r.Context(), userID, deckID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get deck")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, deckToResponse(deck))
+}
+
+// RenameDeck handles PUT /decks/{id} requests
+func (h *DeckHandler) RenameDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	deckID, ok := h.deckID(w, r)
+	if !ok {
+		return
+	}
+
+	var req DeckRequest
+	if !h.decodeDeckRequest(w, r, &req) {
+		return
+	}
+
+	deck, err := h.deckService.RenameDeck(r.Context(), userID, deckID, req.Name)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to rename deck")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, deckToResponse(deck))
+}
+
+// UpdateDeckSettings handles PUT /decks/{id}/settings requests
+// The body replaces all of the deck's SRS overrides; omitted fields are cleared.
+func (h *DeckHandler) UpdateDeckSettings(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	deckID, ok := h.deckID(w, r)
+	if !ok {
+		return
+	}
+
+	var req DeckSRSSettings
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	deck, err := h.deckService.UpdateSRSSettings(r.Context(), userID, deckID, domain.DeckSRSSettings(req))
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to update deck settings")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, deckToResponse(deck))
+}
+
+// DeleteDeck handles DELETE /decks/{id} requests
+// The deck's cards are kept and no longer belong to any deck.
+func (h *DeckHandler) DeleteDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	deckID, ok := h.deckID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.deckService.DeleteDeck(r.Context(), userID, deckID); err != nil {
+		HandleAPIError(w, r, err, "Failed to delete deck")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeckCards handles GET /decks/{id}/cards requests
+// It accepts optional limit (default DefaultDeckCardsLimit, capped at
+// MaxDeckCardsLimit) and offset query parameters.
+func (h *DeckHandler) ListDeckCards(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	deckID, ok := h.deckID(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset, err := parsePaginationQuery(r, DefaultDeckCardsLimit, MaxDeckCardsLimit)
+	if err != nil {
+		log.Warn("invalid pagination parameters", slog.String("error", err.Error()))
+		HandleAPIError(w, r, err, "Invalid pagination parameters")
+		return
+	}
+
+	cards, err := h.deckService.ListDeckCards(r.Context(), userID, deckID, limit, offset)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to list deck cards")
+		return
+	}
+
+	response := make([]CardResponse, 0, len(cards))
+	for _, card := range cards {
+		response = append(response, cardToResponse(card))
+	}
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// GetNextDeckCard handles GET /decks/{id}/cards/next requests
+// It responds with 204 No Content when no card in the deck is due.
+func (h *DeckHandler) GetNextDeckCard(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	deckID, ok := h.deckID(w, r)
+	if !ok {
+		return
+	}
+
+	card, err := h.deckService.GetNextCard(r.Context(), userID, deckID)
+	if errors.Is(err, card_review.ErrNoCardsDue) {
+		log.Debug("no cards due for review in deck",
+			slog.String("user_id", userID.String()),
+			slog.String("deck_id", deckID.String()))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get next review card")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, cardToResponse(card))
+}
+
+// AssignCardDeck handles PUT /cards/{id}/deck requests
+// It moves the card into the deck given in the body, or out of its deck if deck_id is null.
+func (h *DeckHandler) AssignCardDeck(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	cardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid card ID format", slog.String("card_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid card ID format")
+		return
+	}
+
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req AssignCardDeckRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	card, err := h.deckService.AssignCard(r.Context(), userID, cardID, req.DeckID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to assign card to deck")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, cardToResponse(card))
+}
+
+// MoveCards handles POST /decks/{id}/cards requests
+// It moves the listed cards into the deck in one transaction and reports a result
+// per card. Cards the user does not own are skipped rather than failing the request.
+func (h *DeckHandler) MoveCards(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	deckID, ok := h.deckID(w, r)
+	if !ok {
+		return
+	}
+
+	var req MoveCardsRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+	if err := shared.Validate.Struct(req); err != nil {
+		log.Warn("validation error", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	results, err := h.deckService.MoveCards(r.Context(), userID, deckID, req.CardIDs)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to move cards to deck")
+		return
+	}
+
+	response := MoveCardsResponse{Results: make([]CardMoveResultResponse, len(results))}
+	for i, result := range results {
+		response.Results[i] = CardMoveResultResponse{
+			CardID: result.CardID.String(),
+			Status: result.Status,
+		}
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// userID extracts the authenticated user's ID from the request context,
+// responding with 401 Unauthorized if it is missing.
+func (h *DeckHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.FromContextOrDefault(r.Context(), h.logger).
+			Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// deckID parses the deck ID from the URL path, responding with 400 Bad Request if it is invalid.
+func (h *DeckHandler) deckID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	deckID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.FromContextOrDefault(r.Context(), h.logger).
+			Warn("invalid deck ID format", slog.String("deck_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid deck ID format")
+		return uuid.Nil, false
+	}
+	return deckID, true
+}
+
+// decodeDeckRequest decodes and validates a DeckRequest body,
+// responding with 400 Bad Request if it is invalid.
+func (h *DeckHandler) decodeDeckRequest(w http.ResponseWriter, r *http.Request, req *DeckRequest) bool {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	if err := shared.DecodeJSON(r, req); err != nil {
+		log.Warn("invalid request format", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return false
+	}
+	if err := shared.Validate.Struct(req); err != nil {
+		log.Warn("validation error", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return false
+	}
+	return true
+}
+
+// deckToResponse converts a domain.Deck to a DeckResponse
+func deckToResponse(deck *domain.Deck) DeckResponse {
+	return DeckResponse{
+		ID:          deck.ID.String(),
+		UserID:      deck.UserID.String(),
+		Name:        deck.Name,
+		SRSSettings: DeckSRSSettings(deck.SRSSettings),
+		CreatedAt:   deck.CreatedAt,
+		UpdatedAt:   deck.UpdatedAt,
+	}
+}
+
+// AssignMemoDeck handles PUT /memos/{id}/deck requests
+// It moves the memo into the deck given in the body, or out of its deck if deck_id is null.
+// Cards generated from the memo afterwards are placed in its deck.
+func (h *DeckHandler) AssignMemoDeck(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	memoID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Warn("invalid memo ID format", slog.String("memo_id", chi.URLParam(r, "id")))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid memo ID format")
+		return
+	}
+
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req AssignMemoDeckRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		log.Warn("invalid request format", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	memo, err := h.deckService.AssignMemo(r.Context(), userID, memoID, req.DeckID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to assign memo to deck")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, memoToDTOResponse(memo))
+}
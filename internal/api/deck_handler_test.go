@@ -0,0 +1,661 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDeckService is a mock implementation of service.DeckService for testing
+type MockDeckService struct {
+	CreateDeckFn    func(ctx context.Context, userID uuid.UUID, name string) (*domain.Deck, error)
+	ListDecksFn     func(ctx context.Context, userID uuid.UUID) ([]*domain.Deck, error)
+	GetDeckFn       func(ctx context.Context, userID, deckID uuid.UUID) (*domain.Deck, error)
+	RenameDeckFn    func(ctx context.Context, userID, deckID uuid.UUID, name string) (*domain.Deck, error)
+	DeleteDeckFn    func(ctx context.Context, userID, deckID uuid.UUID) error
+	ListDeckCardsFn func(ctx context.Context, userID, deckID uuid.UUID, limit, offset int) ([]*domain.Card, error)
+	AssignCardFn    func(ctx context.Context, userID, cardID uuid.UUID, deckID *uuid.UUID) (*domain.Card, error)
+	GetNextCardFn   func(ctx context.Context, userID, deckID uuid.UUID) (*domain.Card, error)
+	AssignMemoFn    func(ctx context.Context, userID, memoID uuid.UUID, deckID *uuid.UUID) (*domain.Memo, error)
+
+	EnsureDefaultDeckFn func(ctx context.Context, userID uuid.UUID) (*domain.Deck, error)
+	MoveCardsFn         func(ctx context.Context, userID, deckID uuid.UUID, cardIDs []uuid.UUID) ([]service.CardMoveResult, error)
+	UpdateSRSSettingsFn func(ctx context.Context, userID, deckID uuid.UUID, settings domain.DeckSRSSettings) (*domain.Deck, error)
+}
+
+// CreateDeck implements service.DeckService
+func (m *MockDeckService) CreateDeck(ctx context.Context, userID uuid.UUID, name string) (*domain.Deck, error) {
+	if m.CreateDeckFn != nil {
+		return m.CreateDeckFn(ctx, userID, name)
+	}
+	return nil, nil
+}
+
+// ListDecks implements service.DeckService
+func (m *MockDeckService) ListDecks(ctx context.Context, userID uuid.UUID) ([]*domain.Deck, error) {
+	if m.ListDecksFn != nil {
+		return m.ListDecksFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+// GetDeck implements service.DeckService
+func (m *MockDeckService) GetDeck(ctx context.Context, userID, deckID uuid.UUID) (*domain.Deck, error) {
+	if m.GetDeckFn != nil {
+		return m.GetDeckFn(ctx, userID, deckID)
+	}
+	return nil, nil
+}
+
+// RenameDeck implements service.DeckService
+func (m *MockDeckService) RenameDeck(
+	ctx context.Context,
+	userID, deckID uuid.UUID,
+	name string,
+) (*domain.Deck, error) {
+	if m.RenameDeckFn != nil {
+		return m.RenameDeckFn(ctx, userID, deckID, name)
+	}
+	return nil, nil
+}
+
+// DeleteDeck implements service.DeckService
+func (m *MockDeckService) DeleteDeck(ctx context.Context, userID, deckID uuid.UUID) error {
+	if m.DeleteDeckFn != nil {
+		return m.DeleteDeckFn(ctx, userID, deckID)
+	}
+	return nil
+}
+
+// ListDeckCards implements service.DeckService
+func (m *MockDeckService) ListDeckCards(
+	ctx context.Context,
+	userID, deckID uuid.UUID,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	if m.ListDeckCardsFn != nil {
+		return m.ListDeckCardsFn(ctx, userID, deckID, limit, offset)
+	}
+	return nil, nil
+}
+
+// AssignCard implements service.DeckService
+func (m *MockDeckService) AssignCard(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+	deckID *uuid.UUID,
+) (*domain.Card, error) {
+	if m.AssignCardFn != nil {
+		return m.AssignCardFn(ctx, userID, cardID, deckID)
+	}
+	return nil, nil
+}
+
+// AssignMemo implements service.DeckService
+func (m *MockDeckService) AssignMemo(
+	ctx context.Context,
+	userID, memoID uuid.UUID,
+	deckID *uuid.UUID,
+) (*domain.Memo, error) {
+	if m.AssignMemoFn != nil {
+		return m.AssignMemoFn(ctx, userID, memoID, deckID)
+	}
+	return nil, nil
+}
+
+// GetNextCard implements service.DeckService
+func (m *MockDeckService) GetNextCard(ctx context.Context, userID, deckID uuid.UUID) (*domain.Card, error) {
+	if m.GetNextCardFn != nil {
+		return m.GetNextCardFn(ctx, userID, deckID)
+	}
+	return nil, nil
+}
+
+// EnsureDefaultDeck implements service.DeckService
+func (m *MockDeckService) EnsureDefaultDeck(ctx context.Context, userID uuid.UUID) (*domain.Deck, error) {
+	if m.EnsureDefaultDeckFn != nil {
+		return m.EnsureDefaultDeckFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+// MoveCards implements service.DeckService
+func (m *MockDeckService) MoveCards(
+	ctx context.Context,
+	userID, deckID uuid.UUID,
+	cardIDs []uuid.UUID,
+) ([]service.CardMoveResult, error) {
+	if m.MoveCardsFn != nil {
+		return m.MoveCardsFn(ctx, userID, deckID, cardIDs)
+	}
+	return nil, nil
+}
+
+// UpdateSRSSettings implements service.DeckService
+func (m *MockDeckService) UpdateSRSSettings(
+	ctx context.Context,
+	userID, deckID uuid.UUID,
+	settings domain.DeckSRSSettings,
+) (*domain.Deck, error) {
+	if m.UpdateSRSSettingsFn != nil {
+		return m.UpdateSRSSettingsFn(ctx, userID, deckID, settings)
+	}
+	return nil, nil
+}
+
+var _ service.DeckService = (*MockDeckService)(nil)
+
+// newDeckRequest builds an authenticated request with the given chi "id" URL parameter
+func newDeckRequest(method, target, body, id string, userID uuid.UUID) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	ctx := context.WithValue(req.Context(), shared.UserIDContextKey, userID)
+	if id != "" {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+// TestDeckHandler_CreateDeck tests the CreateDeck handler.
+func TestDeckHandler_CreateDeck(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"created", `{"name":"Spanish"}`, nil, http.StatusCreated},
+		{"missing_name", `{}`, nil, http.StatusBadRequest},
+		{
+			"invalid_name",
+			`{"name":"   "}`,
+			domain.NewValidationError("name", "deck name cannot be empty", domain.ErrDeckNameEmpty),
+			http.StatusBadRequest,
+		},
+		{"duplicate_name", `{"name":"Spanish"}`, store.ErrDeckNameExists, http.StatusConflict},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewDeckHandler(&MockDeckService{
+				CreateDeckFn: func(ctx context.Context, id uuid.UUID, name string) (*domain.Deck, error) {
+					assert.Equal(t, userID, id)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.Deck{ID: uuid.New(), UserID: id, Name: name}, nil
+				},
+			}, slog.Default())
+
+			w := httptest.NewRecorder()
+			handler.CreateDeck(w, newDeckRequest(http.MethodPost, "/api/decks", tc.body, "", userID))
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedStatus == http.StatusCreated {
+				var resp DeckResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, "Spanish", resp.Name)
+				assert.Equal(t, userID.String(), resp.UserID)
+			}
+		})
+	}
+}
+
+// TestDeckHandler_GetDeck tests ownership and ID handling for a single deck.
+func TestDeckHandler_GetDeck(t *testing.T) {
+	userID := uuid.New()
+	deckID := uuid.New()
+
+	tests := []struct {
+		name           string
+		id             string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"found", deckID.String(), nil, http.StatusOK},
+		{"invalid_id", "not-a-uuid", nil, http.StatusBadRequest},
+		{"not_found", deckID.String(), store.ErrDeckNotFound, http.StatusNotFound},
+		{"not_owned", deckID.String(), service.ErrDeckNotOwned, http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewDeckHandler(&MockDeckService{
+				GetDeckFn: func(ctx context.Context, uid, id uuid.UUID) (*domain.Deck, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.Deck{ID: id, UserID: uid, Name: "Spanish"}, nil
+				},
+			}, slog.Default())
+
+			w := httptest.NewRecorder()
+			handler.GetDeck(w, newDeckRequest(http.MethodGet, "/api/decks/"+tc.id, "", tc.id, userID))
+
+			assert.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+
+	t.Run("missing_user_id", func(t *testing.T) {
+		handler := NewDeckHandler(&MockDeckService{}, slog.Default())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/decks/"+deckID.String(), nil)
+		w := httptest.NewRecorder()
+		handler.GetDeck(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+// TestDeckHandler_ListDeckCards tests pagination parameters for deck card listings.
+func TestDeckHandler_ListDeckCards(t *testing.T) {
+	userID := uuid.New()
+	deckID := uuid.New()
+	card := &domain.Card{
+		ID:      uuid.New(),
+		UserID:  userID,
+		MemoID:  uuid.New(),
+		DeckID:  &deckID,
+		Content: json.RawMessage(`{"front":"Q","back":"A"}`),
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{"defaults", "", http.StatusOK, DefaultDeckCardsLimit, 0},
+		{"explicit_page", "?limit=10&offset=20", http.StatusOK, 10, 20},
+		{"limit_is_capped", "?limit=100000", http.StatusOK, MaxDeckCardsLimit, 0},
+		{"invalid_limit", "?limit=0", http.StatusBadRequest, 0, 0},
+		{"invalid_offset", "?offset=-1", http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			handler := NewDeckHandler(&MockDeckService{
+				ListDeckCardsFn: func(ctx context.Context, uid, id uuid.UUID, limit, offset int) ([]*domain.Card, error) {
+					called = true
+					assert.Equal(t, deckID, id)
+					assert.Equal(t, tc.expectedLimit, limit)
+					assert.Equal(t, tc.expectedOffset, offset)
+					return []*domain.Card{card}, nil
+				},
+			}, slog.Default())
+
+			target := "/api/decks/" + deckID.String() + "/cards" + tc.query
+			w := httptest.NewRecorder()
+			handler.ListDeckCards(w, newDeckRequest(http.MethodGet, target, "", deckID.String(), userID))
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			assert.Equal(t, tc.expectedStatus == http.StatusOK, called)
+			if tc.expectedStatus == http.StatusOK {
+				var resp []CardResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				require.Len(t, resp, 1)
+				require.NotNil(t, resp[0].DeckID)
+				assert.Equal(t, deckID.String(), *resp[0].DeckID)
+			}
+		})
+	}
+}
+
+// TestDeckHandler_GetNextDeckCard tests the deck-scoped next card handler.
+func TestDeckHandler_GetNextDeckCard(t *testing.T) {
+	userID := uuid.New()
+	deckID := uuid.New()
+	card := &domain.Card{
+		ID:      uuid.New(),
+		UserID:  userID,
+		MemoID:  uuid.New(),
+		DeckID:  &deckID,
+		Content: json.RawMessage(`{"front":"Q","back":"A"}`),
+	}
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"card_due", nil, http.StatusOK},
+		{"no_cards_due", card_review.ErrNoCardsDue, http.StatusNoContent},
+		{"not_owned", service.ErrDeckNotOwned, http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewDeckHandler(&MockDeckService{
+				GetNextCardFn: func(ctx context.Context, uid, id uuid.UUID) (*domain.Card, error) {
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, deckID, id)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return card, nil
+				},
+			}, slog.Default())
+
+			target := "/api/decks/" + deckID.String() + "/cards/next"
+			w := httptest.NewRecorder()
+			handler.GetNextDeckCard(w, newDeckRequest(http.MethodGet, target, "", deckID.String(), userID))
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedStatus == http.StatusOK {
+				var resp CardResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, card.ID.String(), resp.ID)
+			}
+		})
+	}
+}
+
+// TestDeckHandler_AssignCardDeck tests moving a card into and out of a deck.
+func TestDeckHandler_AssignCardDeck(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	deckID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedDeckID *uuid.UUID
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"assign", `{"deck_id":"` + deckID.String() + `"}`, &deckID, nil, http.StatusOK},
+		{"unassign", `{"deck_id":null}`, nil, nil, http.StatusOK},
+		{"invalid_deck_id", `{"deck_id":"nope"}`, nil, nil, http.StatusBadRequest},
+		{"card_not_owned", `{"deck_id":null}`, nil, card_review.ErrCardNotOwned, http.StatusForbidden},
+		{
+			"deck_not_owned",
+			`{"deck_id":"` + deckID.String() + `"}`,
+			&deckID,
+			service.ErrDeckNotOwned,
+			http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewDeckHandler(&MockDeckService{
+				AssignCardFn: func(ctx context.Context, uid, id uuid.UUID, did *uuid.UUID) (*domain.Card, error) {
+					assert.Equal(t, cardID, id)
+					assert.Equal(t, tc.expectedDeckID, did)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.Card{
+						ID:      id,
+						UserID:  uid,
+						MemoID:  uuid.New(),
+						DeckID:  did,
+						Content: json.RawMessage(`{}`),
+					}, nil
+				},
+			}, slog.Default())
+
+			target := "/api/cards/" + cardID.String() + "/deck"
+			w := httptest.NewRecorder()
+			handler.AssignCardDeck(w, newDeckRequest(http.MethodPut, target, tc.body, cardID.String(), userID))
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedStatus == http.StatusOK {
+				var resp map[string]interface{}
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				if tc.expectedDeckID == nil {
+					assert.NotContains(t, resp, "deck_id")
+				} else {
+					assert.Equal(t, deckID.String(), resp["deck_id"])
+				}
+			}
+		})
+	}
+}
+
+// TestDeckHandler_AssignMemoDeck tests moving a memo into and out of a deck.
+func TestDeckHandler_AssignMemoDeck(t *testing.T) {
+	userID := uuid.New()
+	memoID := uuid.New()
+	deckID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedDeckID *uuid.UUID
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"assign", `{"deck_id":"` + deckID.String() + `"}`, &deckID, nil, http.StatusOK},
+		{"unassign", `{"deck_id":null}`, nil, nil, http.StatusOK},
+		{"invalid_deck_id", `{"deck_id":"nope"}`, nil, nil, http.StatusBadRequest},
+		{"memo_not_owned", `{"deck_id":null}`, nil, service.ErrMemoNotOwned, http.StatusForbidden},
+		{"memo_not_found", `{"deck_id":null}`, nil, store.ErrMemoNotFound, http.StatusNotFound},
+		{
+			"deck_not_owned",
+			`{"deck_id":"` + deckID.String() + `"}`,
+			&deckID,
+			service.ErrDeckNotOwned,
+			http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewDeckHandler(&MockDeckService{
+				AssignMemoFn: func(ctx context.Context, uid, id uuid.UUID, did *uuid.UUID) (*domain.Memo, error) {
+					assert.Equal(t, memoID, id)
+					assert.Equal(t, tc.expectedDeckID, did)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.Memo{
+						ID:     id,
+						UserID: uid,
+						Text:   "Notes",
+						Status: domain.MemoStatusDraft,
+						DeckID: did,
+					}, nil
+				},
+			}, slog.Default())
+
+			target := "/api/memos/" + memoID.String() + "/deck"
+			w := httptest.NewRecorder()
+			handler.AssignMemoDeck(w, newDeckRequest(http.MethodPut, target, tc.body, memoID.String(), userID))
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedStatus == http.StatusOK {
+				var resp map[string]interface{}
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, memoID.String(), resp["id"])
+				if tc.expectedDeckID == nil {
+					assert.NotContains(t, resp, "deck_id")
+				} else {
+					assert.Equal(t, deckID.String(), resp["deck_id"])
+				}
+			}
+		})
+	}
+}
+
+// TestDeckHandler_MoveCards tests moving several cards into a deck.
+func TestDeckHandler_MoveCards(t *testing.T) {
+	userID := uuid.New()
+	deckID := uuid.New()
+	ownedID := uuid.New()
+	foreignID := uuid.New()
+	body := `{"card_ids":["` + ownedID.String() + `","` + foreignID.String() + `"]}`
+
+	tests := []struct {
+		name           string
+		deckID         string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectCall     bool
+	}{
+		{"moves_cards", deckID.String(), body, nil, http.StatusOK, true},
+		{"invalid_deck_id", "nope", body, nil, http.StatusBadRequest, false},
+		{"empty_list", deckID.String(), `{"card_ids":[]}`, nil, http.StatusBadRequest, false},
+		{"invalid_card_id", deckID.String(), `{"card_ids":["nope"]}`, nil, http.StatusBadRequest, false},
+		{"deck_not_owned", deckID.String(), body, service.ErrDeckNotOwned, http.StatusForbidden, true},
+		{"deck_not_found", deckID.String(), body, store.ErrDeckNotFound, http.StatusNotFound, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			handler := NewDeckHandler(&MockDeckService{
+				MoveCardsFn: func(
+					ctx context.Context,
+					uid, did uuid.UUID,
+					cardIDs []uuid.UUID,
+				) ([]service.CardMoveResult, error) {
+					called = true
+					assert.Equal(t, userID, uid)
+					assert.Equal(t, deckID, did)
+					assert.Equal(t, []uuid.UUID{ownedID, foreignID}, cardIDs)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return []service.CardMoveResult{
+						{CardID: ownedID, Status: service.CardMoveStatusMoved},
+						{CardID: foreignID, Status: service.CardMoveStatusNotOwned},
+					}, nil
+				},
+			}, slog.Default())
+
+			w := httptest.NewRecorder()
+			target := "/api/decks/" + tc.deckID + "/cards"
+			handler.MoveCards(w, newDeckRequest(http.MethodPost, target, tc.body, tc.deckID, userID))
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			assert.Equal(t, tc.expectCall, called)
+			if tc.expectedStatus == http.StatusOK {
+				var resp MoveCardsResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, []CardMoveResultResponse{
+					{CardID: ownedID.String(), Status: "moved"},
+					{CardID: foreignID.String(), Status: "not_owned"},
+				}, resp.Results)
+			}
+		})
+	}
+}
+
+// TestDeckHandler_UpdateDeckSettings tests replacing a deck's SRS overrides.
+func TestDeckHandler_UpdateDeckSettings(t *testing.T) {
+	userID := uuid.New()
+	deckID := uuid.New()
+	newCards := 40
+	ease := 2.1
+	interval := 3
+	dueImmediately := false
+	order := domain.ReviewOrderLowestEase
+
+	tests := []struct {
+		name             string
+		body             string
+		expectedSettings domain.DeckSRSSettings
+		serviceErr       error
+		expectedStatus   int
+	}{
+		{
+			"set_overrides",
+			`{"new_cards_per_day":40,"initial_ease_factor":2.1}`,
+			domain.DeckSRSSettings{NewCardsPerDay: &newCards, InitialEaseFactor: &ease},
+			nil,
+			http.StatusOK,
+		},
+		{
+			"set_learning_steps",
+			`{"learning_steps":[1,10]}`,
+			domain.DeckSRSSettings{LearningSteps: []int{1, 10}},
+			nil,
+			http.StatusOK,
+		},
+		{
+			"set_new_card_settings",
+			`{"new_card_interval_days":3,"new_cards_due_immediately":false}`,
+			domain.DeckSRSSettings{NewCardIntervalDays: &interval, NewCardsDueImmediately: &dueImmediately},
+			nil,
+			http.StatusOK,
+		},
+		{
+			"set_review_order",
+			`{"review_order":"lowest-ease-first"}`,
+			domain.DeckSRSSettings{ReviewOrder: &order},
+			nil,
+			http.StatusOK,
+		},
+		{"clear_overrides", `{}`, domain.DeckSRSSettings{}, nil, http.StatusOK},
+		{"malformed_body", `{"new_cards_per_day":"many"}`, domain.DeckSRSSettings{}, nil, http.StatusBadRequest},
+		{
+			"out_of_range",
+			`{"new_cards_per_day":40,"initial_ease_factor":2.1}`,
+			domain.DeckSRSSettings{NewCardsPerDay: &newCards, InitialEaseFactor: &ease},
+			domain.NewValidationError(
+				"initial_ease_factor",
+				domain.ErrDeckInitialEaseFactorInvalid.Error(),
+				domain.ErrDeckInitialEaseFactorInvalid,
+			),
+			http.StatusBadRequest,
+		},
+		{
+			"deck_not_owned",
+			`{}`,
+			domain.DeckSRSSettings{},
+			service.ErrDeckNotOwned,
+			http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewDeckHandler(&MockDeckService{
+				UpdateSRSSettingsFn: func(
+					ctx context.Context,
+					uid, did uuid.UUID,
+					settings domain.DeckSRSSettings,
+				) (*domain.Deck, error) {
+					assert.Equal(t, deckID, did)
+					assert.Equal(t, tc.expectedSettings, settings)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &domain.Deck{ID: did, UserID: uid, Name: "Exam", SRSSettings: settings}, nil
+				},
+			}, slog.Default())
+
+			w := httptest.NewRecorder()
+			target := "/api/decks/" + deckID.String() + "/settings"
+			handler.UpdateDeckSettings(w, newDeckRequest(http.MethodPut, target, tc.body, deckID.String(), userID))
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedStatus == http.StatusOK {
+				var resp DeckResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, DeckSRSSettings(tc.expectedSettings), resp.SRSSettings)
+			}
+		})
+	}
+}
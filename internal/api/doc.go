@@ -20,7 +20,9 @@
 //     HTTP responses with proper status codes and JSON formatting.
 //
 //  5. Error Handling: Transforming domain and application errors into meaningful
-//     HTTP error responses with appropriate status codes.
+//     HTTP error responses with appropriate status codes. Error bodies have the
+//     form {"error": "...", "code": "..."}, where code is a stable
+//     domain.ErrorCode such as CARD_NOT_FOUND that clients can switch on.
 //
 // The api package depends on the service package for business logic and the
 // domain package for entity definitions, but other packages should not depend
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/phrazzld/scry-api/internal/api/shared"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
 	"github.com/phrazzld/scry-api/internal/service/auth"
 	"github.com/phrazzld/scry-api/internal/service/card_review"
 	"github.com/phrazzld/scry-api/internal/store"
@@ -21,6 +23,7 @@ func MapErrorToStatusCode(err error) int {
 	// Authentication errors
 	case errors.Is(err, auth.ErrInvalidToken),
 		errors.Is(err, auth.ErrExpiredToken),
+		errors.Is(err, auth.ErrTokenNotYetValid),
 		errors.Is(err, auth.ErrInvalidRefreshToken),
 		errors.Is(err, auth.ErrExpiredRefreshToken),
 		errors.Is(err, auth.ErrWrongTokenType),
@@ -28,13 +31,18 @@ func MapErrorToStatusCode(err error) int {
 		return http.StatusUnauthorized
 
 	// Authorization errors
-	case errors.Is(err, card_review.ErrCardNotOwned):
+	case errors.Is(err, card_review.ErrCardNotOwned),
+		errors.Is(err, auth.ErrAccountDisabled),
+		errors.Is(err, domain.ErrForbidden),
+		errors.Is(err, service.ErrMemoNotOwned),
+		errors.Is(err, service.ErrDeckNotOwned):
 		return http.StatusForbidden
 
 	// Not found errors
 	case errors.Is(err, store.ErrUserNotFound),
 		errors.Is(err, store.ErrCardNotFound),
 		errors.Is(err, store.ErrMemoNotFound),
+		errors.Is(err, store.ErrDeckNotFound),
 		errors.Is(err, store.ErrNotFound),
 		errors.Is(err, card_review.ErrCardNotFound),
 		errors.Is(err, card_review.ErrCardStatsNotFound):
@@ -42,9 +50,21 @@ func MapErrorToStatusCode(err error) int {
 
 	// Conflict errors
 	case errors.Is(err, store.ErrEmailExists),
-		errors.Is(err, store.ErrDuplicate):
+		errors.Is(err, store.ErrDeckNameExists),
+		errors.Is(err, store.ErrDuplicate),
+		errors.Is(err, service.ErrMemoNotDraft),
+		errors.Is(err, service.ErrMemoGenerationInProgress),
+		errors.Is(err, service.ErrCardsNotDuplicates),
+		errors.Is(err, store.ErrVersionConflict),
+		errors.Is(err, auth.ErrTokenAlreadyUsed),
+		errors.Is(err, domain.ErrMemoStatusTransitionInvalid):
 		return http.StatusConflict
 
+	// Card content over the size limit, checked before other validation errors
+	// since it is reported as one
+	case errors.Is(err, domain.ErrCardContentTooLarge):
+		return http.StatusRequestEntityTooLarge
+
 	// Bad request errors - validation errors and invalid entities
 	case errors.Is(err, store.ErrInvalidEntity),
 		errors.Is(err, card_review.ErrInvalidAnswer),
@@ -56,13 +76,30 @@ func MapErrorToStatusCode(err error) int {
 		errors.Is(err, domain.ErrEmptyContent),
 		errors.Is(err, domain.ErrInvalidReviewOutcome),
 		errors.Is(err, domain.ErrInvalidCardContent),
-		errors.Is(err, domain.ErrInvalidMemoStatus):
+		errors.Is(err, domain.ErrInvalidMemoStatus),
+		errors.Is(err, domain.ErrInvalidReviewOrder),
+		errors.Is(err, domain.ErrInvalidTag),
+		errors.Is(err, domain.ErrUserTimezoneInvalid):
 		return http.StatusBadRequest
 
+	// Unprocessable requests
+	case errors.Is(err, service.ErrIdempotencyKeyReused),
+		errors.Is(err, service.ErrImportRejected):
+		return http.StatusUnprocessableEntity
+
+	// Throttled requests
+	case errors.Is(err, auth.ErrTooManyLoginAttempts),
+		errors.Is(err, service.ErrGenerationQuotaExceeded):
+		return http.StatusTooManyRequests
+
 	// Special cases
 	case errors.Is(err, card_review.ErrNoCardsDue):
 		return http.StatusNoContent
 
+	// Work cut short by a request deadline, such as one set by the timeout middleware
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+
 	// Default: internal server error
 	default:
 		// Check if the error is a wrapped validation error
@@ -71,10 +108,242 @@ func MapErrorToStatusCode(err error) int {
 			return http.StatusBadRequest
 		}
 
+		// Domain errors that don't wrap a known sentinel are mapped by code
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) {
+			return statusForErrorCode(domainErr.Code)
+		}
+
+		return http.StatusInternalServerError
+	}
+}
+
+// statusForErrorCode maps an error code to the HTTP status code of the
+// sentinel errors it is derived from.
+func statusForErrorCode(code domain.ErrorCode) int {
+	switch code {
+	case domain.CodeInvalidToken,
+		domain.CodeTokenExpired,
+		domain.CodeUnauthorized,
+		domain.CodeInvalidCredentials:
+		return http.StatusUnauthorized
+
+	case domain.CodeForbidden,
+		domain.CodeEmailNotVerified,
+		domain.CodeAccountDisabled,
+		domain.CodeCardNotOwned,
+		domain.CodeMemoNotOwned,
+		domain.CodeDeckNotOwned:
+		return http.StatusForbidden
+
+	case domain.CodeNotFound,
+		domain.CodeUserNotFound,
+		domain.CodeCardNotFound,
+		domain.CodeCardStatsNotFound,
+		domain.CodeMemoNotFound,
+		domain.CodeDeckNotFound:
+		return http.StatusNotFound
+
+	case domain.CodeConflict,
+		domain.CodeEmailExists,
+		domain.CodeDeckNameExists,
+		domain.CodeVersionConflict,
+		domain.CodeTokenUsed,
+		domain.CodeMemoNotDraft,
+		domain.CodeMemoGenerating,
+		domain.CodeMemoTransition,
+		domain.CodeCardsNotDuplicates:
+		return http.StatusConflict
+
+	case domain.CodeBadRequest,
+		domain.CodeValidationFailed,
+		domain.CodeInvalidID,
+		domain.CodeInvalidEmail,
+		domain.CodeInvalidPassword,
+		domain.CodeInvalidOutcome,
+		domain.CodeInvalidCardContent,
+		domain.CodeInvalidMemoStatus,
+		domain.CodeInvalidTimezone:
+		return http.StatusBadRequest
+
+	case domain.CodeIdempotencyReused,
+		domain.CodeImportRejected:
+		return http.StatusUnprocessableEntity
+
+	case domain.CodeNoCardsDue:
+		return http.StatusNoContent
+
+	case domain.CodeRateLimited,
+		domain.CodeLoginLocked,
+		domain.CodeQuotaExceeded:
+		return http.StatusTooManyRequests
+
+	case domain.CodeRequestTooLarge,
+		domain.CodeCardContentTooLarge:
+		return http.StatusRequestEntityTooLarge
+
+	case domain.CodeUnsupportedMediaType:
+		return http.StatusUnsupportedMediaType
+
+	case domain.CodeRequestTimeout:
+		return http.StatusGatewayTimeout
+
+	default:
 		return http.StatusInternalServerError
 	}
 }
 
+// GetErrorCode returns the machine-readable code for an error. A DomainError
+// anywhere in the chain supplies its own code; otherwise known sentinel errors
+// are mapped to their codes, and anything else is an internal error.
+func GetErrorCode(err error) domain.ErrorCode {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+
+	switch {
+	// Authentication errors
+	case errors.Is(err, auth.ErrExpiredToken),
+		errors.Is(err, auth.ErrExpiredRefreshToken):
+		return domain.CodeTokenExpired
+
+	case errors.Is(err, auth.ErrInvalidToken),
+		errors.Is(err, auth.ErrTokenNotYetValid),
+		errors.Is(err, auth.ErrInvalidRefreshToken),
+		errors.Is(err, auth.ErrWrongTokenType):
+		return domain.CodeInvalidToken
+
+	case errors.Is(err, auth.ErrAccountDisabled):
+		return domain.CodeAccountDisabled
+
+	case errors.Is(err, domain.ErrUnauthorized):
+		return domain.CodeUnauthorized
+
+	// Authorization errors
+	case errors.Is(err, card_review.ErrCardNotOwned):
+		return domain.CodeCardNotOwned
+
+	case errors.Is(err, service.ErrMemoNotOwned):
+		return domain.CodeMemoNotOwned
+
+	case errors.Is(err, service.ErrDeckNotOwned):
+		return domain.CodeDeckNotOwned
+
+	case errors.Is(err, domain.ErrForbidden):
+		return domain.CodeForbidden
+
+	// Not found errors
+	case errors.Is(err, store.ErrUserNotFound):
+		return domain.CodeUserNotFound
+
+	case errors.Is(err, store.ErrCardNotFound),
+		errors.Is(err, card_review.ErrCardNotFound):
+		return domain.CodeCardNotFound
+
+	case errors.Is(err, card_review.ErrCardStatsNotFound):
+		return domain.CodeCardStatsNotFound
+
+	case errors.Is(err, store.ErrMemoNotFound):
+		return domain.CodeMemoNotFound
+
+	case errors.Is(err, store.ErrDeckNotFound):
+		return domain.CodeDeckNotFound
+
+	case errors.Is(err, store.ErrNotFound):
+		return domain.CodeNotFound
+
+	// Conflict errors
+	case errors.Is(err, store.ErrEmailExists):
+		return domain.CodeEmailExists
+
+	case errors.Is(err, store.ErrDeckNameExists):
+		return domain.CodeDeckNameExists
+
+	case errors.Is(err, store.ErrVersionConflict):
+		return domain.CodeVersionConflict
+
+	case errors.Is(err, auth.ErrTokenAlreadyUsed):
+		return domain.CodeTokenUsed
+
+	case errors.Is(err, service.ErrMemoNotDraft):
+		return domain.CodeMemoNotDraft
+
+	case errors.Is(err, service.ErrMemoGenerationInProgress):
+		return domain.CodeMemoGenerating
+
+	case errors.Is(err, domain.ErrMemoStatusTransitionInvalid):
+		return domain.CodeMemoTransition
+
+	case errors.Is(err, service.ErrCardsNotDuplicates):
+		return domain.CodeCardsNotDuplicates
+
+	case errors.Is(err, store.ErrDuplicate):
+		return domain.CodeConflict
+
+	// Unprocessable requests
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		return domain.CodeIdempotencyReused
+
+	case errors.Is(err, service.ErrImportRejected):
+		return domain.CodeImportRejected
+
+	// Throttled requests
+	case errors.Is(err, auth.ErrTooManyLoginAttempts):
+		return domain.CodeLoginLocked
+
+	case errors.Is(err, service.ErrGenerationQuotaExceeded):
+		return domain.CodeQuotaExceeded
+
+	case errors.Is(err, domain.ErrCardContentTooLarge):
+		return domain.CodeCardContentTooLarge
+
+	// Bad request errors
+	case errors.Is(err, domain.ErrInvalidID):
+		return domain.CodeInvalidID
+
+	case errors.Is(err, domain.ErrInvalidEmail):
+		return domain.CodeInvalidEmail
+
+	case errors.Is(err, domain.ErrInvalidPassword):
+		return domain.CodeInvalidPassword
+
+	case errors.Is(err, domain.ErrInvalidReviewOutcome):
+		return domain.CodeInvalidOutcome
+
+	case errors.Is(err, domain.ErrInvalidCardContent):
+		return domain.CodeInvalidCardContent
+
+	case errors.Is(err, domain.ErrInvalidMemoStatus):
+		return domain.CodeInvalidMemoStatus
+
+	case errors.Is(err, domain.ErrUserTimezoneInvalid):
+		return domain.CodeInvalidTimezone
+
+	case errors.Is(err, store.ErrInvalidEntity),
+		errors.Is(err, card_review.ErrInvalidAnswer),
+		errors.Is(err, domain.ErrValidation),
+		errors.Is(err, domain.ErrInvalidFormat),
+		errors.Is(err, domain.ErrInvalidTag),
+		errors.Is(err, domain.ErrEmptyContent):
+		return domain.CodeValidationFailed
+
+	case errors.Is(err, card_review.ErrNoCardsDue):
+		return domain.CodeNoCardsDue
+
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.CodeRequestTimeout
+
+	default:
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			return domain.CodeValidationFailed
+		}
+
+		return domain.CodeInternal
+	}
+}
+
 // GetSafeErrorMessage returns a sanitized, user-friendly error message
 // based on the error type. This prevents leaking sensitive internal details.
 func GetSafeErrorMessage(err error) string {
@@ -94,6 +363,12 @@ func GetSafeErrorMessage(err error) string {
 		return validationErr.Message
 	}
 
+	// Domain errors carry a message that is already safe to expose
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) && domainErr.Message != "" {
+		return domainErr.Message
+	}
+
 	// Handle service errors with wrapped errors
 	var serviceErr *card_review.ServiceError
 	if errors.As(err, &serviceErr) {
@@ -125,7 +400,8 @@ func GetSafeErrorMessage(err error) string {
 	switch {
 	// Authentication errors
 	case errors.Is(err, auth.ErrInvalidToken),
-		errors.Is(err, auth.ErrExpiredToken):
+		errors.Is(err, auth.ErrExpiredToken),
+		errors.Is(err, auth.ErrTokenNotYetValid):
 		return "Invalid token"
 
 	case errors.Is(err, auth.ErrInvalidRefreshToken),
@@ -133,6 +409,9 @@ func GetSafeErrorMessage(err error) string {
 		errors.Is(err, auth.ErrWrongTokenType):
 		return "Invalid refresh token"
 
+	case errors.Is(err, auth.ErrAccountDisabled):
+		return "Account is disabled"
+
 	case errors.Is(err, domain.ErrUnauthorized):
 		return "Unauthorized operation"
 
@@ -140,6 +419,15 @@ func GetSafeErrorMessage(err error) string {
 	case errors.Is(err, card_review.ErrCardNotOwned):
 		return "You do not own this card"
 
+	case errors.Is(err, domain.ErrForbidden):
+		return "You do not have permission to perform this operation"
+
+	case errors.Is(err, service.ErrMemoNotOwned):
+		return "You do not own this memo"
+
+	case errors.Is(err, service.ErrDeckNotOwned):
+		return "You do not own this deck"
+
 	// Not found errors
 	case errors.Is(err, store.ErrUserNotFound):
 		return "User not found"
@@ -151,6 +439,9 @@ func GetSafeErrorMessage(err error) string {
 	case errors.Is(err, store.ErrMemoNotFound):
 		return "Memo not found"
 
+	case errors.Is(err, store.ErrDeckNotFound):
+		return "Deck not found"
+
 	case errors.Is(err, card_review.ErrCardStatsNotFound):
 		return "Card statistics not found"
 
@@ -161,9 +452,44 @@ func GetSafeErrorMessage(err error) string {
 	case errors.Is(err, store.ErrEmailExists):
 		return "Email already exists"
 
+	case errors.Is(err, store.ErrDeckNameExists):
+		return "Deck name already exists"
+
 	case errors.Is(err, store.ErrDuplicate):
 		return "Resource already exists"
 
+	case errors.Is(err, service.ErrMemoNotDraft):
+		return "Memo is not a draft"
+
+	case errors.Is(err, service.ErrMemoGenerationInProgress):
+		return "Memo generation is already in progress"
+
+	case errors.Is(err, service.ErrCardsNotDuplicates):
+		return "Cards do not have identical content"
+
+	case errors.Is(err, store.ErrVersionConflict):
+		return "Resource was modified by another request"
+
+	case errors.Is(err, auth.ErrTokenAlreadyUsed):
+		return "Token has already been used"
+
+	case errors.Is(err, domain.ErrMemoStatusTransitionInvalid):
+		return "Memo status change not allowed"
+
+	// Unprocessable requests
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		return "Idempotency-Key was already used for a different request"
+
+	case errors.Is(err, service.ErrImportRejected):
+		return "Too many rows failed validation; nothing was imported"
+
+	// Throttled requests
+	case errors.Is(err, auth.ErrTooManyLoginAttempts):
+		return "Too many failed login attempts, please try again later"
+
+	case errors.Is(err, service.ErrGenerationQuotaExceeded):
+		return "Monthly card generation quota exceeded"
+
 	// Bad request errors - domain validation errors
 	case errors.Is(err, domain.ErrValidation):
 		return "Validation failed"
@@ -192,6 +518,9 @@ func GetSafeErrorMessage(err error) string {
 	case errors.Is(err, domain.ErrInvalidMemoStatus):
 		return "Invalid memo status"
 
+	case errors.Is(err, domain.ErrUserTimezoneInvalid):
+		return "Invalid timezone"
+
 	// Store/service specific errors
 	case errors.Is(err, store.ErrInvalidEntity):
 		return "Invalid entity data"
@@ -204,6 +533,9 @@ func GetSafeErrorMessage(err error) string {
 		// This should not happen as we return StatusNoContent, but for completeness
 		return "No cards due for review"
 
+	case errors.Is(err, context.DeadlineExceeded):
+		return "Request timed out"
+
 	// Default case for unknown errors
 	default:
 		return "An unexpected error occurred"
@@ -275,25 +607,13 @@ func extractValidatorFieldAndTag(errStr string) (string, string, bool) {
 
 // getValidationTagMessage maps validation tags to user-friendly error messages
 func getValidationTagMessage(tag string) string {
-	switch tag {
-	case "required":
-		return "required field"
-	case "email":
-		return "invalid email format"
-	case "min":
-		return "too short"
-	case "max":
-		return "too long"
-	case "oneof":
-		return "invalid value"
-	default:
-		return "validation failed"
-	}
+	return shared.ValidationTagMessage(tag)
 }
 
 // HandleAPIError is a centralized helper function that handles API errors consistently.
 // It maps the error to an HTTP status code, generates a user-friendly message,
-// and responds with an appropriate HTTP error.
+// and responds with an appropriate HTTP error whose body carries the error's
+// machine-readable code (see GetErrorCode).
 //
 // Parameters:
 // - w: The HTTP response writer
@@ -322,12 +642,17 @@ func HandleAPIError(
 		safeMessage = defaultMsg
 	}
 
+	// Callers' options come last so they can override the derived code
+	opts = append([]shared.ResponseOption{shared.WithErrorCode(GetErrorCode(err))}, opts...)
+
 	// Respond with error using centralized shared function
 	shared.RespondWithErrorAndLog(w, r, statusCode, safeMessage, err, opts...)
 }
 
 // HandleValidationError is a specialized version of HandleAPIError for validation errors.
 // It sanitizes the validation error and responds with a BadRequest status.
+// Errors returned by shared.ValidateStruct also list every invalid field in
+// the response's errors array.
 //
 // Parameters:
 // - w: The HTTP response writer
@@ -340,9 +665,27 @@ func HandleValidationError(
 	err error,
 	opts ...shared.ResponseOption,
 ) {
+	// A body cut off by http.MaxBytesReader is too large rather than invalid
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		HandleAPIError(w, r, domain.NewDomainError(
+			domain.CodeRequestTooLarge,
+			"Request body is too large",
+			err,
+		), "", opts...)
+		return
+	}
+
 	// Sanitize the validation error message
 	sanitizedError := SanitizeValidationError(err)
 
 	// Always use BadRequest status for validation errors
+	opts = append([]shared.ResponseOption{shared.WithErrorCode(domain.CodeValidationFailed)}, opts...)
+
+	// List every invalid field when the request was validated with shared.ValidateStruct
+	var fieldErrs *shared.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		opts = append(opts, shared.WithFieldErrors(fieldErrs.Fields))
+	}
 	shared.RespondWithErrorAndLog(w, r, http.StatusBadRequest, sanitizedError, err, opts...)
 }
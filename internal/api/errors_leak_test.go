@@ -128,7 +128,12 @@ func TestErrorDetailsLeakInResponseAndLogs(t *testing.T) {
 				// Test GetNextCard endpoint
 				server := testutils.SetupCardReviewTestServer(t, testutils.CardReviewServerOptions{
 					UserID: userID,
-					GetNextCardFn: func(ctx context.Context, uid uuid.UUID) (*domain.Card, error) {
+					GetNextCardFn: func(
+						ctx context.Context,
+						uid uuid.UUID,
+						deckID *uuid.UUID,
+						order domain.ReviewOrder,
+					) (*domain.Card, error) {
 						return nil, tc.error
 					},
 				})
@@ -281,7 +286,12 @@ func TestErrorDetailsWithWrappedErrors(t *testing.T) {
 	// Setup test server with a custom function that returns the deeply wrapped error
 	server := testutils.SetupCardReviewTestServer(t, testutils.CardReviewServerOptions{
 		UserID: userID,
-		GetNextCardFn: func(ctx context.Context, uid uuid.UUID) (*domain.Card, error) {
+		GetNextCardFn: func(
+			ctx context.Context,
+			uid uuid.UUID,
+			deckID *uuid.UUID,
+			order domain.ReviewOrder,
+		) (*domain.Card, error) {
 			return nil, deeplyWrappedError
 		},
 	})
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/phrazzld/scry-api/internal/api/shared"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
 	"github.com/phrazzld/scry-api/internal/service/auth"
 	"github.com/phrazzld/scry-api/internal/service/card_review"
 	"github.com/phrazzld/scry-api/internal/store"
@@ -43,6 +45,26 @@ func TestMapErrorToStatusCode(t *testing.T) {
 			err:            card_review.ErrCardNotOwned,
 			expectedStatus: http.StatusForbidden,
 		},
+		{
+			name:           "memo authorization error",
+			err:            service.ErrMemoNotOwned,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "deck authorization error",
+			err:            service.ErrDeckNotOwned,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "deck not found error",
+			err:            fmt.Errorf("failed to get deck: %w", store.ErrDeckNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "deck name conflict",
+			err:            fmt.Errorf("failed to create deck: %w", store.ErrDeckNameExists),
+			expectedStatus: http.StatusConflict,
+		},
 		{
 			name:           "not found error",
 			err:            store.ErrCardNotFound,
@@ -53,6 +75,46 @@ func TestMapErrorToStatusCode(t *testing.T) {
 			err:            store.ErrEmailExists,
 			expectedStatus: http.StatusConflict,
 		},
+		{
+			name:           "memo status transition conflict",
+			err:            fmt.Errorf("failed to update memo status: %w", domain.ErrMemoStatusTransitionInvalid),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "version conflict",
+			err:            fmt.Errorf("failed to update card content: %w", store.ErrVersionConflict),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "idempotency key reused",
+			err:            fmt.Errorf("failed to create memo: %w", service.ErrIdempotencyKeyReused),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "account disabled",
+			err:            auth.ErrAccountDisabled,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "generation quota exceeded",
+			err:            fmt.Errorf("failed to create memo: %w", &service.GenerationQuotaError{Quota: 10}),
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "cards not duplicates conflict",
+			err:            fmt.Errorf("failed to merge: %w", service.ErrCardsNotDuplicates),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "memo not draft conflict",
+			err:            fmt.Errorf("failed to generate: %w", service.ErrMemoNotDraft),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid timezone error",
+			err:            fmt.Errorf("failed to update user timezone: %w", domain.ErrUserTimezoneInvalid),
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "bad request error",
 			err:            store.ErrInvalidEntity,
@@ -63,6 +125,17 @@ func TestMapErrorToStatusCode(t *testing.T) {
 			err:            card_review.ErrNoCardsDue,
 			expectedStatus: http.StatusNoContent,
 		},
+		{
+			name:           "deadline exceeded",
+			err:            fmt.Errorf("get card: %w", context.DeadlineExceeded),
+			expectedStatus: http.StatusGatewayTimeout,
+		},
+		{
+			name: "card content too large",
+			err: domain.NewValidationError("content", "must be at most 10 bytes",
+				domain.ErrCardContentTooLarge),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
 		{
 			name:           "unknown error",
 			err:            errors.New("unknown error"),
@@ -104,6 +177,66 @@ func TestGetSafeErrorMessage(t *testing.T) {
 			err:             card_review.ErrCardNotOwned,
 			expectedMessage: "You do not own this card",
 		},
+		{
+			name:            "memo not owned error",
+			err:             service.ErrMemoNotOwned,
+			expectedMessage: "You do not own this memo",
+		},
+		{
+			name:            "deck not owned error",
+			err:             service.ErrDeckNotOwned,
+			expectedMessage: "You do not own this deck",
+		},
+		{
+			name:            "deck not found error",
+			err:             store.ErrDeckNotFound,
+			expectedMessage: "Deck not found",
+		},
+		{
+			name:            "deck name exists error",
+			err:             store.ErrDeckNameExists,
+			expectedMessage: "Deck name already exists",
+		},
+		{
+			name:            "memo not draft error",
+			err:             service.ErrMemoNotDraft,
+			expectedMessage: "Memo is not a draft",
+		},
+		{
+			name:            "memo status transition error",
+			err:             domain.ErrMemoStatusTransitionInvalid,
+			expectedMessage: "Memo status change not allowed",
+		},
+		{
+			name:            "version conflict error",
+			err:             store.ErrVersionConflict,
+			expectedMessage: "Resource was modified by another request",
+		},
+		{
+			name:            "idempotency key reused error",
+			err:             service.ErrIdempotencyKeyReused,
+			expectedMessage: "Idempotency-Key was already used for a different request",
+		},
+		{
+			name:            "account disabled error",
+			err:             auth.ErrAccountDisabled,
+			expectedMessage: "Account is disabled",
+		},
+		{
+			name:            "generation quota exceeded error",
+			err:             &service.GenerationQuotaError{Quota: 10},
+			expectedMessage: "Monthly card generation quota exceeded",
+		},
+		{
+			name:            "cards not duplicates error",
+			err:             service.ErrCardsNotDuplicates,
+			expectedMessage: "Cards do not have identical content",
+		},
+		{
+			name:            "invalid timezone error",
+			err:             domain.ErrUserTimezoneInvalid,
+			expectedMessage: "Invalid timezone",
+		},
 		{
 			name:            "unknown error",
 			err:             errors.New("database error: connection refused"),
@@ -585,3 +718,177 @@ func TestHandleValidationError(t *testing.T) {
 		})
 	}
 }
+
+// TestGetErrorCode tests that errors map to stable machine-readable codes
+func TestGetErrorCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode domain.ErrorCode
+	}{
+		{"nil error", nil, domain.CodeInternal},
+		{"unknown error", errors.New("boom"), domain.CodeInternal},
+		{"invalid token", auth.ErrInvalidToken, domain.CodeInvalidToken},
+		{"expired token", auth.ErrExpiredToken, domain.CodeTokenExpired},
+		{"expired refresh token", auth.ErrExpiredRefreshToken, domain.CodeTokenExpired},
+		{"account disabled", auth.ErrAccountDisabled, domain.CodeAccountDisabled},
+		{"unauthorized", domain.ErrUnauthorized, domain.CodeUnauthorized},
+		{"forbidden", domain.ErrForbidden, domain.CodeForbidden},
+		{"card not owned", card_review.ErrCardNotOwned, domain.CodeCardNotOwned},
+		{"memo not owned", service.ErrMemoNotOwned, domain.CodeMemoNotOwned},
+		{"user not found", store.ErrUserNotFound, domain.CodeUserNotFound},
+		{"card not found", store.ErrCardNotFound, domain.CodeCardNotFound},
+		{"review card not found", card_review.ErrCardNotFound, domain.CodeCardNotFound},
+		{"memo not found", store.ErrMemoNotFound, domain.CodeMemoNotFound},
+		{"deck not found", store.ErrDeckNotFound, domain.CodeDeckNotFound},
+		{"generic not found", store.ErrNotFound, domain.CodeNotFound},
+		{"email exists", store.ErrEmailExists, domain.CodeEmailExists},
+		{"version conflict", store.ErrVersionConflict, domain.CodeVersionConflict},
+		{"generation in progress", service.ErrMemoGenerationInProgress, domain.CodeMemoGenerating},
+		{"idempotency key reused", service.ErrIdempotencyKeyReused, domain.CodeIdempotencyReused},
+		{"import rejected", service.ErrImportRejected, domain.CodeImportRejected},
+		{"generation quota exceeded", &service.GenerationQuotaError{Quota: 10}, domain.CodeQuotaExceeded},
+		{"deadline exceeded", context.DeadlineExceeded, domain.CodeRequestTimeout},
+		{
+			"card content too large",
+			domain.NewValidationError("content", "must be at most 10 bytes", domain.ErrCardContentTooLarge),
+			domain.CodeCardContentTooLarge,
+		},
+		{"invalid outcome", domain.ErrInvalidReviewOutcome, domain.CodeInvalidOutcome},
+		{"invalid card content", domain.ErrInvalidCardContent, domain.CodeInvalidCardContent},
+		{"invalid timezone", domain.ErrUserTimezoneInvalid, domain.CodeInvalidTimezone},
+		{"validation error", domain.NewValidationError("email", "bad", nil), domain.CodeValidationFailed},
+		{"wrapped sentinel", fmt.Errorf("get card: %w", store.ErrCardNotFound), domain.CodeCardNotFound},
+		{
+			"wrapped in service error",
+			card_review.NewSubmitAnswerError("failed", card_review.ErrCardNotOwned),
+			domain.CodeCardNotOwned,
+		},
+		{
+			"domain error overrides wrapped sentinel",
+			domain.NewDomainError(domain.CodeDeckNameExists, "Deck name taken", store.ErrDuplicate),
+			domain.CodeDeckNameExists,
+		},
+		{
+			"wrapped domain error",
+			fmt.Errorf("create: %w", domain.NewDomainError(domain.CodeInvalidOutcome, "Bad outcome", nil)),
+			domain.CodeInvalidOutcome,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedCode, GetErrorCode(tt.err))
+		})
+	}
+}
+
+// TestDomainErrorStatusAndMessage tests that a DomainError that does not wrap a
+// known sentinel gets its status from its code and exposes its own message
+func TestDomainErrorStatusAndMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:           "status from code",
+			err:            domain.NewDomainError(domain.CodeCardNotFound, "No such card", nil),
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "No such card",
+		},
+		{
+			name:           "status from wrapped sentinel",
+			err:            domain.NewDomainError(domain.CodeDeckNameExists, "Deck name taken", store.ErrDeckNameExists),
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "Deck name taken",
+		},
+		{
+			name:           "request timeout",
+			err:            domain.NewDomainError(domain.CodeRequestTimeout, "Request timed out", nil),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedMsg:    "Request timed out",
+		},
+		{
+			name:           "unknown code",
+			err:            domain.NewDomainError("SOMETHING_NEW", "Something new", errors.New("boom")),
+			expectedStatus: http.StatusInternalServerError,
+			expectedMsg:    "Something new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedStatus, MapErrorToStatusCode(tt.err))
+			assert.Equal(t, tt.expectedMsg, GetSafeErrorMessage(tt.err))
+		})
+	}
+}
+
+// TestHandleAPIErrorCodes tests that the major error paths return the expected
+// status together with the expected code and message in the response body
+func TestHandleAPIErrorCodes(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   domain.ErrorCode
+	}{
+		{"card not found", store.ErrCardNotFound, http.StatusNotFound, domain.CodeCardNotFound},
+		{"memo not found", store.ErrMemoNotFound, http.StatusNotFound, domain.CodeMemoNotFound},
+		{"card not owned", card_review.ErrCardNotOwned, http.StatusForbidden, domain.CodeCardNotOwned},
+		{"invalid outcome", domain.ErrInvalidReviewOutcome, http.StatusBadRequest, domain.CodeInvalidOutcome},
+		{"email exists", store.ErrEmailExists, http.StatusConflict, domain.CodeEmailExists},
+		{"expired token", auth.ErrExpiredToken, http.StatusUnauthorized, domain.CodeTokenExpired},
+		{"forbidden", domain.ErrForbidden, http.StatusForbidden, domain.CodeForbidden},
+		{"validation", domain.NewValidationError("email", "bad", nil), http.StatusBadRequest, domain.CodeValidationFailed},
+		{"internal", errors.New("database down"), http.StatusInternalServerError, domain.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+			HandleAPIError(w, r, tt.err, "Request failed")
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var resp shared.ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			expectedMsg := GetSafeErrorMessage(tt.err)
+			if tt.expectedStatus == http.StatusInternalServerError {
+				expectedMsg = "Request failed"
+			}
+			assert.Equal(t, expectedMsg, resp.Error, "The error text is kept alongside the code")
+		})
+	}
+
+	t.Run("caller options override the code", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+		HandleAPIError(w, r, store.ErrNotFound, "", shared.WithErrorCode(domain.CodeDeckNotFound))
+
+		var resp shared.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, domain.CodeDeckNotFound, resp.Code)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/test", nil)
+
+		HandleValidationError(w, r, errors.New(
+			"Key: 'LoginRequest.Email' Error:Field validation for 'Email' failed on the 'required' tag",
+		))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var resp shared.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, domain.CodeValidationFailed, resp.Code)
+		assert.Equal(t, "Invalid Email: required field", resp.Error)
+	})
+}
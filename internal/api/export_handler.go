@@ -0,0 +1,361 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/service"
+)
+
+// Supported values of the format query parameter of GET /api/export
+const (
+	ExportFormatJSON    = "json"
+	ExportFormatAnkiCSV = "apkg-csv"
+)
+
+// States of an exported card, given in its state field or column
+const (
+	ExportCardStateActive     = "active"
+	ExportCardStateSuspended  = "suspended"
+	ExportCardStateSuperseded = "superseded"
+)
+
+const (
+	// exportCSVFlushRows is how many CSV rows are buffered before flushing to the client
+	exportCSVFlushRows = 100
+
+	// exportCSVTagsColumn is the 1-based position of "tags" in ExportCSVColumns,
+	// as Anki's "#tags column" header expects
+	exportCSVTagsColumn = 3
+)
+
+// ExportCSVColumns are the columns of the Anki CSV export, in order.
+// Anki maps the first two to the Front and Back fields of a Basic note.
+var ExportCSVColumns = []string{
+	"front",
+	"back",
+	"tags",
+	"hint",
+	"interval",
+	"ease_factor",
+	"review_count",
+	"next_review_at",
+	"state",
+}
+
+// ExportedCardStats is the review progress of an exported card
+type ExportedCardStats struct {
+	Interval           int        `json:"interval"`
+	EaseFactor         float64    `json:"ease_factor"`
+	ConsecutiveCorrect int        `json:"consecutive_correct"`
+	LastReviewedAt     *time.Time `json:"last_reviewed_at,omitempty"`
+	NextReviewAt       time.Time  `json:"next_review_at"`
+	ReviewCount        int        `json:"review_count"`
+}
+
+// ExportedCard is one element of the JSON export. The same shape is accepted
+// when importing cards, so an export can be restored into another account.
+type ExportedCard struct {
+	ID        string             `json:"id"`
+	MemoID    string             `json:"memo_id"`
+	DeckID    *string            `json:"deck_id,omitempty"`
+	Type      string             `json:"type"`
+	State     string             `json:"state"`
+	Content   json.RawMessage    `json:"content"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	Stats     *ExportedCardStats `json:"stats,omitempty"`
+}
+
+// ExportHandler handles requests for exporting a user's collection
+type ExportHandler struct {
+	exportService service.ExportService
+	logger        *slog.Logger
+}
+
+// NewExportHandler creates a new ExportHandler
+func NewExportHandler(exportService service.ExportService, logger *slog.Logger) *ExportHandler {
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for ExportHandler")
+	}
+
+	return &ExportHandler{
+		exportService: exportService,
+		logger:        logger.With(slog.String("component", "export_handler")),
+	}
+}
+
+// Export handles GET /api/export requests
+// It streams the authenticated user's cards and review statistics either as a
+// JSON array (format=json, the default) or as a CSV file that Anki can import
+// (format=apkg-csv). Every card is marked with its state; suspended cards are
+// always exported, and cards superseded by regenerating their memo only when
+// include_archived=true, so that the export is a complete backup.
+//
+// The response is written as cards are read, so an error after the first card
+// cannot change the status code; the body is left truncated instead.
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = ExportFormatJSON
+	}
+
+	var writer exportWriter
+	switch format {
+	case ExportFormatJSON:
+		writer = &jsonExportWriter{w: w}
+	case ExportFormatAnkiCSV:
+		writer = &csvExportWriter{w: w}
+	default:
+		log.Warn("invalid export format", slog.String("format", format))
+		HandleAPIError(w, r,
+			domain.NewValidationError("format", "must be json or apkg-csv", domain.ErrValidation),
+			"Invalid format parameter")
+		return
+	}
+
+	includeArchived := false
+	if raw := r.URL.Query().Get("include_archived"); raw != "" {
+		var err error
+		if includeArchived, err = strconv.ParseBool(raw); err != nil {
+			log.Warn("invalid include_archived parameter", slog.String("include_archived", raw))
+			HandleAPIError(w, r,
+				domain.NewValidationError("include_archived", "must be true or false", domain.ErrValidation),
+				"Invalid include_archived parameter")
+			return
+		}
+	}
+
+	err := h.exportService.ExportCards(r.Context(), userID, includeArchived, writer.Write)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		if !writer.Started() {
+			HandleAPIError(w, r, err, "Failed to export cards")
+			return
+		}
+		// Headers have already been sent; all that can be done is stop writing
+		log.Error("export aborted after response started",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()),
+			slog.String("format", format))
+		return
+	}
+}
+
+// exportWriter encodes exported cards onto an HTTP response. Headers are sent
+// with the first card (or on Close for an empty export), so that an error
+// before anything is written can still be reported with a proper status code.
+type exportWriter interface {
+	Write(card *domain.CardWithStats) error
+	Close() error
+	Started() bool
+}
+
+// jsonExportWriter streams cards as the elements of a JSON array
+type jsonExportWriter struct {
+	w       http.ResponseWriter
+	enc     *json.Encoder
+	started bool
+}
+
+func (e *jsonExportWriter) start() error {
+	e.w.Header().Set("Content-Type", "application/json")
+	e.w.Header().Set("Content-Disposition", `attachment; filename="scry-export.json"`)
+	e.w.WriteHeader(http.StatusOK)
+	e.started = true
+	e.enc = json.NewEncoder(e.w)
+	_, err := e.w.Write([]byte("["))
+	return err
+}
+
+func (e *jsonExportWriter) Write(card *domain.CardWithStats) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	} else if _, err := e.w.Write([]byte(",")); err != nil {
+		return err
+	}
+	// Encode appends a newline, which keeps large exports readable line by line
+	return e.enc.Encode(toExportedCard(card))
+}
+
+func (e *jsonExportWriter) Close() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	_, err := e.w.Write([]byte("]\n"))
+	return err
+}
+
+func (e *jsonExportWriter) Started() bool {
+	return e.started
+}
+
+// csvExportWriter streams cards as rows of a CSV file in Anki's import format
+type csvExportWriter struct {
+	w       http.ResponseWriter
+	csv     *csv.Writer
+	rows    int
+	started bool
+}
+
+func (e *csvExportWriter) start() error {
+	e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	e.w.Header().Set("Content-Disposition", `attachment; filename="scry-export.csv"`)
+	e.w.WriteHeader(http.StatusOK)
+	e.started = true
+	e.csv = csv.NewWriter(e.w)
+
+	// Anki reads these header lines to configure the import; the #columns
+	// line names the fields so the file is self-describing.
+	header := [][]string{
+		{"#separator:comma"},
+		{"#html:false"},
+		{"#tags column:" + strconv.Itoa(exportCSVTagsColumn)},
+		append([]string{"#columns:" + ExportCSVColumns[0]}, ExportCSVColumns[1:]...),
+	}
+	for _, record := range header {
+		if err := e.csv.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvExportWriter) Write(card *domain.CardWithStats) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if err := e.csv.Write(toExportCSVRecord(card)); err != nil {
+		return err
+	}
+	e.rows++
+	if e.rows%exportCSVFlushRows == 0 {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	return nil
+}
+
+func (e *csvExportWriter) Close() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *csvExportWriter) Started() bool {
+	return e.started
+}
+
+// toExportedCard converts a card and its stats to the JSON export format
+func toExportedCard(item *domain.CardWithStats) ExportedCard {
+	card := item.Card
+
+	var deckID *string
+	if card.DeckID != nil {
+		id := card.DeckID.String()
+		deckID = &id
+	}
+
+	exported := ExportedCard{
+		ID:        card.ID.String(),
+		MemoID:    card.MemoID.String(),
+		DeckID:    deckID,
+		Type:      string(card.Type.OrDefault()),
+		State:     exportCardState(card),
+		Content:   card.Content,
+		CreatedAt: card.CreatedAt,
+		UpdatedAt: card.UpdatedAt,
+	}
+
+	if stats := item.Stats; stats != nil {
+		exported.Stats = &ExportedCardStats{
+			Interval:           stats.Interval,
+			EaseFactor:         stats.EaseFactor,
+			ConsecutiveCorrect: stats.ConsecutiveCorrect,
+			NextReviewAt:       stats.NextReviewAt,
+			ReviewCount:        stats.ReviewCount,
+		}
+		if !stats.LastReviewedAt.IsZero() {
+			lastReviewedAt := stats.LastReviewedAt
+			exported.Stats.LastReviewedAt = &lastReviewedAt
+		}
+	}
+
+	return exported
+}
+
+// toExportCSVRecord converts a card and its stats to a row of ExportCSVColumns
+func toExportCSVRecord(item *domain.CardWithStats) []string {
+	var content domain.CardContent
+	if err := json.Unmarshal(item.Card.Content, &content); err != nil {
+		// Keep unrecognized content rather than dropping the card
+		content = domain.CardContent{Front: string(item.Card.Content)}
+	}
+	if item.Card.Type == domain.CardTypeCloze {
+		// Anki's Cloze note type reads the markup from the first field
+		content.Front = content.Text
+	}
+
+	record := []string{
+		content.Front,
+		content.Back,
+		// Anki separates tags with spaces; normalized tags never contain one
+		strings.Join(content.Tags, " "),
+		content.Hint,
+		"", "", "", "",
+		exportCardState(item.Card),
+	}
+	if stats := item.Stats; stats != nil {
+		record[4] = strconv.Itoa(stats.Interval)
+		record[5] = strconv.FormatFloat(stats.EaseFactor, 'f', 2, 64)
+		record[6] = strconv.Itoa(stats.ReviewCount)
+		record[7] = stats.NextReviewAt.UTC().Format(time.RFC3339)
+	}
+	return record
+}
+
+// exportCardState returns the state exported for card. A superseded card is
+// reported as superseded even if it was also suspended.
+func exportCardState(card *domain.Card) string {
+	switch {
+	case card.IsSuperseded():
+		return ExportCardStateSuperseded
+	case card.IsSuspended():
+		return ExportCardStateSuspended
+	default:
+		return ExportCardStateActive
+	}
+}
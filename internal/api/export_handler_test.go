@@ -0,0 +1,307 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockExportService is a mock implementation of service.ExportService that
+// streams a fixed collection, skipping superseded cards unless they are included
+type MockExportService struct {
+	Cards []*domain.CardWithStats
+	Err   error
+}
+
+// ExportCards implements service.ExportService
+func (m *MockExportService) ExportCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	includeArchived bool,
+	fn func(*domain.CardWithStats) error,
+) error {
+	for _, card := range m.Cards {
+		if card.Card.IsSuperseded() && !includeArchived {
+			continue
+		}
+		if err := fn(card); err != nil {
+			return err
+		}
+	}
+	return m.Err
+}
+
+var _ service.ExportService = (*MockExportService)(nil)
+
+// seedExportCollection returns two cards, one reviewed and one without stats
+func seedExportCollection(userID uuid.UUID) []*domain.CardWithStats {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	deckID := uuid.New()
+	reviewed := &domain.Card{
+		ID:        uuid.New(),
+		UserID:    userID,
+		MemoID:    uuid.New(),
+		DeckID:    &deckID,
+		Content:   json.RawMessage(`{"front":"Capital of France?","back":"Paris, \"the\" city","tags":["geo","europe"]}`),
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+	fresh := &domain.Card{
+		ID:        uuid.New(),
+		UserID:    userID,
+		MemoID:    uuid.New(),
+		Content:   json.RawMessage(`{"front":"2+2","back":"4","hint":"even"}`),
+		CreatedAt: created.Add(time.Hour),
+		UpdatedAt: created.Add(time.Hour),
+	}
+	return []*domain.CardWithStats{
+		{
+			Card: reviewed,
+			Stats: &domain.UserCardStats{
+				UserID:             userID,
+				CardID:             reviewed.ID,
+				Interval:           6,
+				EaseFactor:         2.36,
+				ConsecutiveCorrect: 2,
+				LastReviewedAt:     created.Add(24 * time.Hour),
+				NextReviewAt:       created.Add(7 * 24 * time.Hour),
+				ReviewCount:        3,
+			},
+		},
+		{Card: fresh},
+	}
+}
+
+func newExportRequest(userID uuid.UUID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/export"+query, nil)
+	return req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+}
+
+// TestExportHandler_JSON tests that the JSON export is an array of cards with stats
+func TestExportHandler_JSON(t *testing.T) {
+	userID := uuid.New()
+	cards := seedExportCollection(userID)
+	handler := NewExportHandler(&MockExportService{Cards: cards}, slog.Default())
+
+	w := httptest.NewRecorder()
+	handler.Export(w, newExportRequest(userID, ""))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "scry-export.json")
+
+	var exported []ExportedCard
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &exported))
+	require.Len(t, exported, 2)
+
+	first := exported[0]
+	assert.Equal(t, cards[0].Card.ID.String(), first.ID)
+	assert.Equal(t, cards[0].Card.MemoID.String(), first.MemoID)
+	require.NotNil(t, first.DeckID)
+	assert.Equal(t, cards[0].Card.DeckID.String(), *first.DeckID)
+	assert.JSONEq(t, string(cards[0].Card.Content), string(first.Content))
+	require.NotNil(t, first.Stats)
+	assert.Equal(t, 6, first.Stats.Interval)
+	assert.Equal(t, 2.36, first.Stats.EaseFactor)
+	assert.Equal(t, 3, first.Stats.ReviewCount)
+	require.NotNil(t, first.Stats.LastReviewedAt)
+	assert.True(t, cards[0].Stats.NextReviewAt.Equal(first.Stats.NextReviewAt))
+
+	assert.Equal(t, ExportCardStateActive, first.State)
+
+	second := exported[1]
+	assert.Nil(t, second.DeckID)
+	assert.Nil(t, second.Stats)
+
+	// The raw document keeps content as a nested object, not an escaped string
+	var raw []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &raw))
+	assert.IsType(t, map[string]interface{}{}, raw[0]["content"])
+	assert.NotContains(t, raw[1], "stats")
+}
+
+// TestExportHandler_AnkiCSV tests the CSV columns and rows of the Anki export
+func TestExportHandler_AnkiCSV(t *testing.T) {
+	userID := uuid.New()
+	handler := NewExportHandler(&MockExportService{Cards: seedExportCollection(userID)}, slog.Default())
+
+	w := httptest.NewRecorder()
+	handler.Export(w, newExportRequest(userID, "?format=apkg-csv"))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "scry-export.csv")
+
+	reader := csv.NewReader(strings.NewReader(w.Body.String()))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6, "4 header lines and 2 cards")
+
+	assert.Equal(t, []string{"#separator:comma"}, records[0])
+	assert.Equal(t, []string{"#html:false"}, records[1])
+	assert.Equal(t, []string{"#tags column:3"}, records[2])
+	assert.Equal(t,
+		[]string{
+			"#columns:front", "back", "tags", "hint", "interval", "ease_factor", "review_count", "next_review_at", "state",
+		},
+		records[3])
+
+	assert.Equal(t, []string{
+		"Capital of France?", `Paris, "the" city`, "geo europe", "",
+		"6", "2.36", "3", "2025-01-09T03:04:05Z", "active",
+	}, records[4])
+	assert.Equal(t, []string{"2+2", "4", "", "even", "", "", "", "", "active"}, records[5])
+	for _, record := range records[4:] {
+		assert.Len(t, record, len(ExportCSVColumns))
+	}
+}
+
+// TestExportHandler_IncludeArchived tests that superseded cards are only exported
+// with include_archived, and that every card is marked with its state
+func TestExportHandler_IncludeArchived(t *testing.T) {
+	userID := uuid.New()
+	cards := seedExportCollection(userID)
+	archivedAt := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	cards[1].Card.SuspendedAt = &archivedAt
+	superseded := &domain.Card{
+		ID:           uuid.New(),
+		UserID:       userID,
+		MemoID:       uuid.New(),
+		Content:      json.RawMessage(`{"front":"Old question","back":"Old answer"}`),
+		CreatedAt:    archivedAt,
+		UpdatedAt:    archivedAt,
+		SupersededAt: &archivedAt,
+	}
+	cards = append(cards, &domain.CardWithStats{Card: superseded})
+	handler := NewExportHandler(&MockExportService{Cards: cards}, slog.Default())
+
+	exportStates := func(t *testing.T, query string) map[string]string {
+		w := httptest.NewRecorder()
+		handler.Export(w, newExportRequest(userID, query))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var exported []ExportedCard
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &exported))
+		states := make(map[string]string, len(exported))
+		for _, card := range exported {
+			states[card.ID] = card.State
+		}
+		return states
+	}
+
+	t.Run("without_flag", func(t *testing.T) {
+		for _, query := range []string{"", "?include_archived=false"} {
+			assert.Equal(t, map[string]string{
+				cards[0].Card.ID.String(): ExportCardStateActive,
+				cards[1].Card.ID.String(): ExportCardStateSuspended,
+			}, exportStates(t, query), "Superseded cards are left out for %q", query)
+		}
+	})
+
+	t.Run("with_flag", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			cards[0].Card.ID.String(): ExportCardStateActive,
+			cards[1].Card.ID.String(): ExportCardStateSuspended,
+			superseded.ID.String():    ExportCardStateSuperseded,
+		}, exportStates(t, "?include_archived=true"))
+	})
+
+	t.Run("with_flag_csv", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Export(w, newExportRequest(userID, "?format=apkg-csv&include_archived=true"))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		reader := csv.NewReader(strings.NewReader(w.Body.String()))
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 7, "4 header lines and 3 cards")
+
+		var states []string
+		for _, record := range records[4:] {
+			states = append(states, record[len(record)-1])
+		}
+		assert.Equal(t,
+			[]string{ExportCardStateActive, ExportCardStateSuspended, ExportCardStateSuperseded}, states)
+	})
+
+	t.Run("invalid_flag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Export(w, newExportRequest(userID, "?include_archived=maybe"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var resp shared.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, domain.CodeValidationFailed, resp.Code)
+	})
+}
+
+// TestExportHandler_Errors tests invalid formats, empty exports and failures
+func TestExportHandler_Errors(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("invalid_format", func(t *testing.T) {
+		handler := NewExportHandler(&MockExportService{}, slog.Default())
+		w := httptest.NewRecorder()
+		handler.Export(w, newExportRequest(userID, "?format=xml"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var resp shared.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, domain.CodeValidationFailed, resp.Code)
+	})
+
+	t.Run("empty_collection", func(t *testing.T) {
+		handler := NewExportHandler(&MockExportService{}, slog.Default())
+		w := httptest.NewRecorder()
+		handler.Export(w, newExportRequest(userID, "?format=json"))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("failure_before_first_card", func(t *testing.T) {
+		handler := NewExportHandler(&MockExportService{Err: errors.New("db down")}, slog.Default())
+		w := httptest.NewRecorder()
+		handler.Export(w, newExportRequest(userID, ""))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotContains(t, w.Body.String(), "db down")
+	})
+
+	t.Run("failure_after_first_card", func(t *testing.T) {
+		handler := NewExportHandler(&MockExportService{
+			Cards: seedExportCollection(userID),
+			Err:   errors.New("db down"),
+		}, slog.Default())
+		w := httptest.NewRecorder()
+		handler.Export(w, newExportRequest(userID, ""))
+
+		// The status was already sent, so the client sees a truncated array
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, json.Valid(w.Body.Bytes()))
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		handler := NewExportHandler(&MockExportService{}, slog.Default())
+		w := httptest.NewRecorder()
+		handler.Export(w, httptest.NewRequest(http.MethodGet, "/api/export", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// ReadinessTimeout bounds the database checks made by a single readiness probe
+const ReadinessTimeout = 2 * time.Second
+
+// Health check statuses reported in HealthResponse
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusNotReady = "not_ready"
+)
+
+// HealthDB is the database access needed by the readiness check
+type HealthDB interface {
+	store.DBTX
+	PingContext(ctx context.Context) error
+}
+
+// GenerationPinger checks that the card generation provider is reachable and
+// accepts the configured credentials and model; generation.Generator implements it
+type GenerationPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// MigrationVersionFunc returns the migration version applied to the database
+type MigrationVersionFunc func(ctx context.Context, db store.DBTX) (int64, error)
+
+// HealthResponse is the body returned by the liveness and readiness endpoints
+type HealthResponse struct {
+	Status string `json:"status"`
+
+	// Database is "ok" or the name of the readiness check that failed
+	Database string `json:"database,omitempty"`
+
+	// MigrationVersion is the migration version applied to the database
+	MigrationVersion int64 `json:"migration_version,omitempty"`
+
+	// ExpectedMigrationVersion is the newest migration known to this server
+	ExpectedMigrationVersion int64 `json:"expected_migration_version,omitempty"`
+
+	// Generation is "ok" or "unreachable" when readiness checks the generation provider
+	Generation string `json:"generation,omitempty"`
+}
+
+// HealthHandler serves liveness and readiness probes
+type HealthHandler struct {
+	db               HealthDB
+	migrationVersion MigrationVersionFunc
+	expectedVersion  int64
+	generation       GenerationPinger
+	logger           *slog.Logger
+}
+
+// NewHealthHandler creates a new HealthHandler.
+// Readiness requires the database to answer a ping and, when expectedVersion
+// is non-zero, migrationVersion to report at least expectedVersion.
+// An expectedVersion of 0 skips the migration check.
+func NewHealthHandler(
+	db HealthDB,
+	migrationVersion MigrationVersionFunc,
+	expectedVersion int64,
+	logger *slog.Logger,
+) *HealthHandler {
+	if db == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("db cannot be nil for HealthHandler")
+	}
+	if migrationVersion == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("migrationVersion cannot be nil for HealthHandler")
+	}
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for HealthHandler")
+	}
+
+	return &HealthHandler{
+		db:               db,
+		migrationVersion: migrationVersion,
+		expectedVersion:  expectedVersion,
+		logger:           logger.With(slog.String("component", "health_handler")),
+	}
+}
+
+// WithGenerationCheck returns a new HealthHandler whose readiness also
+// requires the generation provider to answer a ping.
+// The original handler remains unchanged.
+func (h *HealthHandler) WithGenerationCheck(pinger GenerationPinger) *HealthHandler {
+	newHandler := *h
+	newHandler.generation = pinger
+	return &newHandler
+}
+
+// Liveness handles GET /healthz requests
+// It reports that the process is up and serving requests, and never touches
+// the database, so a database outage does not get the process restarted.
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	shared.RespondWithJSON(w, r, http.StatusOK, HealthResponse{Status: HealthStatusOK})
+}
+
+// Readiness handles GET /readyz requests
+// It responds 200 when the database answers a ping and its migrations are
+// current, and, with WithGenerationCheck, the generation provider answers a
+// ping; and 503 otherwise so that traffic is routed elsewhere.
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	ctx, cancel := context.WithTimeout(r.Context(), ReadinessTimeout)
+	defer cancel()
+
+	response := HealthResponse{
+		Status:                   HealthStatusNotReady,
+		ExpectedMigrationVersion: h.expectedVersion,
+	}
+
+	if err := h.db.PingContext(ctx); err != nil {
+		log.Warn("readiness check failed: database ping", slog.String("error", err.Error()))
+		response.Database = "unreachable"
+		shared.RespondWithJSON(w, r, http.StatusServiceUnavailable, response)
+		return
+	}
+
+	version, err := h.migrationVersion(ctx, h.db)
+	if err != nil {
+		log.Warn("readiness check failed: migration version", slog.String("error", err.Error()))
+		response.Database = "migration_version_unavailable"
+		shared.RespondWithJSON(w, r, http.StatusServiceUnavailable, response)
+		return
+	}
+	response.MigrationVersion = version
+
+	if version < h.expectedVersion {
+		log.Warn("readiness check failed: migrations pending",
+			slog.Int64("migration_version", version),
+			slog.Int64("expected_migration_version", h.expectedVersion))
+		response.Database = "migrations_pending"
+		shared.RespondWithJSON(w, r, http.StatusServiceUnavailable, response)
+		return
+	}
+
+	response.Database = HealthStatusOK
+
+	if h.generation != nil {
+		if err := h.generation.Ping(ctx); err != nil {
+			log.Warn("readiness check failed: generation provider", slog.String("error", err.Error()))
+			response.Generation = "unreachable"
+			shared.RespondWithJSON(w, r, http.StatusServiceUnavailable, response)
+			return
+		}
+		response.Generation = HealthStatusOK
+	}
+
+	response.Status = HealthStatusOK
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/generation"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHealthDB is a HealthDB whose ping result is configurable.
+// Its embedded DBTX is nil, so any query made through it panics.
+type mockHealthDB struct {
+	store.DBTX
+	pingErr   error
+	pingCalls int
+}
+
+func (m *mockHealthDB) PingContext(ctx context.Context) error {
+	m.pingCalls++
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("ping called without a deadline")
+	}
+	return m.pingErr
+}
+
+// staticMigrationVersion returns a MigrationVersionFunc reporting version and err
+// and counting its calls
+func staticMigrationVersion(version int64, err error, calls *int) MigrationVersionFunc {
+	return func(ctx context.Context, db store.DBTX) (int64, error) {
+		*calls++
+		return version, err
+	}
+}
+
+func TestHealthHandler_Liveness(t *testing.T) {
+	db := &mockHealthDB{pingErr: errors.New("connection refused")}
+	var versionCalls int
+	handler := NewHealthHandler(db, staticMigrationVersion(0, nil, &versionCalls), 5, slog.Default())
+
+	w := httptest.NewRecorder()
+	handler.Liveness(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp HealthResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, HealthStatusOK, resp.Status)
+
+	assert.Zero(t, db.pingCalls, "Liveness must not touch the database")
+	assert.Zero(t, versionCalls, "Liveness must not touch the database")
+}
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	tests := []struct {
+		name            string
+		pingErr         error
+		version         int64
+		versionErr      error
+		expectedVersion int64
+		wantStatus      int
+		wantDatabase    string
+		wantVersionCall bool
+	}{
+		{
+			name:            "ready",
+			version:         20250501000010,
+			expectedVersion: 20250501000010,
+			wantStatus:      http.StatusOK,
+			wantDatabase:    HealthStatusOK,
+			wantVersionCall: true,
+		},
+		{
+			name:            "ping_fails",
+			pingErr:         errors.New("connection refused"),
+			version:         20250501000010,
+			expectedVersion: 20250501000010,
+			wantStatus:      http.StatusServiceUnavailable,
+			wantDatabase:    "unreachable",
+		},
+		{
+			name:            "migrations_pending",
+			version:         20250501000009,
+			expectedVersion: 20250501000010,
+			wantStatus:      http.StatusServiceUnavailable,
+			wantDatabase:    "migrations_pending",
+			wantVersionCall: true,
+		},
+		{
+			name:            "migration_version_unavailable",
+			versionErr:      errors.New("relation \"goose_db_version\" does not exist"),
+			expectedVersion: 20250501000010,
+			wantStatus:      http.StatusServiceUnavailable,
+			wantDatabase:    "migration_version_unavailable",
+			wantVersionCall: true,
+		},
+		{
+			name:            "newer_database_is_ready",
+			version:         20250501000011,
+			expectedVersion: 20250501000010,
+			wantStatus:      http.StatusOK,
+			wantDatabase:    HealthStatusOK,
+			wantVersionCall: true,
+		},
+		{
+			name:            "migration_check_disabled",
+			version:         0,
+			expectedVersion: 0,
+			wantStatus:      http.StatusOK,
+			wantDatabase:    HealthStatusOK,
+			wantVersionCall: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &mockHealthDB{pingErr: tc.pingErr}
+			var versionCalls int
+			handler := NewHealthHandler(
+				db,
+				staticMigrationVersion(tc.version, tc.versionErr, &versionCalls),
+				tc.expectedVersion,
+				slog.Default(),
+			)
+
+			w := httptest.NewRecorder()
+			handler.Readiness(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			require.Equal(t, tc.wantStatus, w.Code)
+			assert.Equal(t, 1, db.pingCalls)
+			assert.Equal(t, tc.wantVersionCall, versionCalls == 1)
+
+			var resp HealthResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tc.wantDatabase, resp.Database)
+			if tc.wantStatus == http.StatusOK {
+				assert.Equal(t, HealthStatusOK, resp.Status)
+				assert.Equal(t, tc.version, resp.MigrationVersion)
+			} else {
+				assert.Equal(t, HealthStatusNotReady, resp.Status)
+			}
+
+			// Error details are logged, never returned to the caller
+			assert.NotContains(t, w.Body.String(), "connection refused")
+			assert.NotContains(t, w.Body.String(), "goose_db_version")
+		})
+	}
+}
+
+func TestHealthHandler_Readiness_GenerationCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		pingErr        error
+		wantStatus     int
+		wantGeneration string
+	}{
+		{
+			name:           "provider_reachable",
+			wantStatus:     http.StatusOK,
+			wantGeneration: HealthStatusOK,
+		},
+		{
+			name:           "provider_unreachable",
+			pingErr:        fmt.Errorf("%w: API key not valid", generation.ErrProviderUnreachable),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantGeneration: "unreachable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var versionCalls int
+			handler := NewHealthHandler(
+				&mockHealthDB{},
+				staticMigrationVersion(0, nil, &versionCalls),
+				0,
+				slog.Default(),
+			).WithGenerationCheck(&mocks.MockGenerator{PingErr: tc.pingErr})
+
+			w := httptest.NewRecorder()
+			handler.Readiness(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			require.Equal(t, tc.wantStatus, w.Code)
+			var resp HealthResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, HealthStatusOK, resp.Database)
+			assert.Equal(t, tc.wantGeneration, resp.Generation)
+			assert.NotContains(t, w.Body.String(), "API key not valid")
+		})
+	}
+
+	t.Run("not_checked_by_default", func(t *testing.T) {
+		var versionCalls int
+		handler := NewHealthHandler(&mockHealthDB{}, staticMigrationVersion(0, nil, &versionCalls), 0, slog.Default())
+
+		w := httptest.NewRecorder()
+		handler.Readiness(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "generation")
+	})
+}
+
+func TestNewHealthHandler_NilDependencies(t *testing.T) {
+	var calls int
+	versionFn := staticMigrationVersion(0, nil, &calls)
+
+	assert.Panics(t, func() { NewHealthHandler(nil, versionFn, 0, slog.Default()) })
+	assert.Panics(t, func() { NewHealthHandler(&mockHealthDB{}, nil, 0, slog.Default()) })
+	assert.Panics(t, func() { NewHealthHandler(&mockHealthDB{}, versionFn, 0, nil) })
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/redact"
+	"github.com/phrazzld/scry-api/internal/service"
+)
+
+// ImportMediaTypes are the content types accepted by POST /api/import
+var ImportMediaTypes = []string{"application/json", "text/csv"}
+
+// ImportRowErrorResponse describes a row of an import that failed validation
+type ImportRowErrorResponse struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportResponse is returned when an import succeeds
+type ImportResponse struct {
+	MemoID     string                   `json:"memo_id"`
+	Imported   int                      `json:"imported"`
+	Duplicates int                      `json:"duplicates"`
+	Errors     []ImportRowErrorResponse `json:"errors"`
+}
+
+// ImportRejectedResponse is the error response returned when too many rows of
+// an import fail validation. It extends the standard error response with the
+// failed rows.
+type ImportRejectedResponse struct {
+	shared.ErrorResponse
+	Errors []ImportRowErrorResponse `json:"errors"`
+}
+
+// importCardRequest is one element of a JSON import. Cards may be given either
+// with front, back and tags at the top level, or in the JSON export format
+// with a nested content object; other export fields are ignored.
+// Type is "basic" (the default) or "cloze", whose markup goes in text.
+type importCardRequest struct {
+	Type     string          `json:"type"`
+	Front    string          `json:"front"`
+	Back     string          `json:"back"`
+	Text     string          `json:"text"`
+	Hint     string          `json:"hint"`
+	Tags     []string        `json:"tags"`
+	ImageURL string          `json:"image_url"`
+	Content  json.RawMessage `json:"content"`
+}
+
+// ImportHandler handles requests for importing cards in bulk
+type ImportHandler struct {
+	importService service.ImportService
+	logger        *slog.Logger
+}
+
+// NewImportHandler creates a new ImportHandler
+func NewImportHandler(importService service.ImportService, logger *slog.Logger) *ImportHandler {
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for ImportHandler")
+	}
+
+	return &ImportHandler{
+		importService: importService,
+		logger:        logger.With(slog.String("component", "import_handler")),
+	}
+}
+
+// Import handles POST /api/import requests
+// It creates cards from a JSON array (Content-Type: application/json) or a CSV
+// file with a header row naming front, back and optionally tags and hint columns
+// (Content-Type: text/csv). Both formats produced by GET /api/export are accepted.
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	var cards []service.ImportCard
+	var err error
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		cards, err = parseImportCSV(r.Body)
+	} else {
+		cards, err = parseImportJSON(r.Body)
+	}
+	if err != nil {
+		log.Warn("invalid import file", slog.String("error", redact.Error(err)))
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	result, err := h.importService.ImportCards(r.Context(), userID, cards)
+	if errors.Is(err, service.ErrImportRejected) {
+		log.Debug("import rejected",
+			slog.String("user_id", userID.String()),
+			slog.Int("failed", len(result.Errors)))
+		shared.RespondWithJSON(w, r, http.StatusUnprocessableEntity, ImportRejectedResponse{
+			ErrorResponse: shared.ErrorResponse{
+				Error:   GetSafeErrorMessage(err),
+				Code:    domain.CodeImportRejected,
+				TraceID: shared.GetTraceID(r.Context()),
+			},
+			Errors: toImportRowErrorResponses(result.Errors),
+		})
+		return
+	}
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to import cards")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusCreated, ImportResponse{
+		MemoID:     result.MemoID.String(),
+		Imported:   result.Imported,
+		Duplicates: result.Duplicates,
+		Errors:     toImportRowErrorResponses(result.Errors),
+	})
+}
+
+// parseImportJSON reads a JSON array of cards. An element that is not a card
+// object is reported as a failed row rather than failing the whole upload.
+func parseImportJSON(body io.Reader) ([]service.ImportCard, error) {
+	var elements []json.RawMessage
+	if err := json.NewDecoder(body).Decode(&elements); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, domain.NewValidationError("file", "must be a JSON array of cards", domain.ErrInvalidFormat)
+	}
+
+	cards := make([]service.ImportCard, 0, len(elements))
+	for i, element := range elements {
+		card := service.ImportCard{Line: i + 1}
+
+		var req importCardRequest
+		if err := json.Unmarshal(element, &req); err != nil {
+			card.Error = "card must be an object"
+			cards = append(cards, card)
+			continue
+		}
+		card.Type = domain.CardType(req.Type)
+
+		if len(req.Content) > 0 {
+			if err := json.Unmarshal(req.Content, &card.Content); err != nil {
+				card.Error = "content must be an object with front and back"
+			}
+		} else {
+			card.Content = domain.CardContent{
+				Front:    req.Front,
+				Back:     req.Back,
+				Text:     req.Text,
+				Hint:     req.Hint,
+				Tags:     req.Tags,
+				ImageURL: req.ImageURL,
+			}
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// parseImportCSV reads a CSV file of cards. The first row names the columns,
+// unless an Anki "#columns:" header line does; other lines starting with "#"
+// are skipped, as are columns other than front, back, tags and hint.
+// Tags are separated by spaces, as in Anki.
+func parseImportCSV(body io.Reader) ([]service.ImportCard, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+
+	var columns map[string]int
+	var cards []service.ImportCard
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, domain.NewValidationError("file",
+					fmt.Sprintf("malformed CSV on line %d", parseErr.Line), domain.ErrInvalidFormat)
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if strings.HasPrefix(record[0], "#") {
+			if first, ok := strings.CutPrefix(record[0], "#columns:"); ok {
+				record[0] = first
+				if columns, err = parseImportCSVHeader(record); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if columns == nil {
+			if columns, err = parseImportCSVHeader(record); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		card := service.ImportCard{
+			Line: line,
+			Content: domain.CardContent{
+				Front: field("front"),
+				Back:  field("back"),
+				Hint:  field("hint"),
+			},
+		}
+		if tags := strings.Fields(field("tags")); len(tags) > 0 {
+			card.Content.Tags = tags
+		}
+		cards = append(cards, card)
+	}
+
+	if columns == nil {
+		return nil, domain.NewValidationError("file", "must have a header row", domain.ErrInvalidFormat)
+	}
+	return cards, nil
+}
+
+// parseImportCSVHeader maps lower-cased column names to their positions and
+// checks that the front and back columns are present.
+func parseImportCSVHeader(record []string) (map[string]int, error) {
+	columns := make(map[string]int, len(record))
+	for i, name := range record {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"front", "back"} {
+		if _, ok := columns[required]; !ok {
+			return nil, domain.NewValidationError("file",
+				fmt.Sprintf("header row must include a %s column", required), domain.ErrInvalidFormat)
+		}
+	}
+	return columns, nil
+}
+
+// toImportRowErrorResponses converts row errors to their response form
+func toImportRowErrorResponses(rowErrors []service.ImportRowError) []ImportRowErrorResponse {
+	response := make([]ImportRowErrorResponse, 0, len(rowErrors))
+	for _, rowErr := range rowErrors {
+		response = append(response, ImportRowErrorResponse{Line: rowErr.Line, Error: rowErr.Message})
+	}
+	return response
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockImportService is a mock implementation of service.ImportService
+type MockImportService struct {
+	ImportCardsFn func(ctx context.Context, userID uuid.UUID, cards []service.ImportCard) (*service.ImportResult, error)
+}
+
+// ImportCards implements service.ImportService
+func (m *MockImportService) ImportCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	cards []service.ImportCard,
+) (*service.ImportResult, error) {
+	if m.ImportCardsFn != nil {
+		return m.ImportCardsFn(ctx, userID, cards)
+	}
+	return &service.ImportResult{}, nil
+}
+
+var _ service.ImportService = (*MockImportService)(nil)
+
+func newImportRequest(userID uuid.UUID, contentType, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	return req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+}
+
+// capturingImportService records the parsed rows and reports them all as imported
+func capturingImportService(captured *[]service.ImportCard) *MockImportService {
+	return &MockImportService{
+		ImportCardsFn: func(ctx context.Context, userID uuid.UUID, cards []service.ImportCard) (*service.ImportResult, error) {
+			*captured = cards
+			return &service.ImportResult{MemoID: uuid.New(), Imported: len(cards)}, nil
+		},
+	}
+}
+
+// TestImportHandler_Import tests parsing of JSON and CSV uploads
+func TestImportHandler_Import(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("json_array", func(t *testing.T) {
+		var captured []service.ImportCard
+		handler := NewImportHandler(capturingImportService(&captured), slog.Default())
+
+		w := httptest.NewRecorder()
+		handler.Import(w, newImportRequest(userID, "application/json",
+			`[{"front":"Q1","back":"A1","tags":["geo"]},{"content":{"front":"Q2","back":"A2","hint":"h"}},"oops"]`))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp ImportResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, 3, resp.Imported)
+		assert.NotEmpty(t, resp.MemoID)
+
+		require.Len(t, captured, 3)
+		assert.Equal(t, service.ImportCard{
+			Line:    1,
+			Content: domain.CardContent{Front: "Q1", Back: "A1", Tags: []string{"geo"}},
+		}, captured[0])
+		assert.Equal(t, service.ImportCard{
+			Line:    2,
+			Content: domain.CardContent{Front: "Q2", Back: "A2", Hint: "h"},
+		}, captured[1])
+		assert.Equal(t, 3, captured[2].Line)
+		assert.Equal(t, "card must be an object", captured[2].Error)
+	})
+
+	t.Run("csv_with_header", func(t *testing.T) {
+		var captured []service.ImportCard
+		handler := NewImportHandler(capturingImportService(&captured), slog.Default())
+
+		w := httptest.NewRecorder()
+		handler.Import(w, newImportRequest(userID, "text/csv; charset=utf-8",
+			"Front,Back,Tags\nQ1,A1,geo europe\n\n\"Q2, quoted\",\"A2\nsecond line\",\nQ3,A3\n"))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Len(t, captured, 3)
+		assert.Equal(t, service.ImportCard{
+			Line:    2,
+			Content: domain.CardContent{Front: "Q1", Back: "A1", Tags: []string{"geo", "europe"}},
+		}, captured[0])
+		assert.Equal(t, 4, captured[1].Line)
+		assert.Equal(t, "Q2, quoted", captured[1].Content.Front)
+		assert.Equal(t, "A2\nsecond line", captured[1].Content.Back)
+		assert.Equal(t, 6, captured[2].Line, "Line numbers count the embedded newline")
+		assert.Empty(t, captured[2].Content.Tags, "Short rows leave missing columns empty")
+	})
+
+	t.Run("round_trips_exports", func(t *testing.T) {
+		cards := seedExportCollection(userID)
+		exporter := NewExportHandler(&MockExportService{Cards: cards}, slog.Default())
+
+		for _, tc := range []struct {
+			format      string
+			contentType string
+		}{
+			{ExportFormatJSON, "application/json"},
+			{ExportFormatAnkiCSV, "text/csv"},
+		} {
+			exported := httptest.NewRecorder()
+			exporter.Export(exported, newExportRequest(userID, "?format="+tc.format))
+			require.Equal(t, http.StatusOK, exported.Code)
+
+			var captured []service.ImportCard
+			handler := NewImportHandler(capturingImportService(&captured), slog.Default())
+			w := httptest.NewRecorder()
+			handler.Import(w, newImportRequest(userID, tc.contentType, exported.Body.String()))
+
+			require.Equal(t, http.StatusCreated, w.Code, tc.format)
+			require.Len(t, captured, len(cards), tc.format)
+			for i, card := range cards {
+				var content domain.CardContent
+				require.NoError(t, json.Unmarshal(card.Card.Content, &content))
+				assert.Equal(t, content, captured[i].Content, tc.format)
+				assert.Empty(t, captured[i].Error, tc.format)
+			}
+		}
+	})
+}
+
+// TestImportHandler_Errors tests malformed uploads and rejected imports
+func TestImportHandler_Errors(t *testing.T) {
+	userID := uuid.New()
+
+	invalidFiles := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"json_object", "application/json", `{"front":"Q","back":"A"}`},
+		{"csv_without_back_column", "text/csv", "front,tags\nQ,geo\n"},
+		{"csv_unterminated_quote", "text/csv", "front,back\n\"Q,A\n"},
+		{"empty_csv", "text/csv", ""},
+	}
+	for _, tc := range invalidFiles {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewImportHandler(&MockImportService{}, slog.Default())
+			w := httptest.NewRecorder()
+			handler.Import(w, newImportRequest(userID, tc.contentType, tc.body))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var resp shared.ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, domain.CodeValidationFailed, resp.Code)
+			assert.Contains(t, resp.Error, "Invalid file")
+		})
+	}
+
+	t.Run("partial_failures_are_reported", func(t *testing.T) {
+		handler := NewImportHandler(&MockImportService{
+			ImportCardsFn: func(ctx context.Context, id uuid.UUID, cards []service.ImportCard) (*service.ImportResult, error) {
+				return &service.ImportResult{
+					MemoID:     uuid.New(),
+					Imported:   9,
+					Duplicates: 2,
+					Errors:     []service.ImportRowError{{Line: 7, Message: "back is required"}},
+				}, nil
+			},
+		}, slog.Default())
+		w := httptest.NewRecorder()
+		handler.Import(w, newImportRequest(userID, "application/json", `[]`))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.JSONEq(t,
+			`[{"line":7,"error":"back is required"}]`,
+			string(extractJSONField(t, w.Body.Bytes(), "errors")))
+	})
+
+	t.Run("rejected_import", func(t *testing.T) {
+		handler := NewImportHandler(&MockImportService{
+			ImportCardsFn: func(ctx context.Context, id uuid.UUID, cards []service.ImportCard) (*service.ImportResult, error) {
+				return &service.ImportResult{
+					Errors: []service.ImportRowError{
+						{Line: 2, Message: "front is required"},
+						{Line: 3, Message: `invalid tag "c++"`},
+					},
+				}, service.ErrImportRejected
+			},
+		}, slog.Default())
+		w := httptest.NewRecorder()
+		handler.Import(w, newImportRequest(userID, "text/csv", "front,back\n,A\nQ,A\n"))
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var resp ImportRejectedResponse
+		require.NoError(t, json.NewDecoder(bytes.NewReader(w.Body.Bytes())).Decode(&resp))
+		assert.Equal(t, domain.CodeImportRejected, resp.Code)
+		assert.NotEmpty(t, resp.Error)
+		assert.Equal(t, []ImportRowErrorResponse{
+			{Line: 2, Error: "front is required"},
+			{Line: 3, Error: `invalid tag "c++"`},
+		}, resp.Errors)
+	})
+}
+
+// extractJSONField returns the raw value of a top-level field of a JSON object
+func extractJSONField(t *testing.T, body []byte, field string) json.RawMessage {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &fields))
+	return fields[field]
+}
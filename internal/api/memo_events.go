@@ -0,0 +1,309 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// memoEventBuffer is how many status events a stream holds for a slow client
+// before dropping them. Generation publishes only a handful per memo.
+const memoEventBuffer = 16
+
+// memoEventKeepAlive is how often an idle stream sends a comment so
+// that proxies do not close the connection.
+const memoEventKeepAlive = 15 * time.Second
+
+// MaxMemoStatusWait is the longest GET /api/memos/{id}/status waits for a
+// status change.
+const MaxMemoStatusWait = 60 * time.Second
+
+// memoStatusPollInterval is how often a waiting status request re-reads the
+// memo, in case a change was not published as an event.
+const memoStatusPollInterval = 2 * time.Second
+
+// MemoEventSource is the event bus that memo status events are published on.
+// *events.EventBus implements it.
+type MemoEventSource interface {
+	Subscribe(eventType string, handler events.EventHandler)
+	Unsubscribe(eventType string, handler events.EventHandler)
+}
+
+// MemoStatusEventResponse is the data of a "status" event sent by
+// GET /api/memos/{id}/events, and the response of GET /api/memos/{id}/status
+type MemoStatusEventResponse struct {
+	MemoID string `json:"memo_id"`
+	Status string `json:"status"`
+
+	// CardCount is how many cards have been generated so far. Omitted when
+	// the status was read from the memo rather than from a published change,
+	// such as in the first event of a stream.
+	CardCount *int `json:"card_count,omitempty"`
+}
+
+// WithMemoEvents enables GET /api/memos/{id}/events, which streams status
+// changes published on source.
+func WithMemoEvents(source MemoEventSource) MemoHandlerOption {
+	return func(h *MemoHandler) {
+		h.memoEvents = source
+	}
+}
+
+// memoEventSubscription receives status events for one memo from the event
+// bus and hands them to a single stream.
+type memoEventSubscription struct {
+	memoID uuid.UUID
+	events chan events.MemoStatusChangedEvent
+	logger *slog.Logger
+}
+
+// HandleEvent implements events.EventHandler. It never blocks the publisher:
+// events for a stream that has fallen behind are dropped.
+func (s *memoEventSubscription) HandleEvent(ctx context.Context, event *events.TaskRequestEvent) error {
+	var payload events.MemoStatusChangedEvent
+	if err := event.UnmarshalPayload(&payload); err != nil {
+		return fmt.Errorf("failed to decode memo status event: %w", err)
+	}
+	if payload.MemoID != s.memoID {
+		return nil
+	}
+
+	select {
+	case s.events <- payload:
+	default:
+		s.logger.Warn("dropping memo status event for slow stream",
+			slog.String("memo_id", s.memoID.String()),
+			slog.String("status", payload.Status))
+	}
+	return nil
+}
+
+// StreamMemoEvents handles GET /api/memos/{id}/events requests
+// It streams the memo's status as Server-Sent Events: first its current status,
+// then each change published while cards generate. The stream ends when the
+// memo reaches a terminal status or the client disconnects.
+func (h *MemoHandler) StreamMemoEvents(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	if h.memoEvents == nil {
+		log.Warn("memo events requested but no event source is configured")
+		HandleAPIError(w, r, store.ErrNotFound, "Memo events are not available")
+		return
+	}
+
+	memo, sub, ok := h.subscribeToMemo(w, r, log)
+	if !ok {
+		return
+	}
+	defer h.memoEvents.Unsubscribe(events.EventTypeMemoStatusChanged, sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Stop nginx from buffering the stream
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+
+	send := func(event MemoStatusEventResponse) bool {
+		data, err := json.Marshal(event)
+		if err != nil {
+			log.Error("failed to encode memo status event", slog.Any("error", err))
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	if !send(MemoStatusEventResponse{MemoID: memo.ID.String(), Status: string(memo.Status)}) ||
+		memo.Status.IsTerminal() {
+		return
+	}
+
+	ticker := time.NewTicker(memoEventKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			log.Debug("memo event stream closed by client", slog.String("memo_id", memo.ID.String()))
+			return
+		case event := <-sub.events:
+			if !send(statusEventResponse(event)) || domain.MemoStatus(event.Status).IsTerminal() {
+				return
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
+// WaitForMemoStatus handles GET /api/memos/{id}/status requests
+// It is a long-polling alternative to StreamMemoEvents for clients without
+// Server-Sent Events. A memo in a terminal status is returned at once;
+// otherwise the request waits up to the wait query parameter, such as "25s",
+// for the status to change and returns the new status. If nothing changes in
+// time it returns the current status.
+func (h *MemoHandler) WaitForMemoStatus(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	wait, err := parseWaitQuery(r, MaxMemoStatusWait)
+	if err != nil {
+		log.Warn("invalid wait parameter", slog.String("wait", r.URL.Query().Get("wait")))
+		HandleAPIError(w, r, err, "Invalid wait parameter")
+		return
+	}
+
+	memo, sub, ok := h.subscribeToMemo(w, r, log)
+	if !ok {
+		return
+	}
+	if h.memoEvents != nil {
+		defer h.memoEvents.Unsubscribe(events.EventTypeMemoStatusChanged, sub)
+	}
+
+	if memo.Status.IsTerminal() || wait == 0 {
+		shared.RespondWithJSON(w, r, http.StatusOK,
+			MemoStatusEventResponse{MemoID: memo.ID.String(), Status: string(memo.Status)})
+		return
+	}
+
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+
+	// Poll as well, for changes that are not published on the event bus
+	poll := time.NewTicker(memoStatusPollInterval)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-sub.events:
+			shared.RespondWithJSON(w, r, http.StatusOK, statusEventResponse(event))
+			return
+		case <-poll.C:
+			current, err := h.memoService.GetMemo(r.Context(), memo.ID)
+			if err != nil {
+				HandleAPIError(w, r, err, "Failed to get memo")
+				return
+			}
+			if current.Status != memo.Status {
+				shared.RespondWithJSON(w, r, http.StatusOK,
+					MemoStatusEventResponse{MemoID: current.ID.String(), Status: string(current.Status)})
+				return
+			}
+		case <-timeout.C:
+			shared.RespondWithJSON(w, r, http.StatusOK,
+				MemoStatusEventResponse{MemoID: memo.ID.String(), Status: string(memo.Status)})
+			return
+		}
+	}
+}
+
+// subscribeToMemo reads the memo named in the request path, checking that it
+// belongs to the authenticated user, and subscribes to its status changes if an
+// event source is configured. The subscription is made before the memo is read
+// so that a change in between is still delivered. When it returns false it has
+// written an error response and unsubscribed; otherwise the caller must
+// unsubscribe.
+func (h *MemoHandler) subscribeToMemo(
+	w http.ResponseWriter,
+	r *http.Request,
+	log *slog.Logger,
+) (*domain.Memo, *memoEventSubscription, bool) {
+	// Extract memo ID from URL path using chi router
+	pathMemoID := chi.URLParam(r, "id")
+	if pathMemoID == "" {
+		log.Warn("memo ID not found in URL path")
+		HandleAPIError(w, r, domain.ErrValidation, "Memo ID is required")
+		return nil, nil, false
+	}
+
+	// Parse memo ID as UUID
+	memoID, err := uuid.Parse(pathMemoID)
+	if err != nil {
+		log.Warn("invalid memo ID format", slog.String("memo_id", pathMemoID))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid memo ID format")
+		return nil, nil, false
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return nil, nil, false
+	}
+
+	sub := &memoEventSubscription{
+		memoID: memoID,
+		events: make(chan events.MemoStatusChangedEvent, memoEventBuffer),
+		logger: log,
+	}
+	if h.memoEvents != nil {
+		h.memoEvents.Subscribe(events.EventTypeMemoStatusChanged, sub)
+	}
+	fail := func(err error) (*domain.Memo, *memoEventSubscription, bool) {
+		if h.memoEvents != nil {
+			h.memoEvents.Unsubscribe(events.EventTypeMemoStatusChanged, sub)
+		}
+		HandleAPIError(w, r, err, "Failed to get memo")
+		return nil, nil, false
+	}
+
+	memo, err := h.memoService.GetMemo(r.Context(), memoID)
+	if err != nil {
+		return fail(err)
+	}
+
+	if memo.UserID != userID {
+		log.Warn("user attempted to follow status of memo they don't own",
+			slog.String("user_id", userID.String()),
+			slog.String("memo_id", memoID.String()))
+		return fail(service.ErrMemoNotOwned)
+	}
+
+	return memo, sub, true
+}
+
+// statusEventResponse converts a published status change to its response
+func statusEventResponse(event events.MemoStatusChangedEvent) MemoStatusEventResponse {
+	cardCount := event.CardCount
+	return MemoStatusEventResponse{
+		MemoID:    event.MemoID.String(),
+		Status:    event.Status,
+		CardCount: &cardCount,
+	}
+}
+
+// parseWaitQuery parses the optional wait query parameter, a duration such as
+// "25s". A missing wait is 0 and a longer one is capped at maxWait.
+func parseWaitQuery(r *http.Request, maxWait time.Duration) (time.Duration, error) {
+	raw := r.URL.Query().Get("wait")
+	if raw == "" {
+		return 0, nil
+	}
+
+	wait, err := time.ParseDuration(raw)
+	if err != nil || wait < 0 {
+		return 0, domain.NewValidationError("wait", "must be a non-negative duration such as 25s", domain.ErrValidation)
+	}
+	return min(wait, maxWait), nil
+}
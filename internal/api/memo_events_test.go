@@ -0,0 +1,278 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingEventSource is an event bus that tracks how many stream
+// subscriptions are open
+type countingEventSource struct {
+	*events.EventBus
+
+	mu         sync.Mutex
+	subscribed int
+	changed    chan struct{}
+}
+
+func newCountingEventSource() *countingEventSource {
+	return &countingEventSource{
+		EventBus: events.NewEventBus(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		changed:  make(chan struct{}, 16),
+	}
+}
+
+func (s *countingEventSource) Subscribe(eventType string, handler events.EventHandler) {
+	s.EventBus.Subscribe(eventType, handler)
+	s.add(1)
+}
+
+func (s *countingEventSource) Unsubscribe(eventType string, handler events.EventHandler) {
+	s.EventBus.Unsubscribe(eventType, handler)
+	s.add(-1)
+}
+
+func (s *countingEventSource) add(n int) {
+	s.mu.Lock()
+	s.subscribed += n
+	s.mu.Unlock()
+	s.changed <- struct{}{}
+}
+
+func (s *countingEventSource) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subscribed
+}
+
+// waitForCount waits until want subscriptions are open
+func (s *countingEventSource) waitForCount(t *testing.T, want int) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for s.count() != want {
+		select {
+		case <-s.changed:
+		case <-deadline:
+			t.Fatalf("expected %d open subscriptions, got %d", want, s.count())
+		}
+	}
+}
+
+// publishStatus publishes a memo status change on source
+func publishStatus(t *testing.T, source *countingEventSource, memoID uuid.UUID, status domain.MemoStatus, cards int) {
+	t.Helper()
+	event, err := events.NewMemoStatusChangedEvent(events.MemoStatusChangedEvent{
+		MemoID:    memoID,
+		Status:    string(status),
+		CardCount: cards,
+		ChangedAt: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, source.Publish(context.Background(), event))
+}
+
+// readSSEFrame reads one Server-Sent Events frame, skipping comments
+func readSSEFrame(t *testing.T, reader *bufio.Reader) (string, MemoStatusEventResponse) {
+	t.Helper()
+	var name string
+	var data MemoStatusEventResponse
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && name != "":
+			return name, data
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data))
+		}
+	}
+}
+
+func TestMemoHandler_StreamMemoEvents(t *testing.T) {
+	userID := uuid.New()
+	memoID := uuid.New()
+	source := newCountingEventSource()
+	memoService := &MockMemoService{
+		GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+			return &domain.Memo{ID: id, UserID: userID, Text: "memo", Status: domain.MemoStatusPending}, nil
+		},
+	}
+	handler := NewMemoHandler(memoService, slog.New(slog.NewTextHandler(io.Discard, nil)), WithMemoEvents(source))
+
+	router := chi.NewRouter()
+	router.Get("/api/memos/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), shared.UserIDContextKey, userID)
+		handler.StreamMemoEvents(w, r.WithContext(ctx))
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	connect := func(ctx context.Context) (*http.Response, *bufio.Reader) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			server.URL+"/api/memos/"+memoID.String()+"/events", nil)
+		require.NoError(t, err)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		return resp, bufio.NewReader(resp.Body)
+	}
+
+	t.Run("streams status changes until the memo completes", func(t *testing.T) {
+		resp, reader := connect(context.Background())
+		defer func() { _ = resp.Body.Close() }()
+
+		name, event := readSSEFrame(t, reader)
+		assert.Equal(t, "status", name)
+		assert.Equal(t, string(domain.MemoStatusPending), event.Status)
+		assert.Nil(t, event.CardCount, "the opening status has no card count")
+
+		// Events for other memos are not sent
+		publishStatus(t, source, uuid.New(), domain.MemoStatusProcessing, 0)
+		publishStatus(t, source, memoID, domain.MemoStatusProcessing, 3)
+		name, event = readSSEFrame(t, reader)
+		assert.Equal(t, "status", name)
+		assert.Equal(t, memoID.String(), event.MemoID)
+		assert.Equal(t, string(domain.MemoStatusProcessing), event.Status)
+		require.NotNil(t, event.CardCount)
+		assert.Equal(t, 3, *event.CardCount)
+
+		publishStatus(t, source, memoID, domain.MemoStatusCompleted, 3)
+		_, event = readSSEFrame(t, reader)
+		assert.Equal(t, string(domain.MemoStatusCompleted), event.Status)
+
+		// Completion ends the stream and its subscription
+		rest, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Empty(t, strings.TrimSpace(string(rest)))
+		source.waitForCount(t, 0)
+	})
+
+	t.Run("client disconnect ends the subscription", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		resp, reader := connect(ctx)
+		defer func() { _ = resp.Body.Close() }()
+
+		_, event := readSSEFrame(t, reader)
+		assert.Equal(t, string(domain.MemoStatusPending), event.Status)
+		assert.Equal(t, 1, source.count())
+
+		cancel()
+		source.waitForCount(t, 0)
+	})
+}
+
+func TestMemoHandler_WaitForMemoStatus(t *testing.T) {
+	userID := uuid.New()
+	memoID := uuid.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	memoWithStatus := func(status domain.MemoStatus) *MockMemoService {
+		return &MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return &domain.Memo{ID: id, UserID: userID, Text: "memo", Status: status}, nil
+			},
+		}
+	}
+	newRequest := func(wait string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/memos/"+memoID.String()+"/status?wait="+wait, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", memoID.String())
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		return req.WithContext(context.WithValue(ctx, shared.UserIDContextKey, userID))
+	}
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) MemoStatusEventResponse {
+		t.Helper()
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp MemoStatusEventResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	t.Run("returns promptly when the status changes mid-wait", func(t *testing.T) {
+		source := newCountingEventSource()
+		handler := NewMemoHandler(memoWithStatus(domain.MemoStatusPending), logger, WithMemoEvents(source))
+
+		w := httptest.NewRecorder()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			handler.WaitForMemoStatus(w, newRequest("25s"))
+		}()
+
+		source.waitForCount(t, 1)
+		publishStatus(t, source, memoID, domain.MemoStatusProcessing, 2)
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler did not return after the status changed")
+		}
+
+		resp := decode(t, w)
+		assert.Equal(t, memoID.String(), resp.MemoID)
+		assert.Equal(t, string(domain.MemoStatusProcessing), resp.Status)
+		require.NotNil(t, resp.CardCount)
+		assert.Equal(t, 2, *resp.CardCount)
+		source.waitForCount(t, 0)
+	})
+
+	t.Run("terminal memos return at once", func(t *testing.T) {
+		source := newCountingEventSource()
+		handler := NewMemoHandler(memoWithStatus(domain.MemoStatusCompleted), logger, WithMemoEvents(source))
+
+		w := httptest.NewRecorder()
+		handler.WaitForMemoStatus(w, newRequest("60s"))
+
+		assert.Equal(t, string(domain.MemoStatusCompleted), decode(t, w).Status)
+		assert.Equal(t, 0, source.count())
+	})
+
+	t.Run("returns the current status when the wait ends", func(t *testing.T) {
+		handler := NewMemoHandler(memoWithStatus(domain.MemoStatusProcessing), logger,
+			WithMemoEvents(newCountingEventSource()))
+
+		w := httptest.NewRecorder()
+		handler.WaitForMemoStatus(w, newRequest("20ms"))
+
+		resp := decode(t, w)
+		assert.Equal(t, string(domain.MemoStatusProcessing), resp.Status)
+		assert.Nil(t, resp.CardCount)
+	})
+
+	t.Run("rejects an invalid wait", func(t *testing.T) {
+		handler := NewMemoHandler(memoWithStatus(domain.MemoStatusPending), logger)
+
+		for _, wait := range []string{"soon", "-1s"} {
+			w := httptest.NewRecorder()
+			handler.WaitForMemoStatus(w, newRequest(wait))
+			assert.Equal(t, http.StatusBadRequest, w.Code, wait)
+		}
+	})
+
+	t.Run("caps the wait", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/memos/x/status?wait=10m", nil)
+		wait, err := parseWaitQuery(req, MaxMemoStatusWait)
+		require.NoError(t, err)
+		assert.Equal(t, MaxMemoStatusWait, wait)
+	})
+}
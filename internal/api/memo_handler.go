@@ -1,10 +1,12 @@
 package api
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/api/shared"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -15,8 +17,43 @@ import (
 // CreateMemoRequest represents the request body for creating a new memo
 type CreateMemoRequest struct {
 	Text string `json:"text" validate:"required,min=1"`
+
+	// Draft stores the memo without generating cards.
+	// Generation can be triggered later via POST /api/memos/{id}/generate.
+	Draft bool `json:"draft"`
+}
+
+// RegenerateMemoRequest represents the request body for regenerating a memo's cards
+type RegenerateMemoRequest struct {
+	Text string `json:"text" validate:"required,min=1"`
+
+	// Replace deletes the memo's existing cards, and their review history, instead
+	// of keeping them as superseded cards.
+	Replace bool `json:"replace"`
 }
 
+// PreviewCardsRequest represents the request body for previewing the cards
+// generated from a text
+type PreviewCardsRequest struct {
+	Text string `json:"text" validate:"required,min=1"`
+}
+
+// PreviewCardsResponse lists the cards generated for a preview. They are not
+// saved, so they have no IDs.
+type PreviewCardsResponse struct {
+	Cards []PreviewCardResponse `json:"cards"`
+}
+
+// PreviewCardResponse represents one previewed card
+type PreviewCardResponse struct {
+	Type    string      `json:"type"`
+	Content interface{} `json:"content"`
+}
+
+// IdempotencyKeyHeader is the request header clients set on POST /api/memos so that
+// retried submissions return the originally created memo instead of a duplicate.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 // MemoResponse represents the response data for a memo
 type MemoResponse struct {
 	ID        string    `json:"id"`
@@ -25,25 +62,62 @@ type MemoResponse struct {
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// StatusReason explains the status to the user, such as why generation
+	// was blocked. Omitted when there is nothing to explain.
+	StatusReason string `json:"status_reason,omitempty"`
+
+	// GenerationDurationMs is how long card generation took, in milliseconds.
+	// Only present for generated memos when generation timing is exposed.
+	GenerationDurationMs *int64 `json:"generation_duration_ms,omitempty"`
+
+	// DeckID is the deck the memo's generated cards are placed in, if any
+	DeckID *string `json:"deck_id,omitempty"`
 }
 
 // MemoHandler handles memo-related HTTP requests
 type MemoHandler struct {
 	memoService service.MemoService
 	logger      *slog.Logger
+
+	// exposeGenerationTiming includes generation duration in memo responses
+	exposeGenerationTiming bool
+
+	// memoEvents, if set, is subscribed to for streaming memo status changes
+	memoEvents MemoEventSource
+}
+
+// MemoHandlerOption configures optional MemoHandler behavior
+type MemoHandlerOption func(*MemoHandler)
+
+// WithGenerationTiming controls whether memo responses include how long card
+// generation took. Disabled by default.
+func WithGenerationTiming(enabled bool) MemoHandlerOption {
+	return func(h *MemoHandler) {
+		h.exposeGenerationTiming = enabled
+	}
 }
 
 // NewMemoHandler creates a new MemoHandler
-func NewMemoHandler(memoService service.MemoService, logger *slog.Logger) *MemoHandler {
+func NewMemoHandler(
+	memoService service.MemoService,
+	logger *slog.Logger,
+	opts ...MemoHandlerOption,
+) *MemoHandler {
 	if logger == nil {
 		// ALLOW-PANIC: Constructor enforcing required dependency
 		panic("logger cannot be nil for MemoHandler")
 	}
 
-	return &MemoHandler{
+	h := &MemoHandler{
 		memoService: memoService,
 		logger:      logger.With(slog.String("component", "memo_handler")),
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
 }
 
 // CreateMemo handles POST /api/memos requests
@@ -72,28 +146,277 @@ func (h *MemoHandler) CreateMemo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Requests carrying an idempotency key replay the original memo when repeated
+	if key := r.Header.Get(IdempotencyKeyHeader); key != "" {
+		memo, replayed, err := h.memoService.CreateMemoIdempotent(
+			r.Context(), userID, req.Text, req.Draft, key)
+		if err != nil {
+			handleGenerationError(w, r, err, "Failed to create memo")
+			return
+		}
+
+		status := http.StatusAccepted
+		switch {
+		case replayed:
+			log.Debug("replaying memo for idempotency key", slog.String("memo_id", memo.ID.String()))
+			status = http.StatusOK
+		case req.Draft:
+			status = http.StatusCreated
+		}
+		shared.RespondWithJSON(w, r, status, h.memoResponse(memo))
+		return
+	}
+
+	// Draft memos are stored without enqueuing a generation task
+	if req.Draft {
+		memo, err := h.memoService.CreateDraftMemo(r.Context(), userID, req.Text)
+		if err != nil {
+			HandleAPIError(w, r, err, "Failed to create memo")
+			return
+		}
+
+		shared.RespondWithJSON(w, r, http.StatusCreated, h.memoResponse(memo))
+		return
+	}
+
 	// Create memo and enqueue task
 	memo, err := h.memoService.CreateMemoAndEnqueueTask(r.Context(), userID, req.Text)
 	if err != nil {
-		HandleAPIError(w, r, err, "Failed to create memo")
+		handleGenerationError(w, r, err, "Failed to create memo")
 		return
 	}
 
 	// Transform domain object to response
-	response := memoToDTOResponse(memo)
+	response := h.memoResponse(memo)
 
 	// Return response with 202 Accepted status (since processing happens asynchronously)
 	shared.RespondWithJSON(w, r, http.StatusAccepted, response)
 }
 
+// GenerateMemo handles POST /api/memos/{id}/generate requests
+// It submits a draft memo for card generation.
+func (h *MemoHandler) GenerateMemo(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract memo ID from URL path using chi router
+	pathMemoID := chi.URLParam(r, "id")
+	if pathMemoID == "" {
+		log.Warn("memo ID not found in URL path")
+		HandleAPIError(w, r, domain.ErrValidation, "Memo ID is required")
+		return
+	}
+
+	// Parse memo ID as UUID
+	memoID, err := uuid.Parse(pathMemoID)
+	if err != nil {
+		log.Warn("invalid memo ID format", slog.String("memo_id", pathMemoID))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid memo ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	memo, err := h.memoService.GenerateMemo(r.Context(), userID, memoID)
+	if err != nil {
+		handleGenerationError(w, r, err, "Failed to generate memo")
+		return
+	}
+
+	// Return response with 202 Accepted status (since processing happens asynchronously)
+	shared.RespondWithJSON(w, r, http.StatusAccepted, h.memoResponse(memo))
+}
+
+// RegenerateMemo handles POST /api/memos/{id}/regenerate requests
+// It replaces the memo's text and submits it for card generation again.
+func (h *MemoHandler) RegenerateMemo(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract memo ID from URL path using chi router
+	pathMemoID := chi.URLParam(r, "id")
+	if pathMemoID == "" {
+		log.Warn("memo ID not found in URL path")
+		HandleAPIError(w, r, domain.ErrValidation, "Memo ID is required")
+		return
+	}
+
+	// Parse memo ID as UUID
+	memoID, err := uuid.Parse(pathMemoID)
+	if err != nil {
+		log.Warn("invalid memo ID format", slog.String("memo_id", pathMemoID))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid memo ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	// Parse request body
+	var req RegenerateMemoRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	// Validate request
+	if err := shared.Validate.Struct(req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	memo, err := h.memoService.Regenerate(r.Context(), userID, memoID, req.Text, req.Replace)
+	if err != nil {
+		handleGenerationError(w, r, err, "Failed to regenerate memo")
+		return
+	}
+
+	// Return response with 202 Accepted status (since processing happens asynchronously)
+	shared.RespondWithJSON(w, r, http.StatusAccepted, h.memoResponse(memo))
+}
+
+// PreviewCards handles POST /api/memos/preview requests
+// It generates cards from the given text and returns them without creating a
+// memo or saving the cards.
+func (h *MemoHandler) PreviewCards(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	// Parse request body
+	var req PreviewCardsRequest
+	if err := shared.DecodeJSON(r, &req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	// Validate request
+	if err := shared.Validate.Struct(req); err != nil {
+		HandleValidationError(w, r, err)
+		return
+	}
+
+	cards, err := h.memoService.PreviewCards(r.Context(), userID, req.Text)
+	if err != nil {
+		handleGenerationError(w, r, err, "Failed to preview cards")
+		return
+	}
+
+	response := PreviewCardsResponse{Cards: make([]PreviewCardResponse, 0, len(cards))}
+	for _, card := range cards {
+		full := cardToResponse(card)
+		response.Cards = append(response.Cards, PreviewCardResponse{
+			Type:    full.Type,
+			Content: full.Content,
+		})
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// GetMemo handles GET /api/memos/{id} requests
+// It returns the memo if it belongs to the authenticated user, so clients can
+// poll for generation to finish.
+func (h *MemoHandler) GetMemo(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract memo ID from URL path using chi router
+	pathMemoID := chi.URLParam(r, "id")
+	if pathMemoID == "" {
+		log.Warn("memo ID not found in URL path")
+		HandleAPIError(w, r, domain.ErrValidation, "Memo ID is required")
+		return
+	}
+
+	// Parse memo ID as UUID
+	memoID, err := uuid.Parse(pathMemoID)
+	if err != nil {
+		log.Warn("invalid memo ID format", slog.String("memo_id", pathMemoID))
+		HandleAPIError(w, r, domain.ErrInvalidID, "Invalid memo ID format")
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	memo, err := h.memoService.GetMemo(r.Context(), memoID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get memo")
+		return
+	}
+
+	if memo.UserID != userID {
+		log.Warn("user attempted to access memo they don't own",
+			slog.String("user_id", userID.String()),
+			slog.String("memo_id", memoID.String()))
+		HandleAPIError(w, r, service.ErrMemoNotOwned, "Failed to get memo")
+		return
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, h.memoResponse(memo))
+}
+
+// handleGenerationError writes the error response for a request that submits a
+// memo for generation. When the user has used up their generation quota, the
+// Retry-After header says when the next quota period starts.
+func handleGenerationError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	var quotaErr *service.GenerationQuotaError
+	if errors.As(err, &quotaErr) {
+		w.Header().Set("Retry-After", quotaErr.ResetsAt.UTC().Format(http.TimeFormat))
+	}
+	HandleAPIError(w, r, err, message)
+}
+
+// memoResponse converts a domain.Memo to a MemoResponse, adding generation
+// timing when it is exposed and the memo has been generated.
+func (h *MemoHandler) memoResponse(memo *domain.Memo) MemoResponse {
+	response := memoToDTOResponse(memo)
+	if h.exposeGenerationTiming && memo.GenerationDuration > 0 {
+		ms := memo.GenerationDuration.Milliseconds()
+		response.GenerationDurationMs = &ms
+	}
+	return response
+}
+
 // memoToDTOResponse converts a domain.Memo to a MemoResponse
 func memoToDTOResponse(memo *domain.Memo) MemoResponse {
-	return MemoResponse{
+	response := MemoResponse{
 		ID:        memo.ID.String(),
 		UserID:    memo.UserID.String(),
 		Text:      memo.Text,
 		Status:    string(memo.Status),
 		CreatedAt: memo.CreatedAt,
 		UpdatedAt: memo.UpdatedAt,
+
+		StatusReason: memo.StatusReason,
+	}
+	if memo.DeckID != nil {
+		deckID := memo.DeckID.String()
+		response.DeckID = &deckID
 	}
+	return response
 }
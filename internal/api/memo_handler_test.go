@@ -5,15 +5,22 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/api/shared"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/task"
+	"github.com/phrazzld/scry-api/internal/task/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,8 +28,24 @@ import (
 // MockMemoService is a mock implementation of service.MemoService for testing
 type MockMemoService struct {
 	CreateMemoAndEnqueueTaskFn func(ctx context.Context, userID uuid.UUID, text string) (*domain.Memo, error)
-	UpdateMemoStatusFn         func(ctx context.Context, memoID uuid.UUID, status domain.MemoStatus) error
-	GetMemoFn                  func(ctx context.Context, memoID uuid.UUID) (*domain.Memo, error)
+	CreateDraftMemoFn          func(ctx context.Context, userID uuid.UUID, text string) (*domain.Memo, error)
+	CreateMemoIdempotentFn     func(
+		ctx context.Context,
+		userID uuid.UUID,
+		text string,
+		draft bool,
+		key string,
+	) (*domain.Memo, bool, error)
+	GenerateMemoFn func(ctx context.Context, userID, memoID uuid.UUID) (*domain.Memo, error)
+	RegenerateFn   func(
+		ctx context.Context,
+		userID, memoID uuid.UUID,
+		newText string,
+		replace bool,
+	) (*domain.Memo, error)
+	PreviewCardsFn     func(ctx context.Context, userID uuid.UUID, text string) ([]*domain.Card, error)
+	UpdateMemoStatusFn func(ctx context.Context, memoID uuid.UUID, status domain.MemoStatus) error
+	GetMemoFn          func(ctx context.Context, memoID uuid.UUID) (*domain.Memo, error)
 }
 
 // CreateMemoAndEnqueueTask implements service.MemoService
@@ -37,6 +60,68 @@ func (m *MockMemoService) CreateMemoAndEnqueueTask(
 	return nil, nil
 }
 
+// CreateDraftMemo implements service.MemoService
+func (m *MockMemoService) CreateDraftMemo(
+	ctx context.Context,
+	userID uuid.UUID,
+	text string,
+) (*domain.Memo, error) {
+	if m.CreateDraftMemoFn != nil {
+		return m.CreateDraftMemoFn(ctx, userID, text)
+	}
+	return nil, nil
+}
+
+// CreateMemoIdempotent implements service.MemoService
+func (m *MockMemoService) CreateMemoIdempotent(
+	ctx context.Context,
+	userID uuid.UUID,
+	text string,
+	draft bool,
+	key string,
+) (*domain.Memo, bool, error) {
+	if m.CreateMemoIdempotentFn != nil {
+		return m.CreateMemoIdempotentFn(ctx, userID, text, draft, key)
+	}
+	return nil, false, nil
+}
+
+// PreviewCards implements service.MemoService
+func (m *MockMemoService) PreviewCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	text string,
+) ([]*domain.Card, error) {
+	if m.PreviewCardsFn != nil {
+		return m.PreviewCardsFn(ctx, userID, text)
+	}
+	return nil, nil
+}
+
+// GenerateMemo implements service.MemoService
+func (m *MockMemoService) GenerateMemo(
+	ctx context.Context,
+	userID, memoID uuid.UUID,
+) (*domain.Memo, error) {
+	if m.GenerateMemoFn != nil {
+		return m.GenerateMemoFn(ctx, userID, memoID)
+	}
+	return nil, nil
+}
+
+// Regenerate implements service.MemoService
+func (m *MockMemoService) Regenerate(
+	ctx context.Context,
+	userID, memoID uuid.UUID,
+	newText string,
+	replace bool,
+) (*domain.Memo, error) {
+	if m.RegenerateFn != nil {
+		return m.RegenerateFn(ctx, userID, memoID, newText, replace)
+	}
+	return nil, nil
+}
+
 // UpdateMemoStatus implements service.MemoService
 func (m *MockMemoService) UpdateMemoStatus(
 	ctx context.Context,
@@ -193,6 +278,23 @@ func TestMemoHandler_CreateMemo(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedErrMsg: "Invalid text: contains invalid characters",
 		},
+		{
+			name: "memo_text_too_long",
+			setupContext: func(ctx context.Context) context.Context {
+				return context.WithValue(ctx, shared.UserIDContextKey, fixedUserID)
+			},
+			requestBody: CreateMemoRequest{
+				Text: strings.Repeat("a", domain.DefaultMemoTextLimits.MaxLength+1),
+			},
+			setupMock: func(ms *MockMemoService) {
+				ms.CreateMemoAndEnqueueTaskFn = func(ctx context.Context, userID uuid.UUID, text string) (*domain.Memo, error) {
+					_, err := domain.NewMemo(userID, text)
+					return nil, fmt.Errorf("failed to create memo: %w", err)
+				}
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrMsg: "Invalid text: must be at most 10000 characters",
+		},
 		{
 			name: "service_error",
 			setupContext: func(ctx context.Context) context.Context {
@@ -274,6 +376,715 @@ func TestMemoHandler_CreateMemo(t *testing.T) {
 	}
 }
 
+// TestMemoHandler_CreateDraftMemo tests that the draft flag stores the memo without enqueuing generation.
+func TestMemoHandler_CreateDraftMemo(t *testing.T) {
+	fixedUserID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	fixedMemoID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	fixedTime := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+	enqueueCalled := false
+	mockService := &MockMemoService{
+		CreateMemoAndEnqueueTaskFn: func(ctx context.Context, userID uuid.UUID, text string) (*domain.Memo, error) {
+			enqueueCalled = true
+			return nil, errors.New("should not be called for draft memos")
+		},
+		CreateDraftMemoFn: func(ctx context.Context, userID uuid.UUID, text string) (*domain.Memo, error) {
+			return &domain.Memo{
+				ID:        fixedMemoID,
+				UserID:    userID,
+				Text:      text,
+				Status:    domain.MemoStatusDraft,
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil))
+	handler := NewMemoHandler(mockService, logger)
+
+	reqBody, err := json.Marshal(CreateMemoRequest{Text: "Save this for later", Draft: true})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/memos", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, fixedUserID))
+	w := httptest.NewRecorder()
+
+	handler.CreateMemo(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.False(t, enqueueCalled, "Draft memos must not enqueue a generation task")
+
+	var resp MemoResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, fixedMemoID.String(), resp.ID)
+	assert.Equal(t, string(domain.MemoStatusDraft), resp.Status)
+}
+
+// TestMemoHandler_PreviewCards tests that previewed cards are returned in the
+// response without creating a memo, and that quota and timeout errors map to
+// the right status codes
+func TestMemoHandler_PreviewCards(t *testing.T) {
+	fixedUserID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	resetsAt := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		body           string
+		previewErr     error
+		expectedStatus int
+		expectedCode   domain.ErrorCode
+	}{
+		{
+			name:           "cards are returned",
+			body:           `{"text":"Notes to preview"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing text",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "quota exceeded",
+			body:           `{"text":"Notes to preview"}`,
+			previewErr:     &service.GenerationQuotaError{Quota: 5, ResetsAt: resetsAt},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   domain.CodeQuotaExceeded,
+		},
+		{
+			name:           "generation timed out",
+			body:           `{"text":"Notes to preview"}`,
+			previewErr:     fmt.Errorf("card preview timed out: %w", context.DeadlineExceeded),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCode:   domain.CodeRequestTimeout,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var previewedText string
+			mockService := &MockMemoService{
+				CreateMemoAndEnqueueTaskFn: func(ctx context.Context, userID uuid.UUID, text string) (*domain.Memo, error) {
+					return nil, errors.New("previews must not create a memo")
+				},
+				PreviewCardsFn: func(ctx context.Context, userID uuid.UUID, text string) ([]*domain.Card, error) {
+					previewedText = text
+					if tc.previewErr != nil {
+						return nil, tc.previewErr
+					}
+					return []*domain.Card{
+						{ID: uuid.New(), UserID: userID, Content: json.RawMessage(`{"front":"Q1","back":"A1"}`)},
+						{
+							ID:      uuid.New(),
+							UserID:  userID,
+							Type:    domain.CardTypeCloze,
+							Content: json.RawMessage(`{"text":"The {{c1::sky}} is blue"}`),
+						},
+					}, nil
+				},
+			}
+
+			logger := slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil))
+			handler := NewMemoHandler(mockService, logger)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/memos/preview", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, fixedUserID))
+			w := httptest.NewRecorder()
+
+			handler.PreviewCards(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus != http.StatusOK {
+				if tc.expectedCode != "" {
+					var errResp shared.ErrorResponse
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+					assert.Equal(t, tc.expectedCode, errResp.Code)
+				}
+				if tc.expectedStatus == http.StatusTooManyRequests {
+					assert.Equal(t, resetsAt.Format(http.TimeFormat), w.Header().Get("Retry-After"))
+				}
+				return
+			}
+
+			assert.Equal(t, "Notes to preview", previewedText)
+			var resp PreviewCardsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Len(t, resp.Cards, 2)
+			assert.Equal(t, string(domain.CardTypeBasic), resp.Cards[0].Type)
+			assert.Equal(t, map[string]interface{}{"front": "Q1", "back": "A1"}, resp.Cards[0].Content)
+			assert.Equal(t, string(domain.CardTypeCloze), resp.Cards[1].Type)
+			assert.NotContains(t, w.Body.String(), `"id"`, "Previewed cards are not saved, so they have no IDs")
+		})
+	}
+}
+
+// TestMemoHandler_CreateMemo_IdempotencyKey tests that the Idempotency-Key header is routed
+// through idempotent creation and that replays and key reuse map to the right status codes.
+func TestMemoHandler_CreateMemo_IdempotencyKey(t *testing.T) {
+	fixedUserID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	fixedMemoID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	fixedTime := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		draft          bool
+		replayed       bool
+		serviceErr     error
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "first request",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "first draft request",
+			draft:          true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "replayed request",
+			replayed:       true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "key reused for different request",
+			serviceErr:     service.ErrIdempotencyKeyReused,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "Idempotency-Key was already used for a different request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotKey, gotText string
+			var gotDraft bool
+			mockService := &MockMemoService{
+				CreateMemoAndEnqueueTaskFn: func(ctx context.Context, userID uuid.UUID, text string) (*domain.Memo, error) {
+					return nil, errors.New("should not be called when an idempotency key is set")
+				},
+				CreateMemoIdempotentFn: func(
+					ctx context.Context,
+					userID uuid.UUID,
+					text string,
+					draft bool,
+					key string,
+				) (*domain.Memo, bool, error) {
+					gotKey, gotText, gotDraft = key, text, draft
+					if tc.serviceErr != nil {
+						return nil, false, tc.serviceErr
+					}
+					status := domain.MemoStatusPending
+					if draft {
+						status = domain.MemoStatusDraft
+					}
+					return &domain.Memo{
+						ID:        fixedMemoID,
+						UserID:    userID,
+						Text:      text,
+						Status:    status,
+						CreatedAt: fixedTime,
+						UpdatedAt: fixedTime,
+					}, tc.replayed, nil
+				},
+			}
+
+			logger := slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil))
+			handler := NewMemoHandler(mockService, logger)
+
+			reqBody, err := json.Marshal(CreateMemoRequest{Text: "Remember this", Draft: tc.draft})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/memos", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(IdempotencyKeyHeader, "client-key-1")
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, fixedUserID))
+			w := httptest.NewRecorder()
+
+			handler.CreateMemo(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, "client-key-1", gotKey)
+			assert.Equal(t, "Remember this", gotText)
+			assert.Equal(t, tc.draft, gotDraft)
+
+			if tc.expectedError != "" {
+				var errResp shared.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+				assert.Equal(t, tc.expectedError, errResp.Error)
+				return
+			}
+
+			var resp MemoResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, fixedMemoID.String(), resp.ID)
+		})
+	}
+}
+
+// TestMemoHandler_GenerateMemo tests the GenerateMemo handler functionality.
+func TestMemoHandler_GenerateMemo(t *testing.T) {
+	fixedUserID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	fixedMemoID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	fixedTime := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		userID         uuid.UUID
+		memoIDInPath   string
+		generateFn     func(ctx context.Context, userID, memoID uuid.UUID) (*domain.Memo, error)
+		expectedStatus int
+		expectedErrMsg string
+		retryAfter     string
+	}{
+		{
+			name:         "successful_generation",
+			userID:       fixedUserID,
+			memoIDInPath: fixedMemoID.String(),
+			generateFn: func(ctx context.Context, userID, memoID uuid.UUID) (*domain.Memo, error) {
+				return &domain.Memo{
+					ID:        memoID,
+					UserID:    userID,
+					Text:      "Draft memo",
+					Status:    domain.MemoStatusPending,
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
+				}, nil
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "missing_user_id",
+			memoIDInPath:   fixedMemoID.String(),
+			expectedStatus: http.StatusUnauthorized,
+			expectedErrMsg: "Unauthorized operation",
+		},
+		{
+			name:           "invalid_memo_id",
+			userID:         fixedUserID,
+			memoIDInPath:   "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrMsg: "Invalid ID",
+		},
+		{
+			name:         "memo_not_found",
+			userID:       fixedUserID,
+			memoIDInPath: fixedMemoID.String(),
+			generateFn: func(ctx context.Context, userID, memoID uuid.UUID) (*domain.Memo, error) {
+				return nil, store.ErrMemoNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErrMsg: "Memo not found",
+		},
+		{
+			name:         "memo_not_owned",
+			userID:       fixedUserID,
+			memoIDInPath: fixedMemoID.String(),
+			generateFn: func(ctx context.Context, userID, memoID uuid.UUID) (*domain.Memo, error) {
+				return nil, service.ErrMemoNotOwned
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedErrMsg: "You do not own this memo",
+		},
+		{
+			name:         "memo_not_draft",
+			userID:       fixedUserID,
+			memoIDInPath: fixedMemoID.String(),
+			generateFn: func(ctx context.Context, userID, memoID uuid.UUID) (*domain.Memo, error) {
+				return nil, service.ErrMemoNotDraft
+			},
+			expectedStatus: http.StatusConflict,
+			expectedErrMsg: "Memo is not a draft",
+		},
+		{
+			name:         "generation_quota_exceeded",
+			userID:       fixedUserID,
+			memoIDInPath: fixedMemoID.String(),
+			generateFn: func(ctx context.Context, userID, memoID uuid.UUID) (*domain.Memo, error) {
+				return nil, &service.GenerationQuotaError{
+					Quota:    100,
+					ResetsAt: time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC),
+				}
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedErrMsg: "Monthly card generation quota exceeded",
+			retryAfter:     "Thu, 01 May 2025 00:00:00 GMT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockMemoService{GenerateMemoFn: tt.generateFn}
+			logger := slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil))
+			handler := NewMemoHandler(mockService, logger)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/memos/"+tt.memoIDInPath+"/generate", nil)
+			if tt.userID != uuid.Nil {
+				req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, tt.userID))
+			}
+
+			// Create a chi context with URL parameters
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.memoIDInPath)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			handler.GenerateMemo(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+
+			var respBody map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+
+			if tt.expectedErrMsg != "" {
+				errorMsg, ok := respBody["error"].(string)
+				assert.True(t, ok, "Expected error field in response")
+				assert.Contains(t, errorMsg, tt.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, fixedMemoID.String(), respBody["id"])
+			assert.Equal(t, string(domain.MemoStatusPending), respBody["status"])
+		})
+	}
+}
+
+func TestMemoHandler_RegenerateMemo(t *testing.T) {
+	fixedUserID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	fixedMemoID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	fixedTime := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+	regenerated := func(
+		ctx context.Context,
+		userID, memoID uuid.UUID,
+		newText string,
+		replace bool,
+	) (*domain.Memo, error) {
+		return &domain.Memo{
+			ID:        memoID,
+			UserID:    userID,
+			Text:      newText,
+			Status:    domain.MemoStatusPending,
+			CreatedAt: fixedTime,
+			UpdatedAt: fixedTime,
+		}, nil
+	}
+
+	tests := []struct {
+		name           string
+		userID         uuid.UUID
+		memoIDInPath   string
+		body           string
+		regenerateFn   func(ctx context.Context, userID, memoID uuid.UUID, newText string, replace bool) (*domain.Memo, error)
+		expectedStatus int
+		expectedErrMsg string
+		expectReplace  bool
+	}{
+		{
+			name:           "keeps_history_by_default",
+			userID:         fixedUserID,
+			memoIDInPath:   fixedMemoID.String(),
+			body:           `{"text":"Edited memo"}`,
+			regenerateFn:   regenerated,
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "replaces_cards",
+			userID:         fixedUserID,
+			memoIDInPath:   fixedMemoID.String(),
+			body:           `{"text":"Edited memo","replace":true}`,
+			regenerateFn:   regenerated,
+			expectedStatus: http.StatusAccepted,
+			expectReplace:  true,
+		},
+		{
+			name:           "missing_text",
+			userID:         fixedUserID,
+			memoIDInPath:   fixedMemoID.String(),
+			body:           `{"replace":true}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing_user_id",
+			memoIDInPath:   fixedMemoID.String(),
+			body:           `{"text":"Edited memo"}`,
+			expectedStatus: http.StatusUnauthorized,
+			expectedErrMsg: "Unauthorized operation",
+		},
+		{
+			name:         "memo_not_owned",
+			userID:       fixedUserID,
+			memoIDInPath: fixedMemoID.String(),
+			body:         `{"text":"Edited memo"}`,
+			regenerateFn: func(context.Context, uuid.UUID, uuid.UUID, string, bool) (*domain.Memo, error) {
+				return nil, service.ErrMemoNotOwned
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedErrMsg: "You do not own this memo",
+		},
+		{
+			name:         "generation_in_progress",
+			userID:       fixedUserID,
+			memoIDInPath: fixedMemoID.String(),
+			body:         `{"text":"Edited memo"}`,
+			regenerateFn: func(context.Context, uuid.UUID, uuid.UUID, string, bool) (*domain.Memo, error) {
+				return nil, service.ErrMemoGenerationInProgress
+			},
+			expectedStatus: http.StatusConflict,
+			expectedErrMsg: "Memo generation is already in progress",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotReplace bool
+			mockService := &MockMemoService{}
+			if tt.regenerateFn != nil {
+				mockService.RegenerateFn = func(
+					ctx context.Context,
+					userID, memoID uuid.UUID,
+					newText string,
+					replace bool,
+				) (*domain.Memo, error) {
+					gotReplace = replace
+					return tt.regenerateFn(ctx, userID, memoID, newText, replace)
+				}
+			}
+			logger := slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil))
+			handler := NewMemoHandler(mockService, logger)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/memos/"+tt.memoIDInPath+"/regenerate",
+				bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.userID != uuid.Nil {
+				req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, tt.userID))
+			}
+
+			// Create a chi context with URL parameters
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.memoIDInPath)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			handler.RegenerateMemo(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var respBody map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+
+			if tt.expectedStatus != http.StatusAccepted {
+				errorMsg, ok := respBody["error"].(string)
+				assert.True(t, ok, "Expected error field in response")
+				assert.Contains(t, errorMsg, tt.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, fixedMemoID.String(), respBody["id"])
+			assert.Equal(t, "Edited memo", respBody["text"])
+			assert.Equal(t, string(domain.MemoStatusPending), respBody["status"])
+			assert.Equal(t, tt.expectReplace, gotReplace)
+		})
+	}
+}
+
+// TestMemoHandler_GetMemo tests the GetMemo handler, including generation timing.
+func TestMemoHandler_GetMemo(t *testing.T) {
+	fixedUserID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	fixedMemoID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	fixedTime := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+	completedMemo := func() *domain.Memo {
+		return &domain.Memo{
+			ID:                 fixedMemoID,
+			UserID:             fixedUserID,
+			Text:               "Completed memo",
+			Status:             domain.MemoStatusCompleted,
+			CreatedAt:          fixedTime,
+			UpdatedAt:          fixedTime,
+			GenerationDuration: 4200 * time.Millisecond,
+		}
+	}
+
+	newRequest := func(userID uuid.UUID, memoID string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/memos/"+memoID, nil)
+		if userID != uuid.Nil {
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", memoID)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	tests := []struct {
+		name               string
+		userID             uuid.UUID
+		memoIDInPath       string
+		opts               []MemoHandlerOption
+		memo               *domain.Memo
+		getErr             error
+		expectedStatus     int
+		expectedErrMsg     string
+		expectedDurationMs *float64
+	}{
+		{
+			name:               "timing_exposed",
+			userID:             fixedUserID,
+			memoIDInPath:       fixedMemoID.String(),
+			opts:               []MemoHandlerOption{WithGenerationTiming(true)},
+			memo:               completedMemo(),
+			expectedStatus:     http.StatusOK,
+			expectedDurationMs: func() *float64 { v := 4200.0; return &v }(),
+		},
+		{
+			name:           "timing_hidden_by_default",
+			userID:         fixedUserID,
+			memoIDInPath:   fixedMemoID.String(),
+			memo:           completedMemo(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:         "timing_omitted_before_generation",
+			userID:       fixedUserID,
+			memoIDInPath: fixedMemoID.String(),
+			opts:         []MemoHandlerOption{WithGenerationTiming(true)},
+			memo: &domain.Memo{
+				ID:     fixedMemoID,
+				UserID: fixedUserID,
+				Text:   "Pending memo",
+				Status: domain.MemoStatusPending,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "memo_not_owned",
+			userID:         uuid.New(),
+			memoIDInPath:   fixedMemoID.String(),
+			memo:           completedMemo(),
+			expectedStatus: http.StatusForbidden,
+			expectedErrMsg: "You do not own this memo",
+		},
+		{
+			name:           "memo_not_found",
+			userID:         fixedUserID,
+			memoIDInPath:   fixedMemoID.String(),
+			getErr:         store.ErrMemoNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedErrMsg: "Memo not found",
+		},
+		{
+			name:           "invalid_memo_id",
+			userID:         fixedUserID,
+			memoIDInPath:   "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing_user_id",
+			memoIDInPath:   fixedMemoID.String(),
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockMemoService{
+				GetMemoFn: func(ctx context.Context, memoID uuid.UUID) (*domain.Memo, error) {
+					assert.Equal(t, fixedMemoID, memoID)
+					return tt.memo, tt.getErr
+				},
+			}
+			logger := slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil))
+			handler := NewMemoHandler(mockService, logger, tt.opts...)
+
+			w := httptest.NewRecorder()
+			handler.GetMemo(w, newRequest(tt.userID, tt.memoIDInPath))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var respBody map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+
+			if tt.expectedErrMsg != "" {
+				assert.Equal(t, tt.expectedErrMsg, respBody["error"])
+				return
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, fixedMemoID.String(), respBody["id"])
+			duration, present := respBody["generation_duration_ms"]
+			if tt.expectedDurationMs == nil {
+				assert.False(t, present, "generation_duration_ms should be omitted")
+				return
+			}
+			assert.Equal(t, *tt.expectedDurationMs, duration)
+		})
+	}
+}
+
+// TestMemoHandler_GetMemo_GenerationTimingMatchesTask runs a generation task and
+// checks that the completed memo's response reports the duration the task measured.
+func TestMemoHandler_GetMemo_GenerationTimingMatchesTask(t *testing.T) {
+	userID := uuid.New()
+	memo, err := domain.NewMemo(userID, "Memo to generate")
+	require.NoError(t, err)
+
+	// The task's memo service updates the same memo the handler later reads
+	taskMemoService := &mocks.MockMemoService{
+		GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+			return memo, nil
+		},
+		UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+			return memo.TransitionStatus(status)
+		},
+		RecordGenerationDurationFn: func(ctx context.Context, id uuid.UUID, d time.Duration) error {
+			memo.RecordGenerationDuration(d)
+			return nil
+		},
+	}
+	generator := &mocks.Generator{
+		GenerateCardsFunc: func(ctx context.Context, text string, uid uuid.UUID) ([]*domain.Card, error) {
+			time.Sleep(5 * time.Millisecond)
+			return nil, nil
+		},
+	}
+	cardService := &mocks.CardService{}
+
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer(nil), nil))
+	generationTask, err := task.NewMemoGenerationTask(memo.ID, taskMemoService, generator, cardService, logger)
+	require.NoError(t, err)
+	require.NoError(t, generationTask.Execute(context.Background()))
+	require.Equal(t, domain.MemoStatusCompleted, memo.Status)
+
+	handler := NewMemoHandler(&MockMemoService{
+		GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+			return memo, nil
+		},
+	}, logger, WithGenerationTiming(true))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/memos/"+memo.ID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", memo.ID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.GetMemo(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp MemoResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, string(domain.MemoStatusCompleted), resp.Status)
+	require.NotNil(t, resp.GenerationDurationMs)
+	assert.Equal(t, generationTask.GenerationDuration().Milliseconds(), *resp.GenerationDurationMs)
+	assert.GreaterOrEqual(t, *resp.GenerationDurationMs, int64(5))
+}
+
 // TestMemoHandler_HelperFunctions tests the helper functions in the memo handler.
 func TestMemoHandler_HelperFunctions(t *testing.T) {
 	t.Run("memoToDTOResponse", func(t *testing.T) {
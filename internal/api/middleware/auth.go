@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -9,13 +10,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/api"
 	"github.com/phrazzld/scry-api/internal/api/shared"
+	plogger "github.com/phrazzld/scry-api/internal/platform/logger"
 	"github.com/phrazzld/scry-api/internal/redact"
 	"github.com/phrazzld/scry-api/internal/service/auth"
+	"github.com/phrazzld/scry-api/internal/store"
 )
 
 // AuthMiddleware provides JWT authentication for routes.
 type AuthMiddleware struct {
 	jwtService auth.JWTService
+	userStore  store.UserStore
 }
 
 // NewAuthMiddleware creates a new AuthMiddleware with the given dependencies.
@@ -25,6 +29,16 @@ func NewAuthMiddleware(jwtService auth.JWTService) *AuthMiddleware {
 	}
 }
 
+// WithUserStore returns a new AuthMiddleware that also looks each token's user
+// up in userStore, rejecting tokens of users who were deleted or disabled after
+// the token was issued, and tokens issued before the user's password was last
+// changed or reset. The original middleware remains unchanged.
+func (m *AuthMiddleware) WithUserStore(userStore store.UserStore) *AuthMiddleware {
+	newMiddleware := *m
+	newMiddleware.userStore = userStore
+	return &newMiddleware
+}
+
 // Authenticate validates JWT tokens from the Authorization header and
 // adds the user ID to the request context for authorized requests.
 func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
@@ -51,7 +65,7 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			switch err {
 			case auth.ErrExpiredToken:
 				api.HandleAPIError(w, r, err, "Token expired")
-			case auth.ErrInvalidToken:
+			case auth.ErrInvalidToken, auth.ErrTokenNotYetValid:
 				api.HandleAPIError(w, r, err, "Invalid token")
 			default:
 				slog.Error("failed to validate token", "error", redact.Error(err))
@@ -60,14 +74,42 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		// Add user ID to context
+		if m.userStore != nil && !m.userActive(w, r, claims) {
+			return
+		}
+
+		// Add user ID to context, and to the context logger so downstream logs include it
 		ctx := context.WithValue(r.Context(), shared.UserIDContextKey, claims.UserID)
+		ctx = plogger.WithUserID(ctx, claims.UserID.String())
 
 		// Continue with the authenticated request
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// userActive reports whether the token's user exists, has not revoked the
+// token by changing their password since it was issued, and is not disabled,
+// responding with an error if not.
+func (m *AuthMiddleware) userActive(w http.ResponseWriter, r *http.Request, claims *auth.Claims) bool {
+	user, err := m.userStore.GetByID(r.Context(), claims.UserID)
+	switch {
+	case errors.Is(err, store.ErrUserNotFound):
+		api.HandleAPIError(w, r, auth.ErrInvalidToken, "Invalid token")
+		return false
+	case err != nil:
+		slog.Error("failed to look up token user", "error", redact.Error(err))
+		api.HandleAPIError(w, r, err, "Authentication error")
+		return false
+	case user.SessionRevoked(claims.IssuedAt):
+		api.HandleAPIError(w, r, auth.ErrInvalidToken, "Invalid token")
+		return false
+	case user.IsDisabled():
+		api.HandleAPIError(w, r, auth.ErrAccountDisabled, "Account is disabled")
+		return false
+	}
+	return true
+}
+
 // GetUserID extracts the user ID from the request context.
 // Returns the user ID and a boolean indicating if it was found.
 func GetUserID(r *http.Request) (uuid.UUID, bool) {
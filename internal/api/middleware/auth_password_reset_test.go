@@ -0,0 +1,133 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/api/middleware"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/service/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// resetEventEmitter records the events emitted by the auth handler
+type resetEventEmitter struct {
+	events []*events.TaskRequestEvent
+}
+
+func (e *resetEventEmitter) EmitEvent(ctx context.Context, event *events.TaskRequestEvent) error {
+	e.events = append(e.events, event)
+	return nil
+}
+
+// TestAuthMiddleware_RejectsAccessTokensAfterPasswordReset checks that access
+// tokens issued before a password reset stop authenticating, while tokens
+// issued after it keep working
+func TestAuthMiddleware_RejectsAccessTokensAfterPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	const (
+		email       = "user@example.com"
+		oldPassword = "oldSecurePassword123"
+		newPassword = "newSecurePassword456"
+	)
+
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(oldPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	// The mock store keeps users as given, so hash new passwords like the real one
+	userStore := mocks.NewMockUserStore()
+	userStore.Users[email] = &domain.User{ID: uuid.New(), Email: email, HashedPassword: string(hash)}
+	userStore.UpdateFn = func(ctx context.Context, user *domain.User) error {
+		if user.Password != "" {
+			hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.MinCost)
+			if err != nil {
+				return err
+			}
+			user.HashedPassword = string(hash)
+			user.Password = ""
+		}
+		userStore.Users[user.Email] = user
+		return nil
+	}
+
+	jwtService := auth.NewTestJWTService("test-secret-that-is-32-chars-long!", time.Hour, clock)
+	emitter := &resetEventEmitter{}
+	authHandler := api.NewAuthHandler(
+		userStore,
+		jwtService,
+		auth.NewBcryptVerifier(),
+		&config.AuthConfig{
+			TokenLifetimeMinutes:              60,
+			RefreshTokenLifetimeMinutes:       1440,
+			PasswordResetTokenLifetimeMinutes: 60,
+		},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	).WithTimeFunc(clock).WithEventEmitter(emitter)
+
+	protected := middleware.NewAuthMiddleware(jwtService).WithUserStore(userStore).Authenticate(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	post := func(handlerFunc http.HandlerFunc, path string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	login := func(password string) string {
+		w := post(authHandler.Login, "/api/auth/login", api.LoginRequest{Email: email, Password: password})
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.AuthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp.AccessToken
+	}
+
+	callProtected := func(accessToken string) int {
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		w := httptest.NewRecorder()
+		protected.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	oldToken := login(oldPassword)
+	require.Equal(t, http.StatusOK, callProtected(oldToken))
+
+	w := post(authHandler.ForgotPassword, "/api/auth/forgot-password", api.ForgotPasswordRequest{Email: email})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, emitter.events, 1)
+	var payload events.PasswordResetRequestedEvent
+	require.NoError(t, emitter.events[0].UnmarshalPayload(&payload))
+
+	now = now.Add(time.Minute)
+	w = post(authHandler.ResetPassword, "/api/auth/reset-password",
+		api.ResetPasswordRequest{Token: payload.Token, Password: newPassword})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, http.StatusUnauthorized, callProtected(oldToken),
+		"Access tokens from before the reset should be rejected")
+	assert.Equal(t, http.StatusOK, callProtected(login(newPassword)),
+		"Access tokens from after the reset should be accepted")
+}
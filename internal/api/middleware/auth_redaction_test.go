@@ -50,6 +50,38 @@ func (m *MockJWTService) ValidateRefreshToken(ctx context.Context, token string)
 	return claims, args.Error(1)
 }
 
+func (m *MockJWTService) GenerateEmailVerificationToken(ctx context.Context, userID uuid.UUID) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockJWTService) ValidateEmailVerificationToken(ctx context.Context, token string) (*auth.Claims, error) {
+	args := m.Called(ctx, token)
+	var claims *auth.Claims
+	if arg := args.Get(0); arg != nil {
+		claims = arg.(*auth.Claims)
+	}
+	return claims, args.Error(1)
+}
+
+func (m *MockJWTService) GeneratePasswordResetToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	fingerprint string,
+) (string, error) {
+	args := m.Called(ctx, userID, fingerprint)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockJWTService) ValidatePasswordResetToken(ctx context.Context, token string) (*auth.Claims, error) {
+	args := m.Called(ctx, token)
+	var claims *auth.Claims
+	if arg := args.Get(0); arg != nil {
+		claims = arg.(*auth.Claims)
+	}
+	return claims, args.Error(1)
+}
+
 // setupLogCapture sets up a string builder to capture logs and returns:
 // 1. A function to get the captured logs
 // 2. A cleanup function to restore the original logger
@@ -1,16 +1,24 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/mocks"
+	plogger "github.com/phrazzld/scry-api/internal/platform/logger"
 	"github.com/phrazzld/scry-api/internal/service/auth"
+	"github.com/phrazzld/scry-api/internal/store"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -112,6 +120,114 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_WithUserStore(t *testing.T) {
+	t.Parallel()
+
+	activeID := uuid.New()
+	disabledID := uuid.New()
+	deletedID := uuid.New()
+	resetID := uuid.New()
+	disabledAt := time.Now().UTC()
+	passwordChangedAt := time.Now().UTC()
+
+	userStore := new(mocks.UserStore)
+	userStore.On("GetByID", mock.Anything, activeID).
+		Return(&domain.User{ID: activeID}, nil)
+	userStore.On("GetByID", mock.Anything, disabledID).
+		Return(&domain.User{ID: disabledID, DisabledAt: &disabledAt}, nil)
+	userStore.On("GetByID", mock.Anything, deletedID).
+		Return(nil, store.ErrUserNotFound)
+	userStore.On("GetByID", mock.Anything, resetID).
+		Return(&domain.User{ID: resetID, PasswordChangedAt: &passwordChangedAt}, nil)
+
+	tests := []struct {
+		name           string
+		userID         uuid.UUID
+		issuedAt       time.Time
+		expectedStatus int
+		expectedCode   domain.ErrorCode
+	}{
+		{name: "active user", userID: activeID, expectedStatus: http.StatusOK},
+		{
+			name:           "disabled user",
+			userID:         disabledID,
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   domain.CodeAccountDisabled,
+		},
+		{
+			name:           "deleted user",
+			userID:         deletedID,
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   domain.CodeInvalidToken,
+		},
+		{
+			name:           "token issued before password change",
+			userID:         resetID,
+			issuedAt:       passwordChangedAt.Add(-time.Minute),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   domain.CodeInvalidToken,
+		},
+		{
+			name:           "token issued after password change",
+			userID:         resetID,
+			issuedAt:       passwordChangedAt.Add(time.Minute),
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtService := &mocks.MockJWTService{Claims: &auth.Claims{UserID: tt.userID, IssuedAt: tt.issuedAt}}
+			handler := NewAuthMiddleware(jwtService).WithUserStore(userStore).Authenticate(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer valid-token")
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedCode != "" {
+				var resp shared.ErrorResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Code)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_AddsUserIDToLogger(t *testing.T) {
+	t.Parallel()
+
+	var logBuf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&logBuf, nil))
+
+	userID := uuid.New()
+	jwtService := &mocks.MockJWTService{Claims: &auth.Claims{UserID: userID}}
+
+	// Log from within the authenticated handler using only the request context
+	handler := NewTraceMiddleware(log)(NewAuthMiddleware(jwtService).Authenticate(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			plogger.FromContext(r.Context()).Info("handled authenticated request")
+			w.WriteHeader(http.StatusOK)
+		}),
+	))
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logBuf.Bytes(), &entry))
+	assert.Equal(t, "handled authenticated request", entry["msg"])
+	assert.Equal(t, userID.String(), entry["user_id"])
+	assert.NotEmpty(t, entry["trace_id"], "Fields added before authentication should be kept")
+}
+
 func TestGetUserID(t *testing.T) {
 	t.Parallel()
 
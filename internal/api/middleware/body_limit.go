@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// LimitRequestBody returns middleware that protects handlers from large or
+// malformed request bodies.
+//
+// POST, PUT and PATCH requests that carry a body must declare one of
+// mediaTypes as their content type (application/json when none are given),
+// otherwise they are rejected with 415 Unsupported Media Type. Bodies
+// that declare a length above maxBytes are rejected with 413 Request Entity Too
+// Large up front; other bodies are cut off at maxBytes, and the handler's
+// decoding error is reported as 413 by api.HandleValidationError.
+func LimitRequestBody(maxBytes int64, mediaTypes ...string) func(http.Handler) http.Handler {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/json"}
+	}
+	unsupportedMessage := "Content-Type must be " + strings.Join(mediaTypes, " or ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasBody(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if requiresMediaType(r.Method) && !hasMediaType(r.Header.Get("Content-Type"), mediaTypes) {
+				api.HandleAPIError(w, r, domain.NewDomainError(
+					domain.CodeUnsupportedMediaType,
+					unsupportedMessage,
+					nil,
+				), "")
+				return
+			}
+
+			if r.ContentLength > maxBytes {
+				api.HandleAPIError(w, r, domain.NewDomainError(
+					domain.CodeRequestTooLarge,
+					"Request body is too large",
+					nil,
+				), "")
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// hasBody reports whether a request carries a body. A ContentLength of -1
+// means the length is unknown, as with chunked encoding.
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+// requiresMediaType reports whether bodies sent with the given method must
+// declare an accepted content type.
+func requiresMediaType(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasMediaType reports whether a Content-Type header value is one of
+// mediaTypes, ignoring parameters such as charset.
+func hasMediaType(contentType string, mediaTypes []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && slices.Contains(mediaTypes, mediaType)
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitRequestBody(t *testing.T) {
+	t.Parallel()
+
+	const maxBytes = 64
+
+	// The handler decodes JSON the way API handlers do
+	handler := LimitRequestBody(maxBytes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := shared.DecodeJSON(r, &body); err != nil {
+			api.HandleValidationError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	smallBody := `{"text":"short"}`
+	largeBody := `{"text":"` + strings.Repeat("x", maxBytes) + `"}`
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		contentType    string
+		chunked        bool
+		expectedStatus int
+		expectedCode   domain.ErrorCode
+	}{
+		{
+			name:           "small JSON body",
+			method:         http.MethodPost,
+			body:           smallBody,
+			contentType:    "application/json",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "JSON with charset",
+			method:         http.MethodPut,
+			body:           smallBody,
+			contentType:    "application/json; charset=utf-8",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "oversized body",
+			method:         http.MethodPost,
+			body:           largeBody,
+			contentType:    "application/json",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   domain.CodeRequestTooLarge,
+		},
+		{
+			name:           "oversized body without content length",
+			method:         http.MethodPost,
+			body:           largeBody,
+			contentType:    "application/json",
+			chunked:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   domain.CodeRequestTooLarge,
+		},
+		{
+			name:           "text body",
+			method:         http.MethodPost,
+			body:           smallBody,
+			contentType:    "text/plain",
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   domain.CodeUnsupportedMediaType,
+		},
+		{
+			name:           "missing content type",
+			method:         http.MethodPut,
+			body:           smallBody,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   domain.CodeUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tc.method, "/api/memos", strings.NewReader(tc.body))
+			if tc.chunked {
+				req.ContentLength = -1
+				req.Body = io.NopCloser(strings.NewReader(tc.body))
+			}
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedCode != "" {
+				var resp shared.ErrorResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, tc.expectedCode, resp.Code)
+			}
+		})
+	}
+
+	t.Run("requests without a body pass through", func(t *testing.T) {
+		t.Parallel()
+
+		passthrough := LimitRequestBody(maxBytes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+			rr := httptest.NewRecorder()
+			passthrough.ServeHTTP(rr, httptest.NewRequest(method, "/api/memos/1/generate", nil))
+			assert.Equal(t, http.StatusNoContent, rr.Code, method)
+		}
+	})
+
+	t.Run("accepts configured media types", func(t *testing.T) {
+		t.Parallel()
+
+		upload := LimitRequestBody(maxBytes, "application/json", "text/csv")(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+		for contentType, expected := range map[string]int{
+			"text/csv":                 http.StatusNoContent,
+			"text/csv; charset=utf-8":  http.StatusNoContent,
+			"application/json":         http.StatusNoContent,
+			"application/octet-stream": http.StatusUnsupportedMediaType,
+		} {
+			req := httptest.NewRequest(http.MethodPost, "/api/import", strings.NewReader("front,back"))
+			req.Header.Set("Content-Type", contentType)
+			rr := httptest.NewRecorder()
+			upload.ServeHTTP(rr, req)
+			assert.Equal(t, expected, rr.Code, contentType)
+		}
+	})
+}
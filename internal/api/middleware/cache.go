@@ -0,0 +1,328 @@
+package middleware
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/events"
+	plogger "github.com/phrazzld/scry-api/internal/platform/logger"
+)
+
+// CachedResponse is a captured HTTP response that can be replayed to clients.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// ResponseCacheStore is the backing store used by ResponseCache.
+// Implementations must be safe for concurrent use.
+type ResponseCacheStore interface {
+	// Get returns the cached response for key if present and not expired.
+	Get(key string) (*CachedResponse, bool)
+
+	// Set stores a response under key for the given duration.
+	Set(key string, response *CachedResponse, ttl time.Duration)
+
+	// DeletePrefix removes every entry whose key begins with prefix.
+	DeletePrefix(prefix string)
+}
+
+// DefaultResponseCacheMaxEntries is the number of responses a
+// MemoryResponseCacheStore holds when no limit is configured.
+const DefaultResponseCacheMaxEntries = 10000
+
+type memoryCacheEntry struct {
+	key       string
+	response  *CachedResponse
+	expiresAt time.Time
+}
+
+// MemoryResponseCacheStore is an in-process ResponseCacheStore.
+// It is the default store and is suitable for single-instance deployments.
+// It holds at most maxEntries responses: when full, expired entries are dropped
+// first and then the least recently used ones, so entries that are never read
+// again cannot accumulate.
+type MemoryResponseCacheStore struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // front is most recently used
+	maxEntries int
+	now        func() time.Time
+}
+
+var _ ResponseCacheStore = (*MemoryResponseCacheStore)(nil)
+
+// NewMemoryResponseCacheStore creates an empty in-memory response cache store
+// holding at most maxEntries responses. A non-positive maxEntries uses
+// DefaultResponseCacheMaxEntries.
+func NewMemoryResponseCacheStore(maxEntries int) *MemoryResponseCacheStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultResponseCacheMaxEntries
+	}
+	return &MemoryResponseCacheStore{
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// Get implements ResponseCacheStore.Get. Expired entries are evicted on access.
+func (s *MemoryResponseCacheStore) Get(key string) (*CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if !s.now().Before(entry.expiresAt) {
+		s.remove(elem)
+		return nil, false
+	}
+	s.lru.MoveToFront(elem)
+	return entry.response, true
+}
+
+// Set implements ResponseCacheStore.Set.
+func (s *MemoryResponseCacheStore) Set(key string, response *CachedResponse, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := &memoryCacheEntry{key: key, response: response, expiresAt: s.now().Add(ttl)}
+	if elem, ok := s.entries[key]; ok {
+		elem.Value = entry
+		s.lru.MoveToFront(elem)
+		return
+	}
+
+	if len(s.entries) >= s.maxEntries {
+		s.evict()
+	}
+	s.entries[key] = s.lru.PushFront(entry)
+}
+
+// DeletePrefix implements ResponseCacheStore.DeletePrefix.
+func (s *MemoryResponseCacheStore) DeletePrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, elem := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			s.remove(elem)
+		}
+	}
+}
+
+// Len returns the number of entries currently held, including expired ones
+// that have not been evicted yet.
+func (s *MemoryResponseCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// evict makes room for one entry by dropping every expired entry, or the least
+// recently used entry if none have expired. The caller must hold s.mu.
+func (s *MemoryResponseCacheStore) evict() {
+	now := s.now()
+	for _, elem := range s.entries {
+		if !now.Before(elem.Value.(*memoryCacheEntry).expiresAt) {
+			s.remove(elem)
+		}
+	}
+	if len(s.entries) < s.maxEntries {
+		return
+	}
+	if oldest := s.lru.Back(); oldest != nil {
+		s.remove(oldest)
+	}
+}
+
+// remove deletes elem from the store. The caller must hold s.mu.
+func (s *MemoryResponseCacheStore) remove(elem *list.Element) {
+	s.lru.Remove(elem)
+	delete(s.entries, elem.Value.(*memoryCacheEntry).key)
+}
+
+// ResponseCache caches successful GET responses per authenticated user and
+// invalidates a user's cached responses when they perform a write.
+// Both middlewares must run after AuthMiddleware so the user ID is available.
+type ResponseCache struct {
+	store  ResponseCacheStore
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+// NewResponseCache creates a ResponseCache backed by store.
+// A ttl of zero or less disables caching; the middlewares then pass requests through.
+// If store is nil, an in-memory store is used.
+func NewResponseCache(store ResponseCacheStore, ttl time.Duration, logger *slog.Logger) *ResponseCache {
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for ResponseCache")
+	}
+	if store == nil {
+		store = NewMemoryResponseCacheStore(DefaultResponseCacheMaxEntries)
+	}
+
+	return &ResponseCache{
+		store:  store,
+		ttl:    ttl,
+		logger: logger.With(slog.String("component", "response_cache")),
+	}
+}
+
+// Cache returns middleware that serves GET requests from the cache when a fresh
+// entry exists and otherwise stores 200 OK responses for the configured TTL.
+// Entries are keyed by user ID, path and the values of the named query
+// parameters; any other query parameters are ignored, so they cannot be used
+// to create additional entries.
+func (c *ResponseCache) Cache(params ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return c.cache(next, params)
+	}
+}
+
+func (c *ResponseCache) cache(next http.Handler, params []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+		if c.ttl <= 0 || r.Method != http.MethodGet || !ok || userID == uuid.Nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		log := plogger.FromContextOrDefault(r.Context(), c.logger)
+		key := userKeyPrefix(userID) + r.URL.Path + "?" + cacheKeyQuery(r.URL.Query(), params)
+
+		if cached, found := c.store.Get(key); found {
+			log.Debug("serving cached response", slog.String("path", r.URL.Path))
+			for name, values := range cached.Header {
+				w.Header()[name] = append([]string(nil), values...)
+			}
+			w.Header().Set("X-Cache", "HIT")
+			// Honour conditional requests for handlers that set an ETag
+			if shared.ETagMatches(r, cached.Header.Get("ETag")) {
+				w.Header().Del("Content-Type")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(cached.StatusCode)
+			_, _ = w.Write(cached.Body)
+			return
+		}
+
+		w.Header().Set("X-Cache", "MISS")
+		rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.statusCode == http.StatusOK {
+			header := w.Header().Clone()
+			header.Del("X-Cache")
+			c.store.Set(key, &CachedResponse{
+				StatusCode: rec.statusCode,
+				Header:     header,
+				Body:       rec.body.Bytes(),
+			}, c.ttl)
+		}
+	})
+}
+
+// Invalidate removes the user's cached responses after a successful write
+// (any response with a status code below 400).
+func (c *ResponseCache) Invalidate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+		if c.ttl <= 0 || !ok || userID == uuid.Nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.statusCode < http.StatusBadRequest {
+			c.store.DeletePrefix(userKeyPrefix(userID))
+			plogger.FromContextOrDefault(r.Context(), c.logger).
+				Debug("invalidated cached responses", slog.String("user_id", userID.String()))
+		}
+	})
+}
+
+// InvalidateUser removes every cached response of the user. It is used when a
+// user's data changes outside of a request wrapped in Invalidate.
+func (c *ResponseCache) InvalidateUser(userID uuid.UUID) {
+	c.store.DeletePrefix(userKeyPrefix(userID))
+}
+
+// HandleEvent implements events.EventHandler. It invalidates the owner's cached
+// responses when an events.EventTypeCardsGenerated event reports that background
+// generation has added cards; other events are ignored.
+func (c *ResponseCache) HandleEvent(ctx context.Context, event *events.TaskRequestEvent) error {
+	if event.Type != events.EventTypeCardsGenerated {
+		return nil
+	}
+
+	var payload events.CardsGeneratedEvent
+	if err := event.UnmarshalPayload(&payload); err != nil {
+		return fmt.Errorf("failed to decode cards generated event: %w", err)
+	}
+
+	c.InvalidateUser(payload.UserID)
+	plogger.FromContextOrDefault(ctx, c.logger).
+		Debug("invalidated cached responses after card generation",
+			slog.String("user_id", payload.UserID.String()))
+	return nil
+}
+
+// cacheKeyQuery encodes the values of the named query parameters, in a fixed
+// order, for use in a cache key.
+func cacheKeyQuery(query url.Values, params []string) string {
+	kept := url.Values{}
+	for _, name := range params {
+		if values, ok := query[name]; ok {
+			kept[name] = values
+		}
+	}
+	return kept.Encode()
+}
+
+// userKeyPrefix returns the cache key prefix shared by all entries of a user.
+func userKeyPrefix(userID uuid.UUID) string {
+	return userID.String() + ":"
+}
+
+// responseRecorder passes writes through to the client while capturing
+// the status code and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
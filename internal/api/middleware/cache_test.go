@@ -0,0 +1,255 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCacheTestRouter returns a GET handler that counts invocations behind
+// ResponseCache.Cache and a POST handler wrapped in ResponseCache.Invalidate.
+func newCacheTestRouter(cache *ResponseCache, calls *int) (http.Handler, http.Handler) {
+	get := cache.Cache("days")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"due_count":` + strconv.Itoa(*calls) + `}`))
+	}))
+	post := cache.Invalidate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return get, post
+}
+
+func doCacheRequest(h http.Handler, method string, userID uuid.UUID) *httptest.ResponseRecorder {
+	return doCacheRequestURL(h, method, "/api/users/me", userID)
+}
+
+func doCacheRequestURL(h http.Handler, method, target string, userID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestResponseCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("serves cached response within TTL", func(t *testing.T) {
+		t.Parallel()
+		cache := NewResponseCache(nil, time.Minute, slog.Default())
+		calls := 0
+		get, _ := newCacheTestRouter(cache, &calls)
+		userID := uuid.New()
+
+		first := doCacheRequest(get, http.MethodGet, userID)
+		second := doCacheRequest(get, http.MethodGet, userID)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "MISS", first.Header().Get("X-Cache"))
+		assert.Equal(t, "HIT", second.Header().Get("X-Cache"))
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+	})
+
+	t.Run("cached hit honours If-None-Match", func(t *testing.T) {
+		t.Parallel()
+		cache := NewResponseCache(nil, time.Minute, slog.Default())
+		calls := 0
+		get := cache.Cache()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			shared.RespondWithJSONETag(w, r, map[string]int{"due_count": 1})
+		}))
+		userID := uuid.New()
+
+		first := doCacheRequest(get, http.MethodGet, userID)
+		etag := first.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		req.Header.Set("If-None-Match", etag)
+		rr := httptest.NewRecorder()
+		get.ServeHTTP(rr, req)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "HIT", rr.Header().Get("X-Cache"))
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+		assert.Equal(t, etag, rr.Header().Get("ETag"))
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("entries are scoped per user", func(t *testing.T) {
+		t.Parallel()
+		cache := NewResponseCache(nil, time.Minute, slog.Default())
+		calls := 0
+		get, _ := newCacheTestRouter(cache, &calls)
+
+		doCacheRequest(get, http.MethodGet, uuid.New())
+		rr := doCacheRequest(get, http.MethodGet, uuid.New())
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, "MISS", rr.Header().Get("X-Cache"))
+	})
+
+	t.Run("expires after TTL", func(t *testing.T) {
+		t.Parallel()
+		store := NewMemoryResponseCacheStore(0)
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		store.now = func() time.Time { return now }
+		cache := NewResponseCache(store, 30*time.Second, slog.Default())
+		calls := 0
+		get, _ := newCacheTestRouter(cache, &calls)
+		userID := uuid.New()
+
+		doCacheRequest(get, http.MethodGet, userID)
+		now = now.Add(31 * time.Second)
+		rr := doCacheRequest(get, http.MethodGet, userID)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, "MISS", rr.Header().Get("X-Cache"))
+		assert.JSONEq(t, `{"due_count":2}`, rr.Body.String())
+	})
+
+	t.Run("write invalidates cached response", func(t *testing.T) {
+		t.Parallel()
+		cache := NewResponseCache(nil, time.Minute, slog.Default())
+		calls := 0
+		get, post := newCacheTestRouter(cache, &calls)
+		userID := uuid.New()
+
+		doCacheRequest(get, http.MethodGet, userID)
+		assert.Equal(t, http.StatusNoContent, doCacheRequest(post, http.MethodPost, userID).Code)
+		rr := doCacheRequest(get, http.MethodGet, userID)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, "MISS", rr.Header().Get("X-Cache"))
+		assert.JSONEq(t, `{"due_count":2}`, rr.Body.String())
+	})
+
+	t.Run("failed write keeps cached response", func(t *testing.T) {
+		t.Parallel()
+		cache := NewResponseCache(nil, time.Minute, slog.Default())
+		calls := 0
+		get, _ := newCacheTestRouter(cache, &calls)
+		failingPost := cache.Invalidate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		userID := uuid.New()
+
+		doCacheRequest(get, http.MethodGet, userID)
+		doCacheRequest(failingPost, http.MethodPost, userID)
+		rr := doCacheRequest(get, http.MethodGet, userID)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "HIT", rr.Header().Get("X-Cache"))
+	})
+
+	t.Run("disabled when TTL is zero", func(t *testing.T) {
+		t.Parallel()
+		cache := NewResponseCache(nil, 0, slog.Default())
+		calls := 0
+		get, _ := newCacheTestRouter(cache, &calls)
+		userID := uuid.New()
+
+		doCacheRequest(get, http.MethodGet, userID)
+		rr := doCacheRequest(get, http.MethodGet, userID)
+
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, rr.Header().Get("X-Cache"))
+	})
+
+	t.Run("key ignores undeclared query parameters", func(t *testing.T) {
+		t.Parallel()
+		store := NewMemoryResponseCacheStore(0)
+		cache := NewResponseCache(store, time.Minute, slog.Default())
+		calls := 0
+		get, _ := newCacheTestRouter(cache, &calls)
+		userID := uuid.New()
+
+		for i := 0; i < 5; i++ {
+			doCacheRequestURL(get, http.MethodGet, fmt.Sprintf("/api/users/me?x=%d", i), userID)
+		}
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 1, store.Len())
+
+		rr := doCacheRequestURL(get, http.MethodGet, "/api/users/me?days=7", userID)
+		assert.Equal(t, "MISS", rr.Header().Get("X-Cache"), "Declared parameters are part of the key")
+		assert.Equal(t, 2, store.Len())
+	})
+
+	t.Run("event for generated cards invalidates owner", func(t *testing.T) {
+		t.Parallel()
+		cache := NewResponseCache(nil, time.Minute, slog.Default())
+		calls := 0
+		get, _ := newCacheTestRouter(cache, &calls)
+		userID := uuid.New()
+		otherUserID := uuid.New()
+
+		doCacheRequest(get, http.MethodGet, userID)
+		doCacheRequest(get, http.MethodGet, otherUserID)
+
+		event, err := events.NewCardsGeneratedEvent(events.CardsGeneratedEvent{
+			MemoID: uuid.New(),
+			UserID: userID,
+		})
+		require.NoError(t, err)
+		require.NoError(t, cache.HandleEvent(context.Background(), event))
+
+		assert.Equal(t, "MISS", doCacheRequest(get, http.MethodGet, userID).Header().Get("X-Cache"))
+		assert.Equal(t, "HIT", doCacheRequest(get, http.MethodGet, otherUserID).Header().Get("X-Cache"))
+	})
+}
+
+func TestMemoryResponseCacheStore_MaxEntries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("evicts least recently used entry", func(t *testing.T) {
+		t.Parallel()
+		store := NewMemoryResponseCacheStore(2)
+		response := &CachedResponse{StatusCode: http.StatusOK}
+
+		store.Set("a", response, time.Minute)
+		store.Set("b", response, time.Minute)
+		_, found := store.Get("a") // a is now more recently used than b
+		require.True(t, found)
+		store.Set("c", response, time.Minute)
+
+		assert.Equal(t, 2, store.Len())
+		_, found = store.Get("b")
+		assert.False(t, found, "Least recently used entry should be evicted")
+		_, found = store.Get("a")
+		assert.True(t, found)
+		_, found = store.Get("c")
+		assert.True(t, found)
+	})
+
+	t.Run("drops expired entries before live ones", func(t *testing.T) {
+		t.Parallel()
+		store := NewMemoryResponseCacheStore(3)
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		store.now = func() time.Time { return now }
+		response := &CachedResponse{StatusCode: http.StatusOK}
+
+		store.Set("short-1", response, time.Second)
+		store.Set("long", response, time.Hour)
+		store.Set("short-2", response, time.Second)
+		now = now.Add(time.Minute)
+		store.Set("new", response, time.Hour)
+
+		assert.Equal(t, 2, store.Len(), "Both expired entries should be dropped")
+		_, found := store.Get("long")
+		assert.True(t, found)
+	})
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures the CORS middleware.
+type CORSOptions struct {
+	// AllowedOrigins lists the origins allowed to make cross-origin requests,
+	// or "*" for any origin. If empty, no cross-origin requests are allowed.
+	AllowedOrigins []string
+
+	// AllowedMethods lists the methods allowed in cross-origin requests.
+	AllowedMethods []string
+
+	// AllowedHeaders lists the request headers allowed in cross-origin requests.
+	AllowedHeaders []string
+
+	// AllowCredentials allows cross-origin requests to include credentials.
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache preflight responses.
+	MaxAge time.Duration
+}
+
+// CORS returns middleware implementing Cross-Origin Resource Sharing.
+//
+// Preflight requests (OPTIONS with Access-Control-Request-Method) are answered
+// directly with 204 No Content; they carry the Access-Control-Allow-* headers
+// only if the origin, method and headers are all allowed. Other requests are
+// passed on, with Access-Control-Allow-Origin added for allowed origins. Requests
+// from origins that are not allowed never get CORS headers, so browsers block
+// them. It must be installed on the root router so that it sees preflight
+// requests before routing rejects the OPTIONS method.
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	allowAnyOrigin := slices.Contains(opts.AllowedOrigins, "*")
+	origins := make(map[string]bool, len(opts.AllowedOrigins))
+	for _, origin := range opts.AllowedOrigins {
+		origins[strings.ToLower(origin)] = true
+	}
+	methods := make(map[string]bool, len(opts.AllowedMethods))
+	for _, method := range opts.AllowedMethods {
+		methods[strings.ToUpper(method)] = true
+	}
+	headers := make(map[string]bool, len(opts.AllowedHeaders))
+	for _, header := range opts.AllowedHeaders {
+		headers[http.CanonicalHeaderKey(header)] = true
+	}
+	allowMethods := strings.Join(opts.AllowedMethods, ", ")
+	allowHeaders := strings.Join(opts.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		if len(opts.AllowedOrigins) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions &&
+				r.Header.Get("Access-Control-Request-Method") != ""
+
+			// Responses depend on the Origin header, so shared caches must key on it
+			w.Header().Add("Vary", "Origin")
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			originAllowed := origin != "" && (allowAnyOrigin || origins[strings.ToLower(origin)])
+
+			if !preflight {
+				if originAllowed {
+					setAllowOrigin(w, origin, allowAnyOrigin, opts.AllowCredentials)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if originAllowed &&
+				methods[strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))] &&
+				requestHeadersAllowed(r.Header.Get("Access-Control-Request-Headers"), headers) {
+				setAllowOrigin(w, origin, allowAnyOrigin, opts.AllowCredentials)
+				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+				if allowHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+				}
+				if opts.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", maxAge)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// setAllowOrigin allows origin to read the response. Any origin is allowed with
+// "*" unless credentials are allowed, which browsers only accept with the
+// origin itself.
+func setAllowOrigin(w http.ResponseWriter, origin string, allowAnyOrigin, allowCredentials bool) {
+	if allowAnyOrigin && !allowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if allowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// requestHeadersAllowed reports whether every header named in an
+// Access-Control-Request-Headers value is allowed.
+func requestHeadersAllowed(requested string, allowed map[string]bool) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !allowed[http.CanonicalHeaderKey(header)] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	options := CORSOptions{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	preflight := func(origin, method, headers string) *http.Request {
+		req := httptest.NewRequest(http.MethodOptions, "/api/memos", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", method)
+		if headers != "" {
+			req.Header.Set("Access-Control-Request-Headers", headers)
+		}
+		return req
+	}
+
+	t.Run("allowed preflight", func(t *testing.T) {
+		t.Parallel()
+
+		rr := httptest.NewRecorder()
+		CORS(options)(okHandler).ServeHTTP(rr,
+			preflight("https://app.example.com", "POST", "authorization, content-type"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "https://app.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST, PUT, DELETE", rr.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Authorization, Content-Type", rr.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", rr.Header().Get("Access-Control-Max-Age"))
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Contains(t, rr.Header().Values("Vary"), "Origin")
+	})
+
+	t.Run("rejected preflights get no CORS headers", func(t *testing.T) {
+		t.Parallel()
+
+		for name, req := range map[string]*http.Request{
+			"disallowed origin": preflight("https://evil.example.com", "POST", ""),
+			"disallowed method": preflight("https://app.example.com", "PATCH", ""),
+			"disallowed header": preflight("https://app.example.com", "POST", "X-Custom"),
+		} {
+			rr := httptest.NewRecorder()
+			CORS(options)(okHandler).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusNoContent, rr.Code, name)
+			assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"), name)
+			assert.Empty(t, rr.Header().Get("Access-Control-Allow-Methods"), name)
+		}
+	})
+
+	t.Run("actual requests", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name         string
+			origin       string
+			expectedACAO string
+		}{
+			{"allowed origin", "https://app.example.com", "https://app.example.com"},
+			{"disallowed origin", "https://evil.example.com", ""},
+			{"same origin", "", ""},
+		}
+
+		for _, tc := range tests {
+			req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			rr := httptest.NewRecorder()
+			CORS(options)(okHandler).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code, "%s: the request should reach the handler", tc.name)
+			assert.Equal(t, tc.expectedACAO, rr.Header().Get("Access-Control-Allow-Origin"), tc.name)
+		}
+	})
+
+	t.Run("wildcard origin", func(t *testing.T) {
+		t.Parallel()
+
+		wildcard := options
+		wildcard.AllowedOrigins = []string{"*"}
+		rr := httptest.NewRecorder()
+		CORS(wildcard)(okHandler).ServeHTTP(rr, preflight("https://any.example.com", "GET", ""))
+		assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("credentials echo the origin", func(t *testing.T) {
+		t.Parallel()
+
+		withCredentials := options
+		withCredentials.AllowCredentials = true
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		rr := httptest.NewRecorder()
+		CORS(withCredentials)(okHandler).ServeHTTP(rr, req)
+
+		assert.Equal(t, "https://app.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("disabled without origins", func(t *testing.T) {
+		t.Parallel()
+
+		rr := httptest.NewRecorder()
+		CORS(CORSOptions{})(okHandler).ServeHTTP(rr, preflight("https://app.example.com", "GET", ""))
+
+		// The preflight is left to the router
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	plogger "github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// RequireVerifiedEmail returns middleware that only passes requests from users
+// who have verified their email address and rejects all others with 403
+// Forbidden and the EMAIL_NOT_VERIFIED error code. Like RoleMiddleware, it
+// reads the user from userStore on every request, so a verification takes
+// effect immediately. It must run after AuthMiddleware.
+func RequireVerifiedEmail(userStore store.UserStore, logger *slog.Logger) func(http.Handler) http.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With(slog.String("component", "email_verification_middleware"))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := plogger.FromContextOrDefault(r.Context(), logger)
+
+			userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+			if !ok || userID == uuid.Nil {
+				api.HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+				return
+			}
+
+			user, err := userStore.GetByID(r.Context(), userID)
+			if err != nil {
+				api.HandleAPIError(w, r, err, "Failed to check email verification")
+				return
+			}
+
+			if !user.EmailVerified {
+				log.Debug("user has not verified their email",
+					slog.String("user_id", userID.String()))
+				api.HandleAPIError(w, r, domain.NewDomainError(
+					domain.CodeEmailNotVerified,
+					"Email verification required",
+					domain.ErrForbidden,
+				), "Email verification required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	t.Parallel()
+
+	verifiedID := uuid.New()
+	unverifiedID := uuid.New()
+
+	userStore := new(mocks.UserStore)
+	userStore.On("GetByID", mock.Anything, verifiedID).
+		Return(&domain.User{ID: verifiedID, EmailVerified: true}, nil)
+	userStore.On("GetByID", mock.Anything, unverifiedID).
+		Return(&domain.User{ID: unverifiedID}, nil)
+
+	handler := RequireVerifiedEmail(userStore, slog.Default())(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	tests := []struct {
+		name           string
+		userID         uuid.UUID
+		expectedStatus int
+		expectedCode   domain.ErrorCode
+	}{
+		{name: "verified user is allowed", userID: verifiedID, expectedStatus: http.StatusOK},
+		{
+			name:           "unverified user is forbidden",
+			userID:         unverifiedID,
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   domain.CodeEmailNotVerified,
+		},
+		{
+			name:           "unauthenticated request",
+			userID:         uuid.Nil,
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   domain.CodeUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/memos", nil)
+			if tt.userID != uuid.Nil {
+				req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, tt.userID))
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				var body shared.ErrorResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+				assert.Equal(t, tt.expectedCode, body.Code)
+			}
+		})
+	}
+}
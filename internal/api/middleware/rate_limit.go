@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	plogger "github.com/phrazzld/scry-api/internal/platform/logger"
+)
+
+// minRateLimitSweepInterval is the shortest interval between sweeps of idle buckets.
+const minRateLimitSweepInterval = time.Minute
+
+// tokenBucket holds the tokens left to a single client.
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// RateLimiter limits how often each client may call the routes it wraps, using
+// a token bucket per client. Authenticated requests are keyed by user ID and
+// all others by client IP, so it can protect both public and protected routes.
+// When used on protected routes it must run after AuthMiddleware.
+//
+// Buckets are held in memory, so limits apply per server instance. Buckets
+// that have been idle long enough to refill completely are dropped by a sweep
+// that runs while requests are handled, so idle clients do not accumulate.
+type RateLimiter struct {
+	mu            sync.Mutex
+	buckets       map[string]*tokenBucket
+	rate          float64 // tokens added per second
+	burst         float64
+	idleAfter     time.Duration
+	sweepInterval time.Duration
+	lastSweep     time.Time
+	now           func() time.Time
+	logger        *slog.Logger
+}
+
+// NewRateLimiter creates a RateLimiter allowing each client requestsPerMinute
+// requests per minute on average, and up to burst requests at once. A
+// requestsPerMinute of zero or less disables limiting; a burst below one
+// allows a single request at once.
+func NewRateLimiter(requestsPerMinute, burst int, logger *slog.Logger) *RateLimiter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	l := &RateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		rate:    float64(requestsPerMinute) / 60,
+		burst:   float64(burst),
+		now:     time.Now,
+		logger:  logger.With(slog.String("component", "rate_limiter")),
+	}
+	if l.rate > 0 {
+		// A bucket idle for this long is full again, the same as a new bucket
+		l.idleAfter = time.Duration(l.burst / l.rate * float64(time.Second))
+	}
+	l.sweepInterval = max(l.idleAfter, minRateLimitSweepInterval)
+	return l
+}
+
+// Limit returns middleware that rejects requests with 429 Too Many Requests
+// and a Retry-After header once the client has used up its tokens.
+func (l *RateLimiter) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.rate <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := rateLimitKey(r)
+		allowed, retryAfter := l.allow(key)
+		if !allowed {
+			plogger.FromContextOrDefault(r.Context(), l.logger).
+				Debug("rate limit exceeded", slog.String("path", r.URL.Path))
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			api.HandleAPIError(w, r, domain.NewDomainError(
+				domain.CodeRateLimited,
+				"Too many requests, please try again later",
+				nil,
+			), "")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow takes a token from the bucket for key. If none is left it returns
+// false and how long it will take for the next token to be added.
+func (l *RateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= l.sweepInterval {
+		l.sweep(now)
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, lastSeen: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.lastSeen).Seconds()*l.rate)
+	bucket.lastSeen = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+		return false, max(wait, time.Second)
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// sweep drops buckets that have been idle long enough to refill completely.
+// The caller must hold l.mu.
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) >= l.idleAfter {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Len returns the number of client buckets currently held.
+func (l *RateLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// rateLimitKey identifies the client of a request: the authenticated user if
+// there is one, otherwise the client IP. The IP is read from RemoteAddr, which
+// chi's RealIP middleware sets from proxy headers.
+func rateLimitKey(r *http.Request) string {
+	if userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID); ok && userID != uuid.Nil {
+		return "user:" + userID.String()
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
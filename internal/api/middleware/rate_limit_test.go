@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimiter returns a limiter whose clock is advanced by the returned function
+func newTestRateLimiter(requestsPerMinute, burst int) (*RateLimiter, func(time.Duration)) {
+	limiter := NewRateLimiter(requestsPerMinute, burst, slog.Default())
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	return limiter, func(d time.Duration) { now = now.Add(d) }
+}
+
+func doRateLimitedRequest(handler http.Handler, userID uuid.UUID, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/memos", nil)
+	req.RemoteAddr = remoteAddr
+	if userID != uuid.Nil {
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	t.Parallel()
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("exhausted bucket returns 429 and recovers", func(t *testing.T) {
+		t.Parallel()
+
+		// One token every 10 seconds, three at once
+		limiter, advance := newTestRateLimiter(6, 3)
+		handler := limiter.Limit(okHandler)
+		userID := uuid.New()
+
+		for i := 0; i < 3; i++ {
+			rr := doRateLimitedRequest(handler, userID, "192.0.2.1:1234")
+			require.Equal(t, http.StatusOK, rr.Code, "request %d should be allowed", i+1)
+		}
+
+		rr := doRateLimitedRequest(handler, userID, "192.0.2.1:1234")
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "10", rr.Header().Get("Retry-After"))
+		var resp shared.ErrorResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, domain.CodeRateLimited, resp.Code)
+
+		// Part of the way to the next token
+		advance(4 * time.Second)
+		rr = doRateLimitedRequest(handler, userID, "192.0.2.1:1234")
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "6", rr.Header().Get("Retry-After"))
+
+		// One token has been added
+		advance(6 * time.Second)
+		assert.Equal(t, http.StatusOK, doRateLimitedRequest(handler, userID, "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(handler, userID, "192.0.2.1:1234").Code)
+
+		// After a long pause the full burst is available again, but no more
+		advance(time.Hour)
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, doRateLimitedRequest(handler, userID, "192.0.2.1:1234").Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(handler, userID, "192.0.2.1:1234").Code)
+	})
+
+	t.Run("clients are limited separately", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, _ := newTestRateLimiter(1, 1)
+		handler := limiter.Limit(okHandler)
+		user1, user2 := uuid.New(), uuid.New()
+
+		// Users sharing an IP are keyed by user ID
+		assert.Equal(t, http.StatusOK, doRateLimitedRequest(handler, user1, "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(handler, user1, "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusOK, doRateLimitedRequest(handler, user2, "192.0.2.1:1234").Code)
+
+		// Unauthenticated requests are keyed by IP, ignoring the port
+		assert.Equal(t, http.StatusOK, doRateLimitedRequest(handler, uuid.Nil, "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(handler, uuid.Nil, "192.0.2.1:5678").Code)
+		assert.Equal(t, http.StatusOK, doRateLimitedRequest(handler, uuid.Nil, "192.0.2.2:1234").Code)
+	})
+
+	t.Run("disabled limiter passes everything", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, _ := newTestRateLimiter(0, 0)
+		handler := limiter.Limit(okHandler)
+
+		for i := 0; i < 100; i++ {
+			require.Equal(t, http.StatusOK, doRateLimitedRequest(handler, uuid.Nil, "192.0.2.1:1234").Code)
+		}
+		assert.Zero(t, limiter.Len())
+	})
+
+	t.Run("idle buckets are swept", func(t *testing.T) {
+		t.Parallel()
+
+		// Buckets refill completely after 30 seconds, but sweeps run at most once a minute
+		limiter, advance := newTestRateLimiter(10, 5)
+		handler := limiter.Limit(okHandler)
+
+		for i := 0; i < 10; i++ {
+			doRateLimitedRequest(handler, uuid.New(), "192.0.2.1:1234")
+		}
+		assert.Equal(t, 10, limiter.Len())
+
+		advance(time.Minute)
+		activeUser := uuid.New()
+		doRateLimitedRequest(handler, activeUser, "192.0.2.1:1234")
+		assert.Equal(t, 1, limiter.Len(), "Only the bucket of the active client should remain")
+	})
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+)
+
+// ExposeRequestID returns middleware that reports the request ID assigned by
+// chi's RequestID middleware, which must run first, to clients: in the
+// X-Request-Id response header and in the request_id field of error
+// responses. Users can then quote it in support requests, and operators can
+// find the request in the logs.
+func ExposeRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := chimiddleware.GetReqID(r.Context())
+		if requestID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set(chimiddleware.RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(shared.WithRequestID(r.Context(), requestID)))
+	})
+}
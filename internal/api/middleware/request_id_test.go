@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExposeRequestID(t *testing.T) {
+	t.Parallel()
+
+	forbidden := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.HandleAPIError(w, r, domain.ErrForbidden, "")
+	})
+
+	serve := func(handler http.Handler, requestID string) (*httptest.ResponseRecorder, shared.ErrorResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/decks/1", nil)
+		if requestID != "" {
+			req.Header.Set("X-Request-Id", requestID)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		var resp shared.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return rec, resp
+	}
+
+	t.Run("error responses carry the X-Request-Id header value", func(t *testing.T) {
+		t.Parallel()
+
+		rec, resp := serve(chimiddleware.RequestID(ExposeRequestID(forbidden)), "")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, domain.CodeForbidden, resp.Code)
+		assert.NotEmpty(t, resp.RequestID)
+		assert.Equal(t, rec.Header().Get("X-Request-Id"), resp.RequestID)
+	})
+
+	t.Run("request IDs sent by the client are kept", func(t *testing.T) {
+		t.Parallel()
+
+		rec, resp := serve(chimiddleware.RequestID(ExposeRequestID(forbidden)), "client-chosen-id")
+
+		assert.Equal(t, "client-chosen-id", rec.Header().Get("X-Request-Id"))
+		assert.Equal(t, "client-chosen-id", resp.RequestID)
+	})
+
+	t.Run("omitted without a request ID", func(t *testing.T) {
+		t.Parallel()
+
+		rec, resp := serve(ExposeRequestID(forbidden), "")
+
+		assert.Empty(t, rec.Header().Get("X-Request-Id"))
+		assert.Empty(t, resp.RequestID)
+	})
+}
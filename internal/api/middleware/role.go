@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	plogger "github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// RoleMiddleware restricts routes to users with a given role.
+// The role is read from the user store on every request, so role changes take
+// effect immediately rather than when the user's token is next refreshed.
+type RoleMiddleware struct {
+	userStore store.UserStore
+	logger    *slog.Logger
+}
+
+// NewRoleMiddleware creates a new RoleMiddleware that looks users up in userStore.
+func NewRoleMiddleware(userStore store.UserStore, logger *slog.Logger) *RoleMiddleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &RoleMiddleware{
+		userStore: userStore,
+		logger:    logger.With(slog.String("component", "role_middleware")),
+	}
+}
+
+// RequireRole returns middleware that only passes requests from users with the
+// given role and rejects all others with 403 Forbidden.
+// It must run after AuthMiddleware so the user ID is available.
+func (m *RoleMiddleware) RequireRole(role domain.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := plogger.FromContextOrDefault(r.Context(), m.logger)
+
+			userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+			if !ok || userID == uuid.Nil {
+				api.HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+				return
+			}
+
+			user, err := m.userStore.GetByID(r.Context(), userID)
+			if err != nil {
+				api.HandleAPIError(w, r, err, "Failed to check user role")
+				return
+			}
+
+			if user.Role != role {
+				log.Warn("user lacks required role",
+					slog.String("user_id", userID.String()),
+					slog.String("required_role", string(role)))
+				api.HandleAPIError(w, r, domain.ErrForbidden, "Insufficient role")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRoleMiddleware_RequireRole(t *testing.T) {
+	t.Parallel()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+	missingID := uuid.New()
+
+	userStore := new(mocks.UserStore)
+	userStore.On("GetByID", mock.Anything, adminID).
+		Return(&domain.User{ID: adminID, Role: domain.UserRoleAdmin}, nil)
+	userStore.On("GetByID", mock.Anything, userID).
+		Return(&domain.User{ID: userID, Role: domain.UserRoleUser}, nil)
+	userStore.On("GetByID", mock.Anything, missingID).
+		Return(nil, store.ErrUserNotFound)
+
+	handler := NewRoleMiddleware(userStore, slog.Default()).RequireRole(domain.UserRoleAdmin)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	tests := []struct {
+		name           string
+		userID         uuid.UUID
+		expectedStatus int
+	}{
+		{name: "admin is allowed", userID: adminID, expectedStatus: http.StatusOK},
+		{name: "regular user is forbidden", userID: userID, expectedStatus: http.StatusForbidden},
+		{name: "unknown user is not found", userID: missingID, expectedStatus: http.StatusNotFound},
+		{name: "unauthenticated request", userID: uuid.Nil, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/migrations", nil)
+			if tt.userID != uuid.Nil {
+				req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, tt.userID))
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
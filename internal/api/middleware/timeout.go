@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/api"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// Timeout returns middleware that gives each request a deadline of timeout.
+// The handler runs with a context that is cancelled at the deadline, so
+// database queries and other calls made with it are aborted. If the handler
+// has not started its response by then, the client gets 504 Gateway Timeout
+// with the REQUEST_TIMEOUT code; otherwise its response is cut off. Either
+// way, anything the handler writes after the deadline is discarded, so
+// streaming routes should not be given a timeout.
+//
+// Deadlines nest: a timeout applied inside a group can shorten the group's
+// deadline but not extend it, so routes that need longer, such as card
+// generation, belong in a group of their own. A timeout of zero or less
+// disables the middleware.
+func Timeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &timeoutWriter{w: w, header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- fmt.Sprintf("%v\n%s", p, debug.Stack())
+						return
+					}
+					close(done)
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+			}()
+
+			select {
+			case <-done:
+			case p := <-panicked:
+				// Re-panic on the request goroutine, where a recoverer can handle it
+				panic(p)
+			case <-ctx.Done():
+				if tw.timeOut() {
+					api.HandleAPIError(w, r, domain.NewDomainError(
+						domain.CodeRequestTimeout,
+						"Request timed out",
+						ctx.Err(),
+					), "")
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter passes a handler's response through to w until the request
+// times out. It keeps its own header map so that a handler still running
+// after the timeout can't race with the timeout response, and drops writes
+// made after the timeout, when w may no longer be used.
+type timeoutWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+
+	mu       sync.Mutex
+	wrote    bool
+	timedOut bool
+}
+
+// timeOut marks the request as timed out and reports whether the timeout
+// response should be written, which is only if the handler hasn't started one.
+func (tw *timeoutWriter) timeOut() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.timedOut = true
+	return !tw.wrote
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.wrote {
+		return
+	}
+	tw.writeHeaderLocked(status)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wrote {
+		tw.writeHeaderLocked(http.StatusOK)
+	}
+	return tw.w.Write(b)
+}
+
+// Flush sends buffered data to the client, for handlers that stream.
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || !tw.wrote {
+		return
+	}
+	if f, ok := tw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (tw *timeoutWriter) writeHeaderLocked(status int) {
+	dst := tw.w.Header()
+	for key, values := range tw.header {
+		dst[key] = values
+	}
+	tw.wrote = true
+	tw.w.WriteHeader(status)
+}
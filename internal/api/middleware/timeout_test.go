@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("slow handler times out", func(t *testing.T) {
+		t.Parallel()
+
+		handlerErr := make(chan error, 1)
+		handlerWrite := make(chan error, 1)
+		served := make(chan struct{})
+		handler := Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Stands in for a slow query, which returns once its context is done
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			handlerErr <- r.Context().Err()
+
+			// Respond only after the timeout response has been sent
+			<-served
+			w.Header().Set("X-Late", "true")
+			_, err := w.Write([]byte("too late"))
+			handlerWrite <- err
+		}))
+
+		rec := httptest.NewRecorder()
+		start := time.Now()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cards", nil))
+		close(served)
+
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+		var resp shared.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, domain.CodeRequestTimeout, resp.Code)
+
+		// The handler's context was cancelled, and its late response discarded
+		assert.ErrorIs(t, <-handlerErr, context.DeadlineExceeded)
+		assert.ErrorIs(t, <-handlerWrite, http.ErrHandlerTimeout)
+		assert.Empty(t, rec.Header().Get("X-Late"))
+	})
+
+	t.Run("fast handler responds normally", func(t *testing.T) {
+		t.Parallel()
+
+		handler := Timeout(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deadline, ok := r.Context().Deadline()
+			assert.True(t, ok, "the handler's context has a deadline")
+			assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+			w.Header().Set("X-Handled", "true")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("created"))
+		}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/memos", nil))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get("X-Handled"))
+		assert.Equal(t, "created", rec.Body.String())
+	})
+
+	t.Run("zero disables the timeout", func(t *testing.T) {
+		t.Parallel()
+
+		handler := Timeout(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := r.Context().Deadline()
+			assert.False(t, ok)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cards", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("handler panics reach the caller", func(t *testing.T) {
+		t.Parallel()
+
+		handler := Timeout(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+
+		assert.Panics(t, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/cards", nil))
+		})
+	})
+}
@@ -45,6 +45,38 @@ func (m *TokenRedactionMockJWTService) ValidateRefreshToken(ctx context.Context,
 	return claims, args.Error(1)
 }
 
+func (m *TokenRedactionMockJWTService) GenerateEmailVerificationToken(ctx context.Context, userID uuid.UUID) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TokenRedactionMockJWTService) ValidateEmailVerificationToken(ctx context.Context, token string) (*auth.Claims, error) {
+	args := m.Called(ctx, token)
+	var claims *auth.Claims
+	if arg := args.Get(0); arg != nil {
+		claims = arg.(*auth.Claims)
+	}
+	return claims, args.Error(1)
+}
+
+func (m *TokenRedactionMockJWTService) GeneratePasswordResetToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	fingerprint string,
+) (string, error) {
+	args := m.Called(ctx, userID, fingerprint)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TokenRedactionMockJWTService) ValidatePasswordResetToken(ctx context.Context, token string) (*auth.Claims, error) {
+	args := m.Called(ctx, token)
+	var claims *auth.Claims
+	if arg := args.Get(0); arg != nil {
+		claims = arg.(*auth.Claims)
+	}
+	return claims, args.Error(1)
+}
+
 // TestTokenRedaction verifies that token values are properly redacted in logs
 func TestTokenRedaction(t *testing.T) {
 	// Setup log capture
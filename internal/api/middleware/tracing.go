@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewTracingMiddleware creates a middleware that starts a span for each request,
+// continuing the caller's trace when the request carries a traceparent header.
+// The span is stored in the request context, so spans started by handlers,
+// services and stores become its children.
+//
+// Spans are named after the method and the matched chi route pattern, e.g.
+// "GET /api/cards/{id}", so requests for different resources share a name.
+// This middleware must be applied to the root router; it does nothing visible
+// while tracing is disabled.
+func NewTracingMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		named := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+
+			// The route pattern is only known once routing has completed
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil || rctx.RoutePattern() == "" {
+				return
+			}
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + rctx.RoutePattern())
+			span.SetAttributes(semconv.HTTPRoute(rctx.RoutePattern()))
+		})
+
+		return otelhttp.NewHandler(named, "http.request",
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method
+			}),
+		)
+	}
+}
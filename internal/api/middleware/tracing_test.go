@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/platform/tracing"
+	"github.com/phrazzld/scry-api/internal/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeDB is a store.DBTX whose ExecContext succeeds without a database
+type fakeDB struct{}
+
+func (fakeDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, nil
+}
+
+func (fakeDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, nil
+}
+
+func (fakeDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return nil
+}
+
+// useInMemoryTracing installs a tracer provider recording spans in memory for
+// the duration of the test
+func useInMemoryTracing(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return exporter
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := useInMemoryTracing(t)
+
+	db := postgres.NewInstrumentedDB(fakeDB{}, -1, nil, postgres.WithTracing())
+	router := chi.NewRouter()
+	router.Use(NewTracingMiddleware())
+	router.Post("/api/cards/{id}/answer", func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.Start(r.Context(), "CardReviewService.SubmitAnswer")
+		_, err := db.ExecContext(ctx, "UPDATE user_card_stats SET interval = $1", 3)
+		tracing.End(span, err)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/cards/7f4a0b2e/answer", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	byName := make(map[string]tracetest.SpanStub, len(spans))
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+
+	request, ok := byName["POST /api/cards/{id}/answer"]
+	require.True(t, ok, "The request span should be named after the route pattern")
+	assert.False(t, request.Parent.IsValid(), "The request span should be the root")
+	assert.Equal(t, trace.SpanKindServer, request.SpanKind)
+	assert.Contains(t, request.Attributes, semconv.HTTPRoute("/api/cards/{id}/answer"))
+
+	service, ok := byName["CardReviewService.SubmitAnswer"]
+	require.True(t, ok)
+	assert.Equal(t, request.SpanContext.SpanID(), service.Parent.SpanID())
+
+	query, ok := byName["db.exec"]
+	require.True(t, ok, "The query should have a span")
+	assert.Equal(t, service.SpanContext.SpanID(), query.Parent.SpanID())
+	assert.Equal(t, request.SpanContext.TraceID(), query.SpanContext.TraceID())
+	assert.Contains(t, query.Attributes, semconv.DBSystemPostgreSQL)
+	assert.Contains(t, query.Attributes,
+		semconv.DBQueryText(redact.String("UPDATE user_card_stats SET interval = $1")),
+		"The SQL should be recorded as it would be logged")
+}
+
+func TestTracingMiddleware_ContinuesCallerTrace(t *testing.T) {
+	exporter := useInMemoryTracing(t)
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	router := chi.NewRouter()
+	router.Use(NewTracingMiddleware())
+	router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+}
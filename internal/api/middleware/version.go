@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIVersionHeader names the response header reporting which API version
+// served a request
+const APIVersionHeader = "API-Version"
+
+// APIVersion returns middleware that reports version in the API-Version
+// response header, so clients can tell which version of a route answered
+// them, including through an unversioned alias.
+func APIVersion(version string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(APIVersionHeader, version)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// DeprecationOptions configures the headers set by Deprecated
+type DeprecationOptions struct {
+	// DeprecatedAt is when the routes were deprecated
+	DeprecatedAt time.Time
+
+	// Sunset is when the routes will stop being served; zero if not yet decided
+	Sunset time.Time
+
+	// Successor returns the path of the route replacing the one at path, or
+	// "" if there is none. Nil means no route replaces it.
+	Successor func(path string) string
+}
+
+// Deprecated returns middleware that marks responses as coming from
+// deprecated routes. It sets the Deprecation header (RFC 9745), the Sunset
+// header (RFC 8594) when a sunset is set, and a Link to the successor route
+// with relation "successor-version". Requests are otherwise served as usual.
+func Deprecated(opts DeprecationOptions) func(http.Handler) http.Handler {
+	deprecation := "@" + strconv.FormatInt(opts.DeprecatedAt.Unix(), 10)
+	var sunset string
+	if !opts.Sunset.IsZero() {
+		sunset = opts.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			if sunset != "" {
+				w.Header().Set("Sunset", sunset)
+			}
+			if opts.Successor != nil {
+				if successor := opts.Successor(r.URL.Path); successor != "" {
+					w.Header().Add("Link", "<"+successor+`>; rel="successor-version"`)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
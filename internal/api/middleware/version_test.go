@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	handler := APIVersion("v2")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/decks", nil))
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "v2", rec.Header().Get(APIVersionHeader))
+}
+
+func TestDeprecated(t *testing.T) {
+	t.Parallel()
+
+	deprecatedAt := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, time.July, 1, 12, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	successor := func(path string) string {
+		if path == "/api/gone" {
+			return ""
+		}
+		return "/api/v1" + strings.TrimPrefix(path, "/api")
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		opts       DeprecationOptions
+		path       string
+		wantSunset string
+		wantLink   string
+	}{
+		{
+			name:       "all headers",
+			opts:       DeprecationOptions{DeprecatedAt: deprecatedAt, Sunset: sunset, Successor: successor},
+			path:       "/api/cards/next",
+			wantSunset: "Wed, 01 Jul 2026 10:00:00 GMT",
+			wantLink:   `</api/v1/cards/next>; rel="successor-version"`,
+		},
+		{
+			name: "no sunset",
+			opts: DeprecationOptions{DeprecatedAt: deprecatedAt, Successor: successor},
+			path: "/api/cards/next",
+			// Sunset is omitted until one is decided
+			wantLink: `</api/v1/cards/next>; rel="successor-version"`,
+		},
+		{
+			name: "no successor for the path",
+			opts: DeprecationOptions{DeprecatedAt: deprecatedAt, Successor: successor},
+			path: "/api/gone",
+		},
+		{
+			name: "no successors",
+			opts: DeprecationOptions{DeprecatedAt: deprecatedAt},
+			path: "/api/cards/next",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			Deprecated(tt.opts)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code, "deprecated routes are still served")
+			assert.Equal(t, "@1767225600", rec.Header().Get("Deprecation"))
+			assert.Equal(t, tt.wantSunset, rec.Header().Get("Sunset"))
+			assert.Equal(t, tt.wantLink, rec.Header().Get("Link"))
+		})
+	}
+}
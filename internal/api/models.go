@@ -40,6 +40,40 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+// VerifyEmailRequest defines the payload for the email verification endpoint.
+type VerifyEmailRequest struct {
+	// Token is the email verification token sent to the user's address
+	Token string `json:"token" validate:"required"`
+}
+
+// VerifyEmailResponse defines the successful response for the email verification endpoint.
+type VerifyEmailResponse struct {
+	// UserID is the user whose email address was verified
+	UserID uuid.UUID `json:"user_id"`
+
+	// EmailVerified is always true in a successful response
+	EmailVerified bool `json:"email_verified"`
+}
+
+// ForgotPasswordRequest defines the payload for the forgot password endpoint.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest defines the payload for the reset password endpoint.
+type ResetPasswordRequest struct {
+	// Token is the password reset token sent to the user's address
+	Token string `json:"token" validate:"required"`
+
+	// Password is the new password
+	Password string `json:"password" validate:"required,min=12,max=72"`
+}
+
+// MessageResponse defines a response that only carries a human-readable message.
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
 // RefreshTokenResponse defines the successful response for the token refresh endpoint.
 type RefreshTokenResponse struct {
 	// AccessToken is the new JWT token used for API authorization
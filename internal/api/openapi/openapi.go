@@ -0,0 +1,259 @@
+// Package openapi builds an OpenAPI 3 document describing the HTTP API.
+//
+// Request and response schemas are generated by reflection from the Go types
+// the handlers decode and encode, using their json and validate struct tags,
+// so the document cannot drift from the structs. The endpoints themselves are
+// listed by the caller as Routes.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Version is the OpenAPI specification version of generated documents
+const Version = "3.0.3"
+
+// bearerAuth is the name of the security scheme used by authenticated routes
+const bearerAuth = "bearerAuth"
+
+// Document is an OpenAPI 3 document.
+// Only the parts of the specification used by this API are modelled.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers,omitempty"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info is the metadata of a Document
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a base URL the API is served from
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Components holds the schemas and security schemes referenced by operations
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how clients authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Operation describes a single method on a path
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter describes a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body an operation accepts
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes one response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a body in one content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Route describes an endpoint to include in the document.
+type Route struct {
+	// Method is the HTTP method, such as http.MethodGet
+	Method string
+
+	// Path is the full path with chi-style {name} parameters, such as
+	// /api/cards/{id}. Every path parameter is documented as a UUID.
+	Path string
+
+	// Summary is a one-line description of the endpoint
+	Summary string
+
+	// Tag groups related endpoints
+	Tag string
+
+	// Public routes do not require a bearer token
+	Public bool
+
+	// Parameters lists the query and header parameters the endpoint reads
+	Parameters []Parameter
+
+	// Request is a value of the type the endpoint decodes its JSON body into,
+	// or nil if it takes no body
+	Request any
+
+	// RequestTypes lists additional non-JSON content types accepted as a
+	// plain string body, such as text/csv
+	RequestTypes []string
+
+	// Responses lists the successful responses
+	Responses []Reply
+}
+
+// Reply describes a successful response of a Route
+type Reply struct {
+	// Status is the HTTP status code
+	Status int
+
+	// Description defaults to the status text
+	Description string
+
+	// Body is a value of the type encoded as the JSON body, or nil for none
+	Body any
+
+	// BodyTypes lists additional non-JSON content types of the body, which
+	// are documented as plain strings
+	BodyTypes []string
+}
+
+// Build assembles a document describing routes. errorBody is a value of the
+// type every error response is encoded as; it is documented as the default
+// response of each operation.
+func Build(info Info, routes []Route, errorBody any) (*Document, error) {
+	gen := newGenerator()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]map[string]Operation),
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	errorSchema := gen.schemaOf(errorBody, false)
+	seen := make(map[string]bool)
+	for _, route := range routes {
+		method := strings.ToLower(route.Method)
+		key := route.Method + " " + route.Path
+		if seen[key] {
+			return nil, fmt.Errorf("route %s is listed more than once", key)
+		}
+		seen[key] = true
+		if len(route.Responses) == 0 {
+			return nil, fmt.Errorf("route %s has no responses", key)
+		}
+
+		op := Operation{
+			OperationID: operationID(route.Method, route.Path),
+			Summary:     route.Summary,
+			Parameters:  pathParameters(route.Path),
+			Responses:   make(map[string]Response),
+		}
+		if route.Tag != "" {
+			op.Tags = []string{route.Tag}
+		}
+		op.Parameters = append(op.Parameters, route.Parameters...)
+		if !route.Public {
+			op.Security = []map[string][]string{{bearerAuth: {}}}
+		}
+
+		if route.Request != nil || len(route.RequestTypes) > 0 {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  gen.content(route.Request, route.RequestTypes, true),
+			}
+		}
+
+		for _, reply := range route.Responses {
+			description := reply.Description
+			if description == "" {
+				description = http.StatusText(reply.Status)
+			}
+			response := Response{Description: description}
+			if reply.Body != nil || len(reply.BodyTypes) > 0 {
+				response.Content = gen.content(reply.Body, reply.BodyTypes, false)
+			}
+			op.Responses[strconv.Itoa(reply.Status)] = response
+		}
+		op.Responses["default"] = Response{
+			Description: "Error",
+			Content:     map[string]MediaType{"application/json": {Schema: errorSchema}},
+		}
+
+		if doc.Paths[route.Path] == nil {
+			doc.Paths[route.Path] = make(map[string]Operation)
+		}
+		doc.Paths[route.Path][method] = op
+	}
+
+	doc.Components.Schemas = gen.schemas
+	return doc, nil
+}
+
+// content returns the media types of a request or response body: JSON for
+// body, if not nil, and a plain string for each of the other types
+func (g *generator) content(body any, types []string, request bool) map[string]MediaType {
+	content := make(map[string]MediaType)
+	if body != nil {
+		content["application/json"] = MediaType{Schema: g.schemaOf(body, request)}
+	}
+	for _, t := range types {
+		content[t] = MediaType{Schema: &Schema{Type: "string"}}
+	}
+	return content
+}
+
+// pathParameters documents the {name} parameters of path as UUIDs
+func pathParameters(path string) []Parameter {
+	var params []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, Parameter{
+				Name:     strings.Trim(segment, "{}"),
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string", Format: "uuid"},
+			})
+		}
+	}
+	return params
+}
+
+// operationID derives a stable identifier from the method and path, such as
+// postApiCardsIdAnswer for POST /api/cards/{id}/answer
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '-' || r == '_'
+	}) {
+		b.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBase struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testItem struct {
+	testBase
+	Name     string          `json:"name"`
+	Note     *string         `json:"note,omitempty"`
+	Data     json.RawMessage `json:"data"`
+	Children []testItem      `json:"children"`
+	secret   string
+	Ignored  string `json:"-"`
+}
+
+type testRequest struct {
+	Email   string      `json:"email"   validate:"required,email"`
+	Outcome string      `json:"outcome" validate:"required,oneof=again good"`
+	Count   int         `json:"count"   validate:"gte=1,lte=10"`
+	Name    string      `json:"name"    validate:"min=2,max=5"`
+	IDs     []uuid.UUID `json:"ids"     validate:"required,min=1,max=3,dive"`
+	Item    testItem    `json:"item"    validate:"required"`
+	Draft   bool        `json:"draft"`
+}
+
+func intPtr(n int) *int { return &n }
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestSchema_ResponseBody(t *testing.T) {
+	g := newGenerator()
+	ref := g.schemaOf(testItem{}, false)
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/testItem"}, ref)
+
+	item := g.schemas["testItem"]
+	require.NotNil(t, item)
+	assert.Equal(t, "object", item.Type)
+	assert.ElementsMatch(t, []string{"id", "created_at", "name", "data", "children"}, item.Required,
+		"fields without omitempty are always present in responses")
+	assert.Equal(t, &Schema{Type: "string", Format: "uuid"}, item.Properties["id"], "embedded fields are promoted")
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, item.Properties["created_at"])
+	assert.Equal(t, &Schema{Type: "string", Nullable: true}, item.Properties["note"])
+	assert.Equal(t, &Schema{}, item.Properties["data"], "raw JSON may hold any value")
+	assert.Equal(t, &Schema{Type: "array", Items: ref}, item.Properties["children"],
+		"recursive types refer to themselves")
+	assert.NotContains(t, item.Properties, "secret")
+	assert.NotContains(t, item.Properties, "Ignored")
+	assert.NotContains(t, g.schemas, "testBase", "embedded structs are not referenced")
+}
+
+func TestSchema_RequestBody(t *testing.T) {
+	g := newGenerator()
+	g.schemaOf(testRequest{}, true)
+
+	req := g.schemas["testRequest"]
+	require.NotNil(t, req)
+	assert.Equal(t, []string{"email", "outcome", "ids", "item"}, req.Required,
+		"only fields validated as required are required in requests")
+	assert.Equal(t, &Schema{Type: "string", Format: "email"}, req.Properties["email"])
+	assert.Equal(t, &Schema{Type: "string", Enum: []string{"again", "good"}}, req.Properties["outcome"])
+	assert.Equal(t, &Schema{Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(10)}, req.Properties["count"])
+	assert.Equal(t, &Schema{Type: "string", MinLength: intPtr(2), MaxLength: intPtr(5)}, req.Properties["name"])
+	assert.Equal(t, &Schema{
+		Type:     "array",
+		MinItems: intPtr(1),
+		MaxItems: intPtr(3),
+		Items:    &Schema{Type: "string", Format: "uuid"},
+	}, req.Properties["ids"])
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/testItem"}, req.Properties["item"],
+		"references carry no constraints")
+	assert.Equal(t, &Schema{Type: "boolean"}, req.Properties["draft"])
+}
+
+func TestBuild(t *testing.T) {
+	routes := []Route{
+		{
+			Method:     http.MethodPost,
+			Path:       "/api/items/{id}/rename",
+			Summary:    "Rename an item",
+			Tag:        "items",
+			Parameters: []Parameter{{Name: "dry_run", In: "query", Schema: &Schema{Type: "boolean"}}},
+			Request:    testRequest{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Body: []testItem{}},
+				{Status: http.StatusNoContent, Description: "Nothing to rename"},
+			},
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/health",
+			Public:    true,
+			Responses: []Reply{{Status: http.StatusOK, BodyTypes: []string{"text/plain"}}},
+		},
+	}
+
+	doc, err := Build(Info{Title: "Test", Version: "1"}, routes, testBase{})
+	require.NoError(t, err)
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Contains(t, doc.Components.SecuritySchemes, bearerAuth)
+
+	op := doc.Paths["/api/items/{id}/rename"]["post"]
+	assert.Equal(t, "postApiItemsIdRename", op.OperationID)
+	assert.Equal(t, []string{"items"}, op.Tags)
+	assert.Equal(t, []map[string][]string{{bearerAuth: {}}}, op.Security)
+	require.Len(t, op.Parameters, 2)
+	assert.Equal(t, Parameter{
+		Name:     "id",
+		In:       "path",
+		Required: true,
+		Schema:   &Schema{Type: "string", Format: "uuid"},
+	}, op.Parameters[0])
+	assert.Equal(t, "dry_run", op.Parameters[1].Name)
+
+	require.NotNil(t, op.RequestBody)
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/testRequest"},
+		op.RequestBody.Content["application/json"].Schema)
+	assert.Equal(t, "OK", op.Responses["200"].Description)
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/testItem"}},
+		op.Responses["200"].Content["application/json"].Schema)
+	assert.Equal(t, Response{Description: "Nothing to rename"}, op.Responses["204"])
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/testBase"},
+		op.Responses["default"].Content["application/json"].Schema)
+
+	health := doc.Paths["/health"]["get"]
+	assert.Nil(t, health.Security, "public routes need no token")
+	assert.Nil(t, health.RequestBody)
+	assert.Equal(t, &Schema{Type: "string"}, health.Responses["200"].Content["text/plain"].Schema)
+
+	// The document encodes to JSON
+	_, err = json.Marshal(doc)
+	require.NoError(t, err)
+}
+
+func TestBuild_InvalidRoutes(t *testing.T) {
+	ok := Reply{Status: http.StatusOK}
+
+	_, err := Build(Info{}, []Route{
+		{Method: http.MethodGet, Path: "/a", Responses: []Reply{ok}},
+		{Method: http.MethodGet, Path: "/a", Responses: []Reply{ok}},
+	}, testBase{})
+	assert.ErrorContains(t, err, "listed more than once")
+
+	_, err = Build(Info{}, []Route{{Method: http.MethodGet, Path: "/a"}}, testBase{})
+	assert.ErrorContains(t, err, "has no responses")
+}
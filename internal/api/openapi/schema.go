@@ -0,0 +1,271 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is an OpenAPI 3.0 schema object.
+// An empty Schema accepts any value.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// componentRefPrefix prefixes references to schemas in Components
+const componentRefPrefix = "#/components/schemas/"
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// generator derives schemas from Go types. Named struct types are added to
+// schemas once and referenced by name, so a type used by both requests and
+// responses is described as it was first seen.
+type generator struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+
+	// request is set while generating the schema of a request body
+	request bool
+}
+
+func newGenerator() *generator {
+	return &generator{
+		schemas: make(map[string]*Schema),
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+// schemaOf returns the schema of the type of v, as a request body if request
+// is set and as a response body otherwise
+func (g *generator) schemaOf(v any, request bool) *Schema {
+	g.request = request
+	return g.schema(reflect.TypeOf(v))
+}
+
+// schema returns the schema of t, as encoded by encoding/json
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem := g.schema(t.Elem())
+		// A $ref cannot carry siblings in OpenAPI 3.0, so referenced
+		// schemas are left as they are
+		if elem.Ref == "" {
+			elem.Nullable = true
+		}
+		return elem
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		// Interfaces and anything else may hold any value
+		return &Schema{}
+	}
+}
+
+// structRef adds the schema of the struct type t to the components, if it
+// is not there already, and returns a reference to it. Anonymous structs are
+// returned inline.
+func (g *generator) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+
+	name := t.Name()
+	if existing, ok := g.types[name]; ok && existing != t {
+		// Two packages declare the same type name; qualify the later one
+		name = strings.ReplaceAll(t.PkgPath(), "/", ".") + "." + name
+	}
+	ref := &Schema{Ref: componentRefPrefix + name}
+	if _, ok := g.schemas[name]; ok {
+		return ref
+	}
+
+	// Register the name before generating the fields, so recursive types
+	// refer to themselves instead of recursing forever
+	g.types[name] = t
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return ref
+}
+
+// structSchema returns the object schema of the fields of struct type t.
+// Fields of embedded structs are promoted, as encoding/json does.
+//
+// In request bodies a field is required if its validate tag includes
+// "required". In response bodies every field without omitempty is always
+// present, so those fields are required.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(schema, t)
+	return schema
+}
+
+func (g *generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(schema, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := g.schema(field.Type)
+		constrained := prop
+		if prop.Ref != "" {
+			// A $ref cannot carry siblings in OpenAPI 3.0
+			constrained = &Schema{}
+		}
+		required := applyValidateRules(constrained, field.Tag.Get("validate"))
+		if !g.request {
+			required = !hasOption(opts, "omitempty")
+		}
+
+		schema.Properties[name] = prop
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// applyValidateRules adds the constraints of a validate tag to prop and
+// reports whether the tag requires the field. Rules after "dive" apply to
+// the elements of a slice and are ignored; rules with no schema equivalent
+// are ignored too.
+func applyValidateRules(prop *Schema, rules string) bool {
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			return required
+		case "required":
+			required = true
+		case "email":
+			prop.Format = "email"
+		case "uuid", "uuid4":
+			prop.Format = "uuid"
+		case "oneof":
+			prop.Enum = strings.Fields(param)
+		case "min", "gte":
+			setBound(prop, param, true)
+		case "max", "lte":
+			setBound(prop, param, false)
+		}
+	}
+	return required
+}
+
+// setBound sets the lower or upper bound given by a min, max, gte or lte
+// rule: a length for strings, a count for arrays and a value for numbers
+func setBound(prop *Schema, param string, lower bool) {
+	switch prop.Type {
+	case "string", "array":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		switch {
+		case prop.Type == "string" && lower:
+			prop.MinLength = &n
+		case prop.Type == "string":
+			prop.MaxLength = &n
+		case lower:
+			prop.MinItems = &n
+		default:
+			prop.MaxItems = &n
+		}
+	case "integer", "number":
+		f, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		if lower {
+			prop.Minimum = &f
+		} else {
+			prop.Maximum = &f
+		}
+	}
+}
+
+// hasOption reports whether the comma-separated json tag options include opt
+func hasOption(opts, opt string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,408 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/phrazzld/scry-api/internal/api/openapi"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// OpenAPIInfo is the metadata of the generated OpenAPI document
+var OpenAPIInfo = openapi.Info{
+	Title:       "Scry API",
+	Description: "Spaced repetition flashcards generated from memos.",
+	Version:     "1.0.0",
+}
+
+// OpenAPIRoutes lists every endpoint registered by the server with the types
+// of its request and response bodies. It must be kept in step with the router
+// in cmd/server; a test there fails when the two differ. The deprecated
+// unversioned /api alias of /api/v1 is left out.
+func OpenAPIRoutes() []openapi.Route {
+	idempotencyKey := openapi.Parameter{
+		Name:        IdempotencyKeyHeader,
+		In:          "header",
+		Description: "Replays the original response when a request is repeated with the same key",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+	ifNoneMatch := openapi.Parameter{
+		Name:        "If-None-Match",
+		In:          "header",
+		Description: "ETag of the client's copy; 304 Not Modified is returned if it is current",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+	deckID := queryParam("deck_id", "Only consider cards in this deck", &openapi.Schema{Type: "string", Format: "uuid"})
+	order := queryParam("order", "Which due card to return first; defaults to the deck's review order", &openapi.Schema{
+		Type: "string",
+		Enum: []string{
+			string(domain.ReviewOrderDueDate),
+			string(domain.ReviewOrderRandomDue),
+			string(domain.ReviewOrderLowestEase),
+		},
+	})
+	exclude := queryParam("exclude",
+		"Comma-separated IDs of cards not to return; may be repeated",
+		&openapi.Schema{Type: "string"})
+	limit := queryParam("limit", "Maximum number of results", &openapi.Schema{Type: "integer"})
+	offset := queryParam("offset", "Number of results to skip", &openapi.Schema{Type: "integer"})
+	days := queryParam("days", "Number of days to cover", &openapi.Schema{Type: "integer"})
+	tag := queryParam("tag", "Only list cards with this tag", &openapi.Schema{Type: "string"})
+	tag.Required = true
+	email := queryParam("email", "Only list users whose email contains this text, ignoring case",
+		&openapi.Schema{Type: "string"})
+	wait := queryParam("wait", "How long to wait for a change, such as 25s, up to 60s",
+		&openapi.Schema{Type: "string"})
+	format := queryParam("format", "Export format", &openapi.Schema{
+		Type: "string",
+		Enum: []string{ExportFormatJSON, ExportFormatAnkiCSV},
+	})
+	includeArchived := queryParam("include_archived",
+		"Also export cards superseded by regenerating their memo", &openapi.Schema{Type: "boolean"})
+
+	return []openapi.Route{
+		// Authentication
+		{
+			Method: http.MethodPost, Path: "/api/v1/auth/register", Tag: "auth", Public: true,
+			Summary:   "Create an account",
+			Request:   RegisterRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusCreated, Body: AuthResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/auth/login", Tag: "auth", Public: true,
+			Summary:   "Log in with email and password",
+			Request:   LoginRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: AuthResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/auth/refresh", Tag: "auth", Public: true,
+			Summary:   "Exchange a refresh token for a new token pair",
+			Request:   RefreshTokenRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: RefreshTokenResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/auth/verify-email", Tag: "auth", Public: true,
+			Summary:   "Verify an email address",
+			Request:   VerifyEmailRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: VerifyEmailResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/auth/forgot-password", Tag: "auth", Public: true,
+			Summary:   "Request a password reset email",
+			Request:   ForgotPasswordRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: MessageResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/auth/reset-password", Tag: "auth", Public: true,
+			Summary:   "Set a new password with a reset token",
+			Request:   ResetPasswordRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: MessageResponse{}}},
+		},
+
+		// Memos
+		{
+			Method: http.MethodPost, Path: "/api/v1/memos", Tag: "memos",
+			Summary:    "Create a memo and generate cards from it",
+			Parameters: []openapi.Parameter{idempotencyKey},
+			Request:    CreateMemoRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusAccepted, Description: "Card generation was queued", Body: MemoResponse{}},
+				{Status: http.StatusCreated, Description: "A draft memo was created", Body: MemoResponse{}},
+				{Status: http.StatusOK, Description: "The memo created with the idempotency key", Body: MemoResponse{}},
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/memos/preview", Tag: "memos",
+			Summary: "Preview the cards generated from a text without saving them",
+			Request: PreviewCardsRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Description: "The generated cards, which are not saved", Body: PreviewCardsResponse{}},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/memos/{id}", Tag: "memos",
+			Summary:   "Get a memo",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: MemoResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/memos/{id}/events", Tag: "memos",
+			Summary: "Stream a memo's status changes as Server-Sent Events",
+			Responses: []openapi.Reply{{
+				Status:      http.StatusOK,
+				Description: "An event stream of status events, each with MemoStatusEventResponse data",
+				BodyTypes:   []string{"text/event-stream"},
+			}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/memos/{id}/status", Tag: "memos",
+			Summary:    "Wait for a memo's status to change",
+			Parameters: []openapi.Parameter{wait},
+			Responses:  []openapi.Reply{{Status: http.StatusOK, Body: MemoStatusEventResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/memos/{id}/generate", Tag: "memos",
+			Summary:   "Generate cards from a draft memo",
+			Responses: []openapi.Reply{{Status: http.StatusAccepted, Body: MemoResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/memos/{id}/regenerate", Tag: "memos",
+			Summary:   "Replace a memo's text and generate new cards",
+			Request:   RegenerateMemoRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusAccepted, Body: MemoResponse{}}},
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/memos/{id}/deck", Tag: "memos",
+			Summary:   "Assign a memo to a deck, or remove it from its deck",
+			Request:   AssignMemoDeckRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: MemoResponse{}}},
+		},
+
+		// Cards
+		{
+			Method: http.MethodGet, Path: "/api/v1/cards", Tag: "cards",
+			Summary:    "List cards with a tag",
+			Parameters: []openapi.Parameter{tag, limit, offset},
+			Responses:  []openapi.Reply{{Status: http.StatusOK, Body: []CardResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/cards/next", Tag: "cards",
+			Summary:    "Get the next card due for review",
+			Parameters: []openapi.Parameter{deckID, order, exclude},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: CardResponse{}},
+				{Status: http.StatusNoContent, Description: "No cards are due"},
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/cards/{id}/answer", Tag: "cards",
+			Summary:   "Submit an answer for a card",
+			Request:   SubmitAnswerRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: UserCardStatsResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/cards/answers", Tag: "cards",
+			Summary:   "Submit answers for several cards",
+			Request:   SubmitAnswersRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: SubmitAnswersResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/cards/{id}", Tag: "cards",
+			Summary:    "Get a card",
+			Parameters: []openapi.Parameter{ifNoneMatch},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: CardResponse{}},
+				{Status: http.StatusNotModified, Description: "The card has not changed"},
+			},
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/cards/{id}", Tag: "cards",
+			Summary:   "Edit a card's content",
+			Request:   UpdateCardContentRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: CardResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/cards/{id}/move", Tag: "cards",
+			Summary:   "Move a card to another memo",
+			Request:   MoveCardRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: CardResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/cards/{id}/suspend", Tag: "cards",
+			Summary:   "Suspend a card from review",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: CardResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/cards/{id}/unsuspend", Tag: "cards",
+			Summary:   "Return a suspended card to review",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: CardResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/cards/{id}/bury", Tag: "cards",
+			Summary:   "Hide a card from review until tomorrow",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: CardResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/cards/{id}/reset", Tag: "cards",
+			Summary:   "Reset a card's progress so it is relearned from scratch",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: UserCardStatsResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/cards/{id}/history", Tag: "cards",
+			Summary:   "List the answers given for a card",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: []ReviewEventResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/cards/forecast", Tag: "cards",
+			Summary:    "Count the reviews due on each coming day",
+			Parameters: []openapi.Parameter{days, deckID},
+			Responses:  []openapi.Reply{{Status: http.StatusOK, Body: ReviewForecastResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/cards/duplicates", Tag: "cards",
+			Summary:   "List groups of cards with identical content",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: []DuplicateCardGroupResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/cards/duplicates/merge", Tag: "cards",
+			Summary:   "Merge duplicate cards into one",
+			Request:   MergeDuplicatesRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusNoContent}},
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/cards/{id}/deck", Tag: "cards",
+			Summary:   "Assign a card to a deck, or remove it from its deck",
+			Request:   AssignCardDeckRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: CardResponse{}}},
+		},
+
+		// Statistics and data
+		{
+			Method: http.MethodGet, Path: "/api/v1/stats/retention", Tag: "stats",
+			Summary:    "Get answer accuracy and review activity",
+			Parameters: []openapi.Parameter{days},
+			Responses:  []openapi.Reply{{Status: http.StatusOK, Body: RetentionResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/export", Tag: "data",
+			Summary:    "Export all cards with their review progress",
+			Parameters: []openapi.Parameter{format, includeArchived},
+			Responses: []openapi.Reply{{
+				Status:    http.StatusOK,
+				Body:      []ExportedCard{},
+				BodyTypes: []string{"text/csv"},
+			}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/import", Tag: "data",
+			Summary:      "Import cards from JSON or CSV",
+			Request:      []importCardRequest{},
+			RequestTypes: []string{"text/csv"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: ImportResponse{}},
+				{
+					Status:      http.StatusUnprocessableEntity,
+					Description: "Rows failed validation and nothing was imported",
+					Body:        ImportRejectedResponse{},
+				},
+			},
+		},
+
+		// Decks
+		{
+			Method: http.MethodPost, Path: "/api/v1/decks", Tag: "decks",
+			Summary:   "Create a deck",
+			Request:   DeckRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusCreated, Body: DeckResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/decks", Tag: "decks",
+			Summary:   "List decks",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: []DeckResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/decks/{id}", Tag: "decks",
+			Summary:   "Get a deck",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: DeckResponse{}}},
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/decks/{id}", Tag: "decks",
+			Summary:   "Rename a deck",
+			Request:   DeckRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: DeckResponse{}}},
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/decks/{id}/settings", Tag: "decks",
+			Summary:   "Override the review settings of a deck",
+			Request:   DeckSRSSettings{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: DeckResponse{}}},
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/decks/{id}", Tag: "decks",
+			Summary:   "Delete a deck, keeping its cards",
+			Responses: []openapi.Reply{{Status: http.StatusNoContent}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/decks/{id}/cards", Tag: "decks",
+			Summary:    "List the cards in a deck",
+			Parameters: []openapi.Parameter{limit, offset},
+			Responses:  []openapi.Reply{{Status: http.StatusOK, Body: []CardResponse{}}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/decks/{id}/cards", Tag: "decks",
+			Summary:   "Move cards into a deck",
+			Request:   MoveCardsRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: MoveCardsResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/decks/{id}/cards/next", Tag: "decks",
+			Summary: "Get the next card due for review in a deck",
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: CardResponse{}},
+				{Status: http.StatusNoContent, Description: "No cards in the deck are due"},
+			},
+		},
+
+		// Users and administration
+		{
+			Method: http.MethodGet, Path: "/api/v1/users/me", Tag: "users",
+			Summary:    "Get the current user's profile and statistics",
+			Parameters: []openapi.Parameter{ifNoneMatch},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: UserProfileResponse{}},
+				{Status: http.StatusNotModified, Description: "The profile has not changed"},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/admin/migrations", Tag: "admin",
+			Summary:   "Get the database migration status",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: MigrationStatusResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/admin/diagnostics/generation", Tag: "admin",
+			Summary:   "Check that the generation provider is reachable with the configured model",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: GenerationDiagnosticsResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/admin/users", Tag: "admin",
+			Summary:    "List users, optionally searching by email",
+			Parameters: []openapi.Parameter{email, limit, offset},
+			Responses:  []openapi.Reply{{Status: http.StatusOK, Body: []AdminUserResponse{}}},
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/admin/users/{id}", Tag: "admin",
+			Summary:   "Change a user's role or disable their account",
+			Request:   UpdateUserRequest{},
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: AdminUserResponse{}}},
+		},
+
+		// Health checks
+		{
+			Method: http.MethodGet, Path: "/healthz", Tag: "health", Public: true,
+			Summary:   "Liveness probe",
+			Responses: []openapi.Reply{{Status: http.StatusOK, Body: HealthResponse{}}},
+		},
+		{
+			Method: http.MethodGet, Path: "/readyz", Tag: "health", Public: true,
+			Summary: "Readiness probe",
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: HealthResponse{}},
+				{Status: http.StatusServiceUnavailable, Description: "The server is not ready", Body: HealthResponse{}},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/health", Tag: "health", Public: true,
+			Summary:   "Basic health check",
+			Responses: []openapi.Reply{{Status: http.StatusOK, BodyTypes: []string{"text/plain"}}},
+		},
+	}
+}
+
+// OpenAPIDocument builds the OpenAPI document of the API
+func OpenAPIDocument() (*openapi.Document, error) {
+	return openapi.Build(OpenAPIInfo, OpenAPIRoutes(), shared.ErrorResponse{})
+}
+
+// queryParam describes an optional query parameter
+func queryParam(name, description string, schema *openapi.Schema) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
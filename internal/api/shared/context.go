@@ -20,6 +20,9 @@ const (
 	// TraceIDKey is the key for the trace ID in the request context
 	TraceIDKey ContextKey = "traceID"
 
+	// RequestIDKey is the key for the request ID exposed to clients in error responses
+	RequestIDKey ContextKey = "requestID"
+
 	// TraceIDLength is the number of bytes used to generate the trace ID
 	TraceIDLength = 16 // 32 hex characters
 )
@@ -41,6 +44,22 @@ func GetTraceID(ctx context.Context) string {
 	return traceID
 }
 
+// WithRequestID adds a request ID to the context, to be reported in error
+// responses so users can quote it when asking for support.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+// GetRequestID retrieves the request ID from the context.
+// If no request ID exists, it returns an empty string.
+func GetRequestID(ctx context.Context) string {
+	requestID, ok := ctx.Value(RequestIDKey).(string)
+	if !ok {
+		return ""
+	}
+	return requestID
+}
+
 // generateTraceID creates a random trace ID for request tracking.
 // Returns a 32-character hex string (16 bytes) for optimal uniqueness.
 // If crypto/rand fails, falls back to a secure alternative based on timestamp
@@ -0,0 +1,65 @@
+package shared
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// WeakETag returns a weak entity tag for a response body. Bodies that encode
+// the same resource state, such as a card's content and updated_at, get the
+// same tag.
+func WeakETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ETagMatches reports whether the request's If-None-Match header matches
+// etag, using the weak comparison that RFC 9110 requires for If-None-Match:
+// tags match if their opaque values are equal, whether or not either is weak.
+func ETagMatches(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" || etag == "" {
+		return false
+	}
+
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// RespondWithJSONETag writes data as a 200 OK JSON response with a weak ETag,
+// or an empty 304 Not Modified response when the request's If-None-Match
+// header already names that ETag. Use it for GET endpoints that clients poll,
+// not for ones whose response changes on every call.
+func RespondWithJSONETag(w http.ResponseWriter, r *http.Request, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		slog.Error("failed to encode JSON response", "error", err)
+		RespondWithError(w, r, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	// Match the trailing newline written by RespondWithJSON
+	body = append(body, '\n')
+
+	etag := WeakETag(body)
+	w.Header().Set("ETag", etag)
+	if ETagMatches(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		slog.Error("failed to write JSON response", "error", err)
+	}
+}
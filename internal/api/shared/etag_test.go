@@ -0,0 +1,75 @@
+package shared
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeakETag(t *testing.T) {
+	etag := WeakETag([]byte(`{"id":1}`))
+
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, etag, WeakETag([]byte(`{"id":1}`)), "equal bodies share a tag")
+	assert.NotEqual(t, etag, WeakETag([]byte(`{"id":2}`)))
+}
+
+func TestETagMatches(t *testing.T) {
+	const etag = `W/"abc"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{name: "no header", ifNoneMatch: "", want: false},
+		{name: "same tag", ifNoneMatch: `W/"abc"`, want: true},
+		{name: "strong form of the tag", ifNoneMatch: `"abc"`, want: true},
+		{name: "in a list", ifNoneMatch: `"xyz", W/"abc"`, want: true},
+		{name: "wildcard", ifNoneMatch: "*", want: true},
+		{name: "different tag", ifNoneMatch: `W/"xyz"`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			assert.Equal(t, tt.want, ETagMatches(req, etag))
+		})
+	}
+}
+
+func TestRespondWithJSONETag(t *testing.T) {
+	data := map[string]string{"name": "card"}
+
+	first := httptest.NewRecorder()
+	RespondWithJSONETag(first, httptest.NewRequest(http.MethodGet, "/", nil), data)
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "application/json", first.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"name":"card"}`, first.Body.String())
+	etag := first.Header().Get("ETag")
+	assert.Equal(t, WeakETag(first.Body.Bytes()), etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", etag)
+	second := httptest.NewRecorder()
+	RespondWithJSONETag(second, req, data)
+
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Equal(t, etag, second.Header().Get("ETag"))
+	assert.Empty(t, second.Body.String())
+
+	t.Run("unencodable data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		RespondWithJSONETag(w, httptest.NewRequest(http.MethodGet, "/", nil), math.Inf(1))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+}
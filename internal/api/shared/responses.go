@@ -6,14 +6,23 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/redact"
 )
 
 // ErrorResponse defines the standard error response structure.
+// Error is a human-readable message; Code is a stable machine-readable
+// identifier that clients should switch on instead. RequestID matches the
+// X-Request-Id response header, when request IDs are exposed, so users can
+// quote it in support requests.
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Code    int    `json:"-"` // Not serialized to JSON, used for logging
-	TraceID string `json:"trace_id,omitempty"`
+	Error     string           `json:"error"`
+	Code      domain.ErrorCode `json:"code"`
+	RequestID string           `json:"request_id,omitempty"`
+	Status    int              `json:"-"` // Not serialized to JSON, used for logging
+	TraceID   string           `json:"trace_id,omitempty"`
+	// Errors lists every invalid field of a request that failed validation
+	Errors []FieldError `json:"errors,omitempty"`
 }
 
 // ResponseOption defines a function to customize response behavior.
@@ -22,6 +31,8 @@ type ResponseOption func(*responseOptions)
 // responseOptions holds configurable options for error responses.
 type responseOptions struct {
 	elevateLogLevel bool
+	errorCode       domain.ErrorCode
+	fieldErrors     []FieldError
 }
 
 // WithElevatedLogLevel returns a ResponseOption that raises 4xx errors to WARN level
@@ -33,6 +44,40 @@ func WithElevatedLogLevel() ResponseOption {
 	}
 }
 
+// WithErrorCode returns a ResponseOption that sets the machine-readable code of
+// an error response. Without it the code is derived from the status code.
+func WithErrorCode(code domain.ErrorCode) ResponseOption {
+	return func(opts *responseOptions) {
+		opts.errorCode = code
+	}
+}
+
+// WithFieldErrors returns a ResponseOption that lists the invalid fields of a
+// request in an error response.
+func WithFieldErrors(fields []FieldError) ResponseOption {
+	return func(opts *responseOptions) {
+		opts.fieldErrors = fields
+	}
+}
+
+// defaultErrorCode returns the generic error code for an HTTP status code.
+func defaultErrorCode(status int) domain.ErrorCode {
+	switch {
+	case status == http.StatusUnauthorized:
+		return domain.CodeUnauthorized
+	case status == http.StatusForbidden:
+		return domain.CodeForbidden
+	case status == http.StatusNotFound:
+		return domain.CodeNotFound
+	case status == http.StatusConflict:
+		return domain.CodeConflict
+	case status >= http.StatusInternalServerError:
+		return domain.CodeInternal
+	default:
+		return domain.CodeBadRequest
+	}
+}
+
 // RespondWithJSON writes a JSON response with the given status code and data.
 func RespondWithJSON(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -50,9 +95,11 @@ func RespondWithError(w http.ResponseWriter, r *http.Request, status int, messag
 
 	// Create the error response
 	errorResponse := ErrorResponse{
-		Error:   message,
-		Code:    status,
-		TraceID: traceID,
+		Error:     message,
+		Code:      defaultErrorCode(status),
+		RequestID: GetRequestID(r.Context()),
+		Status:    status,
+		TraceID:   traceID,
 	}
 
 	// Log the error with trace ID for correlation
@@ -89,21 +136,38 @@ func RespondWithErrorAndLog(
 	// Get trace ID from context if available
 	traceID := GetTraceID(r.Context())
 
+	// Initialize response options with defaults
+	responseOpts := responseOptions{}
+
+	// Apply any option overrides
+	for _, opt := range opts {
+		opt(&responseOpts)
+	}
+
+	if responseOpts.errorCode == "" {
+		responseOpts.errorCode = defaultErrorCode(status)
+	}
+
 	// Create the error response with only the safe message
 	// Note: We never include the raw error string in the response
 	errorResponse := ErrorResponse{
-		Error:   userMessage,
-		Code:    status,
-		TraceID: traceID,
+		Error:     userMessage,
+		Code:      responseOpts.errorCode,
+		RequestID: GetRequestID(r.Context()),
+		Status:    status,
+		TraceID:   traceID,
+		Errors:    responseOpts.fieldErrors,
 	}
 
 	// Set up common log attributes
 	logAttrs := []slog.Attr{
 		slog.String("trace_id", traceID),
+		slog.String("request_id", errorResponse.RequestID),
 		slog.String("path", r.URL.Path),
 		slog.String("method", r.Method),
 		slog.Int("status_code", status),
 		slog.String("user_message", userMessage),
+		slog.String("error_code", string(errorResponse.Code)),
 	}
 
 	// Include the redacted error details (but only in the logs)
@@ -116,14 +180,6 @@ func RespondWithErrorAndLog(
 		logAttrs = append(logAttrs, slog.String("error_type", fmt.Sprintf("%T", err)))
 	}
 
-	// Initialize response options with defaults
-	responseOpts := responseOptions{}
-
-	// Apply any option overrides
-	for _, opt := range opts {
-		opt(&responseOpts)
-	}
-
 	// Set appropriate log level based on status code and options
 	logLevel := slog.LevelDebug
 	if status >= http.StatusInternalServerError {
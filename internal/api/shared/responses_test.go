@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -178,6 +179,11 @@ func TestRespondWithError(t *testing.T) {
 
 	// Use helper to check response structure and content
 	ErrorResponseCheck(t, w.Body.Bytes(), "Invalid request", "test-trace-id")
+
+	// The code is derived from the status
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, domain.CodeBadRequest, response.Code)
 }
 
 func TestRespondWithErrorNoTraceID(t *testing.T) {
@@ -355,6 +361,40 @@ func TestRespondWithErrorAndLog(t *testing.T) {
 	}
 }
 
+func TestWithErrorCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		opts         []ResponseOption
+		expectedCode domain.ErrorCode
+	}{
+		{"explicit code", http.StatusNotFound, []ResponseOption{WithErrorCode(domain.CodeCardNotFound)}, domain.CodeCardNotFound},
+		{"last code wins", http.StatusNotFound, []ResponseOption{
+			WithErrorCode(domain.CodeNotFound), WithErrorCode(domain.CodeMemoNotFound),
+		}, domain.CodeMemoNotFound},
+		{"unauthorized default", http.StatusUnauthorized, nil, domain.CodeUnauthorized},
+		{"forbidden default", http.StatusForbidden, nil, domain.CodeForbidden},
+		{"not found default", http.StatusNotFound, nil, domain.CodeNotFound},
+		{"conflict default", http.StatusConflict, nil, domain.CodeConflict},
+		{"bad request default", http.StatusBadRequest, nil, domain.CodeBadRequest},
+		{"server error default", http.StatusServiceUnavailable, nil, domain.CodeInternal},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			w := httptest.NewRecorder()
+
+			RespondWithErrorAndLog(w, req, tc.status, "message", errors.New("detail"), tc.opts...)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, string(tc.expectedCode), response["code"])
+			assert.Equal(t, "message", response["error"], "The error text is kept for existing clients")
+		})
+	}
+}
+
 func TestWithElevatedLogLevel(t *testing.T) {
 	// Test the option function itself
 	opts := responseOptions{}
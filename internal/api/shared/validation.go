@@ -0,0 +1,119 @@
+package shared
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes one invalid field of a request. Field is the field's
+// path in the JSON request body, such as "email" or "answers[1].outcome".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors reports every invalid field of a request, so clients can
+// show them all at once rather than one per attempt. Its Error method returns
+// the underlying validator error, so it still reads like one in logs and in
+// the summary message of an error response.
+type ValidationErrors struct {
+	Fields []FieldError
+	cause  error
+}
+
+// Error implements the error interface.
+func (e *ValidationErrors) Error() string {
+	return e.cause.Error()
+}
+
+// Unwrap returns the underlying validator error.
+func (e *ValidationErrors) Unwrap() error {
+	return e.cause
+}
+
+// ValidateStruct validates v, which must be a struct or a pointer to one, like
+// Validate.Struct, but returns failed field validations as *ValidationErrors
+// listing all of them, named as they appear in JSON.
+func ValidateStruct(v interface{}) error {
+	err := Validate.Struct(v)
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+	fields := make([]FieldError, 0, len(invalid))
+	for _, fe := range invalid {
+		fields = append(fields, FieldError{
+			Field:   jsonFieldPath(t, fe),
+			Message: ValidationTagMessage(fe.Tag()),
+		})
+	}
+	return &ValidationErrors{Fields: fields, cause: err}
+}
+
+// ValidationTagMessage maps a validation tag to a user-friendly message.
+func ValidationTagMessage(tag string) string {
+	switch tag {
+	case "required":
+		return "required field"
+	case "email":
+		return "invalid email format"
+	case "min":
+		return "too short"
+	case "max":
+		return "too long"
+	case "oneof":
+		return "invalid value"
+	default:
+		return "validation failed"
+	}
+}
+
+// jsonFieldPath converts the struct namespace of a failed field, such as
+// "SubmitAnswersRequest.Answers[1].Outcome", into its path in the JSON body,
+// "answers[1].outcome". It falls back to the Go field name if the namespace
+// can't be followed through t.
+func jsonFieldPath(t reflect.Type, fe validator.FieldError) string {
+	segments := strings.Split(fe.StructNamespace(), ".")
+	path := make([]string, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		name, index, _ := strings.Cut(segment, "[")
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return fe.Field()
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return fe.Field()
+		}
+		t = field.Type
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case jsonName == "" && field.Anonymous:
+			// Embedded struct fields are promoted into the parent object
+		case jsonName == "" || jsonName == "-":
+			path = append(path, field.Name)
+		default:
+			path = append(path, jsonName)
+		}
+
+		if index != "" {
+			path[len(path)-1] += "[" + index
+			t = indirectType(t).Elem()
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// indirectType returns the type t points to, or t if it isn't a pointer.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,65 @@
+package shared
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validationTestItem struct {
+	Outcome string `json:"outcome" validate:"required,oneof=good bad"`
+}
+
+type validationTestEmbedded struct {
+	Note string `json:"note" validate:"max=3"`
+}
+
+type validationTestRequest struct {
+	validationTestEmbedded
+	Email    string                `json:"email,omitempty" validate:"required,email"`
+	Untagged string                `validate:"required"`
+	Items    []*validationTestItem `json:"items" validate:"required,min=1,dive"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		err := ValidateStruct(validationTestRequest{
+			Email:    "user@example.com",
+			Untagged: "set",
+			Items:    []*validationTestItem{{Outcome: "good"}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports every invalid field by its JSON path", func(t *testing.T) {
+		t.Parallel()
+		err := ValidateStruct(&validationTestRequest{
+			validationTestEmbedded: validationTestEmbedded{Note: "too long"},
+			Email:                  "not-an-email",
+			Items:                  []*validationTestItem{{Outcome: "good"}, {Outcome: "maybe"}},
+		})
+
+		var validationErrs *ValidationErrors
+		require.True(t, errors.As(err, &validationErrs))
+		assert.Equal(t, []FieldError{
+			{Field: "note", Message: "too long"},
+			{Field: "email", Message: "invalid email format"},
+			{Field: "Untagged", Message: "required field"},
+			{Field: "items[1].outcome", Message: "invalid value"},
+		}, validationErrs.Fields)
+		assert.Contains(t, err.Error(), "Field validation for 'Note'", "reads like the validator error")
+	})
+
+	t.Run("non-struct values are not validation errors", func(t *testing.T) {
+		t.Parallel()
+		err := ValidateStruct("not a struct")
+		require.Error(t, err)
+		var validationErrs *ValidationErrors
+		assert.False(t, errors.As(err, &validationErrs))
+	})
+}
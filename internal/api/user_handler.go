@@ -0,0 +1,182 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/service"
+)
+
+// UserStatsResponse represents aggregate review statistics for a user
+type UserStatsResponse struct {
+	TotalCards    int `json:"total_cards"`
+	DueToday      int `json:"due_today"`
+	CurrentStreak int `json:"current_streak"`
+	LongestStreak int `json:"longest_streak"`
+}
+
+// UserProfileResponse represents the response data for the authenticated user's profile
+type UserProfileResponse struct {
+	ID            string            `json:"id"`
+	Email         string            `json:"email"`
+	EmailVerified bool              `json:"email_verified"`
+	Role          string            `json:"role"`
+	Timezone      string            `json:"timezone"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Stats         UserStatsResponse `json:"stats"`
+}
+
+// DefaultForecastDays is the forecast length used when the days parameter is omitted
+const DefaultForecastDays = 14
+
+// ForecastBucketResponse represents the number of reviews due on one day
+type ForecastBucketResponse struct {
+	Date  string `json:"date"` // YYYY-MM-DD in the user's timezone
+	Count int    `json:"count"`
+}
+
+// ReviewForecastResponse represents the upcoming-reviews forecast for the authenticated user
+type ReviewForecastResponse struct {
+	Days     int                      `json:"days"`
+	Forecast []ForecastBucketResponse `json:"forecast"`
+}
+
+// UserHandler handles user-related HTTP requests
+type UserHandler struct {
+	profileService service.UserProfileService
+	logger         *slog.Logger
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(profileService service.UserProfileService, logger *slog.Logger) *UserHandler {
+	if logger == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("logger cannot be nil for UserHandler")
+	}
+
+	return &UserHandler{
+		profileService: profileService,
+		logger:         logger.With(slog.String("component", "user_handler")),
+	}
+}
+
+// GetProfile handles GET /api/users/me requests
+// It returns the authenticated user's account details and aggregate review statistics.
+func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	profile, err := h.profileService.GetProfile(r.Context(), userID)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get user profile")
+		return
+	}
+
+	shared.RespondWithJSONETag(w, r, profileToResponse(profile))
+}
+
+// profileToResponse converts a service.UserProfile to a UserProfileResponse
+func profileToResponse(profile *service.UserProfile) UserProfileResponse {
+	role := profile.User.Role
+	if role == "" {
+		role = domain.UserRoleUser
+	}
+
+	timezone := profile.User.Timezone
+	if timezone == "" {
+		timezone = domain.DefaultUserTimezone
+	}
+
+	return UserProfileResponse{
+		ID:            profile.User.ID.String(),
+		Email:         profile.User.Email,
+		EmailVerified: profile.User.EmailVerified,
+		Role:          string(role),
+		Timezone:      timezone,
+		CreatedAt:     profile.User.CreatedAt,
+		Stats: UserStatsResponse{
+			TotalCards:    profile.TotalCards,
+			DueToday:      profile.DueToday,
+			CurrentStreak: profile.Streak.Current,
+			LongestStreak: profile.Streak.Longest,
+		},
+	}
+}
+
+// GetReviewForecast handles GET /api/cards/forecast requests
+// It returns the number of reviews due on each of the next days days (default 14,
+// capped at domain.MaxForecastDays), with overdue cards counted today.
+// An optional deck_id query parameter restricts the counts to that deck.
+func (h *UserHandler) GetReviewForecast(w http.ResponseWriter, r *http.Request) {
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(r.Context(), h.logger)
+
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value(shared.UserIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		log.Warn("user ID not found or invalid in request context")
+		HandleAPIError(w, r, domain.ErrUnauthorized, "Authentication required")
+		return
+	}
+
+	days, err := parseDaysQuery(r, DefaultForecastDays, domain.MaxForecastDays)
+	if err != nil {
+		log.Warn("invalid forecast days parameter", slog.String("days", r.URL.Query().Get("days")))
+		HandleAPIError(w, r, err, "Invalid days parameter")
+		return
+	}
+
+	deckID, err := parseDeckIDQuery(r)
+	if err != nil {
+		log.Warn("invalid deck_id parameter", slog.String("deck_id", r.URL.Query().Get("deck_id")))
+		HandleAPIError(w, r, err, "Invalid deck_id parameter")
+		return
+	}
+
+	buckets, err := h.profileService.GetReviewForecast(r.Context(), userID, deckID, days)
+	if err != nil {
+		HandleAPIError(w, r, err, "Failed to get review forecast")
+		return
+	}
+
+	response := ReviewForecastResponse{
+		Days:     days,
+		Forecast: make([]ForecastBucketResponse, 0, len(buckets)),
+	}
+	for _, bucket := range buckets {
+		response.Forecast = append(response.Forecast, ForecastBucketResponse{
+			Date:  bucket.Date.Format(time.DateOnly),
+			Count: bucket.Count,
+		})
+	}
+
+	shared.RespondWithJSON(w, r, http.StatusOK, response)
+}
+
+// parseDaysQuery parses the optional days query parameter. A missing value is
+// defaultDays and a larger one is capped at maxDays.
+func parseDaysQuery(r *http.Request, defaultDays, maxDays int) (int, error) {
+	raw := r.URL.Query().Get("days")
+	if raw == "" {
+		return defaultDays, nil
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil || parsed < 1 {
+		return 0, domain.NewValidationError("days", "must be a positive integer", domain.ErrValidation)
+	}
+	return min(parsed, maxDays), nil
+}
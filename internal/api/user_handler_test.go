@@ -0,0 +1,265 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/api/shared"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUserProfileService is a mock implementation of service.UserProfileService for testing
+type MockUserProfileService struct {
+	GetProfileFn        func(ctx context.Context, userID uuid.UUID) (*service.UserProfile, error)
+	GetReviewForecastFn func(
+		ctx context.Context,
+		userID uuid.UUID,
+		deckID *uuid.UUID,
+		days int,
+	) ([]domain.ForecastBucket, error)
+}
+
+// GetProfile implements service.UserProfileService
+func (m *MockUserProfileService) GetProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*service.UserProfile, error) {
+	if m.GetProfileFn != nil {
+		return m.GetProfileFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+// GetReviewForecast implements service.UserProfileService
+func (m *MockUserProfileService) GetReviewForecast(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	days int,
+) ([]domain.ForecastBucket, error) {
+	if m.GetReviewForecastFn != nil {
+		return m.GetReviewForecastFn(ctx, userID, deckID, days)
+	}
+	return nil, nil
+}
+
+// TestUserHandler_GetProfile tests the GetProfile handler functionality.
+func TestUserHandler_GetProfile(t *testing.T) {
+	fixedUserID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	fixedTime := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		setupContext   func(context.Context) context.Context
+		profileFn      func(ctx context.Context, userID uuid.UUID) (*service.UserProfile, error)
+		expectedStatus int
+		expectedErrMsg string
+	}{
+		{
+			name: "successful_profile_retrieval",
+			setupContext: func(ctx context.Context) context.Context {
+				return context.WithValue(ctx, shared.UserIDContextKey, fixedUserID)
+			},
+			profileFn: func(ctx context.Context, userID uuid.UUID) (*service.UserProfile, error) {
+				return &service.UserProfile{
+					User: &domain.User{
+						ID:        userID,
+						Email:     "profile@example.com",
+						Role:      domain.UserRoleUser,
+						Timezone:  "Asia/Tokyo",
+						CreatedAt: fixedTime,
+					},
+					TotalCards: 12,
+					DueToday:   4,
+					Streak:     domain.ReviewStreak{Current: 3, Longest: 7},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "missing_user_id",
+			setupContext: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedErrMsg: "Unauthorized operation",
+		},
+		{
+			name: "service_error",
+			setupContext: func(ctx context.Context) context.Context {
+				return context.WithValue(ctx, shared.UserIDContextKey, fixedUserID)
+			},
+			profileFn: func(ctx context.Context, userID uuid.UUID) (*service.UserProfile, error) {
+				return nil, errors.New("database unavailable")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewUserHandler(
+				&MockUserProfileService{GetProfileFn: tc.profileFn},
+				slog.Default(),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+			req = req.WithContext(tc.setupContext(req.Context()))
+			w := httptest.NewRecorder()
+
+			handler.GetProfile(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			if tc.expectedStatus != http.StatusOK {
+				var errResp shared.ErrorResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&errResp))
+				if tc.expectedErrMsg != "" {
+					assert.Equal(t, tc.expectedErrMsg, errResp.Error)
+				}
+				return
+			}
+
+			var resp UserProfileResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, fixedUserID.String(), resp.ID)
+			assert.Equal(t, "profile@example.com", resp.Email)
+			assert.Equal(t, "user", resp.Role)
+			assert.Equal(t, "Asia/Tokyo", resp.Timezone)
+			assert.True(t, fixedTime.Equal(resp.CreatedAt))
+			assert.Equal(t, UserStatsResponse{
+				TotalCards:    12,
+				DueToday:      4,
+				CurrentStreak: 3,
+				LongestStreak: 7,
+			}, resp.Stats)
+		})
+	}
+}
+
+// TestUserHandler_GetProfile_ETag tests that an unchanged profile is answered
+// with 304 Not Modified on a conditional request.
+func TestUserHandler_GetProfile_ETag(t *testing.T) {
+	userID := uuid.New()
+	dueToday := 4
+	handler := NewUserHandler(&MockUserProfileService{
+		GetProfileFn: func(ctx context.Context, id uuid.UUID) (*service.UserProfile, error) {
+			return &service.UserProfile{
+				User:       &domain.User{ID: id, Email: "etag@example.com"},
+				TotalCards: 12,
+				DueToday:   dueToday,
+			}, nil
+		},
+	}, slog.Default())
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+		req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handler.GetProfile(w, req)
+		return w
+	}
+
+	first := get("")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	notModified := get(etag)
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Equal(t, etag, notModified.Header().Get("ETag"))
+	assert.Empty(t, notModified.Body.String())
+
+	// Reviewing cards changes the statistics and so the ETag
+	dueToday = 3
+	changed := get(etag)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+}
+
+// TestUserHandler_GetReviewForecast tests the days and deck_id parameter handling and response shape.
+func TestUserHandler_GetReviewForecast(t *testing.T) {
+	userID := uuid.New()
+	deckID := uuid.New()
+	day := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedDays   int
+		expectedDeckID *uuid.UUID
+	}{
+		{name: "default_days", query: "", expectedStatus: http.StatusOK, expectedDays: 14},
+		{name: "explicit_days", query: "?days=7", expectedStatus: http.StatusOK, expectedDays: 7},
+		{name: "capped_days", query: "?days=1000", expectedStatus: http.StatusOK, expectedDays: 365},
+		{name: "zero_days", query: "?days=0", expectedStatus: http.StatusBadRequest},
+		{name: "non_numeric_days", query: "?days=abc", expectedStatus: http.StatusBadRequest},
+		{
+			name:           "deck_scoped",
+			query:          "?days=7&deck_id=" + deckID.String(),
+			expectedStatus: http.StatusOK,
+			expectedDays:   7,
+			expectedDeckID: &deckID,
+		},
+		{name: "invalid_deck_id", query: "?deck_id=not-a-uuid", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requestedDays int
+			var requestedDeckID *uuid.UUID
+			handler := NewUserHandler(&MockUserProfileService{
+				GetReviewForecastFn: func(
+					ctx context.Context,
+					id uuid.UUID,
+					deckID *uuid.UUID,
+					days int,
+				) ([]domain.ForecastBucket, error) {
+					requestedDays = days
+					requestedDeckID = deckID
+					assert.Equal(t, userID, id)
+					return []domain.ForecastBucket{
+						{Date: day, Count: 3},
+						{Date: day.AddDate(0, 0, 1), Count: 0},
+					}, nil
+				},
+			}, slog.Default())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/cards/forecast"+tc.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), shared.UserIDContextKey, userID))
+			w := httptest.NewRecorder()
+
+			handler.GetReviewForecast(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus != http.StatusOK {
+				assert.Zero(t, requestedDays, "Service should not be called for invalid input")
+				return
+			}
+
+			assert.Equal(t, tc.expectedDays, requestedDays)
+			assert.Equal(t, tc.expectedDeckID, requestedDeckID)
+			var resp ReviewForecastResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tc.expectedDays, resp.Days)
+			assert.Equal(t, []ForecastBucketResponse{
+				{Date: "2025-04-01", Count: 3},
+				{Date: "2025-04-02", Count: 0},
+			}, resp.Forecast)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -42,10 +44,35 @@ func Load() (*Config, error) {
 	// These defaults are used if the setting is not found in any other source
 	v.SetDefault("server.port", 8080)
 	v.SetDefault("server.log_level", "info")
+	v.SetDefault("server.response_cache_ttl_seconds", 0) // Default: response caching disabled
+	v.SetDefault("server.response_cache_max_entries", 10000)
+	v.SetDefault("server.max_request_body_bytes", 1<<20) // Default: 1 MiB
+	v.SetDefault("server.expose_generation_timing", false)
+	v.SetDefault("server.idempotency_key_ttl_minutes", 1440) // Default: 24 hours
+	v.SetDefault("server.create_default_decks", false)       // Default: users start without decks
+	v.SetDefault("server.memo_min_text_length", 1)
+	v.SetDefault("server.memo_max_text_length", 10000)
+	v.SetDefault("server.card_content_max_bytes", 65536)
+	v.SetDefault("server.legacy_api_sunset", "") // Default: no sunset announced
+	v.SetDefault("server.request_timeout_seconds", 30)
+	v.SetDefault("server.generation_request_timeout_seconds", 120)
+	v.SetDefault("server.expose_request_ids", true)
+	v.SetDefault("store.backend", StoreBackendPostgres)
+	v.SetDefault("database.read_max_retries", 0) // Default: store read retries disabled
+	v.SetDefault("database.read_retry_delay_ms", 50)
+	v.SetDefault("database.max_open_conns", 10)
+	v.SetDefault("database.max_idle_conns", 5)
+	v.SetDefault("database.conn_max_lifetime_minutes", 5)
+	v.SetDefault("database.conn_max_idle_time_minutes", 0) // Default: idle connections are kept
+	v.SetDefault("database.slow_query_ms", 0)              // Default: slow query logging disabled
 	v.SetDefault(
 		"auth.bcrypt_cost",
 		10,
 	) // Default bcrypt cost (same as bcrypt.DefaultCost)
+	v.SetDefault("auth.password_scheme", "bcrypt")
+	v.SetDefault("auth.password_min_length", 12)
+	v.SetDefault("auth.password_require_mixed_classes", false)
+	v.SetDefault("auth.password_min_strength", 0)
 	v.SetDefault(
 		"auth.token_lifetime_minutes",
 		60,
@@ -54,18 +81,81 @@ func Load() (*Config, error) {
 		"auth.refresh_token_lifetime_minutes",
 		10080,
 	) // Default refresh token lifetime (7 days)
+	v.SetDefault("auth.email_verification_token_lifetime_minutes", 1440) // Default: 24 hours
+	v.SetDefault("auth.password_reset_token_lifetime_minutes", 60)       // Default: 1 hour
+	v.SetDefault("auth.require_email_verification", false)
+	v.SetDefault("auth.strict_token_lifetimes", false)
+	v.SetDefault("auth.clock_skew_seconds", 120) // Default: 2 minutes of clock skew tolerance
+	v.SetDefault("auth.login_max_failures", 5)
+	v.SetDefault("auth.login_failure_window_minutes", 15)
+	v.SetDefault("auth.login_lockout_minutes", 15)
 	v.SetDefault("llm.model_name", "gemini-2.0-flash") // Default Gemini model
 	v.SetDefault(
 		"llm.max_retries",
 		3,
 	) // Default number of retries for transient errors
 	v.SetDefault("llm.retry_delay_seconds", 2) // Default base delay between retries
-	v.SetDefault("task.worker_count", 2)       // Default worker count
-	v.SetDefault("task.queue_size", 100)       // Default queue size
+	v.SetDefault("llm.auto_tag_topics", false) // Default: no automatic topic tags
+	v.SetDefault("llm.breaker_failure_threshold", 5)
+	v.SetDefault("llm.breaker_cooldown_seconds", 30)
+	v.SetDefault("llm.generation_min_cards", 1)
+	v.SetDefault("llm.generation_max_cards", 50)
+	v.SetDefault("llm.deduplicate_cards", true)
+	v.SetDefault("llm.monthly_generation_quota", 100)
+	v.SetDefault("llm.readiness_check", false)
+	v.SetDefault("llm.max_output_tokens", 0)
+	v.SetDefault("llm.preview_timeout_seconds", 20)
+	v.SetDefault("task.worker_count", 2) // Default worker count
+	v.SetDefault("task.queue_size", 100) // Default queue size
 	v.SetDefault(
 		"task.stuck_task_age_minutes",
 		30,
 	) // Default stuck task age (30 minutes)
+	v.SetDefault(
+		"task.stuck_task_check_interval_seconds",
+		300,
+	) // Default stuck task check interval (5 minutes)
+	v.SetDefault(
+		"task.heartbeat_interval_seconds",
+		60,
+	) // Default task heartbeat interval (1 minute)
+	v.SetDefault("task.enforce_memo_status_transitions", true) // Default: reject illegal memo status changes
+	v.SetDefault("webhooks.url", "")                           // Default: webhooks disabled
+	v.SetDefault("webhooks.secret", "")
+	v.SetDefault("webhooks.max_retries", 3)
+	v.SetDefault("webhooks.retry_delay_ms", 500)
+	v.SetDefault("webhooks.timeout_seconds", 10)
+	v.SetDefault("webhooks.queue_size", 100)
+	v.SetDefault("email.smtp_host", "") // Default: email disabled
+	v.SetDefault("email.smtp_port", 587)
+	v.SetDefault("email.username", "")
+	v.SetDefault("email.password", "")
+	v.SetDefault("email.from", "")
+	v.SetDefault("email.app_url", "")
+	v.SetDefault("email.timeout_seconds", 10)
+	v.SetDefault("email.queue_size", 100)
+	v.SetDefault("tracing.enabled", false) // Default: tracing disabled
+	v.SetDefault("tracing.endpoint", "")
+	v.SetDefault("tracing.service_name", "scry-api")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+	v.SetDefault("rate_limit.auth.requests_per_minute", 20)
+	v.SetDefault("rate_limit.auth.burst", 10)
+	v.SetDefault("rate_limit.generation.requests_per_minute", 10)
+	v.SetDefault("rate_limit.generation.burst", 5)
+	v.SetDefault("rate_limit.api.requests_per_minute", 0) // Default: general API not limited
+	v.SetDefault("rate_limit.api.burst", 0)
+	v.SetDefault("cors.allowed_origins", []string{}) // Default: no cross-origin requests
+	v.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "DELETE"})
+	v.SetDefault("cors.allowed_headers", []string{"Authorization", "Content-Type", "Idempotency-Key"})
+	v.SetDefault("cors.allow_credentials", false)
+	v.SetDefault("cors.max_age_seconds", 600)
+	v.SetDefault("import.max_body_bytes", 10<<20) // Default: 10 MiB
+	v.SetDefault("import.max_cards", 1000)
+	v.SetDefault("import.max_failure_fraction", 0.1)
+	v.SetDefault("srs.leech_threshold", 8)
+	v.SetDefault("srs.leech_suspend", false)
+	v.SetDefault("srs.learning_steps", []int{}) // Default: cards graduate on their first review
+	v.SetDefault("analytics.hard_is_correct", true)
 
 	// --- Configure config file (optional, for local dev) ---
 	// Looks for config.yaml in the working directory
@@ -100,21 +190,106 @@ func Load() (*Config, error) {
 		key    string // config key in dot notation (e.g., "server.port")
 		envVar string // environment variable name (e.g., "SCRY_SERVER_PORT")
 	}{
+		{"store.backend", "SCRY_STORE_BACKEND"},
 		{"database.url", "SCRY_DATABASE_URL"},
+		{"database.replica_urls", "SCRY_DATABASE_REPLICA_URLS"},
+		{"database.read_max_retries", "SCRY_DATABASE_READ_MAX_RETRIES"},
+		{"database.read_retry_delay_ms", "SCRY_DATABASE_READ_RETRY_DELAY_MS"},
+		{"database.max_open_conns", "SCRY_DATABASE_MAX_OPEN_CONNS"},
+		{"database.max_idle_conns", "SCRY_DATABASE_MAX_IDLE_CONNS"},
+		{"database.conn_max_lifetime_minutes", "SCRY_DATABASE_CONN_MAX_LIFETIME_MINUTES"},
+		{"database.conn_max_idle_time_minutes", "SCRY_DATABASE_CONN_MAX_IDLE_TIME_MINUTES"},
+		{"database.slow_query_ms", "SCRY_SLOW_QUERY_MS"},
 		{"auth.jwt_secret", "SCRY_AUTH_JWT_SECRET"},
 		{"auth.bcrypt_cost", "SCRY_AUTH_BCRYPT_COST"},
+		{"auth.password_scheme", "SCRY_AUTH_PASSWORD_SCHEME"},
+		{"auth.password_min_length", "SCRY_AUTH_PASSWORD_MIN_LENGTH"},
+		{"auth.password_require_mixed_classes", "SCRY_AUTH_PASSWORD_REQUIRE_MIXED_CLASSES"},
+		{"auth.password_min_strength", "SCRY_AUTH_PASSWORD_MIN_STRENGTH"},
 		{"auth.token_lifetime_minutes", "SCRY_AUTH_TOKEN_LIFETIME_MINUTES"},
 		{"auth.refresh_token_lifetime_minutes", "SCRY_AUTH_REFRESH_TOKEN_LIFETIME_MINUTES"},
+		{"auth.strict_token_lifetimes", "SCRY_AUTH_STRICT_TOKEN_LIFETIMES"},
+		{"auth.email_verification_token_lifetime_minutes", "SCRY_AUTH_EMAIL_VERIFICATION_TOKEN_LIFETIME_MINUTES"},
+		{"auth.password_reset_token_lifetime_minutes", "SCRY_AUTH_PASSWORD_RESET_TOKEN_LIFETIME_MINUTES"},
+		{"auth.require_email_verification", "SCRY_AUTH_REQUIRE_EMAIL_VERIFICATION"},
+		{"auth.clock_skew_seconds", "SCRY_AUTH_CLOCK_SKEW_SECONDS"},
+		{"auth.login_max_failures", "SCRY_AUTH_LOGIN_MAX_FAILURES"},
+		{"auth.login_failure_window_minutes", "SCRY_AUTH_LOGIN_FAILURE_WINDOW_MINUTES"},
+		{"auth.login_lockout_minutes", "SCRY_AUTH_LOGIN_LOCKOUT_MINUTES"},
 		{"llm.gemini_api_key", "SCRY_LLM_GEMINI_API_KEY"},
 		{"llm.model_name", "SCRY_LLM_MODEL_NAME"},
+		{"llm.temperature", "SCRY_LLM_TEMPERATURE"},
+		{"llm.max_output_tokens", "SCRY_LLM_MAX_OUTPUT_TOKENS"},
 		{"llm.prompt_template_path", "SCRY_LLM_PROMPT_TEMPLATE_PATH"},
+		{"llm.card_schema_path", "SCRY_LLM_CARD_SCHEMA_PATH"},
 		{"llm.max_retries", "SCRY_LLM_MAX_RETRIES"},
 		{"llm.retry_delay_seconds", "SCRY_LLM_RETRY_DELAY_SECONDS"},
+		{"llm.auto_tag_topics", "SCRY_LLM_AUTO_TAG_TOPICS"},
+		{"llm.breaker_failure_threshold", "SCRY_LLM_BREAKER_FAILURE_THRESHOLD"},
+		{"llm.breaker_cooldown_seconds", "SCRY_LLM_BREAKER_COOLDOWN_SECONDS"},
+		{"llm.generation_min_cards", "SCRY_LLM_GENERATION_MIN_CARDS"},
+		{"llm.generation_max_cards", "SCRY_LLM_GENERATION_MAX_CARDS"},
+		{"llm.deduplicate_cards", "SCRY_LLM_DEDUPLICATE_CARDS"},
+		{"llm.monthly_generation_quota", "SCRY_LLM_MONTHLY_GENERATION_QUOTA"},
+		{"llm.readiness_check", "SCRY_LLM_READINESS_CHECK"},
+		{"llm.preview_timeout_seconds", "SCRY_LLM_PREVIEW_TIMEOUT_SECONDS"},
 		{"server.port", "SCRY_SERVER_PORT"},
 		{"server.log_level", "SCRY_SERVER_LOG_LEVEL"},
+		{"server.response_cache_ttl_seconds", "SCRY_SERVER_RESPONSE_CACHE_TTL_SECONDS"},
+		{"server.response_cache_max_entries", "SCRY_SERVER_RESPONSE_CACHE_MAX_ENTRIES"},
+		{"server.max_request_body_bytes", "SCRY_SERVER_MAX_REQUEST_BODY_BYTES"},
+		{"server.expose_generation_timing", "SCRY_SERVER_EXPOSE_GENERATION_TIMING"},
+		{"server.idempotency_key_ttl_minutes", "SCRY_SERVER_IDEMPOTENCY_KEY_TTL_MINUTES"},
+		{"server.create_default_decks", "SCRY_SERVER_CREATE_DEFAULT_DECKS"},
+		{"server.memo_min_text_length", "SCRY_SERVER_MEMO_MIN_TEXT_LENGTH"},
+		{"server.memo_max_text_length", "SCRY_SERVER_MEMO_MAX_TEXT_LENGTH"},
+		{"server.card_content_max_bytes", "SCRY_SERVER_CARD_CONTENT_MAX_BYTES"},
+		{"server.legacy_api_sunset", "SCRY_SERVER_LEGACY_API_SUNSET"},
+		{"server.request_timeout_seconds", "SCRY_SERVER_REQUEST_TIMEOUT_SECONDS"},
+		{"server.generation_request_timeout_seconds", "SCRY_SERVER_GENERATION_REQUEST_TIMEOUT_SECONDS"},
+		{"server.expose_request_ids", "SCRY_SERVER_EXPOSE_REQUEST_IDS"},
 		{"task.worker_count", "SCRY_TASK_WORKER_COUNT"},
 		{"task.queue_size", "SCRY_TASK_QUEUE_SIZE"},
 		{"task.stuck_task_age_minutes", "SCRY_TASK_STUCK_TASK_AGE_MINUTES"},
+		{"task.stuck_task_check_interval_seconds", "SCRY_TASK_STUCK_TASK_CHECK_INTERVAL_SECONDS"},
+		{"task.heartbeat_interval_seconds", "SCRY_TASK_HEARTBEAT_INTERVAL_SECONDS"},
+		{"task.enforce_memo_status_transitions", "SCRY_TASK_ENFORCE_MEMO_STATUS_TRANSITIONS"},
+		{"webhooks.url", "SCRY_WEBHOOKS_URL"},
+		{"webhooks.secret", "SCRY_WEBHOOKS_SECRET"},
+		{"webhooks.max_retries", "SCRY_WEBHOOKS_MAX_RETRIES"},
+		{"webhooks.retry_delay_ms", "SCRY_WEBHOOKS_RETRY_DELAY_MS"},
+		{"webhooks.timeout_seconds", "SCRY_WEBHOOKS_TIMEOUT_SECONDS"},
+		{"webhooks.queue_size", "SCRY_WEBHOOKS_QUEUE_SIZE"},
+		{"email.smtp_host", "SCRY_EMAIL_SMTP_HOST"},
+		{"email.smtp_port", "SCRY_EMAIL_SMTP_PORT"},
+		{"email.username", "SCRY_EMAIL_USERNAME"},
+		{"email.password", "SCRY_EMAIL_PASSWORD"},
+		{"email.from", "SCRY_EMAIL_FROM"},
+		{"email.app_url", "SCRY_EMAIL_APP_URL"},
+		{"email.timeout_seconds", "SCRY_EMAIL_TIMEOUT_SECONDS"},
+		{"email.queue_size", "SCRY_EMAIL_QUEUE_SIZE"},
+		{"tracing.enabled", "SCRY_TRACING_ENABLED"},
+		{"tracing.endpoint", "SCRY_TRACING_ENDPOINT"},
+		{"tracing.service_name", "SCRY_TRACING_SERVICE_NAME"},
+		{"tracing.sample_ratio", "SCRY_TRACING_SAMPLE_RATIO"},
+		{"rate_limit.auth.requests_per_minute", "SCRY_RATE_LIMIT_AUTH_REQUESTS_PER_MINUTE"},
+		{"rate_limit.auth.burst", "SCRY_RATE_LIMIT_AUTH_BURST"},
+		{"rate_limit.generation.requests_per_minute", "SCRY_RATE_LIMIT_GENERATION_REQUESTS_PER_MINUTE"},
+		{"rate_limit.generation.burst", "SCRY_RATE_LIMIT_GENERATION_BURST"},
+		{"rate_limit.api.requests_per_minute", "SCRY_RATE_LIMIT_API_REQUESTS_PER_MINUTE"},
+		{"rate_limit.api.burst", "SCRY_RATE_LIMIT_API_BURST"},
+		{"cors.allowed_origins", "SCRY_CORS_ALLOWED_ORIGINS"},
+		{"cors.allowed_methods", "SCRY_CORS_ALLOWED_METHODS"},
+		{"cors.allowed_headers", "SCRY_CORS_ALLOWED_HEADERS"},
+		{"cors.allow_credentials", "SCRY_CORS_ALLOW_CREDENTIALS"},
+		{"cors.max_age_seconds", "SCRY_CORS_MAX_AGE_SECONDS"},
+		{"import.max_body_bytes", "SCRY_IMPORT_MAX_BODY_BYTES"},
+		{"import.max_cards", "SCRY_IMPORT_MAX_CARDS"},
+		{"import.max_failure_fraction", "SCRY_IMPORT_MAX_FAILURE_FRACTION"},
+		{"srs.leech_threshold", "SCRY_SRS_LEECH_THRESHOLD"},
+		{"srs.leech_suspend", "SCRY_SRS_LEECH_SUSPEND"},
+		{"srs.learning_steps", "SCRY_SRS_LEARNING_STEPS"},
+		{"analytics.hard_is_correct", "SCRY_ANALYTICS_HARD_IS_CORRECT"},
 	}
 
 	for _, env := range bindEnvs {
@@ -132,9 +307,64 @@ func Load() (*Config, error) {
 
 	// --- Validate the configuration values against defined rules ---
 	validate := validator.New()
+	if err := validate.RegisterValidation("cors_origin", validateCORSOrigin); err != nil {
+		return nil, fmt.Errorf("failed to register origin validation: %w", err)
+	}
+	validate.RegisterStructValidation(validateCORSConfig, CORSConfig{})
+	validate.RegisterStructValidation(validateStoreConfig, Config{})
+	validate.RegisterStructValidation(validateTaskConfig, TaskConfig{})
 	if err := validate.Struct(&cfg); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
 	return &cfg, nil
 }
+
+// validateCORSOrigin checks that a CORS origin is "*" or an http(s) origin
+// with no path, query or credentials, as browsers send it in the Origin header.
+func validateCORSOrigin(fl validator.FieldLevel) bool {
+	origin := fl.Field().String()
+	if origin == "*" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") &&
+		u.Host != "" &&
+		u.User == nil &&
+		u.Path == "" &&
+		u.RawQuery == "" &&
+		u.Fragment == "" &&
+		!strings.HasSuffix(origin, "?") &&
+		!strings.HasSuffix(origin, "#")
+}
+
+// validateStoreConfig requires a database URL unless data is kept in memory.
+func validateStoreConfig(sl validator.StructLevel) {
+	cfg := sl.Current().Interface().(Config)
+	if cfg.Store.Backend != StoreBackendMemory && cfg.Database.URL == "" {
+		sl.ReportError(cfg.Database.URL, "Database.URL", "URL", "required", "")
+	}
+}
+
+// validateTaskConfig requires heartbeats more often than tasks are considered
+// stuck, so that running tasks are not reset.
+func validateTaskConfig(sl validator.StructLevel) {
+	task := sl.Current().Interface().(TaskConfig)
+	if task.HeartbeatIntervalSeconds >= task.StuckTaskAgeMinutes*60 {
+		sl.ReportError(task.HeartbeatIntervalSeconds, "HeartbeatIntervalSeconds", "HeartbeatIntervalSeconds",
+			"lt_stuck_task_age", "")
+	}
+}
+
+// validateCORSConfig rejects the "*" origin together with credentials, which
+// browsers refuse.
+func validateCORSConfig(sl validator.StructLevel) {
+	cors := sl.Current().Interface().(CORSConfig)
+	if cors.AllowCredentials && slices.Contains(cors.AllowedOrigins, "*") {
+		sl.ReportError(cors.AllowedOrigins, "AllowedOrigins", "AllowedOrigins", "no_wildcard_with_credentials", "")
+	}
+}
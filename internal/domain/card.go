@@ -1,9 +1,14 @@
 package domain
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
@@ -24,8 +29,25 @@ var (
 
 	// ErrCardContentInvalid is returned when a card's content is not valid JSON.
 	ErrCardContentInvalid = errors.New("card content must be valid JSON")
+
+	// ErrCardContentTooLarge is wrapped by the ValidationError returned when a
+	// card's serialized content is longer than the configured limit.
+	ErrCardContentTooLarge = errors.New("card content is too large")
+
+	// ErrInvalidTag is returned when a tag is empty, too long, or contains disallowed characters.
+	ErrInvalidTag = errors.New("invalid tag")
 )
 
+// InitialCardVersion is the version assigned to a newly created card.
+// The version is incremented on every content edit for optimistic concurrency control.
+const InitialCardVersion = 1
+
+// MaxTagLength is the maximum number of characters in a normalized card tag.
+const MaxTagLength = 32
+
+// LeechTag is the tag added to a card that the user keeps forgetting.
+const LeechTag = "leech"
+
 // Card represents a flashcard generated from a user's memo.
 // The content is stored as a JSONB structure, allowing for flexible
 // card formats and future extensibility.
@@ -33,33 +55,87 @@ type Card struct {
 	ID        uuid.UUID       `json:"id"`
 	UserID    uuid.UUID       `json:"user_id"`
 	MemoID    uuid.UUID       `json:"memo_id"`
+	DeckID    *uuid.UUID      `json:"deck_id,omitempty"` // Deck the card belongs to; nil if none
+	Type      CardType        `json:"type"`              // How the content is presented; empty means basic
 	Content   json.RawMessage `json:"content"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
+	Version   int             `json:"version"`
+
+	// SupersededAt is when the card was replaced by regenerating its memo; nil for
+	// active cards. Superseded cards keep their review history but are never due.
+	SupersededAt *time.Time `json:"superseded_at,omitempty"`
+
+	// SuspendedAt is when the user paused the card; nil if it is not suspended.
+	// Suspended cards keep their statistics but are never due until unsuspended.
+	SuspendedAt *time.Time `json:"suspended_at,omitempty"`
+
+	// BuriedUntil hides the card from review until this instant without changing
+	// its schedule; nil or a past time if it is not buried.
+	BuriedUntil *time.Time `json:"buried_until,omitempty"`
+}
+
+// IsSuperseded reports whether the card was replaced by regenerating its memo.
+func (c *Card) IsSuperseded() bool {
+	return c.SupersededAt != nil
+}
+
+// IsSuspended reports whether the user has paused the card.
+func (c *Card) IsSuspended() bool {
+	return c.SuspendedAt != nil
+}
+
+// IsBuried reports whether the card is hidden from review at now.
+func (c *Card) IsBuried(now time.Time) bool {
+	return c.BuriedUntil != nil && now.Before(*c.BuriedUntil)
 }
 
 // CardContent represents the structure of the content field in a Card.
 // This is provided as a sample structure but cards can have flexible content
 // as it's stored as a JSONB field.
 type CardContent struct {
-	Front    string   `json:"front"`
-	Back     string   `json:"back"`
+	Front    string   `json:"front,omitempty"`
+	Back     string   `json:"back,omitempty"` // For cloze cards, optional extra information
+	Text     string   `json:"text,omitempty"` // Cloze cards only: text with {{cN::...}} markers
 	Hint     string   `json:"hint,omitempty"`
 	Tags     []string `json:"tags,omitempty"`
 	ImageURL string   `json:"image_url,omitempty"`
 }
 
+// DuplicateCardGroup is a set of a user's cards that share identical content.
+// ContentHash identifies the shared content; Cards are ordered oldest first.
+type DuplicateCardGroup struct {
+	ContentHash string  `json:"content_hash"`
+	Cards       []*Card `json:"cards"`
+}
+
+// CardWithStats pairs a card with its owner's review statistics.
+// Stats is nil if no statistics have been recorded for the card.
+type CardWithStats struct {
+	Card  *Card          `json:"card"`
+	Stats *UserCardStats `json:"stats,omitempty"`
+}
+
 // NewCard creates a new Card with the given user ID, memo ID, and content.
 // It generates a new UUID for the card ID and sets the creation/update timestamps.
 // Returns an error if validation fails.
 func NewCard(userID, memoID uuid.UUID, content json.RawMessage) (*Card, error) {
+	return NewCardOfType(userID, memoID, CardTypeBasic, content)
+}
+
+// NewCardOfType creates a new Card of the given type, like NewCard.
+// Returns an error if validation fails, including when cloze content has no
+// valid cloze deletions.
+func NewCardOfType(userID, memoID uuid.UUID, cardType CardType, content json.RawMessage) (*Card, error) {
 	card := &Card{
 		ID:        uuid.New(),
 		UserID:    userID,
 		MemoID:    memoID,
+		Type:      cardType,
 		Content:   content,
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
+		Version:   InitialCardVersion,
 	}
 
 	if err := card.Validate(); err != nil {
@@ -88,12 +164,44 @@ func (c *Card) Validate() error {
 		return ErrCardContentEmpty
 	}
 
+	// Check the size before parsing, so oversized content is not decoded
+	if err := ValidateCardContentSize(c.Content); err != nil {
+		return err
+	}
+
 	// Check if content is valid JSON
 	var js json.RawMessage
 	if err := json.Unmarshal(c.Content, &js); err != nil {
 		return ErrCardContentInvalid
 	}
 
+	switch c.Type.OrDefault() {
+	case CardTypeBasic:
+		// Check the content structure with the configured CardContentValidator
+		return ValidateCardContent(c.Content)
+	case CardTypeCloze:
+		return validateClozeContent(c.Content)
+	default:
+		return NewValidationError("type", fmt.Sprintf("unknown card type %q", c.Type), ErrInvalidCardType)
+	}
+}
+
+// validateClozeContent requires cloze card content to be an object whose text
+// field contains well-formed cloze markup. The configured CardContentValidator
+// describes basic cards and is not applied.
+func validateClozeContent(content json.RawMessage) error {
+	var cloze struct {
+		Text *string `json:"text"`
+	}
+	if err := json.Unmarshal(content, &cloze); err != nil {
+		return NewValidationError("content", "must be a JSON object", ErrInvalidCardContent)
+	}
+	if cloze.Text == nil || strings.TrimSpace(*cloze.Text) == "" {
+		return NewValidationError("content.text", "is required", ErrInvalidCardContent)
+	}
+	if _, err := ParseCloze(*cloze.Text); err != nil {
+		return NewValidationError("content.text", err.Error(), errors.Join(ErrInvalidCardContent, err))
+	}
 	return nil
 }
 
@@ -115,3 +223,116 @@ func (c *Card) UpdateContent(content json.RawMessage) error {
 	c.UpdatedAt = time.Now().UTC()
 	return nil
 }
+
+// HashCardFront returns the SHA-256 hash of a card's front text after
+// lower-casing it and collapsing each run of whitespace into a single space,
+// so fronts that differ only in case or spacing hash equally.
+func HashCardFront(front string) [32]byte {
+	return sha256.Sum256([]byte(strings.ToLower(strings.Join(strings.Fields(front), " "))))
+}
+
+// FrontHash returns HashCardFront of the text the card asks: its front, or
+// the text of a cloze card. It returns false if the content has neither.
+func (c *Card) FrontHash() ([32]byte, bool) {
+	return FrontHashOf(c.Content)
+}
+
+// FrontHashOf returns the hash Card.FrontHash would return for a card with content.
+func FrontHashOf(content json.RawMessage) ([32]byte, bool) {
+	var fields struct {
+		Front string `json:"front"`
+		Text  string `json:"text"`
+	}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return [32]byte{}, false
+	}
+	front := fields.Front
+	if strings.TrimSpace(front) == "" {
+		front = fields.Text
+	}
+	if strings.TrimSpace(front) == "" {
+		return [32]byte{}, false
+	}
+	return HashCardFront(front), true
+}
+
+// NormalizeTag converts a free-form label into a card tag.
+// The label is trimmed and lower-cased, and runs of whitespace, underscores and
+// hyphens are collapsed into a single hyphen, so "Cell  Biology" becomes "cell-biology".
+// Returns ErrInvalidTag if the result is empty, longer than MaxTagLength characters,
+// or contains anything other than letters, digits and hyphens.
+func NormalizeTag(tag string) (string, error) {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(strings.TrimSpace(tag)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r), r == '_', r == '-':
+			pendingHyphen = true
+		default:
+			return "", ErrInvalidTag
+		}
+	}
+
+	normalized := b.String()
+	if normalized == "" || len([]rune(normalized)) > MaxTagLength {
+		return "", ErrInvalidTag
+	}
+	return normalized, nil
+}
+
+// HasTag reports whether the card's content lists tag among its tags.
+// Content that is not a JSON object, or has no valid tags array, has no tags.
+func (c *Card) HasTag(tag string) bool {
+	var content struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal(c.Content, &content); err != nil {
+		return false
+	}
+	return slices.Contains(content.Tags, tag)
+}
+
+// AddTag adds tag to the tags in the card's content, keeping every other
+// content field as it is, and updates the UpdatedAt timestamp. It reports
+// whether the content changed: adding a tag the card already has is a no-op.
+// Returns ErrInvalidTag if tag is not normalized, and ErrCardContentInvalid if
+// the content is not a JSON object or its tags are not a list of strings.
+func (c *Card) AddTag(tag string) (bool, error) {
+	if normalized, err := NormalizeTag(tag); err != nil || normalized != tag {
+		return false, ErrInvalidTag
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(c.Content, &fields); err != nil || fields == nil {
+		return false, ErrCardContentInvalid
+	}
+	var tags []string
+	if raw, ok := fields["tags"]; ok {
+		if err := json.Unmarshal(raw, &tags); err != nil {
+			return false, ErrCardContentInvalid
+		}
+	}
+	if slices.Contains(tags, tag) {
+		return false, nil
+	}
+
+	rawTags, err := json.Marshal(append(tags, tag))
+	if err != nil {
+		return false, fmt.Errorf("failed to encode card tags: %w", err)
+	}
+	fields["tags"] = rawTags
+	content, err := json.Marshal(fields)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode card content: %w", err)
+	}
+	if err := c.UpdateContent(content); err != nil {
+		return false, err
+	}
+	return true, nil
+}
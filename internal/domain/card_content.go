@@ -0,0 +1,313 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// CardContentValidator checks the structure of a card's JSON content.
+// Implementations return a *ValidationError wrapping ErrInvalidCardContent
+// that names the offending field.
+type CardContentValidator interface {
+	ValidateContent(content json.RawMessage) error
+}
+
+// RequiredFieldsValidator requires card content to be a JSON object with a
+// non-empty value for each of the Required fields. Other fields are allowed
+// unless AllowedFields is set, in which case only Required and AllowedFields
+// may appear.
+type RequiredFieldsValidator struct {
+	Required      []string
+	AllowedFields []string
+}
+
+// DefaultCardContentValidator requires the front and back fields used by the
+// generated card format and allows any other fields.
+var DefaultCardContentValidator CardContentValidator = RequiredFieldsValidator{
+	Required: []string{"front", "back"},
+}
+
+var (
+	cardContentValidatorMu sync.RWMutex
+	cardContentValidator   = DefaultCardContentValidator
+)
+
+// SetCardContentValidator replaces the validator applied by Card.Validate and
+// returns the previous one, so callers such as tests can restore it.
+// A nil validator disables content structure checks.
+func SetCardContentValidator(v CardContentValidator) CardContentValidator {
+	cardContentValidatorMu.Lock()
+	defer cardContentValidatorMu.Unlock()
+	previous := cardContentValidator
+	cardContentValidator = v
+	return previous
+}
+
+// DefaultMaxCardContentBytes is the default limit on a card's serialized
+// content, far above what a generated or hand-written card needs.
+const DefaultMaxCardContentBytes = 64 << 10
+
+var (
+	maxCardContentBytesMu sync.RWMutex
+	maxCardContentBytes   = DefaultMaxCardContentBytes
+)
+
+// SetMaxCardContentBytes replaces the limit on a card's serialized content
+// applied by Card.Validate and returns the previous one, so callers such as
+// tests can restore it. A limit of 0 leaves the size unlimited.
+func SetMaxCardContentBytes(limit int) int {
+	maxCardContentBytesMu.Lock()
+	defer maxCardContentBytesMu.Unlock()
+	previous := maxCardContentBytes
+	maxCardContentBytes = limit
+	return previous
+}
+
+// ValidateCardContentSize checks content against the configured limit set by
+// SetMaxCardContentBytes. It returns a *ValidationError on the content field
+// wrapping ErrCardContentTooLarge if the content is longer.
+func ValidateCardContentSize(content json.RawMessage) error {
+	maxCardContentBytesMu.RLock()
+	limit := maxCardContentBytes
+	maxCardContentBytesMu.RUnlock()
+
+	if limit > 0 && len(content) > limit {
+		return NewValidationError("content",
+			fmt.Sprintf("must be at most %d bytes", limit), ErrCardContentTooLarge)
+	}
+	return nil
+}
+
+// ValidateCardContent checks content with the configured CardContentValidator.
+func ValidateCardContent(content json.RawMessage) error {
+	cardContentValidatorMu.RLock()
+	v := cardContentValidator
+	cardContentValidatorMu.RUnlock()
+
+	if v == nil {
+		return nil
+	}
+	return v.ValidateContent(content)
+}
+
+// ValidateContent implements CardContentValidator.
+func (v RequiredFieldsValidator) ValidateContent(content json.RawMessage) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil || fields == nil {
+		return NewValidationError("content", "must be a JSON object", ErrInvalidCardContent)
+	}
+
+	for _, name := range v.Required {
+		value, ok := fields[name]
+		if !ok || isEmptyJSONValue(value) {
+			return NewValidationError("content."+name, "is required", ErrInvalidCardContent)
+		}
+	}
+
+	if v.AllowedFields != nil {
+		for _, name := range sortedKeys(fields) {
+			if !slices.Contains(v.Required, name) && !slices.Contains(v.AllowedFields, name) {
+				return NewValidationError("content."+name, "is not an allowed field", ErrInvalidCardContent)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isEmptyJSONValue reports whether a JSON value is null or a blank string.
+func isEmptyJSONValue(value json.RawMessage) bool {
+	var decoded interface{}
+	if err := json.Unmarshal(value, &decoded); err != nil || decoded == nil {
+		return true
+	}
+	s, ok := decoded.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+// JSONSchemaValidator validates card content against a JSON Schema, such as
+// one describing the cards a prompt template asks the model to produce.
+//
+// Only the subset of JSON Schema needed to describe card content is supported:
+// type, required, properties, additionalProperties (as a boolean), items,
+// enum, minLength, maxLength, minItems and maxItems. Other keywords are ignored.
+type JSONSchemaValidator struct {
+	root *jsonSchema
+}
+
+// jsonSchema is the supported subset of a JSON Schema document.
+type jsonSchema struct {
+	Type                 jsonSchemaTypes        `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+}
+
+// jsonSchemaTypes holds the "type" keyword, which is either a single type
+// name or a list of them.
+type jsonSchemaTypes []string
+
+// UnmarshalJSON accepts a type name or a list of type names.
+func (t *jsonSchemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = jsonSchemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = list
+	return nil
+}
+
+// NewJSONSchemaValidator parses a JSON Schema document.
+// Returns an error if the schema is not valid JSON or uses an unknown type.
+func NewJSONSchemaValidator(schema []byte) (*JSONSchemaValidator, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("invalid card content schema: %w", err)
+	}
+	if err := root.check(); err != nil {
+		return nil, fmt.Errorf("invalid card content schema: %w", err)
+	}
+	return &JSONSchemaValidator{root: &root}, nil
+}
+
+// check rejects type names the validator does not know, so that typos in a
+// schema are reported at startup instead of silently accepting everything.
+func (s *jsonSchema) check() error {
+	for _, typ := range s.Type {
+		switch typ {
+		case "object", "array", "string", "number", "integer", "boolean", "null":
+		default:
+			return fmt.Errorf("unknown type %q", typ)
+		}
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		if err := s.Properties[name].check(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.check()
+	}
+	return nil
+}
+
+// ValidateContent implements CardContentValidator.
+func (v *JSONSchemaValidator) ValidateContent(content json.RawMessage) error {
+	var decoded interface{}
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		return NewValidationError("content", "must be valid JSON", ErrInvalidCardContent)
+	}
+	if message, path := v.root.validate(decoded, "content"); message != "" {
+		return NewValidationError(path, message, ErrInvalidCardContent)
+	}
+	return nil
+}
+
+// validate checks a decoded JSON value against the schema. It returns a
+// message and the path of the first violation, or an empty message.
+func (s *jsonSchema) validate(value interface{}, path string) (string, string) {
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(typ string) bool {
+		return jsonValueHasType(value, typ)
+	}) {
+		return "must be of type " + strings.Join(s.Type, " or "), path
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(allowed interface{}) bool {
+		return fmt.Sprint(allowed) == fmt.Sprint(value)
+	}) {
+		return "is not an allowed value", path
+	}
+
+	switch typed := value.(type) {
+	case string:
+		length := len([]rune(typed))
+		if s.MinLength != nil && length < *s.MinLength {
+			return fmt.Sprintf("must be at least %d characters", *s.MinLength), path
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return fmt.Sprintf("must be at most %d characters", *s.MaxLength), path
+		}
+
+	case []interface{}:
+		if s.MinItems != nil && len(typed) < *s.MinItems {
+			return fmt.Sprintf("must have at least %d items", *s.MinItems), path
+		}
+		if s.MaxItems != nil && len(typed) > *s.MaxItems {
+			return fmt.Sprintf("must have at most %d items", *s.MaxItems), path
+		}
+		if s.Items != nil {
+			for i, item := range typed {
+				if message, itemPath := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); message != "" {
+					return message, itemPath
+				}
+			}
+		}
+
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := typed[name]; !ok {
+				return "is required", path + "." + name
+			}
+		}
+		for _, name := range sortedKeys(typed) {
+			property, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return "is not an allowed field", path + "." + name
+				}
+				continue
+			}
+			if message, propertyPath := property.validate(typed[name], path+"."+name); message != "" {
+				return message, propertyPath
+			}
+		}
+	}
+
+	return "", ""
+}
+
+// jsonValueHasType reports whether a value decoded by encoding/json has the
+// given JSON Schema type.
+func jsonValueHasType(value interface{}, typ string) bool {
+	switch typed := value.(type) {
+	case nil:
+		return typ == "null"
+	case bool:
+		return typ == "boolean"
+	case string:
+		return typ == "string"
+	case float64:
+		return typ == "number" || (typ == "integer" && typed == float64(int64(typed)))
+	case []interface{}:
+		return typ == "array"
+	case map[string]interface{}:
+		return typ == "object"
+	default:
+		return false
+	}
+}
+
+// sortedKeys returns the keys of a map in sorted order, so that the first
+// violation reported for a document is deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,262 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// assertContentFieldError checks that err is a ValidationError for field that
+// wraps ErrInvalidCardContent.
+func assertContentFieldError(t *testing.T, err error, field string) {
+	t.Helper()
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError for %s, got %v", field, err)
+	}
+	if validationErr.Field != field {
+		t.Errorf("Expected error on field %s, got %s", field, validationErr.Field)
+	}
+	if !errors.Is(err, ErrInvalidCardContent) {
+		t.Errorf("Expected error to wrap ErrInvalidCardContent, got %v", err)
+	}
+}
+
+func TestRequiredFieldsValidator(t *testing.T) {
+	t.Parallel()
+
+	validator := RequiredFieldsValidator{Required: []string{"front", "back"}}
+
+	tests := []struct {
+		name      string
+		content   string
+		wantField string
+	}{
+		{"valid content", `{"front": "What is Go?", "back": "A programming language"}`, ""},
+		{"extra fields allowed by default", `{"front": "Q", "back": "A", "source": "book"}`, ""},
+		{"missing back", `{"front": "What is Go?"}`, "content.back"},
+		{"missing front", `{"back": "A programming language"}`, "content.front"},
+		{"blank back", `{"front": "Q", "back": "  "}`, "content.back"},
+		{"null back", `{"front": "Q", "back": null}`, "content.back"},
+		{"not an object", `["front", "back"]`, "content"},
+		{"null content", `null`, "content"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.ValidateContent(json.RawMessage(tt.content))
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			assertContentFieldError(t, err, tt.wantField)
+		})
+	}
+}
+
+func TestRequiredFieldsValidator_AllowedFields(t *testing.T) {
+	t.Parallel()
+
+	validator := RequiredFieldsValidator{
+		Required:      []string{"front", "back"},
+		AllowedFields: []string{"hint", "tags"},
+	}
+
+	err := validator.ValidateContent(json.RawMessage(`{"front": "Q", "back": "A", "hint": "H", "tags": ["go"]}`))
+	if err != nil {
+		t.Errorf("Expected no error for allowed fields, got %v", err)
+	}
+
+	err = validator.ValidateContent(json.RawMessage(`{"front": "Q", "back": "A", "source": "book"}`))
+	assertContentFieldError(t, err, "content.source")
+}
+
+func TestJSONSchemaValidator(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewJSONSchemaValidator([]byte(`{
+		"type": "object",
+		"required": ["front", "back"],
+		"additionalProperties": false,
+		"properties": {
+			"front": {"type": "string", "minLength": 1},
+			"back": {"type": "string", "minLength": 1, "maxLength": 10},
+			"difficulty": {"type": "integer", "enum": [1, 2, 3]},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+			"hint": {"type": ["string", "null"]}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Expected schema to parse, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		content   string
+		wantField string
+	}{
+		{"valid content", `{"front": "Q", "back": "A"}`, ""},
+		{"all optional fields", `{"front": "Q", "back": "A", "difficulty": 2, "tags": ["go"], "hint": null}`, ""},
+		{"missing back", `{"front": "Q"}`, "content.back"},
+		{"empty front", `{"front": "", "back": "A"}`, "content.front"},
+		{"back too long", `{"front": "Q", "back": "far too long"}`, "content.back"},
+		{"wrong type", `{"front": 1, "back": "A"}`, "content.front"},
+		{"non-integer", `{"front": "Q", "back": "A", "difficulty": 1.5}`, "content.difficulty"},
+		{"value not in enum", `{"front": "Q", "back": "A", "difficulty": 4}`, "content.difficulty"},
+		{"too many items", `{"front": "Q", "back": "A", "tags": ["a", "b", "c"]}`, "content.tags"},
+		{"wrong item type", `{"front": "Q", "back": "A", "tags": ["a", 2]}`, "content.tags[1]"},
+		{"extra field rejected", `{"front": "Q", "back": "A", "source": "book"}`, "content.source"},
+		{"not an object", `"front"`, "content"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.ValidateContent(json.RawMessage(tt.content))
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			assertContentFieldError(t, err, tt.wantField)
+		})
+	}
+}
+
+func TestJSONSchemaValidator_ExtraFieldsAllowedByDefault(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewJSONSchemaValidator([]byte(`{"type": "object", "required": ["front", "back"]}`))
+	if err != nil {
+		t.Fatalf("Expected schema to parse, got %v", err)
+	}
+
+	err = validator.ValidateContent(json.RawMessage(`{"front": "Q", "back": "A", "source": "book"}`))
+	if err != nil {
+		t.Errorf("Expected extra fields to be allowed, got %v", err)
+	}
+}
+
+func TestNewJSONSchemaValidator_InvalidSchema(t *testing.T) {
+	t.Parallel()
+
+	schemas := map[string]string{
+		"invalid JSON":        `{"type": `,
+		"unknown type":        `{"type": "obj"}`,
+		"unknown nested type": `{"type": "object", "properties": {"front": {"type": "text"}}}`,
+		"invalid type value":  `{"type": 1}`,
+	}
+
+	for name, schema := range schemas {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewJSONSchemaValidator([]byte(schema)); err == nil {
+				t.Error("Expected error for invalid schema, got nil")
+			}
+		})
+	}
+}
+
+func TestCardValidate_ContentValidator(t *testing.T) {
+	t.Parallel()
+
+	card := Card{
+		ID:      uuid.New(),
+		UserID:  uuid.New(),
+		MemoID:  uuid.New(),
+		Content: json.RawMessage(`{"front": "What is Go?"}`),
+	}
+
+	assertContentFieldError(t, card.Validate(), "content.back")
+
+	original := card.Content
+	err := card.UpdateContent(json.RawMessage(`{"back": "A programming language"}`))
+	assertContentFieldError(t, err, "content.front")
+	if string(card.Content) != string(original) {
+		t.Errorf("Expected content to be unchanged after failed update, got %s", card.Content)
+	}
+}
+
+// contentOfSize returns basic card content exactly size bytes long
+func contentOfSize(size int) json.RawMessage {
+	const prefix, suffix = `{"front":"`, `","back":"A"}`
+	return json.RawMessage(prefix + strings.Repeat("x", size-len(prefix)-len(suffix)) + suffix)
+}
+
+// TestSetMaxCardContentBytes replaces the package-wide limit, so it must not
+// run in parallel with tests that create cards.
+func TestSetMaxCardContentBytes(t *testing.T) {
+	previous := SetMaxCardContentBytes(100)
+	t.Cleanup(func() { SetMaxCardContentBytes(previous) })
+
+	if _, err := NewCard(uuid.New(), uuid.New(), contentOfSize(100)); err != nil {
+		t.Errorf("Expected content at the limit to be accepted, got %v", err)
+	}
+
+	_, err := NewCard(uuid.New(), uuid.New(), contentOfSize(101))
+	if !errors.Is(err, ErrCardContentTooLarge) {
+		t.Fatalf("Expected error wrapping %v, got %v", ErrCardContentTooLarge, err)
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "content" {
+		t.Errorf("Expected a validation error on content, got %v", err)
+	}
+
+	// Edits are checked too, and leave the card unchanged
+	card, err := NewCard(uuid.New(), uuid.New(), contentOfSize(50))
+	if err != nil {
+		t.Fatalf("Expected no error creating card, got %v", err)
+	}
+	if err := card.UpdateContent(contentOfSize(101)); !errors.Is(err, ErrCardContentTooLarge) {
+		t.Errorf("Expected error wrapping %v, got %v", ErrCardContentTooLarge, err)
+	}
+	if len(card.Content) != 50 {
+		t.Errorf("Expected content to be unchanged after failed update, got %d bytes", len(card.Content))
+	}
+
+	SetMaxCardContentBytes(0)
+	if _, err := NewCard(uuid.New(), uuid.New(), contentOfSize(DefaultMaxCardContentBytes+1)); err != nil {
+		t.Errorf("Expected no size limit when disabled, got %v", err)
+	}
+}
+
+func TestDefaultMaxCardContentBytes(t *testing.T) {
+	t.Parallel()
+
+	// A long, hand-written card is far below the default limit
+	content := json.RawMessage(`{"front":"` + strings.Repeat("What is spaced repetition? ", 40) +
+		`","back":"` + strings.Repeat("Reviewing at growing intervals. ", 100) + `","hint":"SRS","tags":["learning"]}`)
+	if err := ValidateCardContentSize(content); err != nil {
+		t.Errorf("Expected a normal card to be within the default limit, got %v", err)
+	}
+	if err := ValidateCardContentSize(contentOfSize(DefaultMaxCardContentBytes + 1)); !errors.Is(err, ErrCardContentTooLarge) {
+		t.Errorf("Expected error wrapping %v, got %v", ErrCardContentTooLarge, err)
+	}
+}
+
+// TestSetCardContentValidator replaces the package-wide validator, so it must
+// not run in parallel with tests that create cards.
+func TestSetCardContentValidator(t *testing.T) {
+	strict := RequiredFieldsValidator{Required: []string{"front", "back"}, AllowedFields: []string{}}
+	previous := SetCardContentValidator(strict)
+	t.Cleanup(func() { SetCardContentValidator(previous) })
+
+	_, err := NewCard(uuid.New(), uuid.New(), json.RawMessage(`{"front": "Q", "back": "A", "hint": "H"}`))
+	assertContentFieldError(t, err, "content.hint")
+
+	SetCardContentValidator(nil)
+	if _, err := NewCard(uuid.New(), uuid.New(), json.RawMessage(`{}`)); err != nil {
+		t.Errorf("Expected no error with content validation disabled, got %v", err)
+	}
+}
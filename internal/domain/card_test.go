@@ -3,6 +3,7 @@ package domain
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -164,3 +165,140 @@ func TestUpdateContent(t *testing.T) {
 			string(originalContent), string(card.Content))
 	}
 }
+
+func TestNormalizeTag(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	tests := []struct {
+		name     string
+		tag      string
+		expected string
+		wantErr  bool
+	}{
+		{name: "already normalized", tag: "biology", expected: "biology"},
+		{name: "lower-cased and trimmed", tag: "  Biology ", expected: "biology"},
+		{name: "separators collapse to hyphens", tag: "Cell  Biology_basics", expected: "cell-biology-basics"},
+		{name: "leading and trailing separators dropped", tag: "-world war 2-", expected: "world-war-2"},
+		{name: "non-ASCII letters allowed", tag: "Geschichte Österreichs", expected: "geschichte-österreichs"},
+		{name: "empty", tag: "", wantErr: true},
+		{name: "only separators", tag: " _- ", wantErr: true},
+		{name: "punctuation", tag: "c++", wantErr: true},
+		{name: "too long", tag: "abcdefghijklmnopqrstuvwxyz1234567", wantErr: true},
+		{name: "exactly max length", tag: "abcdefghijklmnopqrstuvwxyz123456", expected: "abcdefghijklmnopqrstuvwxyz123456"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NormalizeTag(tc.tag)
+			if tc.wantErr {
+				if err != ErrInvalidTag {
+					t.Errorf("Expected ErrInvalidTag for %q, got %q, %v", tc.tag, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error for %q, got %v", tc.tag, err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCardIsBuried(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 5, 1, 15, 0, 0, 0, time.UTC)
+	midnight := time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	card := &Card{}
+	if card.IsBuried(now) {
+		t.Error("Expected card without burial not to be buried")
+	}
+
+	card.BuriedUntil = &midnight
+	if !card.IsBuried(now) {
+		t.Error("Expected card to be buried before midnight")
+	}
+	if card.IsBuried(midnight) {
+		t.Error("Expected card to reappear at midnight")
+	}
+	if card.IsBuried(midnight.Add(time.Hour)) {
+		t.Error("Expected card to stay visible after the day rolls over")
+	}
+}
+
+func TestCardAddTag(t *testing.T) {
+	t.Parallel()
+
+	card, err := NewCard(uuid.New(), uuid.New(), json.RawMessage(`{"front":"Q","back":"A","hint":"H","tags":["go"]}`))
+	if err != nil {
+		t.Fatalf("Failed to create card: %v", err)
+	}
+
+	added, err := card.AddTag(LeechTag)
+	if err != nil || !added {
+		t.Fatalf("Expected the tag to be added, got added=%v, err=%v", added, err)
+	}
+	var content CardContent
+	if err := json.Unmarshal(card.Content, &content); err != nil {
+		t.Fatalf("Failed to decode content: %v", err)
+	}
+	if content.Hint != "H" || len(content.Tags) != 2 || content.Tags[1] != LeechTag {
+		t.Errorf("Expected other fields kept and the tag appended, got %s", card.Content)
+	}
+	if !card.HasTag(LeechTag) || !card.HasTag("go") || card.HasTag("rust") {
+		t.Errorf("Unexpected HasTag results for %s", card.Content)
+	}
+
+	added, err = card.AddTag(LeechTag)
+	if err != nil || added {
+		t.Errorf("Expected adding an existing tag to be a no-op, got added=%v, err=%v", added, err)
+	}
+
+	if _, err := card.AddTag("Not Normalized"); err != ErrInvalidTag {
+		t.Errorf("Expected ErrInvalidTag for a tag that is not normalized, got %v", err)
+	}
+
+	card.Content = json.RawMessage(`{"front":"Q","back":"A","tags":"go"}`)
+	if _, err := card.AddTag(LeechTag); err != ErrCardContentInvalid {
+		t.Errorf("Expected ErrCardContentInvalid for malformed tags, got %v", err)
+	}
+}
+
+func TestCardFrontHash(t *testing.T) {
+	t.Parallel()
+
+	if HashCardFront("  What is\tGO? ") != HashCardFront("what is go?") {
+		t.Error("Expected fronts differing only in case and whitespace to hash equally")
+	}
+	if HashCardFront("what is go?") == HashCardFront("what is rust?") {
+		t.Error("Expected different fronts to hash differently")
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		ok      bool
+	}{
+		{name: "front", content: `{"front":"Q","back":"A"}`, want: "q", ok: true},
+		{name: "cloze_text", content: `{"text":"Go is {{c1::fast}}"}`, want: "Go is {{c1::fast}}", ok: true},
+		{name: "blank_front_uses_text", content: `{"front":" ","text":"T"}`, want: "t", ok: true},
+		{name: "no_front", content: `{"back":"A"}`},
+		{name: "invalid_json", content: `{not json`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			card := &Card{Content: json.RawMessage(tc.content)}
+			hash, ok := card.FrontHash()
+			if ok != tc.ok {
+				t.Fatalf("Expected ok=%v, got %v", tc.ok, ok)
+			}
+			if ok && hash != HashCardFront(tc.want) {
+				t.Errorf("Expected the hash of %q", tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CardType identifies how a card's content is presented for review.
+type CardType string
+
+// Card types
+const (
+	// CardTypeBasic cards show the front as the question and the back as the answer.
+	CardTypeBasic CardType = "basic"
+
+	// CardTypeCloze cards hide the cloze deletions marked in their text, such as
+	// "The {{c1::mitochondria}} is the powerhouse of the cell". The back, if any,
+	// holds extra information shown with the answer.
+	CardTypeCloze CardType = "cloze"
+)
+
+// ErrInvalidCardType is returned when a card has an unknown type.
+var ErrInvalidCardType = errors.New("invalid card type")
+
+// ErrInvalidCloze is returned when cloze card text has no cloze deletions or
+// contains a malformed cloze marker.
+var ErrInvalidCloze = errors.New("invalid cloze markup")
+
+// IsValid reports whether t is a known card type. The empty type is not valid;
+// cards without a type are treated as basic (see OrDefault).
+func (t CardType) IsValid() bool {
+	return t == CardTypeBasic || t == CardTypeCloze
+}
+
+// OrDefault returns t, or CardTypeBasic if t is empty.
+func (t CardType) OrDefault() CardType {
+	if t == "" {
+		return CardTypeBasic
+	}
+	return t
+}
+
+// ClozeDeletion is one cloze marker in the text of a cloze card, written as
+// {{cN::answer}} or {{cN::answer::hint}}.
+type ClozeDeletion struct {
+	// Number is N, the ordinal of the deletion. Markers sharing a number are
+	// hidden together.
+	Number int
+
+	// Answer is the hidden text.
+	Answer string
+
+	// Hint is shown in place of the hidden text, if given.
+	Hint string
+}
+
+const (
+	clozeOpen      = "{{c"
+	clozeClose     = "}}"
+	clozeSeparator = "::"
+)
+
+// ParseCloze returns the cloze deletions in text, in order of appearance.
+// Returns an error wrapping ErrInvalidCloze if text has no cloze deletions, or
+// if a marker is unterminated, nested, or has an invalid number or empty answer.
+func ParseCloze(text string) ([]ClozeDeletion, error) {
+	var deletions []ClozeDeletion
+	rest := text
+	for {
+		start := strings.Index(rest, clozeOpen)
+		if start < 0 {
+			break
+		}
+		rest = rest[start+len(clozeOpen):]
+
+		end := strings.Index(rest, clozeClose)
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated cloze marker", ErrInvalidCloze)
+		}
+		marker := rest[:end]
+		rest = rest[end+len(clozeClose):]
+
+		if strings.Contains(marker, "{{") {
+			return nil, fmt.Errorf("%w: nested cloze marker", ErrInvalidCloze)
+		}
+		deletion, err := parseClozeMarker(marker)
+		if err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, deletion)
+	}
+
+	if len(deletions) == 0 {
+		return nil, fmt.Errorf("%w: text must contain at least one {{c1::...}} marker", ErrInvalidCloze)
+	}
+	return deletions, nil
+}
+
+// parseClozeMarker parses the inside of a cloze marker, without the leading
+// "{{c" and trailing "}}".
+func parseClozeMarker(marker string) (ClozeDeletion, error) {
+	parts := strings.SplitN(marker, clozeSeparator, 3)
+	if len(parts) < 2 {
+		return ClozeDeletion{}, fmt.Errorf("%w: cloze marker must be {{cN::answer}}", ErrInvalidCloze)
+	}
+
+	number, err := strconv.Atoi(parts[0])
+	if err != nil || number < 1 || parts[0] != strconv.Itoa(number) {
+		return ClozeDeletion{}, fmt.Errorf("%w: cloze number %q must be a positive integer",
+			ErrInvalidCloze, parts[0])
+	}
+
+	deletion := ClozeDeletion{Number: number, Answer: strings.TrimSpace(parts[1])}
+	if deletion.Answer == "" {
+		return ClozeDeletion{}, fmt.Errorf("%w: cloze c%d has an empty answer", ErrInvalidCloze, number)
+	}
+	if len(parts) == 3 {
+		deletion.Hint = strings.TrimSpace(parts[2])
+	}
+	return deletion, nil
+}
+
+// ClozeNumbers returns the distinct cloze numbers in text in ascending order.
+// Each number is a separately reviewable unit of a cloze card.
+// Returns an error wrapping ErrInvalidCloze if the markup is invalid.
+func ClozeNumbers(text string) ([]int, error) {
+	deletions, err := ParseCloze(text)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]int, 0, len(deletions))
+	for _, deletion := range deletions {
+		if !slices.Contains(numbers, deletion.Number) {
+			numbers = append(numbers, deletion.Number)
+		}
+	}
+	slices.Sort(numbers)
+	return numbers, nil
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseCloze(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want []ClozeDeletion
+	}{
+		{
+			name: "single deletion",
+			text: "The {{c1::mitochondria}} is the powerhouse of the cell",
+			want: []ClozeDeletion{{Number: 1, Answer: "mitochondria"}},
+		},
+		{
+			name: "deletion with hint",
+			text: "{{c1::Paris::capital}} is in France",
+			want: []ClozeDeletion{{Number: 1, Answer: "Paris", Hint: "capital"}},
+		},
+		{
+			name: "several deletions",
+			text: "{{c2::Canberra}} is the capital of {{c1::Australia}}, not {{c2::Sydney}}",
+			want: []ClozeDeletion{
+				{Number: 2, Answer: "Canberra"},
+				{Number: 1, Answer: "Australia"},
+				{Number: 2, Answer: "Sydney"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseCloze(tt.text)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCloze_Malformed(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"no markers":          "The mitochondria is the powerhouse of the cell",
+		"empty text":          "",
+		"unterminated marker": "The {{c1::mitochondria is the powerhouse",
+		"missing separator":   "The {{c1 mitochondria}} is the powerhouse",
+		"missing number":      "The {{c::mitochondria}} is the powerhouse",
+		"zero number":         "The {{c0::mitochondria}} is the powerhouse",
+		"non-numeric number":  "The {{cx::mitochondria}} is the powerhouse",
+		"leading zero":        "The {{c01::mitochondria}} is the powerhouse",
+		"empty answer":        "The {{c1::}} is the powerhouse",
+		"blank answer":        "The {{c1::  ::hint}} is the powerhouse",
+		"nested marker":       "The {{c1::mito{{c2::chondria}}}} is the powerhouse",
+		"valid then broken":   "{{c1::Paris}} is in {{c2::France",
+	}
+
+	for name, text := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := ParseCloze(text); !errors.Is(err, ErrInvalidCloze) {
+				t.Errorf("Expected ErrInvalidCloze, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClozeNumbers(t *testing.T) {
+	t.Parallel()
+
+	numbers, err := ClozeNumbers("{{c3::c}} {{c1::a}} {{c3::again}} {{c2::b}}")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(numbers, want) {
+		t.Errorf("Expected %v, got %v", want, numbers)
+	}
+}
+
+func TestNewCardOfType_Cloze(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	memoID := uuid.New()
+
+	card, err := NewCardOfType(userID, memoID, CardTypeCloze,
+		json.RawMessage(`{"text": "The {{c1::mitochondria}} is the powerhouse of the cell"}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if card.Type != CardTypeCloze {
+		t.Errorf("Expected type %s, got %s", CardTypeCloze, card.Type)
+	}
+
+	// Cloze cards do not need the front and back of basic cards
+	_, err = NewCardOfType(userID, memoID, CardTypeCloze,
+		json.RawMessage(`{"text": "{{c1::Paris}} is in France", "back": "Extra"}`))
+	if err != nil {
+		t.Errorf("Expected no error for cloze card with extra, got %v", err)
+	}
+
+	_, err = NewCardOfType(userID, memoID, CardTypeCloze,
+		json.RawMessage(`{"text": "The mitochondria is the powerhouse of the cell"}`))
+	assertContentFieldError(t, err, "content.text")
+	if !errors.Is(err, ErrInvalidCloze) {
+		t.Errorf("Expected error to wrap ErrInvalidCloze, got %v", err)
+	}
+
+	_, err = NewCardOfType(userID, memoID, CardTypeCloze, json.RawMessage(`{"front": "Q", "back": "A"}`))
+	assertContentFieldError(t, err, "content.text")
+
+	_, err = NewCardOfType(userID, memoID, CardType("reverse"), json.RawMessage(`{"front": "Q", "back": "A"}`))
+	if !errors.Is(err, ErrInvalidCardType) {
+		t.Errorf("Expected ErrInvalidCardType, got %v", err)
+	}
+}
+
+func TestNewCard_DefaultsToBasic(t *testing.T) {
+	t.Parallel()
+
+	card, err := NewCard(uuid.New(), uuid.New(), json.RawMessage(`{"front": "Q", "back": "A"}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if card.Type != CardTypeBasic {
+		t.Errorf("Expected type %s, got %s", CardTypeBasic, card.Type)
+	}
+
+	// Cards loaded without a type are validated as basic cards
+	card.Type = ""
+	if err := card.Validate(); err != nil {
+		t.Errorf("Expected untyped basic card to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxDeckNameLength is the maximum number of characters in a deck name.
+const MaxDeckNameLength = 100
+
+// Bounds for the SRS settings a deck may override.
+// The ease factor bounds match the limits applied by the SRS algorithm.
+const (
+	MinDeckInitialEaseFactor  = 1.3
+	MaxDeckInitialEaseFactor  = 2.5
+	MaxDeckAgainReviewMinutes = 1440
+	MaxDeckLearningSteps      = 10
+)
+
+// DefaultDeckName is the name of the deck that new users are given, and that
+// generated cards are assigned to, when default decks are enabled.
+const DefaultDeckName = "Default"
+
+// Deck-specific validation errors
+var (
+	// ErrDeckIDEmpty is returned when a deck ID is empty or nil.
+	ErrDeckIDEmpty = errors.New("deck ID cannot be empty")
+
+	// ErrDeckUserIDEmpty is returned when a deck's user ID is empty or nil.
+	ErrDeckUserIDEmpty = errors.New("deck user ID cannot be empty")
+
+	// ErrDeckNameEmpty is returned when a deck name is empty or only whitespace.
+	ErrDeckNameEmpty = errors.New("deck name cannot be empty")
+
+	// ErrDeckNameTooLong is returned when a deck name exceeds MaxDeckNameLength characters.
+	ErrDeckNameTooLong = errors.New("deck name is too long")
+
+	// ErrDeckNewCardsPerDayInvalid is returned when a deck's new-card limit is negative.
+	ErrDeckNewCardsPerDayInvalid = errors.New("deck new cards per day cannot be negative")
+
+	// ErrDeckAgainReviewMinutesInvalid is returned when a deck's relearning delay
+	// is not between 1 and MaxDeckAgainReviewMinutes.
+	ErrDeckAgainReviewMinutesInvalid = errors.New("deck again review minutes is out of range")
+
+	// ErrDeckLearningStepsInvalid is returned when a deck's learning steps are
+	// empty or more than MaxDeckLearningSteps, or a step is not between 1 and
+	// MaxDeckAgainReviewMinutes minutes.
+	ErrDeckLearningStepsInvalid = errors.New("deck learning steps are out of range")
+
+	// ErrDeckInitialEaseFactorInvalid is returned when a deck's initial ease factor
+	// is not between MinDeckInitialEaseFactor and MaxDeckInitialEaseFactor.
+	ErrDeckInitialEaseFactorInvalid = errors.New("deck initial ease factor is out of range")
+)
+
+// Deck is a user-defined group of cards, such as "Spanish" or "Med School".
+// Cards belong to at most one deck; cards without a deck are still reviewed
+// as part of the user's overall queue.
+type Deck struct {
+	ID          uuid.UUID       `json:"id"`
+	UserID      uuid.UUID       `json:"user_id"`
+	Name        string          `json:"name"`
+	SRSSettings DeckSRSSettings `json:"srs_settings"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// DeckSRSSettings overrides how the cards in a deck are paced and scheduled.
+// Nil fields fall back to the user's settings and the SRS algorithm's defaults.
+type DeckSRSSettings struct {
+	// NewCardsPerDay replaces the user's NewCardsPerDay while reviewing the deck.
+	// New cards introduced from any deck count towards it.
+	NewCardsPerDay *int `json:"new_cards_per_day,omitempty"`
+
+	// AgainReviewMinutes is the learning step: how many minutes pass before a
+	// card answered "again" is shown again.
+	AgainReviewMinutes *int `json:"again_review_minutes,omitempty"`
+
+	// InitialEaseFactor is the ease factor a card starts from on its first review.
+	InitialEaseFactor *float64 `json:"initial_ease_factor,omitempty"`
+
+	// LearningSteps are the delays in minutes between the reviews of a new or
+	// lapsed card before it graduates to interval scheduling. While set they
+	// replace AgainReviewMinutes.
+	LearningSteps []int `json:"learning_steps,omitempty"`
+
+	// NewCardIntervalDays is the starting interval, in days, of cards created
+	// in the deck.
+	NewCardIntervalDays *int `json:"new_card_interval_days,omitempty"`
+
+	// NewCardsDueImmediately controls whether cards created in the deck are due
+	// at once, or only NewCardIntervalDays after they are created.
+	NewCardsDueImmediately *bool `json:"new_cards_due_immediately,omitempty"`
+
+	// ReviewOrder is the order due cards are served in while reviewing the
+	// deck, unless the review asks for a particular order.
+	ReviewOrder *ReviewOrder `json:"review_order,omitempty"`
+}
+
+// NewCardOverrides returns the deck's overrides of the settings that the stats
+// of its new cards start from. The starting ease factor is InitialEaseFactor.
+func (s DeckSRSSettings) NewCardOverrides() NewCardOverrides {
+	return NewCardOverrides{
+		IntervalDays:   s.NewCardIntervalDays,
+		EaseFactor:     s.InitialEaseFactor,
+		DueImmediately: s.NewCardsDueImmediately,
+	}
+}
+
+// Validate checks that every set override is within range.
+func (s DeckSRSSettings) Validate() error {
+	if s.NewCardsPerDay != nil && *s.NewCardsPerDay < 0 {
+		return ErrDeckNewCardsPerDayInvalid
+	}
+
+	if s.AgainReviewMinutes != nil &&
+		(*s.AgainReviewMinutes < 1 || *s.AgainReviewMinutes > MaxDeckAgainReviewMinutes) {
+		return ErrDeckAgainReviewMinutesInvalid
+	}
+
+	if s.InitialEaseFactor != nil &&
+		(*s.InitialEaseFactor < MinDeckInitialEaseFactor || *s.InitialEaseFactor > MaxDeckInitialEaseFactor) {
+		return ErrDeckInitialEaseFactorInvalid
+	}
+
+	if s.LearningSteps != nil {
+		if len(s.LearningSteps) == 0 || len(s.LearningSteps) > MaxDeckLearningSteps {
+			return ErrDeckLearningStepsInvalid
+		}
+		for _, step := range s.LearningSteps {
+			if step < 1 || step > MaxDeckAgainReviewMinutes {
+				return ErrDeckLearningStepsInvalid
+			}
+		}
+	}
+
+	if s.NewCardIntervalDays != nil &&
+		(*s.NewCardIntervalDays < 0 || *s.NewCardIntervalDays > MaxNewCardIntervalDays) {
+		return ErrNewCardIntervalInvalid
+	}
+
+	if s.ReviewOrder != nil && !s.ReviewOrder.IsValid() {
+		return ErrInvalidReviewOrder
+	}
+
+	return nil
+}
+
+// ReviewSettings decide which card is served next in a review.
+type ReviewSettings struct {
+	// NewCardsPerDay is how many new cards may be introduced per day.
+	NewCardsPerDay int
+
+	// Order is the order due cards are served in when the review does not
+	// ask for one.
+	Order ReviewOrder
+}
+
+// ResolveReviewSettings returns the settings for reviewing deck as user. The
+// deck's overrides take precedence over the user's settings, which take
+// precedence over DefaultReviewOrder. Either may be nil; without a user
+// NewCardsPerDay is only set by the deck.
+func ResolveReviewSettings(user *User, deck *Deck) ReviewSettings {
+	settings := ReviewSettings{Order: DefaultReviewOrder}
+	if user != nil {
+		settings.NewCardsPerDay = user.NewCardsPerDay
+	}
+	if deck != nil {
+		if deck.SRSSettings.NewCardsPerDay != nil {
+			settings.NewCardsPerDay = *deck.SRSSettings.NewCardsPerDay
+		}
+		if deck.SRSSettings.ReviewOrder != nil {
+			settings.Order = *deck.SRSSettings.ReviewOrder
+		}
+	}
+	return settings
+}
+
+// NewDeck creates a new Deck with the given user ID and name.
+// Surrounding whitespace is trimmed from the name.
+// Returns an error if validation fails.
+func NewDeck(userID uuid.UUID, name string) (*Deck, error) {
+	now := time.Now().UTC()
+	deck := &Deck{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := deck.Validate(); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// Validate checks if the Deck has valid data.
+// Returns an error if any field fails validation.
+func (d *Deck) Validate() error {
+	if d.ID == uuid.Nil {
+		return ErrDeckIDEmpty
+	}
+
+	if d.UserID == uuid.Nil {
+		return ErrDeckUserIDEmpty
+	}
+
+	if err := validateDeckName(d.Name); err != nil {
+		return err
+	}
+
+	return d.SRSSettings.Validate()
+}
+
+// Rename changes the deck's name and updates the UpdatedAt timestamp.
+// Surrounding whitespace is trimmed. Returns an error, leaving the deck
+// unchanged, if the new name is invalid.
+func (d *Deck) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if err := validateDeckName(name); err != nil {
+		return err
+	}
+
+	d.Name = name
+	d.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetSRSSettings replaces the deck's SRS overrides and updates the UpdatedAt timestamp.
+// Returns an error, leaving the deck unchanged, if any override is out of range.
+func (d *Deck) SetSRSSettings(settings DeckSRSSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	settings.LearningSteps = slices.Clone(settings.LearningSteps)
+	d.SRSSettings = settings
+	d.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// validateDeckName checks that a trimmed deck name is non-empty and not too long.
+func validateDeckName(name string) error {
+	if name == "" {
+		return ErrDeckNameEmpty
+	}
+
+	if utf8.RuneCountInString(name) > MaxDeckNameLength {
+		return ErrDeckNameTooLong
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewDeck(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	userID := uuid.New()
+
+	deck, err := NewDeck(userID, "  Spanish  ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if deck.ID == uuid.Nil {
+		t.Error("Expected non-nil UUID, got nil UUID")
+	}
+
+	if deck.UserID != userID {
+		t.Errorf("Expected user ID %s, got %s", userID, deck.UserID)
+	}
+
+	if deck.Name != "Spanish" {
+		t.Errorf("Expected trimmed name %q, got %q", "Spanish", deck.Name)
+	}
+
+	if deck.CreatedAt.IsZero() || deck.UpdatedAt.IsZero() {
+		t.Error("Expected non-zero timestamps")
+	}
+
+	tests := []struct {
+		name     string
+		userID   uuid.UUID
+		deckName string
+		expected error
+	}{
+		{"missing user", uuid.Nil, "Spanish", ErrDeckUserIDEmpty},
+		{"empty name", userID, "", ErrDeckNameEmpty},
+		{"whitespace name", userID, "   ", ErrDeckNameEmpty},
+		{"name too long", userID, strings.Repeat("a", MaxDeckNameLength+1), ErrDeckNameTooLong},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewDeck(tc.userID, tc.deckName); err != tc.expected {
+				t.Errorf("Expected error %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	// Length is measured in characters, not bytes
+	if _, err := NewDeck(userID, strings.Repeat("é", MaxDeckNameLength)); err != nil {
+		t.Errorf("Expected multi-byte name of maximum length to be valid, got %v", err)
+	}
+}
+
+func TestDeckRename(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	deck, err := NewDeck(uuid.New(), "Spanish")
+	if err != nil {
+		t.Fatalf("Failed to create deck: %v", err)
+	}
+	originalUpdatedAt := deck.UpdatedAt
+
+	if err := deck.Rename(" Español "); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if deck.Name != "Español" {
+		t.Errorf("Expected name %q, got %q", "Español", deck.Name)
+	}
+	if deck.UpdatedAt.Before(originalUpdatedAt) {
+		t.Error("Expected UpdatedAt to be refreshed")
+	}
+
+	if err := deck.Rename(""); err != ErrDeckNameEmpty {
+		t.Errorf("Expected error %v, got %v", ErrDeckNameEmpty, err)
+	}
+	if deck.Name != "Español" {
+		t.Errorf("Expected name to be unchanged after invalid rename, got %q", deck.Name)
+	}
+}
+
+func TestDeckSetSRSSettings(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	orderPtr := func(v ReviewOrder) *ReviewOrder { return &v }
+
+	tests := []struct {
+		name     string
+		settings DeckSRSSettings
+		wantErr  error
+	}{
+		{"no_overrides", DeckSRSSettings{}, nil},
+		{
+			"all_overrides",
+			DeckSRSSettings{NewCardsPerDay: intPtr(50), AgainReviewMinutes: intPtr(1), InitialEaseFactor: floatPtr(2.0)},
+			nil,
+		},
+		{"new_cards_disabled", DeckSRSSettings{NewCardsPerDay: intPtr(0)}, nil},
+		{"negative_new_cards", DeckSRSSettings{NewCardsPerDay: intPtr(-1)}, ErrDeckNewCardsPerDayInvalid},
+		{"zero_again_minutes", DeckSRSSettings{AgainReviewMinutes: intPtr(0)}, ErrDeckAgainReviewMinutesInvalid},
+		{
+			"again_minutes_too_long",
+			DeckSRSSettings{AgainReviewMinutes: intPtr(MaxDeckAgainReviewMinutes + 1)},
+			ErrDeckAgainReviewMinutesInvalid,
+		},
+		{"ease_too_low", DeckSRSSettings{InitialEaseFactor: floatPtr(1.2)}, ErrDeckInitialEaseFactorInvalid},
+		{"ease_too_high", DeckSRSSettings{InitialEaseFactor: floatPtr(2.6)}, ErrDeckInitialEaseFactorInvalid},
+		{"new_card_interval", DeckSRSSettings{NewCardIntervalDays: intPtr(MaxNewCardIntervalDays)}, nil},
+		{"negative_new_card_interval", DeckSRSSettings{NewCardIntervalDays: intPtr(-1)}, ErrNewCardIntervalInvalid},
+		{
+			"new_card_interval_too_long",
+			DeckSRSSettings{NewCardIntervalDays: intPtr(MaxNewCardIntervalDays + 1)},
+			ErrNewCardIntervalInvalid,
+		},
+		{"learning_steps", DeckSRSSettings{LearningSteps: []int{1, 10, MaxDeckAgainReviewMinutes}}, nil},
+		{"no_learning_steps", DeckSRSSettings{LearningSteps: []int{}}, ErrDeckLearningStepsInvalid},
+		{"zero_learning_step", DeckSRSSettings{LearningSteps: []int{0, 10}}, ErrDeckLearningStepsInvalid},
+		{
+			"learning_step_too_long",
+			DeckSRSSettings{LearningSteps: []int{MaxDeckAgainReviewMinutes + 1}},
+			ErrDeckLearningStepsInvalid,
+		},
+		{
+			"too_many_learning_steps",
+			DeckSRSSettings{LearningSteps: make([]int, MaxDeckLearningSteps+1)},
+			ErrDeckLearningStepsInvalid,
+		},
+		{"review_order", DeckSRSSettings{ReviewOrder: orderPtr(ReviewOrderLowestEase)}, nil},
+		{"invalid_review_order", DeckSRSSettings{ReviewOrder: orderPtr("alphabetical")}, ErrInvalidReviewOrder},
+		{"empty_review_order", DeckSRSSettings{ReviewOrder: orderPtr("")}, ErrInvalidReviewOrder},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deck, err := NewDeck(uuid.New(), "Spanish")
+			if err != nil {
+				t.Fatalf("Failed to create deck: %v", err)
+			}
+
+			err = deck.SetSRSSettings(tc.settings)
+			if err != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if err != nil && !reflect.DeepEqual(deck.SRSSettings, DeckSRSSettings{}) {
+				t.Error("Expected settings to be unchanged after invalid update")
+			}
+			if err == nil && !reflect.DeepEqual(deck.SRSSettings, tc.settings) {
+				t.Errorf("Expected settings %+v, got %+v", tc.settings, deck.SRSSettings)
+			}
+		})
+	}
+}
+
+func TestResolveReviewSettings(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	intPtr := func(v int) *int { return &v }
+	orderPtr := func(v ReviewOrder) *ReviewOrder { return &v }
+
+	user := &User{NewCardsPerDay: 10}
+	deck := &Deck{SRSSettings: DeckSRSSettings{NewCardsPerDay: intPtr(3), ReviewOrder: orderPtr(ReviewOrderRandomDue)}}
+
+	tests := []struct {
+		name string
+		user *User
+		deck *Deck
+		want ReviewSettings
+	}{
+		{"defaults", nil, nil, ReviewSettings{Order: DefaultReviewOrder}},
+		{"user_only", user, nil, ReviewSettings{NewCardsPerDay: 10, Order: DefaultReviewOrder}},
+		{"deck_without_overrides", user, &Deck{}, ReviewSettings{NewCardsPerDay: 10, Order: DefaultReviewOrder}},
+		{"deck_overrides", user, deck, ReviewSettings{NewCardsPerDay: 3, Order: ReviewOrderRandomDue}},
+		{
+			"deck_order_only",
+			user,
+			&Deck{SRSSettings: DeckSRSSettings{ReviewOrder: orderPtr(ReviewOrderLowestEase)}},
+			ReviewSettings{NewCardsPerDay: 10, Order: ReviewOrderLowestEase},
+		},
+		{
+			"new_cards_disabled_by_deck",
+			user,
+			&Deck{SRSSettings: DeckSRSSettings{NewCardsPerDay: intPtr(0)}},
+			ReviewSettings{NewCardsPerDay: 0, Order: DefaultReviewOrder},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ResolveReviewSettings(tc.user, tc.deck); got != tc.want {
+				t.Errorf("Expected settings %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// DueResolution is the finest time resolution at which due times are compared.
+// It matches the microsecond precision of PostgreSQL timestamps, so an instant one
+// DueResolution before a boundary is the last stored instant that precedes it.
+const DueResolution = time.Microsecond
+
+// IsDue reports whether a review scheduled at nextReviewAt has been reached as of asOf.
+// A review scheduled exactly at asOf has been reached, and overdue reviews always have.
+// This is the schedule part of IsCardDue, which also considers the card's state.
+func IsDue(nextReviewAt, asOf time.Time) bool {
+	return !nextReviewAt.After(asOf)
+}
+
+// IsDue reports whether the review scheduled by these statistics has been reached
+// as of asOf. See the package-level IsDue; use IsCardDue to decide if a card is due.
+func (s *UserCardStats) IsDue(asOf time.Time) bool {
+	return IsDue(s.NextReviewAt, asOf)
+}
+
+// IsCardDue reports whether card, scheduled by stats, is due as of asOf.
+//
+// This is the single definition of "due" used across the application: a card is due
+// once its scheduled review time has been reached (see IsDue), unless it was superseded
+// by regenerating its memo or suspended by the user, which are never due. Next-card
+// selection, due counts and review forecasts must all agree with this predicate; the
+// stores express it with the same inclusive comparison and the same exclusions.
+func IsCardDue(card *Card, stats *UserCardStats, asOf time.Time) bool {
+	return stats.IsDue(asOf) && !card.IsSuperseded() && !card.IsSuspended()
+}
+
+// DueAsOfEndOfDay returns the asOf instant that makes IsDue cover every card falling
+// due on the user's calendar day containing now, including overdue cards. It is the last
+// instant before the start of the user's next day, so cards scheduled exactly at
+// midnight belong to the next day, as they do in review forecasts.
+func (u *User) DueAsOfEndOfDay(now time.Time) time.Time {
+	return u.EndOfDay(now).Add(-DueResolution)
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDue(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	asOf := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		nextReviewAt time.Time
+		expected     bool
+	}{
+		{"long overdue", asOf.AddDate(0, 0, -30), true},
+		{"just overdue", asOf.Add(-DueResolution), true},
+		{"scheduled exactly at asOf", asOf, true},
+		{"scheduled just after asOf", asOf.Add(DueResolution), false},
+		{"scheduled tomorrow", asOf.AddDate(0, 0, 1), false},
+		{"same instant in another zone", asOf.In(time.FixedZone("UTC+9", 9*60*60)), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsDue(tc.nextReviewAt, asOf); got != tc.expected {
+				t.Errorf("IsDue(%s, %s) = %v, expected %v", tc.nextReviewAt, asOf, got, tc.expected)
+			}
+
+			stats := UserCardStats{NextReviewAt: tc.nextReviewAt}
+			if got := stats.IsDue(asOf); got != tc.expected {
+				t.Errorf("UserCardStats.IsDue(%s) = %v, expected %v", asOf, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIsCardDue(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	asOf := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	earlier := asOf.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name         string
+		card         Card
+		nextReviewAt time.Time
+		expected     bool
+	}{
+		{"active and overdue", Card{}, earlier, true},
+		{"active and scheduled exactly at asOf", Card{}, asOf, true},
+		{"active and not yet due", Card{}, asOf.Add(DueResolution), false},
+		{"suspended and overdue", Card{SuspendedAt: &earlier}, earlier, false},
+		{"superseded and overdue", Card{SupersededAt: &earlier}, earlier, false},
+		{"superseded and suspended", Card{SupersededAt: &earlier, SuspendedAt: &earlier}, earlier, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stats := UserCardStats{NextReviewAt: tc.nextReviewAt}
+			if got := IsCardDue(&tc.card, &stats, asOf); got != tc.expected {
+				t.Errorf("IsCardDue(%s) = %v, expected %v", tc.name, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUserDueAsOfEndOfDay(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	// 20:00 UTC on March 1 is 05:00 on March 2 in Tokyo, whose day ends at 15:00 UTC on March 2
+	now := time.Date(2025, time.March, 1, 20, 0, 0, 0, time.UTC)
+	user := User{Timezone: "Asia/Tokyo"}
+	nextMidnight := time.Date(2025, time.March, 2, 15, 0, 0, 0, time.UTC)
+
+	asOf := user.DueAsOfEndOfDay(now)
+
+	if !asOf.Equal(nextMidnight.Add(-DueResolution)) {
+		t.Errorf("Expected %s, got %s", nextMidnight.Add(-DueResolution), asOf)
+	}
+	if !IsDue(nextMidnight.Add(-time.Minute), asOf) {
+		t.Error("A card due just before midnight should be due by the end of the day")
+	}
+	if IsDue(nextMidnight, asOf) {
+		t.Error("A card due exactly at midnight belongs to the next day")
+	}
+}
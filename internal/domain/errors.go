@@ -38,6 +38,10 @@ var (
 
 	// ErrUnauthorized is returned when an operation is not permitted.
 	ErrUnauthorized = errors.New("unauthorized operation")
+
+	// ErrForbidden is returned when an authenticated user lacks the role an
+	// operation requires.
+	ErrForbidden = errors.New("forbidden operation")
 )
 
 // ValidationError is a custom error type for validation errors.
@@ -72,3 +76,88 @@ func NewValidationError(field, message string, err error) *ValidationError {
 		Err:     err,
 	}
 }
+
+// ErrorCode is a stable, machine-readable identifier for a class of error.
+// API clients can switch on it instead of parsing human-readable messages,
+// so existing values must never be renamed.
+type ErrorCode string
+
+// Error codes returned to API clients.
+const (
+	CodeInternal             ErrorCode = "INTERNAL_ERROR"
+	CodeBadRequest           ErrorCode = "BAD_REQUEST"
+	CodeValidationFailed     ErrorCode = "VALIDATION_FAILED"
+	CodeInvalidID            ErrorCode = "INVALID_ID"
+	CodeInvalidEmail         ErrorCode = "INVALID_EMAIL"
+	CodeInvalidPassword      ErrorCode = "INVALID_PASSWORD"
+	CodeInvalidOutcome       ErrorCode = "INVALID_OUTCOME"
+	CodeInvalidCardContent   ErrorCode = "INVALID_CARD_CONTENT"
+	CodeInvalidMemoStatus    ErrorCode = "INVALID_MEMO_STATUS"
+	CodeInvalidTimezone      ErrorCode = "INVALID_TIMEZONE"
+	CodeInvalidToken         ErrorCode = "INVALID_TOKEN"
+	CodeTokenExpired         ErrorCode = "TOKEN_EXPIRED"
+	CodeTokenUsed            ErrorCode = "TOKEN_ALREADY_USED"
+	CodeUnauthorized         ErrorCode = "UNAUTHORIZED"
+	CodeInvalidCredentials   ErrorCode = "INVALID_CREDENTIALS"
+	CodeLoginLocked          ErrorCode = "TOO_MANY_LOGIN_ATTEMPTS"
+	CodeAccountDisabled      ErrorCode = "ACCOUNT_DISABLED"
+	CodeForbidden            ErrorCode = "FORBIDDEN"
+	CodeEmailNotVerified     ErrorCode = "EMAIL_NOT_VERIFIED"
+	CodeCardNotOwned         ErrorCode = "CARD_NOT_OWNED"
+	CodeMemoNotOwned         ErrorCode = "MEMO_NOT_OWNED"
+	CodeDeckNotOwned         ErrorCode = "DECK_NOT_OWNED"
+	CodeNotFound             ErrorCode = "NOT_FOUND"
+	CodeUserNotFound         ErrorCode = "USER_NOT_FOUND"
+	CodeCardNotFound         ErrorCode = "CARD_NOT_FOUND"
+	CodeCardStatsNotFound    ErrorCode = "CARD_STATS_NOT_FOUND"
+	CodeMemoNotFound         ErrorCode = "MEMO_NOT_FOUND"
+	CodeDeckNotFound         ErrorCode = "DECK_NOT_FOUND"
+	CodeConflict             ErrorCode = "CONFLICT"
+	CodeEmailExists          ErrorCode = "EMAIL_EXISTS"
+	CodeDeckNameExists       ErrorCode = "DECK_NAME_EXISTS"
+	CodeVersionConflict      ErrorCode = "VERSION_CONFLICT"
+	CodeMemoNotDraft         ErrorCode = "MEMO_NOT_DRAFT"
+	CodeMemoGenerating       ErrorCode = "MEMO_GENERATION_IN_PROGRESS"
+	CodeMemoTransition       ErrorCode = "MEMO_STATUS_TRANSITION_INVALID"
+	CodeCardsNotDuplicates   ErrorCode = "CARDS_NOT_DUPLICATES"
+	CodeIdempotencyReused    ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	CodeNoCardsDue           ErrorCode = "NO_CARDS_DUE"
+	CodeRateLimited          ErrorCode = "RATE_LIMITED"
+	CodeRequestTooLarge      ErrorCode = "REQUEST_TOO_LARGE"
+	CodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	CodeImportRejected       ErrorCode = "IMPORT_REJECTED"
+	CodeQuotaExceeded        ErrorCode = "GENERATION_QUOTA_EXCEEDED"
+	CodeRequestTimeout       ErrorCode = "REQUEST_TIMEOUT"
+	CodeCardContentTooLarge  ErrorCode = "CARD_CONTENT_TOO_LARGE"
+)
+
+// DomainError is an error carrying a stable ErrorCode and a message that is
+// safe to show to API clients. It wraps an underlying error, usually one of
+// the sentinel errors, so errors.Is keeps working on the wrapped value.
+type DomainError struct {
+	Code    ErrorCode // Machine-readable error code
+	Message string    // Human-readable message, safe to expose
+	Err     error     // Underlying error
+}
+
+// Error implements the error interface for DomainError.
+func (e *DomainError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the wrapped error to support errors.Is/errors.As.
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// NewDomainError creates a new DomainError with the given code, message, and wrapped error.
+func NewDomainError(code ErrorCode, message string, err error) *DomainError {
+	return &DomainError{
+		Code:    code,
+		Message: message,
+		Err:     err,
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// GenerationPeriod returns the start of the quota period containing t: midnight
+// UTC on the first day of its month. Generation quotas are counted per period.
+func GenerationPeriod(t time.Time) time.Time {
+	year, month, _ := t.UTC().Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// NextGenerationPeriod returns the start of the quota period after the one
+// containing t, which is when a user who has used up their quota may generate
+// cards again.
+func NextGenerationPeriod(t time.Time) time.Time {
+	return GenerationPeriod(t).AddDate(0, 1, 0)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerationPeriod(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		wantThis time.Time
+		wantNext time.Time
+	}{
+		{
+			name:     "middle of the month",
+			at:       time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC),
+			wantThis: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "end of the year",
+			at:       time.Date(2025, time.December, 31, 23, 59, 59, 0, time.UTC),
+			wantThis: time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// Already April in Berlin, but periods are counted in UTC
+			name:     "non-UTC time",
+			at:       time.Date(2025, time.April, 1, 0, 30, 0, 0, berlin),
+			wantThis: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerationPeriod(tt.at); !got.Equal(tt.wantThis) {
+				t.Errorf("GenerationPeriod() = %v, want %v", got, tt.wantThis)
+			}
+			if got := NextGenerationPeriod(tt.at); !got.Equal(tt.wantNext) {
+				t.Errorf("NextGenerationPeriod() = %v, want %v", got, tt.wantNext)
+			}
+		})
+	}
+}
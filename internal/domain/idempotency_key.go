@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxIdempotencyKeyLength is the longest Idempotency-Key value that is accepted.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey-specific validation errors
+var (
+	// ErrIdempotencyKeyEmpty is returned when an idempotency key is empty.
+	ErrIdempotencyKeyEmpty = errors.New("idempotency key cannot be empty")
+
+	// ErrIdempotencyKeyTooLong is returned when an idempotency key exceeds MaxIdempotencyKeyLength.
+	ErrIdempotencyKeyTooLong = errors.New("idempotency key is too long")
+
+	// ErrIdempotencyKeyUserIDEmpty is returned when an idempotency key has no user ID.
+	ErrIdempotencyKeyUserIDEmpty = errors.New("idempotency key user ID cannot be empty")
+
+	// ErrIdempotencyKeyMemoIDEmpty is returned when an idempotency key has no memo ID.
+	ErrIdempotencyKeyMemoIDEmpty = errors.New("idempotency key memo ID cannot be empty")
+
+	// ErrIdempotencyKeyHashEmpty is returned when an idempotency key has no request hash.
+	ErrIdempotencyKeyHashEmpty = errors.New("idempotency key request hash cannot be empty")
+)
+
+// IdempotencyKey records a client-supplied Idempotency-Key sent with a memo creation
+// request, so that a replay of the same request returns the memo it created instead
+// of creating another one. Keys are scoped to the user that sent them.
+type IdempotencyKey struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Key         string    `json:"key"`
+	RequestHash string    `json:"request_hash"`
+	MemoID      uuid.UUID `json:"memo_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewIdempotencyKey creates a new IdempotencyKey linking key to the memo created
+// by the request whose body hashes to requestHash.
+// Returns an error if validation fails.
+func NewIdempotencyKey(userID uuid.UUID, key, requestHash string, memoID uuid.UUID) (*IdempotencyKey, error) {
+	k := &IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash,
+		MemoID:      memoID,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Validate checks if the IdempotencyKey has valid data.
+// Returns an error if any field fails validation.
+func (k *IdempotencyKey) Validate() error {
+	if err := ValidateIdempotencyKey(k.Key); err != nil {
+		return err
+	}
+
+	if k.UserID == uuid.Nil {
+		return ErrIdempotencyKeyUserIDEmpty
+	}
+
+	if k.MemoID == uuid.Nil {
+		return ErrIdempotencyKeyMemoIDEmpty
+	}
+
+	if k.RequestHash == "" {
+		return ErrIdempotencyKeyHashEmpty
+	}
+
+	return nil
+}
+
+// IsExpired reports whether the key is older than ttl at time now.
+func (k *IdempotencyKey) IsExpired(now time.Time, ttl time.Duration) bool {
+	return now.Sub(k.CreatedAt) >= ttl
+}
+
+// ValidateIdempotencyKey checks that a client-supplied key is non-empty and
+// no longer than MaxIdempotencyKeyLength.
+func ValidateIdempotencyKey(key string) error {
+	if key == "" {
+		return ErrIdempotencyKeyEmpty
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return ErrIdempotencyKeyTooLong
+	}
+	return nil
+}
+
+// HashIdempotentRequest returns the hex SHA-256 of the given request parts.
+// Parts are length-prefixed so that different splits of the same bytes hash differently.
+func HashIdempotentRequest(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(part)))
+		h.Write(length[:])
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
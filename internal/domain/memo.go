@@ -2,7 +2,12 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -12,11 +17,16 @@ type MemoStatus string
 
 // Possible memo status values
 const (
+	MemoStatusDraft               MemoStatus = "draft"
 	MemoStatusPending             MemoStatus = "pending"
 	MemoStatusProcessing          MemoStatus = "processing"
 	MemoStatusCompleted           MemoStatus = "completed"
 	MemoStatusCompletedWithErrors MemoStatus = "completed_with_errors"
 	MemoStatusFailed              MemoStatus = "failed"
+
+	// MemoStatusBlocked means the generation provider refused the memo's text
+	// for content safety. Memo.StatusReason says why.
+	MemoStatusBlocked MemoStatus = "blocked"
 )
 
 // Memo-specific validation errors
@@ -30,10 +40,156 @@ var (
 	// ErrMemoTextEmpty is returned when a memo's text is empty.
 	ErrMemoTextEmpty = errors.New("memo text cannot be empty")
 
+	// ErrMemoTextTooShort is wrapped by the ValidationError returned when a
+	// memo's text has fewer non-whitespace characters than the minimum.
+	ErrMemoTextTooShort = errors.New("memo text is too short")
+
+	// ErrMemoTextTooLong is wrapped by the ValidationError returned when a
+	// memo's text is longer than the maximum.
+	ErrMemoTextTooLong = errors.New("memo text is too long")
+
 	// ErrMemoStatusInvalid is returned when a memo status is not valid.
 	ErrMemoStatusInvalid = errors.New("invalid memo status")
+
+	// ErrMemoStatusTransitionInvalid is returned when a memo cannot move from its
+	// current status to the requested one.
+	ErrMemoStatusTransitionInvalid = errors.New("invalid memo status transition")
+)
+
+// MemoTextLimits bounds the length of a memo's text, counted in characters.
+// MinLength counts only non-whitespace characters, so text made of spaces and
+// line breaks is rejected; MaxLength counts every character. A MaxLength of 0
+// leaves the length unlimited.
+type MemoTextLimits struct {
+	MinLength int
+	MaxLength int
+}
+
+// DefaultMemoTextLimits accepts any text with at least one non-whitespace
+// character and at most 10000 characters.
+var DefaultMemoTextLimits = MemoTextLimits{MinLength: 1, MaxLength: 10000}
+
+var (
+	memoTextLimitsMu sync.RWMutex
+	memoTextLimits   = DefaultMemoTextLimits
 )
 
+// SetMemoTextLimits replaces the limits applied by Memo.Validate and returns
+// the previous ones, so callers such as tests can restore them.
+func SetMemoTextLimits(limits MemoTextLimits) MemoTextLimits {
+	memoTextLimitsMu.Lock()
+	defer memoTextLimitsMu.Unlock()
+	previous := memoTextLimits
+	memoTextLimits = limits
+	return previous
+}
+
+// ValidateMemoText checks text against the configured MemoTextLimits.
+// Returns ErrMemoTextEmpty for empty text, and a *ValidationError on the text
+// field wrapping ErrMemoTextTooShort or ErrMemoTextTooLong otherwise.
+func ValidateMemoText(text string) error {
+	if text == "" {
+		return ErrMemoTextEmpty
+	}
+
+	memoTextLimitsMu.RLock()
+	limits := memoTextLimits
+	memoTextLimitsMu.RUnlock()
+
+	if limits.MaxLength > 0 && utf8.RuneCountInString(text) > limits.MaxLength {
+		return NewValidationError("text",
+			fmt.Sprintf("must be at most %d characters", limits.MaxLength), ErrMemoTextTooLong)
+	}
+
+	nonSpace := 0
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			nonSpace++
+		}
+	}
+	if nonSpace < max(limits.MinLength, 1) {
+		if strings.TrimSpace(text) == "" {
+			return NewValidationError("text", "cannot be blank", ErrMemoTextTooShort)
+		}
+		return NewValidationError("text",
+			fmt.Sprintf("must contain at least %d non-whitespace characters", limits.MinLength),
+			ErrMemoTextTooShort)
+	}
+
+	return nil
+}
+
+// memoStatusTransitions is the canonical memo status state machine: each status
+// maps to the statuses a memo may move to from it. Completed and failed memos are
+// terminal for ordinary status updates; the only way back to pending is an explicit
+// regeneration with new text (see Memo.Regenerate), which is allowed from every
+// status in memoRegenerableStatuses.
+var memoStatusTransitions = map[MemoStatus][]MemoStatus{
+	MemoStatusDraft:   {MemoStatusPending},
+	MemoStatusPending: {MemoStatusProcessing, MemoStatusFailed},
+	MemoStatusProcessing: {
+		MemoStatusCompleted, MemoStatusCompletedWithErrors, MemoStatusFailed, MemoStatusBlocked,
+	},
+	MemoStatusCompleted:           {},
+	MemoStatusCompletedWithErrors: {},
+	MemoStatusFailed:              {},
+	MemoStatusBlocked:             {},
+}
+
+// memoRegenerableStatuses are the statuses from which Memo.Regenerate may move a
+// memo back to pending. Pending and processing memos are excluded so that a
+// generation already under way is never raced by another.
+var memoRegenerableStatuses = []MemoStatus{
+	MemoStatusDraft,
+	MemoStatusCompleted,
+	MemoStatusCompletedWithErrors,
+	MemoStatusFailed,
+	MemoStatusBlocked,
+}
+
+// CanRegenerate reports whether a memo in status s may be regenerated.
+func (s MemoStatus) CanRegenerate() bool {
+	for _, allowed := range memoRegenerableStatuses {
+		if allowed == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether status s ends generation, so a memo in it will
+// not change status again unless it is regenerated.
+func (s MemoStatus) IsTerminal() bool {
+	next, ok := memoStatusTransitions[s]
+	return ok && len(next) == 0
+}
+
+// CanTransitionTo reports whether a memo in status s may move to next.
+// Staying in the same status is always allowed so that retried work is idempotent.
+func (s MemoStatus) CanTransitionTo(next MemoStatus) bool {
+	if s == next {
+		return true
+	}
+	for _, allowed := range memoStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateMemoStatusTransition returns an error wrapping ErrMemoStatusTransitionInvalid
+// if a memo may not move from status from to status to.
+func ValidateMemoStatusTransition(from, to MemoStatus) error {
+	if !isValidMemoStatus(to) {
+		return ErrMemoStatusInvalid
+	}
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s to %s", ErrMemoStatusTransitionInvalid, from, to)
+	}
+	return nil
+}
+
 // Memo represents a text-based entry submitted by a user
 // to generate flashcards. It tracks both the original content
 // and the processing state.
@@ -44,6 +200,18 @@ type Memo struct {
 	Status    MemoStatus `json:"status"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
+
+	// GenerationDuration is how long the generation task took to produce and
+	// save the memo's cards. Zero until generation has completed.
+	GenerationDuration time.Duration `json:"generation_duration,omitempty"`
+
+	// StatusReason is a user-facing explanation of the status, set only for
+	// blocked memos
+	StatusReason string `json:"status_reason,omitempty"`
+
+	// DeckID is the deck that cards generated from the memo are placed in,
+	// or nil to leave them without one
+	DeckID *uuid.UUID `json:"deck_id,omitempty"`
 }
 
 // NewMemo creates a new Memo with the given user ID and text.
@@ -67,6 +235,19 @@ func NewMemo(userID uuid.UUID, text string) (*Memo, error) {
 	return memo, nil
 }
 
+// NewDraftMemo creates a new Memo with the given user ID and text in draft status.
+// Draft memos are stored without being queued for card generation.
+// Returns an error if validation fails.
+func NewDraftMemo(userID uuid.UUID, text string) (*Memo, error) {
+	memo, err := NewMemo(userID, text)
+	if err != nil {
+		return nil, err
+	}
+
+	memo.Status = MemoStatusDraft
+	return memo, nil
+}
+
 // Validate checks if the Memo has valid data.
 // Returns an error if any field fails validation.
 func (m *Memo) Validate() error {
@@ -78,8 +259,8 @@ func (m *Memo) Validate() error {
 		return ErrMemoUserIDEmpty
 	}
 
-	if m.Text == "" {
-		return ErrMemoTextEmpty
+	if err := ValidateMemoText(m.Text); err != nil {
+		return err
 	}
 
 	if !isValidMemoStatus(m.Status) {
@@ -89,23 +270,76 @@ func (m *Memo) Validate() error {
 	return nil
 }
 
-// UpdateStatus updates the memo's status and updates the UpdatedAt timestamp.
-// Returns an error if the new status is invalid.
+// UpdateStatus updates the memo's status and updates the UpdatedAt timestamp,
+// clearing any status reason. Returns an error if the new status is invalid.
 func (m *Memo) UpdateStatus(status MemoStatus) error {
 	if !isValidMemoStatus(status) {
 		return ErrMemoStatusInvalid
 	}
 
 	m.Status = status
+	m.StatusReason = ""
 	m.UpdatedAt = time.Now().UTC()
 	return nil
 }
 
+// Block moves the memo to blocked status with a user-facing reason, such as
+// why the generation provider refused its text, and updates the UpdatedAt
+// timestamp. Like UpdateStatus, it does not check the status state machine;
+// use ValidateMemoStatusTransition first where transitions are enforced.
+func (m *Memo) Block(reason string) error {
+	if err := m.UpdateStatus(MemoStatusBlocked); err != nil {
+		return err
+	}
+	m.StatusReason = reason
+	return nil
+}
+
+// RecordGenerationDuration stores how long card generation took for the memo
+// and updates the UpdatedAt timestamp. Negative durations are stored as zero.
+func (m *Memo) RecordGenerationDuration(d time.Duration) {
+	m.GenerationDuration = max(d, 0)
+	m.UpdatedAt = time.Now().UTC()
+}
+
+// TransitionStatus moves the memo to the given status, enforcing the canonical
+// status state machine, and updates the UpdatedAt timestamp.
+// Returns ErrMemoStatusInvalid for an unknown status and an error wrapping
+// ErrMemoStatusTransitionInvalid if the transition is not allowed.
+func (m *Memo) TransitionStatus(status MemoStatus) error {
+	if err := ValidateMemoStatusTransition(m.Status, status); err != nil {
+		return err
+	}
+	return m.UpdateStatus(status)
+}
+
+// Regenerate replaces the memo's text and moves it back to pending so its cards
+// can be generated again, clearing the previous generation duration.
+// Returns the ValidateMemoText error for text outside the length limits and an
+// error wrapping ErrMemoStatusTransitionInvalid if the memo is pending or processing.
+func (m *Memo) Regenerate(text string) error {
+	if err := ValidateMemoText(text); err != nil {
+		return err
+	}
+	if !m.Status.CanRegenerate() {
+		return fmt.Errorf("%w: cannot regenerate %s memo", ErrMemoStatusTransitionInvalid, m.Status)
+	}
+
+	m.Text = text
+	m.GenerationDuration = 0
+	return m.UpdateStatus(MemoStatusPending)
+}
+
+// IsValid reports whether s is one of the defined memo statuses.
+func (s MemoStatus) IsValid() bool {
+	return isValidMemoStatus(s)
+}
+
 // isValidMemoStatus checks if the given status is a valid MemoStatus.
 func isValidMemoStatus(status MemoStatus) bool {
 	switch status {
-	case MemoStatusPending, MemoStatusProcessing, MemoStatusCompleted,
-		MemoStatusCompletedWithErrors, MemoStatusFailed:
+	case MemoStatusDraft, MemoStatusPending, MemoStatusProcessing, MemoStatusCompleted,
+		MemoStatusCompletedWithErrors, MemoStatusFailed, MemoStatusBlocked:
 		return true
 	default:
 		return false
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -55,6 +57,30 @@ func TestNewMemo(t *testing.T) {
 	}
 }
 
+func TestNewDraftMemo(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+	userID := uuid.New()
+
+	memo, err := NewDraftMemo(userID, "A memo to generate cards from later.")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if memo.Status != MemoStatusDraft {
+		t.Errorf("Expected status %s, got %s", MemoStatusDraft, memo.Status)
+	}
+
+	if err := memo.Validate(); err != nil {
+		t.Errorf("Expected draft memo to be valid, got %v", err)
+	}
+
+	// Test invalid text
+	_, err = NewDraftMemo(userID, "")
+	if err != ErrMemoTextEmpty {
+		t.Errorf("Expected error %v, got %v", ErrMemoTextEmpty, err)
+	}
+}
+
 func TestMemoValidate(t *testing.T) {
 	t.Parallel() // Enable parallel execution
 	validMemo := Memo{
@@ -125,11 +151,13 @@ func TestUpdateStatus(t *testing.T) {
 
 	// Test all valid status transitions
 	validStatuses := []MemoStatus{
+		MemoStatusDraft,
 		MemoStatusPending,
 		MemoStatusProcessing,
 		MemoStatusCompleted,
 		MemoStatusCompletedWithErrors,
 		MemoStatusFailed,
+		MemoStatusBlocked,
 	}
 
 	for _, status := range validStatuses {
@@ -149,3 +177,212 @@ func TestUpdateStatus(t *testing.T) {
 		t.Errorf("Expected error %v, got %v", ErrMemoStatusInvalid, err)
 	}
 }
+
+func TestMemoStatusTransitions(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	legal := []struct{ from, to MemoStatus }{
+		{MemoStatusDraft, MemoStatusPending},
+		{MemoStatusPending, MemoStatusProcessing},
+		{MemoStatusPending, MemoStatusFailed},
+		{MemoStatusProcessing, MemoStatusCompleted},
+		{MemoStatusProcessing, MemoStatusCompletedWithErrors},
+		{MemoStatusProcessing, MemoStatusFailed},
+		{MemoStatusProcessing, MemoStatusBlocked},
+		{MemoStatusProcessing, MemoStatusProcessing},
+		{MemoStatusCompleted, MemoStatusCompleted},
+	}
+	for _, tc := range legal {
+		if err := ValidateMemoStatusTransition(tc.from, tc.to); err != nil {
+			t.Errorf("Expected %s -> %s to be allowed, got %v", tc.from, tc.to, err)
+		}
+	}
+
+	illegal := []struct{ from, to MemoStatus }{
+		{MemoStatusCompleted, MemoStatusPending},
+		{MemoStatusCompletedWithErrors, MemoStatusPending},
+		{MemoStatusFailed, MemoStatusProcessing},
+		{MemoStatusCompleted, MemoStatusProcessing},
+		{MemoStatusDraft, MemoStatusProcessing},
+		{MemoStatusDraft, MemoStatusCompleted},
+		{MemoStatusPending, MemoStatusDraft},
+		{MemoStatusPending, MemoStatusCompleted},
+		{MemoStatusProcessing, MemoStatusPending},
+		{MemoStatusPending, MemoStatusBlocked},
+		{MemoStatusBlocked, MemoStatusProcessing},
+	}
+	for _, tc := range illegal {
+		err := ValidateMemoStatusTransition(tc.from, tc.to)
+		if !errors.Is(err, ErrMemoStatusTransitionInvalid) {
+			t.Errorf("Expected %s -> %s to be rejected with %v, got %v",
+				tc.from, tc.to, ErrMemoStatusTransitionInvalid, err)
+		}
+	}
+
+	if err := ValidateMemoStatusTransition(MemoStatusPending, "invalid_status"); err != ErrMemoStatusInvalid {
+		t.Errorf("Expected error %v, got %v", ErrMemoStatusInvalid, err)
+	}
+}
+
+func TestMemoStatusIsTerminal(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	terminal := map[MemoStatus]bool{
+		MemoStatusDraft:               false,
+		MemoStatusPending:             false,
+		MemoStatusProcessing:          false,
+		MemoStatusCompleted:           true,
+		MemoStatusCompletedWithErrors: true,
+		MemoStatusFailed:              true,
+		MemoStatusBlocked:             true,
+		"invalid_status":              false,
+	}
+	for status, want := range terminal {
+		if got := status.IsTerminal(); got != want {
+			t.Errorf("Expected %s IsTerminal() to be %v, got %v", status, want, got)
+		}
+	}
+}
+
+func TestTransitionStatus(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+	memo := Memo{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Text:   "Test memo",
+		Status: MemoStatusDraft,
+	}
+
+	// Walk the happy path through the state machine
+	for _, status := range []MemoStatus{MemoStatusPending, MemoStatusProcessing, MemoStatusCompleted} {
+		if err := memo.TransitionStatus(status); err != nil {
+			t.Fatalf("Expected transition to %s to succeed, got %v", status, err)
+		}
+		if memo.Status != status {
+			t.Errorf("Expected status %s, got %s", status, memo.Status)
+		}
+	}
+
+	// Completed memos cannot be sent back for generation
+	err := memo.TransitionStatus(MemoStatusPending)
+	if !errors.Is(err, ErrMemoStatusTransitionInvalid) {
+		t.Errorf("Expected error %v, got %v", ErrMemoStatusTransitionInvalid, err)
+	}
+	if memo.Status != MemoStatusCompleted {
+		t.Errorf("Expected status to remain %s, got %s", MemoStatusCompleted, memo.Status)
+	}
+}
+
+func TestMemoBlock(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+	memo := Memo{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Text:   "Test memo",
+		Status: MemoStatusProcessing,
+	}
+
+	if err := memo.Block("Blocked for safety"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if memo.Status != MemoStatusBlocked || memo.StatusReason != "Blocked for safety" {
+		t.Errorf("Expected blocked memo with reason, got %+v", memo)
+	}
+
+	// Moving to any other status clears the reason
+	if err := memo.UpdateStatus(MemoStatusFailed); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if memo.StatusReason != "" {
+		t.Errorf("Expected reason to be cleared, got %q", memo.StatusReason)
+	}
+}
+
+func TestMemoRegenerate(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	for _, status := range []MemoStatus{
+		MemoStatusDraft, MemoStatusCompleted, MemoStatusCompletedWithErrors, MemoStatusFailed, MemoStatusBlocked,
+	} {
+		memo := Memo{
+			ID:                 uuid.New(),
+			UserID:             uuid.New(),
+			Text:               "Original text",
+			Status:             status,
+			GenerationDuration: time.Second,
+		}
+		if status == MemoStatusBlocked {
+			memo.StatusReason = "Blocked for safety"
+		}
+		if err := memo.Regenerate("New text"); err != nil {
+			t.Fatalf("Expected regeneration from %s to succeed, got %v", status, err)
+		}
+		if memo.Status != MemoStatusPending || memo.Text != "New text" || memo.GenerationDuration != 0 ||
+			memo.StatusReason != "" {
+			t.Errorf("Expected pending memo with new text and no duration, got %+v", memo)
+		}
+	}
+
+	// Memos already being generated cannot be regenerated
+	for _, status := range []MemoStatus{MemoStatusPending, MemoStatusProcessing} {
+		memo := Memo{ID: uuid.New(), UserID: uuid.New(), Text: "Original text", Status: status}
+		err := memo.Regenerate("New text")
+		if !errors.Is(err, ErrMemoStatusTransitionInvalid) {
+			t.Errorf("Expected error %v for %s memo, got %v", ErrMemoStatusTransitionInvalid, status, err)
+		}
+		if memo.Text != "Original text" {
+			t.Errorf("Expected text to be unchanged for %s memo, got %q", status, memo.Text)
+		}
+	}
+
+	memo := Memo{ID: uuid.New(), UserID: uuid.New(), Text: "Original text", Status: MemoStatusCompleted}
+	if err := memo.Regenerate(""); err != ErrMemoTextEmpty {
+		t.Errorf("Expected error %v, got %v", ErrMemoTextEmpty, err)
+	}
+}
+
+// TestValidateMemoText replaces the package-wide limits, so it must not run
+// in parallel with other tests that validate memos.
+func TestValidateMemoText(t *testing.T) {
+	previous := SetMemoTextLimits(MemoTextLimits{MinLength: 3, MaxLength: 10})
+	t.Cleanup(func() { SetMemoTextLimits(previous) })
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr error
+	}{
+		{name: "exactly_max", text: "abcdefghij"},
+		{name: "max_plus_one", text: "abcdefghijk", wantErr: ErrMemoTextTooLong},
+		{name: "max_counts_characters_not_bytes", text: "éééééééééé"},
+		{name: "exactly_min", text: " a b c "},
+		{name: "below_min", text: "ab   ", wantErr: ErrMemoTextTooShort},
+		{name: "empty", text: "", wantErr: ErrMemoTextEmpty},
+		{name: "whitespace_only", text: " \t\n ", wantErr: ErrMemoTextTooShort},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			memo := Memo{ID: uuid.New(), UserID: uuid.New(), Text: tc.text, Status: MemoStatusPending}
+			err := memo.Validate()
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+
+			var validationErr *ValidationError
+			if tc.wantErr != nil && tc.wantErr != ErrMemoTextEmpty &&
+				(!errors.As(err, &validationErr) || validationErr.Field != "text") {
+				t.Errorf("Expected a ValidationError on the text field, got %v", err)
+			}
+		})
+	}
+
+	if err := ValidateMemoText("abcdefghijk"); err == nil || err.Error() !=
+		"validation error on field text: must be at most 10 characters" {
+		t.Errorf("Expected the limit in the error message, got %v", err)
+	}
+
+	memo := Memo{ID: uuid.New(), UserID: uuid.New(), Text: "Original text", Status: MemoStatusCompleted}
+	if err := memo.Regenerate("abcdefghijk"); !errors.Is(err, ErrMemoTextTooLong) {
+		t.Errorf("Expected regeneration with too long text to fail with %v, got %v", ErrMemoTextTooLong, err)
+	}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import "errors"
+
+// Bounds and defaults for the settings that new cards' stats start from.
+// The ease factor bounds match the limits applied by the SRS algorithm.
+const (
+	DefaultNewCardEaseFactor = 2.5
+	MinNewCardEaseFactor     = 1.3
+	MaxNewCardEaseFactor     = 2.5
+	MaxNewCardIntervalDays   = 365
+)
+
+// New card settings validation errors
+var (
+	// ErrNewCardIntervalInvalid is returned when a new card interval is not
+	// between 0 and MaxNewCardIntervalDays days.
+	ErrNewCardIntervalInvalid = errors.New("new card interval is out of range")
+
+	// ErrNewCardEaseFactorInvalid is returned when a new card ease factor is not
+	// between MinNewCardEaseFactor and MaxNewCardEaseFactor.
+	ErrNewCardEaseFactorInvalid = errors.New("new card ease factor is out of range")
+)
+
+// NewCardSettings are the values that the stats of a newly created card start from.
+type NewCardSettings struct {
+	// IntervalDays is the card's starting interval in days.
+	IntervalDays int
+
+	// EaseFactor is the card's starting ease factor.
+	EaseFactor float64
+
+	// DueImmediately makes new cards due as soon as they are created.
+	// Otherwise their first review is IntervalDays after creation.
+	DueImmediately bool
+}
+
+// DefaultNewCardSettings returns the settings new cards use when neither their
+// user nor their deck overrides them: no interval, the default ease factor,
+// and due immediately.
+func DefaultNewCardSettings() NewCardSettings {
+	return NewCardSettings{
+		IntervalDays:   0,
+		EaseFactor:     DefaultNewCardEaseFactor,
+		DueImmediately: true,
+	}
+}
+
+// Validate checks that the settings are within range.
+func (s NewCardSettings) Validate() error {
+	if s.IntervalDays < 0 || s.IntervalDays > MaxNewCardIntervalDays {
+		return ErrNewCardIntervalInvalid
+	}
+
+	if s.EaseFactor < MinNewCardEaseFactor || s.EaseFactor > MaxNewCardEaseFactor {
+		return ErrNewCardEaseFactorInvalid
+	}
+
+	return nil
+}
+
+// NewCardOverrides replaces selected new card settings for a user.
+// Nil fields keep the defaults.
+type NewCardOverrides struct {
+	IntervalDays   *int     `json:"interval_days,omitempty"`
+	EaseFactor     *float64 `json:"ease_factor,omitempty"`
+	DueImmediately *bool    `json:"due_immediately,omitempty"`
+}
+
+// Validate checks that every set override is within range.
+func (o NewCardOverrides) Validate() error {
+	if o.IntervalDays != nil && (*o.IntervalDays < 0 || *o.IntervalDays > MaxNewCardIntervalDays) {
+		return ErrNewCardIntervalInvalid
+	}
+
+	if o.EaseFactor != nil && (*o.EaseFactor < MinNewCardEaseFactor || *o.EaseFactor > MaxNewCardEaseFactor) {
+		return ErrNewCardEaseFactorInvalid
+	}
+
+	return nil
+}
+
+// WithOverrides returns the settings with every set override applied.
+func (s NewCardSettings) WithOverrides(o NewCardOverrides) NewCardSettings {
+	if o.IntervalDays != nil {
+		s.IntervalDays = *o.IntervalDays
+	}
+	if o.EaseFactor != nil {
+		s.EaseFactor = *o.EaseFactor
+	}
+	if o.DueImmediately != nil {
+		s.DueImmediately = *o.DueImmediately
+	}
+	return s
+}
+
+// ResolveNewCardSettings returns the settings for a new card of user in deck.
+// The deck's settings take precedence over the user's, which take precedence
+// over DefaultNewCardSettings. Either may be nil.
+func ResolveNewCardSettings(user *User, deck *Deck) NewCardSettings {
+	settings := DefaultNewCardSettings()
+	if user != nil {
+		settings = settings.WithOverrides(user.NewCardOverrides)
+	}
+	if deck != nil {
+		settings = settings.WithOverrides(deck.SRSSettings.NewCardOverrides())
+	}
+	return settings
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestResolveNewCardSettings(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	boolPtr := func(v bool) *bool { return &v }
+
+	user := &User{NewCardOverrides: NewCardOverrides{
+		IntervalDays:   intPtr(2),
+		EaseFactor:     floatPtr(2.2),
+		DueImmediately: boolPtr(false),
+	}}
+	deck := &Deck{SRSSettings: DeckSRSSettings{NewCardIntervalDays: intPtr(5), InitialEaseFactor: floatPtr(1.8)}}
+
+	tests := []struct {
+		name string
+		user *User
+		deck *Deck
+		want NewCardSettings
+	}{
+		{"defaults", nil, nil, DefaultNewCardSettings()},
+		{"no_overrides", &User{}, &Deck{}, DefaultNewCardSettings()},
+		{"user_overrides", user, nil, NewCardSettings{IntervalDays: 2, EaseFactor: 2.2, DueImmediately: false}},
+		{"deck_overrides", nil, deck, NewCardSettings{IntervalDays: 5, EaseFactor: 1.8, DueImmediately: true}},
+		{"deck_wins_over_user", user, deck, NewCardSettings{IntervalDays: 5, EaseFactor: 1.8, DueImmediately: false}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ResolveNewCardSettings(tc.user, tc.deck); got != tc.want {
+				t.Errorf("Expected settings %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNewCardOverridesValidate(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name      string
+		overrides NewCardOverrides
+		wantErr   error
+	}{
+		{"none", NewCardOverrides{}, nil},
+		{"in_range", NewCardOverrides{IntervalDays: intPtr(MaxNewCardIntervalDays), EaseFactor: floatPtr(1.3)}, nil},
+		{"negative_interval", NewCardOverrides{IntervalDays: intPtr(-1)}, ErrNewCardIntervalInvalid},
+		{"interval_too_long", NewCardOverrides{IntervalDays: intPtr(MaxNewCardIntervalDays + 1)}, ErrNewCardIntervalInvalid},
+		{"ease_too_low", NewCardOverrides{EaseFactor: floatPtr(1.2)}, ErrNewCardEaseFactorInvalid},
+		{"ease_too_high", NewCardOverrides{EaseFactor: floatPtr(2.6)}, ErrNewCardEaseFactorInvalid},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.overrides.Validate(); err != tc.wantErr {
+				t.Errorf("Expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewUserCardStatsWithSettings(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("due_after_interval", func(t *testing.T) {
+		settings := NewCardSettings{IntervalDays: 3, EaseFactor: 2.0, DueImmediately: false}
+		stats, err := NewUserCardStatsWithSettings(uuid.New(), uuid.New(), settings, now)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if stats.Interval != 3 {
+			t.Errorf("Expected interval 3, got %d", stats.Interval)
+		}
+		if stats.EaseFactor != 2.0 {
+			t.Errorf("Expected ease factor 2.0, got %f", stats.EaseFactor)
+		}
+		if want := now.AddDate(0, 0, 3); !stats.NextReviewAt.Equal(want) {
+			t.Errorf("Expected NextReviewAt %v, got %v", want, stats.NextReviewAt)
+		}
+	})
+
+	t.Run("due_immediately", func(t *testing.T) {
+		settings := NewCardSettings{IntervalDays: 3, EaseFactor: 2.0, DueImmediately: true}
+		stats, err := NewUserCardStatsWithSettings(uuid.New(), uuid.New(), settings, now)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if stats.Interval != 3 {
+			t.Errorf("Expected interval 3, got %d", stats.Interval)
+		}
+		if !stats.NextReviewAt.Equal(now) {
+			t.Errorf("Expected NextReviewAt %v, got %v", now, stats.NextReviewAt)
+		}
+	})
+
+	t.Run("invalid_settings", func(t *testing.T) {
+		settings := NewCardSettings{IntervalDays: 0, EaseFactor: 3.0, DueImmediately: true}
+		if _, err := NewUserCardStatsWithSettings(uuid.New(), uuid.New(), settings, now); err != ErrNewCardEaseFactorInvalid {
+			t.Errorf("Expected error %v, got %v", ErrNewCardEaseFactorInvalid, err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Password length bounds that hold whatever the password policy
+const (
+	// MinPasswordLength is the shortest password accepted, and the default
+	// minimum of the password policy.
+	MinPasswordLength = 12
+
+	// MaxPasswordLength is the longest password accepted, as bcrypt ignores
+	// anything past 72 bytes.
+	MaxPasswordLength = 72
+)
+
+// ErrPasswordPolicy is wrapped by the ValidationError returned when a password
+// breaks one or more rules of a PasswordPolicy.
+var ErrPasswordPolicy = errors.New("password does not meet the password policy")
+
+// minPasswordClasses is how many character classes a password must mix when a
+// policy requires mixed classes.
+const minPasswordClasses = 3
+
+// PasswordPolicy is the set of rules new passwords must follow, enforced when
+// users register and when they change or reset their password. The zero value
+// only enforces the length bounds.
+type PasswordPolicy struct {
+	// MinLength is the shortest password allowed. Values below
+	// MinPasswordLength, including 0, mean MinPasswordLength.
+	MinLength int
+
+	// RequireMixedClasses requires passwords to mix at least three of
+	// lowercase letters, uppercase letters, digits and symbols.
+	RequireMixedClasses bool
+
+	// MinStrength is the lowest EstimatePasswordStrength score allowed, from 0
+	// to MaxPasswordStrength; 0 accepts any password.
+	MinStrength int
+}
+
+// DefaultPasswordPolicy returns the policy used when none is configured: at
+// least MinPasswordLength characters, with no class or strength requirement.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: MinPasswordLength}
+}
+
+// Check returns nil if password follows every rule of the policy. Otherwise it
+// returns a ValidationError on the "password" field, wrapping ErrPasswordPolicy,
+// whose message lists each rule the password breaks.
+// userInputs, such as the user's email address, are treated as easy to guess
+// when estimating the password's strength.
+func (p PasswordPolicy) Check(password string, userInputs ...string) error {
+	minLength := max(p.MinLength, MinPasswordLength)
+
+	var failures []string
+	if len(password) < minLength {
+		failures = append(failures, fmt.Sprintf("must be at least %d characters long", minLength))
+	}
+	if len(password) > MaxPasswordLength {
+		failures = append(failures, fmt.Sprintf("must be at most %d characters long", MaxPasswordLength))
+	}
+	if p.RequireMixedClasses && passwordClasses(password) < minPasswordClasses {
+		failures = append(failures,
+			"must mix at least three of lowercase letters, uppercase letters, digits and symbols")
+	}
+	if p.MinStrength > 0 {
+		if strength := EstimatePasswordStrength(password, userInputs...); strength < p.MinStrength {
+			failures = append(failures, fmt.Sprintf("is too easy to guess (strength %d of %d, at least %d required)",
+				strength, MaxPasswordStrength, p.MinStrength))
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+	return NewValidationError("password", strings.Join(failures, "; "), ErrPasswordPolicy)
+}
+
+// passwordClasses counts the character classes used in password: lowercase
+// letters, uppercase letters, digits and everything else.
+func passwordClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	count := 0
+	for _, used := range []bool{lower, upper, digit, other} {
+		if used {
+			count++
+		}
+	}
+	return count
+}
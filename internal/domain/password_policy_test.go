@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	const strongPassword = "vT8#qLm2!zRw9p"
+
+	tests := []struct {
+		name      string
+		policy    PasswordPolicy
+		password  string
+		inputs    []string
+		wantRules []string // Substrings of the rules expected to fail; none if valid
+	}{
+		{"default_accepts_minimum_length", DefaultPasswordPolicy(), "123456789012", nil, nil},
+		{"default_rejects_short", DefaultPasswordPolicy(), "12345678901", nil, []string{"at least 12 characters"}},
+		{"zero_value_uses_minimum_length", PasswordPolicy{}, "12345678901", nil, []string{"at least 12 characters"}},
+		{"default_rejects_long", DefaultPasswordPolicy(), strings.Repeat("a", 73), nil, []string{"at most 72 characters"}},
+		{"configured_length_accepts", PasswordPolicy{MinLength: 16}, "vT8#qLm2!zRw9pXy", nil, nil},
+		{
+			"configured_length_rejects",
+			PasswordPolicy{MinLength: 16},
+			strongPassword,
+			nil,
+			[]string{"at least 16 characters"},
+		},
+		{
+			"length_below_floor_uses_floor",
+			PasswordPolicy{MinLength: 8},
+			"abcdefghij",
+			nil,
+			[]string{"at least 12 characters"},
+		},
+		{"mixed_classes_accepts", PasswordPolicy{RequireMixedClasses: true}, "lowercaseAND123", nil, nil},
+		{
+			"mixed_classes_rejects",
+			PasswordPolicy{RequireMixedClasses: true},
+			"lowercaseonly123",
+			nil,
+			[]string{"mix at least three"},
+		},
+		{"strength_accepts_strong", PasswordPolicy{MinStrength: 3}, strongPassword, nil, nil},
+		{
+			"strength_rejects_common",
+			PasswordPolicy{MinStrength: 3},
+			"Password1234!",
+			nil,
+			[]string{"too easy to guess"},
+		},
+		{
+			"strength_rejects_user_inputs",
+			PasswordPolicy{MinStrength: 3},
+			"alice.smith.alice",
+			[]string{"alice.smith@example.com"},
+			[]string{"too easy to guess"},
+		},
+		{
+			"lists_every_failed_rule",
+			PasswordPolicy{MinLength: 20, RequireMixedClasses: true, MinStrength: 4},
+			"qwertyuiop12",
+			nil,
+			[]string{"at least 20 characters", "mix at least three", "too easy to guess"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Check(tc.password, tc.inputs...)
+			if len(tc.wantRules) == 0 {
+				if err != nil {
+					t.Errorf("Expected password to be accepted, got %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a ValidationError, got %v", err)
+			}
+			if !errors.Is(err, ErrPasswordPolicy) {
+				t.Errorf("Expected error to wrap ErrPasswordPolicy, got %v", err)
+			}
+			if validationErr.Field != "password" {
+				t.Errorf("Expected field password, got %q", validationErr.Field)
+			}
+			for _, rule := range tc.wantRules {
+				if !strings.Contains(validationErr.Message, rule) {
+					t.Errorf("Expected message %q to mention %q", validationErr.Message, rule)
+				}
+			}
+			if got := strings.Count(validationErr.Message, ";") + 1; got != len(tc.wantRules) {
+				t.Errorf("Expected %d failed rules, got %d in %q", len(tc.wantRules), got, validationErr.Message)
+			}
+		})
+	}
+}
+
+func TestEstimatePasswordStrength(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	tests := []struct {
+		name     string
+		password string
+		inputs   []string
+		wantMin  int
+		wantMax  int
+	}{
+		{"common_password", "password", nil, 0, 0},
+		{"common_password_with_digits", "password1234", nil, 0, 0},
+		{"l33t_common_password", "P@ssw0rd", nil, 0, 1},
+		{"repeated_characters", "aaaaaaaaaaaa", nil, 0, 0},
+		{"sequence", "abcdefghijkl", nil, 0, 0},
+		{"keyboard_run", "qwertyuiop12", nil, 0, 1},
+		{"email_address", "alice.smith.alice", []string{"alice.smith@example.com"}, 0, 1},
+		{"random_characters", "vT8#qLm2!zRw9p", nil, MaxPasswordStrength, MaxPasswordStrength},
+		{"long_passphrase", "kangaroo-lamp-vivid", nil, MaxPasswordStrength, MaxPasswordStrength},
+		{"empty", "", nil, 0, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := EstimatePasswordStrength(tc.password, tc.inputs...)
+			if got < tc.wantMin || got > tc.wantMax {
+				t.Errorf("Expected strength between %d and %d for %q, got %d", tc.wantMin, tc.wantMax, tc.password, got)
+			}
+		})
+	}
+
+	// The user's own details make a password weaker than the same password without them
+	withoutInputs := EstimatePasswordStrength("alicesmith2025")
+	withInputs := EstimatePasswordStrength("alicesmith2025", "alice.smith@example.com")
+	if withInputs >= withoutInputs {
+		t.Errorf("Expected user inputs to lower the strength, got %d without and %d with", withoutInputs, withInputs)
+	}
+}
@@ -0,0 +1,311 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// MaxPasswordStrength is the highest score EstimatePasswordStrength returns.
+const MaxPasswordStrength = 4
+
+// passwordStrengthThresholds are the base-10 logarithms of the guess counts a
+// password must reach for each score above 0, as in zxcvbn: a score of 1 needs
+// 10^3 guesses, 2 needs 10^6, 3 needs 10^8 and 4 needs 10^10.
+var passwordStrengthThresholds = [MaxPasswordStrength]float64{3, 6, 8, 10}
+
+// bruteforceLog10Guesses is the cost of guessing one character that is not
+// part of any recognised pattern.
+const bruteforceLog10Guesses = 1
+
+// minPasswordPatternLength is the length of the shortest pattern recognised.
+const minPasswordPatternLength = 3
+
+// commonPasswords lists frequently used passwords and words, most common first.
+// A password made of them is cracked in few guesses, however long it is.
+var commonPasswords = []string{
+	"password", "123456", "12345678", "qwerty", "123456789", "12345", "1234", "111111", "1234567",
+	"dragon", "123123", "baseball", "abc123", "football", "monkey", "letmein", "shadow", "master",
+	"666666", "qwertyuiop", "123321", "mustang", "1234567890", "michael", "654321", "superman",
+	"1qaz2wsx", "7777777", "121212", "000000", "qazwsx", "123qwe", "killer", "trustno1", "jordan",
+	"jennifer", "zxcvbnm", "asdfgh", "hunter", "buster", "soccer", "harley", "batman", "andrew",
+	"tigger", "sunshine", "iloveyou", "charlie", "robert", "thomas", "hockey", "ranger", "daniel",
+	"starwars", "112233", "george", "computer", "michelle", "jessica", "pepper", "zxcvbn", "555555",
+	"11111111", "131313", "freedom", "777777", "pass", "maggie", "159753", "aaaaaa", "ginger",
+	"princess", "joshua", "cheese", "amanda", "summer", "love", "ashley", "nicole", "chelsea",
+	"biteme", "matthew", "access", "yankees", "987654321", "dallas", "austin", "thunder", "taylor",
+	"matrix", "welcome", "admin", "login", "secret", "hello", "winter", "spring", "autumn", "changeme",
+	"qwerty123", "password1", "passw0rd", "default", "guest", "root", "test", "user", "flashcard", "scry",
+}
+
+// commonPasswordRanks maps each common password to its rank in commonPasswords.
+var commonPasswordRanks = func() map[string]int {
+	ranks := make(map[string]int, len(commonPasswords))
+	for i, word := range commonPasswords {
+		ranks[word] = i + 1
+	}
+	return ranks
+}()
+
+// keyboardRows are runs of adjacent keys on a QWERTY keyboard.
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// keyboardLines are the keyboard rows typed in either direction.
+var keyboardLines = func() []string {
+	lines := make([]string, 0, 2*len(keyboardRows))
+	for _, row := range keyboardRows {
+		lines = append(lines, row, reverseString(row))
+	}
+	return lines
+}()
+
+// keyboardStartingKeys is roughly how many keys a keyboard run can start from.
+const keyboardStartingKeys = 47
+
+// leetSubstitutions maps the digits and symbols commonly substituted for
+// letters back to the letters.
+var leetSubstitutions = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '(': 'c', '3': 'e', '6': 'g', '1': 'i', '!': 'i',
+	'|': 'l', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't', '2': 'z',
+}
+
+// passwordPattern is a run of a password, runes [start, end), that can be
+// guessed in about 10^log10Guesses attempts.
+type passwordPattern struct {
+	start, end   int
+	log10Guesses float64
+}
+
+// minPatternGuesses is the fewest guesses any pattern costs, so that a password
+// repeating the same pattern is not free to guess.
+const minPatternGuesses = 10
+
+// newPasswordPattern returns the pattern of runes [start, end), guessed in
+// about guesses attempts.
+func newPasswordPattern(start, end int, guesses float64) passwordPattern {
+	return passwordPattern{start: start, end: end, log10Guesses: math.Log10(max(guesses, minPatternGuesses))}
+}
+
+// EstimatePasswordStrength scores how hard password is to guess, from 0 (trivial)
+// to MaxPasswordStrength (very hard), in the manner of zxcvbn. It recognises
+// common passwords (including with capitals and l33t substitutions), repeated
+// characters, sequences such as "abcd" or "9876", and keyboard runs such as
+// "asdf". The password is split into the combination of these patterns and
+// unrecognised characters that is quickest to guess, and the guesses needed
+// decide the score. userInputs, such as the user's email address, are treated
+// like the most common passwords.
+func EstimatePasswordStrength(password string, userInputs ...string) int {
+	log10Guesses := estimatePasswordGuesses(password, userInputs)
+	for score, threshold := range passwordStrengthThresholds {
+		if log10Guesses < threshold {
+			return score
+		}
+	}
+	return MaxPasswordStrength
+}
+
+// estimatePasswordGuesses returns the base-10 logarithm of the guesses needed
+// to find password by trying its cheapest split into patterns.
+func estimatePasswordGuesses(password string, userInputs []string) float64 {
+	runes := []rune(password)
+	patternsByEnd := make([][]passwordPattern, len(runes)+1)
+	for _, p := range findPasswordPatterns(runes, userInputRanks(userInputs)) {
+		patternsByEnd[p.end] = append(patternsByEnd[p.end], p)
+	}
+
+	// best[i] is the cheapest way to guess the first i runes
+	best := make([]float64, len(runes)+1)
+	for i := 1; i <= len(runes); i++ {
+		best[i] = best[i-1] + bruteforceLog10Guesses
+		for _, p := range patternsByEnd[i] {
+			best[i] = min(best[i], best[p.start]+p.log10Guesses)
+		}
+	}
+	return best[len(runes)]
+}
+
+// userInputRanks returns the words of userInputs, such as the parts of an
+// email address, ranked like the most common password.
+func userInputRanks(userInputs []string) map[string]int {
+	ranks := make(map[string]int)
+	for _, input := range userInputs {
+		input = strings.ToLower(input)
+		words := strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if local, _, ok := strings.Cut(input, "@"); ok {
+			words = append(words, local)
+		}
+		for _, word := range words {
+			if len([]rune(word)) >= minPasswordPatternLength {
+				ranks[word] = 1
+			}
+		}
+	}
+	return ranks
+}
+
+// findPasswordPatterns returns every recognised pattern in runes.
+func findPasswordPatterns(runes []rune, userInputs map[string]int) []passwordPattern {
+	lower := make([]rune, len(runes))
+	unleet := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+		unleet[i] = lower[i]
+		if letter, ok := leetSubstitutions[lower[i]]; ok {
+			unleet[i] = letter
+		}
+	}
+
+	var patterns []passwordPattern
+	patterns = append(patterns, dictionaryPatterns(runes, lower, unleet, userInputs)...)
+	patterns = append(patterns, repeatPatterns(lower)...)
+	patterns = append(patterns, sequencePatterns(lower)...)
+	patterns = append(patterns, keyboardPatterns(lower)...)
+	return patterns
+}
+
+// dictionaryPatterns finds common passwords and user inputs, as typed or after
+// undoing l33t substitutions. Capitals and substitutions multiply the guesses.
+func dictionaryPatterns(runes, lower, unleet []rune, userInputs map[string]int) []passwordPattern {
+	longest := 0
+	for _, word := range commonPasswords {
+		longest = max(longest, len(word))
+	}
+	for word := range userInputs {
+		longest = max(longest, len([]rune(word)))
+	}
+
+	rank := func(word string) (int, bool) {
+		if r, ok := userInputs[word]; ok {
+			return r, true
+		}
+		r, ok := commonPasswordRanks[word]
+		return r, ok
+	}
+
+	var patterns []passwordPattern
+	for start := range runes {
+		for end := start + minPasswordPatternLength; end <= min(len(runes), start+longest); end++ {
+			guesses := 0.0
+			if r, ok := rank(string(lower[start:end])); ok {
+				guesses = float64(r)
+			} else if r, ok := rank(string(unleet[start:end])); ok {
+				guesses = float64(r) * 2
+			} else {
+				continue
+			}
+			guesses *= capitalizationVariations(runes[start:end])
+			patterns = append(patterns, newPasswordPattern(start, end, guesses))
+		}
+	}
+	return patterns
+}
+
+// capitalizationVariations returns how many more guesses the capitals in word
+// cost: none for lowercase, a few for a capitalised or uppercase word, and more
+// for capitals elsewhere.
+func capitalizationVariations(word []rune) float64 {
+	upper := 0
+	for _, r := range word {
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+	switch {
+	case upper == 0:
+		return 1
+	case upper == len(word), upper == 1 && unicode.IsUpper(word[0]):
+		return 2
+	default:
+		return 4
+	}
+}
+
+// repeatPatterns finds runs of one repeated character, such as "aaaa".
+func repeatPatterns(lower []rune) []passwordPattern {
+	var patterns []passwordPattern
+	for start := 0; start < len(lower); {
+		end := start + 1
+		for end < len(lower) && lower[end] == lower[start] {
+			end++
+		}
+		if end-start >= minPasswordPatternLength {
+			patterns = append(patterns, newPasswordPattern(start, end, runeCardinality(lower[start])*float64(end-start)))
+		}
+		start = end
+	}
+	return patterns
+}
+
+// sequencePatterns finds runs of consecutive characters, such as "abcd" or "9876".
+func sequencePatterns(lower []rune) []passwordPattern {
+	var patterns []passwordPattern
+	for start := 0; start < len(lower)-1; {
+		delta := lower[start+1] - lower[start]
+		if delta != 1 && delta != -1 {
+			start++
+			continue
+		}
+
+		end := start + 2
+		for end < len(lower) && lower[end]-lower[end-1] == delta {
+			end++
+		}
+		if end-start >= minPasswordPatternLength {
+			guesses := runeCardinality(lower[start]) * float64(end-start)
+			if delta < 0 {
+				guesses *= 2
+			}
+			patterns = append(patterns, newPasswordPattern(start, end, guesses))
+		}
+		start = end - 1
+	}
+	return patterns
+}
+
+// keyboardPatterns finds runs of adjacent keys, such as "qwer" or "lkjh".
+func keyboardPatterns(lower []rune) []passwordPattern {
+	var patterns []passwordPattern
+	for start := range lower {
+		longest := 0
+		for _, line := range keyboardLines {
+			i := strings.IndexRune(line, lower[start])
+			if i < 0 {
+				continue
+			}
+			keys := []rune(line[i:])
+			length := 0
+			for length < len(keys) && start+length < len(lower) && lower[start+length] == keys[length] {
+				length++
+			}
+			longest = max(longest, length)
+		}
+		if longest >= minPasswordPatternLength {
+			patterns = append(patterns, newPasswordPattern(start, start+longest, keyboardStartingKeys*float64(longest)))
+		}
+	}
+	return patterns
+}
+
+// runeCardinality returns how many characters are like r: 10 for digits, 26
+// for letters and 33 for symbols.
+func runeCardinality(r rune) float64 {
+	switch {
+	case unicode.IsDigit(r):
+		return 10
+	case unicode.IsLetter(r):
+		return 26
+	default:
+		return 33
+	}
+}
+
+// reverseString returns s with its runes in reverse order.
+func reverseString(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewEvent-specific validation errors
+var (
+	// ErrReviewEventUserIDEmpty is returned when a review event user ID is empty or nil.
+	ErrReviewEventUserIDEmpty = errors.New("review event user ID cannot be empty")
+
+	// ErrReviewEventCardIDEmpty is returned when a review event card ID is empty or nil.
+	ErrReviewEventCardIDEmpty = errors.New("review event card ID cannot be empty")
+
+	// ErrReviewEventTimeEmpty is returned when a review event has no review timestamp.
+	ErrReviewEventTimeEmpty = errors.New("review event reviewed_at cannot be empty")
+
+	// ErrReviewEventIntervalInvalid is returned when a review event interval is negative.
+	ErrReviewEventIntervalInvalid = errors.New("review event intervals must be greater than or equal to 0")
+)
+
+// ReviewEvent records a single review of a card by a user.
+// Unlike UserCardStats, which only holds the current scheduling state,
+// review events form an append-only history of every answer submitted.
+type ReviewEvent struct {
+	ID         uuid.UUID     `json:"id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	CardID     uuid.UUID     `json:"card_id"`
+	Outcome    ReviewOutcome `json:"outcome"`
+	ReviewedAt time.Time     `json:"reviewed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+
+	// IntervalBefore and IntervalAfter are the card's interval in days before the
+	// review and as scheduled by it. Both are nil for reviews recorded before
+	// intervals were tracked.
+	IntervalBefore *int `json:"interval_before,omitempty"`
+	IntervalAfter  *int `json:"interval_after,omitempty"`
+}
+
+// ReviewedCard pairs a card with the most recent review event recorded for it.
+type ReviewedCard struct {
+	Card       *Card        `json:"card"`
+	LastReview *ReviewEvent `json:"last_review"`
+}
+
+// NewReviewEvent creates a new review event for the given user, card and outcome.
+func NewReviewEvent(
+	userID, cardID uuid.UUID,
+	outcome ReviewOutcome,
+	reviewedAt time.Time,
+) (*ReviewEvent, error) {
+	event := &ReviewEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		CardID:     cardID,
+		Outcome:    outcome,
+		ReviewedAt: reviewedAt.UTC(),
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// SetIntervals records the card's interval before the review and the interval
+// the review scheduled.
+func (e *ReviewEvent) SetIntervals(before, after int) {
+	e.IntervalBefore = &before
+	e.IntervalAfter = &after
+}
+
+// Validate checks if the ReviewEvent has valid data.
+// Returns an error if any field fails validation.
+func (e *ReviewEvent) Validate() error {
+	if e.UserID == uuid.Nil {
+		return ErrReviewEventUserIDEmpty
+	}
+
+	if e.CardID == uuid.Nil {
+		return ErrReviewEventCardIDEmpty
+	}
+
+	if !isValidReviewOutcome(e.Outcome) {
+		return ErrInvalidReviewOutcome
+	}
+
+	if e.ReviewedAt.IsZero() {
+		return ErrReviewEventTimeEmpty
+	}
+
+	if (e.IntervalBefore != nil && *e.IntervalBefore < 0) || (e.IntervalAfter != nil && *e.IntervalAfter < 0) {
+		return ErrReviewEventIntervalInvalid
+	}
+
+	return nil
+}
+
+// isValidReviewOutcome checks if the provided outcome is one of the known review outcomes.
+func isValidReviewOutcome(outcome ReviewOutcome) bool {
+	switch outcome {
+	case ReviewOutcomeAgain, ReviewOutcomeHard, ReviewOutcomeGood, ReviewOutcomeEasy:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewReviewEvent(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+	userID := uuid.New()
+	cardID := uuid.New()
+	reviewedAt := time.Now()
+
+	event, err := NewReviewEvent(userID, cardID, ReviewOutcomeGood, reviewedAt)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if event.ID == uuid.Nil {
+		t.Error("Expected non-nil UUID, got nil UUID")
+	}
+
+	if event.UserID != userID || event.CardID != cardID {
+		t.Errorf("Expected user %s and card %s, got %s and %s",
+			userID, cardID, event.UserID, event.CardID)
+	}
+
+	if !event.ReviewedAt.Equal(reviewedAt) {
+		t.Errorf("Expected reviewed_at %v, got %v", reviewedAt, event.ReviewedAt)
+	}
+
+	// Test invalid userID
+	_, err = NewReviewEvent(uuid.Nil, cardID, ReviewOutcomeGood, reviewedAt)
+	if err != ErrReviewEventUserIDEmpty {
+		t.Errorf("Expected error %v, got %v", ErrReviewEventUserIDEmpty, err)
+	}
+
+	// Test invalid cardID
+	_, err = NewReviewEvent(userID, uuid.Nil, ReviewOutcomeGood, reviewedAt)
+	if err != ErrReviewEventCardIDEmpty {
+		t.Errorf("Expected error %v, got %v", ErrReviewEventCardIDEmpty, err)
+	}
+
+	// Test invalid outcome
+	_, err = NewReviewEvent(userID, cardID, "invalid", reviewedAt)
+	if err != ErrInvalidReviewOutcome {
+		t.Errorf("Expected error %v, got %v", ErrInvalidReviewOutcome, err)
+	}
+
+	// Test missing review time
+	_, err = NewReviewEvent(userID, cardID, ReviewOutcomeGood, time.Time{})
+	if err != ErrReviewEventTimeEmpty {
+		t.Errorf("Expected error %v, got %v", ErrReviewEventTimeEmpty, err)
+	}
+}
+
+func TestReviewEventSetIntervals(t *testing.T) {
+	t.Parallel()
+
+	event, err := NewReviewEvent(uuid.New(), uuid.New(), ReviewOutcomeGood, time.Now())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event.IntervalBefore != nil || event.IntervalAfter != nil {
+		t.Error("Expected a new event to have no intervals")
+	}
+
+	event.SetIntervals(1, 3)
+	if event.IntervalBefore == nil || *event.IntervalBefore != 1 || event.IntervalAfter == nil || *event.IntervalAfter != 3 {
+		t.Errorf("Expected intervals 1 and 3, got %v and %v", event.IntervalBefore, event.IntervalAfter)
+	}
+	if err := event.Validate(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	event.SetIntervals(0, -1)
+	if err := event.Validate(); err != ErrReviewEventIntervalInvalid {
+		t.Errorf("Expected error %v, got %v", ErrReviewEventIntervalInvalid, err)
+	}
+}
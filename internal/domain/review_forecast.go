@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// MaxForecastDays is the longest review forecast, in days, that can be requested.
+const MaxForecastDays = 365
+
+// ForecastBucket is the number of reviews falling due on one calendar day
+// in the user's timezone.
+type ForecastBucket struct {
+	// Date is the calendar day; only its year, month and day are meaningful.
+	Date time.Time `json:"date"`
+
+	// Count is the number of cards due for review on Date.
+	Count int `json:"count"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "errors"
+
+// ReviewOrder selects which due card is served next when several are due.
+type ReviewOrder string
+
+// Review orders
+const (
+	// ReviewOrderDueDate serves the most overdue card first.
+	ReviewOrderDueDate ReviewOrder = "due-date-asc"
+
+	// ReviewOrderRandomDue serves a randomly chosen due card.
+	ReviewOrderRandomDue ReviewOrder = "random-due"
+
+	// ReviewOrderLowestEase serves the due card with the lowest ease factor first,
+	// so the cards the user finds hardest are practised soonest. Ties are broken
+	// by due date.
+	ReviewOrderLowestEase ReviewOrder = "lowest-ease-first"
+)
+
+// DefaultReviewOrder is the order used when none is requested.
+const DefaultReviewOrder = ReviewOrderDueDate
+
+// ErrInvalidReviewOrder is returned when an unknown review order is requested.
+var ErrInvalidReviewOrder = errors.New("invalid review order")
+
+// ReviewOrders lists every supported review order.
+func ReviewOrders() []ReviewOrder {
+	return []ReviewOrder{ReviewOrderDueDate, ReviewOrderRandomDue, ReviewOrderLowestEase}
+}
+
+// IsValid reports whether o is a known review order. The empty order is not
+// valid; callers that accept it treat it as DefaultReviewOrder (see OrDefault).
+func (o ReviewOrder) IsValid() bool {
+	switch o {
+	case ReviewOrderDueDate, ReviewOrderRandomDue, ReviewOrderLowestEase:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrDefault returns o, or DefaultReviewOrder if o is empty.
+func (o ReviewOrder) OrDefault() ReviewOrder {
+	if o == "" {
+		return DefaultReviewOrder
+	}
+	return o
+}
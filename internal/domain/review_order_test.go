@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestReviewOrder(t *testing.T) {
+	t.Parallel()
+
+	for _, order := range ReviewOrders() {
+		if !order.IsValid() {
+			t.Errorf("Expected %q to be valid", order)
+		}
+	}
+
+	for _, order := range []ReviewOrder{"", "due-date-desc", "Random-Due"} {
+		if order.IsValid() {
+			t.Errorf("Expected %q to be invalid", order)
+		}
+	}
+
+	if got := ReviewOrder("").OrDefault(); got != DefaultReviewOrder {
+		t.Errorf("Expected empty order to default to %q, got %q", DefaultReviewOrder, got)
+	}
+	if got := ReviewOrderLowestEase.OrDefault(); got != ReviewOrderLowestEase {
+		t.Errorf("Expected %q, got %q", ReviewOrderLowestEase, got)
+	}
+}
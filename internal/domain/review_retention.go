@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// MaxRetentionDays is the longest period, in days, that retention statistics
+// can be requested for.
+const MaxRetentionDays = 365
+
+// MatureIntervalDays is the interval, in days, from which a card counts as mature.
+const MatureIntervalDays = 21
+
+// CardMaturity classifies a card by its interval at the time of a review.
+type CardMaturity string
+
+// Valid card maturity values
+const (
+	// CardMaturityLearning is a card with an interval under a day.
+	CardMaturityLearning CardMaturity = "learning"
+	// CardMaturityYoung is a card with an interval under MatureIntervalDays.
+	CardMaturityYoung CardMaturity = "young"
+	// CardMaturityMature is a card with an interval of MatureIntervalDays or more.
+	CardMaturityMature CardMaturity = "mature"
+	// CardMaturityUnknown is used for reviews recorded before intervals were tracked.
+	CardMaturityUnknown CardMaturity = "unknown"
+)
+
+// MaturityForInterval returns the maturity of a card whose interval before a
+// review was interval, or CardMaturityUnknown if the interval was not recorded.
+func MaturityForInterval(interval *int) CardMaturity {
+	switch {
+	case interval == nil:
+		return CardMaturityUnknown
+	case *interval < 1:
+		return CardMaturityLearning
+	case *interval < MatureIntervalDays:
+		return CardMaturityYoung
+	default:
+		return CardMaturityMature
+	}
+}
+
+// ReviewOutcomeCount is the number of reviews with one outcome given to cards
+// of one maturity.
+type ReviewOutcomeCount struct {
+	Maturity CardMaturity  `json:"maturity"`
+	Outcome  ReviewOutcome `json:"outcome"`
+	Count    int           `json:"count"`
+}
+
+// ReviewDayCount is the number of reviews made on one calendar day in the
+// user's timezone.
+type ReviewDayCount struct {
+	// Date is the calendar day; only its year, month and day are meaningful.
+	Date time.Time `json:"date"`
+
+	// Count is the number of reviews made on Date.
+	Count int `json:"count"`
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ReviewStreak summarizes the runs of consecutive calendar days on which
+// a user reviewed at least one card.
+type ReviewStreak struct {
+	// Current is the length of the run that ends today, or yesterday if the
+	// user hasn't reviewed anything yet today. It is 0 once a full day is missed.
+	Current int `json:"current"`
+
+	// Longest is the length of the longest run in the user's history.
+	Longest int `json:"longest"`
+}
+
+// CalculateReviewStreak computes the current and longest streak from the days
+// on which a user reviewed cards.
+//
+// Only the year, month and day of each reviewDay are used, so callers should pass
+// dates that are already expressed in the user's timezone. Duplicates and
+// unordered input are allowed. today is interpreted the same way and should be
+// the current time in the user's timezone.
+func CalculateReviewStreak(reviewDays []time.Time, today time.Time) ReviewStreak {
+	if len(reviewDays) == 0 {
+		return ReviewStreak{}
+	}
+
+	// Normalize to calendar dates, removing duplicates
+	seen := make(map[time.Time]bool, len(reviewDays))
+	days := make([]time.Time, 0, len(reviewDays))
+	for _, day := range reviewDays {
+		date := calendarDate(day)
+		if !seen[date] {
+			seen[date] = true
+			days = append(days, date)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	// Longest run of consecutive days
+	longest, run := 1, 1
+	for i := 1; i < len(days); i++ {
+		if days[i-1].AddDate(0, 0, 1).Equal(days[i]) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	// Current run, counting back from today or yesterday
+	day := calendarDate(today)
+	if !seen[day] {
+		day = day.AddDate(0, 0, -1)
+	}
+	current := 0
+	for seen[day] {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return ReviewStreak{
+		Current: current,
+		Longest: longest,
+	}
+}
+
+// calendarDate returns midnight UTC on the calendar date of t in its own location.
+// This makes dates from different locations comparable by year, month and day alone.
+func calendarDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
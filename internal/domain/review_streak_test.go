@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateReviewStreak(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	today := time.Date(2025, time.March, 1, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		days     []time.Time
+		today    time.Time
+		expected ReviewStreak
+	}{
+		{
+			name:     "no reviews",
+			days:     nil,
+			today:    today,
+			expected: ReviewStreak{},
+		},
+		{
+			name:     "reviewed only today",
+			days:     []time.Time{date(2025, time.March, 1)},
+			today:    today,
+			expected: ReviewStreak{Current: 1, Longest: 1},
+		},
+		{
+			name: "streak continues across a month boundary",
+			days: []time.Time{
+				date(2025, time.February, 27),
+				date(2025, time.February, 28),
+				date(2025, time.March, 1),
+			},
+			today:    today,
+			expected: ReviewStreak{Current: 3, Longest: 3},
+		},
+		{
+			name: "streak continues across a year boundary",
+			days: []time.Time{
+				date(2024, time.December, 31),
+				date(2025, time.January, 1),
+			},
+			today:    time.Date(2025, time.January, 1, 8, 0, 0, 0, time.UTC),
+			expected: ReviewStreak{Current: 2, Longest: 2},
+		},
+		{
+			name: "not yet reviewed today keeps yesterday's streak",
+			days: []time.Time{
+				date(2025, time.February, 27),
+				date(2025, time.February, 28),
+			},
+			today:    today,
+			expected: ReviewStreak{Current: 2, Longest: 2},
+		},
+		{
+			name: "missed a full day resets current streak",
+			days: []time.Time{
+				date(2025, time.February, 26),
+				date(2025, time.February, 27),
+			},
+			today:    today,
+			expected: ReviewStreak{Current: 0, Longest: 2},
+		},
+		{
+			name: "gap splits runs and longest is kept",
+			days: []time.Time{
+				date(2025, time.February, 10),
+				date(2025, time.February, 11),
+				date(2025, time.February, 12),
+				date(2025, time.February, 13),
+				date(2025, time.February, 20),
+				date(2025, time.February, 28),
+				date(2025, time.March, 1),
+			},
+			today:    today,
+			expected: ReviewStreak{Current: 2, Longest: 4},
+		},
+		{
+			name: "duplicates and unordered input",
+			days: []time.Time{
+				date(2025, time.March, 1),
+				date(2025, time.February, 28),
+				date(2025, time.March, 1),
+				date(2025, time.February, 28),
+			},
+			today:    today,
+			expected: ReviewStreak{Current: 2, Longest: 2},
+		},
+		{
+			name: "today is evaluated in its own location",
+			days: []time.Time{
+				date(2025, time.March, 1),
+				date(2025, time.March, 2),
+			},
+			// 23:30 UTC on March 1 is already March 2 in Tokyo
+			today:    today.Add(8 * time.Hour).In(time.FixedZone("JST", 9*60*60)),
+			expected: ReviewStreak{Current: 2, Longest: 2},
+		},
+		{
+			name: "same instant in UTC has not reached the next day",
+			days: []time.Time{
+				date(2025, time.March, 1),
+				date(2025, time.March, 2),
+			},
+			today:    today.Add(8 * time.Hour),
+			expected: ReviewStreak{Current: 1, Longest: 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CalculateReviewStreak(tc.days, tc.today)
+			if got != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -175,9 +175,13 @@ func calculateNextReviewDate(
 //   - Updates last reviewed time to now
 //   - Calculates new ease factor based on outcome
 //   - Updates consecutive correct count (reset on "Again", increment otherwise)
+//   - Increments the lapse count on "Again"
 //   - Calculates new interval using current stats and new ease factor
 //   - Determines next review date based on the new interval
 //   - Updates the updated timestamp to now
+//   - With learning steps configured, cards in the learning or relearning phase
+//     are scheduled by calculateLearningStats instead, and cards answered "again"
+//     in the review phase move to the relearning phase
 //
 // This function implements the immutable update pattern - instead of modifying the
 // existing stats object, it creates and returns a completely new object. This approach
@@ -198,22 +202,35 @@ func calculateNextStats(
 		LastReviewedAt:     stats.LastReviewedAt,
 		NextReviewAt:       stats.NextReviewAt,
 		ReviewCount:        stats.ReviewCount,
+		Lapses:             stats.Lapses,
+		Phase:              stats.CurrentPhase(),
+		LearningStep:       stats.LearningStep,
 		CreatedAt:          stats.CreatedAt,
 		UpdatedAt:          stats.UpdatedAt,
 	}
 
+	// Cards still learning step through the learning steps
+	if len(params.LearningSteps) > 0 && newStats.Phase != domain.CardPhaseReview {
+		return calculateLearningStats(stats, newStats, outcome, now, params)
+	}
+
 	// Increment review count
 	newStats.ReviewCount++
 
 	// Update last reviewed time
 	newStats.LastReviewedAt = now
 
-	// Calculate new ease factor
-	newStats.EaseFactor = calculateNewEaseFactor(stats.EaseFactor, outcome, params)
+	// Calculate new ease factor, starting first reviews from the configured ease if set
+	currentEF := stats.EaseFactor
+	if stats.ReviewCount == 0 && params.InitialEaseFactor > 0 {
+		currentEF = params.InitialEaseFactor
+	}
+	newStats.EaseFactor = calculateNewEaseFactor(currentEF, outcome, params)
 
-	// Update consecutive correct count
+	// Update consecutive correct count, counting a lapse on "Again"
 	if outcome == domain.ReviewOutcomeAgain {
 		newStats.ConsecutiveCorrect = 0
+		newStats.Lapses++
 	} else {
 		newStats.ConsecutiveCorrect++
 	}
@@ -230,8 +247,93 @@ func calculateNextStats(
 	// Calculate next review date
 	newStats.NextReviewAt = calculateNextReviewDate(newStats.Interval, outcome, now, params)
 
+	// Without learning steps cards graduate on their first review; with them,
+	// a lapsed card relearns from the first step
+	newStats.Phase = domain.CardPhaseReview
+	newStats.LearningStep = 0
+	if outcome == domain.ReviewOutcomeAgain && len(params.LearningSteps) > 0 {
+		newStats.Phase = domain.CardPhaseRelearning
+		newStats.NextReviewAt = now.Add(learningStepDelay(params.LearningSteps, 0))
+	}
+
 	// Update the updated timestamp
 	newStats.UpdatedAt = now
 
 	return newStats
 }
+
+// calculateLearningStats schedules a card in the learning or relearning phase.
+//
+// Learning cards are reviewed after each of params.LearningSteps in turn, which
+// are sub-day delays in minutes, before graduating to the review phase and
+// being scheduled in days by the interval algorithm.
+//
+// Parameters:
+//   - stats: The current UserCardStats object
+//   - newStats: A copy of stats to update and return
+//   - outcome: The user's review outcome (Again, Hard, Good, Easy)
+//   - now: The current time, usually the time when the review was performed
+//   - params: Configuration parameters for the SRS algorithm; LearningSteps must not be empty
+//
+// Returns:
+//   - newStats, updated for the review
+//
+// Algorithm behavior:
+//   - "Again" returns the card to the first step and counts a lapse
+//   - "Hard" repeats the current step
+//   - "Good" advances to the next step, graduating after the last one
+//   - "Easy" graduates the card immediately
+//   - Graduated cards get params.FirstReviewIntervals for the outcome and enter the review phase
+//   - The ease factor is not adjusted while learning, but a card's first review
+//     still starts it from params.InitialEaseFactor when set
+func calculateLearningStats(
+	stats *domain.UserCardStats,
+	newStats *domain.UserCardStats,
+	outcome domain.ReviewOutcome,
+	now time.Time,
+	params *Params,
+) *domain.UserCardStats {
+	newStats.ReviewCount++
+	newStats.LastReviewedAt = now
+	newStats.UpdatedAt = now
+	newStats.Interval = 0
+	if stats.ReviewCount == 0 && params.InitialEaseFactor > 0 {
+		newStats.EaseFactor = params.InitialEaseFactor
+	}
+
+	// Steps may have been shortened since the card entered its current step
+	step := min(stats.LearningStep, len(params.LearningSteps)-1)
+
+	switch outcome {
+	case domain.ReviewOutcomeAgain:
+		newStats.ConsecutiveCorrect = 0
+		newStats.Lapses++
+		step = 0
+	case domain.ReviewOutcomeHard:
+		newStats.ConsecutiveCorrect++
+	case domain.ReviewOutcomeGood:
+		newStats.ConsecutiveCorrect++
+		step++
+	case domain.ReviewOutcomeEasy:
+		newStats.ConsecutiveCorrect++
+		step = len(params.LearningSteps)
+	}
+
+	// Graduate after the last step
+	if step >= len(params.LearningSteps) {
+		newStats.Phase = domain.CardPhaseReview
+		newStats.LearningStep = 0
+		newStats.Interval = params.FirstReviewIntervals[outcome]
+		newStats.NextReviewAt = now.AddDate(0, 0, newStats.Interval)
+		return newStats
+	}
+
+	newStats.LearningStep = step
+	newStats.NextReviewAt = now.Add(learningStepDelay(params.LearningSteps, step))
+	return newStats
+}
+
+// learningStepDelay returns the delay of the learning step with the given index
+func learningStepDelay(steps []int, step int) time.Duration {
+	return time.Duration(steps[step]) * time.Minute
+}
@@ -282,4 +282,142 @@ func TestCalculateNextStats(t *testing.T) {
 		t.Errorf("Expected ConsecutiveCorrect to reset to 0 for Again outcome, got %d",
 			updated.ConsecutiveCorrect)
 	}
+
+	// Test "Again" outcome counts a lapse and other outcomes do not
+	if updated.Lapses != stats.Lapses+1 {
+		t.Errorf("Expected Lapses to increment by 1 for Again outcome, got %d (from %d)",
+			updated.Lapses, stats.Lapses)
+	}
+	updated = calculateNextStats(updated, domain.ReviewOutcomeGood, now, params)
+	if updated.Lapses != stats.Lapses+1 {
+		t.Errorf("Expected Lapses to be unchanged for Good outcome, got %d", updated.Lapses)
+	}
+}
+
+func TestCalculateNextStats_LearningSteps(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+	params := NewParams(ParamsConfig{LearningSteps: []int{1, 10}})
+	now := time.Date(2025, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	newStats := func(t *testing.T) *domain.UserCardStats {
+		stats, err := domain.NewUserCardStats(uuid.New(), uuid.New())
+		if err != nil {
+			t.Fatalf("Failed to create stats: %v", err)
+		}
+		return stats
+	}
+
+	assertLearning := func(t *testing.T, stats *domain.UserCardStats, step int, due time.Time) {
+		t.Helper()
+		if stats.Phase != domain.CardPhaseLearning {
+			t.Errorf("Expected phase %q, got %q", domain.CardPhaseLearning, stats.Phase)
+		}
+		if stats.LearningStep != step {
+			t.Errorf("Expected learning step %d, got %d", step, stats.LearningStep)
+		}
+		if stats.Interval != 0 {
+			t.Errorf("Expected interval 0 while learning, got %d", stats.Interval)
+		}
+		if !stats.NextReviewAt.Equal(due) {
+			t.Errorf("Expected NextReviewAt %v, got %v", due, stats.NextReviewAt)
+		}
+	}
+
+	t.Run("Good steps forward then graduates", func(t *testing.T) {
+		t.Parallel()
+		stats := newStats(t)
+
+		stats = calculateNextStats(stats, domain.ReviewOutcomeGood, now, params)
+		assertLearning(t, stats, 1, now.Add(10*time.Minute))
+
+		later := now.Add(10 * time.Minute)
+		stats = calculateNextStats(stats, domain.ReviewOutcomeGood, later, params)
+		if stats.Phase != domain.CardPhaseReview {
+			t.Fatalf("Expected the card to graduate, got phase %q", stats.Phase)
+		}
+		if stats.LearningStep != 0 {
+			t.Errorf("Expected learning step to reset on graduation, got %d", stats.LearningStep)
+		}
+		if stats.Interval != params.FirstReviewIntervals[domain.ReviewOutcomeGood] {
+			t.Errorf("Expected the first review interval, got %d", stats.Interval)
+		}
+		if !stats.NextReviewAt.Equal(later.AddDate(0, 0, stats.Interval)) {
+			t.Errorf("Expected NextReviewAt %d days after graduation, got %v", stats.Interval, stats.NextReviewAt)
+		}
+		if stats.EaseFactor != 2.5 {
+			t.Errorf("Expected ease factor to be unchanged while learning, got %f", stats.EaseFactor)
+		}
+	})
+
+	t.Run("Again resets to the first step", func(t *testing.T) {
+		t.Parallel()
+		stats := calculateNextStats(newStats(t), domain.ReviewOutcomeGood, now, params)
+
+		stats = calculateNextStats(stats, domain.ReviewOutcomeAgain, now, params)
+		assertLearning(t, stats, 0, now.Add(time.Minute))
+		if stats.ConsecutiveCorrect != 0 {
+			t.Errorf("Expected ConsecutiveCorrect to reset, got %d", stats.ConsecutiveCorrect)
+		}
+		if stats.Lapses != 1 {
+			t.Errorf("Expected one lapse, got %d", stats.Lapses)
+		}
+	})
+
+	t.Run("Hard repeats the step", func(t *testing.T) {
+		t.Parallel()
+		stats := calculateNextStats(newStats(t), domain.ReviewOutcomeGood, now, params)
+
+		stats = calculateNextStats(stats, domain.ReviewOutcomeHard, now, params)
+		assertLearning(t, stats, 1, now.Add(10*time.Minute))
+	})
+
+	t.Run("Easy graduates immediately", func(t *testing.T) {
+		t.Parallel()
+		stats := calculateNextStats(newStats(t), domain.ReviewOutcomeEasy, now, params)
+
+		if stats.Phase != domain.CardPhaseReview {
+			t.Fatalf("Expected the card to graduate, got phase %q", stats.Phase)
+		}
+		if stats.Interval != params.FirstReviewIntervals[domain.ReviewOutcomeEasy] {
+			t.Errorf("Expected the first easy interval, got %d", stats.Interval)
+		}
+	})
+
+	t.Run("lapsed card relearns", func(t *testing.T) {
+		t.Parallel()
+		stats := newStats(t)
+		stats.Phase = domain.CardPhaseReview
+		stats.ReviewCount = 5
+		stats.Interval = 20
+		stats.ConsecutiveCorrect = 4
+
+		stats = calculateNextStats(stats, domain.ReviewOutcomeAgain, now, params)
+		if stats.Phase != domain.CardPhaseRelearning {
+			t.Fatalf("Expected phase %q, got %q", domain.CardPhaseRelearning, stats.Phase)
+		}
+		if !stats.NextReviewAt.Equal(now.Add(time.Minute)) {
+			t.Errorf("Expected the first learning step, got %v", stats.NextReviewAt)
+		}
+		if stats.EaseFactor != 2.3 {
+			t.Errorf("Expected a lapse to lower the ease factor to 2.3, got %f", stats.EaseFactor)
+		}
+
+		stats = calculateNextStats(stats, domain.ReviewOutcomeGood, now, params)
+		stats = calculateNextStats(stats, domain.ReviewOutcomeGood, now, params)
+		if stats.Phase != domain.CardPhaseReview {
+			t.Errorf("Expected the card to graduate again, got phase %q", stats.Phase)
+		}
+	})
+
+	t.Run("without steps cards graduate on their first review", func(t *testing.T) {
+		t.Parallel()
+		stats := calculateNextStats(newStats(t), domain.ReviewOutcomeAgain, now, NewDefaultParams())
+
+		if stats.Phase != domain.CardPhaseReview {
+			t.Errorf("Expected phase %q, got %q", domain.CardPhaseReview, stats.Phase)
+		}
+		if !stats.NextReviewAt.Equal(now.Add(10 * time.Minute)) {
+			t.Errorf("Expected AgainReviewMinutes to apply, got %v", stats.NextReviewAt)
+		}
+	})
 }
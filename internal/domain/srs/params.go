@@ -1,6 +1,9 @@
 package srs
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/phrazzld/scry-api/internal/domain"
 )
 
@@ -17,6 +20,16 @@ type Params struct {
 	// Special case handling
 	FirstReviewIntervals map[domain.ReviewOutcome]int
 	AgainReviewMinutes   int
+
+	// InitialEaseFactor is the ease factor a card starts from on its first review.
+	// Zero keeps the ease factor stored with the card's stats.
+	InitialEaseFactor float64
+
+	// LearningSteps are the delays in minutes between the reviews of a new or
+	// lapsed card before it graduates to interval scheduling. With no steps,
+	// cards graduate on their first review and a card answered "again" is
+	// shown again after AgainReviewMinutes.
+	LearningSteps []int
 }
 
 // ParamsConfig allows overriding the default parameters when creating a new Params instance
@@ -44,6 +57,27 @@ type ParamsConfig struct {
 
 	// Special timing
 	AgainReviewMinutes int
+
+	// Starting ease factor for first reviews
+	InitialEaseFactor float64
+
+	// Learning steps in minutes
+	LearningSteps []int
+}
+
+// Overrides replaces selected parameters for a subset of cards, such as the
+// cards in one deck. Zero values keep the existing parameters.
+type Overrides struct {
+	AgainReviewMinutes int
+	InitialEaseFactor  float64
+
+	// LearningSteps replaces the learning steps when not nil
+	LearningSteps []int
+}
+
+// IsZero reports whether the overrides change no parameters
+func (o Overrides) IsZero() bool {
+	return o.AgainReviewMinutes == 0 && o.InitialEaseFactor == 0 && o.LearningSteps == nil
 }
 
 // NewDefaultParams creates a new Params instance with default values
@@ -136,5 +170,37 @@ func NewParams(config ParamsConfig) *Params {
 		params.AgainReviewMinutes = config.AgainReviewMinutes
 	}
 
+	// Override the starting ease factor if provided
+	if config.InitialEaseFactor > 0 {
+		params.InitialEaseFactor = config.InitialEaseFactor
+	}
+
+	// Override the learning steps if provided
+	if len(config.LearningSteps) > 0 {
+		params.LearningSteps = slices.Clone(config.LearningSteps)
+	}
+
 	return params
 }
+
+// WithOverrides returns a copy of the parameters with the given overrides applied.
+// The receiver is left unchanged.
+func (p *Params) WithOverrides(overrides Overrides) *Params {
+	params := *p
+	params.EaseFactorAdjustment = maps.Clone(p.EaseFactorAdjustment)
+	params.IntervalModifier = maps.Clone(p.IntervalModifier)
+	params.FirstReviewIntervals = maps.Clone(p.FirstReviewIntervals)
+	params.LearningSteps = slices.Clone(p.LearningSteps)
+
+	if overrides.AgainReviewMinutes > 0 {
+		params.AgainReviewMinutes = overrides.AgainReviewMinutes
+	}
+	if overrides.InitialEaseFactor > 0 {
+		params.InitialEaseFactor = overrides.InitialEaseFactor
+	}
+	if overrides.LearningSteps != nil {
+		params.LearningSteps = slices.Clone(overrides.LearningSteps)
+	}
+
+	return &params
+}
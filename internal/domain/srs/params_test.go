@@ -108,3 +108,32 @@ func TestNewParams(t *testing.T) {
 			customParams.FirstReviewIntervals[domain.ReviewOutcomeHard])
 	}
 }
+
+func TestParamsWithOverrides(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+	params := NewDefaultParams()
+
+	overridden := params.WithOverrides(Overrides{AgainReviewMinutes: 3, InitialEaseFactor: 1.8})
+	if overridden.AgainReviewMinutes != 3 {
+		t.Errorf("Expected AgainReviewMinutes 3, got %d", overridden.AgainReviewMinutes)
+	}
+	if overridden.InitialEaseFactor != 1.8 {
+		t.Errorf("Expected InitialEaseFactor 1.8, got %f", overridden.InitialEaseFactor)
+	}
+
+	// The copy must not share state with the original
+	overridden.EaseFactorAdjustment[domain.ReviewOutcomeEasy] = 1
+	if params.AgainReviewMinutes != 10 || params.InitialEaseFactor != 0 {
+		t.Error("Expected the original params to be unchanged")
+	}
+	if params.EaseFactorAdjustment[domain.ReviewOutcomeEasy] == 1 {
+		t.Error("Expected the original adjustments to be unchanged")
+	}
+	steps := params.WithOverrides(Overrides{LearningSteps: []int{1, 10}})
+	if len(steps.LearningSteps) != 2 || steps.LearningSteps[1] != 10 {
+		t.Errorf("Expected LearningSteps [1 10], got %v", steps.LearningSteps)
+	}
+	if params.LearningSteps != nil {
+		t.Error("Expected the original learning steps to be unchanged")
+	}
+}
@@ -29,6 +29,10 @@ type Service interface {
 		days int,
 		now time.Time,
 	) (*domain.UserCardStats, error)
+
+	// WithOverrides returns a Service that schedules with this service's
+	// parameters after applying overrides, such as a deck's settings.
+	WithOverrides(overrides Overrides) Service
 }
 
 // defaultService is the standard implementation of the Service interface
@@ -103,6 +107,9 @@ func (s *defaultService) PostponeReview(
 		LastReviewedAt:     stats.LastReviewedAt,
 		NextReviewAt:       stats.NextReviewAt,
 		ReviewCount:        stats.ReviewCount,
+		Lapses:             stats.Lapses,
+		Phase:              stats.Phase,
+		LearningStep:       stats.LearningStep,
 		CreatedAt:          stats.CreatedAt,
 		UpdatedAt:          now, // Update the updated timestamp
 	}
@@ -113,6 +120,17 @@ func (s *defaultService) PostponeReview(
 	return newStats, nil
 }
 
+// WithOverrides implements the Service interface for per-deck scheduling
+func (s *defaultService) WithOverrides(overrides Overrides) Service {
+	if overrides.IsZero() {
+		return s
+	}
+
+	return &defaultService{
+		params: s.params.WithOverrides(overrides),
+	}
+}
+
 // isValidOutcome checks if the given outcome is valid
 func isValidOutcome(outcome domain.ReviewOutcome) bool {
 	switch outcome {
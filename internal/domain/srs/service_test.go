@@ -220,3 +220,55 @@ func TestPostponeReview(t *testing.T) {
 		t.Error("Expected error for nil stats, got nil")
 	}
 }
+
+func TestWithOverrides(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+	service, err := NewDefaultService()
+	require.NoError(t, err, "Failed to create SRS service")
+	now := time.Now().UTC()
+
+	newStats := func() *domain.UserCardStats {
+		stats, err := domain.NewUserCardStats(uuid.New(), uuid.New())
+		require.NoError(t, err)
+		return stats
+	}
+
+	if service.WithOverrides(Overrides{}) != service {
+		t.Error("Expected empty overrides to return the same service")
+	}
+
+	t.Run("again_review_minutes", func(t *testing.T) {
+		overridden := service.WithOverrides(Overrides{AgainReviewMinutes: 1})
+
+		updated, err := overridden.CalculateNextReview(newStats(), domain.ReviewOutcomeAgain, now)
+		require.NoError(t, err)
+		if !updated.NextReviewAt.Equal(now.Add(time.Minute)) {
+			t.Errorf("Expected next review in 1 minute, got %v", updated.NextReviewAt.Sub(now))
+		}
+
+		// The original service keeps its default step
+		updated, err = service.CalculateNextReview(newStats(), domain.ReviewOutcomeAgain, now)
+		require.NoError(t, err)
+		if !updated.NextReviewAt.Equal(now.Add(10 * time.Minute)) {
+			t.Errorf("Expected next review in 10 minutes, got %v", updated.NextReviewAt.Sub(now))
+		}
+	})
+
+	t.Run("initial_ease_factor", func(t *testing.T) {
+		overridden := service.WithOverrides(Overrides{InitialEaseFactor: 2.0})
+
+		first, err := overridden.CalculateNextReview(newStats(), domain.ReviewOutcomeGood, now)
+		require.NoError(t, err)
+		if first.EaseFactor != 2.0 {
+			t.Errorf("Expected first review to start from ease 2.0, got %f", first.EaseFactor)
+		}
+
+		// Later reviews continue from the card's own ease factor
+		first.EaseFactor = 2.4
+		second, err := overridden.CalculateNextReview(first, domain.ReviewOutcomeGood, now)
+		require.NoError(t, err)
+		if second.EaseFactor != 2.4 {
+			t.Errorf("Expected later reviews to keep ease 2.4, got %f", second.EaseFactor)
+		}
+	})
+}
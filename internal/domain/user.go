@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"time"
+	_ "time/tzdata" // Embed the IANA time zone database so timezone validation doesn't depend on the host
 
 	"github.com/google/uuid"
 )
@@ -29,6 +30,36 @@ var (
 
 	// ErrUserHashedPasswordEmpty is returned when a hashed password is empty.
 	ErrUserHashedPasswordEmpty = errors.New("hashed password cannot be empty")
+
+	// ErrUserRoleInvalid is returned when a user role is not one of the known roles.
+	ErrUserRoleInvalid = errors.New("invalid user role")
+
+	// ErrUserTimezoneInvalid is returned when a user's timezone is not a known IANA time zone name.
+	ErrUserTimezoneInvalid = errors.New("invalid user timezone")
+
+	// ErrUserNewCardsPerDayInvalid is returned when a user's daily new-card limit is negative.
+	ErrUserNewCardsPerDayInvalid = errors.New("new cards per day cannot be negative")
+
+	// ErrUserGenerationQuotaInvalid is returned when a user's monthly generation quota is negative.
+	ErrUserGenerationQuotaInvalid = errors.New("generation quota cannot be negative")
+)
+
+// DefaultUserTimezone is the timezone assigned to users who haven't configured one.
+const DefaultUserTimezone = "UTC"
+
+// DefaultNewCardsPerDay is the number of never-reviewed cards introduced per day
+// for users who haven't configured a limit.
+const DefaultNewCardsPerDay = 20
+
+// UserRole represents the authorization role assigned to a user.
+type UserRole string
+
+// Possible user role values
+const (
+	// UserRoleUser is the default role for regular users.
+	UserRoleUser UserRole = "user"
+	// UserRoleAdmin grants access to administrative operations.
+	UserRoleAdmin UserRole = "admin"
 )
 
 // User represents a registered user of the Scry application.
@@ -38,8 +69,29 @@ type User struct {
 	Email          string    `json:"email"`
 	Password       string    `json:"-"` // Plaintext password, used temporarily during registration/updates
 	HashedPassword string    `json:"-"` // Never expose password hash in JSON
+	Role           UserRole  `json:"role"`
+	Timezone       string    `json:"timezone"`          // IANA time zone name, e.g. "Europe/Berlin"
+	NewCardsPerDay int       `json:"new_cards_per_day"` // Max never-reviewed cards served per day; 0 disables new cards
+	EmailVerified  bool      `json:"email_verified"`    // Whether the user has confirmed their email address
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
+
+	// PasswordChangedAt is when the password was last changed or reset, or nil
+	// if it never was. Sessions issued before it are revoked.
+	PasswordChangedAt *time.Time `json:"-"`
+
+	// GenerationQuota overrides the configured number of card generations the
+	// user may request per month, or is nil to use it. 0 means unlimited.
+	GenerationQuota *int `json:"generation_quota,omitempty"`
+
+	// DisabledAt is when an admin disabled the account, or nil if it is
+	// enabled. Disabled users cannot log in, refresh sessions or use
+	// previously issued access tokens.
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+
+	// NewCardOverrides replaces the default settings that the stats of the
+	// user's new cards start from. Deck settings take precedence over it.
+	NewCardOverrides NewCardOverrides `json:"new_card_overrides"`
 }
 
 // NewUser creates a new User with the given email and password.
@@ -55,11 +107,14 @@ func NewUser(email, password string) (*User, error) {
 	}
 
 	user := &User{
-		ID:        uuid.New(),
-		Email:     email,
-		Password:  password, // Plaintext password - must be hashed before storage
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
+		ID:             uuid.New(),
+		Email:          email,
+		Password:       password, // Plaintext password - must be hashed before storage
+		Role:           UserRoleUser,
+		Timezone:       DefaultUserTimezone,
+		NewCardsPerDay: DefaultNewCardsPerDay,
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
 	}
 
 	// Check other validation rules (ID, email, etc.)
@@ -94,7 +149,97 @@ func (u *User) Validate() error {
 		return ErrUserHashedPasswordEmpty
 	}
 
-	return nil
+	// An empty role is allowed and treated as UserRoleUser by the store
+	if u.Role != "" && !isValidUserRole(u.Role) {
+		return ErrUserRoleInvalid
+	}
+
+	// An empty timezone is allowed and treated as DefaultUserTimezone by the store
+	if u.Timezone != "" && !isValidTimezone(u.Timezone) {
+		return ErrUserTimezoneInvalid
+	}
+
+	if u.NewCardsPerDay < 0 {
+		return ErrUserNewCardsPerDayInvalid
+	}
+
+	if u.GenerationQuota != nil && *u.GenerationQuota < 0 {
+		return ErrUserGenerationQuotaInvalid
+	}
+
+	return u.NewCardOverrides.Validate()
+}
+
+// MonthlyGenerationQuota returns how many card generations the user may
+// request per month: their own quota if set, otherwise defaultQuota.
+// 0 means unlimited.
+func (u *User) MonthlyGenerationQuota(defaultQuota int) int {
+	if u.GenerationQuota != nil {
+		return *u.GenerationQuota
+	}
+	return defaultQuota
+}
+
+// Location returns the time.Location for the user's configured timezone.
+// It falls back to UTC if the timezone is empty or cannot be loaded.
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// EndOfDay returns the instant at which the calendar day containing now ends in
+// the user's timezone, i.e. midnight at the start of the user's next day, in UTC.
+// Use it for day-based groupings such as "due today"; SRS scheduling itself stays in UTC.
+func (u *User) EndOfDay(now time.Time) time.Time {
+	local := now.In(u.Location())
+	year, month, day := local.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, local.Location()).
+		AddDate(0, 0, 1).
+		UTC()
+}
+
+// SessionRevoked reports whether a session token issued at issuedAt was
+// revoked by a later password change. Token issue times only have one-second
+// precision, so tokens issued during the second of the change are kept;
+// otherwise a login straight after a reset could be rejected.
+func (u *User) SessionRevoked(issuedAt time.Time) bool {
+	if u.PasswordChangedAt == nil {
+		return false
+	}
+	return issuedAt.Before(u.PasswordChangedAt.Truncate(time.Second))
+}
+
+// IsDisabled reports whether an admin has disabled the account.
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// isValidTimezone checks if the provided name is a loadable IANA time zone.
+// "Local" is rejected because its meaning depends on the server's configuration.
+func isValidTimezone(name string) bool {
+	if name == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// isValidUserRole checks if the provided role is one of the defined UserRole values.
+func isValidUserRole(role UserRole) bool {
+	switch role {
+	case UserRoleUser, UserRoleAdmin:
+		return true
+	default:
+		return false
+	}
 }
 
 // TODO(email-validation): Replace this basic email validation with a more robust solution:
@@ -160,12 +305,13 @@ func validateEmailFormat(email string) bool {
 // ValidatePassword validates the plaintext password format
 // This should be called before setting the password field or creating a user
 // Returns specific errors based on validation failures.
+// It only enforces the length bounds; see PasswordPolicy for the configurable rules.
 func ValidatePassword(password string) error {
 	passLen := len(password)
-	if passLen < 12 {
+	if passLen < MinPasswordLength {
 		return ErrUserPasswordTooShort
 	}
-	if passLen > 72 {
+	if passLen > MaxPasswordLength {
 		return ErrUserPasswordTooLong
 	}
 	return nil
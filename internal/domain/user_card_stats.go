@@ -18,6 +18,33 @@ const (
 	ReviewOutcomeEasy  ReviewOutcome = "easy"
 )
 
+// CardPhase is the stage of spaced repetition a card is in for a user
+type CardPhase string
+
+// Possible card phase values
+const (
+	// CardPhaseLearning cards are new and step through the learning steps
+	// before graduating to interval scheduling
+	CardPhaseLearning CardPhase = "learning"
+
+	// CardPhaseReview cards are scheduled by the interval algorithm
+	CardPhaseReview CardPhase = "review"
+
+	// CardPhaseRelearning cards lapsed during review and step through the
+	// learning steps again before returning to interval scheduling
+	CardPhaseRelearning CardPhase = "relearning"
+)
+
+// IsValid reports whether p is a known card phase
+func (p CardPhase) IsValid() bool {
+	switch p {
+	case CardPhaseLearning, CardPhaseReview, CardPhaseRelearning:
+		return true
+	default:
+		return false
+	}
+}
+
 // UserCardStats-specific validation errors
 var (
 	// ErrStatsUserIDEmpty is returned when a user card stats user ID is empty or nil.
@@ -32,6 +59,15 @@ var (
 	// ErrStatsEaseFactorInvalid is returned when an ease factor is invalid.
 	ErrStatsEaseFactorInvalid = errors.New("ease factor must be greater than 1.0")
 
+	// ErrStatsLapsesInvalid is returned when a lapse count is negative.
+	ErrStatsLapsesInvalid = errors.New("lapses must be greater than or equal to 0")
+
+	// ErrStatsPhaseInvalid is returned when a card phase is not a known phase.
+	ErrStatsPhaseInvalid = errors.New("invalid card phase")
+
+	// ErrStatsLearningStepInvalid is returned when a learning step index is negative.
+	ErrStatsLearningStepInvalid = errors.New("learning step must be greater than or equal to 0")
+
 	// ErrStatsReviewOutcomeInvalid is returned when a review outcome is invalid.
 	ErrStatsReviewOutcomeInvalid = errors.New("invalid review outcome")
 )
@@ -47,6 +83,9 @@ type UserCardStats struct {
 	LastReviewedAt     time.Time `json:"last_reviewed_at"`    // When the card was last reviewed
 	NextReviewAt       time.Time `json:"next_review_at"`      // When the card should be reviewed next
 	ReviewCount        int       `json:"review_count"`        // Total number of reviews
+	Lapses             int       `json:"lapses"`              // Number of reviews answered "again"
+	Phase              CardPhase `json:"phase"`               // Learning, review or relearning; see CurrentPhase
+	LearningStep       int       `json:"learning_step"`       // Index of the current learning step while (re)learning
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
 }
@@ -54,16 +93,36 @@ type UserCardStats struct {
 // NewUserCardStats creates new statistics for a user and card with default values.
 // Initial settings are configured for immediate review of new cards.
 func NewUserCardStats(userID, cardID uuid.UUID) (*UserCardStats, error) {
-	now := time.Now().UTC()
+	return NewUserCardStatsWithSettings(userID, cardID, DefaultNewCardSettings(), time.Now().UTC())
+}
+
+// NewUserCardStatsWithSettings creates new statistics for a user and card,
+// starting from settings as of now.
+func NewUserCardStatsWithSettings(
+	userID, cardID uuid.UUID,
+	settings NewCardSettings,
+	now time.Time,
+) (*UserCardStats, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	now = now.UTC()
+	nextReviewAt := now // Card is available for review immediately
+	if !settings.DueImmediately {
+		nextReviewAt = now.AddDate(0, 0, settings.IntervalDays)
+	}
+
 	stats := &UserCardStats{
 		UserID:             userID,
 		CardID:             cardID,
-		Interval:           0,
-		EaseFactor:         2.5, // Default ease factor
+		Interval:           settings.IntervalDays,
+		EaseFactor:         settings.EaseFactor,
 		ConsecutiveCorrect: 0,
 		LastReviewedAt:     time.Time{}, // Zero time
-		NextReviewAt:       now,         // Card is available for review immediately
+		NextReviewAt:       nextReviewAt,
 		ReviewCount:        0,
+		Phase:              CardPhaseLearning,
 		CreatedAt:          now,
 		UpdatedAt:          now,
 	}
@@ -94,9 +153,33 @@ func (s *UserCardStats) Validate() error {
 		return ErrStatsEaseFactorInvalid
 	}
 
+	if s.Lapses < 0 {
+		return ErrStatsLapsesInvalid
+	}
+
+	if s.Phase != "" && !s.Phase.IsValid() {
+		return ErrStatsPhaseInvalid
+	}
+
+	if s.LearningStep < 0 {
+		return ErrStatsLearningStepInvalid
+	}
+
 	return nil
 }
 
+// CurrentPhase returns the card's phase. Stats recorded without a phase are in
+// the learning phase until their first review and in the review phase after it.
+func (s *UserCardStats) CurrentPhase() CardPhase {
+	if s.Phase != "" {
+		return s.Phase
+	}
+	if s.ReviewCount == 0 {
+		return CardPhaseLearning
+	}
+	return CardPhaseReview
+}
+
 // Note: Mutable methods UpdateReview and PostponeReview have been removed.
 // Use srs.Service.CalculateNextReview and srs.Service.PostponeReview instead,
 // which follow immutability principles by returning new instances rather than
@@ -54,6 +54,10 @@ func TestNewUserCardStats(t *testing.T) {
 		t.Errorf("Expected review count 0, got %d", stats.ReviewCount)
 	}
 
+	if stats.Phase != CardPhaseLearning {
+		t.Errorf("Expected phase %q, got %q", CardPhaseLearning, stats.Phase)
+	}
+
 	if stats.CreatedAt.IsZero() {
 		t.Error("Expected non-zero CreatedAt time")
 	}
@@ -116,6 +120,40 @@ func TestUserCardStatsValidate(t *testing.T) {
 	if err := invalidStats.Validate(); err != ErrStatsEaseFactorInvalid {
 		t.Errorf("Expected error %v, got %v", ErrStatsEaseFactorInvalid, err)
 	}
+
+	// Test invalid Phase
+	invalidStats = validStats
+	invalidStats.Phase = "graduated"
+	if err := invalidStats.Validate(); err != ErrStatsPhaseInvalid {
+		t.Errorf("Expected error %v, got %v", ErrStatsPhaseInvalid, err)
+	}
+
+	// Test invalid LearningStep
+	invalidStats = validStats
+	invalidStats.LearningStep = -1
+	if err := invalidStats.Validate(); err != ErrStatsLearningStepInvalid {
+		t.Errorf("Expected error %v, got %v", ErrStatsLearningStepInvalid, err)
+	}
+}
+
+func TestUserCardStatsCurrentPhase(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	tests := []struct {
+		name  string
+		stats UserCardStats
+		want  CardPhase
+	}{
+		{name: "recorded phase", stats: UserCardStats{Phase: CardPhaseRelearning, ReviewCount: 3}, want: CardPhaseRelearning},
+		{name: "no phase, never reviewed", stats: UserCardStats{}, want: CardPhaseLearning},
+		{name: "no phase, reviewed", stats: UserCardStats{ReviewCount: 1}, want: CardPhaseReview},
+	}
+
+	for _, tt := range tests {
+		if got := tt.stats.CurrentPhase(); got != tt.want {
+			t.Errorf("%s: expected phase %q, got %q", tt.name, tt.want, got)
+		}
+	}
 }
 
 // Note: The TestUpdateReview and TestPostponeReview tests have been removed
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -30,6 +31,14 @@ func TestNewUser(t *testing.T) {
 		t.Errorf("Expected password %s, got %s", validPassword, user.Password)
 	}
 
+	if user.Timezone != DefaultUserTimezone {
+		t.Errorf("Expected timezone %s, got %s", DefaultUserTimezone, user.Timezone)
+	}
+
+	if user.NewCardsPerDay != DefaultNewCardsPerDay {
+		t.Errorf("Expected %d new cards per day, got %d", DefaultNewCardsPerDay, user.NewCardsPerDay)
+	}
+
 	if user.CreatedAt.IsZero() {
 		t.Error("Expected non-zero CreatedAt time")
 	}
@@ -95,6 +104,58 @@ func TestUserValidate(t *testing.T) {
 		t.Errorf("Expected error %v, got %v", ErrUserHashedPasswordEmpty, err)
 	}
 
+	// Test invalid role
+	invalidUser = validUser
+	invalidUser.Role = "superuser"
+	if err := invalidUser.Validate(); err != ErrUserRoleInvalid {
+		t.Errorf("Expected error %v, got %v", ErrUserRoleInvalid, err)
+	}
+
+	// Test admin role
+	adminUser := validUser
+	adminUser.Role = UserRoleAdmin
+	if err := adminUser.Validate(); err != nil {
+		t.Errorf("Expected no error for admin role, got %v", err)
+	}
+
+	// Test invalid timezone
+	for _, tz := range []string{"Mars/Olympus_Mons", "Local"} {
+		invalidUser = validUser
+		invalidUser.Timezone = tz
+		if err := invalidUser.Validate(); err != ErrUserTimezoneInvalid {
+			t.Errorf("Expected error %v for timezone %q, got %v", ErrUserTimezoneInvalid, tz, err)
+		}
+	}
+
+	// Test valid timezone
+	tokyoUser := validUser
+	tokyoUser.Timezone = "Asia/Tokyo"
+	if err := tokyoUser.Validate(); err != nil {
+		t.Errorf("Expected no error for valid timezone, got %v", err)
+	}
+
+	// Test negative new-card limit
+	invalidUser = validUser
+	invalidUser.NewCardsPerDay = -1
+	if err := invalidUser.Validate(); err != ErrUserNewCardsPerDayInvalid {
+		t.Errorf("Expected error %v, got %v", ErrUserNewCardsPerDayInvalid, err)
+	}
+
+	// Test zero new-card limit (disables new cards)
+	noNewCardsUser := validUser
+	noNewCardsUser.NewCardsPerDay = 0
+	if err := noNewCardsUser.Validate(); err != nil {
+		t.Errorf("Expected no error for zero new cards per day, got %v", err)
+	}
+
+	// Test negative generation quota
+	invalidUser = validUser
+	quota := -1
+	invalidUser.GenerationQuota = &quota
+	if err := invalidUser.Validate(); err != ErrUserGenerationQuotaInvalid {
+		t.Errorf("Expected error %v, got %v", ErrUserGenerationQuotaInvalid, err)
+	}
+
 	// Test with Password present but HashedPassword empty - should pass validation
 	// as the Password will be hashed during persistence
 	validUser = User{
@@ -309,3 +370,116 @@ func TestValidatePasswordComplexity(t *testing.T) {
 		})
 	}
 }
+
+func TestUserLocation(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	user := User{Timezone: "America/Los_Angeles"}
+	if got := user.Location().String(); got != "America/Los_Angeles" {
+		t.Errorf("Expected location America/Los_Angeles, got %s", got)
+	}
+
+	// Empty and unknown timezones fall back to UTC
+	for _, tz := range []string{"", "Not/AZone"} {
+		user := User{Timezone: tz}
+		if got := user.Location(); got != time.UTC {
+			t.Errorf("Expected UTC for timezone %q, got %s", tz, got)
+		}
+	}
+}
+
+func TestUserEndOfDay(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	// 20:00 UTC on March 1 is 05:00 on March 2 in Tokyo and 12:00 on March 1 in Los Angeles
+	now := time.Date(2025, time.March, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timezone string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "UTC",
+			timezone: "UTC",
+			now:      now,
+			expected: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "empty timezone uses UTC",
+			timezone: "",
+			now:      now,
+			expected: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Tokyo is already on the next day",
+			timezone: "Asia/Tokyo",
+			now:      now,
+			expected: time.Date(2025, time.March, 2, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Los Angeles is still on the same day",
+			timezone: "America/Los_Angeles",
+			now:      now,
+			expected: time.Date(2025, time.March, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Los Angeles across a daylight saving change",
+			timezone: "America/Los_Angeles",
+			now:      time.Date(2025, time.March, 9, 9, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, time.March, 10, 7, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			user := User{Timezone: tc.timezone}
+			got := user.EndOfDay(tc.now)
+			if !got.Equal(tc.expected) {
+				t.Errorf("Expected end of day %s, got %s", tc.expected, got)
+			}
+			if got.Location() != time.UTC {
+				t.Errorf("Expected result in UTC, got %s", got.Location())
+			}
+		})
+	}
+}
+
+func TestUserSessionRevoked(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	changedAt := time.Date(2025, time.March, 1, 12, 0, 0, 500_000_000, time.UTC)
+	user := &User{PasswordChangedAt: &changedAt}
+
+	if (&User{}).SessionRevoked(changedAt.Add(-time.Hour)) {
+		t.Error("Sessions should not be revoked if the password never changed")
+	}
+	if !user.SessionRevoked(changedAt.Add(-time.Second)) {
+		t.Error("Sessions issued before the change should be revoked")
+	}
+	if user.SessionRevoked(changedAt.Truncate(time.Second)) {
+		t.Error("Sessions issued in the second of the change should be kept")
+	}
+	if user.SessionRevoked(changedAt.Add(time.Minute)) {
+		t.Error("Sessions issued after the change should be kept")
+	}
+}
+
+func TestUserMonthlyGenerationQuota(t *testing.T) {
+	t.Parallel() // Enable parallel execution
+
+	if got := (&User{}).MonthlyGenerationQuota(100); got != 100 {
+		t.Errorf("Expected the default quota of 100, got %d", got)
+	}
+
+	quota := 5
+	if got := (&User{GenerationQuota: &quota}).MonthlyGenerationQuota(100); got != 5 {
+		t.Errorf("Expected the user's quota of 5, got %d", got)
+	}
+
+	unlimited := 0
+	if got := (&User{GenerationQuota: &unlimited}).MonthlyGenerationQuota(100); got != 0 {
+		t.Errorf("Expected the user's unlimited quota, got %d", got)
+	}
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// ErrAsyncQueueFull is returned when an AsyncHandler cannot accept an event
+// because its queue is full.
+var ErrAsyncQueueFull = errors.New("async event queue is full")
+
+// ErrAsyncHandlerClosed is returned when an event is handed to an AsyncHandler
+// after it has been closed.
+var ErrAsyncHandlerClosed = errors.New("async event handler is closed")
+
+// AsyncHandler wraps an EventHandler so that events are handled in a background
+// goroutine instead of the caller's. HandleEvent only enqueues the event, so a
+// slow or failing handler, such as an unreachable webhook, never blocks the
+// code that emitted the event.
+//
+// Events are held in memory in a bounded queue; events that arrive while the
+// queue is full are dropped and reported to the caller as ErrAsyncQueueFull.
+type AsyncHandler struct {
+	handler EventHandler
+	queue   chan *TaskRequestEvent
+	done    chan struct{}
+	mu      sync.RWMutex
+	closed  bool
+	logger  *slog.Logger
+}
+
+// NewAsyncHandler starts a background goroutine that passes events to handler,
+// one at a time, from a queue holding up to queueSize events. A non-positive
+// queueSize holds a single event. Call Close to stop the goroutine.
+func NewAsyncHandler(handler EventHandler, queueSize int, logger *slog.Logger) *AsyncHandler {
+	if handler == nil {
+		panic("handler cannot be nil") // ALLOW-PANIC
+	}
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	h := &AsyncHandler{
+		handler: handler,
+		queue:   make(chan *TaskRequestEvent, queueSize),
+		done:    make(chan struct{}),
+		logger:  logger.With("component", "async_event_handler"),
+	}
+	go h.run()
+	return h
+}
+
+// HandleEvent implements EventHandler by queueing the event for the wrapped
+// handler. It returns ErrAsyncQueueFull if the queue is full and
+// ErrAsyncHandlerClosed after Close; the wrapped handler's errors are only logged.
+func (h *AsyncHandler) HandleEvent(ctx context.Context, event *TaskRequestEvent) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.closed {
+		return ErrAsyncHandlerClosed
+	}
+
+	select {
+	case h.queue <- event:
+		return nil
+	default:
+		h.logger.Error("dropping event, async queue is full",
+			"event_id", event.ID,
+			"event_type", event.Type)
+		return fmt.Errorf("%w: event %s", ErrAsyncQueueFull, event.ID)
+	}
+}
+
+// Close stops accepting events and waits until the queued events have been
+// handled or ctx is done, whichever comes first. Events still queued when ctx
+// is done are abandoned.
+func (h *AsyncHandler) Close(ctx context.Context) error {
+	h.mu.Lock()
+	if !h.closed {
+		h.closed = true
+		close(h.queue)
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run handles queued events until the queue is closed and drained.
+func (h *AsyncHandler) run() {
+	defer close(h.done)
+
+	for event := range h.queue {
+		// The emitting request or task may be long finished, so handling uses a
+		// fresh context rather than the one the event was emitted with.
+		if err := h.handle(context.Background(), event); err != nil {
+			h.logger.Error("async handler failed to process event",
+				"error", err,
+				"event_id", event.ID,
+				"event_type", event.Type)
+		}
+	}
+}
+
+// handle runs the wrapped handler, converting a panic into an error so that
+// one bad event does not stop the goroutine.
+func (h *AsyncHandler) handle(ctx context.Context, event *TaskRequestEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+		}
+	}()
+
+	return h.handler.HandleEvent(ctx, event)
+}
+
+// Ensure AsyncHandler implements EventHandler
+var _ EventHandler = (*AsyncHandler)(nil)
@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingEventHandler records events and blocks each one until released
+type blockingEventHandler struct {
+	mu      sync.Mutex
+	handled []*TaskRequestEvent
+	release chan struct{}
+	err     error
+}
+
+func (h *blockingEventHandler) HandleEvent(ctx context.Context, event *TaskRequestEvent) error {
+	<-h.release
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handled = append(h.handled, event)
+	return h.err
+}
+
+func (h *blockingEventHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.handled)
+}
+
+func TestAsyncHandler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	newEvent := func(t *testing.T) *TaskRequestEvent {
+		event, err := NewTaskRequestEvent("test-event", map[string]string{"key": "value"})
+		require.NoError(t, err)
+		return event
+	}
+
+	t.Run("does not block on a slow handler", func(t *testing.T) {
+		inner := &blockingEventHandler{release: make(chan struct{}), err: errors.New("receiver down")}
+		async := NewAsyncHandler(inner, 4, logger)
+
+		done := make(chan error, 1)
+		go func() { done <- async.HandleEvent(context.Background(), newEvent(t)) }()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err, "Handler errors are not returned to the emitter")
+		case <-time.After(time.Second):
+			t.Fatal("HandleEvent blocked on the wrapped handler")
+		}
+
+		close(inner.release)
+		require.NoError(t, async.Close(context.Background()))
+		assert.Equal(t, 1, inner.count())
+	})
+
+	t.Run("drops events when the queue is full", func(t *testing.T) {
+		inner := &blockingEventHandler{release: make(chan struct{})}
+		async := NewAsyncHandler(inner, 1, logger)
+
+		// The first event is taken by the worker, the second fills the queue
+		require.NoError(t, async.HandleEvent(context.Background(), newEvent(t)))
+		require.Eventually(t, func() bool { return len(async.queue) == 0 }, time.Second, time.Millisecond)
+		require.NoError(t, async.HandleEvent(context.Background(), newEvent(t)))
+
+		err := async.HandleEvent(context.Background(), newEvent(t))
+		assert.ErrorIs(t, err, ErrAsyncQueueFull)
+
+		close(inner.release)
+		require.NoError(t, async.Close(context.Background()))
+		assert.Equal(t, 2, inner.count(), "Queued events are handled before Close returns")
+	})
+
+	t.Run("rejects events after close", func(t *testing.T) {
+		inner := &blockingEventHandler{release: make(chan struct{})}
+		close(inner.release)
+		async := NewAsyncHandler(inner, 1, logger)
+		require.NoError(t, async.Close(context.Background()))
+
+		assert.ErrorIs(t, async.HandleEvent(context.Background(), newEvent(t)), ErrAsyncHandlerClosed)
+	})
+
+	t.Run("close gives up when the context is done", func(t *testing.T) {
+		inner := &blockingEventHandler{release: make(chan struct{})}
+		async := NewAsyncHandler(inner, 1, logger)
+		require.NoError(t, async.HandleEvent(context.Background(), newEvent(t)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, async.Close(ctx), context.DeadlineExceeded)
+		close(inner.release)
+	})
+
+	t.Run("recovers from a panicking handler", func(t *testing.T) {
+		async := NewAsyncHandler(&panickingEventHandler{}, 2, logger)
+		require.NoError(t, async.HandleEvent(context.Background(), newEvent(t)))
+		require.NoError(t, async.HandleEvent(context.Background(), newEvent(t)))
+		assert.NoError(t, async.Close(context.Background()))
+	})
+}
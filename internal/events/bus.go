@@ -0,0 +1,128 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// ErrHandlerPanic is returned (joined with any other handler errors) when a
+// handler panics while processing an event.
+var ErrHandlerPanic = errors.New("event handler panicked")
+
+// EventBus dispatches events synchronously to every handler subscribed to the
+// event's type. Unlike InMemoryEventEmitter, handlers are registered per event
+// type and every handler's error is reported, not just the first.
+type EventBus struct {
+	handlers map[string][]EventHandler
+	mu       sync.RWMutex
+	logger   *slog.Logger
+}
+
+// NewEventBus creates a new EventBus with no subscribers.
+func NewEventBus(logger *slog.Logger) *EventBus {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &EventBus{
+		handlers: make(map[string][]EventHandler),
+		logger:   logger.With("component", "event_bus"),
+	}
+}
+
+// Subscribe registers a handler for events of the given type.
+// Handlers are called in the order they were subscribed.
+func (b *EventBus) Subscribe(eventType string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+	b.logger.Debug("subscribed event handler",
+		"event_type", eventType,
+		"handler_count", len(b.handlers[eventType]))
+}
+
+// Unsubscribe removes a handler previously registered for the given event
+// type with Subscribe. The handler must be the same comparable value, such as
+// the same pointer, that was subscribed. Unsubscribing a handler that is not
+// subscribed does nothing.
+func (b *EventBus) Unsubscribe(eventType string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	handlers := b.handlers[eventType]
+	for i, h := range handlers {
+		if h != handler {
+			continue
+		}
+		remaining := slices.Delete(handlers, i, i+1)
+		if len(remaining) == 0 {
+			delete(b.handlers, eventType)
+		} else {
+			b.handlers[eventType] = remaining
+		}
+		b.logger.Debug("unsubscribed event handler",
+			"event_type", eventType,
+			"handler_count", len(remaining))
+		return
+	}
+}
+
+// Publish calls every handler subscribed to the event's type, in order, in the
+// calling goroutine. All handlers run even if earlier ones fail; their errors
+// are combined with errors.Join. A panicking handler is recovered and reported
+// as an error wrapping ErrHandlerPanic. Publishing an event with no subscribers
+// is not an error.
+func (b *EventBus) Publish(ctx context.Context, event *TaskRequestEvent) error {
+	b.mu.RLock()
+	handlers := make([]EventHandler, len(b.handlers[event.Type]))
+	copy(handlers, b.handlers[event.Type])
+	b.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		b.logger.Debug("no handlers subscribed for event",
+			"event_id", event.ID,
+			"event_type", event.Type)
+		return nil
+	}
+
+	var errs []error
+	for i, handler := range handlers {
+		if err := b.callHandler(ctx, handler, event); err != nil {
+			b.logger.Error("handler failed to process event",
+				"error", err,
+				"handler_index", i,
+				"event_id", event.ID,
+				"event_type", event.Type)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// EmitEvent implements EventEmitter by publishing the event.
+func (b *EventBus) EmitEvent(ctx context.Context, event *TaskRequestEvent) error {
+	return b.Publish(ctx, event)
+}
+
+// callHandler runs a single handler, converting a panic into an error.
+func (b *EventBus) callHandler(
+	ctx context.Context,
+	handler EventHandler,
+	event *TaskRequestEvent,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+		}
+	}()
+
+	return handler.HandleEvent(ctx, event)
+}
+
+// Ensure EventBus implements EventEmitter
+var _ EventEmitter = (*EventBus)(nil)
@@ -0,0 +1,124 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// panickingEventHandler is an EventHandler that always panics
+type panickingEventHandler struct{}
+
+func (h *panickingEventHandler) HandleEvent(ctx context.Context, event *TaskRequestEvent) error {
+	panic("boom")
+}
+
+func TestEventBus(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("publish with no subscribers", func(t *testing.T) {
+		bus := NewEventBus(logger)
+		event, err := NewTaskRequestEvent("test-event", map[string]string{"key": "value"})
+		require.NoError(t, err)
+
+		assert.NoError(t, bus.Publish(context.Background(), event))
+	})
+
+	t.Run("all handlers run and errors are surfaced", func(t *testing.T) {
+		bus := NewEventBus(logger)
+
+		handlerErr := errors.New("handler error")
+		failingHandler := &MockEventHandler{HandlerError: handlerErr}
+		successHandler := &MockEventHandler{}
+
+		bus.Subscribe("test-event", failingHandler)
+		bus.Subscribe("test-event", successHandler)
+
+		event, err := NewTaskRequestEvent("test-event", map[string]string{"key": "value"})
+		require.NoError(t, err)
+
+		err = bus.Publish(context.Background(), event)
+
+		assert.ErrorIs(t, err, handlerErr)
+		assert.Equal(t, 1, failingHandler.HandledCount)
+		assert.Equal(t, 1, successHandler.HandledCount, "Handlers after a failing one should still run")
+		assert.Equal(t, event, successHandler.LastEvent)
+	})
+
+	t.Run("errors from several handlers are joined", func(t *testing.T) {
+		bus := NewEventBus(logger)
+
+		firstErr := errors.New("first")
+		secondErr := errors.New("second")
+		bus.Subscribe("test-event", &MockEventHandler{HandlerError: firstErr})
+		bus.Subscribe("test-event", &MockEventHandler{HandlerError: secondErr})
+
+		event, err := NewTaskRequestEvent("test-event", map[string]string{})
+		require.NoError(t, err)
+
+		err = bus.Publish(context.Background(), event)
+
+		assert.ErrorIs(t, err, firstErr)
+		assert.ErrorIs(t, err, secondErr)
+	})
+
+	t.Run("handler panic is recovered", func(t *testing.T) {
+		bus := NewEventBus(logger)
+
+		afterPanic := &MockEventHandler{}
+		bus.Subscribe("test-event", &panickingEventHandler{})
+		bus.Subscribe("test-event", afterPanic)
+
+		event, err := NewTaskRequestEvent("test-event", map[string]string{})
+		require.NoError(t, err)
+
+		var publishErr error
+		require.NotPanics(t, func() {
+			publishErr = bus.Publish(context.Background(), event)
+		})
+
+		assert.ErrorIs(t, publishErr, ErrHandlerPanic)
+		assert.Contains(t, publishErr.Error(), "boom")
+		assert.Equal(t, 1, afterPanic.HandledCount)
+	})
+
+	t.Run("handlers only receive their event type", func(t *testing.T) {
+		bus := NewEventBus(logger)
+
+		memoHandler := &MockEventHandler{}
+		otherHandler := &MockEventHandler{}
+		bus.Subscribe("memo", memoHandler)
+		bus.Subscribe("other", otherHandler)
+
+		event, err := NewTaskRequestEvent("memo", map[string]string{})
+		require.NoError(t, err)
+
+		require.NoError(t, bus.EmitEvent(context.Background(), event))
+
+		assert.Equal(t, 1, memoHandler.HandledCount)
+		assert.Equal(t, 0, otherHandler.HandledCount)
+	})
+
+	t.Run("unsubscribed handlers stop receiving events", func(t *testing.T) {
+		bus := NewEventBus(logger)
+
+		first := &MockEventHandler{}
+		second := &MockEventHandler{}
+		bus.Subscribe("memo", first)
+		bus.Subscribe("memo", second)
+		bus.Unsubscribe("memo", first)
+		bus.Unsubscribe("memo", &MockEventHandler{}) // never subscribed
+
+		event, err := NewTaskRequestEvent("memo", map[string]string{})
+		require.NoError(t, err)
+		require.NoError(t, bus.Publish(context.Background(), event))
+
+		assert.Equal(t, 0, first.HandledCount)
+		assert.Equal(t, 1, second.HandledCount)
+	})
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventTypeCardsGenerated is the type of the event published when a memo has
+// finished generating cards.
+const EventTypeCardsGenerated = "cards_generated"
+
+// CardsGeneratedEvent is the payload of an EventTypeCardsGenerated event.
+type CardsGeneratedEvent struct {
+	// MemoID is the memo the cards were generated from
+	MemoID uuid.UUID `json:"memo_id"`
+
+	// UserID is the owner of the memo and its cards
+	UserID uuid.UUID `json:"user_id"`
+
+	// CardIDs lists the cards that were created, which may be empty
+	CardIDs []uuid.UUID `json:"card_ids"`
+
+	// CompletedAt is when generation finished
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// NewCardsGeneratedEvent wraps the payload in a TaskRequestEvent of type
+// EventTypeCardsGenerated so it can be published on an EventBus.
+func NewCardsGeneratedEvent(payload CardsGeneratedEvent) (*TaskRequestEvent, error) {
+	if payload.CardIDs == nil {
+		payload.CardIDs = []uuid.UUID{}
+	}
+	return NewTaskRequestEvent(EventTypeCardsGenerated, payload)
+}
@@ -9,4 +9,5 @@
 // - TaskRequestEvent: Represents a request to create a background task
 // - EventHandler: Interface for components that can handle events
 // - EventEmitter: Interface for components that can emit events
+// - EventBus: Dispatches each event to every handler subscribed to its type
 package events
@@ -0,0 +1,34 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventTypeEmailVerificationRequested is the type of the event published when
+// a user needs to be sent a link to verify their email address.
+const EventTypeEmailVerificationRequested = "email_verification_requested"
+
+// EmailVerificationRequestedEvent is the payload of an
+// EventTypeEmailVerificationRequested event.
+type EmailVerificationRequestedEvent struct {
+	// UserID is the user whose email address needs verifying
+	UserID uuid.UUID `json:"user_id"`
+
+	// Email is the address the verification token must be sent to
+	Email string `json:"email"`
+
+	// Token is the verification token to include in the email. It grants the
+	// ability to verify the address, so it must not be logged.
+	Token string `json:"token"`
+
+	// ExpiresAt is when the token stops being accepted
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewEmailVerificationRequestedEvent wraps the payload in a TaskRequestEvent of
+// type EventTypeEmailVerificationRequested so it can be published on an EventBus.
+func NewEmailVerificationRequestedEvent(payload EmailVerificationRequestedEvent) (*TaskRequestEvent, error) {
+	return NewTaskRequestEvent(EventTypeEmailVerificationRequested, payload)
+}
@@ -21,6 +21,10 @@ type TaskRequestEvent struct {
 	// Payload contains the task-specific data serialized as JSON
 	Payload json.RawMessage `json:"payload"`
 
+	// Priority is the priority of the task to create; higher runs first.
+	// Zero is the lowest, used for background work.
+	Priority int `json:"priority,omitempty"`
+
 	// CreatedAt is the timestamp when the event was created
 	CreatedAt time.Time `json:"created_at"`
 }
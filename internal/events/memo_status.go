@@ -0,0 +1,35 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventTypeMemoStatusChanged is the type of the event published when a memo
+// moves through card generation, such as from pending to processing.
+const EventTypeMemoStatusChanged = "memo_status_changed"
+
+// MemoStatusChangedEvent is the payload of an EventTypeMemoStatusChanged event.
+type MemoStatusChangedEvent struct {
+	// MemoID is the memo whose status changed
+	MemoID uuid.UUID `json:"memo_id"`
+
+	// UserID is the owner of the memo
+	UserID uuid.UUID `json:"user_id"`
+
+	// Status is the memo's new status, one of the domain.MemoStatus values
+	Status string `json:"status"`
+
+	// CardCount is how many cards have been generated so far
+	CardCount int `json:"card_count"`
+
+	// ChangedAt is when the status changed
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// NewMemoStatusChangedEvent wraps the payload in a TaskRequestEvent of type
+// EventTypeMemoStatusChanged so it can be published on an EventBus.
+func NewMemoStatusChangedEvent(payload MemoStatusChangedEvent) (*TaskRequestEvent, error) {
+	return NewTaskRequestEvent(EventTypeMemoStatusChanged, payload)
+}
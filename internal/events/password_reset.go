@@ -0,0 +1,34 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventTypePasswordResetRequested is the type of the event published when a
+// user asks to reset a forgotten password.
+const EventTypePasswordResetRequested = "password_reset_requested"
+
+// PasswordResetRequestedEvent is the payload of an
+// EventTypePasswordResetRequested event.
+type PasswordResetRequestedEvent struct {
+	// UserID is the user whose password may be reset
+	UserID uuid.UUID `json:"user_id"`
+
+	// Email is the address the reset token must be sent to
+	Email string `json:"email"`
+
+	// Token is the reset token to include in the email. It grants the ability
+	// to change the user's password, so it must not be logged.
+	Token string `json:"token"`
+
+	// ExpiresAt is when the token stops being accepted
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewPasswordResetRequestedEvent wraps the payload in a TaskRequestEvent of
+// type EventTypePasswordResetRequested so it can be published on an EventBus.
+func NewPasswordResetRequestedEvent(payload PasswordResetRequestedEvent) (*TaskRequestEvent, error) {
+	return NewTaskRequestEvent(EventTypePasswordResetRequested, payload)
+}
@@ -0,0 +1,102 @@
+package generation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// CardBoundsGenerator wraps a Generator to keep the number of cards generated
+// from a memo within bounds. A response with fewer than minCards cards is
+// retried once; if the retry is also short, the call fails with
+// ErrInvalidResponse and ErrTooFewCards, which is permanent: the provider
+// responded, so neither retrying the task nor the circuit breaker treat it as
+// an outage. A response with more than maxCards cards is truncated to the
+// first maxCards.
+//
+// It is safe for concurrent use if the wrapped generator is.
+type CardBoundsGenerator struct {
+	generator Generator
+	minCards  int
+	maxCards  int
+	logger    *slog.Logger
+}
+
+// NewCardBoundsGenerator wraps generator so that it returns between minCards
+// and maxCards cards. A minCards of 0 accepts any number of cards, including
+// none, and a maxCards of 0 leaves the number unlimited.
+func NewCardBoundsGenerator(
+	generator Generator,
+	minCards int,
+	maxCards int,
+	logger *slog.Logger,
+) (*CardBoundsGenerator, error) {
+	if generator == nil {
+		return nil, fmt.Errorf("%w: generator cannot be nil", ErrInvalidConfig)
+	}
+	if minCards < 0 || maxCards < 0 {
+		return nil, fmt.Errorf("%w: card count bounds cannot be negative", ErrInvalidConfig)
+	}
+	if maxCards > 0 && minCards > maxCards {
+		return nil, fmt.Errorf("%w: minimum card count %d is above the maximum %d",
+			ErrInvalidConfig, minCards, maxCards)
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &CardBoundsGenerator{
+		generator: generator,
+		minCards:  minCards,
+		maxCards:  maxCards,
+		logger:    logger.With("component", "generation_card_bounds"),
+	}, nil
+}
+
+// GenerateCards implements Generator, enforcing the card count bounds on the
+// wrapped generator's cards.
+func (g *CardBoundsGenerator) GenerateCards(
+	ctx context.Context,
+	memoText string,
+	userID uuid.UUID,
+) ([]*domain.Card, error) {
+	cards, err := g.generator.GenerateCards(ctx, memoText, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cards) < g.minCards {
+		g.logger.WarnContext(ctx, "too few cards generated, retrying",
+			"card_count", len(cards),
+			"min_cards", g.minCards)
+
+		cards, err = g.generator.GenerateCards(ctx, memoText, userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(cards) < g.minCards {
+			return nil, fmt.Errorf("%w: %w: got %d cards, need at least %d",
+				ErrInvalidResponse, ErrTooFewCards, len(cards), g.minCards)
+		}
+	}
+
+	if g.maxCards > 0 && len(cards) > g.maxCards {
+		g.logger.WarnContext(ctx, "too many cards generated, truncating",
+			"card_count", len(cards),
+			"max_cards", g.maxCards)
+		cards = cards[:g.maxCards]
+	}
+
+	return cards, nil
+}
+
+// Ping implements Generator by pinging the wrapped generator.
+func (g *CardBoundsGenerator) Ping(ctx context.Context) error {
+	return g.generator.Ping(ctx)
+}
+
+// Ensure CardBoundsGenerator implements Generator
+var _ Generator = (*CardBoundsGenerator)(nil)
@@ -0,0 +1,109 @@
+package generation_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/generation"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCards returns n cards with distinct fronts
+func newCards(t *testing.T, n int) []*domain.Card {
+	t.Helper()
+	userID, memoID := uuid.New(), uuid.New()
+	cards := make([]*domain.Card, n)
+	for i := range cards {
+		content, err := json.Marshal(domain.CardContent{Front: fmt.Sprintf("Question %d", i), Back: "Answer"})
+		require.NoError(t, err)
+		cards[i], err = domain.NewCard(userID, memoID, content)
+		require.NoError(t, err)
+	}
+	return cards
+}
+
+func newTestBounds(t *testing.T, provider generation.Generator) *generation.CardBoundsGenerator {
+	t.Helper()
+	bounded, err := generation.NewCardBoundsGenerator(provider, 2, 10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return bounded
+}
+
+func TestCardBoundsGenerator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("too few cards are retried once and then fail", func(t *testing.T) {
+		provider := mocks.NewScriptableGenerator().Otherwise(mocks.GeneratorResponse{Cards: nil})
+		bounded := newTestBounds(t, provider)
+
+		cards, err := bounded.GenerateCards(ctx, "memo text", uuid.New())
+
+		assert.Nil(t, cards)
+		assert.ErrorIs(t, err, generation.ErrTooFewCards)
+		assert.ErrorIs(t, err, generation.ErrInvalidResponse, "A short response is not worth retrying the task for")
+		assert.NotErrorIs(t, err, generation.ErrTransientFailure)
+		assert.Equal(t, 2, provider.CallCount())
+	})
+
+	t.Run("a retry with enough cards succeeds", func(t *testing.T) {
+		provider := mocks.NewScriptableGenerator().
+			ThenCards(newCards(t, 1)...).
+			ThenCards(newCards(t, 3)...)
+		bounded := newTestBounds(t, provider)
+
+		cards, err := bounded.GenerateCards(ctx, "memo text", uuid.New())
+
+		require.NoError(t, err)
+		assert.Len(t, cards, 3)
+		assert.Equal(t, 2, provider.CallCount())
+	})
+
+	t.Run("too many cards are truncated to the maximum", func(t *testing.T) {
+		generated := newCards(t, 100)
+		provider := mocks.NewScriptableGenerator().ThenCards(generated...)
+		bounded := newTestBounds(t, provider)
+
+		cards, err := bounded.GenerateCards(ctx, "memo text", uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, generated[:10], cards, "The first cards are kept")
+		assert.Equal(t, 1, provider.CallCount())
+	})
+
+	t.Run("provider errors are returned unchanged", func(t *testing.T) {
+		providerDown := fmt.Errorf("%w: service unavailable", generation.ErrTransientFailure)
+		provider := mocks.NewScriptableGenerator().ThenError(providerDown)
+		bounded := newTestBounds(t, provider)
+
+		_, err := bounded.GenerateCards(ctx, "memo text", uuid.New())
+
+		assert.ErrorIs(t, err, generation.ErrTransientFailure)
+		assert.Equal(t, 1, provider.CallCount(), "Only short responses are retried here")
+	})
+
+	t.Run("invalid bounds are rejected", func(t *testing.T) {
+		provider := mocks.NewScriptableGenerator()
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		_, err := generation.NewCardBoundsGenerator(provider, 5, 4, logger)
+		assert.ErrorIs(t, err, generation.ErrInvalidConfig)
+		_, err = generation.NewCardBoundsGenerator(provider, -1, 0, logger)
+		assert.ErrorIs(t, err, generation.ErrInvalidConfig)
+		_, err = generation.NewCardBoundsGenerator(nil, 0, 0, logger)
+		assert.ErrorIs(t, err, generation.ErrInvalidConfig)
+
+		unbounded, err := generation.NewCardBoundsGenerator(provider.ThenCards(newCards(t, 100)...), 0, 0, logger)
+		require.NoError(t, err)
+		cards, err := unbounded.GenerateCards(ctx, "memo text", uuid.New())
+		require.NoError(t, err)
+		assert.Len(t, cards, 100, "0 leaves the number of cards unlimited")
+	})
+}
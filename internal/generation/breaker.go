@@ -0,0 +1,205 @@
+package generation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// BreakerState is the state of a CircuitBreakerGenerator.
+type BreakerState string
+
+const (
+	// BreakerClosed lets every call through to the provider
+	BreakerClosed BreakerState = "closed"
+
+	// BreakerOpen rejects every call until the cooldown has passed
+	BreakerOpen BreakerState = "open"
+
+	// BreakerHalfOpen lets a single trial call through to test whether the
+	// provider has recovered, rejecting the rest until it completes
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// BreakerOption configures a CircuitBreakerGenerator.
+type BreakerOption func(*CircuitBreakerGenerator)
+
+// WithBreakerTimeFunc sets the clock used to time the cooldown, for tests.
+func WithBreakerTimeFunc(timeFunc func() time.Time) BreakerOption {
+	return func(b *CircuitBreakerGenerator) {
+		b.timeFunc = timeFunc
+	}
+}
+
+// CircuitBreakerGenerator wraps a Generator so that an unavailable provider is
+// not called again and again. After threshold calls in a row fail, the breaker
+// opens and calls fail fast with ErrTransientFailure and ErrCircuitOpen for the
+// cooldown. It then half-opens: the next call is let through, closing the
+// breaker if it succeeds or reopening it for another cooldown if it fails.
+//
+// Failures caused by the memo itself, such as blocked content or a response
+// that cannot be parsed, show the provider is available and count as successes.
+// Calls cancelled by their caller do not count either way.
+//
+// A single CircuitBreakerGenerator must be shared by every worker so that they
+// see the same state. It is safe for concurrent use.
+type CircuitBreakerGenerator struct {
+	generator Generator
+	threshold int
+	cooldown  time.Duration
+	timeFunc  func() time.Time
+	logger    *slog.Logger
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trialing bool
+}
+
+// NewCircuitBreakerGenerator wraps generator with a circuit breaker that opens
+// after threshold consecutive failures and stays open for cooldown.
+func NewCircuitBreakerGenerator(
+	generator Generator,
+	threshold int,
+	cooldown time.Duration,
+	logger *slog.Logger,
+	opts ...BreakerOption,
+) (*CircuitBreakerGenerator, error) {
+	if generator == nil {
+		return nil, fmt.Errorf("%w: generator cannot be nil", ErrInvalidConfig)
+	}
+	if threshold <= 0 {
+		return nil, fmt.Errorf("%w: breaker failure threshold must be positive", ErrInvalidConfig)
+	}
+	if cooldown <= 0 {
+		return nil, fmt.Errorf("%w: breaker cooldown must be positive", ErrInvalidConfig)
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	b := &CircuitBreakerGenerator{
+		generator: generator,
+		threshold: threshold,
+		cooldown:  cooldown,
+		timeFunc:  time.Now,
+		logger:    logger.With("component", "generation_circuit_breaker"),
+		state:     BreakerClosed,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b, nil
+}
+
+// State returns the breaker's current state. An open breaker whose cooldown has
+// passed reports BreakerHalfOpen, since the next call will be let through.
+func (b *CircuitBreakerGenerator) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && !b.timeFunc().Before(b.openedAt.Add(b.cooldown)) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// GenerateCards implements Generator, calling the wrapped generator unless the
+// breaker is open.
+func (b *CircuitBreakerGenerator) GenerateCards(
+	ctx context.Context,
+	memoText string,
+	userID uuid.UUID,
+) ([]*domain.Card, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+
+	cards, err := b.generator.GenerateCards(ctx, memoText, userID)
+	b.record(ctx, err)
+	return cards, err
+}
+
+// Ping implements Generator by pinging the wrapped generator. Pings always
+// reach the provider, even while the breaker is open, and don't affect its state.
+func (b *CircuitBreakerGenerator) Ping(ctx context.Context) error {
+	return b.generator.Ping(ctx)
+}
+
+// allow reports whether a call may go ahead, claiming the trial call when the
+// cooldown has passed
+func (b *CircuitBreakerGenerator) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		return nil
+	case BreakerOpen:
+		if b.timeFunc().Before(b.openedAt.Add(b.cooldown)) {
+			return fmt.Errorf("%w: %w", ErrTransientFailure, ErrCircuitOpen)
+		}
+		b.state = BreakerHalfOpen
+		b.trialing = true
+		b.logger.Info("circuit breaker half-open, testing provider")
+		return nil
+	default: // BreakerHalfOpen
+		if b.trialing {
+			return fmt.Errorf("%w: %w", ErrTransientFailure, ErrCircuitOpen)
+		}
+		b.trialing = true
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of a call that was let through
+func (b *CircuitBreakerGenerator) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	halfOpen := b.state == BreakerHalfOpen
+	b.trialing = false
+
+	switch {
+	case providerResponded(err):
+		if halfOpen {
+			b.logger.Info("circuit breaker closed, provider recovered")
+		}
+		b.state = BreakerClosed
+		b.failures = 0
+
+	case ctx.Err() != nil:
+		// The caller gave up, which says nothing about the provider; a
+		// half-open breaker lets the next call test it instead
+
+	case b.state == BreakerOpen:
+		// A call that started before the breaker opened; the cooldown stands
+
+	default:
+		b.failures++
+		if halfOpen || b.failures >= b.threshold {
+			b.logger.Warn("circuit breaker opened, generation calls will fail fast",
+				"consecutive_failures", b.failures,
+				"cooldown", b.cooldown,
+				"error", err)
+			b.state = BreakerOpen
+			b.openedAt = b.timeFunc()
+		}
+	}
+}
+
+// providerResponded reports whether a call's outcome shows the provider is
+// available: it succeeded, or failed because of the memo rather than the provider
+func providerResponded(err error) bool {
+	return err == nil || errors.Is(err, ErrContentBlocked) || errors.Is(err, ErrInvalidResponse)
+}
+
+// Ensure CircuitBreakerGenerator implements Generator
+var _ Generator = (*CircuitBreakerGenerator)(nil)
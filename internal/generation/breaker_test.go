@@ -0,0 +1,172 @@
+package generation_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/generation"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBreaker wraps generator with a breaker opening after 3 failures for a
+// minute, on a clock the returned function advances
+func newTestBreaker(
+	t *testing.T,
+	generator generation.Generator,
+) (*generation.CircuitBreakerGenerator, func(time.Duration)) {
+	t.Helper()
+
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	breaker, err := generation.NewCircuitBreakerGenerator(
+		generator,
+		3,
+		time.Minute,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		generation.WithBreakerTimeFunc(func() time.Time { return now }),
+	)
+	require.NoError(t, err)
+	return breaker, func(d time.Duration) { now = now.Add(d) }
+}
+
+func generate(breaker *generation.CircuitBreakerGenerator) error {
+	_, err := breaker.GenerateCards(context.Background(), "memo text", uuid.New())
+	return err
+}
+
+func TestCircuitBreakerGenerator(t *testing.T) {
+	providerDown := fmt.Errorf("%w: service unavailable", generation.ErrTransientFailure)
+
+	t.Run("repeated failures open the breaker", func(t *testing.T) {
+		provider := &mocks.MockGenerator{Err: providerDown}
+		breaker, _ := newTestBreaker(t, provider)
+
+		for i := 0; i < 3; i++ {
+			err := generate(breaker)
+			assert.ErrorIs(t, err, generation.ErrTransientFailure)
+			assert.NotErrorIs(t, err, generation.ErrCircuitOpen, "Calls before the threshold reach the provider")
+		}
+		assert.Equal(t, generation.BreakerOpen, breaker.State())
+
+		err := generate(breaker)
+		assert.ErrorIs(t, err, generation.ErrCircuitOpen)
+		assert.ErrorIs(t, err, generation.ErrTransientFailure, "An open breaker should fail with a transient error")
+		assert.Equal(t, 3, provider.GenerateCardsCalls.Count, "An open breaker should not call the provider")
+	})
+
+	t.Run("success after cooldown closes the breaker", func(t *testing.T) {
+		provider := &mocks.MockGenerator{Err: providerDown}
+		breaker, advance := newTestBreaker(t, provider)
+		for i := 0; i < 3; i++ {
+			_ = generate(breaker)
+		}
+
+		advance(59 * time.Second)
+		assert.ErrorIs(t, generate(breaker), generation.ErrCircuitOpen, "The cooldown has not passed yet")
+
+		advance(time.Second)
+		assert.Equal(t, generation.BreakerHalfOpen, breaker.State())
+
+		provider.Err = nil
+		provider.Cards = []*domain.Card{{ID: uuid.New()}}
+		require.NoError(t, generate(breaker))
+		assert.Equal(t, generation.BreakerClosed, breaker.State())
+		assert.Equal(t, 4, provider.GenerateCardsCalls.Count)
+
+		// A closed breaker needs the full threshold of failures to open again
+		provider.Err = providerDown
+		_ = generate(breaker)
+		assert.Equal(t, generation.BreakerClosed, breaker.State())
+	})
+
+	t.Run("failed trial reopens the breaker", func(t *testing.T) {
+		provider := &mocks.MockGenerator{Err: providerDown}
+		breaker, advance := newTestBreaker(t, provider)
+		for i := 0; i < 3; i++ {
+			_ = generate(breaker)
+		}
+
+		advance(time.Minute)
+		assert.NotErrorIs(t, generate(breaker), generation.ErrCircuitOpen, "The trial call should reach the provider")
+		assert.Equal(t, generation.BreakerOpen, breaker.State())
+		assert.ErrorIs(t, generate(breaker), generation.ErrCircuitOpen, "A new cooldown should start")
+		assert.Equal(t, 4, provider.GenerateCardsCalls.Count)
+	})
+
+	t.Run("half-open breaker lets one trial through", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		var once sync.Once
+		provider := &mocks.MockGenerator{Err: providerDown}
+		breaker, advance := newTestBreaker(t, provider)
+		for i := 0; i < 3; i++ {
+			_ = generate(breaker)
+		}
+
+		provider.GenerateCardsFn = func(ctx context.Context, memoText string, userID uuid.UUID) ([]*domain.Card, error) {
+			once.Do(func() { close(started) })
+			<-release
+			return nil, nil
+		}
+		advance(time.Minute)
+
+		trial := make(chan error)
+		go func() { trial <- generate(breaker) }()
+		<-started
+
+		assert.ErrorIs(t, generate(breaker), generation.ErrCircuitOpen,
+			"Calls during the trial should fail fast")
+
+		close(release)
+		require.NoError(t, <-trial)
+		assert.Equal(t, generation.BreakerClosed, breaker.State())
+	})
+
+	t.Run("memo failures and successes reset the count", func(t *testing.T) {
+		provider := &mocks.MockGenerator{Err: providerDown}
+		breaker, _ := newTestBreaker(t, provider)
+
+		_ = generate(breaker)
+		_ = generate(breaker)
+		provider.Err = generation.ErrContentBlocked
+		_ = generate(breaker)
+		provider.Err = providerDown
+		_ = generate(breaker)
+		_ = generate(breaker)
+
+		assert.Equal(t, generation.BreakerClosed, breaker.State(),
+			"Blocked content shows the provider is up, so failures are no longer consecutive")
+	})
+
+	t.Run("cancelled calls do not count", func(t *testing.T) {
+		provider := &mocks.MockGenerator{Err: providerDown}
+		breaker, _ := newTestBreaker(t, provider)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for i := 0; i < 5; i++ {
+			_, _ = breaker.GenerateCards(ctx, "memo text", uuid.New())
+		}
+
+		assert.Equal(t, generation.BreakerClosed, breaker.State())
+	})
+}
+
+func TestNewCircuitBreakerGenerator_InvalidConfig(t *testing.T) {
+	_, err := generation.NewCircuitBreakerGenerator(&mocks.MockGenerator{}, 0, time.Minute, nil)
+	assert.ErrorIs(t, err, generation.ErrInvalidConfig)
+
+	_, err = generation.NewCircuitBreakerGenerator(&mocks.MockGenerator{}, 3, 0, nil)
+	assert.ErrorIs(t, err, generation.ErrInvalidConfig)
+
+	_, err = generation.NewCircuitBreakerGenerator(nil, 3, time.Minute, nil)
+	assert.ErrorIs(t, err, generation.ErrInvalidConfig)
+}
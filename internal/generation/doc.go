@@ -25,6 +25,8 @@
 // 4. Error Handling:
 //   - Provides standardized error types for LLM-specific failure scenarios
 //   - Implements retry logic for transient API failures
+//   - CircuitBreakerGenerator stops calling an unavailable provider after
+//     repeated failures, failing fast until it has had time to recover
 //
 // Usage:
 //
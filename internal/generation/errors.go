@@ -18,4 +18,16 @@ var (
 
 	// ErrInvalidConfig is returned when the generator configuration is invalid
 	ErrInvalidConfig = errors.New("invalid generator configuration")
+
+	// ErrCircuitOpen is returned, together with ErrTransientFailure, when a call is
+	// rejected without reaching the provider because recent calls kept failing
+	ErrCircuitOpen = errors.New("generation circuit breaker is open")
+
+	// ErrTooFewCards is returned, together with ErrInvalidResponse, when the
+	// language model keeps generating fewer cards than the configured minimum
+	ErrTooFewCards = errors.New("too few cards generated")
+
+	// ErrProviderUnreachable is returned by Ping when the provider can't be
+	// reached or refuses the configured credentials or model
+	ErrProviderUnreachable = errors.New("generation provider is unreachable")
 )
@@ -23,4 +23,10 @@ type Generator interface {
 	//   - A slice of domain.Card pointers representing the generated flashcards
 	//   - An error if the generation fails for any reason (see errors.go for specific types)
 	GenerateCards(ctx context.Context, memoText string, userID uuid.UUID) ([]*domain.Card, error)
+
+	// Ping checks that the provider is reachable and accepts the configured
+	// credentials and model, using a cheap call that generates nothing, so it
+	// costs no tokens and counts against no user's generation quota.
+	// It returns an error wrapping ErrProviderUnreachable if not.
+	Ping(ctx context.Context) error
 }
@@ -12,12 +12,19 @@ import (
 // MockCardReviewService implements card_review.CardReviewService for testing
 type MockCardReviewService struct {
 	// Custom behavior functions
-	GetNextCardFn  func(ctx context.Context, userID uuid.UUID) (*domain.Card, error)
-	SubmitAnswerFn func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, answer card_review.ReviewAnswer) (*domain.UserCardStats, error)
+	GetNextCardFn func(ctx context.Context, userID uuid.UUID, deckID *uuid.UUID, order domain.ReviewOrder) (*domain.Card, error)
+	// GetNextCardExcludingFn overrides GetNextCardExcluding; without it,
+	// GetNextCardFn is used and the excluded cards are only recorded
+	GetNextCardExcludingFn func(ctx context.Context, userID uuid.UUID, deckID *uuid.UUID, order domain.ReviewOrder, exclude []uuid.UUID) (*domain.Card, error)
+	SubmitAnswerFn         func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, answer card_review.ReviewAnswer) (*domain.UserCardStats, error)
+	SubmitAnswersFn        func(ctx context.Context, userID uuid.UUID, answers []card_review.CardAnswer) ([]*domain.UserCardStats, error)
+	// GetCardHistoryFn overrides GetCardHistory
+	GetCardHistoryFn func(ctx context.Context, userID, cardID uuid.UUID) ([]*domain.ReviewEvent, error)
 
 	// Default response values
 	NextCard     *domain.Card
 	UpdatedStats *domain.UserCardStats
+	History      []*domain.ReviewEvent
 	Err          error
 
 	// Call tracking for verification
@@ -25,6 +32,9 @@ type MockCardReviewService struct {
 		mu       sync.Mutex
 		Count    int
 		UserIDs  []uuid.UUID
+		DeckIDs  []*uuid.UUID
+		Orders   []domain.ReviewOrder
+		Excludes [][]uuid.UUID
 		Contexts []context.Context
 	}
 
@@ -36,23 +46,59 @@ type MockCardReviewService struct {
 		Answers  []card_review.ReviewAnswer
 		Contexts []context.Context
 	}
+
+	SubmitAnswersCalls struct {
+		mu       sync.Mutex
+		Count    int
+		UserIDs  []uuid.UUID
+		Batches  [][]card_review.CardAnswer
+		Contexts []context.Context
+	}
+
+	GetCardHistoryCalls struct {
+		mu       sync.Mutex
+		Count    int
+		UserIDs  []uuid.UUID
+		CardIDs  []uuid.UUID
+		Contexts []context.Context
+	}
 }
 
 // GetNextCard implements the card_review.CardReviewService interface
 func (m *MockCardReviewService) GetNextCard(
 	ctx context.Context,
 	userID uuid.UUID,
+	deckID *uuid.UUID,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	return m.GetNextCardExcluding(ctx, userID, deckID, order, nil)
+}
+
+// GetNextCardExcluding implements the card_review.CardReviewService interface.
+// Calls are tracked in GetNextCardCalls, like calls to GetNextCard.
+func (m *MockCardReviewService) GetNextCardExcluding(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
 ) (*domain.Card, error) {
 	// Track call details for verification
 	m.GetNextCardCalls.mu.Lock()
 	m.GetNextCardCalls.Count++
 	m.GetNextCardCalls.UserIDs = append(m.GetNextCardCalls.UserIDs, userID)
+	m.GetNextCardCalls.DeckIDs = append(m.GetNextCardCalls.DeckIDs, deckID)
+	m.GetNextCardCalls.Orders = append(m.GetNextCardCalls.Orders, order)
+	m.GetNextCardCalls.Excludes = append(m.GetNextCardCalls.Excludes, exclude)
 	m.GetNextCardCalls.Contexts = append(m.GetNextCardCalls.Contexts, ctx)
 	m.GetNextCardCalls.mu.Unlock()
 
 	// Use custom function if provided
+	if m.GetNextCardExcludingFn != nil {
+		return m.GetNextCardExcludingFn(ctx, userID, deckID, order, exclude)
+	}
 	if m.GetNextCardFn != nil {
-		return m.GetNextCardFn(ctx, userID)
+		return m.GetNextCardFn(ctx, userID, deckID, order)
 	}
 
 	// Return default values
@@ -84,11 +130,71 @@ func (m *MockCardReviewService) SubmitAnswer(
 	return m.UpdatedStats, m.Err
 }
 
-// Reset resets the call tracking state for both methods
+// SubmitAnswers implements the card_review.CardReviewService interface.
+// Without SubmitAnswersFn it returns UpdatedStats once per answer, or Err.
+func (m *MockCardReviewService) SubmitAnswers(
+	ctx context.Context,
+	userID uuid.UUID,
+	answers []card_review.CardAnswer,
+) ([]*domain.UserCardStats, error) {
+	// Track call details for verification
+	m.SubmitAnswersCalls.mu.Lock()
+	m.SubmitAnswersCalls.Count++
+	m.SubmitAnswersCalls.UserIDs = append(m.SubmitAnswersCalls.UserIDs, userID)
+	m.SubmitAnswersCalls.Batches = append(m.SubmitAnswersCalls.Batches, answers)
+	m.SubmitAnswersCalls.Contexts = append(m.SubmitAnswersCalls.Contexts, ctx)
+	m.SubmitAnswersCalls.mu.Unlock()
+
+	// Use custom function if provided
+	if m.SubmitAnswersFn != nil {
+		return m.SubmitAnswersFn(ctx, userID, answers)
+	}
+
+	// Return default values
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	stats := make([]*domain.UserCardStats, len(answers))
+	for i := range answers {
+		stats[i] = m.UpdatedStats
+	}
+	return stats, nil
+}
+
+// GetCardHistory implements the card_review.CardReviewService interface.
+// Without GetCardHistoryFn it returns History, or Err.
+func (m *MockCardReviewService) GetCardHistory(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) ([]*domain.ReviewEvent, error) {
+	// Track call details for verification
+	m.GetCardHistoryCalls.mu.Lock()
+	m.GetCardHistoryCalls.Count++
+	m.GetCardHistoryCalls.UserIDs = append(m.GetCardHistoryCalls.UserIDs, userID)
+	m.GetCardHistoryCalls.CardIDs = append(m.GetCardHistoryCalls.CardIDs, cardID)
+	m.GetCardHistoryCalls.Contexts = append(m.GetCardHistoryCalls.Contexts, ctx)
+	m.GetCardHistoryCalls.mu.Unlock()
+
+	// Use custom function if provided
+	if m.GetCardHistoryFn != nil {
+		return m.GetCardHistoryFn(ctx, userID, cardID)
+	}
+
+	// Return default values
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.History, nil
+}
+
+// Reset resets the call tracking state for all methods
 func (m *MockCardReviewService) Reset() {
 	m.GetNextCardCalls.mu.Lock()
 	m.GetNextCardCalls.Count = 0
 	m.GetNextCardCalls.UserIDs = nil
+	m.GetNextCardCalls.DeckIDs = nil
+	m.GetNextCardCalls.Orders = nil
+	m.GetNextCardCalls.Excludes = nil
 	m.GetNextCardCalls.Contexts = nil
 	m.GetNextCardCalls.mu.Unlock()
 
@@ -99,6 +205,20 @@ func (m *MockCardReviewService) Reset() {
 	m.SubmitAnswerCalls.Answers = nil
 	m.SubmitAnswerCalls.Contexts = nil
 	m.SubmitAnswerCalls.mu.Unlock()
+
+	m.SubmitAnswersCalls.mu.Lock()
+	m.SubmitAnswersCalls.Count = 0
+	m.SubmitAnswersCalls.UserIDs = nil
+	m.SubmitAnswersCalls.Batches = nil
+	m.SubmitAnswersCalls.Contexts = nil
+	m.SubmitAnswersCalls.mu.Unlock()
+
+	m.GetCardHistoryCalls.mu.Lock()
+	m.GetCardHistoryCalls.Count = 0
+	m.GetCardHistoryCalls.UserIDs = nil
+	m.GetCardHistoryCalls.CardIDs = nil
+	m.GetCardHistoryCalls.Contexts = nil
+	m.GetCardHistoryCalls.mu.Unlock()
 }
 
 // Functional option pattern for configuring mock
@@ -120,7 +240,7 @@ func WithUpdatedStats(stats *domain.UserCardStats) MockOption {
 	}
 }
 
-// WithError sets the default error to return from both methods
+// WithError sets the default error to return from all methods
 func WithError(err error) MockOption {
 	return func(m *MockCardReviewService) {
 		m.Err = err
@@ -129,7 +249,7 @@ func WithError(err error) MockOption {
 
 // WithGetNextCardFn sets a custom function for GetNextCard
 func WithGetNextCardFn(
-	fn func(ctx context.Context, userID uuid.UUID) (*domain.Card, error),
+	fn func(ctx context.Context, userID uuid.UUID, deckID *uuid.UUID, order domain.ReviewOrder) (*domain.Card, error),
 ) MockOption {
 	return func(m *MockCardReviewService) {
 		m.GetNextCardFn = fn
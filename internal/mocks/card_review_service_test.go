@@ -60,7 +60,7 @@ func TestMockCardReviewService(t *testing.T) {
 		)
 
 		// GetNextCard should return the default card
-		card, err := mock.GetNextCard(ctx, userID)
+		card, err := mock.GetNextCard(ctx, userID, nil, domain.DefaultReviewOrder)
 		assert.NoError(t, err)
 		assert.Equal(t, sampleCard, card)
 		assert.Equal(t, 1, mock.GetNextCardCalls.Count)
@@ -80,7 +80,12 @@ func TestMockCardReviewService(t *testing.T) {
 	t.Run("Custom Functions", func(t *testing.T) {
 		// Create a mock with custom function implementations
 		mock := NewMockCardReviewService(
-			WithGetNextCardFn(func(ctx context.Context, userID uuid.UUID) (*domain.Card, error) {
+			WithGetNextCardFn(func(
+				ctx context.Context,
+				userID uuid.UUID,
+				deckID *uuid.UUID,
+				order domain.ReviewOrder,
+			) (*domain.Card, error) {
 				return sampleCard, nil
 			}),
 			WithSubmitAnswerFn(
@@ -94,7 +99,7 @@ func TestMockCardReviewService(t *testing.T) {
 		)
 
 		// Test GetNextCard with custom function
-		card, err := mock.GetNextCard(ctx, userID)
+		card, err := mock.GetNextCard(ctx, userID, nil, domain.DefaultReviewOrder)
 		assert.NoError(t, err)
 		assert.Equal(t, sampleCard, card)
 
@@ -120,7 +125,7 @@ func TestMockCardReviewService(t *testing.T) {
 		mock := NewMockCardReviewService()
 
 		// Make some calls
-		_, _ = mock.GetNextCard(ctx, userID)
+		_, _ = mock.GetNextCard(ctx, userID, nil, domain.DefaultReviewOrder)
 		_, _ = mock.SubmitAnswer(
 			ctx,
 			userID,
@@ -146,19 +151,19 @@ func TestMockCardReviewService(t *testing.T) {
 		// Test the convenience constructors
 
 		noCardsMock := NewMockCardReviewServiceWithNoCardsDue()
-		_, err := noCardsMock.GetNextCard(ctx, userID)
+		_, err := noCardsMock.GetNextCard(ctx, userID, nil, domain.DefaultReviewOrder)
 		assert.Equal(t, card_review.ErrNoCardsDue, err)
 
 		notFoundMock := NewMockCardReviewServiceWithCardNotFound()
-		_, err = notFoundMock.GetNextCard(ctx, userID)
+		_, err = notFoundMock.GetNextCard(ctx, userID, nil, domain.DefaultReviewOrder)
 		assert.Equal(t, card_review.ErrCardNotFound, err)
 
 		notOwnedMock := NewMockCardReviewServiceWithCardNotOwned()
-		_, err = notOwnedMock.GetNextCard(ctx, userID)
+		_, err = notOwnedMock.GetNextCard(ctx, userID, nil, domain.DefaultReviewOrder)
 		assert.Equal(t, card_review.ErrCardNotOwned, err)
 
 		invalidAnswerMock := NewMockCardReviewServiceWithInvalidAnswer()
-		_, err = invalidAnswerMock.GetNextCard(ctx, userID)
+		_, err = invalidAnswerMock.GetNextCard(ctx, userID, nil, domain.DefaultReviewOrder)
 		assert.Equal(t, card_review.ErrInvalidAnswer, err)
 	})
 }
@@ -0,0 +1,290 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// MockCardStore implements store.CardStore for testing without a database.
+// Each method calls its function field when set. Otherwise writes succeed
+// without doing anything, lookups of a single card return store.ErrCardNotFound,
+// and lists and counts are empty.
+//
+// Transactions are not simulated: WithTx returns the same mock, so calls made
+// inside a transaction reach the same function fields.
+type MockCardStore struct {
+	// Function fields for customizable behavior
+	CreateMultipleFn          func(ctx context.Context, cards []*domain.Card) error
+	GetByIDFn                 func(ctx context.Context, id uuid.UUID) (*domain.Card, error)
+	GetByIDsFn                func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Card, error)
+	UpdateContentFn           func(ctx context.Context, id uuid.UUID, content []byte, expectedVersion int) (int, error)
+	DeleteFn                  func(ctx context.Context, id uuid.UUID) error
+	GetNextReviewCardFn       func(ctx context.Context, userID uuid.UUID, order domain.ReviewOrder) (*domain.Card, error)
+	GetNextDueCardFn          func(ctx context.Context, userID uuid.UUID, deckID *uuid.UUID, newCards bool, order domain.ReviewOrder) (*domain.Card, error)
+	GetNextDueCardExcludingFn func(ctx context.Context, userID uuid.UUID, deckID *uuid.UUID, newCards bool, order domain.ReviewOrder, exclude []uuid.UUID) (*domain.Card, error)
+	SetDeckFn                 func(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error
+	SetMemoFn                 func(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error
+	SetSuspendedFn            func(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error
+	SetBuriedUntilFn          func(ctx context.Context, id uuid.UUID, until *time.Time) error
+	SupersedeByMemoFn         func(ctx context.Context, memoID uuid.UUID, at time.Time) (int, error)
+	DeleteByMemoFn            func(ctx context.Context, memoID uuid.UUID) (int, error)
+	ListByDeckFn              func(ctx context.Context, deckID uuid.UUID, limit, offset int) ([]*domain.Card, error)
+	ListByTagFn               func(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.Card, error)
+	ExistingFrontHashesFn     func(ctx context.Context, userID uuid.UUID, hashes [][32]byte) (map[[32]byte]bool, error)
+	GetRecentlyReviewedFn     func(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.ReviewedCard, error)
+	FindDuplicatesFn          func(ctx context.Context, userID uuid.UUID) ([]*domain.DuplicateCardGroup, error)
+	ForEachWithStatsFn        func(ctx context.Context, userID uuid.UUID, includeSuperseded bool, fn func(*domain.CardWithStats) error) error
+	CountByUserFn             func(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// SQLDB is returned by DB, nil unless set
+	SQLDB *sql.DB
+}
+
+// NewMockCardStore creates a new mock store with the default behavior.
+func NewMockCardStore() *MockCardStore {
+	return &MockCardStore{}
+}
+
+// CreateMultiple implements the CardStore interface
+func (m *MockCardStore) CreateMultiple(ctx context.Context, cards []*domain.Card) error {
+	if m.CreateMultipleFn != nil {
+		return m.CreateMultipleFn(ctx, cards)
+	}
+	return nil
+}
+
+// GetByID implements the CardStore interface
+func (m *MockCardStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	if m.GetByIDFn != nil {
+		return m.GetByIDFn(ctx, id)
+	}
+	return nil, store.ErrCardNotFound
+}
+
+// GetByIDs implements the CardStore interface
+func (m *MockCardStore) GetByIDs(
+	ctx context.Context,
+	ids []uuid.UUID,
+) (map[uuid.UUID]*domain.Card, error) {
+	if m.GetByIDsFn != nil {
+		return m.GetByIDsFn(ctx, ids)
+	}
+	return map[uuid.UUID]*domain.Card{}, nil
+}
+
+// UpdateContent implements the CardStore interface
+func (m *MockCardStore) UpdateContent(
+	ctx context.Context,
+	id uuid.UUID,
+	content []byte,
+	expectedVersion int,
+) (int, error) {
+	if m.UpdateContentFn != nil {
+		return m.UpdateContentFn(ctx, id, content, expectedVersion)
+	}
+	return expectedVersion + 1, nil
+}
+
+// Delete implements the CardStore interface
+func (m *MockCardStore) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
+
+// GetNextReviewCard implements the CardStore interface
+func (m *MockCardStore) GetNextReviewCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	if m.GetNextReviewCardFn != nil {
+		return m.GetNextReviewCardFn(ctx, userID, order)
+	}
+	return nil, store.ErrCardNotFound
+}
+
+// GetNextDueCard implements the CardStore interface
+func (m *MockCardStore) GetNextDueCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	if m.GetNextDueCardFn != nil {
+		return m.GetNextDueCardFn(ctx, userID, deckID, newCards, order)
+	}
+	return nil, store.ErrCardNotFound
+}
+
+// GetNextDueCardExcluding implements the CardStore interface
+func (m *MockCardStore) GetNextDueCardExcluding(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
+) (*domain.Card, error) {
+	if m.GetNextDueCardExcludingFn != nil {
+		return m.GetNextDueCardExcludingFn(ctx, userID, deckID, newCards, order, exclude)
+	}
+	return nil, store.ErrCardNotFound
+}
+
+// SetDeck implements the CardStore interface
+func (m *MockCardStore) SetDeck(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error {
+	if m.SetDeckFn != nil {
+		return m.SetDeckFn(ctx, id, deckID)
+	}
+	return nil
+}
+
+// SetMemo implements the CardStore interface
+func (m *MockCardStore) SetMemo(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error {
+	if m.SetMemoFn != nil {
+		return m.SetMemoFn(ctx, id, memoID)
+	}
+	return nil
+}
+
+// SetSuspended implements the CardStore interface
+func (m *MockCardStore) SetSuspended(
+	ctx context.Context,
+	id uuid.UUID,
+	suspendedAt *time.Time,
+) error {
+	if m.SetSuspendedFn != nil {
+		return m.SetSuspendedFn(ctx, id, suspendedAt)
+	}
+	return nil
+}
+
+// SetBuriedUntil implements the CardStore interface
+func (m *MockCardStore) SetBuriedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error {
+	if m.SetBuriedUntilFn != nil {
+		return m.SetBuriedUntilFn(ctx, id, until)
+	}
+	return nil
+}
+
+// SupersedeByMemo implements the CardStore interface
+func (m *MockCardStore) SupersedeByMemo(
+	ctx context.Context,
+	memoID uuid.UUID,
+	at time.Time,
+) (int, error) {
+	if m.SupersedeByMemoFn != nil {
+		return m.SupersedeByMemoFn(ctx, memoID, at)
+	}
+	return 0, nil
+}
+
+// DeleteByMemo implements the CardStore interface
+func (m *MockCardStore) DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error) {
+	if m.DeleteByMemoFn != nil {
+		return m.DeleteByMemoFn(ctx, memoID)
+	}
+	return 0, nil
+}
+
+// ListByDeck implements the CardStore interface
+func (m *MockCardStore) ListByDeck(
+	ctx context.Context,
+	deckID uuid.UUID,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	if m.ListByDeckFn != nil {
+		return m.ListByDeckFn(ctx, deckID, limit, offset)
+	}
+	return []*domain.Card{}, nil
+}
+
+// ListByTag implements the CardStore interface
+func (m *MockCardStore) ListByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	if m.ListByTagFn != nil {
+		return m.ListByTagFn(ctx, userID, tag, limit, offset)
+	}
+	return []*domain.Card{}, nil
+}
+
+// ExistingFrontHashes implements the CardStore interface
+func (m *MockCardStore) ExistingFrontHashes(
+	ctx context.Context,
+	userID uuid.UUID,
+	hashes [][32]byte,
+) (map[[32]byte]bool, error) {
+	if m.ExistingFrontHashesFn != nil {
+		return m.ExistingFrontHashesFn(ctx, userID, hashes)
+	}
+	return map[[32]byte]bool{}, nil
+}
+
+// GetRecentlyReviewed implements the CardStore interface
+func (m *MockCardStore) GetRecentlyReviewed(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset int,
+) ([]*domain.ReviewedCard, error) {
+	if m.GetRecentlyReviewedFn != nil {
+		return m.GetRecentlyReviewedFn(ctx, userID, limit, offset)
+	}
+	return []*domain.ReviewedCard{}, nil
+}
+
+// FindDuplicates implements the CardStore interface
+func (m *MockCardStore) FindDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*domain.DuplicateCardGroup, error) {
+	if m.FindDuplicatesFn != nil {
+		return m.FindDuplicatesFn(ctx, userID)
+	}
+	return []*domain.DuplicateCardGroup{}, nil
+}
+
+// ForEachWithStats implements the CardStore interface
+func (m *MockCardStore) ForEachWithStats(
+	ctx context.Context,
+	userID uuid.UUID,
+	includeSuperseded bool,
+	fn func(*domain.CardWithStats) error,
+) error {
+	if m.ForEachWithStatsFn != nil {
+		return m.ForEachWithStatsFn(ctx, userID, includeSuperseded, fn)
+	}
+	return nil
+}
+
+// CountByUser implements the CardStore interface
+func (m *MockCardStore) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	if m.CountByUserFn != nil {
+		return m.CountByUserFn(ctx, userID)
+	}
+	return 0, nil
+}
+
+// WithTx implements the CardStore interface by returning the same mock
+func (m *MockCardStore) WithTx(tx *sql.Tx) store.CardStore {
+	return m
+}
+
+// DB implements the CardStore interface by returning SQLDB
+func (m *MockCardStore) DB() *sql.DB {
+	return m.SQLDB
+}
+
+// Ensure MockCardStore implements store.CardStore
+var _ store.CardStore = (*MockCardStore)(nil)
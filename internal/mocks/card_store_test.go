@@ -0,0 +1,66 @@
+package mocks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockCardStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("Defaults", func(t *testing.T) {
+		t.Parallel()
+
+		cardStore := mocks.NewMockCardStore()
+
+		assert.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{{ID: uuid.New()}}))
+		_, err := cardStore.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+		cards, err := cardStore.ListByDeck(ctx, uuid.New(), 10, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, cards)
+		assert.Same(t, cardStore, cardStore.WithTx(nil), "WithTx should return the same mock")
+		assert.Nil(t, cardStore.DB())
+	})
+
+	t.Run("Function fields", func(t *testing.T) {
+		t.Parallel()
+
+		failure := errors.New("database unavailable")
+		cardStore := mocks.NewMockCardStore()
+		cardStore.DeleteFn = func(ctx context.Context, id uuid.UUID) error {
+			return failure
+		}
+
+		assert.ErrorIs(t, cardStore.WithTx(nil).Delete(ctx, uuid.New()), failure,
+			"Calls inside a transaction should reach the same function fields")
+	})
+}
+
+func TestMockUserCardStatsStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	statsStore := mocks.NewMockUserCardStatsStore()
+
+	_, err := statsStore.Get(ctx, uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+	assert.Same(t, statsStore, statsStore.WithTx(nil), "WithTx should return the same mock")
+
+	statsStore.CountDueFn = func(ctx context.Context, userID uuid.UUID, asOf time.Time) (int, error) {
+		return 7, nil
+	}
+	due, err := statsStore.CountDue(ctx, uuid.New(), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 7, due)
+}
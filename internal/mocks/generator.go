@@ -3,7 +3,9 @@ package mocks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -15,6 +17,9 @@ type MockGenerator struct {
 	// GenerateCardsFn allows test cases to mock the GenerateCards behavior
 	GenerateCardsFn func(ctx context.Context, memoText string, userID uuid.UUID) ([]*domain.Card, error)
 
+	// PingErr is returned by Ping
+	PingErr error
+
 	// Default response values
 	Cards []*domain.Card
 	Err   error
@@ -61,6 +66,11 @@ func (m *MockGenerator) GenerateCards(
 	return m.Cards, m.Err
 }
 
+// Ping implements the generation.Generator interface, returning PingErr
+func (m *MockGenerator) Ping(ctx context.Context) error {
+	return m.PingErr
+}
+
 // NewMockGeneratorWithCards creates a MockGenerator that returns the specified cards
 func NewMockGeneratorWithCards(cards []*domain.Card) *MockGenerator {
 	return &MockGenerator{
@@ -134,3 +144,142 @@ func (m *MockGenerator) Reset() {
 	m.GenerateCardsCalls.UserIDs = nil
 	m.GenerateCardsCalls.Contexts = nil
 }
+
+// ErrScriptExhausted is returned by a ScriptableGenerator called more times than
+// it has scripted responses, unless a fallback response is set with Otherwise.
+var ErrScriptExhausted = errors.New("scriptable generator has no responses left")
+
+// GeneratorResponse is the scripted outcome of a single GenerateCards call.
+type GeneratorResponse struct {
+	// Cards are returned when Err is nil
+	Cards []*domain.Card
+
+	// Err is returned instead of cards when set
+	Err error
+
+	// Delay is waited before returning, to simulate a slow provider.
+	// If the call's context ends first, the context's error is returned instead.
+	Delay time.Duration
+}
+
+// ScriptableGenerator implements generation.Generator by returning a queue of
+// scripted responses, one per call, so tests can describe a sequence of
+// outcomes such as a failure followed by a recovery:
+//
+//	gen := mocks.NewScriptableGenerator().
+//	    ThenError(generation.ErrTransientFailure).
+//	    ThenCards(card1, card2)
+//
+//	_, err := gen.GenerateCards(ctx, text, userID)   // ErrTransientFailure
+//	cards, _ := gen.GenerateCards(ctx, text, userID) // card1, card2
+//	gen.CallCount()                                  // 2
+//
+// Once the queue is empty, calls return ErrScriptExhausted, or the response set
+// with Otherwise. It is safe for concurrent use; concurrent calls take queued
+// responses in the order they arrive.
+type ScriptableGenerator struct {
+	mu        sync.Mutex
+	responses []GeneratorResponse
+	fallback  *GeneratorResponse
+	calls     int
+}
+
+// NewScriptableGenerator creates a generator that returns responses in order.
+func NewScriptableGenerator(responses ...GeneratorResponse) *ScriptableGenerator {
+	return &ScriptableGenerator{responses: responses}
+}
+
+// Then queues responses to be returned after those already queued.
+func (g *ScriptableGenerator) Then(responses ...GeneratorResponse) *ScriptableGenerator {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.responses = append(g.responses, responses...)
+	return g
+}
+
+// ThenCards queues a successful response returning cards.
+func (g *ScriptableGenerator) ThenCards(cards ...*domain.Card) *ScriptableGenerator {
+	return g.Then(GeneratorResponse{Cards: cards})
+}
+
+// ThenError queues a failed response returning err.
+func (g *ScriptableGenerator) ThenError(err error) *ScriptableGenerator {
+	return g.Then(GeneratorResponse{Err: err})
+}
+
+// Otherwise sets the response returned by every call once the queue is empty.
+func (g *ScriptableGenerator) Otherwise(response GeneratorResponse) *ScriptableGenerator {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.fallback = &response
+	return g
+}
+
+// CallCount returns how many times GenerateCards has been called.
+func (g *ScriptableGenerator) CallCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.calls
+}
+
+// Remaining returns how many queued responses have not been returned yet.
+func (g *ScriptableGenerator) Remaining() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return len(g.responses)
+}
+
+// GenerateCards implements generation.Generator by returning the next
+// scripted response.
+func (g *ScriptableGenerator) GenerateCards(
+	ctx context.Context,
+	memoText string,
+	userID uuid.UUID,
+) ([]*domain.Card, error) {
+	response := g.next()
+
+	if response.Delay > 0 {
+		timer := time.NewTimer(response.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if response.Err != nil {
+		return nil, response.Err
+	}
+	return response.Cards, nil
+}
+
+// Ping implements generation.Generator. The scripted provider is always
+// reachable, and pings are not counted as calls.
+func (g *ScriptableGenerator) Ping(ctx context.Context) error {
+	return nil
+}
+
+// next counts a call and takes the response for it
+func (g *ScriptableGenerator) next() GeneratorResponse {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.calls++
+	if len(g.responses) > 0 {
+		response := g.responses[0]
+		g.responses = g.responses[1:]
+		return response
+	}
+	if g.fallback != nil {
+		return *g.fallback
+	}
+	return GeneratorResponse{Err: ErrScriptExhausted}
+}
+
+// Ensure ScriptableGenerator implements generation.Generator
+var _ generation.Generator = (*ScriptableGenerator)(nil)
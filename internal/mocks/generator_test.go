@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -121,3 +122,60 @@ func TestMockGenerator(t *testing.T) {
 		assert.Empty(t, mockGen.GenerateCardsCalls.UserIDs)
 	})
 }
+
+func TestScriptableGenerator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Queued error then success", func(t *testing.T) {
+		t.Parallel()
+
+		card, err := domain.NewCard(userID, uuid.New(), []byte(`{"front":"Q","back":"A"}`))
+		assert.NoError(t, err)
+		gen := mocks.NewScriptableGenerator().
+			ThenError(generation.ErrTransientFailure).
+			ThenCards(card)
+
+		cards, err := gen.GenerateCards(ctx, "memo", userID)
+		assert.ErrorIs(t, err, generation.ErrTransientFailure, "First call should fail")
+		assert.Nil(t, cards)
+
+		cards, err = gen.GenerateCards(ctx, "memo", userID)
+		assert.NoError(t, err, "Second call should succeed")
+		assert.Equal(t, []*domain.Card{card}, cards)
+
+		assert.Equal(t, 2, gen.CallCount())
+		assert.Zero(t, gen.Remaining())
+
+		_, err = gen.GenerateCards(ctx, "memo", userID)
+		assert.ErrorIs(t, err, mocks.ErrScriptExhausted, "Calls beyond the script should fail")
+		assert.Equal(t, 3, gen.CallCount())
+	})
+
+	t.Run("Fallback response", func(t *testing.T) {
+		t.Parallel()
+
+		gen := mocks.NewScriptableGenerator(mocks.GeneratorResponse{Err: generation.ErrContentBlocked}).
+			Otherwise(mocks.GeneratorResponse{Err: generation.ErrGenerationFailed})
+
+		_, err := gen.GenerateCards(ctx, "memo", userID)
+		assert.ErrorIs(t, err, generation.ErrContentBlocked)
+		for i := 0; i < 2; i++ {
+			_, err = gen.GenerateCards(ctx, "memo", userID)
+			assert.ErrorIs(t, err, generation.ErrGenerationFailed)
+		}
+	})
+
+	t.Run("Delay respects context", func(t *testing.T) {
+		t.Parallel()
+
+		gen := mocks.NewScriptableGenerator(mocks.GeneratorResponse{Delay: time.Hour})
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := gen.GenerateCards(cancelled, "memo", userID)
+		assert.True(t, errors.Is(err, context.Canceled), "A cancelled call should not wait out the delay")
+	})
+}
@@ -21,6 +21,18 @@ type MockJWTService struct {
 	// ValidateRefreshTokenFn allows test cases to mock the ValidateRefreshToken behavior
 	ValidateRefreshTokenFn func(ctx context.Context, tokenString string) (*auth.Claims, error)
 
+	// GenerateEmailVerificationTokenFn allows test cases to mock the GenerateEmailVerificationToken behavior
+	GenerateEmailVerificationTokenFn func(ctx context.Context, userID uuid.UUID) (string, error)
+
+	// ValidateEmailVerificationTokenFn allows test cases to mock the ValidateEmailVerificationToken behavior
+	ValidateEmailVerificationTokenFn func(ctx context.Context, tokenString string) (*auth.Claims, error)
+
+	// GeneratePasswordResetTokenFn allows test cases to mock the GeneratePasswordResetToken behavior
+	GeneratePasswordResetTokenFn func(ctx context.Context, userID uuid.UUID, fingerprint string) (string, error)
+
+	// ValidatePasswordResetTokenFn allows test cases to mock the ValidatePasswordResetToken behavior
+	ValidatePasswordResetTokenFn func(ctx context.Context, tokenString string) (*auth.Claims, error)
+
 	// Default values used when functions aren't explicitly defined
 	Token        string
 	RefreshToken string
@@ -81,3 +93,60 @@ func (m *MockJWTService) ValidateRefreshToken(
 	// Otherwise use the default values
 	return m.Claims, m.ValidateErr
 }
+
+// GenerateEmailVerificationToken implements the auth.JWTService interface
+func (m *MockJWTService) GenerateEmailVerificationToken(
+	ctx context.Context,
+	userID uuid.UUID,
+) (string, error) {
+	// If a custom function is provided, use it
+	if m.GenerateEmailVerificationTokenFn != nil {
+		return m.GenerateEmailVerificationTokenFn(ctx, userID)
+	}
+
+	// Otherwise use the default values
+	return m.Token, m.Err
+}
+
+// ValidateEmailVerificationToken implements the auth.JWTService interface
+func (m *MockJWTService) ValidateEmailVerificationToken(
+	ctx context.Context,
+	tokenString string,
+) (*auth.Claims, error) {
+	// If a custom function is provided, use it
+	if m.ValidateEmailVerificationTokenFn != nil {
+		return m.ValidateEmailVerificationTokenFn(ctx, tokenString)
+	}
+
+	// Otherwise use the default values
+	return m.Claims, m.ValidateErr
+}
+
+// GeneratePasswordResetToken implements the auth.JWTService interface
+func (m *MockJWTService) GeneratePasswordResetToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	fingerprint string,
+) (string, error) {
+	// If a custom function is provided, use it
+	if m.GeneratePasswordResetTokenFn != nil {
+		return m.GeneratePasswordResetTokenFn(ctx, userID, fingerprint)
+	}
+
+	// Otherwise use the default values
+	return m.Token, m.Err
+}
+
+// ValidatePasswordResetToken implements the auth.JWTService interface
+func (m *MockJWTService) ValidatePasswordResetToken(
+	ctx context.Context,
+	tokenString string,
+) (*auth.Claims, error) {
+	// If a custom function is provided, use it
+	if m.ValidatePasswordResetTokenFn != nil {
+		return m.ValidatePasswordResetTokenFn(ctx, tokenString)
+	}
+
+	// Otherwise use the default values
+	return m.Claims, m.ValidateErr
+}
@@ -0,0 +1,121 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// MockUserCardStatsStore implements store.UserCardStatsStore for testing
+// without a database. Each method calls its function field when set. Otherwise
+// writes succeed without doing anything, lookups return
+// store.ErrUserCardStatsNotFound, and counts and forecasts are empty.
+//
+// Transactions are not simulated: WithTx returns the same mock.
+type MockUserCardStatsStore struct {
+	// Function fields for customizable behavior
+	CreateFn         func(ctx context.Context, stats *domain.UserCardStats) error
+	GetFn            func(ctx context.Context, userID, cardID uuid.UUID) (*domain.UserCardStats, error)
+	GetForUpdateFn   func(ctx context.Context, userID, cardID uuid.UUID) (*domain.UserCardStats, error)
+	UpdateFn         func(ctx context.Context, stats *domain.UserCardStats) error
+	UpdateMultipleFn func(ctx context.Context, stats []*domain.UserCardStats) error
+	DeleteFn         func(ctx context.Context, userID, cardID uuid.UUID) error
+	CountDueFn       func(ctx context.Context, userID uuid.UUID, asOf time.Time) (int, error)
+	GetForecastFn    func(ctx context.Context, userID uuid.UUID, deckID *uuid.UUID, days int) ([]domain.ForecastBucket, error)
+}
+
+// NewMockUserCardStatsStore creates a new mock store with the default behavior.
+func NewMockUserCardStatsStore() *MockUserCardStatsStore {
+	return &MockUserCardStatsStore{}
+}
+
+// Create implements the UserCardStatsStore interface
+func (m *MockUserCardStatsStore) Create(ctx context.Context, stats *domain.UserCardStats) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, stats)
+	}
+	return nil
+}
+
+// Get implements the UserCardStatsStore interface
+func (m *MockUserCardStatsStore) Get(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) (*domain.UserCardStats, error) {
+	if m.GetFn != nil {
+		return m.GetFn(ctx, userID, cardID)
+	}
+	return nil, store.ErrUserCardStatsNotFound
+}
+
+// GetForUpdate implements the UserCardStatsStore interface
+func (m *MockUserCardStatsStore) GetForUpdate(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) (*domain.UserCardStats, error) {
+	if m.GetForUpdateFn != nil {
+		return m.GetForUpdateFn(ctx, userID, cardID)
+	}
+	return nil, store.ErrUserCardStatsNotFound
+}
+
+// Update implements the UserCardStatsStore interface
+func (m *MockUserCardStatsStore) Update(ctx context.Context, stats *domain.UserCardStats) error {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(ctx, stats)
+	}
+	return nil
+}
+
+// UpdateMultiple implements the UserCardStatsStore interface
+func (m *MockUserCardStatsStore) UpdateMultiple(ctx context.Context, stats []*domain.UserCardStats) error {
+	if m.UpdateMultipleFn != nil {
+		return m.UpdateMultipleFn(ctx, stats)
+	}
+	return nil
+}
+
+// Delete implements the UserCardStatsStore interface
+func (m *MockUserCardStatsStore) Delete(ctx context.Context, userID, cardID uuid.UUID) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, userID, cardID)
+	}
+	return nil
+}
+
+// CountDue implements the UserCardStatsStore interface
+func (m *MockUserCardStatsStore) CountDue(
+	ctx context.Context,
+	userID uuid.UUID,
+	asOf time.Time,
+) (int, error) {
+	if m.CountDueFn != nil {
+		return m.CountDueFn(ctx, userID, asOf)
+	}
+	return 0, nil
+}
+
+// GetForecast implements the UserCardStatsStore interface
+func (m *MockUserCardStatsStore) GetForecast(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	days int,
+) ([]domain.ForecastBucket, error) {
+	if m.GetForecastFn != nil {
+		return m.GetForecastFn(ctx, userID, deckID, days)
+	}
+	return []domain.ForecastBucket{}, nil
+}
+
+// WithTx implements the UserCardStatsStore interface by returning the same mock
+func (m *MockUserCardStatsStore) WithTx(tx *sql.Tx) store.UserCardStatsStore {
+	return m
+}
+
+// Ensure MockUserCardStatsStore implements store.UserCardStatsStore
+var _ store.UserCardStatsStore = (*MockUserCardStatsStore)(nil)
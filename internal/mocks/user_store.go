@@ -12,11 +12,13 @@ import (
 // MockUserStore implements store.UserStore for testing
 type MockUserStore struct {
 	// Function fields for customizable behavior
-	CreateFn     func(ctx context.Context, user *domain.User) error
-	GetByEmailFn func(ctx context.Context, email string) (*domain.User, error)
-	GetByIDFn    func(ctx context.Context, id uuid.UUID) (*domain.User, error)
-	UpdateFn     func(ctx context.Context, user *domain.User) error
-	DeleteFn     func(ctx context.Context, id uuid.UUID) error
+	CreateFn             func(ctx context.Context, user *domain.User) error
+	GetByEmailFn         func(ctx context.Context, email string) (*domain.User, error)
+	GetByIDFn            func(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	ListFn               func(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error)
+	UpdateFn             func(ctx context.Context, user *domain.User) error
+	UpdatePasswordHashFn func(ctx context.Context, id uuid.UUID, oldHash, newHash string) error
+	DeleteFn             func(ctx context.Context, id uuid.UUID) error
 
 	// Data for default implementation
 	Users           map[string]*domain.User
@@ -85,6 +87,20 @@ func (m *MockUserStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.User
 	return nil, store.ErrUserNotFound
 }
 
+// List implements the UserStore interface
+func (m *MockUserStore) List(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx, emailQuery, limit, offset)
+	}
+
+	// Default implementation returns every user in the Users map, unordered
+	users := make([]*domain.User, 0, len(m.Users))
+	for _, user := range m.Users {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
 // Update implements the UserStore interface
 func (m *MockUserStore) Update(ctx context.Context, user *domain.User) error {
 	if m.UpdateFn != nil {
@@ -112,6 +128,26 @@ func (m *MockUserStore) Update(ctx context.Context, user *domain.User) error {
 	return store.ErrUserNotFound
 }
 
+// UpdatePasswordHash implements the UserStore interface
+func (m *MockUserStore) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	if m.UpdatePasswordHashFn != nil {
+		return m.UpdatePasswordHashFn(ctx, id, oldHash, newHash)
+	}
+
+	// Default implementation replaces the hash of the user with the same ID
+	for _, user := range m.Users {
+		if user.ID == id {
+			if user.HashedPassword != oldHash {
+				return store.ErrVersionConflict
+			}
+			user.HashedPassword = newHash
+			return nil
+		}
+	}
+
+	return store.ErrUserNotFound
+}
+
 // Delete implements the UserStore interface
 func (m *MockUserStore) Delete(ctx context.Context, id uuid.UUID) error {
 	if m.DeleteFn != nil {
@@ -185,11 +221,21 @@ func (m *LoginMockUserStore) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	return nil, nil
 }
 
+// List - placeholder implementation for UserStore interface
+func (m *LoginMockUserStore) List(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error) {
+	return nil, nil
+}
+
 // Update - placeholder implementation for UserStore interface
 func (m *LoginMockUserStore) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// UpdatePasswordHash - placeholder implementation for UserStore interface
+func (m *LoginMockUserStore) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	return nil
+}
+
 // Delete - placeholder implementation for UserStore interface
 func (m *LoginMockUserStore) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
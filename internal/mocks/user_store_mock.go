@@ -39,12 +39,27 @@ func (m *UserStore) GetByEmail(ctx context.Context, email string) (*domain.User,
 	return nil, args.Error(1)
 }
 
+// List is a mock implementation of store.UserStore.List
+func (m *UserStore) List(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error) {
+	args := m.Called(ctx, emailQuery, limit, offset)
+	if users, ok := args.Get(0).([]*domain.User); ok {
+		return users, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 // Update is a mock implementation of store.UserStore.Update
 func (m *UserStore) Update(ctx context.Context, user *domain.User) error {
 	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
+// UpdatePasswordHash is a mock implementation of store.UserStore.UpdatePasswordHash
+func (m *UserStore) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	args := m.Called(ctx, id, oldHash, newHash)
+	return args.Error(0)
+}
+
 // Delete is a mock implementation of store.UserStore.Delete
 func (m *UserStore) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
@@ -0,0 +1,14 @@
+// Package email sends application events to users as email.
+//
+// EmailNotifier implements events.EventHandler and is subscribed on an
+// events.EventBus to events.EventTypeEmailVerificationRequested,
+// events.EventTypePasswordResetRequested and events.EventTypeCardsGenerated.
+// Each event is rendered with a plain-text template into a Message and handed
+// to a Sender; SMTPSender delivers messages through the configured SMTP server.
+//
+// Failures to send are logged and returned as ErrSendFailed but never retried:
+// users can request another verification or reset email. HandleEvent blocks
+// while the mail server is contacted, so the server wraps the notifier in an
+// events.AsyncHandler; registration and password resets then only queue the
+// email and respond without waiting on, or failing because of, the mail server.
+package email
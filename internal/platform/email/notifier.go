@@ -0,0 +1,209 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+)
+
+var (
+	// ErrMissingAppURL is returned when the notifier is created without the
+	// URL that links in emails point to.
+	ErrMissingAppURL = errors.New("email app URL cannot be empty")
+
+	// ErrSendFailed is returned when an email could not be rendered or sent.
+	ErrSendFailed = errors.New("email sending failed")
+)
+
+// UserGetter looks up the recipient of emails that are not addressed in the
+// event itself. It is satisfied by store.UserStore.
+type UserGetter interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+}
+
+// emailTemplate renders the subject and body of one kind of email
+type emailTemplate struct {
+	subject string
+	body    *template.Template
+}
+
+var (
+	verificationTemplate = emailTemplate{
+		subject: "Verify your email address",
+		body: template.Must(template.New("verification").Parse(`Welcome to Scry!
+
+Confirm this is your email address by opening the link below:
+
+{{.Link}}
+
+The link expires at {{.ExpiresAt}}. If you did not create a Scry account, you can ignore this email.
+`)),
+	}
+
+	passwordResetTemplate = emailTemplate{
+		subject: "Reset your password",
+		body: template.Must(template.New("password_reset").Parse(`Someone asked to reset the password of your Scry account.
+
+Choose a new password by opening the link below:
+
+{{.Link}}
+
+The link expires at {{.ExpiresAt}} and can only be used once. If you did not ask to reset your password, you can ignore this email; your password has not been changed.
+`)),
+	}
+
+	cardsGeneratedTemplate = emailTemplate{
+		subject: "Your cards are ready",
+		body: template.Must(template.New("cards_generated").Parse(`{{if eq .CardCount 0}}We could not generate any cards from your memo.{{else if eq .CardCount 1}}1 new card was generated from your memo.{{else}}{{.CardCount}} new cards were generated from your memo.{{end}}
+
+See your memo at:
+
+{{.Link}}
+`)),
+	}
+)
+
+// EmailNotifier emails users when their email address needs verifying, when
+// they ask to reset their password, and when their memos finish generating
+// cards. It implements events.EventHandler.
+type EmailNotifier struct {
+	sender Sender
+	users  UserGetter
+	appURL string
+	logger *slog.Logger
+}
+
+// NewEmailNotifier creates a notifier that sends emails with sender, linking
+// to the app at cfg.AppURL. users looks up the owners of generated cards.
+// Returns ErrMissingAppURL if no app URL is configured.
+func NewEmailNotifier(
+	cfg config.EmailConfig,
+	sender Sender,
+	users UserGetter,
+	logger *slog.Logger,
+) (*EmailNotifier, error) {
+	if cfg.AppURL == "" {
+		return nil, ErrMissingAppURL
+	}
+	if sender == nil {
+		return nil, errors.New("sender cannot be nil")
+	}
+	if users == nil {
+		return nil, errors.New("user getter cannot be nil")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &EmailNotifier{
+		sender: sender,
+		users:  users,
+		appURL: strings.TrimRight(cfg.AppURL, "/"),
+		logger: logger.With("component", "email_notifier"),
+	}, nil
+}
+
+// HandleEvent implements events.EventHandler by emailing the user the event
+// concerns. Events of other types are ignored. Failures are logged and
+// returned as an error wrapping ErrSendFailed.
+func (n *EmailNotifier) HandleEvent(ctx context.Context, event *events.TaskRequestEvent) error {
+	log := n.logger.With("event_id", event.ID, "event_type", event.Type)
+
+	var (
+		msg *Message
+		err error
+	)
+	switch event.Type {
+	case events.EventTypeEmailVerificationRequested:
+		msg, err = n.verificationMessage(event)
+	case events.EventTypePasswordResetRequested:
+		msg, err = n.passwordResetMessage(event)
+	case events.EventTypeCardsGenerated:
+		msg, err = n.cardsGeneratedMessage(ctx, event)
+	default:
+		log.Debug("ignoring event without an email")
+		return nil
+	}
+	if err != nil {
+		log.Error("failed to prepare email", "error", err)
+		return fmt.Errorf("%w: %v", ErrSendFailed, err)
+	}
+
+	if err := n.sender.Send(ctx, *msg); err != nil {
+		log.Error("failed to send email", "error", err)
+		return fmt.Errorf("%w: %v", ErrSendFailed, err)
+	}
+
+	log.Debug("sent email")
+	return nil
+}
+
+func (n *EmailNotifier) verificationMessage(event *events.TaskRequestEvent) (*Message, error) {
+	var payload events.EmailVerificationRequestedEvent
+	if err := event.UnmarshalPayload(&payload); err != nil {
+		return nil, err
+	}
+	return render(verificationTemplate, payload.Email, map[string]interface{}{
+		"Link":      n.link("/verify-email", url.Values{"token": {payload.Token}}),
+		"ExpiresAt": payload.ExpiresAt.UTC().Format(time.RFC1123),
+	})
+}
+
+func (n *EmailNotifier) passwordResetMessage(event *events.TaskRequestEvent) (*Message, error) {
+	var payload events.PasswordResetRequestedEvent
+	if err := event.UnmarshalPayload(&payload); err != nil {
+		return nil, err
+	}
+	return render(passwordResetTemplate, payload.Email, map[string]interface{}{
+		"Link":      n.link("/reset-password", url.Values{"token": {payload.Token}}),
+		"ExpiresAt": payload.ExpiresAt.UTC().Format(time.RFC1123),
+	})
+}
+
+func (n *EmailNotifier) cardsGeneratedMessage(
+	ctx context.Context,
+	event *events.TaskRequestEvent,
+) (*Message, error) {
+	var payload events.CardsGeneratedEvent
+	if err := event.UnmarshalPayload(&payload); err != nil {
+		return nil, err
+	}
+	user, err := n.users.GetByID(ctx, payload.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up memo owner: %w", err)
+	}
+	return render(cardsGeneratedTemplate, user.Email, map[string]interface{}{
+		"Link":      n.link("/memos/"+payload.MemoID.String(), nil),
+		"CardCount": len(payload.CardIDs),
+	})
+}
+
+// link builds an absolute URL into the app
+func (n *EmailNotifier) link(path string, query url.Values) string {
+	if len(query) == 0 {
+		return n.appURL + path
+	}
+	return n.appURL + path + "?" + query.Encode()
+}
+
+// render fills in tmpl for the recipient to
+func render(tmpl emailTemplate, to string, data map[string]interface{}) (*Message, error) {
+	var body strings.Builder
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s email: %w", tmpl.body.Name(), err)
+	}
+	return &Message{To: to, Subject: tmpl.subject, Body: body.String()}, nil
+}
+
+// Ensure EmailNotifier implements events.EventHandler
+var _ events.EventHandler = (*EmailNotifier)(nil)
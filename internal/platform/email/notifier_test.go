@@ -0,0 +1,305 @@
+package email_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/platform/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAppURL = "https://scry.example.com/"
+
+// fakeSender records messages instead of sending them, failing with err if set
+type fakeSender struct {
+	mu       sync.Mutex
+	messages []email.Message
+	err      error
+}
+
+func (s *fakeSender) Send(ctx context.Context, msg email.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func newTestNotifier(t *testing.T, sender email.Sender, users email.UserGetter) *email.EmailNotifier {
+	t.Helper()
+
+	if users == nil {
+		users = mocks.NewMockUserStore()
+	}
+	notifier, err := email.NewEmailNotifier(
+		config.EmailConfig{AppURL: testAppURL},
+		sender,
+		users,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	require.NoError(t, err)
+	return notifier
+}
+
+func TestNewEmailNotifier_RequiresAppURL(t *testing.T) {
+	_, err := email.NewEmailNotifier(config.EmailConfig{}, &fakeSender{}, mocks.NewMockUserStore(), nil)
+	assert.ErrorIs(t, err, email.ErrMissingAppURL)
+}
+
+func TestEmailNotifier_HandleEvent(t *testing.T) {
+	expiresAt := time.Date(2025, 5, 2, 12, 0, 0, 0, time.UTC)
+
+	t.Run("verification email", func(t *testing.T) {
+		sender := &fakeSender{}
+		notifier := newTestNotifier(t, sender, nil)
+
+		event, err := events.NewEmailVerificationRequestedEvent(events.EmailVerificationRequestedEvent{
+			UserID:    uuid.New(),
+			Email:     "user@example.com",
+			Token:     "verify+token/123",
+			ExpiresAt: expiresAt,
+		})
+		require.NoError(t, err)
+		require.NoError(t, notifier.HandleEvent(context.Background(), event))
+
+		require.Len(t, sender.messages, 1)
+		msg := sender.messages[0]
+		assert.Equal(t, "user@example.com", msg.To)
+		assert.Equal(t, "Verify your email address", msg.Subject)
+		assert.Contains(t, msg.Body, "https://scry.example.com/verify-email?token=verify%2Btoken%2F123\n",
+			"The link should carry the escaped token")
+		assert.Contains(t, msg.Body, "Fri, 02 May 2025 12:00:00 UTC")
+	})
+
+	t.Run("password reset email", func(t *testing.T) {
+		sender := &fakeSender{}
+		notifier := newTestNotifier(t, sender, nil)
+
+		event, err := events.NewPasswordResetRequestedEvent(events.PasswordResetRequestedEvent{
+			UserID:    uuid.New(),
+			Email:     "user@example.com",
+			Token:     "reset-token",
+			ExpiresAt: expiresAt,
+		})
+		require.NoError(t, err)
+		require.NoError(t, notifier.HandleEvent(context.Background(), event))
+
+		require.Len(t, sender.messages, 1)
+		assert.Equal(t, "user@example.com", sender.messages[0].To)
+		assert.Equal(t, "Reset your password", sender.messages[0].Subject)
+		assert.Contains(t, sender.messages[0].Body, "https://scry.example.com/reset-password?token=reset-token\n")
+	})
+
+	t.Run("cards generated email goes to the memo owner", func(t *testing.T) {
+		owner := &domain.User{ID: uuid.New(), Email: "owner@example.com"}
+		users := mocks.NewMockUserStore()
+		users.Users[owner.Email] = owner
+
+		sender := &fakeSender{}
+		notifier := newTestNotifier(t, sender, users)
+
+		memoID := uuid.New()
+		event, err := events.NewCardsGeneratedEvent(events.CardsGeneratedEvent{
+			MemoID:  memoID,
+			UserID:  owner.ID,
+			CardIDs: []uuid.UUID{uuid.New(), uuid.New()},
+		})
+		require.NoError(t, err)
+		require.NoError(t, notifier.HandleEvent(context.Background(), event))
+
+		require.Len(t, sender.messages, 1)
+		assert.Equal(t, owner.Email, sender.messages[0].To)
+		assert.Contains(t, sender.messages[0].Body, "2 new cards were generated")
+		assert.Contains(t, sender.messages[0].Body, "https://scry.example.com/memos/"+memoID.String())
+	})
+
+	t.Run("unknown memo owner is not emailed", func(t *testing.T) {
+		sender := &fakeSender{}
+		notifier := newTestNotifier(t, sender, nil)
+
+		event, err := events.NewCardsGeneratedEvent(events.CardsGeneratedEvent{MemoID: uuid.New(), UserID: uuid.New()})
+		require.NoError(t, err)
+
+		err = notifier.HandleEvent(context.Background(), event)
+		assert.ErrorIs(t, err, email.ErrSendFailed)
+		assert.Empty(t, sender.messages)
+	})
+
+	t.Run("other events are ignored", func(t *testing.T) {
+		sender := &fakeSender{}
+		notifier := newTestNotifier(t, sender, nil)
+
+		event, err := events.NewTaskRequestEvent("memo_created", map[string]string{})
+		require.NoError(t, err)
+
+		assert.NoError(t, notifier.HandleEvent(context.Background(), event))
+		assert.Empty(t, sender.messages)
+	})
+
+	t.Run("send failure is reported", func(t *testing.T) {
+		notifier := newTestNotifier(t, &fakeSender{err: errors.New("connection refused")}, nil)
+
+		event, err := events.NewPasswordResetRequestedEvent(events.PasswordResetRequestedEvent{
+			UserID: uuid.New(),
+			Email:  "user@example.com",
+			Token:  "reset-token",
+		})
+		require.NoError(t, err)
+
+		err = notifier.HandleEvent(context.Background(), event)
+		assert.ErrorIs(t, err, email.ErrSendFailed)
+		assert.NotContains(t, err.Error(), "reset-token", "Tokens must not leak into errors")
+	})
+}
+
+// smtpSession is what the fake SMTP server received in one session
+type smtpSession struct {
+	from string
+	to   []string
+	data string
+}
+
+// startFakeSMTPServer accepts a single SMTP session without TLS or auth and
+// reports what it received
+func startFakeSMTPServer(t *testing.T) (string, int, <-chan smtpSession) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	sessions := make(chan smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		var session smtpSession
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				session.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				reply("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				session.to = append(session.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 Go ahead")
+				var data strings.Builder
+				for {
+					dataLine, err := r.ReadString('\n')
+					if err != nil || dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				session.data = data.String()
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				sessions <- session
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, sessions
+}
+
+func TestSMTPSender(t *testing.T) {
+	t.Run("delivers message", func(t *testing.T) {
+		host, port, sessions := startFakeSMTPServer(t)
+		sender := email.NewSMTPSender(config.EmailConfig{
+			SMTPHost:       host,
+			SMTPPort:       port,
+			From:           "noreply@scry.example.com",
+			TimeoutSeconds: 5,
+		})
+
+		err := sender.Send(context.Background(), email.Message{
+			To:      "user@example.com",
+			Subject: "Verify your email address",
+			Body:    "Line one\nLine two\n",
+		})
+		require.NoError(t, err)
+
+		select {
+		case session := <-sessions:
+			assert.Equal(t, "noreply@scry.example.com", session.from)
+			assert.Equal(t, []string{"user@example.com"}, session.to)
+			assert.Contains(t, session.data, "To: user@example.com\r\n")
+			assert.Contains(t, session.data, "Subject: Verify your email address\r\n")
+			assert.Contains(t, session.data, "\r\n\r\nLine one\r\nLine two\r\n")
+		case <-time.After(5 * time.Second):
+			t.Fatal("Fake SMTP server did not receive the message")
+		}
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := listener.Addr().(*net.TCPAddr).Port
+		require.NoError(t, listener.Close())
+
+		sender := email.NewSMTPSender(config.EmailConfig{
+			SMTPHost:       "127.0.0.1",
+			SMTPPort:       port,
+			From:           "noreply@scry.example.com",
+			TimeoutSeconds: 1,
+		})
+		notifier := newTestNotifier(t, sender, nil)
+
+		event, err := events.NewEmailVerificationRequestedEvent(events.EmailVerificationRequestedEvent{
+			UserID: uuid.New(),
+			Email:  "user@example.com",
+			Token:  "verify-token",
+		})
+		require.NoError(t, err)
+
+		err = notifier.HandleEvent(context.Background(), event)
+		assert.ErrorIs(t, err, email.ErrSendFailed)
+	})
+
+	t.Run("rejects header injection", func(t *testing.T) {
+		sender := email.NewSMTPSender(config.EmailConfig{SMTPHost: "127.0.0.1", SMTPPort: 25, TimeoutSeconds: 1})
+
+		err := sender.Send(context.Background(), email.Message{
+			To:      "user@example.com\r\nBcc: victim@example.com",
+			Subject: "Hello",
+		})
+		assert.ErrorIs(t, err, email.ErrInvalidHeader)
+	})
+}
@@ -0,0 +1,47 @@
+//go:build !test_without_external_deps
+
+package gemini
+
+import (
+	"fmt"
+
+	"github.com/phrazzld/scry-api/internal/generation"
+	"google.golang.org/genai"
+)
+
+// blockedFinishReasons are the candidate finish reasons that mean Gemini
+// refused to generate content, as opposed to stopping for length or errors
+var blockedFinishReasons = map[genai.FinishReason]bool{
+	genai.FinishReasonSafety:            true,
+	genai.FinishReasonBlocklist:         true,
+	genai.FinishReasonProhibitedContent: true,
+	genai.FinishReasonSPII:              true,
+	genai.FinishReasonImageSafety:       true,
+}
+
+// blockedError returns an error wrapping generation.ErrContentBlocked if
+// Gemini blocked the prompt or the first candidate, or nil otherwise.
+// Blocked responses often carry no candidates or no content, so callers must
+// check this before treating those as malformed responses.
+func blockedError(resp *genai.GenerateContentResponse) error {
+	if resp == nil {
+		return nil
+	}
+
+	if feedback := resp.PromptFeedback; feedback != nil && feedback.BlockReason != "" &&
+		feedback.BlockReason != genai.BlockedReasonUnspecified {
+		if feedback.BlockReasonMessage != "" {
+			return fmt.Errorf("%w: prompt blocked (%s): %s",
+				generation.ErrContentBlocked, feedback.BlockReason, feedback.BlockReasonMessage)
+		}
+		return fmt.Errorf("%w: prompt blocked (%s)", generation.ErrContentBlocked, feedback.BlockReason)
+	}
+
+	if len(resp.Candidates) > 0 && resp.Candidates[0] != nil {
+		if reason := resp.Candidates[0].FinishReason; blockedFinishReasons[reason] {
+			return fmt.Errorf("%w: finish reason %s", generation.ErrContentBlocked, reason)
+		}
+	}
+
+	return nil
+}
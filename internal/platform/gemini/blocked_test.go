@@ -0,0 +1,72 @@
+//go:build !test_without_external_deps
+
+package gemini
+
+import (
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/generation"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genai"
+)
+
+func TestBlockedError(t *testing.T) {
+	t.Parallel()
+
+	candidate := func(reason genai.FinishReason, content *genai.Content) *genai.GenerateContentResponse {
+		return &genai.GenerateContentResponse{
+			Candidates: []*genai.Candidate{{FinishReason: reason, Content: content}},
+		}
+	}
+	text := genai.NewContentFromText(`{"cards":[]}`, genai.RoleModel)
+
+	tests := []struct {
+		name        string
+		resp        *genai.GenerateContentResponse
+		wantBlocked bool
+	}{
+		{name: "nil response", resp: nil},
+		{name: "no candidates", resp: &genai.GenerateContentResponse{}},
+		{name: "stop", resp: candidate(genai.FinishReasonStop, text)},
+		{name: "max tokens", resp: candidate(genai.FinishReasonMaxTokens, text)},
+		// Blocked candidates usually come back without content
+		{name: "safety", resp: candidate(genai.FinishReasonSafety, nil), wantBlocked: true},
+		{name: "safety with content", resp: candidate(genai.FinishReasonSafety, text), wantBlocked: true},
+		{name: "blocklist", resp: candidate(genai.FinishReasonBlocklist, nil), wantBlocked: true},
+		{name: "prohibited content", resp: candidate(genai.FinishReasonProhibitedContent, nil), wantBlocked: true},
+		{name: "personal information", resp: candidate(genai.FinishReasonSPII, nil), wantBlocked: true},
+		{
+			name: "prompt blocked",
+			resp: &genai.GenerateContentResponse{
+				PromptFeedback: &genai.GenerateContentResponsePromptFeedback{
+					BlockReason:        genai.BlockedReasonSafety,
+					BlockReasonMessage: "harassment",
+				},
+			},
+			wantBlocked: true,
+		},
+		{
+			name: "prompt feedback without a block",
+			resp: &genai.GenerateContentResponse{
+				Candidates: []*genai.Candidate{{FinishReason: genai.FinishReasonStop, Content: text}},
+				PromptFeedback: &genai.GenerateContentResponsePromptFeedback{
+					BlockReason: genai.BlockedReasonUnspecified,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := blockedError(tt.resp)
+			if !tt.wantBlocked {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, generation.ErrContentBlocked)
+			assert.NotErrorIs(t, err, generation.ErrInvalidResponse, "blocked content is not a malformed response")
+		})
+	}
+}
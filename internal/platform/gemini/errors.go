@@ -6,4 +6,25 @@ import "errors"
 var (
 	// ErrEmptyMemoText is returned when a memo text is empty.
 	ErrEmptyMemoText = errors.New("memo text cannot be empty")
+
+	// The errors below categorize why an API response could not be parsed.
+	// Each is returned wrapped together with generation.ErrInvalidResponse.
+
+	// ErrResponseTooLarge is returned when a response exceeds MaxResponseBytes.
+	ErrResponseTooLarge = errors.New("response too large")
+
+	// ErrResponseTooDeep is returned when a response nests objects or arrays
+	// more than MaxResponseDepth levels deep.
+	ErrResponseTooDeep = errors.New("response nested too deeply")
+
+	// ErrResponseMalformed is returned when a response is not valid JSON,
+	// including when it is truncated.
+	ErrResponseMalformed = errors.New("malformed JSON response")
+
+	// ErrResponseWrongType is returned when a response field has the wrong JSON type.
+	ErrResponseWrongType = errors.New("response field has the wrong type")
+
+	// ErrResponseMissingField is returned when a response or one of its cards
+	// lacks a required field.
+	ErrResponseMissingField = errors.New("response is missing a required field")
 )
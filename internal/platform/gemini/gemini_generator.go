@@ -5,7 +5,6 @@ package gemini
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
@@ -45,6 +44,10 @@ type GeminiGenerator struct {
 
 	// model is the name of the Gemini model to use
 	model string
+
+	// generationConfig holds the sampling settings sent with each request,
+	// or nil to use the model's defaults
+	generationConfig *genai.GenerateContentConfig
 }
 
 // NewGeminiGenerator creates a new instance of GeminiGenerator with the provided dependencies.
@@ -53,6 +56,7 @@ type GeminiGenerator struct {
 //   - ctx: Context for the operation, which can be used for cancellation
 //   - logger: A structured logger for operation logging
 //   - config: LLM configuration containing API key, model name, and other settings
+//   - opts: Optional settings, such as the HTTP client
 //
 // Returns:
 //   - A properly initialized GeminiGenerator or an error if initialization fails
@@ -60,6 +64,7 @@ func NewGeminiGenerator(
 	ctx context.Context,
 	logger *slog.Logger,
 	config config.LLMConfig,
+	opts ...GeneratorOption,
 ) (*GeminiGenerator, error) {
 	if logger == nil {
 		return nil, errors.New("logger cannot be nil")
@@ -94,10 +99,16 @@ func NewGeminiGenerator(
 			generation.ErrInvalidConfig, err)
 	}
 
+	var options generatorOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Initialize the Gemini client with the new genai package
 	clientConfig := &genai.ClientConfig{
-		APIKey:  config.GeminiAPIKey,
-		Backend: genai.BackendGeminiAPI,
+		APIKey:     config.GeminiAPIKey,
+		Backend:    genai.BackendGeminiAPI,
+		HTTPClient: options.httpClient,
 	}
 
 	client, err := genai.NewClient(ctx, clientConfig)
@@ -107,16 +118,33 @@ func NewGeminiGenerator(
 	}
 
 	generator := &GeminiGenerator{
-		logger:         logger,
-		config:         config,
-		promptTemplate: promptTemplate,
-		client:         client,
-		model:          config.ModelName,
+		logger:           logger,
+		config:           config,
+		promptTemplate:   promptTemplate,
+		client:           client,
+		model:            config.ModelName,
+		generationConfig: newGenerationConfig(config),
 	}
 
 	return generator, nil
 }
 
+// newGenerationConfig returns the sampling settings configured for requests,
+// or nil when none are set so that the model's defaults apply.
+func newGenerationConfig(cfg config.LLMConfig) *genai.GenerateContentConfig {
+	if cfg.Temperature == nil && cfg.MaxOutputTokens == 0 {
+		return nil
+	}
+
+	genCfg := &genai.GenerateContentConfig{
+		MaxOutputTokens: int32(cfg.MaxOutputTokens),
+	}
+	if cfg.Temperature != nil {
+		genCfg.Temperature = genai.Ptr(*cfg.Temperature)
+	}
+	return genCfg
+}
+
 // createPrompt generates a prompt string from the template with the provided memo text.
 //
 // It uses the shared createPromptFromTemplate function to generate the prompt.
@@ -129,7 +157,13 @@ func NewGeminiGenerator(
 //   - The generated prompt string
 //   - An error if the memo text is empty or the template execution fails
 func (g *GeminiGenerator) createPrompt(ctx context.Context, memoText string) (string, error) {
-	return createPromptFromTemplate(ctx, g.logger, g.promptTemplate, memoText)
+	return createPromptFromTemplate(
+		ctx,
+		g.logger,
+		g.promptTemplate,
+		memoText,
+		g.config.AutoTagTopics,
+	)
 }
 
 // callGeminiWithRetry makes a call to the Gemini API with exponential backoff retry logic.
@@ -197,7 +231,7 @@ func (g *GeminiGenerator) callGeminiWithRetry(
 		var isTransientError bool
 
 		// Call the Gemini API using the new genai package
-		resp, err := g.client.Models.GenerateContent(ctx, g.model, content, nil)
+		resp, err := g.client.Models.GenerateContent(ctx, g.model, content, g.generationConfig)
 		if err != nil {
 			// Handle API errors
 			isTransientError = true // Assume transient error by default
@@ -208,6 +242,9 @@ func (g *GeminiGenerator) callGeminiWithRetry(
 			// No response object
 			err = fmt.Errorf("%w: nil response", generation.ErrInvalidResponse)
 			isTransientError = false
+		} else if err = blockedError(resp); err != nil {
+			// Gemini refused the prompt or its output; retrying will not help
+			isTransientError = false
 		} else if len(resp.Candidates) == 0 {
 			// No candidates in response
 			err = fmt.Errorf("%w: no content generated", generation.ErrInvalidResponse)
@@ -216,10 +253,6 @@ func (g *GeminiGenerator) callGeminiWithRetry(
 			// No content in candidate
 			err = fmt.Errorf("%w: empty content in response", generation.ErrInvalidResponse)
 			isTransientError = false
-		} else if resp.Candidates[0].FinishReason == genai.FinishReasonSafety {
-			// Content blocked by safety filters
-			err = fmt.Errorf("%w: content blocked by safety filters", generation.ErrContentBlocked)
-			isTransientError = false
 		} else {
 			// Extract the response text
 			text := ""
@@ -230,12 +263,8 @@ func (g *GeminiGenerator) callGeminiWithRetry(
 			}
 
 			// Parse the JSON response
-			var parsedResponse ResponseSchema
-			if err = json.Unmarshal([]byte(text), &parsedResponse); err != nil {
-				err = fmt.Errorf("%w: failed to parse JSON response: %v", generation.ErrInvalidResponse, err)
+			if response, err = decodeResponse([]byte(text)); err != nil {
 				isTransientError = false
-			} else {
-				response = &parsedResponse
 			}
 		}
 
@@ -308,7 +337,8 @@ func (g *GeminiGenerator) callGeminiWithRetry(
 
 // parseResponse converts a ResponseSchema from the Gemini API into domain.Card objects.
 //
-// It uses the shared parseResponseToCards function to parse the response.
+// It uses the shared parseResponseToCards function to parse the response, first
+// adding each card's topic as a tag when topic auto-tagging is enabled.
 //
 // Parameters:
 //   - ctx: Context for the operation, which can be used for logging
@@ -325,9 +355,21 @@ func (g *GeminiGenerator) parseResponse(
 	userID uuid.UUID,
 	memoID uuid.UUID,
 ) ([]*domain.Card, error) {
+	if g.config.AutoTagTopics {
+		applyTopicTags(ctx, g.logger, response)
+	}
 	return parseResponseToCards(ctx, g.logger, response, userID, memoID, true)
 }
 
+// Ping checks that the Gemini API is reachable and accepts the configured API
+// key and model by fetching the model's metadata, which generates nothing.
+func (g *GeminiGenerator) Ping(ctx context.Context) error {
+	if _, err := g.client.Models.Get(ctx, g.model, nil); err != nil {
+		return fmt.Errorf("%w: model %s: %v", generation.ErrProviderUnreachable, g.model, err)
+	}
+	return nil
+}
+
 // GenerateCards creates flashcards based on the provided memo text and user ID.
 // It fulfills the generation.Generator interface by:
 // 1. Creating a prompt from the memo text
@@ -0,0 +1,99 @@
+//go:build !test_without_external_deps
+
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTransport answers every request with a fixed generation response
+// and keeps the requests it received
+type recordingTransport struct {
+	requests []*http.Request
+	bodies   []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	t.requests = append(t.requests, req)
+	t.bodies = append(t.bodies, string(body))
+
+	cards, err := json.Marshal(`{"cards":[{"front":"Q","back":"A"}]}`)
+	if err != nil {
+		return nil, err
+	}
+	resp := `{"candidates":[{"content":{"role":"model","parts":[{"text":` + string(cards) +
+		`}]},"finishReason":"STOP"}]}`
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(resp)),
+		Request:    req,
+	}, nil
+}
+
+func TestGeminiGenerator_SendsConfiguredModel(t *testing.T) {
+	t.Parallel()
+
+	templatePath := filepath.Join(t.TempDir(), "template.txt")
+	require.NoError(t, os.WriteFile(templatePath, []byte("Make cards from: {{.MemoText}}"), 0o600))
+
+	temperature := float32(0.25)
+	transport := &recordingTransport{}
+	generator, err := NewGeminiGenerator(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		config.LLMConfig{
+			GeminiAPIKey:       "test-api-key",
+			ModelName:          "gemini-custom-model",
+			PromptTemplatePath: templatePath,
+			RetryDelaySeconds:  1,
+			Temperature:        &temperature,
+			MaxOutputTokens:    512,
+		},
+		WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	require.NoError(t, err)
+
+	cards, err := generator.GenerateCards(context.Background(), "Go is a programming language", uuid.New())
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+
+	require.Len(t, transport.requests, 1)
+	assert.True(t, strings.HasSuffix(transport.requests[0].URL.Path, "/models/gemini-custom-model:generateContent"),
+		"request path %q should name the configured model", transport.requests[0].URL.Path)
+
+	var body struct {
+		GenerationConfig struct {
+			Temperature     *float32 `json:"temperature"`
+			MaxOutputTokens int      `json:"maxOutputTokens"`
+		} `json:"generationConfig"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(transport.bodies[0]), &body))
+	require.NotNil(t, body.GenerationConfig.Temperature)
+	assert.InDelta(t, 0.25, *body.GenerationConfig.Temperature, 1e-6)
+	assert.Equal(t, 512, body.GenerationConfig.MaxOutputTokens)
+}
+
+func TestNewGenerationConfig_Unset(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newGenerationConfig(config.LLMConfig{ModelName: "gemini-2.0-flash"}),
+		"requests use the model's defaults unless settings are configured")
+}
@@ -48,6 +48,7 @@ func (g *GeminiGenerator) Client() *MockGenAIClient {
 //   - ctx: Context for the operation, which can be used for cancellation
 //   - logger: A structured logger for operation logging
 //   - config: LLM configuration containing API key, model name, and other settings
+//   - opts: Optional settings, such as the HTTP client
 //
 // Returns:
 //   - A properly initialized GeminiGenerator or an error if initialization fails
@@ -55,6 +56,7 @@ func NewGeminiGenerator(
 	ctx context.Context,
 	logger *slog.Logger,
 	config config.LLMConfig,
+	opts ...GeneratorOption,
 ) (*GeminiGenerator, error) {
 	if logger == nil {
 		return nil, errors.New("logger cannot be nil")
@@ -106,12 +108,19 @@ func NewGeminiGenerator(
 //   - The generated prompt string
 //   - An error if the memo text is empty or the template execution fails
 func (g *GeminiGenerator) createPrompt(ctx context.Context, memoText string) (string, error) {
-	return createPromptFromTemplate(ctx, g.logger, g.promptTemplate, memoText)
+	return createPromptFromTemplate(
+		ctx,
+		g.logger,
+		g.promptTemplate,
+		memoText,
+		g.config.AutoTagTopics,
+	)
 }
 
 // parseResponse converts a ResponseSchema from the mock API into domain.Card objects.
 //
-// It uses the shared parseResponseToCards function to parse the response.
+// It uses the shared parseResponseToCards function to parse the response, first
+// adding each card's topic as a tag when topic auto-tagging is enabled.
 //
 // Parameters:
 //   - ctx: Context for the operation, which can be used for logging
@@ -128,9 +137,21 @@ func (g *GeminiGenerator) parseResponse(
 	userID uuid.UUID,
 	memoID uuid.UUID,
 ) ([]*domain.Card, error) {
+	if g.config.AutoTagTopics {
+		applyTopicTags(ctx, g.logger, response)
+	}
 	return parseResponseToCards(ctx, g.logger, response, userID, memoID, false)
 }
 
+// Ping reports the mock provider as unreachable while its client is set to
+// fail, and reachable otherwise.
+func (g *GeminiGenerator) Ping(ctx context.Context) error {
+	if g.client.ShouldFail {
+		return fmt.Errorf("%w: model %s: mock API error", generation.ErrProviderUnreachable, g.modelName)
+	}
+	return ctx.Err()
+}
+
 // GenerateCards creates mock flashcards based on the provided memo text and user ID.
 // This is a test implementation that doesn't require external API access.
 //
@@ -386,3 +386,126 @@ func TestErrorHandling(t *testing.T) {
 		"Error should be ErrGenerationFailed",
 	)
 }
+
+// Test that topic auto-tagging adds normalized topics to generated cards
+func TestGenerateCards_AutoTagTopics(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+	tmpl := newTestTemplate()
+
+	responseCards := func() []gemini.CardSchema {
+		return []gemini.CardSchema{
+			{Front: "Q1", Back: "A1", Tags: []string{"science"}, Topic: "Cell  Biology"},
+			{Front: "Q2", Back: "A2", Tags: []string{"science"}, Topic: "Science"},
+			{Front: "Q3", Back: "A3", Topic: "C++ & Rust!"},
+			{Front: "Q4", Back: "A4", Topic: "an unreasonably long topic name for a tag"},
+			{Front: "Q5", Back: "A5"},
+		}
+	}
+
+	cardTags := func(t *testing.T, cards []*domain.Card) [][]string {
+		tags := make([][]string, 0, len(cards))
+		for _, card := range cards {
+			var content domain.CardContent
+			require.NoError(t, json.Unmarshal(card.Content, &content))
+			tags = append(tags, content.Tags)
+		}
+		return tags
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		testConfig := newTestConfig()
+		testConfig.AutoTagTopics = true
+
+		generator := gemini.NewTestableGenerator(logger, testConfig, tmpl)
+		generator.Client().SetResponseCards(responseCards())
+
+		cards, err := generator.GenerateCards(ctx, "Memo about cells", uuid.New())
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]string{
+			{"science", "cell-biology"}, // Topic is normalized before tagging
+			{"science"},                 // Topic matching an existing tag is not duplicated
+			nil,                         // Topic with disallowed characters is dropped
+			nil,                         // Topic longer than MaxTagLength is dropped
+			nil,                         // No topic returned
+		}, cardTags(t, cards))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		generator := gemini.NewTestableGenerator(logger, newTestConfig(), tmpl)
+		generator.Client().SetResponseCards(responseCards())
+
+		cards, err := generator.GenerateCards(ctx, "Memo about cells", uuid.New())
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]string{
+			{"science"},
+			{"science"},
+			nil,
+			nil,
+			nil,
+		}, cardTags(t, cards))
+	})
+
+	t.Run("prompt requests topics only when enabled", func(t *testing.T) {
+		topicTmpl, err := template.New("test").
+			Parse("{{.MemoText}}{{if .IncludeTopic}} with topics{{end}}")
+		require.NoError(t, err)
+
+		testConfig := newTestConfig()
+		prompt, err := gemini.CreatePromptForTest(
+			gemini.NewTestableGenerator(logger, testConfig, topicTmpl), ctx, "Memo")
+		require.NoError(t, err)
+		assert.Equal(t, "Memo", prompt)
+
+		testConfig.AutoTagTopics = true
+		prompt, err = gemini.CreatePromptForTest(
+			gemini.NewTestableGenerator(logger, testConfig, topicTmpl), ctx, "Memo")
+		require.NoError(t, err)
+		assert.Equal(t, "Memo with topics", prompt)
+	})
+}
+
+// Test that cloze cards requested by the prompt template are created with their type
+func TestGenerateCards_ClozeCards(t *testing.T) {
+	ctx := context.Background()
+	generator := gemini.NewTestableGenerator(newTestLogger(), newTestConfig(), newTestTemplate())
+
+	t.Run("mixed card types", func(t *testing.T) {
+		generator.Client().SetResponseCards([]gemini.CardSchema{
+			{Front: "Q1", Back: "A1"},
+			{Type: "cloze", Text: "The {{c1::mitochondria}} is the powerhouse of the cell", Back: "Biology 101"},
+		})
+
+		cards, err := generator.GenerateCards(ctx, "Memo about cells", uuid.New())
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+
+		assert.Equal(t, domain.CardTypeBasic, cards[0].Type)
+		assert.Equal(t, domain.CardTypeCloze, cards[1].Type)
+		assert.JSONEq(t,
+			`{"text":"The {{c1::mitochondria}} is the powerhouse of the cell","back":"Biology 101"}`,
+			string(cards[1].Content))
+	})
+
+	t.Run("malformed cloze markup", func(t *testing.T) {
+		generator.Client().SetResponseCards([]gemini.CardSchema{
+			{Type: "cloze", Text: "The {{c1::mitochondria is the powerhouse of the cell"},
+		})
+
+		_, err := generator.GenerateCards(ctx, "Memo about cells", uuid.New())
+		assert.ErrorIs(t, err, generation.ErrGenerationFailed)
+		assert.ErrorContains(t, err, "unterminated cloze marker")
+	})
+
+	t.Run("unknown card type", func(t *testing.T) {
+		generator.Client().SetResponseCards([]gemini.CardSchema{
+			{Type: "reverse", Front: "Q1", Back: "A1"},
+		})
+
+		_, err := generator.GenerateCards(ctx, "Memo about cells", uuid.New())
+		assert.ErrorIs(t, err, generation.ErrGenerationFailed)
+		assert.ErrorContains(t, err, `unknown card type "reverse"`)
+	})
+}
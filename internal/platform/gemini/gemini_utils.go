@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"html/template"
 	"log/slog"
+	"net/http"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/config"
@@ -16,6 +18,22 @@ import (
 	"github.com/phrazzld/scry-api/internal/generation"
 )
 
+// GeneratorOption configures a GeminiGenerator.
+type GeneratorOption func(*generatorOptions)
+
+// generatorOptions holds the settings GeneratorOptions change
+type generatorOptions struct {
+	httpClient *http.Client
+}
+
+// WithHTTPClient sets the HTTP client used to call the Gemini API, such as
+// one with a custom transport for a proxy or for tests.
+func WithHTTPClient(client *http.Client) GeneratorOption {
+	return func(o *generatorOptions) {
+		o.httpClient = client
+	}
+}
+
 // NewGenerator creates the appropriate GeminiGenerator implementation based on build tags.
 // This factory function allows the application to use the real implementation in production
 // and the mock implementation in test environments with the test_without_external_deps build tag.
@@ -24,6 +42,7 @@ import (
 //   - ctx: Context for initialization, which may include timeouts or cancellation
 //   - logger: A logger for recording operations
 //   - config: Configuration information including API keys and settings
+//   - opts: Optional settings, such as the HTTP client
 //
 // Returns:
 //   - A generation.Generator implementation
@@ -32,6 +51,7 @@ func NewGenerator(
 	ctx context.Context,
 	logger *slog.Logger,
 	config config.LLMConfig,
+	opts ...GeneratorOption,
 ) (generation.Generator, error) {
 	if logger == nil {
 		return nil, fmt.Errorf("logger cannot be nil")
@@ -56,7 +76,7 @@ func NewGenerator(
 	logger.InfoContext(ctx, "Using test configuration for Gemini generator")
 
 	// Call the version-specific implementation
-	generator, err := NewGeminiGenerator(ctx, logger, config)
+	generator, err := NewGeminiGenerator(ctx, logger, config, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +94,7 @@ func NewGenerator(
 //   - logger: Structured logger for logging operations
 //   - tmpl: The parsed template to execute
 //   - memoText: The text of the memo to include in the prompt
+//   - includeTopic: Whether the template should ask for a topic per card
 //
 // Returns:
 //   - The generated prompt string
@@ -83,6 +104,7 @@ func createPromptFromTemplate(
 	logger *slog.Logger,
 	tmpl *template.Template,
 	memoText string,
+	includeTopic bool,
 ) (string, error) {
 	// Validate input
 	if memoText == "" {
@@ -91,7 +113,8 @@ func createPromptFromTemplate(
 
 	// Create data for template
 	data := promptData{
-		MemoText: memoText,
+		MemoText:     memoText,
+		IncludeTopic: includeTopic,
 	}
 
 	logger.DebugContext(ctx, "Generating prompt from template",
@@ -111,6 +134,41 @@ func createPromptFromTemplate(
 	return prompt, nil
 }
 
+// applyTopicTags adds each card's topic to its tags, in place.
+//
+// Topics are normalized with domain.NormalizeTag. A topic that fails tag
+// validation is dropped rather than failing generation, and a topic that
+// duplicates an existing tag is not added twice.
+//
+// Parameters:
+//   - ctx: Context for the operation, which can be used for logging
+//   - logger: Structured logger for logging operations
+//   - response: The structured response from the API
+func applyTopicTags(ctx context.Context, logger *slog.Logger, response *ResponseSchema) {
+	if response == nil {
+		return
+	}
+
+	for i := range response.Cards {
+		card := &response.Cards[i]
+		if card.Topic == "" {
+			continue
+		}
+
+		tag, err := domain.NormalizeTag(card.Topic)
+		if err != nil {
+			logger.DebugContext(ctx, "Dropping invalid topic tag",
+				"card_index", i,
+				"topic", card.Topic)
+			continue
+		}
+
+		if !slices.Contains(card.Tags, tag) {
+			card.Tags = append(card.Tags, tag)
+		}
+	}
+}
+
 // parseResponseToCards converts a ResponseSchema from the API into domain.Card objects.
 //
 // It validates each card in the response and creates domain.Card objects with
@@ -167,46 +225,15 @@ func parseResponseToCards(
 	// Create domain cards from response
 	cards := make([]*domain.Card, 0, len(response.Cards))
 	for i, cardSchema := range response.Cards {
-		// Validate required fields
-		if cardSchema.Front == "" {
-			return nil, fmt.Errorf(
-				"%w: card %d missing front side",
-				generation.ErrInvalidResponse,
-				i,
-			)
-		}
-
-		if cardSchema.Back == "" {
-			return nil, fmt.Errorf(
-				"%w: card %d missing back side",
-				generation.ErrInvalidResponse,
-				i,
-			)
-		}
-
-		// Create domain.CardContent structure
-		cardContent := domain.CardContent{
-			Front: cardSchema.Front,
-			Back:  cardSchema.Back,
-			Hint:  cardSchema.Hint,
-			Tags:  cardSchema.Tags,
-		}
-
-		// Convert to JSON
-		contentJSON, err := json.Marshal(cardContent)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal card content to JSON: %w", err)
-		}
-
-		// Create domain.Card
-		card, err := domain.NewCard(userID, memoID, contentJSON)
+		card, err := cardFromSchema(cardSchema, userID, memoID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create card: %w", err)
+			return nil, fmt.Errorf("%w: card %d: %w", generation.ErrInvalidResponse, i, err)
 		}
 
 		cards = append(cards, card)
 		logger.DebugContext(ctx, "Created card from "+sourceType+" response",
 			"card_id", card.ID.String(),
+			"card_type", string(card.Type),
 			"front_length", len(cardSchema.Front),
 			"back_length", len(cardSchema.Back))
 	}
@@ -216,3 +243,53 @@ func parseResponseToCards(
 
 	return cards, nil
 }
+
+// cardFromSchema creates a domain.Card from one card of an API response.
+// Basic cards need a front and a back; cloze cards need text with valid
+// cloze markup, and their back is optional.
+//
+// Parameters:
+//   - cardSchema: The card from the API response
+//   - userID: The UUID of the user who owns the memo
+//   - memoID: The UUID of the memo from which the card is generated
+//
+// Returns:
+//   - The created card
+//   - An error describing the missing or invalid field
+func cardFromSchema(cardSchema CardSchema, userID, memoID uuid.UUID) (*domain.Card, error) {
+	cardType := domain.CardType(cardSchema.Type).OrDefault()
+	cardContent := domain.CardContent{
+		Back: cardSchema.Back,
+		Hint: cardSchema.Hint,
+		Tags: cardSchema.Tags,
+	}
+
+	switch cardType {
+	case domain.CardTypeBasic:
+		if cardSchema.Front == "" {
+			return nil, fmt.Errorf("%w: front", ErrResponseMissingField)
+		}
+		if cardSchema.Back == "" {
+			return nil, fmt.Errorf("%w: back", ErrResponseMissingField)
+		}
+		cardContent.Front = cardSchema.Front
+	case domain.CardTypeCloze:
+		if cardSchema.Text == "" {
+			return nil, fmt.Errorf("%w: text", ErrResponseMissingField)
+		}
+		cardContent.Text = cardSchema.Text
+	default:
+		return nil, fmt.Errorf("unknown card type %q", cardSchema.Type)
+	}
+
+	contentJSON, err := json.Marshal(cardContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal card content to JSON: %w", err)
+	}
+
+	card, err := domain.NewCardOfType(userID, memoID, cardType, contentJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create card: %w", err)
+	}
+	return card, nil
+}
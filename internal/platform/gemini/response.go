@@ -0,0 +1,107 @@
+package gemini
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/phrazzld/scry-api/internal/generation"
+)
+
+const (
+	// MaxResponseBytes caps the size of a response body accepted from the API.
+	// A memo yields at most a few dozen cards, far below this.
+	MaxResponseBytes = 1 << 20
+
+	// MaxResponseDepth caps how deeply a response may nest objects and arrays.
+	// A well-formed response nests four levels: the response object, its cards
+	// array, a card object and the card's tags array.
+	MaxResponseDepth = 16
+)
+
+// decodeResponse parses the JSON text of an API response into a ResponseSchema.
+//
+// It rejects responses larger than MaxResponseBytes or nested deeper than
+// MaxResponseDepth before decoding them, so a runaway or hostile response
+// cannot exhaust memory or the stack. Every error wraps
+// generation.ErrInvalidResponse together with one of ErrResponseTooLarge,
+// ErrResponseTooDeep, ErrResponseMalformed, ErrResponseWrongType or
+// ErrResponseMissingField. Cards are validated later by parseResponseToCards.
+//
+// Parameters:
+//   - data: The raw JSON text returned by the API
+//
+// Returns:
+//   - The decoded response
+//   - A categorized error if the response cannot be decoded
+func decodeResponse(data []byte) (*ResponseSchema, error) {
+	if len(data) > MaxResponseBytes {
+		return nil, invalidResponse(ErrResponseTooLarge,
+			fmt.Sprintf("%d bytes exceeds the limit of %d", len(data), MaxResponseBytes))
+	}
+
+	if err := checkResponseDepth(data); err != nil {
+		return nil, err
+	}
+
+	var response ResponseSchema
+	if err := json.Unmarshal(data, &response); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			field := typeErr.Field
+			if field == "" {
+				field = "response"
+			}
+			return nil, invalidResponse(ErrResponseWrongType,
+				fmt.Sprintf("%s: got JSON %s, want %s", field, typeErr.Value, typeErr.Type))
+		}
+		return nil, invalidResponse(ErrResponseMalformed, err.Error())
+	}
+
+	// A missing or null cards field decodes to nil; an empty array does not
+	// and is reported by parseResponseToCards
+	if response.Cards == nil {
+		return nil, invalidResponse(ErrResponseMissingField, "cards")
+	}
+
+	return &response, nil
+}
+
+// checkResponseDepth verifies that data is a single well-formed JSON value
+// nested no deeper than MaxResponseDepth. It streams tokens, so it stops at
+// the first level past the limit without building the nested value.
+func checkResponseDepth(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			if depth > 0 {
+				return invalidResponse(ErrResponseMalformed, "unexpected end of JSON input")
+			}
+			return nil
+		}
+		if err != nil {
+			return invalidResponse(ErrResponseMalformed, err.Error())
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+			if depth > MaxResponseDepth {
+				return invalidResponse(ErrResponseTooDeep,
+					fmt.Sprintf("exceeds the limit of %d levels", MaxResponseDepth))
+			}
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+}
+
+// invalidResponse wraps a response parsing category and its detail in
+// generation.ErrInvalidResponse
+func invalidResponse(category error, detail string) error {
+	return fmt.Errorf("%w: %w: %s", generation.ErrInvalidResponse, category, detail)
+}
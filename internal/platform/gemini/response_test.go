@@ -0,0 +1,169 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/generation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nested returns a response whose first card's tags are wrapped in depth arrays
+func nested(depth int) string {
+	return `{"cards":[{"front":"Q","back":"A","tags":` +
+		strings.Repeat("[", depth) + strings.Repeat("]", depth) + `}]}`
+}
+
+func TestDecodeResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		wantErr   error
+		wantCards int
+	}{
+		{name: "valid", input: `{"cards":[{"front":"Q","back":"A","tags":["go"]}]}`, wantCards: 1},
+		{name: "empty cards", input: `{"cards":[]}`},
+		{name: "unknown fields are ignored", input: `{"cards":[{"front":"Q","back":"A","extra":{"x":1}}]}`, wantCards: 1},
+		{name: "empty", input: ``, wantErr: ErrResponseMalformed},
+		{name: "truncated", input: `{"cards":[{"front":"Q","ba`, wantErr: ErrResponseMalformed},
+		{name: "truncated after array", input: `{"cards":[]`, wantErr: ErrResponseMalformed},
+		{name: "trailing data", input: `{"cards":[]} {}`, wantErr: ErrResponseMalformed},
+		{name: "markdown fence", input: "```json\n{\"cards\":[]}\n```", wantErr: ErrResponseMalformed},
+		{name: "deeply nested", input: nested(MaxResponseDepth), wantErr: ErrResponseTooDeep},
+		{name: "nested at the limit", input: nested(MaxResponseDepth - 4), wantErr: ErrResponseWrongType},
+		{name: "cards is an object", input: `{"cards":{"front":"Q"}}`, wantErr: ErrResponseWrongType},
+		{name: "front is a number", input: `{"cards":[{"front":1,"back":"A"}]}`, wantErr: ErrResponseWrongType},
+		{name: "tags is a string", input: `{"cards":[{"front":"Q","back":"A","tags":"go"}]}`, wantErr: ErrResponseWrongType},
+		{name: "top level is an array", input: `[{"front":"Q","back":"A"}]`, wantErr: ErrResponseWrongType},
+		{name: "missing cards", input: `{"flashcards":[]}`, wantErr: ErrResponseMissingField},
+		{name: "null cards", input: `{"cards":null}`, wantErr: ErrResponseMissingField},
+		{name: "null", input: `null`, wantErr: ErrResponseMissingField},
+		{
+			name:    "too large",
+			input:   `{"cards":[{"front":"` + strings.Repeat("x", MaxResponseBytes) + `","back":"A"}]}`,
+			wantErr: ErrResponseTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			response, err := decodeResponse([]byte(tt.input))
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, generation.ErrInvalidResponse)
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, response)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, response.Cards, tt.wantCards)
+		})
+	}
+}
+
+func TestParseResponseToCards_MissingFields(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tests := []struct {
+		name string
+		card CardSchema
+	}{
+		{name: "basic without front", card: CardSchema{Back: "A"}},
+		{name: "basic without back", card: CardSchema{Front: "Q"}},
+		{name: "cloze without text", card: CardSchema{Type: "cloze", Back: "extra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			response := &ResponseSchema{Cards: []CardSchema{{Front: "Q", Back: "A"}, tt.card}}
+			cards, err := parseResponseToCards(
+				context.Background(), logger, response, uuid.New(), uuid.New(), true)
+
+			assert.Nil(t, cards)
+			assert.ErrorIs(t, err, generation.ErrInvalidResponse)
+			assert.ErrorIs(t, err, ErrResponseMissingField)
+			assert.ErrorContains(t, err, "card 1")
+		})
+	}
+}
+
+// FuzzDecodeResponse checks that no response text makes parsing panic, and
+// that every rejected response is reported as a categorized invalid response.
+// Run it with: go test -fuzz=FuzzDecodeResponse ./internal/platform/gemini
+func FuzzDecodeResponse(f *testing.F) {
+	seeds := []string{
+		`{"cards":[{"front":"Q","back":"A","hint":"H","tags":["go"],"topic":"Go"}]}`,
+		`{"cards":[{"type":"cloze","text":"{{c1::Go}} is a language","back":""}]}`,
+		`{"cards":[{"type":"reverse","front":"Q","back":"A"}]}`,
+		`{"cards":[]}`,
+		`{"cards":null}`,
+		`{}`,
+		``,
+		`{"cards":[{"front":"Q","ba`,
+		`{"cards":[{"front":"\u00`,
+		`{"cards":[`,
+		`{"cards":{"front":1}}`,
+		`{"cards":[{"front":"Q","back":"A","tags":[1,2]}]}`,
+		`{"cards":[{"front":"\ud800","back":"A"}]}`,
+		`[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[`,
+		strings.Repeat(`{"a":`, 64) + `1` + strings.Repeat(`}`, 64),
+		nested(MaxResponseDepth),
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	categories := []error{
+		ErrResponseTooLarge,
+		ErrResponseTooDeep,
+		ErrResponseMalformed,
+		ErrResponseWrongType,
+		ErrResponseMissingField,
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		response, err := decodeResponse(data)
+		if err != nil {
+			if response != nil {
+				t.Fatalf("decodeResponse returned a response with error %v", err)
+			}
+			if !errors.Is(err, generation.ErrInvalidResponse) {
+				t.Fatalf("error %v does not wrap generation.ErrInvalidResponse", err)
+			}
+			matched := 0
+			for _, category := range categories {
+				if errors.Is(err, category) {
+					matched++
+				}
+			}
+			if matched != 1 {
+				t.Fatalf("error %v matches %d categories, want 1", err, matched)
+			}
+			return
+		}
+
+		if response.Cards == nil {
+			t.Fatal("decodeResponse accepted a response without cards")
+		}
+
+		_, err = parseResponseToCards(context.Background(), logger, response, uuid.New(), uuid.New(), true)
+		if err != nil && !errors.Is(err, generation.ErrInvalidResponse) {
+			t.Fatalf("parseResponseToCards error %v does not wrap generation.ErrInvalidResponse", err)
+		}
+	})
+}
@@ -4,6 +4,9 @@ package gemini
 // promptData represents the data passed to the prompt template
 type promptData struct {
 	MemoText string
+
+	// IncludeTopic asks the model to return a topic for each card
+	IncludeTopic bool
 }
 
 // ResponseSchema represents the expected structure of a card from the Gemini API
@@ -14,15 +17,27 @@ type ResponseSchema struct {
 
 // CardSchema represents a single flashcard in the API response
 type CardSchema struct {
+	// Type is "basic" (the default when empty) or "cloze". Prompt templates
+	// that ask for cloze cards must ask for this field.
+	Type string `json:"type,omitempty"`
+
 	// Front is the question or prompt side of the flashcard
 	Front string `json:"front"`
 
-	// Back is the answer side of the flashcard
+	// Back is the answer side of the flashcard; for cloze cards, optional
+	// extra information shown with the answer
 	Back string `json:"back"`
 
+	// Text is the sentence with {{c1::...}} cloze markers, for cloze cards only
+	Text string `json:"text,omitempty"`
+
 	// Hint is an optional hint to help the user recall the answer
 	Hint string `json:"hint,omitempty"`
 
 	// Tags are optional categories or labels for the flashcard
 	Tags []string `json:"tags,omitempty"`
+
+	// Topic is the subject area of the flashcard, requested when topic
+	// auto-tagging is enabled
+	Topic string `json:"topic,omitempty"`
 }
@@ -124,6 +124,21 @@ func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, loggerKey{}, logger)
 }
 
+// WithUserID adds the ID of the authenticated user to the logger in the context.
+// Unlike WithRequestID, it extends the context's existing logger, so fields
+// added earlier in the request, such as the trace ID, are kept.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - userID: The ID of the user making the request
+//
+// Returns:
+//   - context.Context: A new context containing the logger with user ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	logger := FromContext(ctx).With(slog.String("user_id", userID))
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
 // FromContext retrieves a logger from the context, or returns the default logger
 // if no logger is found in the context.
 //
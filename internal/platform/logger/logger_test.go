@@ -600,6 +600,44 @@ func TestWithRequestID(t *testing.T) {
 	}
 }
 
+// TestWithUserID verifies that WithUserID adds the user ID to the context's
+// logger while keeping the fields already on it.
+func TestWithUserID(t *testing.T) {
+	// Arrange: Setup logger and capture buffer
+	logBuf, cleanup := setupTestLogger(t, slog.LevelDebug)
+	defer cleanup()
+
+	ctxWithRequestID := logger.WithRequestID(context.Background(), "req-67890")
+
+	// Act: Add the user ID and log using the resulting context
+	ctxWithUserID := logger.WithUserID(ctxWithRequestID, "user-12345")
+	logger.FromContext(ctxWithUserID).Info("message with user id")
+
+	// Assert: Both fields are in the log entry
+	entry, err := parseLogEntry(logBuf.String())
+	if err != nil {
+		t.Fatalf("Failed to parse log entry: %v", err)
+	}
+	if entry["user_id"] != "user-12345" {
+		t.Errorf("Expected user ID to be %q, got %v", "user-12345", entry["user_id"])
+	}
+	if entry["request_id"] != "req-67890" {
+		t.Errorf("Expected request ID to be kept as %q, got %v", "req-67890", entry["request_id"])
+	}
+
+	// Assert: The parent context's logger is unchanged
+	logBuf.Reset()
+	logger.FromContext(ctxWithRequestID).Info("message without user id")
+
+	entry, err = parseLogEntry(logBuf.String())
+	if err != nil {
+		t.Fatalf("Failed to parse log entry: %v", err)
+	}
+	if _, hasUserID := entry["user_id"]; hasUserID {
+		t.Error("User ID should not be present in logs from the parent context")
+	}
+}
+
 // TestFromContext verifies that FromContext retrieves the correct logger:
 // - The specific logger if one exists in the context.
 // - The default logger if no logger is found in the context.
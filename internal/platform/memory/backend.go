@@ -0,0 +1,336 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// ErrSQLNotSupported is returned when SQL is executed against the *sql.DB of a
+// Backend. The in-memory stores never issue SQL; the handle only exists so that
+// services can start transactions with store.RunInTransaction.
+var ErrSQLNotSupported = errors.New("the in-memory store backend does not execute SQL")
+
+// statsKey identifies a user_card_stats entry
+type statsKey struct {
+	userID uuid.UUID
+	cardID uuid.UUID
+}
+
+// idempotencyKeyID identifies an idempotency key, which is unique per user
+type idempotencyKeyID struct {
+	userID uuid.UUID
+	key    string
+}
+
+// generationUsageKey identifies a user's generation count for a quota period
+type generationUsageKey struct {
+	userID uuid.UUID
+	period time.Time
+}
+
+// Backend holds the data shared by the in-memory stores. Stores created from
+// the same Backend see each other's writes, as stores sharing a database do,
+// and deleting an entity removes the entities that depend on it just as the
+// ON DELETE rules of the PostgreSQL schema do.
+//
+// A Backend is safe for concurrent use. Every store method is atomic, but
+// transactions started on DB() are not: their writes are visible immediately
+// and are not undone by a rollback.
+type Backend struct {
+	mu sync.RWMutex
+
+	users           map[uuid.UUID]*domain.User
+	memos           map[uuid.UUID]*domain.Memo
+	cards           map[uuid.UUID]*domain.Card
+	stats           map[statsKey]*domain.UserCardStats
+	reviewEvents    map[uuid.UUID]*domain.ReviewEvent
+	reviewLog       map[uuid.UUID]map[time.Time]int // New cards per user and day
+	decks           map[uuid.UUID]*domain.Deck
+	idempotencyKeys map[idempotencyKeyID]*domain.IdempotencyKey
+	tasks           map[uuid.UUID]*storedTask
+	generationUsage map[generationUsageKey]int
+
+	db *sql.DB
+}
+
+// NewBackend creates an empty Backend.
+func NewBackend() *Backend {
+	return &Backend{
+		users:           make(map[uuid.UUID]*domain.User),
+		memos:           make(map[uuid.UUID]*domain.Memo),
+		cards:           make(map[uuid.UUID]*domain.Card),
+		stats:           make(map[statsKey]*domain.UserCardStats),
+		reviewEvents:    make(map[uuid.UUID]*domain.ReviewEvent),
+		reviewLog:       make(map[uuid.UUID]map[time.Time]int),
+		decks:           make(map[uuid.UUID]*domain.Deck),
+		idempotencyKeys: make(map[idempotencyKeyID]*domain.IdempotencyKey),
+		tasks:           make(map[uuid.UUID]*storedTask),
+		generationUsage: make(map[generationUsageKey]int),
+		db:              sql.OpenDB(connector{}),
+	}
+}
+
+// DB returns a database handle for starting transactions with store.RunInTransaction.
+// Its transactions always commit and roll back successfully without doing
+// anything, and any SQL executed on it fails with ErrSQLNotSupported.
+func (b *Backend) DB() *sql.DB {
+	return b.db
+}
+
+// Close releases the handle returned by DB. The stored data is kept.
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+// deleteUserLocked removes a user and everything that belongs to them.
+// The caller must hold the write lock.
+func (b *Backend) deleteUserLocked(userID uuid.UUID) {
+	delete(b.users, userID)
+	for id, card := range b.cards {
+		if card.UserID == userID {
+			b.deleteCardLocked(id)
+		}
+	}
+	for id, memo := range b.memos {
+		if memo.UserID == userID {
+			b.deleteMemoLocked(id)
+		}
+	}
+	for key := range b.stats {
+		if key.userID == userID {
+			delete(b.stats, key)
+		}
+	}
+	for id, event := range b.reviewEvents {
+		if event.UserID == userID {
+			delete(b.reviewEvents, id)
+		}
+	}
+	for id, deck := range b.decks {
+		if deck.UserID == userID {
+			b.deleteDeckLocked(id)
+		}
+	}
+	for key := range b.idempotencyKeys {
+		if key.userID == userID {
+			delete(b.idempotencyKeys, key)
+		}
+	}
+	for key := range b.generationUsage {
+		if key.userID == userID {
+			delete(b.generationUsage, key)
+		}
+	}
+	delete(b.reviewLog, userID)
+}
+
+// deleteMemoLocked removes a memo together with its cards and the idempotency
+// keys that replay it. The caller must hold the write lock.
+func (b *Backend) deleteMemoLocked(memoID uuid.UUID) {
+	delete(b.memos, memoID)
+	for id, card := range b.cards {
+		if card.MemoID == memoID {
+			b.deleteCardLocked(id)
+		}
+	}
+	for key, idempotencyKey := range b.idempotencyKeys {
+		if idempotencyKey.MemoID == memoID {
+			delete(b.idempotencyKeys, key)
+		}
+	}
+}
+
+// deleteCardLocked removes a card together with its statistics and review
+// events. The caller must hold the write lock.
+func (b *Backend) deleteCardLocked(cardID uuid.UUID) {
+	delete(b.cards, cardID)
+	for key := range b.stats {
+		if key.cardID == cardID {
+			delete(b.stats, key)
+		}
+	}
+	for id, event := range b.reviewEvents {
+		if event.CardID == cardID {
+			delete(b.reviewEvents, id)
+		}
+	}
+}
+
+// deleteDeckLocked removes a deck, leaving its cards and memos without a deck.
+// The caller must hold the write lock.
+func (b *Backend) deleteDeckLocked(deckID uuid.UUID) {
+	delete(b.decks, deckID)
+	for _, card := range b.cards {
+		if card.DeckID != nil && *card.DeckID == deckID {
+			card.DeckID = nil
+		}
+	}
+	for _, memo := range b.memos {
+		if memo.DeckID != nil && *memo.DeckID == deckID {
+			memo.DeckID = nil
+		}
+	}
+}
+
+// Entities are copied on the way in and out so that callers can never modify
+// stored data except through a store method.
+
+func copyUser(u *domain.User) *domain.User {
+	c := *u
+	c.Password = ""
+	c.PasswordChangedAt = copyTime(u.PasswordChangedAt)
+	c.GenerationQuota = copyInt(u.GenerationQuota)
+	c.DisabledAt = copyTime(u.DisabledAt)
+	c.NewCardOverrides = domain.NewCardOverrides{
+		IntervalDays:   copyInt(u.NewCardOverrides.IntervalDays),
+		EaseFactor:     copyFloat(u.NewCardOverrides.EaseFactor),
+		DueImmediately: copyBool(u.NewCardOverrides.DueImmediately),
+	}
+	return &c
+}
+
+func copyMemo(m *domain.Memo) *domain.Memo {
+	c := *m
+	c.DeckID = copyUUID(m.DeckID)
+	return &c
+}
+
+func copyCard(card *domain.Card) *domain.Card {
+	c := *card
+	c.DeckID = copyUUID(card.DeckID)
+	c.Content = append(json.RawMessage(nil), card.Content...)
+	c.SupersededAt = copyTime(card.SupersededAt)
+	c.SuspendedAt = copyTime(card.SuspendedAt)
+	c.BuriedUntil = copyTime(card.BuriedUntil)
+	return &c
+}
+
+func copyStats(s *domain.UserCardStats) *domain.UserCardStats {
+	c := *s
+	// Record the phase stats without one are in, as the PostgreSQL store does
+	c.Phase = s.CurrentPhase()
+	return &c
+}
+
+func copyDeck(d *domain.Deck) *domain.Deck {
+	c := *d
+	if v := d.SRSSettings.NewCardsPerDay; v != nil {
+		n := *v
+		c.SRSSettings.NewCardsPerDay = &n
+	}
+	if v := d.SRSSettings.AgainReviewMinutes; v != nil {
+		n := *v
+		c.SRSSettings.AgainReviewMinutes = &n
+	}
+	if v := d.SRSSettings.InitialEaseFactor; v != nil {
+		f := *v
+		c.SRSSettings.InitialEaseFactor = &f
+	}
+	c.SRSSettings.LearningSteps = slices.Clone(d.SRSSettings.LearningSteps)
+	c.SRSSettings.NewCardIntervalDays = copyInt(d.SRSSettings.NewCardIntervalDays)
+	c.SRSSettings.NewCardsDueImmediately = copyBool(d.SRSSettings.NewCardsDueImmediately)
+	if v := d.SRSSettings.ReviewOrder; v != nil {
+		o := *v
+		c.SRSSettings.ReviewOrder = &o
+	}
+	return &c
+}
+
+func copyReviewEvent(e *domain.ReviewEvent) *domain.ReviewEvent {
+	c := *e
+	c.IntervalBefore = copyInt(e.IntervalBefore)
+	c.IntervalAfter = copyInt(e.IntervalAfter)
+	return &c
+}
+
+func copyInt(n *int) *int {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	return &c
+}
+
+func copyFloat(f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	return &c
+}
+
+func copyBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	c := *b
+	return &c
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func copyUUID(id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	c := *id
+	return &c
+}
+
+// The database/sql driver below backs Backend.DB. Transactions are accepted and
+// ignored; statements are refused.
+
+type connector struct{}
+
+func (connector) Connect(context.Context) (driver.Conn, error) {
+	return conn{}, nil
+}
+
+func (connector) Driver() driver.Driver {
+	return sqlDriver{}
+}
+
+type sqlDriver struct{}
+
+func (sqlDriver) Open(string) (driver.Conn, error) {
+	return conn{}, nil
+}
+
+type conn struct{}
+
+func (conn) Prepare(string) (driver.Stmt, error) {
+	return nil, ErrSQLNotSupported
+}
+
+func (conn) Close() error {
+	return nil
+}
+
+func (conn) Begin() (driver.Tx, error) {
+	return noopTx{}, nil
+}
+
+type noopTx struct{}
+
+func (noopTx) Commit() error {
+	return nil
+}
+
+func (noopTx) Rollback() error {
+	return nil
+}
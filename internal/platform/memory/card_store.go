@@ -0,0 +1,624 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure CardStore implements store.CardStore
+var _ store.CardStore = (*CardStore)(nil)
+
+// CardStore implements store.CardStore in memory.
+type CardStore struct {
+	backend *Backend
+}
+
+// NewCardStore creates a CardStore on the given backend.
+func NewCardStore(backend *Backend) *CardStore {
+	return &CardStore{backend: backend}
+}
+
+// CreateMultiple implements store.CardStore.CreateMultiple.
+// All cards are validated and their references checked before any is saved.
+// Returns store.ErrReferencedEntityMissing if a card's user, memo or deck does not exist.
+func (s *CardStore) CreateMultiple(ctx context.Context, cards []*domain.Card) error {
+	for _, card := range cards {
+		if err := card.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+		}
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	seen := make(map[uuid.UUID]bool, len(cards))
+	for _, card := range cards {
+		if _, exists := s.backend.cards[card.ID]; exists || seen[card.ID] {
+			return fmt.Errorf("failed to insert card: %w", store.ErrDuplicate)
+		}
+		seen[card.ID] = true
+		if _, ok := s.backend.users[card.UserID]; !ok {
+			return fmt.Errorf("%w: user with ID %s not found", store.ErrReferencedEntityMissing, card.UserID)
+		}
+		if _, ok := s.backend.memos[card.MemoID]; !ok {
+			return fmt.Errorf("%w: memo with ID %s not found", store.ErrReferencedEntityMissing, card.MemoID)
+		}
+		if card.DeckID != nil {
+			if _, ok := s.backend.decks[*card.DeckID]; !ok {
+				return fmt.Errorf("%w: deck with ID %s not found", store.ErrReferencedEntityMissing, card.DeckID)
+			}
+		}
+	}
+
+	for _, card := range cards {
+		// New cards always start at the initial version
+		card.Version = domain.InitialCardVersion
+		stored := copyCard(card)
+		stored.Type = card.Type.OrDefault()
+		s.backend.cards[card.ID] = stored
+	}
+	return nil
+}
+
+// GetByID implements store.CardStore.GetByID.
+// Returns store.ErrCardNotFound if the card does not exist.
+func (s *CardStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	card, ok := s.backend.cards[id]
+	if !ok {
+		return nil, store.ErrCardNotFound
+	}
+	return copyCard(card), nil
+}
+
+// GetByIDs implements store.CardStore.GetByIDs.
+// IDs that do not match a card are omitted from the result.
+func (s *CardStore) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Card, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	cards := make(map[uuid.UUID]*domain.Card, len(ids))
+	for _, id := range ids {
+		if card, ok := s.backend.cards[id]; ok {
+			cards[id] = copyCard(card)
+		}
+	}
+	return cards, nil
+}
+
+// UpdateContent implements store.CardStore.UpdateContent.
+// Returns store.ErrCardNotFound if the card does not exist and
+// store.ErrVersionConflict if it is no longer at expectedVersion.
+func (s *CardStore) UpdateContent(
+	ctx context.Context,
+	id uuid.UUID,
+	content []byte,
+	expectedVersion int,
+) (int, error) {
+	if !json.Valid(content) {
+		return 0, fmt.Errorf("%w: %v", store.ErrInvalidEntity, domain.ErrInvalidCardContent)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	card, ok := s.backend.cards[id]
+	if !ok {
+		return 0, store.ErrCardNotFound
+	}
+	if card.Version != expectedVersion {
+		return 0, store.ErrVersionConflict
+	}
+	card.Content = append(json.RawMessage(nil), content...)
+	card.UpdatedAt = time.Now().UTC()
+	card.Version++
+	return card.Version, nil
+}
+
+// Delete implements store.CardStore.Delete.
+// The card's statistics and review events are deleted with it.
+// Returns store.ErrCardNotFound if the card does not exist.
+func (s *CardStore) Delete(ctx context.Context, id uuid.UUID) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if _, ok := s.backend.cards[id]; !ok {
+		return store.ErrCardNotFound
+	}
+	s.backend.deleteCardLocked(id)
+	return nil
+}
+
+// GetNextReviewCard implements store.CardStore.GetNextReviewCard.
+// Returns store.ErrCardNotFound if none of the user's cards is due.
+func (s *CardStore) GetNextReviewCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	return s.nextDueCard(userID, nil, nil, order, nil)
+}
+
+// GetNextDueCard implements store.CardStore.GetNextDueCard.
+// Returns store.ErrCardNotFound if no matching card is due.
+func (s *CardStore) GetNextDueCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	return s.nextDueCard(userID, deckID, &newCards, order, nil)
+}
+
+// GetNextDueCardExcluding implements store.CardStore.GetNextDueCardExcluding.
+// Returns store.ErrCardNotFound if no other matching card is due.
+func (s *CardStore) GetNextDueCardExcluding(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
+) (*domain.Card, error) {
+	return s.nextDueCard(userID, deckID, &newCards, order, exclude)
+}
+
+// nextDueCard picks the user's next due card in the given order. When deckID is
+// not nil only cards in that deck are considered, and when newCards is not nil
+// only never-reviewed (true) or reviewed (false) cards are. Excluded cards are skipped.
+func (s *CardStore) nextDueCard(
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards *bool,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
+) (*domain.Card, error) {
+	order = order.OrDefault()
+	if !order.IsValid() {
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidReviewOrder, order)
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	now := time.Now()
+	type candidate struct {
+		card  *domain.Card
+		stats *domain.UserCardStats
+	}
+	var candidates []candidate
+	for key, stats := range s.backend.stats {
+		if key.userID != userID {
+			continue
+		}
+		card, ok := s.backend.cards[key.cardID]
+		if !ok || card.UserID != userID || !s.backend.isDueLocked(stats, now) || card.IsBuried(now) {
+			continue
+		}
+		if deckID != nil && (card.DeckID == nil || *card.DeckID != *deckID) {
+			continue
+		}
+		if newCards != nil && *newCards != (stats.ReviewCount == 0) {
+			continue
+		}
+		if slices.Contains(exclude, card.ID) {
+			continue
+		}
+		candidates = append(candidates, candidate{card: card, stats: stats})
+	}
+	if len(candidates) == 0 {
+		return nil, store.ErrCardNotFound
+	}
+
+	// Due cards part way through their learning steps come first in every order,
+	// as in the PostgreSQL store
+	learning := func(c candidate) bool {
+		return c.stats.CurrentPhase() != domain.CardPhaseReview && c.stats.ReviewCount > 0
+	}
+	if order == domain.ReviewOrderRandomDue {
+		if slices.ContainsFunc(candidates, learning) {
+			candidates = slices.DeleteFunc(candidates, func(c candidate) bool { return !learning(c) })
+		}
+		return copyCard(candidates[rand.IntN(len(candidates))].card), nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if learning(a) != learning(b) {
+			return learning(a)
+		}
+		if order == domain.ReviewOrderLowestEase && a.stats.EaseFactor != b.stats.EaseFactor {
+			return a.stats.EaseFactor < b.stats.EaseFactor
+		}
+		if !a.stats.NextReviewAt.Equal(b.stats.NextReviewAt) {
+			return a.stats.NextReviewAt.Before(b.stats.NextReviewAt)
+		}
+		return lessUUID(a.card.ID, b.card.ID)
+	})
+	return copyCard(candidates[0].card), nil
+}
+
+// SetDeck implements store.CardStore.SetDeck.
+// Returns store.ErrCardNotFound if the card does not exist and
+// store.ErrReferencedEntityMissing if the deck does not exist.
+func (s *CardStore) SetDeck(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	card, ok := s.backend.cards[id]
+	if !ok {
+		return store.ErrCardNotFound
+	}
+	if deckID != nil {
+		if _, ok := s.backend.decks[*deckID]; !ok {
+			return fmt.Errorf("%w: deck with ID %s not found", store.ErrReferencedEntityMissing, deckID)
+		}
+	}
+	card.DeckID = copyUUID(deckID)
+	card.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetMemo implements store.CardStore.SetMemo.
+// Returns store.ErrCardNotFound if the card does not exist and
+// store.ErrReferencedEntityMissing if the memo does not exist.
+func (s *CardStore) SetMemo(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	card, ok := s.backend.cards[id]
+	if !ok {
+		return store.ErrCardNotFound
+	}
+	if _, ok := s.backend.memos[memoID]; !ok {
+		return fmt.Errorf("%w: memo with ID %s not found", store.ErrReferencedEntityMissing, memoID)
+	}
+	card.MemoID = memoID
+	card.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetSuspended implements store.CardStore.SetSuspended.
+// Returns store.ErrCardNotFound if the card does not exist.
+func (s *CardStore) SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	card, ok := s.backend.cards[id]
+	if !ok {
+		return store.ErrCardNotFound
+	}
+	card.SuspendedAt = copyTime(suspendedAt)
+	card.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetBuriedUntil implements store.CardStore.SetBuriedUntil.
+// Returns store.ErrCardNotFound if the card does not exist.
+func (s *CardStore) SetBuriedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	card, ok := s.backend.cards[id]
+	if !ok {
+		return store.ErrCardNotFound
+	}
+	card.BuriedUntil = copyTime(until)
+	card.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SupersedeByMemo implements store.CardStore.SupersedeByMemo.
+// Returns the number of cards marked; cards already superseded are left unchanged.
+func (s *CardStore) SupersedeByMemo(ctx context.Context, memoID uuid.UUID, at time.Time) (int, error) {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	at = at.UTC()
+	count := 0
+	for _, card := range s.backend.cards {
+		if card.MemoID == memoID && card.SupersededAt == nil {
+			card.SupersededAt = copyTime(&at)
+			card.UpdatedAt = at
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeleteByMemo implements store.CardStore.DeleteByMemo.
+// The cards' statistics and review events are deleted with them.
+func (s *CardStore) DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error) {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	count := 0
+	for id, card := range s.backend.cards {
+		if card.MemoID == memoID {
+			s.backend.deleteCardLocked(id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ListByDeck implements store.CardStore.ListByDeck.
+// Returns store.ErrInvalidEntity if limit or offset are out of range.
+func (s *CardStore) ListByDeck(ctx context.Context, deckID uuid.UUID, limit, offset int) ([]*domain.Card, error) {
+	if limit <= 0 || offset < 0 {
+		return nil, fmt.Errorf("%w: invalid limit or offset", store.ErrInvalidEntity)
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	cards := []*domain.Card{}
+	for _, card := range s.backend.cards {
+		if card.DeckID != nil && *card.DeckID == deckID && !card.IsSuperseded() {
+			cards = append(cards, copyCard(card))
+		}
+	}
+	sortByCreation(cards)
+	return page(cards, limit, offset), nil
+}
+
+// ListByTag implements store.CardStore.ListByTag.
+// Returns store.ErrInvalidEntity if limit or offset are out of range.
+func (s *CardStore) ListByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	if limit <= 0 || offset < 0 {
+		return nil, fmt.Errorf("%w: invalid limit or offset", store.ErrInvalidEntity)
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	cards := []*domain.Card{}
+	for _, card := range s.backend.cards {
+		if card.UserID == userID && !card.IsSuperseded() && card.HasTag(tag) {
+			cards = append(cards, copyCard(card))
+		}
+	}
+	sortByCreation(cards)
+	return page(cards, limit, offset), nil
+}
+
+// ExistingFrontHashes implements store.CardStore.ExistingFrontHashes.
+func (s *CardStore) ExistingFrontHashes(
+	ctx context.Context,
+	userID uuid.UUID,
+	hashes [][32]byte,
+) (map[[32]byte]bool, error) {
+	existing := make(map[[32]byte]bool)
+	if len(hashes) == 0 {
+		return existing, nil
+	}
+
+	wanted := make(map[[32]byte]bool, len(hashes))
+	for _, hash := range hashes {
+		wanted[hash] = true
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	for _, card := range s.backend.cards {
+		if card.UserID != userID || card.IsSuperseded() {
+			continue
+		}
+		if hash, ok := card.FrontHash(); ok && wanted[hash] {
+			existing[hash] = true
+		}
+	}
+	return existing, nil
+}
+
+// GetRecentlyReviewed implements store.CardStore.GetRecentlyReviewed.
+// Returns store.ErrInvalidEntity if limit or offset are out of range.
+func (s *CardStore) GetRecentlyReviewed(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset int,
+) ([]*domain.ReviewedCard, error) {
+	if limit <= 0 || offset < 0 {
+		return nil, fmt.Errorf("%w: invalid limit or offset", store.ErrInvalidEntity)
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	// Find each card's most recent review by the user
+	latest := make(map[uuid.UUID]*domain.ReviewEvent)
+	for _, event := range s.backend.reviewEvents {
+		if event.UserID != userID {
+			continue
+		}
+		if current, ok := latest[event.CardID]; !ok || reviewedAfter(event, current) {
+			latest[event.CardID] = event
+		}
+	}
+
+	results := []*domain.ReviewedCard{}
+	for cardID, event := range latest {
+		card, ok := s.backend.cards[cardID]
+		if !ok || card.UserID != userID {
+			continue
+		}
+		lastReview := *event
+		results = append(results, &domain.ReviewedCard{Card: copyCard(card), LastReview: &lastReview})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if !a.LastReview.ReviewedAt.Equal(b.LastReview.ReviewedAt) {
+			return a.LastReview.ReviewedAt.After(b.LastReview.ReviewedAt)
+		}
+		return lessUUID(a.Card.ID, b.Card.ID)
+	})
+	return page(results, limit, offset), nil
+}
+
+// FindDuplicates implements store.CardStore.FindDuplicates.
+// Content is compared after normalizing its JSON, so key order and whitespace
+// do not matter. Content hashes are not comparable with other backends'.
+func (s *CardStore) FindDuplicates(ctx context.Context, userID uuid.UUID) ([]*domain.DuplicateCardGroup, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	byHash := make(map[string][]*domain.Card)
+	for _, card := range s.backend.cards {
+		if card.UserID != userID || card.IsSuperseded() {
+			continue
+		}
+		hash, err := contentHash(card.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash content of card %s: %w", card.ID, err)
+		}
+		byHash[hash] = append(byHash[hash], copyCard(card))
+	}
+
+	groups := []*domain.DuplicateCardGroup{}
+	for hash, cards := range byHash {
+		if len(cards) < 2 {
+			continue
+		}
+		sortByCreation(cards)
+		groups = append(groups, &domain.DuplicateCardGroup{ContentHash: hash, Cards: cards})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ContentHash < groups[j].ContentHash
+	})
+	return groups, nil
+}
+
+// ForEachWithStats implements store.CardStore.ForEachWithStats.
+// The matching cards are copied before fn is first called, so fn may use the
+// backend's other stores.
+func (s *CardStore) ForEachWithStats(
+	ctx context.Context,
+	userID uuid.UUID,
+	includeSuperseded bool,
+	fn func(*domain.CardWithStats) error,
+) error {
+	s.backend.mu.RLock()
+	var items []*domain.CardWithStats
+	for _, card := range s.backend.cards {
+		if card.UserID != userID || (card.IsSuperseded() && !includeSuperseded) {
+			continue
+		}
+		item := &domain.CardWithStats{Card: copyCard(card)}
+		if stats, ok := s.backend.stats[statsKey{userID: card.UserID, cardID: card.ID}]; ok {
+			item.Stats = copyStats(stats)
+		}
+		items = append(items, item)
+	}
+	s.backend.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		return createdBefore(items[i].Card, items[j].Card)
+	})
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountByUser implements store.CardStore.CountByUser.
+// Superseded cards are not counted.
+func (s *CardStore) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	count := 0
+	for _, card := range s.backend.cards {
+		if card.UserID == userID && !card.IsSuperseded() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// WithTx implements store.CardStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *CardStore) WithTx(tx *sql.Tx) store.CardStore {
+	return s
+}
+
+// DB implements store.CardStore.DB.
+// It returns the backend's handle, on which transactions do nothing.
+func (s *CardStore) DB() *sql.DB {
+	return s.backend.DB()
+}
+
+// isDueLocked reports whether the card of stats is due as of asOf, as defined by
+// domain.IsCardDue. The caller must hold the lock.
+func (b *Backend) isDueLocked(stats *domain.UserCardStats, asOf time.Time) bool {
+	card, ok := b.cards[stats.CardID]
+	return ok && domain.IsCardDue(card, stats, asOf)
+}
+
+// contentHash returns the MD5 hash of card content in a normalized JSON form.
+func contentHash(content json.RawMessage) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+	// Maps are marshaled with sorted keys and without insignificant whitespace
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	sum := md5.Sum(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// sortByCreation orders cards oldest first, breaking ties by ID.
+func sortByCreation(cards []*domain.Card) {
+	sort.Slice(cards, func(i, j int) bool {
+		return createdBefore(cards[i], cards[j])
+	})
+}
+
+// createdBefore reports whether card a sorts before card b by creation time, then ID.
+func createdBefore(a, b *domain.Card) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return lessUUID(a.ID, b.ID)
+}
+
+// reviewedAfter reports whether review event a is more recent than b, breaking
+// ties by ID as the PostgreSQL store does.
+func reviewedAfter(a, b *domain.ReviewEvent) bool {
+	if !a.ReviewedAt.Equal(b.ReviewedAt) {
+		return a.ReviewedAt.After(b.ReviewedAt)
+	}
+	return lessUUID(b.ID, a.ID)
+}
+
+// lessUUID orders UUIDs by their bytes, as PostgreSQL orders uuid values.
+func lessUUID(a, b uuid.UUID) bool {
+	return bytes.Compare(a[:], b[:]) < 0
+}
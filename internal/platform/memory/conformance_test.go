@@ -0,0 +1,240 @@
+package memory_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/store/storetest"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newMemoryStores is the storetest.Factory for the in-memory stores
+func newMemoryStores(t *testing.T) storetest.Stores {
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	tx, err := backend.DB().BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+
+	return storetest.Stores{
+		Users:        memory.NewUserStore(backend, bcrypt.MinCost),
+		Memos:        memory.NewMemoStore(backend),
+		Cards:        memory.NewCardStore(backend),
+		Stats:        memory.NewUserCardStatsStore(backend),
+		ReviewEvents: memory.NewReviewEventStore(backend),
+		Tx:           tx,
+	}
+}
+
+func TestCardStoreConformance(t *testing.T) {
+	storetest.RunCardStoreSuite(t, newMemoryStores)
+}
+
+func TestUserCardStatsStoreConformance(t *testing.T) {
+	storetest.RunUserCardStatsStoreSuite(t, newMemoryStores)
+}
+
+func TestReviewEventStoreConformance(t *testing.T) {
+	storetest.RunReviewEventStoreSuite(t, newMemoryStores)
+}
+
+// The tests below run the same scenarios against the in-memory stores and the
+// PostgreSQL stores for behavior the storetest suites do not cover yet.
+// The PostgreSQL runs are skipped unless DATABASE_URL is set.
+
+// forEachBackend runs fn once with fresh in-memory stores and once with
+// PostgreSQL stores in a transaction that is rolled back afterwards.
+func forEachBackend(t *testing.T, fn func(t *testing.T, ctx context.Context, s storetest.Stores)) {
+	t.Run("memory", func(t *testing.T) {
+		fn(t, context.Background(), newMemoryStores(t))
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		if !testutils.IsIntegrationTestEnvironment() {
+			t.Skip("Skipping integration test")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		db, err := testutils.GetTestDB()
+		require.NoError(t, err, "Failed to connect to test database")
+		t.Cleanup(func() { _ = db.Close() })
+
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = tx.Rollback() // Intentionally ignoring error as it's cleanup code
+		})
+
+		fn(t, ctx, storetest.Stores{
+			Users:        postgres.NewPostgresUserStore(tx, bcrypt.MinCost),
+			Memos:        postgres.NewPostgresMemoStore(tx, nil),
+			Cards:        postgres.NewPostgresCardStore(tx, nil),
+			Stats:        postgres.NewPostgresUserCardStatsStore(tx, nil),
+			ReviewEvents: postgres.NewPostgresReviewEventStore(tx, nil),
+			Tx:           tx,
+		})
+	})
+}
+
+func TestConformance_Memos(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, ctx context.Context, s storetest.Stores) {
+		user := storetest.MustCreateUser(ctx, t, s)
+		older := storetest.MustCreateMemo(ctx, t, s, user.ID)
+		newer := storetest.MustCreateMemo(ctx, t, s, user.ID)
+		newer.CreatedAt = older.CreatedAt.Add(time.Minute)
+		newer.UpdatedAt = newer.CreatedAt
+		require.NoError(t, s.Memos.Update(ctx, newer))
+
+		t.Run("get", func(t *testing.T) {
+			got, err := s.Memos.GetByID(ctx, older.ID)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, got.UserID)
+			assert.Equal(t, older.Text, got.Text)
+			assert.Equal(t, domain.MemoStatusPending, got.Status)
+
+			_, err = s.Memos.GetByID(ctx, uuid.New())
+			assert.ErrorIs(t, err, store.ErrMemoNotFound)
+		})
+
+		t.Run("update_status", func(t *testing.T) {
+			require.NoError(t, s.Memos.UpdateStatus(ctx, older.ID, domain.MemoStatusCompleted))
+			got, err := s.Memos.GetByID(ctx, older.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.MemoStatusCompleted, got.Status)
+
+			assert.ErrorIs(t, s.Memos.UpdateStatus(ctx, uuid.New(), domain.MemoStatusCompleted),
+				store.ErrMemoNotFound)
+			assert.ErrorIs(t, s.Memos.UpdateStatus(ctx, older.ID, "unknown"), store.ErrInvalidEntity)
+		})
+
+		t.Run("generation_duration_is_kept_to_the_millisecond", func(t *testing.T) {
+			newer.GenerationDuration = 1500*time.Millisecond + 300*time.Microsecond
+			require.NoError(t, s.Memos.Update(ctx, newer))
+			got, err := s.Memos.GetByID(ctx, newer.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 1500*time.Millisecond, got.GenerationDuration)
+		})
+
+		t.Run("find_by_status", func(t *testing.T) {
+			require.NoError(t, s.Memos.UpdateStatus(ctx, newer.ID, domain.MemoStatusCompleted))
+
+			memos, err := s.Memos.FindMemosByStatus(ctx, domain.MemoStatusCompleted, 100, 0)
+			require.NoError(t, err)
+			var ids []uuid.UUID
+			for _, memo := range memos {
+				if memo.UserID == user.ID {
+					ids = append(ids, memo.ID)
+				}
+			}
+			assert.Equal(t, []uuid.UUID{newer.ID, older.ID}, ids, "Memos are listed newest first")
+		})
+
+		// Runs last: PostgreSQL aborts the transaction on the foreign key violation
+		t.Run("create_for_missing_user", func(t *testing.T) {
+			memo, err := domain.NewMemo(uuid.New(), "Orphan")
+			require.NoError(t, err)
+			assert.ErrorIs(t, s.Memos.Create(ctx, memo), store.ErrReferencedEntityMissing)
+		})
+	})
+}
+
+func TestConformance_DeletingUserCascades(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, ctx context.Context, s storetest.Stores) {
+		user := storetest.MustCreateUser(ctx, t, s)
+		memo := storetest.MustCreateMemo(ctx, t, s, user.ID)
+		at := time.Now().UTC().Truncate(time.Microsecond)
+		card := storetest.MustCreateCard(ctx, t, s, memo, "cascade", at, at, 0)
+
+		require.NoError(t, s.Users.Delete(ctx, user.ID))
+
+		_, err := s.Memos.GetByID(ctx, memo.ID)
+		assert.ErrorIs(t, err, store.ErrMemoNotFound)
+		_, err = s.Cards.GetByID(ctx, card.ID)
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+		_, err = s.Stats.Get(ctx, user.ID, card.ID)
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+
+		assert.ErrorIs(t, s.Users.Delete(ctx, user.ID), store.ErrUserNotFound)
+	})
+}
+
+func TestConformance_UserList(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, ctx context.Context, s storetest.Stores) {
+		// A unique marker keeps users created by other tests out of the results
+		marker := uuid.NewString()[:8]
+		var emails []string
+		for _, name := range []string{"carol", "alice", "bob"} {
+			user, err := domain.NewUser(name+"+"+marker+"@example.com", "a-long-enough-password")
+			require.NoError(t, err)
+			require.NoError(t, s.Users.Create(ctx, user))
+			emails = append(emails, user.Email)
+		}
+
+		listed := func(query string, limit, offset int) []string {
+			users, err := s.Users.List(ctx, query, limit, offset)
+			require.NoError(t, err)
+			var got []string
+			for _, user := range users {
+				assert.NotEmpty(t, user.HashedPassword)
+				got = append(got, user.Email)
+			}
+			return got
+		}
+
+		t.Run("ordered_by_email", func(t *testing.T) {
+			assert.Equal(t, []string{emails[1], emails[2], emails[0]}, listed(marker, 10, 0))
+		})
+
+		t.Run("search_ignores_case", func(t *testing.T) {
+			assert.Equal(t, []string{emails[2]}, listed("BOB+"+strings.ToUpper(marker), 10, 0))
+		})
+
+		t.Run("paginates", func(t *testing.T) {
+			assert.Equal(t, []string{emails[2]}, listed(marker, 1, 1))
+			assert.Empty(t, listed(marker, 10, 3))
+		})
+
+		t.Run("wildcards_match_literally", func(t *testing.T) {
+			assert.Empty(t, listed(marker+"%", 10, 0))
+			assert.Empty(t, listed("_+"+marker, 10, 0))
+		})
+
+		t.Run("invalid_pagination", func(t *testing.T) {
+			_, err := s.Users.List(ctx, "", 0, 0)
+			assert.ErrorIs(t, err, store.ErrInvalidEntity)
+			_, err = s.Users.List(ctx, "", 10, -1)
+			assert.ErrorIs(t, err, store.ErrInvalidEntity)
+		})
+
+		t.Run("disabled_at_round_trip", func(t *testing.T) {
+			user, err := s.Users.GetByEmail(ctx, emails[0])
+			require.NoError(t, err)
+			disabledAt := time.Now().UTC().Truncate(time.Microsecond)
+			user.DisabledAt = &disabledAt
+			require.NoError(t, s.Users.Update(ctx, user))
+
+			got, err := s.Users.GetByID(ctx, user.ID)
+			require.NoError(t, err)
+			require.NotNil(t, got.DisabledAt)
+			assert.True(t, disabledAt.Equal(*got.DisabledAt))
+
+			got.DisabledAt = nil
+			require.NoError(t, s.Users.Update(ctx, got))
+			got, err = s.Users.GetByID(ctx, user.ID)
+			require.NoError(t, err)
+			assert.False(t, got.IsDisabled())
+		})
+	})
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure DeckStore implements store.DeckStore
+var _ store.DeckStore = (*DeckStore)(nil)
+
+// DeckStore implements store.DeckStore in memory.
+type DeckStore struct {
+	backend *Backend
+}
+
+// NewDeckStore creates a DeckStore on the given backend.
+func NewDeckStore(backend *Backend) *DeckStore {
+	return &DeckStore{backend: backend}
+}
+
+// Create implements store.DeckStore.Create.
+// Returns store.ErrDeckNameExists if the user already has a deck with the name
+// and store.ErrReferencedEntityMissing if the user does not exist.
+func (s *DeckStore) Create(ctx context.Context, deck *domain.Deck) error {
+	if err := deck.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if _, exists := s.backend.decks[deck.ID]; exists {
+		return fmt.Errorf("%w: deck with ID %s", store.ErrDuplicate, deck.ID)
+	}
+	if _, ok := s.backend.users[deck.UserID]; !ok {
+		return fmt.Errorf("%w: user with ID %s not found", store.ErrReferencedEntityMissing, deck.UserID)
+	}
+	if s.nameTakenLocked(deck.UserID, deck.Name, deck.ID) {
+		return store.ErrDeckNameExists
+	}
+	s.backend.decks[deck.ID] = copyDeck(deck)
+	return nil
+}
+
+// GetByID implements store.DeckStore.GetByID.
+// Returns store.ErrDeckNotFound if the deck does not exist.
+func (s *DeckStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Deck, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	deck, ok := s.backend.decks[id]
+	if !ok {
+		return nil, store.ErrDeckNotFound
+	}
+	return copyDeck(deck), nil
+}
+
+// GetByName implements store.DeckStore.GetByName.
+// Names are matched exactly.
+// Returns store.ErrDeckNotFound if the user has no deck with the name.
+func (s *DeckStore) GetByName(ctx context.Context, userID uuid.UUID, name string) (*domain.Deck, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	for _, deck := range s.backend.decks {
+		if deck.UserID == userID && deck.Name == name {
+			return copyDeck(deck), nil
+		}
+	}
+	return nil, store.ErrDeckNotFound
+}
+
+// ListByUser implements store.DeckStore.ListByUser.
+// Decks are ordered by name, then ID.
+func (s *DeckStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Deck, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	decks := make([]*domain.Deck, 0)
+	for _, deck := range s.backend.decks {
+		if deck.UserID == userID {
+			decks = append(decks, copyDeck(deck))
+		}
+	}
+	sort.Slice(decks, func(i, j int) bool {
+		if decks[i].Name != decks[j].Name {
+			return decks[i].Name < decks[j].Name
+		}
+		return lessUUID(decks[i].ID, decks[j].ID)
+	})
+	return decks, nil
+}
+
+// Update implements store.DeckStore.Update.
+// The deck's name, settings and updated_at are replaced.
+// Returns store.ErrDeckNotFound if the deck does not exist and
+// store.ErrDeckNameExists if another of the user's decks has the name.
+func (s *DeckStore) Update(ctx context.Context, deck *domain.Deck) error {
+	if err := deck.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	existing, ok := s.backend.decks[deck.ID]
+	if !ok {
+		return store.ErrDeckNotFound
+	}
+	if s.nameTakenLocked(existing.UserID, deck.Name, deck.ID) {
+		return store.ErrDeckNameExists
+	}
+	updated := copyDeck(deck)
+	updated.UserID = existing.UserID
+	updated.CreatedAt = existing.CreatedAt
+	s.backend.decks[deck.ID] = updated
+	return nil
+}
+
+// Delete implements store.DeckStore.Delete.
+// The deck's cards are kept and left without a deck.
+// Returns store.ErrDeckNotFound if the deck does not exist.
+func (s *DeckStore) Delete(ctx context.Context, id uuid.UUID) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if _, ok := s.backend.decks[id]; !ok {
+		return store.ErrDeckNotFound
+	}
+	s.backend.deleteDeckLocked(id)
+	return nil
+}
+
+// WithTx implements store.DeckStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *DeckStore) WithTx(tx *sql.Tx) store.DeckStore {
+	return s
+}
+
+// nameTakenLocked reports whether a deck other than id belongs to userID and has name.
+// The caller must hold the lock.
+func (s *DeckStore) nameTakenLocked(userID uuid.UUID, name string, id uuid.UUID) bool {
+	for _, deck := range s.backend.decks {
+		if deck.ID != id && deck.UserID == userID && deck.Name == name {
+			return true
+		}
+	}
+	return false
+}
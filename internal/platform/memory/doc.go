@@ -0,0 +1,19 @@
+// Package memory provides in-memory implementations of the data storage
+// interfaces defined in the internal/store and internal/task packages, so the
+// API can be run end-to-end without PostgreSQL for local development and demos.
+// It is selected with SCRY_STORE_BACKEND=memory.
+//
+// All stores are created from a shared Backend, which holds the data in maps
+// guarded by a single lock. The stores follow the same contracts as the
+// PostgreSQL stores: the same not-found, duplicate and missing-reference errors,
+// the same filtering and ordering, and the same cascading deletes.
+//
+// Known differences from the PostgreSQL stores:
+//   - Nothing is persisted; all data is lost when the process exits.
+//   - Transactions are accepted but not atomic: writes made inside a transaction
+//     are visible immediately and are not undone when it is rolled back.
+//   - GetForUpdate does not lock, so concurrent updates of the same statistics
+//     are last-writer-wins.
+//   - Duplicate card groups are keyed by a hash of a different JSON normalization,
+//     so content hashes do not match those reported by PostgreSQL.
+package memory
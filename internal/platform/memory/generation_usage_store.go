@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure GenerationUsageStore implements store.GenerationUsageStore
+var _ store.GenerationUsageStore = (*GenerationUsageStore)(nil)
+
+// GenerationUsageStore implements store.GenerationUsageStore in memory.
+type GenerationUsageStore struct {
+	backend *Backend
+}
+
+// NewGenerationUsageStore creates a GenerationUsageStore on the given backend.
+func NewGenerationUsageStore(backend *Backend) *GenerationUsageStore {
+	return &GenerationUsageStore{backend: backend}
+}
+
+// Reserve implements store.GenerationUsageStore.Reserve.
+// Returns store.ErrReferencedEntityMissing if the user does not exist.
+func (s *GenerationUsageStore) Reserve(
+	ctx context.Context,
+	userID uuid.UUID,
+	period time.Time,
+	limit int,
+) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if _, ok := s.backend.users[userID]; !ok {
+		return fmt.Errorf("%w: user with ID %s not found", store.ErrReferencedEntityMissing, userID)
+	}
+
+	key := generationUsageKey{userID: userID, period: period.UTC()}
+	if limit > 0 && s.backend.generationUsage[key] >= limit {
+		return store.ErrQuotaExceeded
+	}
+	s.backend.generationUsage[key]++
+	return nil
+}
+
+// Release implements store.GenerationUsageStore.Release.
+func (s *GenerationUsageStore) Release(ctx context.Context, userID uuid.UUID, period time.Time) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	key := generationUsageKey{userID: userID, period: period.UTC()}
+	if s.backend.generationUsage[key] > 0 {
+		s.backend.generationUsage[key]--
+	}
+	return nil
+}
+
+// Count implements store.GenerationUsageStore.Count.
+// Returns 0 for unknown users and periods without generations.
+func (s *GenerationUsageStore) Count(ctx context.Context, userID uuid.UUID, period time.Time) (int, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	return s.backend.generationUsage[generationUsageKey{userID: userID, period: period.UTC()}], nil
+}
+
+// WithTx implements store.GenerationUsageStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *GenerationUsageStore) WithTx(tx *sql.Tx) store.GenerationUsageStore {
+	return s
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure IdempotencyKeyStore implements store.IdempotencyKeyStore
+var _ store.IdempotencyKeyStore = (*IdempotencyKeyStore)(nil)
+
+// IdempotencyKeyStore implements store.IdempotencyKeyStore in memory.
+type IdempotencyKeyStore struct {
+	backend *Backend
+}
+
+// NewIdempotencyKeyStore creates an IdempotencyKeyStore on the given backend.
+func NewIdempotencyKeyStore(backend *Backend) *IdempotencyKeyStore {
+	return &IdempotencyKeyStore{backend: backend}
+}
+
+// Create implements store.IdempotencyKeyStore.Create.
+// Returns store.ErrDuplicate if the user has already used the key and
+// store.ErrReferencedEntityMissing if the user or memo does not exist.
+func (s *IdempotencyKeyStore) Create(ctx context.Context, key *domain.IdempotencyKey) error {
+	if err := key.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	id := idempotencyKeyID{userID: key.UserID, key: key.Key}
+	if _, exists := s.backend.idempotencyKeys[id]; exists {
+		return fmt.Errorf("failed to create idempotency key: %w", store.ErrDuplicate)
+	}
+	if _, ok := s.backend.users[key.UserID]; !ok {
+		return fmt.Errorf("%w: user with ID %s not found", store.ErrReferencedEntityMissing, key.UserID)
+	}
+	if _, ok := s.backend.memos[key.MemoID]; !ok {
+		return fmt.Errorf("%w: memo with ID %s not found", store.ErrReferencedEntityMissing, key.MemoID)
+	}
+	stored := *key
+	s.backend.idempotencyKeys[id] = &stored
+	return nil
+}
+
+// Get implements store.IdempotencyKeyStore.Get.
+// Returns store.ErrIdempotencyKeyNotFound if the user has not used the key.
+func (s *IdempotencyKeyStore) Get(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	stored, ok := s.backend.idempotencyKeys[idempotencyKeyID{userID: userID, key: key}]
+	if !ok {
+		return nil, store.ErrIdempotencyKeyNotFound
+	}
+	k := *stored
+	return &k, nil
+}
+
+// Delete implements store.IdempotencyKeyStore.Delete.
+// Deleting a key that does not exist is not an error.
+func (s *IdempotencyKeyStore) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	delete(s.backend.idempotencyKeys, idempotencyKeyID{userID: userID, key: key})
+	return nil
+}
+
+// WithTx implements store.IdempotencyKeyStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *IdempotencyKeyStore) WithTx(tx *sql.Tx) store.IdempotencyKeyStore {
+	return s
+}
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure MemoStore implements store.MemoStore
+var _ store.MemoStore = (*MemoStore)(nil)
+
+// MemoStore implements store.MemoStore in memory.
+type MemoStore struct {
+	backend *Backend
+}
+
+// NewMemoStore creates a MemoStore on the given backend.
+func NewMemoStore(backend *Backend) *MemoStore {
+	return &MemoStore{backend: backend}
+}
+
+// Create implements store.MemoStore.Create.
+// Returns store.ErrReferencedEntityMissing if the memo's user or deck does not exist.
+func (s *MemoStore) Create(ctx context.Context, memo *domain.Memo) error {
+	if err := memo.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if _, exists := s.backend.memos[memo.ID]; exists {
+		return fmt.Errorf("%w: memo with ID %s", store.ErrDuplicate, memo.ID)
+	}
+	if _, ok := s.backend.users[memo.UserID]; !ok {
+		return fmt.Errorf("%w: user with ID %s not found", store.ErrReferencedEntityMissing, memo.UserID)
+	}
+	if memo.DeckID != nil {
+		if _, ok := s.backend.decks[*memo.DeckID]; !ok {
+			return fmt.Errorf("%w: deck with ID %s not found", store.ErrReferencedEntityMissing, memo.DeckID)
+		}
+	}
+	s.backend.memos[memo.ID] = storedMemo(memo)
+	return nil
+}
+
+// GetByID implements store.MemoStore.GetByID.
+// Returns store.ErrMemoNotFound if the memo does not exist.
+func (s *MemoStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	memo, ok := s.backend.memos[id]
+	if !ok {
+		return nil, store.ErrMemoNotFound
+	}
+	return copyMemo(memo), nil
+}
+
+// Update implements store.MemoStore.Update.
+// The memo's text, status, updated_at and generation duration are replaced;
+// its deck is kept.
+// Returns store.ErrMemoNotFound if the memo does not exist.
+func (s *MemoStore) Update(ctx context.Context, memo *domain.Memo) error {
+	if err := memo.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	existing, ok := s.backend.memos[memo.ID]
+	if !ok {
+		return store.ErrMemoNotFound
+	}
+	updated := storedMemo(memo)
+	updated.UserID = existing.UserID
+	updated.CreatedAt = existing.CreatedAt
+	updated.DeckID = existing.DeckID
+	s.backend.memos[memo.ID] = updated
+	return nil
+}
+
+// UpdateStatus implements store.MemoStore.UpdateStatus.
+// Returns store.ErrMemoNotFound if the memo does not exist.
+func (s *MemoStore) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+	if !status.IsValid() {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, domain.ErrMemoStatusInvalid)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	memo, ok := s.backend.memos[id]
+	if !ok {
+		return store.ErrMemoNotFound
+	}
+	memo.Status = status
+	memo.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetDeck implements store.MemoStore.SetDeck.
+// Returns store.ErrMemoNotFound if the memo does not exist and
+// store.ErrReferencedEntityMissing if the deck does not exist.
+func (s *MemoStore) SetDeck(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	memo, ok := s.backend.memos[id]
+	if !ok {
+		return store.ErrMemoNotFound
+	}
+	if deckID != nil {
+		if _, ok := s.backend.decks[*deckID]; !ok {
+			return fmt.Errorf("%w: deck with ID %s not found", store.ErrReferencedEntityMissing, deckID)
+		}
+	}
+	memo.DeckID = copyUUID(deckID)
+	memo.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// FindMemosByStatus implements store.MemoStore.FindMemosByStatus.
+// Memos are returned newest first. A non-positive limit defaults to 10 and a
+// negative offset is treated as 0.
+func (s *MemoStore) FindMemosByStatus(
+	ctx context.Context,
+	status domain.MemoStatus,
+	limit, offset int,
+) ([]*domain.Memo, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	memos := []*domain.Memo{}
+	for _, memo := range s.backend.memos {
+		if memo.Status == status {
+			memos = append(memos, copyMemo(memo))
+		}
+	}
+	sort.Slice(memos, func(i, j int) bool {
+		return memos[i].CreatedAt.After(memos[j].CreatedAt)
+	})
+	return page(memos, limit, offset), nil
+}
+
+// WithTx implements store.MemoStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *MemoStore) WithTx(tx *sql.Tx) store.MemoStore {
+	return s
+}
+
+// storedMemo copies a memo for storage, keeping its generation duration to the
+// millisecond as the memos table does.
+func storedMemo(memo *domain.Memo) *domain.Memo {
+	stored := copyMemo(memo)
+	if stored.GenerationDuration < 0 {
+		stored.GenerationDuration = 0
+	}
+	stored.GenerationDuration = stored.GenerationDuration.Truncate(time.Millisecond)
+	return stored
+}
+
+// page returns the items of a sorted slice selected by limit and offset.
+func page[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
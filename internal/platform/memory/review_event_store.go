@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure ReviewEventStore implements store.ReviewEventStore
+var _ store.ReviewEventStore = (*ReviewEventStore)(nil)
+
+// ReviewEventStore implements store.ReviewEventStore in memory.
+type ReviewEventStore struct {
+	backend *Backend
+}
+
+// NewReviewEventStore creates a ReviewEventStore on the given backend.
+func NewReviewEventStore(backend *Backend) *ReviewEventStore {
+	return &ReviewEventStore{backend: backend}
+}
+
+// Create implements store.ReviewEventStore.Create.
+// Returns store.ErrReferencedEntityMissing if the user or card does not exist.
+func (s *ReviewEventStore) Create(ctx context.Context, event *domain.ReviewEvent) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if _, exists := s.backend.reviewEvents[event.ID]; exists {
+		return fmt.Errorf("%w: review event with ID %s", store.ErrDuplicate, event.ID)
+	}
+	if _, ok := s.backend.users[event.UserID]; !ok {
+		return fmt.Errorf("%w: user with ID %s not found", store.ErrReferencedEntityMissing, event.UserID)
+	}
+	if _, ok := s.backend.cards[event.CardID]; !ok {
+		return fmt.Errorf("%w: card with ID %s not found", store.ErrReferencedEntityMissing, event.CardID)
+	}
+	s.backend.reviewEvents[event.ID] = copyReviewEvent(event)
+	return nil
+}
+
+// ListByCard implements store.ReviewEventStore.ListByCard.
+func (s *ReviewEventStore) ListByCard(ctx context.Context, cardID uuid.UUID) ([]*domain.ReviewEvent, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	events := []*domain.ReviewEvent{}
+	for _, event := range s.backend.reviewEvents {
+		if event.CardID == cardID {
+			events = append(events, copyReviewEvent(event))
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.ReviewedAt.Equal(b.ReviewedAt) {
+			return a.ReviewedAt.Before(b.ReviewedAt)
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID.String() < b.ID.String()
+	})
+	return events, nil
+}
+
+// CountOutcomes implements store.ReviewEventStore.CountOutcomes.
+func (s *ReviewEventStore) CountOutcomes(
+	ctx context.Context,
+	userID uuid.UUID,
+	days int,
+) ([]domain.ReviewOutcomeCount, error) {
+	if days < 1 || days > domain.MaxRetentionDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d",
+			store.ErrInvalidEntity, domain.MaxRetentionDays)
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	counts := []domain.ReviewOutcomeCount{}
+	windowStart, ok := s.retentionWindowStartLocked(userID, days)
+	if !ok {
+		return counts, nil
+	}
+
+	type group struct {
+		maturity domain.CardMaturity
+		outcome  domain.ReviewOutcome
+	}
+	groups := make(map[group]int)
+	for _, event := range s.backend.reviewEvents {
+		if event.UserID != userID || event.ReviewedAt.Before(windowStart) {
+			continue
+		}
+		groups[group{domain.MaturityForInterval(event.IntervalBefore), event.Outcome}]++
+	}
+	for g, count := range groups {
+		counts = append(counts, domain.ReviewOutcomeCount{Maturity: g.maturity, Outcome: g.outcome, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Maturity != counts[j].Maturity {
+			return counts[i].Maturity < counts[j].Maturity
+		}
+		return counts[i].Outcome < counts[j].Outcome
+	})
+	return counts, nil
+}
+
+// CountByDay implements store.ReviewEventStore.CountByDay.
+func (s *ReviewEventStore) CountByDay(
+	ctx context.Context,
+	userID uuid.UUID,
+	days int,
+) ([]domain.ReviewDayCount, error) {
+	if days < 1 || days > domain.MaxRetentionDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d",
+			store.ErrInvalidEntity, domain.MaxRetentionDays)
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	counts := make([]domain.ReviewDayCount, 0, days)
+	windowStart, ok := s.retentionWindowStartLocked(userID, days)
+	if !ok {
+		return counts, nil
+	}
+
+	loc := s.backend.users[userID].Location()
+	first := calendarDay(windowStart, loc)
+	for i := 0; i < days; i++ {
+		counts = append(counts, domain.ReviewDayCount{Date: first.AddDate(0, 0, i)})
+	}
+	for _, event := range s.backend.reviewEvents {
+		if event.UserID != userID || event.ReviewedAt.Before(windowStart) {
+			continue
+		}
+		index := int(calendarDay(event.ReviewedAt, loc).Sub(first).Hours() / 24)
+		if index < days {
+			counts[index].Count++
+		}
+	}
+	return counts, nil
+}
+
+// retentionWindowStartLocked returns the start of the user's calendar day days-1
+// days before today, or false if the user does not exist.
+// The caller must hold the backend lock.
+func (s *ReviewEventStore) retentionWindowStartLocked(userID uuid.UUID, days int) (time.Time, bool) {
+	user, ok := s.backend.users[userID]
+	if !ok {
+		return time.Time{}, false
+	}
+	loc := user.Location()
+	year, month, day := time.Now().In(loc).Date()
+	return time.Date(year, month, day-(days-1), 0, 0, 0, 0, loc), true
+}
+
+// WithTx implements store.ReviewEventStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *ReviewEventStore) WithTx(tx *sql.Tx) store.ReviewEventStore {
+	return s
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure ReviewLogStore implements store.ReviewLogStore
+var _ store.ReviewLogStore = (*ReviewLogStore)(nil)
+
+// ReviewLogStore implements store.ReviewLogStore in memory.
+type ReviewLogStore struct {
+	backend *Backend
+}
+
+// NewReviewLogStore creates a ReviewLogStore on the given backend.
+func NewReviewLogStore(backend *Backend) *ReviewLogStore {
+	return &ReviewLogStore{backend: backend}
+}
+
+// RecordReview implements store.ReviewLogStore.RecordReview.
+// The day is taken in the user's timezone; reviews by unknown users are ignored.
+func (s *ReviewLogStore) RecordReview(ctx context.Context, userID uuid.UUID, reviewedAt time.Time) error {
+	s.record(userID, reviewedAt, 0)
+	return nil
+}
+
+// RecordNewCard implements store.ReviewLogStore.RecordNewCard.
+// The day is taken in the user's timezone; reviews by unknown users are ignored.
+func (s *ReviewLogStore) RecordNewCard(ctx context.Context, userID uuid.UUID, reviewedAt time.Time) error {
+	s.record(userID, reviewedAt, 1)
+	return nil
+}
+
+// CountNewCards implements store.ReviewLogStore.CountNewCards.
+// Returns 0 for unknown users and days without reviews.
+func (s *ReviewLogStore) CountNewCards(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	user, ok := s.backend.users[userID]
+	if !ok {
+		return 0, nil
+	}
+	return s.backend.reviewLog[userID][calendarDay(at, user.Location())], nil
+}
+
+// GetReviewDays implements store.ReviewLogStore.GetReviewDays.
+// Days are returned oldest first, as midnight UTC on each date.
+func (s *ReviewLogStore) GetReviewDays(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	days := []time.Time{}
+	for day := range s.backend.reviewLog[userID] {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+	return days, nil
+}
+
+// WithTx implements store.ReviewLogStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *ReviewLogStore) WithTx(tx *sql.Tx) store.ReviewLogStore {
+	return s
+}
+
+// record marks the user as having reviewed on the day of reviewedAt and adds
+// newCards to that day's count of new cards.
+func (s *ReviewLogStore) record(userID uuid.UUID, reviewedAt time.Time, newCards int) {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	user, ok := s.backend.users[userID]
+	if !ok {
+		return
+	}
+	if s.backend.reviewLog[userID] == nil {
+		s.backend.reviewLog[userID] = make(map[time.Time]int)
+	}
+	s.backend.reviewLog[userID][calendarDay(reviewedAt, user.Location())] += newCards
+}
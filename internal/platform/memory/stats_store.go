@@ -0,0 +1,229 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure UserCardStatsStore implements store.UserCardStatsStore
+var _ store.UserCardStatsStore = (*UserCardStatsStore)(nil)
+
+// UserCardStatsStore implements store.UserCardStatsStore in memory.
+type UserCardStatsStore struct {
+	backend *Backend
+}
+
+// NewUserCardStatsStore creates a UserCardStatsStore on the given backend.
+func NewUserCardStatsStore(backend *Backend) *UserCardStatsStore {
+	return &UserCardStatsStore{backend: backend}
+}
+
+// Create implements store.UserCardStatsStore.Create.
+// Returns store.ErrDuplicate if the entry already exists and
+// store.ErrReferencedEntityMissing if the user or card does not exist.
+func (s *UserCardStatsStore) Create(ctx context.Context, stats *domain.UserCardStats) error {
+	if err := stats.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	key := statsKey{userID: stats.UserID, cardID: stats.CardID}
+	if _, exists := s.backend.stats[key]; exists {
+		return fmt.Errorf(
+			"user card stats entry already exists for user_id=%s and card_id=%s: %w",
+			stats.UserID,
+			stats.CardID,
+			store.ErrDuplicate,
+		)
+	}
+	if _, ok := s.backend.users[stats.UserID]; !ok {
+		return fmt.Errorf("%w: user with ID %s not found", store.ErrReferencedEntityMissing, stats.UserID)
+	}
+	if _, ok := s.backend.cards[stats.CardID]; !ok {
+		return fmt.Errorf("%w: card with ID %s not found", store.ErrReferencedEntityMissing, stats.CardID)
+	}
+	s.backend.stats[key] = copyStats(stats)
+	return nil
+}
+
+// Get implements store.UserCardStatsStore.Get.
+// Returns store.ErrUserCardStatsNotFound if the entry does not exist.
+func (s *UserCardStatsStore) Get(ctx context.Context, userID, cardID uuid.UUID) (*domain.UserCardStats, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	stats, ok := s.backend.stats[statsKey{userID: userID, cardID: cardID}]
+	if !ok {
+		return nil, store.ErrUserCardStatsNotFound
+	}
+	return copyStats(stats), nil
+}
+
+// GetForUpdate implements store.UserCardStatsStore.GetForUpdate.
+// Rows cannot be locked in memory, so this is the same as Get.
+func (s *UserCardStatsStore) GetForUpdate(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) (*domain.UserCardStats, error) {
+	return s.Get(ctx, userID, cardID)
+}
+
+// Update implements store.UserCardStatsStore.Update.
+// It sets stats.UpdatedAt to the current time.
+// Returns store.ErrUserCardStatsNotFound if the entry does not exist.
+func (s *UserCardStatsStore) Update(ctx context.Context, stats *domain.UserCardStats) error {
+	if err := stats.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	key := statsKey{userID: stats.UserID, cardID: stats.CardID}
+	existing, ok := s.backend.stats[key]
+	if !ok {
+		return store.ErrUserCardStatsNotFound
+	}
+	stats.UpdatedAt = time.Now().UTC()
+	updated := copyStats(stats)
+	updated.CreatedAt = existing.CreatedAt
+	s.backend.stats[key] = updated
+	return nil
+}
+
+// UpdateMultiple implements store.UserCardStatsStore.UpdateMultiple.
+// Every entry is checked before any is written, so a missing entry leaves all unchanged.
+func (s *UserCardStatsStore) UpdateMultiple(ctx context.Context, stats []*domain.UserCardStats) error {
+	seen := make(map[statsKey]struct{}, len(stats))
+	for _, st := range stats {
+		if err := st.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+		}
+		key := statsKey{userID: st.UserID, cardID: st.CardID}
+		if _, dup := seen[key]; dup {
+			return fmt.Errorf("%w: stats for user_id=%s and card_id=%s given more than once",
+				store.ErrInvalidEntity, st.UserID, st.CardID)
+		}
+		seen[key] = struct{}{}
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	for key := range seen {
+		if _, ok := s.backend.stats[key]; !ok {
+			return fmt.Errorf("%w: not every entry exists for its user", store.ErrUserCardStatsNotFound)
+		}
+	}
+
+	updatedAt := time.Now().UTC()
+	for _, st := range stats {
+		key := statsKey{userID: st.UserID, cardID: st.CardID}
+		st.UpdatedAt = updatedAt
+		updated := copyStats(st)
+		updated.CreatedAt = s.backend.stats[key].CreatedAt
+		s.backend.stats[key] = updated
+	}
+	return nil
+}
+
+// Delete implements store.UserCardStatsStore.Delete.
+// Returns store.ErrUserCardStatsNotFound if the entry does not exist.
+func (s *UserCardStatsStore) Delete(ctx context.Context, userID, cardID uuid.UUID) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	key := statsKey{userID: userID, cardID: cardID}
+	if _, ok := s.backend.stats[key]; !ok {
+		return store.ErrUserCardStatsNotFound
+	}
+	delete(s.backend.stats, key)
+	return nil
+}
+
+// CountDue implements store.UserCardStatsStore.CountDue.
+func (s *UserCardStatsStore) CountDue(ctx context.Context, userID uuid.UUID, asOf time.Time) (int, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	count := 0
+	for key, stats := range s.backend.stats {
+		if key.userID == userID && s.backend.isDueLocked(stats, asOf) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetForecast implements store.UserCardStatsStore.GetForecast.
+// Days are calendar days in the user's timezone; an unknown user has no forecast.
+// Returns store.ErrInvalidEntity if days is out of range.
+func (s *UserCardStatsStore) GetForecast(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	days int,
+) ([]domain.ForecastBucket, error) {
+	if days < 1 || days > domain.MaxForecastDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d",
+			store.ErrInvalidEntity, domain.MaxForecastDays)
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	buckets := make([]domain.ForecastBucket, 0, days)
+	user, ok := s.backend.users[userID]
+	if !ok {
+		return buckets, nil
+	}
+
+	loc := user.Location()
+	today := calendarDay(time.Now(), loc)
+	for i := 0; i < days; i++ {
+		buckets = append(buckets, domain.ForecastBucket{Date: today.AddDate(0, 0, i)})
+	}
+
+	// Count every card due before the day after the last bucket, putting overdue
+	// cards in today's bucket
+	windowEnd := today.AddDate(0, 0, days)
+	windowEnd = time.Date(windowEnd.Year(), windowEnd.Month(), windowEnd.Day(), 0, 0, 0, 0, loc).
+		Add(-domain.DueResolution)
+	for key, stats := range s.backend.stats {
+		if key.userID != userID || !s.backend.isDueLocked(stats, windowEnd) {
+			continue
+		}
+		if deckID != nil {
+			card := s.backend.cards[stats.CardID]
+			if card.DeckID == nil || *card.DeckID != *deckID {
+				continue
+			}
+		}
+		index := int(calendarDay(stats.NextReviewAt, loc).Sub(today).Hours() / 24)
+		if index < 0 {
+			index = 0
+		}
+		buckets[index].Count++
+	}
+	return buckets, nil
+}
+
+// WithTx implements store.UserCardStatsStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *UserCardStatsStore) WithTx(tx *sql.Tx) store.UserCardStatsStore {
+	return s
+}
+
+// calendarDay returns the calendar day of t in loc, as midnight UTC on that date.
+func calendarDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,233 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/task"
+)
+
+// Compile-time check to ensure TaskStore implements task.TaskStore
+var _ task.TaskStore = (*TaskStore)(nil)
+
+// storedTask is the saved state of a task
+type storedTask struct {
+	id           uuid.UUID
+	taskType     string
+	payload      []byte
+	status       task.TaskStatus
+	priority     int
+	runAt        time.Time
+	errorMessage string
+	createdAt    time.Time
+	updatedAt    time.Time
+
+	// heartbeatAt is when the task was last reported alive, zero unless processing
+	heartbeatAt time.Time
+}
+
+// ID implements task.Task.ID
+func (t *storedTask) ID() uuid.UUID {
+	return t.id
+}
+
+// Type implements task.Task.Type
+func (t *storedTask) Type() string {
+	return t.taskType
+}
+
+// Payload implements task.Task.Payload
+func (t *storedTask) Payload() []byte {
+	return t.payload
+}
+
+// Status implements task.Task.Status
+func (t *storedTask) Status() task.TaskStatus {
+	return t.status
+}
+
+// Priority implements task.Prioritized.Priority
+func (t *storedTask) Priority() int {
+	return t.priority
+}
+
+// RunAt implements task.Scheduled.RunAt
+func (t *storedTask) RunAt() time.Time {
+	return t.runAt
+}
+
+// Execute implements task.Task.Execute.
+// Recovered tasks carry only their saved state; the task runner rebuilds them
+// from their type and payload before executing them.
+func (t *storedTask) Execute(ctx context.Context) error {
+	return errors.New("no execution function defined for recovered task")
+}
+
+// TaskStore implements task.TaskStore in memory.
+type TaskStore struct {
+	backend *Backend
+}
+
+// NewTaskStore creates a TaskStore on the given backend.
+func NewTaskStore(backend *Backend) *TaskStore {
+	return &TaskStore{backend: backend}
+}
+
+// SaveTask implements task.TaskStore.SaveTask
+func (s *TaskStore) SaveTask(ctx context.Context, t task.Task) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if memoID := task.PayloadMemoID(t.Payload()); memoID != "" {
+		for _, existing := range s.backend.tasks {
+			if existing.taskType == t.Type() && isActive(existing.status) &&
+				task.PayloadMemoID(existing.payload) == memoID {
+				return task.ErrTaskAlreadyActive
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	runAt := task.RunAtOf(t)
+	if runAt.IsZero() {
+		runAt = now
+	}
+	s.backend.tasks[t.ID()] = &storedTask{
+		id:        t.ID(),
+		taskType:  t.Type(),
+		payload:   append([]byte(nil), t.Payload()...),
+		status:    t.Status(),
+		priority:  task.PriorityOf(t),
+		runAt:     runAt,
+		createdAt: now,
+		updatedAt: now,
+	}
+	return nil
+}
+
+// UpdateTaskStatus implements task.TaskStore.UpdateTaskStatus.
+// Updating a task that was never saved is a no-op. Moving a task to processing
+// records a heartbeat; any other status clears it.
+func (s *TaskStore) UpdateTaskStatus(
+	ctx context.Context,
+	taskID uuid.UUID,
+	status task.TaskStatus,
+	errorMsg string,
+) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	t, ok := s.backend.tasks[taskID]
+	if !ok {
+		return nil
+	}
+	t.status = status
+	t.errorMessage = errorMsg
+	t.updatedAt = time.Now().UTC()
+	t.heartbeatAt = time.Time{}
+	if status == task.TaskStatusProcessing {
+		t.heartbeatAt = t.updatedAt
+	}
+	return nil
+}
+
+// Heartbeat implements task.TaskStore.Heartbeat
+func (s *TaskStore) Heartbeat(ctx context.Context, taskID uuid.UUID) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if t, ok := s.backend.tasks[taskID]; ok && t.status == task.TaskStatusProcessing {
+		t.heartbeatAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// GetPendingTasks implements task.TaskStore.GetPendingTasks
+func (s *TaskStore) GetPendingTasks(ctx context.Context) ([]task.Task, error) {
+	return s.tasksByStatus(task.TaskStatusPending, 0), nil
+}
+
+// GetProcessingTasks implements task.TaskStore.GetProcessingTasks
+func (s *TaskStore) GetProcessingTasks(ctx context.Context, olderThan time.Duration) ([]task.Task, error) {
+	return s.tasksByStatus(task.TaskStatusProcessing, olderThan), nil
+}
+
+// ReclaimTask implements task.TaskStore.ReclaimTask
+func (s *TaskStore) ReclaimTask(
+	ctx context.Context,
+	taskID uuid.UUID,
+	olderThan time.Duration,
+	errorMsg string,
+) (bool, error) {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	t, ok := s.backend.tasks[taskID]
+	if !ok || t.status != task.TaskStatusProcessing {
+		return false, nil
+	}
+	now := time.Now().UTC()
+	if olderThan > 0 && !t.lastSeen().Before(now.Add(-olderThan)) {
+		return false, nil
+	}
+
+	t.status = task.TaskStatusPending
+	t.errorMessage = errorMsg
+	t.updatedAt = now
+	t.heartbeatAt = time.Time{}
+	return true, nil
+}
+
+// WithTx implements task.TaskStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *TaskStore) WithTx(tx *sql.Tx) task.TaskStore {
+	return s
+}
+
+// tasksByStatus returns copies of the tasks with the given status, highest
+// priority first and oldest first within a priority.
+// If olderThan is positive, only tasks last heard from longer ago than that are
+// returned: by their heartbeat if they have one, otherwise by their last update.
+func (s *TaskStore) tasksByStatus(status task.TaskStatus, olderThan time.Duration) []task.Task {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	cutoff := time.Now().UTC().Add(-olderThan)
+	var matching []*storedTask
+	for _, t := range s.backend.tasks {
+		if t.status != status || (olderThan > 0 && !t.lastSeen().Before(cutoff)) {
+			continue
+		}
+		c := *t
+		matching = append(matching, &c)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].priority != matching[j].priority {
+			return matching[i].priority > matching[j].priority
+		}
+		return matching[i].createdAt.Before(matching[j].createdAt)
+	})
+
+	tasks := make([]task.Task, 0, len(matching))
+	for _, t := range matching {
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+// lastSeen is when the task was last known to be alive
+func (t *storedTask) lastSeen() time.Time {
+	if !t.heartbeatAt.IsZero() {
+		return t.heartbeatAt
+	}
+	return t.updatedAt
+}
+
+// isActive reports whether a task with the given status has yet to finish
+func isActive(status task.TaskStatus) bool {
+	return status == task.TaskStatusPending || status == task.TaskStatusProcessing
+}
@@ -0,0 +1,243 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Compile-time check to ensure UserStore implements store.UserStore
+var _ store.UserStore = (*UserStore)(nil)
+
+// UserStore implements store.UserStore in memory.
+type UserStore struct {
+	backend    *Backend
+	bcryptCost int                  // Cost parameter for bcrypt password hashing
+	hasher     store.PasswordHasher // Optional; overrides bcrypt hashing when set
+}
+
+// NewUserStore creates a UserStore on the given backend.
+// Passwords are hashed with bcrypt at bcryptCost unless a hasher is set with
+// WithPasswordHasher; an out-of-range cost uses bcrypt.DefaultCost.
+func NewUserStore(backend *Backend, bcryptCost int) *UserStore {
+	if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+		bcryptCost = bcrypt.DefaultCost
+	}
+	return &UserStore{backend: backend, bcryptCost: bcryptCost}
+}
+
+// WithPasswordHasher makes the store hash new passwords with hasher instead of
+// bcrypt at the configured cost. It returns the store for chaining.
+func (s *UserStore) WithPasswordHasher(hasher store.PasswordHasher) *UserStore {
+	s.hasher = hasher
+	return s
+}
+
+// hashPassword hashes a plaintext password with the configured hasher, or with
+// bcrypt if none is set.
+func (s *UserStore) hashPassword(password string) (string, error) {
+	if s.hasher != nil {
+		return s.hasher.Hash(password)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), s.bcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedPassword), nil
+}
+
+// Create implements store.UserStore.Create.
+// A plaintext password is validated, hashed and cleared from user.
+// Returns store.ErrEmailExists if the email is already registered.
+func (s *UserStore) Create(ctx context.Context, user *domain.User) error {
+	if user.Password != "" {
+		if err := domain.ValidatePassword(user.Password); err != nil {
+			return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+		}
+		hashedPassword, err := s.hashPassword(user.Password)
+		if err != nil {
+			return fmt.Errorf("%w: password hashing failed", store.ErrInvalidEntity)
+		}
+		user.HashedPassword = hashedPassword
+		user.Password = ""
+	}
+	if user.Role == "" {
+		user.Role = domain.UserRoleUser
+	}
+	if user.Timezone == "" {
+		user.Timezone = domain.DefaultUserTimezone
+	}
+	if err := user.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if _, exists := s.backend.users[user.ID]; exists {
+		return fmt.Errorf("%w: user with ID %s", store.ErrDuplicate, user.ID)
+	}
+	if s.emailTakenLocked(user.Email, user.ID) {
+		return store.ErrEmailExists
+	}
+	s.backend.users[user.ID] = copyUser(user)
+	return nil
+}
+
+// GetByID implements store.UserStore.GetByID.
+// Returns store.ErrUserNotFound if the user does not exist.
+func (s *UserStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	user, ok := s.backend.users[id]
+	if !ok {
+		return nil, store.ErrUserNotFound
+	}
+	return copyUser(user), nil
+}
+
+// GetByEmail implements store.UserStore.GetByEmail.
+// Emails are matched case-insensitively.
+// Returns store.ErrUserNotFound if no user has the email.
+func (s *UserStore) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	for _, user := range s.backend.users {
+		if strings.EqualFold(user.Email, email) {
+			return copyUser(user), nil
+		}
+	}
+	return nil, store.ErrUserNotFound
+}
+
+// List implements store.UserStore.List.
+// Users are ordered by email; emailQuery filters them to emails containing it, ignoring case.
+// Returns store.ErrInvalidEntity if limit or offset are out of range.
+func (s *UserStore) List(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error) {
+	if limit <= 0 || offset < 0 {
+		return nil, fmt.Errorf("%w: invalid limit or offset", store.ErrInvalidEntity)
+	}
+
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	query := strings.ToLower(emailQuery)
+	users := []*domain.User{}
+	for _, user := range s.backend.users {
+		if strings.Contains(strings.ToLower(user.Email), query) {
+			users = append(users, copyUser(user))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Email != users[j].Email {
+			return users[i].Email < users[j].Email
+		}
+		return users[i].ID.String() < users[j].ID.String()
+	})
+	return page(users, limit, offset), nil
+}
+
+// Update implements store.UserStore.Update.
+// A plaintext password replaces the stored hash; otherwise HashedPassword is kept.
+// Returns store.ErrUserNotFound if the user does not exist and
+// store.ErrEmailExists if another user has the email.
+func (s *UserStore) Update(ctx context.Context, user *domain.User) error {
+	user.UpdatedAt = time.Now().UTC()
+
+	if user.Password != "" {
+		if err := domain.ValidatePassword(user.Password); err != nil {
+			return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+		}
+		hashedPassword, err := s.hashPassword(user.Password)
+		if err != nil {
+			return fmt.Errorf("%w: password hashing failed", store.ErrInvalidEntity)
+		}
+		user.Password = ""
+		user.HashedPassword = hashedPassword
+	} else if user.HashedPassword == "" {
+		return fmt.Errorf("%w: missing hashed password", store.ErrInvalidEntity)
+	}
+	if user.Role == "" {
+		user.Role = domain.UserRoleUser
+	}
+	if user.Timezone == "" {
+		user.Timezone = domain.DefaultUserTimezone
+	}
+	if err := user.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	existing, ok := s.backend.users[user.ID]
+	if !ok {
+		return store.ErrUserNotFound
+	}
+	if s.emailTakenLocked(user.Email, user.ID) {
+		return store.ErrEmailExists
+	}
+	updated := copyUser(user)
+	updated.CreatedAt = existing.CreatedAt
+	s.backend.users[user.ID] = updated
+	return nil
+}
+
+// UpdatePasswordHash implements store.UserStore.UpdatePasswordHash.
+func (s *UserStore) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	user, ok := s.backend.users[id]
+	if !ok {
+		return store.ErrUserNotFound
+	}
+	if user.HashedPassword != oldHash {
+		return store.ErrVersionConflict
+	}
+	user.HashedPassword = newHash
+	user.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Delete implements store.UserStore.Delete.
+// The user's memos, cards, statistics, decks and review history are deleted with them.
+// Returns store.ErrUserNotFound if the user does not exist.
+func (s *UserStore) Delete(ctx context.Context, id uuid.UUID) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+
+	if _, ok := s.backend.users[id]; !ok {
+		return store.ErrUserNotFound
+	}
+	s.backend.deleteUserLocked(id)
+	return nil
+}
+
+// WithTx implements store.UserStore.WithTx.
+// In-memory stores have no transactions of their own, so the store itself is returned.
+func (s *UserStore) WithTx(tx *sql.Tx) store.UserStore {
+	return s
+}
+
+// emailTakenLocked reports whether a user other than id has exactly this email,
+// matching the case-sensitive unique constraint on users.email.
+// The caller must hold the lock.
+func (s *UserStore) emailTakenLocked(email string, id uuid.UUID) bool {
+	for _, user := range s.backend.users {
+		if user.ID != id && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
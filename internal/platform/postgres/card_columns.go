@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// cardColumn describes one column of the cards table: how a row is scanned
+// into a domain.Card and what is written on insert.
+type cardColumn struct {
+	name string
+
+	// dest returns where the column is scanned into. Columns that are written
+	// but never read back have no dest.
+	dest func(card *domain.Card) any
+
+	// insert returns the value written on insert. Columns left to their
+	// database default have no insert.
+	insert func(card *domain.Card) any
+}
+
+// cardColumns lists the columns of the cards table that the card store reads
+// and writes. Selects, scans and inserts are all built from this list, so a
+// new column is added here and nowhere else.
+var cardColumns = []cardColumn{
+	{
+		name:   "id",
+		dest:   func(c *domain.Card) any { return &c.ID },
+		insert: func(c *domain.Card) any { return c.ID },
+	},
+	{
+		name:   "user_id",
+		dest:   func(c *domain.Card) any { return &c.UserID },
+		insert: func(c *domain.Card) any { return c.UserID },
+	},
+	{
+		name:   "memo_id",
+		dest:   func(c *domain.Card) any { return &c.MemoID },
+		insert: func(c *domain.Card) any { return c.MemoID },
+	},
+	{
+		name:   "deck_id",
+		dest:   func(c *domain.Card) any { return &c.DeckID },
+		insert: func(c *domain.Card) any { return c.DeckID },
+	},
+	{
+		name:   "card_type",
+		dest:   func(c *domain.Card) any { return &c.Type },
+		insert: func(c *domain.Card) any { return c.Type.OrDefault() },
+	},
+	{
+		name:   "content",
+		dest:   func(c *domain.Card) any { return &c.Content },
+		insert: func(c *domain.Card) any { return c.Content },
+	},
+	{
+		name:   "created_at",
+		dest:   func(c *domain.Card) any { return &c.CreatedAt },
+		insert: func(c *domain.Card) any { return c.CreatedAt },
+	},
+	{
+		name:   "updated_at",
+		dest:   func(c *domain.Card) any { return &c.UpdatedAt },
+		insert: func(c *domain.Card) any { return c.UpdatedAt },
+	},
+	{
+		// New cards start at the column's default version
+		name: "version",
+		dest: func(c *domain.Card) any { return &c.Version },
+	},
+	{
+		name: "superseded_at",
+		dest: func(c *domain.Card) any { return &c.SupersededAt },
+	},
+	{
+		name: "suspended_at",
+		dest: func(c *domain.Card) any { return &c.SuspendedAt },
+	},
+	{
+		name: "buried_until",
+		dest: func(c *domain.Card) any { return &c.BuriedUntil },
+	},
+	{
+		// Kept in step with content for duplicate detection; never read back
+		name:   "front_hash",
+		insert: func(c *domain.Card) any { return frontHashParam(c.Content) },
+	},
+}
+
+// cardSelectList is the comma-separated list of card columns scanned by
+// cardScanDest, each qualified by alias if it is not empty.
+func cardSelectList(alias string) string {
+	names := make([]string, 0, len(cardColumns))
+	for _, col := range cardColumns {
+		if col.dest == nil {
+			continue
+		}
+		if alias != "" {
+			names = append(names, alias+"."+col.name)
+		} else {
+			names = append(names, col.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// cardScanDest returns the scan destinations for the columns of
+// cardSelectList, in the same order. Queries selecting further columns after
+// the card append their own destinations.
+func cardScanDest(card *domain.Card) []any {
+	dest := make([]any, 0, len(cardColumns))
+	for _, col := range cardColumns {
+		if col.dest != nil {
+			dest = append(dest, col.dest(card))
+		}
+	}
+	return dest
+}
+
+// cardInsertQuery is the statement inserting one card, with cardInsertArgs as
+// its arguments
+var cardInsertQuery = buildCardInsertQuery()
+
+// buildCardInsertQuery builds the insert statement from cardColumns
+func buildCardInsertQuery() string {
+	var names, params []string
+	for _, col := range cardColumns {
+		if col.insert == nil {
+			continue
+		}
+		names = append(names, col.name)
+		params = append(params, "$"+strconv.Itoa(len(params)+1))
+	}
+	return "INSERT INTO cards (" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")"
+}
+
+// cardInsertArgs returns the arguments of cardInsertQuery for card
+func cardInsertArgs(card *domain.Card) []any {
+	args := make([]any, 0, len(cardColumns))
+	for _, col := range cardColumns {
+		if col.insert != nil {
+			args = append(args, col.insert(card))
+		}
+	}
+	return args
+}
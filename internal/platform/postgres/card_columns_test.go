@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardColumns_SelectMatchesScan(t *testing.T) {
+	t.Parallel()
+
+	var card domain.Card
+	assert.Len(t, cardScanDest(&card), len(strings.Split(cardSelectList(""), ", ")))
+	assert.Equal(t,
+		"c.id, c.user_id, c.memo_id, c.deck_id, c.card_type, c.content, c.created_at, c.updated_at, c.version, "+
+			"c.superseded_at, c.suspended_at, c.buried_until",
+		cardSelectList("c"))
+}
+
+func TestCardColumns_InsertMatchesArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"INSERT INTO cards (id, user_id, memo_id, deck_id, card_type, content, created_at, updated_at, front_hash) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		cardInsertQuery)
+
+	now := time.Now().UTC()
+	card := &domain.Card{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		MemoID:    uuid.New(),
+		Content:   json.RawMessage(`{"front":"Q","back":"A"}`),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	args := cardInsertArgs(card)
+	require.Len(t, args, 9)
+	assert.Equal(t, card.ID, args[0])
+	assert.Equal(t, domain.CardTypeBasic, args[4], "an unset card type is written as the default")
+	assert.Equal(t, frontHashParam(card.Content), args[8])
+}
+
+// TestCardColumns_OneEditPerColumn adds a column to the list and checks that
+// selects, scans and inserts all pick it up. It changes cardColumns, so it
+// must not run in parallel.
+func TestCardColumns_OneEditPerColumn(t *testing.T) {
+	original := cardColumns
+	t.Cleanup(func() { cardColumns = original })
+
+	cardColumns = append(append([]cardColumn(nil), original...), cardColumn{
+		name:   "extra",
+		dest:   func(c *domain.Card) any { return &c.Content },
+		insert: func(c *domain.Card) any { return "extra value" },
+	})
+
+	var card domain.Card
+	assert.True(t, strings.HasSuffix(cardSelectList("c"), ", c.extra"))
+	assert.Len(t, cardScanDest(&card), len(strings.Split(cardSelectList(""), ", ")))
+
+	query := buildCardInsertQuery()
+	assert.Contains(t, query, ", extra)")
+	assert.True(t, strings.HasSuffix(query, ", $10)"))
+	args := cardInsertArgs(&card)
+	require.Len(t, args, 10)
+	assert.Equal(t, "extra value", args[9])
+}
+
+func TestNewStmtCache_OnlyPlainDB(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newStmtCache(&instrumentedDBTX{}),
+		"statements on an instrumented handle are not cached, so queries stay timed")
+
+	// A nil cache runs every query directly
+	var cache *stmtCache
+	assert.Nil(t, cache.stmt(context.Background(), nil, "SELECT 1"))
+}
@@ -27,6 +27,10 @@ type PostgresCardStore struct {
 	logger *slog.Logger
 	// Cached reference to the original *sql.DB for transaction management
 	sqlDB *sql.DB
+	// Prepared statements, shared with transaction-bound copies of the store
+	stmts *stmtCache
+	// The transaction the store is bound to, if any
+	tx *sql.Tx
 }
 
 // NewPostgresCardStore creates a new PostgreSQL implementation of the CardStore interface.
@@ -44,16 +48,52 @@ func NewPostgresCardStore(db store.DBTX, logger *slog.Logger) *PostgresCardStore
 		logger = slog.Default()
 	}
 
-	// Store the database connection if it's a *sql.DB
-	var sqlDB *sql.DB
-	if dbConn, ok := db.(*sql.DB); ok {
-		sqlDB = dbConn
-	}
-
 	return &PostgresCardStore{
 		db:     db,
 		logger: logger.With(slog.String("component", "card_store")),
-		sqlDB:  sqlDB,
+		sqlDB:  underlyingSQLDB(db),
+		stmts:  newStmtCache(db),
+	}
+}
+
+// execContext runs a fixed query through a cached prepared statement when
+// one is available, and directly otherwise
+func (s *PostgresCardStore) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if stmt := s.stmts.stmt(ctx, s.tx, query); stmt != nil {
+		return stmt.ExecContext(ctx, args...)
+	}
+	return s.db.ExecContext(ctx, query, args...)
+}
+
+// queryContext is the QueryContext counterpart of execContext
+func (s *PostgresCardStore) queryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if stmt := s.stmts.stmt(ctx, s.tx, query); stmt != nil {
+		return stmt.QueryContext(ctx, args...)
+	}
+	return s.db.QueryContext(ctx, query, args...)
+}
+
+// queryRowContext is the QueryRowContext counterpart of execContext
+func (s *PostgresCardStore) queryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if stmt := s.stmts.stmt(ctx, s.tx, query); stmt != nil {
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	return s.db.QueryRowContext(ctx, query, args...)
+}
+
+// underlyingSQLDB returns the *sql.DB that transactions should be started on,
+// or nil if db is not backed by one (e.g. it is already a transaction).
+// Transactions on a replicated handle must run on its primary.
+func underlyingSQLDB(db store.DBTX) *sql.DB {
+	switch dbConn := db.(type) {
+	case *sql.DB:
+		return dbConn
+	case *store.ReplicatedDB:
+		return dbConn.Primary()
+	case *instrumentedDBTX:
+		return underlyingSQLDB(dbConn.db)
+	default:
+		return nil
 	}
 }
 
@@ -99,22 +139,11 @@ func (s *PostgresCardStore) CreateMultiple(ctx context.Context, cards []*domain.
 	}
 
 	// Insert cards
-	cardQuery := `
-		INSERT INTO cards (id, user_id, memo_id, content, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-
 	for _, card := range cards {
-		_, err := s.db.ExecContext(
-			ctx,
-			cardQuery,
-			card.ID,
-			card.UserID,
-			card.MemoID,
-			card.Content,
-			card.CreatedAt,
-			card.UpdatedAt,
-		)
+		// New cards always start at the column's default version
+		card.Version = domain.InitialCardVersion
+
+		_, err := s.execContext(ctx, cardInsertQuery, cardInsertArgs(card)...)
 
 		if err != nil {
 			// Check for foreign key violation
@@ -126,7 +155,7 @@ func (s *PostgresCardStore) CreateMultiple(ctx context.Context, cards []*domain.
 						slog.String("card_id", card.ID.String()),
 						slog.String("user_id", card.UserID.String()))
 					return fmt.Errorf("%w: user with ID %s not found",
-						store.ErrInvalidEntity, card.UserID)
+						store.ErrReferencedEntityMissing, card.UserID)
 				}
 				if strings.Contains(pgErr.Message, "fk_cards_memo") {
 					log.Warn("foreign key violation - memo does not exist",
@@ -134,14 +163,21 @@ func (s *PostgresCardStore) CreateMultiple(ctx context.Context, cards []*domain.
 						slog.String("card_id", card.ID.String()),
 						slog.String("memo_id", card.MemoID.String()))
 					return fmt.Errorf("%w: memo with ID %s not found",
-						store.ErrInvalidEntity, card.MemoID)
+						store.ErrReferencedEntityMissing, card.MemoID)
+				}
+				if strings.Contains(pgErr.Message, "fk_cards_deck") {
+					log.Warn("foreign key violation - deck does not exist",
+						slog.String("error", err.Error()),
+						slog.String("card_id", card.ID.String()))
+					return fmt.Errorf("%w: deck with ID %s not found",
+						store.ErrReferencedEntityMissing, card.DeckID)
 				}
 			}
 
 			log.Error("failed to insert card",
 				slog.String("error", err.Error()),
 				slog.String("card_id", card.ID.String()))
-			return fmt.Errorf("failed to insert card: %w", MapError(err))
+			return fmt.Errorf("failed to insert card: %w", mapCardError(err))
 		}
 
 		log.Debug("card inserted successfully",
@@ -165,21 +201,14 @@ func (s *PostgresCardStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	log.Debug("retrieving card by ID", slog.String("card_id", id.String()))
 
 	query := `
-		SELECT id, user_id, memo_id, content, created_at, updated_at
+		SELECT ` + cardSelectList("") + `
 		FROM cards
 		WHERE id = $1
 	`
 
 	var card domain.Card
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
-		&card.ID,
-		&card.UserID,
-		&card.MemoID,
-		&card.Content,
-		&card.CreatedAt,
-		&card.UpdatedAt,
-	)
+	err := s.queryRowContext(ctx, query, id).Scan(cardScanDest(&card)...)
 
 	if err != nil {
 		if IsNotFoundError(err) {
@@ -189,7 +218,7 @@ func (s *PostgresCardStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 		log.Error("failed to get card by ID",
 			slog.String("error", err.Error()),
 			slog.String("card_id", id.String()))
-		return nil, fmt.Errorf("failed to get card by ID: %w", MapError(err))
+		return nil, fmt.Errorf("failed to get card by ID: %w", mapCardError(err))
 	}
 
 	log.Debug("card retrieved successfully",
@@ -199,22 +228,87 @@ func (s *PostgresCardStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return &card, nil
 }
 
+// GetByIDs implements store.CardStore.GetByIDs
+// It fetches all requested cards with a single ANY($1) query.
+// Non-existent IDs are omitted from the result rather than reported as errors.
+func (s *PostgresCardStore) GetByIDs(
+	ctx context.Context,
+	ids []uuid.UUID,
+) (map[uuid.UUID]*domain.Card, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	cards := make(map[uuid.UUID]*domain.Card, len(ids))
+	if len(ids) == 0 {
+		return cards, nil
+	}
+
+	log.Debug("retrieving cards by IDs", slog.Int("id_count", len(ids)))
+
+	// Pass the IDs as text and cast in SQL so the driver encodes a plain text array
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+
+	query := `
+		SELECT ` + cardSelectList("") + `
+		FROM cards
+		WHERE id = ANY($1::uuid[])
+	`
+
+	rows, err := s.queryContext(ctx, query, idStrings)
+	if err != nil {
+		log.Error("failed to query cards by IDs",
+			slog.String("error", err.Error()),
+			slog.Int("id_count", len(ids)))
+		return nil, fmt.Errorf("failed to get cards by IDs: %w", mapCardError(err))
+	}
+	defer func() {
+		_ = rows.Close() // Ignoring error as it's cleanup code
+	}()
+
+	for rows.Next() {
+		var card domain.Card
+		if err := rows.Scan(cardScanDest(&card)...); err != nil {
+			return nil, fmt.Errorf("failed to scan card: %w", mapCardError(err))
+		}
+		cards[card.ID] = &card
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate cards: %w", mapCardError(err))
+	}
+
+	log.Debug("cards retrieved successfully",
+		slog.Int("requested", len(ids)),
+		slog.Int("found", len(cards)))
+	return cards, nil
+}
+
 // UpdateContent implements store.CardStore.UpdateContent
-// It modifies an existing card's content field.
+// It modifies an existing card's content only if the card is still at expectedVersion,
+// incrementing the version in the same statement.
 // Returns store.ErrCardNotFound if the card does not exist.
+// Returns store.ErrVersionConflict if the card has been modified since expectedVersion.
 // Returns validation errors if the content is invalid JSON.
-func (s *PostgresCardStore) UpdateContent(ctx context.Context, id uuid.UUID, content []byte) error {
+func (s *PostgresCardStore) UpdateContent(
+	ctx context.Context,
+	id uuid.UUID,
+	content []byte,
+	expectedVersion int,
+) (int, error) {
 	// Get the logger from context or use default
 	log := logger.FromContextOrDefault(ctx, s.logger)
 
 	log.Debug("updating card content",
-		slog.String("card_id", id.String()))
+		slog.String("card_id", id.String()),
+		slog.Int("expected_version", expectedVersion))
 
 	// Validate JSON content before updating
 	if !json.Valid(content) {
 		log.Warn("invalid JSON content for card update",
 			slog.String("card_id", id.String()))
-		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, domain.ErrInvalidCardContent)
+		return 0, fmt.Errorf("%w: %v", store.ErrInvalidEntity, domain.ErrInvalidCardContent)
 	}
 
 	// Set update timestamp
@@ -222,37 +316,51 @@ func (s *PostgresCardStore) UpdateContent(ctx context.Context, id uuid.UUID, con
 
 	query := `
 		UPDATE cards
-		SET content = $1, updated_at = $2
-		WHERE id = $3
+		SET content = $1, updated_at = $2, version = version + 1, front_hash = $5
+		WHERE id = $3 AND version = $4
+		RETURNING version
 	`
 
-	result, err := s.db.ExecContext(
+	var newVersion int
+	err := s.queryRowContext(
 		ctx,
 		query,
 		content,
 		updatedAt,
 		id,
-	)
+		expectedVersion,
+		frontHashParam(content),
+	).Scan(&newVersion)
 
 	if err != nil {
-		log.Error("failed to update card content",
-			slog.String("error", err.Error()),
-			slog.String("card_id", id.String()))
-		return fmt.Errorf("failed to update card content: %w", MapError(err))
-	}
+		if !IsNotFoundError(err) {
+			log.Error("failed to update card content",
+				slog.String("error", err.Error()),
+				slog.String("card_id", id.String()))
+			return 0, fmt.Errorf("failed to update card content: %w", mapCardError(err))
+		}
 
-	// Check if a row was actually updated
-	err = CheckRowsAffected(result, "card")
-	if err != nil {
-		if errors.Is(err, store.ErrNotFound) {
-			return store.ErrCardNotFound
+		// No row matched: either the card is gone or its version has moved on
+		var exists bool
+		existsErr := s.queryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM cards WHERE id = $1)`, id).Scan(&exists)
+		if existsErr != nil {
+			return 0, fmt.Errorf("failed to update card content: %w", mapCardError(existsErr))
 		}
-		return fmt.Errorf("failed to update card content: %w", err)
+		if !exists {
+			return 0, store.ErrCardNotFound
+		}
+
+		log.Debug("card version conflict",
+			slog.String("card_id", id.String()),
+			slog.Int("expected_version", expectedVersion))
+		return 0, store.ErrVersionConflict
 	}
 
 	log.Debug("card content updated successfully",
-		slog.String("card_id", id.String()))
-	return nil
+		slog.String("card_id", id.String()),
+		slog.Int("version", newVersion))
+	return newVersion, nil
 }
 
 // Delete implements store.CardStore.Delete
@@ -284,12 +392,12 @@ func (s *PostgresCardStore) Delete(ctx context.Context, id uuid.UUID) error {
 		WHERE id = $1
 	`
 
-	result, err := s.db.ExecContext(ctx, query, id)
+	result, err := s.execContext(ctx, query, id)
 	if err != nil {
 		log.Error("failed to delete card",
 			slog.String("error", err.Error()),
 			slog.String("card_id", id.String()))
-		return fmt.Errorf("failed to delete card: %w", MapError(err))
+		return fmt.Errorf("failed to delete card: %w", mapCardError(err))
 	}
 
 	// Check if a row was actually deleted
@@ -306,46 +414,552 @@ func (s *PostgresCardStore) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// SetDeck implements store.CardStore.SetDeck
+// It assigns a card to a deck, or removes it from its deck when deckID is nil.
+// Returns store.ErrCardNotFound if the card does not exist and
+// store.ErrReferencedEntityMissing if the deck does not exist.
+func (s *PostgresCardStore) SetDeck(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	log.Debug("setting card deck", slog.String("card_id", id.String()))
+
+	query := `
+		UPDATE cards
+		SET deck_id = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := s.execContext(ctx, query, deckID, time.Now().UTC(), id)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			log.Warn("foreign key violation - deck does not exist",
+				slog.String("error", err.Error()),
+				slog.String("card_id", id.String()))
+			return fmt.Errorf("%w: deck with ID %s not found", store.ErrReferencedEntityMissing, deckID)
+		}
+		log.Error("failed to set card deck",
+			slog.String("error", err.Error()),
+			slog.String("card_id", id.String()))
+		return fmt.Errorf("failed to set card deck: %w", mapCardError(err))
+	}
+
+	if err := CheckRowsAffected(result, "card"); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return store.ErrCardNotFound
+		}
+		return fmt.Errorf("failed to set card deck: %w", err)
+	}
+
+	log.Debug("card deck set successfully", slog.String("card_id", id.String()))
+	return nil
+}
+
+// SetMemo implements store.CardStore.SetMemo
+// It moves a card to a different parent memo.
+// Returns store.ErrCardNotFound if the card does not exist and
+// store.ErrReferencedEntityMissing if the memo does not exist.
+func (s *PostgresCardStore) SetMemo(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	log.Debug("setting card memo",
+		slog.String("card_id", id.String()),
+		slog.String("memo_id", memoID.String()))
+
+	query := `
+		UPDATE cards
+		SET memo_id = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := s.execContext(ctx, query, memoID, time.Now().UTC(), id)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			log.Warn("foreign key violation - memo does not exist",
+				slog.String("error", err.Error()),
+				slog.String("card_id", id.String()),
+				slog.String("memo_id", memoID.String()))
+			return fmt.Errorf("%w: memo with ID %s not found", store.ErrReferencedEntityMissing, memoID)
+		}
+		log.Error("failed to set card memo",
+			slog.String("error", err.Error()),
+			slog.String("card_id", id.String()))
+		return fmt.Errorf("failed to set card memo: %w", mapCardError(err))
+	}
+
+	if err := CheckRowsAffected(result, "card"); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return store.ErrCardNotFound
+		}
+		return fmt.Errorf("failed to set card memo: %w", err)
+	}
+
+	log.Debug("card memo set successfully",
+		slog.String("card_id", id.String()),
+		slog.String("memo_id", memoID.String()))
+	return nil
+}
+
+// SetSuspended implements store.CardStore.SetSuspended
+func (s *PostgresCardStore) SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	log.Debug("setting card suspension",
+		slog.String("card_id", id.String()),
+		slog.Bool("suspended", suspendedAt != nil))
+
+	query := `
+		UPDATE cards
+		SET suspended_at = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := s.execContext(ctx, query, suspendedAt, time.Now().UTC(), id)
+	if err != nil {
+		log.Error("failed to set card suspension",
+			slog.String("error", err.Error()),
+			slog.String("card_id", id.String()))
+		return fmt.Errorf("failed to set card suspension: %w", mapCardError(err))
+	}
+
+	if err := CheckRowsAffected(result, "card"); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return store.ErrCardNotFound
+		}
+		return fmt.Errorf("failed to set card suspension: %w", err)
+	}
+
+	log.Debug("card suspension set successfully",
+		slog.String("card_id", id.String()),
+		slog.Bool("suspended", suspendedAt != nil))
+	return nil
+}
+
+// SetBuriedUntil implements store.CardStore.SetBuriedUntil
+func (s *PostgresCardStore) SetBuriedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	log.Debug("setting card burial",
+		slog.String("card_id", id.String()),
+		slog.Bool("buried", until != nil))
+
+	query := `
+		UPDATE cards
+		SET buried_until = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := s.execContext(ctx, query, until, time.Now().UTC(), id)
+	if err != nil {
+		log.Error("failed to set card burial",
+			slog.String("error", err.Error()),
+			slog.String("card_id", id.String()))
+		return fmt.Errorf("failed to set card burial: %w", mapCardError(err))
+	}
+
+	if err := CheckRowsAffected(result, "card"); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return store.ErrCardNotFound
+		}
+		return fmt.Errorf("failed to set card burial: %w", err)
+	}
+
+	log.Debug("card burial set successfully",
+		slog.String("card_id", id.String()),
+		slog.Bool("buried", until != nil))
+	return nil
+}
+
+// SupersedeByMemo implements store.CardStore.SupersedeByMemo
+// It marks the memo's active cards as superseded in a single UPDATE.
+func (s *PostgresCardStore) SupersedeByMemo(
+	ctx context.Context,
+	memoID uuid.UUID,
+	at time.Time,
+) (int, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		UPDATE cards
+		SET superseded_at = $1, updated_at = $1
+		WHERE memo_id = $2 AND superseded_at IS NULL
+	`
+
+	result, err := s.execContext(ctx, query, at.UTC(), memoID)
+	if err != nil {
+		log.Error("failed to supersede memo cards",
+			slog.String("error", err.Error()),
+			slog.String("memo_id", memoID.String()))
+		return 0, fmt.Errorf("failed to supersede memo cards: %w", mapCardError(err))
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	log.Debug("superseded memo cards",
+		slog.String("memo_id", memoID.String()),
+		slog.Int64("count", count))
+	return int(count), nil
+}
+
+// DeleteByMemo implements store.CardStore.DeleteByMemo
+// It deletes all of the memo's cards in a single DELETE.
+func (s *PostgresCardStore) DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	result, err := s.execContext(ctx, `DELETE FROM cards WHERE memo_id = $1`, memoID)
+	if err != nil {
+		log.Error("failed to delete memo cards",
+			slog.String("error", err.Error()),
+			slog.String("memo_id", memoID.String()))
+		return 0, fmt.Errorf("failed to delete memo cards: %w", mapCardError(err))
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	log.Debug("deleted memo cards",
+		slog.String("memo_id", memoID.String()),
+		slog.Int64("count", count))
+	return int(count), nil
+}
+
+// ListByDeck implements store.CardStore.ListByDeck
+// It retrieves a page of the cards in a deck, oldest first.
+func (s *PostgresCardStore) ListByDeck(
+	ctx context.Context,
+	deckID uuid.UUID,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if limit <= 0 || offset < 0 {
+		log.Warn("invalid pagination parameters for deck cards",
+			slog.Int("limit", limit),
+			slog.Int("offset", offset))
+		return nil, fmt.Errorf("%w: invalid limit or offset", store.ErrInvalidEntity)
+	}
+
+	query := `
+		SELECT ` + cardSelectList("") + `
+		FROM cards
+		WHERE deck_id = $1 AND superseded_at IS NULL
+		ORDER BY created_at ASC, id ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.queryContext(ctx, query, deckID, limit, offset)
+	if err != nil {
+		log.Error("failed to query deck cards",
+			slog.String("error", err.Error()),
+			slog.String("deck_id", deckID.String()))
+		return nil, fmt.Errorf("failed to list deck cards: %w", mapCardError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	cards := make([]*domain.Card, 0, limit)
+	for rows.Next() {
+		var card domain.Card
+		if err := rows.Scan(cardScanDest(&card)...); err != nil {
+			log.Error("failed to scan deck card",
+				slog.String("error", err.Error()),
+				slog.String("deck_id", deckID.String()))
+			return nil, fmt.Errorf("failed to scan deck card: %w", mapCardError(err))
+		}
+		cards = append(cards, &card)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate deck cards: %w", mapCardError(err))
+	}
+
+	log.Debug("listed deck cards",
+		slog.String("deck_id", deckID.String()),
+		slog.Int("count", len(cards)))
+	return cards, nil
+}
+
+// ListByTag implements store.CardStore.ListByTag
+// It retrieves a page of the user's cards with a tag, oldest first. The containment
+// test on content is served by the GIN index on the content column.
+func (s *PostgresCardStore) ListByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if limit <= 0 || offset < 0 {
+		log.Warn("invalid pagination parameters for tagged cards",
+			slog.Int("limit", limit),
+			slog.Int("offset", offset))
+		return nil, fmt.Errorf("%w: invalid limit or offset", store.ErrInvalidEntity)
+	}
+
+	query := `
+		SELECT ` + cardSelectList("") + `
+		FROM cards
+		WHERE user_id = $1 AND superseded_at IS NULL
+		  AND content @> jsonb_build_object('tags', jsonb_build_array($2::text))
+		ORDER BY created_at ASC, id ASC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.queryContext(ctx, query, userID, tag, limit, offset)
+	if err != nil {
+		log.Error("failed to query tagged cards",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()),
+			slog.String("tag", tag))
+		return nil, fmt.Errorf("failed to list tagged cards: %w", mapCardError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	cards := make([]*domain.Card, 0, limit)
+	for rows.Next() {
+		var card domain.Card
+		if err := rows.Scan(cardScanDest(&card)...); err != nil {
+			log.Error("failed to scan tagged card",
+				slog.String("error", err.Error()),
+				slog.String("user_id", userID.String()),
+				slog.String("tag", tag))
+			return nil, fmt.Errorf("failed to scan tagged card: %w", mapCardError(err))
+		}
+		cards = append(cards, &card)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tagged cards: %w", mapCardError(err))
+	}
+
+	log.Debug("listed tagged cards",
+		slog.String("user_id", userID.String()),
+		slog.String("tag", tag),
+		slog.Int("count", len(cards)))
+	return cards, nil
+}
+
+// ExistingFrontHashes implements store.CardStore.ExistingFrontHashes
+// It matches the hashes against the front_hash column, which is maintained on
+// insert and content update and served by a partial index on (user_id, front_hash).
+func (s *PostgresCardStore) ExistingFrontHashes(
+	ctx context.Context,
+	userID uuid.UUID,
+	hashes [][32]byte,
+) (map[[32]byte]bool, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	existing := make(map[[32]byte]bool)
+	if len(hashes) == 0 {
+		return existing, nil
+	}
+
+	params := make([][]byte, len(hashes))
+	for i := range hashes {
+		params[i] = hashes[i][:]
+	}
+
+	query := `
+		SELECT DISTINCT front_hash
+		FROM cards
+		WHERE user_id = $1 AND superseded_at IS NULL AND front_hash = ANY($2::bytea[])
+	`
+
+	rows, err := s.queryContext(ctx, query, userID, params)
+	if err != nil {
+		log.Error("failed to query card front hashes",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to check card front hashes: %w", mapCardError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	for rows.Next() {
+		var hash []byte
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to scan card front hash: %w", mapCardError(err))
+		}
+		var key [32]byte
+		if len(hash) != len(key) {
+			continue
+		}
+		copy(key[:], hash)
+		existing[key] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate card front hashes: %w", mapCardError(err))
+	}
+
+	log.Debug("checked card front hashes",
+		slog.String("user_id", userID.String()),
+		slog.Int("checked", len(hashes)),
+		slog.Int("existing", len(existing)))
+	return existing, nil
+}
+
+// frontHashParam returns the front_hash column value for content: its
+// domain.FrontHashOf hash, or nil when the content has no front to hash.
+func frontHashParam(content []byte) []byte {
+	hash, ok := domain.FrontHashOf(content)
+	if !ok {
+		return nil
+	}
+	return hash[:]
+}
+
 // GetNextReviewCard implements store.CardStore.GetNextReviewCard
 // It retrieves the next card due for review for a user.
 // This is based on the UserCardStats.NextReviewAt field.
 func (s *PostgresCardStore) GetNextReviewCard(
 	ctx context.Context,
 	userID uuid.UUID,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	return s.getNextDueCard(ctx, userID, nil, "", order, nil)
+}
+
+// GetNextDueCard implements store.CardStore.GetNextDueCard
+// It retrieves the next due card that is either new or has been reviewed before,
+// optionally restricted to a deck.
+func (s *PostgresCardStore) GetNextDueCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	return s.GetNextDueCardExcluding(ctx, userID, deckID, newCards, order, nil)
+}
+
+// GetNextDueCardExcluding implements store.CardStore.GetNextDueCardExcluding
+// It retrieves the next due card like GetNextDueCard, skipping the excluded cards.
+func (s *PostgresCardStore) GetNextDueCardExcluding(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
+) (*domain.Card, error) {
+	if newCards {
+		return s.getNextDueCard(ctx, userID, deckID, "AND ucs.review_count = 0", order, exclude)
+	}
+	return s.getNextDueCard(ctx, userID, deckID, "AND ucs.review_count > 0", order, exclude)
+}
+
+// learningFirst orders due cards that are part way through their learning steps
+// before other due cards, so that short steps are kept close to their sub-day
+// due times. New cards have not started their steps and are not moved ahead.
+const learningFirst = "(ucs.phase = 'review' OR ucs.review_count = 0) ASC"
+
+// reviewOrderBy returns the ORDER BY clause that serves due cards in order.
+// Due learning cards come first in every order. Every clause ends with the
+// card ID except the random one, so that ties are broken deterministically.
+func reviewOrderBy(order domain.ReviewOrder) (string, error) {
+	switch order.OrDefault() {
+	case domain.ReviewOrderDueDate:
+		return "ORDER BY " + learningFirst + ", ucs.next_review_at ASC, c.id ASC", nil
+	case domain.ReviewOrderRandomDue:
+		return "ORDER BY " + learningFirst + ", random()", nil
+	case domain.ReviewOrderLowestEase:
+		return "ORDER BY " + learningFirst + ", ucs.ease_factor ASC, ucs.next_review_at ASC, c.id ASC", nil
+	default:
+		return "", fmt.Errorf("%w: %q", domain.ErrInvalidReviewOrder, order)
+	}
+}
+
+// getNextDueCard runs the next-due-card query, optionally restricted to a deck
+// and to an extra condition on the user_card_stats row, and skipping the
+// excluded cards. The condition must be a constant SQL fragment.
+func (s *PostgresCardStore) getNextDueCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	statsCondition string,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
 ) (*domain.Card, error) {
 	// Get the logger from context or use default
 	log := logger.FromContextOrDefault(ctx, s.logger)
 
 	log.Debug("retrieving next review card for user",
-		slog.String("user_id", userID.String()))
+		slog.String("user_id", userID.String()),
+		slog.String("order", string(order.OrDefault())))
+
+	orderBy, err := reviewOrderBy(order)
+	if err != nil {
+		return nil, err
+	}
 
 	// This query joins cards and user_card_stats tables to find cards that:
 	// 1. Belong to the specified user
 	// 2. Have user_card_stats records
-	// 3. Are due for review (next_review_at <= current time)
-	// The result is ordered by next_review_at ascending to prioritize oldest due cards first
-	// Secondary sort by card ID ensures deterministic ordering when timestamps match
+	// 3. Are due for review as of the current time (see dueCondition)
+	// 4. Are not buried until later today
+	// 5. Belong to the deck, if one is given
+	// 6. Match the optional stats condition
+	// 7. Are not excluded
+	// The result is ordered by the requested review order (see reviewOrderBy)
+	args := []interface{}{userID}
+	deckCondition := ""
+	if deckID != nil {
+		args = append(args, *deckID)
+		deckCondition = "AND c.deck_id = $2"
+	}
+	excludeCondition := ""
+	if len(exclude) > 0 {
+		// Pass the IDs as text and cast in SQL so the driver encodes a plain text array
+		excludeStrings := make([]string, len(exclude))
+		for i, id := range exclude {
+			excludeStrings[i] = id.String()
+		}
+		args = append(args, excludeStrings)
+		excludeCondition = fmt.Sprintf("AND c.id <> ALL($%d::uuid[])", len(args))
+	}
+
 	query := `
-		SELECT c.id, c.user_id, c.memo_id, c.content, c.created_at, c.updated_at
+		SELECT ` + cardSelectList("c") + `
 		FROM cards c
 		JOIN user_card_stats ucs ON c.id = ucs.card_id
 		WHERE c.user_id = $1
 		  AND ucs.user_id = $1
-		  AND ucs.next_review_at <= NOW()
-		ORDER BY ucs.next_review_at ASC, c.id ASC
+		  AND ` + dueCondition("ucs", "NOW()") + `
+		  AND (c.buried_until IS NULL OR c.buried_until <= NOW())
+		  ` + deckCondition + `
+		  ` + excludeCondition + `
+		  ` + statsCondition + `
+		` + orderBy + `
 		LIMIT 1
 	`
 
 	var card domain.Card
 
-	err := s.db.QueryRowContext(ctx, query, userID).Scan(
-		&card.ID,
-		&card.UserID,
-		&card.MemoID,
-		&card.Content,
-		&card.CreatedAt,
-		&card.UpdatedAt,
-	)
+	// The query only varies with the deck, exclusion, stats condition and
+	// order, so there are few enough variants to prepare each once
+	err = s.queryRowContext(ctx, query, args...).Scan(cardScanDest(&card)...)
 
 	if err != nil {
 		if IsNotFoundError(err) {
@@ -357,7 +971,7 @@ func (s *PostgresCardStore) GetNextReviewCard(
 		log.Error("failed to get next review card",
 			slog.String("error", err.Error()),
 			slog.String("user_id", userID.String()))
-		return nil, fmt.Errorf("failed to get next review card: %w", MapError(err))
+		return nil, fmt.Errorf("failed to get next review card: %w", mapCardError(err))
 	}
 
 	log.Debug("next review card retrieved successfully",
@@ -367,6 +981,314 @@ func (s *PostgresCardStore) GetNextReviewCard(
 	return &card, nil
 }
 
+// GetRecentlyReviewed implements store.CardStore.GetRecentlyReviewed
+// It retrieves a page of the user's cards joined to their latest review event,
+// ordered by the time of that event (most recent first).
+func (s *PostgresCardStore) GetRecentlyReviewed(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset int,
+) ([]*domain.ReviewedCard, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if limit <= 0 || offset < 0 {
+		log.Warn("invalid pagination parameters for recently reviewed cards",
+			slog.Int("limit", limit),
+			slog.Int("offset", offset))
+		return nil, fmt.Errorf("%w: invalid limit or offset", store.ErrInvalidEntity)
+	}
+
+	log.Debug("retrieving recently reviewed cards",
+		slog.String("user_id", userID.String()),
+		slog.Int("limit", limit),
+		slog.Int("offset", offset))
+
+	// The lateral subquery picks exactly one event per card: the one with the
+	// latest reviewed_at. The event ID is used as a tie-breaker so that the
+	// result is deterministic when two events share a timestamp.
+	query := `
+		SELECT ` + cardSelectList("c") + `, re.id, re.user_id, re.card_id, re.outcome,
+		       re.reviewed_at, re.created_at
+		FROM cards c
+		JOIN LATERAL (
+			SELECT e.id, e.user_id, e.card_id, e.outcome, e.reviewed_at, e.created_at
+			FROM review_events e
+			WHERE e.card_id = c.id
+			  AND e.user_id = $1
+			ORDER BY e.reviewed_at DESC, e.id DESC
+			LIMIT 1
+		) re ON TRUE
+		WHERE c.user_id = $1
+		ORDER BY re.reviewed_at DESC, c.id ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.queryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		log.Error("failed to query recently reviewed cards",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to get recently reviewed cards: %w", mapCardError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	results := make([]*domain.ReviewedCard, 0, limit)
+	for rows.Next() {
+		var card domain.Card
+		var event domain.ReviewEvent
+		var outcome string
+
+		if err := rows.Scan(append(cardScanDest(&card),
+			&event.ID,
+			&event.UserID,
+			&event.CardID,
+			&outcome,
+			&event.ReviewedAt,
+			&event.CreatedAt,
+		)...); err != nil {
+			log.Error("failed to scan recently reviewed card",
+				slog.String("error", err.Error()),
+				slog.String("user_id", userID.String()))
+			return nil, fmt.Errorf("failed to scan recently reviewed card: %w", mapCardError(err))
+		}
+
+		event.Outcome = domain.ReviewOutcome(outcome)
+		results = append(results, &domain.ReviewedCard{
+			Card:       &card,
+			LastReview: &event,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating recently reviewed cards",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to get recently reviewed cards: %w", mapCardError(err))
+	}
+
+	log.Debug("recently reviewed cards retrieved successfully",
+		slog.String("user_id", userID.String()),
+		slog.Int("count", len(results)))
+	return results, nil
+}
+
+// FindDuplicates implements store.CardStore.FindDuplicates
+// The content hash is the MD5 of the JSONB text representation, which PostgreSQL
+// normalizes (sorted keys, canonical whitespace), so equivalent JSON hashes equally.
+func (s *PostgresCardStore) FindDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*domain.DuplicateCardGroup, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	log.Debug("finding duplicate cards", slog.String("user_id", userID.String()))
+
+	query := `
+		SELECT ` + cardSelectList("") + `, content_hash
+		FROM (
+			SELECT ` + cardSelectList("") + `,
+				md5(content::text) AS content_hash,
+				COUNT(*) OVER (PARTITION BY md5(content::text)) AS group_size
+			FROM cards
+			WHERE user_id = $1 AND superseded_at IS NULL
+		) c
+		WHERE group_size > 1
+		ORDER BY content_hash, created_at, id
+	`
+
+	rows, err := s.queryContext(ctx, query, userID)
+	if err != nil {
+		log.Error("failed to query duplicate cards",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to find duplicate cards: %w", mapCardError(err))
+	}
+	defer func() {
+		_ = rows.Close() // Ignoring error as it's cleanup code
+	}()
+
+	groups := []*domain.DuplicateCardGroup{}
+	var current *domain.DuplicateCardGroup
+	for rows.Next() {
+		var card domain.Card
+		var contentHash string
+		if err := rows.Scan(append(cardScanDest(&card),
+			&contentHash,
+		)...); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate card: %w", mapCardError(err))
+		}
+
+		// Rows are ordered by hash, so a new hash starts a new group
+		if current == nil || current.ContentHash != contentHash {
+			current = &domain.DuplicateCardGroup{ContentHash: contentHash}
+			groups = append(groups, current)
+		}
+		current.Cards = append(current.Cards, &card)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate duplicate cards: %w", mapCardError(err))
+	}
+
+	log.Debug("duplicate cards found",
+		slog.String("user_id", userID.String()),
+		slog.Int("group_count", len(groups)))
+	return groups, nil
+}
+
+// ForEachWithStats implements store.CardStore.ForEachWithStats
+// The stats are LEFT JOINed so that a card missing its statistics is still exported.
+func (s *PostgresCardStore) ForEachWithStats(
+	ctx context.Context,
+	userID uuid.UUID,
+	includeSuperseded bool,
+	fn func(*domain.CardWithStats) error,
+) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	log.Debug("streaming cards with stats", slog.String("user_id", userID.String()))
+
+	query := `
+		SELECT ` + cardSelectList("c") + `, ucs.interval, ucs.ease_factor, ucs.consecutive_correct,
+		       ucs.last_reviewed_at, ucs.next_review_at, ucs.review_count, ucs.lapses, ucs.phase, ucs.learning_step,
+		       ucs.created_at, ucs.updated_at
+		FROM cards c
+		LEFT JOIN user_card_stats ucs ON ucs.card_id = c.id AND ucs.user_id = c.user_id
+		WHERE c.user_id = $1 AND ($2 OR c.superseded_at IS NULL)
+		ORDER BY c.created_at, c.id
+	`
+
+	rows, err := s.queryContext(ctx, query, userID, includeSuperseded)
+	if err != nil {
+		log.Error("failed to query cards with stats",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return fmt.Errorf("failed to get cards with stats: %w", mapCardError(err))
+	}
+	defer func() {
+		_ = rows.Close() // Ignoring error as it's cleanup code
+	}()
+
+	count := 0
+	for rows.Next() {
+		var card domain.Card
+		var (
+			interval           *int
+			easeFactor         *float64
+			consecutiveCorrect *int
+			lastReviewedAt     *time.Time
+			nextReviewAt       *time.Time
+			reviewCount        *int
+			lapses             *int
+			phase              *domain.CardPhase
+			learningStep       *int
+			statsCreatedAt     *time.Time
+			statsUpdatedAt     *time.Time
+		)
+		if err := rows.Scan(append(cardScanDest(&card),
+			&interval,
+			&easeFactor,
+			&consecutiveCorrect,
+			&lastReviewedAt,
+			&nextReviewAt,
+			&reviewCount,
+			&lapses,
+			&phase,
+			&learningStep,
+			&statsCreatedAt,
+			&statsUpdatedAt,
+		)...); err != nil {
+			return fmt.Errorf("failed to scan card with stats: %w", mapCardError(err))
+		}
+
+		item := &domain.CardWithStats{Card: &card}
+		// next_review_at is NOT NULL in the schema, so it is only nil without a stats row
+		if nextReviewAt != nil {
+			stats := &domain.UserCardStats{
+				UserID:       card.UserID,
+				CardID:       card.ID,
+				NextReviewAt: *nextReviewAt,
+			}
+			if interval != nil {
+				stats.Interval = *interval
+			}
+			if easeFactor != nil {
+				stats.EaseFactor = *easeFactor
+			}
+			if consecutiveCorrect != nil {
+				stats.ConsecutiveCorrect = *consecutiveCorrect
+			}
+			if lastReviewedAt != nil {
+				stats.LastReviewedAt = *lastReviewedAt
+			}
+			if reviewCount != nil {
+				stats.ReviewCount = *reviewCount
+			}
+			if lapses != nil {
+				stats.Lapses = *lapses
+			}
+			if phase != nil {
+				stats.Phase = *phase
+			}
+			if learningStep != nil {
+				stats.LearningStep = *learningStep
+			}
+			if statsCreatedAt != nil {
+				stats.CreatedAt = *statsCreatedAt
+			}
+			if statsUpdatedAt != nil {
+				stats.UpdatedAt = *statsUpdatedAt
+			}
+			item.Stats = stats
+		}
+
+		if err := fn(item); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate cards with stats: %w", mapCardError(err))
+	}
+
+	log.Debug("streamed cards with stats",
+		slog.String("user_id", userID.String()),
+		slog.Int("count", count))
+	return nil
+}
+
+// CountByUser implements store.CardStore.CountByUser
+// It returns the number of active (not superseded) cards owned by the user.
+func (s *PostgresCardStore) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT COUNT(*)
+		FROM cards
+		WHERE user_id = $1 AND superseded_at IS NULL
+	`
+
+	var count int
+	if err := s.queryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		log.Error("failed to count cards for user",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return 0, fmt.Errorf("failed to count cards: %w", mapCardError(err))
+	}
+
+	log.Debug("counted cards for user",
+		slog.String("user_id", userID.String()),
+		slog.Int("count", count))
+	return count, nil
+}
+
 // WithTx implements store.CardStore.WithTx
 // It returns a new CardStore instance that uses the provided transaction.
 // This allows for multiple operations to be executed within a single transaction.
@@ -375,6 +1297,8 @@ func (s *PostgresCardStore) WithTx(tx *sql.Tx) store.CardStore {
 		db:     tx,
 		logger: s.logger,
 		sqlDB:  s.sqlDB, // Preserve the original DB connection
+		stmts:  s.stmts,
+		tx:     tx,
 	}
 }
 
@@ -125,14 +125,14 @@ func TestCardStoreErrorLeakage(t *testing.T) {
 	t.Run("UpdateContent errors do not leak details", func(t *testing.T) {
 		// Test non-existent card
 		nonExistentID := uuid.New()
-		err := cardStore.UpdateContent(ctx, nonExistentID, validCardContent)
+		_, err := cardStore.UpdateContent(ctx, nonExistentID, validCardContent, 1)
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, store.ErrCardNotFound)
 		AssertNoErrorLeakage(t, err)
 
 		// Test invalid content
 		invalidContent := []byte(`invalid json`)
-		err = cardStore.UpdateContent(ctx, validCard.ID, invalidContent)
+		_, err = cardStore.UpdateContent(ctx, validCard.ID, invalidContent, 1)
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, store.ErrInvalidEntity)
 		AssertNoErrorLeakage(t, err)
@@ -112,7 +112,7 @@ func TestGetNextReviewCardExtended(t *testing.T) {
 		require.NotNil(t, expectedCard, "Failed to identify card with lowest ID")
 
 		// Call GetNextReviewCard
-		card, err := cardStore.GetNextReviewCard(ctx, testUser.ID)
+		card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
 		assert.NoError(t, err, "GetNextReviewCard should succeed")
 		assert.Equal(
 			t,
@@ -138,7 +138,7 @@ func TestGetNextReviewCardExtended(t *testing.T) {
 
 		// Call GetNextReviewCard - should not return the orphaned stats
 		// due to inner join with cards table
-		card, err := cardStore.GetNextReviewCard(ctx, testUser.ID)
+		card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
 		assert.NoError(
 			t,
 			err,
@@ -170,7 +170,7 @@ func TestGetNextReviewCardExtended(t *testing.T) {
 		require.NoError(t, err, "Failed to create oldest card")
 
 		// Get next card, which should be the one with the oldest review time
-		card, err := cardStore.GetNextReviewCard(ctx, testUser.ID)
+		card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
 		assert.NoError(t, err, "GetNextReviewCard should succeed")
 		assert.Equal(
 			t,
@@ -182,7 +182,7 @@ func TestGetNextReviewCardExtended(t *testing.T) {
 
 	t.Run("handles_nil_user_id", func(t *testing.T) {
 		// Call with zero UUID
-		_, err := cardStore.GetNextReviewCard(ctx, uuid.Nil)
+		_, err := cardStore.GetNextReviewCard(ctx, uuid.Nil, domain.DefaultReviewOrder)
 
 		// Should not panic and should return ErrCardNotFound
 		// since no cards would match a zero UUID
@@ -203,7 +203,7 @@ func TestGetNextReviewCardExtended(t *testing.T) {
 
 		// This card should not be returned by GetNextReviewCard
 		// because the JOIN with user_card_stats will filter it out
-		gotCard, err := cardStore.GetNextReviewCard(ctx, testUser.ID)
+		gotCard, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
 		assert.NoError(t, err, "GetNextReviewCard should succeed with other due cards")
 		assert.NotEqual(t, card.ID, gotCard.ID, "Should not return card without stats")
 	})
@@ -211,7 +211,7 @@ func TestGetNextReviewCardExtended(t *testing.T) {
 	t.Run("error_mapping", func(t *testing.T) {
 		// This is already covered by error_leakage_test.go but adding for completeness
 		nonExistentUserID := uuid.New()
-		_, err := cardStore.GetNextReviewCard(ctx, nonExistentUserID)
+		_, err := cardStore.GetNextReviewCard(ctx, nonExistentUserID, domain.DefaultReviewOrder)
 		assert.Error(t, err, "GetNextReviewCard should return error for nonexistent user")
 		assert.ErrorIs(t, err, store.ErrCardNotFound, "Error should be mapped to ErrCardNotFound")
 
@@ -226,3 +226,187 @@ func TestGetNextReviewCardExtended(t *testing.T) {
 		}
 	})
 }
+
+// TestGetNextDueCard tests that GetNextDueCard separates never-reviewed cards
+// from cards that have been reviewed before
+func TestGetNextDueCard(t *testing.T) {
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Get database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	// Create a transaction for isolation
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback() // Intentionally ignoring error as it's cleanup code
+	}()
+
+	// Set up the stores
+	userStore := postgres.NewPostgresUserStore(tx, 4) // Low cost for test speed
+	cardStore := postgres.NewPostgresCardStore(tx, nil)
+	memoStore := postgres.NewPostgresMemoStore(tx, nil)
+	statsStore := postgres.NewPostgresUserCardStatsStore(tx, nil)
+
+	testUser, err := domain.NewUser("getnextdue@example.com", "password123456")
+	require.NoError(t, err, "Failed to create test user")
+	require.NoError(t, userStore.Create(ctx, testUser), "Failed to save test user")
+
+	testMemo, err := domain.NewMemo(testUser.ID, "GetNextDueCard test memo")
+	require.NoError(t, err, "Failed to create test memo")
+	require.NoError(t, memoStore.Create(ctx, testMemo), "Failed to save test memo")
+
+	// Helper function to create a card with stats
+	createCardWithStats := func(nextReviewAt time.Time, reviewCount int) *domain.Card {
+		content := json.RawMessage(`{"front":"Test front","back":"Test back"}`)
+		card, err := domain.NewCard(testUser.ID, testMemo.ID, content)
+		require.NoError(t, err, "Failed to create card")
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+		stats, err := domain.NewUserCardStats(testUser.ID, card.ID)
+		require.NoError(t, err, "Failed to create stats")
+		stats.NextReviewAt = nextReviewAt
+		stats.ReviewCount = reviewCount
+		require.NoError(t, statsStore.Create(ctx, stats))
+		return card
+	}
+
+	now := time.Now().UTC()
+	newCard := createCardWithStats(now.Add(-2*time.Hour), 0)
+	reviewCard := createCardWithStats(now.Add(-time.Hour), 3)
+	createCardWithStats(now.Add(time.Hour), 5) // Reviewed before but not yet due
+
+	// Only reviewed cards are considered when newCards is false
+	card, err := cardStore.GetNextDueCard(ctx, testUser.ID, nil, false, domain.DefaultReviewOrder)
+	require.NoError(t, err)
+	assert.Equal(t, reviewCard.ID, card.ID)
+
+	// Only never-reviewed cards are considered when newCards is true
+	card, err = cardStore.GetNextDueCard(ctx, testUser.ID, nil, true, domain.DefaultReviewOrder)
+	require.NoError(t, err)
+	assert.Equal(t, newCard.ID, card.ID)
+
+	// GetNextReviewCard still considers both kinds
+	card, err = cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
+	require.NoError(t, err)
+	assert.Equal(t, newCard.ID, card.ID)
+
+	// A user with no due cards of the requested kind gets ErrCardNotFound
+	_, err = cardStore.GetNextDueCard(ctx, uuid.New(), nil, true, domain.DefaultReviewOrder)
+	assert.ErrorIs(t, err, store.ErrCardNotFound)
+}
+
+// TestGetNextReviewCard_Order tests that each review order picks the expected
+// card among cards with different due dates and ease factors
+func TestGetNextReviewCard_Order(t *testing.T) {
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Get database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	// Create a transaction for isolation
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback() // Intentionally ignoring error as it's cleanup code
+	}()
+
+	// Set up the stores
+	userStore := postgres.NewPostgresUserStore(tx, 4) // Low cost for test speed
+	cardStore := postgres.NewPostgresCardStore(tx, nil)
+	memoStore := postgres.NewPostgresMemoStore(tx, nil)
+	statsStore := postgres.NewPostgresUserCardStatsStore(tx, nil)
+
+	testUser, err := domain.NewUser("getnext-order@example.com", "password123456")
+	require.NoError(t, err, "Failed to create test user")
+	require.NoError(t, userStore.Create(ctx, testUser), "Failed to save test user")
+
+	testMemo, err := domain.NewMemo(testUser.ID, "Review order test memo")
+	require.NoError(t, err, "Failed to create test memo")
+	require.NoError(t, memoStore.Create(ctx, testMemo), "Failed to save test memo")
+
+	// Helper function to create a reviewed card with stats
+	createCardWithStats := func(nextReviewAt time.Time, easeFactor float64) *domain.Card {
+		content := json.RawMessage(`{"front":"Test front","back":"Test back"}`)
+		card, err := domain.NewCard(testUser.ID, testMemo.ID, content)
+		require.NoError(t, err, "Failed to create card")
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+		stats, err := domain.NewUserCardStats(testUser.ID, card.ID)
+		require.NoError(t, err, "Failed to create stats")
+		stats.NextReviewAt = nextReviewAt
+		stats.EaseFactor = easeFactor
+		stats.ReviewCount = 1
+		require.NoError(t, statsStore.Create(ctx, stats))
+		return card
+	}
+
+	now := time.Now().UTC()
+	mostOverdue := createCardWithStats(now.Add(-3*time.Hour), 2.5)
+	hardest := createCardWithStats(now.Add(-time.Hour), 1.3)
+	hardButLater := createCardWithStats(now.Add(-30*time.Minute), 1.3)
+	createCardWithStats(now.Add(time.Hour), 1.1) // Hardest of all, but not yet due
+
+	dueIDs := map[uuid.UUID]bool{mostOverdue.ID: true, hardest.ID: true, hardButLater.ID: true}
+
+	t.Run("due_date_asc", func(t *testing.T) {
+		card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.ReviewOrderDueDate)
+		require.NoError(t, err)
+		assert.Equal(t, mostOverdue.ID, card.ID)
+
+		// The default is the most overdue card first
+		card, err = cardStore.GetNextReviewCard(ctx, testUser.ID, "")
+		require.NoError(t, err)
+		assert.Equal(t, mostOverdue.ID, card.ID)
+	})
+
+	t.Run("lowest_ease_first", func(t *testing.T) {
+		// Ease ties are broken by due date
+		card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.ReviewOrderLowestEase)
+		require.NoError(t, err)
+		assert.Equal(t, hardest.ID, card.ID)
+
+		card, err = cardStore.GetNextDueCard(ctx, testUser.ID, nil, false, domain.ReviewOrderLowestEase)
+		require.NoError(t, err)
+		assert.Equal(t, hardest.ID, card.ID)
+	})
+
+	t.Run("random_due", func(t *testing.T) {
+		// Every pick is a due card, and repeated picks eventually vary
+		seen := map[uuid.UUID]bool{}
+		for i := 0; i < 50; i++ {
+			card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.ReviewOrderRandomDue)
+			require.NoError(t, err)
+			require.True(t, dueIDs[card.ID], "random-due served a card that is not due")
+			seen[card.ID] = true
+		}
+		assert.Greater(t, len(seen), 1, "random-due should not always serve the same card")
+	})
+
+	t.Run("unknown_order", func(t *testing.T) {
+		_, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.ReviewOrder("hardest-first"))
+		assert.ErrorIs(t, err, domain.ErrInvalidReviewOrder)
+	})
+}
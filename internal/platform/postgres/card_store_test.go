@@ -14,6 +14,7 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib" // pgx driver
 	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/store/storetest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
@@ -98,6 +99,13 @@ func TestCardStoreIntegration(t *testing.T) {
 	// Run integration tests for each CardStore method
 	t.Run("TestPostgresCardStore_CreateMultiple", TestPostgresCardStore_CreateMultiple)
 	t.Run("TestPostgresCardStore_GetNextReviewCard", TestPostgresCardStore_GetNextReviewCard)
+	t.Run("TestPostgresCardStore_GetRecentlyReviewed", TestPostgresCardStore_GetRecentlyReviewed)
+	t.Run("TestPostgresCardStore_GetByIDs", TestPostgresCardStore_GetByIDs)
+	t.Run("TestPostgresCardStore_PreparedStatements", TestPostgresCardStore_PreparedStatements)
+	t.Run("TestPostgresCardStore_FindDuplicates", TestPostgresCardStore_FindDuplicates)
+	t.Run("TestPostgresCardStore_UpdateContent_Version", TestPostgresCardStore_UpdateContent_Version)
+	t.Run("TestPostgresCardStore_SupersedeByMemo", TestPostgresCardStore_SupersedeByMemo)
+	t.Run("TestPostgresCardStore_Conformance", TestPostgresCardStore_Conformance)
 }
 
 // TestPostgresCardStore_GetNextReviewCard tests the GetNextReviewCard method
@@ -199,7 +207,7 @@ func TestPostgresCardStore_GetNextReviewCard(t *testing.T) {
 			require.NoError(t, err, "Failed to create card with future review date")
 
 			// Call GetNextReviewCard which should return ErrCardNotFound
-			_, err = cardStore.GetNextReviewCard(ctx, testUser.ID)
+			_, err = cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
 			assert.Error(t, err, "GetNextReviewCard should return an error for no due cards")
 			assert.ErrorIs(t, err, store.ErrCardNotFound, "Error should be ErrCardNotFound")
 		})
@@ -224,7 +232,7 @@ func TestPostgresCardStore_GetNextReviewCard(t *testing.T) {
 			require.NoError(t, err, "Failed to create card with past review date 3")
 
 			// Call GetNextReviewCard which should return the oldest due card
-			card, err := cardStore.GetNextReviewCard(ctx, testUser.ID)
+			card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
 			assert.NoError(t, err, "GetNextReviewCard should succeed with due cards")
 			assert.NotNil(t, card, "Returned card should not be nil")
 			assert.Equal(t, oldestCard.ID, card.ID, "Should return the oldest due card")
@@ -246,7 +254,7 @@ func TestPostgresCardStore_GetNextReviewCard(t *testing.T) {
 
 			// Call GetNextReviewCard for the test user
 			// Should only return the test user's card, even though other user has earlier card
-			card, err := cardStore.GetNextReviewCard(ctx, testUser.ID)
+			card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
 			assert.NoError(t, err, "GetNextReviewCard should succeed with due cards")
 			assert.NotNil(t, card, "Returned card should not be nil")
 			assert.Equal(t, userCard.ID, card.ID, "Should return only the test user's due card")
@@ -306,6 +314,20 @@ func TestPostgresCardStore_CreateMultiple(t *testing.T) {
 			assert.NoError(t, err, "CreateMultiple should succeed with empty list")
 		})
 
+		t.Run("cloze_card", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			content := json.RawMessage(`{"text":"The {{c1::mitochondria}} is the powerhouse of the cell"}`)
+			card, err := domain.NewCardOfType(testUser.ID, testMemo.ID, domain.CardTypeCloze, content)
+			require.NoError(t, err, "Failed to create cloze card")
+			require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+			retrievedCard, err := cardStore.GetByID(ctx, card.ID)
+			require.NoError(t, err, "GetByID should find the created card")
+			assert.Equal(t, domain.CardTypeCloze, retrievedCard.Type, "Card type should round-trip")
+		})
+
 		t.Run("single_card", func(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
 			defer cancel()
@@ -515,3 +537,895 @@ func TestPostgresCardStore_CreateMultiple(t *testing.T) {
 		})
 	})
 }
+
+// TestPostgresCardStore_GetRecentlyReviewed tests the GetRecentlyReviewed method
+func TestPostgresCardStore_GetRecentlyReviewed(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		// Create stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+
+		testUser, err := domain.NewUser("recentlyreviewed@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		testMemo, err := domain.NewMemo(testUser.ID, "Test memo for recently reviewed cards")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, testMemo), "Failed to create test memo in DB")
+
+		// Create three cards: two reviewed, one never reviewed
+		content := json.RawMessage(`{"front":"Test front","back":"Test back"}`)
+		cards := make([]*domain.Card, 3)
+		for i := range cards {
+			cards[i], err = domain.NewCard(testUser.ID, testMemo.ID, content)
+			require.NoError(t, err, "Failed to create test card")
+		}
+		require.NoError(t, cardStore.CreateMultiple(ctx, cards), "Failed to insert cards")
+
+		// Helper to seed a review event directly
+		insertEvent := func(cardID uuid.UUID, outcome domain.ReviewOutcome, at time.Time) *domain.ReviewEvent {
+			event, err := domain.NewReviewEvent(testUser.ID, cardID, outcome, at)
+			require.NoError(t, err, "Failed to create review event")
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO review_events (id, user_id, card_id, outcome, reviewed_at, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`, event.ID, event.UserID, event.CardID, string(event.Outcome), event.ReviewedAt, event.CreatedAt)
+			require.NoError(t, err, "Failed to insert review event")
+			return event
+		}
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+
+		// Card 0: three events, latest 1 hour ago
+		insertEvent(cards[0].ID, domain.ReviewOutcomeAgain, now.Add(-5*time.Hour))
+		latest0 := insertEvent(cards[0].ID, domain.ReviewOutcomeGood, now.Add(-1*time.Hour))
+		insertEvent(cards[0].ID, domain.ReviewOutcomeHard, now.Add(-3*time.Hour))
+
+		// Card 1: two events, latest 10 minutes ago
+		insertEvent(cards[1].ID, domain.ReviewOutcomeHard, now.Add(-2*time.Hour))
+		latest1 := insertEvent(cards[1].ID, domain.ReviewOutcomeEasy, now.Add(-10*time.Minute))
+
+		t.Run("returns_latest_event_per_card", func(t *testing.T) {
+			results, err := cardStore.GetRecentlyReviewed(ctx, testUser.ID, 10, 0)
+			require.NoError(t, err, "GetRecentlyReviewed should succeed")
+			require.Len(t, results, 2, "Only reviewed cards should be returned")
+
+			// Most recently reviewed card first
+			assert.Equal(t, cards[1].ID, results[0].Card.ID)
+			assert.Equal(t, latest1.ID, results[0].LastReview.ID)
+			assert.Equal(t, domain.ReviewOutcomeEasy, results[0].LastReview.Outcome)
+			assert.True(t, latest1.ReviewedAt.Equal(results[0].LastReview.ReviewedAt))
+
+			assert.Equal(t, cards[0].ID, results[1].Card.ID)
+			assert.Equal(t, latest0.ID, results[1].LastReview.ID)
+			assert.Equal(t, domain.ReviewOutcomeGood, results[1].LastReview.Outcome)
+		})
+
+		t.Run("paginates_results", func(t *testing.T) {
+			page, err := cardStore.GetRecentlyReviewed(ctx, testUser.ID, 1, 1)
+			require.NoError(t, err, "GetRecentlyReviewed should succeed")
+			require.Len(t, page, 1)
+			assert.Equal(t, cards[0].ID, page[0].Card.ID)
+
+			empty, err := cardStore.GetRecentlyReviewed(ctx, testUser.ID, 10, 2)
+			require.NoError(t, err, "GetRecentlyReviewed should succeed past the end")
+			assert.Empty(t, empty)
+		})
+
+		t.Run("other_user_sees_nothing", func(t *testing.T) {
+			results, err := cardStore.GetRecentlyReviewed(ctx, uuid.New(), 10, 0)
+			require.NoError(t, err, "GetRecentlyReviewed should succeed for unknown user")
+			assert.Empty(t, results)
+		})
+
+		t.Run("invalid_pagination", func(t *testing.T) {
+			_, err := cardStore.GetRecentlyReviewed(ctx, testUser.ID, 0, 0)
+			assert.ErrorIs(t, err, store.ErrInvalidEntity)
+
+			_, err = cardStore.GetRecentlyReviewed(ctx, testUser.ID, 10, -1)
+			assert.ErrorIs(t, err, store.ErrInvalidEntity)
+		})
+	})
+}
+
+// TestPostgresCardStore_GetByIDs tests the GetByIDs batch fetch
+func TestPostgresCardStore_GetByIDs(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		// Create stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+
+		testUser, err := domain.NewUser("getbyids@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		testMemo, err := domain.NewMemo(testUser.ID, "Test memo for batch card fetch")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, testMemo), "Failed to create test memo in DB")
+
+		cards := make([]*domain.Card, 3)
+		for i := range cards {
+			content := json.RawMessage(fmt.Sprintf(`{"front":"Front %d","back":"Back %d"}`, i, i))
+			cards[i], err = domain.NewCard(testUser.ID, testMemo.ID, content)
+			require.NoError(t, err, "Failed to create test card")
+		}
+		require.NoError(t, cardStore.CreateMultiple(ctx, cards), "Failed to insert cards")
+
+		t.Run("returns_all_present_cards", func(t *testing.T) {
+			result, err := cardStore.GetByIDs(ctx, []uuid.UUID{cards[0].ID, cards[1].ID, cards[2].ID})
+			require.NoError(t, err, "GetByIDs should succeed")
+			require.Len(t, result, 3)
+
+			for _, card := range cards {
+				got, ok := result[card.ID]
+				require.True(t, ok, "Card %s should be present", card.ID)
+				assert.Equal(t, card.UserID, got.UserID)
+				assert.Equal(t, card.MemoID, got.MemoID)
+				assert.JSONEq(t, string(card.Content), string(got.Content))
+			}
+		})
+
+		t.Run("omits_missing_ids", func(t *testing.T) {
+			missing := uuid.New()
+			result, err := cardStore.GetByIDs(ctx, []uuid.UUID{cards[0].ID, missing})
+			require.NoError(t, err, "Missing IDs should not cause an error")
+			require.Len(t, result, 1)
+			assert.Contains(t, result, cards[0].ID)
+			assert.NotContains(t, result, missing)
+		})
+
+		t.Run("order_does_not_matter", func(t *testing.T) {
+			forward, err := cardStore.GetByIDs(ctx, []uuid.UUID{cards[0].ID, cards[1].ID, cards[2].ID})
+			require.NoError(t, err)
+			reversed, err := cardStore.GetByIDs(ctx, []uuid.UUID{cards[2].ID, cards[1].ID, cards[0].ID})
+			require.NoError(t, err)
+
+			require.Len(t, reversed, len(forward))
+			for id, card := range forward {
+				require.Contains(t, reversed, id)
+				assert.Equal(t, card.ID, reversed[id].ID)
+			}
+		})
+
+		t.Run("empty_input", func(t *testing.T) {
+			result, err := cardStore.GetByIDs(ctx, nil)
+			require.NoError(t, err)
+			assert.Empty(t, result)
+		})
+	})
+}
+
+// TestPostgresCardStore_PreparedStatements checks that a store on a *sql.DB
+// prepares its fixed queries once and runs them inside transactions
+func TestPostgresCardStore_PreparedStatements(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	dbCardStore := NewPostgresCardStore(db, nil)
+	require.NotNil(t, dbCardStore.stmts, "a store on a *sql.DB caches statements")
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := dbCardStore.WithTx(tx)
+
+		testUser, err := domain.NewUser("prepared@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		testMemo, err := domain.NewMemo(testUser.ID, "Test memo for prepared statements")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, testMemo), "Failed to create test memo in DB")
+
+		cards := make([]*domain.Card, 2)
+		for i := range cards {
+			content := json.RawMessage(fmt.Sprintf(`{"front":"Prepared %d","back":"Back %d"}`, i, i))
+			cards[i], err = domain.NewCard(testUser.ID, testMemo.ID, content)
+			require.NoError(t, err, "Failed to create test card")
+		}
+		require.NoError(t, cardStore.CreateMultiple(ctx, cards), "Failed to insert cards")
+
+		// Both reads use the same prepared statement and see the transaction's rows
+		for _, card := range cards {
+			got, err := cardStore.GetByID(ctx, card.ID)
+			require.NoError(t, err, "GetByID should see cards inserted in the transaction")
+			assert.Equal(t, card.ID, got.ID)
+			assert.Equal(t, domain.InitialCardVersion, got.Version)
+			assert.JSONEq(t, string(card.Content), string(got.Content))
+		}
+
+		cached := func() int {
+			dbCardStore.stmts.mu.Lock()
+			defer dbCardStore.stmts.mu.Unlock()
+			return len(dbCardStore.stmts.stmts)
+		}
+		assert.Equal(t, 2, cached(), "the insert and the select are each prepared once")
+
+		// Every other query is prepared on first use too, and reused after that
+		queries := []func() error{
+			func() error { return cardStore.SetSuspended(ctx, cards[0].ID, nil) },
+			func() error { return cardStore.SetBuriedUntil(ctx, cards[0].ID, nil) },
+			func() error {
+				_, err := cardStore.ListByTag(ctx, testUser.ID, "prepared", 10, 0)
+				return err
+			},
+			func() error {
+				_, err := cardStore.CountByUser(ctx, testUser.ID)
+				return err
+			},
+			func() error {
+				return cardStore.ForEachWithStats(ctx, testUser.ID, false, func(*domain.CardWithStats) error {
+					return nil
+				})
+			},
+		}
+		for pass := 0; pass < 2; pass++ {
+			for _, query := range queries {
+				require.NoError(t, query())
+			}
+			assert.Equal(t, 2+len(queries), cached(), "each query is prepared once")
+		}
+	})
+}
+
+// TestPostgresCardStore_FindDuplicates tests duplicate detection across memos
+func TestPostgresCardStore_FindDuplicates(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		// Create stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+
+		testUser, err := domain.NewUser("findduplicates@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		otherUser, err := domain.NewUser("findduplicates-other@example.com", "password123456")
+		require.NoError(t, err, "Failed to create other user")
+		require.NoError(t, userStore.Create(ctx, otherUser), "Failed to create other user in DB")
+
+		newMemo := func(userID uuid.UUID) *domain.Memo {
+			memo, err := domain.NewMemo(userID, "Test memo for duplicate detection")
+			require.NoError(t, err, "Failed to create test memo")
+			require.NoError(t, memoStore.Create(ctx, memo), "Failed to create test memo in DB")
+			return memo
+		}
+		memoA := newMemo(testUser.ID)
+		memoB := newMemo(testUser.ID)
+		otherMemo := newMemo(otherUser.ID)
+
+		baseTime := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+		insertCard := func(userID, memoID uuid.UUID, content string, offset time.Duration) *domain.Card {
+			card, err := domain.NewCard(userID, memoID, json.RawMessage(content))
+			require.NoError(t, err, "Failed to create test card")
+			card.CreatedAt = baseTime.Add(offset)
+			card.UpdatedAt = card.CreatedAt
+			require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+			return card
+		}
+
+		// Same content across two memos, with different key order and whitespace
+		capitalA := insertCard(testUser.ID, memoA.ID, `{"front":"Capital of France?","back":"Paris"}`, 0)
+		capitalB := insertCard(testUser.ID, memoB.ID, `{"back": "Paris", "front": "Capital of France?"}`, time.Minute)
+		// Three copies of another card
+		water := []*domain.Card{
+			insertCard(testUser.ID, memoA.ID, `{"front":"H2O?","back":"Water"}`, 2*time.Minute),
+			insertCard(testUser.ID, memoB.ID, `{"front":"H2O?","back":"Water"}`, 3*time.Minute),
+			insertCard(testUser.ID, memoB.ID, `{"front":"H2O?","back":"Water"}`, 4*time.Minute),
+		}
+		// Unique card and another user's copy of a duplicate
+		insertCard(testUser.ID, memoA.ID, `{"front":"Unique?","back":"Yes"}`, 5*time.Minute)
+		insertCard(otherUser.ID, otherMemo.ID, `{"front":"H2O?","back":"Water"}`, 6*time.Minute)
+
+		t.Run("groups_duplicates_across_memos", func(t *testing.T) {
+			groups, err := cardStore.FindDuplicates(ctx, testUser.ID)
+			require.NoError(t, err, "FindDuplicates should succeed")
+			require.Len(t, groups, 2, "Unique cards should not form a group")
+
+			byFirstCard := make(map[uuid.UUID]*domain.DuplicateCardGroup)
+			for _, group := range groups {
+				assert.NotEmpty(t, group.ContentHash)
+				byFirstCard[group.Cards[0].ID] = group
+			}
+
+			capitalGroup := byFirstCard[capitalA.ID]
+			require.NotNil(t, capitalGroup, "Capital cards should be grouped with the oldest first")
+			require.Len(t, capitalGroup.Cards, 2)
+			assert.Equal(t, capitalB.ID, capitalGroup.Cards[1].ID)
+
+			waterGroup := byFirstCard[water[0].ID]
+			require.NotNil(t, waterGroup, "Water cards should be grouped with the oldest first")
+			require.Len(t, waterGroup.Cards, 3, "Other users' cards must not be included")
+			for i, card := range water {
+				assert.Equal(t, card.ID, waterGroup.Cards[i].ID)
+				assert.Equal(t, testUser.ID, waterGroup.Cards[i].UserID)
+			}
+		})
+
+		t.Run("no_duplicates", func(t *testing.T) {
+			groups, err := cardStore.FindDuplicates(ctx, uuid.New())
+			require.NoError(t, err, "FindDuplicates should succeed for unknown user")
+			assert.Empty(t, groups)
+		})
+	})
+}
+
+// TestPostgresCardStore_ForEachWithStats tests streaming a user's active cards
+// with their stats for export
+func TestPostgresCardStore_ForEachWithStats(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		// Create stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+		statsStore := NewPostgresUserCardStatsStore(tx, nil)
+
+		testUser, err := domain.NewUser("foreachwithstats@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		memo, err := domain.NewMemo(testUser.ID, "Test memo for export")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, memo), "Failed to create test memo in DB")
+
+		baseTime := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+		insertCard := func(content string, offset time.Duration) *domain.Card {
+			card, err := domain.NewCard(testUser.ID, memo.ID, json.RawMessage(content))
+			require.NoError(t, err, "Failed to create test card")
+			card.CreatedAt = baseTime.Add(offset)
+			card.UpdatedAt = card.CreatedAt
+			require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+			return card
+		}
+
+		withStats := insertCard(`{"front":"Q1","back":"A1"}`, 0)
+		stats, err := domain.NewUserCardStats(testUser.ID, withStats.ID)
+		require.NoError(t, err)
+		stats.ReviewCount = 4
+		stats.Interval = 3
+		require.NoError(t, statsStore.Create(ctx, stats))
+		withoutStats := insertCard(`{"front":"Q2","back":"A2"}`, time.Minute)
+
+		t.Run("streams_cards_oldest_first", func(t *testing.T) {
+			var exported []*domain.CardWithStats
+			err := cardStore.ForEachWithStats(ctx, testUser.ID, false, func(card *domain.CardWithStats) error {
+				exported = append(exported, card)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, exported, 2)
+
+			assert.Equal(t, withStats.ID, exported[0].Card.ID)
+			require.NotNil(t, exported[0].Stats)
+			assert.Equal(t, 4, exported[0].Stats.ReviewCount)
+			assert.Equal(t, 3, exported[0].Stats.Interval)
+
+			assert.Equal(t, withoutStats.ID, exported[1].Card.ID)
+			assert.Nil(t, exported[1].Stats, "Cards without stats are exported with nil stats")
+		})
+
+		t.Run("stops_at_callback_error", func(t *testing.T) {
+			stop := errors.New("stop")
+			calls := 0
+			err := cardStore.ForEachWithStats(ctx, testUser.ID, false, func(*domain.CardWithStats) error {
+				calls++
+				return stop
+			})
+			assert.ErrorIs(t, err, stop)
+			assert.Equal(t, 1, calls)
+		})
+
+		t.Run("superseded_cards_only_when_included", func(t *testing.T) {
+			_, err := cardStore.SupersedeByMemo(ctx, memo.ID, time.Now().UTC())
+			require.NoError(t, err)
+
+			calls := 0
+			err = cardStore.ForEachWithStats(ctx, testUser.ID, false, func(*domain.CardWithStats) error {
+				calls++
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Zero(t, calls)
+
+			var archived []*domain.CardWithStats
+			err = cardStore.ForEachWithStats(ctx, testUser.ID, true, func(card *domain.CardWithStats) error {
+				archived = append(archived, card)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, archived, 2)
+			for _, card := range archived {
+				assert.True(t, card.Card.IsSuperseded())
+			}
+		})
+	})
+}
+
+// TestPostgresCardStore_SupersedeByMemo tests that superseded cards are kept but
+// excluded from duplicate detection, card counts and deck listings
+func TestPostgresCardStore_SupersedeByMemo(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		// Create stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		deckStore := NewPostgresDeckStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+
+		testUser, err := domain.NewUser("supersede@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		deck, err := domain.NewDeck(testUser.ID, "Supersede deck")
+		require.NoError(t, err, "Failed to create test deck")
+		require.NoError(t, deckStore.Create(ctx, deck), "Failed to create test deck in DB")
+
+		memo, err := domain.NewMemo(testUser.ID, "Memo to regenerate")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, memo), "Failed to create test memo in DB")
+
+		insertCard := func(content string) *domain.Card {
+			card, err := domain.NewCard(testUser.ID, memo.ID, json.RawMessage(content))
+			require.NoError(t, err, "Failed to create test card")
+			require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+			require.NoError(t, cardStore.SetDeck(ctx, card.ID, &deck.ID))
+			return card
+		}
+
+		// The old card and its regenerated replacement have identical content
+		oldCard := insertCard(`{"front":"Capital of France?","back":"Paris"}`)
+
+		count, err := cardStore.SupersedeByMemo(ctx, memo.ID, time.Now().UTC())
+		require.NoError(t, err, "SupersedeByMemo should succeed")
+		assert.Equal(t, 1, count)
+
+		newCard := insertCard(`{"front":"Capital of France?","back":"Paris"}`)
+
+		t.Run("superseded_card_is_kept", func(t *testing.T) {
+			card, err := cardStore.GetByID(ctx, oldCard.ID)
+			require.NoError(t, err)
+			assert.True(t, card.IsSuperseded())
+		})
+
+		t.Run("excluded_from_duplicates", func(t *testing.T) {
+			groups, err := cardStore.FindDuplicates(ctx, testUser.ID)
+			require.NoError(t, err)
+			assert.Empty(t, groups, "Regenerated card must not duplicate the card it replaced")
+		})
+
+		t.Run("excluded_from_count", func(t *testing.T) {
+			count, err := cardStore.CountByUser(ctx, testUser.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
+
+		t.Run("excluded_from_deck_listing", func(t *testing.T) {
+			cards, err := cardStore.ListByDeck(ctx, deck.ID, 10, 0)
+			require.NoError(t, err)
+			require.Len(t, cards, 1)
+			assert.Equal(t, newCard.ID, cards[0].ID)
+		})
+
+		t.Run("delete_by_memo_removes_all_cards", func(t *testing.T) {
+			count, err := cardStore.DeleteByMemo(ctx, memo.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			_, err = cardStore.GetByID(ctx, oldCard.ID)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+		})
+	})
+}
+
+// TestPostgresCardStore_SetSuspended tests that suspended cards are excluded from
+// review and due counts but still listed, and return to review when unsuspended
+func TestPostgresCardStore_SetSuspended(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		// Create stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		deckStore := NewPostgresDeckStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+		statsStore := NewPostgresUserCardStatsStore(tx, nil)
+
+		testUser, err := domain.NewUser("suspend@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		deck, err := domain.NewDeck(testUser.ID, "Suspend deck")
+		require.NoError(t, err, "Failed to create test deck")
+		require.NoError(t, deckStore.Create(ctx, deck), "Failed to create test deck in DB")
+
+		memo, err := domain.NewMemo(testUser.ID, "Memo with a suspended card")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, memo), "Failed to create test memo in DB")
+
+		// A single due card, so the next card is either it or nothing
+		card, err := domain.NewCard(testUser.ID, memo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+		require.NoError(t, err, "Failed to create test card")
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+		require.NoError(t, cardStore.SetDeck(ctx, card.ID, &deck.ID))
+		stats, err := domain.NewUserCardStats(testUser.ID, card.ID)
+		require.NoError(t, err, "Failed to create test stats")
+		stats.NextReviewAt = time.Now().UTC().Add(-time.Hour)
+		require.NoError(t, statsStore.Create(ctx, stats))
+
+		suspendedAt := time.Now().UTC().Truncate(time.Microsecond)
+		require.NoError(t, cardStore.SetSuspended(ctx, card.ID, &suspendedAt))
+
+		t.Run("suspended_card_is_kept", func(t *testing.T) {
+			got, err := cardStore.GetByID(ctx, card.ID)
+			require.NoError(t, err)
+			require.True(t, got.IsSuspended())
+			assert.True(t, suspendedAt.Equal(*got.SuspendedAt))
+		})
+
+		t.Run("excluded_from_review", func(t *testing.T) {
+			_, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+
+			_, err = cardStore.GetNextDueCard(ctx, testUser.ID, &deck.ID, true, domain.DefaultReviewOrder)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+		})
+
+		t.Run("excluded_from_due_count", func(t *testing.T) {
+			count, err := statsStore.CountDue(ctx, testUser.ID, time.Now().UTC())
+			require.NoError(t, err)
+			assert.Zero(t, count)
+		})
+
+		t.Run("still_listed", func(t *testing.T) {
+			cards, err := cardStore.ListByDeck(ctx, deck.ID, 10, 0)
+			require.NoError(t, err)
+			require.Len(t, cards, 1)
+			assert.True(t, cards[0].IsSuspended())
+		})
+
+		t.Run("unsuspended_card_returns_to_review", func(t *testing.T) {
+			require.NoError(t, cardStore.SetSuspended(ctx, card.ID, nil))
+
+			next, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
+			require.NoError(t, err)
+			assert.Equal(t, card.ID, next.ID)
+			assert.False(t, next.IsSuspended())
+
+			count, err := statsStore.CountDue(ctx, testUser.ID, time.Now().UTC())
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
+
+		t.Run("missing_card", func(t *testing.T) {
+			err := cardStore.SetSuspended(ctx, uuid.New(), &suspendedAt)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+		})
+	})
+}
+
+// TestPostgresCardStore_SetBuriedUntil tests that buried cards are skipped for
+// review until the burial ends, without changing their schedule
+func TestPostgresCardStore_SetBuriedUntil(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		// Create stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+		statsStore := NewPostgresUserCardStatsStore(tx, nil)
+
+		testUser, err := domain.NewUser("bury@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		memo, err := domain.NewMemo(testUser.ID, "Memo with a buried card")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, memo), "Failed to create test memo in DB")
+
+		// A single due card, so the next card is either it or nothing
+		card, err := domain.NewCard(testUser.ID, memo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+		require.NoError(t, err, "Failed to create test card")
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+		stats, err := domain.NewUserCardStats(testUser.ID, card.ID)
+		require.NoError(t, err, "Failed to create test stats")
+		stats.NextReviewAt = time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+		require.NoError(t, statsStore.Create(ctx, stats))
+
+		buriedUntil := testUser.EndOfDay(time.Now())
+		require.NoError(t, cardStore.SetBuriedUntil(ctx, card.ID, &buriedUntil))
+
+		t.Run("buried_card_is_skipped", func(t *testing.T) {
+			got, err := cardStore.GetByID(ctx, card.ID)
+			require.NoError(t, err)
+			require.NotNil(t, got.BuriedUntil)
+			assert.True(t, buriedUntil.Equal(*got.BuriedUntil))
+
+			_, err = cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+
+			_, err = cardStore.GetNextDueCard(ctx, testUser.ID, nil, true, domain.DefaultReviewOrder)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+		})
+
+		t.Run("schedule_is_unchanged", func(t *testing.T) {
+			got, err := statsStore.Get(ctx, testUser.ID, card.ID)
+			require.NoError(t, err)
+			assert.True(t, stats.NextReviewAt.Equal(got.NextReviewAt))
+
+			count, err := statsStore.CountDue(ctx, testUser.ID, time.Now().UTC())
+			require.NoError(t, err)
+			assert.Equal(t, 1, count, "Buried cards are still due")
+		})
+
+		t.Run("reappears_after_day_rolls_over", func(t *testing.T) {
+			// Simulate the next day by moving the end of the burial into the past
+			yesterday := buriedUntil.AddDate(0, 0, -1).Add(-time.Minute)
+			require.NoError(t, cardStore.SetBuriedUntil(ctx, card.ID, &yesterday))
+
+			next, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
+			require.NoError(t, err)
+			assert.Equal(t, card.ID, next.ID)
+			assert.False(t, next.IsBuried(time.Now()))
+		})
+
+		t.Run("missing_card", func(t *testing.T) {
+			err := cardStore.SetBuriedUntil(ctx, uuid.New(), &buriedUntil)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+		})
+	})
+}
+
+// TestPostgresCardStore_UpdateContent_Version tests optimistic concurrency on content updates
+func TestPostgresCardStore_UpdateContent_Version(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		// Create stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+
+		testUser, err := domain.NewUser("cardversion@example.com", "password123456")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+
+		testMemo, err := domain.NewMemo(testUser.ID, "Test memo for card versions")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, testMemo), "Failed to create test memo in DB")
+
+		card, err := domain.NewCard(testUser.ID, testMemo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+		require.NoError(t, err, "Failed to create test card")
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+		stored, err := cardStore.GetByID(ctx, card.ID)
+		require.NoError(t, err)
+		require.Equal(t, domain.InitialCardVersion, stored.Version, "New cards start at version 1")
+
+		t.Run("successful_update_increments_version", func(t *testing.T) {
+			newVersion, err := cardStore.UpdateContent(ctx, card.ID, []byte(`{"front":"Q2","back":"A2"}`), 1)
+			require.NoError(t, err)
+			assert.Equal(t, 2, newVersion)
+
+			updated, err := cardStore.GetByID(ctx, card.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, updated.Version)
+			assert.JSONEq(t, `{"front":"Q2","back":"A2"}`, string(updated.Content))
+		})
+
+		t.Run("stale_version_conflicts", func(t *testing.T) {
+			_, err := cardStore.UpdateContent(ctx, card.ID, []byte(`{"front":"Stale","back":"Edit"}`), 1)
+			assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+			unchanged, err := cardStore.GetByID(ctx, card.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, unchanged.Version)
+			assert.JSONEq(t, `{"front":"Q2","back":"A2"}`, string(unchanged.Content))
+		})
+
+		t.Run("missing_card", func(t *testing.T) {
+			_, err := cardStore.UpdateContent(ctx, uuid.New(), []byte(`{"front":"Q","back":"A"}`), 1)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+		})
+	})
+}
+
+// newConformanceStoreFactory returns a storetest.Factory that builds the PostgreSQL
+// stores on a transaction of db, rolled back when each conformance subtest ends
+func newConformanceStoreFactory(db *sql.DB) storetest.Factory {
+	return func(t *testing.T) storetest.Stores {
+		tx, err := db.BeginTx(context.Background(), nil)
+		require.NoError(t, err, "Failed to begin transaction")
+		t.Cleanup(func() {
+			_ = tx.Rollback() // Intentionally ignoring error as it's cleanup code
+		})
+
+		return storetest.Stores{
+			Users:        NewPostgresUserStore(tx, bcrypt.MinCost),
+			Memos:        NewPostgresMemoStore(tx, nil),
+			Cards:        NewPostgresCardStore(tx, nil),
+			Stats:        NewPostgresUserCardStatsStore(tx, nil),
+			ReviewEvents: NewPostgresReviewEventStore(tx, nil),
+			Tx:           tx,
+		}
+	}
+}
+
+// TestPostgresCardStore_Conformance runs the shared CardStore conformance suite
+func TestPostgresCardStore_Conformance(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		_ = db.Close()
+	}()
+
+	storetest.RunCardStoreSuite(t, newConformanceStoreFactory(db))
+}
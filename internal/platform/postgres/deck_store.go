@@ -0,0 +1,425 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// deckNameUniqueConstraint is the unique constraint on a user's deck names
+const deckNameUniqueConstraint = "uq_decks_user_name"
+
+// deckColumns lists the deck columns in the order scanned by scanDeck
+const deckColumns = `id, user_id, name, new_cards_per_day, again_review_minutes,
+		initial_ease_factor, learning_steps, new_card_interval_days, new_cards_due_immediately,
+		review_order, created_at, updated_at`
+
+// Compile-time check to ensure PostgresDeckStore implements store.DeckStore
+var _ store.DeckStore = (*PostgresDeckStore)(nil)
+
+// PostgresDeckStore implements the store.DeckStore interface
+// using a PostgreSQL database as the storage backend.
+type PostgresDeckStore struct {
+	db     store.DBTX
+	logger *slog.Logger
+}
+
+// NewPostgresDeckStore creates a new PostgreSQL implementation of the DeckStore interface.
+// It accepts a database connection or transaction that should be initialized and managed by the caller.
+// If logger is nil, a default logger will be used.
+func NewPostgresDeckStore(db store.DBTX, logger *slog.Logger) *PostgresDeckStore {
+	// Validate inputs
+	if db == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("db cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &PostgresDeckStore{
+		db:     db,
+		logger: logger.With(slog.String("component", "deck_store")),
+	}
+}
+
+// Create implements store.DeckStore.Create
+// Returns store.ErrDeckNameExists if the user already has a deck with the same name.
+func (s *PostgresDeckStore) Create(ctx context.Context, deck *domain.Deck) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if err := deck.Validate(); err != nil {
+		log.Warn("deck validation failed during create",
+			slog.String("error", err.Error()),
+			slog.String("deck_id", deck.ID.String()))
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	query := `
+		INSERT INTO decks (` + deckColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := s.db.ExecContext(
+		ctx,
+		query,
+		deck.ID,
+		deck.UserID,
+		deck.Name,
+		nullableInt(deck.SRSSettings.NewCardsPerDay),
+		nullableInt(deck.SRSSettings.AgainReviewMinutes),
+		nullableFloat(deck.SRSSettings.InitialEaseFactor),
+		formatLearningSteps(deck.SRSSettings.LearningSteps),
+		nullableInt(deck.SRSSettings.NewCardIntervalDays),
+		nullableBool(deck.SRSSettings.NewCardsDueImmediately),
+		nullableReviewOrder(deck.SRSSettings.ReviewOrder),
+		deck.CreatedAt,
+		deck.UpdatedAt,
+	)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			log.Debug("deck name already exists for user",
+				slog.String("user_id", deck.UserID.String()))
+			return MapUniqueViolation(err, "deck", deckNameUniqueConstraint, store.ErrDeckNameExists)
+		}
+		log.Error("failed to create deck",
+			slog.String("error", err.Error()),
+			slog.String("deck_id", deck.ID.String()),
+			slog.String("user_id", deck.UserID.String()))
+		return fmt.Errorf("failed to create deck: %w", MapError(err))
+	}
+
+	log.Debug("deck created successfully",
+		slog.String("deck_id", deck.ID.String()),
+		slog.String("user_id", deck.UserID.String()))
+	return nil
+}
+
+// GetByID implements store.DeckStore.GetByID
+// Returns store.ErrDeckNotFound if the deck does not exist.
+func (s *PostgresDeckStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Deck, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT ` + deckColumns + `
+		FROM decks
+		WHERE id = $1
+	`
+
+	deck, err := scanDeck(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if IsNotFoundError(err) {
+			log.Debug("deck not found", slog.String("deck_id", id.String()))
+			return nil, store.ErrDeckNotFound
+		}
+		log.Error("failed to get deck by ID",
+			slog.String("error", err.Error()),
+			slog.String("deck_id", id.String()))
+		return nil, fmt.Errorf("failed to get deck by ID: %w", MapError(err))
+	}
+
+	return deck, nil
+}
+
+// GetByName implements store.DeckStore.GetByName
+func (s *PostgresDeckStore) GetByName(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+) (*domain.Deck, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT ` + deckColumns + `
+		FROM decks
+		WHERE user_id = $1 AND name = $2
+	`
+
+	deck, err := scanDeck(s.db.QueryRowContext(ctx, query, userID, name))
+	if err != nil {
+		if IsNotFoundError(err) {
+			log.Debug("deck not found by name", slog.String("user_id", userID.String()))
+			return nil, store.ErrDeckNotFound
+		}
+		log.Error("failed to get deck by name",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to get deck by name: %w", MapError(err))
+	}
+
+	return deck, nil
+}
+
+// ListByUser implements store.DeckStore.ListByUser
+// Decks are ordered by name, then ID for a deterministic order.
+func (s *PostgresDeckStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Deck, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT ` + deckColumns + `
+		FROM decks
+		WHERE user_id = $1
+		ORDER BY name ASC, id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Error("failed to list decks",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to list decks: %w", MapError(err))
+	}
+	defer func() {
+		_ = rows.Close() // Ignoring error as it's cleanup code
+	}()
+
+	decks := make([]*domain.Deck, 0)
+	for rows.Next() {
+		deck, err := scanDeck(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deck: %w", MapError(err))
+		}
+		decks = append(decks, deck)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate decks: %w", MapError(err))
+	}
+
+	log.Debug("listed decks for user",
+		slog.String("user_id", userID.String()),
+		slog.Int("count", len(decks)))
+	return decks, nil
+}
+
+// Update implements store.DeckStore.Update
+// Returns store.ErrDeckNotFound if the deck does not exist and
+// store.ErrDeckNameExists if the new name is already used by another of the user's decks.
+func (s *PostgresDeckStore) Update(ctx context.Context, deck *domain.Deck) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if err := deck.Validate(); err != nil {
+		log.Warn("deck validation failed during update",
+			slog.String("error", err.Error()),
+			slog.String("deck_id", deck.ID.String()))
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	query := `
+		UPDATE decks
+		SET name = $1, new_cards_per_day = $2, again_review_minutes = $3,
+			initial_ease_factor = $4, learning_steps = $5, new_card_interval_days = $6,
+			new_cards_due_immediately = $7, review_order = $8, updated_at = $9
+		WHERE id = $10
+	`
+
+	result, err := s.db.ExecContext(
+		ctx,
+		query,
+		deck.Name,
+		nullableInt(deck.SRSSettings.NewCardsPerDay),
+		nullableInt(deck.SRSSettings.AgainReviewMinutes),
+		nullableFloat(deck.SRSSettings.InitialEaseFactor),
+		formatLearningSteps(deck.SRSSettings.LearningSteps),
+		nullableInt(deck.SRSSettings.NewCardIntervalDays),
+		nullableBool(deck.SRSSettings.NewCardsDueImmediately),
+		nullableReviewOrder(deck.SRSSettings.ReviewOrder),
+		deck.UpdatedAt,
+		deck.ID,
+	)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			log.Debug("deck name already exists for user",
+				slog.String("user_id", deck.UserID.String()))
+			return MapUniqueViolation(err, "deck", deckNameUniqueConstraint, store.ErrDeckNameExists)
+		}
+		log.Error("failed to update deck",
+			slog.String("error", err.Error()),
+			slog.String("deck_id", deck.ID.String()))
+		return fmt.Errorf("failed to update deck: %w", MapError(err))
+	}
+
+	if err := CheckRowsAffected(result, "deck"); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return store.ErrDeckNotFound
+		}
+		return fmt.Errorf("failed to update deck: %w", err)
+	}
+
+	log.Debug("deck updated successfully", slog.String("deck_id", deck.ID.String()))
+	return nil
+}
+
+// Delete implements store.DeckStore.Delete
+// Cards in the deck keep existing; the fk_cards_deck constraint clears their deck_id.
+func (s *PostgresDeckStore) Delete(ctx context.Context, id uuid.UUID) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM decks WHERE id = $1`, id)
+	if err != nil {
+		log.Error("failed to delete deck",
+			slog.String("error", err.Error()),
+			slog.String("deck_id", id.String()))
+		return fmt.Errorf("failed to delete deck: %w", MapError(err))
+	}
+
+	if err := CheckRowsAffected(result, "deck"); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return store.ErrDeckNotFound
+		}
+		return fmt.Errorf("failed to delete deck: %w", err)
+	}
+
+	log.Debug("deck deleted successfully", slog.String("deck_id", id.String()))
+	return nil
+}
+
+// WithTx implements store.DeckStore.WithTx
+// It returns a new DeckStore instance that uses the provided transaction.
+func (s *PostgresDeckStore) WithTx(tx *sql.Tx) store.DeckStore {
+	return &PostgresDeckStore{
+		db:     tx,
+		logger: s.logger,
+	}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanDeck scans a row of deckColumns into a Deck, leaving unset SRS overrides nil
+func scanDeck(row rowScanner) (*domain.Deck, error) {
+	var (
+		deck               domain.Deck
+		newCardsPerDay     sql.NullInt64
+		againReviewMinutes sql.NullInt64
+		initialEaseFactor  sql.NullFloat64
+		learningSteps      sql.NullString
+		newCardInterval    sql.NullInt64
+		newCardsDueNow     sql.NullBool
+		reviewOrder        sql.NullString
+	)
+	if err := row.Scan(
+		&deck.ID,
+		&deck.UserID,
+		&deck.Name,
+		&newCardsPerDay,
+		&againReviewMinutes,
+		&initialEaseFactor,
+		&learningSteps,
+		&newCardInterval,
+		&newCardsDueNow,
+		&reviewOrder,
+		&deck.CreatedAt,
+		&deck.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if newCardsPerDay.Valid {
+		v := int(newCardsPerDay.Int64)
+		deck.SRSSettings.NewCardsPerDay = &v
+	}
+	if againReviewMinutes.Valid {
+		v := int(againReviewMinutes.Int64)
+		deck.SRSSettings.AgainReviewMinutes = &v
+	}
+	if initialEaseFactor.Valid {
+		deck.SRSSettings.InitialEaseFactor = &initialEaseFactor.Float64
+	}
+	if learningSteps.Valid {
+		steps, err := parseLearningSteps(learningSteps.String)
+		if err != nil {
+			return nil, err
+		}
+		deck.SRSSettings.LearningSteps = steps
+	}
+	if newCardInterval.Valid {
+		v := int(newCardInterval.Int64)
+		deck.SRSSettings.NewCardIntervalDays = &v
+	}
+	if newCardsDueNow.Valid {
+		deck.SRSSettings.NewCardsDueImmediately = &newCardsDueNow.Bool
+	}
+	if reviewOrder.Valid {
+		order := domain.ReviewOrder(reviewOrder.String)
+		deck.SRSSettings.ReviewOrder = &order
+	}
+	return &deck, nil
+}
+
+// formatLearningSteps encodes optional learning steps as the comma-separated
+// minutes stored in the learning_steps column
+func formatLearningSteps(steps []int) sql.NullString {
+	if steps == nil {
+		return sql.NullString{}
+	}
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		parts[i] = strconv.Itoa(step)
+	}
+	return sql.NullString{String: strings.Join(parts, ","), Valid: true}
+}
+
+// parseLearningSteps decodes the comma-separated minutes of the learning_steps column
+func parseLearningSteps(value string) ([]int, error) {
+	parts := strings.Split(value, ",")
+	steps := make([]int, len(parts))
+	for i, part := range parts {
+		step, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid learning steps %q: %w", value, err)
+		}
+		steps[i] = step
+	}
+	return steps, nil
+}
+
+// nullableInt converts an optional override to a nullable column value
+func nullableInt(v *int) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*v), Valid: true}
+}
+
+// nullableFloat converts an optional override to a nullable column value
+func nullableFloat(v *float64) sql.NullFloat64 {
+	if v == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *v, Valid: true}
+}
+
+// nullableBool converts an optional override to a nullable column value
+func nullableBool(v *bool) sql.NullBool {
+	if v == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *v, Valid: true}
+}
+
+// nullableReviewOrder converts an optional review order to a nullable column value
+func nullableReviewOrder(v *domain.ReviewOrder) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(*v), Valid: true}
+}
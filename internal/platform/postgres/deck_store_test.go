@@ -0,0 +1,355 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// beginDeckTestTx opens a transaction that is rolled back when the test ends
+func beginDeckTestTx(ctx context.Context, t *testing.T) *sql.Tx {
+	t.Helper()
+
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	t.Cleanup(func() { _ = db.Close() })
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = tx.Rollback() // Intentionally ignoring error as it's cleanup code
+	})
+	return tx
+}
+
+func TestPostgresDeckStore_CRUD(t *testing.T) {
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx := beginDeckTestTx(ctx, t)
+	deckStore := postgres.NewPostgresDeckStore(tx, nil)
+
+	userID := testutils.MustInsertUser(ctx, t, tx, "deck-crud@example.com", bcrypt.MinCost)
+	otherUserID := testutils.MustInsertUser(ctx, t, tx, "deck-crud-other@example.com", bcrypt.MinCost)
+
+	spanish, err := domain.NewDeck(userID, "Spanish")
+	require.NoError(t, err)
+	require.NoError(t, deckStore.Create(ctx, spanish))
+
+	anatomy, err := domain.NewDeck(userID, "Anatomy")
+	require.NoError(t, err)
+	require.NoError(t, deckStore.Create(ctx, anatomy))
+
+	t.Run("get_by_id", func(t *testing.T) {
+		deck, err := deckStore.GetByID(ctx, spanish.ID)
+		require.NoError(t, err)
+		assert.Equal(t, spanish.ID, deck.ID)
+		assert.Equal(t, userID, deck.UserID)
+		assert.Equal(t, "Spanish", deck.Name)
+	})
+
+	t.Run("get_missing_deck", func(t *testing.T) {
+		_, err := deckStore.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, store.ErrDeckNotFound)
+	})
+
+	t.Run("get_by_name", func(t *testing.T) {
+		deck, err := deckStore.GetByName(ctx, userID, "Spanish")
+		require.NoError(t, err)
+		assert.Equal(t, spanish.ID, deck.ID)
+
+		_, err = deckStore.GetByName(ctx, userID, "spanish")
+		assert.ErrorIs(t, err, store.ErrDeckNotFound, "Names are matched exactly")
+
+		_, err = deckStore.GetByName(ctx, otherUserID, "Spanish")
+		assert.ErrorIs(t, err, store.ErrDeckNotFound)
+	})
+
+	t.Run("list_is_ordered_by_name", func(t *testing.T) {
+		decks, err := deckStore.ListByUser(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, decks, 2)
+		assert.Equal(t, anatomy.ID, decks[0].ID)
+		assert.Equal(t, spanish.ID, decks[1].ID)
+
+		decks, err = deckStore.ListByUser(ctx, otherUserID)
+		require.NoError(t, err)
+		assert.Empty(t, decks)
+	})
+
+	t.Run("names_are_unique_per_user", func(t *testing.T) {
+		// Savepoint so the failed insert does not abort the surrounding transaction
+		_, err := tx.ExecContext(ctx, "SAVEPOINT duplicate_deck")
+		require.NoError(t, err)
+
+		duplicate, err := domain.NewDeck(userID, "Spanish")
+		require.NoError(t, err)
+		assert.ErrorIs(t, deckStore.Create(ctx, duplicate), store.ErrDeckNameExists)
+
+		_, err = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT duplicate_deck")
+		require.NoError(t, err)
+
+		// Another user may use the same name
+		otherSpanish, err := domain.NewDeck(otherUserID, "Spanish")
+		require.NoError(t, err)
+		assert.NoError(t, deckStore.Create(ctx, otherSpanish))
+	})
+
+	t.Run("update_renames_deck", func(t *testing.T) {
+		require.NoError(t, anatomy.Rename("Human Anatomy"))
+		require.NoError(t, deckStore.Update(ctx, anatomy))
+
+		deck, err := deckStore.GetByID(ctx, anatomy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Human Anatomy", deck.Name)
+	})
+
+	t.Run("update_stores_review_order", func(t *testing.T) {
+		order := domain.ReviewOrderRandomDue
+		require.NoError(t, anatomy.SetSRSSettings(domain.DeckSRSSettings{ReviewOrder: &order}))
+		require.NoError(t, deckStore.Update(ctx, anatomy))
+
+		deck, err := deckStore.GetByID(ctx, anatomy.ID)
+		require.NoError(t, err)
+		require.NotNil(t, deck.SRSSettings.ReviewOrder)
+		assert.Equal(t, order, *deck.SRSSettings.ReviewOrder)
+
+		require.NoError(t, anatomy.SetSRSSettings(domain.DeckSRSSettings{}))
+		require.NoError(t, deckStore.Update(ctx, anatomy))
+		deck, err = deckStore.GetByID(ctx, anatomy.ID)
+		require.NoError(t, err)
+		assert.Nil(t, deck.SRSSettings.ReviewOrder, "Clearing the order falls back to the default")
+	})
+
+	t.Run("update_missing_deck", func(t *testing.T) {
+		missing, err := domain.NewDeck(userID, "Missing")
+		require.NoError(t, err)
+		assert.ErrorIs(t, deckStore.Update(ctx, missing), store.ErrDeckNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, deckStore.Delete(ctx, anatomy.ID))
+
+		_, err := deckStore.GetByID(ctx, anatomy.ID)
+		assert.ErrorIs(t, err, store.ErrDeckNotFound)
+		assert.ErrorIs(t, deckStore.Delete(ctx, anatomy.ID), store.ErrDeckNotFound)
+	})
+}
+
+func TestPostgresCardStore_Decks(t *testing.T) {
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx := beginDeckTestTx(ctx, t)
+	deckStore := postgres.NewPostgresDeckStore(tx, nil)
+	cardStore := postgres.NewPostgresCardStore(tx, nil)
+	statsStore := postgres.NewPostgresUserCardStatsStore(tx, nil)
+
+	userID := testutils.MustInsertUser(ctx, t, tx, "deck-cards@example.com", bcrypt.MinCost)
+	memo := testutils.MustInsertMemo(ctx, t, tx, userID)
+
+	deck, err := domain.NewDeck(userID, "Spanish")
+	require.NoError(t, err)
+	require.NoError(t, deckStore.Create(ctx, deck))
+
+	now := time.Now().UTC()
+	createCardWithStats := func(nextReviewAt time.Time) *domain.Card {
+		content := json.RawMessage(`{"front":"Test front","back":"Test back"}`)
+		card, err := domain.NewCard(userID, memo.ID, content)
+		require.NoError(t, err)
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+		stats, err := domain.NewUserCardStats(userID, card.ID)
+		require.NoError(t, err)
+		stats.NextReviewAt = nextReviewAt
+		require.NoError(t, statsStore.Create(ctx, stats))
+		return card
+	}
+
+	// The most overdue card is outside the deck, so it must not be served for the deck
+	outside := createCardWithStats(now.Add(-3 * time.Hour))
+	dueInDeck := createCardWithStats(now.Add(-time.Hour))
+	laterInDeck := createCardWithStats(now.Add(time.Hour))
+
+	t.Run("no_due_cards_before_assignment", func(t *testing.T) {
+		_, err := cardStore.GetNextDueCard(ctx, userID, &deck.ID, true, domain.DefaultReviewOrder)
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+	})
+
+	require.NoError(t, cardStore.SetDeck(ctx, dueInDeck.ID, &deck.ID))
+	require.NoError(t, cardStore.SetDeck(ctx, laterInDeck.ID, &deck.ID))
+
+	t.Run("assignment_is_persisted", func(t *testing.T) {
+		card, err := cardStore.GetByID(ctx, dueInDeck.ID)
+		require.NoError(t, err)
+		require.NotNil(t, card.DeckID)
+		assert.Equal(t, deck.ID, *card.DeckID)
+
+		card, err = cardStore.GetByID(ctx, outside.ID)
+		require.NoError(t, err)
+		assert.Nil(t, card.DeckID)
+	})
+
+	t.Run("list_by_deck", func(t *testing.T) {
+		cards, err := cardStore.ListByDeck(ctx, deck.ID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		assert.Equal(t, dueInDeck.ID, cards[0].ID)
+		assert.Equal(t, laterInDeck.ID, cards[1].ID)
+
+		cards, err = cardStore.ListByDeck(ctx, deck.ID, 10, 1)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		assert.Equal(t, laterInDeck.ID, cards[0].ID)
+
+		_, err = cardStore.ListByDeck(ctx, deck.ID, 0, 0)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+	})
+
+	t.Run("next_due_card_in_deck", func(t *testing.T) {
+		card, err := cardStore.GetNextDueCard(ctx, userID, &deck.ID, true, domain.DefaultReviewOrder)
+		require.NoError(t, err)
+		assert.Equal(t, dueInDeck.ID, card.ID)
+
+		// The deck has no cards that have been reviewed before
+		_, err = cardStore.GetNextDueCard(ctx, userID, &deck.ID, false, domain.DefaultReviewOrder)
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+
+		// The unscoped queue still serves the most overdue card
+		card, err = cardStore.GetNextDueCard(ctx, userID, nil, true, domain.DefaultReviewOrder)
+		require.NoError(t, err)
+		assert.Equal(t, outside.ID, card.ID)
+	})
+
+	t.Run("forecast_is_deck_scoped", func(t *testing.T) {
+		sumCounts := func(deckID *uuid.UUID) int {
+			buckets, err := statsStore.GetForecast(ctx, userID, deckID, 2)
+			require.NoError(t, err)
+			total := 0
+			for _, bucket := range buckets {
+				total += bucket.Count
+			}
+			return total
+		}
+
+		assert.Equal(t, 2, sumCounts(&deck.ID))
+		assert.Equal(t, 3, sumCounts(nil))
+
+		otherDeckID := uuid.New()
+		assert.Equal(t, 0, sumCounts(&otherDeckID))
+	})
+
+	t.Run("set_deck_errors", func(t *testing.T) {
+		assert.ErrorIs(t, cardStore.SetDeck(ctx, uuid.New(), &deck.ID), store.ErrCardNotFound)
+
+		// Savepoint so the foreign key violation does not abort the surrounding transaction
+		_, err := tx.ExecContext(ctx, "SAVEPOINT missing_deck")
+		require.NoError(t, err)
+		missingDeckID := uuid.New()
+		assert.ErrorIs(t, cardStore.SetDeck(ctx, outside.ID, &missingDeckID), store.ErrInvalidEntity)
+		_, err = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT missing_deck")
+		require.NoError(t, err)
+	})
+
+	t.Run("unassign_card", func(t *testing.T) {
+		require.NoError(t, cardStore.SetDeck(ctx, laterInDeck.ID, nil))
+
+		card, err := cardStore.GetByID(ctx, laterInDeck.ID)
+		require.NoError(t, err)
+		assert.Nil(t, card.DeckID)
+	})
+
+	t.Run("deleting_deck_keeps_cards", func(t *testing.T) {
+		require.NoError(t, deckStore.Delete(ctx, deck.ID))
+
+		card, err := cardStore.GetByID(ctx, dueInDeck.ID)
+		require.NoError(t, err)
+		assert.Nil(t, card.DeckID)
+	})
+}
+
+func TestPostgresMemoStore_Decks(t *testing.T) {
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx := beginDeckTestTx(ctx, t)
+	deckStore := postgres.NewPostgresDeckStore(tx, nil)
+	memoStore := postgres.NewPostgresMemoStore(tx, nil)
+
+	userID := testutils.MustInsertUser(ctx, t, tx, "deck-memos@example.com", bcrypt.MinCost)
+	deck, err := domain.NewDeck(userID, "Spanish")
+	require.NoError(t, err)
+	require.NoError(t, deckStore.Create(ctx, deck))
+
+	t.Run("create_with_deck", func(t *testing.T) {
+		memo, err := domain.NewMemo(userID, "Notes in a deck")
+		require.NoError(t, err)
+		memo.DeckID = &deck.ID
+		require.NoError(t, memoStore.Create(ctx, memo))
+
+		stored, err := memoStore.GetByID(ctx, memo.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.DeckID)
+		assert.Equal(t, deck.ID, *stored.DeckID)
+	})
+
+	memo := testutils.MustInsertMemo(ctx, t, tx, userID)
+
+	t.Run("set_deck_and_keep_it_on_update", func(t *testing.T) {
+		require.NoError(t, memoStore.SetDeck(ctx, memo.ID, &deck.ID))
+
+		stored, err := memoStore.GetByID(ctx, memo.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.DeckID)
+		assert.Equal(t, deck.ID, *stored.DeckID)
+
+		// Update does not change the deck, even when the memo passed has none
+		stored.DeckID = nil
+		stored.Text = "Updated notes"
+		require.NoError(t, memoStore.Update(ctx, stored))
+
+		stored, err = memoStore.GetByID(ctx, memo.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.DeckID)
+		assert.Equal(t, deck.ID, *stored.DeckID)
+	})
+
+	t.Run("deleting_deck_leaves_memo_without_deck", func(t *testing.T) {
+		require.NoError(t, deckStore.Delete(ctx, deck.ID))
+
+		stored, err := memoStore.GetByID(ctx, memo.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.DeckID)
+	})
+
+	// A missing deck fails the statement, which aborts the transaction, so this runs last
+	t.Run("missing_memo_and_deck", func(t *testing.T) {
+		assert.ErrorIs(t, memoStore.SetDeck(ctx, uuid.New(), nil), store.ErrMemoNotFound)
+
+		missing := uuid.New()
+		assert.ErrorIs(t, memoStore.SetDeck(ctx, memo.ID, &missing), store.ErrReferencedEntityMissing)
+	})
+}
@@ -0,0 +1,19 @@
+package postgres
+
+// dueCondition returns the SQL condition matching domain.IsCardDue for rows of the
+// user_card_stats table referenced by alias. asOf is a SQL expression or placeholder
+// evaluating to the instant at which cards are considered due. As in the domain
+// predicate, cards superseded by a memo regeneration or suspended by the user are
+// never due.
+//
+// Every query that decides whether a card is due must build its condition here so
+// that next-card selection, due counts and forecasts cannot drift apart.
+// Both arguments must be constant SQL fragments, never user input.
+func dueCondition(alias, asOf string) string {
+	return alias + ".next_review_at <= " + asOf + `
+		  AND NOT EXISTS (
+			SELECT 1 FROM cards excluded
+			WHERE excluded.id = ` + alias + `.card_id
+			  AND (excluded.superseded_at IS NOT NULL OR excluded.suspended_at IS NOT NULL)
+		  )`
+}
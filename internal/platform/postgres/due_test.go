@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestDueConsumersAgree verifies that next-card selection, due counts and the review
+// forecast all agree with domain.IsCardDue on which cards are due under identical
+// parameters, including for suspended and superseded cards.
+func TestDueConsumersAgree(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkStatsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForStatsStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForStatsTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testStatsTimeout)
+		defer cancel()
+
+		// Create necessary stores
+		userStore := NewPostgresUserStore(tx, bcrypt.MinCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+		statsStore := NewPostgresUserCardStatsStore(tx, nil)
+
+		const timezone = "Asia/Tokyo"
+		testUser, err := domain.NewUser("testdueagreement@example.com", "password123")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+		_, err = tx.ExecContext(ctx, "UPDATE users SET timezone = $1 WHERE id = $2", timezone, testUser.ID)
+		require.NoError(t, err, "Failed to set user timezone")
+		testUser.Timezone = timezone
+
+		testMemo, err := domain.NewMemo(testUser.ID, "Test memo for due agreement tests")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, testMemo), "Failed to create test memo in DB")
+
+		// NOW() is fixed for the whole transaction, so it is the "current time" every
+		// query sees; reading it back lets the domain predicate use the same instant
+		var now time.Time
+		require.NoError(t, tx.QueryRowContext(ctx, "SELECT NOW()").Scan(&now))
+		endOfDay := testUser.EndOfDay(now)
+
+		// Seed cards on both sides of "now" and of the end of the user's day,
+		// including the exact boundary instants
+		dueTimes := []time.Time{
+			now.Add(-48 * time.Hour),
+			now.Add(-time.Second),
+			now,
+			now.Add(domain.DueResolution),
+			endOfDay.Add(-domain.DueResolution),
+			endOfDay,
+			endOfDay.Add(time.Hour),
+		}
+		seeded := make(map[uuid.UUID]*domain.CardWithStats, len(dueTimes)+2)
+		seedCard := func(memo *domain.Memo, dueAt time.Time) *domain.Card {
+			content := json.RawMessage(fmt.Sprintf(`{"front":"Due %d","back":"Back"}`, len(seeded)))
+			card, err := domain.NewCard(testUser.ID, memo.ID, content)
+			require.NoError(t, err, "Failed to create test card")
+			require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+			_, err = tx.ExecContext(ctx,
+				"UPDATE user_card_stats SET next_review_at = $1 WHERE user_id = $2 AND card_id = $3",
+				dueAt, testUser.ID, card.ID)
+			require.NoError(t, err, "Failed to set next review time")
+			seeded[card.ID] = &domain.CardWithStats{
+				Card:  card,
+				Stats: &domain.UserCardStats{UserID: testUser.ID, CardID: card.ID, NextReviewAt: dueAt},
+			}
+			return card
+		}
+		for _, dueAt := range dueTimes {
+			seedCard(testMemo, dueAt)
+		}
+
+		// Overdue cards that are never due because they are suspended or superseded
+		suspended := seedCard(testMemo, now.Add(-48*time.Hour))
+		suspendedAt := now.Add(-time.Hour)
+		require.NoError(t, cardStore.SetSuspended(ctx, suspended.ID, &suspendedAt))
+		suspended.SuspendedAt = &suspendedAt
+
+		oldMemo, err := domain.NewMemo(testUser.ID, "Regenerated memo for due agreement tests")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, oldMemo), "Failed to create test memo in DB")
+		superseded := seedCard(oldMemo, now.Add(-48*time.Hour))
+		_, err = cardStore.SupersedeByMemo(ctx, oldMemo.ID, now)
+		require.NoError(t, err, "Failed to supersede card")
+		superseded.SupersededAt = &now
+
+		countDomainDue := func(asOf time.Time) int {
+			count := 0
+			for _, item := range seeded {
+				if domain.IsCardDue(item.Card, item.Stats, asOf) {
+					count++
+				}
+			}
+			return count
+		}
+
+		t.Run("due_by_end_of_day", func(t *testing.T) {
+			asOf := testUser.DueAsOfEndOfDay(now)
+			expected := countDomainDue(asOf)
+			require.Equal(t, 5, expected, "Seed data should straddle the end of the day")
+
+			count, err := statsStore.CountDue(ctx, testUser.ID, asOf)
+			require.NoError(t, err)
+			assert.Equal(t, expected, count, "CountDue should agree with domain.IsCardDue")
+
+			buckets, err := statsStore.GetForecast(ctx, testUser.ID, nil, 1)
+			require.NoError(t, err)
+			require.Len(t, buckets, 1)
+			assert.Equal(t, expected, buckets[0].Count,
+				"Today's forecast bucket should agree with domain.IsCardDue")
+		})
+
+		// Runs last because it reschedules the cards it drains
+		t.Run("due_now", func(t *testing.T) {
+			expected := countDomainDue(now)
+			require.Equal(t, 3, expected, "Seed data should straddle the current time")
+
+			count, err := statsStore.CountDue(ctx, testUser.ID, now)
+			require.NoError(t, err)
+			assert.Equal(t, expected, count, "CountDue should agree with domain.IsCardDue")
+
+			// Drain the queue, pushing each served card out of the due window
+			served := 0
+			for {
+				card, err := cardStore.GetNextReviewCard(ctx, testUser.ID, domain.DefaultReviewOrder)
+				if errors.Is(err, store.ErrCardNotFound) {
+					break
+				}
+				require.NoError(t, err)
+				require.Less(t, served, len(dueTimes), "GetNextReviewCard should not repeat cards")
+
+				item := seeded[card.ID]
+				require.NotNil(t, item, "GetNextReviewCard served an unknown card")
+				assert.True(t, domain.IsCardDue(item.Card, item.Stats, now),
+					"GetNextReviewCard served a card that is not due")
+				served++
+
+				_, err = tx.ExecContext(ctx,
+					"UPDATE user_card_stats SET next_review_at = $1 WHERE user_id = $2 AND card_id = $3",
+					now.AddDate(1, 0, 0), testUser.ID, card.ID)
+				require.NoError(t, err, "Failed to reschedule served card")
+			}
+			assert.Equal(t, expected, served, "GetNextReviewCard should serve every due card")
+		})
+	})
+}
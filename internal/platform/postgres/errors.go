@@ -46,7 +46,7 @@ func MapError(err error) error {
 		case foreignKeyViolationCode:
 			return fmt.Errorf(
 				"%w: foreign key violation (%s): %v",
-				store.ErrInvalidEntity,
+				store.ErrReferencedEntityMissing,
 				pgErr.ConstraintName,
 				err,
 			)
@@ -71,6 +71,44 @@ func MapError(err error) error {
 	return err
 }
 
+// MapEntityError maps a database error like MapError, but reports sql.ErrNoRows as
+// notFound and unique violations as duplicate, so that callers receive the errors
+// documented for a specific entity (e.g. store.ErrCardNotFound).
+// A nil duplicate falls back to store.ErrDuplicate.
+func MapEntityError(err error, notFound error, duplicate error) error {
+	if err == nil {
+		return nil
+	}
+
+	if duplicate == nil {
+		duplicate = store.ErrDuplicate
+	}
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("%w: %v", notFound, err)
+	case IsUniqueViolation(err):
+		return fmt.Errorf("%w: %v", duplicate, err)
+	default:
+		return MapError(err)
+	}
+}
+
+// mapMemoError maps an error from a memo query to the memo store's errors
+func mapMemoError(err error) error {
+	return MapEntityError(err, store.ErrMemoNotFound, store.ErrDuplicate)
+}
+
+// mapCardError maps an error from a card query to the card store's errors
+func mapCardError(err error) error {
+	return MapEntityError(err, store.ErrCardNotFound, store.ErrDuplicate)
+}
+
+// mapUserCardStatsError maps an error from a user card stats query to the stats store's errors
+func mapUserCardStatsError(err error) error {
+	return MapEntityError(err, store.ErrUserCardStatsNotFound, store.ErrDuplicate)
+}
+
 // IsUniqueViolation checks if the given error is a PostgreSQL unique constraint violation.
 // This is useful for detecting duplicate records that violate unique constraints.
 func IsUniqueViolation(err error) bool {
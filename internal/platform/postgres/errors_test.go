@@ -355,7 +355,7 @@ func TestMapError(t *testing.T) {
 		{
 			name:   "foreign key violation",
 			err:    newPgError("23503"),
-			errIs:  store.ErrInvalidEntity,
+			errIs:  store.ErrReferencedEntityMissing,
 			errMsg: "foreign key violation",
 		},
 		{
@@ -410,6 +410,88 @@ func TestMapError(t *testing.T) {
 	}
 }
 
+// TestMapEntityError checks that each entity's store errors are used for
+// missing rows and unique violations, with other errors mapped as by MapError
+func TestMapEntityError(t *testing.T) {
+	t.Parallel()
+
+	entities := []struct {
+		name      string
+		notFound  error
+		duplicate error
+	}{
+		{"memo", store.ErrMemoNotFound, store.ErrDuplicate},
+		{"card", store.ErrCardNotFound, store.ErrDuplicate},
+		{"user_card_stats", store.ErrUserCardStatsNotFound, store.ErrDuplicate},
+		{"deck", store.ErrDeckNotFound, store.ErrDeckNameExists},
+	}
+
+	for _, entity := range entities {
+		tests := []struct {
+			name  string
+			err   error
+			errIs []error
+		}{
+			{
+				name:  "no_rows",
+				err:   sql.ErrNoRows,
+				errIs: []error{entity.notFound, store.ErrNotFound},
+			},
+			{
+				name:  "wrapped_no_rows",
+				err:   fmt.Errorf("scan failed: %w", sql.ErrNoRows),
+				errIs: []error{entity.notFound, store.ErrNotFound},
+			},
+			{
+				name:  "unique_violation",
+				err:   newPgError("23505"),
+				errIs: []error{entity.duplicate, store.ErrDuplicate},
+			},
+			{
+				name:  "foreign_key_violation",
+				err:   newPgError("23503"),
+				errIs: []error{store.ErrReferencedEntityMissing, store.ErrInvalidEntity},
+			},
+			{
+				name:  "check_violation",
+				err:   newPgError("23514"),
+				errIs: []error{store.ErrInvalidEntity},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(entity.name+"/"+tt.name, func(t *testing.T) {
+				t.Parallel()
+				result := postgres.MapEntityError(tt.err, entity.notFound, entity.duplicate)
+
+				for _, target := range tt.errIs {
+					assert.ErrorIs(t, result, target)
+				}
+				if !errors.Is(tt.err, sql.ErrNoRows) {
+					assert.False(t, store.IsNotFoundError(result), "only missing rows are not-found errors")
+				}
+			})
+		}
+	}
+
+	t.Run("nil_error", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, postgres.MapEntityError(nil, store.ErrCardNotFound, nil))
+	})
+
+	t.Run("nil_duplicate_uses_generic_duplicate", func(t *testing.T) {
+		t.Parallel()
+		result := postgres.MapEntityError(newPgError("23505"), store.ErrCardNotFound, nil)
+		assert.ErrorIs(t, result, store.ErrDuplicate)
+	})
+
+	t.Run("unmapped_error_returned_unchanged", func(t *testing.T) {
+		t.Parallel()
+		err := newPgError("42P01") // undefined_table
+		assert.Same(t, err, postgres.MapEntityError(err, store.ErrCardNotFound, nil))
+	})
+}
+
 // TestMapUniqueViolation tests the MapUniqueViolation function
 func TestMapUniqueViolation(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure PostgresGenerationUsageStore implements store.GenerationUsageStore
+var _ store.GenerationUsageStore = (*PostgresGenerationUsageStore)(nil)
+
+// PostgresGenerationUsageStore implements the store.GenerationUsageStore interface
+// using a PostgreSQL database as the storage backend.
+type PostgresGenerationUsageStore struct {
+	db     store.DBTX
+	logger *slog.Logger
+}
+
+// NewPostgresGenerationUsageStore creates a new PostgreSQL implementation of the GenerationUsageStore interface.
+// It accepts a database connection or transaction that should be initialized and managed by the caller.
+// If logger is nil, a default logger will be used.
+func NewPostgresGenerationUsageStore(db store.DBTX, logger *slog.Logger) *PostgresGenerationUsageStore {
+	// Validate inputs
+	if db == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("db cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &PostgresGenerationUsageStore{
+		db:     db,
+		logger: logger.With(slog.String("component", "generation_usage_store")),
+	}
+}
+
+// Reserve implements store.GenerationUsageStore.Reserve
+// The quota check is part of the upsert, so concurrent reservations cannot
+// both take the last generation of a period.
+func (s *PostgresGenerationUsageStore) Reserve(
+	ctx context.Context,
+	userID uuid.UUID,
+	period time.Time,
+	limit int,
+) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		INSERT INTO generation_usage (user_id, period, generations)
+		VALUES ($1, $2::date, 1)
+		ON CONFLICT (user_id, period)
+		DO UPDATE SET generations = generation_usage.generations + 1
+		WHERE $3::integer <= 0 OR generation_usage.generations < $3::integer
+	`
+
+	result, err := s.db.ExecContext(ctx, query, userID, period.UTC(), limit)
+	if err != nil {
+		log.Error("failed to reserve generation",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return fmt.Errorf("failed to reserve generation: %w", MapError(err))
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		log.Error("failed to get rows affected",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return fmt.Errorf("failed to reserve generation: %w", MapError(err))
+	}
+	if rows == 0 {
+		log.Debug("generation quota exceeded",
+			slog.String("user_id", userID.String()),
+			slog.Int("limit", limit))
+		return store.ErrQuotaExceeded
+	}
+
+	log.Debug("generation reserved",
+		slog.String("user_id", userID.String()),
+		slog.Time("period", period))
+	return nil
+}
+
+// Release implements store.GenerationUsageStore.Release
+func (s *PostgresGenerationUsageStore) Release(
+	ctx context.Context,
+	userID uuid.UUID,
+	period time.Time,
+) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		UPDATE generation_usage
+		SET generations = generations - 1
+		WHERE user_id = $1 AND period = $2::date AND generations > 0
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, period.UTC()); err != nil {
+		log.Error("failed to release generation",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return fmt.Errorf("failed to release generation: %w", MapError(err))
+	}
+
+	log.Debug("generation released",
+		slog.String("user_id", userID.String()),
+		slog.Time("period", period))
+	return nil
+}
+
+// Count implements store.GenerationUsageStore.Count
+func (s *PostgresGenerationUsageStore) Count(
+	ctx context.Context,
+	userID uuid.UUID,
+	period time.Time,
+) (int, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT COALESCE(SUM(generations), 0)
+		FROM generation_usage
+		WHERE user_id = $1 AND period = $2::date
+	`
+
+	var count int
+	err := s.db.QueryRowContext(ctx, query, userID, period.UTC()).Scan(&count)
+	if err != nil {
+		log.Error("failed to count generations",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return 0, fmt.Errorf("failed to count generations: %w", MapError(err))
+	}
+
+	return count, nil
+}
+
+// WithTx implements store.GenerationUsageStore.WithTx
+// It returns a new GenerationUsageStore instance that uses the provided transaction.
+func (s *PostgresGenerationUsageStore) WithTx(tx *sql.Tx) store.GenerationUsageStore {
+	return &PostgresGenerationUsageStore{
+		db:     tx,
+		logger: s.logger,
+	}
+}
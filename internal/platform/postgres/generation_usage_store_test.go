@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestPostgresGenerationUsageStore_Reserve tests that generations are counted
+// per user and period and refused once the limit is reached
+func TestPostgresGenerationUsageStore_Reserve(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx := context.Background()
+		userStore := NewPostgresUserStore(tx, bcrypt.MinCost)
+		usageStore := NewPostgresGenerationUsageStore(tx, nil)
+
+		createUser := func(email string) *domain.User {
+			user, err := domain.NewUser(email, "password123456")
+			require.NoError(t, err, "Failed to create test user")
+			require.NoError(t, userStore.Create(ctx, user), "Failed to create test user in DB")
+			return user
+		}
+
+		march := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+		april := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+		t.Run("limit is enforced per period", func(t *testing.T) {
+			user := createUser("generation-usage-limit@example.com")
+
+			count, err := usageStore.Count(ctx, user.ID, march)
+			require.NoError(t, err)
+			assert.Zero(t, count)
+
+			require.NoError(t, usageStore.Reserve(ctx, user.ID, march, 2))
+			require.NoError(t, usageStore.Reserve(ctx, user.ID, march, 2))
+			assert.ErrorIs(t, usageStore.Reserve(ctx, user.ID, march, 2), store.ErrQuotaExceeded)
+
+			count, err = usageStore.Count(ctx, user.ID, march)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count, "Refused reservations are not counted")
+
+			// A new period starts from zero
+			require.NoError(t, usageStore.Reserve(ctx, user.ID, april, 2))
+			count, err = usageStore.Count(ctx, user.ID, april)
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
+
+		t.Run("zero limit is unlimited", func(t *testing.T) {
+			user := createUser("generation-usage-unlimited@example.com")
+
+			for i := 0; i < 3; i++ {
+				require.NoError(t, usageStore.Reserve(ctx, user.ID, march, 0))
+			}
+			count, err := usageStore.Count(ctx, user.ID, march)
+			require.NoError(t, err)
+			assert.Equal(t, 3, count)
+		})
+
+		t.Run("released generations can be reserved again", func(t *testing.T) {
+			user := createUser("generation-usage-release@example.com")
+
+			require.NoError(t, usageStore.Reserve(ctx, user.ID, march, 1))
+			assert.ErrorIs(t, usageStore.Reserve(ctx, user.ID, march, 1), store.ErrQuotaExceeded)
+
+			require.NoError(t, usageStore.Release(ctx, user.ID, march))
+			require.NoError(t, usageStore.Reserve(ctx, user.ID, march, 1))
+
+			// Releasing more than was reserved stops at zero
+			require.NoError(t, usageStore.Release(ctx, user.ID, march))
+			require.NoError(t, usageStore.Release(ctx, user.ID, march))
+			require.NoError(t, usageStore.Release(ctx, user.ID, april))
+			count, err := usageStore.Count(ctx, user.ID, march)
+			require.NoError(t, err)
+			assert.Zero(t, count)
+		})
+
+		t.Run("user quota round trip", func(t *testing.T) {
+			user := createUser("generation-usage-override@example.com")
+
+			stored, err := userStore.GetByID(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Nil(t, stored.GenerationQuota, "New users use the default quota")
+
+			quota := 5
+			stored.GenerationQuota = &quota
+			require.NoError(t, userStore.Update(ctx, stored))
+
+			stored, err = userStore.GetByID(ctx, user.ID)
+			require.NoError(t, err)
+			require.NotNil(t, stored.GenerationQuota)
+			assert.Equal(t, 5, *stored.GenerationQuota)
+		})
+
+		// The foreign key violation aborts the transaction, so this runs last
+		t.Run("unknown user", func(t *testing.T) {
+			err := usageStore.Reserve(ctx, uuid.New(), march, 1)
+			assert.ErrorIs(t, err, store.ErrReferencedEntityMissing)
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure PostgresIdempotencyKeyStore implements store.IdempotencyKeyStore
+var _ store.IdempotencyKeyStore = (*PostgresIdempotencyKeyStore)(nil)
+
+// PostgresIdempotencyKeyStore implements the store.IdempotencyKeyStore interface
+// using a PostgreSQL database as the storage backend.
+type PostgresIdempotencyKeyStore struct {
+	db     store.DBTX
+	logger *slog.Logger
+}
+
+// NewPostgresIdempotencyKeyStore creates a new PostgreSQL implementation of the IdempotencyKeyStore interface.
+// It accepts a database connection or transaction that should be initialized and managed by the caller.
+// If logger is nil, a default logger will be used.
+func NewPostgresIdempotencyKeyStore(db store.DBTX, logger *slog.Logger) *PostgresIdempotencyKeyStore {
+	// Validate inputs
+	if db == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("db cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &PostgresIdempotencyKeyStore{
+		db:     db,
+		logger: logger.With(slog.String("component", "idempotency_key_store")),
+	}
+}
+
+// Create implements store.IdempotencyKeyStore.Create
+// Returns store.ErrDuplicate if the user has already used the key.
+func (s *PostgresIdempotencyKeyStore) Create(ctx context.Context, key *domain.IdempotencyKey) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if err := key.Validate(); err != nil {
+		log.Warn("idempotency key validation failed during create",
+			slog.String("error", err.Error()),
+			slog.String("user_id", key.UserID.String()))
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, request_hash, memo_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := s.db.ExecContext(
+		ctx,
+		query,
+		key.UserID,
+		key.Key,
+		key.RequestHash,
+		key.MemoID,
+		key.CreatedAt,
+	)
+	if err != nil {
+		log.Error("failed to create idempotency key",
+			slog.String("error", err.Error()),
+			slog.String("user_id", key.UserID.String()),
+			slog.String("memo_id", key.MemoID.String()))
+		return fmt.Errorf("failed to create idempotency key: %w", MapError(err))
+	}
+
+	log.Debug("idempotency key created",
+		slog.String("user_id", key.UserID.String()),
+		slog.String("memo_id", key.MemoID.String()))
+	return nil
+}
+
+// Get implements store.IdempotencyKeyStore.Get
+// Returns store.ErrIdempotencyKeyNotFound if the user has not used the key.
+func (s *PostgresIdempotencyKeyStore) Get(
+	ctx context.Context,
+	userID uuid.UUID,
+	key string,
+) (*domain.IdempotencyKey, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT user_id, key, request_hash, memo_id, created_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`
+
+	var k domain.IdempotencyKey
+	err := s.db.QueryRowContext(ctx, query, userID, key).Scan(
+		&k.UserID,
+		&k.Key,
+		&k.RequestHash,
+		&k.MemoID,
+		&k.CreatedAt,
+	)
+	if err != nil {
+		if IsNotFoundError(err) {
+			return nil, store.ErrIdempotencyKeyNotFound
+		}
+		log.Error("failed to get idempotency key",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to get idempotency key: %w", MapError(err))
+	}
+
+	return &k, nil
+}
+
+// Delete implements store.IdempotencyKeyStore.Delete
+func (s *PostgresIdempotencyKeyStore) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, key); err != nil {
+		log.Error("failed to delete idempotency key",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return fmt.Errorf("failed to delete idempotency key: %w", MapError(err))
+	}
+
+	return nil
+}
+
+// WithTx implements store.IdempotencyKeyStore.WithTx
+// It returns a new IdempotencyKeyStore instance that uses the provided transaction.
+func (s *PostgresIdempotencyKeyStore) WithTx(tx *sql.Tx) store.IdempotencyKeyStore {
+	return &PostgresIdempotencyKeyStore{
+		db:     tx,
+		logger: s.logger,
+	}
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/platform/tracing"
+	"github.com/phrazzld/scry-api/internal/redact"
+	"github.com/phrazzld/scry-api/internal/store"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Compile-time check to ensure instrumentedDBTX implements DBTX
+var _ store.DBTX = (*instrumentedDBTX)(nil)
+
+// QueryObserver receives the duration of every query run through an instrumented
+// database handle, e.g. to record a latency metric. Operation is "exec", "query" or
+// "query_row"; err is the error the query returned, if any.
+type QueryObserver func(ctx context.Context, operation string, elapsed time.Duration, err error)
+
+// InstrumentOption configures an instrumented database handle
+type InstrumentOption func(*instrumentedDBTX)
+
+// WithQueryObserver reports the duration of every query to observer
+func WithQueryObserver(observer QueryObserver) InstrumentOption {
+	return func(d *instrumentedDBTX) {
+		d.observer = observer
+	}
+}
+
+// WithTracing records a span for every query, as a child of the span in the
+// query's context. Spans carry the operation and the SQL with sensitive values
+// redacted; query arguments are never recorded.
+func WithTracing() InstrumentOption {
+	return func(d *instrumentedDBTX) {
+		d.tracing = true
+	}
+}
+
+// instrumentedDBTX wraps a store.DBTX, timing each query and logging those that
+// take longer than a threshold. Results and errors are returned unchanged.
+type instrumentedDBTX struct {
+	db        store.DBTX
+	threshold time.Duration
+	logger    *slog.Logger
+	observer  QueryObserver
+	tracing   bool
+}
+
+// NewInstrumentedDB wraps db so that ExecContext, QueryContext and QueryRowContext
+// calls taking at least threshold are logged as slow queries, with sensitive values
+// redacted from the SQL. Query arguments are never logged.
+//
+// For QueryContext the time covers running the query and receiving the first rows,
+// not iterating over the result. Transactions started from the underlying *sql.DB
+// are not instrumented. A negative threshold disables slow query logging, for
+// handles instrumented only to observe or trace queries.
+func NewInstrumentedDB(
+	db store.DBTX,
+	threshold time.Duration,
+	logger *slog.Logger,
+	opts ...InstrumentOption,
+) store.DBTX {
+	if db == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("db cannot be nil")
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	d := &instrumentedDBTX{
+		db:        db,
+		threshold: threshold,
+		logger:    logger,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// ExecContext implements store.DBTX
+func (d *instrumentedDBTX) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := d.startSpan(ctx, "exec", query)
+	start := time.Now()
+	result, err := d.db.ExecContext(ctx, query, args...)
+	d.observe(ctx, "exec", query, time.Since(start), err)
+	tracing.End(span, err)
+	return result, err
+}
+
+// PrepareContext implements store.DBTX. Preparing a statement is not timed.
+func (d *instrumentedDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.db.PrepareContext(ctx, query)
+}
+
+// QueryContext implements store.DBTX
+func (d *instrumentedDBTX) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := d.startSpan(ctx, "query", query)
+	start := time.Now()
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	d.observe(ctx, "query", query, time.Since(start), err)
+	tracing.End(span, err)
+	return rows, err
+}
+
+// QueryRowContext implements store.DBTX
+func (d *instrumentedDBTX) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := d.startSpan(ctx, "query_row", query)
+	start := time.Now()
+	row := d.db.QueryRowContext(ctx, query, args...)
+	// Err does not consume the row, so the caller's Scan still sees the same result
+	d.observe(ctx, "query_row", query, time.Since(start), row.Err())
+	tracing.End(span, row.Err())
+	return row
+}
+
+// startSpan starts a span for a query when tracing is enabled; otherwise it
+// returns ctx unchanged and a span that records nothing
+func (d *instrumentedDBTX) startSpan(ctx context.Context, operation string, query string) (context.Context, trace.Span) {
+	if !d.tracing {
+		return ctx, noop.Span{}
+	}
+	return tracing.Start(ctx, "db."+operation,
+		semconv.DBSystemPostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(redact.String(query)),
+	)
+}
+
+// observe reports a finished query to the observer and logs it if it was slow
+func (d *instrumentedDBTX) observe(
+	ctx context.Context,
+	operation string,
+	query string,
+	elapsed time.Duration,
+	err error,
+) {
+	if d.observer != nil {
+		d.observer(ctx, operation, elapsed, err)
+	}
+
+	if d.threshold < 0 || elapsed < d.threshold {
+		return
+	}
+
+	attrs := []any{
+		slog.String("operation", operation),
+		slog.Int64("duration_ms", elapsed.Milliseconds()),
+		slog.Int64("threshold_ms", d.threshold.Milliseconds()),
+		slog.String("query", redact.String(query)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redact.Error(err)))
+	}
+	logger.FromContextOrDefault(ctx, d.logger).Warn("slow database query", attrs...)
+}
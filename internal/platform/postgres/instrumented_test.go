@@ -0,0 +1,119 @@
+package postgres_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowQueryEntries decodes the JSON log lines in buf that report slow queries
+func slowQueryEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var entries []map[string]any
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var entry map[string]any
+		require.NoError(t, decoder.Decode(&entry))
+		if entry["msg"] == "slow database query" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestInstrumentedDB(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("logs_slow_query", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		db := postgres.NewInstrumentedDB(testDB, 50*time.Millisecond, logger)
+
+		_, err := db.ExecContext(ctx, "SELECT pg_sleep(0.1)")
+		require.NoError(t, err)
+
+		entries := slowQueryEntries(t, &buf)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "WARN", entries[0]["level"])
+		assert.Equal(t, "exec", entries[0]["operation"])
+		assert.Equal(t, "SELECT pg_sleep(0.1)", entries[0]["query"])
+		assert.GreaterOrEqual(t, entries[0]["duration_ms"], float64(100))
+		assert.Equal(t, float64(50), entries[0]["threshold_ms"])
+	})
+
+	t.Run("fast_query_not_logged", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		db := postgres.NewInstrumentedDB(testDB, time.Second, logger)
+
+		var n int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT 1").Scan(&n))
+		assert.Equal(t, 1, n)
+
+		assert.Empty(t, slowQueryEntries(t, &buf))
+	})
+
+	t.Run("preserves_results_and_errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		// A zero threshold logs every query
+		db := postgres.NewInstrumentedDB(testDB, 0, logger)
+
+		rows, err := db.QueryContext(ctx, "SELECT generate_series(1, 3)")
+		require.NoError(t, err)
+		count := 0
+		for rows.Next() {
+			count++
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+		assert.Equal(t, 3, count)
+
+		var n int
+		err = db.QueryRowContext(ctx, "SELECT 1 WHERE false").Scan(&n)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = db.ExecContext(ctx, "SELECT * FROM table_that_does_not_exist")
+		_, directErr := testDB.ExecContext(ctx, "SELECT * FROM table_that_does_not_exist")
+		require.Error(t, err)
+		assert.Equal(t, directErr.Error(), err.Error())
+
+		entries := slowQueryEntries(t, &buf)
+		require.Len(t, entries, 3)
+		assert.Equal(t, "query", entries[0]["operation"])
+		assert.Equal(t, "query_row", entries[1]["operation"])
+		assert.Equal(t, "exec", entries[2]["operation"])
+		assert.Contains(t, entries[2], "error")
+	})
+
+	t.Run("reports_to_observer", func(t *testing.T) {
+		var operations []string
+		observer := func(ctx context.Context, operation string, elapsed time.Duration, err error) {
+			operations = append(operations, operation)
+		}
+		db := postgres.NewInstrumentedDB(testDB, time.Second, nil, postgres.WithQueryObserver(observer))
+
+		_, err := db.ExecContext(ctx, "SELECT 1")
+		require.NoError(t, err)
+		var n int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT 1").Scan(&n))
+
+		assert.Equal(t, []string{"exec", "query_row"}, operations)
+	})
+
+	t.Run("card_store_transactions_use_underlying_db", func(t *testing.T) {
+		db := postgres.NewInstrumentedDB(testDB, time.Second, nil)
+		cardStore := postgres.NewPostgresCardStore(db, nil)
+
+		assert.Same(t, testDB, cardStore.DB())
+	})
+}
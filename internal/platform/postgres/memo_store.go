@@ -51,7 +51,7 @@ var _ store.MemoStore = (*PostgresMemoStore)(nil)
 // Create implements store.MemoStore.Create
 // It saves a new memo to the database, handling domain validation.
 // Returns validation errors from the domain Memo if data is invalid.
-// Returns store.ErrInvalidEntity if the user ID doesn't exist (foreign key violation).
+// Returns store.ErrReferencedEntityMissing if the user or deck doesn't exist (foreign key violation).
 func (s *PostgresMemoStore) Create(ctx context.Context, memo *domain.Memo) error {
 	// Get the logger from context or use default
 	log := logger.FromContextOrDefault(ctx, s.logger)
@@ -65,8 +65,9 @@ func (s *PostgresMemoStore) Create(ctx context.Context, memo *domain.Memo) error
 	}
 
 	query := `
-		INSERT INTO memos (id, user_id, text, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO memos (id, user_id, text, status, created_at, updated_at, generation_duration_ms,
+			status_reason, deck_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 	_, err := s.db.ExecContext(
 		ctx,
@@ -77,6 +78,9 @@ func (s *PostgresMemoStore) Create(ctx context.Context, memo *domain.Memo) error
 		memo.Status,
 		memo.CreatedAt,
 		memo.UpdatedAt,
+		generationDurationToMillis(memo.GenerationDuration),
+		nullableString(memo.StatusReason),
+		memo.DeckID,
 	)
 
 	if err != nil {
@@ -86,8 +90,8 @@ func (s *PostgresMemoStore) Create(ctx context.Context, memo *domain.Memo) error
 				slog.String("error", err.Error()),
 				slog.String("memo_id", memo.ID.String()),
 				slog.String("user_id", memo.UserID.String()))
-			return fmt.Errorf("%w: user with ID %s not found",
-				store.ErrInvalidEntity, memo.UserID)
+			return fmt.Errorf("%w: user with ID %s or deck not found",
+				store.ErrReferencedEntityMissing, memo.UserID)
 		}
 
 		// Log the error
@@ -97,7 +101,7 @@ func (s *PostgresMemoStore) Create(ctx context.Context, memo *domain.Memo) error
 			slog.String("user_id", memo.UserID.String()))
 
 		// Use the error mapping helper with proper context
-		return fmt.Errorf("failed to create memo: %w", MapError(err))
+		return fmt.Errorf("failed to create memo: %w", mapMemoError(err))
 	}
 
 	log.Debug("memo created successfully",
@@ -117,13 +121,16 @@ func (s *PostgresMemoStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	log.Debug("retrieving memo by ID", slog.String("memo_id", id.String()))
 
 	query := `
-		SELECT id, user_id, text, status, created_at, updated_at
+		SELECT id, user_id, text, status, created_at, updated_at, generation_duration_ms, status_reason,
+			deck_id
 		FROM memos
 		WHERE id = $1
 	`
 
 	var memo domain.Memo
 	var status string
+	var generationMillis sql.NullInt64
+	var statusReason sql.NullString
 
 	err := s.db.QueryRowContext(ctx, query, id).Scan(
 		&memo.ID,
@@ -132,6 +139,9 @@ func (s *PostgresMemoStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 		&status,
 		&memo.CreatedAt,
 		&memo.UpdatedAt,
+		&generationMillis,
+		&statusReason,
+		&memo.DeckID,
 	)
 
 	if err != nil {
@@ -142,10 +152,12 @@ func (s *PostgresMemoStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 		log.Error("failed to get memo by ID",
 			slog.String("error", err.Error()),
 			slog.String("memo_id", id.String()))
-		return nil, fmt.Errorf("failed to get memo by ID: %w", MapError(err))
+		return nil, fmt.Errorf("failed to get memo by ID: %w", mapMemoError(err))
 	}
 
 	memo.Status = domain.MemoStatus(status)
+	memo.GenerationDuration = millisToGenerationDuration(generationMillis)
+	memo.StatusReason = statusReason.String
 
 	log.Debug("memo retrieved successfully",
 		slog.String("memo_id", id.String()),
@@ -169,22 +181,12 @@ func (s *PostgresMemoStore) UpdateStatus(
 		slog.String("memo_id", id.String()),
 		slog.String("status", string(status)))
 
-	// Create a temp memo to validate the status
-	tempMemo := &domain.Memo{
-		ID:        id,
-		UserID:    uuid.New(), // This field is required but not used for status validation
-		Text:      "temp",     // This field is required but not used for status validation
-		Status:    status,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
-	}
-
-	if err := tempMemo.Validate(); err != nil {
+	if !status.IsValid() {
 		log.Warn("memo validation failed during status update",
-			slog.String("error", err.Error()),
+			slog.String("error", domain.ErrMemoStatusInvalid.Error()),
 			slog.String("memo_id", id.String()),
 			slog.String("status", string(status)))
-		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, domain.ErrMemoStatusInvalid)
 	}
 
 	updatedAt := time.Now().UTC()
@@ -208,7 +210,7 @@ func (s *PostgresMemoStore) UpdateStatus(
 			slog.String("error", err.Error()),
 			slog.String("memo_id", id.String()),
 			slog.String("status", string(status)))
-		return fmt.Errorf("failed to update memo status: %w", MapError(err))
+		return fmt.Errorf("failed to update memo status: %w", mapMemoError(err))
 	}
 
 	// Check if a row was actually updated using the helper
@@ -228,7 +230,7 @@ func (s *PostgresMemoStore) UpdateStatus(
 }
 
 // Update implements store.MemoStore.Update
-// It saves changes to an existing memo.
+// It saves changes to an existing memo, keeping its deck.
 // Returns store.ErrMemoNotFound if the memo does not exist.
 // Returns validation errors if the memo data is invalid.
 func (s *PostgresMemoStore) Update(ctx context.Context, memo *domain.Memo) error {
@@ -245,8 +247,8 @@ func (s *PostgresMemoStore) Update(ctx context.Context, memo *domain.Memo) error
 
 	query := `
 		UPDATE memos
-		SET text = $1, status = $2, updated_at = $3
-		WHERE id = $4
+		SET text = $1, status = $2, updated_at = $3, generation_duration_ms = $4, status_reason = $5
+		WHERE id = $6
 	`
 
 	result, err := s.db.ExecContext(
@@ -255,6 +257,8 @@ func (s *PostgresMemoStore) Update(ctx context.Context, memo *domain.Memo) error
 		memo.Text,
 		memo.Status,
 		memo.UpdatedAt,
+		generationDurationToMillis(memo.GenerationDuration),
+		nullableString(memo.StatusReason),
 		memo.ID,
 	)
 
@@ -263,7 +267,7 @@ func (s *PostgresMemoStore) Update(ctx context.Context, memo *domain.Memo) error
 			slog.String("error", err.Error()),
 			slog.String("memo_id", memo.ID.String()),
 			slog.String("status", string(memo.Status)))
-		return fmt.Errorf("failed to update memo: %w", MapError(err))
+		return fmt.Errorf("failed to update memo: %w", mapMemoError(err))
 	}
 
 	// Check if a row was actually updated using the helper
@@ -282,6 +286,47 @@ func (s *PostgresMemoStore) Update(ctx context.Context, memo *domain.Memo) error
 	return nil
 }
 
+// SetDeck implements store.MemoStore.SetDeck
+// It assigns a memo to a deck, or removes it from its deck when deckID is nil.
+// Returns store.ErrMemoNotFound if the memo does not exist and
+// store.ErrReferencedEntityMissing if the deck does not exist.
+func (s *PostgresMemoStore) SetDeck(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	log.Debug("setting memo deck", slog.String("memo_id", id.String()))
+
+	query := `
+		UPDATE memos
+		SET deck_id = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := s.db.ExecContext(ctx, query, deckID, time.Now().UTC(), id)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			log.Warn("foreign key violation - deck does not exist",
+				slog.String("error", err.Error()),
+				slog.String("memo_id", id.String()))
+			return fmt.Errorf("%w: deck with ID %s not found", store.ErrReferencedEntityMissing, deckID)
+		}
+		log.Error("failed to set memo deck",
+			slog.String("error", err.Error()),
+			slog.String("memo_id", id.String()))
+		return fmt.Errorf("failed to set memo deck: %w", mapMemoError(err))
+	}
+
+	if err := CheckRowsAffected(result, "memo"); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return store.ErrMemoNotFound
+		}
+		return fmt.Errorf("failed to set memo deck: %w", err)
+	}
+
+	log.Debug("memo deck set successfully", slog.String("memo_id", id.String()))
+	return nil
+}
+
 // FindMemosByStatus implements store.MemoStore.FindMemosByStatus
 // It retrieves all memos with the specified status.
 // Returns an empty slice if no memos match the criteria.
@@ -307,7 +352,8 @@ func (s *PostgresMemoStore) FindMemosByStatus(
 		slog.Int("offset", offset))
 
 	query := `
-		SELECT id, user_id, text, status, created_at, updated_at
+		SELECT id, user_id, text, status, created_at, updated_at, generation_duration_ms, status_reason,
+			deck_id
 		FROM memos
 		WHERE status = $1
 		ORDER BY created_at DESC
@@ -319,7 +365,7 @@ func (s *PostgresMemoStore) FindMemosByStatus(
 		log.Error("failed to query memos by status",
 			slog.String("error", err.Error()),
 			slog.String("status", string(status)))
-		return nil, fmt.Errorf("failed to query memos by status: %w", MapError(err))
+		return nil, fmt.Errorf("failed to query memos by status: %w", mapMemoError(err))
 	}
 	defer func() {
 		err := rows.Close()
@@ -332,6 +378,8 @@ func (s *PostgresMemoStore) FindMemosByStatus(
 	for rows.Next() {
 		var memo domain.Memo
 		var statusStr string
+		var generationMillis sql.NullInt64
+		var statusReason sql.NullString
 
 		err := rows.Scan(
 			&memo.ID,
@@ -340,6 +388,9 @@ func (s *PostgresMemoStore) FindMemosByStatus(
 			&statusStr,
 			&memo.CreatedAt,
 			&memo.UpdatedAt,
+			&generationMillis,
+			&statusReason,
+			&memo.DeckID,
 		)
 		if err != nil {
 			log.Error("failed to scan memo row",
@@ -348,6 +399,8 @@ func (s *PostgresMemoStore) FindMemosByStatus(
 		}
 
 		memo.Status = domain.MemoStatus(statusStr)
+		memo.GenerationDuration = millisToGenerationDuration(generationMillis)
+		memo.StatusReason = statusReason.String
 		memos = append(memos, &memo)
 	}
 
@@ -377,3 +430,25 @@ func (s *PostgresMemoStore) WithTx(tx *sql.Tx) store.MemoStore {
 		logger: s.logger,
 	}
 }
+
+// generationDurationToMillis converts a memo's generation duration to the nullable
+// generation_duration_ms column value. A zero duration (not yet generated) is stored as NULL.
+func generationDurationToMillis(d time.Duration) sql.NullInt64 {
+	if d <= 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: d.Milliseconds(), Valid: true}
+}
+
+// millisToGenerationDuration converts a generation_duration_ms column value to a duration.
+func millisToGenerationDuration(ms sql.NullInt64) time.Duration {
+	if !ms.Valid {
+		return 0
+	}
+	return time.Duration(ms.Int64) * time.Millisecond
+}
+
+// nullableString converts a string to a nullable column value, storing "" as NULL
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
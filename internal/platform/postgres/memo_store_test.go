@@ -637,6 +637,68 @@ func TestPostgresMemoStore_Update(t *testing.T) {
 				"UpdatedAt should be updated")
 		})
 
+		// Generation duration is persisted by Update and read back by GetByID
+		t.Run("Generation duration round trip", func(t *testing.T) {
+			t.Parallel() // Enable parallel subtests
+
+			// Create context
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			// Insert a test user
+			userID := testutils.MustInsertUser(
+				ctx,
+				t,
+				tx,
+				"update-memo-duration-test@example.com",
+				bcrypt.MinCost,
+			)
+
+			// Insert a test memo
+			memo := insertTestMemo(ctx, t, tx, userID)
+
+			stored, err := memoStore.GetByID(ctx, memo.ID)
+			require.NoError(t, err)
+			assert.Zero(t, stored.GenerationDuration, "New memos should have no generation duration")
+
+			memo.RecordGenerationDuration(4200 * time.Millisecond)
+			require.NoError(t, memoStore.Update(ctx, memo))
+
+			stored, err = memoStore.GetByID(ctx, memo.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 4200*time.Millisecond, stored.GenerationDuration)
+		})
+
+		// Blocked status and its reason are persisted by Update
+		t.Run("Blocked status round trip", func(t *testing.T) {
+			t.Parallel() // Enable parallel subtests
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			userID := testutils.MustInsertUser(
+				ctx,
+				t,
+				tx,
+				"update-memo-blocked-test@example.com",
+				bcrypt.MinCost,
+			)
+			memo := insertTestMemo(ctx, t, tx, userID)
+
+			require.NoError(t, memo.Block("Blocked by content safety filters"))
+			require.NoError(t, memoStore.Update(ctx, memo))
+
+			stored, err := memoStore.GetByID(ctx, memo.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.MemoStatusBlocked, stored.Status)
+			assert.Equal(t, "Blocked by content safety filters", stored.StatusReason)
+
+			blocked, err := memoStore.FindMemosByStatus(ctx, domain.MemoStatusBlocked, 10, 0)
+			require.NoError(t, err)
+			require.Len(t, blocked, 1)
+			assert.Equal(t, "Blocked by content safety filters", blocked[0].StatusReason)
+		})
+
 		// Test Case 2: Update with invalid data
 		t.Run("Invalid memo data", func(t *testing.T) {
 			t.Parallel() // Enable parallel subtests
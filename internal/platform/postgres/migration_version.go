@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/pressly/goose/v3"
+)
+
+// MigrationState describes one migration file and whether it has been applied
+type MigrationState struct {
+	Version int64  `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+
+	// AppliedAt is when the migration was applied, or nil if it is pending
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// MigrationReport summarizes the migration state of a database.
+// Its JSON form is consumed by tooling, so field names must not change.
+type MigrationReport struct {
+	CurrentVersion int64            `json:"current_version"`
+	LatestVersion  int64            `json:"latest_version"`
+	PendingCount   int              `json:"pending_count"`
+	Migrations     []MigrationState `json:"migrations"`
+}
+
+// AppliedMigrationVersion returns the highest migration version that goose
+// has applied to the database, or 0 if none have been applied.
+// Unlike goose.GetDBVersion it only reads, and never creates the version table,
+// so it is safe to call from health checks.
+func AppliedMigrationVersion(ctx context.Context, db store.DBTX) (int64, error) {
+	query := `
+		SELECT COALESCE(MAX(version_id), 0)
+		FROM goose_db_version
+		WHERE is_applied
+	`
+
+	var version int64
+	if err := db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to get applied migration version: %w", MapError(err))
+	}
+	return version, nil
+}
+
+// LatestMigrationVersion returns the version of the newest migration in dir,
+// or 0 if the directory contains no migrations.
+func LatestMigrationVersion(dir string) (int64, error) {
+	migrations, err := goose.CollectMigrations(dir, 0, math.MaxInt64)
+	if err != nil {
+		if errors.Is(err, goose.ErrNoMigrationFiles) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to collect migrations: %w", err)
+	}
+
+	last, err := migrations.Last()
+	if err != nil {
+		if errors.Is(err, goose.ErrNoNextVersion) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to find latest migration: %w", err)
+	}
+	return last.Version, nil
+}
+
+// GetMigrationReport compares the migrations in dir with those goose has recorded
+// in the database. Migrations are listed in version order.
+// Like AppliedMigrationVersion it only reads, so the version table must already exist.
+func GetMigrationReport(ctx context.Context, db store.DBTX, dir string) (*MigrationReport, error) {
+	migrations, err := goose.CollectMigrations(dir, 0, math.MaxInt64)
+	if err != nil && !errors.Is(err, goose.ErrNoMigrationFiles) {
+		return nil, fmt.Errorf("failed to collect migrations: %w", err)
+	}
+
+	// goose appends a row for every up and down, so the newest row per version wins
+	query := `
+		SELECT DISTINCT ON (version_id) version_id, is_applied, tstamp
+		FROM goose_db_version
+		WHERE version_id > 0
+		ORDER BY version_id, id DESC
+	`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query migration history: %w", MapError(err))
+	}
+	defer func() {
+		_ = rows.Close() // Intentionally ignoring error as rows are fully read below
+	}()
+
+	appliedAt := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			isApplied bool
+			tstamp    time.Time
+		)
+		if err := rows.Scan(&version, &isApplied, &tstamp); err != nil {
+			return nil, fmt.Errorf("failed to scan migration history: %w", MapError(err))
+		}
+		if isApplied {
+			appliedAt[version] = tstamp.UTC()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read migration history: %w", MapError(err))
+	}
+
+	report := &MigrationReport{Migrations: make([]MigrationState, 0, len(migrations))}
+	for version := range appliedAt {
+		report.CurrentVersion = max(report.CurrentVersion, version)
+	}
+	for _, migration := range migrations {
+		state := MigrationState{
+			Version: migration.Version,
+			Name:    filepath.Base(migration.Source),
+		}
+		if at, ok := appliedAt[migration.Version]; ok {
+			state.Applied = true
+			state.AppliedAt = &at
+		} else {
+			report.PendingCount++
+		}
+		report.LatestVersion = max(report.LatestVersion, migration.Version)
+		report.Migrations = append(report.Migrations, state)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,101 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newestMigrationFileVersion parses the version prefix of the newest .sql file in dir
+func newestMigrationFileVersion(t *testing.T, dir string) int64 {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var newest int64
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		prefix, _, _ := strings.Cut(entry.Name(), "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		require.NoError(t, err, "Unexpected migration file name %q", entry.Name())
+		newest = max(newest, version)
+	}
+	return newest
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	t.Run("returns_newest_migration", func(t *testing.T) {
+		version, err := postgres.LatestMigrationVersion("migrations")
+		require.NoError(t, err)
+		assert.Equal(t, newestMigrationFileVersion(t, "migrations"), version)
+	})
+
+	t.Run("empty_directory", func(t *testing.T) {
+		version, err := postgres.LatestMigrationVersion(t.TempDir())
+		require.NoError(t, err)
+		assert.Zero(t, version)
+	})
+}
+
+func TestAppliedMigrationVersion(t *testing.T) {
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	applied, err := postgres.AppliedMigrationVersion(ctx, db)
+	require.NoError(t, err)
+
+	latest, err := postgres.LatestMigrationVersion("migrations")
+	require.NoError(t, err)
+	assert.Equal(t, latest, applied, "The test database should have every migration applied")
+}
+
+func TestGetMigrationReport(t *testing.T) {
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	report, err := postgres.GetMigrationReport(ctx, db, "migrations")
+	require.NoError(t, err)
+
+	latest := newestMigrationFileVersion(t, "migrations")
+	assert.Equal(t, latest, report.LatestVersion)
+	assert.Equal(t, latest, report.CurrentVersion)
+	assert.Zero(t, report.PendingCount)
+
+	require.NotEmpty(t, report.Migrations)
+	for i, migration := range report.Migrations {
+		assert.True(t, migration.Applied, "Migration %s should be applied", migration.Name)
+		assert.NotNil(t, migration.AppliedAt)
+		if i > 0 {
+			assert.Less(t, report.Migrations[i-1].Version, migration.Version, "Migrations should be in version order")
+		}
+	}
+	assert.Equal(t, latest, report.Migrations[len(report.Migrations)-1].Version)
+}
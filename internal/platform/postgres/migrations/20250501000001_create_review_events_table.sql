@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create review_events table
+CREATE TABLE review_events (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    card_id UUID NOT NULL,
+    outcome VARCHAR(10) NOT NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Add foreign key constraints
+    CONSTRAINT fk_review_events_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_review_events_card
+        FOREIGN KEY (card_id)
+        REFERENCES cards(id)
+        ON DELETE CASCADE,
+
+    -- Add constraints for validity
+    CONSTRAINT check_review_event_outcome
+        CHECK (outcome IN ('again', 'hard', 'good', 'easy'))
+);
+
+-- Create indexes
+-- The composite index supports finding the latest event per card
+CREATE INDEX idx_review_events_card_reviewed_at ON review_events(card_id, reviewed_at DESC);
+CREATE INDEX idx_review_events_user_reviewed_at ON review_events(user_id, reviewed_at DESC);
+
+-- Comment table and columns
+COMMENT ON TABLE review_events IS 'Append-only history of card reviews';
+COMMENT ON COLUMN review_events.id IS 'Unique identifier for the review event';
+COMMENT ON COLUMN review_events.user_id IS 'User who performed the review';
+COMMENT ON COLUMN review_events.card_id IS 'Card that was reviewed';
+COMMENT ON COLUMN review_events.outcome IS 'Review outcome (again, hard, good, easy)';
+COMMENT ON COLUMN review_events.reviewed_at IS 'When the review took place';
+COMMENT ON COLUMN review_events.created_at IS 'Timestamp when the event was recorded';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop review_events table
+DROP TABLE IF EXISTS review_events;
+-- +goose StatementEnd
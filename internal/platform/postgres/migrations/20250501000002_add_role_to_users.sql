@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add role column to users table
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+-- Add constraint for validity
+ALTER TABLE users
+    ADD CONSTRAINT check_user_role
+        CHECK (role IN ('user', 'admin'));
+
+-- Comment column
+COMMENT ON COLUMN users.role IS 'Authorization role of the user (user, admin)';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Remove role column from users table
+ALTER TABLE users DROP CONSTRAINT IF EXISTS check_user_role;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose NO TRANSACTION
+-- ALTER TYPE ... ADD VALUE cannot run inside a transaction block on older PostgreSQL versions
+
+-- +goose Up
+-- Add draft status for memos that are saved without generating cards
+ALTER TYPE memo_status ADD VALUE IF NOT EXISTS 'draft' BEFORE 'pending';
+
+COMMENT ON COLUMN memos.status IS 'Processing status of the memo (draft, pending, processing, completed, completed_with_errors, failed)';
+
+-- +goose Down
+-- PostgreSQL cannot drop a value from an enum, so recreate the type without it
+UPDATE memos SET status = 'pending' WHERE status = 'draft';
+
+ALTER TABLE memos ALTER COLUMN status DROP DEFAULT;
+
+ALTER TYPE memo_status RENAME TO memo_status_old;
+
+CREATE TYPE memo_status AS ENUM (
+    'pending',
+    'processing',
+    'completed',
+    'completed_with_errors',
+    'failed'
+);
+
+ALTER TABLE memos
+    ALTER COLUMN status TYPE memo_status USING status::text::memo_status;
+
+ALTER TABLE memos ALTER COLUMN status SET DEFAULT 'pending';
+
+DROP TYPE memo_status_old;
+
+COMMENT ON COLUMN memos.status IS 'Processing status of the memo (pending, processing, completed, completed_with_errors, failed)';
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add timezone column to users table
+ALTER TABLE users
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Comment column
+COMMENT ON COLUMN users.timezone IS 'IANA time zone name used for calendar-day calculations such as review streaks';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Remove timezone column from users table
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
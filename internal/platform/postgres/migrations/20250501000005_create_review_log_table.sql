@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create review_log table
+CREATE TABLE review_log (
+    user_id UUID NOT NULL,
+    reviewed_on DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- One row per user per calendar day
+    PRIMARY KEY (user_id, reviewed_on),
+
+    -- Add foreign key constraints
+    CONSTRAINT fk_review_log_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Comment table and columns
+COMMENT ON TABLE review_log IS 'Calendar days on which each user reviewed at least one card';
+COMMENT ON COLUMN review_log.user_id IS 'User who reviewed cards on this day';
+COMMENT ON COLUMN review_log.reviewed_on IS 'Calendar date in the user''s timezone at the time of the review';
+COMMENT ON COLUMN review_log.created_at IS 'Timestamp of the first review recorded for this day';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop review_log table
+DROP TABLE IF EXISTS review_log;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add daily new-card limit to users table
+ALTER TABLE users
+    ADD COLUMN new_cards_per_day INTEGER NOT NULL DEFAULT 20;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_new_cards_per_day_check CHECK (new_cards_per_day >= 0);
+
+-- Track how many new cards were introduced on each review day
+ALTER TABLE review_log
+    ADD COLUMN new_cards INTEGER NOT NULL DEFAULT 0;
+
+-- Comment columns
+COMMENT ON COLUMN users.new_cards_per_day IS 'Maximum number of never-reviewed cards served per calendar day in the user''s timezone';
+COMMENT ON COLUMN review_log.new_cards IS 'Number of never-reviewed cards answered for the first time on this day';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Remove new-card tracking from review_log table
+ALTER TABLE review_log DROP COLUMN IF EXISTS new_cards;
+
+-- Remove daily new-card limit from users table
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_new_cards_per_day_check;
+ALTER TABLE users DROP COLUMN IF EXISTS new_cards_per_day;
+-- +goose StatementEnd
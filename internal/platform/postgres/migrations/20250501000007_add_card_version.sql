@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add a version counter to cards for optimistic concurrency control of edits
+ALTER TABLE cards
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE cards
+    ADD CONSTRAINT cards_version_check CHECK (version >= 1);
+
+-- Comment column
+COMMENT ON COLUMN cards.version IS 'Incremented on every content edit; updates must supply the version they read';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Remove version counter from cards table
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS cards_version_check;
+ALTER TABLE cards DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
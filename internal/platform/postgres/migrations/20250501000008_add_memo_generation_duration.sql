@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Record how long card generation took for each memo
+ALTER TABLE memos
+    ADD COLUMN generation_duration_ms BIGINT NULL;
+
+ALTER TABLE memos
+    ADD CONSTRAINT memos_generation_duration_ms_check CHECK (generation_duration_ms >= 0);
+
+-- Comment column
+COMMENT ON COLUMN memos.generation_duration_ms IS 'Wall-clock time in milliseconds the generation task spent producing and saving cards; NULL until generation completes';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Remove generation duration from memos table
+ALTER TABLE memos DROP CONSTRAINT IF EXISTS memos_generation_duration_ms_check;
+ALTER TABLE memos DROP COLUMN IF EXISTS generation_duration_ms;
+-- +goose StatementEnd
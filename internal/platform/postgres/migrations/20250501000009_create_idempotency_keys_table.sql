@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create idempotency_keys table
+CREATE TABLE idempotency_keys (
+    user_id UUID NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    memo_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Keys are scoped to the user that sent them
+    PRIMARY KEY (user_id, key),
+
+    -- Add foreign key constraints
+    CONSTRAINT fk_idempotency_keys_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_idempotency_keys_memo
+        FOREIGN KEY (memo_id)
+        REFERENCES memos(id)
+        ON DELETE CASCADE
+);
+
+-- Comment table and columns
+COMMENT ON TABLE idempotency_keys IS 'Idempotency-Key values sent with memo creation requests, used to replay the original response';
+COMMENT ON COLUMN idempotency_keys.user_id IS 'User who sent the request';
+COMMENT ON COLUMN idempotency_keys.key IS 'Client-supplied Idempotency-Key header value';
+COMMENT ON COLUMN idempotency_keys.request_hash IS 'Hex SHA-256 of the request body, used to reject reuse of a key for a different request';
+COMMENT ON COLUMN idempotency_keys.memo_id IS 'Memo created by the original request';
+COMMENT ON COLUMN idempotency_keys.created_at IS 'When the key was first used; an expired key is replaced when it is next used';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop idempotency_keys table
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Create decks table
+CREATE TABLE decks (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Add foreign key constraints
+    CONSTRAINT fk_decks_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    -- Deck names are unique per user
+    CONSTRAINT uq_decks_user_name UNIQUE (user_id, name)
+);
+
+-- Add an optional deck to cards; deleting a deck leaves its cards without a deck
+ALTER TABLE cards
+    ADD COLUMN deck_id UUID NULL;
+
+ALTER TABLE cards
+    ADD CONSTRAINT fk_cards_deck
+        FOREIGN KEY (deck_id)
+        REFERENCES decks(id)
+        ON DELETE SET NULL;
+
+-- Create indexes
+CREATE INDEX idx_cards_deck_id ON cards(deck_id) WHERE deck_id IS NOT NULL;
+
+-- Comment table and columns
+COMMENT ON TABLE decks IS 'User-defined groups of cards';
+COMMENT ON COLUMN decks.id IS 'Unique identifier (UUID) for the deck';
+COMMENT ON COLUMN decks.user_id IS 'Reference to the user who owns the deck';
+COMMENT ON COLUMN decks.name IS 'Display name of the deck, unique per user';
+COMMENT ON COLUMN decks.created_at IS 'Timestamp when the deck was created';
+COMMENT ON COLUMN decks.updated_at IS 'Timestamp when the deck was last updated';
+COMMENT ON COLUMN cards.deck_id IS 'Deck the card belongs to, if any; must be owned by the card''s user';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Remove deck assignment from cards and drop decks table
+DROP INDEX IF EXISTS idx_cards_deck_id;
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS fk_cards_deck;
+ALTER TABLE cards DROP COLUMN IF EXISTS deck_id;
+DROP TABLE IF EXISTS decks;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add optional per-deck SRS overrides; NULL falls back to the user's and the algorithm's defaults
+ALTER TABLE decks
+    ADD COLUMN new_cards_per_day INTEGER NULL,
+    ADD COLUMN again_review_minutes INTEGER NULL,
+    ADD COLUMN initial_ease_factor DECIMAL(4,2) NULL;
+
+ALTER TABLE decks
+    ADD CONSTRAINT chk_decks_new_cards_per_day
+        CHECK (new_cards_per_day IS NULL OR new_cards_per_day >= 0),
+    ADD CONSTRAINT chk_decks_again_review_minutes
+        CHECK (again_review_minutes IS NULL OR again_review_minutes BETWEEN 1 AND 1440),
+    ADD CONSTRAINT chk_decks_initial_ease_factor
+        CHECK (initial_ease_factor IS NULL OR initial_ease_factor BETWEEN 1.3 AND 2.5);
+
+COMMENT ON COLUMN decks.new_cards_per_day IS 'Daily new-card limit while reviewing the deck; NULL uses the user''s limit';
+COMMENT ON COLUMN decks.again_review_minutes IS 'Minutes before a card answered again is shown again; NULL uses the default';
+COMMENT ON COLUMN decks.initial_ease_factor IS 'Ease factor a card starts from on its first review; NULL uses the default';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE decks
+    DROP CONSTRAINT IF EXISTS chk_decks_initial_ease_factor,
+    DROP CONSTRAINT IF EXISTS chk_decks_again_review_minutes,
+    DROP CONSTRAINT IF EXISTS chk_decks_new_cards_per_day,
+    DROP COLUMN IF EXISTS initial_ease_factor,
+    DROP COLUMN IF EXISTS again_review_minutes,
+    DROP COLUMN IF EXISTS new_cards_per_day;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Cards replaced by a memo regeneration are kept, with their review history, but
+-- marked superseded so they are no longer scheduled for review
+ALTER TABLE cards
+    ADD COLUMN superseded_at TIMESTAMPTZ NULL;
+
+CREATE INDEX idx_cards_memo_id_active ON cards(memo_id) WHERE superseded_at IS NULL;
+
+COMMENT ON COLUMN cards.superseded_at IS 'When the card was replaced by regenerating its memo; NULL for active cards';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_cards_memo_id_active;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS superseded_at;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Cards are either basic (front and back) or cloze (text with {{cN::...}} markers).
+-- Existing cards are all basic.
+ALTER TABLE cards
+    ADD COLUMN card_type TEXT NOT NULL DEFAULT 'basic',
+    ADD CONSTRAINT chk_cards_card_type CHECK (card_type IN ('basic', 'cloze'));
+
+COMMENT ON COLUMN cards.card_type IS 'How the card content is presented for review: basic or cloze';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cards
+    DROP CONSTRAINT IF EXISTS chk_cards_card_type,
+    DROP COLUMN IF EXISTS card_type;
+-- +goose StatementEnd
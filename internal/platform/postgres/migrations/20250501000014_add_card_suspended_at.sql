@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users can suspend a card to pause it without deleting it. Suspended cards keep
+-- their review statistics but are not scheduled for review until unsuspended
+ALTER TABLE cards
+    ADD COLUMN suspended_at TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN cards.suspended_at IS 'When the user suspended the card; NULL if it is not suspended';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS suspended_at;
+-- +goose StatementEnd
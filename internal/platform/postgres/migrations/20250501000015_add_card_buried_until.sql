@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users can bury a card to hide it for the rest of their day without changing
+-- its schedule. Burying is transient: once buried_until has passed the card is
+-- served again, so the column never needs to be cleared
+ALTER TABLE cards
+    ADD COLUMN buried_until TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN cards.buried_until IS 'Until when the card is hidden from review; NULL or a past time if it is not buried';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS buried_until;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- New registrations must verify their email address. Accounts created before
+-- verification existed are treated as verified so they are not locked out of
+-- features that require it
+ALTER TABLE users
+    ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE users SET email_verified = TRUE;
+
+COMMENT ON COLUMN users.email_verified IS 'Whether the user has confirmed ownership of their email address';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verified;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Changing or resetting a password revokes the sessions issued before it, so
+-- the time of the last change is recorded
+ALTER TABLE users
+    ADD COLUMN password_changed_at TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN users.password_changed_at IS 'When the password was last changed or reset; sessions issued earlier are revoked';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS password_changed_at;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A lapse is a review answered "again". Cards that lapse often are detected as
+-- leeches, so the number of lapses is recorded with the rest of the statistics
+ALTER TABLE user_card_stats
+    ADD COLUMN lapses INTEGER NOT NULL DEFAULT 0
+    CONSTRAINT check_lapses_non_negative CHECK (lapses >= 0);
+
+COMMENT ON COLUMN user_card_stats.lapses IS 'Number of reviews of the card answered "again"';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_card_stats
+    DROP COLUMN IF EXISTS lapses;
+-- +goose StatementEnd
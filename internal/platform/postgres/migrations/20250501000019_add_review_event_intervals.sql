@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Each review records the card's interval before and after the answer, so a
+-- card's history shows how its schedule changed. Reviews recorded before this
+-- migration have no intervals
+ALTER TABLE review_events
+    ADD COLUMN interval_before INTEGER NULL,
+    ADD COLUMN interval_after INTEGER NULL;
+
+COMMENT ON COLUMN review_events.interval_before IS 'Interval in days before the review; NULL for reviews recorded before intervals were tracked';
+COMMENT ON COLUMN review_events.interval_after IS 'Interval in days scheduled by the review; NULL for reviews recorded before intervals were tracked';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE review_events
+    DROP COLUMN IF EXISTS interval_before,
+    DROP COLUMN IF EXISTS interval_after;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Hash of each card's normalized front text, so that generated cards can be
+-- checked against the fronts a user already has without reading their content
+ALTER TABLE cards ADD COLUMN front_hash BYTEA;
+
+-- Backfill existing cards. New and updated cards are hashed by the application
+-- (domain.HashCardFront); this matches it for the whitespace and letters
+-- PostgreSQL and Go agree on, which covers ordinary text.
+UPDATE cards
+SET front_hash = sha256(convert_to(
+    lower(regexp_replace(btrim(
+        COALESCE(NULLIF(btrim(content->>'front'), ''), content->>'text')
+    ), '\s+', ' ', 'g')),
+    'UTF8'))
+WHERE COALESCE(NULLIF(btrim(content->>'front'), ''), NULLIF(btrim(content->>'text'), '')) IS NOT NULL;
+
+CREATE INDEX idx_cards_user_front_hash ON cards(user_id, front_hash) WHERE front_hash IS NOT NULL;
+
+COMMENT ON COLUMN cards.front_hash IS 'SHA-256 of the lower-cased, whitespace-collapsed front text; NULL if the card has none';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_cards_user_front_hash;
+ALTER TABLE cards DROP COLUMN IF EXISTS front_hash;
+-- +goose StatementEnd
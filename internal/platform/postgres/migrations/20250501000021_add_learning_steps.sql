@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- New and lapsed cards step through short learning steps before they are
+-- scheduled in days. Track which phase each card is in and its current step.
+ALTER TABLE user_card_stats
+    ADD COLUMN phase TEXT NOT NULL DEFAULT 'review'
+    CONSTRAINT check_phase CHECK (phase IN ('learning', 'review', 'relearning')),
+    ADD COLUMN learning_step INTEGER NOT NULL DEFAULT 0
+    CONSTRAINT check_learning_step_non_negative CHECK (learning_step >= 0);
+
+-- Cards that were never reviewed have not started learning yet
+UPDATE user_card_stats SET phase = 'learning' WHERE review_count = 0;
+
+COMMENT ON COLUMN user_card_stats.phase IS 'Learning, review or relearning';
+COMMENT ON COLUMN user_card_stats.learning_step IS 'Index of the current learning step while learning or relearning';
+
+-- Optional per-deck learning steps, as comma-separated minutes such as '1,10'
+ALTER TABLE decks
+    ADD COLUMN learning_steps TEXT NULL
+    CONSTRAINT chk_decks_learning_steps CHECK (learning_steps IS NULL OR learning_steps ~ '^[0-9]+(,[0-9]+)*$');
+
+COMMENT ON COLUMN decks.learning_steps IS 'Comma-separated learning steps in minutes; NULL uses the default';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE decks
+    DROP COLUMN IF EXISTS learning_steps;
+
+ALTER TABLE user_card_stats
+    DROP COLUMN IF EXISTS learning_step,
+    DROP COLUMN IF EXISTS phase;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A retried memo-create request must not queue a second generation task for
+-- the same memo. Allow one pending or processing task per type and memo.
+CREATE UNIQUE INDEX uq_tasks_active_memo ON tasks (type, (payload->>'memo_id'))
+    WHERE status IN ('pending', 'processing') AND payload ? 'memo_id';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uq_tasks_active_memo;
+-- +goose StatementEnd
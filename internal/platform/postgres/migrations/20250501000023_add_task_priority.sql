@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Tasks a user is waiting on run ahead of background work such as backfills.
+ALTER TABLE tasks
+    ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN tasks.priority IS 'Higher-priority tasks are processed first; 0 is background work';
+
+-- Recovery loads tasks by status, highest priority first, then oldest first
+CREATE INDEX idx_tasks_status_priority_created_at ON tasks(status, priority DESC, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_status_priority_created_at;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS priority;
+-- +goose StatementEnd
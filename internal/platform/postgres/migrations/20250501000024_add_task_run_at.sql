@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Scheduled tasks, such as reminder emails, must not run before their run time.
+-- Existing tasks were due when they were created.
+ALTER TABLE tasks
+    ADD COLUMN run_at TIMESTAMP WITH TIME ZONE NULL;
+
+UPDATE tasks SET run_at = created_at;
+
+ALTER TABLE tasks
+    ALTER COLUMN run_at SET NOT NULL,
+    ALTER COLUMN run_at SET DEFAULT NOW();
+
+COMMENT ON COLUMN tasks.run_at IS 'Earliest time the task may run';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS run_at;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose NO TRANSACTION
+-- ALTER TYPE ... ADD VALUE cannot run inside a transaction block on older PostgreSQL versions
+
+-- +goose Up
+-- Add blocked status for memos whose text the generation provider refused for
+-- content safety, with a user-facing reason
+ALTER TYPE memo_status ADD VALUE IF NOT EXISTS 'blocked';
+
+ALTER TABLE memos ADD COLUMN IF NOT EXISTS status_reason TEXT NULL;
+
+COMMENT ON COLUMN memos.status IS 'Processing status of the memo (draft, pending, processing, completed, completed_with_errors, failed, blocked)';
+COMMENT ON COLUMN memos.status_reason IS 'User-facing explanation of the status; set for blocked memos';
+
+-- +goose Down
+ALTER TABLE memos DROP COLUMN IF EXISTS status_reason;
+
+-- PostgreSQL cannot drop a value from an enum, so recreate the type without it
+UPDATE memos SET status = 'failed' WHERE status = 'blocked';
+
+ALTER TABLE memos ALTER COLUMN status DROP DEFAULT;
+
+ALTER TYPE memo_status RENAME TO memo_status_old;
+
+CREATE TYPE memo_status AS ENUM (
+    'draft',
+    'pending',
+    'processing',
+    'completed',
+    'completed_with_errors',
+    'failed'
+);
+
+ALTER TABLE memos
+    ALTER COLUMN status TYPE memo_status USING status::text::memo_status;
+
+ALTER TABLE memos ALTER COLUMN status SET DEFAULT 'pending';
+
+DROP TYPE memo_status_old;
+
+COMMENT ON COLUMN memos.status IS 'Processing status of the memo (draft, pending, processing, completed, completed_with_errors, failed)';
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Card generations requested by each user per calendar month, for enforcing
+-- the monthly generation quota. period is the first day of the month (UTC).
+CREATE TABLE generation_usage (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period DATE NOT NULL,
+    generations INTEGER NOT NULL DEFAULT 0
+        CONSTRAINT check_generations_non_negative CHECK (generations >= 0),
+    PRIMARY KEY (user_id, period)
+);
+
+COMMENT ON TABLE generation_usage IS 'Card generations requested per user and month';
+COMMENT ON COLUMN generation_usage.period IS 'First day of the month the generations were requested in (UTC)';
+
+-- Optional per-user override of the configured monthly quota
+ALTER TABLE users
+    ADD COLUMN generation_quota INTEGER NULL
+    CONSTRAINT check_generation_quota_non_negative CHECK (generation_quota IS NULL OR generation_quota >= 0);
+
+COMMENT ON COLUMN users.generation_quota IS 'Monthly card generation quota; NULL uses the configured default, 0 is unlimited';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS generation_quota;
+
+DROP TABLE IF EXISTS generation_usage;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Admins can disable accounts; disabled users fail authentication
+ALTER TABLE users
+    ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN users.disabled IS 'Whether an admin has disabled the account';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS disabled;
+-- +goose StatementEnd
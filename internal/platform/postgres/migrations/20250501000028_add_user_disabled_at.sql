@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Record when an account was disabled instead of only whether it is
+ALTER TABLE users
+    ADD COLUMN disabled_at TIMESTAMPTZ NULL;
+
+-- The time accounts were disabled wasn't kept; their last update is the best estimate
+UPDATE users SET disabled_at = updated_at WHERE disabled;
+
+ALTER TABLE users
+    DROP COLUMN disabled;
+
+COMMENT ON COLUMN users.disabled_at IS 'When an admin disabled the account; NULL if it is enabled';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE users SET disabled = true WHERE disabled_at IS NOT NULL;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS disabled_at;
+-- +goose StatementEnd
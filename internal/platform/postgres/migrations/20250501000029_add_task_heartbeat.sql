@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A processing task is stuck when its worker has stopped reporting it alive,
+-- not when it was last updated, so long-running tasks are not reclaimed.
+ALTER TABLE tasks
+    ADD COLUMN heartbeat_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- Tasks already processing were last known alive when they were last updated
+UPDATE tasks SET heartbeat_at = updated_at WHERE status = 'processing';
+
+-- The stuck-task scan looks only at processing tasks
+CREATE INDEX idx_tasks_processing_heartbeat ON tasks (heartbeat_at) WHERE status = 'processing';
+
+COMMENT ON COLUMN tasks.heartbeat_at IS 'When a worker last reported the task alive; NULL unless processing';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_processing_heartbeat;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS heartbeat_at;
+-- +goose StatementEnd
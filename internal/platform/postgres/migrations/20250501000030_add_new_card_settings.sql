@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add optional per-user and per-deck settings that new cards' stats start from;
+-- NULL falls back to the deck's, then the user's, then the built-in defaults.
+-- A deck's starting ease factor is its existing initial_ease_factor.
+ALTER TABLE users
+    ADD COLUMN new_card_interval_days INTEGER NULL,
+    ADD COLUMN new_card_ease_factor DECIMAL(4,2) NULL,
+    ADD COLUMN new_cards_due_immediately BOOLEAN NULL;
+
+ALTER TABLE users
+    ADD CONSTRAINT chk_users_new_card_interval_days
+        CHECK (new_card_interval_days IS NULL OR new_card_interval_days BETWEEN 0 AND 365),
+    ADD CONSTRAINT chk_users_new_card_ease_factor
+        CHECK (new_card_ease_factor IS NULL OR new_card_ease_factor BETWEEN 1.3 AND 2.5);
+
+ALTER TABLE decks
+    ADD COLUMN new_card_interval_days INTEGER NULL,
+    ADD COLUMN new_cards_due_immediately BOOLEAN NULL;
+
+ALTER TABLE decks
+    ADD CONSTRAINT chk_decks_new_card_interval_days
+        CHECK (new_card_interval_days IS NULL OR new_card_interval_days BETWEEN 0 AND 365);
+
+COMMENT ON COLUMN users.new_card_interval_days IS 'Starting interval in days of the user''s new cards; NULL uses the default of 0';
+COMMENT ON COLUMN users.new_card_ease_factor IS 'Starting ease factor of the user''s new cards; NULL uses the default of 2.5';
+COMMENT ON COLUMN users.new_cards_due_immediately IS 'Whether the user''s new cards are due at once rather than after their starting interval; NULL means true';
+COMMENT ON COLUMN decks.new_card_interval_days IS 'Starting interval in days of cards created in the deck; NULL uses the user''s setting';
+COMMENT ON COLUMN decks.new_cards_due_immediately IS 'Whether cards created in the deck are due at once rather than after their starting interval; NULL uses the user''s setting';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE decks
+    DROP CONSTRAINT IF EXISTS chk_decks_new_card_interval_days,
+    DROP COLUMN IF EXISTS new_cards_due_immediately,
+    DROP COLUMN IF EXISTS new_card_interval_days;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS chk_users_new_card_ease_factor,
+    DROP CONSTRAINT IF EXISTS chk_users_new_card_interval_days,
+    DROP COLUMN IF EXISTS new_cards_due_immediately,
+    DROP COLUMN IF EXISTS new_card_ease_factor,
+    DROP COLUMN IF EXISTS new_card_interval_days;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add an optional deck to memos; cards generated from a memo in a deck are
+-- placed in that deck. Deleting a deck leaves its memos without a deck.
+ALTER TABLE memos
+    ADD COLUMN deck_id UUID NULL;
+
+ALTER TABLE memos
+    ADD CONSTRAINT fk_memos_deck
+        FOREIGN KEY (deck_id)
+        REFERENCES decks(id)
+        ON DELETE SET NULL;
+
+CREATE INDEX idx_memos_deck_id ON memos(deck_id) WHERE deck_id IS NOT NULL;
+
+COMMENT ON COLUMN memos.deck_id IS 'Deck the memo''s generated cards are placed in, if any; must be owned by the memo''s user';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_memos_deck_id;
+ALTER TABLE memos DROP CONSTRAINT IF EXISTS fk_memos_deck;
+ALTER TABLE memos DROP COLUMN IF EXISTS deck_id;
+-- +goose StatementEnd
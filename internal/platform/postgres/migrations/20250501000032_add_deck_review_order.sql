@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Optional per-deck review order, used while reviewing the deck unless the
+-- review asks for a particular order
+ALTER TABLE decks
+    ADD COLUMN review_order TEXT NULL
+    CONSTRAINT chk_decks_review_order
+        CHECK (review_order IS NULL OR review_order IN ('due-date-asc', 'random-due', 'lowest-ease-first'));
+
+COMMENT ON COLUMN decks.review_order IS 'Order due cards are served in while reviewing the deck; NULL uses the default';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE decks
+    DROP COLUMN IF EXISTS review_order;
+-- +goose StatementEnd
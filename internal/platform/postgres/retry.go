@@ -0,0 +1,439 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// PostgreSQL error codes for server-side conditions that are safe to retry
+const (
+	// connectionExceptionClass is the PostgreSQL error class for connection exceptions (08xxx)
+	connectionExceptionClass = "08"
+
+	// adminShutdownCode is returned when the server terminates a connection during shutdown
+	adminShutdownCode = "57P01"
+
+	// crashShutdownCode is returned when the server terminates a connection after a crash
+	crashShutdownCode = "57P02"
+
+	// cannotConnectNowCode is returned while the server is starting up or in recovery
+	cannotConnectNowCode = "57P03"
+)
+
+// IsTransientConnectionError checks if the given error is a connection-level failure
+// that is expected to succeed when the same read is attempted again, such as a pooled
+// connection being closed by the server. Constraint violations, not-found errors and
+// context cancellation are never transient.
+func IsTransientConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return strings.HasPrefix(pgErr.Code, connectionExceptionClass) ||
+			pgErr.Code == adminShutdownCode ||
+			pgErr.Code == crashShutdownCode ||
+			pgErr.Code == cannotConnectNowCode
+	}
+
+	// pgx reports errors that occurred before anything was sent to the server as safe to retry
+	return pgconn.SafeToRetry(err)
+}
+
+// ReadRetryPolicy controls how store read methods are retried after transient
+// connection errors.
+type ReadRetryPolicy struct {
+	// MaxRetries is the number of additional attempts after the first failure.
+	// Zero disables retrying.
+	MaxRetries int
+
+	// Delay is the pause before each retry. It doubles after every attempt.
+	Delay time.Duration
+}
+
+// enabled reports whether the policy retries at all
+func (p ReadRetryPolicy) enabled() bool {
+	return p.MaxRetries > 0
+}
+
+// retryRead runs fn, retrying it according to policy while it fails with a transient
+// connection error. Only idempotent reads may be passed to retryRead.
+func retryRead[T any](
+	ctx context.Context,
+	policy ReadRetryPolicy,
+	baseLogger *slog.Logger,
+	operation string,
+	fn func(ctx context.Context) (T, error),
+) (T, error) {
+	result, err := fn(ctx)
+	delay := policy.Delay
+
+	for attempt := 1; attempt <= policy.MaxRetries && IsTransientConnectionError(err); attempt++ {
+		logger.FromContextOrDefault(ctx, baseLogger).Warn("retrying store read after transient error",
+			slog.String("operation", operation),
+			slog.Int("attempt", attempt),
+			slog.String("error", err.Error()))
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return result, err
+			case <-timer.C:
+			}
+			delay *= 2
+		}
+
+		result, err = fn(ctx)
+	}
+
+	return result, err
+}
+
+// retryingCardStore retries CardStore reads after transient connection errors.
+// Writes are passed through unchanged via the embedded store.
+type retryingCardStore struct {
+	store.CardStore
+	policy ReadRetryPolicy
+	logger *slog.Logger
+}
+
+// NewRetryingCardStore wraps a CardStore so that its read methods are retried after
+// transient connection errors. Writes are never retried. Stores returned by WithTx
+// are not wrapped, since a transaction cannot survive a lost connection.
+// If the policy disables retries, the store is returned unchanged.
+func NewRetryingCardStore(
+	s store.CardStore,
+	policy ReadRetryPolicy,
+	logger *slog.Logger,
+) store.CardStore {
+	if !policy.enabled() {
+		return s
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &retryingCardStore{
+		CardStore: s,
+		policy:    policy,
+		logger:    logger.With(slog.String("component", "card_store_retry")),
+	}
+}
+
+// GetByID implements store.CardStore.GetByID with retries
+func (s *retryingCardStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.GetByID",
+		func(ctx context.Context) (*domain.Card, error) {
+			return s.CardStore.GetByID(ctx, id)
+		})
+}
+
+// GetByIDs implements store.CardStore.GetByIDs with retries
+func (s *retryingCardStore) GetByIDs(
+	ctx context.Context,
+	ids []uuid.UUID,
+) (map[uuid.UUID]*domain.Card, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.GetByIDs",
+		func(ctx context.Context) (map[uuid.UUID]*domain.Card, error) {
+			return s.CardStore.GetByIDs(ctx, ids)
+		})
+}
+
+// GetNextReviewCard implements store.CardStore.GetNextReviewCard with retries
+func (s *retryingCardStore) GetNextReviewCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.GetNextReviewCard",
+		func(ctx context.Context) (*domain.Card, error) {
+			return s.CardStore.GetNextReviewCard(ctx, userID, order)
+		})
+}
+
+// GetNextDueCard implements store.CardStore.GetNextDueCard with retries
+func (s *retryingCardStore) GetNextDueCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.GetNextDueCard",
+		func(ctx context.Context) (*domain.Card, error) {
+			return s.CardStore.GetNextDueCard(ctx, userID, deckID, newCards, order)
+		})
+}
+
+// GetNextDueCardExcluding implements store.CardStore.GetNextDueCardExcluding with retries
+func (s *retryingCardStore) GetNextDueCardExcluding(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
+) (*domain.Card, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.GetNextDueCardExcluding",
+		func(ctx context.Context) (*domain.Card, error) {
+			return s.CardStore.GetNextDueCardExcluding(ctx, userID, deckID, newCards, order, exclude)
+		})
+}
+
+// ListByDeck implements store.CardStore.ListByDeck with retries
+func (s *retryingCardStore) ListByDeck(
+	ctx context.Context,
+	deckID uuid.UUID,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.ListByDeck",
+		func(ctx context.Context) ([]*domain.Card, error) {
+			return s.CardStore.ListByDeck(ctx, deckID, limit, offset)
+		})
+}
+
+// ListByTag implements store.CardStore.ListByTag with retries
+func (s *retryingCardStore) ListByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.ListByTag",
+		func(ctx context.Context) ([]*domain.Card, error) {
+			return s.CardStore.ListByTag(ctx, userID, tag, limit, offset)
+		})
+}
+
+// ExistingFrontHashes implements store.CardStore.ExistingFrontHashes with retries
+func (s *retryingCardStore) ExistingFrontHashes(
+	ctx context.Context,
+	userID uuid.UUID,
+	hashes [][32]byte,
+) (map[[32]byte]bool, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.ExistingFrontHashes",
+		func(ctx context.Context) (map[[32]byte]bool, error) {
+			return s.CardStore.ExistingFrontHashes(ctx, userID, hashes)
+		})
+}
+
+// GetRecentlyReviewed implements store.CardStore.GetRecentlyReviewed with retries
+func (s *retryingCardStore) GetRecentlyReviewed(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset int,
+) ([]*domain.ReviewedCard, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.GetRecentlyReviewed",
+		func(ctx context.Context) ([]*domain.ReviewedCard, error) {
+			return s.CardStore.GetRecentlyReviewed(ctx, userID, limit, offset)
+		})
+}
+
+// FindDuplicates implements store.CardStore.FindDuplicates with retries
+func (s *retryingCardStore) FindDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*domain.DuplicateCardGroup, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.FindDuplicates",
+		func(ctx context.Context) ([]*domain.DuplicateCardGroup, error) {
+			return s.CardStore.FindDuplicates(ctx, userID)
+		})
+}
+
+// CountByUser implements store.CardStore.CountByUser with retries
+func (s *retryingCardStore) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	return retryRead(ctx, s.policy, s.logger, "card.CountByUser",
+		func(ctx context.Context) (int, error) {
+			return s.CardStore.CountByUser(ctx, userID)
+		})
+}
+
+// retryingUserCardStatsStore retries UserCardStatsStore reads after transient
+// connection errors. Writes are passed through unchanged via the embedded store.
+type retryingUserCardStatsStore struct {
+	store.UserCardStatsStore
+	policy ReadRetryPolicy
+	logger *slog.Logger
+}
+
+// NewRetryingUserCardStatsStore wraps a UserCardStatsStore so that its read methods
+// are retried after transient connection errors. GetForUpdate is not retried because
+// it is only meaningful inside a transaction.
+// If the policy disables retries, the store is returned unchanged.
+func NewRetryingUserCardStatsStore(
+	s store.UserCardStatsStore,
+	policy ReadRetryPolicy,
+	logger *slog.Logger,
+) store.UserCardStatsStore {
+	if !policy.enabled() {
+		return s
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &retryingUserCardStatsStore{
+		UserCardStatsStore: s,
+		policy:             policy,
+		logger:             logger.With(slog.String("component", "stats_store_retry")),
+	}
+}
+
+// Get implements store.UserCardStatsStore.Get with retries
+func (s *retryingUserCardStatsStore) Get(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) (*domain.UserCardStats, error) {
+	return retryRead(ctx, s.policy, s.logger, "stats.Get",
+		func(ctx context.Context) (*domain.UserCardStats, error) {
+			return s.UserCardStatsStore.Get(ctx, userID, cardID)
+		})
+}
+
+// CountDue implements store.UserCardStatsStore.CountDue with retries
+func (s *retryingUserCardStatsStore) CountDue(
+	ctx context.Context,
+	userID uuid.UUID,
+	asOf time.Time,
+) (int, error) {
+	return retryRead(ctx, s.policy, s.logger, "stats.CountDue",
+		func(ctx context.Context) (int, error) {
+			return s.UserCardStatsStore.CountDue(ctx, userID, asOf)
+		})
+}
+
+// GetForecast implements store.UserCardStatsStore.GetForecast with retries
+func (s *retryingUserCardStatsStore) GetForecast(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	days int,
+) ([]domain.ForecastBucket, error) {
+	return retryRead(ctx, s.policy, s.logger, "stats.GetForecast",
+		func(ctx context.Context) ([]domain.ForecastBucket, error) {
+			return s.UserCardStatsStore.GetForecast(ctx, userID, deckID, days)
+		})
+}
+
+// retryingMemoStore retries MemoStore reads after transient connection errors.
+// Writes are passed through unchanged via the embedded store.
+type retryingMemoStore struct {
+	store.MemoStore
+	policy ReadRetryPolicy
+	logger *slog.Logger
+}
+
+// NewRetryingMemoStore wraps a MemoStore so that its read methods are retried after
+// transient connection errors. Writes are never retried.
+// If the policy disables retries, the store is returned unchanged.
+func NewRetryingMemoStore(
+	s store.MemoStore,
+	policy ReadRetryPolicy,
+	logger *slog.Logger,
+) store.MemoStore {
+	if !policy.enabled() {
+		return s
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &retryingMemoStore{
+		MemoStore: s,
+		policy:    policy,
+		logger:    logger.With(slog.String("component", "memo_store_retry")),
+	}
+}
+
+// GetByID implements store.MemoStore.GetByID with retries
+func (s *retryingMemoStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+	return retryRead(ctx, s.policy, s.logger, "memo.GetByID",
+		func(ctx context.Context) (*domain.Memo, error) {
+			return s.MemoStore.GetByID(ctx, id)
+		})
+}
+
+// FindMemosByStatus implements store.MemoStore.FindMemosByStatus with retries
+func (s *retryingMemoStore) FindMemosByStatus(
+	ctx context.Context,
+	status domain.MemoStatus,
+	limit, offset int,
+) ([]*domain.Memo, error) {
+	return retryRead(ctx, s.policy, s.logger, "memo.FindMemosByStatus",
+		func(ctx context.Context) ([]*domain.Memo, error) {
+			return s.MemoStore.FindMemosByStatus(ctx, status, limit, offset)
+		})
+}
+
+// retryingUserStore retries UserStore reads after transient connection errors.
+// Writes are passed through unchanged via the embedded store.
+type retryingUserStore struct {
+	store.UserStore
+	policy ReadRetryPolicy
+	logger *slog.Logger
+}
+
+// NewRetryingUserStore wraps a UserStore so that its read methods are retried after
+// transient connection errors. Writes are never retried.
+// If the policy disables retries, the store is returned unchanged.
+func NewRetryingUserStore(
+	s store.UserStore,
+	policy ReadRetryPolicy,
+	logger *slog.Logger,
+) store.UserStore {
+	if !policy.enabled() {
+		return s
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &retryingUserStore{
+		UserStore: s,
+		policy:    policy,
+		logger:    logger.With(slog.String("component", "user_store_retry")),
+	}
+}
+
+// GetByID implements store.UserStore.GetByID with retries
+func (s *retryingUserStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return retryRead(ctx, s.policy, s.logger, "user.GetByID",
+		func(ctx context.Context) (*domain.User, error) {
+			return s.UserStore.GetByID(ctx, id)
+		})
+}
+
+// GetByEmail implements store.UserStore.GetByEmail with retries
+func (s *retryingUserStore) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return retryRead(ctx, s.policy, s.logger, "user.GetByEmail",
+		func(ctx context.Context) (*domain.User, error) {
+			return s.UserStore.GetByEmail(ctx, email)
+		})
+}
+
+func (s *retryingUserStore) List(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error) {
+	return retryRead(ctx, s.policy, s.logger, "user.List",
+		func(ctx context.Context) ([]*domain.User, error) {
+			return s.UserStore.List(ctx, emailQuery, limit, offset)
+		})
+}
@@ -0,0 +1,138 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyCardStore is a store.CardStore whose GetByID and Delete return scripted errors.
+// Methods not overridden here panic via the nil embedded interface.
+type flakyCardStore struct {
+	store.CardStore
+	getErrs     []error
+	getCalls    int
+	deleteErr   error
+	deleteCalls int
+}
+
+func (s *flakyCardStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	s.getCalls++
+	if s.getCalls <= len(s.getErrs) {
+		return nil, s.getErrs[s.getCalls-1]
+	}
+	return &domain.Card{ID: id}, nil
+}
+
+func (s *flakyCardStore) Delete(ctx context.Context, id uuid.UUID) error {
+	s.deleteCalls++
+	return s.deleteErr
+}
+
+// errTransient simulates a pooled connection closed by the server
+var errTransient = fmt.Errorf("failed to get card: %w", newPgError("57P01"))
+
+func TestIsTransientConnectionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"admin_shutdown", newPgError("57P01"), true},
+		{"cannot_connect_now", newPgError("57P03"), true},
+		{"connection_failure", newPgError("08006"), true},
+		{"wrapped_connection_failure", fmt.Errorf("query failed: %w", newPgError("08003")), true},
+		{"bad_conn", driver.ErrBadConn, true},
+		{"unique_violation", newPgError("23505"), false},
+		{"not_found", store.ErrCardNotFound, false},
+		{"context_canceled", context.Canceled, false},
+		{"deadline_exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), false},
+		{"plain_error", errors.New("boom"), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, postgres.IsTransientConnectionError(tc.err))
+		})
+	}
+}
+
+func TestRetryingCardStore(t *testing.T) {
+	policy := postgres.ReadRetryPolicy{MaxRetries: 2, Delay: time.Millisecond}
+
+	t.Run("read_retries_transient_error_then_succeeds", func(t *testing.T) {
+		inner := &flakyCardStore{getErrs: []error{errTransient}}
+		cardStore := postgres.NewRetryingCardStore(inner, policy, nil)
+
+		id := uuid.New()
+		card, err := cardStore.GetByID(context.Background(), id)
+
+		require.NoError(t, err)
+		assert.Equal(t, id, card.ID)
+		assert.Equal(t, 2, inner.getCalls)
+	})
+
+	t.Run("read_gives_up_after_max_retries", func(t *testing.T) {
+		inner := &flakyCardStore{getErrs: []error{errTransient, errTransient, errTransient}}
+		cardStore := postgres.NewRetryingCardStore(inner, policy, nil)
+
+		_, err := cardStore.GetByID(context.Background(), uuid.New())
+
+		assert.ErrorIs(t, err, errTransient)
+		assert.Equal(t, 3, inner.getCalls)
+	})
+
+	t.Run("read_does_not_retry_permanent_error", func(t *testing.T) {
+		inner := &flakyCardStore{getErrs: []error{store.ErrCardNotFound}}
+		cardStore := postgres.NewRetryingCardStore(inner, policy, nil)
+
+		_, err := cardStore.GetByID(context.Background(), uuid.New())
+
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+		assert.Equal(t, 1, inner.getCalls)
+	})
+
+	t.Run("read_stops_when_context_is_done", func(t *testing.T) {
+		inner := &flakyCardStore{getErrs: []error{errTransient, errTransient, errTransient}}
+		cardStore := postgres.NewRetryingCardStore(
+			inner,
+			postgres.ReadRetryPolicy{MaxRetries: 2, Delay: time.Hour},
+			nil,
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := cardStore.GetByID(ctx, uuid.New())
+
+		assert.ErrorIs(t, err, errTransient)
+		assert.Equal(t, 1, inner.getCalls)
+	})
+
+	t.Run("write_is_not_retried", func(t *testing.T) {
+		inner := &flakyCardStore{deleteErr: errTransient}
+		cardStore := postgres.NewRetryingCardStore(inner, policy, nil)
+
+		err := cardStore.Delete(context.Background(), uuid.New())
+
+		assert.ErrorIs(t, err, errTransient)
+		assert.Equal(t, 1, inner.deleteCalls)
+	})
+
+	t.Run("disabled_policy_returns_store_unchanged", func(t *testing.T) {
+		inner := &flakyCardStore{}
+		cardStore := postgres.NewRetryingCardStore(inner, postgres.ReadRetryPolicy{}, nil)
+
+		assert.Same(t, inner, cardStore)
+	})
+}
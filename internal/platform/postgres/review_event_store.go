@@ -0,0 +1,316 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure PostgresReviewEventStore implements store.ReviewEventStore
+var _ store.ReviewEventStore = (*PostgresReviewEventStore)(nil)
+
+// PostgresReviewEventStore implements the store.ReviewEventStore interface
+// using a PostgreSQL database as the storage backend.
+type PostgresReviewEventStore struct {
+	db     store.DBTX
+	logger *slog.Logger
+}
+
+// NewPostgresReviewEventStore creates a new PostgreSQL implementation of the ReviewEventStore interface.
+// It accepts a database connection or transaction that should be initialized and managed by the caller.
+// If logger is nil, a default logger will be used.
+func NewPostgresReviewEventStore(db store.DBTX, logger *slog.Logger) *PostgresReviewEventStore {
+	// Validate inputs
+	if db == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("db cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &PostgresReviewEventStore{
+		db:     db,
+		logger: logger.With(slog.String("component", "review_event_store")),
+	}
+}
+
+// Create implements store.ReviewEventStore.Create
+// It records a new review event.
+// Returns validation errors from the domain ReviewEvent if data is invalid.
+func (s *PostgresReviewEventStore) Create(ctx context.Context, event *domain.ReviewEvent) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	// Validate event before inserting
+	if err := event.Validate(); err != nil {
+		log.Warn("review event validation failed",
+			slog.String("error", err.Error()),
+			slog.String("user_id", event.UserID.String()),
+			slog.String("card_id", event.CardID.String()))
+		return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+	}
+
+	query := `
+		INSERT INTO review_events (id, user_id, card_id, outcome, reviewed_at, created_at,
+		                           interval_before, interval_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := s.db.ExecContext(
+		ctx,
+		query,
+		event.ID,
+		event.UserID,
+		event.CardID,
+		string(event.Outcome),
+		event.ReviewedAt,
+		event.CreatedAt,
+		event.IntervalBefore,
+		event.IntervalAfter,
+	)
+	if err != nil {
+		log.Error("failed to insert review event",
+			slog.String("error", err.Error()),
+			slog.String("user_id", event.UserID.String()),
+			slog.String("card_id", event.CardID.String()))
+		return fmt.Errorf("failed to insert review event: %w", MapError(err))
+	}
+
+	log.Debug("review event created successfully",
+		slog.String("event_id", event.ID.String()),
+		slog.String("user_id", event.UserID.String()),
+		slog.String("card_id", event.CardID.String()),
+		slog.String("outcome", string(event.Outcome)))
+	return nil
+}
+
+// ListByCard implements store.ReviewEventStore.ListByCard
+// It retrieves a card's review events, oldest first, using the card and review
+// time index.
+func (s *PostgresReviewEventStore) ListByCard(
+	ctx context.Context,
+	cardID uuid.UUID,
+) ([]*domain.ReviewEvent, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT id, user_id, card_id, outcome, reviewed_at, created_at, interval_before, interval_after
+		FROM review_events
+		WHERE card_id = $1
+		ORDER BY reviewed_at ASC, created_at ASC, id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, cardID)
+	if err != nil {
+		log.Error("failed to query review events",
+			slog.String("error", err.Error()),
+			slog.String("card_id", cardID.String()))
+		return nil, fmt.Errorf("failed to list review events: %w", MapError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	events := []*domain.ReviewEvent{}
+	for rows.Next() {
+		var event domain.ReviewEvent
+		var outcome string
+		if err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.CardID,
+			&outcome,
+			&event.ReviewedAt,
+			&event.CreatedAt,
+			&event.IntervalBefore,
+			&event.IntervalAfter,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan review event: %w", MapError(err))
+		}
+		event.Outcome = domain.ReviewOutcome(outcome)
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate review events: %w", MapError(err))
+	}
+
+	log.Debug("listed review events",
+		slog.String("card_id", cardID.String()),
+		slog.Int("count", len(events)))
+	return events, nil
+}
+
+// retentionWindowStart is the start of the user's calendar day days-1 days
+// before today, in their timezone. It expects the user ID as $1, the number of
+// days as $2 and the users table as u.
+const retentionWindowStart = `(((NOW() AT TIME ZONE u.timezone)::date - ($2::int - 1))::timestamp
+	AT TIME ZONE u.timezone)`
+
+// CountOutcomes implements store.ReviewEventStore.CountOutcomes
+// It counts the events with a single grouped query using the user and review
+// time index.
+func (s *PostgresReviewEventStore) CountOutcomes(
+	ctx context.Context,
+	userID uuid.UUID,
+	days int,
+) ([]domain.ReviewOutcomeCount, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if days < 1 || days > domain.MaxRetentionDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d",
+			store.ErrInvalidEntity, domain.MaxRetentionDays)
+	}
+
+	// The maturity buckets must match domain.MaturityForInterval
+	query := `
+		SELECT CASE
+				WHEN re.interval_before IS NULL THEN $3::text
+				WHEN re.interval_before < 1 THEN $4::text
+				WHEN re.interval_before < $7::int THEN $5::text
+				ELSE $6::text
+			END AS maturity,
+			re.outcome,
+			COUNT(*)
+		FROM review_events re
+		JOIN users u ON u.id = re.user_id
+		WHERE re.user_id = $1
+		  AND re.reviewed_at >= ` + retentionWindowStart + `
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query,
+		userID,
+		days,
+		string(domain.CardMaturityUnknown),
+		string(domain.CardMaturityLearning),
+		string(domain.CardMaturityYoung),
+		string(domain.CardMaturityMature),
+		domain.MatureIntervalDays,
+	)
+	if err != nil {
+		log.Error("failed to count review outcomes",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to count review outcomes: %w", MapError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	counts := []domain.ReviewOutcomeCount{}
+	for rows.Next() {
+		var count domain.ReviewOutcomeCount
+		var maturity, outcome string
+		if err := rows.Scan(&maturity, &outcome, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan review outcome count: %w", MapError(err))
+		}
+		count.Maturity = domain.CardMaturity(maturity)
+		count.Outcome = domain.ReviewOutcome(outcome)
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate review outcome counts: %w", MapError(err))
+	}
+
+	log.Debug("counted review outcomes",
+		slog.String("user_id", userID.String()),
+		slog.Int("days", days))
+	return counts, nil
+}
+
+// CountByDay implements store.ReviewEventStore.CountByDay
+// It counts the events with a single grouped query, filling in days without
+// reviews from a generated series.
+func (s *PostgresReviewEventStore) CountByDay(
+	ctx context.Context,
+	userID uuid.UUID,
+	days int,
+) ([]domain.ReviewDayCount, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if days < 1 || days > domain.MaxRetentionDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d",
+			store.ErrInvalidEntity, domain.MaxRetentionDays)
+	}
+
+	query := `
+		WITH bounds AS (
+			SELECT u.timezone,
+				(NOW() AT TIME ZONE u.timezone)::date AS today,
+				` + retentionWindowStart + ` AS window_start
+			FROM users u
+			WHERE u.id = $1
+		),
+		reviews AS (
+			SELECT (re.reviewed_at AT TIME ZONE b.timezone)::date AS reviewed_on,
+				COUNT(*) AS review_count
+			FROM review_events re
+			CROSS JOIN bounds b
+			WHERE re.user_id = $1
+			  AND re.reviewed_at >= b.window_start
+			GROUP BY 1
+		)
+		SELECT day::date, COALESCE(reviews.review_count, 0)
+		FROM bounds b
+		CROSS JOIN generate_series((b.today - ($2::int - 1))::timestamp, b.today::timestamp,
+			interval '1 day') AS day
+		LEFT JOIN reviews ON reviews.reviewed_on = day::date
+		ORDER BY day
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, days)
+	if err != nil {
+		log.Error("failed to count reviews by day",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to count reviews by day: %w", MapError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	counts := make([]domain.ReviewDayCount, 0, days)
+	for rows.Next() {
+		var count domain.ReviewDayCount
+		if err := rows.Scan(&count.Date, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan review day count: %w", MapError(err))
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate review day counts: %w", MapError(err))
+	}
+
+	log.Debug("counted reviews by day",
+		slog.String("user_id", userID.String()),
+		slog.Int("days", days))
+	return counts, nil
+}
+
+// WithTx implements store.ReviewEventStore.WithTx
+// It returns a new ReviewEventStore instance that uses the provided transaction.
+func (s *PostgresReviewEventStore) WithTx(tx *sql.Tx) store.ReviewEventStore {
+	return &PostgresReviewEventStore{
+		db:     tx,
+		logger: s.logger,
+	}
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestPostgresReviewEventStore_ListByCard tests that a card's review history is
+// returned in chronological order with the recorded intervals
+func TestPostgresReviewEventStore_ListByCard(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx := context.Background()
+		userStore := NewPostgresUserStore(tx, bcrypt.MinCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+		eventStore := NewPostgresReviewEventStore(tx, nil)
+
+		user, err := domain.NewUser("review-history@example.com", "password123456")
+		require.NoError(t, err)
+		require.NoError(t, userStore.Create(ctx, user))
+		memo, err := domain.NewMemo(user.ID, "Review history memo")
+		require.NoError(t, err)
+		require.NoError(t, memoStore.Create(ctx, memo))
+
+		cards := make([]*domain.Card, 2)
+		for i := range cards {
+			cards[i], err = domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+			require.NoError(t, err)
+		}
+		require.NoError(t, cardStore.CreateMultiple(ctx, cards))
+
+		t.Run("no reviews returns empty slice", func(t *testing.T) {
+			events, err := eventStore.ListByCard(ctx, uuid.New())
+			require.NoError(t, err)
+			assert.NotNil(t, events)
+			assert.Empty(t, events)
+		})
+
+		t.Run("events are returned oldest first", func(t *testing.T) {
+			base := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+			// Record the answers out of order, with one answer from before intervals were tracked
+			second, err := domain.NewReviewEvent(user.ID, cards[0].ID, domain.ReviewOutcomeGood, base.Add(24*time.Hour))
+			require.NoError(t, err)
+			second.SetIntervals(1, 6)
+			first, err := domain.NewReviewEvent(user.ID, cards[0].ID, domain.ReviewOutcomeAgain, base)
+			require.NoError(t, err)
+			other, err := domain.NewReviewEvent(user.ID, cards[1].ID, domain.ReviewOutcomeEasy, base)
+			require.NoError(t, err)
+			other.SetIntervals(0, 4)
+			for _, event := range []*domain.ReviewEvent{second, first, other} {
+				require.NoError(t, eventStore.Create(ctx, event))
+			}
+
+			events, err := eventStore.ListByCard(ctx, cards[0].ID)
+			require.NoError(t, err)
+			require.Len(t, events, 2, "Only the card's own events are listed")
+
+			assert.Equal(t, first.ID, events[0].ID)
+			assert.Equal(t, domain.ReviewOutcomeAgain, events[0].Outcome)
+			assert.True(t, base.Equal(events[0].ReviewedAt))
+			assert.Nil(t, events[0].IntervalBefore)
+			assert.Nil(t, events[0].IntervalAfter)
+
+			assert.Equal(t, second.ID, events[1].ID)
+			assert.Equal(t, domain.ReviewOutcomeGood, events[1].Outcome)
+			require.NotNil(t, events[1].IntervalBefore)
+			require.NotNil(t, events[1].IntervalAfter)
+			assert.Equal(t, 1, *events[1].IntervalBefore)
+			assert.Equal(t, 6, *events[1].IntervalAfter)
+		})
+	})
+}
+
+// TestPostgresReviewEventStore_Conformance runs the shared ReviewEventStore conformance suite
+func TestPostgresReviewEventStore_Conformance(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		_ = db.Close()
+	}()
+
+	storetest.RunReviewEventStoreSuite(t, newConformanceStoreFactory(db))
+}
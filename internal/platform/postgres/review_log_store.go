@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Compile-time check to ensure PostgresReviewLogStore implements store.ReviewLogStore
+var _ store.ReviewLogStore = (*PostgresReviewLogStore)(nil)
+
+// PostgresReviewLogStore implements the store.ReviewLogStore interface
+// using a PostgreSQL database as the storage backend.
+type PostgresReviewLogStore struct {
+	db     store.DBTX
+	logger *slog.Logger
+}
+
+// NewPostgresReviewLogStore creates a new PostgreSQL implementation of the ReviewLogStore interface.
+// It accepts a database connection or transaction that should be initialized and managed by the caller.
+// If logger is nil, a default logger will be used.
+func NewPostgresReviewLogStore(db store.DBTX, logger *slog.Logger) *PostgresReviewLogStore {
+	// Validate inputs
+	if db == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("db cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &PostgresReviewLogStore{
+		db:     db,
+		logger: logger.With(slog.String("component", "review_log_store")),
+	}
+}
+
+// RecordReview implements store.ReviewLogStore.RecordReview
+// The user's timezone is read in the same statement, so the stored date always
+// reflects the timezone configured at the time of the review.
+func (s *PostgresReviewLogStore) RecordReview(
+	ctx context.Context,
+	userID uuid.UUID,
+	reviewedAt time.Time,
+) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		INSERT INTO review_log (user_id, reviewed_on)
+		SELECT id, ($2::timestamptz AT TIME ZONE timezone)::date
+		FROM users
+		WHERE id = $1
+		ON CONFLICT (user_id, reviewed_on) DO NOTHING
+	`
+
+	_, err := s.db.ExecContext(ctx, query, userID, reviewedAt)
+	if err != nil {
+		log.Error("failed to record review day",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return fmt.Errorf("failed to record review day: %w", MapError(err))
+	}
+
+	log.Debug("review day recorded",
+		slog.String("user_id", userID.String()),
+		slog.Time("reviewed_at", reviewedAt))
+	return nil
+}
+
+// RecordNewCard implements store.ReviewLogStore.RecordNewCard
+// Like RecordReview, the day is derived from the user's timezone in the same statement.
+func (s *PostgresReviewLogStore) RecordNewCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	reviewedAt time.Time,
+) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		INSERT INTO review_log (user_id, reviewed_on, new_cards)
+		SELECT id, ($2::timestamptz AT TIME ZONE timezone)::date, 1
+		FROM users
+		WHERE id = $1
+		ON CONFLICT (user_id, reviewed_on)
+		DO UPDATE SET new_cards = review_log.new_cards + 1
+	`
+
+	_, err := s.db.ExecContext(ctx, query, userID, reviewedAt)
+	if err != nil {
+		log.Error("failed to record new card",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return fmt.Errorf("failed to record new card: %w", MapError(err))
+	}
+
+	log.Debug("new card recorded",
+		slog.String("user_id", userID.String()),
+		slog.Time("reviewed_at", reviewedAt))
+	return nil
+}
+
+// CountNewCards implements store.ReviewLogStore.CountNewCards
+func (s *PostgresReviewLogStore) CountNewCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	at time.Time,
+) (int, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT COALESCE(SUM(rl.new_cards), 0)
+		FROM review_log rl
+		JOIN users u ON u.id = rl.user_id
+		WHERE rl.user_id = $1
+		  AND rl.reviewed_on = ($2::timestamptz AT TIME ZONE u.timezone)::date
+	`
+
+	var count int
+	err := s.db.QueryRowContext(ctx, query, userID, at).Scan(&count)
+	if err != nil {
+		log.Error("failed to count new cards",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return 0, fmt.Errorf("failed to count new cards: %w", MapError(err))
+	}
+
+	log.Debug("counted new cards",
+		slog.String("user_id", userID.String()),
+		slog.Int("count", count))
+	return count, nil
+}
+
+// GetReviewDays implements store.ReviewLogStore.GetReviewDays
+func (s *PostgresReviewLogStore) GetReviewDays(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]time.Time, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT reviewed_on
+		FROM review_log
+		WHERE user_id = $1
+		ORDER BY reviewed_on ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Error("failed to query review days",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to query review days: %w", MapError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	days := []time.Time{}
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			log.Error("failed to scan review day",
+				slog.String("error", err.Error()),
+				slog.String("user_id", userID.String()))
+			return nil, fmt.Errorf("failed to scan review day: %w", MapError(err))
+		}
+		year, month, date := day.Date()
+		days = append(days, time.Date(year, month, date, 0, 0, 0, 0, time.UTC))
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating review days",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to iterate review days: %w", MapError(err))
+	}
+
+	log.Debug("retrieved review days",
+		slog.String("user_id", userID.String()),
+		slog.Int("count", len(days)))
+	return days, nil
+}
+
+// WithTx implements store.ReviewLogStore.WithTx
+// It returns a new ReviewLogStore instance that uses the provided transaction.
+func (s *PostgresReviewLogStore) WithTx(tx *sql.Tx) store.ReviewLogStore {
+	return &PostgresReviewLogStore{
+		db:     tx,
+		logger: s.logger,
+	}
+}
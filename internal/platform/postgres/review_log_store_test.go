@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestPostgresReviewLogStore_RecordReview tests that review days are recorded
+// in the user's timezone and deduplicated per day
+func TestPostgresReviewLogStore_RecordReview(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := getTestDBForCardStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForCardTest(t, db, func(tx *sql.Tx) {
+		ctx := context.Background()
+		userStore := NewPostgresUserStore(tx, bcrypt.MinCost)
+		reviewLogStore := NewPostgresReviewLogStore(tx, nil)
+
+		createUser := func(email, timezone string) *domain.User {
+			user, err := domain.NewUser(email, "password123456")
+			require.NoError(t, err, "Failed to create test user")
+			user.Timezone = timezone
+			require.NoError(t, userStore.Create(ctx, user), "Failed to create test user in DB")
+			return user
+		}
+
+		date := func(year int, month time.Month, day int) time.Time {
+			return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		}
+
+		t.Run("no reviews returns empty slice", func(t *testing.T) {
+			user := createUser("review-log-empty@example.com", "UTC")
+
+			days, err := reviewLogStore.GetReviewDays(ctx, user.ID)
+			require.NoError(t, err)
+			assert.NotNil(t, days)
+			assert.Empty(t, days)
+		})
+
+		t.Run("days follow the user's timezone", func(t *testing.T) {
+			tokyo := createUser("review-log-tokyo@example.com", "Asia/Tokyo")
+			losAngeles := createUser("review-log-la@example.com", "America/Los_Angeles")
+
+			// 14:00 UTC on March 1 is 23:00 on March 1 in Tokyo
+			// 16:00 UTC on March 1 is 01:00 on March 2 in Tokyo
+			// 20:00 UTC on March 1 is also March 2 in Tokyo and must be deduplicated
+			for _, reviewedAt := range []time.Time{
+				time.Date(2025, time.March, 1, 14, 0, 0, 0, time.UTC),
+				time.Date(2025, time.March, 1, 16, 0, 0, 0, time.UTC),
+				time.Date(2025, time.March, 1, 20, 0, 0, 0, time.UTC),
+			} {
+				require.NoError(t, reviewLogStore.RecordReview(ctx, tokyo.ID, reviewedAt))
+			}
+
+			// 05:00 UTC on March 2 is still March 1 in Los Angeles
+			require.NoError(t, reviewLogStore.RecordReview(
+				ctx,
+				losAngeles.ID,
+				time.Date(2025, time.March, 2, 5, 0, 0, 0, time.UTC),
+			))
+
+			tokyoDays, err := reviewLogStore.GetReviewDays(ctx, tokyo.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []time.Time{
+				date(2025, time.March, 1),
+				date(2025, time.March, 2),
+			}, tokyoDays)
+
+			laDays, err := reviewLogStore.GetReviewDays(ctx, losAngeles.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []time.Time{date(2025, time.March, 1)}, laDays)
+		})
+
+		t.Run("new cards are counted per day in the user's timezone", func(t *testing.T) {
+			tokyo := createUser("review-log-new-tokyo@example.com", "Asia/Tokyo")
+			losAngeles := createUser("review-log-new-la@example.com", "America/Los_Angeles")
+
+			// 20:00 UTC on March 1 is March 2 in Tokyo and March 1 in Los Angeles
+			at := time.Date(2025, time.March, 1, 20, 0, 0, 0, time.UTC)
+			for _, user := range []*domain.User{tokyo, losAngeles} {
+				require.NoError(t, reviewLogStore.RecordReview(ctx, user.ID, at))
+				require.NoError(t, reviewLogStore.RecordNewCard(ctx, user.ID, at))
+				require.NoError(t, reviewLogStore.RecordNewCard(ctx, user.ID, at))
+			}
+
+			// 10:00 UTC on March 2 is still March 2 in both timezones
+			nextMorning := time.Date(2025, time.March, 2, 10, 0, 0, 0, time.UTC)
+
+			count, err := reviewLogStore.CountNewCards(ctx, tokyo.ID, nextMorning)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count, "Tokyo cards were introduced on March 2")
+
+			count, err = reviewLogStore.CountNewCards(ctx, losAngeles.ID, nextMorning)
+			require.NoError(t, err)
+			assert.Equal(t, 0, count, "Los Angeles budget resets on March 2")
+
+			count, err = reviewLogStore.CountNewCards(ctx, losAngeles.ID, at)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			// Recording new cards also marks the review day
+			laDays, err := reviewLogStore.GetReviewDays(ctx, losAngeles.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []time.Time{date(2025, time.March, 1)}, laDays)
+		})
+	})
+}
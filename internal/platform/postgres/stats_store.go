@@ -75,8 +75,9 @@ func (s *PostgresUserCardStatsStore) Create(
 
 	query := `
 		INSERT INTO user_card_stats (user_id, card_id, interval, ease_factor, consecutive_correct,
-								   last_reviewed_at, next_review_at, review_count, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+								   last_reviewed_at, next_review_at, review_count, lapses, phase, learning_step,
+								   created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	// Handling NULL value for LastReviewedAt
@@ -98,6 +99,9 @@ func (s *PostgresUserCardStatsStore) Create(
 		lastReviewedAt,
 		stats.NextReviewAt,
 		stats.ReviewCount,
+		stats.Lapses,
+		stats.CurrentPhase(),
+		stats.LearningStep,
 		stats.CreatedAt,
 		stats.UpdatedAt,
 	)
@@ -125,14 +129,14 @@ func (s *PostgresUserCardStatsStore) Create(
 					slog.String("error", err.Error()),
 					slog.String("user_id", stats.UserID.String()))
 				return fmt.Errorf("%w: user with ID %s not found",
-					store.ErrInvalidEntity, stats.UserID)
+					store.ErrReferencedEntityMissing, stats.UserID)
 			}
 			if strings.Contains(pgErr.Message, "fk_user_card_stats_card") {
 				log.Warn("foreign key violation - card does not exist",
 					slog.String("error", err.Error()),
 					slog.String("card_id", stats.CardID.String()))
 				return fmt.Errorf("%w: card with ID %s not found",
-					store.ErrInvalidEntity, stats.CardID)
+					store.ErrReferencedEntityMissing, stats.CardID)
 			}
 		}
 
@@ -140,7 +144,7 @@ func (s *PostgresUserCardStatsStore) Create(
 			slog.String("error", err.Error()),
 			slog.String("user_id", stats.UserID.String()),
 			slog.String("card_id", stats.CardID.String()))
-		return fmt.Errorf("failed to create user card stats: %w", MapError(err))
+		return fmt.Errorf("failed to create user card stats: %w", mapUserCardStatsError(err))
 	}
 
 	log.Debug("user card stats created successfully",
@@ -170,7 +174,8 @@ func (s *PostgresUserCardStatsStore) Get(
 
 	query := `
 		SELECT user_id, card_id, interval, ease_factor, consecutive_correct,
-			   last_reviewed_at, next_review_at, review_count, created_at, updated_at
+			   last_reviewed_at, next_review_at, review_count, lapses, phase, learning_step,
+			   created_at, updated_at
 		FROM user_card_stats
 		WHERE user_id = $1 AND card_id = $2
 	`
@@ -187,6 +192,9 @@ func (s *PostgresUserCardStatsStore) Get(
 		&lastReviewedAt,
 		&stats.NextReviewAt,
 		&stats.ReviewCount,
+		&stats.Lapses,
+		&stats.Phase,
+		&stats.LearningStep,
 		&stats.CreatedAt,
 		&stats.UpdatedAt,
 	)
@@ -202,7 +210,7 @@ func (s *PostgresUserCardStatsStore) Get(
 			slog.String("error", err.Error()),
 			slog.String("user_id", userID.String()),
 			slog.String("card_id", cardID.String()))
-		return nil, fmt.Errorf("failed to query user card stats: %w", MapError(err))
+		return nil, fmt.Errorf("failed to query user card stats: %w", mapUserCardStatsError(err))
 	}
 
 	// Handle the nullable LastReviewedAt field
@@ -253,8 +261,11 @@ func (s *PostgresUserCardStatsStore) Update(
 			last_reviewed_at = $4,
 			next_review_at = $5,
 			review_count = $6,
-			updated_at = $7
-		WHERE user_id = $8 AND card_id = $9
+			lapses = $7,
+			phase = $8,
+			learning_step = $9,
+			updated_at = $10
+		WHERE user_id = $11 AND card_id = $12
 	`
 
 	// Handling NULL value for LastReviewedAt
@@ -274,6 +285,9 @@ func (s *PostgresUserCardStatsStore) Update(
 		lastReviewedAt,
 		stats.NextReviewAt,
 		stats.ReviewCount,
+		stats.Lapses,
+		stats.CurrentPhase(),
+		stats.LearningStep,
 		stats.UpdatedAt,
 		stats.UserID,
 		stats.CardID,
@@ -284,7 +298,7 @@ func (s *PostgresUserCardStatsStore) Update(
 			slog.String("error", err.Error()),
 			slog.String("user_id", stats.UserID.String()),
 			slog.String("card_id", stats.CardID.String()))
-		return fmt.Errorf("failed to execute user card stats update: %w", MapError(err))
+		return fmt.Errorf("failed to execute user card stats update: %w", mapUserCardStatsError(err))
 	}
 
 	// Check if a row was actually updated using the helper
@@ -303,6 +317,144 @@ func (s *PostgresUserCardStatsStore) Update(
 	return nil
 }
 
+// UpdateMultiple implements store.UserCardStatsStore.UpdateMultiple
+// All entries are written by one UPDATE joined against the unnested input arrays.
+// The update only applies when every input row matches an existing entry for its
+// user, so a single statement is all-or-nothing even outside a transaction.
+func (s *PostgresUserCardStatsStore) UpdateMultiple(
+	ctx context.Context,
+	stats []*domain.UserCardStats,
+) error {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if len(stats) == 0 {
+		log.Debug("no user card stats to update")
+		return nil
+	}
+
+	// Pass UUIDs and timestamps as text and cast in SQL so the driver encodes plain
+	// text arrays; an empty last_reviewed_at stands for NULL
+	n := len(stats)
+	userIDs := make([]string, n)
+	cardIDs := make([]string, n)
+	intervals := make([]int64, n)
+	easeFactors := make([]float64, n)
+	consecutiveCorrect := make([]int64, n)
+	lastReviewedAt := make([]string, n)
+	nextReviewAt := make([]string, n)
+	reviewCounts := make([]int64, n)
+	lapses := make([]int64, n)
+	phases := make([]string, n)
+	learningSteps := make([]int64, n)
+
+	seen := make(map[[2]uuid.UUID]struct{}, n)
+	updatedAt := time.Now().UTC()
+	for i, st := range stats {
+		if err := st.Validate(); err != nil {
+			log.Warn("user card stats validation failed during batch update",
+				slog.String("error", err.Error()),
+				slog.String("user_id", st.UserID.String()),
+				slog.String("card_id", st.CardID.String()))
+			return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
+		}
+		key := [2]uuid.UUID{st.UserID, st.CardID}
+		if _, dup := seen[key]; dup {
+			return fmt.Errorf("%w: stats for user_id=%s and card_id=%s given more than once",
+				store.ErrInvalidEntity, st.UserID, st.CardID)
+		}
+		seen[key] = struct{}{}
+
+		userIDs[i] = st.UserID.String()
+		cardIDs[i] = st.CardID.String()
+		intervals[i] = int64(st.Interval)
+		easeFactors[i] = st.EaseFactor
+		consecutiveCorrect[i] = int64(st.ConsecutiveCorrect)
+		if !st.LastReviewedAt.IsZero() {
+			lastReviewedAt[i] = st.LastReviewedAt.UTC().Format(time.RFC3339Nano)
+		}
+		nextReviewAt[i] = st.NextReviewAt.UTC().Format(time.RFC3339Nano)
+		reviewCounts[i] = int64(st.ReviewCount)
+		lapses[i] = int64(st.Lapses)
+		phases[i] = string(st.CurrentPhase())
+		learningSteps[i] = int64(st.LearningStep)
+	}
+
+	query := `
+		WITH input AS (
+			SELECT t.user_id, t.card_id, t.interval, t.ease_factor, t.consecutive_correct,
+			       NULLIF(t.last_reviewed_at, '')::timestamptz AS last_reviewed_at,
+			       t.next_review_at::timestamptz AS next_review_at,
+			       t.review_count, t.lapses, t.phase, t.learning_step
+			FROM unnest($1::uuid[], $2::uuid[], $3::int[], $4::float8[], $5::int[],
+			            $6::text[], $7::text[], $8::int[], $9::int[], $10::text[], $11::int[])
+			     AS t(user_id, card_id, interval, ease_factor, consecutive_correct,
+			          last_reviewed_at, next_review_at, review_count, lapses, phase, learning_step)
+		),
+		matched AS (
+			SELECT COUNT(*) AS count
+			FROM user_card_stats ucs
+			JOIN input i ON ucs.user_id = i.user_id AND ucs.card_id = i.card_id
+		)
+		UPDATE user_card_stats ucs
+		SET interval = i.interval,
+			ease_factor = i.ease_factor,
+			consecutive_correct = i.consecutive_correct,
+			last_reviewed_at = i.last_reviewed_at,
+			next_review_at = i.next_review_at,
+			review_count = i.review_count,
+			lapses = i.lapses,
+			phase = i.phase,
+			learning_step = i.learning_step,
+			updated_at = $12
+		FROM input i
+		WHERE ucs.user_id = i.user_id AND ucs.card_id = i.card_id
+		  AND (SELECT count FROM matched) = $13
+	`
+
+	result, err := s.db.ExecContext(
+		ctx,
+		query,
+		userIDs,
+		cardIDs,
+		intervals,
+		easeFactors,
+		consecutiveCorrect,
+		lastReviewedAt,
+		nextReviewAt,
+		reviewCounts,
+		lapses,
+		phases,
+		learningSteps,
+		updatedAt,
+		n,
+	)
+	if err != nil {
+		log.Error("failed to batch update user card stats",
+			slog.String("error", err.Error()),
+			slog.Int("count", n))
+		return fmt.Errorf("failed to execute user card stats batch update: %w", mapUserCardStatsError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected != int64(n) {
+		log.Warn("batch update matched missing user card stats",
+			slog.Int("count", n),
+			slog.Int64("rows_affected", rowsAffected))
+		return fmt.Errorf("%w: not every entry exists for its user", store.ErrUserCardStatsNotFound)
+	}
+
+	for _, st := range stats {
+		st.UpdatedAt = updatedAt
+	}
+
+	log.Debug("user card stats batch updated successfully", slog.Int("count", n))
+	return nil
+}
+
 // Delete implements store.UserCardStatsStore.Delete
 // It removes user card statistics by the combination of user ID and card ID.
 // Returns store.ErrUserCardStatsNotFound if the statistics entry does not exist.
@@ -325,7 +477,7 @@ func (s *PostgresUserCardStatsStore) Delete(ctx context.Context, userID, cardID
 			slog.String("error", err.Error()),
 			slog.String("user_id", userID.String()),
 			slog.String("card_id", cardID.String()))
-		return fmt.Errorf("failed to execute user card stats deletion: %w", MapError(err))
+		return fmt.Errorf("failed to execute user card stats deletion: %w", mapUserCardStatsError(err))
 	}
 
 	// Check if a row was actually deleted using the helper
@@ -360,7 +512,8 @@ func (s *PostgresUserCardStatsStore) GetForUpdate(
 
 	query := `
 		SELECT user_id, card_id, interval, ease_factor, consecutive_correct,
-			   last_reviewed_at, next_review_at, review_count, created_at, updated_at
+			   last_reviewed_at, next_review_at, review_count, lapses, phase, learning_step,
+			   created_at, updated_at
 		FROM user_card_stats
 		WHERE user_id = $1 AND card_id = $2
 		FOR UPDATE
@@ -378,6 +531,9 @@ func (s *PostgresUserCardStatsStore) GetForUpdate(
 		&lastReviewedAt,
 		&stats.NextReviewAt,
 		&stats.ReviewCount,
+		&stats.Lapses,
+		&stats.Phase,
+		&stats.LearningStep,
 		&stats.CreatedAt,
 		&stats.UpdatedAt,
 	)
@@ -393,7 +549,7 @@ func (s *PostgresUserCardStatsStore) GetForUpdate(
 			slog.String("error", err.Error()),
 			slog.String("user_id", userID.String()),
 			slog.String("card_id", cardID.String()))
-		return nil, fmt.Errorf("failed to query user card stats with lock: %w", MapError(err))
+		return nil, fmt.Errorf("failed to query user card stats with lock: %w", mapUserCardStatsError(err))
 	}
 
 	// Handle the nullable LastReviewedAt field
@@ -410,6 +566,123 @@ func (s *PostgresUserCardStatsStore) GetForUpdate(
 	return &stats, nil
 }
 
+// CountDue implements store.UserCardStatsStore.CountDue
+// It counts the user's cards that are due as of the given time.
+func (s *PostgresUserCardStatsStore) CountDue(
+	ctx context.Context,
+	userID uuid.UUID,
+	asOf time.Time,
+) (int, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	query := `
+		SELECT COUNT(*)
+		FROM user_card_stats ucs
+		WHERE ucs.user_id = $1
+		  AND ` + dueCondition("ucs", "$2") + `
+	`
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, query, userID, asOf.UTC()).Scan(&count); err != nil {
+		log.Error("failed to count due cards",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return 0, fmt.Errorf("failed to count due cards: %w", mapUserCardStatsError(err))
+	}
+
+	log.Debug("counted due cards for user",
+		slog.String("user_id", userID.String()),
+		slog.Time("as_of", asOf),
+		slog.Int("count", count))
+	return count, nil
+}
+
+// GetForecast implements store.UserCardStatsStore.GetForecast
+// Due dates are grouped in a single query; generate_series fills in days with no reviews.
+// The window covers every card due as of the last instant of its final day, matching
+// domain.User.DueAsOfEndOfDay.
+func (s *PostgresUserCardStatsStore) GetForecast(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	days int,
+) ([]domain.ForecastBucket, error) {
+	// Get the logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if days < 1 || days > domain.MaxForecastDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d",
+			store.ErrInvalidEntity, domain.MaxForecastDays)
+	}
+
+	args := []interface{}{userID, days}
+	deckJoin := ""
+	deckCondition := ""
+	if deckID != nil {
+		deckJoin = "JOIN cards c ON c.id = ucs.card_id"
+		deckCondition = "AND c.deck_id = $3"
+		args = append(args, *deckID)
+	}
+
+	query := `
+		WITH bounds AS (
+			SELECT timezone, today,
+				((today + $2::int)::timestamp AT TIME ZONE timezone) - interval '1 microsecond' AS window_end
+			FROM (
+				SELECT timezone, (NOW() AT TIME ZONE timezone)::date AS today
+				FROM users
+				WHERE id = $1
+			) u
+		),
+		due AS (
+			SELECT GREATEST((ucs.next_review_at AT TIME ZONE b.timezone)::date, b.today) AS due_on,
+				COUNT(*) AS review_count
+			FROM user_card_stats ucs
+			` + deckJoin + `
+			CROSS JOIN bounds b
+			WHERE ucs.user_id = $1
+			  AND ` + dueCondition("ucs", "b.window_end") + `
+			  ` + deckCondition + `
+			GROUP BY 1
+		)
+		SELECT day::date, COALESCE(due.review_count, 0)
+		FROM bounds b
+		CROSS JOIN generate_series(b.today::timestamp, (b.today + $2::int - 1)::timestamp,
+			interval '1 day') AS day
+		LEFT JOIN due ON due.due_on = day::date
+		ORDER BY day
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error("failed to query review forecast",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to get review forecast: %w", mapUserCardStatsError(err))
+	}
+	defer func() {
+		_ = rows.Close() // Ignoring error as it's cleanup code
+	}()
+
+	buckets := make([]domain.ForecastBucket, 0, days)
+	for rows.Next() {
+		var bucket domain.ForecastBucket
+		if err := rows.Scan(&bucket.Date, &bucket.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan forecast bucket: %w", mapUserCardStatsError(err))
+		}
+		buckets = append(buckets, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate forecast buckets: %w", mapUserCardStatsError(err))
+	}
+
+	log.Debug("retrieved review forecast",
+		slog.String("user_id", userID.String()),
+		slog.Int("days", days))
+	return buckets, nil
+}
+
 // WithTx implements store.UserCardStatsStore.WithTx
 // It returns a new UserCardStatsStore instance that uses the provided transaction.
 // This allows for multiple operations to be executed within a single transaction.
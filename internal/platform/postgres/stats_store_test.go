@@ -14,6 +14,7 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib" // pgx driver
 	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/store/storetest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
@@ -94,6 +95,8 @@ func TestUserCardStatsStoreIntegration(t *testing.T) {
 	)
 	t.Run("TestPostgresUserCardStatsStore_Update", TestPostgresUserCardStatsStore_Update)
 	t.Run("TestPostgresUserCardStatsStore_Delete", TestPostgresUserCardStatsStore_Delete)
+	t.Run("TestPostgresUserCardStatsStore_GetForecast", TestPostgresUserCardStatsStore_GetForecast)
+	t.Run("TestPostgresUserCardStatsStore_Conformance", TestPostgresUserCardStatsStore_Conformance)
 }
 
 // TestPostgresUserCardStatsStore_Get tests the Get method
@@ -766,3 +769,206 @@ func TestPostgresUserCardStatsStore_Delete(t *testing.T) {
 		})
 	})
 }
+
+// TestPostgresUserCardStatsStore_GetForecast tests that GetForecast groups due reviews
+// by day in the user's timezone and returns one bucket per day
+func TestPostgresUserCardStatsStore_GetForecast(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkStatsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	t.Parallel() // Enable parallel testing
+
+	// Get a database connection
+	db, err := getTestDBForStatsStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		if db != nil {
+			_ = db.Close()
+		}
+	}()
+
+	withTxForStatsTest(t, db, func(tx *sql.Tx) {
+		ctx, cancel := context.WithTimeout(context.Background(), testStatsTimeout)
+		defer cancel()
+
+		// Create necessary stores
+		userStore := NewPostgresUserStore(tx, bcrypt.DefaultCost)
+		memoStore := NewPostgresMemoStore(tx, nil)
+		cardStore := NewPostgresCardStore(tx, nil)
+		statsStore := NewPostgresUserCardStatsStore(tx, nil)
+
+		// Create a test user in a timezone far from UTC so day boundaries matter
+		const timezone = "Pacific/Auckland"
+		loc, err := time.LoadLocation(timezone)
+		require.NoError(t, err, "Failed to load test timezone")
+
+		testUser, err := domain.NewUser("testforecaststats@example.com", "password123")
+		require.NoError(t, err, "Failed to create test user")
+		require.NoError(t, userStore.Create(ctx, testUser), "Failed to create test user in DB")
+		_, err = tx.ExecContext(ctx, "UPDATE users SET timezone = $1 WHERE id = $2", timezone, testUser.ID)
+		require.NoError(t, err, "Failed to set user timezone")
+
+		testMemo, err := domain.NewMemo(testUser.ID, "Test memo for forecast tests")
+		require.NoError(t, err, "Failed to create test memo")
+		require.NoError(t, memoStore.Create(ctx, testMemo), "Failed to create test memo in DB")
+
+		now := time.Now().In(loc)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+		// Seed cards due at midday on the given day offsets from today (local time)
+		seedDue := func(dayOffsets ...int) {
+			for _, offset := range dayOffsets {
+				content := json.RawMessage(fmt.Sprintf(`{"front":"Forecast %d","back":"Back"}`, offset))
+				card, err := domain.NewCard(testUser.ID, testMemo.ID, content)
+				require.NoError(t, err, "Failed to create test card")
+				require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+				dueAt := today.AddDate(0, 0, offset).Add(12 * time.Hour)
+				_, err = tx.ExecContext(ctx,
+					"UPDATE user_card_stats SET next_review_at = $1 WHERE user_id = $2 AND card_id = $3",
+					dueAt, testUser.ID, card.ID)
+				require.NoError(t, err, "Failed to set next review time")
+			}
+		}
+		// Overdue cards count towards today; day 30 falls outside a 7-day window
+		seedDue(-3, 0, 1, 1, 1, 4, 6, 30)
+
+		t.Run("dense_buckets", func(t *testing.T) {
+			buckets, err := statsStore.GetForecast(ctx, testUser.ID, nil, 7)
+			require.NoError(t, err)
+			require.Len(t, buckets, 7)
+
+			expected := []int{2, 3, 0, 0, 1, 0, 1}
+			for i, bucket := range buckets {
+				assert.Equal(t, today.AddDate(0, 0, i).Format(time.DateOnly),
+					bucket.Date.Format(time.DateOnly), "Unexpected date for bucket %d", i)
+				assert.Equal(t, expected[i], bucket.Count, "Unexpected count for bucket %d", i)
+			}
+		})
+
+		t.Run("longer_window", func(t *testing.T) {
+			buckets, err := statsStore.GetForecast(ctx, testUser.ID, nil, 31)
+			require.NoError(t, err)
+			require.Len(t, buckets, 31)
+			assert.Equal(t, 1, buckets[30].Count)
+
+			total := 0
+			for _, bucket := range buckets {
+				total += bucket.Count
+			}
+			assert.Equal(t, 8, total)
+		})
+
+		t.Run("invalid_days", func(t *testing.T) {
+			_, err := statsStore.GetForecast(ctx, testUser.ID, nil, 0)
+			assert.ErrorIs(t, err, store.ErrInvalidEntity)
+
+			_, err = statsStore.GetForecast(ctx, testUser.ID, nil, domain.MaxForecastDays+1)
+			assert.ErrorIs(t, err, store.ErrInvalidEntity)
+		})
+	})
+}
+
+// TestPostgresUserCardStatsStore_Conformance runs the shared UserCardStatsStore conformance suite
+func TestPostgresUserCardStatsStore_Conformance(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkStatsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	db, err := getTestDBForStatsStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		_ = db.Close()
+	}()
+
+	storetest.RunUserCardStatsStoreSuite(t, newConformanceStoreFactory(db))
+}
+
+// TestPostgresUserCardStatsStore_UpdateMultiple tests that UpdateMultiple writes a
+// whole batch in one call and leaves every entry unchanged if any is missing
+func TestPostgresUserCardStatsStore_UpdateMultiple(t *testing.T) {
+	// Skip if not in integration test environment
+	if !checkStatsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	db, err := getTestDBForStatsStore()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer func() {
+		_ = db.Close()
+	}()
+
+	const batchSize = 50
+
+	// createBatch creates batchSize cards with default stats and returns the stats
+	createBatch := func(ctx context.Context, t *testing.T, s storetest.Stores) []*domain.UserCardStats {
+		user := storetest.MustCreateUser(ctx, t, s)
+		memo := storetest.MustCreateMemo(ctx, t, s, user.ID)
+		created := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+
+		batch := make([]*domain.UserCardStats, batchSize)
+		for i := range batch {
+			card := storetest.MustCreateCard(ctx, t, s, memo, fmt.Sprintf("card %d", i), created, created, 0)
+			stats, err := s.Stats.Get(ctx, user.ID, card.ID)
+			require.NoError(t, err)
+			batch[i] = stats
+		}
+		return batch
+	}
+
+	t.Run("updates_all_entries", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), testStatsTimeout)
+		defer cancel()
+		s := newConformanceStoreFactory(db)(t)
+		batch := createBatch(ctx, t, s)
+
+		reviewedAt := time.Now().UTC().Truncate(time.Microsecond)
+		for i, stats := range batch {
+			stats.Interval = i + 1
+			stats.EaseFactor = 2.1
+			stats.ConsecutiveCorrect = 1
+			stats.ReviewCount = 1
+			stats.LastReviewedAt = reviewedAt
+			stats.NextReviewAt = reviewedAt.Add(time.Duration(i+1) * 24 * time.Hour)
+		}
+
+		require.NoError(t, s.Stats.UpdateMultiple(ctx, batch))
+
+		for i, want := range batch {
+			got, err := s.Stats.Get(ctx, want.UserID, want.CardID)
+			require.NoError(t, err)
+			assert.Equal(t, i+1, got.Interval, "entry %d should have its own interval", i)
+			assert.InDelta(t, 2.1, got.EaseFactor, 0.001)
+			assert.Equal(t, 1, got.ConsecutiveCorrect)
+			assert.Equal(t, 1, got.ReviewCount)
+			assert.True(t, reviewedAt.Equal(got.LastReviewedAt))
+			assert.True(t, want.NextReviewAt.Equal(got.NextReviewAt))
+			assert.True(t, got.UpdatedAt.After(got.CreatedAt))
+		}
+	})
+
+	t.Run("missing_entry_updates_nothing", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), testStatsTimeout)
+		defer cancel()
+		s := newConformanceStoreFactory(db)(t)
+		batch := createBatch(ctx, t, s)
+
+		for _, stats := range batch {
+			stats.Interval = 7
+		}
+		// The last entry belongs to a user who has no stats for the card
+		batch[batchSize-1].UserID = uuid.New()
+
+		err := s.Stats.UpdateMultiple(ctx, batch)
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+
+		for _, stats := range batch[:batchSize-1] {
+			got, err := s.Stats.Get(ctx, stats.UserID, stats.CardID)
+			require.NoError(t, err)
+			assert.Zero(t, got.Interval, "No entry should be updated when one is missing")
+		}
+	})
+}
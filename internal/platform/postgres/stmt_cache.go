@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// stmtCache prepares each query once and reuses the statement for later calls.
+// It is shared by a store and the transaction-bound copies made by its WithTx.
+//
+// Statements are only cached for a plain *sql.DB. Other handles, such as an
+// instrumented or replicated database, run queries unprepared so that their
+// timing and replica routing still apply.
+type stmtCache struct {
+	db *sql.DB
+
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+// newStmtCache returns a statement cache for db, or nil if statements on db
+// are not cached
+func newStmtCache(db store.DBTX) *stmtCache {
+	sqlDB, ok := db.(*sql.DB)
+	if !ok {
+		return nil
+	}
+	return &stmtCache{db: sqlDB, stmts: make(map[string]*sql.Stmt)}
+}
+
+// stmt returns the prepared statement for query, run on tx if tx is not nil.
+// It returns nil if the cache is nil or the query cannot be prepared; the
+// caller then runs the query directly and reports any error from that.
+//
+// Cached statements live as long as the database handle.
+func (c *stmtCache) stmt(ctx context.Context, tx *sql.Tx, query string) *sql.Stmt {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	stmt, ok := c.stmts[query]
+	c.mu.Unlock()
+
+	if !ok {
+		prepared, err := c.db.PrepareContext(ctx, query)
+		if err != nil {
+			return nil
+		}
+
+		c.mu.Lock()
+		if stmt, ok = c.stmts[query]; ok {
+			// Another caller prepared it first
+			_ = prepared.Close()
+		} else {
+			c.stmts[query] = prepared
+			stmt = prepared
+		}
+		c.mu.Unlock()
+	}
+
+	if tx != nil {
+		// The transaction's copy is closed when the transaction ends
+		return tx.StmtContext(ctx, stmt)
+	}
+	return stmt
+}
@@ -32,33 +32,47 @@ func NewPostgresTaskStore(db store.DBTX) *PostgresTaskStore {
 }
 
 // SaveTask persists a task to the database
-func (s *PostgresTaskStore) SaveTask(ctx context.Context, task task.Task) error {
+func (s *PostgresTaskStore) SaveTask(ctx context.Context, t task.Task) error {
 	log := logger.FromContext(ctx)
 
 	// Insert the task into the database
 	query := `
-		INSERT INTO tasks (id, type, payload, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO tasks (id, type, payload, status, priority, run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	// Convert payload to JSONB-compatible format
-	payload := task.Payload()
+	payload := t.Payload()
 
 	now := time.Now().UTC()
+	runAt := task.RunAtOf(t)
+	if runAt.IsZero() {
+		runAt = now
+	}
 
 	_, err := s.db.ExecContext(ctx, query,
-		task.ID(),
-		task.Type(),
+		t.ID(),
+		t.Type(),
 		payload,
-		task.Status(),
+		t.Status(),
+		task.PriorityOf(t),
+		runAt.UTC(),
 		now,
 		now,
 	)
 
 	if err != nil {
+		// The only unique index a new task ID can collide with is the one
+		// allowing a single active task per type and memo
+		if IsUniqueViolation(err) {
+			log.Info("active task already exists for memo",
+				"task_id", t.ID(),
+				"task_type", t.Type())
+			return fmt.Errorf("%w: %v", task.ErrTaskAlreadyActive, err)
+		}
 		log.Error("failed to save task",
-			"task_id", task.ID(),
-			"task_type", task.Type(),
+			"task_id", t.ID(),
+			"task_type", t.Type(),
 			"error", err.Error())
 		// Map the error using the helper to standardize error handling
 		return fmt.Errorf("failed to save task: %w", MapError(err))
@@ -68,6 +82,7 @@ func (s *PostgresTaskStore) SaveTask(ctx context.Context, task task.Task) error
 }
 
 // UpdateTaskStatus updates the status of a task in the database
+// Moving a task to processing records a heartbeat; any other status clears it.
 func (s *PostgresTaskStore) UpdateTaskStatus(
 	ctx context.Context,
 	taskID uuid.UUID,
@@ -78,7 +93,8 @@ func (s *PostgresTaskStore) UpdateTaskStatus(
 
 	query := `
 		UPDATE tasks
-		SET status = $1, error_message = $2, updated_at = $3
+		SET status = $1, error_message = $2, updated_at = $3,
+		    heartbeat_at = CASE WHEN $1 = 'processing' THEN $3 END
 		WHERE id = $4
 	`
 
@@ -118,12 +134,33 @@ func (s *PostgresTaskStore) UpdateTaskStatus(
 	return nil
 }
 
+// Heartbeat implements task.TaskStore.Heartbeat
+func (s *PostgresTaskStore) Heartbeat(ctx context.Context, taskID uuid.UUID) error {
+	log := logger.FromContext(ctx)
+
+	query := `
+		UPDATE tasks
+		SET heartbeat_at = $1
+		WHERE id = $2 AND status = 'processing'
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, time.Now().UTC(), taskID); err != nil {
+		log.Error("failed to record task heartbeat",
+			"task_id", taskID,
+			"error", err.Error())
+		return fmt.Errorf("failed to record task heartbeat: %w", MapError(err))
+	}
+	return nil
+}
+
 // GetPendingTasks retrieves all tasks with "pending" status
 func (s *PostgresTaskStore) GetPendingTasks(ctx context.Context) ([]task.Task, error) {
 	return s.getTasksByStatus(ctx, task.TaskStatusPending, 0)
 }
 
 // GetProcessingTasks retrieves tasks with "processing" status
+// If olderThan is positive, only tasks whose last heartbeat is older than that
+// are returned; tasks without a heartbeat are aged by their last update.
 func (s *PostgresTaskStore) GetProcessingTasks(
 	ctx context.Context,
 	olderThan time.Duration,
@@ -131,6 +168,46 @@ func (s *PostgresTaskStore) GetProcessingTasks(
 	return s.getTasksByStatus(ctx, task.TaskStatusProcessing, olderThan)
 }
 
+// ReclaimTask implements task.TaskStore.ReclaimTask
+// The status and heartbeat checks are part of the update, so concurrent
+// runners cannot both reclaim the same task.
+func (s *PostgresTaskStore) ReclaimTask(
+	ctx context.Context,
+	taskID uuid.UUID,
+	olderThan time.Duration,
+	errorMsg string,
+) (bool, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		UPDATE tasks
+		SET status = 'pending', error_message = $1, updated_at = $2, heartbeat_at = NULL
+		WHERE id = $3 AND status = 'processing'
+		  AND ($4::timestamptz IS NULL OR COALESCE(heartbeat_at, updated_at) < $4)
+		RETURNING id
+	`
+
+	now := time.Now().UTC()
+	var cutoff sql.NullTime
+	if olderThan > 0 {
+		cutoff = sql.NullTime{Time: now.Add(-olderThan), Valid: true}
+	}
+
+	var id uuid.UUID
+	err := s.db.QueryRowContext(ctx, query, errorMsg, now, taskID, cutoff).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		log.Error("failed to reclaim task",
+			"task_id", taskID,
+			"error", err.Error())
+		return false, fmt.Errorf("failed to reclaim task: %w", MapError(err))
+	}
+
+	return true, nil
+}
+
 // WithTx implements task.TaskStore.WithTx
 // It returns a new TaskStore instance that uses the provided transaction.
 // This allows for multiple operations to be executed within a single transaction.
@@ -152,21 +229,21 @@ func (s *PostgresTaskStore) getTasksByStatus(
 	var args []interface{}
 
 	if olderThan > 0 {
-		// Get tasks older than the specified duration
+		// Get tasks not heard from within the specified duration
 		query = `
-			SELECT id, type, payload, status, error_message, created_at, updated_at
+			SELECT id, type, payload, status, priority, run_at, error_message, created_at, updated_at
 			FROM tasks
-			WHERE status = $1 AND updated_at < $2
-			ORDER BY created_at ASC
+			WHERE status = $1 AND COALESCE(heartbeat_at, updated_at) < $2
+			ORDER BY priority DESC, created_at ASC
 		`
 		args = []interface{}{status, time.Now().UTC().Add(-olderThan)}
 	} else {
 		// Get all tasks with the given status
 		query = `
-			SELECT id, type, payload, status, error_message, created_at, updated_at
+			SELECT id, type, payload, status, priority, run_at, error_message, created_at, updated_at
 			FROM tasks
 			WHERE status = $1
-			ORDER BY created_at ASC
+			ORDER BY priority DESC, created_at ASC
 		`
 		args = []interface{}{status}
 	}
@@ -193,11 +270,16 @@ func (s *PostgresTaskStore) getTasksByStatus(
 		var taskType string
 		var payload []byte
 		var taskStatus task.TaskStatus
+		var priority int
+		var runAt time.Time
 		var errorMessage sql.NullString
 		var createdAt time.Time
 		var updatedAt time.Time
 
-		if err := rows.Scan(&id, &taskType, &payload, &taskStatus, &errorMessage, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(
+			&id, &taskType, &payload, &taskStatus, &priority, &runAt,
+			&errorMessage, &createdAt, &updatedAt,
+		); err != nil {
 			log.Error("failed to scan task row",
 				"status", status,
 				"error", err.Error())
@@ -210,6 +292,8 @@ func (s *PostgresTaskStore) getTasksByStatus(
 			taskType:     taskType,
 			payload:      payload,
 			status:       taskStatus,
+			priority:     priority,
+			runAt:        runAt,
 			errorMessage: errorMessage.String,
 			createdAt:    createdAt,
 			updatedAt:    updatedAt,
@@ -234,6 +318,8 @@ type databaseTask struct {
 	taskType     string
 	payload      []byte
 	status       task.TaskStatus
+	priority     int
+	runAt        time.Time
 	errorMessage string
 	createdAt    time.Time
 	updatedAt    time.Time
@@ -260,17 +346,25 @@ func (t *databaseTask) Status() task.TaskStatus {
 	return t.status
 }
 
+// Priority returns the task's priority
+func (t *databaseTask) Priority() int {
+	return t.priority
+}
+
+// RunAt returns the earliest time the task may run
+func (t *databaseTask) RunAt() time.Time {
+	return t.runAt
+}
+
 // Execute runs the task logic
-// Note: For recovered tasks, the execution function needs to be set
-// by the task registry/factory before execution
+// Note: Recovered tasks cannot run themselves; the task runner rebuilds them
+// with the restorer registered for their type (see
+// task.TaskRunner.RegisterTaskType) before executing them
 func (t *databaseTask) Execute(ctx context.Context) error {
 	if t.executeFn != nil {
 		return t.executeFn(ctx)
 	}
 
-	// If no execute function is set, this is a "dummy" recovered task
-	// that hasn't been properly initialized with its execution function.
-	// In a real implementation, you'd have a registry or factory that
-	// creates concrete task instances based on the task type.
+	// No restorer was registered for the task's type
 	return errors.New("no execution function defined for recovered task")
 }
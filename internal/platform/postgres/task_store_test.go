@@ -16,10 +16,12 @@ import (
 
 // testTask implements the task.Task interface for testing
 type testTask struct {
-	id     uuid.UUID
-	typ    string
-	data   []byte
-	status task.TaskStatus
+	id       uuid.UUID
+	typ      string
+	data     []byte
+	status   task.TaskStatus
+	priority int
+	runAt    time.Time
 }
 
 func newTestTask() *testTask {
@@ -52,6 +54,14 @@ func (t *testTask) Status() task.TaskStatus {
 	return t.status
 }
 
+func (t *testTask) Priority() int {
+	return t.priority
+}
+
+func (t *testTask) RunAt() time.Time {
+	return t.runAt
+}
+
 func (t *testTask) Execute(ctx context.Context) error {
 	return nil
 }
@@ -209,12 +219,18 @@ func TestPostgresTaskStore_Integration(t *testing.T) {
 			store.UpdateTaskStatus(ctx, oldProcessingTask.ID(), task.TaskStatusProcessing, ""),
 		)
 
-		// Make the old processing task's updated_at field older
+		// Make the old processing task's last heartbeat older
 		_, err := tx.ExecContext(ctx,
-			"UPDATE tasks SET updated_at = $1 WHERE id = $2",
+			"UPDATE tasks SET updated_at = $1, heartbeat_at = $1 WHERE id = $2",
 			time.Now().UTC().Add(-15*time.Minute), oldProcessingTask.ID())
 		require.NoError(t, err, "Failed to update task timestamp")
 
+		// A task updated long ago but with a recent heartbeat is still alive
+		_, err = tx.ExecContext(ctx,
+			"UPDATE tasks SET updated_at = $1 WHERE id = $2",
+			time.Now().UTC().Add(-15*time.Minute), newProcessingTask.ID())
+		require.NoError(t, err, "Failed to update task timestamp")
+
 		// Test getting all processing tasks
 		allProcessingTasks, err := store.GetProcessingTasks(ctx, 0)
 		require.NoError(t, err, "Failed to get processing tasks")
@@ -261,4 +277,110 @@ func TestPostgresTaskStore_Integration(t *testing.T) {
 		)
 		assert.False(t, oldProcessingIDs[pendingTask.ID()], "Pending task should not be returned")
 	})
+
+	t.Run("ReclaimTask", func(t *testing.T) {
+		stuckTask := newTestTask()
+		aliveTask := newTestTask()
+		pendingTask := newTestTask()
+		for _, tt := range []*testTask{stuckTask, aliveTask, pendingTask} {
+			require.NoError(t, store.SaveTask(ctx, tt))
+		}
+		for _, tt := range []*testTask{stuckTask, aliveTask} {
+			require.NoError(t, store.UpdateTaskStatus(ctx, tt.ID(), task.TaskStatusProcessing, ""))
+		}
+		_, err := tx.ExecContext(ctx, "UPDATE tasks SET heartbeat_at = $1 WHERE id = $2",
+			time.Now().UTC().Add(-15*time.Minute), stuckTask.ID())
+		require.NoError(t, err, "Failed to age task heartbeat")
+
+		reclaimed, err := store.ReclaimTask(ctx, stuckTask.ID(), 10*time.Minute, "Reset after being stuck")
+		require.NoError(t, err)
+		assert.True(t, reclaimed, "A task without a recent heartbeat is reclaimed")
+
+		reclaimed, err = store.ReclaimTask(ctx, stuckTask.ID(), 10*time.Minute, "Reset after being stuck")
+		require.NoError(t, err)
+		assert.False(t, reclaimed, "A task is reclaimed only once")
+
+		reclaimed, err = store.ReclaimTask(ctx, aliveTask.ID(), 10*time.Minute, "Reset after being stuck")
+		require.NoError(t, err)
+		assert.False(t, reclaimed, "A task with a recent heartbeat is not reclaimed")
+
+		reclaimed, err = store.ReclaimTask(ctx, pendingTask.ID(), 0, "Reset after recovery")
+		require.NoError(t, err)
+		assert.False(t, reclaimed, "A task that is not processing is not reclaimed")
+
+		reclaimed, err = store.ReclaimTask(ctx, aliveTask.ID(), 0, "Reset after recovery")
+		require.NoError(t, err)
+		assert.True(t, reclaimed, "Without an age, any processing task is reclaimed")
+
+		var status task.TaskStatus
+		var errorMessage sql.NullString
+		var heartbeatAt sql.NullTime
+		require.NoError(t, tx.QueryRowContext(ctx,
+			"SELECT status, error_message, heartbeat_at FROM tasks WHERE id = $1", stuckTask.ID()).
+			Scan(&status, &errorMessage, &heartbeatAt))
+		assert.Equal(t, task.TaskStatusPending, status)
+		assert.Equal(t, "Reset after being stuck", errorMessage.String)
+		assert.False(t, heartbeatAt.Valid, "A reclaimed task has no heartbeat")
+	})
+
+	t.Run("SaveTask allows one active task per memo", func(t *testing.T) {
+		payload, err := json.Marshal(map[string]string{"memo_id": uuid.New().String()})
+		require.NoError(t, err)
+		newMemoTask := func() *testTask {
+			return &testTask{id: uuid.New(), typ: "test_task", data: payload, status: task.TaskStatusPending}
+		}
+
+		first := newMemoTask()
+		require.NoError(t, store.SaveTask(ctx, first))
+
+		// The rejected insert aborts the transaction, so roll back to a savepoint
+		_, err = tx.ExecContext(ctx, "SAVEPOINT duplicate_task")
+		require.NoError(t, err)
+		err = store.SaveTask(ctx, newMemoTask())
+		assert.ErrorIs(t, err, task.ErrTaskAlreadyActive)
+		_, err = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT duplicate_task")
+		require.NoError(t, err)
+
+		// Once the first task finishes, the memo can be queued again
+		require.NoError(t, store.UpdateTaskStatus(ctx, first.ID(), task.TaskStatusFailed, "failed"))
+		assert.NoError(t, store.SaveTask(ctx, newMemoTask()))
+	})
+
+	t.Run("GetPendingTasks orders by priority", func(t *testing.T) {
+		background := newTestTask()
+		interactive := newTestTask()
+		interactive.priority = task.PriorityInteractive
+		require.NoError(t, store.SaveTask(ctx, background))
+		require.NoError(t, store.SaveTask(ctx, interactive))
+
+		pendingTasks, err := store.GetPendingTasks(ctx)
+		require.NoError(t, err)
+
+		position := make(map[uuid.UUID]int)
+		for i, pending := range pendingTasks {
+			position[pending.ID()] = i
+		}
+		assert.Less(t, position[interactive.ID()], position[background.ID()],
+			"Higher-priority task should come first despite being newer")
+		assert.Equal(t, task.PriorityInteractive, task.PriorityOf(pendingTasks[position[interactive.ID()]]))
+	})
+
+	t.Run("SaveTask stores the run time", func(t *testing.T) {
+		immediate := newTestTask()
+		scheduled := newTestTask()
+		scheduled.runAt = time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+		require.NoError(t, store.SaveTask(ctx, immediate))
+		require.NoError(t, store.SaveTask(ctx, scheduled))
+
+		pendingTasks, err := store.GetPendingTasks(ctx)
+		require.NoError(t, err)
+		runAts := make(map[uuid.UUID]time.Time)
+		for _, pending := range pendingTasks {
+			runAts[pending.ID()] = task.RunAtOf(pending)
+		}
+
+		assert.True(t, scheduled.runAt.Equal(runAts[scheduled.ID()]))
+		assert.False(t, runAts[immediate.ID()].IsZero(), "Unscheduled tasks run from when they are saved")
+		assert.False(t, runAts[immediate.ID()].After(time.Now()))
+	})
 }
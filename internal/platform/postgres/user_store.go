@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,7 +23,8 @@ var _ store.UserStore = (*PostgresUserStore)(nil)
 // using a PostgreSQL database as the storage backend.
 type PostgresUserStore struct {
 	db         store.DBTX
-	bcryptCost int // Cost parameter for bcrypt password hashing
+	bcryptCost int                  // Cost parameter for bcrypt password hashing
+	hasher     store.PasswordHasher // Optional; overrides bcrypt hashing when set
 }
 
 // DB returns the underlying database connection for testing purposes.
@@ -48,6 +50,26 @@ func NewPostgresUserStore(db store.DBTX, bcryptCost int) *PostgresUserStore {
 	}
 }
 
+// WithPasswordHasher makes the store hash passwords with hasher instead of
+// bcrypt at the configured cost, and returns the store for chaining.
+func (s *PostgresUserStore) WithPasswordHasher(hasher store.PasswordHasher) *PostgresUserStore {
+	s.hasher = hasher
+	return s
+}
+
+// hashPassword hashes password with the configured hasher, or with bcrypt at
+// the configured cost if none is set.
+func (s *PostgresUserStore) hashPassword(password string) (string, error) {
+	if s.hasher != nil {
+		return s.hasher.Hash(password)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), s.bcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedPassword), nil
+}
+
 // Ensure PostgresUserStore implements store.UserStore interface
 var _ store.UserStore = (*PostgresUserStore)(nil)
 
@@ -67,8 +89,8 @@ func (s *PostgresUserStore) Create(ctx context.Context, user *domain.User) error
 			return fmt.Errorf("%w: %v", store.ErrInvalidEntity, err)
 		}
 
-		// Hash the password using bcrypt with the configured cost
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), s.bcryptCost)
+		// Hash the password with the configured scheme
+		hashedPassword, err := s.hashPassword(user.Password)
 		if err != nil {
 			log.Error("failed to hash password",
 				slog.String("error", err.Error()),
@@ -78,10 +100,18 @@ func (s *PostgresUserStore) Create(ctx context.Context, user *domain.User) error
 		}
 
 		// Store the hashed password and clear the plaintext password from memory
-		user.HashedPassword = string(hashedPassword)
+		user.HashedPassword = hashedPassword
 		user.Password = "" // Clear plaintext password from memory for security
 	}
 
+	// Default to the regular user role and UTC if none were set
+	if user.Role == "" {
+		user.Role = domain.UserRoleUser
+	}
+	if user.Timezone == "" {
+		user.Timezone = domain.DefaultUserTimezone
+	}
+
 	// Validate user data for persistence
 	if err := user.Validate(); err != nil {
 		log.Warn("user validation failed during create",
@@ -92,9 +122,16 @@ func (s *PostgresUserStore) Create(ctx context.Context, user *domain.User) error
 
 	// Insert the user into the database
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO users (id, email, hashed_password, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
-	`, user.ID, user.Email, user.HashedPassword, user.CreatedAt, user.UpdatedAt)
+		INSERT INTO users (
+			id, email, hashed_password, role, timezone, new_cards_per_day, email_verified,
+			password_changed_at, generation_quota, disabled_at, new_card_interval_days,
+			new_card_ease_factor, new_cards_due_immediately, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`, user.ID, user.Email, user.HashedPassword, user.Role, user.Timezone, user.NewCardsPerDay,
+		user.EmailVerified, user.PasswordChangedAt, user.GenerationQuota, user.DisabledAt,
+		user.NewCardOverrides.IntervalDays, user.NewCardOverrides.EaseFactor, user.NewCardOverrides.DueImmediately,
+		user.CreatedAt, user.UpdatedAt)
 
 	if err != nil {
 		// Check for uniqueness violation
@@ -133,10 +170,28 @@ func (s *PostgresUserStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	// Query the user from database
 	var user domain.User
 	err := s.db.QueryRowContext(ctx, `
-		SELECT id, email, hashed_password, created_at, updated_at
+		SELECT id, email, hashed_password, role, timezone, new_cards_per_day, email_verified,
+			password_changed_at, generation_quota, disabled_at, new_card_interval_days,
+			new_card_ease_factor, new_cards_due_immediately, created_at, updated_at
 		FROM users
 		WHERE id = $1
-	`, id).Scan(&user.ID, &user.Email, &user.HashedPassword, &user.CreatedAt, &user.UpdatedAt)
+	`, id).Scan(
+		&user.ID,
+		&user.Email,
+		&user.HashedPassword,
+		&user.Role,
+		&user.Timezone,
+		&user.NewCardsPerDay,
+		&user.EmailVerified,
+		&user.PasswordChangedAt,
+		&user.GenerationQuota,
+		&user.DisabledAt,
+		&user.NewCardOverrides.IntervalDays,
+		&user.NewCardOverrides.EaseFactor,
+		&user.NewCardOverrides.DueImmediately,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
 
 	// Handle the result
 	if err != nil {
@@ -171,10 +226,28 @@ func (s *PostgresUserStore) GetByEmail(ctx context.Context, email string) (*doma
 	// Query the user from database with case-insensitive email matching
 	var user domain.User
 	err := s.db.QueryRowContext(ctx, `
-		SELECT id, email, hashed_password, created_at, updated_at
+		SELECT id, email, hashed_password, role, timezone, new_cards_per_day, email_verified,
+			password_changed_at, generation_quota, disabled_at, new_card_interval_days,
+			new_card_ease_factor, new_cards_due_immediately, created_at, updated_at
 		FROM users
 		WHERE LOWER(email) = LOWER($1)
-	`, email).Scan(&user.ID, &user.Email, &user.HashedPassword, &user.CreatedAt, &user.UpdatedAt)
+	`, email).Scan(
+		&user.ID,
+		&user.Email,
+		&user.HashedPassword,
+		&user.Role,
+		&user.Timezone,
+		&user.NewCardsPerDay,
+		&user.EmailVerified,
+		&user.PasswordChangedAt,
+		&user.GenerationQuota,
+		&user.DisabledAt,
+		&user.NewCardOverrides.IntervalDays,
+		&user.NewCardOverrides.EaseFactor,
+		&user.NewCardOverrides.DueImmediately,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
 
 	// Handle the result
 	if err != nil {
@@ -197,6 +270,78 @@ func (s *PostgresUserStore) GetByEmail(ctx context.Context, email string) (*doma
 	return &user, nil
 }
 
+// List implements store.UserStore.List
+// It retrieves a page of users ordered by email, optionally filtered to those
+// whose email contains emailQuery, ignoring case.
+func (s *PostgresUserStore) List(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error) {
+	// Get the logger from context or use default
+	log := logger.FromContext(ctx)
+
+	if limit <= 0 || offset < 0 {
+		log.Warn("invalid pagination parameters for users",
+			slog.Int("limit", limit),
+			slog.Int("offset", offset))
+		return nil, fmt.Errorf("%w: invalid limit or offset", store.ErrInvalidEntity)
+	}
+
+	// Escape LIKE wildcards so the query matches literally
+	pattern := "%" + likeEscaper.Replace(emailQuery) + "%"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, email, hashed_password, role, timezone, new_cards_per_day, email_verified,
+			password_changed_at, generation_quota, disabled_at, new_card_interval_days,
+			new_card_ease_factor, new_cards_due_immediately, created_at, updated_at
+		FROM users
+		WHERE email ILIKE $1
+		ORDER BY email ASC, id ASC
+		LIMIT $2 OFFSET $3
+	`, pattern, limit, offset)
+	if err != nil {
+		log.Error("failed to query users", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list users: %w", MapError(err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warn("failed to close rows", slog.String("error", closeErr.Error()))
+		}
+	}()
+
+	users := make([]*domain.User, 0, limit)
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.HashedPassword,
+			&user.Role,
+			&user.Timezone,
+			&user.NewCardsPerDay,
+			&user.EmailVerified,
+			&user.PasswordChangedAt,
+			&user.GenerationQuota,
+			&user.DisabledAt,
+			&user.NewCardOverrides.IntervalDays,
+			&user.NewCardOverrides.EaseFactor,
+			&user.NewCardOverrides.DueImmediately,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		); err != nil {
+			log.Error("failed to scan user", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("failed to scan user: %w", MapError(err))
+		}
+		users = append(users, &user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate users: %w", MapError(err))
+	}
+
+	log.Debug("listed users", slog.Int("count", len(users)))
+	return users, nil
+}
+
+// likeEscaper escapes the LIKE wildcards and the default escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // Update implements store.UserStore.Update
 // It modifies an existing user's details in the database.
 // The caller MUST provide a complete user object including HashedPassword.
@@ -226,7 +371,7 @@ func (s *PostgresUserStore) Update(ctx context.Context, user *domain.User) error
 		}
 
 		// Hash the new password
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), s.bcryptCost)
+		hashedPassword, err := s.hashPassword(user.Password)
 		if err != nil {
 			log.Error("failed to hash password",
 				slog.String("error", err.Error()),
@@ -235,7 +380,7 @@ func (s *PostgresUserStore) Update(ctx context.Context, user *domain.User) error
 			// Don't expose internal error details, return a generic error
 			return fmt.Errorf("%w: password hashing failed", store.ErrInvalidEntity)
 		}
-		hashedPasswordToStore = string(hashedPassword)
+		hashedPasswordToStore = hashedPassword
 		user.Password = "" // Clear plaintext password for security
 		user.HashedPassword = hashedPasswordToStore
 	} else if user.HashedPassword != "" {
@@ -249,6 +394,14 @@ func (s *PostgresUserStore) Update(ctx context.Context, user *domain.User) error
 		return fmt.Errorf("%w: missing hashed password", store.ErrInvalidEntity)
 	}
 
+	// Default to the regular user role and UTC if none were set
+	if user.Role == "" {
+		user.Role = domain.UserRoleUser
+	}
+	if user.Timezone == "" {
+		user.Timezone = domain.DefaultUserTimezone
+	}
+
 	// Validate the user for persistence after any modifications
 	if err := user.Validate(); err != nil {
 		log.Warn("user validation failed during update",
@@ -260,9 +413,15 @@ func (s *PostgresUserStore) Update(ctx context.Context, user *domain.User) error
 	// Execute the update statement
 	result, err := s.db.ExecContext(ctx, `
 		UPDATE users
-		SET email = $1, hashed_password = $2, updated_at = $3
-		WHERE id = $4
-	`, user.Email, hashedPasswordToStore, user.UpdatedAt, user.ID)
+		SET email = $1, hashed_password = $2, role = $3, timezone = $4, new_cards_per_day = $5,
+			email_verified = $6, password_changed_at = $7, generation_quota = $8, disabled_at = $9,
+			new_card_interval_days = $10, new_card_ease_factor = $11, new_cards_due_immediately = $12,
+			updated_at = $13
+		WHERE id = $14
+	`, user.Email, hashedPasswordToStore, user.Role, user.Timezone, user.NewCardsPerDay,
+		user.EmailVerified, user.PasswordChangedAt, user.GenerationQuota, user.DisabledAt,
+		user.NewCardOverrides.IntervalDays, user.NewCardOverrides.EaseFactor, user.NewCardOverrides.DueImmediately,
+		user.UpdatedAt, user.ID)
 
 	if err != nil {
 		// Check for uniqueness violation
@@ -298,6 +457,49 @@ func (s *PostgresUserStore) Update(ctx context.Context, user *domain.User) error
 	return nil
 }
 
+// UpdatePasswordHash implements store.UserStore.UpdatePasswordHash
+// The hash is only replaced if it is still oldHash, so a password changed since
+// oldHash was read is never overwritten.
+// Returns store.ErrUserNotFound if the user does not exist.
+// Returns store.ErrVersionConflict if the stored hash is no longer oldHash.
+func (s *PostgresUserStore) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	// Get the logger from context or use default
+	log := logger.FromContext(ctx)
+
+	log.Debug("updating password hash", slog.String("user_id", id.String()))
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE users
+		SET hashed_password = $1, updated_at = $2
+		WHERE id = $3 AND hashed_password = $4
+	`, newHash, time.Now().UTC(), id, oldHash)
+	if err != nil {
+		log.Error("failed to update password hash",
+			slog.String("error", err.Error()),
+			slog.String("user_id", id.String()))
+		return fmt.Errorf("%w: password hash update failed", store.ErrUpdateFailed)
+	}
+
+	if err := CheckRowsAffected(result, "user"); err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			return fmt.Errorf("failed to update password hash: %w", err)
+		}
+		// Tell a missing user apart from a hash that changed since it was read
+		var exists bool
+		if err := s.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", id).
+			Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check user: %w", MapError(err))
+		}
+		if !exists {
+			return store.ErrUserNotFound
+		}
+		return store.ErrVersionConflict
+	}
+
+	log.Debug("password hash updated", slog.String("user_id", id.String()))
+	return nil
+}
+
 // Delete implements store.UserStore.Delete
 // It removes a user from the database by their ID.
 // Returns store.ErrUserNotFound if the user does not exist.
@@ -343,5 +545,6 @@ func (s *PostgresUserStore) WithTx(tx *sql.Tx) store.UserStore {
 	return &PostgresUserStore{
 		db:         tx,
 		bcryptCost: s.bcryptCost,
+		hasher:     s.hasher,
 	}
 }
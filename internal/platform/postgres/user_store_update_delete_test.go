@@ -118,6 +118,29 @@ func TestPostgresUserStore_Update(t *testing.T) {
 			)
 		})
 
+		t.Run("Mark email verified", func(t *testing.T) {
+			t.Parallel() // Enable parallel subtests
+
+			// Create a context with timeout
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			// Insert a test user
+			email := fmt.Sprintf("update-verify-%s@example.com", uuid.New().String()[:8])
+			userID := insertTestUser(ctx, t, tx, email)
+
+			user, err := userStore.GetByID(ctx, userID)
+			require.NoError(t, err, "User retrieval should succeed")
+			assert.False(t, user.EmailVerified, "New users should start unverified")
+
+			user.EmailVerified = true
+			require.NoError(t, userStore.Update(ctx, user), "User update should succeed")
+
+			updatedUser, err := userStore.GetByID(ctx, userID)
+			require.NoError(t, err, "User retrieval should succeed after update")
+			assert.True(t, updatedUser.EmailVerified, "Email should be marked verified")
+		})
+
 		// Test Case 3: Update with non-existent user
 		t.Run("Update non-existent user", func(t *testing.T) {
 			t.Parallel() // Enable parallel subtests
@@ -210,6 +233,50 @@ func TestPostgresUserStore_Update(t *testing.T) {
 	})
 }
 
+// TestPostgresUserStore_UpdatePasswordHash tests that only the password hash is
+// replaced, and only while it is still the expected one
+func TestPostgresUserStore_UpdatePasswordHash(t *testing.T) {
+	t.Parallel() // Enable parallel testing
+
+	testutils.WithTx(t, testDB, func(tx store.DBTX) {
+		userStore := postgres.NewPostgresUserStore(tx, bcrypt.MinCost)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		email := fmt.Sprintf("rehash-%s@example.com", uuid.New().String()[:8])
+		userID := insertTestUser(ctx, t, tx, email)
+		user, err := userStore.GetByID(ctx, userID)
+		require.NoError(t, err, "User retrieval should succeed")
+
+		t.Run("Replaces the expected hash", func(t *testing.T) {
+			err := userStore.UpdatePasswordHash(ctx, userID, user.HashedPassword, "$2a$10$upgraded")
+			require.NoError(t, err)
+
+			updated, err := userStore.GetByID(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, "$2a$10$upgraded", updated.HashedPassword)
+			assert.Equal(t, user.Email, updated.Email, "Other fields should be untouched")
+			assert.Equal(t, user.PasswordChangedAt, updated.PasswordChangedAt,
+				"Rehashing should not revoke sessions")
+		})
+
+		t.Run("Keeps a hash changed in the meantime", func(t *testing.T) {
+			err := userStore.UpdatePasswordHash(ctx, userID, user.HashedPassword, "$2a$10$stale")
+			assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+			updated, err := userStore.GetByID(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, "$2a$10$upgraded", updated.HashedPassword)
+		})
+
+		t.Run("Unknown user", func(t *testing.T) {
+			err := userStore.UpdatePasswordHash(ctx, uuid.New(), user.HashedPassword, "$2a$10$other")
+			assert.ErrorIs(t, err, store.ErrUserNotFound)
+		})
+	})
+}
+
 // TestPostgresUserStore_Delete tests the Delete method
 func TestPostgresUserStore_Delete(t *testing.T) {
 	t.Parallel() // Enable parallel testing
@@ -0,0 +1,13 @@
+// Package tracing sets up OpenTelemetry distributed tracing and provides
+// helpers for starting spans.
+//
+// Setup installs a global tracer provider that exports spans to an OTLP/HTTP
+// collector. When tracing is disabled Setup installs nothing, and the global
+// no-op provider makes every span started through this package free.
+//
+// Spans are propagated through context.Context: the HTTP tracing middleware
+// starts a span for each request, services start child spans for key
+// operations with Start, and instrumented database handles add a span for each
+// query. Incoming W3C traceparent headers are honored, so a request that is
+// part of a caller's trace is recorded as part of that trace.
+package tracing
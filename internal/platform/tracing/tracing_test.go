@@ -0,0 +1,49 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/platform/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup_Disabled(t *testing.T) {
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := tracing.Setup(context.Background(), config.TracingConfig{Enabled: false})
+	require.NoError(t, err)
+	assert.Same(t, previous, otel.GetTracerProvider(), "Disabled tracing should not install a provider")
+	assert.NoError(t, shutdown(context.Background()))
+
+	// Spans started while disabled record nothing
+	_, span := tracing.Start(context.Background(), "disabled")
+	assert.False(t, span.IsRecording())
+	tracing.End(span, nil)
+}
+
+func TestEnd(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	_, ok := tracing.Start(context.Background(), "succeeds")
+	tracing.End(ok, nil)
+	_, failed := tracing.Start(context.Background(), "fails")
+	tracing.End(failed, errors.New("password=hunter2 rejected"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.NotContains(t, spans[1].Status.Description, "hunter2", "Recorded errors should be redacted")
+}
@@ -0,0 +1,15 @@
+// Package webhook delivers application events to an external HTTP endpoint.
+//
+// WebhookNotifier implements events.EventHandler and is subscribed on an
+// events.EventBus to events.EventTypeCardsGenerated. Each event is POSTed as a
+// JSON payload signed with HMAC-SHA256, so receivers can verify it came from
+// this server by recomputing the signature over the raw request body with the
+// shared secret and comparing it to the X-Scry-Signature header.
+//
+// Deliveries that fail with a 5xx response or a network error are retried with
+// exponential backoff. Other responses are not retried.
+//
+// HandleEvent blocks until delivery succeeds or retries are exhausted, so the
+// server wraps the notifier in an events.AsyncHandler; generation then only
+// queues the notification and never waits on the receiver.
+package webhook
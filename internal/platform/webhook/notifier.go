@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/events"
+)
+
+// SignatureHeader carries the hex-encoded HMAC-SHA256 of the request body.
+const SignatureHeader = "X-Scry-Signature"
+
+// EventTypeHeader carries the type of the delivered event.
+const EventTypeHeader = "X-Scry-Event"
+
+var (
+	// ErrMissingURL is returned when the notifier is created without a URL.
+	ErrMissingURL = errors.New("webhook URL cannot be empty")
+
+	// ErrMissingSecret is returned when the notifier is created without a signing secret.
+	ErrMissingSecret = errors.New("webhook secret cannot be empty")
+
+	// ErrDeliveryFailed is returned when a payload could not be delivered,
+	// either after exhausting retries or because of a non-retryable response.
+	ErrDeliveryFailed = errors.New("webhook delivery failed")
+)
+
+// Payload is the JSON body POSTed to the webhook URL.
+type Payload struct {
+	// ID is the unique identifier of the event, usable for deduplication
+	ID uuid.UUID `json:"id"`
+
+	// Type is the event type, e.g. events.EventTypeCardsGenerated
+	Type string `json:"type"`
+
+	// CreatedAt is when the event was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Data is the event-specific payload
+	Data json.RawMessage `json:"data"`
+}
+
+// WebhookNotifier POSTs signed event payloads to a configured URL.
+// It implements events.EventHandler.
+type WebhookNotifier struct {
+	url        string
+	secret     []byte
+	maxRetries int
+	retryDelay time.Duration
+	client     *http.Client
+	logger     *slog.Logger
+}
+
+// NewWebhookNotifier creates a notifier from the webhooks configuration.
+// Returns ErrMissingURL or ErrMissingSecret if either is not configured.
+func NewWebhookNotifier(cfg config.WebhooksConfig, logger *slog.Logger) (*WebhookNotifier, error) {
+	if cfg.URL == "" {
+		return nil, ErrMissingURL
+	}
+	if cfg.Secret == "" {
+		return nil, ErrMissingSecret
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WebhookNotifier{
+		url:        cfg.URL,
+		secret:     []byte(cfg.Secret),
+		maxRetries: cfg.MaxRetries,
+		retryDelay: time.Duration(cfg.RetryDelayMs) * time.Millisecond,
+		client:     &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second},
+		logger:     logger.With("component", "webhook_notifier"),
+	}, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of body using secret,
+// as sent in the SignatureHeader.
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HandleEvent implements events.EventHandler by delivering the event to the
+// webhook URL, retrying with exponential backoff after 5xx responses and
+// network errors. Returns an error wrapping ErrDeliveryFailed if the payload
+// could not be delivered.
+func (n *WebhookNotifier) HandleEvent(ctx context.Context, event *events.TaskRequestEvent) error {
+	body, err := json.Marshal(Payload{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	signature := Sign(n.secret, body)
+
+	log := n.logger.With("event_id", event.ID, "event_type", event.Type)
+	delay := n.retryDelay
+	for attempt := 0; ; attempt++ {
+		retryable, err := n.deliver(ctx, event.Type, body, signature)
+		if err == nil {
+			log.Debug("delivered webhook", "attempt", attempt+1)
+			return nil
+		}
+
+		if !retryable || attempt >= n.maxRetries {
+			log.Error("webhook delivery failed", "error", err, "attempts", attempt+1)
+			return fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+		}
+
+		log.Warn("webhook delivery failed, retrying",
+			"error", err,
+			"attempt", attempt+1,
+			"retry_delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ErrDeliveryFailed, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// deliver makes a single delivery attempt. It reports whether a failure is
+// worth retrying: network errors and 5xx responses are, anything else is not.
+func (n *WebhookNotifier) deliver(
+	ctx context.Context,
+	eventType string,
+	body []byte,
+	signature string,
+) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, signature)
+	req.Header.Set(EventTypeHeader, eventType)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body) // Drain so the connection can be reused
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+}
+
+// Ensure WebhookNotifier implements events.EventHandler
+var _ events.EventHandler = (*WebhookNotifier)(nil)
@@ -0,0 +1,162 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/platform/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "webhook-test-secret"
+
+// receivedRequest captures what the test server saw for a single delivery
+type receivedRequest struct {
+	body      []byte
+	signature string
+	eventType string
+}
+
+// newTestServer starts a server that records each request and responds with
+// the next status in statuses (repeating the last one once they run out)
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, <-chan receivedRequest, *int32) {
+	t.Helper()
+
+	requests := make(chan receivedRequest, 10)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests <- receivedRequest{
+			body:      body,
+			signature: r.Header.Get(webhook.SignatureHeader),
+			eventType: r.Header.Get(webhook.EventTypeHeader),
+		}
+
+		call := int(atomic.AddInt32(&calls, 1))
+		w.WriteHeader(statuses[min(call, len(statuses))-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, requests, &calls
+}
+
+func newTestNotifier(t *testing.T, url string, maxRetries int) *webhook.WebhookNotifier {
+	t.Helper()
+
+	notifier, err := webhook.NewWebhookNotifier(config.WebhooksConfig{
+		URL:            url,
+		Secret:         testSecret,
+		MaxRetries:     maxRetries,
+		RetryDelayMs:   1,
+		TimeoutSeconds: 5,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return notifier
+}
+
+func newCardsGeneratedEvent(t *testing.T) (*events.TaskRequestEvent, events.CardsGeneratedEvent) {
+	t.Helper()
+
+	payload := events.CardsGeneratedEvent{
+		MemoID:      uuid.New(),
+		UserID:      uuid.New(),
+		CardIDs:     []uuid.UUID{uuid.New(), uuid.New()},
+		CompletedAt: time.Date(2025, time.May, 1, 12, 0, 0, 0, time.UTC),
+	}
+	event, err := events.NewCardsGeneratedEvent(payload)
+	require.NoError(t, err)
+	return event, payload
+}
+
+func TestNewWebhookNotifier(t *testing.T) {
+	_, err := webhook.NewWebhookNotifier(config.WebhooksConfig{Secret: testSecret}, nil)
+	assert.ErrorIs(t, err, webhook.ErrMissingURL)
+
+	_, err = webhook.NewWebhookNotifier(config.WebhooksConfig{URL: "http://example.com"}, nil)
+	assert.ErrorIs(t, err, webhook.ErrMissingSecret)
+}
+
+func TestWebhookNotifier_DeliversSignedPayload(t *testing.T) {
+	server, requests, calls := newTestServer(t, http.StatusOK)
+	notifier := newTestNotifier(t, server.URL, 3)
+	event, payload := newCardsGeneratedEvent(t)
+
+	require.NoError(t, notifier.HandleEvent(context.Background(), event))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	received := <-requests
+	assert.Equal(t, webhook.Sign([]byte(testSecret), received.body), received.signature)
+	assert.NotEqual(t, webhook.Sign([]byte("wrong-secret"), received.body), received.signature)
+	assert.Equal(t, events.EventTypeCardsGenerated, received.eventType)
+
+	var body webhook.Payload
+	require.NoError(t, json.Unmarshal(received.body, &body))
+	assert.Equal(t, event.ID, body.ID)
+	assert.Equal(t, events.EventTypeCardsGenerated, body.Type)
+
+	var data events.CardsGeneratedEvent
+	require.NoError(t, json.Unmarshal(body.Data, &data))
+	assert.Equal(t, payload, data)
+}
+
+func TestWebhookNotifier_RetriesServerErrors(t *testing.T) {
+	server, requests, calls := newTestServer(t, http.StatusInternalServerError, http.StatusOK)
+	notifier := newTestNotifier(t, server.URL, 3)
+	event, _ := newCardsGeneratedEvent(t)
+
+	require.NoError(t, notifier.HandleEvent(context.Background(), event))
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	// The retried delivery carries the same body and signature
+	first, second := <-requests, <-requests
+	assert.Equal(t, first.body, second.body)
+	assert.Equal(t, webhook.Sign([]byte(testSecret), second.body), second.signature)
+}
+
+func TestWebhookNotifier_GivesUpAfterMaxRetries(t *testing.T) {
+	server, _, calls := newTestServer(t, http.StatusServiceUnavailable)
+	notifier := newTestNotifier(t, server.URL, 2)
+	event, _ := newCardsGeneratedEvent(t)
+
+	err := notifier.HandleEvent(context.Background(), event)
+	assert.ErrorIs(t, err, webhook.ErrDeliveryFailed)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls), "Expected the first attempt plus two retries")
+}
+
+func TestWebhookNotifier_DoesNotRetryClientErrors(t *testing.T) {
+	server, _, calls := newTestServer(t, http.StatusBadRequest, http.StatusOK)
+	notifier := newTestNotifier(t, server.URL, 3)
+	event, _ := newCardsGeneratedEvent(t)
+
+	err := notifier.HandleEvent(context.Background(), event)
+	assert.ErrorIs(t, err, webhook.ErrDeliveryFailed)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestWebhookNotifier_SubscribedOnEventBus(t *testing.T) {
+	server, requests, _ := newTestServer(t, http.StatusOK)
+	notifier := newTestNotifier(t, server.URL, 0)
+
+	bus := events.NewEventBus(nil)
+	bus.Subscribe(events.EventTypeCardsGenerated, notifier)
+
+	event, _ := newCardsGeneratedEvent(t)
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	received := <-requests
+	assert.Equal(t, webhook.Sign([]byte(testSecret), received.body), received.signature)
+}
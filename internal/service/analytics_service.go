@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// Accuracy is the share of reviews that were answered correctly
+type Accuracy struct {
+	// Correct is the number of reviews answered correctly
+	Correct int
+
+	// Total is the number of reviews
+	Total int
+}
+
+// Rate returns Correct as a fraction of Total, or 0 if there were no reviews
+func (a Accuracy) Rate() float64 {
+	if a.Total == 0 {
+		return 0
+	}
+	return float64(a.Correct) / float64(a.Total)
+}
+
+// MaturityAccuracy is the accuracy of the reviews of cards of one maturity
+type MaturityAccuracy struct {
+	Maturity domain.CardMaturity
+	Accuracy
+}
+
+// RetentionReport summarizes how well a user remembered their cards over a period
+type RetentionReport struct {
+	// Days is the number of calendar days covered, ending today
+	Days int
+
+	// Overall is the accuracy of every review in the period
+	Overall Accuracy
+
+	// ByMaturity breaks Overall down by card maturity: learning, young and
+	// mature, always in that order, followed by unknown for reviews recorded
+	// before intervals were tracked, if there were any
+	ByMaturity []MaturityAccuracy
+
+	// ReviewsPerDay is the number of reviews on each day of the period, oldest first
+	ReviewsPerDay []domain.ReviewDayCount
+}
+
+// AnalyticsService computes statistics from a user's review history
+type AnalyticsService interface {
+	// Retention reports the user's accuracy and review counts over the last days
+	// calendar days in their timezone, including today. Again is incorrect, Good
+	// and Easy are correct, and Hard is correct unless configured otherwise.
+	// Returns store.ErrInvalidEntity if days is out of range.
+	Retention(ctx context.Context, userID uuid.UUID, days int) (*RetentionReport, error)
+}
+
+// analyticsServiceImpl implements the AnalyticsService interface
+type analyticsServiceImpl struct {
+	eventStore    store.ReviewEventStore
+	hardIsCorrect bool
+	logger        *slog.Logger
+}
+
+// AnalyticsServiceOption configures optional AnalyticsService behavior
+type AnalyticsServiceOption func(*analyticsServiceImpl)
+
+// WithHardIsCorrect sets whether reviews answered Hard count as correct.
+// Without this option they do.
+func WithHardIsCorrect(correct bool) AnalyticsServiceOption {
+	return func(s *analyticsServiceImpl) {
+		s.hardIsCorrect = correct
+	}
+}
+
+// NewAnalyticsService creates a new AnalyticsService
+// It returns an error if any of the required dependencies are nil.
+func NewAnalyticsService(
+	eventStore store.ReviewEventStore,
+	logger *slog.Logger,
+	opts ...AnalyticsServiceOption,
+) (AnalyticsService, error) {
+	// Validate dependencies
+	if eventStore == nil {
+		return nil, fmt.Errorf("eventStore cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	service := &analyticsServiceImpl{
+		eventStore:    eventStore,
+		hardIsCorrect: true,
+		logger:        logger.With("component", "analytics_service"),
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service, nil
+}
+
+// Retention computes the report from two grouped queries: outcome counts by
+// card maturity, and review counts by day.
+func (s *analyticsServiceImpl) Retention(
+	ctx context.Context,
+	userID uuid.UUID,
+	days int,
+) (*RetentionReport, error) {
+	outcomes, err := s.eventStore.CountOutcomes(ctx, userID, days)
+	if err != nil {
+		s.logger.Error("failed to count review outcomes",
+			"error", err,
+			"user_id", userID,
+			"days", days)
+		return nil, fmt.Errorf("failed to count review outcomes: %w", err)
+	}
+
+	perDay, err := s.eventStore.CountByDay(ctx, userID, days)
+	if err != nil {
+		s.logger.Error("failed to count reviews by day",
+			"error", err,
+			"user_id", userID,
+			"days", days)
+		return nil, fmt.Errorf("failed to count reviews by day: %w", err)
+	}
+
+	report := &RetentionReport{
+		Days:          days,
+		ReviewsPerDay: perDay,
+		ByMaturity: []MaturityAccuracy{
+			{Maturity: domain.CardMaturityLearning},
+			{Maturity: domain.CardMaturityYoung},
+			{Maturity: domain.CardMaturityMature},
+			{Maturity: domain.CardMaturityUnknown},
+		},
+	}
+	for _, count := range outcomes {
+		var bucket *MaturityAccuracy
+		for i := range report.ByMaturity {
+			if report.ByMaturity[i].Maturity == count.Maturity {
+				bucket = &report.ByMaturity[i]
+			}
+		}
+		if bucket == nil {
+			continue
+		}
+
+		bucket.Total += count.Count
+		report.Overall.Total += count.Count
+		if s.isCorrect(count.Outcome) {
+			bucket.Correct += count.Count
+			report.Overall.Correct += count.Count
+		}
+	}
+
+	// Reviews of unknown maturity only exist in histories recorded before
+	// intervals were tracked
+	if unknown := report.ByMaturity[len(report.ByMaturity)-1]; unknown.Total == 0 {
+		report.ByMaturity = report.ByMaturity[:len(report.ByMaturity)-1]
+	}
+
+	s.logger.Debug("computed retention report",
+		"user_id", userID,
+		"days", days,
+		"reviews", report.Overall.Total)
+	return report, nil
+}
+
+// isCorrect reports whether a review with the given outcome counts as correct
+func (s *analyticsServiceImpl) isCorrect(outcome domain.ReviewOutcome) bool {
+	switch outcome {
+	case domain.ReviewOutcomeGood, domain.ReviewOutcomeEasy:
+		return true
+	case domain.ReviewOutcomeHard:
+		return s.hardIsCorrect
+	default:
+		return false
+	}
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestAnalyticsService_Retention seeds a review history with known outcomes on
+// the in-memory stores and checks the computed accuracy
+func TestAnalyticsService_Retention(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+	events := memory.NewReviewEventStore(backend)
+
+	user, err := domain.NewUser("retention@example.com", "retention-password")
+	require.NoError(t, err)
+	require.NoError(t, memory.NewUserStore(backend, bcrypt.MinCost).Create(ctx, user))
+	memo, err := domain.NewMemo(user.ID, "Retention memo")
+	require.NoError(t, err)
+	require.NoError(t, memory.NewMemoStore(backend).Create(ctx, memo))
+	card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+	require.NoError(t, err)
+	require.NoError(t, memory.NewCardStore(backend).CreateMultiple(ctx, []*domain.Card{card}))
+
+	// Learning: 1 of 2 correct. Young: 3 of 4 correct, or 2 of 4 if Hard is
+	// incorrect. Mature: 1 of 1 correct. Old reviews fall outside the window.
+	reviewedAt := time.Now().UTC()
+	seed := []struct {
+		outcome  domain.ReviewOutcome
+		interval int
+		at       time.Time
+	}{
+		{domain.ReviewOutcomeAgain, 0, reviewedAt},
+		{domain.ReviewOutcomeGood, 0, reviewedAt},
+		{domain.ReviewOutcomeAgain, 3, reviewedAt.Add(-48 * time.Hour)},
+		{domain.ReviewOutcomeHard, 3, reviewedAt.Add(-48 * time.Hour)},
+		{domain.ReviewOutcomeGood, 6, reviewedAt},
+		{domain.ReviewOutcomeEasy, 10, reviewedAt},
+		{domain.ReviewOutcomeGood, 30, reviewedAt},
+		{domain.ReviewOutcomeAgain, 30, reviewedAt.Add(-60 * 24 * time.Hour)},
+	}
+	for _, review := range seed {
+		event, err := domain.NewReviewEvent(user.ID, card.ID, review.outcome, review.at)
+		require.NoError(t, err)
+		event.SetIntervals(review.interval, review.interval+1)
+		require.NoError(t, events.Create(ctx, event))
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("hard is correct by default", func(t *testing.T) {
+		analytics, err := service.NewAnalyticsService(events, logger)
+		require.NoError(t, err)
+
+		report, err := analytics.Retention(ctx, user.ID, 30)
+		require.NoError(t, err)
+
+		assert.Equal(t, 30, report.Days)
+		assert.Equal(t, service.Accuracy{Correct: 5, Total: 7}, report.Overall)
+		assert.InDelta(t, 5.0/7.0, report.Overall.Rate(), 0.0001)
+		assert.Equal(t, []service.MaturityAccuracy{
+			{Maturity: domain.CardMaturityLearning, Accuracy: service.Accuracy{Correct: 1, Total: 2}},
+			{Maturity: domain.CardMaturityYoung, Accuracy: service.Accuracy{Correct: 3, Total: 4}},
+			{Maturity: domain.CardMaturityMature, Accuracy: service.Accuracy{Correct: 1, Total: 1}},
+		}, report.ByMaturity, "Unknown maturity is left out when every review has intervals")
+		assert.InDelta(t, 0.75, report.ByMaturity[1].Rate(), 0.0001)
+
+		require.Len(t, report.ReviewsPerDay, 30)
+		assert.Equal(t, 5, report.ReviewsPerDay[29].Count, "Today's reviews")
+		assert.Equal(t, 2, report.ReviewsPerDay[27].Count, "Reviews from two days ago")
+	})
+
+	t.Run("hard can be counted as incorrect", func(t *testing.T) {
+		analytics, err := service.NewAnalyticsService(events, logger, service.WithHardIsCorrect(false))
+		require.NoError(t, err)
+
+		report, err := analytics.Retention(ctx, user.ID, 30)
+		require.NoError(t, err)
+
+		assert.Equal(t, service.Accuracy{Correct: 4, Total: 7}, report.Overall)
+		assert.Equal(t, service.Accuracy{Correct: 2, Total: 4}, report.ByMaturity[1].Accuracy)
+	})
+
+	t.Run("no reviews", func(t *testing.T) {
+		analytics, err := service.NewAnalyticsService(events, logger)
+		require.NoError(t, err)
+
+		report, err := analytics.Retention(ctx, uuid.New(), 7)
+		require.NoError(t, err)
+
+		assert.Zero(t, report.Overall.Total)
+		assert.Zero(t, report.Overall.Rate(), "The rate of no reviews is 0")
+		assert.Len(t, report.ByMaturity, 3)
+	})
+
+	t.Run("days out of range", func(t *testing.T) {
+		analytics, err := service.NewAnalyticsService(events, logger)
+		require.NoError(t, err)
+
+		_, err = analytics.Retention(ctx, user.ID, 0)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+	})
+}
@@ -22,6 +22,22 @@ var (
 	// ErrExpiredRefreshToken indicates the refresh token has expired
 	ErrExpiredRefreshToken = errors.New("refresh token has expired")
 
+	// ErrAccountDisabled indicates the user's account was disabled by an admin,
+	// so none of their credentials or tokens are accepted
+	ErrAccountDisabled = errors.New("account is disabled")
+
+	// ErrTooManyLoginAttempts indicates logins for an email are temporarily
+	// refused after repeated failures
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+
+	// ErrTokenAlreadyUsed indicates a single-use token, such as an email
+	// verification token, was presented again after it had taken effect
+	ErrTokenAlreadyUsed = errors.New("token has already been used")
+
+	// ErrInvalidTokenLifetimes indicates the configured access and refresh
+	// token lifetimes are out of bounds or don't pair sensibly
+	ErrInvalidTokenLifetimes = errors.New("invalid token lifetimes")
+
 	// ErrWrongTokenType indicates a token was used for the wrong purpose (e.g., using a refresh token as an access token)
 	ErrWrongTokenType = errors.New("wrong token type")
 )
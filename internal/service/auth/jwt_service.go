@@ -27,18 +27,57 @@ type JWTService interface {
 	// Returns the claims containing user information if the refresh token is valid,
 	// or an error if validation fails (expired, invalid signature, wrong token type, etc.).
 	ValidateRefreshToken(ctx context.Context, tokenString string) (*Claims, error)
+
+	// GenerateEmailVerificationToken creates a signed, time-limited JWT of type
+	// TokenTypeEmailVerify proving that whoever presents it received mail sent to
+	// the user's address.
+	GenerateEmailVerificationToken(ctx context.Context, userID uuid.UUID) (string, error)
+
+	// ValidateEmailVerificationToken validates an email verification token and extracts the claims.
+	// Returns ErrExpiredToken, ErrInvalidToken or ErrWrongTokenType if validation fails.
+	ValidateEmailVerificationToken(ctx context.Context, tokenString string) (*Claims, error)
+
+	// GeneratePasswordResetToken creates a signed, short-lived JWT of type
+	// TokenTypePasswordReset. The fingerprint of the user's current password hash
+	// (see PasswordFingerprint) is embedded so the token stops working once the
+	// password has been reset with it.
+	GeneratePasswordResetToken(ctx context.Context, userID uuid.UUID, fingerprint string) (string, error)
+
+	// ValidatePasswordResetToken validates a password reset token and extracts the claims.
+	// Callers must compare Claims.Fingerprint with the user's current password hash.
+	// Returns ErrExpiredToken, ErrInvalidToken or ErrWrongTokenType if validation fails.
+	ValidatePasswordResetToken(ctx context.Context, tokenString string) (*Claims, error)
 }
 
+// TokenTypeEmailVerify is the TokenType of email verification tokens.
+const TokenTypeEmailVerify = "email_verify"
+
+// TokenTypePasswordReset is the TokenType of password reset tokens.
+const TokenTypePasswordReset = "password_reset"
+
+// DefaultEmailVerificationTokenLifetime is how long email verification tokens
+// are valid when no lifetime is configured.
+const DefaultEmailVerificationTokenLifetime = 24 * time.Hour
+
+// DefaultPasswordResetTokenLifetime is how long password reset tokens are
+// valid when no lifetime is configured.
+const DefaultPasswordResetTokenLifetime = time.Hour
+
 // Claims represents the custom claims structure for the JWT tokens.
 // It extends standard JWT registered claims with application-specific fields.
 type Claims struct {
 	// UserID is the unique identifier of the user the token was issued for.
 	UserID uuid.UUID `json:"uid,omitempty"`
 
-	// TokenType indicates the purpose of the token ("access" or "refresh").
+	// TokenType indicates the purpose of the token ("access", "refresh",
+	// "email_verify" or "password_reset").
 	// Used to prevent token misuse across different contexts.
 	TokenType string `json:"type,omitempty"`
 
+	// Fingerprint identifies the password hash a password reset token was
+	// issued for. It is empty for other token types.
+	Fingerprint string `json:"fp,omitempty"`
+
 	// Standard registered JWT claims
 	Subject   string    `json:"sub,omitempty"`
 	IssuedAt  time.Time `json:"iat,omitempty"`
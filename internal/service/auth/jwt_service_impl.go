@@ -17,14 +17,17 @@ type hmacJWTService struct {
 	signingKey           []byte
 	tokenLifetime        time.Duration    // Access token lifetime
 	refreshTokenLifetime time.Duration    // Refresh token lifetime
+	verifyTokenLifetime  time.Duration    // Email verification token lifetime
+	resetTokenLifetime   time.Duration    // Password reset token lifetime
 	timeFunc             func() time.Time // Injectable for testing
 	clockSkew            time.Duration    // Allowed time difference for validation to handle clock drift
 }
 
 // jwtCustomClaims defines the structure of JWT claims we use
 type jwtCustomClaims struct {
-	UserID    uuid.UUID `json:"uid"`
-	TokenType string    `json:"type"`
+	UserID      uuid.UUID `json:"uid"`
+	TokenType   string    `json:"type"`
+	Fingerprint string    `json:"fp,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -36,18 +39,42 @@ func NewJWTService(cfg config.AuthConfig) (JWTService, error) {
 	// Convert token lifetimes from minutes to duration
 	accessTokenLifetime := time.Duration(cfg.TokenLifetimeMinutes) * time.Minute
 	refreshTokenLifetime := time.Duration(cfg.RefreshTokenLifetimeMinutes) * time.Minute
+	clockSkew := time.Duration(cfg.ClockSkewSeconds) * time.Second
+	verifyTokenLifetime := time.Duration(cfg.EmailVerificationTokenLifetimeMinutes) * time.Minute
+	if verifyTokenLifetime <= 0 {
+		verifyTokenLifetime = DefaultEmailVerificationTokenLifetime
+	}
+	resetTokenLifetime := time.Duration(cfg.PasswordResetTokenLifetimeMinutes) * time.Minute
+	if resetTokenLifetime <= 0 {
+		resetTokenLifetime = DefaultPasswordResetTokenLifetime
+	}
 
 	// Validate that the secret meets minimum length requirements
 	if len(cfg.JWTSecret) < 32 {
 		return nil, fmt.Errorf("jwt secret must be at least 32 characters")
 	}
 
+	// Token lifetimes are only enforced when configured to be strict; existing
+	// deployments and tests rely on zero or unusual lifetimes being accepted
+	if cfg.StrictTokenLifetimes {
+		if err := ValidateTokenLifetimes(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate clock skew bounds (mirrors the config validation tags)
+	if cfg.ClockSkewSeconds < 0 || cfg.ClockSkewSeconds > 300 {
+		return nil, fmt.Errorf("clock skew must be between 0 and 300 seconds")
+	}
+
 	return &hmacJWTService{
 		signingKey:           []byte(cfg.JWTSecret),
 		tokenLifetime:        accessTokenLifetime,
 		refreshTokenLifetime: refreshTokenLifetime,
+		verifyTokenLifetime:  verifyTokenLifetime,
+		resetTokenLifetime:   resetTokenLifetime,
 		timeFunc:             time.Now,
-		clockSkew:            2 * time.Minute, // Allow 2 minutes of clock skew to handle minor time drifts
+		clockSkew:            clockSkew,
 	}, nil
 }
 
@@ -63,6 +90,7 @@ func (s *hmacJWTService) GenerateToken(ctx context.Context, userID uuid.UUID) (s
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   userID.String(),
 			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.tokenLifetime)),
 			ID:        uuid.New().String(), // Unique token ID
 		},
@@ -193,6 +221,7 @@ func (s *hmacJWTService) GenerateRefreshToken(
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   userID.String(),
 			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.refreshTokenLifetime)),
 			ID:        uuid.New().String(), // Unique token ID
 		},
@@ -311,6 +340,142 @@ func (s *hmacJWTService) ValidateRefreshToken(
 	return nil, ErrInvalidRefreshToken
 }
 
+// GenerateEmailVerificationToken creates a signed JWT email verification token.
+func (s *hmacJWTService) GenerateEmailVerificationToken(
+	ctx context.Context,
+	userID uuid.UUID,
+) (string, error) {
+	return s.signToken(ctx, userID, TokenTypeEmailVerify, "", s.verifyTokenLifetime)
+}
+
+// ValidateEmailVerificationToken validates a JWT email verification token and
+// returns the claims if valid. It verifies the token has type "email_verify"
+// and returns ErrWrongTokenType if not.
+func (s *hmacJWTService) ValidateEmailVerificationToken(
+	ctx context.Context,
+	tokenString string,
+) (*Claims, error) {
+	return s.parseToken(ctx, tokenString, TokenTypeEmailVerify)
+}
+
+// GeneratePasswordResetToken creates a signed JWT password reset token bound
+// to the fingerprint of the user's current password hash.
+func (s *hmacJWTService) GeneratePasswordResetToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	fingerprint string,
+) (string, error) {
+	return s.signToken(ctx, userID, TokenTypePasswordReset, fingerprint, s.resetTokenLifetime)
+}
+
+// ValidatePasswordResetToken validates a JWT password reset token and returns
+// the claims if valid. It verifies the token has type "password_reset" and
+// returns ErrWrongTokenType if not.
+func (s *hmacJWTService) ValidatePasswordResetToken(
+	ctx context.Context,
+	tokenString string,
+) (*Claims, error) {
+	return s.parseToken(ctx, tokenString, TokenTypePasswordReset)
+}
+
+// signToken creates a signed JWT of the given type for single-purpose tokens
+// that are sent to users out of band rather than used for API authentication.
+func (s *hmacJWTService) signToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	tokenType string,
+	fingerprint string,
+	lifetime time.Duration,
+) (string, error) {
+	log := logger.FromContext(ctx)
+	now := s.timeFunc()
+
+	claims := jwtCustomClaims{
+		UserID:      userID,
+		TokenType:   tokenType,
+		Fingerprint: fingerprint,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+			ID:        uuid.New().String(), // Unique token ID
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := token.SignedString(s.signingKey)
+	if err != nil {
+		log.Error("failed to sign JWT",
+			"error", err,
+			"user_id", userID,
+			"token_type", tokenType,
+			"signing_method", jwt.SigningMethodHS256.Name)
+		return "", fmt.Errorf("failed to sign %s token with HMAC-SHA256: %w", tokenType, err)
+	}
+
+	return signedToken, nil
+}
+
+// parseToken validates a JWT created by signToken and returns its claims if it
+// has the expected type. Expired tokens return ErrExpiredToken and tokens of
+// another type ErrWrongTokenType; any other failure returns ErrInvalidToken.
+func (s *hmacJWTService) parseToken(
+	ctx context.Context,
+	tokenString string,
+	tokenType string,
+) (*Claims, error) {
+	log := logger.FromContext(ctx)
+	now := s.timeFunc()
+
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		&jwtCustomClaims{},
+		func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return s.signingKey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
+		jwt.WithLeeway(s.clockSkew),
+		jwt.WithTimeFunc(func() time.Time {
+			return now
+		}),
+	)
+	if err != nil {
+		log.Debug("token validation failed",
+			"error", err,
+			"token_type", tokenType)
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*jwtCustomClaims)
+	if !ok || !token.Valid {
+		log.Debug("token validation failed: invalid claims", "token_type", tokenType)
+		return nil, ErrInvalidToken
+	}
+	if claims.TokenType != tokenType {
+		log.Debug("token validation failed: wrong token type",
+			"expected", tokenType,
+			"actual", claims.TokenType)
+		return nil, ErrWrongTokenType
+	}
+
+	return &Claims{
+		UserID:      claims.UserID,
+		TokenType:   claims.TokenType,
+		Fingerprint: claims.Fingerprint,
+		Subject:     claims.Subject,
+		IssuedAt:    claims.IssuedAt.Time,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		ID:          claims.ID,
+	}, nil
+}
+
 // NewTestJWTService creates a JWT service with adjustable time and token lifetimes for testing.
 // If refreshLifetime is 0, it defaults to 7x the access token lifetime.
 func NewTestJWTService(
@@ -329,6 +494,8 @@ func NewTestJWTService(
 		signingKey:           []byte(secret),
 		tokenLifetime:        lifetime,
 		refreshTokenLifetime: refreshTokenLifetime,
+		verifyTokenLifetime:  DefaultEmailVerificationTokenLifetime,
+		resetTokenLifetime:   DefaultPasswordResetTokenLifetime,
 		timeFunc:             timeFunc,
 		clockSkew:            0, // No clock skew for tests to make them deterministic
 	}
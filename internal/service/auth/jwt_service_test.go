@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -336,3 +337,110 @@ func TestGenerateRefreshToken(t *testing.T) {
 		assert.Nil(t, claims)
 	})
 }
+
+func TestValidateTokenClockSkew(t *testing.T) {
+	t.Parallel()
+
+	// Setup
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	secret := "test-secret-that-is-long-enough-for-testing"
+	userID := uuid.New()
+
+	// Issue a token 30 seconds in the future, simulating an issuer whose clock runs ahead
+	genSvc := NewTestJWTService(secret, 60*time.Minute, func() time.Time {
+		return fixedTime.Add(30 * time.Second)
+	})
+	token, err := genSvc.GenerateToken(context.Background(), userID)
+	require.NoError(t, err)
+
+	// newValidator builds a service from config with the given skew and a fixed clock
+	newValidator := func(t *testing.T, skewSeconds int) JWTService {
+		svc, err := NewJWTService(config.AuthConfig{
+			JWTSecret:                   secret,
+			TokenLifetimeMinutes:        60,
+			RefreshTokenLifetimeMinutes: 10080,
+			ClockSkewSeconds:            skewSeconds,
+		})
+		require.NoError(t, err)
+		svc.(*hmacJWTService).timeFunc = func() time.Time { return fixedTime }
+		return svc
+	}
+
+	t.Run("accepted with 60s skew", func(t *testing.T) {
+		t.Parallel()
+		claims, err := newValidator(t, 60).ValidateToken(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+	})
+
+	t.Run("rejected with no skew", func(t *testing.T) {
+		t.Parallel()
+		claims, err := newValidator(t, 0).ValidateToken(context.Background(), token)
+		assert.ErrorIs(t, err, ErrTokenNotYetValid)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("out of range skew rejected", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewJWTService(config.AuthConfig{
+			JWTSecret:                   secret,
+			TokenLifetimeMinutes:        60,
+			RefreshTokenLifetimeMinutes: 10080,
+			ClockSkewSeconds:            301,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestEmailVerificationToken(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	secret := "test-secret-that-is-long-enough-for-testing"
+	userID := uuid.New()
+	ctx := context.Background()
+
+	svc := NewTestJWTService(secret, time.Hour, func() time.Time { return fixedTime })
+	token, err := svc.GenerateEmailVerificationToken(ctx, userID)
+	require.NoError(t, err)
+
+	t.Run("valid token", func(t *testing.T) {
+		t.Parallel()
+		claims, err := svc.ValidateEmailVerificationToken(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, TokenTypeEmailVerify, claims.TokenType)
+		assert.Equal(t, fixedTime.Add(DefaultEmailVerificationTokenLifetime).Unix(), claims.ExpiresAt.Unix())
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		t.Parallel()
+		later := NewTestJWTService(secret, time.Hour, func() time.Time {
+			return fixedTime.Add(DefaultEmailVerificationTokenLifetime + time.Minute)
+		})
+		_, err := later.ValidateEmailVerificationToken(ctx, token)
+		assert.ErrorIs(t, err, ErrExpiredToken)
+	})
+
+	t.Run("not usable as an access token", func(t *testing.T) {
+		t.Parallel()
+		_, err := svc.ValidateToken(ctx, token)
+		assert.ErrorIs(t, err, ErrWrongTokenType)
+	})
+
+	t.Run("access token is not a verification token", func(t *testing.T) {
+		t.Parallel()
+		accessToken, err := svc.GenerateToken(ctx, userID)
+		require.NoError(t, err)
+		_, err = svc.ValidateEmailVerificationToken(ctx, accessToken)
+		assert.ErrorIs(t, err, ErrWrongTokenType)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		t.Parallel()
+		other := NewTestJWTService("wrong-secret-that-is-long-enough-for-testing", time.Hour,
+			func() time.Time { return fixedTime })
+		_, err := other.ValidateEmailVerificationToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
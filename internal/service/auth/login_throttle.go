@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLockoutDoublings caps how often the lockout doubles for an email that
+// keeps getting locked out, so the longest lockout is 16 times the base.
+const maxLockoutDoublings = 4
+
+// loginFailures is the failed-login state of one email address.
+type loginFailures struct {
+	count       int       // failures in the current window
+	windowStart time.Time // start of the current window
+	lastFailure time.Time // time of the most recent failure
+	lockouts    int       // consecutive lockouts, for escalation
+	lockedUntil time.Time // logins are refused until this time
+}
+
+// quietFor returns how long the email has gone without failing or being
+// locked out.
+func (f *loginFailures) quietFor(now time.Time) time.Duration {
+	latest := f.lastFailure
+	if f.lockedUntil.After(latest) {
+		latest = f.lockedUntil
+	}
+	return now.Sub(latest)
+}
+
+// LoginThrottle slows down password guessing by locking an email address out
+// after too many failed logins.
+//
+// After maxFailures failures within window, logins for the email are refused
+// for the lockout duration. Each further lockout doubles the lockout, up to 16
+// times the base, until the email logs in successfully or goes a whole window
+// without failures after its last lockout ends.
+//
+// State is kept in memory, per server instance, and is kept for any email
+// whether or not an account exists, so it does not reveal which emails are
+// registered.
+type LoginThrottle struct {
+	mu          sync.Mutex
+	emails      map[string]*loginFailures
+	maxFailures int
+	window      time.Duration
+	lockout     time.Duration
+	lastSweep   time.Time
+	now         func() time.Time
+}
+
+// NewLoginThrottle creates a LoginThrottle. A maxFailures of zero or less
+// disables throttling.
+func NewLoginThrottle(maxFailures int, window, lockout time.Duration) *LoginThrottle {
+	return &LoginThrottle{
+		emails:      make(map[string]*loginFailures),
+		maxFailures: maxFailures,
+		window:      window,
+		lockout:     lockout,
+		now:         time.Now,
+	}
+}
+
+// WithTimeFunc sets the time source, for tests. It returns the throttle.
+func (t *LoginThrottle) WithTimeFunc(now func() time.Time) *LoginThrottle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.now = now
+	return t
+}
+
+// Check returns ErrTooManyLoginAttempts, and how long until the lockout ends,
+// if logins for email are currently refused.
+func (t *LoginThrottle) Check(email string) (time.Duration, error) {
+	if t.maxFailures <= 0 {
+		return 0, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.emails[normalizeLoginEmail(email)]
+	if !ok {
+		return 0, nil
+	}
+	if remaining := state.lockedUntil.Sub(t.now()); remaining > 0 {
+		return remaining, ErrTooManyLoginAttempts
+	}
+	return 0, nil
+}
+
+// RecordFailure records a failed login for email, locking it out once it
+// reaches the failure limit.
+func (t *LoginThrottle) RecordFailure(email string) {
+	if t.maxFailures <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if now.Sub(t.lastSweep) >= t.window {
+		t.sweep(now)
+	}
+
+	key := normalizeLoginEmail(email)
+	state, ok := t.emails[key]
+	if !ok {
+		state = &loginFailures{windowStart: now}
+		t.emails[key] = state
+	}
+	switch {
+	case state.quietFor(now) >= t.window:
+		*state = loginFailures{windowStart: now}
+	case now.Sub(state.windowStart) >= t.window:
+		state.count = 0
+		state.windowStart = now
+	}
+
+	state.lastFailure = now
+	state.count++
+	if state.count >= t.maxFailures {
+		state.lockedUntil = now.Add(t.lockout << min(state.lockouts, maxLockoutDoublings))
+		state.lockouts++
+		state.count = 0
+		state.windowStart = now
+	}
+}
+
+// Reset forgets the failed logins of email after a successful login.
+func (t *LoginThrottle) Reset(email string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.emails, normalizeLoginEmail(email))
+}
+
+// sweep drops emails that would start over at their next failure.
+// The caller must hold t.mu.
+func (t *LoginThrottle) sweep(now time.Time) {
+	for key, state := range t.emails {
+		if state.quietFor(now) >= t.window {
+			delete(t.emails, key)
+		}
+	}
+	t.lastSweep = now
+}
+
+// normalizeLoginEmail maps the spellings of an email that log in to the same
+// account to one key; emails are matched case-insensitively.
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(email)
+}
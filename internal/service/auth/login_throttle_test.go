@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	t.Parallel()
+
+	newThrottle := func() (*LoginThrottle, func(time.Duration)) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		throttle := NewLoginThrottle(3, 15*time.Minute, 10*time.Minute).
+			WithTimeFunc(func() time.Time { return now })
+		return throttle, func(d time.Duration) { now = now.Add(d) }
+	}
+
+	fail := func(throttle *LoginThrottle, email string, times int) {
+		for i := 0; i < times; i++ {
+			throttle.RecordFailure(email)
+		}
+	}
+
+	t.Run("locks out after max failures", func(t *testing.T) {
+		t.Parallel()
+		throttle, advance := newThrottle()
+
+		fail(throttle, "user@example.com", 2)
+		_, err := throttle.Check("user@example.com")
+		require.NoError(t, err, "Two failures should not lock the email out")
+
+		fail(throttle, "user@example.com", 1)
+		retryAfter, err := throttle.Check("user@example.com")
+		assert.ErrorIs(t, err, ErrTooManyLoginAttempts)
+		assert.Equal(t, 10*time.Minute, retryAfter)
+
+		// Emails are matched case-insensitively, and other emails are unaffected
+		_, err = throttle.Check("USER@example.com")
+		assert.ErrorIs(t, err, ErrTooManyLoginAttempts)
+		_, err = throttle.Check("other@example.com")
+		assert.NoError(t, err)
+
+		advance(10 * time.Minute)
+		_, err = throttle.Check("user@example.com")
+		assert.NoError(t, err, "The lockout should end")
+	})
+
+	t.Run("failures outside the window are not counted", func(t *testing.T) {
+		t.Parallel()
+		throttle, advance := newThrottle()
+
+		fail(throttle, "user@example.com", 2)
+		advance(15 * time.Minute)
+		fail(throttle, "user@example.com", 2)
+
+		_, err := throttle.Check("user@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("repeated lockouts get longer", func(t *testing.T) {
+		t.Parallel()
+		throttle, advance := newThrottle()
+
+		for _, expected := range []time.Duration{10, 20, 40, 80, 160, 160} {
+			fail(throttle, "user@example.com", 3)
+			retryAfter, err := throttle.Check("user@example.com")
+			require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+			assert.Equal(t, expected*time.Minute, retryAfter)
+			advance(retryAfter)
+		}
+
+		// A quiet window after the lockout ends starts over
+		advance(15 * time.Minute)
+		fail(throttle, "user@example.com", 3)
+		retryAfter, err := throttle.Check("user@example.com")
+		require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+		assert.Equal(t, 10*time.Minute, retryAfter)
+	})
+
+	t.Run("reset clears failures", func(t *testing.T) {
+		t.Parallel()
+		throttle, advance := newThrottle()
+
+		fail(throttle, "user@example.com", 3)
+		advance(10 * time.Minute)
+		throttle.Reset("User@Example.com")
+
+		// The next lockout is not escalated
+		fail(throttle, "user@example.com", 2)
+		_, err := throttle.Check("user@example.com")
+		require.NoError(t, err)
+		fail(throttle, "user@example.com", 1)
+		retryAfter, err := throttle.Check("user@example.com")
+		require.ErrorIs(t, err, ErrTooManyLoginAttempts)
+		assert.Equal(t, 10*time.Minute, retryAfter)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		throttle := NewLoginThrottle(0, time.Minute, time.Minute)
+
+		fail(throttle, "user@example.com", 100)
+		_, err := throttle.Check("user@example.com")
+		assert.NoError(t, err)
+	})
+}
@@ -1,6 +1,40 @@
 package auth
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phrazzld/scry-api/internal/config"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password hashing schemes that can be selected with config.AuthConfig.PasswordScheme.
+const (
+	SchemeBcrypt   = "bcrypt"
+	SchemeArgon2id = "argon2id"
+)
+
+// DefaultPasswordScheme is used when no scheme is configured.
+const DefaultPasswordScheme = SchemeBcrypt
+
+var (
+	// ErrPasswordMismatch is returned when a password does not match an Argon2 hash.
+	// Mismatches against bcrypt hashes return bcrypt.ErrMismatchedHashAndPassword.
+	ErrPasswordMismatch = errors.New("password does not match hash")
+
+	// ErrMalformedHash is returned when a stored hash cannot be parsed.
+	ErrMalformedHash = errors.New("malformed password hash")
+
+	// ErrUnknownPasswordScheme is returned for a scheme that is not supported.
+	ErrUnknownPasswordScheme = errors.New("unknown password scheme")
+)
 
 // PasswordVerifier defines the interface for comparing passwords.
 type PasswordVerifier interface {
@@ -9,15 +43,228 @@ type PasswordVerifier interface {
 	Compare(hashedPassword, password string) error
 }
 
-// BcryptVerifier implements PasswordVerifier using bcrypt.
-type BcryptVerifier struct{}
+// PasswordHasher defines the interface for hashing passwords before they are stored.
+type PasswordHasher interface {
+	// Hash returns a self-describing hash of password that any PasswordVerifier
+	// in this package can check, whichever scheme produced it.
+	Hash(password string) (string, error)
+}
+
+// PasswordScheme hashes new passwords with one algorithm and verifies hashes
+// made by any supported algorithm.
+type PasswordScheme interface {
+	PasswordVerifier
+	PasswordHasher
+}
+
+// NewPasswordScheme returns the PasswordScheme selected by cfg.PasswordScheme,
+// defaulting to bcrypt at cfg.BCryptCost.
+func NewPasswordScheme(cfg *config.AuthConfig) (PasswordScheme, error) {
+	switch cfg.PasswordScheme {
+	case "", SchemeBcrypt:
+		return NewBcryptVerifierWithCost(cfg.BCryptCost), nil
+	case SchemeArgon2id:
+		return NewArgon2Verifier(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPasswordScheme, cfg.PasswordScheme)
+	}
+}
+
+// comparePassword checks password against a hash made by any supported scheme,
+// picking the algorithm from the hash's prefix. This lets stored hashes keep
+// verifying after the configured scheme changes.
+func comparePassword(hashedPassword, password string) error {
+	if strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		return compareArgon2id(hashedPassword, password)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+}
+
+// hashScheme returns the scheme that produced hashedPassword, or "" if the hash
+// is not recognized.
+func hashScheme(hashedPassword string) string {
+	if strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		return SchemeArgon2id
+	}
+	if _, err := bcrypt.Cost([]byte(hashedPassword)); err == nil {
+		return SchemeBcrypt
+	}
+	return ""
+}
+
+// BcryptVerifier implements PasswordVerifier and PasswordHasher using bcrypt.
+type BcryptVerifier struct {
+	cost int
+}
 
-// NewBcryptVerifier creates a new BcryptVerifier.
+// NewBcryptVerifier creates a new BcryptVerifier that hashes at bcrypt.DefaultCost.
 func NewBcryptVerifier() *BcryptVerifier {
-	return &BcryptVerifier{}
+	return NewBcryptVerifierWithCost(bcrypt.DefaultCost)
 }
 
-// Compare implements the PasswordVerifier interface using bcrypt.
+// NewBcryptVerifierWithCost creates a new BcryptVerifier that hashes at cost.
+// If cost is 0 or invalid, bcrypt.DefaultCost will be used.
+func NewBcryptVerifierWithCost(cost int) *BcryptVerifier {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptVerifier{cost: cost}
+}
+
+// Compare implements the PasswordVerifier interface. Argon2id hashes are
+// verified too, so switching back from argon2id does not lock users out.
 func (v *BcryptVerifier) Compare(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	return comparePassword(hashedPassword, password)
+}
+
+// Hash implements the PasswordHasher interface using bcrypt.
+func (v *BcryptVerifier) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), v.cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password with bcrypt: %w", err)
+	}
+	return string(hash), nil
+}
+
+// Argon2id parameters, following the RFC 9106 recommendation for
+// memory-constrained environments.
+const (
+	argon2idPrefix  = "$argon2id$"
+	argon2idTime    = 3
+	argon2idMemory  = 64 * 1024 // KiB
+	argon2idThreads = 4
+	argon2idSaltLen = 16
+	argon2idKeyLen  = 32
+)
+
+// Argon2Verifier implements PasswordVerifier and PasswordHasher using Argon2id.
+// Hashes are encoded in the PHC string format, for example
+// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>, so the parameters used for each
+// hash are stored alongside it.
+type Argon2Verifier struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+// NewArgon2Verifier creates a new Argon2Verifier with the default parameters.
+func NewArgon2Verifier() *Argon2Verifier {
+	return &Argon2Verifier{
+		time:    argon2idTime,
+		memory:  argon2idMemory,
+		threads: argon2idThreads,
+	}
+}
+
+// Compare implements the PasswordVerifier interface. Bcrypt hashes are
+// verified too, so existing users can still log in after switching to argon2id.
+func (v *Argon2Verifier) Compare(hashedPassword, password string) error {
+	return comparePassword(hashedPassword, password)
+}
+
+// Hash implements the PasswordHasher interface using Argon2id with a random salt.
+func (v *Argon2Verifier) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, v.time, v.memory, v.threads, argon2idKeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, v.memory, v.time, v.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// compareArgon2id checks password against a PHC-encoded Argon2id hash using the
+// parameters recorded in the hash.
+func compareArgon2id(hashedPassword, password string) error {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 {
+		return ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedHash, err)
+	}
+	if version != argon2.Version {
+		return fmt.Errorf("%w: unsupported argon2 version %d", ErrMalformedHash, version)
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedHash, err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedHash, err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedHash, err)
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash reports whether hashedPassword should be replaced by a new hash of
+// the password under scheme: either it was made by a different scheme, or it
+// is a bcrypt hash with a cost below cost. Checking on login lets the scheme
+// be switched and the cost raised over time without forcing password resets.
+// Hashes that cannot be parsed never need a rehash.
+func NeedsRehash(hashedPassword, scheme string, cost int) bool {
+	if scheme == "" {
+		scheme = DefaultPasswordScheme
+	}
+
+	current := hashScheme(hashedPassword)
+	if current == "" {
+		return false
+	}
+	if current != scheme {
+		return true
+	}
+	if current != SchemeBcrypt {
+		return false
+	}
+
+	hashCost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return false
+	}
+	return hashCost < cost
+}
+
+// PasswordFingerprint returns a short digest identifying hashedPassword.
+// Password reset tokens carry the fingerprint of the hash they were issued for,
+// so a token stops working once the password has been changed, making reset
+// tokens single-use without storing them. The digest reveals nothing useful
+// about the hash, which is itself salted.
+func PasswordFingerprint(hashedPassword string) string {
+	sum := sha256.Sum256([]byte(hashedPassword))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NewDummyPasswordHash returns a hash of a fixed password made by hasher, so
+// with the scheme and cost used for new passwords. Comparing a password against
+// it takes as long as checking a real account's password, so logins for unknown
+// emails can be made as slow as logins with a wrong password. The result of the
+// comparison must be ignored. Hashing is slow by design, so the hash should be
+// made once at startup.
+func NewDummyPasswordHash(hasher PasswordHasher) (string, error) {
+	hash, err := hasher.Hash("dummy password for timing")
+	if err != nil {
+		return "", fmt.Errorf("failed to generate dummy password hash: %w", err)
+	}
+	return hash, nil
 }
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestNeedsRehash(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("securePassword123"), bcrypt.MinCost+1)
+	require.NoError(t, err)
+	bcryptHash := string(hash)
+
+	argon2Hash, err := NewArgon2Verifier().Hash("securePassword123")
+	require.NoError(t, err)
+
+	assert.True(t, NeedsRehash(bcryptHash, SchemeBcrypt, bcrypt.MinCost+2), "Hashes below the cost need a rehash")
+	assert.False(t, NeedsRehash(bcryptHash, SchemeBcrypt, bcrypt.MinCost+1), "Hashes at the cost are current")
+	assert.False(t, NeedsRehash(bcryptHash, SchemeBcrypt, bcrypt.MinCost), "Hashes above the cost are never downgraded")
+	assert.False(t, NeedsRehash(bcryptHash, "", bcrypt.MinCost+1), "An empty scheme means bcrypt")
+	assert.False(t, NeedsRehash("not-a-bcrypt-hash", SchemeBcrypt, bcrypt.DefaultCost), "Unparseable hashes are left alone")
+
+	assert.True(t, NeedsRehash(bcryptHash, SchemeArgon2id, bcrypt.MinCost), "Bcrypt hashes migrate to argon2id")
+	assert.True(t, NeedsRehash(argon2Hash, SchemeBcrypt, bcrypt.MinCost), "Argon2id hashes migrate to bcrypt")
+	assert.False(t, NeedsRehash(argon2Hash, SchemeArgon2id, bcrypt.DefaultCost), "Argon2id hashes are current")
+}
+
+func TestArgon2Verifier(t *testing.T) {
+	t.Parallel()
+
+	verifier := NewArgon2Verifier()
+	password := "securePassword123"
+
+	hash, err := verifier.Hash(password)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=4$"), "Hash should be PHC encoded: %s", hash)
+
+	assert.NoError(t, verifier.Compare(hash, password), "Argon2id hash should verify")
+	assert.ErrorIs(t, verifier.Compare(hash, "wrongPassword123"), ErrPasswordMismatch)
+
+	other, err := verifier.Hash(password)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "Each hash should use a new salt")
+
+	assert.ErrorIs(t, verifier.Compare("$argon2id$v=19$garbage", password), ErrMalformedHash)
+}
+
+func TestPasswordSchemes_VerifyEachOthersHashes(t *testing.T) {
+	t.Parallel()
+
+	password := "securePassword123"
+	bcryptVerifier := NewBcryptVerifierWithCost(bcrypt.MinCost)
+	argon2Verifier := NewArgon2Verifier()
+
+	bcryptHash, err := bcryptVerifier.Hash(password)
+	require.NoError(t, err)
+	argon2Hash, err := argon2Verifier.Hash(password)
+	require.NoError(t, err)
+
+	assert.NoError(t, argon2Verifier.Compare(bcryptHash, password), "Bcrypt hashes should verify under argon2id")
+	assert.Error(t, argon2Verifier.Compare(bcryptHash, "wrongPassword123"))
+	assert.NoError(t, bcryptVerifier.Compare(argon2Hash, password), "Argon2id hashes should verify under bcrypt")
+	assert.Error(t, bcryptVerifier.Compare(argon2Hash, "wrongPassword123"))
+}
+
+// TestNewDummyPasswordHash tests that the dummy hash checked for unknown emails
+// is made with the configured scheme and cost, so it takes as long to check as
+// the hash of an account whose password was set under the same configuration
+func TestNewDummyPasswordHash(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		cfg    config.AuthConfig
+		scheme string
+	}{
+		{"default", config.AuthConfig{}, SchemeBcrypt},
+		{
+			"bcrypt at configured cost",
+			config.AuthConfig{PasswordScheme: SchemeBcrypt, BCryptCost: bcrypt.MinCost + 2},
+			SchemeBcrypt,
+		},
+		{"argon2id", config.AuthConfig{PasswordScheme: SchemeArgon2id, BCryptCost: bcrypt.MinCost}, SchemeArgon2id},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme, err := NewPasswordScheme(&tc.cfg)
+			require.NoError(t, err)
+			dummy, err := NewDummyPasswordHash(scheme)
+			require.NoError(t, err)
+
+			cost := tc.cfg.BCryptCost
+			if cost == 0 {
+				cost = bcrypt.DefaultCost
+			}
+			assert.Equal(t, tc.scheme, hashScheme(dummy))
+			assert.False(t, NeedsRehash(dummy, tc.cfg.PasswordScheme, cost),
+				"The dummy hash should be current under the configuration")
+
+			switch tc.scheme {
+			case SchemeBcrypt:
+				hashCost, err := bcrypt.Cost([]byte(dummy))
+				require.NoError(t, err)
+				assert.Equal(t, cost, hashCost)
+			case SchemeArgon2id:
+				assert.True(t, strings.HasPrefix(dummy, "$argon2id$v=19$m=65536,t=3,p=4$"),
+					"The dummy hash should use the configured parameters: %s", dummy)
+			}
+		})
+	}
+}
+
+func TestNewPasswordScheme(t *testing.T) {
+	t.Parallel()
+
+	scheme, err := NewPasswordScheme(&config.AuthConfig{})
+	require.NoError(t, err)
+	assert.IsType(t, &BcryptVerifier{}, scheme, "Bcrypt should be the default")
+
+	scheme, err = NewPasswordScheme(&config.AuthConfig{PasswordScheme: SchemeArgon2id})
+	require.NoError(t, err)
+	assert.IsType(t, &Argon2Verifier{}, scheme)
+
+	_, err = NewPasswordScheme(&config.AuthConfig{PasswordScheme: "md5"})
+	assert.ErrorIs(t, err, ErrUnknownPasswordScheme)
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/phrazzld/scry-api/internal/config"
+)
+
+// MaxTokenLifetimeMinutes is the exclusive upper bound on access and refresh
+// token lifetimes (31 days), mirroring the config validation tags.
+const MaxTokenLifetimeMinutes = 44640
+
+// ValidateTokenLifetimes checks that the access and refresh token lifetimes in
+// cfg are each between 1 and MaxTokenLifetimeMinutes-1 minutes, and that the
+// refresh token outlives the access token. It returns nil if they are valid,
+// or an error listing every problem found, which wraps ErrInvalidTokenLifetimes.
+//
+// NewJWTService enforces this only when cfg.StrictTokenLifetimes is set, so
+// callers in lenient mode can use it to report problems without failing.
+func ValidateTokenLifetimes(cfg config.AuthConfig) error {
+	var problems []error
+	if !lifetimeInBounds(cfg.TokenLifetimeMinutes) {
+		problems = append(problems, fmt.Errorf("%w: access token lifetime %d minutes is outside 1-%d",
+			ErrInvalidTokenLifetimes, cfg.TokenLifetimeMinutes, MaxTokenLifetimeMinutes-1))
+	}
+	if !lifetimeInBounds(cfg.RefreshTokenLifetimeMinutes) {
+		problems = append(problems, fmt.Errorf("%w: refresh token lifetime %d minutes is outside 1-%d",
+			ErrInvalidTokenLifetimes, cfg.RefreshTokenLifetimeMinutes, MaxTokenLifetimeMinutes-1))
+	}
+	if cfg.RefreshTokenLifetimeMinutes <= cfg.TokenLifetimeMinutes {
+		problems = append(problems, fmt.Errorf(
+			"%w: refresh token lifetime (%d minutes) must be longer than access token lifetime (%d minutes)",
+			ErrInvalidTokenLifetimes, cfg.RefreshTokenLifetimeMinutes, cfg.TokenLifetimeMinutes))
+	}
+	return errors.Join(problems...)
+}
+
+func lifetimeInBounds(minutes int) bool {
+	return minutes > 0 && minutes < MaxTokenLifetimeMinutes
+}
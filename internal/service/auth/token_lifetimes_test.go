@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTokenLifetimes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		access       int
+		refresh      int
+		wantProblems int
+	}{
+		{name: "valid pairing", access: 60, refresh: 10080},
+		{name: "refresh shorter than access", access: 120, refresh: 60, wantProblems: 1},
+		{name: "refresh equal to access", access: 60, refresh: 60, wantProblems: 1},
+		{name: "zero lifetimes", access: 0, refresh: 0, wantProblems: 3},
+		{name: "refresh too long", access: 60, refresh: MaxTokenLifetimeMinutes, wantProblems: 1},
+		{name: "longest allowed refresh", access: 60, refresh: MaxTokenLifetimeMinutes - 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateTokenLifetimes(config.AuthConfig{
+				TokenLifetimeMinutes:        tt.access,
+				RefreshTokenLifetimeMinutes: tt.refresh,
+			})
+			if tt.wantProblems == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidTokenLifetimes)
+			joined, ok := err.(interface{ Unwrap() []error })
+			require.True(t, ok, "every problem should be reported")
+			assert.Len(t, joined.Unwrap(), tt.wantProblems)
+		})
+	}
+}
+
+func TestNewJWTService_StrictTokenLifetimes(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func(access, refresh int, strict bool) config.AuthConfig {
+		return config.AuthConfig{
+			JWTSecret:                   "test-secret-that-is-long-enough-for-testing",
+			TokenLifetimeMinutes:        access,
+			RefreshTokenLifetimeMinutes: refresh,
+			StrictTokenLifetimes:        strict,
+		}
+	}
+
+	t.Run("refresh shorter than access rejected when strict", func(t *testing.T) {
+		t.Parallel()
+		svc, err := NewJWTService(newConfig(120, 60, true))
+		assert.ErrorIs(t, err, ErrInvalidTokenLifetimes)
+		assert.Nil(t, svc)
+	})
+
+	t.Run("refresh shorter than access accepted when lenient", func(t *testing.T) {
+		t.Parallel()
+		svc, err := NewJWTService(newConfig(120, 60, false))
+		require.NoError(t, err)
+		assert.NotNil(t, svc)
+	})
+
+	t.Run("zero lifetimes rejected when strict", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewJWTService(newConfig(0, 0, true))
+		assert.ErrorIs(t, err, ErrInvalidTokenLifetimes)
+	})
+
+	t.Run("valid pairing accepted when strict", func(t *testing.T) {
+		t.Parallel()
+		svc, err := NewJWTService(newConfig(60, 10080, true))
+		require.NoError(t, err)
+		assert.NotNil(t, svc)
+	})
+}
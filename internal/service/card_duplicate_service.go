@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// ErrCardsNotDuplicates indicates that cards submitted for merging do not all
+// have the same content as the card being kept.
+var ErrCardsNotDuplicates = errors.New("cards are not duplicates")
+
+// CardDuplicateService finds and merges cards with identical content across
+// all of a user's memos.
+type CardDuplicateService interface {
+	// FindDuplicates returns the user's groups of cards with identical content.
+	// Only groups with more than one card are returned.
+	FindDuplicates(ctx context.Context, userID uuid.UUID) ([]*domain.DuplicateCardGroup, error)
+
+	// MergeDuplicates keeps keepCardID and deletes each card in duplicateIDs,
+	// together with its review statistics and history, in a single transaction.
+	// The kept card's review progress is unaffected.
+	//
+	// Returns store.ErrCardNotFound if any card does not exist,
+	// card_review.ErrCardNotOwned if any card belongs to another user, and
+	// ErrCardsNotDuplicates if any card's content differs from the kept card's.
+	MergeDuplicates(
+		ctx context.Context,
+		userID uuid.UUID,
+		keepCardID uuid.UUID,
+		duplicateIDs []uuid.UUID,
+	) error
+}
+
+// cardDuplicateServiceImpl implements the CardDuplicateService interface
+type cardDuplicateServiceImpl struct {
+	cardStore store.CardStore
+	logger    *slog.Logger
+}
+
+// NewCardDuplicateService creates a new CardDuplicateService
+// It returns an error if the card store is nil.
+func NewCardDuplicateService(
+	cardStore store.CardStore,
+	logger *slog.Logger,
+) (CardDuplicateService, error) {
+	if cardStore == nil {
+		return nil, fmt.Errorf("cardStore cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &cardDuplicateServiceImpl{
+		cardStore: cardStore,
+		logger:    logger.With("component", "card_duplicate_service"),
+	}, nil
+}
+
+// FindDuplicates implements CardDuplicateService.FindDuplicates
+func (s *cardDuplicateServiceImpl) FindDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*domain.DuplicateCardGroup, error) {
+	groups, err := s.cardStore.FindDuplicates(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to find duplicate cards",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to find duplicate cards: %w", err)
+	}
+	return groups, nil
+}
+
+// MergeDuplicates implements CardDuplicateService.MergeDuplicates
+// All cards are loaded with a single batch query and checked before anything is deleted.
+func (s *cardDuplicateServiceImpl) MergeDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+	keepCardID uuid.UUID,
+	duplicateIDs []uuid.UUID,
+) error {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if len(duplicateIDs) == 0 {
+		return domain.NewValidationError("card_ids", "must not be empty", domain.ErrValidation)
+	}
+	for _, id := range duplicateIDs {
+		if id == keepCardID {
+			return domain.NewValidationError(
+				"card_ids",
+				"must not contain the card being kept",
+				domain.ErrValidation,
+			)
+		}
+	}
+
+	ids := append([]uuid.UUID{keepCardID}, duplicateIDs...)
+
+	return store.RunInTransaction(ctx, s.cardStore.DB(), func(ctx context.Context, tx *sql.Tx) error {
+		txCardStore := s.cardStore.WithTx(tx)
+
+		cards, err := txCardStore.GetByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to load cards: %w", err)
+		}
+
+		for _, id := range ids {
+			card, ok := cards[id]
+			if !ok {
+				return fmt.Errorf("card %s: %w", id, store.ErrCardNotFound)
+			}
+			if card.UserID != userID {
+				return card_review.ErrCardNotOwned
+			}
+		}
+
+		kept := cards[keepCardID]
+		for _, id := range duplicateIDs {
+			if !bytes.Equal(cards[id].Content, kept.Content) {
+				return ErrCardsNotDuplicates
+			}
+		}
+
+		for _, id := range duplicateIDs {
+			if err := txCardStore.Delete(ctx, id); err != nil {
+				// A repeated ID has already been deleted earlier in this merge
+				if errors.Is(err, store.ErrCardNotFound) {
+					continue
+				}
+				log.Error("failed to delete duplicate card",
+					slog.String("error", err.Error()),
+					slog.String("card_id", id.String()))
+				return fmt.Errorf("failed to delete duplicate card: %w", err)
+			}
+		}
+
+		log.Info("merged duplicate cards",
+			slog.String("user_id", userID.String()),
+			slog.String("kept_card_id", keepCardID.String()),
+			slog.Int("removed", len(duplicateIDs)))
+		return nil
+	})
+}
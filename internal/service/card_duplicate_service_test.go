@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestCardDuplicateService_MergeDuplicates verifies that merging keeps the chosen
+// card, removes its duplicates, and rejects cards that are not duplicates
+func TestCardDuplicateService_MergeDuplicates(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	ctx := context.Background()
+	logger := slog.Default()
+	cardStore := postgres.NewPostgresCardStore(db, logger)
+	duplicateService, err := service.NewCardDuplicateService(cardStore, logger)
+	require.NoError(t, err)
+
+	// The service manages its own transaction, so data is committed and cleaned up per user
+	userID := testutils.MustInsertUser(ctx, t, db, "merge-duplicates-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	otherUserID := testutils.MustInsertUser(ctx, t, db, "merge-other-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, "DELETE FROM users WHERE id = ANY($1::uuid[])",
+			[]string{userID.String(), otherUserID.String()})
+	})
+
+	insertCard := func(ownerID uuid.UUID, content string) *domain.Card {
+		memo := testutils.MustInsertMemo(ctx, t, db, ownerID)
+		card, err := domain.NewCard(ownerID, memo.ID, json.RawMessage(content))
+		require.NoError(t, err)
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+		return card
+	}
+
+	keep := insertCard(userID, `{"front":"Q","back":"A"}`)
+	dupA := insertCard(userID, `{"front":"Q","back":"A"}`)
+	dupB := insertCard(userID, `{"back":"A","front":"Q"}`)
+	different := insertCard(userID, `{"front":"Other","back":"A"}`)
+	foreign := insertCard(otherUserID, `{"front":"Q","back":"A"}`)
+
+	t.Run("rejects_cards_with_different_content", func(t *testing.T) {
+		err := duplicateService.MergeDuplicates(ctx, userID, keep.ID, []uuid.UUID{dupA.ID, different.ID})
+		assert.ErrorIs(t, err, service.ErrCardsNotDuplicates)
+
+		// Nothing is deleted when validation fails
+		_, err = cardStore.GetByID(ctx, dupA.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects_cards_owned_by_other_users", func(t *testing.T) {
+		err := duplicateService.MergeDuplicates(ctx, userID, keep.ID, []uuid.UUID{foreign.ID})
+		assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+	})
+
+	t.Run("rejects_missing_cards", func(t *testing.T) {
+		err := duplicateService.MergeDuplicates(ctx, userID, keep.ID, []uuid.UUID{uuid.New()})
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+	})
+
+	t.Run("merges_duplicates", func(t *testing.T) {
+		groups, err := duplicateService.FindDuplicates(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Len(t, groups[0].Cards, 3)
+
+		err = duplicateService.MergeDuplicates(ctx, userID, keep.ID, []uuid.UUID{dupA.ID, dupB.ID})
+		require.NoError(t, err)
+
+		_, err = cardStore.GetByID(ctx, keep.ID)
+		assert.NoError(t, err, "Kept card should remain")
+		_, err = cardStore.GetByID(ctx, dupA.ID)
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+		_, err = cardStore.GetByID(ctx, dupB.ID)
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+
+		groups, err = duplicateService.FindDuplicates(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+}
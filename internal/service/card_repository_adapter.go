@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -34,6 +35,55 @@ func (a *cardRepositoryAdapter) GetByID(ctx context.Context, id uuid.UUID) (*dom
 	return a.cardStore.GetByID(ctx, id)
 }
 
+// UpdateContent implements CardRepository.UpdateContent
+func (a *cardRepositoryAdapter) UpdateContent(
+	ctx context.Context,
+	id uuid.UUID,
+	content []byte,
+	expectedVersion int,
+) (int, error) {
+	return a.cardStore.UpdateContent(ctx, id, content, expectedVersion)
+}
+
+// SetMemo implements CardRepository.SetMemo
+func (a *cardRepositoryAdapter) SetMemo(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error {
+	return a.cardStore.SetMemo(ctx, id, memoID)
+}
+
+// SetSuspended implements CardRepository.SetSuspended
+func (a *cardRepositoryAdapter) SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error {
+	return a.cardStore.SetSuspended(ctx, id, suspendedAt)
+}
+
+// SetBuriedUntil implements CardRepository.SetBuriedUntil
+func (a *cardRepositoryAdapter) SetBuriedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error {
+	return a.cardStore.SetBuriedUntil(ctx, id, until)
+}
+
+// SupersedeByMemo implements CardRepository.SupersedeByMemo
+func (a *cardRepositoryAdapter) SupersedeByMemo(
+	ctx context.Context,
+	memoID uuid.UUID,
+	at time.Time,
+) (int, error) {
+	return a.cardStore.SupersedeByMemo(ctx, memoID, at)
+}
+
+// DeleteByMemo implements CardRepository.DeleteByMemo
+func (a *cardRepositoryAdapter) DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error) {
+	return a.cardStore.DeleteByMemo(ctx, memoID)
+}
+
+// ListByTag implements CardRepository.ListByTag
+func (a *cardRepositoryAdapter) ListByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	return a.cardStore.ListByTag(ctx, userID, tag, limit, offset)
+}
+
 // WithTx implements CardRepository.WithTx
 func (a *cardRepositoryAdapter) WithTx(tx *sql.Tx) CardRepository {
 	return &cardRepositoryAdapter{
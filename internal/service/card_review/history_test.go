@@ -0,0 +1,98 @@
+package card_review_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/domain/srs"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestGetCardHistory records answers on the in-memory stores and reads them back
+func TestGetCardHistory(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	users := memory.NewUserStore(backend, bcrypt.MinCost)
+	cards := memory.NewCardStore(backend)
+	memos := memory.NewMemoStore(backend)
+	srsService, err := srs.NewDefaultService()
+	require.NoError(t, err)
+
+	newCard := func(email string) (*domain.User, *domain.Card) {
+		user, err := domain.NewUser(email, "history-password")
+		require.NoError(t, err)
+		require.NoError(t, users.Create(ctx, user))
+		memo, err := domain.NewMemo(user.ID, "History memo")
+		require.NoError(t, err)
+		require.NoError(t, memos.Create(ctx, memo))
+		card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+		require.NoError(t, err)
+		require.NoError(t, cards.CreateMultiple(ctx, []*domain.Card{card}))
+		return user, card
+	}
+	user, card := newCard("history@example.com")
+	_, foreign := newCard("other-history@example.com")
+
+	service, err := card_review.NewCardReviewService(
+		cards, memory.NewUserCardStatsStore(backend), memory.NewReviewLogStore(backend), users, srsService,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		card_review.WithReviewEvents(memory.NewReviewEventStore(backend)),
+	)
+	require.NoError(t, err)
+
+	history, err := service.GetCardHistory(ctx, user.ID, card.ID)
+	require.NoError(t, err)
+	assert.Empty(t, history, "A card that was never answered has no history")
+
+	outcomes := []domain.ReviewOutcome{domain.ReviewOutcomeGood, domain.ReviewOutcomeGood, domain.ReviewOutcomeAgain}
+	var intervals []int
+	for i, outcome := range outcomes {
+		var stats *domain.UserCardStats
+		if i == 1 {
+			var results []*domain.UserCardStats
+			results, err = service.SubmitAnswers(ctx, user.ID, []card_review.CardAnswer{
+				{CardID: card.ID, Outcome: outcome},
+			})
+			if err == nil {
+				stats = results[0]
+			}
+		} else {
+			stats, err = service.SubmitAnswer(ctx, user.ID, card.ID, card_review.ReviewAnswer{Outcome: outcome})
+		}
+		require.NoError(t, err)
+		intervals = append(intervals, stats.Interval)
+	}
+
+	history, err = service.GetCardHistory(ctx, user.ID, card.ID)
+	require.NoError(t, err)
+	require.Len(t, history, len(outcomes), "Single and batch answers are both recorded")
+	previous := 0
+	for i, event := range history {
+		assert.Equal(t, outcomes[i], event.Outcome)
+		assert.Equal(t, card.ID, event.CardID)
+		require.NotNil(t, event.IntervalBefore)
+		require.NotNil(t, event.IntervalAfter)
+		assert.Equal(t, previous, *event.IntervalBefore, "Each answer starts from the interval the last one scheduled")
+		assert.Equal(t, intervals[i], *event.IntervalAfter)
+		if i > 0 {
+			assert.False(t, event.ReviewedAt.Before(history[i-1].ReviewedAt), "History is oldest first")
+		}
+		previous = intervals[i]
+	}
+
+	_, err = service.GetCardHistory(ctx, user.ID, foreign.ID)
+	assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+	_, err = service.GetCardHistory(ctx, user.ID, uuid.New())
+	assert.ErrorIs(t, err, card_review.ErrCardNotFound)
+}
@@ -0,0 +1,142 @@
+package card_review_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/domain/srs"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestLeechDetection drives cards past the leech threshold on the in-memory stores
+func TestLeechDetection(t *testing.T) {
+	ctx := context.Background()
+	const threshold = 3
+
+	type fixture struct {
+		service card_review.CardReviewService
+		cards   *memory.CardStore
+		stats   *memory.UserCardStatsStore
+		user    *domain.User
+		card    *domain.Card
+	}
+
+	setup := func(t *testing.T, opts ...card_review.CardReviewServiceOption) fixture {
+		t.Helper()
+		backend := memory.NewBackend()
+		t.Cleanup(func() { _ = backend.Close() })
+
+		users := memory.NewUserStore(backend, bcrypt.MinCost)
+		cards := memory.NewCardStore(backend)
+		stats := memory.NewUserCardStatsStore(backend)
+		srsService, err := srs.NewDefaultService()
+		require.NoError(t, err)
+
+		user, err := domain.NewUser("leech@example.com", "leech-password")
+		require.NoError(t, err)
+		require.NoError(t, users.Create(ctx, user))
+		memo, err := domain.NewMemo(user.ID, "Leech memo")
+		require.NoError(t, err)
+		require.NoError(t, memory.NewMemoStore(backend).Create(ctx, memo))
+
+		card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b","tags":["go"]}`))
+		require.NoError(t, err)
+		require.NoError(t, cards.CreateMultiple(ctx, []*domain.Card{card}))
+		cardStats, err := domain.NewUserCardStats(user.ID, card.ID)
+		require.NoError(t, err)
+		require.NoError(t, stats.Create(ctx, cardStats))
+
+		service, err := card_review.NewCardReviewService(
+			cards, stats, memory.NewReviewLogStore(backend), users, srsService,
+			slog.New(slog.NewTextHandler(io.Discard, nil)), opts...,
+		)
+		require.NoError(t, err)
+		return fixture{service: service, cards: cards, stats: stats, user: user, card: card}
+	}
+
+	// lapse answers the card "again" n times, alternating between single and batch answers
+	lapse := func(t *testing.T, f fixture, n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			var err error
+			if i%2 == 0 {
+				_, err = f.service.SubmitAnswer(ctx, f.user.ID, f.card.ID,
+					card_review.ReviewAnswer{Outcome: domain.ReviewOutcomeAgain})
+			} else {
+				_, err = f.service.SubmitAnswers(ctx, f.user.ID, []card_review.CardAnswer{
+					{CardID: f.card.ID, Outcome: domain.ReviewOutcomeAgain},
+				})
+			}
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("tags the card when lapses reach the threshold", func(t *testing.T) {
+		f := setup(t, card_review.WithLeechDetection(threshold, false))
+
+		lapse(t, f, threshold-1)
+		card, err := f.cards.GetByID(ctx, f.card.ID)
+		require.NoError(t, err)
+		assert.False(t, card.HasTag(domain.LeechTag), "The card is not a leech below the threshold")
+
+		lapse(t, f, 1)
+		stats, err := f.stats.Get(ctx, f.user.ID, f.card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, threshold, stats.Lapses)
+
+		card, err = f.cards.GetByID(ctx, f.card.ID)
+		require.NoError(t, err)
+		assert.True(t, card.HasTag(domain.LeechTag))
+		assert.True(t, card.HasTag("go"), "Existing tags are kept")
+		assert.Equal(t, domain.InitialCardVersion+1, card.Version, "Tagging is a content edit")
+		assert.False(t, card.IsSuspended(), "Leeches are only suspended when configured")
+
+		lapse(t, f, 2)
+		card, err = f.cards.GetByID(ctx, f.card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InitialCardVersion+1, card.Version, "Later lapses leave the card alone")
+
+		leeches, err := f.cards.ListByTag(ctx, f.user.ID, domain.LeechTag, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, leeches, 1)
+		assert.Equal(t, f.card.ID, leeches[0].ID)
+	})
+
+	t.Run("suspends the card when configured", func(t *testing.T) {
+		f := setup(t, card_review.WithLeechDetection(threshold, true))
+
+		lapse(t, f, threshold)
+
+		card, err := f.cards.GetByID(ctx, f.card.ID)
+		require.NoError(t, err)
+		assert.True(t, card.HasTag(domain.LeechTag))
+		assert.True(t, card.IsSuspended())
+
+		count, err := f.stats.CountDue(ctx, f.user.ID, time.Now().Add(24*time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, count, "A suspended leech is no longer due")
+	})
+
+	t.Run("is disabled without the option", func(t *testing.T) {
+		f := setup(t)
+
+		lapse(t, f, threshold+1)
+
+		stats, err := f.stats.Get(ctx, f.user.ID, f.card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, threshold+1, stats.Lapses, "Lapses are counted regardless")
+		card, err := f.cards.GetByID(ctx, f.card.ID)
+		require.NoError(t, err)
+		assert.False(t, card.HasTag(domain.LeechTag))
+		assert.False(t, card.IsSuspended())
+	})
+}
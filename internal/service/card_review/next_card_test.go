@@ -0,0 +1,79 @@
+package card_review_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/domain/srs"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestGetNextCardExcluding serves due cards from the in-memory stores while
+// excluding cards already seen in the session
+func TestGetNextCardExcluding(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	users := memory.NewUserStore(backend, bcrypt.MinCost)
+	cards := memory.NewCardStore(backend)
+	stats := memory.NewUserCardStatsStore(backend)
+	srsService, err := srs.NewDefaultService()
+	require.NoError(t, err)
+
+	user, err := domain.NewUser("next-card@example.com", "next-card-password")
+	require.NoError(t, err)
+	require.NoError(t, users.Create(ctx, user))
+	memo, err := domain.NewMemo(user.ID, "Next card memo")
+	require.NoError(t, err)
+	require.NoError(t, memory.NewMemoStore(backend).Create(ctx, memo))
+
+	// Two reviewed cards are due, the first more overdue than the second
+	newDueCard := func(overdue time.Duration) *domain.Card {
+		card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+		require.NoError(t, err)
+		require.NoError(t, cards.CreateMultiple(ctx, []*domain.Card{card}))
+		cardStats, err := domain.NewUserCardStats(user.ID, card.ID)
+		require.NoError(t, err)
+		cardStats.ReviewCount = 1
+		cardStats.NextReviewAt = time.Now().UTC().Add(-overdue)
+		require.NoError(t, stats.Create(ctx, cardStats))
+		return card
+	}
+	top := newDueCard(2 * time.Hour)
+	next := newDueCard(time.Hour)
+
+	service, err := card_review.NewCardReviewService(
+		cards, stats, memory.NewReviewLogStore(backend), users, srsService,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	require.NoError(t, err)
+
+	card, err := service.GetNextCard(ctx, user.ID, nil, domain.ReviewOrderDueDate)
+	require.NoError(t, err)
+	assert.Equal(t, top.ID, card.ID)
+
+	card, err = service.GetNextCardExcluding(ctx, user.ID, nil, domain.ReviewOrderDueDate, []uuid.UUID{top.ID})
+	require.NoError(t, err)
+	assert.Equal(t, next.ID, card.ID, "The excluded top due card is not served again")
+
+	_, err = service.GetNextCardExcluding(ctx, user.ID, nil, domain.ReviewOrderDueDate, []uuid.UUID{top.ID, next.ID})
+	assert.ErrorIs(t, err, card_review.ErrNoCardsDue)
+
+	tooMany := make([]uuid.UUID, card_review.MaxExcludedCards+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+	_, err = service.GetNextCardExcluding(ctx, user.ID, nil, domain.ReviewOrderDueDate, tooMany)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
@@ -14,6 +14,18 @@ type ReviewAnswer struct {
 	Outcome domain.ReviewOutcome `json:"outcome"` // The outcome selected by the user
 }
 
+// CardAnswer is a user's answer to one card in a batch of answers.
+type CardAnswer struct {
+	CardID  uuid.UUID
+	Outcome domain.ReviewOutcome
+}
+
+// MaxBatchAnswers is the largest number of answers SubmitAnswers accepts at once.
+const MaxBatchAnswers = 100
+
+// MaxExcludedCards is the largest number of cards GetNextCardExcluding may skip.
+const MaxExcludedCards = 100
+
 // CardReviewService provides methods for reviewing flashcards
 // using a spaced repetition algorithm.
 type CardReviewService interface {
@@ -24,18 +36,47 @@ type CardReviewService interface {
 	// Parameters:
 	//   - ctx: Context for the operation, which can include correlation ID and cancellation
 	//   - userID: UUID of the user requesting the next card
+	//   - deckID: Optional deck to study; when not nil only cards in that deck are considered
+	//   - order: Which due card to serve first among reviews, and among new cards;
+	//     empty means the deck's review order, or domain.DefaultReviewOrder
 	//
 	// Returns:
 	//   - (*domain.Card, nil): The next card due for review if one exists
-	//   - (nil, ErrNoCardsDue): If the user has no cards due for review
+	//   - (nil, ErrNoCardsDue): If the user has no cards due for review, or only new
+	//     cards are due and the user's daily new-card limit has been reached
 	//   - (nil, error): Any other error, typically from the database or validation
 	//
 	// Error Handling:
 	//   - Returns ErrNoCardsDue when the user has no cards due for review
+	//   - Returns a validation error wrapping domain.ErrInvalidReviewOrder for an unknown order
 	//   - Database errors are logged and wrapped with appropriate service-level errors
 	//
-	// This method is a thin wrapper around the store layer and does not modify any data.
-	GetNextCard(ctx context.Context, userID uuid.UUID) (*domain.Card, error)
+	// Due reviews are served before new cards, and new cards are only served while
+	// the user's NewCardsPerDay allowance for the current day (in their timezone)
+	// has not been used up. The allowance is shared across decks. Deck ownership is
+	// not checked here; a deck the user does not own simply has no cards for them.
+	// This method does not modify any data.
+	GetNextCard(
+		ctx context.Context,
+		userID uuid.UUID,
+		deckID *uuid.UUID,
+		order domain.ReviewOrder,
+	) (*domain.Card, error)
+
+	// GetNextCardExcluding retrieves the next card like GetNextCard, skipping the
+	// cards whose IDs are in exclude. Clients pass the cards already served in the
+	// current review session, so that a card whose answer failed to submit is not
+	// served again straight away.
+	//
+	// Returns a validation error wrapping domain.ErrValidation if exclude lists
+	// more than MaxExcludedCards cards, and ErrNoCardsDue if every due card is excluded.
+	GetNextCardExcluding(
+		ctx context.Context,
+		userID uuid.UUID,
+		deckID *uuid.UUID,
+		order domain.ReviewOrder,
+		exclude []uuid.UUID,
+	) (*domain.Card, error)
 
 	// SubmitAnswer processes a user's answer for a flashcard and updates the
 	// review schedule based on the spaced repetition algorithm.
@@ -71,6 +112,34 @@ type CardReviewService interface {
 		cardID uuid.UUID,
 		answer ReviewAnswer,
 	) (*domain.UserCardStats, error)
+
+	// SubmitAnswers applies several answers, each to a different card, in a single
+	// transaction, as SubmitAnswer does for one answer. It is meant for clients that
+	// queue answers during a review session and send them together.
+	//
+	// Returns:
+	//   - ([]*domain.UserCardStats, nil): The updated statistics, in the order of answers
+	//   - (nil, ErrInvalidAnswer): If answers is empty, has more than MaxBatchAnswers
+	//     entries, answers a card more than once, or has an invalid outcome
+	//   - (nil, ErrCardNotFound): If any card does not exist
+	//   - (nil, ErrCardNotOwned): If the user does not own any of the cards
+	//
+	// The batch is all-or-nothing: if any answer is rejected, none is applied.
+	SubmitAnswers(
+		ctx context.Context,
+		userID uuid.UUID,
+		answers []CardAnswer,
+	) ([]*domain.UserCardStats, error)
+
+	// GetCardHistory returns every recorded review of a card, oldest first, with
+	// the card's interval before and after each review where it was recorded.
+	//
+	// Returns:
+	//   - ([]*domain.ReviewEvent, nil): The reviews; empty if the card has never
+	//     been reviewed, or if the service does not record review events
+	//   - (nil, ErrCardNotFound): If the card does not exist
+	//   - (nil, ErrCardNotOwned): If the user does not own the card
+	GetCardHistory(ctx context.Context, userID, cardID uuid.UUID) ([]*domain.ReviewEvent, error)
 }
 
 // Common error types for CardReviewService
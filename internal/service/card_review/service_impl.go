@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -11,7 +12,9 @@ import (
 	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/domain/srs"
 	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/platform/tracing"
 	"github.com/phrazzld/scry-api/internal/store"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Verify interface compliance at compile time
@@ -19,10 +22,49 @@ var _ CardReviewService = (*cardReviewServiceImpl)(nil)
 
 // cardReviewServiceImpl implements the CardReviewService interface.
 type cardReviewServiceImpl struct {
-	cardStore  store.CardStore
-	statsStore store.UserCardStatsStore
-	srsService srs.Service
-	logger     *slog.Logger
+	cardStore      store.CardStore
+	statsStore     store.UserCardStatsStore
+	reviewLogStore store.ReviewLogStore
+	userStore      store.UserStore
+	srsService     srs.Service
+	deckStore      store.DeckStore
+	eventStore     store.ReviewEventStore
+	leechThreshold int
+	leechSuspend   bool
+	logger         *slog.Logger
+}
+
+// CardReviewServiceOption configures optional behaviour of the card review service
+type CardReviewServiceOption func(*cardReviewServiceImpl)
+
+// WithDeckSettings makes the service honour each deck's SRS overrides: a deck's
+// new-card limit and review order while reviewing that deck, and its scheduling
+// overrides when answering its cards. Without it every card uses the user's and the SRS
+// service's defaults.
+func WithDeckSettings(deckStore store.DeckStore) CardReviewServiceOption {
+	return func(s *cardReviewServiceImpl) {
+		s.deckStore = deckStore
+	}
+}
+
+// WithReviewEvents records a review event for every submitted answer in
+// eventStore, within the same transaction as the schedule update, so the
+// review history reflects exactly the answers that were applied.
+func WithReviewEvents(eventStore store.ReviewEventStore) CardReviewServiceOption {
+	return func(s *cardReviewServiceImpl) {
+		s.eventStore = eventStore
+	}
+}
+
+// WithLeechDetection tags a card domain.LeechTag when an answer brings its lapse
+// count to threshold, and also suspends it if suspend is set. The card is changed
+// within the same transaction as the schedule update. A threshold of 0 or less
+// leaves leech detection disabled, as it is without this option.
+func WithLeechDetection(threshold int, suspend bool) CardReviewServiceOption {
+	return func(s *cardReviewServiceImpl) {
+		s.leechThreshold = threshold
+		s.leechSuspend = suspend
+	}
 }
 
 // NewCardReviewService creates a new CardReviewService implementation.
@@ -30,8 +72,11 @@ type cardReviewServiceImpl struct {
 func NewCardReviewService(
 	cardStore store.CardStore,
 	statsStore store.UserCardStatsStore,
+	reviewLogStore store.ReviewLogStore,
+	userStore store.UserStore,
 	srsService srs.Service,
 	logger *slog.Logger,
+	opts ...CardReviewServiceOption,
 ) (CardReviewService, error) {
 	// Validate inputs
 	if cardStore == nil {
@@ -40,6 +85,16 @@ func NewCardReviewService(
 	if statsStore == nil {
 		return nil, domain.NewValidationError("statsStore", "cannot be nil", domain.ErrValidation)
 	}
+	if reviewLogStore == nil {
+		return nil, domain.NewValidationError(
+			"reviewLogStore",
+			"cannot be nil",
+			domain.ErrValidation,
+		)
+	}
+	if userStore == nil {
+		return nil, domain.NewValidationError("userStore", "cannot be nil", domain.ErrValidation)
+	}
 	if srsService == nil {
 		return nil, domain.NewValidationError("srsService", "cannot be nil", domain.ErrValidation)
 	}
@@ -49,46 +104,247 @@ func NewCardReviewService(
 		logger = slog.Default()
 	}
 
-	return &cardReviewServiceImpl{
-		cardStore:  cardStore,
-		statsStore: statsStore,
-		srsService: srsService,
-		logger:     logger.With(slog.String("component", "card_review_service")),
-	}, nil
+	service := &cardReviewServiceImpl{
+		cardStore:      cardStore,
+		statsStore:     statsStore,
+		reviewLogStore: reviewLogStore,
+		userStore:      userStore,
+		srsService:     srsService,
+		logger:         logger.With(slog.String("component", "card_review_service")),
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service, nil
 }
 
 // GetNextCard implements CardReviewService.GetNextCard.
 // It retrieves the next card due for review for a user.
+//
+// Cards that have been reviewed before are always served first. Once none of
+// those are due, never-reviewed cards are introduced until the user's daily
+// new-card limit is reached, counted per calendar day in the user's timezone.
+// When reviewing a deck that overrides the limit, the deck's limit applies instead.
+// The order decides which card is served among the due reviews, or among the new cards;
+// when none is given, the order of the deck being reviewed applies.
 func (s *cardReviewServiceImpl) GetNextCard(
 	ctx context.Context,
 	userID uuid.UUID,
+	deckID *uuid.UUID,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	return s.GetNextCardExcluding(ctx, userID, deckID, order, nil)
+}
+
+// GetNextCardExcluding implements CardReviewService.GetNextCardExcluding.
+// It serves cards like GetNextCard, skipping the excluded cards.
+func (s *cardReviewServiceImpl) GetNextCardExcluding(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
 ) (*domain.Card, error) {
 	// Get logger from context or use default
 	log := logger.FromContextOrDefault(ctx, s.logger)
 
-	log.Debug("retrieving next review card", slog.String("user_id", userID.String()))
+	if order != "" && !order.IsValid() {
+		return nil, domain.NewValidationError("order", "unknown review order", domain.ErrInvalidReviewOrder)
+	}
+	if len(exclude) > MaxExcludedCards {
+		return nil, domain.NewValidationError("exclude",
+			fmt.Sprintf("must list at most %d card IDs", MaxExcludedCards), domain.ErrValidation)
+	}
 
-	// Call the store to get the next card due for review
-	card, err := s.cardStore.GetNextReviewCard(ctx, userID)
+	deck, err := s.reviewDeck(ctx, userID, deckID)
+	if errors.Is(err, store.ErrDeckNotFound) {
+		log.Debug("deck not found for review",
+			slog.String("user_id", userID.String()),
+			slog.String("deck_id", deckID.String()))
+		return nil, NewGetNextCardError("deck not found", err)
+	}
 	if err != nil {
-		// Map "card not found" errors to service.ErrNoCardsDue
-		if errors.Is(err, store.ErrCardNotFound) || errors.Is(err, store.ErrNotFound) {
+		log.Error("failed to get deck settings",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, NewGetNextCardError("failed to get deck settings", err)
+	}
+	if order == "" {
+		order = domain.ResolveReviewSettings(nil, deck).Order
+	}
+
+	log.Debug("retrieving next review card",
+		slog.String("user_id", userID.String()),
+		slog.String("order", string(order)))
+
+	// Due reviews take priority over new cards
+	card, err := s.nextDueCard(ctx, userID, deckID, false, order, exclude)
+	if err == nil {
+		log.Debug("successfully retrieved next review card",
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", card.ID.String()))
+		return card, nil
+	}
+	if !isCardNotFound(err) {
+		log.Error("failed to get next review card",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, NewGetNextCardError("database error", err)
+	}
+
+	// No reviews are due, so introduce a new card if today's allowance permits
+	remaining, err := s.remainingNewCards(ctx, userID, deck, time.Now())
+	if err != nil {
+		log.Error("failed to check new card allowance",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, NewGetNextCardError("failed to check new card allowance", err)
+	}
+	if remaining <= 0 {
+		log.Debug("daily new card limit reached", slog.String("user_id", userID.String()))
+		return nil, ErrNoCardsDue
+	}
+
+	card, err = s.nextDueCard(ctx, userID, deckID, true, order, exclude)
+	if err != nil {
+		if isCardNotFound(err) {
 			log.Debug("no cards due for review", slog.String("user_id", userID.String()))
 			return nil, ErrNoCardsDue
 		}
 
-		log.Error("failed to get next review card",
+		log.Error("failed to get next new card",
 			slog.String("error", err.Error()),
 			slog.String("user_id", userID.String()))
 		return nil, NewGetNextCardError("database error", err)
 	}
 
-	log.Debug("successfully retrieved next review card",
+	log.Debug("successfully retrieved next new card",
 		slog.String("user_id", userID.String()),
-		slog.String("card_id", card.ID.String()))
+		slog.String("card_id", card.ID.String()),
+		slog.Int("remaining_new_cards", remaining-1))
 	return card, nil
 }
 
+// nextDueCard gets the next due card from the card store, skipping the excluded
+// cards if there are any.
+func (s *cardReviewServiceImpl) nextDueCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
+) (*domain.Card, error) {
+	if len(exclude) == 0 {
+		return s.cardStore.GetNextDueCard(ctx, userID, deckID, newCards, order)
+	}
+	return s.cardStore.GetNextDueCardExcluding(ctx, userID, deckID, newCards, order, exclude)
+}
+
+// reviewDeck returns the deck being reviewed, whose settings override the
+// user's, or nil when no deck is reviewed or deck settings are disabled.
+// Returns store.ErrDeckNotFound if the deck does not exist or belongs to
+// another user.
+func (s *cardReviewServiceImpl) reviewDeck(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+) (*domain.Deck, error) {
+	if deckID == nil || s.deckStore == nil {
+		return nil, nil
+	}
+	deck, err := s.deckStore.GetByID(ctx, *deckID)
+	if err != nil {
+		return nil, err
+	}
+	if deck.UserID != userID {
+		// Another user's deck is reported as missing, so its existence is not revealed
+		return nil, store.ErrDeckNotFound
+	}
+	return deck, nil
+}
+
+// remainingNewCards returns how many more new cards the user may be introduced to
+// on the calendar day containing now, in the user's timezone.
+// The limit is the deck's override when deck has one, and the user's otherwise.
+func (s *cardReviewServiceImpl) remainingNewCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	deck *domain.Deck,
+	now time.Time,
+) (int, error) {
+	user, err := s.userStore.GetByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	limit := domain.ResolveReviewSettings(user, deck).NewCardsPerDay
+
+	introduced, err := s.reviewLogStore.CountNewCards(ctx, userID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return limit - introduced, nil
+}
+
+// schedulerFor returns the SRS service to schedule card with, applying the
+// overrides of the card's deck when deck settings are enabled.
+func (s *cardReviewServiceImpl) schedulerFor(
+	ctx context.Context,
+	tx *sql.Tx,
+	card *domain.Card,
+) (srs.Service, error) {
+	if card.DeckID == nil || s.deckStore == nil {
+		return s.srsService, nil
+	}
+
+	deck, err := s.deckStore.WithTx(tx).GetByID(ctx, *card.DeckID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := srs.Overrides{}
+	if deck.SRSSettings.AgainReviewMinutes != nil {
+		overrides.AgainReviewMinutes = *deck.SRSSettings.AgainReviewMinutes
+	}
+	if deck.SRSSettings.InitialEaseFactor != nil {
+		overrides.InitialEaseFactor = *deck.SRSSettings.InitialEaseFactor
+	}
+	overrides.LearningSteps = deck.SRSSettings.LearningSteps
+	return s.srsService.WithOverrides(overrides), nil
+}
+
+// newCardStats returns stats for a card the user has none for, starting from the
+// new card settings resolved for the user and the card's deck, as card creation does
+func (s *cardReviewServiceImpl) newCardStats(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID uuid.UUID,
+	card *domain.Card,
+	now time.Time,
+) (*domain.UserCardStats, error) {
+	user, err := s.userStore.WithTx(tx).GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var deck *domain.Deck
+	if card.DeckID != nil && s.deckStore != nil {
+		deck, err = s.deckStore.WithTx(tx).GetByID(ctx, *card.DeckID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deck: %w", err)
+		}
+	}
+
+	return domain.NewUserCardStatsWithSettings(userID, card.ID, domain.ResolveNewCardSettings(user, deck), now)
+}
+
+// isCardNotFound reports whether err means that no matching card exists.
+func isCardNotFound(err error) bool {
+	return errors.Is(err, store.ErrCardNotFound) || errors.Is(err, store.ErrNotFound)
+}
+
 // SubmitAnswer implements CardReviewService.SubmitAnswer.
 // It processes a user's answer to a flashcard and updates the review schedule.
 //
@@ -102,7 +358,12 @@ func (s *cardReviewServiceImpl) SubmitAnswer(
 	userID uuid.UUID,
 	cardID uuid.UUID,
 	answer ReviewAnswer,
-) (*domain.UserCardStats, error) {
+) (_ *domain.UserCardStats, err error) {
+	ctx, span := tracing.Start(ctx, "CardReviewService.SubmitAnswer",
+		attribute.String("card_id", cardID.String()),
+		attribute.String("outcome", string(answer.Outcome)))
+	defer func() { tracing.End(span, err) }()
+
 	// Get logger from context or use default
 	log := logger.FromContextOrDefault(ctx, s.logger)
 
@@ -124,7 +385,7 @@ func (s *cardReviewServiceImpl) SubmitAnswer(
 	var updatedStats *domain.UserCardStats
 
 	// Use the standard store.RunInTransaction helper for consistent transaction handling
-	err := store.RunInTransaction(
+	err = store.RunInTransaction(
 		ctx,
 		s.cardStore.DB(),
 		func(ctx context.Context, tx *sql.Tx) error {
@@ -153,52 +414,34 @@ func (s *cardReviewServiceImpl) SubmitAnswer(
 				return ErrCardNotOwned
 			}
 
-			// Get the current stats with a row-level lock to prevent concurrent updates
-			stats, err := txStatsStore.GetForUpdate(ctx, userID, cardID)
-			if err != nil {
-				if errors.Is(err, store.ErrUserCardStatsNotFound) {
-					log.Warn("stats not found for card",
-						slog.String("user_id", userID.String()),
-						slog.String("card_id", cardID.String()))
-					// Create new stats with default values
-					stats, err = domain.NewUserCardStats(userID, cardID)
-					if err != nil {
-						return NewSubmitAnswerError("failed to create new stats", err)
-					}
-				} else {
-					return NewSubmitAnswerError("failed to retrieve stats", err)
-				}
-			}
-
-			// Calculate new review schedule using SRS algorithm
-			newStats, err := s.srsService.CalculateNextReview(
-				stats,
-				answer.Outcome,
-				time.Now().UTC(),
-			)
+			// Schedule the answer with the stats locked against concurrent updates
+			reviewedAt := time.Now().UTC()
+			stats, newStats, found, err := s.scheduleAnswer(ctx, tx, userID, card, answer.Outcome, reviewedAt)
 			if err != nil {
-				log.Error("failed to calculate next review",
-					slog.String("error", err.Error()),
-					slog.String("user_id", userID.String()),
-					slog.String("card_id", cardID.String()))
-				return NewSubmitAnswerError("failed to calculate next review", err)
+				return err
 			}
 
 			// Save or update the stats
-			if stats.LastReviewedAt.IsZero() {
-				// This is a new card that hasn't been reviewed yet
+			if !found {
+				// This is a card without stats yet
 				err = txStatsStore.Create(ctx, newStats)
 				if err != nil {
 					return NewSubmitAnswerError("failed to create stats record", err)
 				}
 			} else {
-				// This is an existing card that has been reviewed before
 				err = txStatsStore.Update(ctx, newStats)
 				if err != nil {
 					return NewSubmitAnswerError("failed to update stats record", err)
 				}
 			}
 
+			if err := s.recordAnswer(ctx, tx, userID, cardID, stats, newStats, answer.Outcome, reviewedAt); err != nil {
+				return err
+			}
+			if err := s.markLeech(ctx, tx, card, stats, newStats, reviewedAt); err != nil {
+				return err
+			}
+
 			// Store the updated stats for the return value
 			updatedStats = newStats
 			return nil
@@ -231,6 +474,299 @@ func (s *cardReviewServiceImpl) SubmitAnswer(
 	return updatedStats, nil
 }
 
+// SubmitAnswers implements CardReviewService.SubmitAnswers.
+// Every card is checked before anything is written, and the stats of cards that
+// already have them are written with a single UserCardStatsStore.UpdateMultiple
+// call rather than one update per answer.
+func (s *cardReviewServiceImpl) SubmitAnswers(
+	ctx context.Context,
+	userID uuid.UUID,
+	answers []CardAnswer,
+) (_ []*domain.UserCardStats, err error) {
+	ctx, span := tracing.Start(ctx, "CardReviewService.SubmitAnswers",
+		attribute.Int("answer_count", len(answers)))
+	defer func() { tracing.End(span, err) }()
+
+	// Get logger from context or use default
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	log.Debug("processing review answers",
+		slog.String("user_id", userID.String()),
+		slog.Int("answer_count", len(answers)))
+
+	if len(answers) == 0 || len(answers) > MaxBatchAnswers {
+		log.Warn("invalid number of review answers",
+			slog.String("user_id", userID.String()),
+			slog.Int("answer_count", len(answers)))
+		return nil, ErrInvalidAnswer
+	}
+
+	cardIDs := make([]uuid.UUID, len(answers))
+	seen := make(map[uuid.UUID]struct{}, len(answers))
+	for i, answer := range answers {
+		if !isValidOutcome(answer.Outcome) {
+			log.Warn("invalid review outcome",
+				slog.String("user_id", userID.String()),
+				slog.String("card_id", answer.CardID.String()),
+				slog.String("outcome", string(answer.Outcome)))
+			return nil, ErrInvalidAnswer
+		}
+		if _, dup := seen[answer.CardID]; dup {
+			log.Warn("card answered more than once in a batch",
+				slog.String("user_id", userID.String()),
+				slog.String("card_id", answer.CardID.String()))
+			return nil, ErrInvalidAnswer
+		}
+		seen[answer.CardID] = struct{}{}
+		cardIDs[i] = answer.CardID
+	}
+
+	results := make([]*domain.UserCardStats, len(answers))
+	err = store.RunInTransaction(
+		ctx,
+		s.cardStore.DB(),
+		func(ctx context.Context, tx *sql.Tx) error {
+			txStatsStore := s.statsStore.WithTx(tx)
+
+			cards, err := s.cardStore.WithTx(tx).GetByIDs(ctx, cardIDs)
+			if err != nil {
+				return NewSubmitAnswerError("failed to retrieve cards", err)
+			}
+
+			// Reject the whole batch before writing anything
+			for _, cardID := range cardIDs {
+				card, ok := cards[cardID]
+				if !ok {
+					log.Warn("card not found for review",
+						slog.String("user_id", userID.String()),
+						slog.String("card_id", cardID.String()))
+					return ErrCardNotFound
+				}
+				if card.UserID != userID {
+					log.Warn("user does not own card",
+						slog.String("user_id", userID.String()),
+						slog.String("card_id", cardID.String()),
+						slog.String("owner_id", card.UserID.String()))
+					return ErrCardNotOwned
+				}
+			}
+
+			reviewedAt := time.Now().UTC()
+			var updates []*domain.UserCardStats
+			for i, answer := range answers {
+				card := cards[answer.CardID]
+				stats, newStats, found, err := s.scheduleAnswer(ctx, tx, userID, card, answer.Outcome, reviewedAt)
+				if err != nil {
+					return err
+				}
+
+				if found {
+					updates = append(updates, newStats)
+				} else if err := txStatsStore.Create(ctx, newStats); err != nil {
+					return NewSubmitAnswerError("failed to create stats record", err)
+				}
+
+				err = s.recordAnswer(ctx, tx, userID, answer.CardID, stats, newStats, answer.Outcome, reviewedAt)
+				if err != nil {
+					return err
+				}
+				if err := s.markLeech(ctx, tx, card, stats, newStats, reviewedAt); err != nil {
+					return err
+				}
+				results[i] = newStats
+			}
+
+			if err := txStatsStore.UpdateMultiple(ctx, updates); err != nil {
+				return NewSubmitAnswerError("failed to update stats records", err)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.Is(err, ErrCardNotFound) || errors.Is(err, ErrCardNotOwned) {
+			return nil, err
+		}
+
+		log.Error("failed to submit answers",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()),
+			slog.Int("answer_count", len(answers)))
+		return nil, err
+	}
+
+	log.Debug("successfully processed review answers",
+		slog.String("user_id", userID.String()),
+		slog.Int("answer_count", len(answers)))
+
+	return results, nil
+}
+
+// GetCardHistory implements CardReviewService.GetCardHistory.
+func (s *cardReviewServiceImpl) GetCardHistory(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) ([]*domain.ReviewEvent, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	card, err := s.cardStore.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, store.ErrCardNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	if card.UserID != userID {
+		log.Warn("user does not own card",
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", cardID.String()),
+			slog.String("owner_id", card.UserID.String()))
+		return nil, ErrCardNotOwned
+	}
+
+	if s.eventStore == nil {
+		return []*domain.ReviewEvent{}, nil
+	}
+	events, err := s.eventStore.ListByCard(ctx, cardID)
+	if err != nil {
+		log.Error("failed to list review events",
+			slog.String("error", err.Error()),
+			slog.String("card_id", cardID.String()))
+		return nil, err
+	}
+	return events, nil
+}
+
+// scheduleAnswer locks the user's stats for card and calculates the schedule that
+// follows outcome, using the card's deck settings if any. It returns the stats
+// before and after the answer, and whether the stats already existed; if they did
+// not, the stats before the answer start from the user's and deck's new card
+// settings, and have not been saved.
+func (s *cardReviewServiceImpl) scheduleAnswer(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID uuid.UUID,
+	card *domain.Card,
+	outcome domain.ReviewOutcome,
+	reviewedAt time.Time,
+) (stats, newStats *domain.UserCardStats, found bool, err error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	// Get the current stats with a row-level lock to prevent concurrent updates
+	stats, err = s.statsStore.WithTx(tx).GetForUpdate(ctx, userID, card.ID)
+	switch {
+	case err == nil:
+		found = true
+	case errors.Is(err, store.ErrUserCardStatsNotFound):
+		log.Warn("stats not found for card",
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", card.ID.String()))
+		// Create new stats from the user's and deck's new card settings
+		stats, err = s.newCardStats(ctx, tx, userID, card, reviewedAt)
+		if err != nil {
+			return nil, nil, false, NewSubmitAnswerError("failed to create new stats", err)
+		}
+	default:
+		return nil, nil, false, NewSubmitAnswerError("failed to retrieve stats", err)
+	}
+
+	// Schedule with the card's deck settings, if any
+	scheduler, err := s.schedulerFor(ctx, tx, card)
+	if err != nil {
+		return nil, nil, false, NewSubmitAnswerError("failed to load deck settings", err)
+	}
+
+	// Calculate new review schedule using SRS algorithm
+	newStats, err = scheduler.CalculateNextReview(stats, outcome, reviewedAt)
+	if err != nil {
+		log.Error("failed to calculate next review",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", card.ID.String()))
+		return nil, nil, false, NewSubmitAnswerError("failed to calculate next review", err)
+	}
+	return stats, newStats, found, nil
+}
+
+// recordAnswer records the review day for streak tracking and appends the answer
+// to the card's review history. previous and updated are the card's stats before
+// and after the answer.
+func (s *cardReviewServiceImpl) recordAnswer(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID, cardID uuid.UUID,
+	previous, updated *domain.UserCardStats,
+	outcome domain.ReviewOutcome,
+	reviewedAt time.Time,
+) error {
+	// A card's first review also counts towards the daily new-card limit
+	var err error
+	if previous.ReviewCount == 0 {
+		err = s.reviewLogStore.WithTx(tx).RecordNewCard(ctx, userID, reviewedAt)
+	} else {
+		err = s.reviewLogStore.WithTx(tx).RecordReview(ctx, userID, reviewedAt)
+	}
+	if err != nil {
+		return NewSubmitAnswerError("failed to record review day", err)
+	}
+
+	// Append the answer to the card's review history
+	if s.eventStore != nil {
+		event, err := domain.NewReviewEvent(userID, cardID, outcome, reviewedAt)
+		if err != nil {
+			return NewSubmitAnswerError("failed to create review event", err)
+		}
+		event.SetIntervals(previous.Interval, updated.Interval)
+		if err := s.eventStore.WithTx(tx).Create(ctx, event); err != nil {
+			return NewSubmitAnswerError("failed to record review event", err)
+		}
+	}
+	return nil
+}
+
+// markLeech tags card as a leech, and suspends it if configured, when the answer
+// that turned previous into updated brought its lapse count to the leech threshold.
+// Later lapses leave the card alone, so a user who removes the tag or unsuspends
+// the card is not overruled on every answer.
+func (s *cardReviewServiceImpl) markLeech(
+	ctx context.Context,
+	tx *sql.Tx,
+	card *domain.Card,
+	previous, updated *domain.UserCardStats,
+	reviewedAt time.Time,
+) error {
+	if s.leechThreshold <= 0 || previous.Lapses >= s.leechThreshold || updated.Lapses < s.leechThreshold {
+		return nil
+	}
+
+	txCardStore := s.cardStore.WithTx(tx)
+	added, err := card.AddTag(domain.LeechTag)
+	if err != nil {
+		return NewSubmitAnswerError("failed to tag leech", err)
+	}
+	if added {
+		version, err := txCardStore.UpdateContent(ctx, card.ID, card.Content, card.Version)
+		if err != nil {
+			return NewSubmitAnswerError("failed to tag leech", err)
+		}
+		card.Version = version
+	}
+
+	if s.leechSuspend && !card.IsSuspended() {
+		if err := txCardStore.SetSuspended(ctx, card.ID, &reviewedAt); err != nil {
+			return NewSubmitAnswerError("failed to suspend leech", err)
+		}
+		card.SuspendedAt = &reviewedAt
+	}
+
+	logger.FromContextOrDefault(ctx, s.logger).Info("card detected as leech",
+		slog.String("user_id", card.UserID.String()),
+		slog.String("card_id", card.ID.String()),
+		slog.Int("lapses", updated.Lapses),
+		slog.Bool("suspended", card.IsSuspended()))
+	return nil
+}
+
 // isValidOutcome checks if the given outcome is valid
 func isValidOutcome(outcome domain.ReviewOutcome) bool {
 	switch outcome {
@@ -0,0 +1,91 @@
+package card_review_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetNextCard_WithFunctionFieldMocks shows the service being tested without
+// a database using the shared function-field store mocks: each test sets only
+// the store methods it expects to be called, and the rest keep their defaults.
+func TestGetNextCard_WithFunctionFieldMocks(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "user@example.com", NewCardsPerDay: 2}
+
+	newService := func(t *testing.T, cardStore *mocks.MockCardStore, newCardsToday int) card_review.CardReviewService {
+		t.Helper()
+
+		userStore := mocks.NewMockUserStore()
+		userStore.Users[user.Email] = user
+		reviewLogStore := &MockReviewLogStore{}
+		reviewLogStore.On("CountNewCards", mock.Anything, user.ID, mock.Anything).Return(newCardsToday, nil)
+
+		service, err := card_review.NewCardReviewService(
+			cardStore,
+			mocks.NewMockUserCardStatsStore(),
+			reviewLogStore,
+			userStore,
+			&MockSRSService{},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+		require.NoError(t, err)
+		return service
+	}
+
+	t.Run("due review is served before new cards", func(t *testing.T) {
+		review := createTestCard(user.ID)
+		cardStore := mocks.NewMockCardStore()
+		cardStore.GetNextDueCardFn = func(
+			ctx context.Context,
+			userID uuid.UUID,
+			deckID *uuid.UUID,
+			newCards bool,
+			order domain.ReviewOrder,
+		) (*domain.Card, error) {
+			assert.False(t, newCards, "New cards should not be looked up while reviews are due")
+			return review, nil
+		}
+
+		card, err := newService(t, cardStore, 0).GetNextCard(ctx, user.ID, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, review.ID, card.ID)
+	})
+
+	t.Run("new card is served when no reviews are due", func(t *testing.T) {
+		newCard := createTestCard(user.ID)
+		cardStore := mocks.NewMockCardStore()
+		cardStore.GetNextDueCardFn = func(
+			ctx context.Context,
+			userID uuid.UUID,
+			deckID *uuid.UUID,
+			newCards bool,
+			order domain.ReviewOrder,
+		) (*domain.Card, error) {
+			if newCards {
+				return newCard, nil
+			}
+			// The default: no card found
+			return mocks.NewMockCardStore().GetNextDueCard(ctx, userID, deckID, newCards, order)
+		}
+
+		card, err := newService(t, cardStore, 1).GetNextCard(ctx, user.ID, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, newCard.ID, card.ID)
+	})
+
+	t.Run("nothing is due once the new card limit is reached", func(t *testing.T) {
+		// With no function fields set, the store finds no cards
+		_, err := newService(t, mocks.NewMockCardStore(), 2).GetNextCard(ctx, user.ID, nil, "")
+		assert.ErrorIs(t, err, card_review.ErrNoCardsDue)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/domain/srs"
 	"github.com/phrazzld/scry-api/internal/service/card_review"
 	"github.com/phrazzld/scry-api/internal/store"
 	"github.com/stretchr/testify/assert"
@@ -50,9 +51,14 @@ func (m *MockCardStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Card
 	return args.Get(0).(*domain.Card), args.Error(1)
 }
 
-func (m *MockCardStore) UpdateContent(ctx context.Context, id uuid.UUID, content []byte) error {
-	args := m.Called(ctx, id, content)
-	return args.Error(0)
+func (m *MockCardStore) UpdateContent(
+	ctx context.Context,
+	id uuid.UUID,
+	content []byte,
+	expectedVersion int,
+) (int, error) {
+	args := m.Called(ctx, id, content, expectedVersion)
+	return args.Int(0), args.Error(1)
 }
 
 func (m *MockCardStore) Delete(ctx context.Context, id uuid.UUID) error {
@@ -60,17 +66,163 @@ func (m *MockCardStore) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockCardStore) GetByIDs(
+	ctx context.Context,
+	ids []uuid.UUID,
+) (map[uuid.UUID]*domain.Card, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Card), args.Error(1)
+}
+
 func (m *MockCardStore) GetNextReviewCard(
 	ctx context.Context,
 	userID uuid.UUID,
+	order domain.ReviewOrder,
 ) (*domain.Card, error) {
-	args := m.Called(ctx, userID)
+	args := m.Called(ctx, userID, order)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Card), args.Error(1)
+}
+
+func (m *MockCardStore) GetNextDueCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+) (*domain.Card, error) {
+	args := m.Called(ctx, userID, deckID, newCards, order)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Card), args.Error(1)
 }
 
+func (m *MockCardStore) GetNextDueCardExcluding(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	newCards bool,
+	order domain.ReviewOrder,
+	exclude []uuid.UUID,
+) (*domain.Card, error) {
+	args := m.Called(ctx, userID, deckID, newCards, order, exclude)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Card), args.Error(1)
+}
+
+func (m *MockCardStore) SetDeck(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error {
+	args := m.Called(ctx, id, deckID)
+	return args.Error(0)
+}
+
+func (m *MockCardStore) SetMemo(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error {
+	args := m.Called(ctx, id, memoID)
+	return args.Error(0)
+}
+
+func (m *MockCardStore) SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error {
+	args := m.Called(ctx, id, suspendedAt)
+	return args.Error(0)
+}
+
+func (m *MockCardStore) SetBuriedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error {
+	args := m.Called(ctx, id, until)
+	return args.Error(0)
+}
+
+func (m *MockCardStore) SupersedeByMemo(ctx context.Context, memoID uuid.UUID, at time.Time) (int, error) {
+	args := m.Called(ctx, memoID, at)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCardStore) DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error) {
+	args := m.Called(ctx, memoID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCardStore) ListByDeck(
+	ctx context.Context,
+	deckID uuid.UUID,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	args := m.Called(ctx, deckID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Card), args.Error(1)
+}
+
+func (m *MockCardStore) ListByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	args := m.Called(ctx, userID, tag, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Card), args.Error(1)
+}
+
+func (m *MockCardStore) ExistingFrontHashes(
+	ctx context.Context,
+	userID uuid.UUID,
+	hashes [][32]byte,
+) (map[[32]byte]bool, error) {
+	args := m.Called(ctx, userID, hashes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[[32]byte]bool), args.Error(1)
+}
+
+func (m *MockCardStore) GetRecentlyReviewed(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset int,
+) ([]*domain.ReviewedCard, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReviewedCard), args.Error(1)
+}
+
+func (m *MockCardStore) FindDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*domain.DuplicateCardGroup, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DuplicateCardGroup), args.Error(1)
+}
+
+func (m *MockCardStore) ForEachWithStats(
+	ctx context.Context,
+	userID uuid.UUID,
+	includeSuperseded bool,
+	fn func(*domain.CardWithStats) error,
+) error {
+	args := m.Called(ctx, userID, includeSuperseded, fn)
+	return args.Error(0)
+}
+
+func (m *MockCardStore) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockCardStore) WithTx(tx *sql.Tx) store.CardStore {
 	args := m.Called(tx)
 	return args.Get(0).(store.CardStore)
@@ -123,16 +275,194 @@ func (m *MockUserCardStatsStore) Update(ctx context.Context, stats *domain.UserC
 	return args.Error(0)
 }
 
+func (m *MockUserCardStatsStore) UpdateMultiple(ctx context.Context, stats []*domain.UserCardStats) error {
+	args := m.Called(ctx, stats)
+	return args.Error(0)
+}
+
 func (m *MockUserCardStatsStore) Delete(ctx context.Context, userID, cardID uuid.UUID) error {
 	args := m.Called(ctx, userID, cardID)
 	return args.Error(0)
 }
 
+func (m *MockUserCardStatsStore) CountDue(
+	ctx context.Context,
+	userID uuid.UUID,
+	asOf time.Time,
+) (int, error) {
+	args := m.Called(ctx, userID, asOf)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserCardStatsStore) GetForecast(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	days int,
+) ([]domain.ForecastBucket, error) {
+	args := m.Called(ctx, userID, deckID, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ForecastBucket), args.Error(1)
+}
+
 func (m *MockUserCardStatsStore) WithTx(tx *sql.Tx) store.UserCardStatsStore {
 	args := m.Called(tx)
 	return args.Get(0).(store.UserCardStatsStore)
 }
 
+// MockReviewLogStore is a mock implementation of the store.ReviewLogStore interface
+type MockReviewLogStore struct {
+	mock.Mock
+}
+
+func (m *MockReviewLogStore) RecordReview(
+	ctx context.Context,
+	userID uuid.UUID,
+	reviewedAt time.Time,
+) error {
+	args := m.Called(ctx, userID, reviewedAt)
+	return args.Error(0)
+}
+
+func (m *MockReviewLogStore) RecordNewCard(
+	ctx context.Context,
+	userID uuid.UUID,
+	reviewedAt time.Time,
+) error {
+	args := m.Called(ctx, userID, reviewedAt)
+	return args.Error(0)
+}
+
+func (m *MockReviewLogStore) CountNewCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	at time.Time,
+) (int, error) {
+	args := m.Called(ctx, userID, at)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockReviewLogStore) GetReviewDays(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]time.Time, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *MockReviewLogStore) WithTx(tx *sql.Tx) store.ReviewLogStore {
+	args := m.Called(tx)
+	return args.Get(0).(store.ReviewLogStore)
+}
+
+// MockUserStore is a mock implementation of the store.UserStore interface
+type MockUserStore struct {
+	mock.Mock
+}
+
+func (m *MockUserStore) Create(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserStore) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserStore) List(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error) {
+	args := m.Called(ctx, emailQuery, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserStore) Update(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserStore) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	args := m.Called(ctx, id, oldHash, newHash)
+	return args.Error(0)
+}
+
+func (m *MockUserStore) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserStore) WithTx(tx *sql.Tx) store.UserStore {
+	args := m.Called(tx)
+	return args.Get(0).(store.UserStore)
+}
+
+// MockDeckStore is a mock implementation of the store.DeckStore interface
+type MockDeckStore struct {
+	mock.Mock
+}
+
+func (m *MockDeckStore) Create(ctx context.Context, deck *domain.Deck) error {
+	args := m.Called(ctx, deck)
+	return args.Error(0)
+}
+
+func (m *MockDeckStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.Deck, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Deck), args.Error(1)
+}
+
+func (m *MockDeckStore) GetByName(ctx context.Context, userID uuid.UUID, name string) (*domain.Deck, error) {
+	args := m.Called(ctx, userID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Deck), args.Error(1)
+}
+
+func (m *MockDeckStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Deck, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Deck), args.Error(1)
+}
+
+func (m *MockDeckStore) Update(ctx context.Context, deck *domain.Deck) error {
+	args := m.Called(ctx, deck)
+	return args.Error(0)
+}
+
+func (m *MockDeckStore) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDeckStore) WithTx(tx *sql.Tx) store.DeckStore {
+	args := m.Called(tx)
+	return args.Get(0).(store.DeckStore)
+}
+
 // MockSRSService is a mock implementation of the srs.Service interface
 type MockSRSService struct {
 	mock.Mock
@@ -162,6 +492,14 @@ func (m *MockSRSService) PostponeReview(
 	return args.Get(0).(*domain.UserCardStats), args.Error(1)
 }
 
+func (m *MockSRSService) WithOverrides(overrides srs.Overrides) srs.Service {
+	if overrides.IsZero() {
+		return m
+	}
+	args := m.Called(overrides)
+	return args.Get(0).(srs.Service)
+}
+
 // Helper function to create a test card
 func createTestCard(userID uuid.UUID) *domain.Card {
 	cardID := uuid.New()
@@ -181,6 +519,8 @@ func TestNewCardReviewService(t *testing.T) {
 	// Setup
 	mockCardStore := NewMockCardStore()
 	mockStatsStore := new(MockUserCardStatsStore)
+	mockReviewLogStore := new(MockReviewLogStore)
+	mockUserStore := new(MockUserStore)
 	mockSrsService := new(MockSRSService)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
@@ -189,6 +529,8 @@ func TestNewCardReviewService(t *testing.T) {
 		service, err := card_review.NewCardReviewService(
 			mockCardStore,
 			mockStatsStore,
+			mockReviewLogStore,
+			mockUserStore,
 			mockSrsService,
 			logger,
 		)
@@ -200,6 +542,8 @@ func TestNewCardReviewService(t *testing.T) {
 		service, err := card_review.NewCardReviewService(
 			nil,
 			mockStatsStore,
+			mockReviewLogStore,
+			mockUserStore,
 			mockSrsService,
 			logger,
 		)
@@ -213,7 +557,14 @@ func TestNewCardReviewService(t *testing.T) {
 	})
 
 	t.Run("nil stats store", func(t *testing.T) {
-		service, err := card_review.NewCardReviewService(mockCardStore, nil, mockSrsService, logger)
+		service, err := card_review.NewCardReviewService(
+			mockCardStore,
+			nil,
+			mockReviewLogStore,
+			mockUserStore,
+			mockSrsService,
+			logger,
+		)
 		assert.Error(t, err)
 		assert.Nil(t, service)
 
@@ -223,8 +574,51 @@ func TestNewCardReviewService(t *testing.T) {
 		assert.Equal(t, "cannot be nil", validationErr.Message)
 	})
 
+	t.Run("nil review log store", func(t *testing.T) {
+		service, err := card_review.NewCardReviewService(
+			mockCardStore,
+			mockStatsStore,
+			nil,
+			mockUserStore,
+			mockSrsService,
+			logger,
+		)
+		assert.Error(t, err)
+		assert.Nil(t, service)
+
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "reviewLogStore", validationErr.Field)
+		assert.Equal(t, "cannot be nil", validationErr.Message)
+	})
+
+	t.Run("nil user store", func(t *testing.T) {
+		service, err := card_review.NewCardReviewService(
+			mockCardStore,
+			mockStatsStore,
+			mockReviewLogStore,
+			nil,
+			mockSrsService,
+			logger,
+		)
+		assert.Error(t, err)
+		assert.Nil(t, service)
+
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "userStore", validationErr.Field)
+		assert.Equal(t, "cannot be nil", validationErr.Message)
+	})
+
 	t.Run("nil SRS service", func(t *testing.T) {
-		service, err := card_review.NewCardReviewService(mockCardStore, mockStatsStore, nil, logger)
+		service, err := card_review.NewCardReviewService(
+			mockCardStore,
+			mockStatsStore,
+			mockReviewLogStore,
+			mockUserStore,
+			nil,
+			logger,
+		)
 		assert.Error(t, err)
 		assert.Nil(t, service)
 
@@ -238,6 +632,8 @@ func TestNewCardReviewService(t *testing.T) {
 		service, err := card_review.NewCardReviewService(
 			mockCardStore,
 			mockStatsStore,
+			mockReviewLogStore,
+			mockUserStore,
 			mockSrsService,
 			nil,
 		)
@@ -253,16 +649,32 @@ func TestGetNextCard(t *testing.T) {
 	testCases := []struct {
 		name          string
 		userID        uuid.UUID
-		setupMock     func(*MockCardStore, uuid.UUID)
+		setupMock     func(*MockCardStore, *MockReviewLogStore, *MockUserStore, uuid.UUID)
 		expectedError error
 		checkError    func(*testing.T, error)
 	}{
 		{
-			name:   "happy path - card found",
+			name:   "happy path - review card found",
 			userID: uuid.New(),
-			setupMock: func(store *MockCardStore, userID uuid.UUID) {
+			setupMock: func(store *MockCardStore, _ *MockReviewLogStore, _ *MockUserStore, userID uuid.UUID) {
 				card := createTestCard(userID)
-				store.On("GetNextReviewCard", mock.Anything, userID).Return(card, nil)
+				store.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.DefaultReviewOrder).
+					Return(card, nil)
+			},
+			expectedError: nil,
+			checkError:    nil,
+		},
+		{
+			name:   "new card served when no reviews are due",
+			userID: uuid.New(),
+			setupMock: func(store *MockCardStore, reviewLog *MockReviewLogStore, users *MockUserStore, userID uuid.UUID) {
+				store.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.DefaultReviewOrder).
+					Return(nil, store.ErrCardNotFound)
+				users.On("GetByID", mock.Anything, userID).
+					Return(&domain.User{ID: userID, NewCardsPerDay: 5}, nil)
+				reviewLog.On("CountNewCards", mock.Anything, userID, mock.Anything).Return(4, nil)
+				store.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), true, domain.DefaultReviewOrder).
+					Return(createTestCard(userID), nil)
 			},
 			expectedError: nil,
 			checkError:    nil,
@@ -270,8 +682,13 @@ func TestGetNextCard(t *testing.T) {
 		{
 			name:   "no cards due",
 			userID: uuid.New(),
-			setupMock: func(store *MockCardStore, userID uuid.UUID) {
-				store.On("GetNextReviewCard", mock.Anything, userID).
+			setupMock: func(store *MockCardStore, reviewLog *MockReviewLogStore, users *MockUserStore, userID uuid.UUID) {
+				store.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.DefaultReviewOrder).
+					Return(nil, store.ErrCardNotFound)
+				users.On("GetByID", mock.Anything, userID).
+					Return(&domain.User{ID: userID, NewCardsPerDay: 5}, nil)
+				reviewLog.On("CountNewCards", mock.Anything, userID, mock.Anything).Return(0, nil)
+				store.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), true, domain.DefaultReviewOrder).
 					Return(nil, store.ErrCardNotFound)
 			},
 			expectedError: card_review.ErrNoCardsDue,
@@ -280,8 +697,8 @@ func TestGetNextCard(t *testing.T) {
 		{
 			name:   "repository error",
 			userID: uuid.New(),
-			setupMock: func(store *MockCardStore, userID uuid.UUID) {
-				store.On("GetNextReviewCard", mock.Anything, userID).
+			setupMock: func(store *MockCardStore, _ *MockReviewLogStore, _ *MockUserStore, userID uuid.UUID) {
+				store.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.DefaultReviewOrder).
 					Return(nil, errors.New("database error"))
 			},
 			expectedError: nil,
@@ -293,15 +710,40 @@ func TestGetNextCard(t *testing.T) {
 				assert.Equal(t, "database error", serviceErr.Message)
 			},
 		},
+		{
+			name:   "new card allowance error",
+			userID: uuid.New(),
+			setupMock: func(store *MockCardStore, reviewLog *MockReviewLogStore, users *MockUserStore, userID uuid.UUID) {
+				store.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.DefaultReviewOrder).
+					Return(nil, store.ErrCardNotFound)
+				users.On("GetByID", mock.Anything, userID).
+					Return(&domain.User{ID: userID, NewCardsPerDay: 5}, nil)
+				reviewLog.On("CountNewCards", mock.Anything, userID, mock.Anything).
+					Return(0, errors.New("database error"))
+			},
+			expectedError: nil,
+			checkError: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				var serviceErr *card_review.ServiceError
+				assert.ErrorAs(t, err, &serviceErr)
+				assert.Equal(t, "get_next_card", serviceErr.Operation)
+				assert.Equal(t, "failed to check new card allowance", serviceErr.Message)
+			},
+		},
 		{
 			name:   "nil uuid",
 			userID: uuid.Nil,
-			setupMock: func(store *MockCardStore, userID uuid.UUID) {
-				store.On("GetNextReviewCard", mock.Anything, userID).
+			setupMock: func(store *MockCardStore, _ *MockReviewLogStore, users *MockUserStore, userID uuid.UUID) {
+				store.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.DefaultReviewOrder).
 					Return(nil, store.ErrCardNotFound)
+				users.On("GetByID", mock.Anything, userID).
+					Return(nil, store.ErrNotFound)
+			},
+			expectedError: nil,
+			checkError: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, card_review.ErrNoCardsDue)
 			},
-			expectedError: card_review.ErrNoCardsDue,
-			checkError:    nil,
 		},
 	}
 
@@ -311,8 +753,10 @@ func TestGetNextCard(t *testing.T) {
 			// Create mock stores
 			mockCardStore := NewMockCardStore()
 			mockStatsStore := new(MockUserCardStatsStore)
+			mockReviewLogStore := new(MockReviewLogStore)
+			mockUserStore := new(MockUserStore)
 			mockSrsService := new(MockSRSService)
-			tc.setupMock(mockCardStore, tc.userID)
+			tc.setupMock(mockCardStore, mockReviewLogStore, mockUserStore, tc.userID)
 
 			// Create no-op logger for testing
 			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -321,6 +765,8 @@ func TestGetNextCard(t *testing.T) {
 			service, err := card_review.NewCardReviewService(
 				mockCardStore,
 				mockStatsStore,
+				mockReviewLogStore,
+				mockUserStore,
 				mockSrsService,
 				logger,
 			)
@@ -328,7 +774,7 @@ func TestGetNextCard(t *testing.T) {
 			assert.NotNil(t, service)
 
 			// Call method
-			card, err := service.GetNextCard(context.Background(), tc.userID)
+			card, err := service.GetNextCard(context.Background(), tc.userID, nil, domain.DefaultReviewOrder)
 
 			// Verify expectations
 			if tc.expectedError != nil {
@@ -342,11 +788,240 @@ func TestGetNextCard(t *testing.T) {
 
 			// Verify all expectations were met
 			mockCardStore.AssertExpectations(t)
-			// No need to verify other mocks for GetNextCard as they aren't used
+			mockReviewLogStore.AssertExpectations(t)
+			mockUserStore.AssertExpectations(t)
 		})
 	}
 }
 
+// TestGetNextCard_NewCardLimit tests that new cards stop being served once the
+// daily allowance is used up, and resume when the count for the day resets
+func TestGetNextCard_NewCardLimit(t *testing.T) {
+	userID := uuid.New()
+	const newCardsPerDay = 3
+
+	mockCardStore := NewMockCardStore()
+	mockReviewLogStore := new(MockReviewLogStore)
+	mockUserStore := new(MockUserStore)
+
+	// No reviews are due, but there are always new cards available
+	mockCardStore.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.DefaultReviewOrder).
+		Return(nil, store.ErrCardNotFound)
+	mockCardStore.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), true, domain.DefaultReviewOrder).
+		Return(createTestCard(userID), nil)
+	mockUserStore.On("GetByID", mock.Anything, userID).
+		Return(&domain.User{ID: userID, NewCardsPerDay: newCardsPerDay}, nil)
+
+	// The review log reports one more introduced card after each new card is
+	// served, then resets to zero when the next day begins
+	for introduced := 0; introduced <= newCardsPerDay; introduced++ {
+		mockReviewLogStore.On("CountNewCards", mock.Anything, userID, mock.Anything).
+			Return(introduced, nil).Once()
+	}
+	mockReviewLogStore.On("CountNewCards", mock.Anything, userID, mock.Anything).
+		Return(0, nil).Once()
+
+	service, err := card_review.NewCardReviewService(
+		mockCardStore,
+		new(MockUserCardStatsStore),
+		mockReviewLogStore,
+		mockUserStore,
+		new(MockSRSService),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	assert.NoError(t, err)
+
+	// Each new card is served until the allowance is exhausted
+	for i := 0; i < newCardsPerDay; i++ {
+		card, err := service.GetNextCard(context.Background(), userID, nil, domain.DefaultReviewOrder)
+		assert.NoError(t, err, "new card %d should be served", i+1)
+		assert.NotNil(t, card)
+	}
+
+	// The budget is spent, so no further new cards are served today
+	card, err := service.GetNextCard(context.Background(), userID, nil, domain.DefaultReviewOrder)
+	assert.ErrorIs(t, err, card_review.ErrNoCardsDue)
+	assert.Nil(t, card)
+	mockCardStore.AssertNumberOfCalls(t, "GetNextDueCard", 2*newCardsPerDay+1)
+
+	// On the next day the log has no new cards recorded yet
+	card, err = service.GetNextCard(context.Background(), userID, nil, domain.DefaultReviewOrder)
+	assert.NoError(t, err)
+	assert.NotNil(t, card)
+	mockReviewLogStore.AssertExpectations(t)
+}
+
+// TestGetNextCard_DeckNewCardLimit tests that a deck's new-card limit replaces
+// the user's while reviewing that deck, and that decks without one use the user's
+func TestGetNextCard_DeckNewCardLimit(t *testing.T) {
+	userID := uuid.New()
+	examDeckID := uuid.New()
+	otherDeckID := uuid.New()
+	examLimit := 5
+
+	mockCardStore := NewMockCardStore()
+	mockReviewLogStore := new(MockReviewLogStore)
+	mockUserStore := new(MockUserStore)
+	mockDeckStore := new(MockDeckStore)
+
+	// No reviews are due in either deck, but both have new cards
+	for _, deckID := range []*uuid.UUID{&examDeckID, &otherDeckID} {
+		mockCardStore.On("GetNextDueCard", mock.Anything, userID, deckID, false, domain.DefaultReviewOrder).
+			Return(nil, store.ErrCardNotFound)
+		mockCardStore.On("GetNextDueCard", mock.Anything, userID, deckID, true, domain.DefaultReviewOrder).
+			Return(createTestCard(userID), nil)
+	}
+
+	// The user's own allowance is used up for today
+	mockUserStore.On("GetByID", mock.Anything, userID).
+		Return(&domain.User{ID: userID, NewCardsPerDay: 3}, nil)
+	mockReviewLogStore.On("CountNewCards", mock.Anything, userID, mock.Anything).Return(3, nil)
+	mockDeckStore.On("GetByID", mock.Anything, examDeckID).
+		Return(&domain.Deck{ID: examDeckID, UserID: userID, SRSSettings: domain.DeckSRSSettings{
+			NewCardsPerDay: &examLimit,
+		}}, nil)
+	mockDeckStore.On("GetByID", mock.Anything, otherDeckID).
+		Return(&domain.Deck{ID: otherDeckID, UserID: userID}, nil)
+
+	service, err := card_review.NewCardReviewService(
+		mockCardStore,
+		new(MockUserCardStatsStore),
+		mockReviewLogStore,
+		mockUserStore,
+		new(MockSRSService),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		card_review.WithDeckSettings(mockDeckStore),
+	)
+	assert.NoError(t, err)
+
+	card, err := service.GetNextCard(context.Background(), userID, &examDeckID, domain.DefaultReviewOrder)
+	assert.NoError(t, err, "The exam deck's higher limit should allow more new cards")
+	assert.NotNil(t, card)
+
+	card, err = service.GetNextCard(context.Background(), userID, &otherDeckID, domain.DefaultReviewOrder)
+	assert.ErrorIs(t, err, card_review.ErrNoCardsDue, "Other decks should use the user's limit")
+	assert.Nil(t, card)
+
+	mockDeckStore.AssertExpectations(t)
+}
+
+// TestGetNextCard_Order tests that the requested review order reaches the store,
+// that a deck's order applies when none is requested, and that unknown orders
+// are rejected before querying it
+func TestGetNextCard_Order(t *testing.T) {
+	userID := uuid.New()
+
+	newService := func(
+		t *testing.T,
+		cardStore *MockCardStore,
+		opts ...card_review.CardReviewServiceOption,
+	) card_review.CardReviewService {
+		t.Helper()
+		service, err := card_review.NewCardReviewService(
+			cardStore,
+			new(MockUserCardStatsStore),
+			new(MockReviewLogStore),
+			new(MockUserStore),
+			new(MockSRSService),
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+			opts...,
+		)
+		assert.NoError(t, err)
+		return service
+	}
+
+	t.Run("order is passed to the store", func(t *testing.T) {
+		card := createTestCard(userID)
+		mockCardStore := NewMockCardStore()
+		mockCardStore.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.ReviewOrderLowestEase).
+			Return(card, nil)
+
+		got, err := newService(t, mockCardStore).
+			GetNextCard(context.Background(), userID, nil, domain.ReviewOrderLowestEase)
+
+		assert.NoError(t, err)
+		assert.Equal(t, card, got)
+		mockCardStore.AssertExpectations(t)
+	})
+
+	t.Run("empty order uses the default", func(t *testing.T) {
+		mockCardStore := NewMockCardStore()
+		mockCardStore.On("GetNextDueCard", mock.Anything, userID, (*uuid.UUID)(nil), false, domain.DefaultReviewOrder).
+			Return(createTestCard(userID), nil)
+
+		_, err := newService(t, mockCardStore).GetNextCard(context.Background(), userID, nil, "")
+
+		assert.NoError(t, err)
+		mockCardStore.AssertExpectations(t)
+	})
+
+	t.Run("empty order uses the deck's order", func(t *testing.T) {
+		deckID := uuid.New()
+		deckOrder := domain.ReviewOrderLowestEase
+		mockDeckStore := new(MockDeckStore)
+		mockDeckStore.On("GetByID", mock.Anything, deckID).
+			Return(&domain.Deck{ID: deckID, UserID: userID, SRSSettings: domain.DeckSRSSettings{
+				ReviewOrder: &deckOrder,
+			}}, nil)
+		mockCardStore := NewMockCardStore()
+		mockCardStore.On("GetNextDueCard", mock.Anything, userID, &deckID, false, domain.ReviewOrderLowestEase).
+			Return(createTestCard(userID), nil)
+		mockCardStore.On("GetNextDueCard", mock.Anything, userID, &deckID, false, domain.ReviewOrderRandomDue).
+			Return(createTestCard(userID), nil)
+		service := newService(t, mockCardStore, card_review.WithDeckSettings(mockDeckStore))
+
+		_, err := service.GetNextCard(context.Background(), userID, &deckID, "")
+		assert.NoError(t, err)
+
+		// A requested order takes precedence over the deck's
+		_, err = service.GetNextCard(context.Background(), userID, &deckID, domain.ReviewOrderRandomDue)
+		assert.NoError(t, err)
+
+		mockCardStore.AssertExpectations(t)
+	})
+
+	t.Run("deck without an order uses the default", func(t *testing.T) {
+		deckID := uuid.New()
+		mockDeckStore := new(MockDeckStore)
+		mockDeckStore.On("GetByID", mock.Anything, deckID).
+			Return(&domain.Deck{ID: deckID, UserID: userID}, nil)
+		mockCardStore := NewMockCardStore()
+		mockCardStore.On("GetNextDueCard", mock.Anything, userID, &deckID, false, domain.DefaultReviewOrder).
+			Return(createTestCard(userID), nil)
+
+		_, err := newService(t, mockCardStore, card_review.WithDeckSettings(mockDeckStore)).
+			GetNextCard(context.Background(), userID, &deckID, "")
+
+		assert.NoError(t, err)
+		mockCardStore.AssertExpectations(t)
+	})
+
+	t.Run("deck of another user is not found", func(t *testing.T) {
+		deckID := uuid.New()
+		mockDeckStore := new(MockDeckStore)
+		mockDeckStore.On("GetByID", mock.Anything, deckID).
+			Return(&domain.Deck{ID: deckID, UserID: uuid.New()}, nil)
+		mockCardStore := NewMockCardStore()
+
+		_, err := newService(t, mockCardStore, card_review.WithDeckSettings(mockDeckStore)).
+			GetNextCard(context.Background(), userID, &deckID, "")
+
+		assert.ErrorIs(t, err, store.ErrDeckNotFound)
+		mockCardStore.AssertNotCalled(t, "GetNextDueCard",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown order", func(t *testing.T) {
+		mockCardStore := NewMockCardStore()
+
+		_, err := newService(t, mockCardStore).GetNextCard(context.Background(), userID, nil, "hardest-first")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidReviewOrder)
+		mockCardStore.AssertNotCalled(t, "GetNextDueCard",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 // TestSubmitAnswer tests the SubmitAnswer method of CardReviewService
 func TestSubmitAnswer(t *testing.T) {
 	// Only test invalid answer case since we can't easily mock RunInTransaction
@@ -358,6 +1033,8 @@ func TestSubmitAnswer(t *testing.T) {
 	// Create mocks
 	mockCardStore := NewMockCardStore()
 	mockStatsStore := new(MockUserCardStatsStore)
+	mockReviewLogStore := new(MockReviewLogStore)
+	mockUserStore := new(MockUserStore)
 	mockSrsService := new(MockSRSService)
 
 	// Create service
@@ -365,6 +1042,8 @@ func TestSubmitAnswer(t *testing.T) {
 	service, err := card_review.NewCardReviewService(
 		mockCardStore,
 		mockStatsStore,
+		mockReviewLogStore,
+		mockUserStore,
 		mockSrsService,
 		logger,
 	)
@@ -0,0 +1,172 @@
+package card_review_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/domain/srs"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestSubmitAnswers runs the batch answer flow end-to-end on the in-memory stores
+func TestSubmitAnswers(t *testing.T) {
+	ctx := context.Background()
+
+	type fixture struct {
+		service   card_review.CardReviewService
+		users     *memory.UserStore
+		stats     *memory.UserCardStatsStore
+		reviewLog *memory.ReviewLogStore
+		user      *domain.User
+		cards     []*domain.Card
+		foreign   *domain.Card
+	}
+
+	// setup creates a user with three cards: the first two have stats, the second
+	// of which has been reviewed once, and the third has none yet. It also creates
+	// a card belonging to another user.
+	setup := func(t *testing.T) fixture {
+		t.Helper()
+		backend := memory.NewBackend()
+		t.Cleanup(func() { _ = backend.Close() })
+
+		users := memory.NewUserStore(backend, bcrypt.MinCost)
+		cards := memory.NewCardStore(backend)
+		stats := memory.NewUserCardStatsStore(backend)
+		reviewLog := memory.NewReviewLogStore(backend)
+		srsService, err := srs.NewDefaultService()
+		require.NoError(t, err)
+
+		user, err := domain.NewUser("batch@example.com", "batch-password")
+		require.NoError(t, err)
+		require.NoError(t, users.Create(ctx, user))
+		memo, err := domain.NewMemo(user.ID, "Batch memo")
+		require.NoError(t, err)
+		require.NoError(t, memory.NewMemoStore(backend).Create(ctx, memo))
+
+		f := fixture{users: users, stats: stats, reviewLog: reviewLog, user: user}
+		for i := 0; i < 3; i++ {
+			card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+			require.NoError(t, err)
+			require.NoError(t, cards.CreateMultiple(ctx, []*domain.Card{card}))
+			f.cards = append(f.cards, card)
+		}
+		for i, card := range f.cards[:2] {
+			cardStats, err := domain.NewUserCardStats(user.ID, card.ID)
+			require.NoError(t, err)
+			if i == 1 {
+				cardStats.ReviewCount = 1
+				cardStats.Interval = 1
+				cardStats.LastReviewedAt = time.Now().UTC().Add(-24 * time.Hour)
+			}
+			require.NoError(t, stats.Create(ctx, cardStats))
+		}
+
+		otherUser, err := domain.NewUser("other@example.com", "other-password")
+		require.NoError(t, err)
+		require.NoError(t, users.Create(ctx, otherUser))
+		otherMemo, err := domain.NewMemo(otherUser.ID, "Other memo")
+		require.NoError(t, err)
+		require.NoError(t, memory.NewMemoStore(backend).Create(ctx, otherMemo))
+		f.foreign, err = domain.NewCard(otherUser.ID, otherMemo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+		require.NoError(t, err)
+		require.NoError(t, cards.CreateMultiple(ctx, []*domain.Card{f.foreign}))
+
+		f.service, err = card_review.NewCardReviewService(
+			cards, stats, reviewLog, users, srsService,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+		require.NoError(t, err)
+		return f
+	}
+
+	answersFor := func(cards []*domain.Card, outcome domain.ReviewOutcome) []card_review.CardAnswer {
+		answers := make([]card_review.CardAnswer, len(cards))
+		for i, card := range cards {
+			answers[i] = card_review.CardAnswer{CardID: card.ID, Outcome: outcome}
+		}
+		return answers
+	}
+
+	t.Run("applies every answer", func(t *testing.T) {
+		f := setup(t)
+
+		results, err := f.service.SubmitAnswers(ctx, f.user.ID, answersFor(f.cards, domain.ReviewOutcomeGood))
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		for i, card := range f.cards {
+			assert.Equal(t, card.ID, results[i].CardID, "Results follow the order of the answers")
+
+			stored, err := f.stats.Get(ctx, f.user.ID, card.ID)
+			require.NoError(t, err, "Stats are created for a card that had none")
+			assert.Equal(t, results[i].ReviewCount, stored.ReviewCount)
+			assert.False(t, stored.LastReviewedAt.IsZero())
+			assert.True(t, stored.NextReviewAt.After(time.Now()))
+		}
+		assert.Equal(t, 2, results[1].ReviewCount)
+
+		newCards, err := f.reviewLog.CountNewCards(ctx, f.user.ID, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 2, newCards, "The two never-reviewed cards count as new")
+	})
+
+	t.Run("stats created for a card that had none start from the user's new card settings", func(t *testing.T) {
+		f := setup(t)
+		interval, ease := 3, 1.8
+		f.user.NewCardOverrides = domain.NewCardOverrides{IntervalDays: &interval, EaseFactor: &ease}
+		require.NoError(t, f.users.Update(ctx, f.user))
+
+		results, err := f.service.SubmitAnswers(ctx, f.user.ID, answersFor(f.cards[2:], domain.ReviewOutcomeGood))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+
+		stored, err := f.stats.Get(ctx, f.user.ID, f.cards[2].ID)
+		require.NoError(t, err)
+		assert.Equal(t, ease, stored.EaseFactor)
+	})
+
+	t.Run("rejects the batch if any card is missing or not owned", func(t *testing.T) {
+		f := setup(t)
+
+		answers := answersFor([]*domain.Card{f.cards[0], f.foreign}, domain.ReviewOutcomeGood)
+		_, err := f.service.SubmitAnswers(ctx, f.user.ID, answers)
+		assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+
+		answers = answersFor(f.cards[:1], domain.ReviewOutcomeGood)
+		answers = append(answers, card_review.CardAnswer{CardID: uuid.New(), Outcome: domain.ReviewOutcomeGood})
+		_, err = f.service.SubmitAnswers(ctx, f.user.ID, answers)
+		assert.ErrorIs(t, err, card_review.ErrCardNotFound)
+
+		stored, err := f.stats.Get(ctx, f.user.ID, f.cards[0].ID)
+		require.NoError(t, err)
+		assert.Zero(t, stored.ReviewCount, "No answer is applied when the batch is rejected")
+	})
+
+	t.Run("rejects invalid batches", func(t *testing.T) {
+		f := setup(t)
+
+		tests := map[string][]card_review.CardAnswer{
+			"empty":         nil,
+			"too many":      make([]card_review.CardAnswer, card_review.MaxBatchAnswers+1),
+			"repeated card": answersFor([]*domain.Card{f.cards[0], f.cards[0]}, domain.ReviewOutcomeGood),
+			"bad outcome":   answersFor(f.cards[:1], "maybe"),
+		}
+		for name, answers := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := f.service.SubmitAnswers(ctx, f.user.ID, answers)
+				assert.ErrorIs(t, err, card_review.ErrInvalidAnswer)
+			})
+		}
+	})
+}
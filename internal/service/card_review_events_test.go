@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/domain/srs"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestCardReviewService_RecordsReviewEvents verifies that submitting answers
+// appends to the review history read by GetRecentlyReviewed
+func TestCardReviewService_RecordsReviewEvents(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	ctx := context.Background()
+	logger := slog.Default()
+	cardStore := postgres.NewPostgresCardStore(db, logger)
+	statsStore := postgres.NewPostgresUserCardStatsStore(db, logger)
+	srsService, err := srs.NewDefaultService()
+	require.NoError(t, err)
+	cardReviewService, err := card_review.NewCardReviewService(
+		cardStore,
+		statsStore,
+		postgres.NewPostgresReviewLogStore(db, logger),
+		postgres.NewPostgresUserStore(db, bcrypt.MinCost),
+		srsService,
+		logger,
+		card_review.WithReviewEvents(postgres.NewPostgresReviewEventStore(db, logger)),
+	)
+	require.NoError(t, err)
+
+	// Answering cards commits transactions, so data is committed and cleaned up per user
+	userID := testutils.MustInsertUser(ctx, t, db, "review-events-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", userID)
+	})
+
+	memo := testutils.MustInsertMemo(ctx, t, db, userID)
+	card, err := domain.NewCard(userID, memo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+	require.NoError(t, err)
+	require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+	stats, err := domain.NewUserCardStats(userID, card.ID)
+	require.NoError(t, err)
+	require.NoError(t, statsStore.Create(ctx, stats))
+
+	// Nothing has been reviewed yet
+	reviewed, err := cardStore.GetRecentlyReviewed(ctx, userID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, reviewed)
+
+	_, err = cardReviewService.SubmitAnswer(ctx, userID, card.ID,
+		card_review.ReviewAnswer{Outcome: domain.ReviewOutcomeAgain})
+	require.NoError(t, err)
+	_, err = cardReviewService.SubmitAnswer(ctx, userID, card.ID,
+		card_review.ReviewAnswer{Outcome: domain.ReviewOutcomeGood})
+	require.NoError(t, err)
+
+	// The card is listed once, with its latest answer
+	reviewed, err = cardStore.GetRecentlyReviewed(ctx, userID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, reviewed, 1)
+	assert.Equal(t, card.ID, reviewed[0].Card.ID)
+	assert.Equal(t, domain.ReviewOutcomeGood, reviewed[0].LastReview.Outcome)
+
+	// A rejected answer records nothing
+	_, err = cardReviewService.SubmitAnswer(ctx, uuid.New(), card.ID,
+		card_review.ReviewAnswer{Outcome: domain.ReviewOutcomeEasy})
+	assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+
+	var eventCount int
+	require.NoError(t, db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM review_events WHERE card_id = $1", card.ID).Scan(&eventCount))
+	assert.Equal(t, 2, eventCount)
+}
@@ -3,12 +3,16 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
 	"github.com/phrazzld/scry-api/internal/store"
 )
 
@@ -49,6 +53,28 @@ type CardRepository interface {
 	// GetByID retrieves a card by its unique ID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Card, error)
 
+	// UpdateContent replaces a card's content if it is still at expectedVersion
+	// and returns the card's new version
+	UpdateContent(ctx context.Context, id uuid.UUID, content []byte, expectedVersion int) (int, error)
+
+	// SetMemo moves a card to a different parent memo
+	SetMemo(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error
+
+	// SetSuspended suspends a card as of suspendedAt, or unsuspends it when suspendedAt is nil
+	SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error
+
+	// SetBuriedUntil hides a card from review until the given time, or clears the burial when until is nil
+	SetBuriedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error
+
+	// SupersedeByMemo marks a memo's active cards as superseded and returns how many were marked
+	SupersedeByMemo(ctx context.Context, memoID uuid.UUID, at time.Time) (int, error)
+
+	// DeleteByMemo deletes all of a memo's cards and returns how many were deleted
+	DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error)
+
+	// ListByTag retrieves a page of a user's active cards that have a tag, oldest first
+	ListByTag(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.Card, error)
+
 	// WithTx returns a new repository instance that uses the provided transaction
 	// This is used for transactional operations
 	WithTx(tx *sql.Tx) CardRepository
@@ -80,13 +106,97 @@ type CardService interface {
 
 	// GetCard retrieves a card by its ID
 	GetCard(ctx context.Context, cardID uuid.UUID) (*domain.Card, error)
+
+	// GetUserCard retrieves a card owned by the user.
+	// Returns store.ErrCardNotFound if the card does not exist, and
+	// card_review.ErrCardNotOwned if it belongs to another user.
+	GetUserCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
+
+	// UpdateCardContent replaces the content of a card owned by the user.
+	// expectedVersion must be the card version the caller last read; if the card
+	// has been edited since, store.ErrVersionConflict is returned and nothing changes.
+	// Returns the updated card with its new version.
+	UpdateCardContent(
+		ctx context.Context,
+		userID, cardID uuid.UUID,
+		content json.RawMessage,
+		expectedVersion int,
+	) (*domain.Card, error)
+
+	// MoveCardToMemo reassigns a card owned by the user to another of the user's memos,
+	// for example when memos are merged or split. Returns the moved card.
+	// Returns card_review.ErrCardNotOwned or ErrMemoNotOwned if either belongs to
+	// another user, and store.ErrCardNotFound or store.ErrMemoNotFound if either
+	// does not exist.
+	MoveCardToMemo(ctx context.Context, userID, cardID, targetMemoID uuid.UUID) (*domain.Card, error)
+
+	// SuspendCard pauses a card owned by the user: it keeps its statistics and
+	// stays listable, but is excluded from review and due counts until unsuspended.
+	// Suspending a suspended card leaves it unchanged. Returns the card.
+	// Returns card_review.ErrCardNotOwned if the card belongs to another user and
+	// store.ErrCardNotFound if it does not exist.
+	SuspendCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
+
+	// UnsuspendCard returns a suspended card owned by the user to review. If it
+	// became due while suspended it is due immediately. Unsuspending a card that
+	// is not suspended leaves it unchanged. Returns the card, with the same errors
+	// as SuspendCard.
+	UnsuspendCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
+
+	// BuryCard hides a card owned by the user from review for the rest of the
+	// user's current day. Unlike postponing, its schedule is left untouched: the
+	// card is served again from the next midnight in the user's timezone.
+	// Returns the card, with the same errors as SuspendCard.
+	BuryCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
+
+	// ResetProgress lets the user relearn a card from scratch: its statistics go
+	// back to those of a new card, with the interval and ease factor a new card
+	// would start from, and it is due immediately. The card's review history is
+	// kept. Returns the reset statistics, with the same errors as SuspendCard.
+	ResetProgress(ctx context.Context, userID, cardID uuid.UUID) (*domain.UserCardStats, error)
+
+	// ListCardsByTag returns a page of the user's active cards whose content lists
+	// tag among its tags, oldest first; suspended cards are included. The tag is
+	// normalized with domain.NormalizeTag first, so "Leech" finds cards tagged
+	// "leech". Returns domain.ErrInvalidTag for a tag that cannot be normalized.
+	ListCardsByTag(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.Card, error)
 }
 
 // cardServiceImpl implements the CardService interface
 type cardServiceImpl struct {
 	cardRepo  CardRepository
 	statsRepo StatsRepository
+	memoRepo  MemoRepository
+	userStore store.UserStore
 	logger    *slog.Logger
+
+	// newCardUsers and newCardDecks, if set, supply the overrides that new
+	// cards' stats start from
+	newCardUsers store.UserStore
+	newCardDecks store.DeckStore
+}
+
+// CardServiceOption configures optional CardService behavior
+type CardServiceOption func(*cardServiceImpl)
+
+// WithCardUserStore lets BuryCard look up the user's timezone in userStore to
+// find the end of their day. Without this option days end at midnight UTC.
+func WithCardUserStore(userStore store.UserStore) CardServiceOption {
+	return func(s *cardServiceImpl) {
+		s.userStore = userStore
+	}
+}
+
+// WithNewCardSettings makes CreateCards start each card's stats from the
+// settings resolved by domain.ResolveNewCardSettings, looking up the card's
+// user in userStore and its deck in deckStore. Either may be nil to skip its
+// overrides. Without this option new cards start from
+// domain.DefaultNewCardSettings.
+func WithNewCardSettings(userStore store.UserStore, deckStore store.DeckStore) CardServiceOption {
+	return func(s *cardServiceImpl) {
+		s.newCardUsers = userStore
+		s.newCardDecks = deckStore
+	}
 }
 
 // NewCardService creates a new CardService
@@ -94,7 +204,9 @@ type cardServiceImpl struct {
 func NewCardService(
 	cardRepo CardRepository,
 	statsRepo StatsRepository,
+	memoRepo MemoRepository,
 	logger *slog.Logger,
+	opts ...CardServiceOption,
 ) (CardService, error) {
 	// Validate dependencies
 	if cardRepo == nil {
@@ -103,17 +215,26 @@ func NewCardService(
 	if statsRepo == nil {
 		return nil, domain.NewValidationError("statsRepo", "cannot be nil", domain.ErrValidation)
 	}
+	if memoRepo == nil {
+		return nil, domain.NewValidationError("memoRepo", "cannot be nil", domain.ErrValidation)
+	}
 
 	// Use provided logger or create default
 	if logger == nil {
 		logger = slog.Default()
 	}
 
-	return &cardServiceImpl{
+	service := &cardServiceImpl{
 		cardRepo:  cardRepo,
 		statsRepo: statsRepo,
+		memoRepo:  memoRepo,
 		logger:    logger.With(slog.String("component", "card_service")),
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service, nil
 }
 
 // CreateCards implements CardService.CreateCards
@@ -148,9 +269,20 @@ func (s *cardServiceImpl) CreateCards(ctx context.Context, cards []*domain.Card)
 			}
 
 			// 2. Create a UserCardStats entry for each card
+			now := time.Now().UTC()
+			settingsFor := s.newCardSettingsResolver(tx)
 			for _, card := range cards {
-				// Create a new UserCardStats with default values
-				stats, err := domain.NewUserCardStats(card.UserID, card.ID)
+				settings, err := settingsFor(ctx, card)
+				if err != nil {
+					log.Error("failed to resolve new card settings",
+						slog.String("error", err.Error()),
+						slog.String("user_id", card.UserID.String()),
+						slog.String("card_id", card.ID.String()))
+					return NewCardServiceError("create_cards", "failed to resolve new card settings", err)
+				}
+
+				// Create a new UserCardStats starting from the resolved settings
+				stats, err := domain.NewUserCardStatsWithSettings(card.UserID, card.ID, settings, now)
 				if err != nil {
 					log.Error("failed to create user card stats object",
 						slog.String("error", err.Error()),
@@ -181,6 +313,45 @@ func (s *cardServiceImpl) CreateCards(ctx context.Context, cards []*domain.Card)
 	)
 }
 
+// newCardSettingsResolver returns a function resolving the settings that a new
+// card's stats start from, looking up each user and deck in tx at most once
+func (s *cardServiceImpl) newCardSettingsResolver(
+	tx *sql.Tx,
+) func(ctx context.Context, card *domain.Card) (domain.NewCardSettings, error) {
+	users := make(map[uuid.UUID]*domain.User)
+	decks := make(map[uuid.UUID]*domain.Deck)
+
+	return func(ctx context.Context, card *domain.Card) (domain.NewCardSettings, error) {
+		var user *domain.User
+		if s.newCardUsers != nil {
+			var ok bool
+			if user, ok = users[card.UserID]; !ok {
+				var err error
+				user, err = s.newCardUsers.WithTx(tx).GetByID(ctx, card.UserID)
+				if err != nil {
+					return domain.NewCardSettings{}, fmt.Errorf("failed to get user: %w", err)
+				}
+				users[card.UserID] = user
+			}
+		}
+
+		var deck *domain.Deck
+		if s.newCardDecks != nil && card.DeckID != nil {
+			var ok bool
+			if deck, ok = decks[*card.DeckID]; !ok {
+				var err error
+				deck, err = s.newCardDecks.WithTx(tx).GetByID(ctx, *card.DeckID)
+				if err != nil {
+					return domain.NewCardSettings{}, fmt.Errorf("failed to get deck: %w", err)
+				}
+				decks[*card.DeckID] = deck
+			}
+		}
+
+		return domain.ResolveNewCardSettings(user, deck), nil
+	}
+}
+
 // GetCard implements CardService.GetCard
 // It retrieves a card by its ID
 func (s *cardServiceImpl) GetCard(ctx context.Context, cardID uuid.UUID) (*domain.Card, error) {
@@ -209,3 +380,359 @@ func (s *cardServiceImpl) GetCard(ctx context.Context, cardID uuid.UUID) (*domai
 
 	return card, nil
 }
+
+// UpdateCardContent implements CardService.UpdateCardContent
+// The version check and increment happen in a single UPDATE, so concurrent edits
+// from different clients cannot silently overwrite each other.
+func (s *cardServiceImpl) UpdateCardContent(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+	content json.RawMessage,
+	expectedVersion int,
+) (*domain.Card, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	// Reject oversized content before loading the card
+	if err := domain.ValidateCardContentSize(content); err != nil {
+		return nil, err
+	}
+
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError("update_card_content", "card not found", store.ErrCardNotFound)
+		}
+		return nil, NewCardServiceError("update_card_content", "failed to retrieve card", err)
+	}
+
+	if card.UserID != userID {
+		return nil, NewCardServiceError(
+			"update_card_content",
+			"card not owned by user",
+			card_review.ErrCardNotOwned,
+		)
+	}
+
+	if err := card.UpdateContent(content); err != nil {
+		// Content validator errors already name the offending field
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, validationErr
+		}
+		return nil, domain.NewValidationError("content", err.Error(), domain.ErrInvalidCardContent)
+	}
+
+	newVersion, err := s.cardRepo.UpdateContent(ctx, cardID, card.Content, expectedVersion)
+	if err != nil {
+		if errors.Is(err, store.ErrVersionConflict) {
+			log.Debug("card content update rejected due to version conflict",
+				slog.String("card_id", cardID.String()),
+				slog.Int("expected_version", expectedVersion),
+				slog.Int("current_version", card.Version))
+		} else {
+			log.Error("failed to update card content",
+				slog.String("error", err.Error()),
+				slog.String("card_id", cardID.String()))
+		}
+		return nil, NewCardServiceError("update_card_content", "failed to update card content", err)
+	}
+
+	card.Version = newVersion
+	log.Debug("updated card content",
+		slog.String("card_id", cardID.String()),
+		slog.Int("version", newVersion))
+
+	return card, nil
+}
+
+// MoveCardToMemo implements CardService.MoveCardToMemo
+// Moving a card to the memo it already belongs to succeeds without changing it.
+func (s *cardServiceImpl) MoveCardToMemo(
+	ctx context.Context,
+	userID, cardID, targetMemoID uuid.UUID,
+) (*domain.Card, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError("move_card_to_memo", "card not found", store.ErrCardNotFound)
+		}
+		return nil, NewCardServiceError("move_card_to_memo", "failed to retrieve card", err)
+	}
+
+	if card.UserID != userID {
+		return nil, NewCardServiceError(
+			"move_card_to_memo",
+			"card not owned by user",
+			card_review.ErrCardNotOwned,
+		)
+	}
+
+	memo, err := s.memoRepo.GetByID(ctx, targetMemoID)
+	if err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError("move_card_to_memo", "memo not found", store.ErrMemoNotFound)
+		}
+		return nil, NewCardServiceError("move_card_to_memo", "failed to retrieve memo", err)
+	}
+
+	if memo.UserID != userID {
+		return nil, NewCardServiceError("move_card_to_memo", "memo not owned by user", ErrMemoNotOwned)
+	}
+
+	if card.MemoID == targetMemoID {
+		return card, nil
+	}
+
+	if err := s.cardRepo.SetMemo(ctx, cardID, targetMemoID); err != nil {
+		// The memo may have been deleted since it was retrieved
+		if errors.Is(err, store.ErrReferencedEntityMissing) {
+			return nil, NewCardServiceError("move_card_to_memo", "memo not found", store.ErrMemoNotFound)
+		}
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError("move_card_to_memo", "card not found", store.ErrCardNotFound)
+		}
+		log.Error("failed to move card to memo",
+			slog.String("error", err.Error()),
+			slog.String("card_id", cardID.String()),
+			slog.String("memo_id", targetMemoID.String()))
+		return nil, NewCardServiceError("move_card_to_memo", "failed to move card", err)
+	}
+
+	// Reload the card to pick up the updated_at set by the store
+	moved, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		return nil, NewCardServiceError("move_card_to_memo", "failed to retrieve moved card", err)
+	}
+
+	log.Debug("moved card to memo",
+		slog.String("card_id", cardID.String()),
+		slog.String("from_memo_id", card.MemoID.String()),
+		slog.String("to_memo_id", targetMemoID.String()))
+
+	return moved, nil
+}
+
+// GetUserCard implements CardService.GetUserCard
+func (s *cardServiceImpl) GetUserCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error) {
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError("get_user_card", "card not found", store.ErrCardNotFound)
+		}
+		return nil, NewCardServiceError("get_user_card", "failed to retrieve card", err)
+	}
+
+	if card.UserID != userID {
+		return nil, NewCardServiceError("get_user_card", "card not owned by user", card_review.ErrCardNotOwned)
+	}
+
+	return card, nil
+}
+
+// SuspendCard implements CardService.SuspendCard
+func (s *cardServiceImpl) SuspendCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error) {
+	now := time.Now().UTC()
+	return s.setCardSuspended(ctx, "suspend_card", userID, cardID, &now)
+}
+
+// UnsuspendCard implements CardService.UnsuspendCard
+func (s *cardServiceImpl) UnsuspendCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error) {
+	return s.setCardSuspended(ctx, "unsuspend_card", userID, cardID, nil)
+}
+
+// setCardSuspended suspends the user's card as of suspendedAt, or unsuspends it
+// when suspendedAt is nil, and returns the reloaded card. A card already in the
+// requested state is returned unchanged.
+func (s *cardServiceImpl) setCardSuspended(
+	ctx context.Context,
+	operation string,
+	userID, cardID uuid.UUID,
+	suspendedAt *time.Time,
+) (*domain.Card, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError(operation, "card not found", store.ErrCardNotFound)
+		}
+		return nil, NewCardServiceError(operation, "failed to retrieve card", err)
+	}
+
+	if card.UserID != userID {
+		return nil, NewCardServiceError(operation, "card not owned by user", card_review.ErrCardNotOwned)
+	}
+
+	if card.IsSuspended() == (suspendedAt != nil) {
+		return card, nil
+	}
+
+	if err := s.cardRepo.SetSuspended(ctx, cardID, suspendedAt); err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError(operation, "card not found", store.ErrCardNotFound)
+		}
+		log.Error("failed to set card suspension",
+			slog.String("error", err.Error()),
+			slog.String("card_id", cardID.String()),
+			slog.String("operation", operation))
+		return nil, NewCardServiceError(operation, "failed to update card", err)
+	}
+
+	// Reload the card to pick up the timestamps set by the store
+	updated, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		return nil, NewCardServiceError(operation, "failed to retrieve updated card", err)
+	}
+
+	log.Debug("set card suspension",
+		slog.String("card_id", cardID.String()),
+		slog.Bool("suspended", suspendedAt != nil))
+
+	return updated, nil
+}
+
+// BuryCard implements CardService.BuryCard
+func (s *cardServiceImpl) BuryCard(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError("bury_card", "card not found", store.ErrCardNotFound)
+		}
+		return nil, NewCardServiceError("bury_card", "failed to retrieve card", err)
+	}
+
+	if card.UserID != userID {
+		return nil, NewCardServiceError("bury_card", "card not owned by user", card_review.ErrCardNotOwned)
+	}
+
+	until, err := s.endOfUserDay(ctx, userID, time.Now())
+	if err != nil {
+		return nil, NewCardServiceError("bury_card", "failed to retrieve user", err)
+	}
+
+	if err := s.cardRepo.SetBuriedUntil(ctx, cardID, &until); err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError("bury_card", "card not found", store.ErrCardNotFound)
+		}
+		log.Error("failed to bury card",
+			slog.String("error", err.Error()),
+			slog.String("card_id", cardID.String()))
+		return nil, NewCardServiceError("bury_card", "failed to update card", err)
+	}
+
+	// Reload the card to pick up the timestamps set by the store
+	buried, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		return nil, NewCardServiceError("bury_card", "failed to retrieve updated card", err)
+	}
+
+	log.Debug("buried card",
+		slog.String("card_id", cardID.String()),
+		slog.Time("buried_until", until))
+
+	return buried, nil
+}
+
+// ResetProgress implements CardService.ResetProgress
+func (s *cardServiceImpl) ResetProgress(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+) (*domain.UserCardStats, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if store.IsNotFoundError(err) {
+			return nil, NewCardServiceError("reset_progress", "card not found", store.ErrCardNotFound)
+		}
+		return nil, NewCardServiceError("reset_progress", "failed to retrieve card", err)
+	}
+
+	if card.UserID != userID {
+		return nil, NewCardServiceError("reset_progress", "card not owned by user", card_review.ErrCardNotOwned)
+	}
+
+	var reset *domain.UserCardStats
+	err = store.RunInTransaction(ctx, s.cardRepo.DB(), func(ctx context.Context, tx *sql.Tx) error {
+		txStatsRepo := s.statsRepo.WithTx(tx)
+
+		settings, err := s.newCardSettingsResolver(tx)(ctx, card)
+		if err != nil {
+			return NewCardServiceError("reset_progress", "failed to resolve new card settings", err)
+		}
+		// A reset card is relearned straight away, whatever new cards default to
+		settings.DueImmediately = true
+
+		reset, err = domain.NewUserCardStatsWithSettings(userID, cardID, settings, time.Now())
+		if err != nil {
+			return NewCardServiceError("reset_progress", "failed to create initial stats", err)
+		}
+
+		existing, err := txStatsRepo.Get(ctx, userID, cardID)
+		if err != nil {
+			if !store.IsNotFoundError(err) {
+				return NewCardServiceError("reset_progress", "failed to retrieve stats", err)
+			}
+			// Stats missing for any reason are recreated
+			if err := txStatsRepo.Create(ctx, reset); err != nil {
+				return NewCardServiceError("reset_progress", "failed to create stats", err)
+			}
+			return nil
+		}
+
+		reset.CreatedAt = existing.CreatedAt
+		if err := txStatsRepo.Update(ctx, reset); err != nil {
+			return NewCardServiceError("reset_progress", "failed to update stats", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to reset card progress",
+			slog.String("error", err.Error()),
+			slog.String("card_id", cardID.String()))
+		return nil, err
+	}
+
+	log.Debug("reset card progress",
+		slog.String("user_id", userID.String()),
+		slog.String("card_id", cardID.String()))
+
+	return reset, nil
+}
+
+// ListCardsByTag implements CardService.ListCardsByTag
+func (s *cardServiceImpl) ListCardsByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	normalized, err := domain.NormalizeTag(tag)
+	if err != nil {
+		return nil, NewCardServiceError("list_cards_by_tag", "invalid tag", err)
+	}
+
+	cards, err := s.cardRepo.ListByTag(ctx, userID, normalized, limit, offset)
+	if err != nil {
+		return nil, NewCardServiceError("list_cards_by_tag", "failed to list cards", err)
+	}
+	return cards, nil
+}
+
+// endOfUserDay returns the next midnight after now in the user's timezone, or in
+// UTC when the service has no user store.
+func (s *cardServiceImpl) endOfUserDay(ctx context.Context, userID uuid.UUID, now time.Time) (time.Time, error) {
+	if s.userStore == nil {
+		return (&domain.User{}).EndOfDay(now), nil
+	}
+
+	user, err := s.userStore.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return user.EndOfDay(now), nil
+}
@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestCardService_CreateCards_NewCardSettings checks that new cards' stats
+// start from the defaults, the user's overrides, or their deck's overrides
+func TestCardService_CreateCards_NewCardSettings(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	userStore := memory.NewUserStore(backend, bcrypt.MinCost)
+	memoStore := memory.NewMemoStore(backend)
+	cardStore := memory.NewCardStore(backend)
+	statsStore := memory.NewUserCardStatsStore(backend)
+	deckStore := memory.NewDeckStore(backend)
+
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	boolPtr := func(v bool) *bool { return &v }
+
+	newUser := func(t *testing.T, email string, overrides domain.NewCardOverrides) *domain.User {
+		user, err := domain.NewUser(email, "new-card-settings-password")
+		require.NoError(t, err)
+		user.NewCardOverrides = overrides
+		require.NoError(t, userStore.Create(ctx, user))
+		return user
+	}
+
+	newService := func(t *testing.T, opts ...service.CardServiceOption) service.CardService {
+		cardService, err := service.NewCardService(
+			service.NewCardRepositoryAdapter(cardStore, backend.DB()),
+			service.NewStatsRepositoryAdapter(statsStore),
+			service.NewMemoRepositoryAdapter(memoStore, backend.DB()),
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+			opts...,
+		)
+		require.NoError(t, err)
+		return cardService
+	}
+
+	// createCard creates one card through the service and returns its stats
+	createCard := func(
+		t *testing.T,
+		cardService service.CardService,
+		user *domain.User,
+		deck *domain.Deck,
+	) *domain.UserCardStats {
+		memo, err := domain.NewMemo(user.ID, "Notes")
+		require.NoError(t, err)
+		require.NoError(t, memoStore.Create(ctx, memo))
+
+		card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+		require.NoError(t, err)
+		if deck != nil {
+			card.DeckID = &deck.ID
+		}
+
+		require.NoError(t, cardService.CreateCards(ctx, []*domain.Card{card}))
+
+		stats, err := statsStore.Get(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+		return stats
+	}
+
+	// assertStats checks the stats against settings, relative to when they were created
+	assertStats := func(t *testing.T, want domain.NewCardSettings, stats *domain.UserCardStats) {
+		assert.Equal(t, want.IntervalDays, stats.Interval)
+		assert.InDelta(t, want.EaseFactor, stats.EaseFactor, 1e-9)
+		wantDue := stats.CreatedAt
+		if !want.DueImmediately {
+			wantDue = stats.CreatedAt.AddDate(0, 0, want.IntervalDays)
+		}
+		assert.WithinDuration(t, wantDue, stats.NextReviewAt, time.Millisecond)
+	}
+
+	overrides := domain.NewCardOverrides{
+		IntervalDays:   intPtr(2),
+		EaseFactor:     floatPtr(2.1),
+		DueImmediately: boolPtr(false),
+	}
+
+	t.Run("defaults without overrides", func(t *testing.T) {
+		user := newUser(t, "defaults@example.com", domain.NewCardOverrides{})
+		stats := createCard(t, newService(t, service.WithNewCardSettings(userStore, deckStore)), user, nil)
+		assertStats(t, domain.DefaultNewCardSettings(), stats)
+	})
+
+	t.Run("user overrides", func(t *testing.T) {
+		user := newUser(t, "user-overrides@example.com", overrides)
+		stats := createCard(t, newService(t, service.WithNewCardSettings(userStore, deckStore)), user, nil)
+		assertStats(t, domain.NewCardSettings{IntervalDays: 2, EaseFactor: 2.1, DueImmediately: false}, stats)
+	})
+
+	t.Run("deck overrides take precedence", func(t *testing.T) {
+		user := newUser(t, "deck-overrides@example.com", overrides)
+		deck, err := domain.NewDeck(user.ID, "Spanish")
+		require.NoError(t, err)
+		require.NoError(t, deck.SetSRSSettings(domain.DeckSRSSettings{
+			NewCardIntervalDays: intPtr(4),
+			InitialEaseFactor:   floatPtr(1.9),
+		}))
+		require.NoError(t, deckStore.Create(ctx, deck))
+
+		stats := createCard(t, newService(t, service.WithNewCardSettings(userStore, deckStore)), user, deck)
+		assertStats(t, domain.NewCardSettings{IntervalDays: 4, EaseFactor: 1.9, DueImmediately: false}, stats)
+	})
+
+	t.Run("overrides are ignored when not configured", func(t *testing.T) {
+		user := newUser(t, "unconfigured@example.com", overrides)
+		stats := createCard(t, newService(t), user, nil)
+		assertStats(t, domain.DefaultNewCardSettings(), stats)
+	})
+}
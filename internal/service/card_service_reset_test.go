@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestCardService_ResetProgress checks that resetting a card returns its stats
+// to those of a new card that is due now, and keeps its review history
+func TestCardService_ResetProgress(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	userStore := memory.NewUserStore(backend, bcrypt.MinCost)
+	memoStore := memory.NewMemoStore(backend)
+	cardStore := memory.NewCardStore(backend)
+	statsStore := memory.NewUserCardStatsStore(backend)
+	eventStore := memory.NewReviewEventStore(backend)
+
+	user, err := domain.NewUser("reset@example.com", "reset-progress-password")
+	require.NoError(t, err)
+	require.NoError(t, userStore.Create(ctx, user))
+
+	cardService, err := service.NewCardService(
+		service.NewCardRepositoryAdapter(cardStore, backend.DB()),
+		service.NewStatsRepositoryAdapter(statsStore),
+		service.NewMemoRepositoryAdapter(memoStore, backend.DB()),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		service.WithNewCardSettings(userStore, nil),
+	)
+	require.NoError(t, err)
+
+	memo, err := domain.NewMemo(user.ID, "Notes")
+	require.NoError(t, err)
+	require.NoError(t, memoStore.Create(ctx, memo))
+	card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+	require.NoError(t, err)
+	require.NoError(t, cardService.CreateCards(ctx, []*domain.Card{card}))
+
+	// The card has been learned well, and is not due for weeks
+	original, err := statsStore.Get(ctx, user.ID, card.ID)
+	require.NoError(t, err)
+	learned := *original
+	learned.Interval = 30
+	learned.EaseFactor = 2.8
+	learned.ConsecutiveCorrect = 5
+	learned.ReviewCount = 7
+	learned.Lapses = 1
+	learned.Phase = domain.CardPhaseReview
+	learned.LastReviewedAt = time.Now().UTC().Add(-time.Hour)
+	learned.NextReviewAt = time.Now().UTC().AddDate(0, 0, 30)
+	require.NoError(t, statsStore.Update(ctx, &learned))
+
+	event, err := domain.NewReviewEvent(user.ID, card.ID, domain.ReviewOutcomeGood, learned.LastReviewedAt)
+	require.NoError(t, err)
+	require.NoError(t, eventStore.Create(ctx, event))
+
+	t.Run("resets stats and makes the card due now", func(t *testing.T) {
+		before := time.Now().UTC()
+		reset, err := cardService.ResetProgress(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+
+		initial := domain.DefaultNewCardSettings()
+		stats, err := statsStore.Get(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+		for _, s := range []*domain.UserCardStats{reset, stats} {
+			assert.Equal(t, initial.IntervalDays, s.Interval)
+			assert.Equal(t, initial.EaseFactor, s.EaseFactor)
+			assert.Zero(t, s.ConsecutiveCorrect)
+			assert.Zero(t, s.ReviewCount)
+			assert.Zero(t, s.Lapses)
+			assert.Equal(t, domain.CardPhaseLearning, s.Phase)
+			assert.True(t, s.LastReviewedAt.IsZero())
+			assert.False(t, s.NextReviewAt.Before(before), "The card should be due from now")
+			assert.False(t, s.NextReviewAt.After(time.Now().UTC()), "The card should be due immediately")
+		}
+		assert.True(t, original.CreatedAt.Equal(stats.CreatedAt), "The stats keep their creation time")
+
+		events, err := eventStore.ListByCard(ctx, card.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 1, "The review history is kept")
+		assert.Equal(t, event.ID, events[0].ID)
+	})
+
+	t.Run("uses the user's new card ease and interval but is still due now", func(t *testing.T) {
+		interval, ease, dueImmediately := 4, 2.0, false
+		user.NewCardOverrides = domain.NewCardOverrides{
+			IntervalDays:   &interval,
+			EaseFactor:     &ease,
+			DueImmediately: &dueImmediately,
+		}
+		require.NoError(t, userStore.Update(ctx, user))
+
+		reset, err := cardService.ResetProgress(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, interval, reset.Interval)
+		assert.Equal(t, ease, reset.EaseFactor)
+		assert.False(t, reset.NextReviewAt.After(time.Now().UTC()), "A reset card is due immediately")
+	})
+
+	t.Run("card not owned", func(t *testing.T) {
+		_, err := cardService.ResetProgress(ctx, uuid.New(), card.ID)
+		assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		_, err := cardService.ResetProgress(ctx, user.ID, uuid.New())
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+	})
+}
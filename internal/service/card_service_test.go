@@ -3,11 +3,19 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Note: We're skipping transaction-based tests in this package since they're better suited
@@ -31,6 +39,61 @@ func (m *MockCardRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	return card, args.Error(1)
 }
 
+// UpdateContent implements CardRepository
+func (m *MockCardRepository) UpdateContent(
+	ctx context.Context,
+	id uuid.UUID,
+	content []byte,
+	expectedVersion int,
+) (int, error) {
+	args := m.Called(ctx, id, content, expectedVersion)
+	return args.Int(0), args.Error(1)
+}
+
+// SetMemo implements CardRepository
+func (m *MockCardRepository) SetMemo(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error {
+	args := m.Called(ctx, id, memoID)
+	return args.Error(0)
+}
+
+// SetSuspended implements CardRepository
+func (m *MockCardRepository) SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error {
+	args := m.Called(ctx, id, suspendedAt)
+	return args.Error(0)
+}
+
+// SetBuriedUntil implements CardRepository
+func (m *MockCardRepository) SetBuriedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error {
+	args := m.Called(ctx, id, until)
+	return args.Error(0)
+}
+
+// ListByTag implements CardRepository
+func (m *MockCardRepository) ListByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	args := m.Called(ctx, userID, tag, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Card), args.Error(1)
+}
+
+// SupersedeByMemo implements CardRepository
+func (m *MockCardRepository) SupersedeByMemo(ctx context.Context, memoID uuid.UUID, at time.Time) (int, error) {
+	args := m.Called(ctx, memoID, at)
+	return args.Int(0), args.Error(1)
+}
+
+// DeleteByMemo implements CardRepository
+func (m *MockCardRepository) DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error) {
+	args := m.Called(ctx, memoID)
+	return args.Int(0), args.Error(1)
+}
+
 // WithTx implements CardRepository
 func (m *MockCardRepository) WithTx(tx *sql.Tx) CardRepository {
 	args := m.Called(tx)
@@ -88,3 +151,503 @@ func TestCardService_GetCard(t *testing.T) {
 	// Skip test with transaction mocking - this would be tested in an integration test
 	t.Skip("Skipping test that requires transaction management")
 }
+
+func TestCardService_UpdateCardContent(t *testing.T) {
+	userID := uuid.New()
+	newContent := json.RawMessage(`{"front":"Updated front","back":"Updated back"}`)
+
+	newService := func(t *testing.T, cardRepo *MockCardRepository) CardService {
+		t.Helper()
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil)
+		require.NoError(t, err)
+		return svc
+	}
+	newCard := func(ownerID uuid.UUID, version int) *domain.Card {
+		return &domain.Card{
+			ID:      uuid.New(),
+			UserID:  ownerID,
+			MemoID:  uuid.New(),
+			Content: json.RawMessage(`{"front":"Front","back":"Back"}`),
+			Version: version,
+		}
+	}
+
+	t.Run("increments version", func(t *testing.T) {
+		card := newCard(userID, 3)
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		cardRepo.On("UpdateContent", mock.Anything, card.ID, []byte(newContent), 3).Return(4, nil)
+
+		updated, err := newService(t, cardRepo).UpdateCardContent(
+			context.Background(), userID, card.ID, newContent, 3,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, updated.Version)
+		assert.JSONEq(t, string(newContent), string(updated.Content))
+		cardRepo.AssertExpectations(t)
+	})
+
+	t.Run("stale version conflicts", func(t *testing.T) {
+		card := newCard(userID, 5)
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		cardRepo.On("UpdateContent", mock.Anything, card.ID, []byte(newContent), 4).
+			Return(0, store.ErrVersionConflict)
+
+		updated, err := newService(t, cardRepo).UpdateCardContent(
+			context.Background(), userID, card.ID, newContent, 4,
+		)
+
+		assert.Nil(t, updated)
+		assert.ErrorIs(t, err, store.ErrVersionConflict)
+	})
+
+	t.Run("card owned by another user", func(t *testing.T) {
+		card := newCard(uuid.New(), 1)
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+
+		_, err := newService(t, cardRepo).UpdateCardContent(
+			context.Background(), userID, card.ID, newContent, 1,
+		)
+
+		assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+		cardRepo.AssertNotCalled(t, "UpdateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		cardID := uuid.New()
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, cardID).Return(nil, store.ErrCardNotFound)
+
+		_, err := newService(t, cardRepo).UpdateCardContent(
+			context.Background(), userID, cardID, newContent, 1,
+		)
+
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+	})
+
+	t.Run("invalid content", func(t *testing.T) {
+		card := newCard(userID, 1)
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+
+		_, err := newService(t, cardRepo).UpdateCardContent(
+			context.Background(), userID, card.ID, json.RawMessage(`not json`), 1,
+		)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCardContent)
+		cardRepo.AssertNotCalled(t, "UpdateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing required field", func(t *testing.T) {
+		card := newCard(userID, 1)
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+
+		_, err := newService(t, cardRepo).UpdateCardContent(
+			context.Background(), userID, card.ID, json.RawMessage(`{"front":"Front only"}`), 1,
+		)
+
+		var validationErr *domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "content.back", validationErr.Field)
+		assert.ErrorIs(t, err, domain.ErrInvalidCardContent)
+		cardRepo.AssertNotCalled(t, "UpdateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("oversized content", func(t *testing.T) {
+		cardRepo := &MockCardRepository{}
+		oversized := json.RawMessage(`{"front":"` +
+			strings.Repeat("x", domain.DefaultMaxCardContentBytes) + `","back":"Back"}`)
+
+		_, err := newService(t, cardRepo).UpdateCardContent(
+			context.Background(), userID, uuid.New(), oversized, 1,
+		)
+
+		var validationErr *domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "content", validationErr.Field)
+		assert.ErrorIs(t, err, domain.ErrCardContentTooLarge)
+		cardRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		cardRepo.AssertNotCalled(t, "UpdateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCardService_MoveCardToMemo(t *testing.T) {
+	userID := uuid.New()
+
+	newService := func(t *testing.T, cardRepo *MockCardRepository, memoRepo *MockMemoRepository) CardService {
+		t.Helper()
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, memoRepo, nil)
+		require.NoError(t, err)
+		return svc
+	}
+	newCard := func(ownerID uuid.UUID) *domain.Card {
+		return &domain.Card{
+			ID:      uuid.New(),
+			UserID:  ownerID,
+			MemoID:  uuid.New(),
+			Content: json.RawMessage(`{"front":"Front","back":"Back"}`),
+			Version: 1,
+		}
+	}
+
+	t.Run("moves card", func(t *testing.T) {
+		card := newCard(userID)
+		memo := &domain.Memo{ID: uuid.New(), UserID: userID}
+		moved := *card
+		moved.MemoID = memo.ID
+
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil).Once()
+		cardRepo.On("SetMemo", mock.Anything, card.ID, memo.ID).Return(nil)
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(&moved, nil).Once()
+		memoRepo := &MockMemoRepository{}
+		memoRepo.On("GetByID", mock.Anything, memo.ID).Return(memo, nil)
+
+		result, err := newService(t, cardRepo, memoRepo).MoveCardToMemo(
+			context.Background(), userID, card.ID, memo.ID,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, memo.ID, result.MemoID)
+		cardRepo.AssertExpectations(t)
+	})
+
+	t.Run("already in memo", func(t *testing.T) {
+		card := newCard(userID)
+		memo := &domain.Memo{ID: card.MemoID, UserID: userID}
+
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		memoRepo := &MockMemoRepository{}
+		memoRepo.On("GetByID", mock.Anything, memo.ID).Return(memo, nil)
+
+		result, err := newService(t, cardRepo, memoRepo).MoveCardToMemo(
+			context.Background(), userID, card.ID, memo.ID,
+		)
+
+		require.NoError(t, err)
+		assert.Same(t, card, result)
+		cardRepo.AssertNotCalled(t, "SetMemo", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("card owned by another user", func(t *testing.T) {
+		card := newCard(uuid.New())
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		memoRepo := &MockMemoRepository{}
+
+		_, err := newService(t, cardRepo, memoRepo).MoveCardToMemo(
+			context.Background(), userID, card.ID, uuid.New(),
+		)
+
+		assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+		memoRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("memo owned by another user", func(t *testing.T) {
+		card := newCard(userID)
+		memo := &domain.Memo{ID: uuid.New(), UserID: uuid.New()}
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		memoRepo := &MockMemoRepository{}
+		memoRepo.On("GetByID", mock.Anything, memo.ID).Return(memo, nil)
+
+		_, err := newService(t, cardRepo, memoRepo).MoveCardToMemo(
+			context.Background(), userID, card.ID, memo.ID,
+		)
+
+		assert.ErrorIs(t, err, ErrMemoNotOwned)
+		cardRepo.AssertNotCalled(t, "SetMemo", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("memo not found", func(t *testing.T) {
+		card := newCard(userID)
+		memoID := uuid.New()
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		memoRepo := &MockMemoRepository{}
+		memoRepo.On("GetByID", mock.Anything, memoID).Return(nil, store.ErrMemoNotFound)
+
+		_, err := newService(t, cardRepo, memoRepo).MoveCardToMemo(
+			context.Background(), userID, card.ID, memoID,
+		)
+
+		assert.ErrorIs(t, err, store.ErrMemoNotFound)
+	})
+
+	t.Run("memo deleted before move", func(t *testing.T) {
+		card := newCard(userID)
+		memo := &domain.Memo{ID: uuid.New(), UserID: userID}
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		cardRepo.On("SetMemo", mock.Anything, card.ID, memo.ID).Return(store.ErrReferencedEntityMissing)
+		memoRepo := &MockMemoRepository{}
+		memoRepo.On("GetByID", mock.Anything, memo.ID).Return(memo, nil)
+
+		_, err := newService(t, cardRepo, memoRepo).MoveCardToMemo(
+			context.Background(), userID, card.ID, memo.ID,
+		)
+
+		assert.ErrorIs(t, err, store.ErrMemoNotFound)
+	})
+}
+
+func TestCardService_SuspendCard(t *testing.T) {
+	userID := uuid.New()
+
+	newService := func(t *testing.T, cardRepo *MockCardRepository) CardService {
+		t.Helper()
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil)
+		require.NoError(t, err)
+		return svc
+	}
+	newCard := func(ownerID uuid.UUID, suspendedAt *time.Time) *domain.Card {
+		return &domain.Card{
+			ID:          uuid.New(),
+			UserID:      ownerID,
+			MemoID:      uuid.New(),
+			Content:     json.RawMessage(`{"front":"Front","back":"Back"}`),
+			Version:     1,
+			SuspendedAt: suspendedAt,
+		}
+	}
+
+	t.Run("suspends card", func(t *testing.T) {
+		card := newCard(userID, nil)
+		suspendedAt := time.Now().UTC()
+		suspended := *card
+		suspended.SuspendedAt = &suspendedAt
+
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil).Once()
+		cardRepo.On("SetSuspended", mock.Anything, card.ID, mock.MatchedBy(func(at *time.Time) bool {
+			return at != nil
+		})).Return(nil)
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(&suspended, nil).Once()
+
+		updated, err := newService(t, cardRepo).SuspendCard(context.Background(), userID, card.ID)
+
+		require.NoError(t, err)
+		assert.True(t, updated.IsSuspended())
+		cardRepo.AssertExpectations(t)
+	})
+
+	t.Run("unsuspends card", func(t *testing.T) {
+		suspendedAt := time.Now().UTC()
+		card := newCard(userID, &suspendedAt)
+		unsuspended := *card
+		unsuspended.SuspendedAt = nil
+
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil).Once()
+		cardRepo.On("SetSuspended", mock.Anything, card.ID, (*time.Time)(nil)).Return(nil)
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(&unsuspended, nil).Once()
+
+		updated, err := newService(t, cardRepo).UnsuspendCard(context.Background(), userID, card.ID)
+
+		require.NoError(t, err)
+		assert.False(t, updated.IsSuspended())
+		cardRepo.AssertExpectations(t)
+	})
+
+	t.Run("already in requested state", func(t *testing.T) {
+		suspendedAt := time.Now().UTC()
+		suspendedCard := newCard(userID, &suspendedAt)
+		activeCard := newCard(userID, nil)
+
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, suspendedCard.ID).Return(suspendedCard, nil)
+		cardRepo.On("GetByID", mock.Anything, activeCard.ID).Return(activeCard, nil)
+		svc := newService(t, cardRepo)
+
+		card, err := svc.SuspendCard(context.Background(), userID, suspendedCard.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &suspendedAt, card.SuspendedAt, "Suspension time should be kept")
+
+		card, err = svc.UnsuspendCard(context.Background(), userID, activeCard.ID)
+		require.NoError(t, err)
+		assert.False(t, card.IsSuspended())
+
+		cardRepo.AssertNotCalled(t, "SetSuspended", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("card owned by another user", func(t *testing.T) {
+		card := newCard(uuid.New(), nil)
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+
+		_, err := newService(t, cardRepo).SuspendCard(context.Background(), userID, card.ID)
+
+		assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+		cardRepo.AssertNotCalled(t, "SetSuspended", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		cardID := uuid.New()
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, cardID).Return(nil, store.ErrCardNotFound)
+
+		_, err := newService(t, cardRepo).UnsuspendCard(context.Background(), userID, cardID)
+
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+	})
+}
+
+func TestCardService_GetUserCard(t *testing.T) {
+	userID := uuid.New()
+	card := &domain.Card{
+		ID:      uuid.New(),
+		UserID:  userID,
+		MemoID:  uuid.New(),
+		Content: json.RawMessage(`{"front":"Front","back":"Back"}`),
+		Version: 1,
+	}
+	missingID := uuid.New()
+
+	cardRepo := &MockCardRepository{}
+	cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+	cardRepo.On("GetByID", mock.Anything, missingID).Return(nil, store.ErrCardNotFound)
+	svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil)
+	require.NoError(t, err)
+
+	got, err := svc.GetUserCard(context.Background(), userID, card.ID)
+	require.NoError(t, err)
+	assert.Equal(t, card, got)
+
+	_, err = svc.GetUserCard(context.Background(), uuid.New(), card.ID)
+	assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+
+	_, err = svc.GetUserCard(context.Background(), userID, missingID)
+	assert.ErrorIs(t, err, store.ErrCardNotFound)
+}
+
+// TestCardService_BuryCard tests hiding a card until the end of the user's day
+func TestCardService_BuryCard(t *testing.T) {
+	userID := uuid.New()
+
+	newCard := func(ownerID uuid.UUID) *domain.Card {
+		return &domain.Card{
+			ID:      uuid.New(),
+			UserID:  ownerID,
+			MemoID:  uuid.New(),
+			Content: json.RawMessage(`{"front":"Front","back":"Back"}`),
+			Version: 1,
+		}
+	}
+
+	t.Run("buries card until the user's next midnight", func(t *testing.T) {
+		user := &domain.User{ID: userID, Email: "bury@example.com", Timezone: "Asia/Tokyo"}
+		userStore := mocks.NewMockUserStore()
+		userStore.Users[user.Email] = user
+
+		card := newCard(userID)
+		var buriedUntil *time.Time
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		cardRepo.On("SetBuriedUntil", mock.Anything, card.ID, mock.Anything).
+			Run(func(args mock.Arguments) {
+				buriedUntil = args.Get(2).(*time.Time)
+			}).
+			Return(nil)
+
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil,
+			WithCardUserStore(userStore))
+		require.NoError(t, err)
+
+		before := time.Now()
+		_, err = svc.BuryCard(context.Background(), userID, card.ID)
+		after := time.Now()
+
+		require.NoError(t, err)
+		require.NotNil(t, buriedUntil)
+		// The day cannot realistically roll over during the call, but allow for it
+		assert.Contains(t, []time.Time{user.EndOfDay(before), user.EndOfDay(after)}, *buriedUntil)
+
+		local := buriedUntil.In(user.Location())
+		assert.Equal(t, 0, local.Hour()*60+local.Minute(), "Burial should end at local midnight")
+		assert.True(t, buriedUntil.After(after))
+		assert.False(t, buriedUntil.After(after.Add(24*time.Hour)))
+
+		// The card is buried for the rest of the day and reappears at midnight
+		buried := *card
+		buried.BuriedUntil = buriedUntil
+		assert.True(t, buried.IsBuried(after))
+		assert.False(t, buried.IsBuried(*buriedUntil))
+	})
+
+	t.Run("days end at midnight UTC without a user store", func(t *testing.T) {
+		card := newCard(userID)
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+		cardRepo.On("SetBuriedUntil", mock.Anything, card.ID, mock.MatchedBy(func(until *time.Time) bool {
+			return until != nil && until.Equal(until.Truncate(24*time.Hour)) && until.After(time.Now())
+		})).Return(nil)
+
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil)
+		require.NoError(t, err)
+
+		_, err = svc.BuryCard(context.Background(), userID, card.ID)
+
+		require.NoError(t, err)
+		cardRepo.AssertExpectations(t)
+	})
+
+	t.Run("card owned by another user", func(t *testing.T) {
+		card := newCard(uuid.New())
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil)
+		require.NoError(t, err)
+
+		_, err = svc.BuryCard(context.Background(), userID, card.ID)
+
+		assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+		cardRepo.AssertNotCalled(t, "SetBuriedUntil", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		cardID := uuid.New()
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("GetByID", mock.Anything, cardID).Return(nil, store.ErrCardNotFound)
+
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil)
+		require.NoError(t, err)
+
+		_, err = svc.BuryCard(context.Background(), userID, cardID)
+
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+	})
+}
+
+func TestCardService_ListCardsByTag(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("normalizes the tag", func(t *testing.T) {
+		card := &domain.Card{ID: uuid.New(), UserID: userID}
+		cardRepo := &MockCardRepository{}
+		cardRepo.On("ListByTag", mock.Anything, userID, domain.LeechTag, 50, 10).
+			Return([]*domain.Card{card}, nil)
+
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil)
+		require.NoError(t, err)
+
+		cards, err := svc.ListCardsByTag(context.Background(), userID, " Leech ", 50, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.Card{card}, cards)
+		cardRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid tag", func(t *testing.T) {
+		cardRepo := &MockCardRepository{}
+		svc, err := NewCardService(cardRepo, &MockStatsRepository{}, &MockMemoRepository{}, nil)
+		require.NoError(t, err)
+
+		_, err = svc.ListCardsByTag(context.Background(), userID, "leech!", 50, 0)
+		assert.ErrorIs(t, err, domain.ErrInvalidTag, "The store is not queried for an invalid tag")
+	})
+}
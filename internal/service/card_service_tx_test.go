@@ -15,6 +15,7 @@ import (
 	"github.com/phrazzld/scry-api/internal/domain"
 	"github.com/phrazzld/scry-api/internal/platform/postgres"
 	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
 	"github.com/phrazzld/scry-api/internal/store"
 	"github.com/phrazzld/scry-api/internal/testutils"
 	"github.com/stretchr/testify/assert"
@@ -48,6 +49,60 @@ func (m *MockFailingCardRepository) GetByID(
 	return m.CardStore.GetByID(ctx, id)
 }
 
+func (m *MockFailingCardRepository) UpdateContent(
+	ctx context.Context,
+	id uuid.UUID,
+	content []byte,
+	expectedVersion int,
+) (int, error) {
+	return m.CardStore.UpdateContent(ctx, id, content, expectedVersion)
+}
+
+func (m *MockFailingCardRepository) SetMemo(
+	ctx context.Context,
+	id uuid.UUID,
+	memoID uuid.UUID,
+) error {
+	return m.CardStore.SetMemo(ctx, id, memoID)
+}
+
+func (m *MockFailingCardRepository) SetSuspended(
+	ctx context.Context,
+	id uuid.UUID,
+	suspendedAt *time.Time,
+) error {
+	return m.CardStore.SetSuspended(ctx, id, suspendedAt)
+}
+
+func (m *MockFailingCardRepository) SetBuriedUntil(
+	ctx context.Context,
+	id uuid.UUID,
+	until *time.Time,
+) error {
+	return m.CardStore.SetBuriedUntil(ctx, id, until)
+}
+
+func (m *MockFailingCardRepository) ListByTag(
+	ctx context.Context,
+	userID uuid.UUID,
+	tag string,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	return m.CardStore.ListByTag(ctx, userID, tag, limit, offset)
+}
+
+func (m *MockFailingCardRepository) SupersedeByMemo(
+	ctx context.Context,
+	memoID uuid.UUID,
+	at time.Time,
+) (int, error) {
+	return m.CardStore.SupersedeByMemo(ctx, memoID, at)
+}
+
+func (m *MockFailingCardRepository) DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error) {
+	return m.CardStore.DeleteByMemo(ctx, memoID)
+}
+
 func (m *MockFailingCardRepository) WithTx(tx *sql.Tx) service.CardRepository {
 	return &MockFailingCardRepository{
 		CardStore:         m.CardStore.WithTx(tx),
@@ -180,6 +235,7 @@ func TestCardService_CreateCards_Atomicity(t *testing.T) {
 		// Setup base stores with transaction
 		cardStore := postgres.NewPostgresCardStore(tx, logger)
 		statsStore := postgres.NewPostgresUserCardStatsStore(tx, logger)
+		memoRepo := service.NewMemoRepositoryAdapter(postgres.NewPostgresMemoStore(tx, logger), db)
 
 		// Helper function to create test cards
 		createTestCards := func(count int) []*domain.Card {
@@ -214,7 +270,7 @@ func TestCardService_CreateCards_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the failing repository
-			cardService, err := service.NewCardService(failingCardRepo, statsRepo, logger)
+			cardService, err := service.NewCardService(failingCardRepo, statsRepo, memoRepo, logger)
 			require.NoError(t, err, "Failed to create card service")
 
 			// Create test cards
@@ -276,7 +332,7 @@ func TestCardService_CreateCards_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the repositories
-			cardService, err := service.NewCardService(cardRepo, statsRepo, logger)
+			cardService, err := service.NewCardService(cardRepo, statsRepo, memoRepo, logger)
 			require.NoError(t, err, "Failed to create card service")
 
 			// Create test cards
@@ -338,7 +394,7 @@ func TestCardService_CreateCards_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the successful repositories
-			cardService, err := service.NewCardService(cardRepo, adapter, logger)
+			cardService, err := service.NewCardService(cardRepo, adapter, memoRepo, logger)
 			require.NoError(t, err, "Failed to create card service")
 
 			// Create test cards
@@ -412,3 +468,72 @@ func (a *statsRepositoryAdapter) WithTx(tx *sql.Tx) service.StatsRepository {
 		statsStore: a.statsStore.WithTx(tx),
 	}
 }
+
+// TestCardService_MoveCardToMemo_Integration tests moving a card between memos against the database
+func TestCardService_MoveCardToMemo_Integration(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+
+		ownerID := testutils.MustInsertUser(ctx, t, tx, "card-move-owner@example.com", bcrypt.MinCost)
+		otherID := testutils.MustInsertUser(ctx, t, tx, "card-move-other@example.com", bcrypt.MinCost)
+		sourceMemo := testutils.MustInsertMemo(ctx, t, tx, ownerID)
+		targetMemo := testutils.MustInsertMemo(ctx, t, tx, ownerID)
+		otherMemo := testutils.MustInsertMemo(ctx, t, tx, otherID)
+		card := testutils.MustInsertCard(ctx, t, tx, ownerID, sourceMemo.ID)
+		otherCard := testutils.MustInsertCard(ctx, t, tx, otherID, otherMemo.ID)
+
+		cardStore := postgres.NewPostgresCardStore(tx, logger)
+		cardService, err := service.NewCardService(
+			service.NewCardRepositoryAdapter(cardStore, db),
+			service.NewStatsRepositoryAdapter(postgres.NewPostgresUserCardStatsStore(tx, logger)),
+			service.NewMemoRepositoryAdapter(postgres.NewPostgresMemoStore(tx, logger), db),
+			logger,
+		)
+		require.NoError(t, err)
+
+		t.Run("card_owned_by_another_user", func(t *testing.T) {
+			_, err := cardService.MoveCardToMemo(ctx, ownerID, otherCard.ID, targetMemo.ID)
+			assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+		})
+
+		t.Run("memo_owned_by_another_user", func(t *testing.T) {
+			_, err := cardService.MoveCardToMemo(ctx, ownerID, card.ID, otherMemo.ID)
+			assert.ErrorIs(t, err, service.ErrMemoNotOwned)
+
+			unchanged, err := cardStore.GetByID(ctx, card.ID)
+			require.NoError(t, err)
+			assert.Equal(t, sourceMemo.ID, unchanged.MemoID)
+		})
+
+		t.Run("memo_not_found", func(t *testing.T) {
+			_, err := cardService.MoveCardToMemo(ctx, ownerID, card.ID, uuid.New())
+			assert.ErrorIs(t, err, store.ErrMemoNotFound)
+		})
+
+		t.Run("card_not_found", func(t *testing.T) {
+			_, err := cardService.MoveCardToMemo(ctx, ownerID, uuid.New(), targetMemo.ID)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+		})
+
+		t.Run("moves_card", func(t *testing.T) {
+			moved, err := cardService.MoveCardToMemo(ctx, ownerID, card.ID, targetMemo.ID)
+			require.NoError(t, err)
+			assert.Equal(t, targetMemo.ID, moved.MemoID)
+			assert.False(t, moved.UpdatedAt.Before(card.UpdatedAt), "updated_at should be bumped")
+
+			stored, err := cardStore.GetByID(ctx, card.ID)
+			require.NoError(t, err)
+			assert.Equal(t, targetMemo.ID, stored.MemoID)
+		})
+	})
+}
@@ -0,0 +1,461 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// ErrDeckNotOwned indicates that the user does not own the deck.
+var ErrDeckNotOwned = errors.New("unauthorized access: deck not owned by user")
+
+// Per-card outcomes reported by DeckService.MoveCards
+const (
+	CardMoveStatusMoved    = "moved"
+	CardMoveStatusNotFound = "not_found"
+	CardMoveStatusNotOwned = "not_owned"
+)
+
+// CardMoveResult reports what happened to one card in DeckService.MoveCards
+type CardMoveResult struct {
+	CardID uuid.UUID
+	Status string
+}
+
+// DeckService manages a user's decks and the assignment of cards and memos to them.
+// Every method is scoped to the requesting user: decks and cards owned by
+// another user are reported as ErrDeckNotOwned or card_review.ErrCardNotOwned.
+type DeckService interface {
+	// CreateDeck creates a new deck with the given name for the user.
+	// Returns a validation error if the name is invalid and
+	// store.ErrDeckNameExists if the user already has a deck with that name.
+	CreateDeck(ctx context.Context, userID uuid.UUID, name string) (*domain.Deck, error)
+
+	// ListDecks returns all of the user's decks ordered by name.
+	ListDecks(ctx context.Context, userID uuid.UUID) ([]*domain.Deck, error)
+
+	// GetDeck returns one of the user's decks.
+	// Returns store.ErrDeckNotFound if the deck does not exist and
+	// ErrDeckNotOwned if it belongs to another user.
+	GetDeck(ctx context.Context, userID, deckID uuid.UUID) (*domain.Deck, error)
+
+	// RenameDeck changes the name of one of the user's decks.
+	// Returns the same errors as GetDeck and CreateDeck.
+	RenameDeck(ctx context.Context, userID, deckID uuid.UUID, name string) (*domain.Deck, error)
+
+	// UpdateSRSSettings replaces the SRS overrides of one of the user's decks.
+	// Returns a validation error if an override is out of range, and the same
+	// deck errors as GetDeck.
+	UpdateSRSSettings(
+		ctx context.Context,
+		userID, deckID uuid.UUID,
+		settings domain.DeckSRSSettings,
+	) (*domain.Deck, error)
+
+	// DeleteDeck deletes one of the user's decks. Its cards are kept and no
+	// longer belong to any deck.
+	// Returns the same errors as GetDeck.
+	DeleteDeck(ctx context.Context, userID, deckID uuid.UUID) error
+
+	// ListDeckCards returns a page of the cards in one of the user's decks.
+	// Returns the same errors as GetDeck.
+	ListDeckCards(ctx context.Context, userID, deckID uuid.UUID, limit, offset int) ([]*domain.Card, error)
+
+	// AssignCard moves one of the user's cards into a deck, or out of its
+	// deck when deckID is nil.
+	// Returns store.ErrCardNotFound if the card does not exist,
+	// card_review.ErrCardNotOwned if it belongs to another user, and the same
+	// deck errors as GetDeck.
+	AssignCard(ctx context.Context, userID, cardID uuid.UUID, deckID *uuid.UUID) (*domain.Card, error)
+
+	// AssignMemo moves one of the user's memos into a deck, or out of its deck
+	// when deckID is nil. Cards generated from the memo afterwards are placed
+	// in its deck; its existing cards stay where they are.
+	// Returns store.ErrMemoNotFound if the memo does not exist,
+	// ErrMemoNotOwned if it belongs to another user, and the same deck errors
+	// as GetDeck.
+	AssignMemo(ctx context.Context, userID, memoID uuid.UUID, deckID *uuid.UUID) (*domain.Memo, error)
+
+	// MoveCards moves the listed cards into one of the user's decks in a single
+	// transaction. Cards that do not exist or belong to another user are skipped
+	// and reported as CardMoveStatusNotFound or CardMoveStatusNotOwned; every other
+	// card is moved. Results follow the order of cardIDs, with repeated IDs
+	// reported once.
+	// Returns a validation error if cardIDs is empty, and the same deck errors as GetDeck.
+	MoveCards(ctx context.Context, userID, deckID uuid.UUID, cardIDs []uuid.UUID) ([]CardMoveResult, error)
+
+	// GetNextCard returns the next card due for review in one of the user's decks,
+	// following the same rules as card_review.CardReviewService.GetNextCard.
+	// Returns card_review.ErrNoCardsDue if no card in the deck is due, and the
+	// same errors as GetDeck.
+	GetNextCard(ctx context.Context, userID, deckID uuid.UUID) (*domain.Card, error)
+
+	// EnsureDefaultDeck returns the user's deck named domain.DefaultDeckName,
+	// creating it first if the user does not have one.
+	EnsureDefaultDeck(ctx context.Context, userID uuid.UUID) (*domain.Deck, error)
+}
+
+// deckServiceImpl implements the DeckService interface
+type deckServiceImpl struct {
+	deckStore         store.DeckStore
+	cardStore         store.CardStore
+	memoStore         store.MemoStore
+	cardReviewService card_review.CardReviewService
+	logger            *slog.Logger
+}
+
+// NewDeckService creates a new DeckService
+// It returns an error if any of the required dependencies are nil.
+func NewDeckService(
+	deckStore store.DeckStore,
+	cardStore store.CardStore,
+	memoStore store.MemoStore,
+	cardReviewService card_review.CardReviewService,
+	logger *slog.Logger,
+) (DeckService, error) {
+	if deckStore == nil {
+		return nil, fmt.Errorf("deckStore cannot be nil")
+	}
+	if cardStore == nil {
+		return nil, fmt.Errorf("cardStore cannot be nil")
+	}
+	if memoStore == nil {
+		return nil, fmt.Errorf("memoStore cannot be nil")
+	}
+	if cardReviewService == nil {
+		return nil, fmt.Errorf("cardReviewService cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &deckServiceImpl{
+		deckStore:         deckStore,
+		cardStore:         cardStore,
+		memoStore:         memoStore,
+		cardReviewService: cardReviewService,
+		logger:            logger.With("component", "deck_service"),
+	}, nil
+}
+
+// CreateDeck implements DeckService.CreateDeck
+func (s *deckServiceImpl) CreateDeck(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+) (*domain.Deck, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	deck, err := domain.NewDeck(userID, name)
+	if err != nil {
+		return nil, deckNameValidationError(err)
+	}
+
+	if err := s.deckStore.Create(ctx, deck); err != nil {
+		if !errors.Is(err, store.ErrDeckNameExists) {
+			log.Error("failed to create deck",
+				slog.String("error", err.Error()),
+				slog.String("user_id", userID.String()))
+		}
+		return nil, fmt.Errorf("failed to create deck: %w", err)
+	}
+
+	log.Info("deck created",
+		slog.String("deck_id", deck.ID.String()),
+		slog.String("user_id", userID.String()))
+	return deck, nil
+}
+
+// ListDecks implements DeckService.ListDecks
+func (s *deckServiceImpl) ListDecks(ctx context.Context, userID uuid.UUID) ([]*domain.Deck, error) {
+	decks, err := s.deckStore.ListByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to list decks",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to list decks: %w", err)
+	}
+	return decks, nil
+}
+
+// GetDeck implements DeckService.GetDeck
+func (s *deckServiceImpl) GetDeck(ctx context.Context, userID, deckID uuid.UUID) (*domain.Deck, error) {
+	deck, err := s.deckStore.GetByID(ctx, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deck: %w", err)
+	}
+	if deck.UserID != userID {
+		return nil, ErrDeckNotOwned
+	}
+	return deck, nil
+}
+
+// RenameDeck implements DeckService.RenameDeck
+func (s *deckServiceImpl) RenameDeck(
+	ctx context.Context,
+	userID, deckID uuid.UUID,
+	name string,
+) (*domain.Deck, error) {
+	deck, err := s.GetDeck(ctx, userID, deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deck.Rename(name); err != nil {
+		return nil, deckNameValidationError(err)
+	}
+
+	if err := s.deckStore.Update(ctx, deck); err != nil {
+		return nil, fmt.Errorf("failed to rename deck: %w", err)
+	}
+	return deck, nil
+}
+
+// UpdateSRSSettings implements DeckService.UpdateSRSSettings
+func (s *deckServiceImpl) UpdateSRSSettings(
+	ctx context.Context,
+	userID, deckID uuid.UUID,
+	settings domain.DeckSRSSettings,
+) (*domain.Deck, error) {
+	deck, err := s.GetDeck(ctx, userID, deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deck.SetSRSSettings(settings); err != nil {
+		return nil, deckSettingsValidationError(err)
+	}
+
+	if err := s.deckStore.Update(ctx, deck); err != nil {
+		return nil, fmt.Errorf("failed to update deck settings: %w", err)
+	}
+	return deck, nil
+}
+
+// DeleteDeck implements DeckService.DeleteDeck
+func (s *deckServiceImpl) DeleteDeck(ctx context.Context, userID, deckID uuid.UUID) error {
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return err
+	}
+
+	if err := s.deckStore.Delete(ctx, deckID); err != nil {
+		return fmt.Errorf("failed to delete deck: %w", err)
+	}
+
+	logger.FromContextOrDefault(ctx, s.logger).Info("deck deleted",
+		slog.String("deck_id", deckID.String()),
+		slog.String("user_id", userID.String()))
+	return nil
+}
+
+// ListDeckCards implements DeckService.ListDeckCards
+func (s *deckServiceImpl) ListDeckCards(
+	ctx context.Context,
+	userID, deckID uuid.UUID,
+	limit, offset int,
+) ([]*domain.Card, error) {
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return nil, err
+	}
+
+	cards, err := s.cardStore.ListByDeck(ctx, deckID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deck cards: %w", err)
+	}
+	return cards, nil
+}
+
+// AssignCard implements DeckService.AssignCard
+func (s *deckServiceImpl) AssignCard(
+	ctx context.Context,
+	userID, cardID uuid.UUID,
+	deckID *uuid.UUID,
+) (*domain.Card, error) {
+	card, err := s.cardStore.GetByID(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+	if card.UserID != userID {
+		return nil, card_review.ErrCardNotOwned
+	}
+
+	// Cards may only be placed in decks owned by the same user
+	if deckID != nil {
+		if _, err := s.GetDeck(ctx, userID, *deckID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.cardStore.SetDeck(ctx, cardID, deckID); err != nil {
+		return nil, fmt.Errorf("failed to assign card to deck: %w", err)
+	}
+
+	card.DeckID = deckID
+	return card, nil
+}
+
+// AssignMemo implements DeckService.AssignMemo
+func (s *deckServiceImpl) AssignMemo(
+	ctx context.Context,
+	userID, memoID uuid.UUID,
+	deckID *uuid.UUID,
+) (*domain.Memo, error) {
+	memo, err := s.memoStore.GetByID(ctx, memoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memo: %w", err)
+	}
+	if memo.UserID != userID {
+		return nil, ErrMemoNotOwned
+	}
+
+	// Memos may only be placed in decks owned by the same user
+	if deckID != nil {
+		if _, err := s.GetDeck(ctx, userID, *deckID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.memoStore.SetDeck(ctx, memoID, deckID); err != nil {
+		return nil, fmt.Errorf("failed to assign memo to deck: %w", err)
+	}
+
+	memo.DeckID = deckID
+	return memo, nil
+}
+
+// MoveCards implements DeckService.MoveCards
+// All cards are loaded with a single batch query inside the transaction.
+func (s *deckServiceImpl) MoveCards(
+	ctx context.Context,
+	userID, deckID uuid.UUID,
+	cardIDs []uuid.UUID,
+) ([]CardMoveResult, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if len(cardIDs) == 0 {
+		return nil, domain.NewValidationError("card_ids", "must not be empty", domain.ErrValidation)
+	}
+
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return nil, err
+	}
+
+	var results []CardMoveResult
+	err := store.RunInTransaction(ctx, s.cardStore.DB(), func(ctx context.Context, tx *sql.Tx) error {
+		txCardStore := s.cardStore.WithTx(tx)
+
+		cards, err := txCardStore.GetByIDs(ctx, cardIDs)
+		if err != nil {
+			return fmt.Errorf("failed to load cards: %w", err)
+		}
+
+		results = make([]CardMoveResult, 0, len(cardIDs))
+		seen := make(map[uuid.UUID]bool, len(cardIDs))
+		for _, id := range cardIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			card, ok := cards[id]
+			switch {
+			case !ok:
+				results = append(results, CardMoveResult{CardID: id, Status: CardMoveStatusNotFound})
+			case card.UserID != userID:
+				results = append(results, CardMoveResult{CardID: id, Status: CardMoveStatusNotOwned})
+			default:
+				if err := txCardStore.SetDeck(ctx, id, &deckID); err != nil {
+					log.Error("failed to move card to deck",
+						slog.String("error", err.Error()),
+						slog.String("card_id", id.String()),
+						slog.String("deck_id", deckID.String()))
+					return fmt.Errorf("failed to move card to deck: %w", err)
+				}
+				results = append(results, CardMoveResult{CardID: id, Status: CardMoveStatusMoved})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("moved cards to deck",
+		slog.String("user_id", userID.String()),
+		slog.String("deck_id", deckID.String()),
+		slog.Int("requested", len(cardIDs)))
+	return results, nil
+}
+
+// GetNextCard implements DeckService.GetNextCard
+func (s *deckServiceImpl) GetNextCard(ctx context.Context, userID, deckID uuid.UUID) (*domain.Card, error) {
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return nil, err
+	}
+
+	// No order is requested, so the deck's review order applies
+	return s.cardReviewService.GetNextCard(ctx, userID, &deckID, "")
+}
+
+// deckNameValidationError wraps a domain deck name error as a validation error
+// on the name field, keeping the domain error available to errors.Is.
+func deckNameValidationError(err error) error {
+	return domain.NewValidationError("name", err.Error(), err)
+}
+
+// deckSettingsValidationError converts a domain SRS settings error into a
+// ValidationError naming the offending field.
+func deckSettingsValidationError(err error) error {
+	field := "srs_settings"
+	switch {
+	case errors.Is(err, domain.ErrDeckNewCardsPerDayInvalid):
+		field = "new_cards_per_day"
+	case errors.Is(err, domain.ErrDeckAgainReviewMinutesInvalid):
+		field = "again_review_minutes"
+	case errors.Is(err, domain.ErrDeckInitialEaseFactorInvalid):
+		field = "initial_ease_factor"
+	case errors.Is(err, domain.ErrDeckLearningStepsInvalid):
+		field = "learning_steps"
+	case errors.Is(err, domain.ErrNewCardIntervalInvalid):
+		field = "new_card_interval_days"
+	case errors.Is(err, domain.ErrInvalidReviewOrder):
+		field = "review_order"
+	}
+	return domain.NewValidationError(field, err.Error(), err)
+}
+
+// EnsureDefaultDeck implements DeckService.EnsureDefaultDeck
+func (s *deckServiceImpl) EnsureDefaultDeck(ctx context.Context, userID uuid.UUID) (*domain.Deck, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	deck, err := s.deckStore.GetByName(ctx, userID, domain.DefaultDeckName)
+	if err == nil {
+		return deck, nil
+	}
+	if !errors.Is(err, store.ErrDeckNotFound) {
+		log.Error("failed to look up default deck",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, fmt.Errorf("failed to look up default deck: %w", err)
+	}
+
+	deck, err = s.CreateDeck(ctx, userID, domain.DefaultDeckName)
+	if errors.Is(err, store.ErrDeckNameExists) {
+		// Created concurrently since the lookup above
+		deck, err = s.deckStore.GetByName(ctx, userID, domain.DefaultDeckName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create default deck: %w", err)
+	}
+	return deck, nil
+}
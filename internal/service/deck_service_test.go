@@ -0,0 +1,433 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/domain/srs"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/service/card_review"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestDeckService verifies deck management, card assignment and deck-scoped
+// review, including that every operation is scoped to the deck's owner
+func TestDeckService(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	ctx := context.Background()
+
+	// Apart from MoveCards the service does not manage transactions, so everything runs in one rolled-back transaction
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback() // Intentionally ignoring error as it's cleanup code
+	}()
+
+	logger := slog.Default()
+	cardStore := postgres.NewPostgresCardStore(tx, logger)
+	memoStore := postgres.NewPostgresMemoStore(tx, logger)
+	statsStore := postgres.NewPostgresUserCardStatsStore(tx, logger)
+	srsService, err := srs.NewDefaultService()
+	require.NoError(t, err)
+	cardReviewService, err := card_review.NewCardReviewService(
+		cardStore,
+		statsStore,
+		postgres.NewPostgresReviewLogStore(tx, logger),
+		postgres.NewPostgresUserStore(tx, bcrypt.MinCost),
+		srsService,
+		logger,
+	)
+	require.NoError(t, err)
+	deckService, err := service.NewDeckService(
+		postgres.NewPostgresDeckStore(tx, logger),
+		cardStore,
+		memoStore,
+		cardReviewService,
+		logger,
+	)
+	require.NoError(t, err)
+
+	userID := testutils.MustInsertUser(ctx, t, tx, "deck-service-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	otherUserID := testutils.MustInsertUser(ctx, t, tx, "deck-other-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+
+	insertDueCard := func(ownerID uuid.UUID, nextReviewAt time.Time) *domain.Card {
+		memo := testutils.MustInsertMemo(ctx, t, tx, ownerID)
+		card, err := domain.NewCard(ownerID, memo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+		require.NoError(t, err)
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+		stats, err := domain.NewUserCardStats(ownerID, card.ID)
+		require.NoError(t, err)
+		stats.NextReviewAt = nextReviewAt
+		require.NoError(t, statsStore.Create(ctx, stats))
+		return card
+	}
+
+	deck, err := deckService.CreateDeck(ctx, userID, "  Spanish  ")
+	require.NoError(t, err)
+	assert.Equal(t, "Spanish", deck.Name)
+
+	foreignDeck, err := deckService.CreateDeck(ctx, otherUserID, "Theirs")
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	outside := insertDueCard(userID, now.Add(-2*time.Hour))
+	inDeck := insertDueCard(userID, now.Add(-time.Hour))
+	foreignCard := insertDueCard(otherUserID, now.Add(-time.Hour))
+	memo := testutils.MustInsertMemo(ctx, t, tx, userID)
+	foreignMemo := testutils.MustInsertMemo(ctx, t, tx, otherUserID)
+
+	t.Run("create_rejects_invalid_name", func(t *testing.T) {
+		_, err := deckService.CreateDeck(ctx, userID, "   ")
+		assert.ErrorIs(t, err, domain.ErrDeckNameEmpty)
+
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("list_returns_only_own_decks", func(t *testing.T) {
+		decks, err := deckService.ListDecks(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, decks, 1)
+		assert.Equal(t, deck.ID, decks[0].ID)
+	})
+
+	t.Run("other_users_deck_is_not_accessible", func(t *testing.T) {
+		_, err := deckService.GetDeck(ctx, userID, foreignDeck.ID)
+		assert.ErrorIs(t, err, service.ErrDeckNotOwned)
+
+		_, err = deckService.RenameDeck(ctx, userID, foreignDeck.ID, "Mine")
+		assert.ErrorIs(t, err, service.ErrDeckNotOwned)
+
+		assert.ErrorIs(t, deckService.DeleteDeck(ctx, userID, foreignDeck.ID), service.ErrDeckNotOwned)
+
+		_, err = deckService.ListDeckCards(ctx, userID, foreignDeck.ID, 10, 0)
+		assert.ErrorIs(t, err, service.ErrDeckNotOwned)
+
+		_, err = deckService.GetNextCard(ctx, userID, foreignDeck.ID)
+		assert.ErrorIs(t, err, service.ErrDeckNotOwned)
+	})
+
+	t.Run("missing_deck", func(t *testing.T) {
+		_, err := deckService.GetDeck(ctx, userID, uuid.New())
+		assert.ErrorIs(t, err, store.ErrDeckNotFound)
+	})
+
+	t.Run("empty_deck_has_no_cards_due", func(t *testing.T) {
+		_, err := deckService.GetNextCard(ctx, userID, deck.ID)
+		assert.ErrorIs(t, err, card_review.ErrNoCardsDue)
+	})
+
+	t.Run("assign_card_rejects_foreign_cards_and_decks", func(t *testing.T) {
+		_, err := deckService.AssignCard(ctx, userID, foreignCard.ID, &deck.ID)
+		assert.ErrorIs(t, err, card_review.ErrCardNotOwned)
+
+		_, err = deckService.AssignCard(ctx, userID, inDeck.ID, &foreignDeck.ID)
+		assert.ErrorIs(t, err, service.ErrDeckNotOwned)
+	})
+
+	t.Run("assign_card_and_review_within_deck", func(t *testing.T) {
+		card, err := deckService.AssignCard(ctx, userID, inDeck.ID, &deck.ID)
+		require.NoError(t, err)
+		require.NotNil(t, card.DeckID)
+		assert.Equal(t, deck.ID, *card.DeckID)
+
+		cards, err := deckService.ListDeckCards(ctx, userID, deck.ID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		assert.Equal(t, inDeck.ID, cards[0].ID)
+
+		// The more overdue card outside the deck is not served for the deck
+		next, err := deckService.GetNextCard(ctx, userID, deck.ID)
+		require.NoError(t, err)
+		assert.Equal(t, inDeck.ID, next.ID)
+		assert.NotEqual(t, outside.ID, next.ID)
+	})
+
+	t.Run("assign_memo_rejects_foreign_memos_and_decks", func(t *testing.T) {
+		_, err := deckService.AssignMemo(ctx, userID, foreignMemo.ID, &deck.ID)
+		assert.ErrorIs(t, err, service.ErrMemoNotOwned)
+
+		_, err = deckService.AssignMemo(ctx, userID, memo.ID, &foreignDeck.ID)
+		assert.ErrorIs(t, err, service.ErrDeckNotOwned)
+
+		_, err = deckService.AssignMemo(ctx, userID, uuid.New(), &deck.ID)
+		assert.ErrorIs(t, err, store.ErrMemoNotFound)
+	})
+
+	t.Run("assign_memo_and_remove_it", func(t *testing.T) {
+		assigned, err := deckService.AssignMemo(ctx, userID, memo.ID, &deck.ID)
+		require.NoError(t, err)
+		require.NotNil(t, assigned.DeckID)
+		assert.Equal(t, deck.ID, *assigned.DeckID)
+
+		stored, err := memoStore.GetByID(ctx, memo.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.DeckID)
+		assert.Equal(t, deck.ID, *stored.DeckID)
+
+		removed, err := deckService.AssignMemo(ctx, userID, memo.ID, nil)
+		require.NoError(t, err)
+		assert.Nil(t, removed.DeckID)
+
+		_, err = deckService.AssignMemo(ctx, userID, memo.ID, &deck.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("ensure_default_deck_is_idempotent", func(t *testing.T) {
+		defaultDeck, err := deckService.EnsureDefaultDeck(ctx, otherUserID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultDeckName, defaultDeck.Name)
+		assert.Equal(t, otherUserID, defaultDeck.UserID)
+
+		again, err := deckService.EnsureDefaultDeck(ctx, otherUserID)
+		require.NoError(t, err)
+		assert.Equal(t, defaultDeck.ID, again.ID)
+
+		decks, err := deckService.ListDecks(ctx, otherUserID)
+		require.NoError(t, err)
+		assert.Len(t, decks, 2, "Only one default deck should be created")
+	})
+
+	t.Run("rename_and_delete", func(t *testing.T) {
+		renamed, err := deckService.RenameDeck(ctx, userID, deck.ID, "Español")
+		require.NoError(t, err)
+		assert.Equal(t, "Español", renamed.Name)
+
+		require.NoError(t, deckService.DeleteDeck(ctx, userID, deck.ID))
+
+		card, err := cardStore.GetByID(ctx, inDeck.ID)
+		require.NoError(t, err)
+		assert.Nil(t, card.DeckID, "Cards should leave a deleted deck")
+
+		stored, err := memoStore.GetByID(ctx, memo.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.DeckID, "Memos should leave a deleted deck")
+	})
+}
+
+// TestDeckService_MoveCards verifies that a bulk move assigns every owned card
+// to the deck and reports foreign and missing cards instead of moving them
+func TestDeckService_MoveCards(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	ctx := context.Background()
+	logger := slog.Default()
+	cardStore := postgres.NewPostgresCardStore(db, logger)
+	deckService, err := service.NewDeckService(
+		postgres.NewPostgresDeckStore(db, logger),
+		cardStore,
+		postgres.NewPostgresMemoStore(db, logger),
+		&mocks.MockCardReviewService{},
+		logger,
+	)
+	require.NoError(t, err)
+
+	// The service manages its own transaction, so data is committed and cleaned up per user
+	userID := testutils.MustInsertUser(ctx, t, db, "move-cards-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	otherUserID := testutils.MustInsertUser(ctx, t, db, "move-other-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, "DELETE FROM users WHERE id = ANY($1::uuid[])",
+			[]string{userID.String(), otherUserID.String()})
+	})
+
+	insertCard := func(ownerID uuid.UUID) *domain.Card {
+		memo := testutils.MustInsertMemo(ctx, t, db, ownerID)
+		card, err := domain.NewCard(ownerID, memo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+		require.NoError(t, err)
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+		return card
+	}
+
+	deck, err := deckService.CreateDeck(ctx, userID, "Spanish")
+	require.NoError(t, err)
+	foreignDeck, err := deckService.CreateDeck(ctx, otherUserID, "Theirs")
+	require.NoError(t, err)
+
+	first := insertCard(userID)
+	second := insertCard(userID)
+	foreign := insertCard(otherUserID)
+	missingID := uuid.New()
+
+	t.Run("rejects_empty_list", func(t *testing.T) {
+		_, err := deckService.MoveCards(ctx, userID, deck.ID, nil)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+
+	t.Run("rejects_foreign_deck", func(t *testing.T) {
+		_, err := deckService.MoveCards(ctx, userID, foreignDeck.ID, []uuid.UUID{first.ID})
+		assert.ErrorIs(t, err, service.ErrDeckNotOwned)
+	})
+
+	t.Run("moves_owned_cards_and_reports_the_rest", func(t *testing.T) {
+		results, err := deckService.MoveCards(ctx, userID, deck.ID,
+			[]uuid.UUID{first.ID, foreign.ID, second.ID, missingID, first.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []service.CardMoveResult{
+			{CardID: first.ID, Status: service.CardMoveStatusMoved},
+			{CardID: foreign.ID, Status: service.CardMoveStatusNotOwned},
+			{CardID: second.ID, Status: service.CardMoveStatusMoved},
+			{CardID: missingID, Status: service.CardMoveStatusNotFound},
+		}, results)
+
+		cards, err := deckService.ListDeckCards(ctx, userID, deck.ID, 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, cards, 2)
+
+		card, err := cardStore.GetByID(ctx, foreign.ID)
+		require.NoError(t, err)
+		assert.Nil(t, card.DeckID, "Cards owned by other users must not be moved")
+	})
+}
+
+// TestDeckService_SRSSettings verifies that a deck's SRS overrides change the
+// pacing and scheduling of its cards while other decks keep the defaults
+func TestDeckService_SRSSettings(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	ctx := context.Background()
+	logger := slog.Default()
+	cardStore := postgres.NewPostgresCardStore(db, logger)
+	statsStore := postgres.NewPostgresUserCardStatsStore(db, logger)
+	deckStore := postgres.NewPostgresDeckStore(db, logger)
+	srsService, err := srs.NewDefaultService()
+	require.NoError(t, err)
+	cardReviewService, err := card_review.NewCardReviewService(
+		cardStore,
+		statsStore,
+		postgres.NewPostgresReviewLogStore(db, logger),
+		postgres.NewPostgresUserStore(db, bcrypt.MinCost),
+		srsService,
+		logger,
+		card_review.WithDeckSettings(deckStore),
+	)
+	require.NoError(t, err)
+	deckService, err := service.NewDeckService(
+		deckStore,
+		cardStore,
+		postgres.NewPostgresMemoStore(db, logger),
+		cardReviewService,
+		logger,
+	)
+	require.NoError(t, err)
+
+	// Answering cards commits transactions, so data is committed and cleaned up per user
+	userID := testutils.MustInsertUser(ctx, t, db, "deck-srs-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", userID)
+	})
+
+	// The user introduces no new cards per day unless a deck says otherwise
+	_, err = db.ExecContext(ctx, "UPDATE users SET new_cards_per_day = 0 WHERE id = $1", userID)
+	require.NoError(t, err)
+
+	insertNewCard := func(deckID uuid.UUID) *domain.Card {
+		memo := testutils.MustInsertMemo(ctx, t, db, userID)
+		card, err := domain.NewCard(userID, memo.ID, json.RawMessage(`{"front":"Q","back":"A"}`))
+		require.NoError(t, err)
+		card.DeckID = &deckID
+		require.NoError(t, cardStore.CreateMultiple(ctx, []*domain.Card{card}))
+
+		stats, err := domain.NewUserCardStats(userID, card.ID)
+		require.NoError(t, err)
+		require.NoError(t, statsStore.Create(ctx, stats))
+		return card
+	}
+
+	examDeck, err := deckService.CreateDeck(ctx, userID, "Exam")
+	require.NoError(t, err)
+	plainDeck, err := deckService.CreateDeck(ctx, userID, "Plain")
+	require.NoError(t, err)
+
+	newCards := 5
+	againMinutes := 1
+	initialEase := 2.0
+	_, err = deckService.UpdateSRSSettings(ctx, userID, examDeck.ID, domain.DeckSRSSettings{
+		NewCardsPerDay:     &newCards,
+		AgainReviewMinutes: &againMinutes,
+		InitialEaseFactor:  &initialEase,
+	})
+	require.NoError(t, err)
+
+	t.Run("settings_are_persisted", func(t *testing.T) {
+		deck, err := deckService.GetDeck(ctx, userID, examDeck.ID)
+		require.NoError(t, err)
+		require.NotNil(t, deck.SRSSettings.NewCardsPerDay)
+		assert.Equal(t, newCards, *deck.SRSSettings.NewCardsPerDay)
+		require.NotNil(t, deck.SRSSettings.InitialEaseFactor)
+		assert.InDelta(t, initialEase, *deck.SRSSettings.InitialEaseFactor, 0.001)
+
+		deck, err = deckService.GetDeck(ctx, userID, plainDeck.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DeckSRSSettings{}, deck.SRSSettings)
+	})
+
+	t.Run("rejects_out_of_range_settings", func(t *testing.T) {
+		negative := -1
+		_, err := deckService.UpdateSRSSettings(ctx, userID, plainDeck.ID,
+			domain.DeckSRSSettings{NewCardsPerDay: &negative})
+		assert.ErrorIs(t, err, domain.ErrDeckNewCardsPerDayInvalid)
+	})
+
+	examCard := insertNewCard(examDeck.ID)
+	plainCard := insertNewCard(plainDeck.ID)
+
+	t.Run("deck_new_card_limit_overrides_user_limit", func(t *testing.T) {
+		card, err := deckService.GetNextCard(ctx, userID, examDeck.ID)
+		require.NoError(t, err)
+		assert.Equal(t, examCard.ID, card.ID)
+
+		_, err = deckService.GetNextCard(ctx, userID, plainDeck.ID)
+		assert.ErrorIs(t, err, card_review.ErrNoCardsDue)
+	})
+
+	t.Run("deck_overrides_scheduling", func(t *testing.T) {
+		again := card_review.ReviewAnswer{Outcome: domain.ReviewOutcomeAgain}
+
+		examStats, err := cardReviewService.SubmitAnswer(ctx, userID, examCard.ID, again)
+		require.NoError(t, err)
+		assert.WithinDuration(t, examStats.LastReviewedAt.Add(time.Minute), examStats.NextReviewAt, time.Second)
+		assert.InDelta(t, initialEase-0.2, examStats.EaseFactor, 0.001)
+
+		plainStats, err := cardReviewService.SubmitAnswer(ctx, userID, plainCard.ID, again)
+		require.NoError(t, err)
+		assert.WithinDuration(t, plainStats.LastReviewedAt.Add(10*time.Minute), plainStats.NextReviewAt, time.Second)
+		assert.InDelta(t, 2.3, plainStats.EaseFactor, 0.001)
+	})
+}
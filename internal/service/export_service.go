@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// ExportService streams a user's collection for export.
+type ExportService interface {
+	// ExportCards calls fn for each of the user's cards with its review
+	// statistics, oldest first. Suspended cards are always exported; cards
+	// superseded by regenerating their memo only when includeArchived is true.
+	// Cards are streamed from the store rather than loaded at once. Iteration
+	// stops at the first error returned by fn.
+	ExportCards(
+		ctx context.Context,
+		userID uuid.UUID,
+		includeArchived bool,
+		fn func(*domain.CardWithStats) error,
+	) error
+}
+
+// exportServiceImpl implements the ExportService interface
+type exportServiceImpl struct {
+	cardStore store.CardStore
+	logger    *slog.Logger
+}
+
+// NewExportService creates a new ExportService
+// It returns an error if the card store is nil.
+func NewExportService(cardStore store.CardStore, logger *slog.Logger) (ExportService, error) {
+	if cardStore == nil {
+		return nil, fmt.Errorf("cardStore cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &exportServiceImpl{
+		cardStore: cardStore,
+		logger:    logger.With("component", "export_service"),
+	}, nil
+}
+
+// ExportCards implements ExportService.ExportCards
+func (s *exportServiceImpl) ExportCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	includeArchived bool,
+	fn func(*domain.CardWithStats) error,
+) error {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	count := 0
+	err := s.cardStore.ForEachWithStats(ctx, userID, includeArchived, func(card *domain.CardWithStats) error {
+		if err := fn(card); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to export cards",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()),
+			slog.Int("exported", count))
+		return fmt.Errorf("failed to export cards: %w", err)
+	}
+
+	log.Info("exported cards",
+		slog.String("user_id", userID.String()),
+		slog.Bool("include_archived", includeArchived),
+		slog.Int("count", count))
+	return nil
+}
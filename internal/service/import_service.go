@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/logger"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// ErrImportRejected indicates that too many rows of an import failed validation,
+// so nothing was imported. The ImportResult returned with it lists the failures.
+var ErrImportRejected = errors.New("import rejected")
+
+// DefaultImportMaxFailureFraction is the fraction of rows that may fail
+// validation before an import is rejected, when not configured.
+const DefaultImportMaxFailureFraction = 0.1
+
+// DefaultImportMaxCards is the maximum number of rows in one import, when not configured.
+const DefaultImportMaxCards = 1000
+
+// ImportCard is one row of an uploaded import.
+type ImportCard struct {
+	// Line identifies the row in the upload: the line number for CSV files
+	// and the 1-based element number for JSON arrays.
+	Line int
+
+	// Type is the card type; empty means basic.
+	Type domain.CardType
+
+	// Content is the card content as uploaded, before validation.
+	Content domain.CardContent
+
+	// Error, if set, is why the row could not be read from the upload.
+	// The row is reported as failed with this message.
+	Error string
+}
+
+// ImportRowError describes why one row of an import was not imported.
+type ImportRowError struct {
+	Line    int
+	Message string
+}
+
+// ImportResult summarizes an import.
+type ImportResult struct {
+	// MemoID is the memo created to hold the imported cards; uuid.Nil if nothing was imported.
+	MemoID uuid.UUID
+
+	// Imported is the number of cards created.
+	Imported int
+
+	// Duplicates is the number of rows skipped because an earlier row had the same content.
+	Duplicates int
+
+	// Errors lists the rows that failed validation, in upload order.
+	Errors []ImportRowError
+}
+
+// ImportService creates cards in bulk from uploaded files.
+type ImportService interface {
+	// ImportCards validates the rows and creates a card for each valid, distinct
+	// row under a new memo, together with fresh review statistics, in a single
+	// transaction. Rows that fail validation are reported in the result and skipped.
+	//
+	// If more than the configured fraction of rows fail, or no row is valid,
+	// nothing is imported and the result is returned with ErrImportRejected.
+	// Returns a validation error if there are no rows or more than the configured maximum.
+	ImportCards(ctx context.Context, userID uuid.UUID, cards []ImportCard) (*ImportResult, error)
+}
+
+// ImportServiceOption configures optional ImportService behavior
+type ImportServiceOption func(*importServiceImpl)
+
+// WithImportMaxFailureFraction sets the fraction of rows (between 0 and 1) that
+// may fail validation before an import is rejected.
+func WithImportMaxFailureFraction(fraction float64) ImportServiceOption {
+	return func(s *importServiceImpl) {
+		s.maxFailureFraction = fraction
+	}
+}
+
+// WithImportMaxCards sets the maximum number of rows accepted in one import.
+func WithImportMaxCards(maxCards int) ImportServiceOption {
+	return func(s *importServiceImpl) {
+		s.maxCards = maxCards
+	}
+}
+
+// WithImportNewCardSettings makes imported cards' stats start from the
+// importing user's new card overrides, looked up in userStore. Without it
+// imported cards start from domain.DefaultNewCardSettings.
+func WithImportNewCardSettings(userStore store.UserStore) ImportServiceOption {
+	return func(s *importServiceImpl) {
+		s.userStore = userStore
+	}
+}
+
+// importServiceImpl implements the ImportService interface
+type importServiceImpl struct {
+	memoStore          store.MemoStore
+	cardStore          store.CardStore
+	statsStore         store.UserCardStatsStore
+	maxFailureFraction float64
+	maxCards           int
+	userStore          store.UserStore
+	logger             *slog.Logger
+}
+
+// NewImportService creates a new ImportService
+// It returns an error if any of the stores are nil.
+func NewImportService(
+	memoStore store.MemoStore,
+	cardStore store.CardStore,
+	statsStore store.UserCardStatsStore,
+	logger *slog.Logger,
+	opts ...ImportServiceOption,
+) (ImportService, error) {
+	if memoStore == nil {
+		return nil, fmt.Errorf("memoStore cannot be nil")
+	}
+	if cardStore == nil {
+		return nil, fmt.Errorf("cardStore cannot be nil")
+	}
+	if statsStore == nil {
+		return nil, fmt.Errorf("statsStore cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	s := &importServiceImpl{
+		memoStore:          memoStore,
+		cardStore:          cardStore,
+		statsStore:         statsStore,
+		maxFailureFraction: DefaultImportMaxFailureFraction,
+		maxCards:           DefaultImportMaxCards,
+		logger:             logger.With("component", "import_service"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// ImportCards implements ImportService.ImportCards
+func (s *importServiceImpl) ImportCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	cards []ImportCard,
+) (*ImportResult, error) {
+	log := logger.FromContextOrDefault(ctx, s.logger)
+
+	if len(cards) == 0 {
+		return nil, domain.NewValidationError("cards", "must not be empty", domain.ErrValidation)
+	}
+	if len(cards) > s.maxCards {
+		return nil, domain.NewValidationError("cards",
+			fmt.Sprintf("must not contain more than %d cards", s.maxCards), domain.ErrValidation)
+	}
+
+	planned, result := planImport(cards)
+	if importRejected(len(planned), len(result.Errors), len(cards), s.maxFailureFraction) {
+		log.Info("import rejected",
+			slog.String("user_id", userID.String()),
+			slog.Int("rows", len(cards)),
+			slog.Int("failed", len(result.Errors)))
+		return result, ErrImportRejected
+	}
+
+	memoText := fmt.Sprintf("Imported %d cards on %s", len(planned), time.Now().UTC().Format(time.DateOnly))
+	memo, err := domain.NewMemo(userID, memoText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import memo: %w", err)
+	}
+	// The cards already exist, so the memo never goes through generation
+	memo.Status = domain.MemoStatusCompleted
+
+	newCards := make([]*domain.Card, 0, len(planned))
+	for _, p := range planned {
+		card, err := domain.NewCardOfType(userID, memo.ID, p.cardType, p.content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create imported card: %w", err)
+		}
+		newCards = append(newCards, card)
+	}
+
+	err = store.RunInTransaction(ctx, s.cardStore.DB(), func(ctx context.Context, tx *sql.Tx) error {
+		if err := s.memoStore.WithTx(tx).Create(ctx, memo); err != nil {
+			return fmt.Errorf("failed to save import memo: %w", err)
+		}
+		if err := s.cardStore.WithTx(tx).CreateMultiple(ctx, newCards); err != nil {
+			return fmt.Errorf("failed to save imported cards: %w", err)
+		}
+
+		settings := domain.DefaultNewCardSettings()
+		if s.userStore != nil {
+			user, err := s.userStore.WithTx(tx).GetByID(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get user for new card settings: %w", err)
+			}
+			settings = domain.ResolveNewCardSettings(user, nil)
+		}
+
+		now := time.Now().UTC()
+		txStatsStore := s.statsStore.WithTx(tx)
+		for _, card := range newCards {
+			stats, err := domain.NewUserCardStatsWithSettings(userID, card.ID, settings, now)
+			if err != nil {
+				return fmt.Errorf("failed to create stats for imported card: %w", err)
+			}
+			if err := txStatsStore.Create(ctx, stats); err != nil {
+				return fmt.Errorf("failed to save stats for imported card: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to import cards",
+			slog.String("error", err.Error()),
+			slog.String("user_id", userID.String()))
+		return nil, err
+	}
+
+	result.MemoID = memo.ID
+	result.Imported = len(newCards)
+	log.Info("imported cards",
+		slog.String("user_id", userID.String()),
+		slog.String("memo_id", memo.ID.String()),
+		slog.Int("imported", result.Imported),
+		slog.Int("duplicates", result.Duplicates),
+		slog.Int("failed", len(result.Errors)))
+	return result, nil
+}
+
+// importRejected reports whether an import with the given numbers of valid
+// (distinct) and failed rows out of total must be rejected: when nothing is
+// left to import, or more than maxFailureFraction of the rows failed.
+func importRejected(valid, failed, total int, maxFailureFraction float64) bool {
+	return valid == 0 || float64(failed) > maxFailureFraction*float64(total)
+}
+
+// planImport validates and normalizes the rows of an import. It returns the
+// type and JSON content of each valid row whose content has not been seen earlier in
+// the upload, and a result counting the duplicates and listing the failures.
+func planImport(cards []ImportCard) ([]plannedImportCard, *ImportResult) {
+	result := &ImportResult{Errors: []ImportRowError{}}
+	planned := make([]plannedImportCard, 0, len(cards))
+	seen := make(map[string]struct{}, len(cards))
+
+	for _, card := range cards {
+		if card.Error != "" {
+			result.Errors = append(result.Errors, ImportRowError{Line: card.Line, Message: card.Error})
+			continue
+		}
+
+		cardType := card.Type.OrDefault()
+		content, err := normalizeImportContent(cardType, card.Content)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Line: card.Line, Message: err.Error()})
+			continue
+		}
+
+		encoded, err := json.Marshal(content)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Line: card.Line, Message: "invalid content"})
+			continue
+		}
+		key := string(cardType) + ":" + string(encoded)
+		if _, ok := seen[key]; ok {
+			result.Duplicates++
+			continue
+		}
+		seen[key] = struct{}{}
+		planned = append(planned, plannedImportCard{cardType: cardType, content: encoded})
+	}
+
+	return planned, result
+}
+
+// plannedImportCard is a validated, distinct row of an import.
+type plannedImportCard struct {
+	cardType domain.CardType
+	content  json.RawMessage
+}
+
+// normalizeImportContent trims the text fields of uploaded content, requires a
+// front and a back (or valid cloze text for cloze cards), and normalizes and
+// deduplicates the tags.
+func normalizeImportContent(cardType domain.CardType, content domain.CardContent) (domain.CardContent, error) {
+	content.Front = strings.TrimSpace(content.Front)
+	content.Back = strings.TrimSpace(content.Back)
+	content.Text = strings.TrimSpace(content.Text)
+	content.Hint = strings.TrimSpace(content.Hint)
+	content.ImageURL = strings.TrimSpace(content.ImageURL)
+
+	switch cardType {
+	case domain.CardTypeBasic:
+		if content.Front == "" {
+			return content, errors.New("front is required")
+		}
+		if content.Back == "" {
+			return content, errors.New("back is required")
+		}
+	case domain.CardTypeCloze:
+		if content.Text == "" {
+			return content, errors.New("text is required")
+		}
+		if _, err := domain.ParseCloze(content.Text); err != nil {
+			return content, err
+		}
+	default:
+		return content, fmt.Errorf("unknown card type %q", cardType)
+	}
+
+	tags := make([]string, 0, len(content.Tags))
+	for _, tag := range content.Tags {
+		normalized, err := domain.NormalizeTag(tag)
+		if err != nil {
+			return content, fmt.Errorf("invalid tag %q", tag)
+		}
+		if !slices.Contains(tags, normalized) {
+			tags = append(tags, normalized)
+		}
+	}
+	content.Tags = nil
+	if len(tags) > 0 {
+		content.Tags = tags
+	}
+
+	return content, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPlanImport tests validation, normalization and deduplication of import rows
+func TestPlanImport(t *testing.T) {
+	t.Run("clean import", func(t *testing.T) {
+		planned, result := planImport([]ImportCard{
+			{Line: 2, Content: domain.CardContent{Front: " Q1 ", Back: "A1", Tags: []string{"Cell Biology", "cell_biology"}}},
+			{Line: 3, Content: domain.CardContent{Front: "Q2", Back: "A2", Hint: "h"}},
+		})
+
+		require.Len(t, planned, 2)
+		assert.JSONEq(t, `{"front":"Q1","back":"A1","tags":["cell-biology"]}`, string(planned[0].content))
+		assert.JSONEq(t, `{"front":"Q2","back":"A2","hint":"h"}`, string(planned[1].content))
+		assert.Empty(t, result.Errors)
+		assert.Zero(t, result.Duplicates)
+	})
+
+	t.Run("reports failed rows with their lines", func(t *testing.T) {
+		planned, result := planImport([]ImportCard{
+			{Line: 2, Content: domain.CardContent{Front: "Q1", Back: "A1"}},
+			{Line: 3, Content: domain.CardContent{Front: "  ", Back: "A2"}},
+			{Line: 4, Content: domain.CardContent{Front: "Q3"}},
+			{Line: 5, Content: domain.CardContent{Front: "Q4", Back: "A4", Tags: []string{"c++"}}},
+			{Line: 6, Error: "card must be an object"},
+		})
+
+		require.Len(t, planned, 1)
+		assert.Equal(t, []ImportRowError{
+			{Line: 3, Message: "front is required"},
+			{Line: 4, Message: "back is required"},
+			{Line: 5, Message: `invalid tag "c++"`},
+			{Line: 6, Message: "card must be an object"},
+		}, result.Errors)
+	})
+
+	t.Run("skips identical cards after normalization", func(t *testing.T) {
+		planned, result := planImport([]ImportCard{
+			{Line: 1, Content: domain.CardContent{Front: "Q", Back: "A", Tags: []string{"geo"}}},
+			{Line: 2, Content: domain.CardContent{Front: "Q ", Back: " A", Tags: []string{"GEO", "geo"}}},
+			{Line: 3, Content: domain.CardContent{Front: "Q", Back: "B"}},
+		})
+
+		require.Len(t, planned, 2)
+		assert.Equal(t, 1, result.Duplicates)
+		assert.Empty(t, result.Errors)
+
+		var second domain.CardContent
+		require.NoError(t, json.Unmarshal(planned[1].content, &second))
+		assert.Equal(t, "B", second.Back)
+	})
+
+	t.Run("cloze cards", func(t *testing.T) {
+		planned, result := planImport([]ImportCard{
+			{Line: 1, Type: domain.CardTypeCloze, Content: domain.CardContent{Text: " {{c1::Paris}} is in France "}},
+			{Line: 2, Type: domain.CardTypeCloze, Content: domain.CardContent{Text: "No markers"}},
+			{Line: 3, Type: domain.CardTypeCloze, Content: domain.CardContent{Front: "Q", Back: "A"}},
+			{Line: 4, Type: "reverse", Content: domain.CardContent{Front: "Q", Back: "A"}},
+		})
+
+		require.Len(t, planned, 1)
+		assert.Equal(t, domain.CardTypeCloze, planned[0].cardType)
+		assert.JSONEq(t, `{"text":"{{c1::Paris}} is in France"}`, string(planned[0].content))
+		require.Len(t, result.Errors, 3)
+		assert.Equal(t, 2, result.Errors[0].Line)
+		assert.Contains(t, result.Errors[0].Message, "invalid cloze markup")
+		assert.Equal(t, ImportRowError{Line: 3, Message: "text is required"}, result.Errors[1])
+		assert.Equal(t, ImportRowError{Line: 4, Message: `unknown card type "reverse"`}, result.Errors[2])
+	})
+}
+
+// TestImportRejected tests the all-or-nothing failure threshold
+func TestImportRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		valid    int
+		failed   int
+		total    int
+		fraction float64
+		rejected bool
+	}{
+		{name: "no failures", valid: 10, failed: 0, total: 10, fraction: 0.1},
+		{name: "failures at the threshold", valid: 9, failed: 1, total: 10, fraction: 0.1},
+		{name: "failures above the threshold", valid: 8, failed: 2, total: 10, fraction: 0.1, rejected: true},
+		{name: "any failure with zero tolerance", valid: 9, failed: 1, total: 10, fraction: 0, rejected: true},
+		{name: "all failures tolerated", valid: 1, failed: 9, total: 10, fraction: 1},
+		{name: "nothing valid", valid: 0, failed: 10, total: 10, fraction: 1, rejected: true},
+		{name: "only duplicates of one card", valid: 1, failed: 0, total: 5, fraction: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.rejected, importRejected(tc.valid, tc.failed, tc.total, tc.fraction))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestImportService_ImportCards verifies that an import creates its cards with
+// stats under a new memo, and that a rejected import creates nothing
+func TestImportService_ImportCards(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	ctx := context.Background()
+	logger := slog.Default()
+	importService, err := service.NewImportService(
+		postgres.NewPostgresMemoStore(db, logger),
+		postgres.NewPostgresCardStore(db, logger),
+		postgres.NewPostgresUserCardStatsStore(db, logger),
+		logger,
+		service.WithImportMaxFailureFraction(0.25),
+	)
+	require.NoError(t, err)
+
+	// The service manages its own transaction, so data is committed and cleaned up per user
+	userID := testutils.MustInsertUser(ctx, t, db, "import-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", userID)
+	})
+
+	countRows := func(query string, args ...interface{}) int {
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, query, args...).Scan(&count))
+		return count
+	}
+
+	t.Run("imports valid distinct rows", func(t *testing.T) {
+		result, err := importService.ImportCards(ctx, userID, []service.ImportCard{
+			{Line: 2, Content: domain.CardContent{Front: "Q1", Back: "A1", Tags: []string{"geo"}}},
+			{Line: 3, Content: domain.CardContent{Front: "Q2", Back: "A2"}},
+			{Line: 4, Content: domain.CardContent{Front: "Q1", Back: "A1", Tags: []string{"geo"}}},
+			{Line: 5, Content: domain.CardContent{Front: "Q3"}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, 1, result.Duplicates)
+		assert.Equal(t, []service.ImportRowError{{Line: 5, Message: "back is required"}}, result.Errors)
+		require.NotEqual(t, uuid.Nil, result.MemoID)
+
+		var status string
+		require.NoError(t, db.QueryRowContext(ctx,
+			"SELECT status FROM memos WHERE id = $1", result.MemoID).Scan(&status))
+		assert.Equal(t, string(domain.MemoStatusCompleted), status)
+		assert.Equal(t, 2, countRows("SELECT COUNT(*) FROM cards WHERE memo_id = $1", result.MemoID))
+		assert.Equal(t, 2, countRows(`SELECT COUNT(*) FROM user_card_stats s
+			JOIN cards c ON c.id = s.card_id WHERE c.memo_id = $1`, result.MemoID))
+	})
+
+	t.Run("rejects the whole import above the failure threshold", func(t *testing.T) {
+		before := countRows("SELECT COUNT(*) FROM cards WHERE user_id = $1", userID)
+
+		result, err := importService.ImportCards(ctx, userID, []service.ImportCard{
+			{Line: 1, Content: domain.CardContent{Front: "Q4", Back: "A4"}},
+			{Line: 2, Content: domain.CardContent{Front: "Q5"}},
+			{Line: 3, Content: domain.CardContent{Back: "A6"}},
+		})
+		require.ErrorIs(t, err, service.ErrImportRejected)
+		assert.Len(t, result.Errors, 2)
+		assert.Equal(t, before, countRows("SELECT COUNT(*) FROM cards WHERE user_id = $1", userID))
+	})
+
+	t.Run("rejects empty imports", func(t *testing.T) {
+		_, err := importService.ImportCards(ctx, userID, nil)
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -13,6 +16,55 @@ import (
 	"github.com/phrazzld/scry-api/internal/task"
 )
 
+// Memo service errors
+var (
+	// ErrMemoNotOwned indicates that the user does not own the memo.
+	ErrMemoNotOwned = errors.New("unauthorized access: memo not owned by user")
+
+	// ErrMemoNotDraft indicates that the memo is not in draft status and
+	// cannot be submitted for generation.
+	ErrMemoNotDraft = errors.New("memo is not a draft")
+
+	// ErrIdempotencyKeyReused indicates that an idempotency key was replayed with
+	// a request that differs from the one it was first used for.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+
+	// ErrMemoGenerationInProgress indicates that the memo is pending or processing
+	// and cannot be regenerated until its current generation finishes.
+	ErrMemoGenerationInProgress = errors.New("memo generation is in progress")
+
+	// ErrGenerationQuotaExceeded indicates that the user has used up their
+	// monthly card generation quota. It is returned wrapped in a
+	// GenerationQuotaError.
+	ErrGenerationQuotaExceeded = errors.New("generation quota exceeded")
+)
+
+// GenerationQuotaError reports that a memo was not submitted for generation
+// because the user has used up their quota for the month.
+type GenerationQuotaError struct {
+	Quota    int       // The user's monthly generation quota
+	ResetsAt time.Time // When the next quota period starts
+}
+
+// Error implements the error interface
+func (e *GenerationQuotaError) Error() string {
+	return fmt.Sprintf("%s: %d generations per month, resets at %s",
+		ErrGenerationQuotaExceeded, e.Quota, e.ResetsAt.Format(time.RFC3339))
+}
+
+// Unwrap returns ErrGenerationQuotaExceeded so errors.Is matches it
+func (e *GenerationQuotaError) Unwrap() error {
+	return ErrGenerationQuotaExceeded
+}
+
+// DefaultIdempotencyKeyTTL is how long an idempotency key replays its original
+// memo when no TTL is configured.
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// DefaultCardPreviewTimeout is how long PreviewCards waits for generation when
+// no timeout is configured.
+const DefaultCardPreviewTimeout = 20 * time.Second
+
 // MemoRepository defines the repository interface for the service layer
 // This is now aligned with store.MemoStore to ensure proper separation of concerns
 type MemoRepository interface {
@@ -33,12 +85,6 @@ type MemoRepository interface {
 	DB() *sql.DB
 }
 
-// TaskRunner defines the interface for submitting background tasks
-type TaskRunner interface {
-	// Submit adds a task to the processing queue
-	Submit(ctx context.Context, task task.Task) error
-}
-
 // MemoGenerationTaskFactory creates MemoGenerationTask instances
 type MemoGenerationTaskFactory interface {
 	// CreateTask creates a new MemoGenerationTask for the specified memo
@@ -47,13 +93,58 @@ type MemoGenerationTaskFactory interface {
 
 // MemoService provides memo-related operations
 type MemoService interface {
-	// CreateMemoAndEnqueueTask creates a new memo and enqueues it for processing
+	// CreateMemoAndEnqueueTask creates a new memo and enqueues it for processing.
+	// Returns a GenerationQuotaError if the user has used up their generation quota.
 	CreateMemoAndEnqueueTask(
 		ctx context.Context,
 		userID uuid.UUID,
 		text string,
 	) (*domain.Memo, error)
 
+	// CreateDraftMemo creates a new memo in draft status without enqueuing it for processing
+	CreateDraftMemo(ctx context.Context, userID uuid.UUID, text string) (*domain.Memo, error)
+
+	// CreateMemoIdempotent creates a memo like CreateMemoAndEnqueueTask, or like
+	// CreateDraftMemo when draft is true, and records idempotencyKey against it.
+	// Repeating the same request with the same key within the key TTL returns the
+	// originally created memo with replayed set to true instead of creating another.
+	// Returns ErrIdempotencyKeyReused if the key was first used with a different
+	// text or draft flag.
+	CreateMemoIdempotent(
+		ctx context.Context,
+		userID uuid.UUID,
+		text string,
+		draft bool,
+		idempotencyKey string,
+	) (memo *domain.Memo, replayed bool, err error)
+
+	// GenerateMemo moves a draft memo to pending status and enqueues it for processing.
+	// Returns ErrMemoNotOwned if the memo belongs to another user and
+	// ErrMemoNotDraft if the memo is not in draft status, and a GenerationQuotaError
+	// if the user has used up their generation quota.
+	GenerateMemo(ctx context.Context, userID, memoID uuid.UUID) (*domain.Memo, error)
+
+	// Regenerate replaces a memo's text, moves it back to pending status and enqueues
+	// it for processing. The memo's existing cards are kept, with their review history,
+	// but marked superseded so they are no longer reviewed; when replace is true they
+	// are deleted instead.
+	// Returns ErrMemoNotOwned if the memo belongs to another user and
+	// ErrMemoGenerationInProgress if the memo is pending or processing, and a
+	// GenerationQuotaError if the user has used up their generation quota.
+	Regenerate(
+		ctx context.Context,
+		userID, memoID uuid.UUID,
+		newText string,
+		replace bool,
+	) (*domain.Memo, error)
+
+	// PreviewCards generates cards from text and returns them without saving a
+	// memo or any cards. The preview counts against the user's generation quota
+	// like a submitted memo, and fails with an error wrapping
+	// context.DeadlineExceeded if generation takes longer than the preview timeout.
+	// Returns a GenerationQuotaError if the user has used up their quota.
+	PreviewCards(ctx context.Context, userID uuid.UUID, text string) ([]*domain.Card, error)
+
 	// UpdateMemoStatus updates a memo's status and handles related business logic
 	UpdateMemoStatus(ctx context.Context, memoID uuid.UUID, status domain.MemoStatus) error
 
@@ -63,27 +154,107 @@ type MemoService interface {
 
 // memoServiceImpl implements the MemoService interface
 type memoServiceImpl struct {
-	memoRepo     MemoRepository
-	taskRunner   TaskRunner
-	eventEmitter events.EventEmitter
-	logger       *slog.Logger
+	memoRepo                 MemoRepository
+	eventEmitter             events.EventEmitter
+	enforceStatusTransitions bool
+	idempotencyKeys          store.IdempotencyKeyStore
+	idempotencyKeyTTL        time.Duration
+	cardRepo                 CardRepository
+	generationUsage          store.GenerationUsageStore
+	userStore                store.UserStore
+	generationQuota          int
+	previewGenerator         task.Generator
+	previewTimeout           time.Duration
+	now                      func() time.Time
+	logger                   *slog.Logger
+}
+
+// MemoServiceOption configures optional MemoService behavior
+type MemoServiceOption func(*memoServiceImpl)
+
+// WithMemoStatusTransitionEnforcement controls whether status updates must follow
+// the canonical memo state machine (see domain.ValidateMemoStatusTransition).
+// Enforcement is enabled by default.
+func WithMemoStatusTransitionEnforcement(enabled bool) MemoServiceOption {
+	return func(s *memoServiceImpl) {
+		s.enforceStatusTransitions = enabled
+	}
+}
+
+// WithIdempotencyKeys stores the idempotency keys passed to CreateMemoIdempotent
+// in keyStore, replaying the original memo for ttl after a key is first used.
+// A non-positive ttl uses DefaultIdempotencyKeyTTL. Without this option,
+// CreateMemoIdempotent creates a new memo for every request.
+func WithIdempotencyKeys(keyStore store.IdempotencyKeyStore, ttl time.Duration) MemoServiceOption {
+	return func(s *memoServiceImpl) {
+		if ttl <= 0 {
+			ttl = DefaultIdempotencyKeyTTL
+		}
+		s.idempotencyKeys = keyStore
+		s.idempotencyKeyTTL = ttl
+	}
+}
+
+// WithMemoCardRepository lets Regenerate supersede or delete the memo's existing
+// cards through cardRepo. Without this option, Regenerate returns an error.
+func WithMemoCardRepository(cardRepo CardRepository) MemoServiceOption {
+	return func(s *memoServiceImpl) {
+		s.cardRepo = cardRepo
+	}
+}
+
+// WithGenerationQuota limits how many memos each user may submit for card
+// generation per calendar month, counting submissions in usage. Users without a
+// quota of their own (see domain.User.MonthlyGenerationQuota), looked up in
+// userStore, get monthlyQuota; 0 means unlimited. Submissions past the quota
+// fail with a GenerationQuotaError. Without this option, generation is unlimited.
+func WithGenerationQuota(
+	usage store.GenerationUsageStore,
+	userStore store.UserStore,
+	monthlyQuota int,
+) MemoServiceOption {
+	return func(s *memoServiceImpl) {
+		s.generationUsage = usage
+		s.userStore = userStore
+		s.generationQuota = monthlyQuota
+	}
+}
+
+// WithCardPreview lets PreviewCards generate cards with generator, giving up
+// after timeout. A non-positive timeout uses DefaultCardPreviewTimeout.
+// Without this option, PreviewCards returns an error.
+func WithCardPreview(generator task.Generator, timeout time.Duration) MemoServiceOption {
+	return func(s *memoServiceImpl) {
+		if timeout <= 0 {
+			timeout = DefaultCardPreviewTimeout
+		}
+		s.previewGenerator = generator
+		s.previewTimeout = timeout
+	}
+}
+
+// WithMemoTimeFunc sets the function used to get the current time, which
+// decides the generation quota period. Defaults to time.Now.
+func WithMemoTimeFunc(now func() time.Time) MemoServiceOption {
+	return func(s *memoServiceImpl) {
+		s.now = now
+	}
 }
 
 // NewMemoService creates a new MemoService
+// Card generation is requested by emitting events on eventEmitter, so the service
+// has no direct dependency on the task runner.
 // It returns an error if any of the required dependencies are nil.
 func NewMemoService(
 	memoRepo MemoRepository,
-	taskRunner TaskRunner,
 	eventEmitter events.EventEmitter,
 	logger *slog.Logger,
+	opts ...MemoServiceOption,
 ) (MemoService, error) {
 	// Validate dependencies
 	if memoRepo == nil {
 		return nil, fmt.Errorf("memoRepo cannot be nil")
 	}
-	if taskRunner == nil {
-		return nil, fmt.Errorf("taskRunner cannot be nil")
-	}
 	if eventEmitter == nil {
 		return nil, fmt.Errorf("eventEmitter cannot be nil")
 	}
@@ -93,12 +264,18 @@ func NewMemoService(
 		logger = slog.Default()
 	}
 
-	return &memoServiceImpl{
-		memoRepo:     memoRepo,
-		taskRunner:   taskRunner,
-		eventEmitter: eventEmitter,
-		logger:       logger.With("component", "memo_service"),
-	}, nil
+	s := &memoServiceImpl{
+		memoRepo:                 memoRepo,
+		eventEmitter:             eventEmitter,
+		enforceStatusTransitions: true,
+		now:                      time.Now,
+		logger:                   logger.With("component", "memo_service"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
 }
 
 // CreateMemoAndEnqueueTask creates a new memo with pending status and emits an event for processing
@@ -118,7 +295,13 @@ func (s *memoServiceImpl) CreateMemoAndEnqueueTask(
 	}
 
 	// 2. Save the memo to the database using a transaction
+	period := domain.GenerationPeriod(s.now())
 	err = store.RunInTransaction(ctx, s.memoRepo.DB(), func(ctx context.Context, tx *sql.Tx) error {
+		// Count the generation first, so a rejected memo is never saved
+		if err := s.reserveGeneration(ctx, tx, userID, period); err != nil {
+			return err
+		}
+
 		// Get a transactional repo
 		txRepo := s.memoRepo.WithTx(tx)
 
@@ -138,40 +321,459 @@ func (s *memoServiceImpl) CreateMemoAndEnqueueTask(
 		"memo_id", memo.ID,
 		"user_id", userID)
 
-	// 3. Create a payload for the event
+	// 3. Emit the generation event
+	if err := s.emitGenerationEvent(ctx, memo); err != nil {
+		s.releaseGeneration(ctx, userID, period)
+		return nil, err
+	}
+
+	return memo, nil
+}
+
+// CreateDraftMemo creates a new memo with draft status.
+// No generation event is emitted; use GenerateMemo to start processing later.
+func (s *memoServiceImpl) CreateDraftMemo(
+	ctx context.Context,
+	userID uuid.UUID,
+	text string,
+) (*domain.Memo, error) {
+	memo, err := domain.NewDraftMemo(userID, text)
+	if err != nil {
+		s.logger.Error("failed to create draft memo object",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to create memo: %w", err)
+	}
+
+	err = store.RunInTransaction(ctx, s.memoRepo.DB(), func(ctx context.Context, tx *sql.Tx) error {
+		return s.memoRepo.WithTx(tx).Create(ctx, memo)
+	})
+	if err != nil {
+		s.logger.Error("failed to save draft memo to database",
+			"error", err,
+			"user_id", userID,
+			"memo_id", memo.ID)
+		return nil, fmt.Errorf("failed to create memo: %w", err)
+	}
+
+	s.logger.Info("memo created successfully with draft status",
+		"memo_id", memo.ID,
+		"user_id", userID)
+
+	return memo, nil
+}
+
+// CreateMemoIdempotent implements MemoService.CreateMemoIdempotent
+// The key lookup, memo creation and key creation share one transaction. If a concurrent
+// request with the same key commits first, the lookup is repeated once so that request's
+// memo is replayed.
+func (s *memoServiceImpl) CreateMemoIdempotent(
+	ctx context.Context,
+	userID uuid.UUID,
+	text string,
+	draft bool,
+	idempotencyKey string,
+) (*domain.Memo, bool, error) {
+	if err := domain.ValidateIdempotencyKey(idempotencyKey); err != nil {
+		return nil, false, domain.NewValidationError("Idempotency-Key", err.Error(), domain.ErrValidation)
+	}
+
+	if s.idempotencyKeys == nil {
+		s.logger.Debug("idempotency keys not configured, creating memo without recording key",
+			"user_id", userID)
+		if draft {
+			memo, err := s.CreateDraftMemo(ctx, userID, text)
+			return memo, false, err
+		}
+		memo, err := s.CreateMemoAndEnqueueTask(ctx, userID, text)
+		return memo, false, err
+	}
+
+	newMemo := domain.NewMemo
+	if draft {
+		newMemo = domain.NewDraftMemo
+	}
+	memo, err := newMemo(userID, text)
+	if err != nil {
+		s.logger.Error("failed to create memo object",
+			"error", err,
+			"user_id", userID)
+		return nil, false, fmt.Errorf("failed to create memo: %w", err)
+	}
+
+	requestHash := domain.HashIdempotentRequest(text, strconv.FormatBool(draft))
+
+	var result *domain.Memo
+	var replayed bool
+	period := domain.GenerationPeriod(s.now())
+	for attempt := 0; attempt < 2; attempt++ {
+		err = store.RunInTransaction(ctx, s.memoRepo.DB(), func(ctx context.Context, tx *sql.Tx) error {
+			txRepo := s.memoRepo.WithTx(tx)
+			txKeys := s.idempotencyKeys.WithTx(tx)
+
+			existing, err := txKeys.Get(ctx, userID, idempotencyKey)
+			switch {
+			case err == nil && !existing.IsExpired(time.Now().UTC(), s.idempotencyKeyTTL):
+				if existing.RequestHash != requestHash {
+					return ErrIdempotencyKeyReused
+				}
+				original, err := txRepo.GetByID(ctx, existing.MemoID)
+				if err != nil {
+					return fmt.Errorf("failed to retrieve original memo: %w", err)
+				}
+				result, replayed = original, true
+				return nil
+			case err == nil:
+				// The key has expired, so it may be used for a new memo
+				if err := txKeys.Delete(ctx, userID, idempotencyKey); err != nil {
+					return fmt.Errorf("failed to delete expired idempotency key: %w", err)
+				}
+			case !errors.Is(err, store.ErrIdempotencyKeyNotFound):
+				return fmt.Errorf("failed to look up idempotency key: %w", err)
+			}
+
+			if !draft {
+				if err := s.reserveGeneration(ctx, tx, userID, period); err != nil {
+					return err
+				}
+			}
+
+			if err := txRepo.Create(ctx, memo); err != nil {
+				return err
+			}
+
+			key, err := domain.NewIdempotencyKey(userID, idempotencyKey, requestHash, memo.ID)
+			if err != nil {
+				return err
+			}
+			if err := txKeys.Create(ctx, key); err != nil {
+				return fmt.Errorf("failed to record idempotency key: %w", err)
+			}
+
+			result, replayed = memo, false
+			return nil
+		})
+		if attempt == 0 && errors.Is(err, store.ErrDuplicate) {
+			continue
+		}
+		break
+	}
+	if err != nil {
+		s.logger.Error("failed to create memo with idempotency key",
+			"error", err,
+			"user_id", userID)
+		return nil, false, fmt.Errorf("failed to create memo: %w", err)
+	}
+
+	if replayed {
+		s.logger.Info("replayed memo for repeated idempotency key",
+			"memo_id", result.ID,
+			"user_id", userID)
+		return result, true, nil
+	}
+
+	s.logger.Info("memo created successfully with idempotency key",
+		"memo_id", result.ID,
+		"user_id", userID,
+		"status", result.Status)
+
+	if !draft {
+		if err := s.emitGenerationEvent(ctx, result); err != nil {
+			s.releaseGeneration(ctx, userID, period)
+			return nil, false, err
+		}
+	}
+
+	return result, false, nil
+}
+
+// GenerateMemo transitions a draft memo to pending status and emits an event for processing.
+// The status change is committed before the event is emitted so the generation task
+// always observes the memo as pending.
+func (s *memoServiceImpl) GenerateMemo(
+	ctx context.Context,
+	userID, memoID uuid.UUID,
+) (*domain.Memo, error) {
+	var memo *domain.Memo
+	period := domain.GenerationPeriod(s.now())
+	err := store.RunInTransaction(ctx, s.memoRepo.DB(), func(ctx context.Context, tx *sql.Tx) error {
+		txRepo := s.memoRepo.WithTx(tx)
+
+		var err error
+		memo, err = txRepo.GetByID(ctx, memoID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve memo: %w", err)
+		}
+
+		if memo.UserID != userID {
+			return ErrMemoNotOwned
+		}
+
+		if memo.Status != domain.MemoStatusDraft {
+			return ErrMemoNotDraft
+		}
+
+		if err := s.reserveGeneration(ctx, tx, userID, period); err != nil {
+			return err
+		}
+
+		if err := s.applyStatus(memo, domain.MemoStatusPending); err != nil {
+			return fmt.Errorf("failed to update memo status: %w", err)
+		}
+
+		if err := txRepo.Update(ctx, memo); err != nil {
+			return fmt.Errorf("failed to save memo status: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to submit draft memo for generation",
+			"error", err,
+			"memo_id", memoID,
+			"user_id", userID)
+		return nil, err
+	}
+
+	s.logger.Info("draft memo submitted for generation",
+		"memo_id", memo.ID,
+		"user_id", userID)
+
+	if err := s.emitGenerationEvent(ctx, memo); err != nil {
+		s.releaseGeneration(ctx, userID, period)
+		return nil, err
+	}
+
+	return memo, nil
+}
+
+// Regenerate implements MemoService.Regenerate
+// The memo update and the card changes share one transaction, which is committed
+// before the event is emitted so the generation task always observes the new text.
+func (s *memoServiceImpl) Regenerate(
+	ctx context.Context,
+	userID, memoID uuid.UUID,
+	newText string,
+	replace bool,
+) (*domain.Memo, error) {
+	if s.cardRepo == nil {
+		return nil, fmt.Errorf("memo regeneration requires a card repository")
+	}
+	if newText == "" {
+		return nil, domain.NewValidationError("text", domain.ErrMemoTextEmpty.Error(), domain.ErrValidation)
+	}
+
+	var memo *domain.Memo
+	var affected int
+	period := domain.GenerationPeriod(s.now())
+	err := store.RunInTransaction(ctx, s.memoRepo.DB(), func(ctx context.Context, tx *sql.Tx) error {
+		txRepo := s.memoRepo.WithTx(tx)
+		txCardRepo := s.cardRepo.WithTx(tx)
+
+		var err error
+		memo, err = txRepo.GetByID(ctx, memoID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve memo: %w", err)
+		}
+
+		if memo.UserID != userID {
+			return ErrMemoNotOwned
+		}
+
+		if !memo.Status.CanRegenerate() {
+			return ErrMemoGenerationInProgress
+		}
+
+		if err := s.reserveGeneration(ctx, tx, userID, period); err != nil {
+			return err
+		}
+
+		if replace {
+			affected, err = txCardRepo.DeleteByMemo(ctx, memo.ID)
+			if err != nil {
+				return fmt.Errorf("failed to delete memo cards: %w", err)
+			}
+		} else {
+			affected, err = txCardRepo.SupersedeByMemo(ctx, memo.ID, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("failed to supersede memo cards: %w", err)
+			}
+		}
+
+		if err := memo.Regenerate(newText); err != nil {
+			return fmt.Errorf("failed to regenerate memo: %w", err)
+		}
+
+		if err := txRepo.Update(ctx, memo); err != nil {
+			return fmt.Errorf("failed to save memo: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to regenerate memo",
+			"error", err,
+			"memo_id", memoID,
+			"user_id", userID)
+		return nil, err
+	}
+
+	s.logger.Info("memo submitted for regeneration",
+		"memo_id", memo.ID,
+		"user_id", userID,
+		"replace", replace,
+		"previous_cards", affected)
+
+	if err := s.emitGenerationEvent(ctx, memo); err != nil {
+		s.releaseGeneration(ctx, userID, period)
+		return nil, err
+	}
+
+	return memo, nil
+}
+
+// PreviewCards generates cards from text without saving a memo or cards
+func (s *memoServiceImpl) PreviewCards(
+	ctx context.Context,
+	userID uuid.UUID,
+	text string,
+) ([]*domain.Card, error) {
+	if s.previewGenerator == nil {
+		return nil, errors.New("card preview is not configured")
+	}
+	if userID == uuid.Nil {
+		return nil, domain.ErrMemoUserIDEmpty
+	}
+	if err := domain.ValidateMemoText(text); err != nil {
+		return nil, err
+	}
+
+	// A preview costs a generation, so it is counted before calling the
+	// generator, and given back if the generator fails. Nothing else is written.
+	period := domain.GenerationPeriod(s.now())
+	if s.generationUsage != nil {
+		err := store.RunInTransaction(ctx, s.memoRepo.DB(), func(ctx context.Context, tx *sql.Tx) error {
+			return s.reserveGeneration(ctx, tx, userID, period)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview cards: %w", err)
+		}
+	}
+
+	genCtx, cancel := context.WithTimeout(ctx, s.previewTimeout)
+	defer cancel()
+
+	cards, err := s.previewGenerator.GenerateCards(genCtx, text, userID)
+	if err != nil {
+		s.releaseGeneration(ctx, userID, period)
+
+		// The generator may not wrap the context error, so report the timeout
+		// ourselves for it to be recognized
+		if errors.Is(genCtx.Err(), context.DeadlineExceeded) {
+			s.logger.Warn("card preview timed out",
+				"user_id", userID,
+				"timeout", s.previewTimeout)
+			return nil, fmt.Errorf("card preview timed out after %s: %w",
+				s.previewTimeout, context.DeadlineExceeded)
+		}
+		s.logger.Error("failed to generate card preview",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to preview cards: %w", err)
+	}
+
+	s.logger.Info("generated card preview",
+		"user_id", userID,
+		"card_count", len(cards))
+	return cards, nil
+}
+
+// reserveGeneration counts one generation against the user's quota for the
+// period starting at period within tx, or returns a GenerationQuotaError if
+// they have none left. It does nothing unless WithGenerationQuota was given.
+func (s *memoServiceImpl) reserveGeneration(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID uuid.UUID,
+	period time.Time,
+) error {
+	if s.generationUsage == nil {
+		return nil
+	}
+
+	user, err := s.userStore.WithTx(tx).GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve user for generation quota: %w", err)
+	}
+
+	quota := user.MonthlyGenerationQuota(s.generationQuota)
+	err = s.generationUsage.WithTx(tx).Reserve(ctx, userID, period, quota)
+	if errors.Is(err, store.ErrQuotaExceeded) {
+		s.logger.Info("generation quota exceeded",
+			"user_id", userID,
+			"quota", quota)
+		return &GenerationQuotaError{Quota: quota, ResetsAt: domain.NextGenerationPeriod(period)}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record generation usage: %w", err)
+	}
+	return nil
+}
+
+// releaseGeneration gives back a generation reserved by reserveGeneration for
+// the period starting at period, when the generation could not be started.
+// Failures are logged rather than returned, as the caller is already failing.
+func (s *memoServiceImpl) releaseGeneration(ctx context.Context, userID uuid.UUID, period time.Time) {
+	if s.generationUsage == nil {
+		return
+	}
+
+	// The caller may be failing because ctx was canceled, which must not keep
+	// the generation counted
+	if err := s.generationUsage.Release(context.WithoutCancel(ctx), userID, period); err != nil {
+		s.logger.Error("failed to release generation usage",
+			"error", err,
+			"user_id", userID)
+	}
+}
+
+// emitGenerationEvent emits a TaskRequestEvent asking for cards to be generated from the memo
+func (s *memoServiceImpl) emitGenerationEvent(ctx context.Context, memo *domain.Memo) error {
+	// Create a payload for the event
 	payload := struct {
 		MemoID uuid.UUID `json:"memo_id"`
 	}{
 		MemoID: memo.ID,
 	}
 
-	// 4. Create and emit a TaskRequestEvent
+	// Create and emit a TaskRequestEvent
 	event, err := events.NewTaskRequestEvent(task.TaskTypeMemoGeneration, payload)
 	if err != nil {
 		s.logger.Error("failed to create memo generation event",
 			"error", err,
 			"memo_id", memo.ID,
-			"user_id", userID)
-		return nil, fmt.Errorf("failed to create event: %w", err)
+			"user_id", memo.UserID)
+		return fmt.Errorf("failed to create event: %w", err)
 	}
+	// A user is waiting on these cards, so they go ahead of background work
+	event.Priority = task.PriorityInteractive
 
-	// 5. Emit the event
 	err = s.eventEmitter.EmitEvent(ctx, event)
 	if err != nil {
 		s.logger.Error("failed to emit memo generation event",
 			"error", err,
 			"memo_id", memo.ID,
-			"user_id", userID,
+			"user_id", memo.UserID,
 			"event_id", event.ID)
-		return nil, fmt.Errorf("failed to emit event: %w", err)
+		return fmt.Errorf("failed to emit event: %w", err)
 	}
 
 	s.logger.Info("memo generation event emitted successfully",
 		"memo_id", memo.ID,
-		"user_id", userID,
+		"user_id", memo.UserID,
 		"event_id", event.ID)
 
-	return memo, nil
+	return nil
 }
 
 // GetMemo retrieves a memo by its ID
@@ -219,7 +821,7 @@ func (s *memoServiceImpl) UpdateMemoStatus(
 			}
 
 			// Update the memo's status
-			err = memo.UpdateStatus(status)
+			err = s.applyStatus(memo, status)
 			if err != nil {
 				s.logger.Error("failed to update memo status",
 					"error", err,
@@ -246,3 +848,12 @@ func (s *memoServiceImpl) UpdateMemoStatus(
 		},
 	)
 }
+
+// applyStatus sets the memo's status, validating the transition against the
+// canonical state machine when enforcement is enabled.
+func (s *memoServiceImpl) applyStatus(memo *domain.Memo, status domain.MemoStatus) error {
+	if s.enforceStatusTransitions {
+		return memo.TransitionStatus(status)
+	}
+	return memo.UpdateStatus(status)
+}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/task"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// txBoundMemoRepository keeps every operation on the test transaction, even when
+// the service opens its own transaction, so all changes are rolled back with the test.
+type txBoundMemoRepository struct {
+	store.MemoStore
+	dbConn *sql.DB
+}
+
+func (r *txBoundMemoRepository) WithTx(tx *sql.Tx) service.MemoRepository {
+	return r
+}
+
+func (r *txBoundMemoRepository) DB() *sql.DB {
+	return r.dbConn
+}
+
+// TestMemoService_DraftMemos tests that draft memos are not queued for generation
+// until GenerateMemo is called explicitly
+func TestMemoService_DraftMemos(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+
+		userID := testutils.MustInsertUser(ctx, t, tx, "draft-memo-test@example.com", bcrypt.MinCost)
+		otherUserID := testutils.MustInsertUser(ctx, t, tx, "draft-memo-other@example.com", bcrypt.MinCost)
+
+		repo := &txBoundMemoRepository{
+			MemoStore: postgres.NewPostgresMemoStore(tx, logger),
+			dbConn:    db,
+		}
+
+		mockEventEmitter := new(MockEventEmitter)
+		mockEventEmitter.On("EmitEvent", mock.Anything, mock.Anything).Return(nil)
+
+		memoService, err := service.NewMemoService(repo, mockEventEmitter, logger)
+		require.NoError(t, err, "Failed to create memo service")
+
+		memoStatus := func(memoID uuid.UUID) string {
+			var status string
+			err := tx.QueryRowContext(ctx, "SELECT status FROM memos WHERE id = $1", memoID).
+				Scan(&status)
+			require.NoError(t, err, "Failed to get memo status")
+			return status
+		}
+
+		// Creating a draft stores the memo without emitting a generation event
+		memo, err := memoService.CreateDraftMemo(ctx, userID, "Draft memo to generate later")
+		require.NoError(t, err, "Draft creation should succeed")
+		assert.Equal(t, domain.MemoStatusDraft, memo.Status)
+		assert.Equal(t, string(domain.MemoStatusDraft), memoStatus(memo.ID))
+		mockEventEmitter.AssertNotCalled(t, "EmitEvent", mock.Anything, mock.Anything)
+
+		// Another user cannot trigger generation
+		_, err = memoService.GenerateMemo(ctx, otherUserID, memo.ID)
+		assert.ErrorIs(t, err, service.ErrMemoNotOwned)
+		assert.Equal(t, string(domain.MemoStatusDraft), memoStatus(memo.ID))
+		mockEventEmitter.AssertNotCalled(t, "EmitEvent", mock.Anything, mock.Anything)
+
+		// The owner triggers generation explicitly
+		generated, err := memoService.GenerateMemo(ctx, userID, memo.ID)
+		require.NoError(t, err, "Generation should succeed")
+		assert.Equal(t, domain.MemoStatusPending, generated.Status)
+		assert.Equal(t, string(domain.MemoStatusPending), memoStatus(memo.ID))
+		mockEventEmitter.AssertNumberOfCalls(t, "EmitEvent", 1)
+		mockEventEmitter.AssertCalled(t, "EmitEvent", mock.Anything,
+			mock.MatchedBy(func(event *events.TaskRequestEvent) bool {
+				return event.Priority == task.PriorityInteractive
+			}))
+
+		// A memo that is no longer a draft cannot be generated again
+		_, err = memoService.GenerateMemo(ctx, userID, memo.ID)
+		assert.ErrorIs(t, err, service.ErrMemoNotDraft)
+		mockEventEmitter.AssertNumberOfCalls(t, "EmitEvent", 1)
+
+		// Unknown memos are reported as not found
+		_, err = memoService.GenerateMemo(ctx, userID, uuid.New())
+		assert.ErrorIs(t, err, store.ErrMemoNotFound)
+	})
+}
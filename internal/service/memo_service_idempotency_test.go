@@ -0,0 +1,142 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestMemoService_CreateMemoIdempotent verifies that replaying an idempotency key
+// returns the original memo and that reusing it for a different request is rejected
+func TestMemoService_CreateMemoIdempotent(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	ctx := context.Background()
+	logger := slog.Default()
+	memoStore := postgres.NewPostgresMemoStore(db, logger)
+	keyStore := postgres.NewPostgresIdempotencyKeyStore(db, logger)
+
+	// The service manages its own transactions, so data is committed and cleaned up per user
+	userID := testutils.MustInsertUser(ctx, t, db, "idempotent-memo-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	otherUserID := testutils.MustInsertUser(ctx, t, db, "idempotent-other-"+uuid.NewString()+"@example.com", bcrypt.MinCost)
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, "DELETE FROM users WHERE id = ANY($1::uuid[])",
+			[]string{userID.String(), otherUserID.String()})
+	})
+
+	newService := func(t *testing.T, ttl time.Duration) (service.MemoService, *MockEventEmitter) {
+		eventEmitter := new(MockEventEmitter)
+		eventEmitter.On("EmitEvent", mock.Anything, mock.Anything).Return(nil)
+		memoService, err := service.NewMemoService(
+			service.NewMemoRepositoryAdapter(memoStore, db),
+			eventEmitter,
+			logger,
+			service.WithIdempotencyKeys(keyStore, ttl),
+		)
+		require.NoError(t, err)
+		return memoService, eventEmitter
+	}
+
+	t.Run("replay_returns_same_memo", func(t *testing.T) {
+		memoService, eventEmitter := newService(t, time.Hour)
+		key := uuid.NewString()
+
+		first, replayed, err := memoService.CreateMemoIdempotent(ctx, userID, "Replay me", false, key)
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		assert.Equal(t, domain.MemoStatusPending, first.Status)
+
+		second, replayed, err := memoService.CreateMemoIdempotent(ctx, userID, "Replay me", false, key)
+		require.NoError(t, err)
+		assert.True(t, replayed)
+		assert.Equal(t, first.ID, second.ID)
+
+		// Only the original request queues generation
+		eventEmitter.AssertNumberOfCalls(t, "EmitEvent", 1)
+
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM memos WHERE user_id = $1 AND text = $2", userID, "Replay me").Scan(&count))
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("different_body_is_rejected", func(t *testing.T) {
+		memoService, _ := newService(t, time.Hour)
+		key := uuid.NewString()
+
+		_, _, err := memoService.CreateMemoIdempotent(ctx, userID, "Original text", false, key)
+		require.NoError(t, err)
+
+		_, _, err = memoService.CreateMemoIdempotent(ctx, userID, "Changed text", false, key)
+		assert.ErrorIs(t, err, service.ErrIdempotencyKeyReused)
+
+		_, _, err = memoService.CreateMemoIdempotent(ctx, userID, "Original text", true, key)
+		assert.ErrorIs(t, err, service.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("keys_are_scoped_per_user", func(t *testing.T) {
+		memoService, _ := newService(t, time.Hour)
+		key := uuid.NewString()
+
+		mine, _, err := memoService.CreateMemoIdempotent(ctx, userID, "Shared key", false, key)
+		require.NoError(t, err)
+
+		theirs, replayed, err := memoService.CreateMemoIdempotent(ctx, otherUserID, "Shared key", false, key)
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		assert.NotEqual(t, mine.ID, theirs.ID)
+	})
+
+	t.Run("expired_key_creates_new_memo", func(t *testing.T) {
+		memoService, _ := newService(t, time.Hour)
+		key := uuid.NewString()
+
+		first, _, err := memoService.CreateMemoIdempotent(ctx, userID, "Expiring", true, key)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MemoStatusDraft, first.Status)
+
+		// Age the key past the TTL
+		_, err = db.ExecContext(ctx,
+			"UPDATE idempotency_keys SET created_at = NOW() - INTERVAL '2 hours' WHERE user_id = $1 AND key = $2",
+			userID, key)
+		require.NoError(t, err)
+
+		second, replayed, err := memoService.CreateMemoIdempotent(ctx, userID, "Expiring", true, key)
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		assert.NotEqual(t, first.ID, second.ID)
+	})
+
+	t.Run("invalid_key", func(t *testing.T) {
+		memoService, _ := newService(t, time.Hour)
+
+		_, _, err := memoService.CreateMemoIdempotent(ctx, userID, "Text", false, "")
+		assert.ErrorIs(t, err, domain.ErrValidation)
+
+		longKey := make([]byte, domain.MaxIdempotencyKeyLength+1)
+		for i := range longKey {
+			longKey[i] = 'k'
+		}
+		_, _, err = memoService.CreateMemoIdempotent(ctx, userID, "Text", false, string(longKey))
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
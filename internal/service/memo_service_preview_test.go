@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// previewGenerator is a fake generator returning fixed cards, failing with err
+// when it is set, or waiting for its context to end when block is set
+type previewGenerator struct {
+	cards []*domain.Card
+	err   error
+	block bool
+	calls int
+}
+
+// GenerateCards implements task.Generator
+func (g *previewGenerator) GenerateCards(
+	ctx context.Context,
+	memoText string,
+	userID uuid.UUID,
+) ([]*domain.Card, error) {
+	g.calls++
+	if g.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+	return g.cards, nil
+}
+
+// TestMemoService_PreviewCards checks that previews return the generated cards
+// without saving anything but the quota usage
+func TestMemoService_PreviewCards(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	userStore := memory.NewUserStore(backend, bcrypt.MinCost)
+	memoStore := memory.NewMemoStore(backend)
+	cardStore := memory.NewCardStore(backend)
+	usage := memory.NewGenerationUsageStore(backend)
+
+	user, err := domain.NewUser("preview@example.com", "preview-test-password")
+	require.NoError(t, err)
+	require.NoError(t, userStore.Create(ctx, user))
+
+	generator := &previewGenerator{cards: []*domain.Card{
+		{ID: uuid.New(), UserID: user.ID, Content: json.RawMessage(`{"front":"Q1","back":"A1"}`)},
+		{ID: uuid.New(), UserID: user.ID, Content: json.RawMessage(`{"front":"Q2","back":"A2"}`)},
+	}}
+
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	emitter := new(MockEventEmitter)
+	newService := func(t *testing.T, timeout time.Duration) service.MemoService {
+		memoService, err := service.NewMemoService(
+			service.NewMemoRepositoryAdapter(memoStore, backend.DB()),
+			emitter,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+			service.WithGenerationQuota(usage, userStore, 2),
+			service.WithCardPreview(generator, timeout),
+			service.WithMemoTimeFunc(func() time.Time { return now }),
+		)
+		require.NoError(t, err)
+		return memoService
+	}
+	memoService := newService(t, time.Second)
+
+	generations := func(t *testing.T) int {
+		count, err := usage.Count(ctx, user.ID, domain.GenerationPeriod(now))
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("returns generated cards without saving them", func(t *testing.T) {
+		cards, err := memoService.PreviewCards(ctx, user.ID, "Some notes to preview")
+		require.NoError(t, err)
+		assert.Equal(t, generator.cards, cards)
+
+		for _, status := range []domain.MemoStatus{
+			domain.MemoStatusDraft,
+			domain.MemoStatusPending,
+			domain.MemoStatusProcessing,
+			domain.MemoStatusCompleted,
+		} {
+			memos, err := memoStore.FindMemosByStatus(ctx, status, 10, 0)
+			require.NoError(t, err)
+			assert.Empty(t, memos, "No %s memo should be saved", status)
+		}
+		cardCount, err := cardStore.CountByUser(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Zero(t, cardCount, "Previewed cards should not be saved")
+		emitter.AssertNotCalled(t, "EmitEvent", mock.Anything, mock.Anything)
+
+		assert.Equal(t, 1, generations(t), "A preview counts against the generation quota")
+	})
+
+	t.Run("rejects invalid text before generating", func(t *testing.T) {
+		calls := generator.calls
+		_, err := memoService.PreviewCards(ctx, user.ID, "")
+		require.Error(t, err)
+		assert.Equal(t, calls, generator.calls)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		generator.block = true
+		t.Cleanup(func() { generator.block = false })
+
+		before := generations(t)
+		_, err := newService(t, 10*time.Millisecond).PreviewCards(ctx, user.ID, "Slow notes")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, before, generations(t), "A timed out preview does not count")
+	})
+
+	t.Run("failed preview does not count", func(t *testing.T) {
+		generator.err = errors.New("generation failed")
+		t.Cleanup(func() { generator.err = nil })
+
+		before := generations(t)
+		_, err := memoService.PreviewCards(ctx, user.ID, "Failing notes")
+		require.Error(t, err)
+		assert.Equal(t, before, generations(t), "A failed preview does not count")
+	})
+
+	t.Run("respects the generation quota", func(t *testing.T) {
+		_, err := memoService.PreviewCards(ctx, user.ID, "Last preview within quota")
+		require.NoError(t, err)
+
+		calls := generator.calls
+		_, err = memoService.PreviewCards(ctx, user.ID, "Over quota")
+		require.ErrorIs(t, err, service.ErrGenerationQuotaExceeded)
+		assert.Equal(t, calls, generator.calls, "The generator is not called over quota")
+	})
+
+	t.Run("is unavailable without a generator", func(t *testing.T) {
+		unconfigured, err := service.NewMemoService(
+			service.NewMemoRepositoryAdapter(memoStore, backend.DB()),
+			emitter,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+		require.NoError(t, err)
+
+		_, err = unconfigured.PreviewCards(ctx, user.ID, "Some notes")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,157 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestMemoService_GenerationQuota uses up a user's monthly generation quota on
+// the in-memory stores and checks that further generation is refused until
+// the next month
+func TestMemoService_GenerationQuota(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	userStore := memory.NewUserStore(backend, bcrypt.MinCost)
+	memoStore := memory.NewMemoStore(backend)
+	usage := memory.NewGenerationUsageStore(backend)
+
+	newUser := func(email string, quota *int) *domain.User {
+		user, err := domain.NewUser(email, "quota-test-password")
+		require.NoError(t, err)
+		user.GenerationQuota = quota
+		require.NoError(t, userStore.Create(ctx, user))
+		return user
+	}
+
+	now := time.Date(2025, time.March, 30, 12, 0, 0, 0, time.UTC)
+	emitter := new(MockEventEmitter)
+	emitter.On("EmitEvent", mock.Anything, mock.Anything).Return(nil)
+	memoService, err := service.NewMemoService(
+		service.NewMemoRepositoryAdapter(memoStore, backend.DB()),
+		emitter,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		service.WithGenerationQuota(usage, userStore, 2),
+		service.WithMemoTimeFunc(func() time.Time { return now }),
+	)
+	require.NoError(t, err)
+
+	t.Run("default quota", func(t *testing.T) {
+		user := newUser("quota-default@example.com", nil)
+
+		for i := 0; i < 2; i++ {
+			_, err := memoService.CreateMemoAndEnqueueTask(ctx, user.ID, "Within quota")
+			require.NoError(t, err)
+		}
+
+		_, err := memoService.CreateMemoAndEnqueueTask(ctx, user.ID, "Over quota")
+		require.ErrorIs(t, err, service.ErrGenerationQuotaExceeded)
+		var quotaErr *service.GenerationQuotaError
+		require.True(t, errors.As(err, &quotaErr))
+		assert.Equal(t, 2, quotaErr.Quota)
+		assert.Equal(t, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), quotaErr.ResetsAt)
+
+		// The rejected memo is not stored
+		pending, err := memoStore.FindMemosByStatus(ctx, domain.MemoStatusPending, 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, pending, 2)
+
+		// Drafts don't generate cards, so they can still be saved, but not submitted
+		draft, err := memoService.CreateDraftMemo(ctx, user.ID, "Draft")
+		require.NoError(t, err)
+		_, err = memoService.GenerateMemo(ctx, user.ID, draft.ID)
+		require.ErrorIs(t, err, service.ErrGenerationQuotaExceeded)
+		stored, err := memoStore.GetByID(ctx, draft.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MemoStatusDraft, stored.Status, "A rejected draft stays a draft")
+
+		_, replayed, err := memoService.CreateMemoIdempotent(ctx, user.ID, "Over quota", false, "quota-key")
+		require.ErrorIs(t, err, service.ErrGenerationQuotaExceeded)
+		assert.False(t, replayed)
+
+		count, err := usage.Count(ctx, user.ID, domain.GenerationPeriod(now))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count, "Rejected generations are not counted")
+
+		// The quota resets when the month rolls over
+		now = now.AddDate(0, 0, 3)
+		_, err = memoService.GenerateMemo(ctx, user.ID, draft.ID)
+		require.NoError(t, err)
+		_, err = memoService.CreateMemoAndEnqueueTask(ctx, user.ID, "Next month")
+		require.NoError(t, err)
+		_, err = memoService.CreateMemoAndEnqueueTask(ctx, user.ID, "Over next month's quota")
+		require.ErrorIs(t, err, service.ErrGenerationQuotaExceeded)
+	})
+
+	t.Run("user quota overrides the default", func(t *testing.T) {
+		one := 1
+		user := newUser("quota-override@example.com", &one)
+
+		_, err := memoService.CreateMemoAndEnqueueTask(ctx, user.ID, "Within quota")
+		require.NoError(t, err)
+		_, err = memoService.CreateMemoAndEnqueueTask(ctx, user.ID, "Over quota")
+		require.ErrorIs(t, err, service.ErrGenerationQuotaExceeded)
+	})
+
+	t.Run("unlimited user quota", func(t *testing.T) {
+		unlimited := 0
+		user := newUser("quota-unlimited@example.com", &unlimited)
+
+		for i := 0; i < 5; i++ {
+			_, err := memoService.CreateMemoAndEnqueueTask(ctx, user.ID, "Unlimited")
+			require.NoError(t, err)
+		}
+
+		count, err := usage.Count(ctx, user.ID, domain.GenerationPeriod(now))
+		require.NoError(t, err)
+		assert.Equal(t, 5, count, "Generations are counted even without a limit")
+	})
+
+	t.Run("generations whose event fails are not counted", func(t *testing.T) {
+		user := newUser("quota-emit-failure@example.com", nil)
+
+		failingEmitter := new(MockEventEmitter)
+		failingEmitter.On("EmitEvent", mock.Anything, mock.Anything).Return(errors.New("queue unavailable"))
+		failingService, err := service.NewMemoService(
+			service.NewMemoRepositoryAdapter(memoStore, backend.DB()),
+			failingEmitter,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+			service.WithGenerationQuota(usage, userStore, 2),
+			service.WithMemoTimeFunc(func() time.Time { return now }),
+		)
+		require.NoError(t, err)
+
+		_, err = failingService.CreateMemoAndEnqueueTask(ctx, user.ID, "Not generated")
+		require.Error(t, err)
+		draft, err := failingService.CreateDraftMemo(ctx, user.ID, "Draft")
+		require.NoError(t, err)
+		_, err = failingService.GenerateMemo(ctx, user.ID, draft.ID)
+		require.Error(t, err)
+		_, _, err = failingService.CreateMemoIdempotent(ctx, user.ID, "Not generated", false, "emit-failure-key")
+		require.Error(t, err)
+
+		count, err := usage.Count(ctx, user.ID, domain.GenerationPeriod(now))
+		require.NoError(t, err)
+		assert.Zero(t, count, "Generations that never started are given back")
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		err := usage.Reserve(ctx, uuid.New(), domain.GenerationPeriod(now), 0)
+		assert.ErrorIs(t, err, store.ErrReferencedEntityMissing)
+	})
+}
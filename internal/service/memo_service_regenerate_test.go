@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// txBoundCardRepository keeps every card operation on the test transaction, like
+// txBoundMemoRepository does for memos.
+type txBoundCardRepository struct {
+	service.CardRepository
+}
+
+func (r *txBoundCardRepository) WithTx(tx *sql.Tx) service.CardRepository {
+	return r
+}
+
+// TestMemoService_Regenerate tests that regenerating a memo either supersedes or
+// deletes its existing cards before queueing it for generation again
+func TestMemoService_Regenerate(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+
+		userID := testutils.MustInsertUser(ctx, t, tx, "regenerate-memo-test@example.com", bcrypt.MinCost)
+		otherUserID := testutils.MustInsertUser(ctx, t, tx, "regenerate-memo-other@example.com", bcrypt.MinCost)
+
+		memoRepo := &txBoundMemoRepository{
+			MemoStore: postgres.NewPostgresMemoStore(tx, logger),
+			dbConn:    db,
+		}
+		cardRepo := &txBoundCardRepository{
+			CardRepository: service.NewCardRepositoryAdapter(postgres.NewPostgresCardStore(tx, logger), db),
+		}
+
+		mockEventEmitter := new(MockEventEmitter)
+		mockEventEmitter.On("EmitEvent", mock.Anything, mock.Anything).Return(nil)
+
+		memoService, err := service.NewMemoService(
+			memoRepo,
+			mockEventEmitter,
+			logger,
+			service.WithMemoCardRepository(cardRepo),
+		)
+		require.NoError(t, err, "Failed to create memo service")
+
+		// insertCompletedMemo inserts a completed memo with one reviewed card
+		insertCompletedMemo := func() (*domain.Memo, *domain.Card) {
+			memo := testutils.MustInsertMemo(ctx, t, tx, userID)
+			_, err := tx.ExecContext(ctx, "UPDATE memos SET status = $1 WHERE id = $2",
+				domain.MemoStatusCompleted, memo.ID)
+			require.NoError(t, err, "Failed to complete memo")
+			card := testutils.MustInsertCard(ctx, t, tx, userID, memo.ID)
+			testutils.MustInsertUserCardStats(ctx, t, tx, userID, card.ID)
+			return memo, card
+		}
+
+		countRows := func(query string, args ...interface{}) int {
+			var count int
+			require.NoError(t, tx.QueryRowContext(ctx, query, args...).Scan(&count))
+			return count
+		}
+
+		t.Run("keeps superseded cards and their history by default", func(t *testing.T) {
+			memo, card := insertCompletedMemo()
+
+			regenerated, err := memoService.Regenerate(ctx, userID, memo.ID, "Edited memo text", false)
+			require.NoError(t, err, "Regeneration should succeed")
+			assert.Equal(t, domain.MemoStatusPending, regenerated.Status)
+			assert.Equal(t, "Edited memo text", regenerated.Text)
+
+			stored, err := memoRepo.GetByID(ctx, memo.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "Edited memo text", stored.Text)
+			assert.Equal(t, domain.MemoStatusPending, stored.Status)
+
+			kept, err := cardRepo.GetByID(ctx, card.ID)
+			require.NoError(t, err, "Superseded card should be kept")
+			assert.True(t, kept.IsSuperseded())
+			assert.Equal(t, 1, countRows("SELECT COUNT(*) FROM user_card_stats WHERE card_id = $1", card.ID))
+		})
+
+		t.Run("deletes existing cards when replacing", func(t *testing.T) {
+			memo, card := insertCompletedMemo()
+
+			regenerated, err := memoService.Regenerate(ctx, userID, memo.ID, "Replaced memo text", true)
+			require.NoError(t, err, "Regeneration should succeed")
+			assert.Equal(t, domain.MemoStatusPending, regenerated.Status)
+
+			_, err = cardRepo.GetByID(ctx, card.ID)
+			assert.ErrorIs(t, err, store.ErrCardNotFound)
+			assert.Equal(t, 0, countRows("SELECT COUNT(*) FROM user_card_stats WHERE card_id = $1", card.ID))
+		})
+
+		t.Run("rejects memos that cannot be regenerated", func(t *testing.T) {
+			memo, card := insertCompletedMemo()
+			emitted := len(mockEventEmitter.Calls)
+
+			_, err := memoService.Regenerate(ctx, otherUserID, memo.ID, "Someone else's text", false)
+			assert.ErrorIs(t, err, service.ErrMemoNotOwned)
+
+			_, err = memoService.Regenerate(ctx, userID, uuid.New(), "Unknown memo", false)
+			assert.ErrorIs(t, err, store.ErrMemoNotFound)
+
+			_, err = memoService.Regenerate(ctx, userID, memo.ID, "First edit", false)
+			require.NoError(t, err)
+			_, err = memoService.Regenerate(ctx, userID, memo.ID, "Second edit", true)
+			assert.ErrorIs(t, err, service.ErrMemoGenerationInProgress)
+
+			// The rejected replace left the superseded card in place
+			kept, err := cardRepo.GetByID(ctx, card.ID)
+			require.NoError(t, err)
+			assert.True(t, kept.IsSuperseded())
+			mockEventEmitter.AssertNumberOfCalls(t, "EmitEvent", emitted+1)
+		})
+	})
+}
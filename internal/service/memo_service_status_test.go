@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestMemoService_UpdateMemoStatus_StateMachine tests that UpdateMemoStatus follows
+// the memo state machine unless enforcement is disabled
+func TestMemoService_UpdateMemoStatus_StateMachine(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+
+		userID := testutils.MustInsertUser(ctx, t, tx, "memo-state-machine@example.com", bcrypt.MinCost)
+		memoStore := postgres.NewPostgresMemoStore(tx, logger)
+		repo := &txBoundMemoRepository{MemoStore: memoStore, dbConn: db}
+
+		strictService, err := service.NewMemoService(
+			repo, new(MockEventEmitter), logger,
+		)
+		require.NoError(t, err, "Failed to create memo service")
+
+		lenientService, err := service.NewMemoService(
+			repo, new(MockEventEmitter), logger,
+			service.WithMemoStatusTransitionEnforcement(false),
+		)
+		require.NoError(t, err, "Failed to create memo service")
+
+		memo := testutils.MustInsertMemo(ctx, t, tx, userID)
+		require.Equal(t, domain.MemoStatusPending, memo.Status)
+
+		// Legal transitions succeed
+		require.NoError(t, strictService.UpdateMemoStatus(ctx, memo.ID, domain.MemoStatusProcessing))
+		require.NoError(t, strictService.UpdateMemoStatus(ctx, memo.ID, domain.MemoStatusCompleted))
+
+		// Illegal transitions are rejected and leave the memo unchanged
+		err = strictService.UpdateMemoStatus(ctx, memo.ID, domain.MemoStatusPending)
+		assert.ErrorIs(t, err, domain.ErrMemoStatusTransitionInvalid)
+
+		stored, err := memoStore.GetByID(ctx, memo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MemoStatusCompleted, stored.Status)
+
+		// Without enforcement any valid status may be set
+		require.NoError(t, lenientService.UpdateMemoStatus(ctx, memo.ID, domain.MemoStatusPending))
+
+		stored, err = memoStore.GetByID(ctx, memo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MemoStatusPending, stored.Status)
+	})
+}
@@ -54,17 +54,6 @@ func (m *MockMemoRepository) DB() *sql.DB {
 	return nil
 }
 
-// MockTaskRunner is a mock implementation of the TaskRunner
-type MockTaskRunner struct {
-	mock.Mock
-}
-
-// Submit implements TaskRunner
-func (m *MockTaskRunner) Submit(ctx context.Context, task task.Task) error {
-	args := m.Called(ctx, task)
-	return args.Error(0)
-}
-
 // MockEventEmitter is a mock implementation of the events.EventEmitter interface
 type MockEventEmitter struct {
 	mock.Mock
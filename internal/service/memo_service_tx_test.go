@@ -82,16 +82,6 @@ func (m *MockFailingMemoRepository) DB() *sql.DB {
 	return m.dbConn
 }
 
-// MockTaskRunner for task submission
-type MockTaskRunner struct {
-	mock.Mock
-}
-
-func (m *MockTaskRunner) Submit(ctx context.Context, task task.Task) error {
-	args := m.Called(ctx, task)
-	return args.Error(0)
-}
-
 // MockEventEmitter implements the events.EventEmitter interface for testing
 type MockEventEmitter struct {
 	mock.Mock
@@ -165,7 +155,6 @@ func TestMemoService_CreateMemoAndEnqueueTask_Atomicity(t *testing.T) {
 			}
 
 			// Create mocks for tasks
-			mockRunner := new(MockTaskRunner)
 			mockEventEmitter := new(MockEventEmitter)
 
 			// Setup expectations
@@ -176,7 +165,6 @@ func TestMemoService_CreateMemoAndEnqueueTask_Atomicity(t *testing.T) {
 			// Create service with the failing repository
 			memoService, err := service.NewMemoService(
 				failingRepo,
-				mockRunner,
 				mockEventEmitter,
 				logger,
 			)
@@ -216,7 +204,6 @@ func TestMemoService_CreateMemoAndEnqueueTask_Atomicity(t *testing.T) {
 			}
 
 			// Create mocks for tasks
-			mockRunner := new(MockTaskRunner)
 			mockEventEmitter := new(MockEventEmitter)
 
 			// Setup expectations
@@ -225,7 +212,6 @@ func TestMemoService_CreateMemoAndEnqueueTask_Atomicity(t *testing.T) {
 			// Create service with the succeeding repository
 			memoService, err := service.NewMemoService(
 				successRepo,
-				mockRunner,
 				mockEventEmitter,
 				logger,
 			)
@@ -278,7 +264,6 @@ func TestMemoService_UpdateMemoStatus_Atomicity(t *testing.T) {
 		memoStore := postgres.NewPostgresMemoStore(tx, logger)
 
 		// Create mocks for task components (not used in these tests)
-		mockRunner := new(MockTaskRunner)
 		mockEventEmitter := new(MockEventEmitter)
 
 		// Create a test memo directly
@@ -307,7 +292,6 @@ func TestMemoService_UpdateMemoStatus_Atomicity(t *testing.T) {
 			// Create service with the failing repository
 			memoService, err := service.NewMemoService(
 				failingRepo,
-				mockRunner,
 				mockEventEmitter,
 				logger,
 			)
@@ -347,7 +331,6 @@ func TestMemoService_UpdateMemoStatus_Atomicity(t *testing.T) {
 			// Create service with the failing repository
 			memoService, err := service.NewMemoService(
 				failingRepo,
-				mockRunner,
 				mockEventEmitter,
 				logger,
 			)
@@ -386,7 +369,6 @@ func TestMemoService_UpdateMemoStatus_Atomicity(t *testing.T) {
 			// Create service with the succeeding repository
 			memoService, err := service.NewMemoService(
 				successRepo,
-				mockRunner,
 				mockEventEmitter,
 				logger,
 			)
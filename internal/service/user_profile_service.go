@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+)
+
+// UserProfile combines a user's account details with aggregate review statistics.
+type UserProfile struct {
+	User *domain.User
+
+	// TotalCards is the number of cards the user owns
+	TotalCards int
+
+	// DueToday is the number of cards due for review before the end of the current day
+	// in the user's timezone, including overdue cards
+	DueToday int
+
+	// Streak holds the current and longest runs of consecutive review days
+	Streak domain.ReviewStreak
+}
+
+// UserProfileService provides read access to a user's profile and aggregate statistics
+type UserProfileService interface {
+	// GetProfile retrieves the profile of the specified user.
+	// Returns store.ErrUserNotFound if the user does not exist.
+	GetProfile(ctx context.Context, userID uuid.UUID) (*UserProfile, error)
+
+	// GetReviewForecast returns the number of reviews due on each of the next days
+	// days in the user's timezone, starting today (which includes overdue cards).
+	// If deckID is non-nil, only cards in that deck are counted.
+	// Returns store.ErrInvalidEntity if days is out of range.
+	GetReviewForecast(
+		ctx context.Context,
+		userID uuid.UUID,
+		deckID *uuid.UUID,
+		days int,
+	) ([]domain.ForecastBucket, error)
+}
+
+// userProfileServiceImpl implements the UserProfileService interface
+type userProfileServiceImpl struct {
+	userStore      store.UserStore
+	cardStore      store.CardStore
+	statsStore     store.UserCardStatsStore
+	reviewLogStore store.ReviewLogStore
+	timeFunc       func() time.Time
+	logger         *slog.Logger
+}
+
+// NewUserProfileService creates a new UserProfileService
+// It returns an error if any of the required dependencies are nil.
+func NewUserProfileService(
+	userStore store.UserStore,
+	cardStore store.CardStore,
+	statsStore store.UserCardStatsStore,
+	reviewLogStore store.ReviewLogStore,
+	logger *slog.Logger,
+) (UserProfileService, error) {
+	// Validate dependencies
+	if userStore == nil {
+		return nil, fmt.Errorf("userStore cannot be nil")
+	}
+	if cardStore == nil {
+		return nil, fmt.Errorf("cardStore cannot be nil")
+	}
+	if statsStore == nil {
+		return nil, fmt.Errorf("statsStore cannot be nil")
+	}
+	if reviewLogStore == nil {
+		return nil, fmt.Errorf("reviewLogStore cannot be nil")
+	}
+
+	// Use provided logger or create default
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &userProfileServiceImpl{
+		userStore:      userStore,
+		cardStore:      cardStore,
+		statsStore:     statsStore,
+		reviewLogStore: reviewLogStore,
+		timeFunc:       time.Now,
+		logger:         logger.With("component", "user_profile_service"),
+	}, nil
+}
+
+// GetProfile retrieves the user and computes their aggregate statistics.
+// Each aggregate is backed by a single count query, so no card rows are loaded.
+func (s *userProfileServiceImpl) GetProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*UserProfile, error) {
+	user, err := s.userStore.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to retrieve user for profile",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	totalCards, err := s.cardStore.CountByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to count cards for profile",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to count cards: %w", err)
+	}
+
+	// "Due today" covers everything due by the end of the user's current day
+	now := s.timeFunc()
+
+	dueToday, err := s.statsStore.CountDue(ctx, userID, user.DueAsOfEndOfDay(now))
+	if err != nil {
+		s.logger.Error("failed to count due cards for profile",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to count due cards: %w", err)
+	}
+
+	streak, err := calculateUserStreak(ctx, s.reviewLogStore, user, now)
+	if err != nil {
+		s.logger.Error("failed to compute review streak for profile",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to compute review streak: %w", err)
+	}
+
+	s.logger.Debug("retrieved user profile successfully",
+		"user_id", userID,
+		"total_cards", totalCards,
+		"due_today", dueToday,
+		"current_streak", streak.Current,
+		"longest_streak", streak.Longest)
+
+	return &UserProfile{
+		User:       user,
+		TotalCards: totalCards,
+		DueToday:   dueToday,
+		Streak:     streak,
+	}, nil
+}
+
+// GetReviewForecast retrieves the per-day review counts with a single grouped query.
+func (s *userProfileServiceImpl) GetReviewForecast(
+	ctx context.Context,
+	userID uuid.UUID,
+	deckID *uuid.UUID,
+	days int,
+) ([]domain.ForecastBucket, error) {
+	buckets, err := s.statsStore.GetForecast(ctx, userID, deckID, days)
+	if err != nil {
+		s.logger.Error("failed to retrieve review forecast",
+			"error", err,
+			"user_id", userID,
+			"days", days)
+		return nil, fmt.Errorf("failed to retrieve review forecast: %w", err)
+	}
+	return buckets, nil
+}
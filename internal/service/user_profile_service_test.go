@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestUserProfileService_GetProfile_Aggregates verifies that the profile aggregates
+// are computed from the database for the requesting user only
+func TestUserProfileService_GetProfile_Aggregates(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+		now := time.Now().UTC()
+
+		userID := testutils.MustInsertUser(ctx, t, tx, "profile-stats@example.com", bcrypt.MinCost)
+		otherUserID := testutils.MustInsertUser(ctx, t, tx, "profile-other@example.com", bcrypt.MinCost)
+
+		userStore := postgres.NewPostgresUserStore(tx, bcrypt.MinCost)
+		cardStore := postgres.NewPostgresCardStore(tx, logger)
+		statsStore := postgres.NewPostgresUserCardStatsStore(tx, logger)
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
+
+		// Seed a card with stats due at the given time
+		insertCardDueAt := func(ownerID, memoID uuid.UUID, dueAt time.Time) {
+			card := testutils.MustInsertCard(ctx, t, tx, ownerID, memoID)
+			stats := testutils.MustCreateStatsForTest(t,
+				testutils.WithStatsUserID(ownerID),
+				testutils.WithStatsCardID(card.ID),
+				testutils.WithStatsNextReviewAt(dueAt),
+			)
+			require.NoError(t, statsStore.Create(ctx, stats), "Failed to insert test stats")
+		}
+
+		memo := testutils.MustInsertMemo(ctx, t, tx, userID)
+		insertCardDueAt(userID, memo.ID, now.Add(-2*24*time.Hour))
+		insertCardDueAt(userID, memo.ID, now.Add(-time.Hour))
+		insertCardDueAt(userID, memo.ID, now.Add(3*24*time.Hour))
+
+		// Cards belonging to another user must not be counted
+		otherMemo := testutils.MustInsertMemo(ctx, t, tx, otherUserID)
+		insertCardDueAt(otherUserID, otherMemo.ID, now.Add(-time.Hour))
+
+		// Reviews on two consecutive days, then a gap
+		for _, seed := range []struct {
+			userID     uuid.UUID
+			reviewedAt time.Time
+		}{
+			{userID, now.Add(-time.Minute)},
+			{userID, now.Add(-24 * time.Hour)},
+			{userID, now.Add(-72 * time.Hour)},
+			{otherUserID, now.Add(-48 * time.Hour)},
+		} {
+			err := reviewLogStore.RecordReview(ctx, seed.userID, seed.reviewedAt)
+			require.NoError(t, err, "Failed to record review day")
+		}
+
+		profileService, err := service.NewUserProfileService(
+			userStore,
+			cardStore,
+			statsStore,
+			reviewLogStore,
+			logger,
+		)
+		require.NoError(t, err, "Failed to create profile service")
+
+		profile, err := profileService.GetProfile(ctx, userID)
+		require.NoError(t, err, "GetProfile should succeed")
+
+		assert.Equal(t, userID, profile.User.ID)
+		assert.Equal(t, "profile-stats@example.com", profile.User.Email)
+		assert.Equal(t, domain.UserRoleUser, profile.User.Role)
+		assert.Equal(t, 3, profile.TotalCards, "All of the user's cards should be counted")
+		assert.Equal(t, 2, profile.DueToday, "Overdue and due cards should be counted")
+		assert.Equal(t, 2, profile.Streak.Current, "Streak should stop at the first missed day")
+		assert.Equal(t, 2, profile.Streak.Longest)
+	})
+}
+
+// TestUserProfileService_GetProfile_DueTodayTimezone verifies that "due today" ends
+// at midnight in each user's own timezone rather than at midnight UTC
+func TestUserProfileService_GetProfile_DueTodayTimezone(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+		now := time.Now()
+
+		userStore := postgres.NewPostgresUserStore(tx, bcrypt.MinCost)
+		cardStore := postgres.NewPostgresCardStore(tx, logger)
+		statsStore := postgres.NewPostgresUserCardStatsStore(tx, logger)
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
+
+		profileService, err := service.NewUserProfileService(
+			userStore,
+			cardStore,
+			statsStore,
+			reviewLogStore,
+			logger,
+		)
+		require.NoError(t, err, "Failed to create profile service")
+
+		for _, timezone := range []string{"Asia/Tokyo", "America/Los_Angeles"} {
+			t.Run(timezone, func(t *testing.T) {
+				userID := testutils.MustInsertUser(
+					ctx, t, tx, "due-today-"+uuid.NewString()+"@example.com", bcrypt.MinCost,
+				)
+				_, err := tx.ExecContext(ctx,
+					"UPDATE users SET timezone = $1 WHERE id = $2", timezone, userID)
+				require.NoError(t, err, "Failed to set user timezone")
+
+				user := &domain.User{Timezone: timezone}
+				endOfDay := user.EndOfDay(now)
+
+				// One card due just before the user's midnight, one just after
+				memo := testutils.MustInsertMemo(ctx, t, tx, userID)
+				for _, dueAt := range []time.Time{
+					endOfDay.Add(-time.Minute),
+					endOfDay.Add(time.Minute),
+				} {
+					card := testutils.MustInsertCard(ctx, t, tx, userID, memo.ID)
+					stats := testutils.MustCreateStatsForTest(t,
+						testutils.WithStatsUserID(userID),
+						testutils.WithStatsCardID(card.ID),
+						testutils.WithStatsNextReviewAt(dueAt),
+					)
+					require.NoError(t, statsStore.Create(ctx, stats), "Failed to insert test stats")
+				}
+
+				profile, err := profileService.GetProfile(ctx, userID)
+				require.NoError(t, err, "GetProfile should succeed")
+
+				assert.Equal(t, timezone, profile.User.Timezone)
+				assert.Equal(t, 2, profile.TotalCards)
+				assert.Equal(t, 1, profile.DueToday,
+					"Only the card due before midnight in %s should be counted", timezone)
+			})
+		}
+	})
+}
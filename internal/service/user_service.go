@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -20,7 +21,9 @@ type UserService interface {
 	// GetUserByEmail retrieves a user by their email address
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 
-	// CreateUser creates a new user with the specified email and password
+	// CreateUser creates a new user with the specified email and password.
+	// A password breaking the service's password policy is rejected with a
+	// domain.ValidationError wrapping domain.ErrPasswordPolicy.
 	CreateUser(ctx context.Context, email, password string) (*domain.User, error)
 
 	// UpdateUserEmail updates a user's email address
@@ -30,26 +33,94 @@ type UserService interface {
 
 	// UpdateUserPassword updates a user's password
 	// Following the pattern of getting the full user first, then updating only the specific field
+	// The new password must follow the password policy, as in CreateUser.
 	UpdateUserPassword(ctx context.Context, userID uuid.UUID, newPassword string) error
 
+	// UpdateUserTimezone sets the IANA time zone used for the user's day boundaries.
+	// Returns domain.ErrUserTimezoneInvalid if the name is not a known time zone.
+	UpdateUserTimezone(ctx context.Context, userID uuid.UUID, timezone string) error
+
+	// SetDisabled disables or re-enables the target user's account and returns
+	// the updated user. Disabled users fail authentication; disabling an
+	// already disabled account keeps the time it was first disabled.
+	SetDisabled(ctx context.Context, targetID uuid.UUID, disabled bool) (*domain.User, error)
+
 	// DeleteUser deletes a user by their ID
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
+
+	// GetStreak computes the user's current and longest review streaks.
+	// Calendar days are evaluated in the user's configured timezone.
+	GetStreak(ctx context.Context, userID uuid.UUID) (*domain.ReviewStreak, error)
 }
 
 // UserServiceImpl implements the UserService interface
 type UserServiceImpl struct {
-	userStore store.UserStore
-	logger    *slog.Logger
-	db        *sql.DB
+	userStore      store.UserStore
+	reviewLogStore store.ReviewLogStore
+	logger         *slog.Logger
+	db             *sql.DB
+	passwordPolicy domain.PasswordPolicy
+}
+
+// UserServiceOption configures optional UserService behavior
+type UserServiceOption func(*UserServiceImpl)
+
+// WithUserPasswordPolicy makes the service enforce policy on new and changed
+// passwords. Without this option domain.DefaultPasswordPolicy is enforced.
+func WithUserPasswordPolicy(policy domain.PasswordPolicy) UserServiceOption {
+	return func(s *UserServiceImpl) {
+		s.passwordPolicy = policy
+	}
 }
 
 // NewUserService creates a new UserService
-func NewUserService(userStore store.UserStore, db *sql.DB, logger *slog.Logger) UserService {
-	return &UserServiceImpl{
-		userStore: userStore,
-		db:        db,
-		logger:    logger.With("component", "user_service"),
+func NewUserService(
+	userStore store.UserStore,
+	reviewLogStore store.ReviewLogStore,
+	db *sql.DB,
+	logger *slog.Logger,
+	opts ...UserServiceOption,
+) UserService {
+	service := &UserServiceImpl{
+		userStore:      userStore,
+		reviewLogStore: reviewLogStore,
+		db:             db,
+		logger:         logger.With("component", "user_service"),
+		passwordPolicy: domain.DefaultPasswordPolicy(),
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
+}
+
+// EnsureEmailAvailable returns store.ErrEmailExists if a user other than
+// exceptUserID is already registered with email. Pass uuid.Nil for exceptUserID
+// when registering a new user.
+//
+// The check is an early, explicit guard; callers must still handle
+// store.ErrEmailExists from Create/Update, which the database unique constraint
+// produces when a concurrent request claims the email between check and write.
+// Both paths report the same error so callers can treat them identically.
+func EnsureEmailAvailable(
+	ctx context.Context,
+	userStore store.UserStore,
+	email string,
+	exceptUserID uuid.UUID,
+) error {
+	existing, err := userStore.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to check email availability: %w", err)
+	}
+
+	if existing.ID == exceptUserID {
+		return nil
 	}
+
+	return store.ErrEmailExists
 }
 
 // GetUser retrieves a user by their ID
@@ -97,6 +168,12 @@ func (s *UserServiceImpl) CreateUser(
 	ctx context.Context,
 	email, password string,
 ) (*domain.User, error) {
+	if err := s.passwordPolicy.Check(password, email); err != nil {
+		s.logger.Debug("rejected password breaking the password policy",
+			"email", email)
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
 	user, err := domain.NewUser(email, password)
 	if err != nil {
 		s.logger.Error("failed to create user object",
@@ -110,7 +187,14 @@ func (s *UserServiceImpl) CreateUser(
 		// Get a transaction-aware store
 		txStore := s.userStore.WithTx(tx)
 
-		// Create the user within the transaction
+		// Check for an existing account first so the common case fails fast
+		if err := EnsureEmailAvailable(ctx, txStore, email, uuid.Nil); err != nil {
+			return err
+		}
+
+		// Create the user within the transaction; a concurrent registration
+		// can still win the race, in which case the unique constraint reports
+		// the same store.ErrEmailExists
 		return txStore.Create(ctx, user)
 	})
 
@@ -154,6 +238,16 @@ func (s *UserServiceImpl) UpdateUserEmail(
 			return fmt.Errorf("failed to retrieve user for update: %w", err)
 		}
 
+		// Make sure no other user already has the new email
+		if err := EnsureEmailAvailable(ctx, txStore, newEmail, userID); err != nil {
+			if errors.Is(err, store.ErrEmailExists) {
+				s.logger.Debug("attempted to update to an existing email",
+					"user_id", userID,
+					"new_email", newEmail)
+			}
+			return fmt.Errorf("failed to update user email: %w", err)
+		}
+
 		// Update only the email field
 		user.Email = newEmail
 
@@ -203,8 +297,17 @@ func (s *UserServiceImpl) UpdateUserPassword(
 			return fmt.Errorf("failed to retrieve user for password update: %w", err)
 		}
 
+		if err := s.passwordPolicy.Check(newPassword, user.Email); err != nil {
+			s.logger.Debug("rejected password breaking the password policy",
+				"user_id", userID)
+			return fmt.Errorf("failed to update user password: %w", err)
+		}
+
 		// Set the new password (UserStore.Update will handle the hashing)
+		// and revoke the sessions issued before the change
 		user.Password = newPassword
+		changedAt := time.Now().UTC()
+		user.PasswordChangedAt = &changedAt
 
 		// Save the complete user object back to the store
 		// Note: UserStore.Update now requires a complete user object including HashedPassword
@@ -223,6 +326,111 @@ func (s *UserServiceImpl) UpdateUserPassword(
 	})
 }
 
+// UpdateUserTimezone updates a user's timezone
+// The timezone is validated before the store is touched so unknown zones are
+// reported as domain.ErrUserTimezoneInvalid rather than a generic invalid entity
+// Uses a transaction to ensure atomicity of the operation
+func (s *UserServiceImpl) UpdateUserTimezone(
+	ctx context.Context,
+	userID uuid.UUID,
+	timezone string,
+) error {
+	return store.RunInTransaction(ctx, s.db, func(ctx context.Context, tx *sql.Tx) error {
+		// Get a transaction-aware store
+		txStore := s.userStore.WithTx(tx)
+
+		// First, retrieve the current user to get the complete user object
+		user, err := txStore.GetByID(ctx, userID)
+		if err != nil {
+			s.logger.Error("failed to retrieve user for timezone update",
+				"error", err,
+				"user_id", userID)
+			return fmt.Errorf("failed to retrieve user for timezone update: %w", err)
+		}
+
+		// Update only the timezone field and validate it up front; unlike on
+		// creation, an empty timezone is rejected rather than defaulted
+		user.Timezone = timezone
+		err = user.Validate()
+		if err == nil && timezone == "" {
+			err = domain.ErrUserTimezoneInvalid
+		}
+		if err != nil {
+			s.logger.Debug("rejected invalid timezone",
+				"user_id", userID,
+				"timezone", timezone)
+			return fmt.Errorf("failed to update user timezone: %w", err)
+		}
+
+		err = txStore.Update(ctx, user)
+		if err != nil {
+			s.logger.Error("failed to update user timezone",
+				"error", err,
+				"user_id", userID,
+				"timezone", timezone)
+			return fmt.Errorf("failed to update user timezone: %w", err)
+		}
+
+		s.logger.Info("user timezone updated successfully in transaction",
+			"user_id", userID,
+			"timezone", timezone)
+
+		return nil
+	})
+}
+
+// SetDisabled disables or re-enables a user's account
+// Uses a transaction to ensure atomicity of the operation
+func (s *UserServiceImpl) SetDisabled(
+	ctx context.Context,
+	targetID uuid.UUID,
+	disabled bool,
+) (*domain.User, error) {
+	var user *domain.User
+	err := store.RunInTransaction(ctx, s.db, func(ctx context.Context, tx *sql.Tx) error {
+		// Get a transaction-aware store
+		txStore := s.userStore.WithTx(tx)
+
+		var err error
+		user, err = txStore.GetByID(ctx, targetID)
+		if err != nil {
+			s.logger.Error("failed to retrieve user to change disabled state",
+				"error", err,
+				"user_id", targetID)
+			return fmt.Errorf("failed to retrieve user to change disabled state: %w", err)
+		}
+
+		// Nothing to do if the account is already in the requested state
+		if user.IsDisabled() == disabled {
+			return nil
+		}
+		if disabled {
+			now := time.Now().UTC()
+			user.DisabledAt = &now
+		} else {
+			user.DisabledAt = nil
+		}
+
+		if err := txStore.Update(ctx, user); err != nil {
+			s.logger.Error("failed to change user disabled state",
+				"error", err,
+				"user_id", targetID,
+				"disabled", disabled)
+			return fmt.Errorf("failed to change user disabled state: %w", err)
+		}
+
+		s.logger.Info("user disabled state changed successfully in transaction",
+			"user_id", targetID,
+			"disabled", disabled)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 // DeleteUser deletes a user by their ID
 // Uses a transaction to ensure atomicity of the operation
 func (s *UserServiceImpl) DeleteUser(ctx context.Context, userID uuid.UUID) error {
@@ -250,3 +458,48 @@ func (s *UserServiceImpl) DeleteUser(ctx context.Context, userID uuid.UUID) erro
 		return nil
 	})
 }
+
+// GetStreak computes the user's current and longest review streaks from the review log
+func (s *UserServiceImpl) GetStreak(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*domain.ReviewStreak, error) {
+	user, err := s.userStore.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to retrieve user for streak",
+			"error", err,
+			"user_id", userID)
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	streak, err := calculateUserStreak(ctx, s.reviewLogStore, user, time.Now())
+	if err != nil {
+		s.logger.Error("failed to calculate review streak",
+			"error", err,
+			"user_id", userID)
+		return nil, err
+	}
+
+	s.logger.Debug("calculated review streak",
+		"user_id", userID,
+		"current_streak", streak.Current,
+		"longest_streak", streak.Longest)
+
+	return &streak, nil
+}
+
+// calculateUserStreak computes a user's review streak as of now, with "today"
+// evaluated in the user's timezone
+func calculateUserStreak(
+	ctx context.Context,
+	reviewLogStore store.ReviewLogStore,
+	user *domain.User,
+	now time.Time,
+) (domain.ReviewStreak, error) {
+	days, err := reviewLogStore.GetReviewDays(ctx, user.ID)
+	if err != nil {
+		return domain.ReviewStreak{}, fmt.Errorf("failed to retrieve review days: %w", err)
+	}
+
+	return domain.CalculateReviewStreak(days, now.In(user.Location())), nil
+}
@@ -1,12 +1,67 @@
 package service_test
 
 import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/mocks"
+	"github.com/phrazzld/scry-api/internal/platform/memory"
+	"github.com/phrazzld/scry-api/internal/platform/postgres"
+	"github.com/phrazzld/scry-api/internal/service"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/phrazzld/scry-api/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Note: We're skipping transaction-based tests in this package since they're better suited
 // for integration tests. See cmd/server/*_test.go for transaction-based testing.
 
+func TestEnsureEmailAvailable(t *testing.T) {
+	ctx := context.Background()
+	existingID := uuid.New()
+
+	newStore := func() *mocks.MockUserStore {
+		userStore := mocks.NewMockUserStore()
+		userStore.Users["taken@example.com"] = &domain.User{
+			ID:    existingID,
+			Email: "taken@example.com",
+		}
+		return userStore
+	}
+
+	t.Run("unused email is available", func(t *testing.T) {
+		err := service.EnsureEmailAvailable(ctx, newStore(), "free@example.com", uuid.Nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("registered email conflicts", func(t *testing.T) {
+		err := service.EnsureEmailAvailable(ctx, newStore(), "taken@example.com", uuid.Nil)
+		assert.ErrorIs(t, err, store.ErrEmailExists)
+	})
+
+	t.Run("own email does not conflict", func(t *testing.T) {
+		err := service.EnsureEmailAvailable(ctx, newStore(), "taken@example.com", existingID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("lookup failure is returned", func(t *testing.T) {
+		userStore := newStore()
+		userStore.GetByEmailError = errors.New("database unavailable")
+
+		err := service.EnsureEmailAvailable(ctx, userStore, "taken@example.com", uuid.Nil)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, store.ErrEmailExists)
+	})
+}
+
 func TestUserService_UpdateUserEmail(t *testing.T) {
 	// Skip test with transaction mocking - this would be tested in an integration test
 	t.Skip("Skipping test that requires transaction management")
@@ -16,3 +71,140 @@ func TestUserService_UpdateUserPassword(t *testing.T) {
 	// Skip test with transaction mocking - this would be tested in an integration test
 	t.Skip("Skipping test that requires transaction management")
 }
+
+func TestUserService_GetStreak(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+
+		userStore := postgres.NewPostgresUserStore(tx, bcrypt.MinCost)
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
+		userService := service.NewUserService(userStore, reviewLogStore, db, logger)
+
+		// A timezone far from UTC makes day boundaries differ from the server's
+		user, err := domain.NewUser("streak-test@example.com", "password123456")
+		require.NoError(t, err)
+		user.Timezone = "Pacific/Kiritimati"
+		require.NoError(t, userStore.Create(ctx, user))
+
+		// No reviews yet
+		streak, err := userService.GetStreak(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReviewStreak{}, *streak)
+
+		// An older run of three days, a gap, then today and yesterday
+		now := time.Now()
+		for _, daysAgo := range []int{10, 9, 8, 1, 0} {
+			reviewedAt := now.AddDate(0, 0, -daysAgo)
+			require.NoError(t, reviewLogStore.RecordReview(ctx, user.ID, reviewedAt))
+		}
+
+		streak, err = userService.GetStreak(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, streak.Current, "Current streak should cover today and yesterday")
+		assert.Equal(t, 3, streak.Longest, "Longest streak should be the older three-day run")
+
+		// Unknown users are reported as not found
+		_, err = userService.GetStreak(ctx, uuid.New())
+		assert.ErrorIs(t, err, store.ErrUserNotFound)
+	})
+}
+
+// TestUserService_SetDisabled disables and re-enables an account on the
+// in-memory stores
+func TestUserService_SetDisabled(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	userStore := memory.NewUserStore(backend, bcrypt.MinCost)
+	userService := service.NewUserService(
+		userStore,
+		memory.NewReviewLogStore(backend),
+		backend.DB(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	user, err := domain.NewUser("disable-test@example.com", "disable-test-password")
+	require.NoError(t, err)
+	require.NoError(t, userStore.Create(ctx, user))
+
+	t.Run("disables the account", func(t *testing.T) {
+		before := time.Now().UTC()
+		disabled, err := userService.SetDisabled(ctx, user.ID, true)
+		require.NoError(t, err)
+		require.NotNil(t, disabled.DisabledAt)
+		assert.False(t, disabled.DisabledAt.Before(before))
+
+		stored, err := userStore.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, disabled.DisabledAt, stored.DisabledAt)
+	})
+
+	t.Run("disabling again keeps the original time", func(t *testing.T) {
+		stored, err := userStore.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+
+		again, err := userService.SetDisabled(ctx, user.ID, true)
+		require.NoError(t, err)
+		assert.Equal(t, stored.DisabledAt, again.DisabledAt)
+	})
+
+	t.Run("enables the account", func(t *testing.T) {
+		enabled, err := userService.SetDisabled(ctx, user.ID, false)
+		require.NoError(t, err)
+		assert.False(t, enabled.IsDisabled())
+
+		stored, err := userStore.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.IsDisabled())
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := userService.SetDisabled(ctx, uuid.New(), true)
+		assert.ErrorIs(t, err, store.ErrUserNotFound)
+	})
+}
+
+// TestUserService_PasswordPolicy checks that new and changed passwords must
+// follow the configured password policy
+func TestUserService_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+	t.Cleanup(func() { _ = backend.Close() })
+
+	userStore := memory.NewUserStore(backend, bcrypt.MinCost)
+	userService := service.NewUserService(
+		userStore,
+		memory.NewReviewLogStore(backend),
+		backend.DB(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		service.WithUserPasswordPolicy(domain.PasswordPolicy{MinLength: 14, MinStrength: 3}),
+	)
+
+	_, err := userService.CreateUser(ctx, "weak@example.com", "password123456")
+	require.ErrorIs(t, err, domain.ErrPasswordPolicy, "A common password should be rejected")
+	_, err = userStore.GetByEmail(ctx, "weak@example.com")
+	assert.ErrorIs(t, err, store.ErrUserNotFound, "No user should be created")
+
+	user, err := userService.CreateUser(ctx, "strong@example.com", "vT8#qLm2!zRw9p")
+	require.NoError(t, err)
+
+	err = userService.UpdateUserPassword(ctx, user.ID, "vT8#qLm2!zRw9")
+	require.ErrorIs(t, err, domain.ErrPasswordPolicy, "A password below the configured length should be rejected")
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Message, "at least 14 characters")
+
+	require.NoError(t, userService.UpdateUserPassword(ctx, user.ID, "kangaroo-lamp-vivid"))
+}
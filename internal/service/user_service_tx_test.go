@@ -69,6 +69,14 @@ func (m *MockFailingUserStore) GetByEmail(ctx context.Context, email string) (*d
 	return m.UserStore.GetByEmail(ctx, email)
 }
 
+func (m *MockFailingUserStore) List(
+	ctx context.Context,
+	emailQuery string,
+	limit, offset int,
+) ([]*domain.User, error) {
+	return m.UserStore.List(ctx, emailQuery, limit, offset)
+}
+
 func (m *MockFailingUserStore) Update(ctx context.Context, user *domain.User) error {
 	if m.FailOnUpdate {
 		return errors.New("simulated update failure")
@@ -83,6 +91,13 @@ func (m *MockFailingUserStore) Update(ctx context.Context, user *domain.User) er
 	return nil
 }
 
+func (m *MockFailingUserStore) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	if m.FailOnUpdate {
+		return errors.New("simulated update failure")
+	}
+	return m.UserStore.UpdatePasswordHash(ctx, id, oldHash, newHash)
+}
+
 func (m *MockFailingUserStore) Delete(ctx context.Context, id uuid.UUID) error {
 	if m.FailOnDelete {
 		return errors.New("simulated delete failure")
@@ -105,6 +120,80 @@ func (m *MockFailingUserStore) WithTx(tx *sql.Tx) store.UserStore {
 	}
 }
 
+// txBoundUserStore keeps every operation on the test transaction, even when
+// the service opens its own transaction. When skipEmailCheck is set, GetByEmail
+// never finds a user, simulating a concurrent registration that lands between
+// the service's pre-check and its insert.
+type txBoundUserStore struct {
+	store.UserStore
+	skipEmailCheck bool
+}
+
+func (s *txBoundUserStore) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if s.skipEmailCheck {
+		return nil, store.ErrUserNotFound
+	}
+	return s.UserStore.GetByEmail(ctx, email)
+}
+
+func (s *txBoundUserStore) WithTx(tx *sql.Tx) store.UserStore {
+	return s
+}
+
+// TestUserService_CreateUser_EmailUniqueness tests that a duplicate email produces
+// the same conflict error whether caught by the pre-check or the unique constraint
+func TestUserService_CreateUser_EmailUniqueness(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+
+		userStore := postgres.NewPostgresUserStore(tx, bcrypt.MinCost)
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
+
+		email := "email-uniqueness-test@example.com"
+		testutils.MustInsertUser(ctx, t, tx, email, bcrypt.MinCost)
+
+		tests := []struct {
+			name           string
+			skipEmailCheck bool
+		}{
+			{name: "caught by pre-check", skipEmailCheck: false},
+			{name: "caught by unique constraint", skipEmailCheck: true},
+		}
+
+		var messages []string
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				userService := service.NewUserService(
+					&txBoundUserStore{UserStore: userStore, skipEmailCheck: tc.skipEmailCheck},
+					reviewLogStore,
+					db,
+					logger,
+				)
+
+				user, err := userService.CreateUser(ctx, email, "password123456")
+				assert.Nil(t, user)
+				require.ErrorIs(t, err, store.ErrEmailExists)
+				messages = append(messages, err.Error())
+			})
+		}
+
+		// Both paths must surface an identical error to callers
+		require.Len(t, messages, 2)
+		assert.Equal(t, messages[0], messages[1])
+	})
+}
+
 // TestUserService_CreateUser_Atomicity tests that user creation is atomic
 func TestUserService_CreateUser_Atomicity(t *testing.T) {
 	// Skip if not in integration test environment
@@ -123,6 +212,7 @@ func TestUserService_CreateUser_Atomicity(t *testing.T) {
 
 		// Setup base user store with transaction
 		userStore := postgres.NewPostgresUserStore(tx, 4) // Low cost for testing
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
 
 		t.Run("Transaction_Rollback_On_Failure", func(t *testing.T) {
 			// Create a failing repository that first creates the user but then fails
@@ -132,7 +222,7 @@ func TestUserService_CreateUser_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the failing store
-			userService := service.NewUserService(failingStore, db, logger)
+			userService := service.NewUserService(failingStore, reviewLogStore, db, logger)
 
 			// Attempt to create a user - this should fail after committing to DB but before committing the transaction
 			email := "tx-rollback-test@example.com"
@@ -165,7 +255,7 @@ func TestUserService_CreateUser_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the succeeding store
-			userService := service.NewUserService(successStore, db, logger)
+			userService := service.NewUserService(successStore, reviewLogStore, db, logger)
 
 			// Create a user - this should succeed
 			email := "tx-commit-success@example.com"
@@ -207,6 +297,7 @@ func TestUserService_UpdateUserEmail_Atomicity(t *testing.T) {
 
 		// Setup base user store with transaction
 		userStore := postgres.NewPostgresUserStore(tx, 4) // Low cost for testing
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
 
 		// Create a test user directly
 		initialEmail := "update-email-tx-test@example.com"
@@ -220,7 +311,7 @@ func TestUserService_UpdateUserEmail_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the failing store
-			userService := service.NewUserService(failingStore, db, logger)
+			userService := service.NewUserService(failingStore, reviewLogStore, db, logger)
 
 			// Attempt to update email - this should fail during GetByID
 			newEmail := "new-email-getbyid-fail@example.com"
@@ -254,7 +345,7 @@ func TestUserService_UpdateUserEmail_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the failing store
-			userService := service.NewUserService(failingStore, db, logger)
+			userService := service.NewUserService(failingStore, reviewLogStore, db, logger)
 
 			// Attempt to update email - this should fail during Update
 			newEmail := "new-email-update-fail@example.com"
@@ -287,7 +378,7 @@ func TestUserService_UpdateUserEmail_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the succeeding store
-			userService := service.NewUserService(successStore, db, logger)
+			userService := service.NewUserService(successStore, reviewLogStore, db, logger)
 
 			// Update the email - this should succeed
 			newEmail := "new-email-success@example.com"
@@ -326,6 +417,7 @@ func TestUserService_UpdateUserPassword_Atomicity(t *testing.T) {
 
 		// Setup base user store with transaction
 		userStore := postgres.NewPostgresUserStore(tx, 4) // Low cost for testing
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
 
 		// Create a test user directly
 		email := "update-password-tx-test@example.com"
@@ -347,7 +439,7 @@ func TestUserService_UpdateUserPassword_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the failing store
-			userService := service.NewUserService(failingStore, db, logger)
+			userService := service.NewUserService(failingStore, reviewLogStore, db, logger)
 
 			// Attempt to update password - this should fail after Get but before Update
 			newPassword := "NewSecurePass456!"
@@ -376,7 +468,7 @@ func TestUserService_UpdateUserPassword_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the succeeding store
-			userService := service.NewUserService(successStore, db, logger)
+			userService := service.NewUserService(successStore, reviewLogStore, db, logger)
 
 			// Update the password - this should succeed
 			newPassword := "SuccessPassword789!"
@@ -416,6 +508,7 @@ func TestUserService_DeleteUser_Atomicity(t *testing.T) {
 
 		// Setup base user store with transaction
 		userStore := postgres.NewPostgresUserStore(tx, 4) // Low cost for testing
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
 
 		// Create a test user for the delete failure test
 		emailFail := "delete-fail-tx-test@example.com"
@@ -433,7 +526,7 @@ func TestUserService_DeleteUser_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the failing store
-			userService := service.NewUserService(failingStore, db, logger)
+			userService := service.NewUserService(failingStore, reviewLogStore, db, logger)
 
 			// Attempt to delete the user - this should fail
 			err := userService.DeleteUser(ctx, userIDFail)
@@ -464,7 +557,7 @@ func TestUserService_DeleteUser_Atomicity(t *testing.T) {
 			}
 
 			// Create service with the succeeding store
-			userService := service.NewUserService(successStore, db, logger)
+			userService := service.NewUserService(successStore, reviewLogStore, db, logger)
 
 			// Delete the user - this should succeed
 			err := userService.DeleteUser(ctx, userIDSuccess)
@@ -483,3 +576,55 @@ func TestUserService_DeleteUser_Atomicity(t *testing.T) {
 		})
 	})
 }
+
+// TestUserService_UpdateUserTimezone tests that timezones are validated when updated
+func TestUserService_UpdateUserTimezone(t *testing.T) {
+	// Skip if not in integration test environment
+	if !testutils.IsIntegrationTestEnvironment() {
+		t.Skip("Skipping integration test - requires DATABASE_URL environment variable")
+	}
+
+	// Get a database connection
+	db, err := testutils.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+	defer testutils.AssertCloseNoError(t, db)
+
+	testutils.WithTx(t, db, func(tx store.DBTX) {
+		ctx := context.Background()
+		logger := slog.Default()
+
+		userStore := postgres.NewPostgresUserStore(tx, bcrypt.MinCost)
+		reviewLogStore := postgres.NewPostgresReviewLogStore(tx, logger)
+		userService := service.NewUserService(
+			&txBoundUserStore{UserStore: userStore},
+			reviewLogStore,
+			db,
+			logger,
+		)
+
+		userID := testutils.MustInsertUser(ctx, t, tx, "timezone-update@example.com", bcrypt.MinCost)
+
+		storedTimezone := func() string {
+			user, err := userStore.GetByID(ctx, userID)
+			require.NoError(t, err, "Failed to retrieve user")
+			return user.Timezone
+		}
+
+		// Valid zones on opposite sides of the date line are accepted
+		for _, timezone := range []string{"Asia/Tokyo", "America/Los_Angeles"} {
+			require.NoError(t, userService.UpdateUserTimezone(ctx, userID, timezone))
+			assert.Equal(t, timezone, storedTimezone())
+		}
+
+		// Unknown and empty zones are rejected without changing the stored value
+		for _, timezone := range []string{"Mars/Olympus_Mons", "Local", ""} {
+			err := userService.UpdateUserTimezone(ctx, userID, timezone)
+			assert.ErrorIs(t, err, domain.ErrUserTimezoneInvalid, "timezone %q", timezone)
+			assert.Equal(t, "America/Los_Angeles", storedTimezone())
+		}
+
+		// Unknown users are reported as not found
+		err := userService.UpdateUserTimezone(ctx, uuid.New(), "Asia/Tokyo")
+		assert.ErrorIs(t, err, store.ErrUserNotFound)
+	})
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -36,11 +37,20 @@ type CardStore interface {
 	// The returned card will have its Content field properly populated from JSONB.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Card, error)
 
-	// UpdateContent modifies an existing card's content field.
+	// GetByIDs retrieves multiple cards in a single query, keyed by card ID.
+	// IDs that do not match an existing card are simply absent from the returned
+	// map; this is not an error. Duplicate IDs are allowed and the order of ids
+	// is not significant. An empty ids slice returns an empty map.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Card, error)
+
+	// UpdateContent modifies an existing card's content field using optimistic
+	// concurrency control: the update only applies if the card's current version
+	// equals expectedVersion, and it increments the version. Returns the new version.
 	// Returns ErrCardNotFound if the card does not exist.
+	// Returns ErrVersionConflict if the card has been modified since expectedVersion.
 	// Returns validation errors if the content is invalid JSON.
 	// Implementations should validate the content before updating.
-	UpdateContent(ctx context.Context, id uuid.UUID, content []byte) error
+	UpdateContent(ctx context.Context, id uuid.UUID, content []byte, expectedVersion int) (int, error)
 
 	// Delete removes a card from the store by its ID.
 	// Returns ErrCardNotFound if the card does not exist.
@@ -56,16 +66,22 @@ type CardStore interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// GetNextReviewCard retrieves the next card due for review for a user.
-	// It determines which card is due next based on the UserCardStats.NextReviewAt field,
-	// returning the card with the earliest NextReviewAt that is <= current time.
+	// It considers the cards that are due at the current time, as defined by
+	// domain.IsCardDue, and picks one of them according to order:
+	//   - domain.ReviewOrderDueDate: the card with the earliest NextReviewAt
+	//   - domain.ReviewOrderRandomDue: a random due card
+	//   - domain.ReviewOrderLowestEase: the card with the lowest EaseFactor,
+	//     then the earliest NextReviewAt
+	// An empty order means domain.DefaultReviewOrder. Remaining ties are broken by
+	// card ID so that the deterministic orders always return the same card.
 	//
 	// The method queries both the cards and user_card_stats tables, joining them to find
 	// cards owned by the specified user that are due for review (based on NextReviewAt).
-	// Results are ordered by NextReviewAt ascending (oldest due cards first).
 	//
 	// Parameters:
 	//   - ctx: Context for the operation, which can be used for cancellation
 	//   - userID: UUID of the user whose cards to check for review
+	//   - order: Which due card to return first
 	//
 	// Returns:
 	//   - (*domain.Card, nil): The next card due for review if one exists
@@ -74,11 +90,148 @@ type CardStore interface {
 	//
 	// Error Handling:
 	//   - Returns store.ErrCardNotFound (which wraps store.ErrNotFound) when no cards are due
+	//   - Returns an error wrapping domain.ErrInvalidReviewOrder for an unknown order
 	//   - Database errors are mapped to appropriate store errors via MapError or similar
 	//
 	// This method is central to the spaced repetition system (SRS) functionality and
 	// should be optimized for performance, as it may be called frequently during review sessions.
-	GetNextReviewCard(ctx context.Context, userID uuid.UUID) (*domain.Card, error)
+	GetNextReviewCard(ctx context.Context, userID uuid.UUID, order domain.ReviewOrder) (*domain.Card, error)
+
+	// GetNextDueCard retrieves the next due card for a user, like GetNextReviewCard,
+	// but restricted by review history. When newCards is true only cards that have
+	// never been reviewed (ReviewCount = 0) are considered; otherwise only cards that
+	// have been reviewed at least once are considered. When deckID is not nil, only
+	// cards assigned to that deck are considered. Matching cards are picked in the
+	// given order, as for GetNextReviewCard.
+	//
+	// This lets callers serve due reviews before introducing new cards, and cap how
+	// many new cards are introduced. Returns store.ErrCardNotFound if no matching
+	// card is due.
+	GetNextDueCard(
+		ctx context.Context,
+		userID uuid.UUID,
+		deckID *uuid.UUID,
+		newCards bool,
+		order domain.ReviewOrder,
+	) (*domain.Card, error)
+
+	// GetNextDueCardExcluding is GetNextDueCard, skipping the cards whose IDs are
+	// in exclude. Clients pass the cards already served in a review session, so a
+	// card whose answer failed to submit is not served again straight away.
+	// Returns store.ErrCardNotFound if no other matching card is due.
+	GetNextDueCardExcluding(
+		ctx context.Context,
+		userID uuid.UUID,
+		deckID *uuid.UUID,
+		newCards bool,
+		order domain.ReviewOrder,
+		exclude []uuid.UUID,
+	) (*domain.Card, error)
+
+	// SetDeck assigns a card to a deck, or removes it from its deck when deckID is nil.
+	// Ownership of the deck is not checked here; callers must ensure the deck
+	// belongs to the card's user.
+	// Returns ErrCardNotFound if the card does not exist.
+	// Returns ErrReferencedEntityMissing if the deck does not exist.
+	SetDeck(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error
+
+	// SetMemo moves a card to a different parent memo and updates its updated_at.
+	// Ownership of the memo is not checked here; callers must ensure the memo
+	// belongs to the card's user.
+	// Returns ErrCardNotFound if the card does not exist.
+	// Returns ErrReferencedEntityMissing if the memo does not exist.
+	SetMemo(ctx context.Context, id uuid.UUID, memoID uuid.UUID) error
+
+	// SetSuspended suspends a card as of suspendedAt, or unsuspends it when
+	// suspendedAt is nil, and updates its updated_at. Suspended cards are excluded
+	// from review and due counts but are still returned by listing methods.
+	// Returns ErrCardNotFound if the card does not exist.
+	SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error
+
+	// SetBuriedUntil hides a card from next-card selection until the given time,
+	// or clears the burial when until is nil, and updates its updated_at. Burying
+	// does not change the card's schedule, so buried cards still count as due.
+	// Returns ErrCardNotFound if the card does not exist.
+	SetBuriedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error
+
+	// SupersedeByMemo marks the memo's active cards as superseded at the given time,
+	// keeping them and their review history but excluding them from review.
+	// Returns the number of cards marked; cards already superseded are left unchanged.
+	SupersedeByMemo(ctx context.Context, memoID uuid.UUID, at time.Time) (int, error)
+
+	// DeleteByMemo deletes all of the memo's cards, including superseded ones.
+	// Their statistics and review history are removed by ON DELETE CASCADE.
+	// Returns the number of cards deleted.
+	DeleteByMemo(ctx context.Context, memoID uuid.UUID) (int, error)
+
+	// ListByDeck retrieves a page of the active cards assigned to a deck, ordered by
+	// creation time (oldest first). Superseded cards are excluded.
+	// Returns an empty slice (not an error) when the deck has no cards.
+	// Returns store.ErrInvalidEntity if limit or offset are out of range.
+	ListByDeck(ctx context.Context, deckID uuid.UUID, limit, offset int) ([]*domain.Card, error)
+
+	// ListByTag retrieves a page of the user's active cards whose content lists tag
+	// among its tags, ordered by creation time (oldest first). Superseded cards are
+	// excluded; suspended cards are included.
+	// Returns an empty slice (not an error) when no card has the tag.
+	// Returns store.ErrInvalidEntity if limit or offset are out of range.
+	ListByTag(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.Card, error)
+
+	// ExistingFrontHashes reports which of hashes belong to the user's active cards,
+	// where a card's hash is domain.HashCardFront of its front (or cloze text).
+	// Superseded cards are excluded, so cards regenerated from a memo are not
+	// treated as duplicates of the cards they replace.
+	// Returns an empty map (not an error) when hashes is empty or none match.
+	ExistingFrontHashes(ctx context.Context, userID uuid.UUID, hashes [][32]byte) (map[[32]byte]bool, error)
+
+	// GetRecentlyReviewed retrieves a user's cards paired with their most recent
+	// review event, ordered by that event's reviewed_at timestamp descending.
+	// Cards that have never been reviewed are not included.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - userID: UUID of the user whose reviewed cards to list
+	//   - limit: Maximum number of results to return (must be > 0)
+	//   - offset: Number of results to skip for pagination (must be >= 0)
+	//
+	// Returns an empty slice (not an error) when the user has no reviewed cards.
+	// Returns store.ErrInvalidEntity if limit or offset are out of range.
+	GetRecentlyReviewed(
+		ctx context.Context,
+		userID uuid.UUID,
+		limit, offset int,
+	) ([]*domain.ReviewedCard, error)
+
+	// FindDuplicates finds groups of the user's cards whose content is identical,
+	// regardless of which memo they were generated from. Content is compared in
+	// its normalized JSON form, so key order and whitespace do not matter.
+	// Superseded cards are excluded, so regenerated cards are not reported as
+	// duplicates of the cards they replaced.
+	//
+	// Only groups with more than one card are returned. Groups are ordered by
+	// content hash and cards within a group by creation time (oldest first).
+	// Returns an empty slice (not an error) when the user has no duplicates.
+	FindDuplicates(ctx context.Context, userID uuid.UUID) ([]*domain.DuplicateCardGroup, error)
+
+	// ForEachWithStats calls fn for each of the user's cards together with its
+	// review statistics, ordered by creation time (oldest first). Suspended cards
+	// are included; superseded cards are only included when includeSuperseded is
+	// true. Rows are streamed rather than loaded into memory, so this is suitable
+	// for exporting large collections.
+	//
+	// Iteration stops at the first error returned by fn, and that error is returned.
+	// Because fn may have side effects, implementations must not retry this method.
+	ForEachWithStats(
+		ctx context.Context,
+		userID uuid.UUID,
+		includeSuperseded bool,
+		fn func(*domain.CardWithStats) error,
+	) error
+
+	// CountByUser returns the number of active cards owned by the specified user.
+	// Superseded cards are not counted.
+	// Returns 0 (not an error) if the user has no cards.
+	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
 
 	// WithTx returns a new CardStore instance that uses the provided transaction.
 	// This allows for multiple operations to be executed within a single transaction.
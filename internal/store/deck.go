@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// DeckStore defines the interface for deck data persistence.
+// Version: 1.0
+type DeckStore interface {
+	// Create saves a new deck to the store.
+	// Returns ErrDeckNameExists if the user already has a deck with the same name.
+	// Returns validation errors from the domain Deck if data is invalid.
+	Create(ctx context.Context, deck *domain.Deck) error
+
+	// GetByID retrieves a deck by its unique ID.
+	// Returns ErrDeckNotFound if the deck does not exist.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Deck, error)
+
+	// GetByName retrieves the user's deck with exactly the given name.
+	// Returns ErrDeckNotFound if the user has no deck with that name.
+	GetByName(ctx context.Context, userID uuid.UUID, name string) (*domain.Deck, error)
+
+	// ListByUser retrieves all of a user's decks ordered by name.
+	// Returns an empty slice (not an error) if the user has no decks.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Deck, error)
+
+	// Update saves changes to an existing deck's name.
+	// Returns ErrDeckNotFound if the deck does not exist.
+	// Returns ErrDeckNameExists if the user already has another deck with the same name.
+	Update(ctx context.Context, deck *domain.Deck) error
+
+	// Delete removes a deck from the store by its ID.
+	// Cards in the deck are kept and no longer belong to any deck.
+	// Returns ErrDeckNotFound if the deck does not exist.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// WithTx returns a new DeckStore instance that uses the provided transaction.
+	// This allows for multiple operations to be executed within a single transaction.
+	// The transaction should be created and managed by the caller (typically a service).
+	WithTx(tx *sql.Tx) DeckStore
+}
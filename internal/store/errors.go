@@ -36,6 +36,19 @@ var (
 	// to commit or when an operation within a transaction fails.
 	ErrTransactionFailed = errors.New("transaction failed")
 
+	// ErrReferencedEntityMissing is returned when an entity refers to another entity
+	// that does not exist, such as a card whose memo has been deleted (a foreign key
+	// violation). It wraps ErrInvalidEntity.
+	ErrReferencedEntityMissing = fmt.Errorf("%w: referenced entity missing", ErrInvalidEntity)
+
+	// ErrVersionConflict is returned when an optimistic concurrency check fails
+	// because the entity was modified since the version the caller read.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrQuotaExceeded is returned when recording usage would take a user past
+	// their quota for the period.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
 	// Entity-specific "not found" errors
 
 	// ErrUserNotFound indicates that the requested user does not exist in the store.
@@ -50,11 +63,20 @@ var (
 	// ErrUserCardStatsNotFound indicates that the requested user card stats do not exist in the store.
 	ErrUserCardStatsNotFound = fmt.Errorf("%w: user card stats", ErrNotFound)
 
+	// ErrIdempotencyKeyNotFound indicates that the user has not used the requested idempotency key.
+	ErrIdempotencyKeyNotFound = fmt.Errorf("%w: idempotency key", ErrNotFound)
+
+	// ErrDeckNotFound indicates that the requested deck does not exist in the store.
+	ErrDeckNotFound = fmt.Errorf("%w: deck", ErrNotFound)
+
 	// Entity-specific "duplicate" errors
 
 	// ErrEmailExists indicates that a user with the given email already exists.
 	// This is returned when attempting to create a user with an email that's already in use.
 	ErrEmailExists = fmt.Errorf("%w: email", ErrDuplicate)
+
+	// ErrDeckNameExists indicates that the user already has a deck with the given name.
+	ErrDeckNameExists = fmt.Errorf("%w: deck name", ErrDuplicate)
 )
 
 // IsNotFoundError checks if the error is any kind of "not found" error.
@@ -64,14 +86,16 @@ func IsNotFoundError(err error) bool {
 		errors.Is(err, ErrUserNotFound) ||
 		errors.Is(err, ErrMemoNotFound) ||
 		errors.Is(err, ErrCardNotFound) ||
-		errors.Is(err, ErrUserCardStatsNotFound)
+		errors.Is(err, ErrUserCardStatsNotFound) ||
+		errors.Is(err, ErrDeckNotFound)
 }
 
 // IsDuplicateError checks if the error is any kind of "duplicate" error.
 // This includes the generic ErrDuplicate and all entity-specific duplicate errors.
 func IsDuplicateError(err error) bool {
 	return errors.Is(err, ErrDuplicate) ||
-		errors.Is(err, ErrEmailExists)
+		errors.Is(err, ErrEmailExists) ||
+		errors.Is(err, ErrDeckNameExists)
 }
 
 // StoreError is a custom error type for store-specific errors with additional context.
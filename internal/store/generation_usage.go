@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GenerationUsageStore defines the interface for counting the card generations
+// each user requests per quota period (see domain.GenerationPeriod). It is the
+// source for enforcing monthly generation quotas.
+// Version: 1.0
+type GenerationUsageStore interface {
+	// Reserve counts one generation for the user in the period starting at
+	// period, unless they already have limit generations in it, in which case
+	// it returns ErrQuotaExceeded and records nothing. The check and the
+	// increment are atomic. A limit of 0 or less means unlimited.
+	Reserve(ctx context.Context, userID uuid.UUID, period time.Time, limit int) error
+
+	// Release gives back one generation reserved for the user in the period
+	// starting at period, for a generation that was reserved but never ran.
+	// It does nothing if the user has no generations in the period.
+	Release(ctx context.Context, userID uuid.UUID, period time.Time) error
+
+	// Count returns how many generations the user has in the period starting
+	// at period. Returns 0 if nothing was recorded for that period.
+	Count(ctx context.Context, userID uuid.UUID, period time.Time) (int, error)
+
+	// WithTx returns a new GenerationUsageStore instance that uses the provided transaction.
+	// This allows for multiple operations to be executed within a single transaction.
+	// The transaction should be created and managed by the caller (typically a service).
+	WithTx(tx *sql.Tx) GenerationUsageStore
+}
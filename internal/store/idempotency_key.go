@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// IdempotencyKeyStore defines the interface for persisting Idempotency-Key values
+// sent with memo creation requests.
+// Version: 1.0
+type IdempotencyKeyStore interface {
+	// Create saves a new idempotency key.
+	// Returns ErrDuplicate if the user has already used the key.
+	// Returns validation errors from the domain IdempotencyKey if data is invalid.
+	Create(ctx context.Context, key *domain.IdempotencyKey) error
+
+	// Get retrieves the user's idempotency key.
+	// Returns ErrIdempotencyKeyNotFound if the user has not used the key.
+	Get(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error)
+
+	// Delete removes the user's idempotency key.
+	// Deleting a key that does not exist is not an error.
+	Delete(ctx context.Context, userID uuid.UUID, key string) error
+
+	// WithTx returns a new IdempotencyKeyStore instance that uses the provided transaction.
+	// This allows for multiple operations to be executed within a single transaction.
+	// The transaction should be created and managed by the caller (typically a service).
+	WithTx(tx *sql.Tx) IdempotencyKeyStore
+}
@@ -20,7 +20,8 @@ type MemoStore interface {
 	// Returns ErrMemoNotFound if the memo does not exist.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Memo, error)
 
-	// Update saves changes to an existing memo.
+	// Update saves changes to an existing memo. The memo's deck is not
+	// changed; use SetDeck for that.
 	// Returns ErrMemoNotFound if the memo does not exist.
 	// Returns validation errors if the memo data is invalid.
 	Update(ctx context.Context, memo *domain.Memo) error
@@ -30,6 +31,11 @@ type MemoStore interface {
 	// Returns validation errors if the status is invalid.
 	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error
 
+	// SetDeck assigns a memo to a deck, or removes it from its deck when deckID is nil.
+	// Returns ErrMemoNotFound if the memo does not exist and
+	// ErrReferencedEntityMissing if the deck does not exist.
+	SetDeck(ctx context.Context, id uuid.UUID, deckID *uuid.UUID) error
+
 	// FindMemosByStatus retrieves all memos with the specified status.
 	// Returns an empty slice if no memos match the criteria.
 	// Can limit the number of results and paginate through offset.
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+)
+
+// Compile-time check to ensure ReplicatedDB implements DBTX
+var _ DBTX = (*ReplicatedDB)(nil)
+
+// ReplicatedDB is a DBTX that sends queries returning rows to read replicas,
+// taking turns between them, and everything else to the primary.
+// Transactions always run on the primary.
+//
+// Only give it to stores whose non-transactional queries are all reads:
+// a write issued with QueryContext or QueryRowContext (e.g. INSERT ... RETURNING)
+// would be sent to a replica. Replicas may lag behind the primary, so a read can
+// miss a write that was just committed.
+type ReplicatedDB struct {
+	primary  *sql.DB
+	replicas []*sql.DB
+	next     atomic.Uint64
+}
+
+// NewReplicatedDB creates a ReplicatedDB. With no replicas every query goes to the primary.
+// The caller keeps ownership of the databases and must close them.
+func NewReplicatedDB(primary *sql.DB, replicas ...*sql.DB) *ReplicatedDB {
+	if primary == nil {
+		// ALLOW-PANIC: Constructor enforcing required dependency
+		panic("primary cannot be nil")
+	}
+
+	return &ReplicatedDB{
+		primary:  primary,
+		replicas: replicas,
+	}
+}
+
+// Primary returns the primary database, for starting transactions
+func (r *ReplicatedDB) Primary() *sql.DB {
+	return r.primary
+}
+
+// ExecContext runs the statement on the primary
+func (r *ReplicatedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+// PrepareContext prepares the statement on the primary, as it may be used for writes
+func (r *ReplicatedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.primary.PrepareContext(ctx, query)
+}
+
+// QueryContext runs the query on the next replica
+func (r *ReplicatedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return r.reader().QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs the query on the next replica
+func (r *ReplicatedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return r.reader().QueryRowContext(ctx, query, args...)
+}
+
+// BeginTx starts a transaction on the primary
+func (r *ReplicatedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.primary.BeginTx(ctx, opts)
+}
+
+// reader returns the database for the next read, rotating through the replicas
+func (r *ReplicatedDB) reader() *sql.DB {
+	if len(r.replicas) == 0 {
+		return r.primary
+	}
+	n := r.next.Add(1) - 1
+	return r.replicas[n%uint64(len(r.replicas))]
+}
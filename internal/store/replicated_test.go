@@ -0,0 +1,159 @@
+package store_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callLog records which fake database handled each call
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *callLog) add(call string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+func (l *callLog) take() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	calls := l.calls
+	l.calls = nil
+	return calls
+}
+
+// fakeConnector opens connections to a named fake database
+type fakeConnector struct {
+	name string
+	log  *callLog
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, driver.ErrSkip }
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (c *fakeConn) record(kind string) {
+	c.connector.log.add(c.connector.name + ":" + kind)
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.record("begin")
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	c.record("query")
+	return fakeRows{}, nil
+}
+
+func (c *fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	c.record("exec")
+	return driver.RowsAffected(1), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string         { return []string{"n"} }
+func (fakeRows) Close() error              { return nil }
+func (fakeRows) Next([]driver.Value) error { return io.EOF }
+
+func openFakeDB(t *testing.T, name string, log *callLog) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(&fakeConnector{name: name, log: log})
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestReplicatedDB_Routing(t *testing.T) {
+	ctx := context.Background()
+	log := &callLog{}
+	primary := openFakeDB(t, "primary", log)
+	replicaA := openFakeDB(t, "replica-a", log)
+	replicaB := openFakeDB(t, "replica-b", log)
+
+	db := store.NewReplicatedDB(primary, replicaA, replicaB)
+	assert.Same(t, primary, db.Primary())
+
+	t.Run("reads rotate through replicas", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			rows, err := db.QueryContext(ctx, "SELECT 1")
+			require.NoError(t, err)
+			require.NoError(t, rows.Close())
+		}
+		var n int
+		err := db.QueryRowContext(ctx, "SELECT 1").Scan(&n)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		assert.Equal(t, []string{"replica-a:query", "replica-b:query", "replica-a:query"}, log.take())
+	})
+
+	t.Run("writes go to primary", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, "UPDATE cards SET content = '{}'")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"primary:exec"}, log.take())
+	})
+
+	t.Run("transactions go to primary", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+
+		rows, err := tx.QueryContext(ctx, "SELECT 1")
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+		_, err = tx.ExecContext(ctx, "UPDATE cards SET content = '{}'")
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+
+		assert.Equal(t, []string{"primary:begin", "primary:query", "primary:exec"}, log.take())
+	})
+}
+
+func TestReplicatedDB_NoReplicas(t *testing.T) {
+	ctx := context.Background()
+	log := &callLog{}
+	primary := openFakeDB(t, "primary", log)
+
+	db := store.NewReplicatedDB(primary)
+	rows, err := db.QueryContext(ctx, "SELECT 1")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+
+	assert.Equal(t, []string{"primary:query"}, log.take())
+}
+
+func TestNewReplicatedDB_NilPrimary(t *testing.T) {
+	assert.Panics(t, func() { store.NewReplicatedDB(nil) })
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+)
+
+// ReviewEventStore defines the interface for review event persistence.
+// Review events are append-only: once recorded they are never modified.
+// Version: 1.0
+type ReviewEventStore interface {
+	// Create records a new review event.
+	// It handles domain validation internally.
+	// Returns validation errors from the domain ReviewEvent if data is invalid.
+	Create(ctx context.Context, event *domain.ReviewEvent) error
+
+	// ListByCard retrieves every review event recorded for a card in chronological
+	// order (oldest first). Ownership is not checked here; callers must ensure the
+	// card belongs to the user asking for its history.
+	// Returns an empty slice (not an error) when the card has never been reviewed.
+	ListByCard(ctx context.Context, cardID uuid.UUID) ([]*domain.ReviewEvent, error)
+
+	// CountOutcomes counts the user's review events from the last days calendar
+	// days in their timezone, including today, grouped by the maturity of the card
+	// when it was reviewed (see domain.MaturityForInterval) and by outcome.
+	// Groups without reviews are omitted.
+	// Returns store.ErrInvalidEntity if days is out of range.
+	CountOutcomes(ctx context.Context, userID uuid.UUID, days int) ([]domain.ReviewOutcomeCount, error)
+
+	// CountByDay returns the number of the user's review events on each of the
+	// last days calendar days in their timezone, oldest first and ending today.
+	// Days without reviews have a count of 0.
+	// Returns store.ErrInvalidEntity if days is out of range.
+	CountByDay(ctx context.Context, userID uuid.UUID, days int) ([]domain.ReviewDayCount, error)
+
+	// WithTx returns a new ReviewEventStore instance that uses the provided transaction.
+	// This allows for multiple operations to be executed within a single transaction.
+	// The transaction should be created and managed by the caller (typically a service).
+	WithTx(tx *sql.Tx) ReviewEventStore
+}
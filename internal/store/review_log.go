@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewLogStore defines the interface for the per-day review log.
+// The log records which calendar days a user reviewed at least one card,
+// and how many new cards were introduced on each of them. It is the source
+// for review streaks and the daily new-card limit.
+// Version: 1.0
+type ReviewLogStore interface {
+	// RecordReview marks the calendar day containing reviewedAt as a review day
+	// for the user. The day is determined in the user's configured timezone.
+	// Recording the same day more than once has no further effect.
+	RecordReview(ctx context.Context, userID uuid.UUID, reviewedAt time.Time) error
+
+	// RecordNewCard counts a never-reviewed card as introduced on the calendar day
+	// containing reviewedAt, in the user's configured timezone. It also marks the
+	// day as a review day, so callers don't need to call RecordReview separately.
+	RecordNewCard(ctx context.Context, userID uuid.UUID, reviewedAt time.Time) error
+
+	// CountNewCards returns how many new cards the user was introduced to on the
+	// calendar day containing at, in the user's configured timezone.
+	// Returns 0 if nothing was recorded for that day.
+	CountNewCards(ctx context.Context, userID uuid.UUID, at time.Time) (int, error)
+
+	// GetReviewDays returns the distinct days on which the user reviewed cards,
+	// in ascending order. Each day is returned as midnight UTC on that calendar date.
+	// Returns an empty slice (not an error) if the user has never reviewed a card.
+	GetReviewDays(ctx context.Context, userID uuid.UUID) ([]time.Time, error)
+
+	// WithTx returns a new ReviewLogStore instance that uses the provided transaction.
+	// This allows for multiple operations to be executed within a single transaction.
+	// The transaction should be created and managed by the caller (typically a service).
+	WithTx(tx *sql.Tx) ReviewLogStore
+}
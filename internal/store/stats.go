@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -37,11 +38,38 @@ type UserCardStatsStore interface {
 	// Returns validation errors from the domain UserCardStats if data is invalid.
 	Update(ctx context.Context, stats *domain.UserCardStats) error
 
+	// UpdateMultiple modifies several existing statistics entries in a single write,
+	// each identified by its userID and cardID as in Update.
+	// The update is all-or-nothing: if any entry does not exist for its user, no
+	// entry is modified and ErrUserCardStatsNotFound is returned.
+	// Returns ErrInvalidEntity if any entry fails domain validation or if the same
+	// entry appears more than once. Updating no entries is a no-op.
+	UpdateMultiple(ctx context.Context, stats []*domain.UserCardStats) error
+
 	// Delete removes user card statistics by the combination of user ID and card ID.
 	// Returns ErrUserCardStatsNotFound if the statistics entry does not exist.
 	// This operation is permanent and cannot be undone.
 	Delete(ctx context.Context, userID, cardID uuid.UUID) error
 
+	// CountDue returns the number of the user's cards that are due as of asOf,
+	// as defined by domain.IsCardDue. Overdue cards are included in the count.
+	// Use domain.User.DueAsOfEndOfDay to count cards due by the end of a day.
+	// Returns 0 (not an error) if no cards are due.
+	CountDue(ctx context.Context, userID uuid.UUID, asOf time.Time) (int, error)
+
+	// GetForecast returns one bucket per calendar day, in the user's timezone,
+	// for the next days days starting today, each holding the number of cards
+	// whose NextReviewAt falls on that day. Overdue cards are counted in today's
+	// bucket. Days with no reviews are included with a count of 0.
+	// If deckID is non-nil, only cards in that deck are counted.
+	// Returns ErrInvalidEntity if days is less than 1 or greater than domain.MaxForecastDays.
+	GetForecast(
+		ctx context.Context,
+		userID uuid.UUID,
+		deckID *uuid.UUID,
+		days int,
+	) ([]domain.ForecastBucket, error)
+
 	// WithTx returns a new UserCardStatsStore instance that uses the provided transaction.
 	// This allows for multiple operations to be executed within a single transaction.
 	// The transaction should be created and managed by the caller (typically a service).
@@ -0,0 +1,508 @@
+package storetest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunCardStoreSuite checks that the card store built by factory follows the
+// store.CardStore contract. Each subtest gets its own stores from factory, and
+// any operation that fails inside the database is the last one of its subtest,
+// so backends that abort a transaction on error can run the suite unchanged.
+func RunCardStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	run(t, "create_and_get", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		created := now().Add(-time.Hour)
+		card := MustCreateCard(ctx, t, s, memo, "Question", created, now().Add(time.Hour), 0)
+
+		got, err := s.Cards.GetByID(ctx, card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, card.ID, got.ID)
+		assert.Equal(t, user.ID, got.UserID)
+		assert.Equal(t, memo.ID, got.MemoID)
+		assert.Equal(t, domain.CardTypeBasic, got.Type)
+		assert.JSONEq(t, string(card.Content), string(got.Content))
+		assert.True(t, created.Equal(got.CreatedAt), "CreatedAt should round-trip")
+		assert.Equal(t, domain.InitialCardVersion, got.Version)
+		assert.Nil(t, got.DeckID)
+		assert.Nil(t, got.SupersededAt)
+
+		require.NoError(t, s.Cards.CreateMultiple(ctx, nil), "Creating no cards is a no-op")
+	})
+
+	run(t, "get_not_found", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		_, err := s.Cards.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+	})
+
+	run(t, "get_by_ids_omits_missing", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "Question", now(), now(), 0)
+
+		cards, err := s.Cards.GetByIDs(ctx, []uuid.UUID{card.ID, uuid.New(), card.ID})
+		require.NoError(t, err)
+		assert.Len(t, cards, 1)
+		assert.Contains(t, cards, card.ID)
+
+		cards, err = s.Cards.GetByIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, cards)
+	})
+
+	run(t, "create_invalid", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+		require.NoError(t, err)
+		card.Content = json.RawMessage(`{not json`)
+
+		err = s.Cards.CreateMultiple(ctx, []*domain.Card{card})
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+	})
+
+	run(t, "create_duplicate", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "Question", now(), now(), 0)
+
+		duplicate, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+		require.NoError(t, err)
+		duplicate.ID = card.ID
+
+		err = s.Cards.CreateMultiple(ctx, []*domain.Card{duplicate})
+		assert.ErrorIs(t, err, store.ErrDuplicate)
+	})
+
+	run(t, "create_with_missing_memo", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		orphan, err := domain.NewCard(user.ID, uuid.New(), json.RawMessage(`{"front":"f","back":"b"}`))
+		require.NoError(t, err)
+
+		err = s.Cards.CreateMultiple(ctx, []*domain.Card{orphan})
+		assert.ErrorIs(t, err, store.ErrReferencedEntityMissing)
+	})
+
+	run(t, "update_content_with_versions", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "Question", now(), now(), 0)
+
+		version, err := s.Cards.UpdateContent(ctx, card.ID,
+			[]byte(`{"front":"Edited","back":"back"}`), domain.InitialCardVersion)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InitialCardVersion+1, version)
+
+		_, err = s.Cards.UpdateContent(ctx, card.ID,
+			[]byte(`{"front":"Stale","back":"back"}`), domain.InitialCardVersion)
+		assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+		_, err = s.Cards.UpdateContent(ctx, uuid.New(), []byte(`{"front":"x"}`), 1)
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+
+		_, err = s.Cards.UpdateContent(ctx, card.ID, []byte(`{not json`), version)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+
+		got, err := s.Cards.GetByID(ctx, card.ID)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"front":"Edited","back":"back"}`, string(got.Content))
+		assert.Equal(t, version, got.Version)
+	})
+
+	run(t, "set_memo", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		other := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "Question", now(), now(), 0)
+
+		require.NoError(t, s.Cards.SetMemo(ctx, card.ID, other.ID))
+		got, err := s.Cards.GetByID(ctx, card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, other.ID, got.MemoID)
+
+		assert.ErrorIs(t, s.Cards.SetMemo(ctx, uuid.New(), other.ID), store.ErrCardNotFound)
+		assert.ErrorIs(t, s.Cards.SetMemo(ctx, card.ID, uuid.New()), store.ErrReferencedEntityMissing)
+	})
+
+	run(t, "setters_on_missing_card", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		at := now()
+		assert.ErrorIs(t, s.Cards.SetDeck(ctx, uuid.New(), nil), store.ErrCardNotFound)
+		assert.ErrorIs(t, s.Cards.SetSuspended(ctx, uuid.New(), &at), store.ErrCardNotFound)
+		assert.ErrorIs(t, s.Cards.SetBuriedUntil(ctx, uuid.New(), &at), store.ErrCardNotFound)
+	})
+
+	run(t, "delete_cascades_to_stats", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "Question", now(), now(), 0)
+
+		require.NoError(t, s.Cards.Delete(ctx, card.ID))
+
+		_, err := s.Cards.GetByID(ctx, card.ID)
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+		_, err = s.Stats.Get(ctx, user.ID, card.ID)
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+
+		assert.ErrorIs(t, s.Cards.Delete(ctx, card.ID), store.ErrCardNotFound)
+	})
+
+	run(t, "next_due_card", factory, testNextDueCard)
+
+	run(t, "next_due_card_serves_learning_cards_first", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		created := now().Add(-48 * time.Hour)
+
+		MustCreateCard(ctx, t, s, memo, "overdue review", created, now().Add(-24*time.Hour), 3)
+		learning := MustCreateCard(ctx, t, s, memo, "relearning", created, now().Add(-time.Minute), 1)
+		MustCreateCard(ctx, t, s, memo, "not due", created, now().Add(10*time.Minute), 1)
+		stats, err := s.Stats.Get(ctx, user.ID, learning.ID)
+		require.NoError(t, err)
+		stats.Phase = domain.CardPhaseRelearning
+		stats.LearningStep = 1
+		require.NoError(t, s.Stats.Update(ctx, stats))
+
+		for _, order := range []domain.ReviewOrder{
+			domain.ReviewOrderDueDate,
+			domain.ReviewOrderLowestEase,
+			domain.ReviewOrderRandomDue,
+		} {
+			card, err := s.Cards.GetNextReviewCard(ctx, user.ID, order)
+			require.NoError(t, err)
+			assert.Equal(t, learning.ID, card.ID, "A due learning card comes before due reviews in %s order", order)
+		}
+
+		stats, err = s.Stats.Get(ctx, user.ID, learning.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.CardPhaseRelearning, stats.Phase, "The phase should round-trip")
+		assert.Equal(t, 1, stats.LearningStep)
+	})
+
+	run(t, "supersede_and_delete_by_memo", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		base := now().Add(-time.Hour)
+		first := MustCreateCard(ctx, t, s, memo, "first", base, base, 0)
+		second := MustCreateCard(ctx, t, s, memo, "second", base.Add(time.Minute), base, 0)
+
+		count, err := s.Cards.CountByUser(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		at := now()
+		count, err = s.Cards.SupersedeByMemo(ctx, memo.ID, at)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		count, err = s.Cards.SupersedeByMemo(ctx, memo.ID, at.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, count, "Only active cards are superseded")
+
+		got, err := s.Cards.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.SupersededAt)
+		assert.True(t, at.Equal(*got.SupersededAt))
+
+		count, err = s.Cards.CountByUser(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Zero(t, count, "Superseded cards are not counted")
+
+		count, err = s.Cards.DeleteByMemo(ctx, memo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		_, err = s.Stats.Get(ctx, user.ID, second.ID)
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+	})
+
+	run(t, "for_each_with_stats", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		base := now().Add(-time.Hour)
+		first := MustCreateCard(ctx, t, s, memo, "first", base, base, 0)
+		second := MustCreateCard(ctx, t, s, memo, "second", base.Add(time.Minute), base, 0)
+		suspendedAt := now()
+		require.NoError(t, s.Cards.SetSuspended(ctx, second.ID, &suspendedAt))
+
+		oldMemo := MustCreateMemo(ctx, t, s, user.ID)
+		superseded := MustCreateCard(ctx, t, s, oldMemo, "superseded", base.Add(2*time.Minute), base, 0)
+		_, err := s.Cards.SupersedeByMemo(ctx, oldMemo.ID, now())
+		require.NoError(t, err)
+
+		stream := func(includeSuperseded bool) []uuid.UUID {
+			var streamed []uuid.UUID
+			err := s.Cards.ForEachWithStats(ctx, user.ID, includeSuperseded, func(item *domain.CardWithStats) error {
+				require.NotNil(t, item.Stats)
+				assert.Equal(t, item.Card.ID, item.Stats.CardID)
+				streamed = append(streamed, item.Card.ID)
+				return nil
+			})
+			require.NoError(t, err)
+			return streamed
+		}
+		assert.Equal(t, []uuid.UUID{first.ID, second.ID}, stream(false),
+			"Cards are streamed oldest first, suspended cards included")
+		assert.Equal(t, []uuid.UUID{first.ID, second.ID, superseded.ID}, stream(true),
+			"Superseded cards are streamed when included")
+	})
+
+	run(t, "recently_reviewed", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		base := now().Add(-time.Hour)
+		first := MustCreateCard(ctx, t, s, memo, "first", base, base, 1)
+		second := MustCreateCard(ctx, t, s, memo, "second", base, base, 1)
+		MustCreateCard(ctx, t, s, memo, "never reviewed", base, base, 0)
+
+		review := func(cardID uuid.UUID, at time.Time) {
+			event, err := domain.NewReviewEvent(user.ID, cardID, domain.ReviewOutcomeGood, at)
+			require.NoError(t, err)
+			require.NoError(t, s.ReviewEvents.Create(ctx, event))
+		}
+		review(first.ID, base.Add(10*time.Minute))
+		review(second.ID, base.Add(20*time.Minute))
+		review(first.ID, base.Add(30*time.Minute))
+
+		reviewed, err := s.Cards.GetRecentlyReviewed(ctx, user.ID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, reviewed, 2)
+		assert.Equal(t, first.ID, reviewed[0].Card.ID)
+		assert.True(t, base.Add(30*time.Minute).Equal(reviewed[0].LastReview.ReviewedAt))
+		assert.Equal(t, second.ID, reviewed[1].Card.ID)
+
+		reviewed, err = s.Cards.GetRecentlyReviewed(ctx, user.ID, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, reviewed, 1)
+		assert.Equal(t, second.ID, reviewed[0].Card.ID)
+
+		_, err = s.Cards.GetRecentlyReviewed(ctx, user.ID, 0, 0)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+	})
+
+	run(t, "find_duplicates", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		base := now().Add(-time.Hour)
+		first := MustCreateCard(ctx, t, s, memo, "same", base, base, 0)
+		MustCreateCard(ctx, t, s, memo, "other", base, base, 0)
+
+		// The duplicate has the same content with its keys in another order
+		duplicate, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"back":"back", "front":"same"}`))
+		require.NoError(t, err)
+		duplicate.CreatedAt = base.Add(time.Minute)
+		require.NoError(t, s.Cards.CreateMultiple(ctx, []*domain.Card{duplicate}))
+
+		groups, err := s.Cards.FindDuplicates(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		require.Len(t, groups[0].Cards, 2)
+		assert.Equal(t, first.ID, groups[0].Cards[0].ID)
+		assert.Equal(t, duplicate.ID, groups[0].Cards[1].ID)
+	})
+
+	run(t, "list_by_tag", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		base := now().Add(-time.Hour)
+		MustCreateCard(ctx, t, s, memo, "untagged", base, base, 0)
+
+		var tagged []*domain.Card
+		for i, tags := range []string{`["leech"]`, `["go","leech"]`, `["leech"]`} {
+			card, err := domain.NewCard(user.ID, memo.ID,
+				json.RawMessage(`{"front":"tagged","back":"back","tags":`+tags+`}`))
+			require.NoError(t, err)
+			card.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, s.Cards.CreateMultiple(ctx, []*domain.Card{card}))
+			tagged = append(tagged, card)
+		}
+		suspendedAt := now()
+		require.NoError(t, s.Cards.SetSuspended(ctx, tagged[1].ID, &suspendedAt))
+		_, err := s.Cards.SupersedeByMemo(ctx, memo.ID, now())
+		require.NoError(t, err)
+
+		// Only cards created after superseding the memo's cards remain active
+		active, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"new","back":"back","tags":["leech"]}`))
+		require.NoError(t, err)
+		require.NoError(t, s.Cards.CreateMultiple(ctx, []*domain.Card{active}))
+		suspended, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"old","back":"back","tags":["leech"]}`))
+		require.NoError(t, err)
+		suspended.CreatedAt = base
+		require.NoError(t, s.Cards.CreateMultiple(ctx, []*domain.Card{suspended}))
+		require.NoError(t, s.Cards.SetSuspended(ctx, suspended.ID, &suspendedAt))
+
+		otherUser := MustCreateUser(ctx, t, s)
+		otherMemo := MustCreateMemo(ctx, t, s, otherUser.ID)
+		other, err := domain.NewCard(otherUser.ID, otherMemo.ID, json.RawMessage(`{"front":"f","back":"b","tags":["leech"]}`))
+		require.NoError(t, err)
+		require.NoError(t, s.Cards.CreateMultiple(ctx, []*domain.Card{other}))
+
+		cards, err := s.Cards.ListByTag(ctx, user.ID, domain.LeechTag, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, cards, 2, "Superseded cards and other users' cards are excluded")
+		assert.Equal(t, suspended.ID, cards[0].ID, "Suspended cards are included, oldest first")
+		assert.Equal(t, active.ID, cards[1].ID)
+
+		cards, err = s.Cards.ListByTag(ctx, user.ID, domain.LeechTag, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		assert.Equal(t, active.ID, cards[0].ID)
+
+		cards, err = s.Cards.ListByTag(ctx, user.ID, "go", 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, cards)
+
+		_, err = s.Cards.ListByTag(ctx, user.ID, domain.LeechTag, 0, 0)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+	})
+
+	run(t, "existing_front_hashes", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		base := now().Add(-time.Hour)
+		superseded := MustCreateCard(ctx, t, s, memo, "Superseded question", base, base, 0)
+		_, err := s.Cards.SupersedeByMemo(ctx, memo.ID, now())
+		require.NoError(t, err)
+		MustCreateCard(ctx, t, s, memo, "What is  Go?", base, base, 0)
+		edited := MustCreateCard(ctx, t, s, memo, "Before edit", base, base, 0)
+		_, err = s.Cards.UpdateContent(ctx, edited.ID, []byte(`{"front":"After edit","back":"back"}`), edited.Version)
+		require.NoError(t, err)
+		cloze, err := domain.NewCardOfType(user.ID, memo.ID, domain.CardTypeCloze,
+			json.RawMessage(`{"text":"Go was released in {{c1::2009}}"}`))
+		require.NoError(t, err)
+		require.NoError(t, s.Cards.CreateMultiple(ctx, []*domain.Card{cloze}))
+
+		otherUser := MustCreateUser(ctx, t, s)
+		MustCreateCard(ctx, t, s, MustCreateMemo(ctx, t, s, otherUser.ID), "Other user's question", base, base, 0)
+
+		goHash := domain.HashCardFront("what is go?")
+		clozeHash := domain.HashCardFront("Go was released in {{c1::2009}}")
+		supersededHash, ok := superseded.FrontHash()
+		require.True(t, ok)
+		hashes := [][32]byte{
+			goHash,
+			clozeHash,
+			supersededHash,
+			domain.HashCardFront("Before edit"),
+			domain.HashCardFront("After edit"),
+			domain.HashCardFront("Other user's question"),
+			domain.HashCardFront("Never asked"),
+		}
+
+		existing, err := s.Cards.ExistingFrontHashes(ctx, user.ID, hashes)
+		require.NoError(t, err)
+		assert.Equal(t, map[[32]byte]bool{
+			goHash:                             true,
+			clozeHash:                          true,
+			domain.HashCardFront("After edit"): true,
+		}, existing, "Superseded cards, replaced content and other users' cards are excluded")
+
+		existing, err = s.Cards.ExistingFrontHashes(ctx, user.ID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, existing)
+	})
+
+	run(t, "with_tx", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+
+		txCards := s.Cards.WithTx(s.Tx)
+		require.NotNil(t, txCards)
+
+		card, err := domain.NewCard(user.ID, memo.ID, json.RawMessage(`{"front":"f","back":"b"}`))
+		require.NoError(t, err)
+		require.NoError(t, txCards.CreateMultiple(ctx, []*domain.Card{card}))
+
+		_, err = s.Cards.GetByID(ctx, card.ID)
+		require.NoError(t, err, "A card created in the transaction is visible to the original store")
+
+		require.NoError(t, s.Cards.Delete(ctx, card.ID))
+		_, err = txCards.GetByID(ctx, card.ID)
+		assert.ErrorIs(t, err, store.ErrCardNotFound)
+	})
+}
+
+// testNextDueCard checks due-card selection: review orders, the new-card
+// filter, and the cards that are never served
+func testNextDueCard(t *testing.T, ctx context.Context, s Stores) {
+	user := MustCreateUser(ctx, t, s)
+	memo := MustCreateMemo(ctx, t, s, user.ID)
+	created := now().Add(-48 * time.Hour)
+
+	mostOverdue := MustCreateCard(ctx, t, s, memo, "most overdue", created, now().Add(-3*time.Hour), 2)
+	overdue := MustCreateCard(ctx, t, s, memo, "overdue", created, now().Add(-2*time.Hour), 1)
+	newCard := MustCreateCard(ctx, t, s, memo, "new", created, now().Add(-time.Hour), 0)
+	MustCreateCard(ctx, t, s, memo, "not due", created, now().Add(time.Hour), 1)
+
+	// Give the less overdue card the lowest ease factor
+	stats, err := s.Stats.Get(ctx, user.ID, overdue.ID)
+	require.NoError(t, err)
+	stats.EaseFactor = 1.3
+	require.NoError(t, s.Stats.Update(ctx, stats))
+
+	// Another user's due card is never served
+	otherUser := MustCreateUser(ctx, t, s)
+	otherMemo := MustCreateMemo(ctx, t, s, otherUser.ID)
+	MustCreateCard(ctx, t, s, otherMemo, "other user", created, now().Add(-24*time.Hour), 1)
+
+	card, err := s.Cards.GetNextReviewCard(ctx, user.ID, domain.ReviewOrderDueDate)
+	require.NoError(t, err)
+	assert.Equal(t, mostOverdue.ID, card.ID)
+
+	card, err = s.Cards.GetNextReviewCard(ctx, user.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, mostOverdue.ID, card.ID, "The default order is by due date")
+
+	card, err = s.Cards.GetNextReviewCard(ctx, user.ID, domain.ReviewOrderLowestEase)
+	require.NoError(t, err)
+	assert.Equal(t, overdue.ID, card.ID)
+
+	card, err = s.Cards.GetNextReviewCard(ctx, user.ID, domain.ReviewOrderRandomDue)
+	require.NoError(t, err)
+	assert.Contains(t, []uuid.UUID{mostOverdue.ID, overdue.ID, newCard.ID}, card.ID)
+
+	_, err = s.Cards.GetNextReviewCard(ctx, user.ID, "alphabetical")
+	assert.ErrorIs(t, err, domain.ErrInvalidReviewOrder)
+
+	card, err = s.Cards.GetNextDueCard(ctx, user.ID, nil, true, domain.ReviewOrderDueDate)
+	require.NoError(t, err)
+	assert.Equal(t, newCard.ID, card.ID, "Only new cards are served when asked for")
+
+	card, err = s.Cards.GetNextDueCard(ctx, user.ID, nil, false, domain.ReviewOrderDueDate)
+	require.NoError(t, err)
+	assert.Equal(t, mostOverdue.ID, card.ID)
+
+	card, err = s.Cards.GetNextDueCardExcluding(ctx, user.ID, nil, false, domain.ReviewOrderDueDate,
+		[]uuid.UUID{mostOverdue.ID, uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, overdue.ID, card.ID, "The excluded top due card is skipped")
+
+	_, err = s.Cards.GetNextDueCardExcluding(ctx, user.ID, nil, false, domain.ReviewOrderDueDate,
+		[]uuid.UUID{mostOverdue.ID, overdue.ID})
+	assert.ErrorIs(t, err, store.ErrCardNotFound, "No card is served when every due card is excluded")
+
+	suspendedAt := now()
+	require.NoError(t, s.Cards.SetSuspended(ctx, mostOverdue.ID, &suspendedAt))
+	buriedUntil := now().Add(time.Hour)
+	require.NoError(t, s.Cards.SetBuriedUntil(ctx, overdue.ID, &buriedUntil))
+
+	card, err = s.Cards.GetNextReviewCard(ctx, user.ID, domain.ReviewOrderDueDate)
+	require.NoError(t, err)
+	assert.Equal(t, newCard.ID, card.ID, "Suspended and buried cards are skipped")
+
+	_, err = s.Cards.SupersedeByMemo(ctx, memo.ID, now())
+	require.NoError(t, err)
+
+	_, err = s.Cards.GetNextReviewCard(ctx, user.ID, domain.ReviewOrderDueDate)
+	assert.ErrorIs(t, err, store.ErrCardNotFound, "Superseded cards are skipped")
+}
@@ -0,0 +1,134 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunReviewEventStoreSuite checks that the review event store built by factory
+// follows the store.ReviewEventStore contract, in the same way as RunCardStoreSuite.
+func RunReviewEventStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	// record creates a review event for the card, with the intervals set unless
+	// before is negative
+	record := func(
+		ctx context.Context,
+		t *testing.T,
+		s Stores,
+		card *domain.Card,
+		outcome domain.ReviewOutcome,
+		reviewedAt time.Time,
+		before int,
+	) *domain.ReviewEvent {
+		t.Helper()
+		event, err := domain.NewReviewEvent(card.UserID, card.ID, outcome, reviewedAt)
+		require.NoError(t, err)
+		if before >= 0 {
+			event.SetIntervals(before, before+1)
+		}
+		require.NoError(t, s.ReviewEvents.Create(ctx, event))
+		return event
+	}
+
+	run(t, "list_by_card", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "history", now(), now(), 0)
+		other := MustCreateCard(ctx, t, s, memo, "other", now(), now(), 0)
+
+		events, err := s.ReviewEvents.ListByCard(ctx, card.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, events)
+		assert.Empty(t, events)
+
+		later := record(ctx, t, s, card, domain.ReviewOutcomeGood, now(), 1)
+		earlier := record(ctx, t, s, card, domain.ReviewOutcomeAgain, now().Add(-time.Hour), -1)
+		record(ctx, t, s, other, domain.ReviewOutcomeEasy, now(), 0)
+
+		events, err = s.ReviewEvents.ListByCard(ctx, card.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 2, "Only the card's own events are listed")
+		assert.Equal(t, earlier.ID, events[0].ID, "Events are listed oldest first")
+		assert.Equal(t, domain.ReviewOutcomeAgain, events[0].Outcome)
+		assert.Nil(t, events[0].IntervalBefore)
+		assert.Equal(t, later.ID, events[1].ID)
+		require.NotNil(t, events[1].IntervalBefore)
+		require.NotNil(t, events[1].IntervalAfter)
+		assert.Equal(t, 1, *events[1].IntervalBefore)
+		assert.Equal(t, 2, *events[1].IntervalAfter)
+	})
+
+	run(t, "count_outcomes", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "retention", now(), now(), 0)
+
+		recent := now().Add(-24 * time.Hour)
+		record(ctx, t, s, card, domain.ReviewOutcomeAgain, recent, 0)
+		record(ctx, t, s, card, domain.ReviewOutcomeGood, recent, 0)
+		record(ctx, t, s, card, domain.ReviewOutcomeGood, recent, 3)
+		record(ctx, t, s, card, domain.ReviewOutcomeGood, recent, 3)
+		record(ctx, t, s, card, domain.ReviewOutcomeHard, recent, domain.MatureIntervalDays)
+		record(ctx, t, s, card, domain.ReviewOutcomeEasy, recent, -1)
+		record(ctx, t, s, card, domain.ReviewOutcomeAgain, now().Add(-40*24*time.Hour), 3)
+
+		otherUser := MustCreateUser(ctx, t, s)
+		otherMemo := MustCreateMemo(ctx, t, s, otherUser.ID)
+		otherCard := MustCreateCard(ctx, t, s, otherMemo, "other user", now(), now(), 0)
+		record(ctx, t, s, otherCard, domain.ReviewOutcomeAgain, recent, 3)
+
+		counts, err := s.ReviewEvents.CountOutcomes(ctx, user.ID, 30)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.ReviewOutcomeCount{
+			{Maturity: domain.CardMaturityLearning, Outcome: domain.ReviewOutcomeAgain, Count: 1},
+			{Maturity: domain.CardMaturityLearning, Outcome: domain.ReviewOutcomeGood, Count: 1},
+			{Maturity: domain.CardMaturityYoung, Outcome: domain.ReviewOutcomeGood, Count: 2},
+			{Maturity: domain.CardMaturityMature, Outcome: domain.ReviewOutcomeHard, Count: 1},
+			{Maturity: domain.CardMaturityUnknown, Outcome: domain.ReviewOutcomeEasy, Count: 1},
+		}, counts, "Reviews before the window and other users' reviews are not counted")
+
+		counts, err = s.ReviewEvents.CountOutcomes(ctx, uuid.New(), 30)
+		require.NoError(t, err)
+		assert.NotNil(t, counts)
+		assert.Empty(t, counts)
+
+		_, err = s.ReviewEvents.CountOutcomes(ctx, user.ID, 0)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+		_, err = s.ReviewEvents.CountOutcomes(ctx, user.ID, domain.MaxRetentionDays+1)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+	})
+
+	run(t, "count_by_day", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "retention", now(), now(), 0)
+
+		reviewedAt := now()
+		record(ctx, t, s, card, domain.ReviewOutcomeGood, reviewedAt, 1)
+		record(ctx, t, s, card, domain.ReviewOutcomeGood, reviewedAt, 2)
+		record(ctx, t, s, card, domain.ReviewOutcomeAgain, reviewedAt.Add(-48*time.Hour), 1)
+		record(ctx, t, s, card, domain.ReviewOutcomeAgain, reviewedAt.Add(-72*time.Hour), 1)
+
+		counts, err := s.ReviewEvents.CountByDay(ctx, user.ID, 3)
+		require.NoError(t, err)
+		require.Len(t, counts, 3)
+		today := reviewedAt.In(user.Location())
+		for i, expected := range []int{1, 0, 2} {
+			day := today.AddDate(0, 0, i-2)
+			assert.Equal(t, day.Format(time.DateOnly), counts[i].Date.Format(time.DateOnly),
+				"Days run oldest first and end today")
+			assert.Equal(t, expected, counts[i].Count)
+		}
+
+		_, err = s.ReviewEvents.CountByDay(ctx, user.ID, 0)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+	})
+}
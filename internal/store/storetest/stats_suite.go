@@ -0,0 +1,237 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunUserCardStatsStoreSuite checks that the statistics store built by factory
+// follows the store.UserCardStatsStore contract, in the same way as RunCardStoreSuite.
+func RunUserCardStatsStoreSuite(t *testing.T, factory Factory) {
+	t.Helper()
+
+	run(t, "create_and_get", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		created := now().Add(-time.Hour)
+		nextReview := now().Add(time.Hour)
+		card := MustCreateCard(ctx, t, s, memo, "stats", created, nextReview, 2)
+
+		stats, err := s.Stats.Get(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, stats.UserID)
+		assert.Equal(t, card.ID, stats.CardID)
+		assert.Equal(t, 2, stats.ReviewCount)
+		assert.True(t, nextReview.Equal(stats.NextReviewAt))
+		assert.True(t, created.Equal(stats.CreatedAt))
+		assert.True(t, stats.LastReviewedAt.IsZero(), "A card that was never reviewed has no review time")
+
+		locked, err := s.Stats.GetForUpdate(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, stats.NextReviewAt, locked.NextReviewAt)
+	})
+
+	run(t, "get_not_found", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "stats", now(), now(), 0)
+
+		_, err := s.Stats.Get(ctx, uuid.New(), card.ID)
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound, "Statistics belong to one user")
+		_, err = s.Stats.Get(ctx, user.ID, uuid.New())
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+		_, err = s.Stats.GetForUpdate(ctx, user.ID, uuid.New())
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+	})
+
+	run(t, "create_duplicate", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "stats", now(), now(), 0)
+
+		stats, err := domain.NewUserCardStats(user.ID, card.ID)
+		require.NoError(t, err)
+		assert.ErrorIs(t, s.Stats.Create(ctx, stats), store.ErrDuplicate)
+	})
+
+	run(t, "create_for_missing_card", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		orphan, err := domain.NewUserCardStats(user.ID, uuid.New())
+		require.NoError(t, err)
+		assert.ErrorIs(t, s.Stats.Create(ctx, orphan), store.ErrReferencedEntityMissing)
+	})
+
+	run(t, "update", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		created := now().Add(-time.Hour)
+		card := MustCreateCard(ctx, t, s, memo, "stats", created, now(), 0)
+
+		stats, err := s.Stats.GetForUpdate(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+		stats.Interval = 3
+		stats.EaseFactor = 2.6
+		stats.ConsecutiveCorrect = 1
+		stats.ReviewCount = 1
+		stats.Lapses = 2
+		stats.LastReviewedAt = now()
+		stats.NextReviewAt = now().Add(72 * time.Hour)
+		require.NoError(t, s.Stats.Update(ctx, stats))
+
+		got, err := s.Stats.Get(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, got.Interval)
+		assert.InDelta(t, 2.6, got.EaseFactor, 0.0001)
+		assert.Equal(t, 1, got.ConsecutiveCorrect)
+		assert.Equal(t, 1, got.ReviewCount)
+		assert.Equal(t, 2, got.Lapses)
+		assert.True(t, stats.LastReviewedAt.Equal(got.LastReviewedAt))
+		assert.True(t, stats.NextReviewAt.Equal(got.NextReviewAt))
+		assert.True(t, created.Equal(got.CreatedAt), "Updates keep the creation time")
+
+		missing, err := domain.NewUserCardStats(user.ID, uuid.New())
+		require.NoError(t, err)
+		assert.ErrorIs(t, s.Stats.Update(ctx, missing), store.ErrUserCardStatsNotFound)
+	})
+
+	run(t, "update_multiple", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		created := now().Add(-time.Hour)
+		first := MustCreateCard(ctx, t, s, memo, "first", created, now(), 0)
+		second := MustCreateCard(ctx, t, s, memo, "second", created, now(), 0)
+
+		require.NoError(t, s.Stats.UpdateMultiple(ctx, nil), "Updating no entries is a no-op")
+
+		var batch []*domain.UserCardStats
+		for i, card := range []*domain.Card{first, second} {
+			stats, err := s.Stats.Get(ctx, user.ID, card.ID)
+			require.NoError(t, err)
+			stats.Interval = i + 2
+			stats.ReviewCount = 1
+			stats.Lapses = i
+			stats.LastReviewedAt = now()
+			batch = append(batch, stats)
+		}
+		require.NoError(t, s.Stats.UpdateMultiple(ctx, batch))
+
+		for i, card := range []*domain.Card{first, second} {
+			got, err := s.Stats.Get(ctx, user.ID, card.ID)
+			require.NoError(t, err)
+			assert.Equal(t, i+2, got.Interval)
+			assert.Equal(t, 1, got.ReviewCount)
+			assert.Equal(t, i, got.Lapses)
+			assert.True(t, created.Equal(got.CreatedAt), "Updates keep the creation time")
+		}
+
+		duplicate := *batch[0]
+		err := s.Stats.UpdateMultiple(ctx, []*domain.UserCardStats{batch[0], &duplicate})
+		assert.ErrorIs(t, err, store.ErrInvalidEntity, "An entry may only be given once")
+
+		// An entry for another user makes the whole batch fail
+		batch[0].Interval = 9
+		foreign := *batch[1]
+		foreign.UserID = uuid.New()
+		err = s.Stats.UpdateMultiple(ctx, []*domain.UserCardStats{batch[0], &foreign})
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+
+		got, err := s.Stats.Get(ctx, user.ID, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got.Interval, "No entry is updated when one is missing")
+	})
+
+	run(t, "delete", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "stats", now(), now(), 0)
+
+		require.NoError(t, s.Stats.Delete(ctx, user.ID, card.ID))
+		_, err := s.Stats.Get(ctx, user.ID, card.ID)
+		assert.ErrorIs(t, err, store.ErrUserCardStatsNotFound)
+
+		_, err = s.Cards.GetByID(ctx, card.ID)
+		assert.NoError(t, err, "Deleting statistics keeps the card")
+
+		assert.ErrorIs(t, s.Stats.Delete(ctx, user.ID, card.ID), store.ErrUserCardStatsNotFound)
+	})
+
+	run(t, "count_due", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		created := now().Add(-48 * time.Hour)
+		suspended := MustCreateCard(ctx, t, s, memo, "suspended", created, now().Add(-2*time.Hour), 1)
+		buried := MustCreateCard(ctx, t, s, memo, "buried", created, now().Add(-time.Hour), 1)
+		MustCreateCard(ctx, t, s, memo, "due", created, now().Add(-time.Hour), 0)
+		MustCreateCard(ctx, t, s, memo, "not due", created, now().Add(time.Hour), 0)
+
+		otherUser := MustCreateUser(ctx, t, s)
+		otherMemo := MustCreateMemo(ctx, t, s, otherUser.ID)
+		MustCreateCard(ctx, t, s, otherMemo, "other user", created, now().Add(-time.Hour), 0)
+
+		count, err := s.Stats.CountDue(ctx, user.ID, now())
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		suspendedAt := now()
+		require.NoError(t, s.Cards.SetSuspended(ctx, suspended.ID, &suspendedAt))
+		buriedUntil := now().Add(time.Hour)
+		require.NoError(t, s.Cards.SetBuriedUntil(ctx, buried.ID, &buriedUntil))
+
+		count, err = s.Stats.CountDue(ctx, user.ID, now())
+		require.NoError(t, err)
+		assert.Equal(t, 2, count, "Buried cards still count as due; suspended cards do not")
+
+		_, err = s.Cards.SupersedeByMemo(ctx, memo.ID, now())
+		require.NoError(t, err)
+
+		count, err = s.Stats.CountDue(ctx, user.ID, now())
+		require.NoError(t, err)
+		assert.Zero(t, count, "Superseded cards are not due")
+	})
+
+	run(t, "forecast", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		created := now().Add(-48 * time.Hour)
+		MustCreateCard(ctx, t, s, memo, "overdue", created, now().Add(-24*time.Hour), 1)
+		MustCreateCard(ctx, t, s, memo, "later", created, now().Add(30*24*time.Hour), 1)
+
+		buckets, err := s.Stats.GetForecast(ctx, user.ID, nil, 7)
+		require.NoError(t, err)
+		require.Len(t, buckets, 7)
+		assert.Equal(t, 1, buckets[0].Count, "Overdue cards are forecast for today")
+		total := 0
+		for _, bucket := range buckets {
+			total += bucket.Count
+		}
+		assert.Equal(t, 1, total, "Cards due after the window are not forecast")
+
+		_, err = s.Stats.GetForecast(ctx, user.ID, nil, 0)
+		assert.ErrorIs(t, err, store.ErrInvalidEntity)
+	})
+
+	run(t, "with_tx", factory, func(t *testing.T, ctx context.Context, s Stores) {
+		user := MustCreateUser(ctx, t, s)
+		memo := MustCreateMemo(ctx, t, s, user.ID)
+		card := MustCreateCard(ctx, t, s, memo, "stats", now(), now(), 0)
+
+		txStats := s.Stats.WithTx(s.Tx)
+		require.NotNil(t, txStats)
+
+		stats, err := txStats.GetForUpdate(ctx, user.ID, card.ID)
+		require.NoError(t, err, "Statistics created outside the transaction are visible inside it")
+		stats.Interval = 5
+		require.NoError(t, txStats.Update(ctx, stats))
+
+		got, err := s.Stats.Get(ctx, user.ID, card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, got.Interval)
+	})
+}
@@ -0,0 +1,122 @@
+// Package storetest provides conformance test suites for implementations of
+// the interfaces in the store package. Every backend runs the same suites, so
+// a new backend only has to supply a factory to be checked against the same
+// contracts as the existing ones: the same not-found, duplicate and
+// missing-reference errors, the same filtering and ordering, and the same
+// WithTx behavior.
+//
+// A backend wires a suite into its own tests with a Factory:
+//
+//	func TestCardStoreConformance(t *testing.T) {
+//	    storetest.RunCardStoreSuite(t, func(t *testing.T) storetest.Stores {
+//	        backend := memory.NewBackend()
+//	        ...
+//	    })
+//	}
+package storetest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/store"
+	"github.com/stretchr/testify/require"
+)
+
+// suiteTimeout bounds each subtest of a suite
+const suiteTimeout = 10 * time.Second
+
+// Stores is one isolated set of stores sharing the same data.
+// Cards and statistics reference users and memos, so the suites need the
+// sibling stores to create the entities they refer to.
+type Stores struct {
+	Users        store.UserStore
+	Memos        store.MemoStore
+	Cards        store.CardStore
+	Stats        store.UserCardStatsStore
+	ReviewEvents store.ReviewEventStore
+
+	// Tx is a transaction that the stores' WithTx results are run on.
+	// Data written through a store returned by WithTx(Tx) must be visible
+	// through the original store and the other way around.
+	Tx *sql.Tx
+}
+
+// Factory returns a fresh, empty set of stores for a single test.
+// Any cleanup, such as rolling back a transaction, is registered on t.
+type Factory func(t *testing.T) Stores
+
+// run runs fn as a subtest with its own stores and a bounded context
+func run(t *testing.T, name string, factory Factory, fn func(t *testing.T, ctx context.Context, s Stores)) {
+	t.Helper()
+	t.Run(name, func(t *testing.T) {
+		s := factory(t)
+		ctx, cancel := context.WithTimeout(context.Background(), suiteTimeout)
+		defer cancel()
+		fn(t, ctx, s)
+	})
+}
+
+// now is truncated to PostgreSQL's timestamp precision so that times read back
+// from any backend compare equal to the times written
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// MustCreateUser creates a user with a unique email address
+func MustCreateUser(ctx context.Context, t *testing.T, s Stores) *domain.User {
+	t.Helper()
+	user, err := domain.NewUser(fmt.Sprintf("storetest-%s@example.com", uuid.New()), "storetest-password")
+	require.NoError(t, err)
+	require.NoError(t, s.Users.Create(ctx, user))
+	return user
+}
+
+// MustCreateMemo creates a pending memo for the user
+func MustCreateMemo(ctx context.Context, t *testing.T, s Stores, userID uuid.UUID) *domain.Memo {
+	t.Helper()
+	memo, err := domain.NewMemo(userID, "Store test memo")
+	require.NoError(t, err)
+	memo.CreatedAt = now()
+	memo.UpdatedAt = memo.CreatedAt
+	require.NoError(t, s.Memos.Create(ctx, memo))
+	return memo
+}
+
+// MustCreateCard creates a card with the given front, created at createdAt,
+// and statistics that make it due at nextReviewAt after reviewCount reviews
+func MustCreateCard(
+	ctx context.Context,
+	t *testing.T,
+	s Stores,
+	memo *domain.Memo,
+	front string,
+	createdAt time.Time,
+	nextReviewAt time.Time,
+	reviewCount int,
+) *domain.Card {
+	t.Helper()
+	card, err := domain.NewCard(memo.UserID, memo.ID, json.RawMessage(`{"front":"`+front+`","back":"back"}`))
+	require.NoError(t, err)
+	card.CreatedAt = createdAt
+	card.UpdatedAt = createdAt
+	require.NoError(t, s.Cards.CreateMultiple(ctx, []*domain.Card{card}))
+
+	stats, err := domain.NewUserCardStats(memo.UserID, card.ID)
+	require.NoError(t, err)
+	stats.NextReviewAt = nextReviewAt
+	stats.ReviewCount = reviewCount
+	if reviewCount > 0 {
+		stats.Phase = domain.CardPhaseReview
+	}
+	stats.CreatedAt = createdAt
+	stats.UpdatedAt = createdAt
+	require.NoError(t, s.Stats.Create(ctx, stats))
+	return card
+}
@@ -27,6 +27,11 @@ type UserStore interface {
 	// The returned user contains all fields except the plaintext password.
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 
+	// List retrieves a page of users ordered by email. A non-empty emailQuery
+	// limits the results to users whose email contains it, ignoring case.
+	// Returns ErrInvalidEntity if limit is not positive or offset is negative.
+	List(ctx context.Context, emailQuery string, limit, offset int) ([]*domain.User, error)
+
 	// Update modifies an existing user's details.
 	// The caller MUST provide a complete user object including HashedPassword.
 	// If a new plain text Password is provided, it will be hashed and the HashedPassword will be updated.
@@ -35,6 +40,14 @@ type UserStore interface {
 	// Returns validation errors from the domain User if data is invalid.
 	Update(ctx context.Context, user *domain.User) error
 
+	// UpdatePasswordHash replaces the user's password hash with newHash if it is
+	// still oldHash, leaving the user's other fields untouched. It is meant for
+	// upgrading a hash after a login, so that neither concurrent changes to the
+	// user nor a password changed in the meantime are overwritten.
+	// Returns ErrUserNotFound if the user does not exist.
+	// Returns ErrVersionConflict if the stored hash is no longer oldHash.
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error
+
 	// Delete removes a user from the store by their ID.
 	// Returns ErrUserNotFound if the user does not exist.
 	// This operation is permanent and cannot be undone.
@@ -45,3 +58,9 @@ type UserStore interface {
 	// The transaction should be created and managed by the caller (typically a service).
 	WithTx(tx *sql.Tx) UserStore
 }
+
+// PasswordHasher hashes plaintext passwords before a UserStore saves them.
+// It is satisfied by the password schemes in the auth service package.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+}
@@ -13,6 +13,8 @@
 // 1. Task Queue:
 //   - In-memory queue for background job storage and processing
 //   - Ensure tasks are preserved during application lifecycle
+//   - Higher-priority tasks are dequeued first, FIFO within a priority
+//   - Scheduled tasks are held back until their run time
 //
 // 2. Worker Pool:
 //   - Concurrent execution of background tasks using goroutines
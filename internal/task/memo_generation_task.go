@@ -6,9 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/generation"
+	"github.com/phrazzld/scry-api/internal/platform/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Status constants for MemoGenerationTask
@@ -20,6 +26,11 @@ const (
 	statusFailed     = "failed"
 )
 
+// ContentBlockedReason is the status reason given to memos whose text the
+// generation provider refused for content safety
+const ContentBlockedReason = "Cards could not be generated because the memo was flagged by " +
+	"the content safety filter. Edit the memo text and regenerate it."
+
 // Common errors
 var (
 	ErrNilMemoService = errors.New("memo service cannot be nil")
@@ -37,6 +48,12 @@ type MemoService interface {
 
 	// UpdateMemoStatus updates a memo's status and handles related business logic
 	UpdateMemoStatus(ctx context.Context, memoID uuid.UUID, status domain.MemoStatus) error
+
+	// RecordGenerationDuration stores how long card generation took for a memo
+	RecordGenerationDuration(ctx context.Context, memoID uuid.UUID, duration time.Duration) error
+
+	// BlockMemo moves a memo to blocked status with a user-facing reason
+	BlockMemo(ctx context.Context, memoID uuid.UUID, reason string) error
 }
 
 // Generator defines the interface for flashcard generation services
@@ -55,6 +72,20 @@ type CardService interface {
 	GetCard(ctx context.Context, cardID uuid.UUID) (*domain.Card, error)
 }
 
+// DefaultDeckProvider supplies the deck that generated cards are assigned to
+// when they do not specify one
+type DefaultDeckProvider interface {
+	// EnsureDefaultDeck returns the user's default deck, creating it if needed
+	EnsureDefaultDeck(ctx context.Context, userID uuid.UUID) (*domain.Deck, error)
+}
+
+// CardFrontChecker reports which card fronts a user already has
+type CardFrontChecker interface {
+	// ExistingFrontHashes returns the subset of hashes, computed with
+	// domain.HashCardFront, that belong to the user's active cards
+	ExistingFrontHashes(ctx context.Context, userID uuid.UUID, hashes [][32]byte) (map[[32]byte]bool, error)
+}
+
 // memoGenerationPayload represents the serialized data stored in the task
 type memoGenerationPayload struct {
 	MemoID uuid.UUID `json:"memo_id"`
@@ -70,6 +101,74 @@ type MemoGenerationTask struct {
 	cardService CardService
 	logger      *slog.Logger
 	status      string // Using string instead of TaskStatus to avoid circular imports
+	priority    int
+
+	// completionEmitter, if set, receives an events.CardsGeneratedEvent once
+	// the task has completed successfully
+	completionEmitter events.EventEmitter
+
+	// statusEmitter, if set, receives an events.MemoStatusChangedEvent each
+	// time the task moves the memo to a new status
+	statusEmitter events.EventEmitter
+
+	// defaultDecks, if set, supplies the deck for generated cards without one
+	defaultDecks DefaultDeckProvider
+
+	// duplicates, if set, is checked to skip generated cards whose front the
+	// user already has
+	duplicates CardFrontChecker
+
+	// generationDuration is the time spent generating and saving cards,
+	// measured during Execute
+	generationDuration time.Duration
+}
+
+// MemoGenerationTaskOption configures optional MemoGenerationTask behavior
+type MemoGenerationTaskOption func(*MemoGenerationTask)
+
+// WithCompletionEmitter publishes an events.CardsGeneratedEvent to emitter
+// when the task completes successfully. Emitter errors are logged and never
+// fail the task, since the cards have already been saved by then.
+func WithCompletionEmitter(emitter events.EventEmitter) MemoGenerationTaskOption {
+	return func(t *MemoGenerationTask) {
+		t.completionEmitter = emitter
+	}
+}
+
+// WithStatusEmitter publishes an events.MemoStatusChangedEvent to emitter as
+// the task moves the memo through processing to completed, failed or blocked,
+// including the number of cards generated so far. Emitter errors are logged
+// and never fail the task.
+func WithStatusEmitter(emitter events.EventEmitter) MemoGenerationTaskOption {
+	return func(t *MemoGenerationTask) {
+		t.statusEmitter = emitter
+	}
+}
+
+// WithPriority sets the task's priority, PriorityBackground by default
+func WithPriority(priority int) MemoGenerationTaskOption {
+	return func(t *MemoGenerationTask) {
+		t.priority = priority
+	}
+}
+
+// WithDefaultDeck assigns generated cards that have no deck to the deck
+// returned by provider. If the deck cannot be resolved the cards are saved
+// without a deck rather than failing the task.
+func WithDefaultDeck(provider DefaultDeckProvider) MemoGenerationTaskOption {
+	return func(t *MemoGenerationTask) {
+		t.defaultDecks = provider
+	}
+}
+
+// WithDuplicateFilter skips generated cards whose front, ignoring case and
+// whitespace, matches one of the user's existing cards or an earlier card in
+// the same batch. If checker fails, the cards are saved unfiltered rather
+// than failing the task.
+func WithDuplicateFilter(checker CardFrontChecker) MemoGenerationTaskOption {
+	return func(t *MemoGenerationTask) {
+		t.duplicates = checker
+	}
 }
 
 // NewMemoGenerationTask creates a new memo generation task
@@ -79,6 +178,7 @@ func NewMemoGenerationTask(
 	generator Generator,
 	cardService CardService,
 	logger *slog.Logger,
+	opts ...MemoGenerationTaskOption,
 ) (*MemoGenerationTask, error) {
 	// Validate dependencies
 	if memoService == nil {
@@ -99,7 +199,7 @@ func NewMemoGenerationTask(
 		return nil, ErrEmptyMemoID
 	}
 
-	return &MemoGenerationTask{
+	task := &MemoGenerationTask{
 		id:          uuid.New(),
 		memoID:      memoID,
 		memoService: memoService,
@@ -107,7 +207,11 @@ func NewMemoGenerationTask(
 		cardService: cardService,
 		logger:      logger.With("task_type", TaskTypeMemoGeneration, "memo_id", memoID),
 		status:      statusPending,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(task)
+	}
+	return task, nil
 }
 
 // ID returns the task's unique identifier
@@ -136,17 +240,33 @@ func (t *MemoGenerationTask) Payload() []byte {
 	return data
 }
 
+// Priority implements Prioritized
+func (t *MemoGenerationTask) Priority() int {
+	return t.priority
+}
+
 // Status returns the current task status
 // We convert the string to TaskStatus to fulfill the Task interface
 func (t *MemoGenerationTask) Status() TaskStatus {
 	return TaskStatus(t.status)
 }
 
+// GenerationDuration returns the time Execute spent generating and saving cards.
+// It is zero until the task has completed successfully.
+func (t *MemoGenerationTask) GenerationDuration() time.Duration {
+	return t.generationDuration
+}
+
 // Execute runs the memo generation task, handling the complete lifecycle
 // from fetching the memo, updating status, generating cards, saving them,
 // and finalizing the process. It handles errors at each step and ensures
 // appropriate status updates.
-func (t *MemoGenerationTask) Execute(ctx context.Context) error {
+func (t *MemoGenerationTask) Execute(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "MemoGenerationTask.Execute",
+		attribute.String("task_id", t.id.String()),
+		attribute.String("memo_id", t.memoID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	// Update task status to processing
 	t.status = statusProcessing
 	t.logger.Info("starting memo generation task")
@@ -168,20 +288,44 @@ func (t *MemoGenerationTask) Execute(ctx context.Context) error {
 
 	t.logger.Info("retrieved memo", "user_id", memo.UserID, "memo_status", memo.Status)
 
-	// 2. Update memo status to processing
-	err = t.memoService.UpdateMemoStatus(ctx, t.memoID, domain.MemoStatusProcessing)
-	if err != nil {
-		t.status = statusFailed
-		t.logger.Error("failed to update memo status to processing", "error", err)
-		return fmt.Errorf("failed to update memo status to processing: %w", err)
+	// 2. Update memo status to processing, unless this run resumes an
+	// interrupted one that already did
+	if memo.Status != domain.MemoStatusProcessing {
+		err = t.memoService.UpdateMemoStatus(ctx, t.memoID, domain.MemoStatusProcessing)
+		if err != nil {
+			t.status = statusFailed
+			t.logger.Error("failed to update memo status to processing", "error", err)
+			return fmt.Errorf("failed to update memo status to processing: %w", err)
+		}
 	}
+	t.emitStatusChanged(ctx, memo.UserID, domain.MemoStatusProcessing, 0)
 
 	// 3. Generate cards
 	t.logger.Info("generating cards from memo text")
+	generationStart := time.Now()
 	cards, err := t.generator.GenerateCards(ctx, memo.Text, memo.UserID)
+	if errors.Is(err, generation.ErrContentBlocked) {
+		// Retrying cannot help, so tell the user why instead of just failing
+		if blockErr := t.memoService.BlockMemo(ctx, t.memoID, ContentBlockedReason); blockErr != nil {
+			t.logger.Error("failed to update memo status to blocked", "error", blockErr)
+		} else {
+			t.emitStatusChanged(ctx, memo.UserID, domain.MemoStatusBlocked, 0)
+		}
+		t.status = statusFailed
+		t.logger.Warn("memo content blocked by generation safety filters", "error", err)
+		return fmt.Errorf("failed to generate cards: %w", err)
+	}
+	if err != nil && ctx.Err() != nil {
+		// Cancelled, such as by shutdown: leave the memo processing so that
+		// the task can run again
+		t.status = statusFailed
+		t.logger.Warn("card generation interrupted", "error", err)
+		return fmt.Errorf("card generation interrupted: %w", err)
+	}
 	if err != nil {
 		// Update memo status to failed on generation error
 		_ = t.memoService.UpdateMemoStatus(ctx, t.memoID, domain.MemoStatusFailed)
+		t.emitStatusChanged(ctx, memo.UserID, domain.MemoStatusFailed, 0)
 		t.status = statusFailed
 		t.logger.Error("failed to generate cards", "error", err)
 		return fmt.Errorf("failed to generate cards: %w", err)
@@ -190,14 +334,28 @@ func (t *MemoGenerationTask) Execute(ctx context.Context) error {
 	// Log the number of cards generated
 	t.logger.Info("cards generated", "count", len(cards))
 
+	cards = t.skipDuplicates(ctx, memo.UserID, cards)
+	t.emitStatusChanged(ctx, memo.UserID, domain.MemoStatusProcessing, len(cards))
+
 	// 4. Save the generated cards (if any)
 	if len(cards) > 0 {
+		assignMemoDeck(memo, cards)
+		t.assignDefaultDeck(ctx, memo.UserID, cards)
+
 		// Use CardService to create cards and stats in a single transaction
 		err = t.cardService.CreateCards(ctx, cards)
 
+		if err != nil && ctx.Err() != nil {
+			// The cards are saved in one transaction, so none were saved and
+			// the task can run again
+			t.status = statusFailed
+			t.logger.Warn("saving generated cards interrupted", "error", err)
+			return fmt.Errorf("saving generated cards interrupted: %w", err)
+		}
 		if err != nil {
 			// Update memo status to failed if we couldn't save the cards
 			_ = t.memoService.UpdateMemoStatus(ctx, t.memoID, domain.MemoStatusFailed)
+			t.emitStatusChanged(ctx, memo.UserID, domain.MemoStatusFailed, 0)
 			t.status = statusFailed
 			t.logger.Error("failed to save generated cards and stats", "error", err)
 			return fmt.Errorf("failed to save generated cards and stats: %w", err)
@@ -207,6 +365,16 @@ func (t *MemoGenerationTask) Execute(ctx context.Context) error {
 		t.logger.Info("no cards were generated for this memo")
 	}
 
+	// Record how long generation took so clients can display it
+	t.generationDuration = time.Since(generationStart)
+	err = t.memoService.RecordGenerationDuration(ctx, t.memoID, t.generationDuration)
+	if err != nil {
+		// Timing is informational only, so a failure here doesn't fail the task
+		t.logger.Error("failed to record memo generation duration",
+			"error", err,
+			"generation_duration", t.generationDuration)
+	}
+
 	// 5. Update memo status to completed
 	finalStatus := domain.MemoStatusCompleted
 	if len(cards) == 0 {
@@ -223,8 +391,165 @@ func (t *MemoGenerationTask) Execute(ctx context.Context) error {
 			"cards_generated", len(cards))
 	}
 
+	t.emitStatusChanged(ctx, memo.UserID, finalStatus, len(cards))
+
 	// Update task status to completed
 	t.status = statusCompleted
 	t.logger.Info("memo generation task completed successfully", "cards_generated", len(cards))
+
+	t.emitCardsGenerated(ctx, memo.UserID, cards)
 	return nil
 }
+
+// assignMemoDeck puts cards without a deck into the memo's deck, if it has one.
+func assignMemoDeck(memo *domain.Memo, cards []*domain.Card) {
+	if memo.DeckID == nil {
+		return
+	}
+
+	for _, card := range cards {
+		if card.DeckID == nil {
+			deckID := *memo.DeckID
+			card.DeckID = &deckID
+		}
+	}
+}
+
+// assignDefaultDeck puts cards without a deck into the user's default deck,
+// if a default deck provider is configured.
+func (t *MemoGenerationTask) assignDefaultDeck(
+	ctx context.Context,
+	userID uuid.UUID,
+	cards []*domain.Card,
+) {
+	if t.defaultDecks == nil || !slices.ContainsFunc(cards, func(card *domain.Card) bool {
+		return card.DeckID == nil
+	}) {
+		return
+	}
+
+	deck, err := t.defaultDecks.EnsureDefaultDeck(ctx, userID)
+	if err != nil {
+		t.logger.Error("failed to resolve default deck, saving cards without a deck", "error", err)
+		return
+	}
+
+	for _, card := range cards {
+		if card.DeckID == nil {
+			card.DeckID = &deck.ID
+		}
+	}
+}
+
+// skipDuplicates drops cards whose front the user already has or that repeat
+// an earlier card in the batch, if a duplicate filter is configured. Cards
+// without a front to compare are always kept.
+func (t *MemoGenerationTask) skipDuplicates(
+	ctx context.Context,
+	userID uuid.UUID,
+	cards []*domain.Card,
+) []*domain.Card {
+	if t.duplicates == nil || len(cards) == 0 {
+		return cards
+	}
+
+	hashes := make([][32]byte, 0, len(cards))
+	for _, card := range cards {
+		if hash, ok := card.FrontHash(); ok {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	seen, err := t.duplicates.ExistingFrontHashes(ctx, userID, hashes)
+	if err != nil {
+		t.logger.Error("failed to check for duplicate cards, saving all generated cards", "error", err)
+		return cards
+	}
+	if seen == nil {
+		seen = make(map[[32]byte]bool)
+	}
+
+	unique := make([]*domain.Card, 0, len(cards))
+	for _, card := range cards {
+		hash, ok := card.FrontHash()
+		if ok && seen[hash] {
+			continue
+		}
+		if ok {
+			// Also drop later repeats within the batch
+			seen[hash] = true
+		}
+		unique = append(unique, card)
+	}
+
+	if skipped := len(cards) - len(unique); skipped > 0 {
+		t.logger.Info("skipped duplicate generated cards", "skipped", skipped, "kept", len(unique))
+	}
+	return unique
+}
+
+// emitStatusChanged publishes a MemoStatusChangedEvent to the status emitter,
+// if any. Failures are logged only, like emitCardsGenerated.
+func (t *MemoGenerationTask) emitStatusChanged(
+	ctx context.Context,
+	userID uuid.UUID,
+	status domain.MemoStatus,
+	cardCount int,
+) {
+	if t.statusEmitter == nil {
+		return
+	}
+
+	event, err := events.NewMemoStatusChangedEvent(events.MemoStatusChangedEvent{
+		MemoID:    t.memoID,
+		UserID:    userID,
+		Status:    string(status),
+		CardCount: cardCount,
+		ChangedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		t.logger.Error("failed to create memo status changed event", "error", err)
+		return
+	}
+
+	if err := t.statusEmitter.EmitEvent(ctx, event); err != nil {
+		t.logger.Error("failed to emit memo status changed event",
+			"error", err,
+			"event_id", event.ID,
+			"status", status)
+	}
+}
+
+// emitCardsGenerated publishes a CardsGeneratedEvent to the completion emitter, if any.
+// Failures are logged only; notifying listeners is not part of the task's work.
+func (t *MemoGenerationTask) emitCardsGenerated(
+	ctx context.Context,
+	userID uuid.UUID,
+	cards []*domain.Card,
+) {
+	if t.completionEmitter == nil {
+		return
+	}
+
+	cardIDs := make([]uuid.UUID, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	event, err := events.NewCardsGeneratedEvent(events.CardsGeneratedEvent{
+		MemoID:      t.memoID,
+		UserID:      userID,
+		CardIDs:     cardIDs,
+		CompletedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		t.logger.Error("failed to create cards generated event", "error", err)
+		return
+	}
+
+	if err := t.completionEmitter.EmitEvent(ctx, event); err != nil {
+		t.logger.Error("failed to emit cards generated event",
+			"error", err,
+			"event_id", event.ID)
+	}
+}
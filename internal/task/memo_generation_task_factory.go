@@ -1,7 +1,9 @@
 package task
 
 import (
+	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/google/uuid"
 )
@@ -12,20 +14,24 @@ type MemoGenerationTaskFactory struct {
 	generator   Generator
 	cardService CardService
 	logger      *slog.Logger
+	taskOpts    []MemoGenerationTaskOption
 }
 
-// NewMemoGenerationTaskFactory creates a new factory for MemoGenerationTasks
+// NewMemoGenerationTaskFactory creates a new factory for MemoGenerationTasks.
+// The options are applied to every task the factory creates.
 func NewMemoGenerationTaskFactory(
 	memoService MemoService,
 	generator Generator,
 	cardService CardService,
 	logger *slog.Logger,
+	opts ...MemoGenerationTaskOption,
 ) *MemoGenerationTaskFactory {
 	return &MemoGenerationTaskFactory{
 		memoService: memoService,
 		generator:   generator,
 		cardService: cardService,
 		logger:      logger.With("component", "memo_generation_task_factory"),
+		taskOpts:    opts,
 	}
 }
 
@@ -37,9 +43,52 @@ func (f *MemoGenerationTaskFactory) CreateTask(memoID uuid.UUID) (Task, error) {
 		f.generator,
 		f.cardService,
 		f.logger,
+		f.taskOpts...,
 	)
 	if err != nil {
 		return nil, err
 	}
 	return task, nil
 }
+
+// CreateTaskWithPriority creates a new MemoGenerationTask for the specified
+// memo with the given priority
+func (f *MemoGenerationTaskFactory) CreateTaskWithPriority(memoID uuid.UUID, priority int) (Task, error) {
+	opts := append(slices.Clip(f.taskOpts), WithPriority(priority))
+	task, err := NewMemoGenerationTask(
+		memoID,
+		f.memoService,
+		f.generator,
+		f.cardService,
+		f.logger,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// RestoreTask rebuilds a memo generation task loaded from a TaskStore, keeping
+// its ID and priority. It is the TaskRestorer for TaskTypeMemoGeneration.
+func (f *MemoGenerationTaskFactory) RestoreTask(stored Task) (Task, error) {
+	memoID, err := uuid.Parse(PayloadMemoID(stored.Payload()))
+	if err != nil {
+		return nil, fmt.Errorf("invalid memo ID: %w", err)
+	}
+
+	opts := append(slices.Clip(f.taskOpts), WithPriority(PriorityOf(stored)))
+	task, err := NewMemoGenerationTask(
+		memoID,
+		f.memoService,
+		f.generator,
+		f.cardService,
+		f.logger,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	task.id = stored.ID()
+	return task, nil
+}
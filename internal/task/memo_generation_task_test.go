@@ -4,12 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/generation"
 	"github.com/phrazzld/scry-api/internal/task/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,6 +32,36 @@ func createCardServiceMock(
 	}
 }
 
+// recordingEmitter is an events.EventEmitter that records emitted events
+// and returns err from every EmitEvent call
+type recordingEmitter struct {
+	emitted []*events.TaskRequestEvent
+	err     error
+}
+
+func (e *recordingEmitter) EmitEvent(ctx context.Context, event *events.TaskRequestEvent) error {
+	e.emitted = append(e.emitted, event)
+	return e.err
+}
+
+// defaultDeckProviderFunc adapts a function to DefaultDeckProvider
+type defaultDeckProviderFunc func(ctx context.Context, userID uuid.UUID) (*domain.Deck, error)
+
+func (f defaultDeckProviderFunc) EnsureDefaultDeck(ctx context.Context, userID uuid.UUID) (*domain.Deck, error) {
+	return f(ctx, userID)
+}
+
+// cardFrontCheckerFunc adapts a function to CardFrontChecker
+type cardFrontCheckerFunc func(ctx context.Context, userID uuid.UUID, hashes [][32]byte) (map[[32]byte]bool, error)
+
+func (f cardFrontCheckerFunc) ExistingFrontHashes(
+	ctx context.Context,
+	userID uuid.UUID,
+	hashes [][32]byte,
+) (map[[32]byte]bool, error) {
+	return f(ctx, userID, hashes)
+}
+
 func TestNewMemoGenerationTask(t *testing.T) {
 	t.Parallel()
 
@@ -218,6 +253,102 @@ func TestMemoGenerationTask_Execute(t *testing.T) {
 		assert.Equal(t, domain.MemoStatusCompleted, memo.Status)
 	})
 
+	t.Run("records generation duration", func(t *testing.T) {
+		memoID := uuid.New()
+		userID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: userID,
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+
+		var recorded time.Duration
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+			RecordGenerationDurationFn: func(ctx context.Context, id uuid.UUID, d time.Duration) error {
+				assert.Equal(t, memoID, id)
+				// Timing must be recorded before the memo is marked completed
+				assert.Equal(t, domain.MemoStatusProcessing, memo.Status)
+				recorded = d
+				memo.RecordGenerationDuration(d)
+				return nil
+			},
+		}
+
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				time.Sleep(10 * time.Millisecond)
+				return nil, nil
+			},
+		}
+
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			return nil
+		})
+
+		logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService, logger)
+		require.NoError(t, err)
+		assert.Zero(t, task.GenerationDuration())
+
+		require.NoError(t, task.Execute(context.Background()))
+
+		assert.GreaterOrEqual(t, task.GenerationDuration(), 10*time.Millisecond)
+		assert.Equal(t, task.GenerationDuration(), recorded)
+		assert.Equal(t, task.GenerationDuration(), memo.GenerationDuration)
+		assert.Equal(t, domain.MemoStatusCompleted, memo.Status)
+	})
+
+	t.Run("succeeds when recording generation duration fails", func(t *testing.T) {
+		memoID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: uuid.New(),
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+			RecordGenerationDurationFn: func(ctx context.Context, id uuid.UUID, d time.Duration) error {
+				return errors.New("database unavailable")
+			},
+		}
+
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return nil, nil
+			},
+		}
+
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			return nil
+		})
+
+		logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService, logger)
+		require.NoError(t, err)
+
+		require.NoError(t, task.Execute(context.Background()))
+		assert.Equal(t, TaskStatus(statusCompleted), task.Status())
+		assert.Equal(t, domain.MemoStatusCompleted, memo.Status)
+	})
+
 	t.Run("handles memo not found error", func(t *testing.T) {
 		// Setup mocks and data
 		memoID := uuid.New()
@@ -329,6 +460,129 @@ func TestMemoGenerationTask_Execute(t *testing.T) {
 		assert.Equal(t, domain.MemoStatusFailed, memo.Status)
 	})
 
+	t.Run("leaves the memo processing when cancelled during generation", func(t *testing.T) {
+		memoID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: uuid.New(),
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				// A slow generation that is cancelled part way through
+				cancel()
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, createCardServiceMock(nil),
+			slog.New(slog.NewTextHandler(io.Discard, nil)))
+		require.NoError(t, err)
+
+		err = task.Execute(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, TaskStatus(statusFailed), task.Status())
+		assert.Equal(t, domain.MemoStatusProcessing, memo.Status, "the memo is not failed, so the task can run again")
+	})
+
+	t.Run("resumes a memo left processing", func(t *testing.T) {
+		memoID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: uuid.New(),
+			Text:   "Test memo text",
+			Status: domain.MemoStatusProcessing,
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				if err := domain.ValidateMemoStatusTransition(memo.Status, status); err != nil {
+					return err
+				}
+				memo.Status = status
+				return nil
+			},
+		}
+
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return []*domain.Card{{
+					ID:      uuid.New(),
+					MemoID:  memoID,
+					UserID:  userID,
+					Content: json.RawMessage(`{"front":"Test front","back":"Test back"}`),
+				}}, nil
+			},
+		}
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			return nil
+		})
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService,
+			slog.New(slog.NewTextHandler(io.Discard, nil)))
+		require.NoError(t, err)
+
+		require.NoError(t, task.Execute(context.Background()))
+		assert.Equal(t, domain.MemoStatusCompleted, memo.Status)
+	})
+
+	t.Run("blocks the memo when content is blocked", func(t *testing.T) {
+		memoID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: uuid.New(),
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				return memo.TransitionStatus(status)
+			},
+			BlockMemoFn: func(ctx context.Context, id uuid.UUID, reason string) error {
+				return memo.Block(reason)
+			},
+		}
+
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return nil, fmt.Errorf("%w: finish reason SAFETY", generation.ErrContentBlocked)
+			},
+		}
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, createCardServiceMock(nil),
+			slog.New(slog.NewTextHandler(io.Discard, nil)))
+		require.NoError(t, err)
+
+		err = task.Execute(context.Background())
+
+		assert.ErrorIs(t, err, generation.ErrContentBlocked)
+		assert.Equal(t, TaskStatus(statusFailed), task.Status())
+		assert.Equal(t, domain.MemoStatusBlocked, memo.Status)
+		assert.Equal(t, ContentBlockedReason, memo.StatusReason)
+	})
+
 	t.Run("handles save cards error", func(t *testing.T) {
 		// Setup mocks and data
 		memoID := uuid.New()
@@ -384,4 +638,411 @@ func TestMemoGenerationTask_Execute(t *testing.T) {
 		assert.Equal(t, TaskStatus(statusFailed), task.Status())
 		assert.Equal(t, domain.MemoStatusFailed, memo.Status)
 	})
+	t.Run("emits cards generated event on completion", func(t *testing.T) {
+		memoID := uuid.New()
+		userID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: userID,
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+		cards := []*domain.Card{
+			{ID: uuid.New(), MemoID: memoID, UserID: userID},
+			{ID: uuid.New(), MemoID: memoID, UserID: userID},
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+		}
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return cards, nil
+			},
+		}
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			return nil
+		})
+		emitter := &recordingEmitter{}
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService,
+			slog.New(slog.NewTextHandler(os.Stdout, nil)), WithCompletionEmitter(emitter))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+
+		require.Len(t, emitter.emitted, 1)
+		event := emitter.emitted[0]
+		assert.Equal(t, events.EventTypeCardsGenerated, event.Type)
+
+		var payload events.CardsGeneratedEvent
+		require.NoError(t, event.UnmarshalPayload(&payload))
+		assert.Equal(t, memoID, payload.MemoID)
+		assert.Equal(t, userID, payload.UserID)
+		assert.Equal(t, []uuid.UUID{cards[0].ID, cards[1].ID}, payload.CardIDs)
+		assert.False(t, payload.CompletedAt.IsZero())
+	})
+
+	t.Run("succeeds when emitting cards generated event fails", func(t *testing.T) {
+		memoID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: uuid.New(),
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+		}
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return nil, nil
+			},
+		}
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			return nil
+		})
+		emitter := &recordingEmitter{err: errors.New("webhook unreachable")}
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService,
+			slog.New(slog.NewTextHandler(os.Stdout, nil)), WithCompletionEmitter(emitter))
+		require.NoError(t, err)
+
+		require.NoError(t, task.Execute(context.Background()))
+		assert.Len(t, emitter.emitted, 1)
+		assert.Equal(t, TaskStatus(statusCompleted), task.Status())
+		assert.Equal(t, domain.MemoStatusCompleted, memo.Status)
+	})
+
+	t.Run("does not emit cards generated event on failure", func(t *testing.T) {
+		memoID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: uuid.New(),
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+		}
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return nil, errors.New("generation error")
+			},
+		}
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			return nil
+		})
+		emitter := &recordingEmitter{}
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService,
+			slog.New(slog.NewTextHandler(os.Stdout, nil)), WithCompletionEmitter(emitter))
+		require.NoError(t, err)
+
+		assert.Error(t, task.Execute(context.Background()))
+		assert.Empty(t, emitter.emitted)
+	})
+
+	t.Run("emits status changes with card counts", func(t *testing.T) {
+		statuses := func(emitted []*events.TaskRequestEvent) []string {
+			var got []string
+			for _, event := range emitted {
+				require.Equal(t, events.EventTypeMemoStatusChanged, event.Type)
+				var payload events.MemoStatusChangedEvent
+				require.NoError(t, event.UnmarshalPayload(&payload))
+				got = append(got, fmt.Sprintf("%s:%d", payload.Status, payload.CardCount))
+			}
+			return got
+		}
+		run := func(generateErr error) []string {
+			memoID := uuid.New()
+			userID := uuid.New()
+			memo := &domain.Memo{ID: memoID, UserID: userID, Text: "Test memo text", Status: domain.MemoStatusPending}
+			memoService := &mocks.MockMemoService{
+				GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+					return memo, nil
+				},
+				UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+					memo.Status = status
+					return nil
+				},
+			}
+			generator := &mocks.Generator{
+				GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+					if generateErr != nil {
+						return nil, generateErr
+					}
+					return []*domain.Card{
+						{ID: uuid.New(), MemoID: memoID, UserID: userID},
+						{ID: uuid.New(), MemoID: memoID, UserID: userID},
+					}, nil
+				},
+			}
+			cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+				return nil
+			})
+			emitter := &recordingEmitter{}
+
+			task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService,
+				slog.New(slog.NewTextHandler(os.Stdout, nil)), WithStatusEmitter(emitter))
+			require.NoError(t, err)
+			_ = task.Execute(context.Background())
+			return statuses(emitter.emitted)
+		}
+
+		assert.Equal(t, []string{"processing:0", "processing:2", "completed:2"}, run(nil))
+		assert.Equal(t, []string{"processing:0", "failed:0"}, run(errors.New("generation error")))
+	})
+	t.Run("assigns generated cards without a deck to the default deck", func(t *testing.T) {
+		memoID := uuid.New()
+		userID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: userID,
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+		defaultDeck, err := domain.NewDeck(userID, domain.DefaultDeckName)
+		require.NoError(t, err)
+		otherDeckID := uuid.New()
+		cards := []*domain.Card{
+			{ID: uuid.New(), MemoID: memoID, UserID: userID},
+			{ID: uuid.New(), MemoID: memoID, UserID: userID, DeckID: &otherDeckID},
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+		}
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return cards, nil
+			},
+		}
+		var saved []*domain.Card
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			saved = cards
+			return nil
+		})
+		provider := defaultDeckProviderFunc(func(ctx context.Context, id uuid.UUID) (*domain.Deck, error) {
+			assert.Equal(t, userID, id)
+			return defaultDeck, nil
+		})
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService,
+			slog.New(slog.NewTextHandler(os.Stdout, nil)), WithDefaultDeck(provider))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+
+		require.Len(t, saved, 2)
+		require.NotNil(t, saved[0].DeckID)
+		assert.Equal(t, defaultDeck.ID, *saved[0].DeckID)
+		assert.Equal(t, otherDeckID, *saved[1].DeckID, "Cards with a deck keep it")
+	})
+
+	t.Run("assigns generated cards to the memo's deck before the default deck", func(t *testing.T) {
+		memoID := uuid.New()
+		userID := uuid.New()
+		memoDeckID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: userID,
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+			DeckID: &memoDeckID,
+		}
+		otherDeckID := uuid.New()
+		cards := []*domain.Card{
+			{ID: uuid.New(), MemoID: memoID, UserID: userID},
+			{ID: uuid.New(), MemoID: memoID, UserID: userID, DeckID: &otherDeckID},
+		}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+		}
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return cards, nil
+			},
+		}
+		var saved []*domain.Card
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			saved = cards
+			return nil
+		})
+		provider := defaultDeckProviderFunc(func(ctx context.Context, id uuid.UUID) (*domain.Deck, error) {
+			t.Error("The default deck should not be needed when the memo has a deck")
+			return nil, errors.New("unexpected call")
+		})
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService,
+			slog.New(slog.NewTextHandler(os.Stdout, nil)), WithDefaultDeck(provider))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+
+		require.Len(t, saved, 2)
+		require.NotNil(t, saved[0].DeckID)
+		assert.Equal(t, memoDeckID, *saved[0].DeckID)
+		assert.Equal(t, otherDeckID, *saved[1].DeckID, "Cards with a deck keep it")
+	})
+
+	t.Run("saves cards without a deck when the default deck is unavailable", func(t *testing.T) {
+		memoID := uuid.New()
+		userID := uuid.New()
+		memo := &domain.Memo{
+			ID:     memoID,
+			UserID: userID,
+			Text:   "Test memo text",
+			Status: domain.MemoStatusPending,
+		}
+		cards := []*domain.Card{{ID: uuid.New(), MemoID: memoID, UserID: userID}}
+
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+		}
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return cards, nil
+			},
+		}
+		var saved []*domain.Card
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			saved = cards
+			return nil
+		})
+		provider := defaultDeckProviderFunc(func(ctx context.Context, id uuid.UUID) (*domain.Deck, error) {
+			return nil, errors.New("database unavailable")
+		})
+
+		task, err := NewMemoGenerationTask(memoID, memoService, generator, cardService,
+			slog.New(slog.NewTextHandler(os.Stdout, nil)), WithDefaultDeck(provider))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+
+		require.Len(t, saved, 1)
+		assert.Nil(t, saved[0].DeckID)
+		assert.Equal(t, domain.MemoStatusCompleted, memo.Status)
+	})
+}
+
+func TestMemoGenerationTask_DuplicateFilter(t *testing.T) {
+	newCard := func(t *testing.T, userID, memoID uuid.UUID, front string) *domain.Card {
+		t.Helper()
+		card, err := domain.NewCard(userID, memoID, json.RawMessage(`{"front":"`+front+`","back":"back"}`))
+		require.NoError(t, err)
+		return card
+	}
+
+	// execute runs a task generating cards with checker as its duplicate
+	// filter, returning the cards it saved
+	execute := func(t *testing.T, memo *domain.Memo, cards []*domain.Card, checker CardFrontChecker) []*domain.Card {
+		t.Helper()
+		memoService := &mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				memo.Status = status
+				return nil
+			},
+		}
+		generator := &mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				return cards, nil
+			},
+		}
+		var saved []*domain.Card
+		cardService := createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			saved = cards
+			return nil
+		})
+
+		task, err := NewMemoGenerationTask(memo.ID, memoService, generator, cardService,
+			slog.New(slog.NewTextHandler(os.Stdout, nil)), WithDuplicateFilter(checker))
+		require.NoError(t, err)
+		require.NoError(t, task.Execute(context.Background()))
+		assert.Equal(t, domain.MemoStatusCompleted, memo.Status)
+		return saved
+	}
+
+	t.Run("skips cards the user already has and repeats within the batch", func(t *testing.T) {
+		memo := &domain.Memo{ID: uuid.New(), UserID: uuid.New(), Text: "Test memo text", Status: domain.MemoStatusPending}
+		existing := newCard(t, memo.UserID, memo.ID, "What is  GO?")
+		unique := newCard(t, memo.UserID, memo.ID, "What is a goroutine?")
+		repeat := newCard(t, memo.UserID, memo.ID, "what is a GOROUTINE?")
+
+		var checked [][32]byte
+		checker := cardFrontCheckerFunc(func(ctx context.Context, id uuid.UUID, hashes [][32]byte) (map[[32]byte]bool, error) {
+			assert.Equal(t, memo.UserID, id)
+			checked = hashes
+			return map[[32]byte]bool{domain.HashCardFront("what is go?"): true}, nil
+		})
+
+		saved := execute(t, memo, []*domain.Card{existing, unique, repeat}, checker)
+
+		assert.Equal(t, []*domain.Card{unique}, saved)
+		assert.Len(t, checked, 3, "All generated fronts are checked in one call")
+	})
+
+	t.Run("saves unique cards", func(t *testing.T) {
+		memo := &domain.Memo{ID: uuid.New(), UserID: uuid.New(), Text: "Test memo text", Status: domain.MemoStatusPending}
+		cards := []*domain.Card{
+			newCard(t, memo.UserID, memo.ID, "First question"),
+			newCard(t, memo.UserID, memo.ID, "Second question"),
+		}
+		checker := cardFrontCheckerFunc(func(ctx context.Context, id uuid.UUID, hashes [][32]byte) (map[[32]byte]bool, error) {
+			return map[[32]byte]bool{}, nil
+		})
+
+		assert.Equal(t, cards, execute(t, memo, cards, checker))
+	})
+
+	t.Run("saves all cards when the check fails", func(t *testing.T) {
+		memo := &domain.Memo{ID: uuid.New(), UserID: uuid.New(), Text: "Test memo text", Status: domain.MemoStatusPending}
+		cards := []*domain.Card{
+			newCard(t, memo.UserID, memo.ID, "Question"),
+			newCard(t, memo.UserID, memo.ID, "Question"),
+		}
+		checker := cardFrontCheckerFunc(func(ctx context.Context, id uuid.UUID, hashes [][32]byte) (map[[32]byte]bool, error) {
+			return nil, errors.New("database unavailable")
+		})
+
+		assert.Equal(t, cards, execute(t, memo, cards, checker))
+	})
 }
@@ -3,6 +3,7 @@ package task
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -28,6 +29,22 @@ type MemoServiceAdapter struct {
 	// Store these as explicit fields since we no longer have MemoRepository in this package
 	getByIDFn func(ctx context.Context, id uuid.UUID) (*domain.Memo, error)
 	updateFn  func(ctx context.Context, memo *domain.Memo) error
+
+	// enforceStatusTransitions requires status updates to follow the canonical
+	// memo state machine (see domain.ValidateMemoStatusTransition)
+	enforceStatusTransitions bool
+}
+
+// MemoServiceAdapterOption configures optional MemoServiceAdapter behavior
+type MemoServiceAdapterOption func(*MemoServiceAdapter)
+
+// WithStatusTransitionEnforcement controls whether UpdateMemoStatus rejects
+// transitions that the memo state machine does not allow.
+// Enforcement is enabled by default.
+func WithStatusTransitionEnforcement(enabled bool) MemoServiceAdapterOption {
+	return func(a *MemoServiceAdapter) {
+		a.enforceStatusTransitions = enabled
+	}
 }
 
 // NewMemoServiceAdapter creates a new adapter that implements MemoService
@@ -39,7 +56,10 @@ type MemoServiceAdapter struct {
 //
 // The adapter will use these methods to implement the MemoService interface.
 // If any required method is missing, an error will be returned.
-func NewMemoServiceAdapter(repo interface{}) (*MemoServiceAdapter, error) {
+func NewMemoServiceAdapter(
+	repo interface{},
+	opts ...MemoServiceAdapterOption,
+) (*MemoServiceAdapter, error) {
 	// Validate repository is not nil
 	if repo == nil {
 		return nil, ErrNilRepository
@@ -68,10 +88,16 @@ func NewMemoServiceAdapter(repo interface{}) (*MemoServiceAdapter, error) {
 	}
 
 	// Both methods are available, create and return the adapter
-	return &MemoServiceAdapter{
-		getByIDFn: getByIDFn,
-		updateFn:  updateFn,
-	}, nil
+	adapter := &MemoServiceAdapter{
+		getByIDFn:                getByIDFn,
+		updateFn:                 updateFn,
+		enforceStatusTransitions: true,
+	}
+	for _, opt := range opts {
+		opt(adapter)
+	}
+
+	return adapter, nil
 }
 
 // GetMemo retrieves a memo by its ID (simple pass-through to repository)
@@ -93,7 +119,11 @@ func (a *MemoServiceAdapter) UpdateMemoStatus(
 	}
 
 	// Update the memo's status
-	err = memo.UpdateStatus(status)
+	if a.enforceStatusTransitions {
+		err = memo.TransitionStatus(status)
+	} else {
+		err = memo.UpdateStatus(status)
+	}
 	if err != nil {
 		return err
 	}
@@ -102,5 +132,40 @@ func (a *MemoServiceAdapter) UpdateMemoStatus(
 	return a.updateFn(ctx, memo)
 }
 
+// RecordGenerationDuration stores how long card generation took for a memo
+func (a *MemoServiceAdapter) RecordGenerationDuration(
+	ctx context.Context,
+	memoID uuid.UUID,
+	duration time.Duration,
+) error {
+	memo, err := a.getByIDFn(ctx, memoID)
+	if err != nil {
+		return err
+	}
+
+	memo.RecordGenerationDuration(duration)
+
+	return a.updateFn(ctx, memo)
+}
+
+// BlockMemo moves a memo to blocked status with a user-facing reason
+func (a *MemoServiceAdapter) BlockMemo(ctx context.Context, memoID uuid.UUID, reason string) error {
+	memo, err := a.getByIDFn(ctx, memoID)
+	if err != nil {
+		return err
+	}
+
+	if a.enforceStatusTransitions {
+		if err := domain.ValidateMemoStatusTransition(memo.Status, domain.MemoStatusBlocked); err != nil {
+			return err
+		}
+	}
+	if err := memo.Block(reason); err != nil {
+		return err
+	}
+
+	return a.updateFn(ctx, memo)
+}
+
 // Ensure MemoServiceAdapter implements MemoService
 var _ MemoService = (*MemoServiceAdapter)(nil)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -214,4 +215,93 @@ func TestNewMemoServiceAdapter(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, updateErr, err) // Should return the error from repo's Update method
 	})
+
+	t.Run("adapter behavior - illegal status transition", func(t *testing.T) {
+		newRepo := func(memo *domain.Memo, updated *bool) *validRepository {
+			return &validRepository{
+				getByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+					return memo, nil
+				},
+				updateFunc: func(ctx context.Context, memo *domain.Memo) error {
+					*updated = true
+					return nil
+				},
+			}
+		}
+
+		// Enforcement is on by default
+		var updated bool
+		memo := &domain.Memo{ID: uuid.New(), Status: domain.MemoStatusCompleted}
+		adapter, err := NewMemoServiceAdapter(newRepo(memo, &updated))
+		require.NoError(t, err)
+
+		err = adapter.UpdateMemoStatus(context.Background(), memo.ID, domain.MemoStatusProcessing)
+		assert.ErrorIs(t, err, domain.ErrMemoStatusTransitionInvalid)
+		assert.False(t, updated, "Rejected transitions must not be saved")
+		assert.Equal(t, domain.MemoStatusCompleted, memo.Status)
+
+		// With enforcement disabled the update is applied
+		updated = false
+		adapter, err = NewMemoServiceAdapter(
+			newRepo(memo, &updated),
+			WithStatusTransitionEnforcement(false),
+		)
+		require.NoError(t, err)
+
+		err = adapter.UpdateMemoStatus(context.Background(), memo.ID, domain.MemoStatusProcessing)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+		assert.Equal(t, domain.MemoStatusProcessing, memo.Status)
+	})
+	t.Run("adapter behavior - record generation duration", func(t *testing.T) {
+		memo := &domain.Memo{ID: uuid.New(), Status: domain.MemoStatusProcessing}
+		var saved *domain.Memo
+		repo := &validRepository{
+			getByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			updateFunc: func(ctx context.Context, memo *domain.Memo) error {
+				saved = memo
+				return nil
+			},
+		}
+
+		adapter, err := NewMemoServiceAdapter(repo)
+		require.NoError(t, err)
+
+		err = adapter.RecordGenerationDuration(context.Background(), memo.ID, 1500*time.Millisecond)
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, 1500*time.Millisecond, saved.GenerationDuration)
+		assert.Equal(t, domain.MemoStatusProcessing, saved.Status, "Status should be unchanged")
+	})
+	t.Run("adapter behavior - block memo", func(t *testing.T) {
+		memo := &domain.Memo{ID: uuid.New(), Status: domain.MemoStatusProcessing}
+		var saved *domain.Memo
+		repo := &validRepository{
+			getByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			updateFunc: func(ctx context.Context, memo *domain.Memo) error {
+				saved = memo
+				return nil
+			},
+		}
+
+		adapter, err := NewMemoServiceAdapter(repo)
+		require.NoError(t, err)
+
+		err = adapter.BlockMemo(context.Background(), memo.ID, "blocked for safety")
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, domain.MemoStatusBlocked, saved.Status)
+		assert.Equal(t, "blocked for safety", saved.StatusReason)
+
+		// Completed memos cannot be blocked
+		saved = nil
+		memo.Status = domain.MemoStatusCompleted
+		err = adapter.BlockMemo(context.Background(), memo.ID, "blocked for safety")
+		assert.ErrorIs(t, err, domain.ErrMemoStatusTransitionInvalid)
+		assert.Nil(t, saved)
+	})
 }
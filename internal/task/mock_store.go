@@ -30,6 +30,18 @@ func NewMockTaskStore() *MockTaskStore {
 		store.mutex.Lock()
 		defer store.mutex.Unlock()
 
+		// Mirror the stores' rule of one active task per type and memo
+		if memoID := PayloadMemoID(task.Payload()); memoID != "" {
+			for _, existing := range store.tasks {
+				status := existing.Status()
+				if existing.Type() == task.Type() &&
+					(status == TaskStatusPending || status == TaskStatusProcessing) &&
+					PayloadMemoID(existing.Payload()) == memoID {
+					return ErrTaskAlreadyActive
+				}
+			}
+		}
+
 		mockTask, ok := task.(*MockTask)
 		if !ok {
 			// If it's not a MockTask, create a new one with same properties
@@ -77,6 +89,18 @@ func (s *MockTaskStore) UpdateTaskStatus(
 	return s.UpdateStatusFn(ctx, taskID, status, errorMsg)
 }
 
+// Heartbeat records that a processing task is still running by refreshing
+// its status time, which GetProcessingTasks ages tasks by
+func (s *MockTaskStore) Heartbeat(ctx context.Context, taskID uuid.UUID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if task, ok := s.tasks[taskID]; ok && task.Status() == TaskStatusProcessing {
+		s.taskStatusTimes[taskID] = time.Now()
+	}
+	return nil
+}
+
 // GetPendingTasks retrieves all tasks with "pending" status
 func (s *MockTaskStore) GetPendingTasks(ctx context.Context) ([]Task, error) {
 	s.mutex.RLock()
@@ -117,6 +141,30 @@ func (s *MockTaskStore) GetProcessingTasks(
 	return processingTasks, nil
 }
 
+// ReclaimTask resets a processing task to pending if it is still processing
+// and, when olderThan is non-zero, its status time is older than olderThan
+func (s *MockTaskStore) ReclaimTask(
+	ctx context.Context,
+	taskID uuid.UUID,
+	olderThan time.Duration,
+	errorMsg string,
+) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	task, ok := s.tasks[taskID].(*MockTask)
+	if !ok || task.TaskStatus != TaskStatusProcessing {
+		return false, nil
+	}
+	if olderThan > 0 && time.Since(s.taskStatusTimes[taskID]) <= olderThan {
+		return false, nil
+	}
+
+	task.TaskStatus = TaskStatusPending
+	s.taskStatusTimes[taskID] = time.Now()
+	return true, nil
+}
+
 // WithTx implements TaskStore.WithTx for the mock store
 // In the mock implementation, we just return the same store instance
 func (s *MockTaskStore) WithTx(tx *sql.Tx) TaskStore {
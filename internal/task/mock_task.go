@@ -14,7 +14,10 @@ type MockTask struct {
 	TaskType    string
 	TaskPayload []byte
 	TaskStatus  TaskStatus
-	ExecuteFn   func(ctx context.Context) error
+
+	// TaskPriority is returned by Priority, PriorityBackground by default
+	TaskPriority int
+	ExecuteFn    func(ctx context.Context) error
 }
 
 // NewMockTask creates a new MockTask with the given ID and type
@@ -48,6 +51,11 @@ func (t *MockTask) Status() TaskStatus {
 	return t.TaskStatus
 }
 
+// Priority returns the task's priority
+func (t *MockTask) Priority() int {
+	return t.TaskPriority
+}
+
 // Execute runs the task logic
 func (t *MockTask) Execute(ctx context.Context) error {
 	return t.ExecuteFn(ctx)
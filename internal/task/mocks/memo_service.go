@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/phrazzld/scry-api/internal/domain"
@@ -11,6 +12,10 @@ import (
 type MockMemoService struct {
 	GetMemoFn          func(ctx context.Context, memoID uuid.UUID) (*domain.Memo, error)
 	UpdateMemoStatusFn func(ctx context.Context, memoID uuid.UUID, status domain.MemoStatus) error
+
+	RecordGenerationDurationFn func(ctx context.Context, memoID uuid.UUID, duration time.Duration) error
+
+	BlockMemoFn func(ctx context.Context, memoID uuid.UUID, reason string) error
 }
 
 // GetMemo implements task.MemoService
@@ -32,3 +37,23 @@ func (m *MockMemoService) UpdateMemoStatus(
 	}
 	return nil
 }
+
+// RecordGenerationDuration implements task.MemoService
+func (m *MockMemoService) RecordGenerationDuration(
+	ctx context.Context,
+	memoID uuid.UUID,
+	duration time.Duration,
+) error {
+	if m.RecordGenerationDurationFn != nil {
+		return m.RecordGenerationDurationFn(ctx, memoID, duration)
+	}
+	return nil
+}
+
+// BlockMemo implements task.MemoService
+func (m *MockMemoService) BlockMemo(ctx context.Context, memoID uuid.UUID, reason string) error {
+	if m.BlockMemoFn != nil {
+		return m.BlockMemoFn(ctx, memoID, reason)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package task
+
+import (
+	"container/heap"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// priorityQueue is a bounded, concurrency-safe queue of tasks that yields the
+// highest-priority task first, and tasks of equal priority in the order they
+// were pushed. A task already in the queue is not queued a second time.
+type priorityQueue struct {
+	mu       sync.Mutex
+	entries  taskHeap
+	queued   map[uuid.UUID]bool
+	capacity int
+	seq      uint64
+
+	// ready holds one token per queued task, so consumers can wait for a
+	// task in a select alongside other channels
+	ready chan struct{}
+}
+
+// newPriorityQueue creates a priorityQueue holding at most capacity tasks
+func newPriorityQueue(capacity int) *priorityQueue {
+	return &priorityQueue{
+		queued:   make(map[uuid.UUID]bool),
+		capacity: capacity,
+		ready:    make(chan struct{}, capacity),
+	}
+}
+
+// push adds task to the queue. It returns false if the queue is full. Pushing
+// a task that is already queued succeeds without queuing it again.
+func (q *priorityQueue) push(task Task) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.queued[task.ID()] {
+		return true
+	}
+	if len(q.entries) >= q.capacity {
+		return false
+	}
+
+	q.seq++
+	heap.Push(&q.entries, queueEntry{task: task, priority: PriorityOf(task), seq: q.seq})
+	q.queued[task.ID()] = true
+	q.ready <- struct{}{}
+	return true
+}
+
+// pop removes and returns the next task. Call it only after receiving from
+// ready, which guarantees a task is queued.
+func (q *priorityQueue) pop() Task {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entry := heap.Pop(&q.entries).(queueEntry)
+	delete(q.queued, entry.task.ID())
+	return entry.task
+}
+
+// queueEntry is a task in a priorityQueue with its ordering keys
+type queueEntry struct {
+	task     Task
+	priority int
+	seq      uint64
+}
+
+// taskHeap implements heap.Interface, ordering entries by descending
+// priority, then by ascending sequence number
+type taskHeap []queueEntry
+
+func (h taskHeap) Len() int { return len(h) }
+
+func (h taskHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h taskHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *taskHeap) Push(x any) { *h = append(*h, x.(queueEntry)) }
+
+func (h *taskHeap) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = queueEntry{}
+	*h = old[:n-1]
+	return entry
+}
@@ -2,10 +2,13 @@ package task
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // TaskRunnerConfig holds configuration for the task runner
@@ -13,38 +16,70 @@ type TaskRunnerConfig struct {
 	// WorkerCount determines how many concurrent workers process tasks
 	WorkerCount int
 
-	// QueueSize determines the buffer size for the in-memory task queue
+	// QueueSize determines the buffer size for the in-memory task queue,
+	// which hands tasks to workers highest priority first
 	QueueSize int
 
-	// StuckTaskAge defines how long a task can be in processing state
-	// before it's considered stuck and reset
+	// StuckTaskAge defines how long a task can be in processing state without
+	// a heartbeat before it's considered stuck and reset
 	StuckTaskAge time.Duration
 
-	// StuckTaskCheckInterval defines how often to check for stuck tasks
+	// StuckTaskCheckInterval defines how often to check for stuck tasks while
+	// the runner is running, in addition to the recovery on Start.
 	// If zero, defaults to 5 minutes
 	StuckTaskCheckInterval time.Duration
+
+	// DelayedTaskCheckInterval defines how often to check whether scheduled
+	// tasks have become due. If zero, defaults to 1 second
+	DelayedTaskCheckInterval time.Duration
+
+	// HeartbeatInterval defines how often a worker reports a running task
+	// alive. It must be well below StuckTaskAge, or long tasks are reset while
+	// they run. If zero, defaults to a third of StuckTaskAge, or 1 minute if
+	// StuckTaskAge is zero
+	HeartbeatInterval time.Duration
 }
 
 // DefaultTaskRunnerConfig returns a TaskRunnerConfig with reasonable defaults
 func DefaultTaskRunnerConfig() TaskRunnerConfig {
 	return TaskRunnerConfig{
-		WorkerCount:            2,
-		QueueSize:              100,
-		StuckTaskAge:           30 * time.Minute,
-		StuckTaskCheckInterval: 5 * time.Minute,
+		WorkerCount:              2,
+		QueueSize:                100,
+		StuckTaskAge:             30 * time.Minute,
+		StuckTaskCheckInterval:   5 * time.Minute,
+		DelayedTaskCheckInterval: time.Second,
+		HeartbeatInterval:        time.Minute,
 	}
 }
 
+// TaskRestorer rebuilds a runnable task from one loaded from the TaskStore,
+// which carries the task's ID, type, payload and priority but cannot run.
+// The rebuilt task must keep the stored task's ID.
+type TaskRestorer func(stored Task) (Task, error)
+
 // TaskRunner manages background task processing
 type TaskRunner struct {
 	store      TaskStore
-	taskChan   chan Task
+	queue      *priorityQueue
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 	wg         sync.WaitGroup
 	config     TaskRunnerConfig
 	logger     *slog.Logger
 	errHandler func(task Task, err error)
+
+	// restorers rebuild the tasks of each type loaded from the store
+	restorers map[string]TaskRestorer
+
+	// delayed holds saved tasks that are not yet due, until
+	// delayedTaskMonitor moves them to the queue
+	delayedMu sync.Mutex
+	delayed   []Task
+
+	// running holds the IDs of the tasks this runner is executing, which are
+	// never reset as stuck however old their heartbeat
+	runningMu sync.Mutex
+	running   map[uuid.UUID]bool
 }
 
 // NewTaskRunner creates a new TaskRunner
@@ -53,17 +88,28 @@ func NewTaskRunner(store TaskStore, config TaskRunnerConfig, logger *slog.Logger
 	if config.StuckTaskCheckInterval == 0 {
 		config.StuckTaskCheckInterval = 5 * time.Minute
 	}
+	if config.DelayedTaskCheckInterval == 0 {
+		config.DelayedTaskCheckInterval = time.Second
+	}
+	if config.HeartbeatInterval == 0 {
+		config.HeartbeatInterval = config.StuckTaskAge / 3
+		if config.HeartbeatInterval == 0 {
+			config.HeartbeatInterval = time.Minute
+		}
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &TaskRunner{
 		store:      store,
-		taskChan:   make(chan Task, config.QueueSize),
+		queue:      newPriorityQueue(config.QueueSize),
 		ctx:        ctx,
 		cancelFunc: cancel,
 		wg:         sync.WaitGroup{},
 		config:     config,
 		logger:     logger,
+		running:    make(map[uuid.UUID]bool),
+		restorers:  make(map[string]TaskRestorer),
 		errHandler: func(task Task, err error) {
 			// Default error handler just logs the error
 			logger.Error("task execution failed",
@@ -79,21 +125,54 @@ func (r *TaskRunner) SetErrorHandler(handler func(task Task, err error)) {
 	r.errHandler = handler
 }
 
-// Submit adds a new task to the queue
+// RegisterTaskType registers the restorer that rebuilds tasks of the given
+// type when they are loaded from the store, by Recover or when they are found
+// stuck. Register every task type before calling Start. Tasks of a type
+// without a restorer are run as loaded, which only works for stores that keep
+// the original tasks.
+func (r *TaskRunner) RegisterTaskType(taskType string, restore TaskRestorer) {
+	r.restorers[taskType] = restore
+}
+
+// Submit adds a new task to the queue.
+// Submitting a task for a memo that already has a pending or processing task
+// of the same type, such as when a memo-create request is retried, is a no-op.
 func (r *TaskRunner) Submit(ctx context.Context, task Task) error {
 	// Save task to database first
 	if err := r.store.SaveTask(ctx, task); err != nil {
+		if errors.Is(err, ErrTaskAlreadyActive) {
+			r.logger.Info("skipping duplicate task, one is already active",
+				"task_id", task.ID(),
+				"task_type", task.Type())
+			return nil
+		}
 		return fmt.Errorf("failed to save task: %w", err)
 	}
 
 	// Then add to in-memory queue
-	select {
-	case r.taskChan <- task:
-		return nil
-	default:
-		// Queue is full, return error
+	if !r.enqueue(task) {
 		return fmt.Errorf("task queue is full, try again later")
 	}
+	return nil
+}
+
+// Schedule adds a task that must not run before runAt. The task is saved
+// with its run time, so it survives a restart, and queued once it is due.
+// A runAt in the past schedules the task to run as soon as possible.
+func (r *TaskRunner) Schedule(ctx context.Context, task Task, runAt time.Time) error {
+	return r.Submit(ctx, &scheduledTask{Task: task, runAt: runAt.UTC()})
+}
+
+// enqueue adds a saved task to the in-memory queue, or holds it until it is
+// due if it is scheduled for later. It returns false if the queue is full.
+func (r *TaskRunner) enqueue(task Task) bool {
+	if RunAtOf(task).After(time.Now()) {
+		r.delayedMu.Lock()
+		r.delayed = append(r.delayed, task)
+		r.delayedMu.Unlock()
+		return true
+	}
+	return r.queue.push(task)
 }
 
 // Start initializes the worker pool and begins processing tasks
@@ -113,14 +192,18 @@ func (r *TaskRunner) Start() error {
 	r.wg.Add(1)
 	go r.stuckTaskMonitor()
 
+	// Start goroutine to queue scheduled tasks once they are due
+	r.wg.Add(1)
+	go r.delayedTaskMonitor()
+
 	return nil
 }
 
-// Stop gracefully shuts down the task runner
+// Stop shuts down the task runner. Running tasks are cancelled through their
+// context and left pending, so they run again after the next Recover.
 func (r *TaskRunner) Stop() {
 	r.cancelFunc()
 	r.wg.Wait()
-	close(r.taskChan)
 }
 
 // Recover loads any unfinished tasks from the database
@@ -147,13 +230,13 @@ func (r *TaskRunner) Recover() error {
 		"pending_count", len(pendingTasks),
 		"processing_count", len(processingTasks))
 
-	// Requeue pending tasks
-	for _, task := range pendingTasks {
-		select {
-		case r.taskChan <- task:
-			// Successfully requeued
-		default:
-			// Queue is full, log error
+	// Requeue pending tasks, holding back those scheduled for later
+	for _, stored := range pendingTasks {
+		task, ok := r.restore(ctx, stored)
+		if !ok {
+			continue
+		}
+		if !r.enqueue(task) {
 			r.logger.Error("failed to requeue pending task, queue is full",
 				"task_id", task.ID(),
 				"task_type", task.Type())
@@ -161,22 +244,31 @@ func (r *TaskRunner) Recover() error {
 	}
 
 	// Reset processing tasks back to pending state and requeue them
-	for _, task := range processingTasks {
-		// Update status in database to pending
-		if err := r.store.UpdateTaskStatus(ctx, task.ID(), TaskStatusPending, "Reset after recovery"); err != nil {
+	for _, stored := range processingTasks {
+		// Reset the status in the database to pending, unless another runner
+		// has already done so
+		reclaimed, err := r.store.ReclaimTask(ctx, stored.ID(), 0, "Reset after recovery")
+		if err != nil {
 			r.logger.Error("failed to reset processing task status",
-				"task_id", task.ID(),
-				"task_type", task.Type(),
+				"task_id", stored.ID(),
+				"task_type", stored.Type(),
 				"error", err)
 			continue
 		}
+		if !reclaimed {
+			r.logger.Debug("skipping processing task reclaimed elsewhere",
+				"task_id", stored.ID(),
+				"task_type", stored.Type())
+			continue
+		}
+
+		task, ok := r.restore(ctx, stored)
+		if !ok {
+			continue
+		}
 
 		// Requeue
-		select {
-		case r.taskChan <- task:
-			// Successfully requeued
-		default:
-			// Queue is full, log error
+		if !r.queue.push(task) {
 			r.logger.Error("failed to requeue processing task, queue is full",
 				"task_id", task.ID(),
 				"task_type", task.Type())
@@ -199,25 +291,33 @@ func (r *TaskRunner) worker(id int) {
 			r.logger.Debug("stopping worker", "worker_id", id)
 			return
 
-		case task, ok := <-r.taskChan:
-			if !ok {
-				// Channel closed, stop worker
-				r.logger.Debug("task channel closed, stopping worker", "worker_id", id)
+		case <-r.queue.ready:
+			task := r.queue.pop()
+
+			// A task taken as the runner stops is left pending for the next Recover
+			if r.ctx.Err() != nil {
+				r.logger.Debug("stopping worker, leaving dequeued task pending",
+					"worker_id", id,
+					"task_id", task.ID(),
+					"task_type", task.Type())
 				return
 			}
 
-			// Process the task
+			// Process the highest-priority task
 			r.processTask(task, id)
 		}
 	}
 }
 
 // processTask handles execution of a single task
+// The task runs with the runner's context, so Stop cancels it. Status updates
+// use a context that is not cancelled, so that they are still recorded.
 func (r *TaskRunner) processTask(task Task, workerID int) {
-	ctx := context.Background()
+	ctx := context.WithoutCancel(r.ctx)
 	logger := r.logger.With(
 		"task_id", task.ID(),
 		"task_type", task.Type(),
+		"priority", PriorityOf(task),
 		"worker_id", workerID,
 	)
 
@@ -229,10 +329,22 @@ func (r *TaskRunner) processTask(task Task, workerID int) {
 
 	logger.Info("processing task")
 
-	// Execute task
-	err := task.Execute(ctx)
+	r.setRunning(task.ID(), true)
+	defer r.setRunning(task.ID(), false)
 
-	if err != nil {
+	// Execute task, reporting it alive until it returns
+	stopHeartbeat := r.startHeartbeat(ctx, task, logger)
+	err := task.Execute(r.ctx)
+	stopHeartbeat()
+
+	if err != nil && r.ctx.Err() != nil {
+		// Interrupted by Stop: leave the task to run again rather than failing it
+		logger.Info("task interrupted by shutdown, resetting to pending", "error", err)
+		if updateErr := r.store.UpdateTaskStatus(ctx, task.ID(), TaskStatusPending,
+			"Reset after being interrupted by shutdown"); updateErr != nil {
+			logger.Error("failed to reset interrupted task status", "error", updateErr)
+		}
+	} else if err != nil {
 		// Task failed
 		logger.Error("task execution failed", "error", err)
 		if updateErr := r.store.UpdateTaskStatus(ctx, task.ID(), TaskStatusFailed, err.Error()); updateErr != nil {
@@ -250,6 +362,37 @@ func (r *TaskRunner) processTask(task Task, workerID int) {
 	}
 }
 
+// startHeartbeat records a heartbeat for the task every HeartbeatInterval, so
+// that no runner resets it as stuck while it runs, until the returned function
+// is called. A failed heartbeat is logged and retried on the next interval.
+func (r *TaskRunner) startHeartbeat(ctx context.Context, task Task, logger *slog.Logger) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(r.config.HeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := r.store.Heartbeat(ctx, task.ID()); err != nil {
+					logger.Warn("failed to record task heartbeat", "error", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
 // stuckTaskMonitor periodically checks for tasks that have been in "processing"
 // state for too long and resets them
 func (r *TaskRunner) stuckTaskMonitor() {
@@ -265,44 +408,166 @@ func (r *TaskRunner) stuckTaskMonitor() {
 			return
 
 		case <-ticker.C:
-			ctx := context.Background()
+			r.requeueStuckTasks(r.ctx)
+		}
+	}
+}
 
-			// Find tasks that have been in "processing" state for too long
-			stuckTasks, err := r.store.GetProcessingTasks(ctx, r.config.StuckTaskAge)
-			if err != nil {
-				r.logger.Error("failed to check for stuck tasks", "error", err)
-				continue
-			}
+// requeueStuckTasks resets tasks that have been processing without a heartbeat
+// for longer than StuckTaskAge, such as those whose worker crashed, to pending
+// and requeues them. Tasks this runner is still executing are left alone, as
+// are tasks that another runner reclaimed, or that reported a heartbeat, since
+// they were found.
+func (r *TaskRunner) requeueStuckTasks(ctx context.Context) {
+	// Find tasks that have been in "processing" state for too long
+	stuckTasks, err := r.store.GetProcessingTasks(ctx, r.config.StuckTaskAge)
+	if err != nil {
+		r.logger.Error("failed to check for stuck tasks", "error", err)
+		return
+	}
 
-			if len(stuckTasks) > 0 {
-				r.logger.Info("found stuck tasks", "count", len(stuckTasks))
-
-				// Reset each stuck task
-				for _, task := range stuckTasks {
-					if err := r.store.UpdateTaskStatus(ctx, task.ID(), TaskStatusPending,
-						"Reset after being stuck in processing state"); err != nil {
-						r.logger.Error("failed to reset stuck task status",
-							"task_id", task.ID(),
-							"task_type", task.Type(),
-							"error", err)
-						continue
-					}
-
-					// Requeue
-					select {
-					case r.taskChan <- task:
-						// Successfully requeued
-						r.logger.Info("requeued stuck task",
-							"task_id", task.ID(),
-							"task_type", task.Type())
-					default:
-						// Queue is full, log error
-						r.logger.Error("failed to requeue stuck task, queue is full",
-							"task_id", task.ID(),
-							"task_type", task.Type())
-					}
-				}
-			}
+	if len(stuckTasks) == 0 {
+		return
+	}
+	r.logger.Info("found stuck tasks", "count", len(stuckTasks))
+
+	// Reset each stuck task
+	for _, stored := range stuckTasks {
+		if r.isRunning(stored.ID()) {
+			r.logger.Debug("skipping stuck task that is still running",
+				"task_id", stored.ID(),
+				"task_type", stored.Type())
+			continue
+		}
+
+		reclaimed, err := r.store.ReclaimTask(ctx, stored.ID(), r.config.StuckTaskAge,
+			"Reset after being stuck in processing state")
+		if err != nil {
+			r.logger.Error("failed to reset stuck task status",
+				"task_id", stored.ID(),
+				"task_type", stored.Type(),
+				"error", err)
+			continue
+		}
+		if !reclaimed {
+			r.logger.Debug("skipping stuck task that is no longer stuck",
+				"task_id", stored.ID(),
+				"task_type", stored.Type())
+			continue
+		}
+
+		task, ok := r.restore(ctx, stored)
+		if !ok {
+			continue
+		}
+
+		// Requeue
+		if r.queue.push(task) {
+			r.logger.Info("requeued stuck task",
+				"task_id", task.ID(),
+				"task_type", task.Type())
+		} else {
+			r.logger.Error("failed to requeue stuck task, queue is full",
+				"task_id", task.ID(),
+				"task_type", task.Type())
+		}
+	}
+}
+
+// restore rebuilds a task loaded from the store with the restorer registered
+// for its type, keeping the time it was scheduled for. If the task cannot be
+// rebuilt it is marked failed, so it is not loaded again, and restore returns
+// false.
+func (r *TaskRunner) restore(ctx context.Context, stored Task) (Task, bool) {
+	restoreTask, ok := r.restorers[stored.Type()]
+	if !ok {
+		return stored, true
+	}
+
+	task, err := restoreTask(stored)
+	if err != nil {
+		r.logger.Error("failed to restore task",
+			"task_id", stored.ID(),
+			"task_type", stored.Type(),
+			"error", err)
+		if updateErr := r.store.UpdateTaskStatus(ctx, stored.ID(), TaskStatusFailed,
+			fmt.Sprintf("Could not be restored: %v", err)); updateErr != nil {
+			r.logger.Error("failed to update unrestorable task status",
+				"task_id", stored.ID(),
+				"task_type", stored.Type(),
+				"error", updateErr)
+		}
+		return nil, false
+	}
+
+	if runAt := RunAtOf(stored); !runAt.IsZero() && RunAtOf(task).IsZero() {
+		task = &scheduledTask{Task: task, runAt: runAt}
+	}
+	return task, true
+}
+
+// setRunning records whether this runner is executing the task
+func (r *TaskRunner) setRunning(taskID uuid.UUID, running bool) {
+	r.runningMu.Lock()
+	defer r.runningMu.Unlock()
+
+	if running {
+		r.running[taskID] = true
+	} else {
+		delete(r.running, taskID)
+	}
+}
+
+// isRunning reports whether this runner is executing the task
+func (r *TaskRunner) isRunning(taskID uuid.UUID) bool {
+	r.runningMu.Lock()
+	defer r.runningMu.Unlock()
+
+	return r.running[taskID]
+}
+
+// delayedTaskMonitor periodically moves scheduled tasks that have become due
+// to the queue. Tasks that do not fit in the queue are retried on the next check.
+func (r *TaskRunner) delayedTaskMonitor() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.config.DelayedTaskCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			// Context cancelled, stop monitor
+			return
+
+		case <-ticker.C:
+			r.queueDueTasks(time.Now())
+		}
+	}
+}
+
+// queueDueTasks moves the delayed tasks due by now to the queue
+func (r *TaskRunner) queueDueTasks(now time.Time) {
+	r.delayedMu.Lock()
+	defer r.delayedMu.Unlock()
+
+	waiting := r.delayed[:0]
+	for _, task := range r.delayed {
+		if RunAtOf(task).After(now) {
+			waiting = append(waiting, task)
+			continue
+		}
+		if !r.queue.push(task) {
+			r.logger.Warn("failed to queue due task, queue is full",
+				"task_id", task.ID(),
+				"task_type", task.Type())
+			waiting = append(waiting, task)
+			continue
 		}
+		r.logger.Debug("queued scheduled task",
+			"task_id", task.ID(),
+			"task_type", task.Type())
 	}
+	clear(r.delayed[len(waiting):])
+	r.delayed = waiting
 }
@@ -2,6 +2,7 @@ package task
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
@@ -10,6 +11,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/task/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -158,6 +161,182 @@ taskWaitLoop:
 	assert.Len(t, completedTasks, 3, "All 3 tasks should have been completed")
 }
 
+func TestTaskRunner_SubmitDeduplicatesActiveMemoTasks(t *testing.T) {
+	t.Parallel()
+
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	runner := NewTaskRunner(store, DefaultTaskRunnerConfig(), logger)
+	require.NoError(t, runner.Start())
+	defer runner.Stop()
+
+	payload, err := json.Marshal(map[string]string{"memo_id": uuid.New().String()})
+	require.NoError(t, err)
+
+	// Tasks block until both submissions are done, so the first is still
+	// active when the second is submitted
+	release := make(chan struct{})
+	executed := make(chan uuid.UUID, 2)
+	newTask := func() *MockTask {
+		task := NewMockTask(uuid.New(), TaskTypeMemoGeneration, payload)
+		task.ExecuteFn = func(ctx context.Context) error {
+			<-release
+			executed <- task.ID()
+			return nil
+		}
+		return task
+	}
+
+	// Submit the same memo twice at once, as a retried request would
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, runner.Submit(context.Background(), newTask()))
+		}()
+	}
+	wg.Wait()
+	close(release)
+
+	select {
+	case <-executed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the task to run")
+	}
+	select {
+	case id := <-executed:
+		t.Fatalf("Duplicate task %s ran", id)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	pending, err := store.GetPendingTasks(context.Background())
+	require.NoError(t, err)
+	processing, err := store.GetProcessingTasks(context.Background(), 0)
+	require.NoError(t, err)
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	assert.Len(t, store.tasks, 1, "Only one task should be saved")
+	assert.Empty(t, append(pending, processing...))
+}
+
+func TestTaskRunner_ProcessesHighestPriorityFirst(t *testing.T) {
+	t.Parallel()
+
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	config := DefaultTaskRunnerConfig()
+	config.WorkerCount = 1
+	runner := NewTaskRunner(store, config, logger)
+
+	// Queue everything before starting the single worker, so the order in
+	// which tasks run depends only on the queue
+	var mu sync.Mutex
+	var order []string
+	done := make(chan struct{}, 5)
+	submit := func(name string, priority int) {
+		task := CreateMockTaskWithPayload(name)
+		task.TaskPriority = priority
+		task.ExecuteFn = func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			done <- struct{}{}
+			return nil
+		}
+		require.NoError(t, runner.Submit(context.Background(), task))
+	}
+	submit("backfill 1", PriorityBackground)
+	submit("interactive 1", PriorityInteractive)
+	submit("backfill 2", PriorityBackground)
+	submit("middle", 5)
+	submit("interactive 2", PriorityInteractive)
+
+	require.NoError(t, runner.Start())
+	defer runner.Stop()
+
+	for i := 0; i < 5; i++ {
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for task %d", i+1)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t,
+		[]string{"interactive 1", "interactive 2", "middle", "backfill 1", "backfill 2"},
+		order,
+		"Tasks should run highest priority first, then in submission order")
+}
+
+func TestTaskRunner_Schedule(t *testing.T) {
+	t.Parallel()
+
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	config := DefaultTaskRunnerConfig()
+	config.DelayedTaskCheckInterval = 10 * time.Millisecond
+	runner := NewTaskRunner(store, config, logger)
+	require.NoError(t, runner.Start())
+	defer runner.Stop()
+
+	ran := make(chan time.Time, 1)
+	task := CreateMockTaskWithPayload("scheduled task")
+	task.ExecuteFn = func(ctx context.Context) error {
+		ran <- time.Now()
+		return nil
+	}
+
+	runAt := time.Now().Add(100 * time.Millisecond)
+	require.NoError(t, runner.Schedule(context.Background(), task, runAt))
+
+	// The task is saved right away, but does not run early
+	pending, err := store.GetPendingTasks(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, extractTaskIDs(pending), task.ID())
+
+	select {
+	case <-ran:
+		t.Fatal("Scheduled task ran before its run time")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	select {
+	case at := <-ran:
+		assert.False(t, at.Before(runAt), "Task ran at %v, before %v", at, runAt)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the scheduled task to run")
+	}
+}
+
+func TestTaskRunner_ScheduleInThePastRunsNow(t *testing.T) {
+	t.Parallel()
+
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	config := DefaultTaskRunnerConfig()
+	config.DelayedTaskCheckInterval = time.Hour
+	runner := NewTaskRunner(store, config, logger)
+	require.NoError(t, runner.Start())
+	defer runner.Stop()
+
+	ran := make(chan struct{}, 1)
+	task := CreateMockTaskWithPayload("overdue task")
+	task.ExecuteFn = func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}
+	require.NoError(t, runner.Schedule(context.Background(), task, time.Now().Add(-time.Minute)))
+
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the overdue task to run")
+	}
+}
+
 func TestTaskRunner_TaskFailure(t *testing.T) {
 	t.Parallel()
 
@@ -360,6 +539,347 @@ func TestTaskRunner_StuckTasks(t *testing.T) {
 	runner.Stop()
 }
 
+// ageTaskHeartbeat makes a processing task in store look as if its worker
+// stopped reporting it alive age ago
+func ageTaskHeartbeat(store *MockTaskStore, taskID uuid.UUID, age time.Duration) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.taskStatusTimes[taskID] = time.Now().Add(-age)
+}
+
+func TestTaskRunner_RequeuesTaskStuckMidRun(t *testing.T) {
+	t.Parallel()
+
+	// Setup
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	config := DefaultTaskRunnerConfig()
+	config.StuckTaskAge = 15 * time.Minute
+	config.StuckTaskCheckInterval = 50 * time.Millisecond
+
+	runner := NewTaskRunner(store, config, logger)
+	require.NoError(t, runner.Start())
+	defer runner.Stop()
+
+	// After startup recovery, a task is left processing by a worker that
+	// stopped without finishing it
+	executed := make(chan struct{}, 1)
+	stuckTask := CreateMockTaskWithPayload("stuck mid-run")
+	stuckTask.ExecuteFn = func(ctx context.Context) error {
+		executed <- struct{}{}
+		return nil
+	}
+	require.NoError(t, store.SaveTask(context.Background(), stuckTask))
+	require.NoError(t, store.UpdateTaskStatus(context.Background(), stuckTask.ID(), TaskStatusProcessing, ""))
+	ageTaskHeartbeat(store, stuckTask.ID(), 30*time.Minute)
+
+	select {
+	case <-executed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the stuck task to be requeued and run")
+	}
+}
+
+func TestTaskRunner_StuckScanSkipsRunningTasks(t *testing.T) {
+	t.Parallel()
+
+	// Setup
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	config := DefaultTaskRunnerConfig()
+	config.StuckTaskAge = 15 * time.Minute
+	config.StuckTaskCheckInterval = time.Hour // Scans are run by the test
+
+	runner := NewTaskRunner(store, config, logger)
+
+	// A long task that is still running, though its heartbeat is old
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	task := CreateMockTaskWithPayload("long task")
+	task.ExecuteFn = func(ctx context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	require.NoError(t, runner.Submit(context.Background(), task))
+	require.NoError(t, runner.Start())
+	defer runner.Stop()
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for task to start")
+	}
+	ageTaskHeartbeat(store, task.ID(), 30*time.Minute)
+
+	runner.requeueStuckTasks(context.Background())
+
+	processing, err := store.GetProcessingTasks(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{task.ID()}, extractTaskIDs(processing), "the running task is not reset")
+	assert.Empty(t, runner.queue.ready, "the running task is not requeued")
+
+	close(release)
+}
+
+func TestTaskRunner_HeartbeatKeepsLongTasksFromBeingReclaimed(t *testing.T) {
+	t.Parallel()
+
+	// Setup: two runners share a store, as two server instances share a database
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	config := DefaultTaskRunnerConfig()
+	config.StuckTaskAge = 200 * time.Millisecond
+	config.StuckTaskCheckInterval = time.Hour // Scans are run by the test
+	config.HeartbeatInterval = 20 * time.Millisecond
+
+	// A long task running on the first instance, which keeps heartbeating
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	longTask := CreateMockTaskWithPayload("long task")
+	longTask.ExecuteFn = func(ctx context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	running := NewTaskRunner(store, config, logger)
+	require.NoError(t, running.Submit(context.Background(), longTask))
+	require.NoError(t, running.Start())
+	defer running.Stop()
+	defer close(release)
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for task to start")
+	}
+
+	// A task whose worker stopped heartbeating when it crashed
+	abandoned := CreateMockTaskWithPayload("abandoned task")
+	require.NoError(t, store.SaveTask(context.Background(), abandoned))
+	require.NoError(t, store.UpdateTaskStatus(context.Background(), abandoned.ID(), TaskStatusProcessing, ""))
+
+	// Both tasks have been processing for longer than the stuck task age
+	time.Sleep(2 * config.StuckTaskAge)
+
+	// The second instance reclaims only the task that stopped heartbeating
+	other := NewTaskRunner(store, config, logger)
+	other.requeueStuckTasks(context.Background())
+
+	require.Len(t, other.queue.ready, 1)
+	<-other.queue.ready
+	assert.Equal(t, abandoned.ID(), other.queue.pop().ID(), "the abandoned task is reclaimed")
+
+	processing, err := store.GetProcessingTasks(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{longTask.ID()}, extractTaskIDs(processing), "the heartbeating task is not reset")
+}
+
+// staleTaskStore is a MockTaskStore whose GetProcessingTasks returns the tasks
+// found by an earlier scan, as a runner sees them when another runner acts
+// between its scan and its reset
+type staleTaskStore struct {
+	*MockTaskStore
+	found []Task
+}
+
+func (s *staleTaskStore) GetProcessingTasks(ctx context.Context, olderThan time.Duration) ([]Task, error) {
+	return s.found, nil
+}
+
+func TestTaskRunner_StuckTaskIsReclaimedOnce(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	config := DefaultTaskRunnerConfig()
+	config.StuckTaskAge = 15 * time.Minute
+	config.StuckTaskCheckInterval = time.Hour // Scans are run by the test
+
+	// stuckTask saves a task left processing by a crashed worker
+	stuckTask := func(store *MockTaskStore) *MockTask {
+		task := CreateMockTaskWithPayload("stuck task")
+		require.NoError(t, store.SaveTask(context.Background(), task))
+		require.NoError(t, store.UpdateTaskStatus(context.Background(), task.ID(), TaskStatusProcessing, ""))
+		ageTaskHeartbeat(store, task.ID(), 30*time.Minute)
+		return task
+	}
+
+	t.Run("by one of two runners", func(t *testing.T) {
+		store := NewMockTaskStore()
+		task := stuckTask(store)
+
+		// Both runners find the task stuck before either resets it
+		found, err := store.GetProcessingTasks(context.Background(), config.StuckTaskAge)
+		require.NoError(t, err)
+		first := NewTaskRunner(&staleTaskStore{MockTaskStore: store, found: found}, config, logger)
+		second := NewTaskRunner(&staleTaskStore{MockTaskStore: store, found: found}, config, logger)
+
+		first.requeueStuckTasks(context.Background())
+		second.requeueStuckTasks(context.Background())
+
+		require.Len(t, first.queue.ready, 1, "the first runner reclaims the task")
+		assert.Empty(t, second.queue.ready, "the second runner does not run it again")
+		<-first.queue.ready
+		assert.Equal(t, task.ID(), first.queue.pop().ID())
+	})
+
+	t.Run("unless it reports a heartbeat first", func(t *testing.T) {
+		store := NewMockTaskStore()
+		task := stuckTask(store)
+
+		found, err := store.GetProcessingTasks(context.Background(), config.StuckTaskAge)
+		require.NoError(t, err)
+		require.NoError(t, store.Heartbeat(context.Background(), task.ID()))
+
+		runner := NewTaskRunner(&staleTaskStore{MockTaskStore: store, found: found}, config, logger)
+		runner.requeueStuckTasks(context.Background())
+
+		assert.Empty(t, runner.queue.ready, "a task that is alive again is not reclaimed")
+		processing, err := store.GetProcessingTasks(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{task.ID()}, extractTaskIDs(processing))
+	})
+}
+
+func TestTaskRunner_StopCancelsRunningTask(t *testing.T) {
+	t.Parallel()
+
+	// Setup
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	runner := NewTaskRunner(store, DefaultTaskRunnerConfig(), logger)
+	runner.SetErrorHandler(func(task Task, err error) {
+		t.Errorf("error handler called for interrupted task: %v", err)
+	})
+
+	// A slow task that runs until its context is cancelled
+	started := make(chan struct{})
+	task := CreateMockTaskWithPayload("slow task")
+	task.ExecuteFn = func(ctx context.Context) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Minute):
+			return nil
+		}
+	}
+
+	require.NoError(t, runner.Submit(context.Background(), task))
+	require.NoError(t, runner.Start())
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for task to start")
+	}
+
+	// Stop returns without waiting for the task to finish
+	stopped := make(chan struct{})
+	go func() {
+		runner.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not return while a task was running")
+	}
+
+	// The task is left pending, so a restarted runner picks it up again
+	pendingTasks, err := store.GetPendingTasks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{task.ID()}, extractTaskIDs(pendingTasks))
+
+	restarted := NewTaskRunner(store, DefaultTaskRunnerConfig(), logger)
+	require.NoError(t, restarted.Recover())
+	assert.Len(t, restarted.queue.ready, 1, "the interrupted task is requeued on recovery")
+}
+
+// TestTaskRunner_RecoverRestoresTasks checks that tasks loaded from the store
+// are rebuilt by the restorer of their type, so a recovered memo generation
+// task generates cards instead of running the stored stub
+func TestTaskRunner_RecoverRestoresTasks(t *testing.T) {
+	t.Parallel()
+
+	// Setup
+	store := NewMockTaskStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	memoID := uuid.New()
+	memo := &domain.Memo{ID: memoID, UserID: uuid.New(), Text: "Recovered memo", Status: domain.MemoStatusPending}
+	generated := make(chan uuid.UUID, 1)
+	factory := NewMemoGenerationTaskFactory(
+		&mocks.MockMemoService{
+			GetMemoFn: func(ctx context.Context, id uuid.UUID) (*domain.Memo, error) {
+				return memo, nil
+			},
+			UpdateMemoStatusFn: func(ctx context.Context, id uuid.UUID, status domain.MemoStatus) error {
+				return nil
+			},
+		},
+		&mocks.Generator{
+			GenerateCardsFunc: func(ctx context.Context, text string, userID uuid.UUID) ([]*domain.Card, error) {
+				generated <- memoID
+				return nil, nil
+			},
+		},
+		createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			return nil
+		}),
+		logger,
+	)
+
+	// A memo generation task whose worker crashed, which the store only keeps
+	// as a stub, and one whose payload cannot be restored
+	interrupted, err := factory.CreateTaskWithPriority(memoID, PriorityInteractive)
+	require.NoError(t, err)
+	require.NoError(t, store.SaveTask(context.Background(), interrupted))
+	require.NoError(t, store.UpdateTaskStatus(context.Background(), interrupted.ID(), TaskStatusProcessing, ""))
+	broken := NewMockTask(uuid.New(), TaskTypeMemoGeneration, []byte(`{}`))
+	require.NoError(t, store.SaveTask(context.Background(), broken))
+
+	runner := NewTaskRunner(store, DefaultTaskRunnerConfig(), logger)
+	runner.RegisterTaskType(TaskTypeMemoGeneration, factory.RestoreTask)
+	require.NoError(t, runner.Start())
+	defer runner.Stop()
+
+	select {
+	case id := <-generated:
+		assert.Equal(t, memoID, id)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the recovered task to generate cards")
+	}
+
+	taskStatus := func(id uuid.UUID) TaskStatus {
+		store.mutex.RLock()
+		defer store.mutex.RUnlock()
+		return store.tasks[id].Status()
+	}
+	assert.Eventually(t, func() bool {
+		return taskStatus(interrupted.ID()) == TaskStatusCompleted
+	}, 2*time.Second, 10*time.Millisecond, "the restored task keeps the stored task's ID")
+	assert.Equal(t, TaskStatusFailed, taskStatus(broken.ID()), "a task that cannot be restored is failed")
+}
+
 // Helper function to extract task IDs from a slice of tasks
 func extractTaskIDs(tasks []Task) []uuid.UUID {
 	ids := make([]uuid.UUID, len(tasks))
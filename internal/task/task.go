@@ -3,6 +3,8 @@ package task
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,6 +27,80 @@ const (
 	TaskTypeMemoGeneration = "memo_generation"
 )
 
+// Task priorities. Runners process higher-priority tasks first.
+const (
+	// PriorityBackground is for system work such as bulk backfills, and is
+	// the priority of tasks that do not implement Prioritized
+	PriorityBackground = 0
+
+	// PriorityInteractive is for work a user is waiting on, such as
+	// generating cards for a memo they just created or regenerated
+	PriorityInteractive = 10
+)
+
+// Prioritized is implemented by tasks that have a priority
+type Prioritized interface {
+	// Priority returns the task's priority; higher runs first
+	Priority() int
+}
+
+// PriorityOf returns the priority of task, or PriorityBackground if it does
+// not implement Prioritized
+func PriorityOf(task Task) int {
+	if p, ok := task.(Prioritized); ok {
+		return p.Priority()
+	}
+	return PriorityBackground
+}
+
+// Scheduled is implemented by tasks that must not run before a given time
+type Scheduled interface {
+	// RunAt returns the earliest time the task may run; zero means now
+	RunAt() time.Time
+}
+
+// RunAtOf returns the earliest time task may run, or the zero time if it
+// does not implement Scheduled
+func RunAtOf(task Task) time.Time {
+	if s, ok := task.(Scheduled); ok {
+		return s.RunAt()
+	}
+	return time.Time{}
+}
+
+// scheduledTask wraps a task with the time it was scheduled to run
+type scheduledTask struct {
+	Task
+	runAt time.Time
+}
+
+// RunAt implements Scheduled
+func (t *scheduledTask) RunAt() time.Time {
+	return t.runAt
+}
+
+// Priority implements Prioritized with the wrapped task's priority
+func (t *scheduledTask) Priority() int {
+	return PriorityOf(t.Task)
+}
+
+// ErrTaskAlreadyActive is returned by TaskStore.SaveTask when a pending or
+// processing task of the same type already exists for the same memo
+var ErrTaskAlreadyActive = errors.New("an active task already exists for this memo")
+
+// PayloadMemoID returns the memo_id field of a task payload, or "" if the
+// payload has none. Stores allow at most one pending or processing task of
+// each type per memo ID.
+func PayloadMemoID(payload []byte) string {
+	var p struct {
+		MemoID string `json:"memo_id"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return ""
+	}
+	return p.MemoID
+}
+
 // Task represents a unit of background work to be processed
 // Version: 1.0
 type Task interface {
@@ -67,10 +143,15 @@ type TaskQueueWriter interface {
 // TaskStore defines the interface for persisting tasks
 // Version: 1.0
 type TaskStore interface {
-	// SaveTask persists a task to the database
+	// SaveTask persists a task to the database, with its run time if it
+	// implements Scheduled.
+	// Returns ErrTaskAlreadyActive if a pending or processing task of the same
+	// type already exists for the memo in the task's payload.
 	SaveTask(ctx context.Context, task Task) error
 
 	// UpdateTaskStatus updates the status of a task
+	// Moving a task to processing records a heartbeat for it; any other
+	// status clears the heartbeat.
 	UpdateTaskStatus(
 		ctx context.Context,
 		taskID uuid.UUID,
@@ -78,14 +159,29 @@ type TaskStore interface {
 		errorMsg string,
 	) error
 
-	// GetPendingTasks retrieves all tasks with "pending" status
+	// Heartbeat records that a processing task is still running, so that it
+	// is not reset as stuck. Heartbeats for tasks that are not processing,
+	// or that do not exist, are ignored.
+	Heartbeat(ctx context.Context, taskID uuid.UUID) error
+
+	// GetPendingTasks retrieves all tasks with "pending" status, including
+	// those scheduled for later, highest priority first and oldest first
+	// within a priority. The tasks implement Scheduled.
 	GetPendingTasks(ctx context.Context) ([]Task, error)
 
 	// GetProcessingTasks retrieves tasks with "processing" status
-	// If olderThan is non-zero, only returns tasks that have been in this state
-	// longer than the specified duration
+	// If olderThan is non-zero, only returns tasks whose last heartbeat is
+	// older than the specified duration, i.e. tasks no worker has reported
+	// alive in that time
 	GetProcessingTasks(ctx context.Context, olderThan time.Duration) ([]Task, error)
 
+	// ReclaimTask resets a processing task to pending with errorMsg, if it is
+	// still processing and, when olderThan is non-zero, its last heartbeat is
+	// older than olderThan. The check and the reset are atomic, so a task that
+	// several runners find stuck is reclaimed by only one of them.
+	// Returns whether the task was reclaimed.
+	ReclaimTask(ctx context.Context, taskID uuid.UUID, olderThan time.Duration, errorMsg string) (bool, error)
+
 	// WithTx returns a new TaskStore instance that uses the provided transaction.
 	// This allows for multiple operations to be executed within a single transaction.
 	// The transaction should be created and managed by the caller (typically a service).
@@ -0,0 +1,127 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/events"
+)
+
+// ErrUnregisteredEventType is returned when an event is emitted for a type
+// that has no registered task builder.
+var ErrUnregisteredEventType = errors.New("no task registered for event type")
+
+// TaskSubmitter enqueues tasks for background processing.
+// *TaskRunner satisfies this interface.
+type TaskSubmitter interface {
+	// Submit persists the task and adds it to the processing queue
+	Submit(ctx context.Context, task Task) error
+}
+
+// TaskBuilder creates the task requested by an event.
+type TaskBuilder func(event *events.TaskRequestEvent) (Task, error)
+
+// TaskQueueEmitter implements events.EventEmitter by translating each
+// TaskRequestEvent into a task and submitting it to a task runner.
+//
+// Builders are registered per event type with RegisterTaskType, so services
+// only need to emit events and never depend on the runner directly.
+type TaskQueueEmitter struct {
+	submitter TaskSubmitter
+	builders  map[string]TaskBuilder
+	mu        sync.RWMutex
+	logger    *slog.Logger
+}
+
+// NewTaskQueueEmitter creates a TaskQueueEmitter that submits tasks to submitter.
+func NewTaskQueueEmitter(submitter TaskSubmitter, logger *slog.Logger) *TaskQueueEmitter {
+	if submitter == nil {
+		panic("submitter cannot be nil") // ALLOW-PANIC
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &TaskQueueEmitter{
+		submitter: submitter,
+		builders:  make(map[string]TaskBuilder),
+		logger:    logger.With("component", "task_queue_emitter"),
+	}
+}
+
+// RegisterTaskType registers the builder used to create tasks for events of
+// the given type. Registering a type again replaces its builder.
+func (e *TaskQueueEmitter) RegisterTaskType(eventType string, builder TaskBuilder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.builders[eventType] = builder
+	e.logger.Debug("registered task type", "event_type", eventType)
+}
+
+// EmitEvent builds the task registered for the event's type and submits it.
+// It returns ErrUnregisteredEventType if no builder is registered for the type,
+// so requested work is never dropped silently.
+func (e *TaskQueueEmitter) EmitEvent(ctx context.Context, event *events.TaskRequestEvent) error {
+	e.mu.RLock()
+	builder, ok := e.builders[event.Type]
+	e.mu.RUnlock()
+
+	if !ok {
+		e.logger.Error("no task registered for event type",
+			"event_id", event.ID,
+			"event_type", event.Type)
+		return fmt.Errorf("%w: %s", ErrUnregisteredEventType, event.Type)
+	}
+
+	task, err := builder(event)
+	if err != nil {
+		e.logger.Error("failed to build task for event",
+			"error", err,
+			"event_id", event.ID,
+			"event_type", event.Type)
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+
+	if err := e.submitter.Submit(ctx, task); err != nil {
+		e.logger.Error("failed to submit task for event",
+			"error", err,
+			"task_id", task.ID(),
+			"event_id", event.ID,
+			"event_type", event.Type)
+		return fmt.Errorf("failed to submit task: %w", err)
+	}
+
+	e.logger.Info("task submitted for event",
+		"task_id", task.ID(),
+		"task_type", task.Type(),
+		"event_id", event.ID)
+	return nil
+}
+
+// MemoGenerationTaskBuilder returns a TaskBuilder that creates memo generation
+// tasks with factory from events whose payload carries a memo_id. Tasks get
+// the event's priority.
+func MemoGenerationTaskBuilder(factory *MemoGenerationTaskFactory) TaskBuilder {
+	return func(event *events.TaskRequestEvent) (Task, error) {
+		var payload struct {
+			MemoID string `json:"memo_id"`
+		}
+		if err := event.UnmarshalPayload(&payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		memoID, err := uuid.Parse(payload.MemoID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memo ID: %w", err)
+		}
+
+		return factory.CreateTaskWithPriority(memoID, event.Priority)
+	}
+}
+
+// Ensure TaskQueueEmitter implements events.EventEmitter
+var _ events.EventEmitter = (*TaskQueueEmitter)(nil)
@@ -0,0 +1,192 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phrazzld/scry-api/internal/domain"
+	"github.com/phrazzld/scry-api/internal/events"
+	"github.com/phrazzld/scry-api/internal/task/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taskStatus returns the status recorded for a task in the mock store
+func (s *MockTaskStore) taskStatus(taskID uuid.UUID) (TaskStatus, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	task, exists := s.tasks[taskID]
+	if !exists {
+		return "", false
+	}
+	return task.Status(), true
+}
+
+// recordingSubmitter is a TaskSubmitter that records submitted tasks
+type recordingSubmitter struct {
+	submitted []Task
+	err       error
+}
+
+func (s *recordingSubmitter) Submit(ctx context.Context, task Task) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.submitted = append(s.submitted, task)
+	return nil
+}
+
+func TestTaskQueueEmitter_EmitEvent(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("event is enqueued and processed by the runner", func(t *testing.T) {
+		store := NewMockTaskStore()
+		config := DefaultTaskRunnerConfig()
+		config.WorkerCount = 1
+		runner := NewTaskRunner(store, config, logger)
+		require.NoError(t, runner.Start())
+		defer runner.Stop()
+
+		emitter := NewTaskQueueEmitter(runner, logger)
+
+		executed := make(chan MockPayload, 1)
+		var builtTask *MockTask
+		emitter.RegisterTaskType("mock_task", func(event *events.TaskRequestEvent) (Task, error) {
+			builtTask = NewMockTask(uuid.New(), event.Type, event.Payload)
+			builtTask.ExecuteFn = func(ctx context.Context) error {
+				var payload MockPayload
+				if err := json.Unmarshal(builtTask.Payload(), &payload); err != nil {
+					return err
+				}
+				executed <- payload
+				return nil
+			}
+			return builtTask, nil
+		})
+
+		event, err := events.NewTaskRequestEvent("mock_task", MockPayload{Message: "hello"})
+		require.NoError(t, err)
+
+		require.NoError(t, emitter.EmitEvent(context.Background(), event))
+		require.NotNil(t, builtTask, "Emitting should build a task")
+
+		select {
+		case payload := <-executed:
+			assert.Equal(t, "hello", payload.Message)
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out waiting for the emitted task to execute")
+		}
+
+		assert.Eventually(t, func() bool {
+			status, ok := store.taskStatus(builtTask.ID())
+			return ok && status == TaskStatusCompleted
+		}, 2*time.Second, 10*time.Millisecond, "Task should be marked completed in the store")
+	})
+
+	t.Run("unregistered event type", func(t *testing.T) {
+		submitter := &recordingSubmitter{}
+		emitter := NewTaskQueueEmitter(submitter, logger)
+
+		event, err := events.NewTaskRequestEvent("unknown", map[string]string{})
+		require.NoError(t, err)
+
+		err = emitter.EmitEvent(context.Background(), event)
+
+		assert.ErrorIs(t, err, ErrUnregisteredEventType)
+		assert.Empty(t, submitter.submitted)
+	})
+
+	t.Run("builder error is returned", func(t *testing.T) {
+		submitter := &recordingSubmitter{}
+		emitter := NewTaskQueueEmitter(submitter, logger)
+		buildErr := errors.New("bad payload")
+		emitter.RegisterTaskType("mock_task", func(event *events.TaskRequestEvent) (Task, error) {
+			return nil, buildErr
+		})
+
+		event, err := events.NewTaskRequestEvent("mock_task", map[string]string{})
+		require.NoError(t, err)
+
+		err = emitter.EmitEvent(context.Background(), event)
+
+		assert.ErrorIs(t, err, buildErr)
+		assert.Empty(t, submitter.submitted)
+	})
+
+	t.Run("submit error is returned", func(t *testing.T) {
+		submitErr := errors.New("queue full")
+		emitter := NewTaskQueueEmitter(&recordingSubmitter{err: submitErr}, logger)
+		emitter.RegisterTaskType("mock_task", func(event *events.TaskRequestEvent) (Task, error) {
+			return NewMockTask(uuid.New(), event.Type, event.Payload), nil
+		})
+
+		event, err := events.NewTaskRequestEvent("mock_task", map[string]string{})
+		require.NoError(t, err)
+
+		err = emitter.EmitEvent(context.Background(), event)
+
+		assert.ErrorIs(t, err, submitErr)
+	})
+}
+
+func TestMemoGenerationTaskBuilder(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	factory := NewMemoGenerationTaskFactory(
+		&mocks.MockMemoService{},
+		&mocks.Generator{},
+		createCardServiceMock(func(ctx context.Context, cards []*domain.Card) error {
+			return nil
+		}),
+		logger,
+	)
+	builder := MemoGenerationTaskBuilder(factory)
+
+	t.Run("builds memo generation task", func(t *testing.T) {
+		memoID := uuid.New()
+		event, err := events.NewTaskRequestEvent(TaskTypeMemoGeneration,
+			map[string]string{"memo_id": memoID.String()})
+		require.NoError(t, err)
+
+		task, err := builder(event)
+
+		require.NoError(t, err)
+		memoTask, ok := task.(*MemoGenerationTask)
+		require.True(t, ok, "Expected a *MemoGenerationTask")
+		assert.Equal(t, memoID, memoTask.memoID)
+		assert.Equal(t, TaskTypeMemoGeneration, memoTask.Type())
+	})
+
+	t.Run("carries the event priority", func(t *testing.T) {
+		event, err := events.NewTaskRequestEvent(TaskTypeMemoGeneration,
+			map[string]string{"memo_id": uuid.NewString()})
+		require.NoError(t, err)
+		event.Priority = PriorityInteractive
+
+		task, err := builder(event)
+
+		require.NoError(t, err)
+		assert.Equal(t, PriorityInteractive, PriorityOf(task))
+	})
+
+	t.Run("invalid memo ID", func(t *testing.T) {
+		event, err := events.NewTaskRequestEvent(TaskTypeMemoGeneration,
+			map[string]string{"memo_id": "not-a-uuid"})
+		require.NoError(t, err)
+
+		task, err := builder(event)
+
+		assert.Error(t, err)
+		assert.Nil(t, task)
+	})
+}
@@ -199,7 +199,12 @@ func TestCardReviewServerOptions(t *testing.T) {
 		testError := errors.New("test error")
 
 		// Define custom functions that will take precedence
-		customGetNextCardFn := func(ctx context.Context, uid uuid.UUID) (*domain.Card, error) {
+		customGetNextCardFn := func(
+			ctx context.Context,
+			uid uuid.UUID,
+			deckID *uuid.UUID,
+			order domain.ReviewOrder,
+		) (*domain.Card, error) {
 			// This should override the NextCard field
 			return nil, testError
 		}
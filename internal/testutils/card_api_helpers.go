@@ -42,7 +42,7 @@ type CardReviewServerOptions struct {
 
 	// Override fields for advanced use cases - these take precedence over data fields
 	// Function to replace the default GetNextCard behavior
-	GetNextCardFn func(ctx context.Context, userID uuid.UUID) (*domain.Card, error)
+	GetNextCardFn func(ctx context.Context, userID uuid.UUID, deckID *uuid.UUID, order domain.ReviewOrder) (*domain.Card, error)
 	// Function to replace the default SubmitAnswer behavior
 	SubmitAnswerFn func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, answer card_review.ReviewAnswer) (*domain.UserCardStats, error)
 
@@ -118,7 +118,7 @@ func SetupCardReviewTestServer(t *testing.T, opts CardReviewServerOptions) *http
 	logger := slog.Default()
 
 	// Create card handler
-	cardHandler := api.NewCardHandler(cardReviewMock, logger)
+	cardHandler := api.NewCardHandler(cardReviewMock, nil, logger)
 
 	// Set up API routes
 	router.Route("/api", func(r chi.Router) {
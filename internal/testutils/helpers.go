@@ -87,11 +87,12 @@ func MustInsertUser(
 		// UserStore approach - let the store handle domain validation and password hashing
 		// Create a user with the provided email and test password
 		user := &domain.User{
-			ID:        userID,
-			Email:     email,
-			Password:  password, // Plain password - UserStore.Create will hash it
-			CreatedAt: now,
-			UpdatedAt: now,
+			ID:             userID,
+			Email:          email,
+			Password:       password, // Plain password - UserStore.Create will hash it
+			NewCardsPerDay: domain.DefaultNewCardsPerDay,
+			CreatedAt:      now,
+			UpdatedAt:      now,
 		}
 
 		// Create a UserStore to handle the proper creation logic
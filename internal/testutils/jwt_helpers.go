@@ -260,3 +260,42 @@ func CreateFixedTimeJWTService(fixedTime time.Time) auth.JWTService {
 		func() time.Time { return fixedTime },
 	)
 }
+
+// GenerateEmailVerificationToken creates a signed JWT email verification token for the given user ID
+func (s *TestJWTService) GenerateEmailVerificationToken(
+	ctx context.Context,
+	userID uuid.UUID,
+) (string, error) {
+	return s.purposeTokens().GenerateEmailVerificationToken(ctx, userID)
+}
+
+// ValidateEmailVerificationToken validates a JWT email verification token and returns the claims if valid
+func (s *TestJWTService) ValidateEmailVerificationToken(
+	ctx context.Context,
+	tokenString string,
+) (*auth.Claims, error) {
+	return s.purposeTokens().ValidateEmailVerificationToken(ctx, tokenString)
+}
+
+// purposeTokens returns a real JWT service with the same secret and clock, used
+// for single-purpose tokens whose behavior tests don't need to customize
+func (s *TestJWTService) purposeTokens() auth.JWTService {
+	return auth.NewTestJWTService(s.secret, s.tokenLifetime, s.timeFunc, s.refreshTokenLifetime)
+}
+
+// GeneratePasswordResetToken creates a signed JWT password reset token for the given user ID
+func (s *TestJWTService) GeneratePasswordResetToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	fingerprint string,
+) (string, error) {
+	return s.purposeTokens().GeneratePasswordResetToken(ctx, userID, fingerprint)
+}
+
+// ValidatePasswordResetToken validates a JWT password reset token and returns the claims if valid
+func (s *TestJWTService) ValidatePasswordResetToken(
+	ctx context.Context,
+	tokenString string,
+) (*auth.Claims, error) {
+	return s.purposeTokens().ValidatePasswordResetToken(ctx, tokenString)
+}